@@ -583,6 +583,54 @@ func typeToAvroSchema(typ *types.T) (*avroSchemaField, error) {
 				return tree.ParseDJSON(x.(string))
 			},
 		)
+	case types.JsonpathFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return d.(*tree.DJsonpath).Path.String(), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				return tree.ParseDJsonpath(x.(string))
+			},
+		)
+	case types.PGVectorFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return d.(*tree.DPGVector).T.String(), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				return tree.ParseDPGVector(x.(string))
+			},
+		)
+	case types.RangeFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return tree.AsStringWithFlags(d, tree.FmtPgwireText), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				d, _, err := tree.ParseDRangeFromString(nil /* ctx */, x.(string), typ)
+				if err != nil {
+					return nil, err
+				}
+				return d, nil
+			},
+		)
+	case types.MultirangeFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return tree.AsStringWithFlags(d, tree.FmtPgwireText), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				d, _, err := tree.ParseDMultirangeFromString(nil /* ctx */, x.(string), typ)
+				if err != nil {
+					return nil, err
+				}
+				return d, nil
+			},
+		)
 	case types.TSQueryFamily:
 		setNullable(
 			avroSchemaString,
@@ -273,7 +273,7 @@ func normalizeSelectClause(
 			if udts == nil {
 				udts = make(map[oid.Oid]struct{})
 			}
-			udts[typ.Oid()] = struct{}{}
+			udts[typ.UserDefinedOID()] = struct{}{}
 		}
 		return typ, nil
 	}
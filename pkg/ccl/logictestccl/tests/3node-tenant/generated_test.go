@@ -637,6 +637,13 @@ func TestTenantLogic_distsql_tenant(
 	runLogicTest(t, "distsql_tenant")
 }
 
+func TestTenantLogic_domain(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "domain")
+}
+
 func TestTenantLogic_drop_database(
 	t *testing.T,
 ) {
@@ -756,6 +763,13 @@ func TestTenantLogic_exclude_data_from_backup(
 	runLogicTest(t, "exclude_data_from_backup")
 }
 
+func TestTenantLogic_exclusion_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "exclusion_constraints")
+}
+
 func TestTenantLogic_experimental_distsql_planning(
 	t *testing.T,
 ) {
@@ -805,6 +819,13 @@ func TestTenantLogic_float(
 	runLogicTest(t, "float")
 }
 
+func TestTenantLogic_foreign_tables(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "foreign_tables")
+}
+
 func TestTenantLogic_format(
 	t *testing.T,
 ) {
@@ -959,6 +980,13 @@ func TestTenantLogic_inflight_trace_spans(
 	runLogicTest(t, "inflight_trace_spans")
 }
 
+func TestTenantLogic_inherits(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "inherits")
+}
+
 func TestTenantLogic_inner_join(
 	t *testing.T,
 ) {
@@ -1078,6 +1106,13 @@ func TestTenantLogic_json_builtins(
 	runLogicTest(t, "json_builtins")
 }
 
+func TestTenantLogic_jsonpath(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "jsonpath")
+}
+
 func TestTenantLogic_kv_builtin_functions_tenant(
 	t *testing.T,
 ) {
@@ -1127,6 +1162,13 @@ func TestTenantLogic_materialized_view(
 	runLogicTest(t, "materialized_view")
 }
 
+func TestTenantLogic_merge(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "merge")
+}
+
 func TestTenantLogic_merge_join(
 	t *testing.T,
 ) {
@@ -1316,6 +1358,20 @@ func TestTenantLogic_pgoidtype(
 	runLogicTest(t, "pgoidtype")
 }
 
+func TestTenantLogic_pgvector(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "pgvector")
+}
+
+func TestTenantLogic_plpgsql(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "plpgsql")
+}
+
 func TestTenantLogic_poison_after_push(
 	t *testing.T,
 ) {
@@ -1365,6 +1421,13 @@ func TestTenantLogic_propagate_input_ordering(
 	runLogicTest(t, "propagate_input_ordering")
 }
 
+func TestTenantLogic_range(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "range")
+}
+
 func TestTenantLogic_reassign_owned_by(
 	t *testing.T,
 ) {
@@ -1925,6 +1988,13 @@ func TestTenantLogic_timetz(
 	runLogicTest(t, "timetz")
 }
 
+func TestTenantLogic_triggers(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "triggers")
+}
+
 func TestTenantLogic_trigram_builtins(
 	t *testing.T,
 ) {
@@ -2009,6 +2079,13 @@ func TestTenantLogic_udf(
 	runLogicTest(t, "udf")
 }
 
+func TestTenantLogic_udf_aggregate(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_aggregate")
+}
+
 func TestTenantLogic_union(
 	t *testing.T,
 ) {
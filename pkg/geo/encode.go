@@ -14,6 +14,8 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
@@ -313,3 +315,186 @@ func StringToByteOrder(s string) binary.ByteOrder {
 		return DefaultEWKBEncodingFormat
 	}
 }
+
+// DefaultSVGDecimalDigits is the default number of digits coordinates in SVG.
+const DefaultSVGDecimalDigits = 15
+
+// SpatialObjectToSVG transforms a given SpatialObject to SVG path data. If
+// relative is set, paths are written using relative moves.
+func SpatialObjectToSVG(
+	so geopb.SpatialObject, relative bool, maxDecimalDigits int,
+) (string, error) {
+	t, err := ewkb.Unmarshal([]byte(so.EWKB))
+	if err != nil {
+		return "", err
+	}
+	e := svgEncoder{relative: relative, maxDecimalDigits: maxDecimalDigits}
+	e.encode(t)
+	return e.String(), nil
+}
+
+// svgEncoder writes geom.T objects as SVG. As SVG has its Y axis pointing
+// downwards, all Y coordinates are negated.
+type svgEncoder struct {
+	strings.Builder
+	relative         bool
+	maxDecimalDigits int
+}
+
+func (e *svgEncoder) encode(t geom.T) {
+	if t.Empty() {
+		return
+	}
+	switch t := t.(type) {
+	case *geom.Point:
+		x := e.formatFloat(t.X())
+		y := e.formatFloat(-t.Y())
+		if e.relative {
+			fmt.Fprintf(e, `x="%s" y="%s"`, x, y)
+		} else {
+			fmt.Fprintf(e, `cx="%s" cy="%s"`, x, y)
+		}
+	case *geom.LineString:
+		e.WriteString("M ")
+		e.writePath(t.FlatCoords(), t.Stride(), false /* isRing */)
+	case *geom.Polygon:
+		for i := 0; i < t.NumLinearRings(); i++ {
+			if i > 0 {
+				e.WriteString(" ")
+			}
+			e.WriteString("M ")
+			e.writePath(t.LinearRing(i).FlatCoords(), t.Stride(), true /* isRing */)
+			if e.relative {
+				e.WriteString(" z")
+			} else {
+				e.WriteString(" Z")
+			}
+		}
+	case *geom.MultiPoint:
+		for i := 0; i < t.NumPoints(); i++ {
+			if i > 0 {
+				e.WriteString(",")
+			}
+			e.encode(t.Point(i))
+		}
+	case *geom.MultiLineString:
+		for i := 0; i < t.NumLineStrings(); i++ {
+			if i > 0 {
+				e.WriteString(" ")
+			}
+			e.encode(t.LineString(i))
+		}
+	case *geom.MultiPolygon:
+		for i := 0; i < t.NumPolygons(); i++ {
+			if i > 0 {
+				e.WriteString(" ")
+			}
+			e.encode(t.Polygon(i))
+		}
+	case *geom.GeometryCollection:
+		for i, subT := range t.Geoms() {
+			if i > 0 {
+				e.WriteString(";")
+			}
+			e.encode(subT)
+		}
+	}
+}
+
+// writePath writes the points of a path. The closing point of a ring is
+// omitted, as the path is closed by the caller.
+func (e *svgEncoder) writePath(flatCoords []float64, stride int, isRing bool) {
+	end := len(flatCoords)
+	if isRing {
+		end -= stride
+	}
+	if !e.relative {
+		for i := 0; i < end; i += stride {
+			switch i {
+			case 0:
+			case stride:
+				e.WriteString(" L ")
+			default:
+				e.WriteString(" ")
+			}
+			fmt.Fprintf(e, "%s %s", e.formatFloat(flatCoords[i]), e.formatFloat(-flatCoords[i+1]))
+		}
+		return
+	}
+	// Relative paths are computed from the rounded coordinates, so that
+	// rounding errors do not accumulate along the path.
+	prevX := roundToDecimalDigits(flatCoords[0], e.maxDecimalDigits)
+	prevY := roundToDecimalDigits(flatCoords[1], e.maxDecimalDigits)
+	fmt.Fprintf(e, "%s %s l", e.formatFloat(prevX), e.formatFloat(-prevY))
+	for i := stride; i < end; i += stride {
+		x := roundToDecimalDigits(flatCoords[i], e.maxDecimalDigits)
+		y := roundToDecimalDigits(flatCoords[i+1], e.maxDecimalDigits)
+		fmt.Fprintf(e, " %s %s", e.formatFloat(x-prevX), e.formatFloat(-(y - prevY)))
+		prevX, prevY = x, y
+	}
+}
+
+func (e *svgEncoder) formatFloat(f float64) string {
+	return formatFloatWithMaxDecimalDigits(f, e.maxDecimalDigits)
+}
+
+// roundToDecimalDigits rounds f to the given number of decimal digits.
+func roundToDecimalDigits(f float64, decimalDigits int) float64 {
+	scale := math.Pow(10, float64(decimalDigits))
+	return math.Round(f*scale) / scale
+}
+
+// formatFloatWithMaxDecimalDigits formats f with at most maxDecimalDigits
+// digits after the decimal point, omitting any trailing zeros.
+func formatFloatWithMaxDecimalDigits(f float64, maxDecimalDigits int) string {
+	if maxDecimalDigits < 0 {
+		maxDecimalDigits = 0
+	}
+	ret := strconv.FormatFloat(f, 'f', maxDecimalDigits, 64)
+	if strings.ContainsRune(ret, '.') {
+		ret = strings.TrimRight(ret, "0")
+		ret = strings.TrimSuffix(ret, ".")
+	}
+	if ret == "-0" {
+		return "0"
+	}
+	return ret
+}
+
+// DefaultLatLonTextFormat is the default format used by
+// SpatialObjectToLatLonText.
+const DefaultLatLonTextFormat = `D°M'S.SSS"C`
+
+// SpatialObjectToLatLonText transforms a given point SpatialObject into a
+// latitude/longitude text representation using the given format. See
+// degreesToLatLonText for a description of the format.
+func SpatialObjectToLatLonText(so geopb.SpatialObject, format string) (string, error) {
+	t, err := ewkb.Unmarshal([]byte(so.EWKB))
+	if err != nil {
+		return "", err
+	}
+	pt, ok := t.(*geom.Point)
+	if !ok {
+		return "", pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"only points are supported, found %s",
+			so.ShapeType,
+		)
+	}
+	if pt.Empty() {
+		return "", pgerror.Newf(pgcode.InvalidParameterValue, "cannot convert an empty point to lat/lon text")
+	}
+	if format == "" {
+		format = DefaultLatLonTextFormat
+	}
+	lat, lng := normalizeLatLngDegreesPair(pt.Y(), pt.X())
+	latText, err := degreesToLatLonText(lat, "N", "S", format)
+	if err != nil {
+		return "", err
+	}
+	lngText, err := degreesToLatLonText(lng, "E", "W", format)
+	if err != nil {
+		return "", err
+	}
+	return latText + " " + lngText, nil
+}
@@ -158,6 +158,86 @@ func TestSpatialObjectToKML(t *testing.T) {
 	}
 }
 
+func TestSpatialObjectToSVG(t *testing.T) {
+	testCases := []struct {
+		ewkt             geopb.EWKT
+		relative         bool
+		maxDecimalDigits int
+		expected         string
+	}{
+		{"POINT(1 2)", false, 15, `cx="1" cy="-2"`},
+		{"POINT(1 2)", true, 15, `x="1" y="-2"`},
+		{"POINT EMPTY", false, 15, ``},
+		{"LINESTRING(1 2, 3 4, 5 -6)", false, 15, `M 1 -2 L 3 -4 5 6`},
+		{"LINESTRING(1 2, 3 4, 5 -6)", true, 15, `M 1 -2 l 2 -2 2 10`},
+		{"LINESTRING(1.2345 0, 2.3456 0)", true, 2, `M 1.23 0 l 1.12 0`},
+		{"POLYGON((0 0, 1 0, 1 1, 0 0))", false, 15, `M 0 0 L 1 0 1 -1 Z`},
+		{"POLYGON((0 0, 4 0, 4 4, 0 0), (1 1, 2 1, 2 2, 1 1))", true, 15, `M 0 0 l 4 0 0 -4 z M 1 -1 l 1 0 0 -1 z`},
+		{"MULTIPOINT(1 2, 3 4)", false, 15, `cx="1" cy="-2",cx="3" cy="-4"`},
+		{"MULTILINESTRING((1 2, 3 4), (5 6, 7 8))", false, 15, `M 1 -2 L 3 -4 M 5 -6 L 7 -8`},
+		{"GEOMETRYCOLLECTION(POINT(1 2), LINESTRING(1 2, 3 4))", false, 15, `cx="1" cy="-2";M 1 -2 L 3 -4`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.ewkt), func(t *testing.T) {
+			so, err := parseEWKT(geopb.SpatialObjectType_GeometryType, tc.ewkt, geopb.DefaultGeometrySRID, DefaultSRIDIsHint)
+			require.NoError(t, err)
+			encoded, err := SpatialObjectToSVG(so, tc.relative, tc.maxDecimalDigits)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, encoded)
+		})
+	}
+}
+
+func TestSpatialObjectToLatLonText(t *testing.T) {
+	testCases := []struct {
+		ewkt     geopb.EWKT
+		format   string
+		expected string
+	}{
+		{"POINT(-3.2342342 -2.32498)", "", `2°19'29.928"S 3°14'3.243"W`},
+		{"POINT(-3.2342342 -2.32498)", `D degrees, M minutes, S seconds to the C`, `2 degrees, 19 minutes, 30 seconds to the S 3 degrees, 14 minutes, 3 seconds to the W`},
+		{"POINT(-3.2342342 -2.32498)", `D°M.MMMM'`, `-2°19.4988' -3°14.0541'`},
+		{"POINT(-3.2342342 -2.32498)", `DD.DDDDC`, `2.3250S 3.2342W`},
+		{"POINT(-302.2342342 -792.32498)", `DDD°MM'SS.S"C`, ` 72°19'29.9"S  57°45'56.8"E`},
+		{"POINT(1.99999999 1)", "", `1°0'0.000"N 2°0'0.000"E`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.ewkt)+tc.format, func(t *testing.T) {
+			so, err := parseEWKT(geopb.SpatialObjectType_GeometryType, tc.ewkt, geopb.DefaultGeometrySRID, DefaultSRIDIsHint)
+			require.NoError(t, err)
+			encoded, err := SpatialObjectToLatLonText(so, tc.format)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, encoded)
+		})
+	}
+
+	errorTestCases := []struct {
+		ewkt        geopb.EWKT
+		format      string
+		expectedErr string
+	}{
+		{"LINESTRING(0 0, 1 1)", "", "only points are supported, found LineString"},
+		{"POINT EMPTY", "", "cannot convert an empty point to lat/lon text"},
+		{"POINT(1 1)", "M", "bad format, must include degrees (DD.DDD)"},
+		{"POINT(1 1)", "D D", "bad format, cannot include degrees (DD.DDD) more than once"},
+		{"POINT(1 1)", "M D", "bad format, cannot include minutes (MM.MMM) before degrees (DD.DDD)"},
+		{"POINT(1 1)", "C D", "bad format, cannot include degrees (DD.DDD) after compass dir (C)"},
+		{"POINT(1 1)", "D S", "bad format, cannot include seconds (SS.SSS) without including minutes (MM.MMM)"},
+		{"POINT(1 1)", "D.D M", "bad format, only the minutes (MM.MMM) can have a decimal part"},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run(string(tc.ewkt)+tc.format, func(t *testing.T) {
+			so, err := parseEWKT(geopb.SpatialObjectType_GeometryType, tc.ewkt, geopb.DefaultGeometrySRID, DefaultSRIDIsHint)
+			require.NoError(t, err)
+			_, err = SpatialObjectToLatLonText(so, tc.format)
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
 func TestSpatialObjectToGeoHash(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -257,6 +257,16 @@ func ParseGeometryFromGeoJSON(json []byte) (Geometry, error) {
 	return MakeGeometry(g)
 }
 
+// ParseGeometryFromGML parses the GML into a given Geometry. If srid is
+// non-zero, it is used in place of any SRID specified in the GML.
+func ParseGeometryFromGML(gml []byte, srid geopb.SRID) (Geometry, error) {
+	g, err := parseGML(geopb.SpatialObjectType_GeometryType, gml, srid)
+	if err != nil {
+		return Geometry{}, err
+	}
+	return MakeGeometry(g)
+}
+
 // ParseGeometryFromEWKBUnsafe returns a new Geometry from an EWKB, without any SRID checks.
 // You should only do this if you trust the EWKB is setup correctly.
 // You most likely want geo.ParseGeometryFromEWKB instead.
@@ -0,0 +1,157 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+)
+
+// maxChaikinIterations is the maximum number of smoothing iterations allowed
+// by ChaikinSmoothing, as every iteration doubles the number of vertices.
+const maxChaikinIterations = 5
+
+// ChaikinSmoothing smooths the given geometry using Chaikin's algorithm, which
+// replaces every segment by two new vertices at 1/4 and 3/4 of its length in
+// every iteration. The endpoints of LineStrings are always kept, and the
+// endpoints of LinearRings are only kept if preserveEndPoints is set. Points
+// and MultiPoints are returned unchanged.
+func ChaikinSmoothing(
+	g geo.Geometry, numIterations int, preserveEndPoints bool,
+) (geo.Geometry, error) {
+	if numIterations < 1 || numIterations > maxChaikinIterations {
+		return geo.Geometry{}, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"number of iterations must be between 1 and %d",
+			maxChaikinIterations,
+		)
+	}
+	if g.Empty() {
+		return g, nil
+	}
+	t, err := g.AsGeomT()
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	smoothedT, err := chaikinSmoothing(t, numIterations, preserveEndPoints)
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.MakeGeometryFromGeomT(smoothedT)
+}
+
+func chaikinSmoothing(t geom.T, numIterations int, preserveEndPoints bool) (geom.T, error) {
+	if t.Empty() {
+		return t, nil
+	}
+	switch t := t.(type) {
+	case *geom.Point, *geom.MultiPoint:
+		return t, nil
+	case *geom.LineString:
+		coords := t.Coords()
+		for i := 0; i < numIterations; i++ {
+			coords = chaikinSmoothingCoords(coords, true /* preserveEndPoints */, false /* closed */)
+		}
+		return geom.NewLineString(t.Layout()).MustSetCoords(coords).SetSRID(t.SRID()), nil
+	case *geom.MultiLineString:
+		ret := geom.NewMultiLineString(t.Layout()).SetSRID(t.SRID())
+		for i := 0; i < t.NumLineStrings(); i++ {
+			ls, err := chaikinSmoothing(t.LineString(i), numIterations, preserveEndPoints)
+			if err != nil {
+				return nil, err
+			}
+			if err := ret.Push(ls.(*geom.LineString)); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	case *geom.Polygon:
+		ret := geom.NewPolygon(t.Layout()).SetSRID(t.SRID())
+		for i := 0; i < t.NumLinearRings(); i++ {
+			coords := t.LinearRing(i).Coords()
+			for j := 0; j < numIterations; j++ {
+				coords = chaikinSmoothingCoords(coords, preserveEndPoints, true /* closed */)
+			}
+			if err := ret.Push(geom.NewLinearRing(t.Layout()).MustSetCoords(coords)); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	case *geom.MultiPolygon:
+		ret := geom.NewMultiPolygon(t.Layout()).SetSRID(t.SRID())
+		for i := 0; i < t.NumPolygons(); i++ {
+			p, err := chaikinSmoothing(t.Polygon(i), numIterations, preserveEndPoints)
+			if err != nil {
+				return nil, err
+			}
+			if err := ret.Push(p.(*geom.Polygon)); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	case *geom.GeometryCollection:
+		ret := geom.NewGeometryCollection().SetSRID(t.SRID())
+		for _, gcT := range t.Geoms() {
+			smoothedT, err := chaikinSmoothing(gcT, numIterations, preserveEndPoints)
+			if err != nil {
+				return nil, err
+			}
+			if err := ret.Push(smoothedT); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	default:
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unknown shape type: %T", t)
+	}
+}
+
+// chaikinSmoothingCoords applies a single iteration of Chaikin's algorithm to
+// the given coordinates. If preserveEndPoints is set, the first and last
+// coordinates are kept and replace the outermost new vertices. Otherwise, a
+// closed ring is closed again using its first new vertex.
+func chaikinSmoothingCoords(coords []geom.Coord, preserveEndPoints bool, closed bool) []geom.Coord {
+	numSegments := len(coords) - 1
+	if numSegments < 2 {
+		return coords
+	}
+	ret := make([]geom.Coord, 0, 2*numSegments+2)
+	if preserveEndPoints {
+		ret = append(ret, coords[0])
+	}
+	for i := 0; i < numSegments; i++ {
+		a, b := coords[i], coords[i+1]
+		if !preserveEndPoints || i > 0 {
+			ret = append(ret, interpolateCoord(a, b, 0.25))
+		}
+		if !preserveEndPoints || i < numSegments-1 {
+			ret = append(ret, interpolateCoord(a, b, 0.75))
+		}
+	}
+	if preserveEndPoints {
+		ret = append(ret, coords[numSegments])
+	} else if closed {
+		ret = append(ret, ret[0])
+	}
+	return ret
+}
+
+// interpolateCoord returns the coordinate at the given fraction of the way
+// from a to b, interpolating every dimension.
+func interpolateCoord(a, b geom.Coord, fraction float64) geom.Coord {
+	ret := make(geom.Coord, len(a))
+	for i := range ret {
+		ret[i] = a[i] + (b[i]-a[i])*fraction
+	}
+	return ret
+}
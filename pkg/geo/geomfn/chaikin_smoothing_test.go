@@ -0,0 +1,68 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaikinSmoothing(t *testing.T) {
+	testCases := []struct {
+		wkt               string
+		numIterations     int
+		preserveEndPoints bool
+		expected          string
+	}{
+		{"POINT(1 1)", 1, false, "POINT(1 1)"},
+		{"LINESTRING EMPTY", 1, false, "LINESTRING EMPTY"},
+		{"LINESTRING(0 0, 8 8)", 1, false, "LINESTRING(0 0, 8 8)"},
+		{"LINESTRING(0 0, 8 8, 0 16)", 1, false, "LINESTRING(0 0, 6 6, 6 10, 0 16)"},
+		{"LINESTRING(0 0, 8 8, 0 16)", 2, false, "LINESTRING(0 0, 4.5 4.5, 6 7, 6 9, 4.5 11.5, 0 16)"},
+		{"SRID=4326;LINESTRING Z (0 0 0, 8 8 8, 0 16 0)", 1, false, "SRID=4326;LINESTRING Z (0 0 0, 6 6 6, 6 10 6, 0 16 0)"},
+		{"POLYGON((0 0, 8 8, 0 16, 0 0))", 1, false, "POLYGON((2 2, 6 6, 6 10, 2 14, 0 12, 0 4, 2 2))"},
+		{"POLYGON((0 0, 8 8, 0 16, 0 0))", 1, true, "POLYGON((0 0, 6 6, 6 10, 2 14, 0 12, 0 0))"},
+		{
+			"MULTILINESTRING((0 0, 8 8, 0 16), (0 0, 1 1))",
+			1,
+			false,
+			"MULTILINESTRING((0 0, 6 6, 6 10, 0 16), (0 0, 1 1))",
+		},
+		{
+			"GEOMETRYCOLLECTION(POINT(1 1), LINESTRING(0 0, 8 8, 0 16))",
+			1,
+			false,
+			"GEOMETRYCOLLECTION(POINT(1 1), LINESTRING(0 0, 6 6, 6 10, 0 16))",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s, %d, %t", tc.wkt, tc.numIterations, tc.preserveEndPoints), func(t *testing.T) {
+			g, err := geo.ParseGeometry(tc.wkt)
+			require.NoError(t, err)
+			ret, err := ChaikinSmoothing(g, tc.numIterations, tc.preserveEndPoints)
+			require.NoError(t, err)
+			expected, err := geo.ParseGeometry(tc.expected)
+			require.NoError(t, err)
+			require.Equal(t, expected, ret)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		g := geo.MustParseGeometry("LINESTRING(0 0, 8 8, 0 16)")
+		for _, numIterations := range []int{0, 6} {
+			_, err := ChaikinSmoothing(g, numIterations, false /* preserveEndPoints */)
+			require.EqualError(t, err, "number of iterations must be between 1 and 5")
+		}
+	})
+}
@@ -0,0 +1,453 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+)
+
+// maxKMeansIterations bounds the number of refinement passes done by
+// ClusterKMeans, in case the assignments never settle.
+const maxKMeansIterations = 1000
+
+// ClusterIntersecting groups the given geometries into clusters of geometries
+// which are connected through intersections, and returns each cluster as a
+// GEOMETRYCOLLECTION.
+func ClusterIntersecting(geoms []geo.Geometry) ([]geo.Geometry, error) {
+	return clusterConnected(geoms, Intersects)
+}
+
+// ClusterWithin groups the given geometries into clusters of geometries which
+// are connected through pairs within the given distance of each other, and
+// returns each cluster as a GEOMETRYCOLLECTION.
+func ClusterWithin(geoms []geo.Geometry, distance float64) ([]geo.Geometry, error) {
+	if distance < 0 {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "tolerance must be non-negative")
+	}
+	return clusterConnected(geoms, func(a, b geo.Geometry) (bool, error) {
+		return DWithin(a, b, distance, geo.FnInclusive)
+	})
+}
+
+// clusterConnected returns the connected components of the graph whose edges
+// are the pairs of geometries for which connected returns true. Clusters are
+// ordered by their first geometry, and geometries within a cluster keep their
+// input order.
+func clusterConnected(
+	geoms []geo.Geometry, connected func(a, b geo.Geometry) (bool, error),
+) ([]geo.Geometry, error) {
+	if err := checkSameSRID(geoms); err != nil {
+		return nil, err
+	}
+	uf := makeUnionFind(len(geoms))
+	for i := range geoms {
+		for j := i + 1; j < len(geoms); j++ {
+			if uf.find(i) == uf.find(j) {
+				continue
+			}
+			ok, err := connected(geoms[i], geoms[j])
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				uf.union(i, j)
+			}
+		}
+	}
+
+	var clusters []*geom.GeometryCollection
+	clusterIdxByRoot := make(map[int]int)
+	for i, g := range geoms {
+		t, err := g.AsGeomT()
+		if err != nil {
+			return nil, err
+		}
+		root := uf.find(i)
+		clusterIdx, ok := clusterIdxByRoot[root]
+		if !ok {
+			clusterIdx = len(clusters)
+			clusterIdxByRoot[root] = clusterIdx
+			clusters = append(clusters, geom.NewGeometryCollection().SetSRID(t.SRID()))
+		}
+		if err := clusters[clusterIdx].Push(t); err != nil {
+			return nil, err
+		}
+	}
+
+	ret := make([]geo.Geometry, len(clusters))
+	for i, c := range clusters {
+		g, err := geo.MakeGeometryFromGeomT(c)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = g
+	}
+	return ret, nil
+}
+
+// GeometrySource provides the geometries to be clustered by their position,
+// so that callers do not have to hold all of them in memory at once.
+type GeometrySource interface {
+	// Len returns the number of geometries.
+	Len() int
+	// Geometry returns the geometry at the given position. ok is false if there
+	// is no geometry at the position (e.g. it is NULL), in which case it is
+	// treated like an EMPTY geometry.
+	Geometry(ctx context.Context, i int) (_ geo.Geometry, ok bool, _ error)
+}
+
+// ClusterDBSCAN assigns a cluster number to each of the given geometries using
+// the 2D DBSCAN algorithm. A geometry is a core geometry if at least minPoints
+// geometries (including itself) are within eps of it; clusters are formed by
+// core geometries within eps of each other, along with the non-core geometries
+// within eps of a core geometry. Cluster numbers are 0-indexed and assigned in
+// input order. Geometries which do not belong to any cluster, as well as EMPTY
+// geometries, are assigned -1.
+func ClusterDBSCAN(
+	ctx context.Context, geoms GeometrySource, eps float64, minPoints int,
+) ([]int, error) {
+	if eps < 0 {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "eps must be non-negative")
+	}
+	if minPoints < 0 {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "minpoints must be non-negative")
+	}
+	idx, err := makeBoundingBoxIndex(ctx, geoms)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterIDs := make([]int, geoms.Len())
+	for i := range clusterIDs {
+		clusterIDs[i] = -1
+	}
+	// notCore marks the geometries which are known to not be core geometries,
+	// so that the neighbors of every geometry are searched for at most once.
+	notCore := make([]bool, len(clusterIDs))
+	isCore := func(i int) ([]int, bool, error) {
+		if notCore[i] || !idx.contains(i) {
+			return nil, false, nil
+		}
+		neighbors, err := idx.within(ctx, i, eps)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(neighbors) < minPoints {
+			notCore[i] = true
+			return nil, false, nil
+		}
+		return neighbors, true, nil
+	}
+	nextClusterID := 0
+	for i := range clusterIDs {
+		if clusterIDs[i] != -1 {
+			continue
+		}
+		neighbors, ok, err := isCore(i)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		// i is an unassigned core geometry, so it starts a new cluster which is
+		// expanded through all reachable core geometries. Border geometries
+		// belong to the cluster but do not expand it.
+		clusterIDs[i] = nextClusterID
+		var queue []int
+		for {
+			for _, n := range neighbors {
+				if clusterIDs[n] == -1 {
+					clusterIDs[n] = nextClusterID
+					queue = append(queue, n)
+				}
+			}
+			if len(queue) == 0 {
+				break
+			}
+			cur := queue[0]
+			queue = queue[1:]
+			if neighbors, _, err = isCore(cur); err != nil {
+				return nil, err
+			}
+		}
+		nextClusterID++
+	}
+	return clusterIDs, nil
+}
+
+// boundingBoxIndex finds the geometries whose bounding boxes are close to the
+// bounding box of a given geometry, so that the exact distance only has to be
+// computed for those. The non-EMPTY geometries are sorted by the lower X
+// coordinate of their bounding boxes, which bounds the candidates of a search
+// to a contiguous range.
+type boundingBoxIndex struct {
+	geoms GeometrySource
+	// boxes holds the bounding box of every geometry, and is nil for the NULL
+	// and EMPTY geometries.
+	boxes []*geo.CartesianBoundingBox
+	// sorted holds the positions of the other geometries, sorted by the
+	// lower X coordinate of their bounding boxes.
+	sorted []int
+	// maxWidth is the largest width of any bounding box.
+	maxWidth float64
+}
+
+// makeBoundingBoxIndex reads all the given geometries once to build their
+// index, checking that they share the same SRID.
+func makeBoundingBoxIndex(
+	ctx context.Context, geoms GeometrySource,
+) (boundingBoxIndex, error) {
+	idx := boundingBoxIndex{
+		geoms: geoms,
+		boxes: make([]*geo.CartesianBoundingBox, geoms.Len()),
+	}
+	var first geo.Geometry
+	var hasFirst bool
+	for i := range idx.boxes {
+		g, ok, err := geoms.Geometry(ctx, i)
+		if err != nil {
+			return boundingBoxIndex{}, err
+		}
+		if !ok {
+			continue
+		}
+		if !hasFirst {
+			first, hasFirst = g, true
+		} else if g.SRID() != first.SRID() {
+			return boundingBoxIndex{}, geo.NewMismatchingSRIDsError(first.SpatialObject(), g.SpatialObject())
+		}
+		if g.Empty() {
+			continue
+		}
+		idx.boxes[i] = g.CartesianBoundingBox()
+		idx.sorted = append(idx.sorted, i)
+		idx.maxWidth = math.Max(idx.maxWidth, idx.boxes[i].HiX-idx.boxes[i].LoX)
+	}
+	sort.Slice(idx.sorted, func(a, b int) bool {
+		return idx.boxes[idx.sorted[a]].LoX < idx.boxes[idx.sorted[b]].LoX
+	})
+	return idx, nil
+}
+
+// contains returns whether the geometry at position i is indexed, i.e. it is
+// neither NULL nor EMPTY.
+func (idx *boundingBoxIndex) contains(i int) bool {
+	return idx.boxes[i] != nil
+}
+
+// within returns the positions of the geometries within distance of the
+// indexed geometry at position i, including i itself, in ascending order.
+func (idx *boundingBoxIndex) within(ctx context.Context, i int, distance float64) ([]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	g, _, err := idx.geoms.Geometry(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+	// The bounding boxes are only used to rule out geometries, so the search
+	// distance is slightly enlarged to make up for rounding errors.
+	box := idx.boxes[i]
+	d := distance + (distance+math.Max(box.HiX-box.LoX, box.HiY-box.LoY))*1e-9
+	loX, hiX := box.LoX-d-idx.maxWidth, box.HiX+d
+	start := sort.Search(len(idx.sorted), func(k int) bool {
+		return idx.boxes[idx.sorted[k]].LoX >= loX
+	})
+	var ret []int
+	for _, j := range idx.sorted[start:] {
+		other := idx.boxes[j]
+		if other.LoX > hiX {
+			break
+		}
+		if other.HiX < box.LoX-d || other.LoY > box.HiY+d || other.HiY < box.LoY-d {
+			continue
+		}
+		if j == i {
+			ret = append(ret, j)
+			continue
+		}
+		h, _, err := idx.geoms.Geometry(ctx, j)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := DWithin(g, h, distance, geo.FnInclusive)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ret = append(ret, j)
+		}
+	}
+	sort.Ints(ret)
+	return ret, nil
+}
+
+// ClusterKMeans partitions the given geometries into k clusters using the 2D
+// k-means algorithm, where each geometry is represented by the center of its
+// bounding box. If there are fewer than k non-EMPTY geometries, every geometry
+// is placed in its own cluster. Cluster numbers are 0-indexed; EMPTY
+// geometries are assigned -1.
+func ClusterKMeans(ctx context.Context, geoms GeometrySource, k int) ([]int, error) {
+	if k <= 0 {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "number of clusters must be greater than 0")
+	}
+
+	clusterIDs := make([]int, geoms.Len())
+	var idxs []int
+	var points []geom.Coord
+	var first geo.Geometry
+	var hasFirst bool
+	for i := range clusterIDs {
+		clusterIDs[i] = -1
+		g, ok, err := geoms.Geometry(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if !hasFirst {
+			first, hasFirst = g, true
+		} else if g.SRID() != first.SRID() {
+			return nil, geo.NewMismatchingSRIDsError(first.SpatialObject(), g.SpatialObject())
+		}
+		if g.Empty() {
+			continue
+		}
+		bbox := g.CartesianBoundingBox()
+		idxs = append(idxs, i)
+		points = append(points, geom.Coord{(bbox.LoX + bbox.HiX) / 2, (bbox.LoY + bbox.HiY) / 2})
+	}
+	if len(points) == 0 {
+		return clusterIDs, nil
+	}
+	if k > len(points) {
+		k = len(points)
+	}
+
+	// Pick the initial centers deterministically: start with the first point,
+	// and then repeatedly add the point furthest from all centers picked so far.
+	centers := make([]geom.Coord, 0, k)
+	centers = append(centers, points[0])
+	minDists := make([]float64, len(points))
+	for i := range minDists {
+		minDists[i] = math.Inf(1)
+	}
+	for len(centers) < k {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		last := centers[len(centers)-1]
+		furthest := 0
+		for i, p := range points {
+			minDists[i] = math.Min(minDists[i], squaredDistance(p, last))
+			if minDists[i] > minDists[furthest] {
+				furthest = i
+			}
+		}
+		centers = append(centers, points[furthest])
+	}
+
+	assignments := make([]int, len(points))
+	for iter := 0; iter < maxKMeansIterations; iter++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		changed := iter == 0
+		for i, p := range points {
+			closest := 0
+			for c := 1; c < len(centers); c++ {
+				if squaredDistance(p, centers[c]) < squaredDistance(p, centers[closest]) {
+					closest = c
+				}
+			}
+			if assignments[i] != closest {
+				assignments[i] = closest
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		// Move every center to the mean of the points assigned to it. Centers
+		// without any points stay where they are.
+		sums := make([]geom.Coord, len(centers))
+		counts := make([]int, len(centers))
+		for i, p := range points {
+			c := assignments[i]
+			if sums[c] == nil {
+				sums[c] = geom.Coord{0, 0}
+			}
+			sums[c][0] += p[0]
+			sums[c][1] += p[1]
+			counts[c]++
+		}
+		for c := range centers {
+			if counts[c] > 0 {
+				centers[c] = geom.Coord{sums[c][0] / float64(counts[c]), sums[c][1] / float64(counts[c])}
+			}
+		}
+	}
+
+	for i, idx := range idxs {
+		clusterIDs[idx] = assignments[i]
+	}
+	return clusterIDs, nil
+}
+
+// checkSameSRID returns an error if the given geometries do not all share the
+// same SRID.
+func checkSameSRID(geoms []geo.Geometry) error {
+	for i := 1; i < len(geoms); i++ {
+		if geoms[i].SRID() != geoms[0].SRID() {
+			return geo.NewMismatchingSRIDsError(geoms[0].SpatialObject(), geoms[i].SpatialObject())
+		}
+	}
+	return nil
+}
+
+func squaredDistance(a, b geom.Coord) float64 {
+	dx, dy := a[0]-b[0], a[1]-b[1]
+	return dx*dx + dy*dy
+}
+
+// unionFind is a disjoint-set forest over the integers [0, n).
+type unionFind struct {
+	parent []int
+}
+
+func makeUnionFind(n int) unionFind {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	return unionFind{parent: parent}
+}
+
+func (uf unionFind) find(i int) int {
+	for uf.parent[i] != i {
+		uf.parent[i] = uf.parent[uf.parent[i]]
+		i = uf.parent[i]
+	}
+	return i
+}
+
+func (uf unionFind) union(i, j int) {
+	uf.parent[uf.find(i)] = uf.find(j)
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+func parseGeometries(wkts ...string) []geo.Geometry {
+	ret := make([]geo.Geometry, len(wkts))
+	for i, wkt := range wkts {
+		ret[i] = geo.MustParseGeometry(wkt)
+	}
+	return ret
+}
+
+// geometrySlice implements GeometrySource on top of a slice, where nil
+// elements are NULL.
+type geometrySlice []*geo.Geometry
+
+func (s geometrySlice) Len() int { return len(s) }
+
+func (s geometrySlice) Geometry(_ context.Context, i int) (geo.Geometry, bool, error) {
+	if s[i] == nil {
+		return geo.Geometry{}, false, nil
+	}
+	return *s[i], true, nil
+}
+
+func makeGeometrySlice(geoms []geo.Geometry) geometrySlice {
+	ret := make(geometrySlice, len(geoms))
+	for i := range geoms {
+		ret[i] = &geoms[i]
+	}
+	return ret
+}
+
+func TestClusterIntersecting(t *testing.T) {
+	clusters, err := ClusterIntersecting(parseGeometries(
+		"LINESTRING(0 0, 1 1)",
+		"LINESTRING(5 5, 4 4)",
+		"LINESTRING(6 6, 7 7)",
+		"LINESTRING(0 0, -1 -1)",
+		"POLYGON((0 0, 4 0, 4 4, 0 4, 0 0))",
+	))
+	require.NoError(t, err)
+	require.Equal(t, parseGeometries(
+		"GEOMETRYCOLLECTION(LINESTRING(0 0, 1 1), LINESTRING(5 5, 4 4), LINESTRING(0 0, -1 -1), POLYGON((0 0, 4 0, 4 4, 0 4, 0 0)))",
+		"GEOMETRYCOLLECTION(LINESTRING(6 6, 7 7))",
+	), clusters)
+}
+
+func TestClusterWithin(t *testing.T) {
+	clusters, err := ClusterWithin(parseGeometries(
+		"POINT(0 0)",
+		"POINT(10 0)",
+		"POINT(1 0)",
+		"POINT(2 0)",
+		"POINT(11.5 0)",
+	), 1.5)
+	require.NoError(t, err)
+	require.Equal(t, parseGeometries(
+		"GEOMETRYCOLLECTION(POINT(0 0), POINT(1 0), POINT(2 0))",
+		"GEOMETRYCOLLECTION(POINT(10 0), POINT(11.5 0))",
+	), clusters)
+
+	_, err = ClusterWithin(parseGeometries("POINT(0 0)", "SRID=4326;POINT(0 0)"), 1)
+	require.Error(t, err)
+
+	_, err = ClusterWithin(parseGeometries("POINT(0 0)"), -1)
+	require.EqualError(t, err, "tolerance must be non-negative")
+}
+
+func TestClusterDBSCAN(t *testing.T) {
+	ctx := context.Background()
+	geoms := makeGeometrySlice(parseGeometries(
+		"POINT(0 0)",
+		"POINT(0 1)",
+		"POINT(0 2)",
+		"POINT(0 3.5)",
+		"POINT(10 10)",
+		"POINT EMPTY",
+		"POINT(10 11)",
+		"POINT(20 20)",
+	))
+	geoms = append(geoms, nil)
+	testCases := []struct {
+		desc      string
+		eps       float64
+		minPoints int
+		expected  []int
+	}{
+		{"every point is a core point", 1, 1, []int{0, 0, 0, 1, 2, -1, 2, 3, -1}},
+		{"isolated points are noise", 1, 2, []int{0, 0, 0, -1, 1, -1, 1, -1, -1}},
+		{"border points join the cluster", 1.5, 3, []int{0, 0, 0, 0, -1, -1, -1, -1, -1}},
+		{"no core points", 1, 4, []int{-1, -1, -1, -1, -1, -1, -1, -1, -1}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			clusterIDs, err := ClusterDBSCAN(ctx, geoms, tc.eps, tc.minPoints)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, clusterIDs)
+		})
+	}
+
+	_, err := ClusterDBSCAN(ctx, geoms, -1, 1)
+	require.EqualError(t, err, "eps must be non-negative")
+
+	_, err = ClusterDBSCAN(ctx, makeGeometrySlice(parseGeometries("POINT(0 0)", "SRID=4326;POINT(0 0)")), 1, 1)
+	require.Error(t, err)
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = ClusterDBSCAN(cancelCtx, geoms, 1, 1)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// TestClusterDBSCANRandom checks the neighbors found through the bounding box
+// index against a search through all the pairs of geometries.
+func TestClusterDBSCANRandom(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(0))
+	var geoms []geo.Geometry
+	for i := 0; i < 200; i++ {
+		x, y := rng.Float64()*100, rng.Float64()*100
+		wkt := fmt.Sprintf("POINT(%f %f)", x, y)
+		if i%10 == 0 {
+			wkt = fmt.Sprintf("LINESTRING(%f %f, %f %f)", x, y, x+rng.Float64()*20, y+rng.Float64()*20)
+		}
+		geoms = append(geoms, geo.MustParseGeometry(wkt))
+	}
+	const eps = 5
+	idx, err := makeBoundingBoxIndex(ctx, makeGeometrySlice(geoms))
+	require.NoError(t, err)
+	for i := range geoms {
+		var expected []int
+		for j := range geoms {
+			within, err := DWithin(geoms[i], geoms[j], eps, geo.FnInclusive)
+			require.NoError(t, err)
+			if within {
+				expected = append(expected, j)
+			}
+		}
+		neighbors, err := idx.within(ctx, i, eps)
+		require.NoError(t, err)
+		require.Equal(t, expected, neighbors)
+	}
+}
+
+func TestClusterKMeans(t *testing.T) {
+	ctx := context.Background()
+	geoms := makeGeometrySlice(parseGeometries(
+		"POINT(0 0)",
+		"POINT(1 0)",
+		"POINT(10 10)",
+		"POINT EMPTY",
+		"LINESTRING(9 9, 11 11)",
+		"POINT(0 1)",
+	))
+	geoms = append(geoms, nil)
+	testCases := []struct {
+		k        int
+		expected []int
+	}{
+		{1, []int{0, 0, 0, -1, 0, 0, -1}},
+		{2, []int{0, 0, 1, -1, 1, 0, -1}},
+		{10, []int{0, 2, 1, -1, 1, 3, -1}},
+	}
+	for _, tc := range testCases {
+		clusterIDs, err := ClusterKMeans(ctx, geoms, tc.k)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, clusterIDs)
+	}
+
+	_, err := ClusterKMeans(ctx, geoms, 0)
+	require.EqualError(t, err, "number of clusters must be greater than 0")
+}
@@ -0,0 +1,288 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+	"github.com/twpayne/go-geom"
+)
+
+// ConcaveHull returns a possibly concave polygon enclosing all the vertices of
+// the given geometry. The hull is computed by eroding the Delaunay
+// triangulation of the vertices from the outside in, removing border triangles
+// whose longest border edge is longer than a threshold. The threshold is
+// determined by targetPercent, where 0 produces the most concave hull and 1
+// produces the convex hull.
+func ConcaveHull(g geo.Geometry, targetPercent float64, allowHoles bool) (geo.Geometry, error) {
+	if targetPercent < 0 || targetPercent > 1 || math.IsNaN(targetPercent) {
+		return geo.Geometry{}, pgerror.Newf(
+			pgcode.InvalidParameterValue, "target percent must be between 0 and 1",
+		)
+	}
+	if allowHoles {
+		return geo.Geometry{}, pgerror.Newf(
+			pgcode.FeatureNotSupported, "concave hulls with holes are not yet supported",
+		)
+	}
+	if g.Empty() {
+		return g, nil
+	}
+	if targetPercent == 1 {
+		return ConvexHull(g)
+	}
+
+	triangulation, err := DelaunayTriangles(g, 0 /* tolerance */, DelaunayTrianglesPolygons)
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	t, err := triangulation.AsGeomT()
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	gc, ok := t.(*geom.GeometryCollection)
+	if !ok {
+		return geo.Geometry{}, errors.AssertionFailedf("unexpected triangulation type: %T", t)
+	}
+	if gc.NumGeoms() == 0 {
+		// The vertices are collinear or too few to be triangulated.
+		return ConvexHull(g)
+	}
+
+	h := makeConcaveHullTriangulation(gc)
+	h.erode(targetPercent)
+
+	// Union the remaining triangles to form the hull.
+	remaining := geom.NewMultiPolygon(geom.XY).SetSRID(int(g.SRID()))
+	for i, tri := range h.triangles {
+		if h.removed[i] {
+			continue
+		}
+		coords := []geom.Coord{h.vertices[tri[0]], h.vertices[tri[1]], h.vertices[tri[2]], h.vertices[tri[0]]}
+		p, err := geom.NewPolygon(geom.XY).SetCoords([][]geom.Coord{coords})
+		if err != nil {
+			return geo.Geometry{}, err
+		}
+		if err := remaining.Push(p); err != nil {
+			return geo.Geometry{}, err
+		}
+	}
+	remainingGeom, err := geo.MakeGeometryFromGeomT(remaining)
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	return UnaryUnion(remainingGeom)
+}
+
+// concaveHullEdge is an edge of a triangulation, identified by the indexes of
+// its vertices in increasing order.
+type concaveHullEdge [2]int
+
+func makeConcaveHullEdge(a, b int) concaveHullEdge {
+	if a > b {
+		a, b = b, a
+	}
+	return concaveHullEdge{a, b}
+}
+
+// concaveHullTriangulation tracks the state of a triangulation as its border
+// triangles are removed.
+type concaveHullTriangulation struct {
+	vertices  []geom.Coord
+	triangles [][3]int
+	removed   []bool
+	// edgeTriangles maps each edge to the triangles which have not been
+	// removed and contain the edge. An edge with a single triangle lies on the
+	// border of the triangulation.
+	edgeTriangles map[concaveHullEdge][]int
+	// borderDegree counts the border edges incident to each vertex.
+	borderDegree []int
+}
+
+func makeConcaveHullTriangulation(gc *geom.GeometryCollection) *concaveHullTriangulation {
+	h := &concaveHullTriangulation{edgeTriangles: make(map[concaveHullEdge][]int)}
+	vertexIdxs := make(map[[2]float64]int)
+	vertexIdx := func(c geom.Coord) int {
+		key := [2]float64{c.X(), c.Y()}
+		idx, ok := vertexIdxs[key]
+		if !ok {
+			idx = len(h.vertices)
+			vertexIdxs[key] = idx
+			h.vertices = append(h.vertices, geom.Coord{c.X(), c.Y()})
+		}
+		return idx
+	}
+	for _, t := range gc.Geoms() {
+		ring := t.(*geom.Polygon).LinearRing(0)
+		tri := [3]int{vertexIdx(ring.Coord(0)), vertexIdx(ring.Coord(1)), vertexIdx(ring.Coord(2))}
+		triIdx := len(h.triangles)
+		h.triangles = append(h.triangles, tri)
+		for _, e := range h.edges(triIdx) {
+			h.edgeTriangles[e] = append(h.edgeTriangles[e], triIdx)
+		}
+	}
+	h.removed = make([]bool, len(h.triangles))
+	h.borderDegree = make([]int, len(h.vertices))
+	for e, tris := range h.edgeTriangles {
+		if len(tris) == 1 {
+			h.borderDegree[e[0]]++
+			h.borderDegree[e[1]]++
+		}
+	}
+	return h
+}
+
+func (h *concaveHullTriangulation) edges(triIdx int) [3]concaveHullEdge {
+	tri := h.triangles[triIdx]
+	return [3]concaveHullEdge{
+		makeConcaveHullEdge(tri[0], tri[1]),
+		makeConcaveHullEdge(tri[1], tri[2]),
+		makeConcaveHullEdge(tri[2], tri[0]),
+	}
+}
+
+func (h *concaveHullTriangulation) edgeLength(e concaveHullEdge) float64 {
+	return math.Sqrt(squaredDistance(h.vertices[e[0]], h.vertices[e[1]]))
+}
+
+func (h *concaveHullTriangulation) isBorder(e concaveHullEdge) bool {
+	return len(h.edgeTriangles[e]) == 1
+}
+
+// longestBorderEdge returns the length of the longest border edge of the given
+// triangle along with the number of border edges it has.
+func (h *concaveHullTriangulation) longestBorderEdge(triIdx int) (float64, int) {
+	longest, numBorder := 0.0, 0
+	for _, e := range h.edges(triIdx) {
+		if h.isBorder(e) {
+			longest = math.Max(longest, h.edgeLength(e))
+			numBorder++
+		}
+	}
+	return longest, numBorder
+}
+
+// isRemovable returns whether removing the given border triangle keeps every
+// vertex in a single polygon without holes. Only triangles with exactly one
+// border edge can be removed, and only if the vertex opposite to the border
+// edge is not already on the border, as that would split the polygon in two.
+func (h *concaveHullTriangulation) isRemovable(triIdx int, numBorder int) bool {
+	if numBorder != 1 {
+		return false
+	}
+	for _, v := range h.triangles[triIdx] {
+		if h.borderDegree[v] == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// erode removes border triangles, longest border edge first, until the longest
+// border edge is no longer than the threshold determined by targetPercent.
+func (h *concaveHullTriangulation) erode(targetPercent float64) {
+	minLength, maxLength := math.Inf(1), 0.0
+	for e := range h.edgeTriangles {
+		l := h.edgeLength(e)
+		minLength = math.Min(minLength, l)
+		maxLength = math.Max(maxLength, l)
+	}
+	threshold := minLength + targetPercent*(maxLength-minLength)
+
+	var q concaveHullQueue
+	for triIdx := range h.triangles {
+		if length, numBorder := h.longestBorderEdge(triIdx); numBorder > 0 {
+			q = append(q, concaveHullQueueItem{triIdx: triIdx, length: length})
+		}
+	}
+	heap.Init(&q)
+	for q.Len() > 0 {
+		item := heap.Pop(&q).(concaveHullQueueItem)
+		if h.removed[item.triIdx] {
+			continue
+		}
+		length, numBorder := h.longestBorderEdge(item.triIdx)
+		if length != item.length {
+			// The triangle gained a border edge after it was queued, and was
+			// queued again with its new length.
+			continue
+		}
+		if length <= threshold {
+			break
+		}
+		if !h.isRemovable(item.triIdx, numBorder) {
+			continue
+		}
+		h.remove(item.triIdx, &q)
+	}
+}
+
+// remove removes the given triangle, queueing the triangles which it exposes.
+func (h *concaveHullTriangulation) remove(triIdx int, q *concaveHullQueue) {
+	h.removed[triIdx] = true
+	for _, e := range h.edges(triIdx) {
+		wasBorder := h.isBorder(e)
+		tris := h.edgeTriangles[e]
+		for i, t := range tris {
+			if t == triIdx {
+				h.edgeTriangles[e] = append(tris[:i:i], tris[i+1:]...)
+				break
+			}
+		}
+		if wasBorder {
+			h.borderDegree[e[0]]--
+			h.borderDegree[e[1]]--
+			continue
+		}
+		h.borderDegree[e[0]]++
+		h.borderDegree[e[1]]++
+		exposed := h.edgeTriangles[e][0]
+		length, _ := h.longestBorderEdge(exposed)
+		heap.Push(q, concaveHullQueueItem{triIdx: exposed, length: length})
+	}
+}
+
+type concaveHullQueueItem struct {
+	triIdx int
+	length float64
+}
+
+// concaveHullQueue is a max-heap of triangles ordered by the length of their
+// longest border edge.
+type concaveHullQueue []concaveHullQueueItem
+
+var _ heap.Interface = (*concaveHullQueue)(nil)
+
+func (q concaveHullQueue) Len() int { return len(q) }
+
+func (q concaveHullQueue) Less(i, j int) bool {
+	if q[i].length != q[j].length {
+		return q[i].length > q[j].length
+	}
+	return q[i].triIdx < q[j].triIdx
+}
+
+func (q concaveHullQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *concaveHullQueue) Push(x interface{}) { *q = append(*q, x.(concaveHullQueueItem)) }
+
+func (q *concaveHullQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
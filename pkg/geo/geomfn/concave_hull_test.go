@@ -0,0 +1,88 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestConcaveHull(t *testing.T) {
+	testCases := []struct {
+		wkt           string
+		targetPercent float64
+		expected      string
+	}{
+		{"POINT EMPTY", 0.5, "POINT EMPTY"},
+		{"POINT(1 1)", 0, "POINT(1 1)"},
+		{"LINESTRING(0 0, 1 1, 2 2)", 0, "LINESTRING(0 0, 2 2)"},
+		{"MULTIPOINT((0 0), (14 0), (12 10), (2 10), (7 9))", 1, "POLYGON((0 0, 2 10, 12 10, 14 0, 0 0))"},
+		{"MULTIPOINT((0 0), (14 0), (12 10), (2 10), (7 9))", 0.5, "POLYGON((0 0, 7 9, 14 0, 12 10, 2 10, 0 0))"},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s, %g", tc.wkt, tc.targetPercent), func(t *testing.T) {
+			g, err := geo.ParseGeometry(tc.wkt)
+			require.NoError(t, err)
+			ret, err := ConcaveHull(g, tc.targetPercent, false /* allowHoles */)
+			require.NoError(t, err)
+			expected, err := geo.ParseGeometry(tc.expected)
+			require.NoError(t, err)
+			equals, err := Equals(expected, ret)
+			require.NoError(t, err)
+			require.True(t, equals, "expected %s, got %s", tc.expected, ret.ShapeType())
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		g := geo.MustParseGeometry("MULTIPOINT((0 0), (10 0), (10 10))")
+		_, err := ConcaveHull(g, -0.1, false /* allowHoles */)
+		require.EqualError(t, err, "target percent must be between 0 and 1")
+		_, err = ConcaveHull(g, 1.1, false /* allowHoles */)
+		require.EqualError(t, err, "target percent must be between 0 and 1")
+		_, err = ConcaveHull(g, 0.5, true /* allowHoles */)
+		require.EqualError(t, err, "concave hulls with holes are not yet supported")
+	})
+}
+
+func TestConcaveHullErode(t *testing.T) {
+	// A square triangulated around an interior vertex close to its top edge.
+	triangulation := geo.MustParseGeometry(`GEOMETRYCOLLECTION(
+		POLYGON((0 0, 10 0, 5 9, 0 0)),
+		POLYGON((0 0, 5 9, 0 10, 0 0)),
+		POLYGON((10 0, 10 10, 5 9, 10 0)),
+		POLYGON((0 10, 5 9, 10 10, 0 10))
+	)`)
+	testCases := []struct {
+		targetPercent float64
+		expected      []bool
+	}{
+		// Only the first triangle is removed, as removing any other triangle
+		// afterwards would remove a vertex from the hull.
+		{0, []bool{true, false, false, false}},
+		{0.9, []bool{true, false, false, false}},
+		// The threshold is above the length of the sides of the square.
+		{0.99, []bool{false, false, false, false}},
+		{1, []bool{false, false, false, false}},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%g", tc.targetPercent), func(t *testing.T) {
+			gt, err := triangulation.AsGeomT()
+			require.NoError(t, err)
+			h := makeConcaveHullTriangulation(gt.(*geom.GeometryCollection))
+			h.erode(tc.targetPercent)
+			require.Equal(t, tc.expected, h.removed)
+		})
+	}
+}
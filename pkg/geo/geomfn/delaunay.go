@@ -0,0 +1,55 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geos"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// DelaunayTrianglesFlag determines the output of DelaunayTriangles.
+type DelaunayTrianglesFlag int
+
+const (
+	// DelaunayTrianglesPolygons returns a GEOMETRYCOLLECTION of triangular
+	// POLYGONs.
+	DelaunayTrianglesPolygons DelaunayTrianglesFlag = 0
+	// DelaunayTrianglesEdges returns a MULTILINESTRING of the edges of the
+	// triangulation.
+	DelaunayTrianglesEdges DelaunayTrianglesFlag = 1
+	// DelaunayTrianglesTIN returns a TIN, which is not supported.
+	DelaunayTrianglesTIN DelaunayTrianglesFlag = 2
+)
+
+// DelaunayTriangles returns the Delaunay triangulation of the vertices of the
+// given geometry. Vertices within tolerance of each other are snapped
+// together before triangulating.
+func DelaunayTriangles(
+	g geo.Geometry, tolerance float64, flag DelaunayTrianglesFlag,
+) (geo.Geometry, error) {
+	var onlyEdges bool
+	switch flag {
+	case DelaunayTrianglesPolygons:
+	case DelaunayTrianglesEdges:
+		onlyEdges = true
+	case DelaunayTrianglesTIN:
+		return geo.Geometry{}, pgerror.Newf(pgcode.FeatureNotSupported, "TIN geometries are not supported")
+	default:
+		return geo.Geometry{}, pgerror.Newf(pgcode.InvalidParameterValue, "unknown flags: %d", flag)
+	}
+	retEWKB, err := geos.DelaunayTriangles(g.EWKB(), tolerance, onlyEdges)
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.ParseGeometryFromEWKB(retEWKB)
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelaunayTriangles(t *testing.T) {
+	// The triangulation of a trapezoid around an interior vertex.
+	g := geo.MustParseGeometry("SRID=4326;MULTIPOINT((0 0), (14 0), (12 10), (2 10), (7 9))")
+
+	t.Run("polygons", func(t *testing.T) {
+		ret, err := DelaunayTriangles(g, 0 /* tolerance */, DelaunayTrianglesPolygons)
+		require.NoError(t, err)
+		require.Equal(t, geopb.SRID(4326), ret.SRID())
+		dumped, err := Dump(ret)
+		require.NoError(t, err)
+		require.Len(t, dumped, 4)
+		area, err := Area(ret)
+		require.NoError(t, err)
+		require.Equal(t, 120.0, area)
+	})
+
+	t.Run("edges", func(t *testing.T) {
+		ret, err := DelaunayTriangles(g, 0 /* tolerance */, DelaunayTrianglesEdges)
+		require.NoError(t, err)
+		equals, err := Equals(
+			geo.MustParseGeometry(
+				"SRID=4326;MULTILINESTRING((0 0, 14 0), (14 0, 12 10), (12 10, 2 10), (2 10, 0 0), "+
+					"(7 9, 0 0), (7 9, 14 0), (7 9, 12 10), (7 9, 2 10))",
+			),
+			ret,
+		)
+		require.NoError(t, err)
+		require.True(t, equals)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := DelaunayTriangles(g, 0 /* tolerance */, DelaunayTrianglesTIN)
+		require.EqualError(t, err, "TIN geometries are not supported")
+		_, err = DelaunayTriangles(g, 0 /* tolerance */, 3)
+		require.EqualError(t, err, "unknown flags: 3")
+	})
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+)
+
+// DumpedGeometry is a component of a geometry along with the path to that
+// component, as returned by ST_Dump, ST_DumpPoints and ST_DumpRings. Path
+// elements are 1-indexed, with the exception of ST_DumpRings which numbers the
+// exterior ring 0.
+type DumpedGeometry struct {
+	Path     []int
+	Geometry geo.Geometry
+}
+
+// Dump decomposes the given geometry into its single-part components. MULTI-*
+// and GEOMETRYCOLLECTION objects are recursively decomposed, with the path of
+// each component being its 1-indexed position at every level of nesting. A
+// single-part geometry is returned as-is with an empty path, and an EMPTY
+// geometry returns nothing.
+func Dump(g geo.Geometry) ([]DumpedGeometry, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return nil, err
+	}
+	if t.Empty() {
+		return nil, nil
+	}
+	var ret []DumpedGeometry
+	err = dumpGeomT(t, nil /* path */, func(path []int, t geom.T) error {
+		dumped, err := geo.MakeGeometryFromGeomT(t)
+		if err != nil {
+			return err
+		}
+		ret = append(ret, DumpedGeometry{Path: path, Geometry: dumped})
+		return nil
+	})
+	return ret, err
+}
+
+// dumpGeomT calls fn for every single-part component of t, recursing into
+// MULTI-* and GEOMETRYCOLLECTION objects.
+func dumpGeomT(t geom.T, path []int, fn func(path []int, t geom.T) error) error {
+	switch t := t.(type) {
+	case *geom.Point, *geom.LineString, *geom.Polygon:
+		return fn(path, t)
+	case *geom.MultiPoint:
+		for i := 0; i < t.NumPoints(); i++ {
+			if err := fn(appendPath(path, i+1), t.Point(i).SetSRID(t.SRID())); err != nil {
+				return err
+			}
+		}
+	case *geom.MultiLineString:
+		for i := 0; i < t.NumLineStrings(); i++ {
+			if err := fn(appendPath(path, i+1), t.LineString(i).SetSRID(t.SRID())); err != nil {
+				return err
+			}
+		}
+	case *geom.MultiPolygon:
+		for i := 0; i < t.NumPolygons(); i++ {
+			if err := fn(appendPath(path, i+1), t.Polygon(i).SetSRID(t.SRID())); err != nil {
+				return err
+			}
+		}
+	case *geom.GeometryCollection:
+		for i := 0; i < t.NumGeoms(); i++ {
+			child, err := setGeomTSRID(t.Geom(i), t.SRID())
+			if err != nil {
+				return err
+			}
+			if err := dumpGeomT(child, appendPath(path, i+1), fn); err != nil {
+				return err
+			}
+		}
+	default:
+		return geom.ErrUnsupportedType{Value: t}
+	}
+	return nil
+}
+
+// DumpPoints returns every vertex of the given geometry as a POINT. The path of
+// each point identifies the component it belongs to (as in Dump), followed by
+// the 1-indexed ring for polygons, followed by the 1-indexed position of the
+// vertex.
+func DumpPoints(g geo.Geometry) ([]DumpedGeometry, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return nil, err
+	}
+	var ret []DumpedGeometry
+	appendPoints := func(path []int, layout geom.Layout, flatCoords []float64) error {
+		stride := layout.Stride()
+		for i := 0; i < len(flatCoords)/stride; i++ {
+			p := geom.NewPointFlat(layout, flatCoords[i*stride:(i+1)*stride]).SetSRID(t.SRID())
+			dumped, err := geo.MakeGeometryFromGeomT(p)
+			if err != nil {
+				return err
+			}
+			ret = append(ret, DumpedGeometry{Path: appendPath(path, i+1), Geometry: dumped})
+		}
+		return nil
+	}
+	err = dumpGeomT(t, nil /* path */, func(path []int, t geom.T) error {
+		if t.Empty() {
+			return nil
+		}
+		switch t := t.(type) {
+		case *geom.Polygon:
+			for i := 0; i < t.NumLinearRings(); i++ {
+				ring := t.LinearRing(i)
+				if err := appendPoints(appendPath(path, i+1), ring.Layout(), ring.FlatCoords()); err != nil {
+					return err
+				}
+			}
+			return nil
+		default:
+			return appendPoints(path, t.Layout(), t.FlatCoords())
+		}
+	})
+	return ret, err
+}
+
+// DumpRings returns the rings of the given POLYGON, each as a single-ring
+// POLYGON. The exterior ring has path {0}, and the interior rings are numbered
+// from 1.
+func DumpRings(g geo.Geometry) ([]DumpedGeometry, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return nil, err
+	}
+	poly, ok := t.(*geom.Polygon)
+	if !ok {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "input is not a polygon")
+	}
+	ret := make([]DumpedGeometry, 0, poly.NumLinearRings())
+	for i := 0; i < poly.NumLinearRings(); i++ {
+		ring := poly.LinearRing(i)
+		p := geom.NewPolygonFlat(
+			ring.Layout(), ring.FlatCoords(), []int{len(ring.FlatCoords())},
+		).SetSRID(poly.SRID())
+		dumped, err := geo.MakeGeometryFromGeomT(p)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, DumpedGeometry{Path: []int{i}, Geometry: dumped})
+	}
+	return ret, nil
+}
+
+// appendPath returns a copy of path with idx appended, so that paths handed
+// out to callers never share a backing array.
+func appendPath(path []int, idx int) []int {
+	ret := make([]int, len(path)+1)
+	copy(ret, path)
+	ret[len(path)] = idx
+	return ret
+}
+
+// setGeomTSRID sets the SRID of a geom.T that was extracted from a
+// GEOMETRYCOLLECTION, which does not propagate its SRID to its children.
+func setGeomTSRID(t geom.T, srid int) (geom.T, error) {
+	switch t := t.(type) {
+	case *geom.Point:
+		return t.SetSRID(srid), nil
+	case *geom.LineString:
+		return t.SetSRID(srid), nil
+	case *geom.Polygon:
+		return t.SetSRID(srid), nil
+	case *geom.MultiPoint:
+		return t.SetSRID(srid), nil
+	case *geom.MultiLineString:
+		return t.SetSRID(srid), nil
+	case *geom.MultiPolygon:
+		return t.SetSRID(srid), nil
+	case *geom.GeometryCollection:
+		return t.SetSRID(srid), nil
+	default:
+		return nil, geom.ErrUnsupportedType{Value: t}
+	}
+}
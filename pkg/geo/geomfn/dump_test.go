@@ -0,0 +1,116 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+type dumpedWKT struct {
+	path []int
+	wkt  string
+}
+
+func requireDumped(t *testing.T, expected []dumpedWKT, dumped []DumpedGeometry) {
+	require.Len(t, dumped, len(expected))
+	for i, d := range dumped {
+		require.Equal(t, expected[i].path, d.Path)
+		require.Equal(t, geo.MustParseGeometry(expected[i].wkt), d.Geometry)
+	}
+}
+
+func TestDump(t *testing.T) {
+	testCases := []struct {
+		wkt      string
+		expected []dumpedWKT
+	}{
+		{"POINT EMPTY", nil},
+		{"GEOMETRYCOLLECTION EMPTY", nil},
+		{"SRID=4326;POINT(1 2)", []dumpedWKT{{nil, "SRID=4326;POINT(1 2)"}}},
+		{
+			"MULTIPOINT(1 2, 3 4)",
+			[]dumpedWKT{{[]int{1}, "POINT(1 2)"}, {[]int{2}, "POINT(3 4)"}},
+		},
+		{
+			"SRID=4326;GEOMETRYCOLLECTION(LINESTRING(0 0, 1 1), MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0))), GEOMETRYCOLLECTION(POINT(5 5)))",
+			[]dumpedWKT{
+				{[]int{1}, "SRID=4326;LINESTRING(0 0, 1 1)"},
+				{[]int{2, 1}, "SRID=4326;POLYGON((0 0, 1 0, 1 1, 0 0))"},
+				{[]int{3, 1}, "SRID=4326;POINT(5 5)"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			dumped, err := Dump(geo.MustParseGeometry(tc.wkt))
+			require.NoError(t, err)
+			if tc.expected == nil {
+				require.Empty(t, dumped)
+				return
+			}
+			requireDumped(t, tc.expected, dumped)
+		})
+	}
+}
+
+func TestDumpPoints(t *testing.T) {
+	testCases := []struct {
+		wkt      string
+		expected []dumpedWKT
+	}{
+		{"POINT EMPTY", nil},
+		{"POINT(1 2)", []dumpedWKT{{[]int{1}, "POINT(1 2)"}}},
+		{
+			"SRID=4326;LINESTRING(0 0, 1 1)",
+			[]dumpedWKT{{[]int{1}, "SRID=4326;POINT(0 0)"}, {[]int{2}, "SRID=4326;POINT(1 1)"}},
+		},
+		{
+			"GEOMETRYCOLLECTION(POINT(0 1), POLYGON((2 0, 2 3, 0 2, 2 0)))",
+			[]dumpedWKT{
+				{[]int{1, 1}, "POINT(0 1)"},
+				{[]int{2, 1, 1}, "POINT(2 0)"},
+				{[]int{2, 1, 2}, "POINT(2 3)"},
+				{[]int{2, 1, 3}, "POINT(0 2)"},
+				{[]int{2, 1, 4}, "POINT(2 0)"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			dumped, err := DumpPoints(geo.MustParseGeometry(tc.wkt))
+			require.NoError(t, err)
+			if tc.expected == nil {
+				require.Empty(t, dumped)
+				return
+			}
+			requireDumped(t, tc.expected, dumped)
+		})
+	}
+}
+
+func TestDumpRings(t *testing.T) {
+	dumped, err := DumpRings(geo.MustParseGeometry(
+		"SRID=4326;POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 2 1, 2 2, 1 1))",
+	))
+	require.NoError(t, err)
+	requireDumped(t, []dumpedWKT{
+		{[]int{0}, "SRID=4326;POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))"},
+		{[]int{1}, "SRID=4326;POLYGON((1 1, 2 1, 2 2, 1 1))"},
+	}, dumped)
+
+	_, err = DumpRings(geo.MustParseGeometry("LINESTRING(0 0, 1 1)"))
+	require.EqualError(t, err, "input is not a polygon")
+}
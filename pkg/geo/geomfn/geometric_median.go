@@ -0,0 +1,168 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+)
+
+// DefaultGeometricMedianTolerance returns the tolerance used by
+// GeometricMedian if none is specified, which scales with the magnitude of the
+// coordinates of the given geometry.
+func DefaultGeometricMedianTolerance(g geo.Geometry) float64 {
+	bbox := g.CartesianBoundingBox()
+	if bbox == nil {
+		return 0
+	}
+	maxAbs := math.Max(
+		math.Max(math.Abs(bbox.LoX), math.Abs(bbox.HiX)),
+		math.Max(math.Abs(bbox.LoY), math.Abs(bbox.HiY)),
+	)
+	return 1e-10 * maxAbs
+}
+
+// GeometricMedian returns the geometric median of the given (MULTI)POINT,
+// which is the point minimizing the sum of distances to all points, computed
+// using the Weiszfeld algorithm. If the points have M coordinates, they are
+// used as the weights of the points. Z coordinates are taken into account and
+// retained in the result. The algorithm stops once an iteration moves the
+// median by less than tolerance, or after maxIterations iterations.
+func GeometricMedian(
+	g geo.Geometry, tolerance float64, maxIterations int, failIfNotConverged bool,
+) (geo.Geometry, error) {
+	if shapeType := g.ShapeType2D(); shapeType != geopb.ShapeType_Point &&
+		shapeType != geopb.ShapeType_MultiPoint {
+		return geo.Geometry{}, pgerror.Newf(
+			pgcode.InvalidParameterValue, "unsupported geometry type: %s", shapeType,
+		)
+	}
+	if tolerance < 0 {
+		return geo.Geometry{}, pgerror.Newf(pgcode.InvalidParameterValue, "tolerance must be non-negative")
+	}
+	if maxIterations < 0 {
+		return geo.Geometry{}, pgerror.Newf(
+			pgcode.InvalidParameterValue, "maximum number of iterations must be non-negative",
+		)
+	}
+	t, err := g.AsGeomT()
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	retLayout := geom.XY
+	if t.Layout().ZIndex() != -1 {
+		retLayout = geom.XYZ
+	}
+	dims := retLayout.Stride()
+	if t.Empty() {
+		return geo.MakeGeometryFromGeomT(geom.NewPointEmpty(retLayout).SetSRID(t.SRID()))
+	}
+
+	// Collect the non-empty points and their weights.
+	var coords []geom.Coord
+	switch t := t.(type) {
+	case *geom.Point:
+		coords = append(coords, t.Coords())
+	case *geom.MultiPoint:
+		for i := 0; i < t.NumPoints(); i++ {
+			if p := t.Point(i); !p.Empty() {
+				coords = append(coords, p.Coords())
+			}
+		}
+	}
+	points := make([][]float64, 0, len(coords))
+	weights := make([]float64, 0, len(coords))
+	totalWeight := 0.0
+	mIndex := t.Layout().MIndex()
+	for _, coord := range coords {
+		weight := 1.0
+		if mIndex != -1 {
+			weight = coord[mIndex]
+			if weight < 0 {
+				return geo.Geometry{}, pgerror.Newf(
+					pgcode.InvalidParameterValue,
+					"geometric median input contains points with negative weights",
+				)
+			}
+		}
+		points = append(points, coord[:dims])
+		weights = append(weights, weight)
+		totalWeight += weight
+	}
+	if len(points) == 0 || totalWeight == 0 {
+		return geo.MakeGeometryFromGeomT(geom.NewPointEmpty(retLayout).SetSRID(t.SRID()))
+	}
+
+	// Start from the weighted centroid of the points.
+	median := make([]float64, dims)
+	for i, p := range points {
+		for d := range median {
+			median[d] += p[d] * weights[i] / totalWeight
+		}
+	}
+	converged := false
+	next := make([]float64, dims)
+	for iter := 0; iter < maxIterations; iter++ {
+		for d := range next {
+			next[d] = 0
+		}
+		denominator := 0.0
+		for i, p := range points {
+			distance := euclideanDistance(median, p)
+			// Points coinciding with the current median do not pull it in any
+			// direction.
+			if distance == 0 {
+				continue
+			}
+			for d := range next {
+				next[d] += weights[i] * p[d] / distance
+			}
+			denominator += weights[i] / distance
+		}
+		if denominator == 0 {
+			converged = true
+			break
+		}
+		for d := range next {
+			next[d] /= denominator
+		}
+		delta := euclideanDistance(median, next)
+		copy(median, next)
+		if delta < tolerance {
+			converged = true
+			break
+		}
+	}
+	if !converged && failIfNotConverged {
+		return geo.Geometry{}, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"median failed to converge within %g after %d iterations",
+			tolerance,
+			maxIterations,
+		)
+	}
+	return geo.MakeGeometryFromGeomT(geom.NewPointFlat(retLayout, median).SetSRID(t.SRID()))
+}
+
+// euclideanDistance returns the distance between the given points, which have
+// the same number of dimensions.
+func euclideanDistance(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestGeometricMedian(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		wkt      string
+		expected []float64
+	}{
+		{"point", "POINT(1 2)", []float64{1, 2}},
+		{"square", "MULTIPOINT((0 0), (10 0), (0 10), (10 10))", []float64{5, 5}},
+		{"with empty point", "MULTIPOINT((0 0), EMPTY, (10 10))", []float64{5, 5}},
+		{"3D", "MULTIPOINT Z ((0 0 0), (2 2 2))", []float64{1, 1, 1}},
+		{"heavy weight", "MULTIPOINT M ((0 0 1), (10 0 1), (5 10 100))", []float64{5, 10}},
+		{"triangle", "MULTIPOINT((0 0), (10 0), (5 10))", []float64{5, 2.886751345948}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := geo.ParseGeometry(tc.wkt)
+			require.NoError(t, err)
+			ret, err := GeometricMedian(g, DefaultGeometricMedianTolerance(g), 10000, true /* failIfNotConverged */)
+			require.NoError(t, err)
+			retT, err := ret.AsGeomT()
+			require.NoError(t, err)
+			require.InDeltaSlice(t, tc.expected, retT.(*geom.Point).FlatCoords(), 1e-6)
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		ret, err := GeometricMedian(geo.MustParseGeometry("SRID=4326;MULTIPOINT EMPTY"), 0, 10, false)
+		require.NoError(t, err)
+		require.Equal(t, geo.MustParseGeometry("SRID=4326;POINT EMPTY"), ret)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := GeometricMedian(geo.MustParseGeometry("LINESTRING(0 0, 1 1)"), 0, 10, false)
+		require.EqualError(t, err, "unsupported geometry type: LineString")
+		_, err = GeometricMedian(geo.MustParseGeometry("MULTIPOINT M ((0 0 1), (1 1 -1))"), 0, 10, false)
+		require.EqualError(t, err, "geometric median input contains points with negative weights")
+		_, err = GeometricMedian(geo.MustParseGeometry("MULTIPOINT((0 0), (10 0), (5 10))"), 0, 1, true)
+		require.EqualError(t, err, "median failed to converge within 0 after 1 iterations")
+	})
+}
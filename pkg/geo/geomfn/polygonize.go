@@ -0,0 +1,58 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geos"
+	"github.com/twpayne/go-geom"
+)
+
+// BuildArea returns the areal geometry formed by the linework of the given
+// geometry. Rings nested inside other rings become holes of the enclosing
+// polygon.
+func BuildArea(g geo.Geometry) (geo.Geometry, error) {
+	retEWKB, err := geos.BuildArea(g.EWKB())
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.ParseGeometryFromEWKB(retEWKB)
+}
+
+// Polygonize returns a GEOMETRYCOLLECTION of the polygons formed by the
+// linework of the given geometries.
+func Polygonize(geoms []geo.Geometry) (geo.Geometry, error) {
+	if err := checkSameSRID(geoms); err != nil {
+		return geo.Geometry{}, err
+	}
+	gc := geom.NewGeometryCollection()
+	for _, g := range geoms {
+		t, err := g.AsGeomT()
+		if err != nil {
+			return geo.Geometry{}, err
+		}
+		if err := gc.Push(t); err != nil {
+			return geo.Geometry{}, err
+		}
+	}
+	if len(geoms) > 0 {
+		gc.SetSRID(int(geoms[0].SRID()))
+	}
+	in, err := geo.MakeGeometryFromGeomT(gc)
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	retEWKB, err := geos.Polygonize(in.EWKB())
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.ParseGeometryFromEWKB(retEWKB)
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildArea(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		wkt      string
+		expected string
+	}{
+		{
+			"nested rings form a hole",
+			"MULTILINESTRING((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 4 2, 4 4, 2 4, 2 2))",
+			"POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 4 2, 4 4, 2 4, 2 2))",
+		},
+		{
+			"disjoint rings",
+			"MULTILINESTRING((0 0, 1 0, 1 1, 0 0), (5 5, 6 5, 6 6, 5 5))",
+			"MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0)), ((5 5, 6 5, 6 6, 5 5)))",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ret, err := BuildArea(geo.MustParseGeometry(tc.wkt))
+			require.NoError(t, err)
+			equals, err := Equals(geo.MustParseGeometry(tc.expected), ret)
+			require.NoError(t, err)
+			require.True(t, equals)
+		})
+	}
+}
+
+func TestPolygonize(t *testing.T) {
+	ret, err := Polygonize([]geo.Geometry{
+		geo.MustParseGeometry("SRID=4326;LINESTRING(0 0, 10 0, 10 10)"),
+		geo.MustParseGeometry("SRID=4326;LINESTRING(10 10, 0 10, 0 0)"),
+		geo.MustParseGeometry("SRID=4326;LINESTRING(5 0, 5 10)"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, geopb.SRID(4326), ret.SRID())
+	dumped, err := Dump(ret)
+	require.NoError(t, err)
+	require.Len(t, dumped, 2)
+	area, err := Area(ret)
+	require.NoError(t, err)
+	require.Equal(t, 100.0, area)
+
+	_, err = Polygonize([]geo.Geometry{
+		geo.MustParseGeometry("SRID=4326;LINESTRING(0 0, 10 0, 10 10)"),
+		geo.MustParseGeometry("LINESTRING(10 10, 0 10, 0 0)"),
+	})
+	require.EqualError(t, err, "operation on mixed SRIDs forbidden: (LineString, 4326) != (LineString, 0)")
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+)
+
+// SimplifyVW simplifies the given Geometry using the Visvalingam-Whyatt
+// algorithm, repeatedly removing the vertex forming the triangle of smallest
+// area with its neighbors until every remaining triangle has an area of at
+// least the given tolerance. LineStrings keep at least 2 points and
+// LinearRings keep at least 4 points.
+func SimplifyVW(g geo.Geometry, tolerance float64) (geo.Geometry, error) {
+	if g.Empty() {
+		return g, nil
+	}
+	t, err := g.AsGeomT()
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	simplifiedT, err := simplifyVW(t, tolerance)
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.MakeGeometryFromGeomT(simplifiedT)
+}
+
+func simplifyVW(t geom.T, tolerance float64) (geom.T, error) {
+	if t.Empty() {
+		return t, nil
+	}
+	switch t := t.(type) {
+	case *geom.Point, *geom.MultiPoint:
+		return t, nil
+	case *geom.LineString:
+		return geom.NewLineString(t.Layout()).
+			MustSetCoords(simplifyVWCoords(t.Coords(), tolerance, 2 /* minPoints */)).
+			SetSRID(t.SRID()), nil
+	case *geom.MultiLineString:
+		ret := geom.NewMultiLineString(t.Layout()).SetSRID(t.SRID())
+		for i := 0; i < t.NumLineStrings(); i++ {
+			ls, err := simplifyVW(t.LineString(i), tolerance)
+			if err != nil {
+				return nil, err
+			}
+			if err := ret.Push(ls.(*geom.LineString)); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	case *geom.Polygon:
+		ret := geom.NewPolygon(t.Layout()).SetSRID(t.SRID())
+		for i := 0; i < t.NumLinearRings(); i++ {
+			coords := simplifyVWCoords(t.LinearRing(i).Coords(), tolerance, 4 /* minPoints */)
+			if err := ret.Push(geom.NewLinearRing(t.Layout()).MustSetCoords(coords)); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	case *geom.MultiPolygon:
+		ret := geom.NewMultiPolygon(t.Layout()).SetSRID(t.SRID())
+		for i := 0; i < t.NumPolygons(); i++ {
+			p, err := simplifyVW(t.Polygon(i), tolerance)
+			if err != nil {
+				return nil, err
+			}
+			if err := ret.Push(p.(*geom.Polygon)); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	case *geom.GeometryCollection:
+		ret := geom.NewGeometryCollection().SetSRID(t.SRID())
+		for _, gcT := range t.Geoms() {
+			simplifiedT, err := simplifyVW(gcT, tolerance)
+			if err != nil {
+				return nil, err
+			}
+			if err := ret.Push(simplifiedT); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	default:
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unknown shape type: %T", t)
+	}
+}
+
+// simplifyVWCoords applies the Visvalingam-Whyatt algorithm to the given
+// coordinates. The first and last coordinates are always kept.
+func simplifyVWCoords(coords []geom.Coord, tolerance float64, minPoints int) []geom.Coord {
+	n := len(coords)
+	if n <= 2 || n <= minPoints {
+		return coords
+	}
+	// prev and next form a doubly linked list of the remaining coordinates.
+	prev := make([]int, n)
+	next := make([]int, n)
+	areas := make([]float64, n)
+	var q vwQueue
+	for i := range coords {
+		prev[i], next[i] = i-1, i+1
+		if i > 0 && i < n-1 {
+			areas[i] = triangleArea(coords[i-1], coords[i], coords[i+1])
+			q = append(q, vwQueueItem{idx: i, area: areas[i]})
+		}
+	}
+	heap.Init(&q)
+
+	removed := make([]bool, n)
+	remaining := n
+	for q.Len() > 0 && remaining > minPoints {
+		item := heap.Pop(&q).(vwQueueItem)
+		if removed[item.idx] || item.area != areas[item.idx] {
+			// The vertex was removed, or its area was updated and it was queued
+			// again.
+			continue
+		}
+		if item.area >= tolerance {
+			break
+		}
+		removed[item.idx] = true
+		remaining--
+		p, nx := prev[item.idx], next[item.idx]
+		next[p], prev[nx] = nx, p
+		for _, neighbor := range []int{p, nx} {
+			if neighbor == 0 || neighbor == n-1 {
+				continue
+			}
+			areas[neighbor] = triangleArea(coords[prev[neighbor]], coords[neighbor], coords[next[neighbor]])
+			heap.Push(&q, vwQueueItem{idx: neighbor, area: areas[neighbor]})
+		}
+	}
+
+	ret := make([]geom.Coord, 0, remaining)
+	for i := 0; i < n; i = next[i] {
+		ret = append(ret, coords[i])
+	}
+	return ret
+}
+
+// triangleArea returns the area of the triangle formed by the given points.
+func triangleArea(a, b, c geom.Coord) float64 {
+	return math.Abs(coordCross(coordSub(b, a), coordSub(c, a))) / 2
+}
+
+type vwQueueItem struct {
+	idx  int
+	area float64
+}
+
+// vwQueue is a min-heap of vertices ordered by their effective area.
+type vwQueue []vwQueueItem
+
+var _ heap.Interface = (*vwQueue)(nil)
+
+func (q vwQueue) Len() int { return len(q) }
+
+func (q vwQueue) Less(i, j int) bool {
+	if q[i].area != q[j].area {
+		return q[i].area < q[j].area
+	}
+	return q[i].idx < q[j].idx
+}
+
+func (q vwQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *vwQueue) Push(x interface{}) { *q = append(*q, x.(vwQueueItem)) }
+
+func (q *vwQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimplifyVW(t *testing.T) {
+	testCases := []struct {
+		wkt       string
+		tolerance float64
+		expected  string
+	}{
+		{"POINT(1 1)", 10, "POINT(1 1)"},
+		{"MULTIPOINT((1 1), (2 2))", 10, "MULTIPOINT((1 1), (2 2))"},
+		{"LINESTRING EMPTY", 10, "LINESTRING EMPTY"},
+		{"LINESTRING(0 0, 1 0.1, 2 0, 3 3, 4 0)", 0, "LINESTRING(0 0, 1 0.1, 2 0, 3 3, 4 0)"},
+		{"LINESTRING(0 0, 1 0.1, 2 0, 3 3, 4 0)", 1, "LINESTRING(0 0, 2 0, 3 3, 4 0)"},
+		{"LINESTRING(0 0, 1 0.1, 2 0, 3 3, 4 0)", 100, "LINESTRING(0 0, 4 0)"},
+		{"SRID=4326;LINESTRING Z (0 0 1, 1 0.1 2, 2 0 3)", 1, "SRID=4326;LINESTRING Z (0 0 1, 2 0 3)"},
+		{
+			"MULTILINESTRING((0 0, 1 0.1, 2 0, 3 3, 4 0), (0 0, 1 1))",
+			1,
+			"MULTILINESTRING((0 0, 2 0, 3 3, 4 0), (0 0, 1 1))",
+		},
+		{
+			"POLYGON((0 0, 10 0, 10 10, 5 11, 0 10, 0 0))",
+			10,
+			"POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))",
+		},
+		{
+			"POLYGON((0 0, 10 0, 10 10, 5 11, 0 10, 0 0))",
+			1000,
+			"POLYGON((0 0, 10 10, 0 10, 0 0))",
+		},
+		{
+			"GEOMETRYCOLLECTION(POINT(1 1), LINESTRING(0 0, 1 0.1, 2 0))",
+			1,
+			"GEOMETRYCOLLECTION(POINT(1 1), LINESTRING(0 0, 2 0))",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s, %g", tc.wkt, tc.tolerance), func(t *testing.T) {
+			g, err := geo.ParseGeometry(tc.wkt)
+			require.NoError(t, err)
+			ret, err := SimplifyVW(g, tc.tolerance)
+			require.NoError(t, err)
+			expected, err := geo.ParseGeometry(tc.expected)
+			require.NoError(t, err)
+			require.Equal(t, expected, ret)
+		})
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+)
+
+// Split splits the given geometry by the given blade, returning the resulting
+// parts as a GEOMETRYCOLLECTION. (MULTI)LINESTRINGs can be split by
+// (MULTI)POINTs, (MULTI)LINESTRINGs, and the boundaries of (MULTI)POLYGONs.
+// (MULTI)POLYGONs can be split by (MULTI)LINESTRINGs.
+func Split(input geo.Geometry, blade geo.Geometry) (geo.Geometry, error) {
+	if input.SRID() != blade.SRID() {
+		return geo.Geometry{}, geo.NewMismatchingSRIDsError(input.SpatialObject(), blade.SpatialObject())
+	}
+	inputType, bladeType := input.ShapeType2D(), blade.ShapeType2D()
+	supported := false
+	switch inputType {
+	case geopb.ShapeType_LineString, geopb.ShapeType_MultiLineString:
+		switch bladeType {
+		case geopb.ShapeType_Point, geopb.ShapeType_MultiPoint,
+			geopb.ShapeType_LineString, geopb.ShapeType_MultiLineString,
+			geopb.ShapeType_Polygon, geopb.ShapeType_MultiPolygon:
+			supported = true
+		}
+	case geopb.ShapeType_Polygon, geopb.ShapeType_MultiPolygon:
+		switch bladeType {
+		case geopb.ShapeType_LineString, geopb.ShapeType_MultiLineString:
+			supported = true
+		}
+	}
+	if !supported {
+		return geo.Geometry{}, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"splitting a %s by a %s is unsupported",
+			inputType,
+			bladeType,
+		)
+	}
+
+	t, err := input.AsGeomT()
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	bladeT, err := blade.AsGeomT()
+	if err != nil {
+		return geo.Geometry{}, err
+	}
+	// Lines are split by the boundary of polygons.
+	if bladeType == geopb.ShapeType_Polygon || bladeType == geopb.ShapeType_MultiPolygon {
+		if blade, err = Boundary(blade); err != nil {
+			return geo.Geometry{}, err
+		}
+	}
+
+	ret := geom.NewGeometryCollection().SetSRID(t.SRID())
+	if err := splitGeomT(t, blade, bladeT, ret); err != nil {
+		return geo.Geometry{}, err
+	}
+	return geo.MakeGeometryFromGeomT(ret)
+}
+
+// splitGeomT splits each component of t by the blade, appending the parts to
+// ret.
+func splitGeomT(t geom.T, blade geo.Geometry, bladeT geom.T, ret *geom.GeometryCollection) error {
+	switch t := t.(type) {
+	case *geom.LineString:
+		if t.Empty() {
+			return nil
+		}
+		var parts []geom.T
+		var err error
+		switch bladeT := bladeT.(type) {
+		case *geom.Point:
+			var points []geom.Coord
+			if !bladeT.Empty() {
+				points = append(points, bladeT.Coords())
+			}
+			parts = splitLineStringByPoints(t, points)
+		case *geom.MultiPoint:
+			points := make([]geom.Coord, 0, bladeT.NumPoints())
+			for i := 0; i < bladeT.NumPoints(); i++ {
+				if p := bladeT.Point(i); !p.Empty() {
+					points = append(points, p.Coords())
+				}
+			}
+			parts = splitLineStringByPoints(t, points)
+		default:
+			parts, err = splitLineStringByLines(t, blade)
+		}
+		if err != nil {
+			return err
+		}
+		for _, part := range parts {
+			// Only the collection itself carries the SRID.
+			if part, err = setGeomTSRID(part, 0); err != nil {
+				return err
+			}
+			if err := ret.Push(part); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *geom.MultiLineString:
+		for i := 0; i < t.NumLineStrings(); i++ {
+			if err := splitGeomT(t.LineString(i).SetSRID(t.SRID()), blade, bladeT, ret); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *geom.Polygon:
+		if t.Empty() {
+			return nil
+		}
+		return splitPolygonByLines(t, blade, ret)
+	case *geom.MultiPolygon:
+		for i := 0; i < t.NumPolygons(); i++ {
+			if err := splitGeomT(t.Polygon(i).SetSRID(t.SRID()), blade, bladeT, ret); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return pgerror.Newf(pgcode.InvalidParameterValue, "unsupported type: %T", t)
+	}
+}
+
+// splitLineStringByPoints splits the given LineString at each of the given
+// points which lie on it. Points at the start or end of the LineString, or not
+// on the LineString at all, do not split it.
+func splitLineStringByPoints(l *geom.LineString, points []geom.Coord) []geom.T {
+	parts := []*geom.LineString{l}
+	for _, p := range points {
+		for i, part := range parts {
+			a, b, ok := splitLineStringAtPoint(part, p)
+			if !ok {
+				continue
+			}
+			parts = append(parts[:i+1], parts[i:]...)
+			parts[i], parts[i+1] = a, b
+			break
+		}
+	}
+	ret := make([]geom.T, len(parts))
+	for i, part := range parts {
+		ret[i] = part
+	}
+	return ret
+}
+
+// splitLineStringAtPoint splits the given LineString at the first location the
+// given point lies on it, returning false if no such split is possible.
+func splitLineStringAtPoint(l *geom.LineString, p geom.Coord) (*geom.LineString, *geom.LineString, bool) {
+	numCoords := l.NumCoords()
+	if coordEqual(l.Coord(0), p) || coordEqual(l.Coord(numCoords-1), p) {
+		return nil, nil, false
+	}
+	for i := 0; i < numCoords-1; i++ {
+		start, end := l.Coord(i), l.Coord(i+1)
+		if coordEqual(end, p) || !pointOnSegment(p, start, end) {
+			// If p is at the end of the segment, the split happens at the start
+			// of the next segment.
+			continue
+		}
+		splitCoord := start
+		if !coordEqual(start, p) {
+			// Interpolate any Z and M values at the split point.
+			fraction := coordNorm(coordSub(p, start)) / coordNorm(coordSub(end, start))
+			splitCoord = interpolateCoord(start, end, fraction)
+			splitCoord[0], splitCoord[1] = p.X(), p.Y()
+		}
+		firstCoords := append(append([]geom.Coord{}, l.Coords()[:i+1]...), splitCoord)
+		if coordEqual(start, p) {
+			firstCoords = firstCoords[:i+1]
+		}
+		secondCoords := append([]geom.Coord{splitCoord}, l.Coords()[i+1:]...)
+		first := geom.NewLineString(l.Layout()).MustSetCoords(firstCoords).SetSRID(l.SRID())
+		second := geom.NewLineString(l.Layout()).MustSetCoords(secondCoords).SetSRID(l.SRID())
+		return first, second, true
+	}
+	return nil, nil, false
+}
+
+// pointOnSegment returns whether p lies on the segment from start to end.
+func pointOnSegment(p, start, end geom.Coord) bool {
+	if coordCross(coordSub(end, start), coordSub(p, start)) != 0 {
+		return false
+	}
+	return p.X() >= math.Min(start.X(), end.X()) && p.X() <= math.Max(start.X(), end.X()) &&
+		p.Y() >= math.Min(start.Y(), end.Y()) && p.Y() <= math.Max(start.Y(), end.Y())
+}
+
+// splitLineStringByLines splits the given LineString at its intersections
+// with the given (MULTI)LINESTRING blade.
+func splitLineStringByLines(l *geom.LineString, blade geo.Geometry) ([]geom.T, error) {
+	line, err := geo.MakeGeometryFromGeomT(l)
+	if err != nil {
+		return nil, err
+	}
+	overlaps, err := RelatePattern(line, blade, "1********")
+	if err != nil {
+		return nil, err
+	}
+	if overlaps {
+		return nil, pgerror.Newf(
+			pgcode.InvalidParameterValue, "splitter line has linear intersection with input",
+		)
+	}
+	diff, err := Difference(line, blade)
+	if err != nil {
+		return nil, err
+	}
+	diffT, err := diff.AsGeomT()
+	if err != nil {
+		return nil, err
+	}
+	switch diffT := diffT.(type) {
+	case *geom.LineString:
+		return []geom.T{diffT}, nil
+	case *geom.MultiLineString:
+		ret := make([]geom.T, diffT.NumLineStrings())
+		for i := range ret {
+			ret[i] = diffT.LineString(i).SetSRID(diffT.SRID())
+		}
+		return ret, nil
+	default:
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unexpected split result: %T", diffT)
+	}
+}
+
+// splitPolygonByLines splits the given Polygon by polygonizing its boundary
+// along with the given (MULTI)LINESTRING blade, appending the resulting
+// polygons which lie within the original Polygon to ret.
+func splitPolygonByLines(p *geom.Polygon, blade geo.Geometry, ret *geom.GeometryCollection) error {
+	polygon, err := geo.MakeGeometryFromGeomT(p)
+	if err != nil {
+		return err
+	}
+	boundary, err := Boundary(polygon)
+	if err != nil {
+		return err
+	}
+	noded, err := Union(boundary, blade)
+	if err != nil {
+		return err
+	}
+	polygonized, err := Polygonize([]geo.Geometry{noded})
+	if err != nil {
+		return err
+	}
+	polygonizedT, err := polygonized.AsGeomT()
+	if err != nil {
+		return err
+	}
+	gc, ok := polygonizedT.(*geom.GeometryCollection)
+	if !ok {
+		return pgerror.Newf(pgcode.InvalidParameterValue, "unexpected polygonize result: %T", polygonizedT)
+	}
+	for _, part := range gc.Geoms() {
+		part, err := setGeomTSRID(part, p.SRID())
+		if err != nil {
+			return err
+		}
+		partGeom, err := geo.MakeGeometryFromGeomT(part)
+		if err != nil {
+			return err
+		}
+		pointOnSurface, err := PointOnSurface(partGeom)
+		if err != nil {
+			return err
+		}
+		contains, err := Contains(polygon, pointOnSurface)
+		if err != nil {
+			return err
+		}
+		if contains {
+			if part, err = setGeomTSRID(part, 0); err != nil {
+				return err
+			}
+			if err := ret.Push(part); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
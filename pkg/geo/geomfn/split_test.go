@@ -0,0 +1,137 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geomfn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	testCases := []struct {
+		input    string
+		blade    string
+		expected string
+	}{
+		{
+			"LINESTRING(0 0, 10 0)",
+			"POINT(5 0)",
+			"GEOMETRYCOLLECTION(LINESTRING(0 0, 5 0), LINESTRING(5 0, 10 0))",
+		},
+		{
+			"SRID=4326;LINESTRING(0 0, 10 0)",
+			"SRID=4326;POINT(5 0)",
+			"SRID=4326;GEOMETRYCOLLECTION(LINESTRING(0 0, 5 0), LINESTRING(5 0, 10 0))",
+		},
+		{
+			"LINESTRING(0 0, 5 0, 10 0)",
+			"POINT(5 0)",
+			"GEOMETRYCOLLECTION(LINESTRING(0 0, 5 0), LINESTRING(5 0, 10 0))",
+		},
+		{
+			"LINESTRING(0 0, 10 0)",
+			"POINT(0 0)",
+			"GEOMETRYCOLLECTION(LINESTRING(0 0, 10 0))",
+		},
+		{
+			"LINESTRING(0 0, 10 0)",
+			"POINT(5 1)",
+			"GEOMETRYCOLLECTION(LINESTRING(0 0, 10 0))",
+		},
+		{
+			"LINESTRING(0 0, 10 0)",
+			"POINT EMPTY",
+			"GEOMETRYCOLLECTION(LINESTRING(0 0, 10 0))",
+		},
+		{
+			"LINESTRING Z (0 0 0, 10 0 10)",
+			"POINT(4 0)",
+			"GEOMETRYCOLLECTION(LINESTRING Z (0 0 0, 4 0 4), LINESTRING Z (4 0 4, 10 0 10))",
+		},
+		{
+			"LINESTRING(0 0, 10 0, 10 10)",
+			"MULTIPOINT((2 0), (10 5), (3 3))",
+			"GEOMETRYCOLLECTION(LINESTRING(0 0, 2 0), LINESTRING(2 0, 10 0, 10 5), LINESTRING(10 5, 10 10))",
+		},
+		{
+			"MULTILINESTRING((0 0, 10 0), (0 1, 10 1))",
+			"POINT(5 1)",
+			"GEOMETRYCOLLECTION(LINESTRING(0 0, 10 0), LINESTRING(0 1, 5 1), LINESTRING(5 1, 10 1))",
+		},
+		{
+			"LINESTRING EMPTY",
+			"POINT(5 1)",
+			"GEOMETRYCOLLECTION EMPTY",
+		},
+		{
+			"LINESTRING(0 0, 10 0)",
+			"LINESTRING(5 -5, 5 5)",
+			"GEOMETRYCOLLECTION(LINESTRING(0 0, 5 0), LINESTRING(5 0, 10 0))",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s by %s", tc.input, tc.blade), func(t *testing.T) {
+			input, err := geo.ParseGeometry(tc.input)
+			require.NoError(t, err)
+			blade, err := geo.ParseGeometry(tc.blade)
+			require.NoError(t, err)
+			ret, err := Split(input, blade)
+			require.NoError(t, err)
+			expected, err := geo.ParseGeometry(tc.expected)
+			require.NoError(t, err)
+			require.Equal(t, expected, ret)
+		})
+	}
+
+	t.Run("polygon by line", func(t *testing.T) {
+		ret, err := Split(
+			geo.MustParseGeometry("POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 4 2, 4 4, 2 4, 2 2))"),
+			geo.MustParseGeometry("LINESTRING(5 -1, 5 11)"),
+		)
+		require.NoError(t, err)
+		expected := []string{
+			"POLYGON((0 0, 0 10, 5 10, 5 0, 0 0), (2 2, 4 2, 4 4, 2 4, 2 2))",
+			"POLYGON((5 0, 5 10, 10 10, 10 0, 5 0))",
+		}
+		dumped, err := Dump(ret)
+		require.NoError(t, err)
+		require.Len(t, dumped, len(expected))
+		for _, e := range expected {
+			found := false
+			for _, d := range dumped {
+				equals, err := Equals(geo.MustParseGeometry(e), d.Geometry)
+				require.NoError(t, err)
+				found = found || equals
+			}
+			require.True(t, found, "expected %s in split result", e)
+		}
+	})
+
+	errorTestCases := []struct {
+		input  string
+		blade  string
+		errMsg string
+	}{
+		{"POINT(0 0)", "POINT(0 0)", "splitting a Point by a Point is unsupported"},
+		{"POLYGON((0 0, 1 0, 1 1, 0 0))", "POINT(0 0)", "splitting a Polygon by a Point is unsupported"},
+		{"LINESTRING(0 0, 10 0)", "LINESTRING(2 0, 5 0)", "splitter line has linear intersection with input"},
+		{"LINESTRING(0 0, 10 0)", "SRID=4326;POINT(5 0)", `operation on mixed SRIDs forbidden: (LineString, 0) != (Point, 4326)`},
+	}
+	for _, tc := range errorTestCases {
+		t.Run(fmt.Sprintf("error: %s by %s", tc.input, tc.blade), func(t *testing.T) {
+			_, err := Split(geo.MustParseGeometry(tc.input), geo.MustParseGeometry(tc.blade))
+			require.EqualError(t, err, tc.errMsg)
+		})
+	}
+}
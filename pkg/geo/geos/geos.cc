@@ -176,6 +176,12 @@ typedef CR_GEOS_Geometry (*CR_GEOS_MinimumRotatedRectangle_r)(CR_GEOS_Handle, CR
 
 typedef CR_GEOS_Geometry (*CR_GEOS_Snap_r)(CR_GEOS_Handle, CR_GEOS_Geometry, CR_GEOS_Geometry, double);
 
+typedef CR_GEOS_Geometry (*CR_GEOS_DelaunayTriangulation_r)(CR_GEOS_Handle, CR_GEOS_Geometry,
+                                                         double, int);
+typedef CR_GEOS_Geometry (*CR_GEOS_Polygonize_r)(CR_GEOS_Handle, const CR_GEOS_Geometry*,
+                                              unsigned int);
+typedef CR_GEOS_Geometry (*CR_GEOS_BuildArea_r)(CR_GEOS_Handle, CR_GEOS_Geometry);
+
 std::string ToString(CR_GEOS_Slice slice) { return std::string(slice.data, slice.len); }
 
 }  // namespace
@@ -287,6 +293,10 @@ struct CR_GEOS {
 
   CR_GEOS_Snap_r GEOSSnap_r;
 
+  CR_GEOS_DelaunayTriangulation_r GEOSDelaunayTriangulation_r;
+  CR_GEOS_Polygonize_r GEOSPolygonize_r;
+  CR_GEOS_BuildArea_r GEOSBuildArea_r;
+
   CR_GEOS(dlhandle geoscHandle, dlhandle geosHandle)
       : geoscHandle(geoscHandle), geosHandle(geosHandle) {}
 
@@ -390,6 +400,9 @@ struct CR_GEOS {
     INIT(GEOSClipByRect_r);
     INIT(GEOSNode_r);
     INIT(GEOSSnap_r);
+    INIT(GEOSDelaunayTriangulation_r);
+    INIT(GEOSPolygonize_r);
+    INIT(GEOSBuildArea_r);
     return nullptr;
 
 #undef INIT
@@ -1552,3 +1565,61 @@ CR_GEOS_Status CR_GEOS_Snap(CR_GEOS* lib, CR_GEOS_Slice input, CR_GEOS_Slice tar
   lib->GEOS_finish_r(handle);
   return toGEOSString(error.data(), error.length());
 }
+
+CR_GEOS_Status CR_GEOS_DelaunayTriangles(CR_GEOS* lib, CR_GEOS_Slice g, double tolerance,
+                                         int onlyEdges, CR_GEOS_String* ret) {
+  std::string error;
+  auto handle = initHandleWithErrorBuffer(lib, &error);
+  auto gGeom = CR_GEOS_GeometryFromSlice(lib, handle, g);
+  *ret = {.data = NULL, .len = 0};
+  if (gGeom != nullptr) {
+    auto r = lib->GEOSDelaunayTriangulation_r(handle, gGeom, tolerance, onlyEdges);
+    if (r != NULL) {
+      auto srid = lib->GEOSGetSRID_r(handle, gGeom);
+      CR_GEOS_writeGeomToEWKB(lib, handle, r, ret, srid);
+      lib->GEOSGeom_destroy_r(handle, r);
+    }
+    lib->GEOSGeom_destroy_r(handle, gGeom);
+  }
+
+  lib->GEOS_finish_r(handle);
+  return toGEOSString(error.data(), error.length());
+}
+
+CR_GEOS_Status CR_GEOS_Polygonize(CR_GEOS* lib, CR_GEOS_Slice g, CR_GEOS_String* ret) {
+  std::string error;
+  auto handle = initHandleWithErrorBuffer(lib, &error);
+  auto gGeom = CR_GEOS_GeometryFromSlice(lib, handle, g);
+  *ret = {.data = NULL, .len = 0};
+  if (gGeom != nullptr) {
+    auto r = lib->GEOSPolygonize_r(handle, &gGeom, 1);
+    if (r != NULL) {
+      auto srid = lib->GEOSGetSRID_r(handle, gGeom);
+      CR_GEOS_writeGeomToEWKB(lib, handle, r, ret, srid);
+      lib->GEOSGeom_destroy_r(handle, r);
+    }
+    lib->GEOSGeom_destroy_r(handle, gGeom);
+  }
+
+  lib->GEOS_finish_r(handle);
+  return toGEOSString(error.data(), error.length());
+}
+
+CR_GEOS_Status CR_GEOS_BuildArea(CR_GEOS* lib, CR_GEOS_Slice g, CR_GEOS_String* ret) {
+  std::string error;
+  auto handle = initHandleWithErrorBuffer(lib, &error);
+  auto gGeom = CR_GEOS_GeometryFromSlice(lib, handle, g);
+  *ret = {.data = NULL, .len = 0};
+  if (gGeom != nullptr) {
+    auto r = lib->GEOSBuildArea_r(handle, gGeom);
+    if (r != NULL) {
+      auto srid = lib->GEOSGetSRID_r(handle, gGeom);
+      CR_GEOS_writeGeomToEWKB(lib, handle, r, ret, srid);
+      lib->GEOSGeom_destroy_r(handle, r);
+    }
+    lib->GEOSGeom_destroy_r(handle, gGeom);
+  }
+
+  lib->GEOS_finish_r(handle);
+  return toGEOSString(error.data(), error.length());
+}
@@ -1116,3 +1116,53 @@ func Snap(input, target geopb.EWKB, tolerance float64) (geopb.EWKB, error) {
 	}
 	return cStringToSafeGoBytes(cEWKB), nil
 }
+
+// DelaunayTriangles returns the Delaunay triangulation of the vertices of the
+// given EWKB. If onlyEdges is set, the edges of the triangulation are returned
+// as a MULTILINESTRING, otherwise the triangles are returned as a
+// GEOMETRYCOLLECTION of POLYGONs.
+func DelaunayTriangles(ewkb geopb.EWKB, tolerance float64, onlyEdges bool) (geopb.EWKB, error) {
+	g, err := ensureInitInternal()
+	if err != nil {
+		return nil, err
+	}
+	var cEWKB C.CR_GEOS_String
+	flag := 0
+	if onlyEdges {
+		flag = 1
+	}
+	if err := statusToError(
+		C.CR_GEOS_DelaunayTriangles(g, goToCSlice(ewkb), C.double(tolerance), C.int(flag), &cEWKB),
+	); err != nil {
+		return nil, err
+	}
+	return cStringToSafeGoBytes(cEWKB), nil
+}
+
+// Polygonize returns a GEOMETRYCOLLECTION of the POLYGONs formed by the
+// linework of the given EWKB.
+func Polygonize(ewkb geopb.EWKB) (geopb.EWKB, error) {
+	g, err := ensureInitInternal()
+	if err != nil {
+		return nil, err
+	}
+	var cEWKB C.CR_GEOS_String
+	if err := statusToError(C.CR_GEOS_Polygonize(g, goToCSlice(ewkb), &cEWKB)); err != nil {
+		return nil, err
+	}
+	return cStringToSafeGoBytes(cEWKB), nil
+}
+
+// BuildArea returns the areal geometry formed by the linework of the given
+// EWKB, with rings nested inside other rings forming holes.
+func BuildArea(ewkb geopb.EWKB) (geopb.EWKB, error) {
+	g, err := ensureInitInternal()
+	if err != nil {
+		return nil, err
+	}
+	var cEWKB C.CR_GEOS_String
+	if err := statusToError(C.CR_GEOS_BuildArea(g, goToCSlice(ewkb), &cEWKB)); err != nil {
+		return nil, err
+	}
+	return cStringToSafeGoBytes(cEWKB), nil
+}
@@ -194,6 +194,13 @@ CR_GEOS_Status CR_GEOS_VoronoiDiagram(CR_GEOS* lib, CR_GEOS_Slice g, CR_GEOS_Sli
 
 CR_GEOS_Status CR_GEOS_Snap(CR_GEOS* lib, CR_GEOS_Slice input, CR_GEOS_Slice target, double tolerance, CR_GEOS_String* ret);
 
+CR_GEOS_Status CR_GEOS_DelaunayTriangles(CR_GEOS* lib, CR_GEOS_Slice g, double tolerance,
+                                         int onlyEdges, CR_GEOS_String* ret);
+
+CR_GEOS_Status CR_GEOS_Polygonize(CR_GEOS* lib, CR_GEOS_Slice g, CR_GEOS_String* ret);
+
+CR_GEOS_Status CR_GEOS_BuildArea(CR_GEOS* lib, CR_GEOS_Slice g, CR_GEOS_String* ret);
+
 #ifdef __cplusplus
 }  // extern "C"
 #endif
@@ -0,0 +1,821 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/geo/geoprojbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/ewkb"
+)
+
+// GMLVersion is the version of GML used to represent a SpatialObject.
+type GMLVersion int
+
+const (
+	// GMLVersion2 is GML 2.1.2.
+	GMLVersion2 GMLVersion = 2
+	// GMLVersion3 is GML 3.1.1.
+	GMLVersion3 GMLVersion = 3
+)
+
+// DefaultGMLDecimalDigits is the default number of digits coordinates in GML.
+const DefaultGMLDecimalDigits = 15
+
+// DefaultGMLNamespacePrefix is the default namespace prefix of GML elements.
+const DefaultGMLNamespacePrefix = "gml"
+
+// SpatialObjectToGMLFlag maps to the ST_AsGML options for PostGIS.
+type SpatialObjectToGMLFlag int
+
+// These should be kept with ST_AsGML in PostGIS.
+// 0: GML Short CRS (e.g EPSG:4326) (default)
+// 1: GML Long CRS (e.g urn:ogc:def:crs:EPSG::4326)
+// 2: GML 3 only, omit the srsDimension attribute
+// 4: GML 3 only, use <LineString> rather than <Curve> for lines
+// 16: GML 3 only, write coordinates in lat/lon order
+// 32: output the bounding box of the geometry
+const (
+	SpatialObjectToGMLFlagLongCRS SpatialObjectToGMLFlag = 1 << (iota)
+	SpatialObjectToGMLFlagNoSRSDimension
+	SpatialObjectToGMLFlagShortLine
+	_
+	SpatialObjectToGMLFlagLatLonOrder
+	SpatialObjectToGMLFlagBoundingBox
+
+	SpatialObjectToGMLFlagZero = 0
+)
+
+// SpatialObjectToGML transforms a given SpatialObject to GML. Elements are
+// qualified by the given namespace prefix, which may be empty.
+func SpatialObjectToGML(
+	so geopb.SpatialObject,
+	version GMLVersion,
+	maxDecimalDigits int,
+	flag SpatialObjectToGMLFlag,
+	namespacePrefix string,
+) (string, error) {
+	if version != GMLVersion2 && version != GMLVersion3 {
+		return "", pgerror.Newf(pgcode.InvalidParameterValue, "only GML 2 and GML 3 are supported")
+	}
+	if flag&SpatialObjectToGMLFlagBoundingBox != 0 {
+		return "", pgerror.Newf(pgcode.FeatureNotSupported, "outputting the bounding box as GML is not yet supported")
+	}
+	t, err := ewkb.Unmarshal([]byte(so.EWKB))
+	if err != nil {
+		return "", err
+	}
+	e := gmlEncoder{
+		version:          version,
+		maxDecimalDigits: maxDecimalDigits,
+		flag:             flag,
+	}
+	if namespacePrefix != "" {
+		e.prefix = namespacePrefix + ":"
+	}
+	if t.SRID() != 0 {
+		projection, err := geoprojbase.Projection(geopb.SRID(t.SRID()))
+		if err != nil {
+			return "", err
+		}
+		if flag&SpatialObjectToGMLFlagLongCRS != 0 {
+			e.srsName = fmt.Sprintf("urn:ogc:def:crs:%s::%d", projection.AuthName, projection.AuthSRID)
+		} else {
+			e.srsName = fmt.Sprintf("%s:%d", projection.AuthName, projection.AuthSRID)
+		}
+	}
+	if err := e.encode(t, true /* isRoot */); err != nil {
+		return "", err
+	}
+	return e.String(), nil
+}
+
+// gmlEncoder writes geom.T objects as GML.
+type gmlEncoder struct {
+	strings.Builder
+	version          GMLVersion
+	prefix           string
+	maxDecimalDigits int
+	flag             SpatialObjectToGMLFlag
+	// srsName is written as an attribute of the root element if set.
+	srsName string
+}
+
+func (e *gmlEncoder) writeStartElement(name string, isRoot bool, selfClosing bool) {
+	e.WriteString("<")
+	e.WriteString(e.prefix)
+	e.WriteString(name)
+	if isRoot && e.srsName != "" {
+		e.WriteString(` srsName="`)
+		e.WriteString(e.srsName)
+		e.WriteString(`"`)
+	}
+	if selfClosing {
+		e.WriteString("/")
+	}
+	e.WriteString(">")
+}
+
+func (e *gmlEncoder) writeEndElement(name string) {
+	e.WriteString("</")
+	e.WriteString(e.prefix)
+	e.WriteString(name)
+	e.WriteString(">")
+}
+
+func (e *gmlEncoder) encode(t geom.T, isRoot bool) error {
+	name, err := e.elementName(t)
+	if err != nil {
+		return err
+	}
+	if t.Empty() {
+		e.writeStartElement(name, isRoot, true /* selfClosing */)
+		return nil
+	}
+	e.writeStartElement(name, isRoot, false /* selfClosing */)
+	switch t := t.(type) {
+	case *geom.Point:
+		e.writeCoords(t.FlatCoords(), t.Layout(), true /* isSinglePoint */)
+	case *geom.LineString:
+		if e.version == GMLVersion3 && e.flag&SpatialObjectToGMLFlagShortLine == 0 {
+			e.writeStartElement("segments", false /* isRoot */, false /* selfClosing */)
+			e.writeStartElement("LineStringSegment", false /* isRoot */, false /* selfClosing */)
+			e.writeCoords(t.FlatCoords(), t.Layout(), false /* isSinglePoint */)
+			e.writeEndElement("LineStringSegment")
+			e.writeEndElement("segments")
+		} else {
+			e.writeCoords(t.FlatCoords(), t.Layout(), false /* isSinglePoint */)
+		}
+	case *geom.Polygon:
+		for i := 0; i < t.NumLinearRings(); i++ {
+			var boundaryName string
+			switch {
+			case i == 0 && e.version == GMLVersion2:
+				boundaryName = "outerBoundaryIs"
+			case i == 0:
+				boundaryName = "exterior"
+			case e.version == GMLVersion2:
+				boundaryName = "innerBoundaryIs"
+			default:
+				boundaryName = "interior"
+			}
+			e.writeStartElement(boundaryName, false /* isRoot */, false /* selfClosing */)
+			e.writeStartElement("LinearRing", false /* isRoot */, false /* selfClosing */)
+			e.writeCoords(t.LinearRing(i).FlatCoords(), t.Layout(), false /* isSinglePoint */)
+			e.writeEndElement("LinearRing")
+			e.writeEndElement(boundaryName)
+		}
+	case *geom.MultiPoint:
+		for i := 0; i < t.NumPoints(); i++ {
+			if err := e.encodeMember("pointMember", t.Point(i)); err != nil {
+				return err
+			}
+		}
+	case *geom.MultiLineString:
+		memberName := "lineStringMember"
+		if e.version == GMLVersion3 {
+			memberName = "curveMember"
+		}
+		for i := 0; i < t.NumLineStrings(); i++ {
+			if err := e.encodeMember(memberName, t.LineString(i)); err != nil {
+				return err
+			}
+		}
+	case *geom.MultiPolygon:
+		memberName := "polygonMember"
+		if e.version == GMLVersion3 {
+			memberName = "surfaceMember"
+		}
+		for i := 0; i < t.NumPolygons(); i++ {
+			if err := e.encodeMember(memberName, t.Polygon(i)); err != nil {
+				return err
+			}
+		}
+	case *geom.GeometryCollection:
+		for _, subT := range t.Geoms() {
+			if err := e.encodeMember("geometryMember", subT); err != nil {
+				return err
+			}
+		}
+	}
+	e.writeEndElement(name)
+	return nil
+}
+
+func (e *gmlEncoder) encodeMember(memberName string, t geom.T) error {
+	e.writeStartElement(memberName, false /* isRoot */, false /* selfClosing */)
+	if err := e.encode(t, false /* isRoot */); err != nil {
+		return err
+	}
+	e.writeEndElement(memberName)
+	return nil
+}
+
+// elementName returns the name of the GML element representing t.
+func (e *gmlEncoder) elementName(t geom.T) (string, error) {
+	switch t.(type) {
+	case *geom.Point:
+		return "Point", nil
+	case *geom.LineString:
+		if e.version == GMLVersion3 && e.flag&SpatialObjectToGMLFlagShortLine == 0 {
+			return "Curve", nil
+		}
+		return "LineString", nil
+	case *geom.Polygon:
+		return "Polygon", nil
+	case *geom.MultiPoint:
+		return "MultiPoint", nil
+	case *geom.MultiLineString:
+		if e.version == GMLVersion3 {
+			return "MultiCurve", nil
+		}
+		return "MultiLineString", nil
+	case *geom.MultiPolygon:
+		if e.version == GMLVersion3 {
+			return "MultiSurface", nil
+		}
+		return "MultiPolygon", nil
+	case *geom.GeometryCollection:
+		return "MultiGeometry", nil
+	default:
+		return "", pgerror.Newf(pgcode.InvalidParameterValue, "unknown geometry type: %T", t)
+	}
+}
+
+// writeCoords writes the given coordinates, ignoring any M dimension.
+// GML 2 uses a <coordinates> element, whereas GML 3 uses <pos> for a single
+// point and <posList> otherwise.
+func (e *gmlEncoder) writeCoords(flatCoords []float64, layout geom.Layout, isSinglePoint bool) {
+	dims := 2
+	if layout.ZIndex() != -1 {
+		dims = 3
+	}
+	elementName := "coordinates"
+	coordSep, pointSep := ",", " "
+	if e.version == GMLVersion3 {
+		elementName = "posList"
+		if isSinglePoint {
+			elementName = "pos"
+		}
+		coordSep = " "
+	}
+	e.WriteString("<")
+	e.WriteString(e.prefix)
+	e.WriteString(elementName)
+	if e.version == GMLVersion3 && e.flag&SpatialObjectToGMLFlagNoSRSDimension == 0 {
+		fmt.Fprintf(e, ` srsDimension="%d"`, dims)
+	}
+	e.WriteString(">")
+	stride := layout.Stride()
+	for i := 0; i < len(flatCoords); i += stride {
+		if i > 0 {
+			e.WriteString(pointSep)
+		}
+		x, y := flatCoords[i], flatCoords[i+1]
+		if e.version == GMLVersion3 && e.flag&SpatialObjectToGMLFlagLatLonOrder != 0 {
+			x, y = y, x
+		}
+		e.WriteString(formatFloatWithMaxDecimalDigits(x, e.maxDecimalDigits))
+		e.WriteString(coordSep)
+		e.WriteString(formatFloatWithMaxDecimalDigits(y, e.maxDecimalDigits))
+		if dims == 3 {
+			e.WriteString(coordSep)
+			e.WriteString(formatFloatWithMaxDecimalDigits(flatCoords[i+layout.ZIndex()], e.maxDecimalDigits))
+		}
+	}
+	e.writeEndElement(elementName)
+}
+
+// gmlNode is an element in a GML document.
+type gmlNode struct {
+	// name is the local name of the element, i.e. without any namespace.
+	name     string
+	attrs    []xml.Attr
+	children []*gmlNode
+	text     []byte
+}
+
+func (n *gmlNode) attr(name string) (string, bool) {
+	for _, attr := range n.attrs {
+		if attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// parseGMLTree parses the given GML document into a tree of gmlNodes.
+func parseGMLTree(b []byte) (*gmlNode, error) {
+	d := xml.NewDecoder(bytes.NewReader(b))
+	var root *gmlNode
+	var stack []*gmlNode
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue, "error parsing GML")
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &gmlNode{name: tok.Name.Local, attrs: tok.Copy().Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root != nil {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue, "error parsing GML: multiple root elements")
+			} else {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				n := stack[len(stack)-1]
+				n.text = append(n.text, tok...)
+			}
+		}
+	}
+	if root == nil {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation")
+	}
+	return root, nil
+}
+
+// gmlParser converts a tree of gmlNodes into a geom.T.
+type gmlParser struct {
+	srid    geopb.SRID
+	hasSRID bool
+	// reverseAxis is set if the coordinates are in lat/lon order.
+	reverseAxis bool
+	// hasZ is unset as soon as a coordinate without a Z value is encountered.
+	// Geometries are parsed with a Z value, which is dropped at the end if any
+	// coordinate is missing one or if there are no coordinates at all.
+	hasZ      bool
+	hasCoords bool
+}
+
+// parseGML takes given bytes assumed to be GML and transforms it into a
+// SpatialObject. If defaultSRID is non-zero, it takes precedence over any SRID
+// specified by the GML.
+func parseGML(
+	soType geopb.SpatialObjectType, b []byte, defaultSRID geopb.SRID,
+) (geopb.SpatialObject, error) {
+	root, err := parseGMLTree(b)
+	if err != nil {
+		return geopb.SpatialObject{}, err
+	}
+	p := gmlParser{hasZ: true}
+	t, err := p.parseGeom(root)
+	if err != nil {
+		return geopb.SpatialObject{}, err
+	}
+	if !p.hasZ || !p.hasCoords {
+		t = gmlForce2D(t)
+	}
+	srid := p.srid
+	if defaultSRID != 0 {
+		srid = defaultSRID
+	}
+	AdjustGeomTSRID(t, srid)
+	return spatialObjectFromGeomT(t, soType)
+}
+
+// parseSRSName updates the SRID of the parser with the srsName attribute of the
+// given node, if one is present.
+func (p *gmlParser) parseSRSName(n *gmlNode) error {
+	srsName, ok := n.attr("srsName")
+	if !ok {
+		return nil
+	}
+	srid, reverseAxis, err := parseGMLSRSName(srsName)
+	if err != nil {
+		return err
+	}
+	if p.hasSRID {
+		if srid != p.srid {
+			return pgerror.Newf(
+				pgcode.InvalidParameterValue,
+				"GML geometries with mixed SRIDs are not supported",
+			)
+		}
+		return nil
+	}
+	p.srid = srid
+	p.hasSRID = true
+	p.reverseAxis = reverseAxis
+	return nil
+}
+
+// parseGMLSRSName returns the SRID denoted by the given srsName, as well as
+// whether the coordinates are in lat/lon order. Only URN and URL forms
+// of geographic spatial reference systems use the lat/lon axis order.
+func parseGMLSRSName(srsName string) (geopb.SRID, bool, error) {
+	lowerSRSName := strings.ToLower(srsName)
+	if !strings.Contains(lowerSRSName, "epsg") {
+		return 0, false, pgerror.Newf(pgcode.InvalidParameterValue, "unknown spatial reference system: %s", srsName)
+	}
+	srid, err := strconv.Atoi(srsName[strings.LastIndexAny(srsName, ":#/")+1:])
+	if err != nil {
+		return 0, false, pgerror.Newf(pgcode.InvalidParameterValue, "unknown spatial reference system: %s", srsName)
+	}
+	reverseAxis := false
+	if strings.HasPrefix(lowerSRSName, "urn:") ||
+		strings.HasPrefix(lowerSRSName, "http://www.opengis.net/def/crs/") {
+		if projection, err := geoprojbase.Projection(geopb.SRID(srid)); err == nil {
+			reverseAxis = projection.IsLatLng
+		}
+	}
+	return geopb.SRID(srid), reverseAxis, nil
+}
+
+func (p *gmlParser) parseGeom(n *gmlNode) (geom.T, error) {
+	if err := p.parseSRSName(n); err != nil {
+		return nil, err
+	}
+	switch n.name {
+	case "Point":
+		coords, err := p.parseCoords(n)
+		if err != nil {
+			return nil, err
+		}
+		switch len(coords) {
+		case 0:
+			return geom.NewPointEmpty(geom.XYZ), nil
+		case 3:
+			return geom.NewPointFlat(geom.XYZ, coords), nil
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: Point must have exactly one coordinate")
+		}
+	case "LineString":
+		coords, err := p.parseCoords(n)
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewLineStringFlat(geom.XYZ, coords), nil
+	case "Curve":
+		// A Curve is made up of consecutive LineStringSegments, where each
+		// segment starts at the end of the previous one.
+		var coords []float64
+		for _, segments := range n.children {
+			if segments.name != "segments" {
+				continue
+			}
+			for _, segment := range segments.children {
+				if segment.name != "LineStringSegment" {
+					return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unsupported GML curve segment: %s", segment.name)
+				}
+				segmentCoords, err := p.parseCoords(segment)
+				if err != nil {
+					return nil, err
+				}
+				if len(coords) > 0 && len(segmentCoords) > 0 {
+					segmentCoords = segmentCoords[3:]
+				}
+				coords = append(coords, segmentCoords...)
+			}
+		}
+		return geom.NewLineStringFlat(geom.XYZ, coords), nil
+	case "Polygon", "PolygonPatch":
+		return p.parsePolygon(n)
+	case "Surface":
+		var patches []*gmlNode
+		for _, c := range n.children {
+			if c.name == "patches" {
+				patches = append(patches, c.children...)
+			}
+		}
+		switch len(patches) {
+		case 0:
+			return geom.NewPolygon(geom.XYZ), nil
+		case 1:
+			return p.parseGeom(patches[0])
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "GML surfaces with multiple patches are not supported")
+		}
+	case "MultiPoint":
+		ret := geom.NewMultiPoint(geom.XYZ)
+		if err := p.parseMembers(n, []string{"pointMember"}, func(t geom.T) error {
+			pt, ok := t.(*geom.Point)
+			if !ok {
+				return pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: expected Point in MultiPoint")
+			}
+			return ret.Push(pt)
+		}); err != nil {
+			return nil, err
+		}
+		return ret, nil
+	case "MultiLineString", "MultiCurve":
+		ret := geom.NewMultiLineString(geom.XYZ)
+		if err := p.parseMembers(n, []string{"lineStringMember", "curveMember"}, func(t geom.T) error {
+			ls, ok := t.(*geom.LineString)
+			if !ok {
+				return pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: expected LineString in %s", n.name)
+			}
+			return ret.Push(ls)
+		}); err != nil {
+			return nil, err
+		}
+		return ret, nil
+	case "MultiPolygon", "MultiSurface":
+		ret := geom.NewMultiPolygon(geom.XYZ)
+		if err := p.parseMembers(n, []string{"polygonMember", "surfaceMember"}, func(t geom.T) error {
+			poly, ok := t.(*geom.Polygon)
+			if !ok {
+				return pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: expected Polygon in %s", n.name)
+			}
+			return ret.Push(poly)
+		}); err != nil {
+			return nil, err
+		}
+		return ret, nil
+	case "MultiGeometry":
+		ret := geom.NewGeometryCollection()
+		if err := p.parseMembers(n, []string{"geometryMember"}, func(t geom.T) error {
+			return ret.Push(t)
+		}); err != nil {
+			return nil, err
+		}
+		return ret, nil
+	default:
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unsupported GML geometry type: %s", n.name)
+	}
+}
+
+// parseMembers calls pushFn for each geometry contained in a member element of
+// the given multi geometry. memberNames contains the names of the elements
+// which may contain members, which may be either singular (e.g. pointMember)
+// or plural (e.g. surfaceMembers).
+func (p *gmlParser) parseMembers(
+	n *gmlNode, memberNames []string, pushFn func(t geom.T) error,
+) error {
+	for _, c := range n.children {
+		isMember := false
+		for _, memberName := range memberNames {
+			if c.name == memberName || c.name == memberName+"s" {
+				isMember = true
+				break
+			}
+		}
+		if !isMember {
+			continue
+		}
+		for _, memberNode := range c.children {
+			t, err := p.parseGeom(memberNode)
+			if err != nil {
+				return err
+			}
+			if err := pushFn(t); err != nil {
+				return pgerror.WithCandidateCode(err, pgcode.InvalidParameterValue)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *gmlParser) parsePolygon(n *gmlNode) (geom.T, error) {
+	var flatCoords []float64
+	var ends []int
+	for _, c := range n.children {
+		isExterior := c.name == "outerBoundaryIs" || c.name == "exterior"
+		isInterior := c.name == "innerBoundaryIs" || c.name == "interior"
+		if !isExterior && !isInterior {
+			continue
+		}
+		if isExterior != (len(ends) == 0) {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: Polygon must have exactly one exterior ring before any interior rings")
+		}
+		for _, ring := range c.children {
+			if ring.name != "LinearRing" {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unsupported GML ring type: %s", ring.name)
+			}
+			coords, err := p.parseCoords(ring)
+			if err != nil {
+				return nil, err
+			}
+			flatCoords = append(flatCoords, coords...)
+			ends = append(ends, len(flatCoords))
+		}
+	}
+	return geom.NewPolygonFlat(geom.XYZ, flatCoords, ends), nil
+}
+
+// parseCoords returns the flat XYZ coordinates contained in the coordinate
+// elements which are children of the given node.
+func (p *gmlParser) parseCoords(n *gmlNode) ([]float64, error) {
+	var flatCoords []float64
+	for _, c := range n.children {
+		var err error
+		switch c.name {
+		case "coordinates":
+			flatCoords, err = p.appendCoordinatesElement(flatCoords, c)
+		case "pos", "posList":
+			flatCoords, err = p.appendPosElement(flatCoords, c)
+		case "coord":
+			flatCoords, err = p.appendCoordElement(flatCoords, c)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return flatCoords, nil
+}
+
+// appendCoordinatesElement appends the contents of a GML 2 <coordinates>
+// element, e.g. "1,2 3,4".
+func (p *gmlParser) appendCoordinatesElement(flatCoords []float64, n *gmlNode) ([]float64, error) {
+	coordSep, ok := n.attr("cs")
+	if !ok {
+		coordSep = ","
+	}
+	tupleSep, ok := n.attr("ts")
+	if !ok {
+		tupleSep = " "
+	}
+	decimal, ok := n.attr("decimal")
+	if !ok {
+		decimal = "."
+	}
+	var tuples []string
+	if strings.TrimSpace(tupleSep) == "" {
+		tuples = strings.Fields(string(n.text))
+	} else {
+		tuples = strings.Split(strings.TrimSpace(string(n.text)), tupleSep)
+	}
+	for _, tuple := range tuples {
+		var coords []float64
+		for _, s := range strings.Split(strings.TrimSpace(tuple), coordSep) {
+			if decimal != "." {
+				s = strings.ReplaceAll(s, decimal, ".")
+			}
+			f, err := parseGMLFloat(s)
+			if err != nil {
+				return nil, err
+			}
+			coords = append(coords, f)
+		}
+		var err error
+		if flatCoords, err = p.appendCoord(flatCoords, coords); err != nil {
+			return nil, err
+		}
+	}
+	return flatCoords, nil
+}
+
+// appendPosElement appends the contents of a GML 3 <pos> or <posList>
+// element, e.g. "1 2 3 4".
+func (p *gmlParser) appendPosElement(flatCoords []float64, n *gmlNode) ([]float64, error) {
+	fields := strings.Fields(string(n.text))
+	dims := len(fields)
+	if n.name == "posList" {
+		dims = 2
+	}
+	dimsAttr, ok := n.attr("srsDimension")
+	if !ok {
+		dimsAttr, ok = n.attr("dimension")
+	}
+	if ok {
+		var err error
+		if dims, err = strconv.Atoi(dimsAttr); err != nil {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML dimension: %s", dimsAttr)
+		}
+	}
+	if dims != 2 && dims != 3 {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: coordinates must have 2 or 3 dimensions")
+	}
+	if len(fields)%dims != 0 {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: %s does not match its dimension", n.name)
+	}
+	for i := 0; i < len(fields); i += dims {
+		coords := make([]float64, dims)
+		for j := range coords {
+			f, err := parseGMLFloat(fields[i+j])
+			if err != nil {
+				return nil, err
+			}
+			coords[j] = f
+		}
+		var err error
+		if flatCoords, err = p.appendCoord(flatCoords, coords); err != nil {
+			return nil, err
+		}
+	}
+	return flatCoords, nil
+}
+
+// appendCoordElement appends the contents of a GML 2 <coord> element, e.g.
+// "<X>1</X><Y>2</Y>".
+func (p *gmlParser) appendCoordElement(flatCoords []float64, n *gmlNode) ([]float64, error) {
+	var coords []float64
+	for _, name := range []string{"X", "Y", "Z"} {
+		for _, c := range n.children {
+			if c.name != name {
+				continue
+			}
+			f, err := parseGMLFloat(string(c.text))
+			if err != nil {
+				return nil, err
+			}
+			coords = append(coords, f)
+		}
+	}
+	return p.appendCoord(flatCoords, coords)
+}
+
+// appendCoord appends a coordinate with 2 or 3 dimensions to flatCoords as
+// an XYZ coordinate.
+func (p *gmlParser) appendCoord(flatCoords []float64, coords []float64) ([]float64, error) {
+	switch len(coords) {
+	case 2:
+		p.hasZ = false
+		coords = append(coords, 0)
+	case 3:
+	default:
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML representation: coordinates must have 2 or 3 dimensions")
+	}
+	if p.reverseAxis {
+		coords[0], coords[1] = coords[1], coords[0]
+	}
+	p.hasCoords = true
+	return append(flatCoords, coords...), nil
+}
+
+func parseGMLFloat(s string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue, "invalid GML coordinate: %q", s)
+	}
+	return f, nil
+}
+
+// gmlForce2D drops the Z value from a geom.T which was parsed from GML.
+func gmlForce2D(t geom.T) geom.T {
+	switch t := t.(type) {
+	case *geom.Point:
+		if t.Empty() {
+			return geom.NewPointEmpty(geom.XY)
+		}
+		return geom.NewPointFlat(geom.XY, gmlForce2DFlatCoords(t.FlatCoords()))
+	case *geom.LineString:
+		return geom.NewLineStringFlat(geom.XY, gmlForce2DFlatCoords(t.FlatCoords()))
+	case *geom.Polygon:
+		return geom.NewPolygonFlat(geom.XY, gmlForce2DFlatCoords(t.FlatCoords()), gmlForce2DEnds(t.Ends()))
+	case *geom.MultiPoint:
+		return geom.NewMultiPointFlat(
+			geom.XY,
+			gmlForce2DFlatCoords(t.FlatCoords()),
+			geom.NewMultiPointFlatOptionWithEnds(gmlForce2DEnds(t.Ends())),
+		)
+	case *geom.MultiLineString:
+		return geom.NewMultiLineStringFlat(geom.XY, gmlForce2DFlatCoords(t.FlatCoords()), gmlForce2DEnds(t.Ends()))
+	case *geom.MultiPolygon:
+		endss := make([][]int, len(t.Endss()))
+		for i, ends := range t.Endss() {
+			endss[i] = gmlForce2DEnds(ends)
+		}
+		return geom.NewMultiPolygonFlat(geom.XY, gmlForce2DFlatCoords(t.FlatCoords()), endss)
+	case *geom.GeometryCollection:
+		ret := geom.NewGeometryCollection()
+		for _, subT := range t.Geoms() {
+			ret.MustPush(gmlForce2D(subT))
+		}
+		return ret
+	default:
+		return t
+	}
+}
+
+func gmlForce2DFlatCoords(flatCoords []float64) []float64 {
+	ret := make([]float64, 0, len(flatCoords)/3*2)
+	for i := 0; i < len(flatCoords); i += 3 {
+		ret = append(ret, flatCoords[i], flatCoords[i+1])
+	}
+	return ret
+}
+
+func gmlForce2DEnds(ends []int) []int {
+	ret := make([]int, len(ends))
+	for i, end := range ends {
+		ret[i] = end / 3 * 2
+	}
+	return ret
+}
@@ -0,0 +1,253 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geo
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpatialObjectToGML(t *testing.T) {
+	testCases := []struct {
+		ewkt             geopb.EWKT
+		version          GMLVersion
+		maxDecimalDigits int
+		flag             SpatialObjectToGMLFlag
+		prefix           string
+		expected         string
+	}{
+		{
+			ewkt:     "SRID=4326;POINT(1.5 2.5)",
+			version:  GMLVersion2,
+			expected: `<gml:Point srsName="EPSG:4326"><gml:coordinates>1.5,2.5</gml:coordinates></gml:Point>`,
+		},
+		{
+			ewkt:     "POINT Z (1 2 3)",
+			version:  GMLVersion3,
+			expected: `<gml:Point><gml:pos srsDimension="3">1 2 3</gml:pos></gml:Point>`,
+		},
+		{
+			ewkt:     "POINT EMPTY",
+			version:  GMLVersion2,
+			expected: `<gml:Point/>`,
+		},
+		{
+			ewkt:             "LINESTRING(0.123456 1, 2 3)",
+			version:          GMLVersion2,
+			maxDecimalDigits: 2,
+			expected:         `<gml:LineString><gml:coordinates>0.12,1 2,3</gml:coordinates></gml:LineString>`,
+		},
+		{
+			ewkt:     "LINESTRING(0 1, 2 3)",
+			version:  GMLVersion3,
+			expected: `<gml:Curve><gml:segments><gml:LineStringSegment><gml:posList srsDimension="2">0 1 2 3</gml:posList></gml:LineStringSegment></gml:segments></gml:Curve>`,
+		},
+		{
+			ewkt:     "SRID=4326;LINESTRING(0 1, 2 3)",
+			version:  GMLVersion3,
+			flag:     SpatialObjectToGMLFlagLongCRS | SpatialObjectToGMLFlagNoSRSDimension | SpatialObjectToGMLFlagShortLine | SpatialObjectToGMLFlagLatLonOrder,
+			expected: `<gml:LineString srsName="urn:ogc:def:crs:EPSG::4326"><gml:posList>1 0 3 2</gml:posList></gml:LineString>`,
+		},
+		{
+			ewkt:     "POLYGON((0 0, 1 0, 1 1, 0 0), (0.1 0.1, 0.2 0.1, 0.2 0.2, 0.1 0.1))",
+			version:  GMLVersion2,
+			expected: `<gml:Polygon><gml:outerBoundaryIs><gml:LinearRing><gml:coordinates>0,0 1,0 1,1 0,0</gml:coordinates></gml:LinearRing></gml:outerBoundaryIs><gml:innerBoundaryIs><gml:LinearRing><gml:coordinates>0.1,0.1 0.2,0.1 0.2,0.2 0.1,0.1</gml:coordinates></gml:LinearRing></gml:innerBoundaryIs></gml:Polygon>`,
+		},
+		{
+			ewkt:     "POLYGON((0 0, 1 0, 1 1, 0 0))",
+			version:  GMLVersion3,
+			prefix:   "ns",
+			expected: `<ns:Polygon><ns:exterior><ns:LinearRing><ns:posList srsDimension="2">0 0 1 0 1 1 0 0</ns:posList></ns:LinearRing></ns:exterior></ns:Polygon>`,
+		},
+		{
+			ewkt:     "MULTIPOINT(1 2, 3 4)",
+			version:  GMLVersion2,
+			expected: `<MultiPoint><pointMember><Point><coordinates>1,2</coordinates></Point></pointMember><pointMember><Point><coordinates>3,4</coordinates></Point></pointMember></MultiPoint>`,
+		},
+		{
+			ewkt:     "MULTILINESTRING((1 2, 3 4))",
+			version:  GMLVersion3,
+			flag:     SpatialObjectToGMLFlagShortLine,
+			expected: `<gml:MultiCurve><gml:curveMember><gml:LineString><gml:posList srsDimension="2">1 2 3 4</gml:posList></gml:LineString></gml:curveMember></gml:MultiCurve>`,
+		},
+		{
+			ewkt:     "MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0)))",
+			version:  GMLVersion3,
+			expected: `<gml:MultiSurface><gml:surfaceMember><gml:Polygon><gml:exterior><gml:LinearRing><gml:posList srsDimension="2">0 0 1 0 1 1 0 0</gml:posList></gml:LinearRing></gml:exterior></gml:Polygon></gml:surfaceMember></gml:MultiSurface>`,
+		},
+		{
+			ewkt:     "SRID=3857;GEOMETRYCOLLECTION(POINT(1 2), LINESTRING EMPTY)",
+			version:  GMLVersion2,
+			expected: `<gml:MultiGeometry srsName="EPSG:3857"><gml:geometryMember><gml:Point><gml:coordinates>1,2</gml:coordinates></gml:Point></gml:geometryMember><gml:geometryMember><gml:LineString/></gml:geometryMember></gml:MultiGeometry>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.ewkt), func(t *testing.T) {
+			so, err := parseEWKT(geopb.SpatialObjectType_GeometryType, tc.ewkt, geopb.DefaultGeometrySRID, DefaultSRIDIsHint)
+			require.NoError(t, err)
+			maxDecimalDigits := tc.maxDecimalDigits
+			if maxDecimalDigits == 0 {
+				maxDecimalDigits = DefaultGMLDecimalDigits
+			}
+			prefix := tc.prefix
+			if prefix == "" && tc.expected[1:4] == "gml" {
+				prefix = DefaultGMLNamespacePrefix
+			}
+			encoded, err := SpatialObjectToGML(so, tc.version, maxDecimalDigits, tc.flag, prefix)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, encoded)
+
+			// Check that the GML can be parsed back into the same geometry.
+			parsed, err := parseGML(geopb.SpatialObjectType_GeometryType, []byte(encoded), 0 /* defaultSRID */)
+			require.NoError(t, err)
+			if tc.maxDecimalDigits == 0 {
+				require.Equal(t, so, parsed)
+			}
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		so, err := parseEWKT(geopb.SpatialObjectType_GeometryType, "POINT(1 2)", geopb.DefaultGeometrySRID, DefaultSRIDIsHint)
+		require.NoError(t, err)
+		_, err = SpatialObjectToGML(so, 4, DefaultGMLDecimalDigits, SpatialObjectToGMLFlagZero, DefaultGMLNamespacePrefix)
+		require.EqualError(t, err, "only GML 2 and GML 3 are supported")
+		_, err = SpatialObjectToGML(so, GMLVersion3, DefaultGMLDecimalDigits, SpatialObjectToGMLFlagBoundingBox, DefaultGMLNamespacePrefix)
+		require.EqualError(t, err, "outputting the bounding box as GML is not yet supported")
+	})
+}
+
+func TestParseGML(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		gml         string
+		defaultSRID geopb.SRID
+		expected    geopb.EWKT
+	}{
+		{
+			desc:     "GML 2 point with SRID",
+			gml:      `<gml:Point srsName="EPSG:4326"><gml:coordinates>1,2</gml:coordinates></gml:Point>`,
+			expected: "SRID=4326;POINT(1 2)",
+		},
+		{
+			desc:        "SRID overrides srsName",
+			gml:         `<gml:Point srsName="EPSG:4326"><gml:coordinates>1,2</gml:coordinates></gml:Point>`,
+			defaultSRID: 3857,
+			expected:    "SRID=3857;POINT(1 2)",
+		},
+		{
+			desc:     "GML 2 coord",
+			gml:      `<Point><coord><X>1</X><Y>2</Y><Z>3</Z></coord></Point>`,
+			expected: "POINT Z (1 2 3)",
+		},
+		{
+			desc:     "coordinates with custom separators",
+			gml:      `<gml:LineString><gml:coordinates cs=";" ts="|" decimal=",">1,5;2|3;4,5</gml:coordinates></gml:LineString>`,
+			expected: "LINESTRING(1.5 2, 3 4.5)",
+		},
+		{
+			desc:     "mixed dimensions are forced to 2D",
+			gml:      `<gml:LineString><gml:pos>1 2 3</gml:pos><gml:pos>4 5</gml:pos></gml:LineString>`,
+			expected: "LINESTRING(1 2, 4 5)",
+		},
+		{
+			desc:     "posList with dimension",
+			gml:      `<gml:LineString><gml:posList srsDimension="3">1 2 3 4 5 6</gml:posList></gml:LineString>`,
+			expected: "LINESTRING Z (1 2 3, 4 5 6)",
+		},
+		{
+			desc:     "curve with multiple segments",
+			gml:      `<gml:Curve><gml:segments><gml:LineStringSegment><gml:posList>0 0 1 1</gml:posList></gml:LineStringSegment><gml:LineStringSegment><gml:posList>1 1 2 0</gml:posList></gml:LineStringSegment></gml:segments></gml:Curve>`,
+			expected: "LINESTRING(0 0, 1 1, 2 0)",
+		},
+		{
+			desc:     "URN srsName uses lat/lon axis order",
+			gml:      `<gml:Point srsName="urn:ogc:def:crs:EPSG::4326"><gml:pos>1 2</gml:pos></gml:Point>`,
+			expected: "SRID=4326;POINT(2 1)",
+		},
+		{
+			desc:     "URN srsName of a projected SRS",
+			gml:      `<gml:Point srsName="urn:ogc:def:crs:EPSG::3857"><gml:pos>1 2</gml:pos></gml:Point>`,
+			expected: "SRID=3857;POINT(1 2)",
+		},
+		{
+			desc:     "surface",
+			gml:      `<gml:Surface><gml:patches><gml:PolygonPatch><gml:exterior><gml:LinearRing><gml:posList>0 0 1 0 1 1 0 0</gml:posList></gml:LinearRing></gml:exterior></gml:PolygonPatch></gml:patches></gml:Surface>`,
+			expected: "POLYGON((0 0, 1 0, 1 1, 0 0))",
+		},
+		{
+			desc:     "plural members",
+			gml:      `<gml:MultiPoint><gml:pointMembers><gml:Point><gml:pos>1 2</gml:pos></gml:Point><gml:Point><gml:pos>3 4</gml:pos></gml:Point></gml:pointMembers></gml:MultiPoint>`,
+			expected: "MULTIPOINT(1 2, 3 4)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			so, err := parseGML(geopb.SpatialObjectType_GeometryType, []byte(tc.gml), tc.defaultSRID)
+			require.NoError(t, err)
+			expected, err := parseEWKT(geopb.SpatialObjectType_GeometryType, tc.expected, geopb.DefaultGeometrySRID, DefaultSRIDIsHint)
+			require.NoError(t, err)
+			require.Equal(t, expected, so)
+		})
+	}
+
+	errorTestCases := []struct {
+		gml         string
+		expectedErr string
+	}{
+		{
+			gml:         ``,
+			expectedErr: "invalid GML representation",
+		},
+		{
+			gml:         `<gml:Point><gml:coordinates>1,2</gml:Point>`,
+			expectedErr: "error parsing GML: XML syntax error on line 1: element <coordinates> closed by </Point>",
+		},
+		{
+			gml:         `<gml:Box><gml:coordinates>1,2 3,4</gml:coordinates></gml:Box>`,
+			expectedErr: "unsupported GML geometry type: Box",
+		},
+		{
+			gml:         `<gml:Point><gml:coordinates>1,2 3,4</gml:coordinates></gml:Point>`,
+			expectedErr: "invalid GML representation: Point must have exactly one coordinate",
+		},
+		{
+			gml:         `<gml:Point><gml:coordinates>1,a</gml:coordinates></gml:Point>`,
+			expectedErr: `invalid GML coordinate: "a"`,
+		},
+		{
+			gml:         `<gml:Point srsName="foo"><gml:coordinates>1,2</gml:coordinates></gml:Point>`,
+			expectedErr: "unknown spatial reference system: foo",
+		},
+		{
+			gml:         `<gml:MultiPoint srsName="EPSG:4326"><gml:pointMember><gml:Point srsName="EPSG:3857"><gml:pos>1 2</gml:pos></gml:Point></gml:pointMember></gml:MultiPoint>`,
+			expectedErr: "GML geometries with mixed SRIDs are not supported",
+		},
+		{
+			gml:         `<gml:MultiPoint><gml:pointMember><gml:LineString><gml:posList>1 2 3 4</gml:posList></gml:LineString></gml:pointMember></gml:MultiPoint>`,
+			expectedErr: "invalid GML representation: expected Point in MultiPoint",
+		},
+		{
+			gml:         `<gml:LineString><gml:posList>1 2 3</gml:posList></gml:LineString>`,
+			expectedErr: "invalid GML representation: posList does not match its dimension",
+		},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run(tc.gml, func(t *testing.T) {
+			_, err := parseGML(geopb.SpatialObjectType_GeometryType, []byte(tc.gml), 0 /* defaultSRID */)
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
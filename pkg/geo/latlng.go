@@ -10,7 +10,14 @@
 
 package geo
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
 
 // NormalizeLatitudeDegrees normalizes latitudes to the range [-90, 90].
 func NormalizeLatitudeDegrees(lat float64) float64 {
@@ -32,3 +39,183 @@ func NormalizeLongitudeDegrees(lng float64) float64 {
 	// math.Remainder(lng, 360) returns in the range [-180, 180].
 	return math.Remainder(lng, 360)
 }
+
+// normalizeLatLngDegreesPair normalizes a latitude and longitude to the ranges
+// [-90, 90] and [-180, 180] respectively. Unlike NormalizeLatitudeDegrees,
+// crossing a pole also moves the longitude to the other side of the globe.
+func normalizeLatLngDegreesPair(lat float64, lng float64) (float64, float64) {
+	lat = math.Remainder(lat, 360)
+	if lat > 90 {
+		lat = 180 - lat
+		lng += 180
+	} else if lat < -90 {
+		lat = -180 - lat
+		lng += 180
+	}
+	return lat, NormalizeLongitudeDegrees(lng)
+}
+
+// degreesToLatLonText formats the given number of degrees according to the
+// format used by ST_AsLatLonText in PostGIS.
+//
+// The format consists of the following characters:
+//   - D, M and S are replaced by degrees, minutes and seconds respectively.
+//     Repeating the character sets the minimum width of the number, and a
+//     decimal point followed by more characters sets the number of decimal
+//     digits, which is only allowed on the last of degrees, minutes or seconds.
+//   - C is replaced by the compass direction (posDir or negDir). If omitted,
+//     negative values are prefixed with a minus sign instead.
+//   - Any other character is copied as is.
+func degreesToLatLonText(val float64, posDir string, negDir string, format string) (string, error) {
+	const (
+		readingNone = iota
+		readingDeg
+		readingMin
+		readingSec
+	)
+	// unit is a number within the format, e.g. DD.DDD.
+	type unit struct {
+		piece       int
+		digits      int
+		decDigits   int
+		hasDecPoint bool
+	}
+	units := [...]unit{
+		readingDeg: {piece: -1},
+		readingMin: {piece: -1},
+		readingSec: {piece: -1},
+	}
+	unitNames := [...]string{
+		readingDeg: "degrees (DD.DDD)",
+		readingMin: "minutes (MM.MMM)",
+		readingSec: "seconds (SS.SSS)",
+	}
+	pieces := []string{""}
+	compassDirPiece := -1
+	reading := readingNone
+	nextPiece := func() {
+		pieces = append(pieces, "")
+	}
+
+	for _, c := range format {
+		var unitIdx int
+		switch c {
+		case 'D':
+			unitIdx = readingDeg
+		case 'M':
+			unitIdx = readingMin
+		case 'S':
+			unitIdx = readingSec
+		case 'C':
+			reading = readingNone
+			if compassDirPiece >= 0 {
+				return "", pgerror.Newf(pgcode.InvalidParameterValue, "bad format, cannot include compass dir (C) more than once")
+			}
+			nextPiece()
+			compassDirPiece = len(pieces) - 1
+			nextPiece()
+			continue
+		case '.':
+			if reading != readingNone {
+				if units[reading].hasDecPoint {
+					return "", pgerror.Newf(pgcode.InvalidParameterValue, "bad format, %s cannot have more than one decimal point", unitNames[reading])
+				}
+				units[reading].hasDecPoint = true
+				continue
+			}
+			pieces[len(pieces)-1] += string(c)
+			continue
+		default:
+			if reading != readingNone {
+				nextPiece()
+				reading = readingNone
+			}
+			pieces[len(pieces)-1] += string(c)
+			continue
+		}
+
+		if reading == unitIdx {
+			if units[unitIdx].hasDecPoint {
+				units[unitIdx].decDigits++
+			} else {
+				units[unitIdx].digits++
+			}
+			continue
+		}
+		// We are starting to read a new unit.
+		if units[unitIdx].digits > 0 {
+			return "", pgerror.Newf(pgcode.InvalidParameterValue, "bad format, cannot include %s more than once", unitNames[unitIdx])
+		}
+		for laterUnitIdx := unitIdx + 1; laterUnitIdx <= readingSec; laterUnitIdx++ {
+			if units[laterUnitIdx].digits > 0 {
+				return "", pgerror.Newf(
+					pgcode.InvalidParameterValue,
+					"bad format, cannot include %s before %s",
+					unitNames[laterUnitIdx],
+					unitNames[unitIdx],
+				)
+			}
+		}
+		if compassDirPiece >= 0 {
+			return "", pgerror.Newf(pgcode.InvalidParameterValue, "bad format, cannot include %s after compass dir (C)", unitNames[unitIdx])
+		}
+		nextPiece()
+		units[unitIdx].piece = len(pieces) - 1
+		units[unitIdx].digits++
+		reading = unitIdx
+	}
+
+	deg, min, sec := &units[readingDeg], &units[readingMin], &units[readingSec]
+	if deg.digits == 0 {
+		return "", pgerror.Newf(pgcode.InvalidParameterValue, "bad format, must include degrees (DD.DDD)")
+	}
+	if sec.digits > 0 {
+		if min.digits == 0 {
+			return "", pgerror.Newf(pgcode.InvalidParameterValue, "bad format, cannot include seconds (SS.SSS) without including minutes (MM.MMM)")
+		}
+		if deg.hasDecPoint || min.hasDecPoint {
+			return "", pgerror.Newf(pgcode.InvalidParameterValue, "bad format, only the seconds (SS.SSS) can have a decimal part")
+		}
+	} else if min.digits > 0 && deg.hasDecPoint {
+		return "", pgerror.Newf(pgcode.InvalidParameterValue, "bad format, only the minutes (MM.MMM) can have a decimal part")
+	}
+
+	dir := posDir
+	isNegative := val < 0
+	if isNegative {
+		val = -val
+		dir = negDir
+	}
+	degrees, minutes, seconds := val, 0.0, 0.0
+	if min.digits > 0 {
+		degrees = math.Floor(val)
+		minutes = (val - degrees) * 60
+	}
+	if sec.digits > 0 {
+		minutes = math.Floor(minutes)
+		seconds = (val - degrees - minutes/60) * 3600
+		// Carry over any seconds which round up to a whole minute.
+		if roundToDecimalDigits(seconds, sec.decDigits) >= 60 {
+			seconds = 0
+			minutes++
+		}
+	}
+	if min.digits > 0 && roundToDecimalDigits(minutes, min.decDigits) >= 60 {
+		minutes = 0
+		degrees++
+	}
+
+	if compassDirPiece >= 0 {
+		pieces[compassDirPiece] = dir
+	} else if isNegative {
+		degrees = -degrees
+	}
+	pieces[deg.piece] = fmt.Sprintf("%*.*f", deg.digits, deg.decDigits, degrees)
+	if min.piece >= 0 {
+		pieces[min.piece] = fmt.Sprintf("%*.*f", min.digits, min.decDigits, minutes)
+	}
+	if sec.piece >= 0 {
+		pieces[sec.piece] = fmt.Sprintf("%*.*f", sec.digits, sec.decDigits, seconds)
+	}
+	return strings.Join(pieces, ""), nil
+}
@@ -8,12 +8,13 @@
 // by the Apache License, Version 2.0, included in the file
 // licenses/APL.txt.
 
-// Package twkb implements the Tiny Well-known Binary (TWKB) encoding
-// as described in https://github.com/TWKB/Specification/blob/master/twkb.md.
+// Package twkb implements encoding and decoding of the Tiny Well-known Binary
+// (TWKB) format as described in https://github.com/TWKB/Specification/blob/master/twkb.md.
 package twkb
 
 import (
 	"bytes"
+	"encoding/binary"
 	"math"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -362,3 +363,314 @@ func zigzagInt8(x int8) byte {
 	}
 	return uint8(x) << 1
 }
+
+type unmarshaller struct {
+	*bytes.Reader
+	layout geom.Layout
+	// scales contains the divisor to apply to each decoded coordinate,
+	// indexed by its offset within a point.
+	scales []float64
+	// prevCoords keeps track of the previously read coordinates, as each
+	// coordinate is encoded as a delta of the coordinate before it.
+	prevCoords []int64
+}
+
+// Unmarshal converts a TWKB encoded byte array into a geom.T.
+// The returned geometry has no SRID set.
+func Unmarshal(b []byte) (geom.T, error) {
+	r := bytes.NewReader(b)
+	t, err := unmarshal(r)
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() > 0 {
+		return nil, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"unexpected %d bytes after TWKB geometry",
+			r.Len(),
+		)
+	}
+	return t, nil
+}
+
+func unmarshal(r *bytes.Reader) (geom.T, error) {
+	typeAndPrecisionHeader, err := readTWKBByte(r)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := readTWKBByte(r)
+	if err != nil {
+		return nil, err
+	}
+	typ := twkbType(typeAndPrecisionHeader & 0x0F)
+	precisionXY := unzigzagInt8(typeAndPrecisionHeader >> 4)
+	var precisionZ, precisionM int8
+	layout := geom.XY
+	if metadata&0b1000 != 0 {
+		extDimByte, err := readTWKBByte(r)
+		if err != nil {
+			return nil, err
+		}
+		switch extDimByte & 0b11 {
+		case 0b1:
+			layout = geom.XYZ
+		case 0b10:
+			layout = geom.XYM
+		case 0b11:
+			layout = geom.XYZM
+		}
+		precisionZ = int8((extDimByte >> 2) & 0b111)
+		precisionM = int8((extDimByte >> 5) & 0b111)
+	}
+
+	u := unmarshaller{
+		Reader:     r,
+		layout:     layout,
+		scales:     make([]float64, layout.Stride()),
+		prevCoords: make([]int64, layout.Stride()),
+	}
+	for i := range u.scales {
+		precision := precisionXY
+		if i == layout.ZIndex() {
+			precision = precisionZ
+		}
+		if i == layout.MIndex() {
+			precision = precisionM
+		}
+		u.scales[i] = math.Pow(10, float64(precision))
+	}
+
+	// The size and bounding box are not needed to decode the geometry, so they
+	// are skipped.
+	if metadata&0b10 != 0 {
+		size, err := u.readUvarint()
+		if err != nil {
+			return nil, err
+		}
+		if size > uint64(r.Len()) {
+			return nil, errTruncatedTWKB
+		}
+	}
+	if metadata&0b1 != 0 {
+		for i := 0; i < 2*layout.Stride(); i++ {
+			if _, err := u.readVarint(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	hasIDList := metadata&0b100 != 0
+	isEmpty := metadata&0b10000 != 0
+
+	switch typ {
+	case twkbTypePoint:
+		if isEmpty {
+			return geom.NewPointEmpty(layout), nil
+		}
+		flatCoords, err := u.readFlatCoords(1)
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewPointFlat(layout, flatCoords), nil
+	case twkbTypeLineString:
+		if isEmpty {
+			return geom.NewLineString(layout), nil
+		}
+		flatCoords, err := u.readFlatCoordsWithLen()
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewLineStringFlat(layout, flatCoords), nil
+	case twkbTypePolygon:
+		if isEmpty {
+			return geom.NewPolygon(layout), nil
+		}
+		flatCoords, ends, err := u.readGeomWithEnds(nil /* flatCoords */)
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewPolygonFlat(layout, flatCoords, ends), nil
+	case twkbTypeMultiPoint:
+		if isEmpty {
+			return geom.NewMultiPoint(layout), nil
+		}
+		n, err := u.readNumElements(hasIDList)
+		if err != nil {
+			return nil, err
+		}
+		flatCoords, err := u.readFlatCoords(n)
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewMultiPointFlat(layout, flatCoords), nil
+	case twkbTypeMultiLineString:
+		if isEmpty {
+			return geom.NewMultiLineString(layout), nil
+		}
+		n, err := u.readNumElements(hasIDList)
+		if err != nil {
+			return nil, err
+		}
+		var flatCoords []float64
+		ends := make([]int, 0, n)
+		for i := 0; i < n; i++ {
+			if flatCoords, err = u.appendFlatCoordsWithLen(flatCoords); err != nil {
+				return nil, err
+			}
+			ends = append(ends, len(flatCoords))
+		}
+		return geom.NewMultiLineStringFlat(layout, flatCoords, ends), nil
+	case twkbTypeMultiPolygon:
+		if isEmpty {
+			return geom.NewMultiPolygon(layout), nil
+		}
+		n, err := u.readNumElements(hasIDList)
+		if err != nil {
+			return nil, err
+		}
+		var flatCoords []float64
+		endss := make([][]int, 0, n)
+		for i := 0; i < n; i++ {
+			var ends []int
+			if flatCoords, ends, err = u.readGeomWithEnds(flatCoords); err != nil {
+				return nil, err
+			}
+			endss = append(endss, ends)
+		}
+		return geom.NewMultiPolygonFlat(layout, flatCoords, endss), nil
+	case twkbTypeGeometryCollection:
+		gc := geom.NewGeometryCollection()
+		if isEmpty {
+			return gc, nil
+		}
+		n, err := u.readNumElements(hasIDList)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < n; i++ {
+			// Each geometry in the collection is a TWKB in its own right.
+			subT, err := unmarshal(r)
+			if err != nil {
+				return nil, err
+			}
+			if err := gc.Push(subT); err != nil {
+				return nil, pgerror.WithCandidateCode(err, pgcode.InvalidParameterValue)
+			}
+		}
+		return gc, nil
+	default:
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unknown TWKB type: %d", typ)
+	}
+}
+
+var errTruncatedTWKB = pgerror.Newf(pgcode.InvalidParameterValue, "TWKB input is truncated")
+
+// readNumElements reads the number of elements in a multi geometry, skipping
+// over the id list if one is present.
+func (u *unmarshaller) readNumElements(hasIDList bool) (int, error) {
+	n, err := u.readLen()
+	if err != nil {
+		return 0, err
+	}
+	if hasIDList {
+		for i := 0; i < n; i++ {
+			if _, err := u.readVarint(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// readGeomWithEnds reads a number of rings, each prefixed by its length, and
+// appends them to flatCoords.
+func (u *unmarshaller) readGeomWithEnds(flatCoords []float64) ([]float64, []int, error) {
+	n, err := u.readLen()
+	if err != nil {
+		return nil, nil, err
+	}
+	ends := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		if flatCoords, err = u.appendFlatCoordsWithLen(flatCoords); err != nil {
+			return nil, nil, err
+		}
+		ends = append(ends, len(flatCoords))
+	}
+	return flatCoords, ends, nil
+}
+
+func (u *unmarshaller) readFlatCoordsWithLen() ([]float64, error) {
+	return u.appendFlatCoordsWithLen(nil /* flatCoords */)
+}
+
+func (u *unmarshaller) appendFlatCoordsWithLen(flatCoords []float64) ([]float64, error) {
+	n, err := u.readLen()
+	if err != nil {
+		return nil, err
+	}
+	coords, err := u.readFlatCoords(n)
+	if err != nil {
+		return nil, err
+	}
+	return append(flatCoords, coords...), nil
+}
+
+// readFlatCoords reads numPoints points from the buffer.
+func (u *unmarshaller) readFlatCoords(numPoints int) ([]float64, error) {
+	stride := u.layout.Stride()
+	flatCoords := make([]float64, numPoints*stride)
+	for i := range flatCoords {
+		delta, err := u.readVarint()
+		if err != nil {
+			return nil, err
+		}
+		u.prevCoords[i%stride] += delta
+		flatCoords[i] = float64(u.prevCoords[i%stride]) / u.scales[i%stride]
+	}
+	return flatCoords, nil
+}
+
+// readLen reads the number of elements that follow, ensuring that the input
+// is long enough to contain them.
+func (u *unmarshaller) readLen() (int, error) {
+	n, err := u.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	// Every element takes up at least one byte.
+	if n > uint64(u.Len()) {
+		return 0, errTruncatedTWKB
+	}
+	return int(n), nil
+}
+
+func (u *unmarshaller) readVarint() (int64, error) {
+	ux, err := u.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	x := int64(ux >> 1)
+	if ux&1 != 0 {
+		x = ^x
+	}
+	return x, nil
+}
+
+func (u *unmarshaller) readUvarint() (uint64, error) {
+	x, err := binary.ReadUvarint(u)
+	if err != nil {
+		return 0, errTruncatedTWKB
+	}
+	return x, nil
+}
+
+func readTWKBByte(r *bytes.Reader) (byte, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, errTruncatedTWKB
+	}
+	return b, nil
+}
+
+func unzigzagInt8(x byte) int8 {
+	return int8(x>>1) ^ -int8(x&1)
+}
@@ -380,6 +380,141 @@ func TestMarshal(t *testing.T) {
 	}
 }
 
+func TestUnmarshal(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		b        []byte
+		expected geom.T
+	}{
+		{
+			desc:     "empty point",
+			b:        mustDecodeHex("0110"),
+			expected: geom.NewPointEmpty(geom.XY),
+		},
+		{
+			desc:     "point",
+			b:        mustDecodeHex("01000406"),
+			expected: geom.NewPointFlat(geom.XY, []float64{2, 3}),
+		},
+		{
+			desc:     "linestring with size and bounding box",
+			b:        mustDecodeHex("020309020802080202020808"),
+			expected: geom.NewLineStringFlat(geom.XY, []float64{1, 1, 5, 5}),
+		},
+		{
+			desc:     "multipoint with id list",
+			b:        mustDecodeHex("040402142802040404"),
+			expected: geom.NewMultiPointFlat(geom.XY, []float64{1, 2, 3, 4}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ret, err := Unmarshal(tc.b)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, ret)
+		})
+	}
+
+	roundTripTestCases := []struct {
+		desc string
+		t    geom.T
+		opts []MarshalOption
+	}{
+		{
+			desc: "linestring with precision",
+			t:    geom.NewLineStringFlat(geom.XY, []float64{1.5, 2.25, -3.75, 4}),
+			opts: []MarshalOption{MarshalOptionPrecisionXY(2)},
+		},
+		{
+			desc: "linestring with negative precision",
+			t:    geom.NewLineStringFlat(geom.XY, []float64{100, 200, 300, 400}),
+			opts: []MarshalOption{MarshalOptionPrecisionXY(-2)},
+		},
+		{
+			desc: "polygon with holes",
+			t: geom.NewPolygonFlat(
+				geom.XY,
+				[]float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0, 2, 2, 2, 4, 4, 4, 2, 2},
+				[]int{10, 18},
+			),
+		},
+		{
+			desc: "XYZM multilinestring",
+			t: geom.NewMultiLineStringFlat(
+				geom.XYZM,
+				[]float64{1, 2, 3.5, 4.25, 5, 6, 7.5, 8.25, 9, 10, 11, 12},
+				[]int{8, 12},
+			),
+			opts: []MarshalOption{MarshalOptionPrecisionZ(1), MarshalOptionPrecisionM(2)},
+		},
+		{
+			desc: "XYM multipolygon",
+			t: geom.NewMultiPolygonFlat(
+				geom.XYM,
+				[]float64{0, 0, 1, 1, 0, 1, 1, 1, 1, 0, 0, 1, 5, 5, 2, 6, 5, 2, 6, 6, 2, 5, 5, 2},
+				[][]int{{12}, {24}},
+			),
+		},
+		{
+			desc: "geometry collection",
+			t: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+				geom.NewLineStringFlat(geom.XY, []float64{3, 4, 5, 6}),
+				geom.NewPolygon(geom.XY),
+			),
+		},
+		{
+			desc: "empty geometry collection",
+			t:    geom.NewGeometryCollection(),
+		},
+	}
+
+	for _, tc := range roundTripTestCases {
+		t.Run("round trip "+tc.desc, func(t *testing.T) {
+			b, err := Marshal(tc.t, tc.opts...)
+			require.NoError(t, err)
+			ret, err := Unmarshal(b)
+			require.NoError(t, err)
+			require.Equal(t, tc.t, ret)
+		})
+	}
+
+	errorTestCases := []struct {
+		desc                string
+		b                   []byte
+		expectedErrorString string
+	}{
+		{
+			desc:                "empty input",
+			b:                   []byte{},
+			expectedErrorString: "TWKB input is truncated",
+		},
+		{
+			desc:                "truncated linestring",
+			b:                   mustDecodeHex("02000402"),
+			expectedErrorString: "TWKB input is truncated",
+		},
+		{
+			desc:                "unknown type",
+			b:                   mustDecodeHex("0910"),
+			expectedErrorString: "unknown TWKB type: 9",
+		},
+		{
+			desc:                "trailing bytes",
+			b:                   mustDecodeHex("0110ff"),
+			expectedErrorString: "unexpected 1 bytes after TWKB geometry",
+		},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := Unmarshal(tc.b)
+			require.EqualError(t, err, tc.expectedErrorString)
+		})
+	}
+}
+
 func mustDecodeHex(h string) []byte {
 	ret, err := hex.DecodeString(h)
 	if err != nil {
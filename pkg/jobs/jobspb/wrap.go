@@ -47,6 +47,7 @@ var (
 	_ Details = StreamReplicationDetails{}
 	_ Details = RowLevelTTLDetails{}
 	_ Details = SchemaTelemetryDetails{}
+	_ Details = MaterializedViewMaintenanceDetails{}
 )
 
 // ProgressDetails is a marker interface for job progress details proto structs.
@@ -66,6 +67,7 @@ var (
 	_ ProgressDetails = StreamReplicationProgress{}
 	_ ProgressDetails = RowLevelTTLProgress{}
 	_ ProgressDetails = SchemaTelemetryProgress{}
+	_ ProgressDetails = MaterializedViewMaintenanceProgress{}
 )
 
 // Type returns the payload's job type and panics if the type is invalid.
@@ -151,6 +153,8 @@ func DetailsType(d isPayload_Details) (Type, error) {
 		return TypeRowLevelTTL, nil
 	case *Payload_SchemaTelemetry:
 		return TypeAutoSchemaTelemetry, nil
+	case *Payload_MaterializedViewMaintenance:
+		return TypeMaterializedViewMaintenance, nil
 	default:
 		return TypeUnspecified, errors.Newf("Payload.Type called on a payload with an unknown details type: %T", d)
 	}
@@ -189,6 +193,7 @@ var JobDetailsForEveryJobType = map[Type]Details{
 	TypeStreamReplication:            StreamReplicationDetails{},
 	TypeRowLevelTTL:                  RowLevelTTLDetails{},
 	TypeAutoSchemaTelemetry:          SchemaTelemetryDetails{},
+	TypeMaterializedViewMaintenance:  MaterializedViewMaintenanceDetails{},
 }
 
 // WrapProgressDetails wraps a ProgressDetails object in the protobuf wrapper
@@ -232,6 +237,8 @@ func WrapProgressDetails(details ProgressDetails) interface {
 		return &Progress_RowLevelTTL{RowLevelTTL: &d}
 	case SchemaTelemetryProgress:
 		return &Progress_SchemaTelemetry{SchemaTelemetry: &d}
+	case MaterializedViewMaintenanceProgress:
+		return &Progress_MaterializedViewMaintenance{MaterializedViewMaintenance: &d}
 	default:
 		panic(errors.AssertionFailedf("WrapProgressDetails: unknown progress type %T", d))
 	}
@@ -273,6 +280,8 @@ func (p *Payload) UnwrapDetails() Details {
 		return *d.RowLevelTTL
 	case *Payload_SchemaTelemetry:
 		return *d.SchemaTelemetry
+	case *Payload_MaterializedViewMaintenance:
+		return *d.MaterializedViewMaintenance
 	default:
 		return nil
 	}
@@ -314,6 +323,8 @@ func (p *Progress) UnwrapDetails() ProgressDetails {
 		return *d.RowLevelTTL
 	case *Progress_SchemaTelemetry:
 		return *d.SchemaTelemetry
+	case *Progress_MaterializedViewMaintenance:
+		return *d.MaterializedViewMaintenance
 	default:
 		return nil
 	}
@@ -368,6 +379,8 @@ func WrapPayloadDetails(details Details) interface {
 		return &Payload_RowLevelTTL{RowLevelTTL: &d}
 	case SchemaTelemetryDetails:
 		return &Payload_SchemaTelemetry{SchemaTelemetry: &d}
+	case MaterializedViewMaintenanceDetails:
+		return &Payload_MaterializedViewMaintenance{MaterializedViewMaintenance: &d}
 	default:
 		panic(errors.AssertionFailedf("jobs.WrapPayloadDetails: unknown details type %T", d))
 	}
@@ -403,7 +416,7 @@ const (
 func (Type) SafeValue() {}
 
 // NumJobTypes is the number of jobs types.
-const NumJobTypes = 18
+const NumJobTypes = 19
 
 // ChangefeedDetailsMarshaler allows for dependency injection of
 // cloud.SanitizeExternalStorageURI to avoid the dependency from this
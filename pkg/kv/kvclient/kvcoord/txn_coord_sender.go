@@ -15,6 +15,7 @@ import (
 	"runtime/debug"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
@@ -770,7 +771,9 @@ func (tc *TxnCoordSender) UpdateStateOnRemoteRetryableErr(
 // the TxnCoordSender's state. Depending on the error, the TxnCoordSender might
 // not be usable afterwards (in case of TransactionAbortedError). The caller is
 // expected to check the ID of the resulting transaction. If the TxnCoordSender
-// can still be used, it will have been prepared for a new epoch.
+// can still be used, it will have been prepared for a new epoch or, for
+// transactions that can retry a single statement (see
+// roachpb.CanRetryStatement), for a retry of the current statement.
 func (tc *TxnCoordSender) handleRetryableErrLocked(
 	ctx context.Context, pErr *roachpb.Error,
 ) *roachpb.TransactionRetryWithProtoRefreshError {
@@ -809,6 +812,7 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 		tc.metrics.RestartsUnknown.Inc()
 	}
 	errTxnID := pErr.GetTxn().ID
+	statementRetry := roachpb.CanRetryStatement(pErr, pErr.GetTxn())
 	newTxn := roachpb.PrepareTransactionForRetry(ctx, pErr, tc.mu.userPriority, tc.clock)
 
 	// We'll pass a TransactionRetryWithProtoRefreshError up to the next layer.
@@ -816,6 +820,7 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 		redact.Sprint(pErr),
 		errTxnID, // the id of the transaction that encountered the error
 		newTxn)
+	retErr.StatementRetry = statementRetry
 
 	// Move to a retryable error state, where all Send() calls fail until the
 	// state is cleared.
@@ -836,9 +841,18 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 		return retErr
 	}
 
-	// This is where we get a new epoch.
+	// This is where we get a new epoch, unless only the current statement is
+	// retried.
 	tc.mu.txn.Update(&newTxn)
 
+	if statementRetry {
+		// The transaction keeps its epoch, so the epoch-based state remains
+		// valid. The client rolls back to a savepoint taken at the start of the
+		// statement, which restores the interceptors' state.
+		log.VEventf(ctx, 2, "preparing txn for a retry of the current statement")
+		return retErr
+	}
+
 	// Reset state as this is a retryable txn error that is incrementing
 	// the transaction's epoch.
 	log.VEventf(ctx, 2, "resetting epoch-based coordinator state on retry")
@@ -997,6 +1011,27 @@ func (tc *TxnCoordSender) SetUserPriority(pri roachpb.UserPriority) error {
 	return nil
 }
 
+// SetIsoLevel is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) SetIsoLevel(isoLevel isolation.Level) error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if tc.mu.txn.IsoLevel == isoLevel {
+		return nil
+	}
+	if tc.mu.active {
+		return errors.New("cannot change the isolation level of a running transaction")
+	}
+	tc.mu.txn.IsoLevel = isoLevel
+	return nil
+}
+
+// IsoLevel is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) IsoLevel() isolation.Level {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return tc.mu.txn.IsoLevel
+}
+
 // SetDebugName is part of the client.TxnSender interface.
 func (tc *TxnCoordSender) SetDebugName(name string) {
 	tc.mu.Lock()
@@ -1111,6 +1146,10 @@ func (tc *TxnCoordSender) IsSerializablePushAndRefreshNotPossible() bool {
 	tc.mu.Lock()
 	defer tc.mu.Unlock()
 
+	if tc.mu.txn.IsoLevel.ToleratesWriteSkew() {
+		// The transaction can commit at its pushed timestamp.
+		return false
+	}
 	isTxnPushed := tc.mu.txn.WriteTimestamp != tc.mu.txn.ReadTimestamp
 	refreshAttemptNotPossible := tc.interceptorAlloc.txnSpanRefresher.refreshInvalid ||
 		tc.mu.txn.CommitTimestampFixed
@@ -1292,6 +1331,27 @@ func (tc *TxnCoordSender) Step(ctx context.Context) error {
 	return tc.interceptorAlloc.txnSeqNumAllocator.stepLocked(ctx)
 }
 
+// StepReadTimestamp is part of the TxnSender interface.
+func (tc *TxnCoordSender) StepReadTimestamp(ctx context.Context) error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if !tc.mu.txn.IsoLevel.PerStatementReadSnapshot() || tc.mu.txn.CommitTimestampFixed {
+		return nil
+	}
+	if tc.mu.txnState != txnPending {
+		// The next request will return the transaction's error.
+		return nil
+	}
+	now := tc.clock.Now()
+	tc.mu.txn.BumpReadTimestamp(now)
+	// The new read snapshot gets a new uncertainty interval. Observed
+	// timestamps were taken before the snapshot and would shrink it.
+	tc.mu.txn.GlobalUncertaintyLimit.Forward(now.Add(tc.clock.MaxOffset().Nanoseconds(), 0))
+	tc.mu.txn.ResetObservedTimestamps()
+	tc.interceptorAlloc.txnSpanRefresher.resetRefreshSpansLocked(tc.mu.txn.ReadTimestamp)
+	return nil
+}
+
 // SetReadSeqNum is part of the TxnSender interface.
 func (tc *TxnCoordSender) SetReadSeqNum(seq enginepb.TxnSeq) error {
 	tc.mu.Lock()
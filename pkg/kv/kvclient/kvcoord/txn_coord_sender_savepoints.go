@@ -147,6 +147,14 @@ func (tc *TxnCoordSender) RollbackToSavepoint(ctx context.Context, s kv.Savepoin
 			})
 	}
 
+	// A retryable error that only requires the current statement to be retried
+	// is resolved by discarding the statement's writes. The savepoint was taken
+	// in the current epoch, so it precedes the statement's writes.
+	if tc.mu.txnState == txnRetryableError && tc.mu.storedRetryableErr.StatementRetry {
+		tc.mu.storedRetryableErr = nil
+		tc.mu.txnState = txnPending
+	}
+
 	return nil
 }
 
@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage"
@@ -3016,3 +3017,75 @@ func TestTxnCoordSenderSetFixedTimestamp(t *testing.T) {
 		})
 	}
 }
+
+// TestTxnCoordSenderReadCommitted tests that a read committed transaction
+// reads at a new snapshot in each statement and that a retryable error only
+// requires a retry of the current statement.
+func TestTxnCoordSenderReadCommitted(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	s := createTestDB(t)
+	defer s.Stop()
+
+	txn := kv.NewTxn(ctx, s.DB, 0 /* gatewayNodeID */)
+	require.NoError(t, txn.SetIsoLevel(isolation.ReadCommitted))
+	require.Equal(t, isolation.ReadCommitted, txn.IsoLevel())
+
+	// The first statement writes a key.
+	require.NoError(t, txn.StepReadTimestamp(ctx))
+	require.NoError(t, txn.Put(ctx, "a", "txn"))
+	require.Regexp(t, "cannot change the isolation level of a running transaction",
+		txn.SetIsoLevel(isolation.Serializable))
+
+	// The second statement reads a key, which is then written by another
+	// transaction before the statement writes it.
+	require.NoError(t, txn.StepReadTimestamp(ctx))
+	readTS := txn.ReadTimestamp()
+	sp, err := txn.CreateSavepoint(ctx)
+	require.NoError(t, err)
+	_, err = txn.Get(ctx, "k")
+	require.NoError(t, err)
+	require.NoError(t, s.DB.Put(ctx, "k", "other"))
+	err = txn.Put(ctx, "k", "txn")
+	var retryErr *roachpb.TransactionRetryWithProtoRefreshError
+	require.True(t, errors.As(err, &retryErr), "expected retry error, got %v", err)
+	require.True(t, retryErr.StatementRetry)
+
+	// Retry the statement at a new snapshot, without restarting the txn.
+	require.NoError(t, txn.RollbackToSavepoint(ctx, sp))
+	require.NoError(t, txn.PrepareForPartialRetry(ctx))
+	require.NoError(t, txn.StepReadTimestamp(ctx))
+	require.True(t, readTS.Less(txn.ReadTimestamp()))
+	res, err := txn.Get(ctx, "k")
+	require.NoError(t, err)
+	require.Equal(t, []byte("other"), res.ValueBytes())
+	require.NoError(t, txn.Put(ctx, "k", "txn"))
+	require.Equal(t, enginepb.TxnEpoch(0), txn.Epoch())
+	require.NoError(t, txn.Commit(ctx))
+
+	// The write from the first statement survived the statement retry.
+	for _, key := range []string{"a", "k"} {
+		res, err := s.DB.Get(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, []byte("txn"), res.ValueBytes())
+	}
+}
+
+// TestTxnCoordSenderStepReadTimestampSerializable tests that stepping the read
+// timestamp is a no-op for serializable transactions.
+func TestTxnCoordSenderStepReadTimestampSerializable(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	s := createTestDB(t)
+	defer s.Stop()
+
+	txn := kv.NewTxn(ctx, s.DB, 0 /* gatewayNodeID */)
+	require.NoError(t, txn.Put(ctx, "a", "txn"))
+	readTS := txn.ReadTimestamp()
+	s.Manual.Advance(time.Second)
+	require.NoError(t, txn.StepReadTimestamp(ctx))
+	require.Equal(t, readTS, txn.ReadTimestamp())
+	require.NoError(t, txn.Commit(ctx))
+}
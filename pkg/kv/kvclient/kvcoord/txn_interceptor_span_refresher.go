@@ -436,7 +436,10 @@ func (sr *txnSpanRefresher) maybeRefreshPreemptivelyLocked(
 
 	// If true, this batch is guaranteed to fail without a refresh.
 	args, hasET := ba.GetArg(roachpb.EndTxn)
-	refreshInevitable := hasET && args.(*roachpb.EndTxnRequest).Commit
+	// Isolation levels that tolerate write skew commit at their pushed write
+	// timestamp without validating their reads, so they never need a refresh.
+	refreshInevitable := hasET && args.(*roachpb.EndTxnRequest).Commit &&
+		!ba.Txn.IsoLevel.ToleratesWriteSkew()
 
 	// If neither condition is true, defer the refresh.
 	if !refreshFree && !refreshInevitable && !force {
@@ -706,6 +709,15 @@ func (sr *txnSpanRefresher) epochBumpedLocked() {
 	sr.refreshedTimestamp.Reset()
 }
 
+// resetRefreshSpansLocked drops the refresh spans of a transaction that has
+// moved its read timestamp to a new snapshot. Reads performed at earlier
+// snapshots never need to be refreshed to the new one.
+func (sr *txnSpanRefresher) resetRefreshSpansLocked(readTimestamp hlc.Timestamp) {
+	sr.refreshFootprint.clear()
+	sr.refreshInvalid = false
+	sr.refreshedTimestamp.Forward(readTimestamp)
+}
+
 // createSavepointLocked is part of the txnInterceptor interface.
 func (sr *txnSpanRefresher) createSavepointLocked(ctx context.Context, s *savepoint) {
 	s.refreshSpans = make([]roachpb.Span, len(sr.refreshFootprint.asSlice()))
@@ -483,8 +483,10 @@ func IsEndTxnTriggeringRetryError(
 		isTxnPushed := txn.WriteTimestamp != readTimestamp
 
 		// Return a transaction retry error if the commit timestamp isn't equal to
-		// the txn timestamp.
-		if isTxnPushed {
+		// the txn timestamp, unless the isolation level of the transaction
+		// tolerates write skew, in which case it can commit at its pushed
+		// timestamp without validating its reads.
+		if isTxnPushed && !txn.IsoLevel.ToleratesWriteSkew() {
 			retry, reason = true, roachpb.RETRY_SERIALIZABLE
 		}
 	}
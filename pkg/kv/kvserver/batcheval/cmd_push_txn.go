@@ -255,6 +255,11 @@ func PushTxn(
 		// If just attempting to cleanup old or already-committed txns,
 		// pusher always fails.
 		pusherWins = false
+	case pushType == roachpb.PUSH_TIMESTAMP && reply.PusheeTxn.IsoLevel.ToleratesWriteSkew():
+		// A pushee that tolerates write skew can commit at its pushed
+		// timestamp without a refresh, so pushing it is cheap.
+		reason = "pushee tolerates write skew"
+		pusherWins = true
 	case txnwait.CanPushWithPriority(args.PusherTxn.Priority, reply.PusheeTxn.Priority):
 		reason = "pusher has priority"
 		pusherWins = true
@@ -0,0 +1,30 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package isolation provides type definitions for the transaction isolation
+// levels supported by the key-value layer.
+package isolation
+
+// ToleratesWriteSkew returns whether transactions running at the isolation
+// level may commit at a write timestamp above their read timestamp without
+// refreshing their reads. Pushing the timestamp of such a transaction costs it
+// nothing, so conflicting readers may do so without waiting.
+func (l Level) ToleratesWriteSkew() bool {
+	return l == ReadCommitted
+}
+
+// PerStatementReadSnapshot returns whether transactions running at the
+// isolation level establish a new read snapshot for every SQL statement.
+func (l Level) PerStatementReadSnapshot() bool {
+	return l == ReadCommitted
+}
+
+// SafeValue implements redact.SafeValue.
+func (Level) SafeValue() {}
@@ -0,0 +1,33 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package isolation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelProperties(t *testing.T) {
+	for _, tc := range []struct {
+		level                    Level
+		toleratesWriteSkew       bool
+		perStatementReadSnapshot bool
+	}{
+		{Serializable, false, false},
+		{ReadCommitted, true, true},
+	} {
+		t.Run(tc.level.String(), func(t *testing.T) {
+			require.Equal(t, tc.toleratesWriteSkew, tc.level.ToleratesWriteSkew())
+			require.Equal(t, tc.perStatementReadSnapshot, tc.level.PerStatementReadSnapshot())
+		})
+	}
+}
@@ -227,9 +227,15 @@ func (w *lockTableWaiterImpl) WaitOn(
 				// The push should succeed without entering the txn wait-queue.
 				priorityPush := canPushWithPriority(req, state)
 
+				// If the lock holder tolerates write skew and the request only
+				// needs to push its timestamp, push immediately. The lock holder
+				// can commit at its pushed timestamp without refreshing its reads,
+				// so the push should succeed without entering the txn wait-queue.
+				isoLevelPush := canPushTimestampOfWeakIsoTxn(req, state)
+
 				// If the request doesn't want to perform a delayed push for any
 				// reason, continue waiting without a timer.
-				if !(livenessPush || deadlockPush || timeoutPush || priorityPush) {
+				if !(livenessPush || deadlockPush || timeoutPush || priorityPush || isoLevelPush) {
 					log.Eventf(ctx, "not pushing")
 					continue
 				}
@@ -257,14 +263,15 @@ func (w *lockTableWaiterImpl) WaitOn(
 					}
 					delay = minDuration(delay, w.timeUntilDeadline(lockDeadline))
 				}
-				if priorityPush {
+				if priorityPush || isoLevelPush {
 					delay = 0
 				}
 
 				log.Eventf(ctx, "pushing after %s for: "+
 					"liveness detection = %t, deadlock detection = %t, "+
-					"timeout enforcement = %t, priority enforcement = %t",
-					delay, livenessPush, deadlockPush, timeoutPush, priorityPush)
+					"timeout enforcement = %t, priority enforcement = %t, "+
+					"isolation level enforcement = %t",
+					delay, livenessPush, deadlockPush, timeoutPush, priorityPush, isoLevelPush)
 
 				if delay > 0 {
 					if timer == nil {
@@ -1246,6 +1253,20 @@ func canPushWithPriority(req Request, s waitingState) bool {
 	return txnwait.CanPushWithPriority(pusher, pushee)
 }
 
+// canPushTimestampOfWeakIsoTxn returns true if the request is a blocking read
+// that conflicts with a lock held by a transaction whose isolation level
+// tolerates write skew. Such a read pushes the lock holder's timestamp, which
+// the lock holder can accept without refreshing its reads.
+func canPushTimestampOfWeakIsoTxn(req Request, s waitingState) bool {
+	if s.txn == nil || !s.held {
+		return false
+	}
+	if req.WaitPolicy != lock.WaitPolicy_Block || s.guardAccess != spanset.SpanReadOnly {
+		return false
+	}
+	return s.txn.IsoLevel.ToleratesWriteSkew()
+}
+
 func minDuration(a, b time.Duration) time.Duration {
 	if a < b {
 		return a
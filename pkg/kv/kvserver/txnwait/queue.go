@@ -78,6 +78,11 @@ func ShouldPushImmediately(req *roachpb.PushTxnRequest) bool {
 	if CanPushWithPriority(req.PusherTxn.Priority, req.PusheeTxn.Priority) {
 		return true
 	}
+	if req.PushType == roachpb.PUSH_TIMESTAMP && req.PusheeTxn.IsoLevel.ToleratesWriteSkew() {
+		// A pushee that tolerates write skew does not need to refresh its reads
+		// when pushed, so there is no reason to wait for it.
+		return true
+	}
 	return false
 }
 
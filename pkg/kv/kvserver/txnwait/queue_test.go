@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -161,6 +162,33 @@ func TestShouldPushImmediately(t *testing.T) {
 	}
 }
 
+func TestShouldPushImmediatelyWeakIsolation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		typ        roachpb.PushTxnType
+		isoLevel   isolation.Level
+		shouldPush bool
+	}{
+		{roachpb.PUSH_ABORT, isolation.Serializable, false},
+		{roachpb.PUSH_ABORT, isolation.ReadCommitted, false},
+		{roachpb.PUSH_TIMESTAMP, isolation.Serializable, false},
+		// Pushing the timestamp of a txn that tolerates write skew is cheap.
+		{roachpb.PUSH_TIMESTAMP, isolation.ReadCommitted, true},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%s/%s", test.typ, test.isoLevel), func(t *testing.T) {
+			req := roachpb.PushTxnRequest{
+				PushType: test.typ,
+				PusheeTxn: enginepb.TxnMeta{
+					IsoLevel: test.isoLevel,
+				},
+			}
+			require.Equal(t, test.shouldPush, ShouldPushImmediately(&req))
+		})
+	}
+}
+
 func TestCanPushWithPriority(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -13,6 +13,7 @@ package kv
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -116,6 +117,13 @@ type TxnSender interface {
 	// SetUserPriority sets the txn's priority.
 	SetUserPriority(roachpb.UserPriority) error
 
+	// SetIsoLevel sets the txn's isolation level. The isolation level cannot
+	// be changed once the transaction has sent its first request.
+	SetIsoLevel(isolation.Level) error
+
+	// IsoLevel returns the txn's isolation level.
+	IsoLevel() isolation.Level
+
 	// SetDebugName sets the txn's debug name.
 	SetDebugName(name string)
 
@@ -278,6 +286,13 @@ type TxnSender interface {
 	// SetReadSeqNum sets the read sequence point for the current transaction.
 	SetReadSeqNum(seq enginepb.TxnSeq) error
 
+	// StepReadTimestamp moves the read timestamp of a transaction that uses a
+	// new read snapshot for each statement (see
+	// isolation.Level.PerStatementReadSnapshot) to the current time. It is a
+	// no-op for other transactions and for transactions whose commit
+	// timestamp is fixed.
+	StepReadTimestamp(context.Context) error
+
 	// ConfigureStepping sets the sequencing point behavior.
 	//
 	// Note that a Sender is initially in the non-stepping mode,
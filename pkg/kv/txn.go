@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/closedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -334,6 +335,26 @@ func (txn *Txn) SetDebugName(name string) {
 	txn.mu.debugName = name
 }
 
+// SetIsoLevel sets the transaction's isolation level. Transactions default to
+// Serializable. The isolation level must be set before any operations are
+// performed on the transaction.
+func (txn *Txn) SetIsoLevel(isoLevel isolation.Level) error {
+	if txn.typ != RootTxn {
+		return errors.AssertionFailedf("SetIsoLevel() called on leaf txn")
+	}
+
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.SetIsoLevel(isoLevel)
+}
+
+// IsoLevel returns the transaction's isolation level.
+func (txn *Txn) IsoLevel() isolation.Level {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.IsoLevel()
+}
+
 // DebugName returns the debug name associated with the transaction.
 func (txn *Txn) DebugName() string {
 	txn.mu.Lock()
@@ -1076,9 +1097,45 @@ func (txn *Txn) handleRetryableErrLocked(
 	ctx context.Context, retryErr *roachpb.TransactionRetryWithProtoRefreshError,
 ) {
 	txn.resetDeadlineLocked()
+	if retryErr.StatementRetry && txn.mu.ID == retryErr.TxnID {
+		// The sender prepared the transaction for a retry of the current
+		// statement only, but the caller is retrying the whole transaction.
+		// Restart it in a new epoch so that its earlier writes are discarded.
+		txn.mu.sender.ClearTxnRetryableErr(ctx)
+		txn.mu.sender.ManualRestart(ctx, txn.mu.userPriority, retryErr.Transaction.WriteTimestamp)
+		return
+	}
 	txn.replaceRootSenderIfTxnAbortedLocked(ctx, retryErr, retryErr.TxnID)
 }
 
+// PrepareForPartialRetry prepares the transaction for a retry of the current
+// statement after a retryable error that allows it (see
+// roachpb.CanRetryStatement). The caller must have rolled back to a savepoint
+// created at the start of the statement; the transaction keeps its epoch and
+// its writes from earlier statements.
+func (txn *Txn) PrepareForPartialRetry(ctx context.Context) error {
+	if txn.typ != RootTxn {
+		return errors.WithContextTags(errors.AssertionFailedf(
+			"PrepareForPartialRetry() called on leaf txn"), ctx)
+	}
+
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+
+	retryErr := txn.mu.sender.GetTxnRetryableErr(ctx)
+	if retryErr == nil {
+		return nil
+	}
+	if !retryErr.StatementRetry {
+		return errors.WithContextTags(errors.NewAssertionErrorWithWrappedErrf(
+			retryErr, "PrepareForPartialRetry() called after a non-statement retryable error"), ctx)
+	}
+	log.VEventf(ctx, 2, "retrying statement in transaction: %s because of a retryable error: %s",
+		txn.debugNameLocked(), retryErr)
+	txn.mu.sender.ClearTxnRetryableErr(ctx)
+	return nil
+}
+
 // NegotiateAndSend is a specialized version of Send that is capable of
 // orchestrating a bounded-staleness read through the transaction, given a
 // read-only BatchRequest with a min_timestamp_bound set in its Header.
@@ -1267,7 +1324,9 @@ func (txn *Txn) GetLeafTxnInputStateOrRejectClient(
 	tfs, err := txn.mu.sender.GetLeafTxnInputState(ctx, OnlyPending)
 	if err != nil {
 		var retryErr *roachpb.TransactionRetryWithProtoRefreshError
-		if errors.As(err, &retryErr) {
+		// Errors that allow a statement retry are left in place; the caller
+		// decides whether to retry the statement or the whole transaction.
+		if errors.As(err, &retryErr) && !retryErr.StatementRetry {
 			txn.handleRetryableErrLocked(ctx, retryErr)
 		}
 		return nil, err
@@ -1362,6 +1421,13 @@ func (txn *Txn) replaceRootSenderIfTxnAbortedLocked(
 		log.VEventf(ctx, 2, "retriable error for old incarnation of the transaction")
 		return
 	}
+	if retryErr.StatementRetry {
+		// The transaction has not moved to a new epoch. Leave the retryable
+		// error in place so that the caller either retries the statement
+		// (PrepareForPartialRetry) or restarts the transaction
+		// (PrepareForRetry).
+		return
+	}
 	if !retryErr.PrevTxnAborted() {
 		// We don't need a new transaction as a result of this error, but we may
 		// have a retryable error that should be cleared.
@@ -1491,6 +1557,15 @@ func (txn *Txn) Step(ctx context.Context) error {
 	return txn.mu.sender.Step(ctx)
 }
 
+// StepReadTimestamp moves the read timestamp of the transaction to the current
+// time if its isolation level takes a new read snapshot for each statement.
+// It is a no-op otherwise.
+func (txn *Txn) StepReadTimestamp(ctx context.Context) error {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.StepReadTimestamp(ctx)
+}
+
 // SetReadSeqNum sets the read sequence number for this transaction.
 func (txn *Txn) SetReadSeqNum(seq enginepb.TxnSeq) error {
 	txn.mu.Lock()
@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/keysbase"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util"
@@ -1150,6 +1151,17 @@ func (t *Transaction) Refresh(timestamp hlc.Timestamp) {
 	t.WriteTooOld = false
 }
 
+// BumpReadTimestamp moves the transaction's read timestamp (and, if necessary,
+// its write timestamp) forward to the specified timestamp without restarting
+// the transaction. Unlike Refresh, the caller does not need to prove that the
+// transaction's earlier reads are still valid at the new timestamp; it is used
+// by transactions that establish a new read snapshot for each statement.
+func (t *Transaction) BumpReadTimestamp(timestamp hlc.Timestamp) {
+	t.WriteTimestamp.Forward(timestamp)
+	t.ReadTimestamp.Forward(timestamp)
+	t.WriteTooOld = false
+}
+
 // Update ratchets priority, timestamp and original timestamp values (among
 // others) for the transaction. If t.ID is empty, then the transaction is
 // copied from o.
@@ -1168,6 +1180,9 @@ func (t *Transaction) Update(o *Transaction) {
 	if len(t.Key) == 0 {
 		t.Key = o.Key
 	}
+	if t.IsoLevel == isolation.Serializable {
+		t.IsoLevel = o.IsoLevel
+	}
 
 	// Update epoch-scoped state, depending on the two transactions' epochs.
 	if t.Epoch < o.Epoch {
@@ -1310,6 +1325,9 @@ func (t Transaction) SafeFormat(w redact.SafePrinter, _ rune) {
 	if ni := len(t.IgnoredSeqNums); ni > 0 {
 		w.Printf(" isn=%d", ni)
 	}
+	if t.IsoLevel != isolation.Serializable {
+		w.Printf(" iso=%s", t.IsoLevel)
+	}
 }
 
 // ResetObservedTimestamps clears out all timestamps recorded from individual
@@ -1437,6 +1455,12 @@ func (tr *TransactionRecord) AsTransaction() Transaction {
 //
 // In case retryErr tells us that a new Transaction needs to be created,
 // isolation and name help initialize this new transaction.
+//
+// If the transaction establishes a new read snapshot for each statement and
+// the error does not require a restart of the whole transaction, the returned
+// Transaction keeps its epoch (and so its writes) and only has its timestamps
+// moved forward. The caller is expected to retry only the current statement,
+// after rolling back the writes that the statement performed.
 func PrepareTransactionForRetry(
 	ctx context.Context, pErr *Error, pri UserPriority, clock *hlc.Clock,
 ) Transaction {
@@ -1458,6 +1482,7 @@ func PrepareTransactionForRetry(
 		// TODO(andrei): Should we preserve the ObservedTimestamps across the
 		// restart?
 		errTxnPri := txn.Priority
+		errTxnIsoLevel := txn.IsoLevel
 		// Start the new transaction at the current time from the local clock.
 		// The local hlc should have been advanced to at least the error's
 		// timestamp already.
@@ -1474,6 +1499,7 @@ func PrepareTransactionForRetry(
 		)
 		// Use the priority communicated back by the server.
 		txn.Priority = errTxnPri
+		txn.IsoLevel = errTxnIsoLevel
 	case *ReadWithinUncertaintyIntervalError:
 		txn.WriteTimestamp.Forward(tErr.RetryTimestamp())
 	case *TransactionPushError:
@@ -1514,11 +1540,41 @@ func PrepareTransactionForRetry(
 		if txn.Status.IsFinalized() {
 			log.Fatalf(ctx, "transaction unexpectedly finalized in (%T): %s", pErr.GetDetail(), pErr)
 		}
-		txn.Restart(pri, txn.Priority, txn.WriteTimestamp)
+		if CanRetryStatement(pErr, &txn) {
+			txn.BumpReadTimestamp(txn.WriteTimestamp)
+			txn.UpgradePriority(MakePriority(pri))
+		} else {
+			txn.Restart(pri, txn.Priority, txn.WriteTimestamp)
+		}
 	}
 	return txn
 }
 
+// CanRetryStatement returns whether the retryable error can be handled by
+// retrying only the statement that encountered it, as opposed to restarting
+// the whole transaction. This is possible for transactions that establish a
+// new read snapshot for each statement, unless the error indicates that one of
+// the writes of an earlier statement may have been lost or that the
+// transaction's commit timestamp can not move.
+func CanRetryStatement(pErr *Error, txn *Transaction) bool {
+	if !txn.IsoLevel.PerStatementReadSnapshot() || txn.CommitTimestampFixed {
+		return false
+	}
+	switch tErr := pErr.GetDetail().(type) {
+	case *ReadWithinUncertaintyIntervalError, *WriteTooOldError, *TransactionPushError:
+		return true
+	case *TransactionRetryError:
+		switch tErr.Reason {
+		case RETRY_ASYNC_WRITE_FAILURE, RETRY_COMMIT_DEADLINE_EXCEEDED:
+			// A pipelined write of an earlier statement failed, or the commit
+			// itself can not succeed. Neither is fixed by retrying a statement.
+			return false
+		}
+		return true
+	}
+	return false
+}
+
 // TransactionRefreshTimestamp returns whether the supplied error is a retry
 // error that can be discarded if the transaction in the error is refreshed. If
 // true, the function returns the timestamp that the Transaction object should
@@ -23,6 +23,7 @@ import (
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/cli/exit"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/testutils/zerofields"
@@ -512,6 +513,7 @@ var nonZeroTxn = Transaction{
 		Priority:          957356782,
 		Sequence:          123,
 		CoordinatorNodeID: 3,
+		IsoLevel:          isolation.ReadCommitted,
 	},
 	Name:                   "name",
 	Status:                 COMMITTED,
@@ -769,6 +771,56 @@ func TestTransactionRefresh(t *testing.T) {
 	require.Equal(t, expTxn, txn)
 }
 
+func TestTransactionBumpReadTimestamp(t *testing.T) {
+	txn := nonZeroTxn
+	txn.BumpReadTimestamp(makeTS(25, 1))
+
+	expTxn := nonZeroTxn
+	expTxn.WriteTimestamp = makeTS(25, 1)
+	expTxn.ReadTimestamp = makeTS(25, 1)
+	expTxn.WriteTooOld = false
+	require.Equal(t, expTxn, txn)
+}
+
+func TestCanRetryStatement(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	rc := nonZeroTxn
+	rc.CommitTimestampFixed = false
+	ser := rc
+	ser.IsoLevel = isolation.Serializable
+	fixed := rc
+	fixed.CommitTimestampFixed = true
+
+	retryErr := func(reason TransactionRetryReason) error {
+		return NewTransactionRetryError(reason, "")
+	}
+	testCases := []struct {
+		name string
+		err  error
+		txn  Transaction
+		exp  bool
+	}{
+		{"write too old", &WriteTooOldError{}, rc, true},
+		{"uncertainty", &ReadWithinUncertaintyIntervalError{}, rc, true},
+		{"push", &TransactionPushError{}, rc, true},
+		{"retry serializable", retryErr(RETRY_SERIALIZABLE), rc, true},
+		{"retry write too old", retryErr(RETRY_WRITE_TOO_OLD), rc, true},
+		{"retry async write failure", retryErr(RETRY_ASYNC_WRITE_FAILURE), rc, false},
+		{"retry deadline exceeded", retryErr(RETRY_COMMIT_DEADLINE_EXCEEDED), rc, false},
+		{"aborted", NewTransactionAbortedError(ABORT_REASON_ABORTED_RECORD_FOUND), rc, false},
+		{"serializable", &WriteTooOldError{}, ser, false},
+		{"fixed commit timestamp", &WriteTooOldError{}, fixed, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			txn := tc.txn
+			pErr := NewErrorWithTxn(tc.err, &txn)
+			require.Equal(t, tc.exp, CanRetryStatement(pErr, &txn))
+		})
+	}
+}
+
 // TestTransactionRecordRoundtrips tests a few properties about Transaction
 // and TransactionRecord protos. Remember that the latter is wire compatible
 // with the former and contains a subset of its protos.
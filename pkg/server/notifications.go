@@ -0,0 +1,85 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SendNotifications delivers the notifications committed by a session, with
+// NOTIFY or pg_notify(), to the sessions listening on their channel. Unless
+// the request is for the local node, it fans out to all the nodes.
+func (s *statusServer) SendNotifications(
+	ctx context.Context, req *serverpb.SendNotificationsRequest,
+) (*serverpb.SendNotificationsResponse, error) {
+	ctx = propagateGatewayMetadata(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	if _, err := s.privilegeChecker.requireAdminUser(ctx); err != nil {
+		return nil, err
+	}
+
+	response := &serverpb.SendNotificationsResponse{}
+	sqlServer := s.sqlServer.pgServer.SQLServer
+
+	if len(req.NodeID) > 0 {
+		_, local, err := s.parseNodeID(req.NodeID)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		if !local {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"notifications can only be sent to the local node or to all nodes")
+		}
+		sqlServer.PublishNotifications(ctx, req.Notifications)
+		return response, nil
+	}
+
+	localReq := &serverpb.SendNotificationsRequest{
+		NodeID:        "local",
+		Notifications: req.Notifications,
+	}
+
+	dialFn := func(ctx context.Context, nodeID roachpb.NodeID) (interface{}, error) {
+		client, err := s.dialNode(ctx, nodeID)
+		return client, err
+	}
+
+	sendNotifications := func(ctx context.Context, client interface{}, _ roachpb.NodeID) (interface{}, error) {
+		status := client.(serverpb.StatusClient)
+		return status.SendNotifications(ctx, localReq)
+	}
+
+	var fanoutError error
+
+	if err := s.iterateNodes(ctx, "send notifications",
+		dialFn,
+		sendNotifications,
+		func(nodeID roachpb.NodeID, resp interface{}) {
+			// Nothing to do here.
+		},
+		func(nodeID roachpb.NodeID, nodeFnError error) {
+			if nodeFnError != nil {
+				fanoutError = errors.CombineErrors(fanoutError, nodeFnError)
+			}
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return response, fanoutError
+}
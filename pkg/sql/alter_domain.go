@@ -0,0 +1,279 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type alterDomainNode struct {
+	n    *tree.AlterDomain
+	desc *typedesc.Mutable
+}
+
+// alterDomainNode implements planNode. We set n here to satisfy the linter.
+var _ planNode = &alterDomainNode{n: nil}
+
+func (p *planner) AlterDomain(ctx context.Context, n *tree.AlterDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	// Resolve the domain.
+	_, desc, err := p.ResolveMutableTypeDescriptor(ctx, n.Domain, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if desc.Kind != descpb.TypeDescriptor_DOMAIN {
+		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", desc.Name)
+	}
+
+	// Renaming, moving and changing the owner of a domain work the same way as
+	// they do for any other type.
+	switch t := n.Cmd.(type) {
+	case *tree.AlterDomainRename:
+		return p.AlterType(ctx, &tree.AlterType{Type: n.Domain, Cmd: &tree.AlterTypeRename{NewName: t.NewName}})
+	case *tree.AlterDomainSetSchema:
+		return p.AlterType(ctx, &tree.AlterType{Type: n.Domain, Cmd: &tree.AlterTypeSetSchema{Schema: t.Schema}})
+	case *tree.AlterDomainOwner:
+		return p.AlterType(ctx, &tree.AlterType{Type: n.Domain, Cmd: &tree.AlterTypeOwner{Owner: t.Owner}})
+	}
+
+	// The user needs ownership privilege to alter the domain.
+	if err := p.canModifyType(ctx, desc); err != nil {
+		return nil, err
+	}
+
+	return &alterDomainNode{n: n, desc: desc}, nil
+}
+
+func (n *alterDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounterWithExtra("domain", n.n.Cmd.TelemetryName()))
+
+	domain := n.desc.Domain
+	var err error
+	switch t := n.n.Cmd.(type) {
+	case *tree.AlterDomainSetDefault:
+		domain.DefaultExpr = nil
+		if t.Default != nil {
+			domain.DefaultExpr, err = makeDomainDefaultExpr(params, t.Default, domain.BaseType)
+		}
+	case *tree.AlterDomainSetNotNull:
+		if t.NotNull && !domain.NotNull {
+			err = params.p.validateDomainColumns(params.ctx, n.desc, func(col string) string {
+				return fmt.Sprintf("%s IS NULL", tree.NameString(col))
+			}, "contains null values")
+		}
+		domain.NotNull = t.NotNull
+	case *tree.AlterDomainAddConstraint:
+		if t.Constraint.Check == nil {
+			return pgerror.New(pgcode.Syntax,
+				"only CHECK constraints can be added to a domain")
+		}
+		var check descpb.TypeDescriptor_Domain_CheckConstraint
+		check, err = makeDomainCheckConstraint(params, n.desc.Name, domain, t.Constraint)
+		if err != nil {
+			return err
+		}
+		err = params.p.validateDomainColumns(params.ctx, n.desc, func(col string) string {
+			return fmt.Sprintf("NOT (%s)", replaceDomainValue(t.Constraint.Check, col))
+		}, "contains values that violate the new constraint")
+		domain.Checks = append(domain.Checks, check)
+	case *tree.AlterDomainDropConstraint:
+		idx := -1
+		for i := range domain.Checks {
+			if domain.Checks[i].Name == string(t.Constraint) {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			if t.IfExists {
+				params.p.BufferClientNotice(params.ctx, pgnotice.Newf(
+					"constraint %q of domain %q does not exist, skipping", t.Constraint, n.desc.Name))
+				return nil
+			}
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q of domain %q does not exist", t.Constraint, n.desc.Name)
+		}
+		domain.Checks = append(domain.Checks[:idx], domain.Checks[idx+1:]...)
+	default:
+		err = errors.AssertionFailedf("unknown alter domain cmd %s", t)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := params.p.writeTypeSchemaChange(
+		params.ctx, n.desc, tree.AsStringWithFQNames(n.n, params.p.Ann()),
+	); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx,
+		n.desc.ID,
+		&eventpb.AlterType{
+			TypeName: tree.AsStringWithFQNames(n.n.Domain, params.p.Ann()),
+		})
+}
+
+// validateDomainColumns checks that no row of a table with a column of the
+// given domain type matches the predicate returned by makePredicate for that
+// column. If a row does, an error with the given message is returned.
+func (p *planner) validateDomainColumns(
+	ctx context.Context,
+	desc *typedesc.Mutable,
+	makePredicate func(col string) string,
+	violation string,
+) error {
+	for _, id := range desc.ReferencingDescriptorIDs {
+		tbl, err := p.Descriptors().ByID(p.txn).WithoutNonPublic().Get().Table(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !tbl.IsPhysicalTable() {
+			continue
+		}
+		for _, col := range tbl.PublicColumns() {
+			if !col.GetType().IsDomain() ||
+				typedesc.GetUserDefinedTypeDescID(col.GetType()) != desc.ID {
+				continue
+			}
+			query := fmt.Sprintf(`SELECT 1 FROM [%d AS t] WHERE %s LIMIT 1`, id, makePredicate(col.GetName()))
+			row, err := p.InternalSQLTxn().QueryRowEx(
+				ctx, "validate domain constraint", p.txn,
+				sessiondata.RootUserSessionDataOverride,
+				query,
+			)
+			if err != nil {
+				return err
+			}
+			if row != nil {
+				return pgerror.Newf(pgcode.CheckViolation,
+					"column %q of table %q %s", col.GetName(), tbl.GetName(), violation)
+			}
+		}
+	}
+	return nil
+}
+
+// replaceDomainValue returns the given domain CHECK constraint expression
+// with all references to VALUE replaced by the given column name.
+func replaceDomainValue(expr tree.Expr, col string) string {
+	expr, _ = tree.SimpleVisit(expr, func(e tree.Expr) (recurse bool, newExpr tree.Expr, _ error) {
+		if n, ok := e.(*tree.UnresolvedName); ok && n.NumParts == 1 && n.Parts[0] == eval.DomainValueName {
+			return false, &tree.UnresolvedName{NumParts: 1, Parts: tree.NameParts{col}}, nil
+		}
+		return true, e, nil
+	})
+	return tree.Serialize(expr)
+}
+
+// checkDomainBaseType returns an error if typ cannot be used as the base type
+// of a domain.
+func checkDomainBaseType(typ *types.T) error {
+	if typ.UserDefined() {
+		return unimplemented.NewWithIssue(27796,
+			"domains over user-defined types are not yet supported")
+	}
+	switch typ.Family() {
+	case types.ArrayFamily, types.TupleFamily, types.AnyFamily, types.UnknownFamily, types.VoidFamily:
+		return pgerror.Newf(pgcode.DatatypeMismatch,
+			"%s is not a valid base type for a domain", typ.SQLString())
+	}
+	return nil
+}
+
+// makeDomainDefaultExpr type checks the DEFAULT expression of a domain with
+// the given base type and returns its serialized form.
+func makeDomainDefaultExpr(params runParams, expr tree.Expr, base *types.T) (*string, error) {
+	typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+		params.ctx, expr, base, "DEFAULT", &params.p.semaCtx, volatility.Volatile, true, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.MaybeFailOnUDFUsage(typedExpr); err != nil {
+		return nil, err
+	}
+	if typedExpr == tree.DNull {
+		return nil, nil
+	}
+	s := tree.Serialize(typedExpr)
+	return &s, nil
+}
+
+// makeDomainCheckConstraint validates the given CHECK constraint of the domain
+// with the given name. If the constraint is unnamed, a name that does not
+// conflict with the existing constraints of the domain is generated for it.
+func makeDomainCheckConstraint(
+	params runParams,
+	domainName string,
+	domain *descpb.TypeDescriptor_Domain,
+	c tree.DomainConstraint,
+) (descpb.TypeDescriptor_Domain_CheckConstraint, error) {
+	inUse := func(name string) bool {
+		for i := range domain.Checks {
+			if domain.Checks[i].Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	name := string(c.Name)
+	if name == "" {
+		name = domainName + "_check"
+		for i := 1; inUse(name); i++ {
+			name = fmt.Sprintf("%s_check%d", domainName, i)
+		}
+	} else if inUse(name) {
+		return descpb.TypeDescriptor_Domain_CheckConstraint{}, pgerror.Newf(pgcode.DuplicateObject,
+			"constraint %q for domain %q already exists", name, domainName)
+	}
+
+	// Serialize the expression before type checking it, since type checking
+	// replaces the references to VALUE.
+	s := tree.Serialize(c.Check)
+	typedExpr, err := eval.TypeCheckDomainCheckExpr(params.ctx, &params.p.semaCtx, c.Check, domain.BaseType)
+	if err != nil {
+		return descpb.TypeDescriptor_Domain_CheckConstraint{}, err
+	}
+	if err := tree.MaybeFailOnUDFUsage(typedExpr); err != nil {
+		return descpb.TypeDescriptor_Domain_CheckConstraint{}, err
+	}
+	return descpb.TypeDescriptor_Domain_CheckConstraint{Name: name, Expr: s}, nil
+}
+
+func (n *alterDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *alterDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *alterDomainNode) Close(ctx context.Context)           {}
+func (n *alterDomainNode) ReadingOwnWrites()                   {}
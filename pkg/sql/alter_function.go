@@ -63,7 +63,7 @@ func (p *planner) AlterFunctionOptions(
 }
 
 func (n *alterFunctionOptionsNode) startExec(params runParams) error {
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, false /* isAggregate */)
 	if err != nil {
 		return err
 	}
@@ -112,7 +112,7 @@ func (p *planner) AlterFunctionRename(
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"ALTER FUNCTION",
+		n.StatementTag(),
 	); err != nil {
 		return nil, err
 	}
@@ -124,7 +124,7 @@ func (n *alterFunctionRenameNode) startExec(params runParams) error {
 	// TODO(chengxiong): add validation that a function can not be altered if it's
 	// referenced by other objects. This is needed when want to allow function
 	// references.
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, n.n.IsAggregate)
 	if err != nil {
 		return err
 	}
@@ -185,7 +185,7 @@ func (p *planner) AlterFunctionSetOwner(
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"ALTER FUNCTION",
+		n.StatementTag(),
 	); err != nil {
 		return nil, err
 	}
@@ -194,7 +194,7 @@ func (p *planner) AlterFunctionSetOwner(
 }
 
 func (n *alterFunctionSetOwnerNode) startExec(params runParams) error {
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, n.n.IsAggregate)
 	if err != nil {
 		return err
 	}
@@ -245,7 +245,7 @@ func (p *planner) AlterFunctionSetSchema(
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"ALTER FUNCTION",
+		n.StatementTag(),
 	); err != nil {
 		return nil, err
 	}
@@ -257,7 +257,7 @@ func (n *alterFunctionSetSchemaNode) startExec(params runParams) error {
 	// TODO(chengxiong): add validation that a function can not be altered if it's
 	// referenced by other objects. This is needed when want to allow function
 	// references.
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, n.n.IsAggregate)
 	if err != nil {
 		return err
 	}
@@ -366,7 +366,7 @@ func (n *alterFunctionDepExtensionNode) Values() tree.Datums                 { r
 func (n *alterFunctionDepExtensionNode) Close(ctx context.Context)           {}
 
 func (p *planner) mustGetMutableFunctionForAlter(
-	ctx context.Context, funcObj *tree.FuncObj,
+	ctx context.Context, funcObj *tree.FuncObj, isAggregate bool,
 ) (*funcdesc.Mutable, error) {
 	ol, err := p.matchUDF(ctx, funcObj, true /*required*/)
 	if err != nil {
@@ -377,15 +377,20 @@ func (p *planner) mustGetMutableFunctionForAlter(
 	if err != nil {
 		return nil, err
 	}
+	if err := checkAggregateKind(mut, isAggregate, "ALTER"); err != nil {
+		return nil, err
+	}
 	return mut, nil
 }
 
 func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor_FunctionOverload {
 	ret := descpb.SchemaDescriptor_FunctionOverload{
-		ID:         fnDesc.GetID(),
-		ArgTypes:   make([]*types.T, len(fnDesc.GetParams())),
-		ReturnType: fnDesc.ReturnType.Type,
-		ReturnSet:  fnDesc.ReturnType.ReturnSet,
+		ID:          fnDesc.GetID(),
+		ArgTypes:    make([]*types.T, len(fnDesc.GetParams())),
+		ReturnType:  fnDesc.ReturnType.Type,
+		ReturnSet:   fnDesc.ReturnType.ReturnSet,
+		IsAggregate: fnDesc.Aggregate != nil,
+		IsVariadic:  fnDesc.IsVariadic(),
 	}
 	for i := range fnDesc.Params {
 		ret.ArgTypes[i] = fnDesc.Params[i].Type
@@ -196,6 +196,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 				return pgerror.Newf(pgcode.InvalidColumnDefinition,
 					"multiple primary keys for table %q are not allowed", tn.Object())
 			}
+			if err := checkNoInheritingTables(n.tableDesc, "add a column to"); err != nil {
+				return err
+			}
 			var err error
 			params.p.runWithOptions(resolveFlags{contextDatabaseID: n.tableDesc.ParentID}, func() {
 				err = params.p.addColumnImpl(params, n, tn, n.tableDesc, t)
@@ -345,6 +348,20 @@ func (n *alterTableNode) startExec(params runParams) error {
 						return err
 					}
 				}
+			case *tree.ExcludeConstraintTableDef:
+				if err := addExcludeConstraintTableDef(
+					params.ctx,
+					params.EvalContext(),
+					d,
+					n.tableDesc,
+					*tn,
+					NonEmptyTable,
+					t.ValidationBehavior,
+					params.p.SemaCtx(),
+				); err != nil {
+					return err
+				}
+
 			case *tree.CheckConstraintTableDef:
 				var err error
 				params.p.runWithOptions(resolveFlags{contextDatabaseID: n.tableDesc.ParentID}, func() {
@@ -793,6 +810,59 @@ func (n *alterTableNode) startExec(params runParams) error {
 				return err
 			}
 			descriptorChanged = true
+
+		case *tree.AlterTableAlterConstraint:
+			c := catalog.FindConstraintByName(n.tableDesc, string(t.Constraint))
+			if c == nil {
+				return pgerror.Newf(pgcode.UndefinedObject,
+					"constraint %q of relation %q does not exist", t.Constraint, n.tableDesc.Name)
+			}
+			fk := c.AsForeignKey()
+			if fk == nil {
+				return pgerror.Newf(pgcode.WrongObjectType,
+					"constraint %q of relation %q is not a foreign key constraint",
+					tree.ErrString(&t.Constraint), tree.ErrString(n.n.Table))
+			}
+			switch c.GetConstraintValidity() {
+			case descpb.ConstraintValidity_Validating:
+				return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+					"constraint %q in the middle of being added, try again later", t.Constraint)
+			case descpb.ConstraintValidity_Dropping:
+				return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+					"constraint %q in the middle of being dropped", t.Constraint)
+			}
+			deferrability := semenumpb.ConstraintDeferrability(t.Deferrable)
+			if fk.Deferrability() == deferrability {
+				continue
+			}
+			fk.ForeignKeyDesc().Deferrability = deferrability
+			if err := params.p.updateFKBackReferenceDeferrability(
+				params.ctx, n.tableDesc, fk.ForeignKeyDesc(),
+			); err != nil {
+				return err
+			}
+			descriptorChanged = true
+
+		case *tree.AlterTableInherit:
+			_, parent, err := params.p.ResolveMutableTableDescriptor(
+				params.ctx, &t.Parent, true /* required */, tree.ResolveRequireTableDesc,
+			)
+			if err != nil {
+				return err
+			}
+			if err := params.p.CheckPrivilege(params.ctx, parent, privilege.CREATE); err != nil {
+				return err
+			}
+			if t.NoInherit {
+				err = params.p.removeInheritance(params.ctx, n.tableDesc, parent)
+			} else {
+				err = params.p.addInheritance(params.ctx, n.tableDesc, parent, true /* checkColumns */)
+			}
+			if err != nil {
+				return err
+			}
+			descriptorChanged = true
+
 		default:
 			return errors.AssertionFailedf("unsupported alter command: %T", cmd)
 		}
@@ -897,6 +967,9 @@ func applyColumnMutation(
 ) error {
 	switch t := mut.(type) {
 	case *tree.AlterTableAlterColumnType:
+		if err := checkNoInheritingTables(tableDesc, "alter the type of a column of"); err != nil {
+			return err
+		}
 		return AlterColumnType(ctx, tableDesc, col, t, params, cmds, tn)
 
 	case *tree.AlterTableSetDefault:
@@ -1386,6 +1459,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExcludeConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
@@ -1473,6 +1549,44 @@ func validateConstraintNameIsNotUsed(
 		"duplicate constraint name: %q", name)
 }
 
+// updateFKBackReferenceDeferrability copies the deferrability of the supplied
+// foreign key onto its backreference in the referenced table descriptor.
+func (p *planner) updateFKBackReferenceDeferrability(
+	ctx context.Context, tableDesc *tabledesc.Mutable, ref *descpb.ForeignKeyConstraint,
+) error {
+	var referencedTableDesc *tabledesc.Mutable
+	// We don't want to lookup/edit a second copy of the same table.
+	if tableDesc.ID == ref.ReferencedTableID {
+		referencedTableDesc = tableDesc
+	} else {
+		lookup, err := p.Descriptors().MutableByID(p.txn).Table(ctx, ref.ReferencedTableID)
+		if err != nil {
+			return errors.Wrapf(err, "error resolving referenced table ID %d", ref.ReferencedTableID)
+		}
+		referencedTableDesc = lookup
+	}
+	if referencedTableDesc.Dropped() {
+		// The referenced table is being dropped. No need to modify it further.
+		return nil
+	}
+	for i := range referencedTableDesc.InboundFKs {
+		backref := &referencedTableDesc.InboundFKs[i]
+		if backref.Name == ref.Name && backref.OriginTableID == tableDesc.ID {
+			backref.Deferrability = ref.Deferrability
+			if referencedTableDesc == tableDesc {
+				// The caller writes out the origin table descriptor.
+				return nil
+			}
+			return p.writeSchemaChange(
+				ctx, referencedTableDesc, descpb.InvalidMutationID,
+				fmt.Sprintf("updating referenced FK table %s(%d) for table %s(%d)",
+					referencedTableDesc.Name, referencedTableDesc.ID, tableDesc.Name, tableDesc.ID),
+			)
+		}
+	}
+	return errors.Errorf("missing backreference for foreign key %s", ref.Name)
+}
+
 // updateFKBackReferenceName updates the name of a foreign key reference on
 // the referenced table descriptor.
 // TODO (lucy): This method is meant to be analogous to removeFKBackReference,
@@ -1558,6 +1672,14 @@ func dropColumnImpl(
 		)
 	}
 
+	// You can't drop a column referenced by a trigger unless CASCADE was
+	// specified, in which case the trigger is dropped.
+	if err := params.p.dropTriggersReferencingColumn(
+		params.ctx, tableDesc, colToDrop, t.DropBehavior,
+	); err != nil {
+		return nil, err
+	}
+
 	// If the dropped column uses a sequence, remove references to it from that sequence.
 	if colToDrop.NumUsesSequences() > 0 {
 		if err := params.p.removeSequenceDependencies(params.ctx, tableDesc, colToDrop); err != nil {
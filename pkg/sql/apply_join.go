@@ -253,7 +253,7 @@ func (a *applyJoinNode) runNextRightSideIteration(params runParams, leftRow tree
 	}
 	plan := p.(*planComponents)
 	rowResultWriter := NewRowResultWriter(&a.run.rightRows)
-	if err := runPlanInsidePlan(ctx, params, plan, rowResultWriter); err != nil {
+	if err := runPlanInsidePlan(ctx, params, plan, tree.Rows, rowResultWriter); err != nil {
 		return err
 	}
 	a.run.rightRowsIterator = newRowContainerIterator(ctx, a.run.rightRows, a.rightTypes)
@@ -261,13 +261,19 @@ func (a *applyJoinNode) runNextRightSideIteration(params runParams, leftRow tree
 }
 
 // runPlanInsidePlan is used to run a plan and gather the results in the
-// resultWriter, as part of the execution of an "outer" plan.
+// resultWriter, as part of the execution of an "outer" plan. stmtType is the
+// type of the results of the plan. The cascades and checks of the plan, if
+// any, are run after its main query.
 func runPlanInsidePlan(
-	ctx context.Context, params runParams, plan *planComponents, resultWriter rowResultWriter,
+	ctx context.Context,
+	params runParams,
+	plan *planComponents,
+	stmtType tree.StatementReturnType,
+	resultWriter rowResultWriter,
 ) error {
 	defer plan.close(ctx)
 	recv := MakeDistSQLReceiver(
-		ctx, resultWriter, tree.Rows,
+		ctx, resultWriter, stmtType,
 		params.ExecCfg().RangeDescriptorCache,
 		params.p.Txn(),
 		params.ExecCfg().Clock,
@@ -334,6 +340,16 @@ func runPlanInsidePlan(
 	params.p.extendedEvalCtx.ExecCfg.DistSQLPlanner.PlanAndRun(
 		ctx, evalCtx, planCtx, params.p.Txn(), plan.main, recv, nil, /* finishedSetupFn */
 	)
+	if err := resultWriter.Err(); err != nil {
+		return err
+	}
+
+	// The cascades and checks must not commit the transaction of the outer
+	// plan.
+	plannerCopy.autoCommit = false
+	params.p.extendedEvalCtx.ExecCfg.DistSQLPlanner.PlanAndRunCascadesAndChecks(
+		ctx, &plannerCopy, params.extendedEvalCtx.copy, plan, recv,
+	)
 	return resultWriter.Err()
 }
 
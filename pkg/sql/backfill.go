@@ -1569,10 +1569,8 @@ func ValidateConstraint(
 			return txn.WithSyntheticDescriptors(
 				[]catalog.Descriptor{tableDesc},
 				func() error {
-					return validateUniqueConstraint(
-						ctx, tableDesc, uwi.GetName(),
-						uwi.CollectKeyColumnIDs().Ordered(),
-						uwi.GetPredicate(),
+					return validateUniqueWithoutIndexConstraint(
+						ctx, tableDesc, uwi,
 						indexIDForValidation,
 						txn,
 						sessionData.User(),
@@ -2732,11 +2730,10 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	if tableDesc.Version > tableDesc.ClusterVersion().Version {
 		syntheticDescs = append(syntheticDescs, tableDesc)
 	}
-	var uc *descpb.UniqueWithoutIndexConstraint
-	for i := range tableDesc.UniqueWithoutIndexConstraints {
-		def := &tableDesc.UniqueWithoutIndexConstraints[i]
-		if def.Name == constraintName {
-			uc = def
+	var uc catalog.UniqueWithoutIndexConstraint
+	for _, c := range tableDesc.UniqueConstraintsWithoutIndex() {
+		if !c.IsMutation() && c.GetName() == constraintName {
+			uc = c
 			break
 		}
 	}
@@ -2747,12 +2744,10 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	return txn.WithSyntheticDescriptors(
 		syntheticDescs,
 		func() error {
-			return validateUniqueConstraint(
+			return validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.Name,
-				uc.ColumnIDs,
-				uc.Predicate,
+				uc,
 				0, /* indexIDForValidation */
 				txn,
 				user,
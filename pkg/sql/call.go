@@ -0,0 +1,32 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// callNode implements CALL. It invokes a procedure once and produces no rows.
+type callNode struct {
+	proc tree.TypedExpr
+}
+
+func (n *callNode) startExec(params runParams) error {
+	_, err := eval.Expr(params.ctx, params.EvalContext(), n.proc)
+	return err
+}
+
+func (*callNode) Next(params runParams) (bool, error) { return false, nil }
+func (*callNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*callNode) Close(ctx context.Context)           {}
@@ -224,6 +224,10 @@ func FormatIndexElements(
 			switch index.InvertedColumnKinds[0] {
 			case catpb.InvertedIndexColumnKind_TRIGRAM:
 				f.WriteString(" gin_trgm_ops")
+			case catpb.InvertedIndexColumnKind_VECTOR_COSINE:
+				f.WriteString(" vector_cosine_ops")
+			case catpb.InvertedIndexColumnKind_VECTOR_IP:
+				f.WriteString(" vector_ip_ops")
 			}
 		}
 		// The last column of an inverted index cannot have a DESC direction.
@@ -314,6 +318,17 @@ func formatStorageConfigs(
 		}
 	}
 
+	if index.VectorLists != 0 {
+		if numCustomSettings > 0 {
+			f.WriteString(", ")
+		} else {
+			f.WriteString(" WITH (")
+		}
+		f.WriteString(`lists=`)
+		f.WriteString(strconv.FormatInt(int64(index.VectorLists), 10))
+		numCustomSettings++
+	}
+
 	if index.IsSharded() {
 		if numCustomSettings > 0 {
 			f.WriteString(", ")
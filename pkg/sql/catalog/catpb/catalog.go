@@ -108,3 +108,15 @@ func (rowLevelTTL *RowLevelTTL) HasDurationExpr() bool {
 func (rowLevelTTL *RowLevelTTL) HasExpirationExpr() bool {
 	return rowLevelTTL.ExpirationExpr != ""
 }
+
+// IsVector returns whether the kind is one of the kinds of inverted columns
+// that index vectors for approximate nearest neighbor search.
+func (k InvertedIndexColumnKind) IsVector() bool {
+	switch k {
+	case InvertedIndexColumnKind_VECTOR_L2,
+		InvertedIndexColumnKind_VECTOR_COSINE,
+		InvertedIndexColumnKind_VECTOR_IP:
+		return true
+	}
+	return false
+}
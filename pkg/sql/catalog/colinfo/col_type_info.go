@@ -99,6 +99,12 @@ func ValidateColumnDefType(ctx context.Context, version clusterversion.Handle, t
 			return unimplemented.NewWithIssueDetailf(23468, t.String(),
 				"arrays of JSON unsupported as column type")
 		}
+		if t.ArrayContents().Family() == types.PGVectorFamily {
+			// Vectors have no key encoding, so they cannot be the elements of an
+			// array column that may be inverted indexed.
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"arrays of vector unsupported as column type")
+		}
 		if err := types.CheckArrayElementType(t.ArrayContents()); err != nil {
 			return err
 		}
@@ -124,6 +130,24 @@ func ValidateColumnDefType(ctx context.Context, version clusterversion.Handle, t
 				"TSVector/TSQuery not supported until version 23.1")
 		}
 
+	case types.JsonpathFamily:
+		if !version.IsActive(ctx, clusterversion.V23_1) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"jsonpath not supported until version 23.1")
+		}
+
+	case types.RangeFamily, types.MultirangeFamily:
+		if !version.IsActive(ctx, clusterversion.V23_1) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"range types not supported until version 23.1")
+		}
+
+	case types.PGVectorFamily:
+		if !version.IsActive(ctx, clusterversion.V23_1) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"vector not supported until version 23.1")
+		}
+
 	default:
 		return pgerror.Newf(pgcode.InvalidTableDefinition,
 			"value type %s cannot be used for table columns", t.String())
@@ -166,6 +190,7 @@ func ColumnTypeIsOnlyInvertedIndexable(t *types.T) bool {
 	case types.GeographyFamily:
 	case types.GeometryFamily:
 	case types.TSVectorFamily:
+	case types.PGVectorFamily:
 	default:
 		return false
 	}
@@ -185,7 +210,7 @@ func MustBeValueEncoded(semanticType *types.T) bool {
 		}
 	case types.JsonFamily, types.TupleFamily, types.GeographyFamily, types.GeometryFamily:
 		return true
-	case types.TSVectorFamily, types.TSQueryFamily:
+	case types.TSVectorFamily, types.TSQueryFamily, types.JsonpathFamily, types.PGVectorFamily:
 		return true
 	}
 	return false
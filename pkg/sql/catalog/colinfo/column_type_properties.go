@@ -55,6 +55,12 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		return true
 	case types.ArrayFamily:
 		return CanHaveCompositeKeyEncoding(typ.ArrayContents())
+	case types.RangeFamily, types.MultirangeFamily:
+		if typ.RangeContents() == nil {
+			// This is the AnyRange or AnyMultirange wildcard.
+			return true
+		}
+		return CanHaveCompositeKeyEncoding(typ.RangeContents())
 	case types.TupleFamily:
 		for _, t := range typ.TupleContents() {
 			if CanHaveCompositeKeyEncoding(t) {
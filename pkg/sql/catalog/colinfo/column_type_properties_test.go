@@ -28,6 +28,7 @@ func TestCanHaveCompositeKeyEncoding(t *testing.T) {
 		{types.AnyArray, true},
 		{types.AnyCollatedString, true},
 		{types.AnyEnum, false},
+		{types.AnyRange, true},
 		{types.AnyTuple, true},
 		{types.Bool, false},
 		{types.BoolArray, false},
@@ -35,6 +36,8 @@ func TestCanHaveCompositeKeyEncoding(t *testing.T) {
 		{types.Bytes, false},
 		{types.Date, false},
 		{types.DateArray, false},
+		{types.DateMultirange, false},
+		{types.DateRange, false},
 		{types.Decimal, true},
 		{types.DecimalArray, true},
 		{types.EmptyTuple, false},
@@ -49,11 +52,14 @@ func TestCanHaveCompositeKeyEncoding(t *testing.T) {
 		{types.Int2, false},
 		{types.Int2Vector, false},
 		{types.Int4, false},
+		{types.Int4Range, false},
 		{types.IntArray, false},
 		{types.Interval, false},
 		{types.IntervalArray, false},
 		{types.Jsonb, false},
 		{types.Name, false},
+		{types.NumMultirange, true},
+		{types.NumRange, true},
 		{types.Oid, false},
 		{types.String, false},
 		{types.StringArray, false},
@@ -14,8 +14,10 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -120,6 +122,30 @@ func (desc *IndexDescriptor) InvertedColumnName() string {
 	return desc.KeyColumnNames[len(desc.KeyColumnNames)-1]
 }
 
+// VectorConfig returns the configuration of the IVF lists of a vector inverted
+// index.
+//
+// Panics if the index is not inverted.
+func (desc *IndexDescriptor) VectorConfig() vector.IVFConfig {
+	if desc.Type != IndexDescriptor_INVERTED {
+		panic(errors.AssertionFailedf("index is not inverted"))
+	}
+	config := vector.IVFConfig{
+		Lists:     int(desc.VectorLists),
+		Centroids: desc.VectorCentroids,
+	}
+	if config.Lists == 0 {
+		config.Lists = vector.DefaultLists
+	}
+	switch desc.InvertedColumnKinds[0] {
+	case catpb.InvertedIndexColumnKind_VECTOR_COSINE:
+		config.Metric = vector.CosineMetric
+	case catpb.InvertedIndexColumnKind_VECTOR_IP:
+		config.Metric = vector.InnerProductMetric
+	}
+	return config
+}
+
 // InvertedColumnKeyType returns the type of the data element that is encoded
 // as the inverted index key. This is currently always EncodedKey.
 //
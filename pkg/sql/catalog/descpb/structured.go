@@ -74,6 +74,9 @@ type IndexID = catid.IndexID
 // ConstraintID is a custom type for TableDescriptor constraint IDs.
 type ConstraintID = catid.ConstraintID
 
+// TriggerID is a custom type for TableDescriptor trigger IDs.
+type TriggerID = catid.TriggerID
+
 // DescriptorVersion is a custom type for TableDescriptor Versions.
 type DescriptorVersion uint64
 
@@ -217,7 +220,7 @@ func (desc *TableDescriptor) Adding() bool {
 
 // IsTable implements the TableDescriptor interface.
 func (desc *TableDescriptor) IsTable() bool {
-	return !desc.IsView() && !desc.IsSequence()
+	return !desc.IsView() && !desc.IsSequence() && !desc.IsForeignTable()
 }
 
 // IsView implements the TableDescriptor interface.
@@ -230,6 +233,16 @@ func (desc *TableDescriptor) MaterializedView() bool {
 	return desc.IsMaterializedView
 }
 
+// IsForeignTable implements the TableDescriptor interface.
+func (desc *TableDescriptor) IsForeignTable() bool {
+	return desc.ForeignTable != nil
+}
+
+// IsIncrementallyRefreshed implements the TableDescriptor interface.
+func (desc *TableDescriptor) IsIncrementallyRefreshed() bool {
+	return desc.IncrementalRefresh != nil
+}
+
 // IsPhysicalTable implements the TableDescriptor interface.
 func (desc *TableDescriptor) IsPhysicalTable() bool {
 	return desc.IsSequence() || (desc.IsTable() && !desc.IsVirtualTable()) || desc.MaterializedView()
@@ -323,6 +336,11 @@ func (u *UniqueWithoutIndexConstraint) IsPartial() bool {
 	return u.Predicate != ""
 }
 
+// IsExclusion returns true if the constraint is an exclusion constraint.
+func (u *UniqueWithoutIndexConstraint) IsExclusion() bool {
+	return len(u.ExclusionOperators) > 0
+}
+
 // GetParentID implements the catalog.NameKeyHaver interface.
 func (ni NameInfo) GetParentID() ID {
 	return ni.ParentID
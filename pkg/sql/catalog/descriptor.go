@@ -312,6 +312,13 @@ type TableDescriptor interface {
 	IsPhysicalTable() bool
 	// MaterializedView returns whether this TableDescriptor is a MaterializedView.
 	MaterializedView() bool
+	// IsForeignTable returns whether this TableDescriptor is a foreign table,
+	// whose rows are read from a file in external storage. A foreign table is
+	// neither a table nor a view.
+	IsForeignTable() bool
+	// IsIncrementallyRefreshed returns whether this TableDescriptor is a
+	// materialized view which is maintained incrementally.
+	IsIncrementallyRefreshed() bool
 	// IsAs returns true if the TableDescriptor describes a Table that was created
 	// with a CREATE TABLE AS command.
 	IsAs() bool
@@ -319,6 +326,12 @@ type TableDescriptor interface {
 	// GetSequenceOpts returns the sequence options for this table. Only valid if
 	// IsSequence is true.
 	GetSequenceOpts() *descpb.TableDescriptor_SequenceOpts
+	// GetForeignTable returns the foreign server, file and options of this
+	// table. Only valid if IsForeignTable is true.
+	GetForeignTable() *descpb.TableDescriptor_ForeignTable
+	// GetIncrementalRefresh returns the incremental maintenance state of this
+	// materialized view. Only valid if IsIncrementallyRefreshed is true.
+	GetIncrementalRefresh() *descpb.TableDescriptor_IncrementalRefresh
 
 	// GetCreateQuery returns the full CREATE TABLE AS query that was used for
 	// table's creation. Only valid if IsAs is true.
@@ -588,6 +601,15 @@ type TableDescriptor interface {
 	// GetDependsOnTypes returns the IDs of all types that this view depends on.
 	// It's only non-nil if IsView is true.
 	GetDependsOnTypes() []descpb.ID
+	// GetTriggers returns the triggers defined on this table, in the order in
+	// which they fire.
+	GetTriggers() []descpb.TableDescriptor_Trigger
+	// GetInherits returns the IDs of the tables that this table inherits from,
+	// in order.
+	GetInherits() []descpb.ID
+	// GetInheritedBy returns the IDs of the tables that inherit from this
+	// table.
+	GetInheritedBy() []descpb.ID
 
 	// AllConstraints returns all constraints in this table, regardless if
 	// they're enforced yet or not. The ordering of the constraints within this
@@ -765,6 +787,11 @@ type TypeDescriptor interface {
 	// nil otherwise.
 	AsCompositeTypeDescriptor() CompositeTypeDescriptor
 
+	// AsDomainTypeDescriptor returns this instance cast to
+	// DomainTypeDescriptor if this type is a domain type,
+	// nil otherwise.
+	AsDomainTypeDescriptor() DomainTypeDescriptor
+
 	// AsTableImplicitRecordTypeDescriptor returns this instance cast to
 	// TableImplicitRecordTypeDescriptor if this type is an implicit table record
 	// type, nil otherwise.
@@ -852,6 +879,32 @@ type CompositeTypeDescriptor interface {
 	GetElementType(ordinal int) *types.T
 }
 
+// DomainTypeDescriptor is the TypeDescriptor subtype for domain types.
+type DomainTypeDescriptor interface {
+	NonAliasTypeDescriptor
+
+	// BaseType returns the type underlying the domain.
+	BaseType() *types.T
+
+	// IsNotNull returns true if the domain does not allow NULL values.
+	IsNotNull() bool
+
+	// GetDefaultExpr returns the serialized default expression of the domain,
+	// or nil if it has none.
+	GetDefaultExpr() *string
+
+	// NumChecks returns the number of CHECK constraints on the domain.
+	NumChecks() int
+
+	// GetCheckName returns the name of the CHECK constraint at the given
+	// ordinal.
+	GetCheckName(ordinal int) string
+
+	// GetCheckExpr returns the serialized expression of the CHECK constraint
+	// at the given ordinal.
+	GetCheckExpr(ordinal int) string
+}
+
 // TableImplicitRecordTypeDescriptor is the TypeDescriptor subtype for the
 // record type implicitly defined by a table.
 type TableImplicitRecordTypeDescriptor interface {
@@ -899,6 +952,13 @@ type FunctionDescriptor interface {
 	// GetNullInputBehavior returns the function's attribute on null inputs.
 	GetNullInputBehavior() catpb.Function_NullInputBehavior
 
+	// GetIsProcedure returns true if the descriptor is for a procedure.
+	GetIsProcedure() bool
+
+	// GetAggregate returns the definition of a user-defined aggregate, or nil
+	// if the descriptor is not for an aggregate.
+	GetAggregate() *descpb.FunctionDescriptor_Aggregate
+
 	// GetFunctionBody returns the function body string.
 	GetFunctionBody() string
 
@@ -924,6 +984,10 @@ type FunctionDescriptor interface {
 	// GetLanguage returns the language of this function.
 	GetLanguage() catpb.Function_Language
 
+	// IsVariadic returns true if the last parameter of the function is
+	// declared VARIADIC.
+	IsVariadic() bool
+
 	// ToCreateExpr converts a function descriptor back to a CREATE FUNCTION
 	// statement. This is mainly used for formatting, e.g. SHOW CREATE FUNCTION.
 	ToCreateExpr() (*tree.CreateFunction, error)
@@ -172,10 +172,28 @@ func (desc *immutable) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, dep := range desc.DependedOnBy {
 		ret.Add(dep.ID)
 	}
+	for _, id := range desc.aggregateFuncIDs() {
+		ret.Add(id)
+	}
 
 	return ret, nil
 }
 
+// aggregateFuncIDs returns the IDs of the user-defined functions referenced by
+// an aggregate descriptor.
+func (desc *immutable) aggregateFuncIDs() []descpb.ID {
+	if desc.Aggregate == nil {
+		return nil
+	}
+	var ret []descpb.ID
+	for _, o := range []oid.Oid{desc.Aggregate.TransitionFunc, desc.Aggregate.FinalFunc} {
+		if id := UserDefinedFunctionOIDToID(o); id != descpb.InvalidID {
+			ret = append(ret, id)
+		}
+	}
+	return ret
+}
+
 // ValidateSelf implements the catalog.Descriptor interface.
 func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	vea.Report(catalog.ValidateName(desc))
@@ -198,6 +216,8 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	// Validate types are properly set.
 	if desc.ReturnType.Type == nil {
 		vea.Report(errors.AssertionFailedf("return type not set"))
+	} else if desc.IsProcedure && (desc.ReturnType.Type.Family() != types.VoidFamily || desc.ReturnType.ReturnSet) {
+		vea.Report(errors.AssertionFailedf("procedure has return type %s", desc.ReturnType.Type.SQLString()))
 	}
 	for i, param := range desc.Params {
 		if param.Type == nil {
@@ -207,6 +227,18 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 
 	vea.Report(CheckLeakProofVolatility(desc))
 
+	if agg := desc.Aggregate; agg != nil {
+		if desc.IsProcedure {
+			vea.Report(errors.AssertionFailedf("aggregate is marked as a procedure"))
+		}
+		if agg.StateType == nil {
+			vea.Report(errors.AssertionFailedf("aggregate state type not set"))
+		}
+		if agg.TransitionFunc == 0 {
+			vea.Report(errors.AssertionFailedf("aggregate transition function not set"))
+		}
+	}
+
 	for i, dep := range desc.DependedOnBy {
 		if dep.ID == descpb.InvalidID {
 			vea.Report(errors.AssertionFailedf("invalid relation id %d in depended-on-by references #%d", dep.ID, i))
@@ -253,6 +285,16 @@ func (desc *immutable) ValidateForwardReferences(
 	for _, typeID := range desc.DependsOnTypes {
 		vea.Report(catalog.ValidateOutboundTypeRef(typeID, vdg))
 	}
+
+	for _, fnID := range desc.aggregateFuncIDs() {
+		fn, err := vdg.GetFunctionDescriptor(fnID)
+		if err != nil {
+			vea.Report(errors.NewAssertionErrorWithWrappedErrf(err, "invalid aggregate support function reference"))
+		} else if fn.Dropped() {
+			vea.Report(errors.AssertionFailedf("aggregate support function %q (%d) is dropped",
+				fn.GetName(), fn.GetID()))
+		}
+	}
 }
 
 // ValidateBackReferences implements the catalog.Descriptor interface.
@@ -274,13 +316,54 @@ func (desc *immutable) ValidateBackReferences(
 		vea.Report(catalog.ValidateOutboundTypeRefBackReference(desc.GetID(), typ))
 	}
 
-	// Currently, we don't support cross function references yet.
-	// So here we assume that all inbound references are from tables.
+	for _, fnID := range desc.aggregateFuncIDs() {
+		fn, _ := vdg.GetFunctionDescriptor(fnID)
+		if fn == nil {
+			continue
+		}
+		found := false
+		for _, by := range fn.GetDependedOnBy() {
+			if by.ID == desc.GetID() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			vea.Report(errors.AssertionFailedf("aggregate support function %q (%d) has no corresponding "+
+				"depended-on-by back reference", fn.GetName(), fn.GetID()))
+		}
+	}
+
+	// Inbound references are either from tables or, for aggregate support
+	// functions, from the aggregates using them.
 	for _, by := range desc.DependedOnBy {
+		if d, err := vdg.GetDescriptor(by.ID); err == nil && d.DescriptorType() == catalog.Function {
+			vea.Report(desc.validateInboundAggregateRef(by, vdg))
+			continue
+		}
 		vea.Report(desc.validateInboundTableRef(by, vdg))
 	}
 }
 
+func (desc *immutable) validateInboundAggregateRef(
+	by descpb.FunctionDescriptor_Reference, vdg catalog.ValidationDescGetter,
+) error {
+	backRefFn, err := vdg.GetFunctionDescriptor(by.ID)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err, "invalid depended-on-by function back reference")
+	}
+	if backRefFn.Dropped() {
+		return errors.AssertionFailedf("depended-on-by function %q (%d) is dropped",
+			backRefFn.GetName(), backRefFn.GetID())
+	}
+	fnOID := catid.FuncIDToOID(desc.GetID())
+	if agg := backRefFn.GetAggregate(); agg != nil && (agg.TransitionFunc == fnOID || agg.FinalFunc == fnOID) {
+		return nil
+	}
+	return errors.AssertionFailedf("depended-on-by function %q (%d) does not use function %q (%d)",
+		backRefFn.GetName(), by.ID, desc.GetName(), desc.GetID())
+}
+
 func (desc *immutable) validateFuncExistsInSchema(scDesc catalog.SchemaDescriptor) error {
 	// Check that parent Schema contains the matching function signature.
 	if _, ok := scDesc.GetFunction(desc.GetName()); !ok {
@@ -333,6 +416,18 @@ func (desc *immutable) validateInboundTableRef(
 		}
 	}
 
+	// A table references the functions of its triggers from the triggers
+	// themselves rather than from its depends-on references.
+	if len(by.TriggerIDs) > 0 {
+		for _, trigID := range by.TriggerIDs {
+			if !tableHasTrigger(backRefTbl, trigID, desc.GetID()) {
+				return errors.AssertionFailedf("depended-on-by relation %q (%d) does not have a trigger "+
+					"with ID %d that executes this function", backRefTbl.GetName(), by.ID, trigID)
+			}
+		}
+		return nil
+	}
+
 	for _, id := range backRefTbl.GetDependsOn() {
 		if id == desc.GetID() {
 			return nil
@@ -342,6 +437,17 @@ func (desc *immutable) validateInboundTableRef(
 		backRefTbl.GetName(), by.ID)
 }
 
+// tableHasTrigger returns whether the table has a trigger with the given ID
+// that executes the given function.
+func tableHasTrigger(tbl catalog.TableDescriptor, trigID descpb.TriggerID, fnID descpb.ID) bool {
+	for _, trig := range tbl.GetTriggers() {
+		if trig.ID == trigID {
+			return trig.FuncID == fnID
+		}
+	}
+	return false
+}
+
 // ValidateTxnCommit implements the catalog.Descriptor interface.
 func (desc *immutable) ValidateTxnCommit(
 	vea catalog.ValidationErrorAccumulator, vdg catalog.ValidationDescGetter,
@@ -373,14 +479,14 @@ func (desc *immutable) GetRawBytesInStorage() []byte {
 // ForEachUDTDependentForHydration implements the catalog.Descriptor interface.
 func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error) error {
 	for _, p := range desc.Params {
-		if !catid.IsOIDUserDefined(p.Type.Oid()) {
+		if !p.Type.UserDefined() {
 			continue
 		}
 		if err := fn(p.Type); err != nil {
 			return iterutil.Map(err)
 		}
 	}
-	if !catid.IsOIDUserDefined(desc.ReturnType.Type.Oid()) {
+	if !desc.ReturnType.Type.UserDefined() {
 		return nil
 	}
 	return iterutil.Map(fn(desc.ReturnType.Type))
@@ -493,6 +599,16 @@ func (desc *Mutable) SetFuncBody(v string) {
 	desc.FunctionBody = v
 }
 
+// SetIsProcedure sets whether the descriptor is for a procedure.
+func (desc *Mutable) SetIsProcedure(v bool) {
+	desc.IsProcedure = v
+}
+
+// SetAggregate sets the definition of a user-defined aggregate.
+func (desc *Mutable) SetAggregate(agg *descpb.FunctionDescriptor_Aggregate) {
+	desc.Aggregate = agg
+}
+
 // SetName sets the function name.
 func (desc *Mutable) SetName(n string) {
 	desc.Name = n
@@ -534,21 +650,34 @@ func (desc *immutable) GetLanguage() catpb.Function_Language {
 
 func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	ret = &tree.Overload{
-		Oid:        catid.FuncIDToOID(desc.ID),
-		ReturnType: tree.FixedReturnType(desc.ReturnType.Type),
-		ReturnSet:  desc.ReturnType.ReturnSet,
-		Body:       desc.FunctionBody,
-		IsUDF:      true,
+		Oid:         catid.FuncIDToOID(desc.ID),
+		ReturnSet:   desc.ReturnType.ReturnSet,
+		Body:        desc.FunctionBody,
+		Language:    desc.getCreateExprLang(),
+		IsUDF:       true,
+		IsProcedure: desc.IsProcedure,
 	}
 
 	argTypes := make(tree.ParamTypes, 0, len(desc.Params))
+	hasOutParams := false
 	for _, param := range desc.Params {
 		argTypes = append(
 			argTypes,
 			tree.ParamType{Name: param.Name, Typ: param.Type},
 		)
+		switch param.Class {
+		case catpb.Function_Param_OUT, catpb.Function_Param_IN_OUT:
+			hasOutParams = true
+		}
+	}
+	if hasOutParams {
+		ret.ParamClasses = make([]tree.FuncParamClass, len(desc.Params))
+		for i := range desc.Params {
+			ret.ParamClasses[i] = toTreeNodeParamClass(desc.Params[i].Class)
+		}
 	}
-	ret.Types = argTypes
+	ret.Types = tree.MakeUDFParamTypes(argTypes, desc.IsVariadic())
+	ret.ReturnType = tree.PolymorphicReturnType(ret.Types, desc.ReturnType.Type)
 	ret.Volatility, err = desc.getOverloadVolatility()
 	if err != nil {
 		return nil, err
@@ -557,10 +686,25 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	if err != nil {
 		return nil, err
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Class = tree.AggregateClass
+		ret.UDFAggregate = &tree.UDFAggregate{
+			TransitionFunc: agg.TransitionFunc,
+			FinalFunc:      agg.FinalFunc,
+			StateType:      agg.StateType,
+			InitCond:       agg.InitCond,
+		}
+	}
 
 	return ret, nil
 }
 
+// IsVariadic implements the FunctionDescriptor interface.
+func (desc *immutable) IsVariadic() bool {
+	n := len(desc.Params)
+	return n > 0 && desc.Params[n-1].Class == catpb.Function_Param_VARIADIC
+}
+
 func (desc *immutable) getOverloadVolatility() (volatility.V, error) {
 	var ret volatility.V
 	switch desc.Volatility {
@@ -599,7 +743,8 @@ func (desc *immutable) calledOnNullInput() (bool, error) {
 // ToCreateExpr implements the FunctionDescriptor interface.
 func (desc *immutable) ToCreateExpr() (ret *tree.CreateFunction, err error) {
 	ret = &tree.CreateFunction{
-		FuncName: tree.MakeFunctionNameFromPrefix(tree.ObjectNamePrefix{}, tree.Name(desc.Name)),
+		IsProcedure: desc.IsProcedure,
+		FuncName:    tree.MakeFunctionNameFromPrefix(tree.ObjectNamePrefix{}, tree.Name(desc.Name)),
 		ReturnType: tree.FuncReturnType{
 			Type:  desc.ReturnType.Type,
 			IsSet: desc.ReturnType.ReturnSet,
@@ -622,9 +767,13 @@ func (desc *immutable) ToCreateExpr() (ret *tree.CreateFunction, err error) {
 	// We only store 5 function attributes at the moment. We may extend the
 	// pre-allocated capacity in the future.
 	ret.Options = make(tree.FunctionOptions, 0, 5)
-	ret.Options = append(ret.Options, desc.getCreateExprVolatility())
-	ret.Options = append(ret.Options, tree.FunctionLeakproof(desc.LeakProof))
-	ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
+	// Procedures do not accept volatility, leakproof or null input behavior
+	// attributes.
+	if !desc.IsProcedure {
+		ret.Options = append(ret.Options, desc.getCreateExprVolatility())
+		ret.Options = append(ret.Options, tree.FunctionLeakproof(desc.LeakProof))
+		ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
+	}
 	ret.Options = append(ret.Options, tree.FunctionBodyStr(desc.FunctionBody))
 	ret.Options = append(ret.Options, desc.getCreateExprLang())
 	return ret, nil
@@ -634,6 +783,8 @@ func (desc *immutable) getCreateExprLang() tree.FunctionLanguage {
 	switch desc.Lang {
 	case catpb.Function_SQL:
 		return tree.FunctionLangSQL
+	case catpb.Function_PLPGSQL:
+		return tree.FunctionLangPLpgSQL
 	}
 	return 0
 }
@@ -54,6 +54,8 @@ func FunctionLangToProto(v tree.FunctionLanguage) (catpb.Function_Language, erro
 	switch v {
 	case tree.FunctionLangSQL:
 		return catpb.Function_SQL, nil
+	case tree.FunctionLangPLpgSQL:
+		return catpb.Function_PLPGSQL, nil
 	}
 
 	return -1, pgerror.Newf(pgcode.InvalidParameterValue, "Unknown function language %q", v)
@@ -173,6 +173,25 @@ func TableDescs(
 			}
 		}
 
+		// Triggers that execute functions which are not being restored are
+		// dropped.
+		origTriggers := table.Triggers
+		table.Triggers = nil
+		for _, trig := range origTriggers {
+			if trig.FuncID != descpb.InvalidID {
+				fnRewrite, ok := descriptorRewrites[trig.FuncID]
+				if !ok {
+					continue
+				}
+				trig.FuncID = fnRewrite.ID
+			}
+			table.Triggers = append(table.Triggers, trig)
+		}
+		// Inheritance relationships with tables that are not being restored are
+		// dropped, along with the missing tables.
+		table.Inherits = rewriteIDs(table.Inherits, descriptorRewrites)
+		table.InheritedBy = rewriteIDs(table.InheritedBy, descriptorRewrites)
+
 		// Rewrite unique_without_index in both `UniqueWithoutIndexConstraints`
 		// and `Mutations` slice.
 		origUniqueWithoutIndexConstraints := table.UniqueWithoutIndexConstraints
@@ -269,6 +288,18 @@ func TableDescs(
 	return nil
 }
 
+// rewriteIDs rewrites the given descriptor IDs, omitting those which have no
+// rewrite.
+func rewriteIDs(ids []descpb.ID, descriptorRewrites jobspb.DescRewriteMap) []descpb.ID {
+	var ret []descpb.ID
+	for _, id := range ids {
+		if rewrite, ok := descriptorRewrites[id]; ok {
+			ret = append(ret, rewrite.ID)
+		}
+	}
+	return ret
+}
+
 func makeDBNameReplaceFunc(newDB string) func(ctx *tree.FmtCtx, tn *tree.TableName) {
 	return func(ctx *tree.FmtCtx, tn *tree.TableName) {
 		// empty catalog e.g. ``"".information_schema.tables` should stay empty.
@@ -742,6 +773,32 @@ func FunctionDescs(
 					fnDesc.Name, typID)
 			}
 		}
+
+		// Rewrite the support functions of an aggregate and the aggregates
+		// depending on this function.
+		if agg := fnDesc.Aggregate; agg != nil {
+			if err := rewriteIDsInTypesT(agg.StateType, descriptorRewrites); err != nil {
+				return err
+			}
+			for _, o := range []*oid.Oid{&agg.TransitionFunc, &agg.FinalFunc} {
+				if !funcdesc.IsOIDUserDefinedFunc(*o) {
+					continue
+				}
+				fnID := funcdesc.UserDefinedFunctionOIDToID(*o)
+				fnRewrite, ok := descriptorRewrites[fnID]
+				if !ok {
+					return errors.AssertionFailedf(
+						"cannot restore aggregate %q because referenced function %d was not found",
+						fnDesc.Name, fnID)
+				}
+				*o = catid.FuncIDToOID(fnRewrite.ID)
+			}
+		}
+		for i := range fnDesc.DependedOnBy {
+			if rewrite, ok := descriptorRewrites[fnDesc.DependedOnBy[i].ID]; ok {
+				fnDesc.DependedOnBy[i].ID = rewrite.ID
+			}
+		}
 	}
 	return nil
 }
@@ -82,14 +82,14 @@ func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error
 	for _, f := range desc.Functions {
 		for _, fo := range f.Overloads {
 			for _, typ := range fo.ArgTypes {
-				if !catid.IsOIDUserDefined(typ.Oid()) {
+				if !typ.UserDefined() {
 					continue
 				}
 				if err := fn(typ); err != nil {
 					return iterutil.Map(err)
 				}
 			}
-			if !catid.IsOIDUserDefined(fo.ReturnType.Oid()) {
+			if !fo.ReturnType.UserDefined() {
 				continue
 			}
 			if err := fn(fo.ReturnType); err != nil {
@@ -493,15 +493,14 @@ func (desc *immutable) GetResolvedFuncDefinition(
 		Overloads: make([]tree.QualifiedOverload, 0, len(funcDescPb.Overloads)),
 	}
 	for i := range funcDescPb.Overloads {
-		retType := funcDescPb.Overloads[i].ReturnType
 		overload := &tree.Overload{
-			Oid: catid.FuncIDToOID(funcDescPb.Overloads[i].ID),
-			ReturnType: func(args []tree.TypedExpr) *types.T {
-				return retType
-			},
+			Oid:                      catid.FuncIDToOID(funcDescPb.Overloads[i].ID),
 			IsUDF:                    true,
 			UDFContainsOnlySignature: true,
 		}
+		if funcDescPb.Overloads[i].IsAggregate {
+			overload.Class = tree.AggregateClass
+		}
 		paramTypes := make(tree.ParamTypes, 0, len(funcDescPb.Overloads[i].ArgTypes))
 		for _, paramType := range funcDescPb.Overloads[i].ArgTypes {
 			paramTypes = append(
@@ -509,7 +508,8 @@ func (desc *immutable) GetResolvedFuncDefinition(
 				tree.ParamType{Typ: paramType},
 			)
 		}
-		overload.Types = paramTypes
+		overload.Types = tree.MakeUDFParamTypes(paramTypes, funcDescPb.Overloads[i].IsVariadic)
+		overload.ReturnType = tree.PolymorphicReturnType(overload.Types, funcDescPb.Overloads[i].ReturnType)
 		prefixedOverload := tree.MakeQualifiedOverload(desc.GetName(), overload)
 		funcDef.Overloads = append(funcDef.Overloads, prefixedOverload)
 	}
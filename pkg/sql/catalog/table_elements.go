@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -226,6 +227,12 @@ type Index interface {
 	// index.
 	InvertedColumnKind() catpb.InvertedIndexColumnKind
 
+	// VectorConfig returns the configuration of the IVF lists that the vectors
+	// of a vector inverted index are partitioned into.
+	//
+	// Panics if the index is not inverted.
+	VectorConfig() vector.IVFConfig
+
 	NumPrimaryStoredColumns() int
 	NumSecondaryStoredColumns() int
 	GetStoredColumnID(storedColumnOrdinal int) descpb.ColumnID
@@ -578,6 +585,10 @@ type ForeignKeyConstraint interface {
 
 	// Match returns the type of algorithm used to match composite keys.
 	Match() semenumpb.Match
+
+	// Deferrability returns whether the checking of this foreign key may be
+	// deferred until the end of the transaction.
+	Deferrability() semenumpb.ConstraintDeferrability
 }
 
 // UniqueWithoutIndexConstraint is an interface around a unique constraint
@@ -591,6 +602,19 @@ type UniqueWithoutIndexConstraint interface {
 
 	// ParentTableID returns the ID of the table this constraint applies to.
 	ParentTableID() descpb.ID
+
+	// IsExclusion returns true iff this is an exclusion constraint, in which
+	// case rows conflict when the operators returned by ExclusionOperators
+	// all hold, rather than when all key columns are equal.
+	IsExclusion() bool
+
+	// ExclusionOperators returns the comparison operators of an exclusion
+	// constraint, one per key column, or nil if IsExclusion is false.
+	ExclusionOperators() []string
+
+	// Deferrability returns whether the checking of this constraint may be
+	// deferred until the end of the transaction.
+	Deferrability() semenumpb.ConstraintDeferrability
 }
 
 // PrimaryKeySwap is an interface around a primary key swap mutation.
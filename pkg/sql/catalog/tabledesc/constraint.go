@@ -146,12 +146,32 @@ func (c uniqueWithoutIndexConstraint) ParentTableID() descpb.ID {
 	return c.desc.TableID
 }
 
-// IsValidReferencedUniqueConstraint implements the catalog.UniqueConstraint
+// IsExclusion implements the catalog.UniqueWithoutIndexConstraint interface.
+func (c uniqueWithoutIndexConstraint) IsExclusion() bool {
+	return c.desc.IsExclusion()
+}
+
+// ExclusionOperators implements the catalog.UniqueWithoutIndexConstraint
+// interface.
+func (c uniqueWithoutIndexConstraint) ExclusionOperators() []string {
+	return c.desc.ExclusionOperators
+}
+
+// Deferrability implements the catalog.UniqueWithoutIndexConstraint
 // interface.
+func (c uniqueWithoutIndexConstraint) Deferrability() semenumpb.ConstraintDeferrability {
+	return c.desc.Deferrability
+}
+
+// IsValidReferencedUniqueConstraint implements the catalog.UniqueConstraint
+// interface. As in Postgres, a deferrable unique constraint cannot be
+// referenced by a foreign key.
 func (c uniqueWithoutIndexConstraint) IsValidReferencedUniqueConstraint(
 	fk catalog.ForeignKeyConstraint,
 ) bool {
-	return !c.IsPartial() && descpb.ColumnIDs(c.desc.ColumnIDs).PermutationOf(fk.ForeignKeyDesc().ReferencedColumnIDs)
+	return !c.IsPartial() && !c.IsExclusion() &&
+		c.desc.Deferrability == semenumpb.ConstraintDeferrability_NOT_DEFERRABLE &&
+		descpb.ColumnIDs(c.desc.ColumnIDs).PermutationOf(fk.ForeignKeyDesc().ReferencedColumnIDs)
 }
 
 // NumKeyColumns implements the catalog.UniqueConstraint interface.
@@ -292,6 +312,11 @@ func (c foreignKeyConstraint) Match() semenumpb.Match {
 	return c.desc.Match
 }
 
+// Deferrability implements the catalog.ForeignKeyConstraint interface.
+func (c foreignKeyConstraint) Deferrability() semenumpb.ConstraintDeferrability {
+	return c.desc.Deferrability
+}
+
 // GetConstraintID implements the catalog.Constraint interface.
 func (c foreignKeyConstraint) GetConstraintID() descpb.ConstraintID {
 	return c.desc.ConstraintID
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -222,6 +223,14 @@ func (w index) InvertedColumnKind() catpb.InvertedIndexColumnKind {
 	return w.desc.InvertedColumnKinds[0]
 }
 
+// VectorConfig returns the configuration of the IVF lists that the vectors of
+// a vector inverted index are partitioned into.
+//
+// Panics if the index is not inverted.
+func (w index) VectorConfig() vector.IVFConfig {
+	return w.desc.VectorConfig()
+}
+
 // CollectKeyColumnIDs creates a new set containing the column IDs in the key
 // of this index.
 func (w index) CollectKeyColumnIDs() catalog.TableColSet {
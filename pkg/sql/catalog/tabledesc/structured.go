@@ -300,6 +300,15 @@ func ForEachExprStringInTableDesc(descI catalog.TableDescriptor, f func(expr *st
 		}
 	}
 
+	// Process the WHEN conditions of triggers.
+	for i := range desc.Triggers {
+		if trig := &desc.Triggers[i]; trig.WhenExpr != "" {
+			if err := f(&trig.WhenExpr); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Process all non-index mutations.
 	for _, mut := range desc.Mutations {
 		if c := mut.GetColumn(); c != nil {
@@ -2312,6 +2321,9 @@ func (desc *wrapper) GetStorageParams(spaceBetweenEqual bool) []string {
 	if enabled, ok := desc.ForecastStatsEnabled(); ok {
 		appendStorageParam(`sql_stats_forecasts_enabled`, strconv.FormatBool(enabled))
 	}
+	if desc.IsIncrementallyRefreshed() {
+		appendStorageParam(`incremental`, `true`)
+	}
 	return storageParams
 }
 
@@ -511,6 +511,15 @@ func RenameColumnInTable(
 		}
 	}
 
+	// Rename the column in the WHEN conditions of triggers.
+	for i := range tableDesc.Triggers {
+		if trig := &tableDesc.Triggers[i]; trig.WhenExpr != "" {
+			if err := renameInExpr(&trig.WhenExpr); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Do all of the above renames inside check constraints, computed expressions,
 	// and idx predicates that are in mutations.
 	for i := range tableDesc.Mutations {
@@ -109,6 +109,19 @@ func (desc *wrapper) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, ref := range desc.GetDependedOnBy() {
 		ids.Add(ref.ID)
 	}
+	// Add inheritance parents and children.
+	for _, id := range desc.GetInherits() {
+		ids.Add(id)
+	}
+	for _, id := range desc.GetInheritedBy() {
+		ids.Add(id)
+	}
+	// Add trigger functions.
+	for i := range desc.Triggers {
+		if id := desc.Triggers[i].FuncID; id != descpb.InvalidID {
+			ids.Add(id)
+		}
+	}
 	// Add sequence dependencies
 	return ids, nil
 }
@@ -210,6 +223,18 @@ func (desc *wrapper) ValidateForwardReferences(
 		vea.Report(desc.validateOutboundFK(&desc.OutboundFKs[i], vdg))
 	}
 
+	// Check inheritance parents.
+	for _, id := range desc.Inherits {
+		vea.Report(catalog.ValidateOutboundTableRef(id, vdg))
+	}
+
+	// Check trigger functions.
+	for i := range desc.Triggers {
+		if id := desc.Triggers[i].FuncID; id != descpb.InvalidID {
+			vea.Report(desc.validateOutboundTriggerFuncRef(id, vdg))
+		}
+	}
+
 	// Check partitioning is correctly set.
 	// We only check these for active indexes, as inactive indexes may be in the
 	// process of being backfilled without PartitionAllBy.
@@ -264,6 +289,25 @@ func (desc *wrapper) ValidateBackReferences(
 		}
 	}
 
+	// Check that inheritance parents and children reference this table.
+	for _, id := range desc.Inherits {
+		vea.Report(desc.validateInheritanceRef(id, vdg, false /* isParent */))
+	}
+	for _, id := range desc.InheritedBy {
+		vea.Report(desc.validateInheritanceRef(id, vdg, true /* isParent */))
+	}
+
+	// Check that trigger functions reference the triggers.
+	for i := range desc.Triggers {
+		if id := desc.Triggers[i].FuncID; id != descpb.InvalidID {
+			fn, _ := vdg.GetFunctionDescriptor(id)
+			if fn == nil {
+				continue
+			}
+			vea.Report(desc.validateTriggerFuncBackReference(&desc.Triggers[i], fn))
+		}
+	}
+
 	// Check relation back-references to relations and functions.
 	for _, by := range desc.DependedOnBy {
 		depDesc, err := vdg.GetDescriptor(by.ID)
@@ -286,6 +330,33 @@ func (desc *wrapper) ValidateBackReferences(
 	}
 }
 
+// validateInheritanceRef checks that the table with the given ID, which is a
+// child of this table if isParent is true and a parent of this table
+// otherwise, has the matching reference to this table.
+func (desc *wrapper) validateInheritanceRef(
+	id descpb.ID, vdg catalog.ValidationDescGetter, isParent bool,
+) error {
+	other, err := vdg.GetTableDescriptor(id)
+	if err != nil {
+		if isParent {
+			return errors.NewAssertionErrorWithWrappedErrf(err, "invalid inherited-by back reference")
+		}
+		// The forward reference is validated in ValidateForwardReferences.
+		return nil
+	}
+	ids, refName := other.GetInheritedBy(), "inherited-by back reference"
+	if isParent {
+		ids, refName = other.GetInherits(), "inherits reference"
+	}
+	for _, otherID := range ids {
+		if otherID == desc.GetID() {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("table %q (%d) has no corresponding %s",
+		other.GetName(), other.GetID(), refName)
+}
+
 func (desc *wrapper) validateOutboundTypeRef(id descpb.ID, vdg catalog.ValidationDescGetter) error {
 	typ, err := vdg.GetTypeDescriptor(id)
 	if err != nil {
@@ -321,6 +392,37 @@ func (desc *wrapper) validateOutboundFuncRefBackReference(ref catalog.FunctionDe
 		ref.GetName(), ref.GetID())
 }
 
+func (desc *wrapper) validateOutboundTriggerFuncRef(
+	id descpb.ID, vdg catalog.ValidationDescGetter,
+) error {
+	fn, err := vdg.GetFunctionDescriptor(id)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err, "invalid trigger function reference")
+	}
+	if fn.Dropped() {
+		return errors.AssertionFailedf("trigger function %q (%d) is dropped",
+			fn.GetName(), fn.GetID())
+	}
+	return nil
+}
+
+func (desc *wrapper) validateTriggerFuncBackReference(
+	trig *descpb.TableDescriptor_Trigger, fn catalog.FunctionDescriptor,
+) error {
+	for _, dep := range fn.GetDependedOnBy() {
+		if dep.ID != desc.GetID() {
+			continue
+		}
+		for _, id := range dep.TriggerIDs {
+			if id == trig.ID {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("function %q (%d) of trigger %q has no corresponding "+
+		"depended-on-by back reference", fn.GetName(), fn.GetID(), trig.Name)
+}
+
 func (desc *wrapper) validateInboundFunctionRef(
 	by descpb.TableDescriptor_Reference, vdg catalog.ValidationDescGetter,
 ) error {
@@ -427,6 +529,10 @@ func (desc *wrapper) validateOutboundFKBackReference(
 
 	for _, backref := range referencedTable.InboundForeignKeys() {
 		if backref.GetOriginTableID() == desc.ID && backref.GetName() == fk.Name {
+			if backref.Deferrability() != fk.Deferrability {
+				return errors.AssertionFailedf("fk back reference %q to %q from %q has deferrability %s, expected %s",
+					fk.Name, desc.Name, referencedTable.GetName(), backref.Deferrability(), fk.Deferrability)
+			}
 			return nil
 		}
 	}
@@ -594,6 +700,34 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 				"has depends-on-types references despite not being a view"))
 		}
 	}
+	if desc.IsForeignTable() {
+		if desc.IsView() {
+			vea.Report(errors.AssertionFailedf(
+				"is a foreign table despite being a view"))
+		}
+		if desc.IsSequence() {
+			vea.Report(errors.AssertionFailedf(
+				"is a foreign table despite being a sequence"))
+		}
+		if len(desc.Indexes) > 0 || desc.PrimaryIndex.ID != 0 {
+			vea.Report(errors.AssertionFailedf(
+				"is a foreign table despite having indexes"))
+		}
+	}
+	if desc.IsIncrementallyRefreshed() {
+		if !desc.MaterializedView() {
+			vea.Report(errors.AssertionFailedf(
+				"is incrementally refreshed despite not being a materialized view"))
+		}
+		// The dependencies of a view are removed when it is dropped.
+		dependsOn := catalog.MakeDescriptorIDSet(desc.DependsOn...)
+		for _, id := range desc.IncrementalRefresh.TableIDs {
+			if !dependsOn.Contains(id) && !desc.Dropped() {
+				vea.Report(errors.AssertionFailedf(
+					"incrementally refreshed view refers to relation %d without depending on it", id))
+			}
+		}
+	}
 
 	desc.validateAutoStatsSettings(vea)
 
@@ -699,6 +833,7 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			desc.validateColumnFamilies(columnsByID),
 			desc.validateCheckConstraints(columnsByID),
 			desc.validateUniqueWithoutIndexConstraints(columnsByID),
+			desc.validateTriggers(columnsByID),
 			desc.validateTableIndexes(columnsByID),
 			desc.validatePartitioning(),
 		}
@@ -1137,6 +1272,40 @@ func (desc *wrapper) validateCheckConstraints(
 // validateUniqueWithoutIndexConstraints validates that unique without index
 // constraints are well formed. Checks include validating the column IDs and
 // column names.
+// validateTriggers validates that the triggers are well formed and sorted by
+// name.
+func (desc *wrapper) validateTriggers(columnsByID map[descpb.ColumnID]catalog.Column) error {
+	for i := range desc.Triggers {
+		trig := &desc.Triggers[i]
+		if len(trig.Name) == 0 {
+			return pgerror.Newf(pgcode.Syntax, "empty trigger name")
+		}
+		if i > 0 && desc.Triggers[i-1].Name >= trig.Name {
+			return errors.AssertionFailedf("triggers %q and %q are not sorted by name",
+				desc.Triggers[i-1].Name, trig.Name)
+		}
+		if trig.ID == 0 || trig.ID >= desc.NextTriggerID {
+			return errors.AssertionFailedf("trigger %q has invalid ID %d", trig.Name, trig.ID)
+		}
+		if (trig.FuncID == descpb.InvalidID) == (trig.FuncName == "") {
+			return errors.AssertionFailedf(
+				"trigger %q must have either a function ID or a builtin function name", trig.Name)
+		}
+		if len(trig.Events) == 0 {
+			return errors.AssertionFailedf("trigger %q has no events", trig.Name)
+		}
+		for j := range trig.Events {
+			for _, colID := range trig.Events[j].ColumnIDs {
+				if _, ok := columnsByID[colID]; !ok {
+					return errors.AssertionFailedf("trigger %q contains unknown column \"%d\"",
+						trig.Name, colID)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 	columnsByID map[descpb.ColumnID]catalog.Column,
 ) error {
@@ -1171,6 +1340,16 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 			seen.Add(int(colID))
 		}
 
+		if c.IsExclusion() && len(c.ExclusionOperators()) != c.NumKeyColumns() {
+			return errors.Newf(
+				"exclusion constraint %q has %d operators for %d columns",
+				c.GetName(), len(c.ExclusionOperators()), c.NumKeyColumns(),
+			)
+		}
+		if c.IsExclusion() && c.Deferrability() != semenumpb.ConstraintDeferrability_NOT_DEFERRABLE {
+			return errors.Newf("exclusion constraint %q cannot be deferrable", c.GetName())
+		}
+
 		if c.IsPartial() {
 			expr, err := parser.ParseExpr(c.GetPredicate())
 			if err != nil {
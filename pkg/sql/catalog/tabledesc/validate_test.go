@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
@@ -134,6 +135,10 @@ var validationMap = []struct {
 			"AutoStatsSettings":             {status: iSolemnlySwearThisFieldIsValidated},
 			"ForecastStats":                 {status: thisFieldReferencesNoObjects},
 			"ImportStartWallTime":           {status: thisFieldReferencesNoObjects},
+			"Inherits":                      {status: iSolemnlySwearThisFieldIsValidated},
+			"InheritedBy":                   {status: iSolemnlySwearThisFieldIsValidated},
+			"ForeignTable":                  {status: iSolemnlySwearThisFieldIsValidated},
+			"IncrementalRefresh":            {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -177,6 +182,8 @@ var validationMap = []struct {
 			"UseDeletePreservingEncoding": {status: thisFieldReferencesNoObjects},
 			"ConstraintID":                {status: iSolemnlySwearThisFieldIsValidated},
 			"CreatedAtNanos":              {status: thisFieldReferencesNoObjects},
+			"VectorLists":                 {status: thisFieldReferencesNoObjects},
+			"VectorCentroids":             {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
@@ -226,17 +233,20 @@ var validationMap = []struct {
 			"OnUpdate":          {status: thisFieldReferencesNoObjects},
 			"Match":             {status: thisFieldReferencesNoObjects},
 			"ConstraintID":      {status: iSolemnlySwearThisFieldIsValidated},
+			"Deferrability":     {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
 		obj: descpb.UniqueWithoutIndexConstraint{},
 		fieldMap: map[string]validationStatusInfo{
-			"TableID":      {status: iSolemnlySwearThisFieldIsValidated},
-			"ColumnIDs":    {status: iSolemnlySwearThisFieldIsValidated},
-			"Name":         {status: thisFieldReferencesNoObjects},
-			"Validity":     {status: thisFieldReferencesNoObjects},
-			"Predicate":    {status: iSolemnlySwearThisFieldIsValidated},
-			"ConstraintID": {status: iSolemnlySwearThisFieldIsValidated},
+			"TableID":            {status: iSolemnlySwearThisFieldIsValidated},
+			"ColumnIDs":          {status: iSolemnlySwearThisFieldIsValidated},
+			"Name":               {status: thisFieldReferencesNoObjects},
+			"Validity":           {status: thisFieldReferencesNoObjects},
+			"Predicate":          {status: iSolemnlySwearThisFieldIsValidated},
+			"ConstraintID":       {status: iSolemnlySwearThisFieldIsValidated},
+			"ExclusionOperators": {status: iSolemnlySwearThisFieldIsValidated},
+			"Deferrability":      {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -260,6 +270,7 @@ var validationMap = []struct {
 			"RegionConfig":                  {status: iSolemnlySwearThisFieldIsValidated},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
 			"Composite":                     {status: iSolemnlySwearThisFieldIsValidated},
+			"Domain":                        {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -326,6 +337,8 @@ var validationMap = []struct {
 			"ModificationTime":              {status: thisFieldReferencesNoObjects},
 			"Version":                       {status: thisFieldReferencesNoObjects},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
+			"IsProcedure":                   {status: iSolemnlySwearThisFieldIsValidated},
+			"Aggregate":                     {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 }
@@ -1269,6 +1282,63 @@ func TestValidateTableDesc(t *testing.T) {
 					},
 				},
 			}},
+		{`exclusion constraint "bar_exclude" has 2 operators for 1 columns`,
+			descpb.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: descpb.InterleavedFormatVersion,
+				Columns: []descpb.ColumnDescriptor{
+					{ID: 1, Name: "bar"},
+				},
+				Families: []descpb.ColumnFamilyDescriptor{
+					{ID: 0, Name: "primary",
+						ColumnIDs:   []descpb.ColumnID{1},
+						ColumnNames: []string{"bar"},
+					},
+				},
+				NextColumnID:     2,
+				NextFamilyID:     1,
+				NextConstraintID: 2,
+				UniqueWithoutIndexConstraints: []descpb.UniqueWithoutIndexConstraint{
+					{
+						TableID:            2,
+						ConstraintID:       1,
+						ColumnIDs:          []descpb.ColumnID{1},
+						Name:               "bar_exclude",
+						ExclusionOperators: []string{"=", "&&"},
+					},
+				},
+			}},
+		{`exclusion constraint "bar_exclude" cannot be deferrable`,
+			descpb.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: descpb.InterleavedFormatVersion,
+				Columns: []descpb.ColumnDescriptor{
+					{ID: 1, Name: "bar"},
+				},
+				Families: []descpb.ColumnFamilyDescriptor{
+					{ID: 0, Name: "primary",
+						ColumnIDs:   []descpb.ColumnID{1},
+						ColumnNames: []string{"bar"},
+					},
+				},
+				NextColumnID:     2,
+				NextFamilyID:     1,
+				NextConstraintID: 2,
+				UniqueWithoutIndexConstraints: []descpb.UniqueWithoutIndexConstraint{
+					{
+						TableID:            2,
+						ConstraintID:       1,
+						ColumnIDs:          []descpb.ColumnID{1},
+						Name:               "bar_exclude",
+						ExclusionOperators: []string{"&&"},
+						Deferrability:      semenumpb.ConstraintDeferrability_INITIALLY_DEFERRED,
+					},
+				},
+			}},
 		{`empty constraint name`,
 			descpb.TableDescriptor{
 				ID:            2,
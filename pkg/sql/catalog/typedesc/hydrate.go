@@ -149,4 +149,17 @@ func ensureTypeMetadataIsHydrated(
 			tm.EnumData.IsMemberReadOnly[i] = e.IsMemberReadOnly(i)
 		}
 	}
+	if d := maybeDesc.AsDomainTypeDescriptor(); d != nil {
+		n := d.NumChecks()
+		tm.DomainData = &types.DomainMetadata{
+			NotNull:     d.IsNotNull(),
+			DefaultExpr: d.GetDefaultExpr(),
+			CheckNames:  make([]string, n),
+			CheckExprs:  make([]string, n),
+		}
+		for i := 0; i < n; i++ {
+			tm.DomainData.CheckNames[i] = d.GetCheckName(i)
+			tm.DomainData.CheckExprs[i] = d.GetCheckExpr(i)
+		}
+	}
 }
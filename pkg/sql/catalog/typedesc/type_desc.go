@@ -43,6 +43,7 @@ var _ catalog.EnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.RegionEnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.AliasTypeDescriptor = (*immutable)(nil)
 var _ catalog.CompositeTypeDescriptor = (*immutable)(nil)
+var _ catalog.DomainTypeDescriptor = (*immutable)(nil)
 var _ catalog.TypeDescriptor = (*Mutable)(nil)
 var _ catalog.MutableDescriptor = (*Mutable)(nil)
 
@@ -146,7 +147,7 @@ func UserDefinedTypeOIDToID(oid oid.Oid) descpb.ID {
 
 // GetUserDefinedTypeDescID gets the type descriptor ID from a user defined type.
 func GetUserDefinedTypeDescID(t *types.T) descpb.ID {
-	return UserDefinedTypeOIDToID(t.Oid())
+	return UserDefinedTypeOIDToID(t.UserDefinedOID())
 }
 
 // GetUserDefinedArrayTypeDescID gets the ID of the array type descriptor from a user
@@ -508,6 +509,22 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.Composite == nil {
 			vea.Report(errors.AssertionFailedf("COMPOSITE type desc has nil composite type"))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		if desc.Domain == nil || desc.Domain.BaseType == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil base type"))
+			break
+		}
+		if desc.Domain.BaseType.UserDefined() {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has user-defined base type %s",
+				desc.Domain.BaseType.String()))
+		}
+		names := make(map[string]struct{}, len(desc.Domain.Checks))
+		for _, c := range desc.Domain.Checks {
+			if _, ok := names[c.Name]; ok {
+				vea.Report(errors.AssertionFailedf("duplicate domain constraint name %q", c.Name))
+			}
+			names[c.Name] = struct{}{}
+		}
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -633,7 +650,7 @@ func (desc *immutable) ValidateBackReferences(
 ) {
 
 	// Validate that the backward-referenced types exist.
-	if e := desc.AsEnumTypeDescriptor(); e != nil {
+	if e := desc.asArrayHavingTypeDescriptor(); e != nil {
 		// Ensure that the array type exists.
 		// This is considered to be a backward reference, not a forward reference,
 		// as the element type doesn't need the array type to exist, but the
@@ -768,6 +785,12 @@ func (desc *immutable) AsTypesT() *types.T {
 			contents,
 			labels,
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		return types.MakeDomain(
+			catid.TypeIDToOID(desc.GetID()),
+			catid.TypeIDToOID(desc.ArrayTypeID),
+			desc.Domain.BaseType,
+		)
 	}
 	panic(errors.AssertionFailedf("unsupported descriptor kind %s", desc.Kind.String()))
 }
@@ -884,7 +907,7 @@ func (desc *immutable) GetRawBytesInStorage() []byte {
 
 // ForEachUDTDependentForHydration implements the catalog.Descriptor interface.
 func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error) error {
-	if desc.Alias != nil && catid.IsOIDUserDefined(desc.Alias.Oid()) {
+	if desc.Alias != nil && desc.Alias.UserDefined() {
 		if err := fn(desc.Alias); err != nil {
 			return iterutil.Map(err)
 		}
@@ -893,7 +916,7 @@ func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error
 		return nil
 	}
 	for _, e := range desc.Composite.Elements {
-		if !catid.IsOIDUserDefined(e.ElementType.Oid()) {
+		if !e.ElementType.UserDefined() {
 			continue
 		}
 		if err := fn(e.ElementType); err != nil {
@@ -985,6 +1008,26 @@ func (desc *immutable) AsCompositeTypeDescriptor() catalog.CompositeTypeDescript
 	return nil
 }
 
+// AsDomainTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (desc *immutable) AsDomainTypeDescriptor() catalog.DomainTypeDescriptor {
+	if desc.Kind == descpb.TypeDescriptor_DOMAIN {
+		return desc
+	}
+	return nil
+}
+
+// asArrayHavingTypeDescriptor returns this instance as a
+// NonAliasTypeDescriptor if it has an array type which must exist.
+func (desc *immutable) asArrayHavingTypeDescriptor() catalog.NonAliasTypeDescriptor {
+	if e := desc.AsEnumTypeDescriptor(); e != nil {
+		return e
+	}
+	if d := desc.AsDomainTypeDescriptor(); d != nil {
+		return d
+	}
+	return nil
+}
+
 // AsTableImplicitRecordTypeDescriptor implements the catalog.TypeDescriptor
 // interface.
 func (desc *immutable) AsTableImplicitRecordTypeDescriptor() catalog.TableImplicitRecordTypeDescriptor {
@@ -1011,6 +1054,36 @@ func (desc *immutable) GetElementType(ordinal int) *types.T {
 	return desc.Composite.Elements[ordinal].ElementType
 }
 
+// BaseType implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) BaseType() *types.T {
+	return desc.Domain.BaseType
+}
+
+// IsNotNull implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) IsNotNull() bool {
+	return desc.Domain.NotNull
+}
+
+// GetDefaultExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetDefaultExpr() *string {
+	return desc.Domain.DefaultExpr
+}
+
+// NumChecks implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) NumChecks() int {
+	return len(desc.Domain.Checks)
+}
+
+// GetCheckName implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetCheckName(ordinal int) string {
+	return desc.Domain.Checks[ordinal].Name
+}
+
+// GetCheckExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetCheckExpr(ordinal int) string {
+	return desc.Domain.Checks[ordinal].Expr
+}
+
 // ForEachRegionInSuperRegion implements the catalog.RegionEnumTypeDescriptor
 // interface.
 func (desc *immutable) ForEachRegionInSuperRegion(
@@ -502,12 +502,10 @@ func (p *planner) RevalidateUniqueConstraint(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.GetName() == constraintName {
-			return validateUniqueConstraint(
+			return validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc,
 				0, /* indexIDForValidation */
 				p.InternalSQLTxn(),
 				p.User(),
@@ -582,12 +580,10 @@ func RevalidateUniqueConstraintsInTable(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.IsConstraintValidated() {
-			if err := validateUniqueConstraint(
+			if err := validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc,
 				0, /* indexIDForValidation */
 				txn,
 				user,
@@ -603,6 +599,186 @@ func RevalidateUniqueConstraintsInTable(
 	return nil
 }
 
+// validateUniqueWithoutIndexConstraint verifies that all the rows in the
+// srcTable satisfy the given UNIQUE WITHOUT INDEX or exclusion constraint.
+func validateUniqueWithoutIndexConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc catalog.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	if uc.IsExclusion() {
+		return validateExclusionConstraint(
+			ctx, srcTable, uc, indexIDForValidation, txn, user, preExisting,
+		)
+	}
+	return validateUniqueConstraint(
+		ctx,
+		srcTable,
+		uc.GetName(),
+		uc.CollectKeyColumnIDs().Ordered(),
+		uc.GetPredicate(),
+		indexIDForValidation,
+		txn,
+		user,
+		preExisting,
+	)
+}
+
+// conflictingRowQuery generates and returns a SELECT query that returns a pair
+// of distinct rows of srcTbl that conflict under the given exclusion
+// constraint, if any. For example, for the constraint
+// EXCLUDE (a WITH =, b WITH &&) on a table with primary key k, the query is:
+//
+// SELECT l.a, l.b, r.a, r.b
+// FROM (SELECT a, b, k FROM [<id of srcTbl> AS tbl] WHERE a IS NOT NULL AND b IS NOT NULL) AS l,
+// (SELECT a, b, k FROM [<id of srcTbl> AS tbl] WHERE a IS NOT NULL AND b IS NOT NULL) AS r
+// WHERE l.a = r.a AND l.b && r.b AND (l.k) != (r.k)
+// LIMIT 1
+//
+// If the constraint is partial, its predicate is added to the WHERE clauses
+// of the subqueries.
+func conflictingRowQuery(
+	srcTbl catalog.TableDescriptor,
+	uc catalog.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+) (sql string, colNames []string, _ error) {
+	colIDs := make([]descpb.ColumnID, uc.NumKeyColumns())
+	for i := range colIDs {
+		colIDs[i] = uc.GetKeyColumnID(i)
+	}
+	colNames, err := catalog.ColumnNamesForIDs(srcTbl, colIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	pkColNames, err := catalog.ColumnNamesForIDs(
+		srcTbl, srcTbl.GetPrimaryIndex().IndexDesc().KeyColumnIDs,
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	srcCols := make([]string, len(colNames))
+	srcWhere := make([]string, 0, len(colNames)+1)
+	leftCols := make([]string, 0, 2*len(colNames))
+	conflictConds := make([]string, len(colNames))
+	for i, n := range colNames {
+		srcCols[i] = tree.NameString(n)
+		srcWhere = append(srcWhere, fmt.Sprintf("%s IS NOT NULL", srcCols[i]))
+		leftCols = append(leftCols, "l."+srcCols[i])
+		conflictConds[i] = fmt.Sprintf(
+			"l.%[1]s %[2]s r.%[1]s", srcCols[i], uc.ExclusionOperators()[i],
+		)
+	}
+	rightCols := make([]string, len(colNames))
+	for i := range srcCols {
+		rightCols[i] = "r." + srcCols[i]
+	}
+	if pred := uc.GetPredicate(); pred != "" {
+		srcWhere = append(srcWhere, fmt.Sprintf("(%s)", pred))
+	}
+
+	// The primary key columns are needed to prevent rows from conflicting with
+	// themselves.
+	scanCols := append([]string(nil), srcCols...)
+	leftPK := make([]string, len(pkColNames))
+	rightPK := make([]string, len(pkColNames))
+	for i, n := range pkColNames {
+		name := tree.NameString(n)
+		leftPK[i], rightPK[i] = "l."+name, "r."+name
+		found := false
+		for _, c := range scanCols {
+			if c == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			scanCols = append(scanCols, name)
+		}
+	}
+
+	src := fmt.Sprintf("[%d AS tbl]", srcTbl.GetID())
+	if indexIDForValidation != 0 {
+		src = fmt.Sprintf("%s@[%d]", src, indexIDForValidation)
+	}
+	subquery := fmt.Sprintf(
+		"(SELECT %s FROM %s WHERE %s)",
+		strings.Join(scanCols, ", "), src, strings.Join(srcWhere, " AND "),
+	)
+	query := fmt.Sprintf(
+		`SELECT %[1]s FROM %[2]s AS l, %[2]s AS r WHERE %[3]s AND (%[4]s) != (%[5]s) LIMIT 1`,
+		strings.Join(append(leftCols, rightCols...), ", "), // 1
+		subquery,                             // 2
+		strings.Join(conflictConds, " AND "), // 3
+		strings.Join(leftPK, ", "),           // 4
+		strings.Join(rightPK, ", "),          // 5
+	)
+	return query, colNames, nil
+}
+
+// validateExclusionConstraint verifies that no two rows in the srcTable
+// conflict under the given exclusion constraint. See validateUniqueConstraint
+// for a description of the arguments.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc catalog.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	query, colNames, err := conflictingRowQuery(srcTable, uc, indexIDForValidation)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		uc.GetName(),
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	sessionDataOverride := sessiondata.NoSessionDataOverride
+	sessionDataOverride.User = user
+	values, err := txn.QueryRowEx(ctx, "validate exclusion constraint", txn.KV(), sessionDataOverride, query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		n := len(colNames)
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add an exclusion constraint due to conflicting keys.
+		errMsg := "could not create exclusion constraint"
+		if preExisting {
+			errMsg = "failed to validate exclusion constraint"
+		}
+		cols := strings.Join(colNames, ", ")
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.ExclusionViolation, "%s %q", errMsg, uc.GetName(),
+				),
+				uc.GetName(),
+			),
+			fmt.Sprintf(
+				"Key (%s)=(%s) conflicts with key (%s)=(%s).",
+				cols, strings.Join(valuesStr[:n], ", "), cols, strings.Join(valuesStr[n:], ", "),
+			),
+		)
+	}
+	return nil
+}
+
 // validateUniqueConstraint verifies that all the rows in the srcTable
 // have unique values for the given columns.
 //
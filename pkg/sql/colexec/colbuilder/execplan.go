@@ -226,6 +226,11 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 					return errDefaultAggregateWindowFunction
 				}
 			}
+			if wf.Func.WindowFunc != nil {
+				if !colexecwindow.WindowFnSupported(*wf.Func.WindowFunc) {
+					return errUnsupportedWindowFunction
+				}
+			}
 		}
 		return nil
 
@@ -259,6 +264,7 @@ var (
 	errNonInnerMergeJoinWithOnExpr    = errors.New("can't plan vectorized non-inner merge joins with ON expressions")
 	errWindowFunctionFilterClause     = errors.New("window functions with FILTER clause are not supported")
 	errDefaultAggregateWindowFunction = errors.New("default aggregate window functions not supported")
+	errUnsupportedWindowFunction      = errors.New("window function not supported natively")
 )
 
 func canWrap(mode sessiondatapb.VectorizeExecMode, core *execinfrapb.ProcessorCoreUnion) error {
@@ -2401,6 +2407,12 @@ func planProjectionOperators(
 		op = colexec.NewCaseOp(allocator, buffer, caseOps, elseOp, thenIdxs, caseOutputIdx, caseOutputType)
 		return op, caseOutputIdx, typs, err
 	case *tree.CastExpr:
+		if typ := t.ResolvedType(); typ.IsDomain() ||
+			(typ.Family() == types.ArrayFamily && typ.ArrayContents().IsDomain()) {
+			// The vectorized cast operators don't check the constraints of
+			// domains, so we fall back to the row-by-row engine.
+			return nil, resultIdx, nil, errors.Errorf("unhandled cast to domain %s", typ.SQLString())
+		}
 		expr := t.Expr.(tree.TypedExpr)
 		op, resultIdx, typs, err = planProjectionOperators(
 			ctx, evalCtx, expr, columnTypes, input, acc, factory, releasables,
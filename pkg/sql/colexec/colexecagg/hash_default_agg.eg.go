@@ -74,7 +74,7 @@ func (a *defaultHashAgg) Compute(
 }
 
 func (a *defaultHashAgg) Flush(outputIdx int) {
-	res, err := a.fn.Result()
+	res, err := eval.AggregateResult(a.ctx, a.fn)
 	if err != nil {
 		colexecerror.ExpectedError(err)
 	}
@@ -59,7 +59,7 @@ func (a *defaultOrderedAgg) Compute(
 				//gcassert:bce
 				if groups[tupleIdx] {
 					if !a.isFirstGroup {
-						res, err := a.fn.Result()
+						res, err := eval.AggregateResult(a.ctx, a.fn)
 						if err != nil {
 							colexecerror.ExpectedError(err)
 						}
@@ -91,7 +91,7 @@ func (a *defaultOrderedAgg) Compute(
 			for _, tupleIdx := range sel[startIdx:endIdx] {
 				if groups[tupleIdx] {
 					if !a.isFirstGroup {
-						res, err := a.fn.Result()
+						res, err := eval.AggregateResult(a.ctx, a.fn)
 						if err != nil {
 							colexecerror.ExpectedError(err)
 						}
@@ -125,7 +125,7 @@ func (a *defaultOrderedAgg) Flush(outputIdx int) {
 	_ = outputIdx
 	outputIdx = a.curIdx
 	a.curIdx++
-	res, err := a.fn.Result()
+	res, err := eval.AggregateResult(a.ctx, a.fn)
 	if err != nil {
 		colexecerror.ExpectedError(err)
 	}
@@ -138,7 +138,7 @@ func (a *defaultOrderedAgg) Flush(outputIdx int) {
 
 func (a *defaultOrderedAgg) HandleEmptyInputScalar() {
 	outputIdx := 0
-	res, err := a.fn.Result()
+	res, err := eval.AggregateResult(a.ctx, a.fn)
 	if err != nil {
 		colexecerror.ExpectedError(err)
 	}
@@ -1309,6 +1309,9 @@ func BenchmarkWindowFunctions(b *testing.B) {
 
 	for windowFnIdx := 0; windowFnIdx < len(execinfrapb.WindowerSpec_WindowFunc_name); windowFnIdx++ {
 		windowFn := execinfrapb.WindowerSpec_WindowFunc(windowFnIdx)
+		if !WindowFnSupported(windowFn) {
+			continue
+		}
 		numArgs := windowFnMaxNumArgs[windowFn]
 		runBench(execinfrapb.WindowerSpec_Func{WindowFunc: &windowFn}, windowFn.String(), numArgs)
 	}
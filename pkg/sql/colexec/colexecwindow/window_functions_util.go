@@ -58,6 +58,14 @@ var windowFnMaxNumArgs = map[execinfrapb.WindowerSpec_WindowFunc]int{
 	execinfrapb.WindowerSpec_NTH_VALUE:    2,
 }
 
+// WindowFnSupported returns whether there is a vectorized implementation of
+// the given window function. Unsupported window functions (like the spatial
+// clustering functions) are evaluated by the row-by-row windower instead.
+func WindowFnSupported(windowFn execinfrapb.WindowerSpec_WindowFunc) bool {
+	_, ok := windowFnMaxNumArgs[windowFn]
+	return ok
+}
+
 // WindowFnNeedsPeersInfo returns whether a window function pays attention to
 // the concept of "peers" during its computation ("peers" are tuples within the
 // same partition - from PARTITION BY clause - that are not distinct on the
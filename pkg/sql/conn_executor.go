@@ -21,15 +21,18 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/multitenant"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/multitenantcpu"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catsessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descidgen"
@@ -317,6 +320,9 @@ type Server struct {
 
 	idxRecommendationsCache *idxrecommendations.IndexRecCache
 
+	// notifications routes notifications between the sessions on this node.
+	notifications *notificationRegistry
+
 	mu struct {
 		syncutil.Mutex
 		connectionCount int64
@@ -406,6 +412,7 @@ func NewServer(cfg *ExecutorConfig, pool *mon.BytesMonitor) *Server {
 			cfg.Settings,
 			&serverMetrics.ContentionSubsystemMetrics),
 		idxRecommendationsCache: idxrecommendations.NewIndexRecommendationsCache(cfg.Settings),
+		notifications:           newNotificationRegistry(),
 	}
 
 	telemetryLoggingMetrics := &TelemetryLoggingMetrics{}
@@ -539,6 +546,8 @@ func (s *Server) Start(ctx context.Context, stopper *stop.Stopper) {
 	s.insights.Start(ctx, stopper)
 
 	s.txnIDCache.Start(ctx, stopper)
+
+	s.notifications.start(ctx, stopper, s.cfg.SQLStatusServer)
 }
 
 // GetSQLStatsController returns the persistedsqlstats.Controller for current
@@ -1014,6 +1023,7 @@ func (s *Server) newConnExecutor(
 		portals:   make(map[string]PreparedPortal),
 	}
 	ex.extraTxnState.prepStmtsNamespaceMemAcc = ex.sessionMon.MakeBoundAccount()
+	ex.extraTxnState.sqlCursors.mon = ex.sessionMon
 	dsdp := catsessiondata.NewDescriptorSessionDataStackProvider(sdMutIterator.sds)
 	ex.extraTxnState.descCollection = s.cfg.CollectionFactory.NewCollection(
 		ctx, descs.WithDescriptorSessionDataProvider(dsdp), descs.WithMonitor(ex.sessionMon),
@@ -1127,6 +1137,7 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 	}
 
 	ex.resetExtraTxnState(ctx, txnEvent{eventType: txnEvType})
+	ex.closeNotificationListener()
 	if ex.hasCreatedTemporarySchema && !ex.server.cfg.TestingKnobs.DisableTempObjectsCleanupOnSessionExit {
 		err := cleanupSessionTempObjects(
 			ctx,
@@ -1153,7 +1164,7 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 			ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc,
 		)
 		ex.extraTxnState.prepStmtsNamespaceMemAcc.Close(ctx)
-		if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
+		if err := ex.extraTxnState.sqlCursors.closeAll(ctx, false /* keepHoldable */); err != nil {
 			log.Warningf(ctx, "error closing cursors: %v", err)
 		}
 	}
@@ -1350,7 +1361,8 @@ type connExecutor struct {
 		// sqlCursors contains the list of SQL CURSORs the session currently has
 		// access to.
 		// Cursors are bound to an explicit transaction and they're all destroyed
-		// once the transaction finishes.
+		// once the transaction finishes, except for holdable cursors, whose
+		// results are materialized when the transaction commits.
 		sqlCursors cursorMap
 
 		// shouldExecuteOnTxnFinish indicates that ex.onTxnFinish will be called
@@ -1439,6 +1451,14 @@ type connExecutor struct {
 		// createdSequences keeps track of sequences created in the current transaction.
 		// The map key is the sequence descpb.ID.
 		createdSequences map[descpb.ID]struct{}
+
+		// deferredConstraints keeps track of SET CONSTRAINTS modes and of the
+		// deferred constraint checks that need to run before commit.
+		deferredConstraints deferredConstraintState
+
+		// notifications keeps track of the LISTEN, UNLISTEN and NOTIFY
+		// statements that take effect when the transaction commits.
+		notifications notificationTxnState
 	}
 
 	// sessionDataStack contains the user-configurable connection variables.
@@ -1541,6 +1561,10 @@ type connExecutor struct {
 	// temporary schema, which requires special cleanup on close.
 	hasCreatedTemporarySchema bool
 
+	// notificationListener delivers notifications to the client once the
+	// session has executed LISTEN. It is nil before that.
+	notificationListener *notificationListener
+
 	// stmtDiagnosticsRecorder is used to track which queries need to have
 	// information collected.
 	stmtDiagnosticsRecorder *stmtdiagnostics.Registry
@@ -1729,12 +1753,15 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) {
 		delete(ex.extraTxnState.prepStmtsNamespace.portals, name)
 	}
 
-	// Close all cursors.
-	if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
+	// Close all cursors, except for holdable cursors that outlive the
+	// transaction.
+	if err := ex.extraTxnState.sqlCursors.closeAll(ctx, true /* keepHoldable */); err != nil {
 		log.Warningf(ctx, "error closing cursors: %v", err)
 	}
 
 	ex.extraTxnState.createdSequences = make(map[descpb.ID]struct{})
+	ex.extraTxnState.deferredConstraints = deferredConstraintState{}
+	ex.extraTxnState.notifications = notificationTxnState{}
 
 	switch ev.eventType {
 	case txnCommit, txnRollback:
@@ -1743,6 +1770,9 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) {
 			delete(ex.extraTxnState.prepStmtsNamespaceAtTxnRewindPos.portals, name)
 		}
 		ex.extraTxnState.savepoints.clear()
+		if ex.notificationListener != nil {
+			ex.notificationListener.setInTxn(false)
+		}
 		ex.onTxnFinish(ctx, ev)
 	case txnRestart:
 		ex.onTxnRestart(ctx)
@@ -2706,9 +2736,17 @@ func (ex *connExecutor) setTransactionModes(
 			return err
 		}
 	}
-	if modes.Isolation != tree.UnspecifiedIsolation && modes.Isolation != tree.SerializableIsolation {
-		return errors.AssertionFailedf(
-			"unknown isolation level: %s", errors.Safe(modes.Isolation))
+	if modes.Isolation != tree.UnspecifiedIsolation {
+		if err := checkIsolationLevelActive(ctx, ex.server.cfg.Settings, modes.Isolation); err != nil {
+			return err
+		}
+		isoLevel, err := txnIsoLevelToKV(modes.Isolation)
+		if err != nil {
+			return err
+		}
+		if err := ex.state.setIsolationLevel(isoLevel); err != nil {
+			return err
+		}
 	}
 	rwMode := modes.ReadWriteMode
 	if modes.AsOf.Expr != nil && asOfTs.IsEmpty() {
@@ -2752,6 +2790,42 @@ func (ex *connExecutor) txnPriorityWithSessionDefault(mode tree.UserPriority) ro
 	return txnPriorityToProto(mode)
 }
 
+func txnIsoLevelToKV(level tree.IsolationLevel) (isolation.Level, error) {
+	switch level {
+	case tree.UnspecifiedIsolation, tree.SerializableIsolation:
+		return isolation.Serializable, nil
+	case tree.ReadCommittedIsolation:
+		return isolation.ReadCommitted, nil
+	default:
+		return 0, errors.AssertionFailedf("unknown isolation level: %s", errors.Safe(level))
+	}
+}
+
+// checkIsolationLevelActive returns an error if the given isolation level
+// cannot be used before the cluster is upgraded.
+func checkIsolationLevelActive(
+	ctx context.Context, st *cluster.Settings, level tree.IsolationLevel,
+) error {
+	if level == tree.ReadCommittedIsolation && !st.Version.IsActive(ctx, clusterversion.V23_1) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use READ COMMITTED isolation",
+			clusterversion.ByKey(clusterversion.V23_1))
+	}
+	return nil
+}
+
+func (ex *connExecutor) txnIsoLevelWithSessionDefault(level tree.IsolationLevel) isolation.Level {
+	if level == tree.UnspecifiedIsolation {
+		level = tree.IsolationLevel(ex.sessionData().DefaultTxnIsolationLevel)
+	}
+	isoLevel, err := txnIsoLevelToKV(level)
+	if err != nil {
+		// The parser and the session variables only produce known levels.
+		panic(err)
+	}
+	return isoLevel
+}
+
 // QualityOfService returns the QoSLevel session setting if the session
 // settings are populated, otherwise the default QoSLevel.
 func (ex *connExecutor) QualityOfService() sessiondatapb.QoSLevel {
@@ -2916,6 +2990,12 @@ func (ex *connExecutor) initPlanner(ctx context.Context, p *planner) {
 	p.preparedStatements = ex.getPrepStmtsAccessor()
 	p.sqlCursors = ex.getCursorAccessor()
 	p.createdSequences = ex.getCreatedSequencesAccessor()
+	p.deferredConstraints = nil
+	p.notifications = nil
+	if ex.executorType == executorTypeExec {
+		p.deferredConstraints = &ex.extraTxnState.deferredConstraints
+		p.notifications = &ex.extraTxnState.notifications
+	}
 
 	p.queryCacheSession.Init()
 	p.optPlanningCtx.init(p)
@@ -3005,6 +3085,9 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 	case txnStart:
 		ex.extraTxnState.firstStmtExecuted = false
 		ex.recordTransactionStart(advInfo.txnEvent.txnID)
+		if ex.notificationListener != nil {
+			ex.notificationListener.setInTxn(true)
+		}
 		// Start of the transaction, so no statements were executed earlier.
 		// Bump the txn counter for logging.
 		ex.extraTxnState.txnCounter++
@@ -3076,6 +3159,7 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 			}
 		}
 		ex.notifyStatsRefresherOfNewTables(ex.Ctx())
+		ex.commitNotifications(ex.Ctx())
 
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionStartPostCommitJob, timeutil.Now())
 		if err := ex.server.cfg.JobRegistry.Run(
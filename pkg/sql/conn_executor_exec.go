@@ -741,7 +741,7 @@ func (ex *connExecutor) execStmtInOpenState(
 		stmtCtx = ctx
 	}
 
-	if err := ex.dispatchToExecutionEngine(stmtCtx, p, res); err != nil {
+	if err := ex.dispatchToExecutionEngineWithStatementRetries(stmtCtx, p, res); err != nil {
 		stmtThresholdSpan.Finish()
 		return nil, nil, err
 	}
@@ -995,7 +995,7 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error
 	ctx, sp := tracing.EnsureChildSpan(ctx, ex.server.cfg.AmbientCtx.Tracer, "commit sql txn")
 	defer sp.Finish()
 
-	if err := ex.extraTxnState.sqlCursors.closeAll(true /* errorOnWithHold */); err != nil {
+	if err := ex.extraTxnState.sqlCursors.materializeHoldable(ctx); err != nil {
 		return err
 	}
 
@@ -1011,6 +1011,12 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error
 		ex.state.mu.txn.ConfigureStepping(ctx, prevSteppingMode)
 	}
 
+	if err := ex.extraTxnState.deferredConstraints.validatePending(
+		ctx, &ex.planner, true, /* all */
+	); err != nil {
+		return err
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
@@ -1044,6 +1050,7 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error
 	if err := ex.state.mu.txn.Commit(ctx); err != nil {
 		return err
 	}
+	ex.extraTxnState.sqlCursors.detachHoldable()
 
 	// Now that we've committed, if we modified any descriptor we need to make sure
 	// to release the leases for them so that the schema change can proceed and
@@ -1081,7 +1088,7 @@ func (ex *connExecutor) createJobs(ctx context.Context) error {
 func (ex *connExecutor) rollbackSQLTransaction(
 	ctx context.Context, stmt tree.Statement,
 ) (fsm.Event, fsm.EventPayload) {
-	if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
+	if err := ex.extraTxnState.sqlCursors.closeAll(ctx, true /* keepHoldable */); err != nil {
 		return ex.makeErrEvent(err, stmt)
 	}
 	if err := ex.state.mu.txn.Rollback(ctx); err != nil {
@@ -1097,6 +1104,87 @@ func (ex *connExecutor) rollbackSQLTransaction(
 	return eventTxnFinishAborted{}, nil
 }
 
+// maxStatementRetries is the number of times a statement of a transaction
+// that uses a new read snapshot for each statement is retried after a
+// retryable error before the error is returned to the connExecutor, which then
+// retries the whole transaction (if possible).
+const maxStatementRetries = 10
+
+// dispatchToExecutionEngineWithStatementRetries is like
+// dispatchToExecutionEngine, but for transactions that use a new read snapshot
+// for each statement (READ COMMITTED) it establishes that snapshot and retries
+// the statement, instead of the whole transaction, after write-write conflicts
+// and other retryable errors that allow it.
+//
+// Internal executors that run inside an outer transaction are excluded, since
+// moving the read snapshot of the outer transaction in the middle of one of
+// its statements would break that statement's snapshot.
+func (ex *connExecutor) dispatchToExecutionEngineWithStatementRetries(
+	ctx context.Context, planner *planner, res RestrictedCommandResult,
+) error {
+	txn := ex.state.mu.txn
+	if ex.executorType == executorTypeInternal || !txn.IsoLevel().PerStatementReadSnapshot() {
+		return ex.dispatchToExecutionEngine(ctx, planner, res)
+	}
+	for attempt := 0; ; attempt++ {
+		if err := txn.StepReadTimestamp(ctx); err != nil {
+			res.SetError(err)
+			return nil
+		}
+		savepoint, err := txn.CreateSavepoint(ctx)
+		if err != nil {
+			res.SetError(err)
+			return nil
+		}
+		if err := ex.dispatchToExecutionEngine(ctx, planner, res); err != nil {
+			return err
+		}
+		if attempt == maxStatementRetries || !ex.canRetryStatement(ctx, res) {
+			return nil
+		}
+		log.VEventf(ctx, 2, "retrying statement after retryable error: %v", res.Err())
+		if err := txn.RollbackToSavepoint(ctx, savepoint); err != nil {
+			res.SetError(err)
+			return nil
+		}
+		if err := txn.PrepareForPartialRetry(ctx); err != nil {
+			res.SetError(err)
+			return nil
+		}
+		if err := txn.Step(ctx); err != nil {
+			res.SetError(err)
+			return nil
+		}
+		res.SetError(nil)
+	}
+}
+
+// canRetryStatement returns whether the statement that produced the result
+// can be retried on its own. This requires a retryable error that allows a
+// statement retry, and that none of the statement's results have been
+// produced. Results that were buffered but not yet delivered to the client,
+// such as the row description, are discarded.
+func (ex *connExecutor) canRetryStatement(ctx context.Context, res RestrictedCommandResult) bool {
+	var retryErr *roachpb.TransactionRetryWithProtoRefreshError
+	if !errors.As(res.Err(), &retryErr) || !retryErr.StatementRetry {
+		return false
+	}
+	if res.RowsAffected() != 0 {
+		return false
+	}
+	_, pos, err := ex.stmtBuf.CurCmd()
+	if err != nil {
+		return false
+	}
+	cl := ex.clientComm.LockCommunication()
+	defer cl.Close()
+	if cl.ClientPos() >= pos {
+		return false
+	}
+	cl.RTrim(ctx, pos)
+	return true
+}
+
 // dispatchToExecutionEngine executes the statement, writes the result to res
 // and returns an event for the connection's state machine.
 //
@@ -1184,6 +1272,10 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 	var cols colinfo.ResultColumns
 	if stmt.AST.StatementReturnType() == tree.Rows {
 		cols = planner.curPlan.main.planColumns()
+		// FETCH on a BINARY cursor returns its rows in the binary format.
+		if f, ok := planner.curPlan.main.planNode.(*fetchNode); ok && f.cursor.binary {
+			res.UseBinaryFormat()
+		}
 	}
 	if err := ex.initStatementResult(ctx, res, stmt.AST, cols); err != nil {
 		res.SetError(err)
@@ -1712,6 +1804,9 @@ func (ex *connExecutor) execStmtInNoTxnState(
 				ex.incrementExecutedStmtCounter(ast)
 			}
 		}()
+		if err := checkIsolationLevelActive(ctx, ex.server.cfg.Settings, s.Modes.Isolation); err != nil {
+			return ex.makeErrEvent(err, s)
+		}
 		mode, sqlTs, historicalTs, err := ex.beginTransactionTimestampsAndReadMode(ctx, s)
 		if err != nil {
 			return ex.makeErrEvent(err, s)
@@ -1720,6 +1815,7 @@ func (ex *connExecutor) execStmtInNoTxnState(
 		return eventStartExplicitTxn,
 			makeEventTxnStartPayload(
 				ex.txnPriorityWithSessionDefault(s.Modes.UserPriority),
+				ex.txnIsoLevelWithSessionDefault(s.Modes.Isolation),
 				mode,
 				sqlTs,
 				historicalTs,
@@ -1743,6 +1839,7 @@ func (ex *connExecutor) execStmtInNoTxnState(
 		return eventStartImplicitTxn,
 			makeEventTxnStartPayload(
 				ex.txnPriorityWithSessionDefault(tree.UnspecifiedUserPriority),
+				ex.txnIsoLevelWithSessionDefault(tree.UnspecifiedIsolation),
 				mode,
 				sqlTs,
 				historicalTs,
@@ -1773,6 +1870,7 @@ func (ex *connExecutor) beginImplicitTxn(
 	return eventStartImplicitTxn,
 		makeEventTxnStartPayload(
 			ex.txnPriorityWithSessionDefault(tree.UnspecifiedUserPriority),
+			ex.txnIsoLevelWithSessionDefault(tree.UnspecifiedIsolation),
 			mode,
 			sqlTs,
 			historicalTs,
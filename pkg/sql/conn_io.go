@@ -739,6 +739,13 @@ type RestrictedCommandResult interface {
 	// This needs to be called (once) before AddRow.
 	SetColumns(context.Context, colinfo.ResultColumns)
 
+	// UseBinaryFormat makes the result encode its rows in the binary format,
+	// unless the client requested specific formats for them. This is used for
+	// FETCH statements on BINARY cursors.
+	//
+	// This needs to be called before SetColumns.
+	UseBinaryFormat()
+
 	// ResetStmtType allows a client to change the statement type of the current
 	// result, from the original one set when the result was created trough
 	// ClientComm.createStatementResult.
@@ -933,6 +940,12 @@ func (r *streamingCommandResult) SetColumns(ctx context.Context, cols colinfo.Re
 	_ = r.w.addResult(ctx, ieIteratorResult{cols: cols})
 }
 
+// UseBinaryFormat is part of the RestrictedCommandResult interface.
+func (r *streamingCommandResult) UseBinaryFormat() {
+	// Unimplemented: the internal executor returns datums, so there is no format
+	// to choose.
+}
+
 // BufferParamStatusUpdate is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) BufferParamStatusUpdate(key string, val string) {
 	// Unimplemented: the internal executor does not support status updated.
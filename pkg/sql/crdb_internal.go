@@ -2985,6 +2985,24 @@ CREATE TABLE crdb_internal.create_function_statements (
 			if err != nil {
 				return err
 			}
+			if fnDesc.GetAggregate() != nil {
+				createStmt, err := p.aggregateCreateStatement(ctx, fnDesc, fnIDToScName[fnDesc.GetID()])
+				if err != nil {
+					return err
+				}
+				if err := addRow(
+					tree.NewDInt(tree.DInt(fnIDToDBID[fnDesc.GetID()])), // database_id
+					tree.NewDString(fnIDToDBName[fnDesc.GetID()]),       // database_name
+					tree.NewDInt(tree.DInt(fnIDToScID[fnDesc.GetID()])), // schema_id
+					tree.NewDString(fnIDToScName[fnDesc.GetID()]),       // schema_name
+					tree.NewDInt(tree.DInt(fnDesc.GetID())),             // function_id
+					tree.NewDString(fnDesc.GetName()),                   // function_name
+					tree.NewDString(createStmt),                         // create_statement
+				); err != nil {
+					return err
+				}
+				continue
+			}
 			treeNode, err := fnDesc.ToCreateExpr()
 			treeNode.FuncName.ObjectNamePrefix = tree.ObjectNamePrefix{
 				ExplicitSchema: true,
@@ -2993,7 +3011,12 @@ CREATE TABLE crdb_internal.create_function_statements (
 			if err != nil {
 				return err
 			}
+			// The body of a PL/pgSQL function is not SQL, so it is shown as
+			// written.
 			for i := range treeNode.Options {
+				if fnDesc.GetLanguage() == catpb.Function_PLPGSQL {
+					break
+				}
 				if body, ok := treeNode.Options[i].(tree.FunctionBodyStr); ok {
 					typeReplacedBody, err := formatFunctionQueryTypesForDisplay(ctx, &p.semaCtx, p.SessionData(), string(body))
 					if err != nil {
@@ -3079,7 +3102,10 @@ CREATE TABLE crdb_internal.create_statements (
 		namePrefix := tree.ObjectNamePrefix{SchemaName: tree.Name(sc.GetName()), ExplicitSchema: true}
 		name := tree.MakeTableNameFromPrefix(namePrefix, tree.Name(table.GetName()))
 		var err error
-		if table.IsView() {
+		if table.IsForeignTable() {
+			descType = typeTable
+			stmt = ShowCreateForeignTable(&name, table)
+		} else if table.IsView() {
 			descType = typeView
 			stmt, err = ShowCreateView(ctx, &p.semaCtx, p.SessionData(), &name, table)
 		} else if table.IsSequence() {
@@ -3650,7 +3676,7 @@ CREATE TABLE crdb_internal.forward_dependencies (
 					)
 				}
 
-				if table.IsTable() || table.IsView() {
+				if table.IsTable() || table.IsView() || table.IsForeignTable() {
 					return table.ForeachDependedOnBy(func(dep *descpb.TableDescriptor_Reference) error {
 						return reportDependedOnBy(dep, viewDep)
 					})
@@ -0,0 +1,436 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+type createAggregateNode struct {
+	n *tree.CreateAggregate
+}
+
+// CreateAggregate creates a user-defined aggregate function.
+func (p *planner) CreateAggregate(ctx context.Context, n *tree.CreateAggregate) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE AGGREGATE",
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_1) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create aggregates",
+			clusterversion.ByKey(clusterversion.V23_1))
+	}
+	return &createAggregateNode{n: n}, nil
+}
+
+func (n *createAggregateNode) ReadingOwnWrites() {}
+
+// aggregateDefinition is the resolved form of the options of a CREATE
+// AGGREGATE statement.
+type aggregateDefinition struct {
+	paramTypes []*types.T
+	returnType *types.T
+	agg        descpb.FunctionDescriptor_Aggregate
+}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	un := n.n.FuncName.ToUnresolvedObjectName()
+	dbDesc, scDesc, _, err := params.p.ResolveTargetObject(params.ctx, un)
+	if err != nil {
+		return err
+	}
+	if err := params.p.canCreateOnSchema(
+		params.ctx, scDesc.GetID(), dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return err
+	}
+
+	def, err := params.p.resolveAggregateDefinition(params.ctx, n.n)
+	if err != nil {
+		return err
+	}
+
+	pbParams := make([]descpb.FunctionDescriptor_Parameter, len(n.n.Params))
+	for i, param := range n.n.Params {
+		if param.Class != tree.FunctionParamIn {
+			return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"aggregates can only have IN parameters")
+		}
+		pbParams[i], err = makeFunctionParam(params.ctx, param, params.p)
+		if err != nil {
+			return err
+		}
+	}
+
+	fuObj := tree.FuncObj{FuncName: n.n.FuncName, Params: n.n.Params}
+	existing, err := params.p.matchUDF(params.ctx, &fuObj, false /* required */)
+	if err != nil {
+		return err
+	}
+	var fnDesc *funcdesc.Mutable
+	if existing != nil {
+		if !n.n.Replace {
+			return pgerror.Newf(
+				pgcode.DuplicateFunction,
+				"function %q already exists with same argument types",
+				n.n.FuncName.Object(),
+			)
+		}
+		fnDesc, err = params.p.checkPrivilegesForDropFunction(
+			params.ctx, funcdesc.UserDefinedFunctionOIDToID(existing.Oid),
+		)
+		if err != nil {
+			return err
+		}
+		if fnDesc.Aggregate == nil {
+			return pgerror.New(pgcode.WrongObjectType, "cannot change routine kind")
+		}
+		if !def.returnType.Equal(fnDesc.ReturnType.Type) {
+			return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"cannot change return type of existing function")
+		}
+		if err := params.p.removeAggregateBackReferences(params.ctx, fnDesc); err != nil {
+			return err
+		}
+		jobDesc := fmt.Sprintf("updating type back reference %d for aggregate %d", fnDesc.DependsOnTypes, fnDesc.ID)
+		if err := params.p.removeTypeBackReferences(params.ctx, fnDesc.DependsOnTypes, fnDesc.ID, jobDesc); err != nil {
+			return err
+		}
+	} else {
+		id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+		if err != nil {
+			return err
+		}
+		privileges := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+			dbDesc.GetDefaultPrivilegeDescriptor(),
+			scDesc.GetDefaultPrivilegeDescriptor(),
+			dbDesc.GetID(),
+			params.SessionData().User(),
+			privilege.Functions,
+		)
+		desc := funcdesc.NewMutableFunctionDescriptor(
+			id,
+			dbDesc.GetID(),
+			scDesc.GetID(),
+			string(n.n.FuncName.ObjectName),
+			pbParams,
+			def.returnType,
+			false, /* returnSet */
+			privileges,
+		)
+		fnDesc = &desc
+	}
+
+	fnDesc.SetAggregate(&def.agg)
+	if err := params.p.addAggregateBackReferences(params.ctx, fnDesc); err != nil {
+		return err
+	}
+	typeDeps := catalog.DescriptorIDSet{}
+	for _, typ := range append(append([]*types.T(nil), def.paramTypes...), def.agg.StateType) {
+		if typ.UserDefined() {
+			typedesc.GetTypeDescriptorClosure(typ).ForEach(typeDeps.Add)
+		}
+	}
+	for _, id := range typeDeps.Ordered() {
+		jobDesc := fmt.Sprintf("updating type back reference %d for aggregate %d", id, fnDesc.ID)
+		if err := params.p.addTypeBackReference(params.ctx, id, fnDesc.ID, jobDesc); err != nil {
+			return err
+		}
+	}
+	fnDesc.DependsOnTypes = typeDeps.Ordered()
+
+	if existing != nil {
+		if err := params.p.writeFuncSchemaChange(params.ctx, fnDesc); err != nil {
+			return err
+		}
+	} else {
+		if err := params.p.createDescriptor(
+			params.ctx, fnDesc, tree.AsStringWithFQNames(&n.n.FuncName, params.Ann()),
+		); err != nil {
+			return err
+		}
+		mutScDesc, err := params.p.Descriptors().MutableByID(params.p.Txn()).Schema(params.ctx, scDesc.GetID())
+		if err != nil {
+			return err
+		}
+		mutScDesc.AddFunction(fnDesc.GetName(), toSchemaOverloadSignature(fnDesc))
+		if err := params.p.writeSchemaDescChange(params.ctx, mutScDesc, "Create Aggregate"); err != nil {
+			return err
+		}
+	}
+
+	fnName := tree.MakeQualifiedFunctionName(dbDesc.GetName(), scDesc.GetName(), n.n.FuncName.Object())
+	event := eventpb.CreateFunction{
+		FunctionName: fnName.FQString(),
+		IsReplace:    existing != nil,
+	}
+	return params.p.logEvent(params.ctx, fnDesc.GetID(), &event)
+}
+
+func (*createAggregateNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createAggregateNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createAggregateNode) Close(ctx context.Context)           {}
+
+// resolveAggregateDefinition validates the options of a CREATE AGGREGATE
+// statement and resolves the support functions they refer to.
+func (p *planner) resolveAggregateDefinition(
+	ctx context.Context, n *tree.CreateAggregate,
+) (*aggregateDefinition, error) {
+	var sfunc, ffunc *tree.FunctionName
+	var stype tree.ResolvableTypeReference
+	var initCond *string
+	for _, option := range n.Options {
+		switch t := option.(type) {
+		case tree.AggregateTransitionFunc:
+			if sfunc != nil {
+				return nil, errors.Wrapf(tree.ErrConflictingFunctionOption, "%s", tree.AsString(option))
+			}
+			sfunc = &t.Name
+		case tree.AggregateStateType:
+			if stype != nil {
+				return nil, errors.Wrapf(tree.ErrConflictingFunctionOption, "%s", tree.AsString(option))
+			}
+			stype = t.Type
+		case tree.AggregateFinalFunc:
+			if ffunc != nil {
+				return nil, errors.Wrapf(tree.ErrConflictingFunctionOption, "%s", tree.AsString(option))
+			}
+			ffunc = &t.Name
+		case tree.AggregateInitCond:
+			if initCond != nil {
+				return nil, errors.Wrapf(tree.ErrConflictingFunctionOption, "%s", tree.AsString(option))
+			}
+			s := string(t)
+			initCond = &s
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unknown aggregate option %q", t)
+		}
+	}
+	if sfunc == nil {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified")
+	}
+	if stype == nil {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified")
+	}
+	if len(n.Params) == 0 {
+		return nil, pgerror.New(pgcode.FeatureNotSupported, "aggregates with no arguments are not supported")
+	}
+
+	def := &aggregateDefinition{paramTypes: make([]*types.T, len(n.Params))}
+	for i, param := range n.Params {
+		typ, err := tree.ResolveType(ctx, param.Type, p)
+		if err != nil {
+			return nil, err
+		}
+		def.paramTypes[i] = typ
+	}
+	stateType, err := tree.ResolveType(ctx, stype, p)
+	if err != nil {
+		return nil, err
+	}
+	if stateType.IsAmbiguous() {
+		return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"aggregate state type cannot be %s", stateType.SQLString())
+	}
+	def.agg.StateType = stateType
+
+	// The transition function is called with the state followed by the
+	// aggregated arguments and must return the new state.
+	sfuncTypes := append([]*types.T{stateType}, def.paramTypes...)
+	sfuncOverload, err := p.resolveAggregateSupportFunction(ctx, sfunc, sfuncTypes)
+	if err != nil {
+		return nil, err
+	}
+	if sfuncRet := sfuncOverload.FixedReturnType(); !sfuncRet.Equivalent(stateType) {
+		return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"return type of transition function %s is not %s", sfunc.Object(), stateType.SQLString())
+	}
+	def.agg.TransitionFunc = sfuncOverload.Oid
+
+	def.returnType = stateType
+	if ffunc != nil {
+		ffuncOverload, err := p.resolveAggregateSupportFunction(ctx, ffunc, []*types.T{stateType})
+		if err != nil {
+			return nil, err
+		}
+		def.returnType = ffuncOverload.FixedReturnType()
+		def.agg.FinalFunc = ffuncOverload.Oid
+	}
+
+	if initCond != nil {
+		if _, _, err := tree.ParseAndRequireString(stateType, *initCond, p.EvalContext()); err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.InvalidFunctionDefinition,
+				"invalid initial value for aggregate state type %s", stateType.SQLString())
+		}
+		def.agg.InitCond = initCond
+	} else if !sfuncOverload.CalledOnNullInput &&
+		(len(def.paramTypes) == 0 || !def.paramTypes[0].Equivalent(stateType)) {
+		// A strict transition function is never called with a NULL state, so
+		// the first input value becomes the initial state. That is only
+		// possible if it has the state type.
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"must not omit initial value when transition function is strict "+
+				"and transition type is not compatible with input type")
+	}
+	return def, nil
+}
+
+// resolveAggregateSupportFunction resolves the transition or final function of
+// an aggregate with exactly the given parameter types.
+func (p *planner) resolveAggregateSupportFunction(
+	ctx context.Context, name *tree.FunctionName, paramTypes []*types.T,
+) (*tree.Overload, error) {
+	path := p.CurrentSearchPath()
+	fnDef, err := p.ResolveFunction(ctx, name.ToUnresolvedObjectName().ToUnresolvedName(), &path)
+	if err != nil {
+		return nil, err
+	}
+	ol, err := fnDef.MatchOverload(paramTypes, name.Schema(), &path)
+	if err != nil {
+		return nil, err
+	}
+	if ol.Class != tree.NormalClass || ol.IsProcedure || ol.ReturnSet || ol.IsGenerator() {
+		return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"%s%s cannot be used as an aggregate support function", fnDef.Name, ol.Signature(true /* simplify */))
+	}
+	// Signature-only overloads of user-defined functions do not carry the null
+	// input behavior, so fetch the full overload.
+	_, fullOverload, err := p.ResolveFunctionByOID(ctx, ol.Oid)
+	if err != nil {
+		return nil, err
+	}
+	return fullOverload, nil
+}
+
+// addAggregateBackReferences adds a back reference to the aggregate to each of
+// its user-defined support functions.
+func (p *planner) addAggregateBackReferences(ctx context.Context, aggDesc *funcdesc.Mutable) error {
+	return p.forEachAggregateSupportFunction(ctx, aggDesc, func(fnDesc *funcdesc.Mutable) {
+		for _, by := range fnDesc.DependedOnBy {
+			if by.ID == aggDesc.ID {
+				return
+			}
+		}
+		fnDesc.DependedOnBy = append(fnDesc.DependedOnBy, descpb.FunctionDescriptor_Reference{ID: aggDesc.ID})
+	})
+}
+
+// removeAggregateBackReferences removes the back references to the aggregate
+// from its user-defined support functions. It is a no-op if the descriptor is
+// not for an aggregate.
+func (p *planner) removeAggregateBackReferences(
+	ctx context.Context, aggDesc *funcdesc.Mutable,
+) error {
+	return p.forEachAggregateSupportFunction(ctx, aggDesc, func(fnDesc *funcdesc.Mutable) {
+		updated := fnDesc.DependedOnBy[:0]
+		for _, by := range fnDesc.DependedOnBy {
+			if by.ID != aggDesc.ID {
+				updated = append(updated, by)
+			}
+		}
+		fnDesc.DependedOnBy = updated
+	})
+}
+
+func (p *planner) forEachAggregateSupportFunction(
+	ctx context.Context, aggDesc *funcdesc.Mutable, fn func(fnDesc *funcdesc.Mutable),
+) error {
+	agg := aggDesc.Aggregate
+	if agg == nil {
+		return nil
+	}
+	var seen catalog.DescriptorIDSet
+	for _, o := range []oid.Oid{agg.TransitionFunc, agg.FinalFunc} {
+		if !funcdesc.IsOIDUserDefinedFunc(o) {
+			continue
+		}
+		id := funcdesc.UserDefinedFunctionOIDToID(o)
+		if seen.Contains(id) {
+			continue
+		}
+		seen.Add(id)
+		fnDesc, err := p.Descriptors().MutableByID(p.txn).Function(ctx, id)
+		if err != nil {
+			return err
+		}
+		fn(fnDesc)
+		if err := p.writeFuncSchemaChange(ctx, fnDesc); err != nil {
+			return errors.Wrapf(err, "updating back reference of aggregate %s(%d)", aggDesc.Name, aggDesc.ID)
+		}
+	}
+	return nil
+}
+
+// aggregateCreateStatement returns the CREATE AGGREGATE statement for an
+// aggregate function descriptor, with its support functions referenced by
+// name.
+func (p *planner) aggregateCreateStatement(
+	ctx context.Context, fnDesc catalog.FunctionDescriptor, scName string,
+) (string, error) {
+	agg := fnDesc.GetAggregate()
+	ret := &tree.CreateAggregate{
+		FuncName: tree.MakeFunctionNameFromPrefix(tree.ObjectNamePrefix{
+			ExplicitSchema: true,
+			SchemaName:     tree.Name(scName),
+		}, tree.Name(fnDesc.GetName())),
+	}
+	createExpr, err := fnDesc.ToCreateExpr()
+	if err != nil {
+		return "", err
+	}
+	ret.Params = createExpr.Params
+	supportFuncName := func(o oid.Oid) (tree.FunctionName, error) {
+		name, _, err := p.ResolveFunctionByOID(ctx, o)
+		if err != nil {
+			return tree.FunctionName{}, err
+		}
+		return tree.MakeFunctionNameFromPrefix(tree.ObjectNamePrefix{}, tree.Name(name)), nil
+	}
+	sfunc, err := supportFuncName(agg.TransitionFunc)
+	if err != nil {
+		return "", err
+	}
+	ret.Options = append(ret.Options,
+		tree.AggregateTransitionFunc{Name: sfunc},
+		tree.AggregateStateType{Type: agg.StateType},
+	)
+	if agg.FinalFunc != 0 {
+		ffunc, err := supportFuncName(agg.FinalFunc)
+		if err != nil {
+			return "", err
+		}
+		ret.Options = append(ret.Options, tree.AggregateFinalFunc{Name: ffunc})
+	}
+	if agg.InitCond != nil {
+		ret.Options = append(ret.Options, tree.AggregateInitCond(*agg.InitCond))
+	}
+	return tree.AsString(ret), nil
+}
@@ -54,6 +54,15 @@ func (n *createFunctionNode) startExec(params runParams) error {
 			"cannot run CREATE FUNCTION before system is fully upgraded to v22.2",
 		)
 	}
+	if !params.EvalContext().Settings.Version.IsActive(params.ctx, clusterversion.V23_1) {
+		for _, param := range n.cf.Params {
+			if param.Class == tree.FunctionParamVariadic {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"version %v must be finalized to create variadic functions",
+					clusterversion.ByKey(clusterversion.V23_1))
+			}
+		}
+	}
 
 	if n.cf.RoutineBody != nil {
 		return unimplemented.NewWithIssue(85144, "CREATE FUNCTION...sql_body unimplemented")
@@ -111,11 +120,8 @@ func (*createFunctionNode) Close(ctx context.Context)           {}
 func (n *createFunctionNode) createNewFunction(
 	udfDesc *funcdesc.Mutable, scDesc *schemadesc.Mutable, params runParams,
 ) error {
-	for _, option := range n.cf.Options {
-		err := setFuncOption(params, udfDesc, option)
-		if err != nil {
-			return err
-		}
+	if err := setFuncOptions(params, udfDesc, n.cf.Options); err != nil {
+		return err
 	}
 	if err := funcdesc.CheckLeakProofVolatility(udfDesc); err != nil {
 		return err
@@ -149,6 +155,7 @@ func (n *createFunctionNode) createNewFunction(
 			ArgTypes:   paramTypes,
 			ReturnType: returnType,
 			ReturnSet:  udfDesc.ReturnType.ReturnSet,
+			IsVariadic: udfDesc.IsVariadic(),
 		},
 	)
 	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Function"); err != nil {
@@ -162,6 +169,12 @@ func (n *createFunctionNode) replaceFunction(udfDesc *funcdesc.Mutable, params r
 	// TODO(chengxiong): add validation that the function is not referenced. This
 	// is needed when we start allowing function references from other objects.
 
+	// Make sure a function is not replaced by a procedure or an aggregate, or
+	// vice versa.
+	if n.cf.IsProcedure != udfDesc.IsProcedure || udfDesc.Aggregate != nil {
+		return pgerror.New(pgcode.WrongObjectType, "cannot change routine kind")
+	}
+
 	// Make sure parameter names are not changed.
 	for i := range n.cf.Params {
 		if string(n.cf.Params[i].Name) != udfDesc.Params[i].Name {
@@ -171,21 +184,39 @@ func (n *createFunctionNode) replaceFunction(udfDesc *funcdesc.Mutable, params r
 		}
 	}
 
+	// Make sure the VARIADIC parameter is not changed.
+	numParams := len(n.cf.Params)
+	isVariadic := numParams > 0 && n.cf.Params[numParams-1].Class == tree.FunctionParamVariadic
+	if isVariadic != udfDesc.IsVariadic() {
+		return pgerror.Newf(
+			pgcode.InvalidFunctionDefinition, "cannot change whether a function is variadic",
+		)
+	}
+
+	// Make sure the OUT and INOUT parameters, which make up the result of a
+	// procedure, are not changed.
+	for i := range n.cf.Params {
+		class, err := funcdesc.ParamClassToProto(n.cf.Params[i].Class)
+		if err != nil {
+			return err
+		}
+		if class != udfDesc.Params[i].Class {
+			return pgerror.New(pgcode.InvalidFunctionDefinition, "cannot change return type of existing function")
+		}
+	}
+
 	// Make sure return type is the same.
 	retType, err := tree.ResolveType(params.ctx, n.cf.ReturnType.Type, params.p)
 	if err != nil {
 		return err
 	}
 	if n.cf.ReturnType.IsSet != udfDesc.ReturnType.ReturnSet || !retType.Equal(udfDesc.ReturnType.Type) {
-		return pgerror.Newf(pgcode.InvalidFunctionDefinition, "cannot change return type of existing function")
+		return pgerror.New(pgcode.InvalidFunctionDefinition, "cannot change return type of existing function")
 	}
 
 	resetFuncOption(udfDesc)
-	for _, option := range n.cf.Options {
-		err := setFuncOption(params, udfDesc, option)
-		if err != nil {
-			return err
-		}
+	if err := setFuncOptions(params, udfDesc, n.cf.Options); err != nil {
+		return err
 	}
 
 	if err := funcdesc.CheckLeakProofVolatility(udfDesc); err != nil {
@@ -299,6 +330,7 @@ func (n *createFunctionNode) getMutableFuncDesc(
 		n.cf.ReturnType.IsSet,
 		privileges,
 	)
+	newUdfDesc.SetIsProcedure(n.cf.IsProcedure)
 
 	return &newUdfDesc, true, nil
 }
@@ -392,6 +424,27 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 	return nil
 }
 
+// setFuncOptions sets the given options of a CREATE FUNCTION statement. The
+// body is set last because how it is stored depends on the language.
+func setFuncOptions(
+	params runParams, udfDesc *funcdesc.Mutable, options tree.FunctionOptions,
+) error {
+	var body tree.FunctionOption
+	for _, option := range options {
+		if _, ok := option.(tree.FunctionBodyStr); ok {
+			body = option
+			continue
+		}
+		if err := setFuncOption(params, udfDesc, option); err != nil {
+			return err
+		}
+	}
+	if body == nil {
+		return nil
+	}
+	return setFuncOption(params, udfDesc, body)
+}
+
 func setFuncOption(params runParams, udfDesc *funcdesc.Mutable, option tree.FunctionOption) error {
 	switch t := option.(type) {
 	case tree.FunctionVolatility:
@@ -415,6 +468,11 @@ func setFuncOption(params runParams, udfDesc *funcdesc.Mutable, option tree.Func
 		}
 		udfDesc.SetLang(v)
 	case tree.FunctionBodyStr:
+		// The body of a PL/pgSQL function is not SQL, so it is stored as written.
+		if udfDesc.Lang == catpb.Function_PLPGSQL {
+			udfDesc.SetFuncBody(string(t))
+			return nil
+		}
 		// Replace any sequence names in the function body with IDs.
 		seqReplacedFuncBody, err := replaceSeqNamesWithIDs(params.ctx, params.p, string(t), true)
 		if err != nil {
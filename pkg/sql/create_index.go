@@ -12,6 +12,8 @@ package sql
 
 import (
 	"context"
+	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
@@ -31,12 +33,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/storageparam"
 	"github.com/cockroachdb/cockroach/pkg/sql/storageparam/indexstorageparam"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -288,6 +292,9 @@ func makeIndexDescriptor(
 		if len(indexDesc.InvertedColumnKinds) > 0 && indexDesc.InvertedColumnKinds[0] == catpb.InvertedIndexColumnKind_TRIGRAM {
 			telemetry.Inc(sqltelemetry.TrigramInvertedIndexCounter)
 		}
+		if len(indexDesc.InvertedColumnKinds) > 0 && indexDesc.InvertedColumnKinds[0].IsVector() {
+			telemetry.Inc(sqltelemetry.VectorInvertedIndexCounter)
+		}
 		if indexDesc.IsPartial() {
 			telemetry.Inc(sqltelemetry.PartialInvertedIndexCounter)
 		}
@@ -410,6 +417,28 @@ func populateInvertedIndexDescriptor(
 		default:
 			return newUndefinedOpclassError(invCol.OpClass)
 		}
+	case types.PGVectorFamily:
+		if !cs.Version.IsActive(ctx, clusterversion.V23_1) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to create vector indexes",
+				clusterversion.ByKey(clusterversion.V23_1))
+		}
+		// The IVF lists of a vector depend on its number of dimensions, so all
+		// the vectors in the index must have the same number of them.
+		if column.GetType().Width() == 0 {
+			return pgerror.Newf(pgcode.InvalidObjectDefinition,
+				"column %q does not have dimensions", column.GetName())
+		}
+		switch invCol.OpClass {
+		case "vector_l2_ops", "":
+			indexDesc.InvertedColumnKinds[0] = catpb.InvertedIndexColumnKind_VECTOR_L2
+		case "vector_cosine_ops":
+			indexDesc.InvertedColumnKinds[0] = catpb.InvertedIndexColumnKind_VECTOR_COSINE
+		case "vector_ip_ops":
+			indexDesc.InvertedColumnKinds[0] = catpb.InvertedIndexColumnKind_VECTOR_IP
+		default:
+			return newUndefinedOpclassError(invCol.OpClass)
+		}
 	default:
 		return tabledesc.NewInvalidInvertedColumnError(column.GetName(), column.GetType().Name())
 	}
@@ -779,6 +808,12 @@ func (n *createIndexNode) startExec(params runParams) error {
 		telemetry.Inc(sqltelemetry.PartitionedInvertedIndexCounter)
 	}
 
+	if len(indexDesc.InvertedColumnKinds) > 0 && indexDesc.InvertedColumnKinds[0].IsVector() {
+		if err := params.p.trainVectorIndex(params.ctx, n.tableDesc, indexDesc); err != nil {
+			return err
+		}
+	}
+
 	mutationIdx := len(n.tableDesc.Mutations)
 	if err := n.tableDesc.AddIndexMutationMaybeWithTempIndex(
 		indexDesc, descpb.DescriptorMutation_ADD,
@@ -827,6 +862,69 @@ func (n *createIndexNode) startExec(params runParams) error {
 		})
 }
 
+// trainVectorIndex sets the centroids of the IVF lists of a new vector index.
+// Like pgvector does for IVFFlat indexes, the centroids are computed with
+// k-means over a random sample of the vectors of the table, so that the lists
+// follow the distribution of the indexed vectors. If the table has no vectors
+// to index yet, the centroids are left unset and the lists have random
+// centroids.
+func (p *planner) trainVectorIndex(
+	ctx context.Context, tableDesc *tabledesc.Mutable, indexDesc *descpb.IndexDescriptor,
+) (retErr error) {
+	col, err := catalog.MustFindColumnByID(tableDesc, indexDesc.InvertedColumnID())
+	if err != nil {
+		return err
+	}
+	vec := tree.NameString(col.GetName())
+	if col.IsInaccessible() {
+		// The virtual column of an expression index cannot be referenced, so
+		// evaluate its expression instead.
+		vec = "(" + col.GetComputeExpr() + ")"
+	}
+	query := fmt.Sprintf("SELECT %[1]s FROM [%[2]d AS t] WHERE %[1]s IS NOT NULL", vec, tableDesc.GetID())
+	if indexDesc.IsPartial() {
+		query += fmt.Sprintf(" AND (%s)", indexDesc.Predicate)
+	}
+	it, err := p.QueryIteratorEx(ctx, "train-vector-index", sessiondata.NodeUserSessionDataOverride, query)
+	if err != nil {
+		return err
+	}
+	defer func() { retErr = errors.CombineErrors(retErr, it.Close()) }()
+
+	// Sample the vectors with reservoir sampling. A fixed seed makes the
+	// centroids reproducible.
+	config := indexDesc.VectorConfig()
+	sampleSize := vector.SampleSize(config.Lists)
+	rng := rand.New(rand.NewSource(int64(tableDesc.GetID())))
+	acc := p.Mon().MakeBoundAccount()
+	defer acc.Close(ctx)
+	var samples []vector.T
+	var seen int
+	var ok bool
+	for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+		v := tree.MustBeDPGVector(it.Cur()[0]).T
+		seen++
+		if len(samples) < sampleSize {
+			if err := acc.Grow(ctx, int64(v.Size())); err != nil {
+				return err
+			}
+			samples = append(samples, v)
+		} else if i := rng.Intn(seen); i < sampleSize {
+			samples[i] = v
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+	indexDesc.VectorCentroids, err = vector.TrainIVF(
+		ctx, config.Metric, int(col.GetType().Width()), config.Lists, samples, rng,
+	)
+	return err
+}
+
 func (*createIndexNode) Next(runParams) (bool, error) { return false, nil }
 func (*createIndexNode) Values() tree.Datums          { return tree.Datums{} }
 func (*createIndexNode) Close(context.Context)        {}
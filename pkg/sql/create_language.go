@@ -0,0 +1,53 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+type createLanguageNode struct {
+	n *tree.CreateLanguage
+}
+
+// CreateLanguage creates a language.
+// Privileges: none.
+//
+// The languages that can be used to write functions are built in, so this is
+// a pseudo-statement that only accepts the languages that already exist.
+func (p *planner) CreateLanguage(ctx context.Context, n *tree.CreateLanguage) (planNode, error) {
+	return &createLanguageNode{n: n}, nil
+}
+
+func (n *createLanguageNode) startExec(params runParams) error {
+	name := string(n.n.Name)
+	for i := range pgLanguages {
+		if pgLanguages[i].name != name {
+			continue
+		}
+		if n.n.Replace {
+			return nil
+		}
+		return pgerror.Newf(pgcode.DuplicateObject, "language %q already exists", name)
+	}
+	return unimplemented.NewWithIssueDetailf(
+		17511, "CREATE LANGUAGE "+name, "language %q is not yet supported", name,
+	)
+}
+
+func (n *createLanguageNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createLanguageNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createLanguageNode) Close(ctx context.Context)           {}
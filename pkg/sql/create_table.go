@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/docs"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -47,6 +48,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
@@ -192,6 +194,8 @@ func getSchemaForCreateTable(
 					mismatchedType = !tableDescriptor.IsView()
 				case tree.ResolveRequireSequenceDesc:
 					mismatchedType = !tableDescriptor.IsSequence()
+				case tree.ResolveRequireForeignTableDesc:
+					mismatchedType = !tableDescriptor.IsForeignTable()
 				}
 				// If kind any is passed then there will never be a mismatch
 				// and we can return an exists error.
@@ -648,7 +652,9 @@ func addUniqueWithoutIndexColumnTableDef(
 		desc,
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
-		"", /* predicate */
+		"",  /* predicate */
+		nil, /* exclusionOps */
+		semenumpb.ConstraintDeferrability_NOT_DEFERRABLE,
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -693,6 +699,12 @@ func addUniqueWithoutIndexTableDef(
 			"creating a unique constraint using UNIQUE WITH NOT VISIBLE INDEX is not supported",
 		)
 	}
+	if d.Deferrable != tree.ConstraintNotDeferrable &&
+		!evalCtx.Settings.Version.IsActive(ctx, clusterversion.V23_1) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create a deferrable unique constraint",
+			clusterversion.ByKey(clusterversion.V23_1))
+	}
 
 	// If there is a predicate, validate it.
 	var predicate string
@@ -712,16 +724,83 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, nil, /* exclusionOps */
+		semenumpb.ConstraintDeferrability(d.Deferrable), ts, validationBehavior,
 	); err != nil {
 		return err
 	}
 	return nil
 }
 
+// addExcludeConstraintTableDef runs various checks on the given
+// ExcludeConstraintTableDef before adding it as an exclusion constraint to the
+// given table descriptor. Exclusion constraints are stored as UNIQUE WITHOUT
+// INDEX constraints with an operator for each column.
+func addExcludeConstraintTableDef(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	d *tree.ExcludeConstraintTableDef,
+	desc *tabledesc.Mutable,
+	tn tree.TableName,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
+	semaCtx *tree.SemaContext,
+) error {
+	if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V23_1) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create an exclusion constraint",
+			clusterversion.ByKey(clusterversion.V23_1))
+	}
+	colNames := make([]string, len(d.Elems))
+	ops := make([]string, len(d.Elems))
+	for i := range d.Elems {
+		colNames[i] = string(d.Elems[i].Column)
+		col, err := desc.FindActiveOrNewColumnByName(d.Elems[i].Column)
+		if err != nil {
+			return err
+		}
+		op := d.Elems[i].Operator
+		switch op.Symbol {
+		case treecmp.EQ, treecmp.NE, treecmp.Overlaps:
+		default:
+			return pgerror.Newf(pgcode.WrongObjectType,
+				"operator %s is not supported in exclusion constraints", op,
+			)
+		}
+		// NE is evaluated as the negation of EQ.
+		cmpOp, _, _, _, _ := tree.FoldComparisonExpr(op, nil, nil)
+		if _, ok := tree.CmpOps[cmpOp.Symbol].LookupImpl(col.GetType(), col.GetType()); !ok {
+			return pgerror.Newf(pgcode.UndefinedFunction,
+				"unsupported comparison operator: <%s> %s <%s>", col.GetType(), op, col.GetType(),
+			)
+		}
+		ops[i] = op.Symbol.String()
+	}
+
+	// If there is a predicate, validate it.
+	var predicate string
+	if d.Predicate != nil {
+		var err error
+		predicate, err = schemaexpr.ValidateUniqueWithoutIndexPredicate(
+			ctx, tn, desc, d.Predicate, semaCtx,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ResolveUniqueWithoutIndexConstraint(
+		ctx, desc, string(d.Name), colNames, predicate, ops,
+		semenumpb.ConstraintDeferrability_NOT_DEFERRABLE, ts, validationBehavior,
+	)
+}
+
 // ResolveUniqueWithoutIndexConstraint looks up the columns mentioned in a
 // UNIQUE WITHOUT INDEX constraint and adds metadata representing that
-// constraint to the descriptor.
+// constraint to the descriptor. If exclusionOps is non-nil, the constraint is
+// an exclusion constraint that compares each column using the corresponding
+// operator. The deferrability determines whether violations of the constraint
+// can be postponed until the end of the transaction.
 //
 // The passed validationBehavior is used to determine whether or not preexisting
 // entries in the table need to be validated against the unique constraint being
@@ -732,9 +811,15 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	exclusionOps []string,
+	deferrability semenumpb.ConstraintDeferrability,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
+	constraintKind, namePrefix := "unique", "unique"
+	if exclusionOps != nil {
+		constraintKind, namePrefix = "exclusion", "exclude"
+	}
 	var colSet catalog.TableColSet
 	cols := make([]catalog.Column, len(colNames))
 	for i, name := range colNames {
@@ -745,7 +830,7 @@ func ResolveUniqueWithoutIndexConstraint(
 		// Ensure that the columns don't have duplicates.
 		if colSet.Contains(col.GetID()) {
 			return pgerror.Newf(pgcode.DuplicateColumn,
-				"column %q appears twice in unique constraint", col.GetName())
+				"column %q appears twice in %s constraint", col.GetName(), constraintKind)
 		}
 		colSet.Add(col.GetID())
 		cols[i] = col
@@ -754,7 +839,7 @@ func ResolveUniqueWithoutIndexConstraint(
 	// Verify we are not writing a constraint over the same name.
 	if constraintName == "" {
 		constraintName = tabledesc.GenerateUniqueName(
-			fmt.Sprintf("unique_%s", strings.Join(colNames, "_")),
+			fmt.Sprintf("%s_%s", namePrefix, strings.Join(colNames, "_")),
 			func(p string) bool {
 				return catalog.FindConstraintByName(tbl, p) != nil
 			},
@@ -786,6 +871,9 @@ func ResolveUniqueWithoutIndexConstraint(
 		Predicate:    predicate,
 		Validity:     validity,
 		ConstraintID: tbl.NextConstraintID,
+
+		ExclusionOperators: exclusionOps,
+		Deferrability:      deferrability,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1030,6 +1118,7 @@ func ResolveFK(
 		OnUpdate:            tree.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               tree.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
+		Deferrability:       semenumpb.ConstraintDeferrability(d.Deferrable),
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1965,7 +2054,8 @@ func NewTableDesc(
 					return nil, err
 				}
 			}
-		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
+		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef,
+			*tree.ExcludeConstraintTableDef:
 			// pass, handled below.
 
 		default:
@@ -2134,6 +2224,13 @@ func NewTableDesc(
 				}
 			}
 
+		case *tree.ExcludeConstraintTableDef:
+			if err := addExcludeConstraintTableDef(
+				ctx, evalCtx, d, &desc, n.Table, NewTable, tree.ValidationDefault, semaCtx,
+			); err != nil {
+				return nil, err
+			}
+
 		case *tree.IndexTableDef, *tree.FamilyTableDef, *tree.LikeTableDef:
 			// Pass, handled above.
 
@@ -2207,6 +2304,9 @@ func NewTableDesc(
 			if idx.InvertedColumnKind() == catpb.InvertedIndexColumnKind_TRIGRAM {
 				telemetry.Inc(sqltelemetry.TrigramInvertedIndexCounter)
 			}
+			if idx.InvertedColumnKind().IsVector() {
+				telemetry.Inc(sqltelemetry.VectorInvertedIndexCounter)
+			}
 			if idx.IsPartial() {
 				telemetry.Inc(sqltelemetry.PartialInvertedIndexCounter)
 			}
@@ -2276,6 +2376,12 @@ func newTableDesc(
 		n.Defs = newDefs
 	}
 
+	parents, newDefs, err := params.p.resolveInheritedTables(params.ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	n.Defs = newDefs
+
 	// Process any SERIAL columns to remove the SERIAL type, as required by
 	// NewTableDesc.
 	colNameToOwnedSeq, err := createSequencesForSerialColumns(
@@ -2321,6 +2427,12 @@ func newTableDesc(
 		return nil, err
 	}
 
+	for _, parent := range parents {
+		if err := params.p.addInheritance(params.ctx, ret, parent, false /* checkColumns */); err != nil {
+			return nil, err
+		}
+	}
+
 	// We need to ensure sequence ownerships so that column owned sequences are
 	// correctly dropped when a column/table is dropped.
 	for colName, seqDesc := range colNameToOwnedSeq {
@@ -2550,12 +2662,43 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				defs = append(defs, &def)
 			}
 			for _, c := range td.UniqueWithoutIndexConstraints {
+				if c.IsExclusion() {
+					def := tree.ExcludeConstraintTableDef{
+						Name:  tree.Name(c.Name),
+						Elems: make(tree.ExcludeElemList, len(c.ColumnIDs)),
+					}
+					colNames, err := catalog.ColumnNamesForIDs(td, c.ColumnIDs)
+					if err != nil {
+						return nil, err
+					}
+					for i := range colNames {
+						op, ok := treecmp.LookupComparisonOperatorSymbol(c.ExclusionOperators[i])
+						if !ok {
+							return nil, errors.AssertionFailedf(
+								"unknown operator %q in exclusion constraint %q", c.ExclusionOperators[i], c.Name,
+							)
+						}
+						def.Elems[i] = tree.ExcludeElem{
+							Column:   tree.Name(colNames[i]),
+							Operator: treecmp.MakeComparisonOperator(op),
+						}
+					}
+					if c.IsPartial() {
+						def.Predicate, err = parser.ParseExpr(c.Predicate)
+						if err != nil {
+							return nil, err
+						}
+					}
+					defs = append(defs, &def)
+					continue
+				}
 				def := tree.UniqueConstraintTableDef{
 					IndexTableDef: tree.IndexTableDef{
 						Name:    tree.Name(c.Name),
 						Columns: make(tree.IndexElemList, 0, len(c.ColumnIDs)),
 					},
 					WithoutIndex: true,
+					Deferrable:   tree.ConstraintDeferrability(c.Deferrability),
 				}
 				colNames, err := catalog.ColumnNamesForIDs(td, c.ColumnIDs)
 				if err != nil {
@@ -234,6 +234,8 @@ func CreateUserDefinedArrayTypeDesc(
 			labels[i] = e.ElementLabel
 		}
 		elemTyp = types.NewCompositeType(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), contents, labels)
+	case descpb.TypeDescriptor_DOMAIN:
+		elemTyp = types.MakeDomain(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), typDesc.Domain.BaseType)
 	default:
 		return nil, errors.AssertionFailedf("cannot make array type for kind %s", t.String())
 	}
@@ -320,6 +322,13 @@ func (p *planner) createUserDefinedType(params runParams, n *createTypeNode) err
 		return params.p.createCompositeWithID(
 			params, id, n.n.CompositeTypeList, n.dbDesc, n.typeName,
 		)
+	case tree.Domain:
+		if !p.execCfg.Settings.Version.IsActive(params.ctx, clusterversion.V23_1) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to create domains",
+				clusterversion.ByKey(clusterversion.V23_1))
+		}
+		return params.p.createDomainWithID(params, id, n.n, n.dbDesc, n.typeName)
 	}
 	return unimplemented.NewWithIssue(25123, "CREATE TYPE")
 }
@@ -451,6 +460,60 @@ func CreateCompositeTypeDesc(
 	}).BuildCreatedMutableType(), nil
 }
 
+// CreateDomainTypeDesc creates a new domain type descriptor.
+func CreateDomainTypeDesc(
+	params runParams,
+	id descpb.ID,
+	n *tree.CreateType,
+	dbDesc catalog.DatabaseDescriptor,
+	schema catalog.SchemaDescriptor,
+	typeName *tree.TypeName,
+) (*typedesc.Mutable, error) {
+	base, err := tree.ResolveType(params.ctx, n.DomainBaseType, params.p.semaCtx.TypeResolver)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDomainBaseType(base); err != nil {
+		return nil, err
+	}
+	domain := &descpb.TypeDescriptor_Domain{BaseType: base}
+	if n.DomainDefault != nil {
+		if domain.DefaultExpr, err = makeDomainDefaultExpr(params, n.DomainDefault, base); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range n.DomainConstraints {
+		if c.Check == nil {
+			domain.NotNull = c.Nullability == tree.NotNull
+			continue
+		}
+		check, err := makeDomainCheckConstraint(params, typeName.Type(), domain, c)
+		if err != nil {
+			return nil, err
+		}
+		domain.Checks = append(domain.Checks, check)
+	}
+
+	privs := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+
+	return typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           typeName.Type(),
+		ID:             id,
+		ParentID:       dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		Domain:         domain,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType(), nil
+}
+
 func (p *planner) createEnumWithID(
 	params runParams,
 	id descpb.ID,
@@ -503,6 +566,27 @@ func (p *planner) createCompositeWithID(
 	return nil
 }
 
+func (p *planner) createDomainWithID(
+	params runParams,
+	id descpb.ID,
+	n *tree.CreateType,
+	dbDesc catalog.DatabaseDescriptor,
+	typeName *tree.TypeName,
+) error {
+	// Generate a key in the namespace table and a new id for this type.
+	schema, err := getCreateTypeParams(params, typeName, dbDesc)
+	if err != nil {
+		return err
+	}
+
+	typeDesc, err := CreateDomainTypeDesc(params, id, n, dbDesc, schema, typeName)
+	if err != nil {
+		return err
+	}
+
+	return p.finishCreateType(params, id, typeName, typeDesc, dbDesc, schema)
+}
+
 func (p *planner) finishCreateType(
 	params runParams,
 	id descpb.ID,
@@ -68,6 +68,9 @@ type createViewNode struct {
 	// withData indicates if a materialized view should be populated
 	// with data by executing the underlying query.
 	withData bool
+	// incremental indicates if a materialized view is kept up to date
+	// incrementally by a maintenance job.
+	incremental bool
 }
 
 // ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
@@ -85,6 +88,11 @@ func (n *createViewNode) startExec(params runParams) error {
 		telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter(tableType))
 	}
 
+	if n.incremental && !n.withData {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"incrementally refreshed materialized views cannot be created WITH NO DATA")
+	}
+
 	viewName := n.viewName.Object()
 	log.VEventf(params.ctx, 2, "dependencies for view %s:\n%s", viewName, n.planDeps.String())
 
@@ -253,6 +261,11 @@ func (n *createViewNode) startExec(params runParams) error {
 					// on it.
 					desc.RefreshViewRequired = !n.withData
 					desc.State = descpb.DescriptorState_ADD
+					if n.incremental {
+						if err := params.p.addIncrementalViewIndex(params.ctx, &desc, n.viewQuery); err != nil {
+							return err
+						}
+					}
 					version := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
 					if err := desc.AllocateIDs(params.ctx, version); err != nil {
 						return err
@@ -270,6 +283,13 @@ func (n *createViewNode) startExec(params runParams) error {
 					orderedDependsOn.Add(backrefID)
 				}
 				desc.DependsOn = append(desc.DependsOn, orderedDependsOn.Ordered()...)
+				if n.incremental {
+					tableIDs, err := params.p.resolveIncrementalViewSources(params.ctx, n.viewQuery)
+					if err != nil {
+						return err
+					}
+					desc.IncrementalRefresh = &descpb.TableDescriptor_IncrementalRefresh{TableIDs: tableIDs}
+				}
 
 				// Collect all types this view depends on.
 				orderedTypeDeps := catalog.DescriptorIDSet{}
@@ -334,6 +354,12 @@ func (n *createViewNode) startExec(params runParams) error {
 				return err
 			}
 
+			if newDesc.IsIncrementallyRefreshed() {
+				if err := params.p.createMaterializedViewMaintenanceJob(params.ctx, newDesc); err != nil {
+					return err
+				}
+			}
+
 			if applyGlobalMultiRegionZoneConfig {
 				regionConfig, err := SynthesizeRegionConfig(params.ctx, params.p.txn, n.dbDesc.GetID(), params.p.Descriptors())
 				if err != nil {
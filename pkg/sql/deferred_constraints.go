@@ -0,0 +1,515 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// maxDeferredConstraintKeys is the number of violating rows that are
+// remembered for each deferred constraint. Once it is exceeded, the whole
+// origin table is validated instead.
+const maxDeferredConstraintKeys = 1000
+
+// deferredConstraintState tracks the deferrable constraints of the current
+// transaction: the checking modes selected with SET CONSTRAINTS, and the
+// constraints whose violations have been postponed until commit.
+//
+// Foreign key constraints and UNIQUE WITHOUT INDEX constraints can be
+// deferred. Unique constraints that are backed by an index cannot, since the
+// index rejects a duplicate key as soon as it is written. For each pending
+// constraint, the key values of the rows that violated it are remembered, and
+// only those rows are checked again.
+type deferredConstraintState struct {
+	// allSet is true if SET CONSTRAINTS ALL was issued in the transaction, in
+	// which case allDeferred holds the mode that it selected.
+	allSet      bool
+	allDeferred bool
+
+	// named holds the modes selected by SET CONSTRAINTS for individual
+	// constraints since the last SET CONSTRAINTS ALL.
+	named map[deferredConstraintKey]bool
+
+	// pending holds the constraints that must be checked before the
+	// transaction commits.
+	pending map[deferredConstraintKey]*pendingConstraint
+}
+
+// deferredConstraintKey identifies a constraint by the table that owns it.
+type deferredConstraintKey struct {
+	tableID descpb.ID
+	name    string
+}
+
+// pendingConstraint holds the violations of a deferred constraint.
+type pendingConstraint struct {
+	deferrability tree.ConstraintDeferrability
+
+	// violations holds the rows that violated the constraint. It is nil if
+	// fullCheck is set.
+	violations []pendingViolation
+
+	// fullCheck is set once more than maxDeferredConstraintKeys violations were
+	// recorded, in which case every row of the origin table is checked.
+	fullCheck bool
+}
+
+// pendingViolation is a violation of a deferred constraint.
+type pendingViolation struct {
+	// keyVals are the values of the constraint columns of the row, ordered as
+	// in exec.DeferrableConstraintViolation.
+	keyVals tree.Datums
+	// err is the error that is reported if the violation persists.
+	err error
+}
+
+// isDeferred returns whether violations of the given constraint, which has the
+// given declared deferrability, are currently postponed until commit.
+func (s *deferredConstraintState) isDeferred(
+	k deferredConstraintKey, deferrability tree.ConstraintDeferrability,
+) bool {
+	if deferrability == tree.ConstraintNotDeferrable {
+		return false
+	}
+	if deferred, ok := s.named[k]; ok {
+		return deferred
+	}
+	if s.allSet {
+		return s.allDeferred
+	}
+	return deferrability == tree.ConstraintInitiallyDeferred
+}
+
+// addPending records a violation of the given constraint while it was
+// deferred. The constraint must be checked again before commit.
+func (s *deferredConstraintState) addPending(
+	k deferredConstraintKey, deferrability tree.ConstraintDeferrability, v pendingViolation,
+) {
+	if s.pending == nil {
+		s.pending = make(map[deferredConstraintKey]*pendingConstraint)
+	}
+	pc, ok := s.pending[k]
+	if !ok {
+		pc = &pendingConstraint{deferrability: deferrability}
+		s.pending[k] = pc
+	}
+	if pc.fullCheck {
+		return
+	}
+	if len(pc.violations) >= maxDeferredConstraintKeys {
+		pc.violations = nil
+		pc.fullCheck = true
+		return
+	}
+	pc.violations = append(pc.violations, v)
+}
+
+// setMode applies a SET CONSTRAINTS statement. keys holds the resolved
+// constraints named by the statement; it is empty for SET CONSTRAINTS ALL.
+func (s *deferredConstraintState) setMode(keys []deferredConstraintKey, deferred bool) {
+	if len(keys) == 0 {
+		s.allSet = true
+		s.allDeferred = deferred
+		s.named = nil
+		return
+	}
+	if s.named == nil {
+		s.named = make(map[deferredConstraintKey]bool)
+	}
+	for _, k := range keys {
+		s.named[k] = deferred
+	}
+}
+
+// validatePending checks the pending constraints and forgets the ones that
+// hold. If all is false, only the constraints that are no longer deferred are
+// checked.
+func (s *deferredConstraintState) validatePending(
+	ctx context.Context, p *planner, all bool,
+) error {
+	if len(s.pending) == 0 {
+		return nil
+	}
+	keys := make([]deferredConstraintKey, 0, len(s.pending))
+	for k, pc := range s.pending {
+		if all || !s.isDeferred(k, pc.deferrability) {
+			keys = append(keys, k)
+		}
+	}
+	// Check the constraints in a deterministic order, so that the reported
+	// violation does not depend on map iteration order.
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].tableID != keys[j].tableID {
+			return keys[i].tableID < keys[j].tableID
+		}
+		return keys[i].name < keys[j].name
+	})
+	for _, k := range keys {
+		if err := validateDeferredConstraint(ctx, p, k, s.pending[k]); err != nil {
+			return err
+		}
+		delete(s.pending, k)
+	}
+	return nil
+}
+
+// validateDeferredConstraint checks that the rows that violated the given
+// constraint while it was deferred no longer do. Constraints and tables that
+// were dropped after the violations were recorded are skipped.
+func validateDeferredConstraint(
+	ctx context.Context, p *planner, k deferredConstraintKey, pc *pendingConstraint,
+) error {
+	tbl, err := p.Descriptors().ByID(p.Txn()).Get().Table(ctx, k.tableID)
+	if err != nil {
+		return err
+	}
+	if tbl.Dropped() {
+		return nil
+	}
+	c := catalog.FindConstraintByName(tbl, k.name)
+	if c == nil {
+		return nil
+	}
+	if c.AsForeignKey() != nil {
+		return validateDeferredForeignKey(ctx, p, tbl, k.name, pc)
+	}
+	if uwi := c.AsUniqueWithoutIndex(); uwi != nil {
+		return validateDeferredUniqueConstraint(ctx, p, tbl, uwi, pc)
+	}
+	return nil
+}
+
+// validateDeferredForeignKey checks that the rows that violated the given
+// foreign key constraint while it was deferred no longer do.
+func validateDeferredForeignKey(
+	ctx context.Context,
+	p *planner,
+	tbl catalog.TableDescriptor,
+	fkName string,
+	pc *pendingConstraint,
+) error {
+	srcTable := tabledesc.NewBuilder(tbl.TableDesc()).BuildExistingMutableTable()
+	txn := p.InternalSQLTxn()
+	if pc.fullCheck {
+		return validateFkInTxn(ctx, txn, p.Descriptors(), srcTable, fkName)
+	}
+	syntheticDescs, fk, targetTable, err := getTargetTablesAndFk(
+		ctx, srcTable, txn.KV(), p.Descriptors(), fkName,
+	)
+	if err != nil {
+		return err
+	}
+	return txn.WithSyntheticDescriptors(syntheticDescs, func() error {
+		for _, v := range pc.violations {
+			query, args, err := deferredFKViolationQuery(srcTable, fk, targetTable, v.keyVals)
+			if err != nil {
+				return err
+			}
+			log.VEventf(ctx, 2, "validating deferred FK %q with query %q", fk.Name, query)
+			row, err := txn.QueryRowEx(ctx, "validate deferred fk constraint", txn.KV(),
+				sessiondata.NodeUserSessionDataOverride, query, args...)
+			if err != nil {
+				return err
+			}
+			if row != nil {
+				return v.err
+			}
+		}
+		return nil
+	})
+}
+
+// validateDeferredUniqueConstraint checks that the rows that violated the
+// given UNIQUE WITHOUT INDEX constraint while it was deferred no longer do.
+func validateDeferredUniqueConstraint(
+	ctx context.Context,
+	p *planner,
+	tbl catalog.TableDescriptor,
+	uwi catalog.UniqueWithoutIndexConstraint,
+	pc *pendingConstraint,
+) error {
+	txn := p.InternalSQLTxn()
+	// The key values are ordered like the columns of the constraint in the
+	// optimizer catalog, which sorts them by ID.
+	columnIDs := uwi.CollectKeyColumnIDs().Ordered()
+	if pc.fullCheck {
+		return validateUniqueConstraint(
+			ctx, tbl, uwi.GetName(), columnIDs, uwi.GetPredicate(), 0, /* indexIDForValidation */
+			txn, p.User(), true, /* preExisting */
+		)
+	}
+	for _, v := range pc.violations {
+		query, args, err := deferredUniqueViolationQuery(tbl, columnIDs, uwi.GetPredicate(), v.keyVals)
+		if err != nil {
+			return err
+		}
+		if query == "" {
+			continue
+		}
+		log.VEventf(ctx, 2, "validating deferred unique constraint %q with query %q", uwi.GetName(), query)
+		row, err := txn.QueryRowEx(ctx, "validate deferred unique constraint", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride, query, args...)
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			return v.err
+		}
+	}
+	return nil
+}
+
+// deferredUniqueViolationQuery generates a query that returns a row if more
+// than one row of the table has the given key values, i.e. if the key values
+// still violate the specified unique constraint. An empty query is returned if
+// any of the key values is NULL, since NULL values never conflict.
+//
+// For example, a unique constraint on columns (a, b) of the table "t" with the
+// predicate "c > 0" and the key values (1, 2) would require the following
+// query:
+//
+//	SELECT 1
+//	  FROM [<ID of t> AS tbl]
+//	 WHERE tbl.a = $1 AND tbl.b = $2 AND (c > 0)
+//	HAVING count(*) > 1
+func deferredUniqueViolationQuery(
+	tbl catalog.TableDescriptor, columnIDs []descpb.ColumnID, pred string, keyVals tree.Datums,
+) (sql string, args []interface{}, _ error) {
+	colNames, err := catalog.ColumnNamesForIDs(tbl, columnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	where := make([]string, 0, len(colNames)+1)
+	for i := range colNames {
+		if keyVals[i] == tree.DNull {
+			return "", nil, nil
+		}
+		args = append(args, keyVals[i])
+		where = append(where, fmt.Sprintf("tbl.%s = $%d", tree.NameString(colNames[i]), len(args)))
+	}
+	if pred != "" {
+		where = append(where, fmt.Sprintf("(%s)", pred))
+	}
+	return fmt.Sprintf(
+		`SELECT 1 FROM [%d AS tbl] WHERE %s HAVING count(*) > 1`,
+		tbl.GetID(), strings.Join(where, " AND "),
+	), args, nil
+}
+
+// deferredFKViolationQuery generates a query that returns a row if the given
+// key values still violate the specified FK constraint, i.e. if a row of the
+// referencing table has these key values and no row of the referenced table
+// matches them.
+//
+// For example, a FK constraint on columns (a_id, b_id) on the table "child",
+// referencing columns (a, b) on the table "parent", and the key values (1, 2)
+// would require the following query:
+//
+//	SELECT 1
+//	  FROM [<ID of child> AS src]@{IGNORE_FOREIGN_KEYS}
+//	 WHERE src.a_id = $1 AND src.b_id = $2
+//	   AND NOT EXISTS (
+//	         SELECT 1 FROM [<ID of parent> AS target]
+//	          WHERE target.a = $1 AND target.b = $2
+//	       )
+//	 LIMIT 1
+//
+// NULL key values, which can only be recorded for MATCH FULL constraints, are
+// matched with IS NULL in the referencing table and never match the
+// referenced table.
+func deferredFKViolationQuery(
+	srcTbl catalog.TableDescriptor,
+	fk *descpb.ForeignKeyConstraint,
+	targetTbl catalog.TableDescriptor,
+	keyVals tree.Datums,
+) (sql string, args []interface{}, _ error) {
+	originColNames, err := catalog.ColumnNamesForIDs(srcTbl, fk.OriginColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	referencedColNames, err := catalog.ColumnNamesForIDs(targetTbl, fk.ReferencedColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	srcWhere := make([]string, len(originColNames))
+	targetWhere := make([]string, len(originColNames))
+	for i := range originColNames {
+		srcCol := fmt.Sprintf("src.%s", tree.NameString(originColNames[i]))
+		targetCol := fmt.Sprintf("target.%s", tree.NameString(referencedColNames[i]))
+		if keyVals[i] == tree.DNull {
+			srcWhere[i] = fmt.Sprintf("%s IS NULL", srcCol)
+			targetWhere[i] = "false"
+			continue
+		}
+		args = append(args, keyVals[i])
+		srcWhere[i] = fmt.Sprintf("%s = $%d", srcCol, len(args))
+		targetWhere[i] = fmt.Sprintf("%s = $%d", targetCol, len(args))
+	}
+	return fmt.Sprintf(
+		`SELECT 1 FROM [%[1]d AS src]@{IGNORE_FOREIGN_KEYS}
+		  WHERE %[2]s
+		    AND NOT EXISTS (SELECT 1 FROM [%[3]d AS target] WHERE %[4]s)
+		  LIMIT 1`,
+		srcTbl.GetID(),                     // 1
+		strings.Join(srcWhere, " AND "),    // 2
+		targetTbl.GetID(),                  // 3
+		strings.Join(targetWhere, " AND "), // 4
+	), args, nil
+}
+
+// maybeDeferConstraintViolation inspects an error returned by a constraint
+// check. If the error is a violation of a constraint that is currently
+// deferred, the violation is recorded for re-checking at commit and nil is
+// returned. Otherwise, the error that should be reported is returned.
+func (p *planner) maybeDeferConstraintViolation(err error) error {
+	v, ok := err.(*exec.DeferrableConstraintViolation)
+	if !ok {
+		return err
+	}
+	k := deferredConstraintKey{tableID: descpb.ID(v.TableID), name: v.ConstraintName}
+	if p.deferredConstraints == nil || !p.deferredConstraints.isDeferred(k, v.Deferrability) {
+		return v.Err
+	}
+	p.deferredConstraints.addPending(k, v.Deferrability, pendingViolation{
+		keyVals: v.KeyVals,
+		err:     v.Err,
+	})
+	return nil
+}
+
+// resolveSetConstraintsNames resolves the constraint names of a SET
+// CONSTRAINTS statement. As in Postgres, a name that is not qualified by a
+// schema refers to the constraints with that name in the first schema of the
+// search path that has any. An error is returned if no constraint has the
+// name, or if one of the constraints with the name is not deferrable.
+func (p *planner) resolveSetConstraintsNames(
+	ctx context.Context, names tree.TableNames,
+) ([]deferredConstraintKey, error) {
+	var keys []deferredConstraintKey
+	for i := range names {
+		name := &names[i]
+		dbName := p.CurrentDatabase()
+		if name.ExplicitCatalog {
+			dbName = name.Catalog()
+		}
+		db, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, dbName)
+		if err != nil {
+			return nil, err
+		}
+		var schemaNames []string
+		if name.ExplicitSchema {
+			schemaNames = []string{name.Schema()}
+		} else {
+			iter := p.CurrentSearchPath().Iter()
+			for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+				schemaNames = append(schemaNames, scName)
+			}
+		}
+		var found []deferredConstraintKey
+		for _, scName := range schemaNames {
+			sc, err := p.Descriptors().ByNameWithLeased(p.txn).MaybeGet().Schema(ctx, db, scName)
+			if err != nil {
+				return nil, err
+			}
+			if sc == nil || sc.SchemaKind() == catalog.SchemaVirtual {
+				continue
+			}
+			objects, err := p.Descriptors().GetAllObjectsInSchema(ctx, p.txn, db, sc)
+			if err != nil {
+				return nil, err
+			}
+			if err := objects.ForEachDescriptor(func(desc catalog.Descriptor) error {
+				tbl, ok := desc.(catalog.TableDescriptor)
+				if !ok || tbl.Dropped() {
+					return nil
+				}
+				for _, c := range tbl.AllConstraints() {
+					if c.IsMutation() || c.GetName() != string(name.ObjectName) {
+						continue
+					}
+					deferrability := semenumpb.ConstraintDeferrability_NOT_DEFERRABLE
+					if fk := c.AsForeignKey(); fk != nil {
+						deferrability = fk.Deferrability()
+					} else if uwi := c.AsUniqueWithoutIndex(); uwi != nil {
+						deferrability = uwi.Deferrability()
+					}
+					if deferrability == semenumpb.ConstraintDeferrability_NOT_DEFERRABLE {
+						return pgerror.Newf(pgcode.WrongObjectType,
+							"constraint %q is not deferrable", c.GetName())
+					}
+					found = append(found, deferredConstraintKey{tableID: tbl.GetID(), name: c.GetName()})
+				}
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+			if len(found) > 0 {
+				break
+			}
+		}
+		if len(found) == 0 {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q does not exist", tree.ErrString(name))
+		}
+		keys = append(keys, found...)
+	}
+	return keys, nil
+}
+
+// SetConstraints implements the SET CONSTRAINTS statement.
+// See https://www.postgresql.org/docs/current/sql-set-constraints.html.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	return &setConstraintsNode{n: n}, nil
+}
+
+type setConstraintsNode struct {
+	n *tree.SetConstraints
+}
+
+func (n *setConstraintsNode) startExec(params runParams) error {
+	p := params.p
+	if p.extendedEvalCtx.TxnImplicit || p.deferredConstraints == nil {
+		// This no-ops in postgres with a warning, so copy accordingly.
+		p.BufferClientNotice(
+			params.ctx,
+			pgnotice.NewWithSeverityf(
+				"WARNING",
+				"SET CONSTRAINTS can only be used in transaction blocks",
+			),
+		)
+		return nil
+	}
+	keys, err := p.resolveSetConstraintsNames(params.ctx, n.n.Names)
+	if err != nil {
+		return err
+	}
+	p.deferredConstraints.setMode(keys, n.n.Deferred)
+	return p.deferredConstraints.validatePending(params.ctx, p, false /* all */)
+}
+
+func (n *setConstraintsNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *setConstraintsNode) Values() tree.Datums            { return nil }
+func (n *setConstraintsNode) Close(_ context.Context)        {}
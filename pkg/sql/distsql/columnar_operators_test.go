@@ -104,6 +104,9 @@ var aggregateFuncToNumArguments = map[execinfrapb.AggregatorSpec_Func]int{
 	execinfrapb.FinalCovarSamp:          1,
 	execinfrapb.FinalCorr:               1,
 	execinfrapb.FinalSqrdiff:            3,
+	execinfrapb.StClusterIntersecting:   1,
+	execinfrapb.StClusterWithin:         2,
+	execinfrapb.StPolygonize:            1,
 }
 
 // TestAggregateFuncToNumArguments ensures that all aggregate functions are
@@ -129,6 +132,10 @@ func TestAggregateFuncToNumArguments(t *testing.T) {
 	fns := make([]execinfrapb.AggregatorSpec_Func, 0,
 		len(execinfrapb.AggregatorSpec_Func_name))
 	for fn := range execinfrapb.AggregatorSpec_Func_name {
+		if execinfrapb.AggregatorSpec_Func(fn) == execinfrapb.UserDefined {
+			// User-defined aggregates do not have a builtin overload.
+			continue
+		}
 		fns = append(fns, execinfrapb.AggregatorSpec_Func(fn))
 	}
 	sort.Slice(fns, func(i, j int) bool { return fns[i] < fns[j] })
@@ -262,6 +269,9 @@ func TestAggregatorAgainstProcessor(t *testing.T) {
 									execinfrapb.StExtent,
 									execinfrapb.StUnion,
 									execinfrapb.StCollect,
+									execinfrapb.StClusterIntersecting,
+									execinfrapb.StClusterWithin,
+									execinfrapb.StPolygonize,
 									execinfrapb.ArrayAgg:
 									for _, typ := range aggFnInputTypes {
 										if typ.Family() == types.TupleFamily || (typ.Family() == types.ArrayFamily && typ.ArrayContents().Family() == types.TupleFamily) {
@@ -1278,6 +1288,11 @@ func TestWindowFunctionsAgainstProcessor(t *testing.T) {
 
 	for windowFnIdx := 0; windowFnIdx < len(execinfrapb.WindowerSpec_WindowFunc_name); windowFnIdx++ {
 		windowFn := execinfrapb.WindowerSpec_WindowFunc(windowFnIdx)
+		if !colexecwindow.WindowFnSupported(windowFn) {
+			// Window functions without a vectorized implementation are always
+			// planned with the wrapped row-by-row windower.
+			continue
+		}
 		var argTypes []*types.T
 		randArgType := types.Int
 		if rand.Float64() < randTypesProbability {
@@ -39,6 +39,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
@@ -481,6 +482,11 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		if err != nil {
 			return cannotDistribute, err
 		}
+		for _, f := range n.funcs {
+			if err := checkUserDefinedAgg(f.userDefined); err != nil {
+				return cannotDistribute, err
+			}
+		}
 		// Distribute aggregations if possible.
 		return rec.compose(shouldDistribute), nil
 
@@ -667,6 +673,11 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		if err != nil {
 			return cannotDistribute, err
 		}
+		for _, f := range n.funcs {
+			if err := checkUserDefinedAgg(f.userDefined); err != nil {
+				return cannotDistribute, err
+			}
+		}
 		for _, f := range n.funcs {
 			if len(f.partitionIdxs) > 0 {
 				// If at least one function has PARTITION BY clause, then we
@@ -1879,11 +1890,20 @@ func (dsp *DistSQLPlanner) addAggregators(
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		if fholder.userDefined != nil {
+			var err error
+			aggregations[i].Func = execinfrapb.UserDefined
+			aggregations[i].UserDefined, err = makeUserDefinedAggregateSpec(ctx, planCtx, fholder.userDefined)
+			if err != nil {
+				return err
+			}
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -1915,6 +1935,48 @@ func (dsp *DistSQLPlanner) addAggregators(
 	})
 }
 
+// checkUserDefinedAgg returns an error if the transition or final function of
+// the given user-defined aggregate cannot be evaluated by DistSQL. info may be
+// nil, in which case the aggregate is a builtin.
+func checkUserDefinedAgg(info *exec.UserDefinedAggInfo) error {
+	if info == nil {
+		return nil
+	}
+	if err := checkExpr(info.Transition); err != nil {
+		return err
+	}
+	return checkExpr(info.Final)
+}
+
+// makeUserDefinedAggregateSpec returns the specification of an aggregate
+// created with CREATE AGGREGATE.
+func makeUserDefinedAggregateSpec(
+	ctx context.Context, planCtx *PlanningCtx, info *exec.UserDefinedAggInfo,
+) (*execinfrapb.AggregatorSpec_UserDefinedAggregate, error) {
+	spec := &execinfrapb.AggregatorSpec_UserDefinedAggregate{
+		StateType:  info.StateType,
+		ReturnType: info.Final.ResolvedType(),
+		Strict:     info.Strict,
+	}
+	var err error
+	if spec.Transition, err = physicalplan.MakeExpression(
+		ctx, info.Transition, planCtx, nil, /* indexVarMap */
+	); err != nil {
+		return nil, err
+	}
+	if spec.Final, err = physicalplan.MakeExpression(
+		ctx, info.Final, planCtx, nil, /* indexVarMap */
+	); err != nil {
+		return nil, err
+	}
+	if spec.Init, err = physicalplan.MakeExpression(
+		ctx, info.InitCond, planCtx, nil, /* indexVarMap */
+	); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
 // planAggregators plans the aggregator processors. An evaluator stage is added
 // if necessary.
 // Invariants assumed:
@@ -2462,6 +2524,10 @@ func (dsp *DistSQLPlanner) planAggregators(
 			argTypes[j] = inputTypes[c]
 		}
 		copy(argTypes[len(agg.ColIdx):], info.argumentsColumnTypes[i])
+		if agg.UserDefined != nil {
+			finalOutTypes[i] = agg.UserDefined.ReturnType
+			continue
+		}
 		var err error
 		_, returnTyp, err := execagg.GetAggregateInfo(agg.Func, argTypes...)
 		if err != nil {
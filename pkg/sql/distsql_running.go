@@ -1537,7 +1537,7 @@ func (dsp *DistSQLPlanner) PlanAndRunAll(
 			// can get it via the statement bundle.
 			true, /* skipDistSQLDiagramGeneration */
 		) {
-			return recv.commErr
+			return recv.getError()
 		}
 	}
 	recv.discardRows = planner.instrumentation.ShouldDiscardRows()
@@ -1864,10 +1864,7 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 		}
 		cp := cascadePlan.(*planComponents)
 		plan.cascades[i].plan = cp.main
-		if len(cp.subqueryPlans) > 0 {
-			recv.SetError(errors.AssertionFailedf("cascades should not have subqueries"))
-			return false
-		}
+		plan.cascades[i].subqueryPlans = cp.subqueryPlans
 
 		// Queue any new cascades.
 		if len(cp.cascades) > 0 {
@@ -1889,13 +1886,7 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			return false
 		}
 
-		if err := dsp.planAndRunPostquery(
-			ctx,
-			cp.main,
-			planner,
-			evalCtx,
-			recv,
-		); err != nil {
+		if err := dsp.planAndRunCascade(ctx, cp, planner, evalCtxFactory, evalCtx, recv); err != nil {
 			recv.SetError(err)
 			return false
 		}
@@ -1933,6 +1924,43 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 	return true
 }
 
+// planAndRunCascade runs a cascade query, after running its subqueries, if
+// any. Cascades that fire the triggers of the mutated table can have
+// subqueries.
+func (dsp *DistSQLPlanner) planAndRunCascade(
+	ctx context.Context,
+	cascadePlan *planComponents,
+	planner *planner,
+	evalCtxFactory func() *extendedEvalContext,
+	evalCtx *extendedEvalContext,
+	recv *DistSQLReceiver,
+) error {
+	if len(cascadePlan.subqueryPlans) > 0 {
+		// The cascade refers to its subqueries by their index, so they
+		// temporarily replace the subqueries of the main query, which have
+		// already been run.
+		oldSubqueries := planner.curPlan.subqueryPlans
+		planner.curPlan.subqueryPlans = cascadePlan.subqueryPlans
+		defer func() {
+			planner.curPlan.subqueryPlans = oldSubqueries
+		}()
+		subqueryResultMemAcc := planner.Mon().MakeBoundAccount()
+		defer subqueryResultMemAcc.Close(ctx)
+		if !dsp.PlanAndRunSubqueries(
+			ctx,
+			planner,
+			evalCtxFactory,
+			cascadePlan.subqueryPlans,
+			recv,
+			&subqueryResultMemAcc,
+			true, /* skipDistSQLDiagramGeneration */
+		) {
+			return recv.getError()
+		}
+	}
+	return dsp.planAndRunPostquery(ctx, cascadePlan.main, planner, evalCtx, recv)
+}
+
 // planAndRunPostquery runs a cascade or check query.
 func (dsp *DistSQLPlanner) planAndRunPostquery(
 	ctx context.Context,
@@ -1983,9 +2011,8 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 
 	postqueryRecv := recv.clone()
 	defer postqueryRecv.Release()
-	// TODO(yuzefovich): at the moment, errOnlyResultWriter is sufficient here,
-	// but it may not be the case when we support cascades through the optimizer.
-	postqueryResultWriter := &errOnlyResultWriter{}
+	// Cascades that fire AFTER triggers produce rows, which are discarded.
+	postqueryResultWriter := &droppingResultWriter{}
 	postqueryRecv.resultWriterMu.row = postqueryResultWriter
 	postqueryRecv.resultWriterMu.batch = postqueryResultWriter
 	dsp.Run(ctx, postqueryPlanCtx, planner.txn, postqueryPhysPlan, postqueryRecv, evalCtx, nil /* finishedSetupFn */)
@@ -520,6 +520,9 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 		i := len(groupCols) + j
 		spec := &aggregationSpecs[i]
 		agg := &aggregations[j]
+		if agg.UserDefined != nil {
+			return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: user-defined aggregate")
+		}
 		argumentsColumnTypes[i], err = populateAggFuncSpec(
 			e.ctx, spec, agg.FuncName, agg.Distinct, agg.ArgCols,
 			agg.ConstArgs, agg.Filter, planCtx, physPlan,
@@ -1047,6 +1050,7 @@ func (e *distSQLSpecExecFactory) ConstructCreateView(
 	deps opt.SchemaDeps,
 	typeDeps opt.SchemaTypeDeps,
 	withData bool,
+	incremental bool,
 ) (exec.Node, error) {
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: create view")
 }
@@ -1152,6 +1156,10 @@ func (e *distSQLSpecExecFactory) ConstructCancelQueries(
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: cancel queries")
 }
 
+func (e *distSQLSpecExecFactory) ConstructCall(proc tree.TypedExpr) (exec.Node, error) {
+	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: call")
+}
+
 func (e *distSQLSpecExecFactory) ConstructShowCompletions(
 	input *tree.ShowCompletions,
 ) (exec.Node, error) {
@@ -18,6 +18,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -38,7 +40,7 @@ func (p *planner) DropFunction(
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"DROP FUNCTION",
+		n.StatementTag(),
 	); err != nil {
 		return nil, err
 	}
@@ -69,6 +71,18 @@ func (p *planner) DropFunction(
 		if err != nil {
 			return nil, err
 		}
+		if err := checkRoutineKind(mut, n.IsProcedure); err != nil {
+			return nil, err
+		}
+		if err := checkAggregateKind(mut, n.IsAggregate, "DROP"); err != nil {
+			return nil, err
+		}
+		if err := p.checkNoDependentAggregates(ctx, mut); err != nil {
+			return nil, err
+		}
+		if err := p.checkNoDependentTriggers(ctx, mut); err != nil {
+			return nil, err
+		}
 		dropNode.toDrop = append(dropNode.toDrop, mut)
 	}
 
@@ -81,6 +95,67 @@ func (p *planner) DropFunction(
 	return dropNode, nil
 }
 
+// checkAggregateKind returns an error if the given descriptor is an aggregate
+// and isAggregate is false, or vice versa. stmt is the statement verb used in
+// the hint, e.g. DROP or ALTER.
+func checkAggregateKind(fnDesc catalog.FunctionDescriptor, isAggregate bool, stmt string) error {
+	if (fnDesc.GetAggregate() != nil) == isAggregate {
+		return nil
+	}
+	if isAggregate {
+		return errors.WithHintf(
+			pgerror.Newf(pgcode.WrongObjectType, "%s() is not an aggregate function", fnDesc.GetName()),
+			"Use %s FUNCTION for functions.", stmt,
+		)
+	}
+	return errors.WithHintf(
+		pgerror.Newf(pgcode.WrongObjectType, "%s() is an aggregate function", fnDesc.GetName()),
+		"Use %s AGGREGATE for aggregate functions.", stmt,
+	)
+}
+
+// checkNoDependentAggregates returns an error if the function is used as the
+// transition or final function of a user-defined aggregate.
+func (p *planner) checkNoDependentAggregates(
+	ctx context.Context, fnDesc catalog.FunctionDescriptor,
+) error {
+	for _, by := range fnDesc.GetDependedOnBy() {
+		dep, err := p.Descriptors().ByID(p.Txn()).Get().Desc(ctx, by.ID)
+		if err != nil {
+			return err
+		}
+		if dep.DescriptorType() != catalog.Function {
+			continue
+		}
+		return errors.WithHint(
+			pgerror.Newf(pgcode.DependentObjectsStillExist,
+				"cannot drop function %q because aggregate %q depends on it",
+				fnDesc.GetName(), dep.GetName(),
+			),
+			"Drop the aggregate first.",
+		)
+	}
+	return nil
+}
+
+// checkRoutineKind returns an error if the given descriptor is a procedure
+// and isProcedure is false, or vice versa.
+func checkRoutineKind(fnDesc catalog.FunctionDescriptor, isProcedure bool) error {
+	if fnDesc.GetIsProcedure() == isProcedure {
+		return nil
+	}
+	if isProcedure {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s() is not a procedure", fnDesc.GetName()),
+			"Use DROP FUNCTION to drop functions.",
+		)
+	}
+	return errors.WithHint(
+		pgerror.Newf(pgcode.WrongObjectType, "%s() is not a function", fnDesc.GetName()),
+		"Use DROP PROCEDURE to drop procedures.",
+	)
+}
+
 func (n *dropFunctionNode) startExec(params runParams) error {
 	for _, fnMutable := range n.toDrop {
 		if err := params.p.dropFunctionImpl(params.ctx, fnMutable); err != nil {
@@ -197,6 +272,11 @@ func (p *planner) dropFunctionImpl(ctx context.Context, fnMutable *funcdesc.Muta
 		}
 	}
 
+	// Remove backreferences from the support functions of an aggregate.
+	if err := p.removeAggregateBackReferences(ctx, fnMutable); err != nil {
+		return err
+	}
+
 	// Remove backreference from types referenced by this UDF.
 	jobDesc := fmt.Sprintf(
 		"updating type backreference %v for function %s(%d)",
@@ -72,6 +72,10 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 		td[droppedDesc.ID] = toDelete{tn, droppedDesc}
 	}
 
+	if err := p.addInheritingTablesToDrop(ctx, td, n.DropBehavior); err != nil {
+		return nil, err
+	}
+
 	for _, toDel := range td {
 		droppedDesc := toDel.desc
 		for _, fk := range droppedDesc.InboundForeignKeys() {
@@ -250,6 +254,16 @@ func (p *planner) dropTableImpl(
 	}
 	tableDesc.InboundFKs = nil
 
+	// Remove the references from the parent and inheriting tables.
+	if err := p.removeInheritanceReferences(ctx, tableDesc); err != nil {
+		return droppedViews, err
+	}
+
+	// Remove the references from the functions executed by triggers.
+	if err := p.removeTriggerBackReferences(ctx, tableDesc); err != nil {
+		return droppedViews, err
+	}
+
 	// Remove sequence dependencies.
 	for _, col := range tableDesc.PublicColumns() {
 		if err := p.removeSequenceDependencies(ctx, tableDesc, col); err != nil {
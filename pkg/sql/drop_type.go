@@ -38,10 +38,26 @@ type dropTypeNode struct {
 var _ planNode = &dropTypeNode{n: nil}
 
 func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, error) {
+	return p.dropTypes(ctx, n, "DROP TYPE", false /* domains */)
+}
+
+// DropDomain drops the given domains. It works like DropType, but only accepts
+// domains.
+func (p *planner) DropDomain(ctx context.Context, n *tree.DropDomain) (planNode, error) {
+	return p.dropTypes(ctx, &tree.DropType{
+		Names:        n.Names,
+		IfExists:     n.IfExists,
+		DropBehavior: n.DropBehavior,
+	}, "DROP DOMAIN", true /* domains */)
+}
+
+func (p *planner) dropTypes(
+	ctx context.Context, n *tree.DropType, opName string, domains bool,
+) (planNode, error) {
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"DROP TYPE",
+		opName,
 	); err != nil {
 		return nil, err
 	}
@@ -51,7 +67,7 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 		toDrop: make(map[descpb.ID]*typedesc.Mutable),
 	}
 	if n.DropBehavior == tree.DropCascade {
-		return nil, unimplemented.NewWithIssue(51480, "DROP TYPE CASCADE is not yet supported")
+		return nil, unimplemented.NewWithIssuef(51480, "%s CASCADE is not yet supported", opName)
 	}
 	for _, name := range n.Names {
 		// Resolve the desired type descriptor.
@@ -66,6 +82,9 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 		if _, ok := node.toDrop[typeDesc.ID]; ok {
 			continue
 		}
+		if err := checkDropTypeKind(name, typeDesc, domains); err != nil {
+			return nil, err
+		}
 		switch typeDesc.Kind {
 		case descpb.TypeDescriptor_ALIAS:
 			// The implicit array types are not directly droppable.
@@ -114,6 +133,23 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 	return node, nil
 }
 
+// checkDropTypeKind returns an error if the given type cannot be dropped with
+// DROP DOMAIN (if domains is set) or DROP TYPE (otherwise).
+func checkDropTypeKind(
+	name *tree.UnresolvedObjectName, typeDesc catalog.TypeDescriptor, domains bool,
+) error {
+	isDomain := typeDesc.GetKind() == descpb.TypeDescriptor_DOMAIN
+	if domains && !isDomain {
+		return pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", name)
+	}
+	if !domains && isDomain {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%q is a domain", name),
+			"Use DROP DOMAIN to remove a domain.")
+	}
+	return nil
+}
+
 func (p *planner) canDropTypeDesc(
 	ctx context.Context, desc *typedesc.Mutable, behavior tree.DropBehavior,
 ) error {
@@ -22,8 +22,7 @@ import (
 type errorIfRowsNode struct {
 	plan planNode
 
-	// mkErr creates the error message, given the values of the first row
-	// produced.
+	// mkErr creates the error message, given the values of a row produced.
 	mkErr exec.MkErrFn
 
 	nexted bool
@@ -39,14 +38,17 @@ func (n *errorIfRowsNode) Next(params runParams) (bool, error) {
 	}
 	n.nexted = true
 
-	ok, err := n.plan.Next(params)
-	if err != nil {
-		return false, err
+	for {
+		ok, err := n.plan.Next(params)
+		if err != nil || !ok {
+			return false, err
+		}
+		// The violation of a deferred constraint is recorded rather than
+		// reported, and so are the violations of the remaining rows.
+		if err := params.p.maybeDeferConstraintViolation(n.mkErr(n.plan.Values())); err != nil {
+			return false, err
+		}
 	}
-	if ok {
-		return false, n.mkErr(n.plan.Values())
-	}
-	return false, nil
 }
 
 func (n *errorIfRowsNode) Values() tree.Datums {
@@ -1865,6 +1865,10 @@ func checkResultType(typ *types.T) error {
 	case types.TSVectorFamily:
 	case types.IntervalFamily:
 	case types.JsonFamily:
+	case types.JsonpathFamily:
+	case types.PGVectorFamily:
+	case types.RangeFamily:
+	case types.MultirangeFamily:
 	case types.UuidFamily:
 	case types.INetFamily:
 	case types.OidFamily:
@@ -3020,10 +3024,18 @@ func (m *sessionDataMutator) SetDefaultIntSize(size int32) {
 	}
 }
 
+func (m *sessionDataMutator) SetDefaultTextSearchConfig(val string) {
+	m.data.DefaultTextSearchConfig = val
+}
+
 func (m *sessionDataMutator) SetDefaultTransactionPriority(val tree.UserPriority) {
 	m.data.DefaultTxnPriority = int64(val)
 }
 
+func (m *sessionDataMutator) SetDefaultTransactionIsolationLevel(val tree.IsolationLevel) {
+	m.data.DefaultTxnIsolationLevel = int64(val)
+}
+
 func (m *sessionDataMutator) SetDefaultTransactionReadOnly(val bool) {
 	m.data.DefaultTxnReadOnly = val
 }
@@ -3389,6 +3401,18 @@ func (m *sessionDataMutator) SetTrigramSimilarityThreshold(val float64) {
 	m.data.TrigramSimilarityThreshold = val
 }
 
+func (m *sessionDataMutator) SetTrigramWordSimilarityThreshold(val float64) {
+	m.data.TrigramWordSimilarityThreshold = val
+}
+
+func (m *sessionDataMutator) SetTrigramStrictWordSimilarityThreshold(val float64) {
+	m.data.TrigramStrictWordSimilarityThreshold = val
+}
+
+func (m *sessionDataMutator) SetVectorSearchProbes(val int64) {
+	m.data.VectorSearchProbes = val
+}
+
 func (m *sessionDataMutator) SetUnconstrainedNonCoveringIndexScanEnabled(val bool) {
 	m.data.UnconstrainedNonCoveringIndexScanEnabled = val
 }
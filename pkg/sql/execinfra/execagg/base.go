@@ -89,6 +89,12 @@ func GetAggregateConstructor(
 		}
 		argTypes[j] = inputTypes[c]
 	}
+	if aggInfo.Func == execinfrapb.UserDefined {
+		constructor, outputType, err = newUserDefinedAggregateConstructor(
+			ctx, evalCtx, semaCtx, aggInfo.UserDefined, argTypes,
+		)
+		return
+	}
 	arguments = make(tree.Datums, len(aggInfo.Arguments))
 	var d tree.Datum
 	for j, argument := range aggInfo.Arguments {
@@ -0,0 +1,206 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package execagg
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+// userDefinedAggregateDef contains the expressions of an aggregate created
+// with CREATE AGGREGATE. It is shared by all instances of the aggregate
+// created by a single processor, which evaluate the expressions one at a time.
+type userDefinedAggregateDef struct {
+	transition execinfrapb.ExprHelper
+	final      execinfrapb.ExprHelper
+	stateType  *types.T
+	argTypes   []*types.T
+	init       tree.Datum
+	strict     bool
+}
+
+// newUserDefinedAggregateConstructor returns the constructor and the return
+// type of the aggregate described by spec when applied on the given types.
+func newUserDefinedAggregateConstructor(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+	argTypes []*types.T,
+) (AggregateConstructor, *types.T, error) {
+	if spec == nil {
+		return nil, nil, errors.AssertionFailedf("missing definition of user-defined aggregate")
+	}
+	def := &userDefinedAggregateDef{
+		stateType: spec.StateType,
+		argTypes:  argTypes,
+		strict:    spec.Strict,
+	}
+	transitionTypes := make([]*types.T, 0, len(argTypes)+1)
+	transitionTypes = append(transitionTypes, spec.StateType)
+	transitionTypes = append(transitionTypes, argTypes...)
+	if err := def.transition.Init(ctx, spec.Transition, transitionTypes, semaCtx, evalCtx); err != nil {
+		return nil, nil, err
+	}
+	if err := def.final.Init(ctx, spec.Final, []*types.T{spec.StateType}, semaCtx, evalCtx); err != nil {
+		return nil, nil, err
+	}
+	var initHelper execinfrapb.ExprHelper
+	// Pass nil types and row - there are no variables in the initial state.
+	if err := initHelper.Init(ctx, spec.Init, nil /* types */, semaCtx, evalCtx); err != nil {
+		return nil, nil, err
+	}
+	init, err := initHelper.Eval(ctx, nil /* row */)
+	if err != nil {
+		return nil, nil, err
+	}
+	def.init = init
+	constructor := func(evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		acc := evalCtx.Planner.Mon().MakeBoundAccount()
+		a := &userDefinedAggregate{
+			def: def,
+			acc: acc,
+			row: make(rowenc.EncDatumRow, len(transitionTypes)),
+		}
+		a.state = def.init
+		a.noState = def.strict && def.init == tree.DNull
+		return a
+	}
+	return constructor, spec.ReturnType, nil
+}
+
+// userDefinedAggregate computes an aggregate created with CREATE AGGREGATE.
+type userDefinedAggregate struct {
+	def   *userDefinedAggregateDef
+	state tree.Datum
+	// noState is true if the aggregate is strict, has no initial condition
+	// and has not yet seen a row without NULL arguments. The first such row
+	// provides the state.
+	noState bool
+	row     rowenc.EncDatumRow
+	// acc accounts for the memory used by the state beyond the initial state,
+	// which is included in Size.
+	acc          mon.BoundAccount
+	accountedFor int64
+}
+
+var _ eval.ContextualAggregateFunc = &userDefinedAggregate{}
+
+// Add implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	if a.def.strict {
+		if firstArg == tree.DNull {
+			return nil
+		}
+		for _, arg := range otherArgs {
+			if arg == tree.DNull {
+				return nil
+			}
+		}
+		if a.noState {
+			a.noState = false
+			return a.setState(ctx, firstArg)
+		}
+		if a.state == tree.DNull {
+			// A strict transition function is never called with a NULL state,
+			// so the state remains NULL.
+			return nil
+		}
+	}
+	a.row[0] = rowenc.DatumToEncDatum(a.def.stateType, a.state)
+	a.row[1] = rowenc.DatumToEncDatum(a.def.argTypes[0], firstArg)
+	for i, arg := range otherArgs {
+		a.row[i+2] = rowenc.DatumToEncDatum(a.def.argTypes[i+1], arg)
+	}
+	state, err := a.def.transition.Eval(ctx, a.row)
+	if err != nil {
+		return err
+	}
+	return a.setState(ctx, state)
+}
+
+// setState replaces the state of the aggregate and updates the memory
+// account accordingly.
+func (a *userDefinedAggregate) setState(ctx context.Context, state tree.Datum) error {
+	newUsage := int64(state.Size()) - int64(a.def.init.Size())
+	if newUsage < 0 {
+		newUsage = 0
+	}
+	if err := a.acc.Grow(ctx, newUsage-a.accountedFor); err != nil {
+		return err
+	}
+	a.accountedFor = newUsage
+	a.state = state
+	return nil
+}
+
+// Result implements the eval.AggregateFunc interface. The result of a
+// user-defined aggregate requires a context, so ResultWithContext must be
+// used instead.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	return nil, errors.AssertionFailedf("the result of a user-defined aggregate requires a context")
+}
+
+// ResultWithContext implements the eval.ContextualAggregateFunc interface.
+func (a *userDefinedAggregate) ResultWithContext(ctx context.Context) (tree.Datum, error) {
+	return a.def.final.Eval(ctx, rowenc.EncDatumRow{rowenc.DatumToEncDatum(a.def.stateType, a.state)})
+}
+
+// Reset implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(ctx context.Context) {
+	a.acc.Shrink(ctx, a.accountedFor)
+	a.accountedFor = 0
+	a.state = a.def.init
+	a.noState = a.def.strict && a.def.init == tree.DNull
+}
+
+// Close implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(ctx context.Context) {
+	a.acc.Close(ctx)
+	a.accountedFor = 0
+}
+
+const sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
+
+// Size implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate + int64(a.state.Size())
+}
+
+// GetUserDefinedWindowFunctionInfo returns the window function constructor
+// and the return type of the aggregate described by spec when it is used as a
+// window function on the given types.
+func GetUserDefinedWindowFunctionInfo(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+	inputTypes []*types.T,
+) (windowConstructor func(*eval.Context) eval.WindowFunc, returnType *types.T, err error) {
+	constructor, returnType, err := newUserDefinedAggregateConstructor(
+		ctx, evalCtx, semaCtx, spec, inputTypes,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return builtins.NewFramableAggregateWindowFunc(constructor), returnType, nil
+}
@@ -73,4 +73,8 @@ const (
 	FinalCovarSamp          = AggregatorSpec_FINAL_COVAR_SAMP
 	FinalCorr               = AggregatorSpec_FINAL_CORR
 	FinalSqrdiff            = AggregatorSpec_FINAL_SQRDIFF
+	UserDefined             = AggregatorSpec_USER_DEFINED
+	StClusterIntersecting   = AggregatorSpec_ST_CLUSTERINTERSECTING
+	StClusterWithin         = AggregatorSpec_ST_CLUSTERWITHIN
+	StPolygonize            = AggregatorSpec_ST_POLYGONIZE
 )
@@ -42,6 +42,11 @@ func (a AggregatorSpec_Aggregation) Equals(b AggregatorSpec_Aggregation) bool {
 	if a.Func != b.Func || a.Distinct != b.Distinct {
 		return false
 	}
+	if a.UserDefined != b.UserDefined {
+		// User-defined aggregates are only considered identical if they share
+		// the same definition.
+		return false
+	}
 	if a.FilterColIdx == nil {
 		if b.FilterColIdx != nil {
 			return false
@@ -48,7 +48,7 @@ func (p *planner) fillInPlaceholders(
 		// the latest changes to the type.
 		if typ.UserDefined() {
 			var err error
-			typ, err = p.ResolveTypeByOID(ctx, typ.Oid())
+			typ, err = p.ResolveTypeByOID(ctx, typ.UserDefinedOID())
 			if err != nil {
 				return nil, err
 			}
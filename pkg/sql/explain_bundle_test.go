@@ -192,6 +192,7 @@ CREATE TABLE users(id UUID DEFAULT gen_random_uuid() PRIMARY KEY, promo_id INT R
 			{"enable_zigzag_join", "off"},
 			{"expect_and_ignore_not_visible_columns_in_copy", "on"},
 			{"intervalstyle", "iso_8601"},
+			{"ivfflat.probes", "20"},
 			{"large_full_scan_rows", "2000"},
 			{"locality_optimized_partitioned_index_scan", "off"},
 			{"null_ordered_last", "on"},
@@ -201,6 +202,8 @@ CREATE TABLE users(id UUID DEFAULT gen_random_uuid() PRIMARY KEY, promo_id INT R
 			{"optimizer_use_multicol_stats", "off"},
 			{"optimizer_use_not_visible_indexes", "on"},
 			{"pg_trgm.similarity_threshold", "0.6"},
+			{"pg_trgm.strict_word_similarity_threshold", "0.2"},
+			{"pg_trgm.word_similarity_threshold", "0.3"},
 			{"prefer_lookup_joins_for_fks", "on"},
 			{"propagate_input_ordering", "on"},
 			{"reorder_joins_limit", "3"},
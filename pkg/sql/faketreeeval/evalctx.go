@@ -187,6 +187,11 @@ func (ep *DummyEvalPlanner) GenerateTestObjects(
 	return "", errors.WithStack(errEvalPlanner)
 }
 
+// NotifyChannel is part of the Planner interface.
+func (ep *DummyEvalPlanner) NotifyChannel(ctx context.Context, channel, payload string) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
 // UnsafeUpsertDescriptor is part of the Planner interface.
 func (ep *DummyEvalPlanner) UnsafeUpsertDescriptor(
 	ctx context.Context, descID int64, encodedDescriptor []byte, force bool,
@@ -244,6 +249,13 @@ func (*DummyEvalPlanner) ExternalWriteFile(ctx context.Context, uri string, cont
 	return errors.WithStack(errEvalPlanner)
 }
 
+// OpenForeignTableFile is part of the Planner interface.
+func (*DummyEvalPlanner) OpenForeignTableFile(
+	ctx context.Context, server, filename string,
+) (eval.ForeignTableFile, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
 // DecodeGist is part of the Planner interface.
 func (*DummyEvalPlanner) DecodeGist(gist string, external bool) ([]string, error) {
 	return nil, errors.WithStack(errEvalPlanner)
@@ -0,0 +1,540 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/foreigntable"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+// externalStorageWrapperName is the name of the only foreign-data wrapper.
+// The foreign servers that use it are stored as External Connections, and
+// the files of their foreign tables are read through the external storage
+// of the connection.
+const externalStorageWrapperName = "external_storage"
+
+// The options of foreign servers and foreign tables that are not interpreted
+// by the foreigntable package.
+const (
+	serverOptionURI         = "uri"
+	foreignTableOptFilename = "filename"
+	foreignTableOptFormat   = "format"
+)
+
+type createServerNode struct {
+	n *tree.CreateServer
+}
+
+// CreateServer creates a foreign server, which is backed by an External
+// Connection of the same name.
+// Privileges: EXTERNALCONNECTION system privilege.
+func (p *planner) CreateServer(ctx context.Context, n *tree.CreateServer) (planNode, error) {
+	if n.Wrapper != externalStorageWrapperName {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"foreign-data wrapper %q does not exist", n.Wrapper)
+	}
+	return &createServerNode{n: n}, nil
+}
+
+func (n *createServerNode) startExec(params runParams) error {
+	var uri string
+	for _, opt := range n.n.Options {
+		if opt.Name != serverOptionURI {
+			return pgerror.Newf(pgcode.FdwInvalidOptionName, "invalid option %q", opt.Name)
+		}
+		uri = opt.Value
+	}
+	if uri == "" {
+		return pgerror.Newf(pgcode.FdwOptionNameNotFound, "option %q is required", serverOptionURI)
+	}
+
+	name := string(n.n.Name)
+	exists, err := params.p.foreignServerExists(params.ctx, name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if n.n.IfNotExists {
+			return nil
+		}
+		return pgerror.Newf(pgcode.DuplicateObject, "server %q already exists", name)
+	}
+	return params.p.createExternalConnection(params, &tree.CreateExternalConnection{
+		ConnectionLabelSpec: tree.LabelSpec{Label: tree.NewStrVal(name)},
+		As:                  tree.NewStrVal(uri),
+	})
+}
+
+func (*createServerNode) Next(runParams) (bool, error) { return false, nil }
+func (*createServerNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createServerNode) Close(context.Context)        {}
+
+type dropServerNode struct {
+	n *tree.DropServer
+}
+
+// DropServer drops foreign servers, along with their External Connections.
+// Privileges: DROP on the External Connection.
+func (p *planner) DropServer(ctx context.Context, n *tree.DropServer) (planNode, error) {
+	return &dropServerNode{n: n}, nil
+}
+
+func (n *dropServerNode) startExec(params runParams) error {
+	ctx := params.ctx
+	p := params.p
+	for _, name := range n.n.Names {
+		exists, err := p.foreignServerExists(ctx, string(name))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if n.n.IfExists {
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedObject, "server %q does not exist", name)
+		}
+
+		// Foreign tables cannot be read without their server.
+		all, err := p.Descriptors().GetAll(ctx, p.Txn())
+		if err != nil {
+			return err
+		}
+		if err := all.ForEachDescriptor(func(desc catalog.Descriptor) error {
+			tbl, ok := desc.(catalog.TableDescriptor)
+			if !ok || tbl.Dropped() || !tbl.IsForeignTable() {
+				return nil
+			}
+			if tbl.GetForeignTable().Server == string(name) {
+				return pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"cannot drop server %q because foreign table %q depends on it",
+					name, tbl.GetName())
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := p.dropExternalConnection(params, &tree.DropExternalConnection{
+			ConnectionLabel: tree.NewStrVal(string(name)),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*dropServerNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropServerNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropServerNode) Close(context.Context)        {}
+
+// foreignServerExists returns whether the External Connection that backs the
+// given foreign server exists. The lookup runs as node since the user might
+// not have SELECT on the system table.
+func (p *planner) foreignServerExists(ctx context.Context, name string) (bool, error) {
+	row, err := p.InternalSQLTxn().QueryRowEx(
+		ctx, "foreign-server-exists", p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT 1 FROM system.external_connections WHERE connection_name = $1`, name,
+	)
+	if err != nil {
+		return false, err
+	}
+	return row != nil, nil
+}
+
+type createForeignTableNode struct {
+	n      *tree.CreateForeignTable
+	dbDesc catalog.DatabaseDescriptor
+}
+
+// CreateForeignTable creates a foreign table, whose rows are read from a file
+// through the foreign server of the table.
+// Privileges: CREATE on the database, and USAGE on the foreign server.
+func (p *planner) CreateForeignTable(
+	ctx context.Context, n *tree.CreateForeignTable,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE FOREIGN TABLE",
+	); err != nil {
+		return nil, err
+	}
+
+	un := n.Table.ToUnresolvedObjectName()
+	dbDesc, _, prefix, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	n.Table.ObjectNamePrefix = prefix
+
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	return &createForeignTableNode{
+		n:      n,
+		dbDesc: dbDesc,
+	}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE FOREIGN TABLE performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *createForeignTableNode) ReadingOwnWrites() {}
+
+func (n *createForeignTableNode) startExec(params runParams) error {
+	ctx := params.ctx
+	p := params.p
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("foreign_table"))
+
+	server := string(n.n.Server)
+	exists, err := p.foreignServerExists(ctx, server)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return pgerror.Newf(pgcode.UndefinedObject, "server %q does not exist", server)
+	}
+	if err := p.CheckPrivilege(ctx, &syntheticprivilege.ExternalConnectionPrivilege{
+		ConnectionName: server,
+	}, privilege.USAGE); err != nil {
+		return err
+	}
+
+	schemaDesc, err := getSchemaForCreateTable(params, n.dbDesc, tree.PersistencePermanent,
+		&n.n.Table, tree.ResolveRequireForeignTableDesc, n.n.IfNotExists)
+	if err != nil {
+		if sqlerrors.IsRelationAlreadyExistsError(err) && n.n.IfNotExists {
+			return nil
+		}
+		return err
+	}
+
+	columns, err := p.foreignTableColumns(ctx, n.n.Defs)
+	if err != nil {
+		return err
+	}
+
+	foreignTable := &descpb.TableDescriptor_ForeignTable{
+		Server: server,
+		Format: foreigntable.FormatCSV,
+	}
+	var fileOpts []foreigntable.Option
+	for _, opt := range n.n.Options {
+		switch opt.Name {
+		case foreignTableOptFilename:
+			foreignTable.Filename = opt.Value
+		case foreignTableOptFormat:
+			foreignTable.Format = opt.Value
+		default:
+			fileOpts = append(fileOpts, foreigntable.Option{Name: string(opt.Name), Value: opt.Value})
+			foreignTable.Options = append(foreignTable.Options, descpb.TableDescriptor_ForeignTable_Option{
+				Name:  string(opt.Name),
+				Value: opt.Value,
+			})
+		}
+	}
+	if foreignTable.Filename == "" {
+		return pgerror.Newf(pgcode.FdwOptionNameNotFound,
+			"option %q is required", foreignTableOptFilename)
+	}
+	parsed, err := foreigntable.ParseOptions(foreignTable.Format, fileOpts)
+	if err != nil {
+		return err
+	}
+	foreignTable.Format = parsed.Format
+
+	id, err := p.EvalContext().DescIDGenerator.GenerateUniqueDescID(ctx)
+	if err != nil {
+		return err
+	}
+	privs := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schemaDesc.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Tables,
+	)
+
+	// creationTime is initialized to a zero value and populated at read time.
+	// See the comment in desc.MaybeIncrementVersion.
+	var creationTime hlc.Timestamp
+	desc := tabledesc.InitTableDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		schemaDesc.GetID(),
+		n.n.Table.Table(),
+		creationTime,
+		privs,
+		tree.PersistencePermanent,
+	)
+	if n.dbDesc.IsMultiRegion() {
+		desc.SetTableLocalityRegionalByTable(tree.PrimaryRegionNotSpecifiedName)
+	}
+	// The foreign table must be set before the columns are added, so that no
+	// primary key is added to the descriptor.
+	desc.ForeignTable = foreignTable
+	if err := addResultColumns(
+		ctx, &p.semaCtx, p.EvalContext(), p.EvalContext().Settings, &desc, columns,
+	); err != nil {
+		return err
+	}
+
+	if err := p.createDescriptor(
+		ctx, &desc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	if err := validateDescriptor(ctx, p, &desc); err != nil {
+		return err
+	}
+	return p.logEvent(ctx,
+		desc.ID,
+		&eventpb.CreateTable{
+			TableName: n.n.Table.FQString(),
+		})
+}
+
+func (*createForeignTableNode) Next(runParams) (bool, error) { return false, nil }
+func (*createForeignTableNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createForeignTableNode) Close(context.Context)        {}
+
+// foreignTableColumns returns the columns of a foreign table. Foreign tables
+// only support plain column definitions, since their rows are not stored by
+// the database.
+func (p *planner) foreignTableColumns(
+	ctx context.Context, defs tree.TableDefs,
+) (colinfo.ResultColumns, error) {
+	columns := make(colinfo.ResultColumns, 0, len(defs))
+	for _, def := range defs {
+		d, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"constraints are not supported on foreign tables")
+		}
+		if d.Nullable.Nullability == tree.NotNull || d.PrimaryKey.IsPrimaryKey ||
+			d.Unique.IsUnique || len(d.CheckExprs) > 0 || d.HasFKConstraint() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"constraints are not supported on foreign tables")
+		}
+		if d.HasDefaultExpr() || d.HasOnUpdateExpr() || d.IsComputed() || d.IsSerial ||
+			d.GeneratedIdentity.IsGeneratedAsIdentity || d.Hidden || d.HasColumnFamily() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %q of a foreign table must be a plain column", d.Name)
+		}
+		typ, err := tree.ResolveType(ctx, d.Type, p.semaCtx.GetTypeResolver())
+		if err != nil {
+			return nil, err
+		}
+		if typ.UserDefined() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"user-defined types are not supported on foreign tables")
+		}
+		columns = append(columns, colinfo.ResultColumn{Name: string(d.Name), Typ: typ})
+	}
+	return columns, nil
+}
+
+// foreignTableQuery returns the query that reads the rows of a foreign table,
+// which is planned like the query of a view. The fields of the file are
+// decoded as the columns given in the column definition list of the function.
+func foreignTableQuery(desc catalog.TableDescriptor) string {
+	foreignTable := desc.GetForeignTable()
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM crdb_internal.read_foreign_table(")
+	lexbase.EncodeSQLString(&buf, foreignTable.Server)
+	buf.WriteString(", ")
+	lexbase.EncodeSQLString(&buf, foreignTable.Filename)
+	buf.WriteString(", ")
+	lexbase.EncodeSQLString(&buf, foreignTable.Format)
+	buf.WriteString(", ARRAY[")
+	for i, opt := range foreignTable.Options {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		lexbase.EncodeSQLString(&buf, opt.Name)
+		buf.WriteString(", ")
+		lexbase.EncodeSQLString(&buf, opt.Value)
+	}
+	buf.WriteString("]:::STRING[]) AS t (")
+	for i, col := range desc.PublicColumns() {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		lexbase.EncodeRestrictedSQLIdent(&buf, col.GetName(), lexbase.EncNoFlags)
+		buf.WriteByte(' ')
+		buf.WriteString(col.GetType().SQLString())
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
+
+type dropForeignTableNode struct {
+	n  *tree.DropForeignTable
+	td []toDelete
+}
+
+// DropForeignTable drops foreign tables.
+// Privileges: DROP on the foreign table.
+func (p *planner) DropForeignTable(
+	ctx context.Context, n *tree.DropForeignTable,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP FOREIGN TABLE",
+	); err != nil {
+		return nil, err
+	}
+
+	td := make([]toDelete, 0, len(n.Names))
+	for i := range n.Names {
+		tn := &n.Names[i]
+		droppedDesc, err := p.prepareDrop(ctx, tn, !n.IfExists, tree.ResolveRequireForeignTableDesc)
+		if err != nil {
+			return nil, err
+		}
+		if droppedDesc == nil {
+			// IfExists specified and the foreign table did not exist.
+			continue
+		}
+		td = append(td, toDelete{tn, droppedDesc})
+	}
+
+	// Ensure that the foreign tables aren't depended on by any views, or that
+	// if they are then `cascade` was specified.
+	for _, toDel := range td {
+		for _, ref := range toDel.desc.DependedOnBy {
+			if descInSlice(ref.ID, td) {
+				continue
+			}
+			if err := p.canRemoveDependentFromTable(ctx, toDel.desc, ref, n.DropBehavior); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(td) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return &dropForeignTableNode{n: n, td: td}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because DROP FOREIGN TABLE performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *dropForeignTableNode) ReadingOwnWrites() {}
+
+func (n *dropForeignTableNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("foreign_table"))
+
+	ctx := params.ctx
+	for _, toDel := range n.td {
+		// Foreign tables have no rows or indexes of their own, so they are
+		// dropped like views, along with the views that depend on them.
+		cascadeDroppedViews, err := params.p.dropViewImpl(
+			ctx, toDel.desc, true /* queueJob */, tree.AsStringWithFQNames(n.n, params.Ann()), n.n.DropBehavior,
+		)
+		if err != nil {
+			return err
+		}
+		if err := params.p.logEvent(ctx,
+			toDel.desc.ID,
+			&eventpb.DropTable{
+				TableName:           toDel.tn.FQString(),
+				CascadeDroppedViews: cascadeDroppedViews}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*dropForeignTableNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropForeignTableNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropForeignTableNode) Close(context.Context)        {}
+
+// OpenForeignTableFile is part of the eval.Planner interface.
+func (p *planner) OpenForeignTableFile(
+	ctx context.Context, server, filename string,
+) (eval.ForeignTableFile, error) {
+	if err := p.CheckPrivilege(ctx, &syntheticprivilege.ExternalConnectionPrivilege{
+		ConnectionName: server,
+	}, privilege.USAGE); err != nil {
+		return nil, err
+	}
+
+	uri := url.URL{Scheme: "external", Host: server, Path: filename}
+	conn, err := p.ExecCfg().DistSQLSrv.ExternalStorageFromURI(ctx, uri.String(), p.User())
+	if err != nil {
+		return nil, err
+	}
+	size, err := conn.Size(ctx, "")
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Wrapf(err, "reading file %q of server %q", filename, server)
+	}
+	return &foreignTableFile{conn: conn, server: server, filename: filename, size: size}, nil
+}
+
+// foreignTableFile implements eval.ForeignTableFile for a file stored in an
+// External Connection.
+type foreignTableFile struct {
+	conn     cloud.ExternalStorage
+	server   string
+	filename string
+	size     int64
+}
+
+var _ eval.ForeignTableFile = &foreignTableFile{}
+
+// OpenAt is part of the eval.ForeignTableFile interface.
+func (f *foreignTableFile) OpenAt(ctx context.Context, offset int64) (ioctx.ReadCloserCtx, error) {
+	r, _, err := f.conn.ReadFileAt(ctx, "", offset)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading file %q of server %q", f.filename, f.server)
+	}
+	return r, nil
+}
+
+// Size is part of the eval.ForeignTableFile interface.
+func (f *foreignTableFile) Size() int64 {
+	return f.size
+}
+
+// Close is part of the eval.ForeignTableFile interface.
+func (f *foreignTableFile) Close(context.Context) error {
+	return f.conn.Close()
+}
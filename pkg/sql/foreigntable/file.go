@@ -0,0 +1,108 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package foreigntable
+
+import (
+	"context"
+	"io"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/errors"
+)
+
+// fileReader implements io.ReadSeeker and io.ReaderAt on top of the file of a
+// foreign table, which the Parquet decoder needs to read the footer of the
+// file before its row groups. The file is reopened whenever a read does not
+// continue the previous one. Note: contrary to io.ReaderAt, ReadAt does *not*
+// support parallel calls.
+type fileReader struct {
+	// ctx is captured at construction time and used for I/O operations.
+	ctx  context.Context
+	file eval.ForeignTableFile
+	// body, if set, is positioned at offset pos of the file.
+	body ioctx.ReadCloserCtx
+	pos  int64
+
+	readPos int64 // readPos is used to transform Read() to ReadAt(readPos).
+}
+
+var _ io.ReadSeeker = &fileReader{}
+var _ io.ReaderAt = &fileReader{}
+
+// Read implements io.Reader.
+func (r *fileReader) Read(p []byte) (int, error) {
+	n, err := r.ReadAt(p, r.readPos)
+	r.readPos += int64(n)
+	return n, err
+}
+
+// Seek implements io.Seeker.
+func (r *fileReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.readPos
+	case io.SeekEnd:
+		offset += r.file.Size()
+	default:
+		return 0, errors.AssertionFailedf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.Newf("cannot seek to negative offset %d", offset)
+	}
+	r.readPos = offset
+	return offset, nil
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *fileReader) ReadAt(p []byte, offset int64) (int, error) {
+	if offset >= r.file.Size() {
+		return 0, io.EOF
+	}
+	if r.body == nil || r.pos != offset {
+		if err := r.Close(); err != nil {
+			return 0, err
+		}
+		body, err := r.file.OpenAt(r.ctx, offset)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+		r.pos = offset
+	}
+
+	var read int
+	var err error
+	for n := 0; read < len(p); n, err = r.body.Read(r.ctx, p[read:]) {
+		read += n
+		if err != nil {
+			break
+		}
+	}
+	r.pos += int64(read)
+
+	// If we got an EOF after we had read enough, ignore it.
+	if read == len(p) && errors.Is(err, io.EOF) {
+		return read, nil
+	}
+	return read, err
+}
+
+// Close closes the reader of the file that is currently open, if any.
+func (r *fileReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close(r.ctx)
+	r.body = nil
+	return err
+}
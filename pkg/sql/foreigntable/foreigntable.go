@@ -0,0 +1,357 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package foreigntable decodes the files that back foreign tables into rows of
+// the types of their columns. CSV and Avro files are decoded by the readers of
+// the importer, which registers them with RegisterDecoderFactory; Parquet
+// files are decoded here.
+package foreigntable
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+	goparquet "github.com/fraugster/parquet-go"
+)
+
+// The file formats supported by foreign tables.
+const (
+	FormatCSV     = "csv"
+	FormatAvro    = "avro"
+	FormatParquet = "parquet"
+)
+
+// The options of a foreign table that are interpreted by this package.
+const (
+	optDelimiter = "delimiter"
+	optHeader    = "header"
+	optNull      = "null"
+)
+
+// Option is a name/value option of a foreign table.
+type Option struct {
+	Name  string
+	Value string
+}
+
+// Options describes how the file of a foreign table is decoded.
+type Options struct {
+	Format string
+	// Delimiter is the field delimiter of CSV files.
+	Delimiter rune
+	// Header is set if the first line of a CSV file contains the column names
+	// and should be skipped.
+	Header bool
+	// Null is the string that represents NULL in CSV files. Only unquoted
+	// fields are compared with it.
+	Null string
+}
+
+// ParseOptions validates the format and the options of a foreign table.
+func ParseOptions(format string, opts []Option) (Options, error) {
+	res := Options{Format: strings.ToLower(format), Delimiter: ','}
+	switch res.Format {
+	case FormatCSV, FormatAvro, FormatParquet:
+	default:
+		return Options{}, pgerror.Newf(pgcode.FdwInvalidOptionName,
+			"unsupported foreign table format %q", format)
+	}
+	for _, opt := range opts {
+		if res.Format != FormatCSV {
+			return Options{}, pgerror.Newf(pgcode.FdwInvalidOptionName,
+				"invalid option %q for format %s", opt.Name, res.Format)
+		}
+		switch opt.Name {
+		case optDelimiter:
+			r, size := utf8.DecodeRuneInString(opt.Value)
+			if r == utf8.RuneError || size != len(opt.Value) {
+				return Options{}, pgerror.Newf(pgcode.FdwInvalidStringFormat,
+					"%s must be a single character", optDelimiter)
+			}
+			res.Delimiter = r
+		case optHeader:
+			b, err := strconv.ParseBool(opt.Value)
+			if err != nil {
+				return Options{}, pgerror.Newf(pgcode.FdwInvalidStringFormat,
+					"%s requires a Boolean value", optHeader)
+			}
+			res.Header = b
+		case optNull:
+			res.Null = opt.Value
+		default:
+			return Options{}, pgerror.Newf(pgcode.FdwInvalidOptionName,
+				"invalid option %q", opt.Name)
+		}
+	}
+	return res, nil
+}
+
+// Column is a column of a foreign table.
+type Column struct {
+	Name string
+	Type *types.T
+}
+
+// Reader produces the rows of a foreign table file.
+type Reader interface {
+	// Next returns the next row, which has one datum per column. It returns
+	// io.EOF once all the rows have been read.
+	Next(ctx context.Context) (tree.Datums, error)
+	// Close releases the resources held by the reader, including the file.
+	Close(ctx context.Context) error
+}
+
+// RowDecoder decodes the rows of a file that is streamed.
+type RowDecoder interface {
+	// Next returns the next row, which has one datum per column. It returns
+	// io.EOF once all the rows have been read.
+	Next(ctx context.Context) (tree.Datums, error)
+}
+
+// DecoderFactory returns a RowDecoder of the rows of src, which is a file in
+// the format of opts.
+type DecoderFactory func(
+	ctx context.Context, evalCtx *eval.Context, src io.Reader, opts Options, columns []Column,
+) (RowDecoder, error)
+
+var decoderFactories = map[string]DecoderFactory{}
+
+// RegisterDecoderFactory registers the DecoderFactory of a format. The
+// decoders of CSV and Avro files are registered by the importer, so that
+// foreign tables and IMPORT decode files the same way.
+func RegisterDecoderFactory(format string, fn DecoderFactory) {
+	decoderFactories[format] = fn
+}
+
+// NewReader returns a Reader that decodes the file incrementally. CSV fields
+// are mapped to the columns by position; the fields of Avro and Parquet
+// records are mapped by name, and missing fields are NULL.
+//
+// The parts of the file held in memory by the decoder and the last row are
+// accounted for in acc. The Reader takes ownership of the file, even if an
+// error is returned.
+func NewReader(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	file eval.ForeignTableFile,
+	opts Options,
+	columns []Column,
+	acc *mon.BoundAccount,
+) (_ Reader, retErr error) {
+	base := reader{file: file, acc: acc}
+	defer func() {
+		if retErr != nil {
+			retErr = errors.CombineErrors(retErr, base.Close(ctx))
+		}
+	}()
+	if opts.Format == FormatParquet {
+		// The footer of a Parquet file describes where its row groups are, so
+		// the file is read at arbitrary offsets rather than streamed.
+		base.readerAt = &fileReader{ctx: ctx, file: file}
+		r, err := goparquet.NewFileReader(base.readerAt)
+		if err != nil {
+			return nil, pgerror.Wrap(err, pgcode.FdwError, "reading parquet file")
+		}
+		return &parquetReader{reader: base, r: r, evalCtx: evalCtx, columns: columns}, nil
+	}
+
+	newDecoder, ok := decoderFactories[opts.Format]
+	if !ok {
+		return nil, errors.AssertionFailedf("no decoder registered for foreign table format %q", opts.Format)
+	}
+	body, err := file.OpenAt(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	base.body = body
+	src := &countingReader{r: ioctx.ReaderCtxAdapter(ctx, body)}
+	dec, err := newDecoder(ctx, evalCtx, src, opts, columns)
+	if err != nil {
+		return nil, err
+	}
+	return &streamReader{reader: base, dec: dec, src: src}, nil
+}
+
+// reader holds the state shared by the readers of all the formats.
+type reader struct {
+	file eval.ForeignTableFile
+	// Either body, which streams the file, or readerAt is set.
+	body     ioctx.ReadCloserCtx
+	readerAt *fileReader
+	acc      *mon.BoundAccount
+	// buffered is the number of bytes of the file that the decoder holds in
+	// memory. It is accounted for together with the last row.
+	buffered int64
+}
+
+// account resizes the account to the buffered bytes and the given row.
+func (r *reader) account(ctx context.Context, row tree.Datums) error {
+	sz := r.buffered
+	for _, d := range row {
+		sz += int64(d.Size())
+	}
+	return r.acc.ResizeTo(ctx, sz)
+}
+
+// Close implements the Reader interface.
+func (r *reader) Close(ctx context.Context) error {
+	var err error
+	if r.body != nil {
+		err = r.body.Close(ctx)
+		r.body = nil
+	}
+	if r.readerAt != nil {
+		err = errors.CombineErrors(err, r.readerAt.Close())
+		r.readerAt = nil
+	}
+	if r.file != nil {
+		err = errors.CombineErrors(err, r.file.Close(ctx))
+		r.file = nil
+	}
+	return err
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements io.Reader.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// streamReader decodes the files that are streamed with a registered
+// RowDecoder. The bytes that the decoder read from the file to produce the
+// last row, such as a block of an Avro file, are accounted for until it reads
+// more of the file.
+type streamReader struct {
+	reader
+	dec RowDecoder
+	src *countingReader
+}
+
+func (s *streamReader) Next(ctx context.Context) (tree.Datums, error) {
+	before := s.src.n
+	row, err := s.dec.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if read := s.src.n - before; read > 0 {
+		s.buffered = read
+	}
+	if err := s.account(ctx, row); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// parquetReader decodes Parquet files. The Parquet decoder loads a whole row
+// group at a time, whose size is accounted for until the next row group is
+// loaded.
+type parquetReader struct {
+	reader
+	r       *goparquet.FileReader
+	evalCtx *eval.Context
+	columns []Column
+}
+
+func (p *parquetReader) Next(ctx context.Context) (tree.Datums, error) {
+	record, err := p.r.NextRow()
+	if err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, pgerror.Wrap(err, pgcode.FdwError, "reading parquet file")
+	}
+	if rg := p.r.CurrentRowGroup(); rg != nil {
+		p.buffered = rg.TotalByteSize
+	}
+	row, err := recordToRow(ctx, p.evalCtx, record, p.columns)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.account(ctx, row); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// recordToRow picks the fields of a decoded Parquet record that correspond to
+// the columns and converts them to the types of the columns.
+func recordToRow(
+	ctx context.Context, evalCtx *eval.Context, record map[string]interface{}, columns []Column,
+) (tree.Datums, error) {
+	row := make(tree.Datums, len(columns))
+	for i, col := range columns {
+		s, isNull, err := nativeToString(record[col.Name])
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %q", col.Name)
+		}
+		if isNull {
+			row[i] = tree.DNull
+			continue
+		}
+		row[i], err = rowenc.ParseDatumStringAs(ctx, col.Type, s, evalCtx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %q", col.Name)
+		}
+	}
+	return row, nil
+}
+
+// nativeToString converts a value decoded by the Parquet library to its
+// string representation.
+func nativeToString(v interface{}) (s string, isNull bool, _ error) {
+	switch t := v.(type) {
+	case nil:
+		return "", true, nil
+	case string:
+		return t, false, nil
+	case []byte:
+		return string(t), false, nil
+	case bool:
+		return strconv.FormatBool(t), false, nil
+	case int32:
+		return strconv.FormatInt(int64(t), 10), false, nil
+	case int64:
+		return strconv.FormatInt(t, 10), false, nil
+	case int:
+		return strconv.Itoa(t), false, nil
+	case float32:
+		return strconv.FormatFloat(float64(t), 'g', -1, 32), false, nil
+	case float64:
+		return strconv.FormatFloat(t, 'g', -1, 64), false, nil
+	case time.Time:
+		return t.Format(time.RFC3339Nano), false, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", false, pgerror.Wrapf(err, pgcode.FdwInvalidDataType, "cannot convert %T", v)
+	}
+	return string(b), false, nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package foreigntable
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/stretchr/testify/require"
+)
+
+// memFile is a File backed by a byte slice that records the offsets it was
+// opened at.
+type memFile struct {
+	data   []byte
+	opened []int64
+	open   int
+	closed bool
+}
+
+var _ eval.ForeignTableFile = &memFile{}
+
+func (f *memFile) OpenAt(_ context.Context, offset int64) (ioctx.ReadCloserCtx, error) {
+	f.opened = append(f.opened, offset)
+	f.open++
+	return &memBody{Reader: bytes.NewReader(f.data[offset:]), f: f}, nil
+}
+
+func (f *memFile) Size() int64 { return int64(len(f.data)) }
+
+func (f *memFile) Close(context.Context) error {
+	f.closed = true
+	return nil
+}
+
+type memBody struct {
+	*bytes.Reader
+	f *memFile
+}
+
+func (b *memBody) Read(_ context.Context, p []byte) (int, error) { return b.Reader.Read(p) }
+
+func (b *memBody) Close(context.Context) error {
+	b.f.open--
+	return nil
+}
+
+// newAccount returns an account of a monitor with the given budget.
+func newAccount(ctx context.Context, budget int64) *mon.BoundAccount {
+	monitor := mon.NewMonitor("test_monitor", mon.MemoryResource,
+		nil, nil, -1, math.MaxInt64, cluster.MakeTestingClusterSettings())
+	monitor.Start(ctx, nil, mon.NewStandaloneBudget(budget))
+	acc := monitor.MakeBoundAccount()
+	return &acc
+}
+
+func TestParseOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	opts, err := ParseOptions("CSV", []Option{
+		{Name: "delimiter", Value: "|"},
+		{Name: "header", Value: "true"},
+		{Name: "null", Value: `\N`},
+	})
+	require.NoError(t, err)
+	require.Equal(t, Options{Format: FormatCSV, Delimiter: '|', Header: true, Null: `\N`}, opts)
+
+	for _, tc := range []struct {
+		format string
+		opts   []Option
+		err    string
+	}{
+		{format: "xml", err: `unsupported foreign table format "xml"`},
+		{format: "csv", opts: []Option{{Name: "delimiter", Value: "ab"}}, err: "delimiter must be a single character"},
+		{format: "csv", opts: []Option{{Name: "header", Value: "maybe"}}, err: "header requires a Boolean value"},
+		{format: "csv", opts: []Option{{Name: "quote", Value: "'"}}, err: `invalid option "quote"`},
+		{format: "avro", opts: []Option{{Name: "header", Value: "true"}}, err: `invalid option "header" for format avro`},
+	} {
+		_, err := ParseOptions(tc.format, tc.opts)
+		require.EqualError(t, err, tc.err)
+	}
+}
+
+// linesFormat is the format of the files decoded by linesDecoder.
+const linesFormat = "lines"
+
+func init() {
+	RegisterDecoderFactory(linesFormat, func(
+		_ context.Context, _ *eval.Context, src io.Reader, _ Options, _ []Column,
+	) (RowDecoder, error) {
+		return &linesDecoder{s: bufio.NewScanner(src)}, nil
+	})
+}
+
+// linesDecoder decodes each line of a file as a row with a single string.
+type linesDecoder struct {
+	s *bufio.Scanner
+}
+
+func (d *linesDecoder) Next(context.Context) (tree.Datums, error) {
+	if !d.s.Scan() {
+		if err := d.s.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	return tree.Datums{tree.NewDString(d.s.Text())}, nil
+}
+
+func TestStreamReader(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	f := &memFile{data: []byte("a\nb\n")}
+	acc := newAccount(ctx, math.MaxInt64)
+	columns := []Column{{Name: "x", Type: types.String}}
+	r, err := NewReader(ctx, nil /* evalCtx */, f, Options{Format: linesFormat}, columns, acc)
+	require.NoError(t, err)
+	for _, expected := range []string{"a", "b"} {
+		row, err := r.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, tree.Datums{tree.NewDString(expected)}, row)
+		require.NotZero(t, acc.Used())
+	}
+	_, err = r.Next(ctx)
+	require.Equal(t, io.EOF, err)
+	require.NoError(t, r.Close(ctx))
+	require.Equal(t, []int64{0}, f.opened)
+	require.Zero(t, f.open)
+	require.True(t, f.closed)
+}
+
+func TestReaderMemoryBudget(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	f := &memFile{data: []byte("a,b\n")}
+	acc := newAccount(ctx, 1)
+	columns := []Column{{Name: "x", Type: types.String}}
+	r, err := NewReader(ctx, nil /* evalCtx */, f, Options{Format: linesFormat}, columns, acc)
+	require.NoError(t, err)
+	_, err = r.Next(ctx)
+	require.Error(t, err)
+	require.Equal(t, pgcode.OutOfMemory, pgerror.GetPGCode(err))
+	require.NoError(t, r.Close(ctx))
+	require.True(t, f.closed)
+}
+
+func TestFileReader(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	f := &memFile{data: []byte("0123456789")}
+	r := &fileReader{ctx: context.Background(), file: f}
+
+	// Sequential reads reuse the open reader.
+	buf := make([]byte, 3)
+	n, err := r.ReadAt(buf, 2)
+	require.NoError(t, err)
+	require.Equal(t, "234", string(buf[:n]))
+	n, err = r.ReadAt(buf, 5)
+	require.NoError(t, err)
+	require.Equal(t, "567", string(buf[:n]))
+	require.Equal(t, []int64{2}, f.opened)
+
+	// A read at another offset reopens the file.
+	pos, err := r.Seek(-2, io.SeekEnd)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), pos)
+	n, err = r.Read(buf)
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, "89", string(buf[:n]))
+	require.Equal(t, []int64{2, 8}, f.opened)
+	_, err = r.Read(buf)
+	require.Equal(t, io.EOF, err)
+
+	require.NoError(t, r.Close())
+	require.Zero(t, f.open)
+}
@@ -223,17 +223,17 @@ CREATE FUNCTION sc1.lower() RETURNS INT IMMUTABLE LANGUAGE SQL AS $$ SELECT 3 $$
 			testName:       "builtin function schema is respected",
 			funName:        tree.UnresolvedName{NumParts: 1, Parts: tree.NameParts{"lower", "", "", ""}},
 			searchPath:     []string{"sc1", "sc2"},
-			expectUDF:      []bool{false, true},
-			expectedBody:   []string{"", "SELECT 3;"},
-			expectedSchema: []string{"pg_catalog", "sc1"},
+			expectUDF:      []bool{false, false, false, true},
+			expectedBody:   []string{"", "", "", "SELECT 3;"},
+			expectedSchema: []string{"pg_catalog", "pg_catalog", "pg_catalog", "sc1"},
 		},
 		{
 			testName:       "explicit builtin function schema",
 			funName:        tree.UnresolvedName{NumParts: 2, Parts: tree.NameParts{"lower", "pg_catalog", "", ""}},
 			searchPath:     []string{"sc1", "sc2"},
-			expectUDF:      []bool{false},
-			expectedBody:   []string{""},
-			expectedSchema: []string{"pg_catalog"},
+			expectUDF:      []bool{false, false, false},
+			expectedBody:   []string{"", "", ""},
+			expectedSchema: []string{"pg_catalog", "pg_catalog", "pg_catalog"},
 		},
 		{
 			testName:    "unsupported builtin function",
@@ -310,6 +310,9 @@ CREATE FUNCTION sc1.f(a INT, b INT) RETURNS INT IMMUTABLE LANGUAGE SQL AS $$ SEL
 CREATE FUNCTION sc1.f(a INT) RETURNS INT IMMUTABLE LANGUAGE SQL AS $$ SELECT 2 $$;
 CREATE FUNCTION sc2.f(a INT) RETURNS INT IMMUTABLE LANGUAGE SQL AS $$ SELECT 3 $$;
 CREATE FUNCTION sc1.lower(a STRING) RETURNS STRING IMMUTABLE LANGUAGE SQL AS $$ SELECT lower('HI') $$;
+CREATE FUNCTION sc1.v(a INT, VARIADIC b INT[]) RETURNS INT IMMUTABLE LANGUAGE SQL AS $$ SELECT a + b[1] $$;
+CREATE FUNCTION sc1.p(a anyelement, b anyelement) RETURNS anyelement IMMUTABLE LANGUAGE SQL AS $$ SELECT a $$;
+CREATE FUNCTION sc1.p(a anyarray) RETURNS anyelement IMMUTABLE LANGUAGE SQL AS $$ SELECT a[1] $$;
 `,
 	)
 
@@ -321,6 +324,7 @@ CREATE FUNCTION sc1.lower(a STRING) RETURNS STRING IMMUTABLE LANGUAGE SQL AS $$
 		expectedErr      string
 		expectedFuncBody string
 		desiredType      *types.T
+		expectedType     *types.T
 	}{
 		{
 			testName:    "explicit schema but function not found",
@@ -378,6 +382,42 @@ CREATE FUNCTION sc1.lower(a STRING) RETURNS STRING IMMUTABLE LANGUAGE SQL AS $$
 			expectedFuncBody: "SELECT lower('HI');",
 			desiredType:      types.String,
 		},
+		{
+			testName:         "variadic",
+			exprStr:          "sc1.v(1, 2, 3)",
+			searchPath:       []string{"sc1"},
+			expectedFuncOID:  100110,
+			expectedFuncBody: "SELECT a + b[1];",
+			expectedType:     types.Int,
+		},
+		{
+			testName:    "variadic without variadic arguments",
+			exprStr:     "sc1.v(1)",
+			searchPath:  []string{"sc1"},
+			expectedErr: `unknown signature: v\(int\)`,
+		},
+		{
+			testName:         "polymorphic",
+			exprStr:          "sc1.p('a'::STRING, 'b'::STRING)",
+			searchPath:       []string{"sc1"},
+			expectedFuncOID:  100111,
+			expectedFuncBody: "SELECT a;",
+			expectedType:     types.String,
+		},
+		{
+			testName:         "polymorphic array",
+			exprStr:          "sc1.p(ARRAY[1.5::FLOAT])",
+			searchPath:       []string{"sc1"},
+			expectedFuncOID:  100112,
+			expectedFuncBody: "SELECT a[1];",
+			expectedType:     types.Float,
+		},
+		{
+			testName:    "polymorphic arguments not alike",
+			exprStr:     "sc1.p(1, 'b'::STRING)",
+			searchPath:  []string{"sc1"},
+			expectedErr: "could not determine polymorphic type",
+		},
 	}
 
 	var sessionData sessiondatapb.SessionData
@@ -429,6 +469,9 @@ CREATE FUNCTION sc1.lower(a STRING) RETURNS STRING IMMUTABLE LANGUAGE SQL AS $$
 				require.Equal(t, tc.expectedFuncOID, int(funcExpr.ResolvedOverload().Oid))
 				require.Equal(t, funcExpr.ResolvedOverload().IsUDF, funcdesc.IsOIDUserDefinedFunc(funcExpr.ResolvedOverload().Oid))
 				require.Equal(t, tc.expectedFuncBody, funcExpr.ResolvedOverload().Body)
+				if tc.expectedType != nil {
+					require.Equal(t, tc.expectedType, funcExpr.ResolvedType())
+				}
 			})
 		}
 		return nil
@@ -0,0 +1,164 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+	"io"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/foreigntable"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// The files of foreign tables are decoded by the same producers and consumers
+// as the files of IMPORT, so that both interpret CSV and Avro files the same
+// way.
+func init() {
+	foreigntable.RegisterDecoderFactory(foreigntable.FormatCSV, newForeignTableCSVDecoder)
+	foreigntable.RegisterDecoderFactory(foreigntable.FormatAvro, newForeignTableAvroDecoder)
+}
+
+func newForeignTableCSVDecoder(
+	_ context.Context,
+	evalCtx *eval.Context,
+	src io.Reader,
+	opts foreigntable.Options,
+	columns []foreigntable.Column,
+) (foreigntable.RowDecoder, error) {
+	importCtx := newForeignTableImportContext(evalCtx, columns)
+	nullEncoding := opts.Null
+	c := &csvInputReader{
+		importCtx:           importCtx,
+		numExpectedDataCols: len(columns),
+		opts: roachpb.CSVOptions{
+			Comma:        opts.Delimiter,
+			NullEncoding: &nullEncoding,
+		},
+	}
+	producer, consumer := newCSVPipeline(c, &fileReader{Reader: src})
+	d := newForeignTableDecoder(importCtx, producer, consumer)
+	if opts.Header {
+		d.skip = 1
+	}
+	return d, nil
+}
+
+func newForeignTableAvroDecoder(
+	_ context.Context,
+	evalCtx *eval.Context,
+	src io.Reader,
+	_ foreigntable.Options,
+	columns []foreigntable.Column,
+) (foreigntable.RowDecoder, error) {
+	importCtx := newForeignTableImportContext(evalCtx, columns)
+	avro := &avroInputReader{
+		importContext: importCtx,
+		opts:          roachpb.AvroOptions{Format: roachpb.AvroOptions_OCF},
+	}
+	producer, consumer, err := newImportAvroPipeline(avro, &fileReader{Reader: src})
+	if err != nil {
+		return nil, pgerror.Wrap(err, pgcode.FdwError, "reading avro file")
+	}
+	return newForeignTableDecoder(importCtx, producer, consumer), nil
+}
+
+// newForeignTableImportContext returns the context of the readers of a
+// foreign table file. The readers only look at the columns of the table
+// descriptor, so it describes a foreign table with the given columns and
+// nothing else.
+func newForeignTableImportContext(
+	evalCtx *eval.Context, columns []foreigntable.Column,
+) *parallelImportContext {
+	desc := descpb.TableDescriptor{
+		Name:         "foreign_table",
+		ForeignTable: &descpb.TableDescriptor_ForeignTable{},
+	}
+	for i, col := range columns {
+		desc.Columns = append(desc.Columns, descpb.ColumnDescriptor{
+			ID:       descpb.ColumnID(i + 1),
+			Name:     col.Name,
+			Type:     col.Type,
+			Nullable: true,
+		})
+	}
+	return &parallelImportContext{
+		evalCtx:   evalCtx,
+		tableDesc: tabledesc.NewBuilder(&desc).BuildImmutableTable(),
+	}
+}
+
+// foreignTableDecoder implements foreigntable.RowDecoder on top of the
+// producer and consumer of an import reader. Rows are decoded one at a time
+// rather than by parallel workers, since they are returned to a query.
+type foreignTableDecoder struct {
+	producer importRowProducer
+	consumer importRowConsumer
+	conv     *row.DatumRowConverter
+	// skip is the number of records to skip, such as the header of a CSV file.
+	skip   int
+	rowNum int64
+}
+
+var _ foreigntable.RowDecoder = &foreignTableDecoder{}
+
+func newForeignTableDecoder(
+	importCtx *parallelImportContext, producer importRowProducer, consumer importRowConsumer,
+) *foreignTableDecoder {
+	conv := &row.DatumRowConverter{
+		EvalCtx:     importCtx.evalCtx,
+		VisibleCols: importCtx.tableDesc.VisibleColumns(),
+	}
+	conv.VisibleColTypes = make([]*types.T, len(conv.VisibleCols))
+	for i, col := range conv.VisibleCols {
+		conv.VisibleColTypes[i] = col.GetType()
+		conv.TargetColOrds.Add(i)
+	}
+	return &foreignTableDecoder{producer: producer, consumer: consumer, conv: conv}
+}
+
+// Next implements the foreigntable.RowDecoder interface.
+func (d *foreignTableDecoder) Next(ctx context.Context) (tree.Datums, error) {
+	for {
+		if !d.producer.Scan() {
+			if err := d.producer.Err(); err != nil {
+				return nil, pgerror.WithCandidateCode(err, pgcode.FdwError)
+			}
+			return nil, io.EOF
+		}
+		if d.skip == 0 {
+			break
+		}
+		d.skip--
+		if err := d.producer.Skip(); err != nil {
+			return nil, pgerror.WithCandidateCode(err, pgcode.FdwError)
+		}
+	}
+	data, err := d.producer.Row()
+	if err != nil {
+		return nil, pgerror.WithCandidateCode(err, pgcode.FdwError)
+	}
+	d.rowNum++
+	// The rows are handed to the caller, so each one gets its own slice. The
+	// consumers leave the datums of missing fields unset.
+	d.conv.Datums = make(tree.Datums, len(d.conv.VisibleCols))
+	if err := d.consumer.FillDatums(ctx, data, d.rowNum, d.conv); err != nil {
+		return nil, pgerror.WithCandidateCode(err, pgcode.FdwInvalidDataType)
+	}
+	return d.conv.Datums, nil
+}
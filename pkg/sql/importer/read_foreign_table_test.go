@@ -0,0 +1,155 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/foreigntable"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// foreignTableFile is a foreign table file backed by a byte slice.
+type foreignTableFile struct {
+	data   []byte
+	closed bool
+}
+
+var _ eval.ForeignTableFile = &foreignTableFile{}
+
+func (f *foreignTableFile) OpenAt(_ context.Context, offset int64) (ioctx.ReadCloserCtx, error) {
+	return ioctx.NopCloser(ioctx.ReaderAdapter(bytes.NewReader(f.data[offset:]))), nil
+}
+
+func (f *foreignTableFile) Size() int64 { return int64(len(f.data)) }
+
+func (f *foreignTableFile) Close(context.Context) error {
+	f.closed = true
+	return nil
+}
+
+// readForeignTable decodes all the rows of a foreign table file, formatted as
+// strings.
+func readForeignTable(
+	t *testing.T, data []byte, opts foreigntable.Options, columns []foreigntable.Column,
+) ([][]string, error) {
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	evalCtx := eval.MakeTestingEvalContext(st)
+	defer evalCtx.Stop(ctx)
+	monitor := mon.NewMonitor("test_monitor", mon.MemoryResource,
+		nil, nil, -1, math.MaxInt64, st)
+	monitor.Start(ctx, nil, mon.NewStandaloneBudget(math.MaxInt64))
+	defer monitor.Stop(ctx)
+	acc := monitor.MakeBoundAccount()
+	defer acc.Close(ctx)
+
+	f := &foreignTableFile{data: data}
+	r, err := foreigntable.NewReader(ctx, &evalCtx, f, opts, columns, &acc)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, r.Close(ctx))
+		require.True(t, f.closed)
+	}()
+	var res [][]string
+	for {
+		row, err := r.Next(ctx)
+		if err == io.EOF {
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		require.NotZero(t, acc.Used())
+		strs := make([]string, len(row))
+		for i, d := range row {
+			require.True(t, d == tree.DNull || d.ResolvedType().Equivalent(columns[i].Type))
+			strs[i] = tree.AsStringWithFlags(d, tree.FmtBareStrings)
+		}
+		res = append(res, strs)
+	}
+}
+
+func TestForeignTableCSV(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	columns := []foreigntable.Column{
+		{Name: "id", Type: types.Int},
+		{Name: "name", Type: types.String},
+	}
+	opts, err := foreigntable.ParseOptions(foreigntable.FormatCSV, []foreigntable.Option{
+		{Name: "delimiter", Value: "|"},
+		{Name: "header", Value: "true"},
+	})
+	require.NoError(t, err)
+	rows, err := readForeignTable(t, []byte("id|name\n1|a\n2|\n3|\"\"\n"), opts, columns)
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"1", "a"}, {"2", "NULL"}, {"3", ""}}, rows)
+
+	opts, err = foreigntable.ParseOptions(foreigntable.FormatCSV, nil /* opts */)
+	require.NoError(t, err)
+	_, err = readForeignTable(t, []byte("1,2,3\n"), opts, columns)
+	require.ErrorContains(t, err, "error parsing row 1: expected 2 fields, got 3")
+	_, err = readForeignTable(t, []byte("a,b\n"), opts, columns)
+	require.ErrorContains(t, err, `parse "id" as INT8`)
+}
+
+func TestForeignTableAvro(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const schema = `{
+		"type": "record",
+		"name": "r",
+		"fields": [
+			{"name": "id", "type": "long"},
+			{"name": "name", "type": ["null", "string"]},
+			{"name": "score", "type": "double"}
+		]
+	}`
+	codec, err := goavro.NewCodec(schema)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	w, err := goavro.NewOCFWriter(goavro.OCFConfig{W: &buf, Codec: codec})
+	require.NoError(t, err)
+	require.NoError(t, w.Append([]interface{}{
+		map[string]interface{}{"id": int64(1), "name": goavro.Union("string", "a"), "score": 1.5},
+		map[string]interface{}{"id": int64(2), "name": nil, "score": 2.0},
+	}))
+
+	opts, err := foreigntable.ParseOptions(foreigntable.FormatAvro, nil /* opts */)
+	require.NoError(t, err)
+	rows, err := readForeignTable(t, buf.Bytes(), opts, []foreigntable.Column{
+		{Name: "name", Type: types.String},
+		{Name: "id", Type: types.Int},
+		{Name: "missing", Type: types.Int},
+		{Name: "score", Type: types.Float},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"a", "1", "NULL", "1.5"},
+		{"NULL", "2", "NULL", "2.0"},
+	}, rows)
+}
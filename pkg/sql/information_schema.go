@@ -776,7 +776,7 @@ https://www.postgresql.org/docs/9.5/infoschema-constraint-column-usage.html`,
 					cols = refTable.ForeignKeyReferencedColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.IsExclusion() {
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for _, col := range cols {
@@ -822,7 +822,7 @@ https://www.postgresql.org/docs/9.5/infoschema-key-column-usage.html`,
 					cols = table.ForeignKeyOriginColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.IsExclusion() {
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for pos, col := range cols {
@@ -1281,6 +1281,11 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 				tbNameStr := tree.NewDString(table.GetName())
 
 				for _, c := range table.AllConstraints() {
+					if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && uwoi.IsExclusion() {
+						// Like Postgres, omit exclusion constraints, which have no
+						// corresponding constraint_type.
+						continue
+					}
 					kind := catconstants.ConstraintTypeUnique
 					if c.AsCheck() != nil {
 						kind = catconstants.ConstraintTypeCheck
@@ -1430,6 +1435,7 @@ var (
 	tableTypeBaseTable  = tree.NewDString("BASE TABLE")
 	tableTypeView       = tree.NewDString("VIEW")
 	tableTypeTemporary  = tree.NewDString("LOCAL TEMPORARY")
+	tableTypeForeign    = tree.NewDString("FOREIGN")
 )
 
 var informationSchemaTablesTable = virtualSchemaTable{
@@ -1458,6 +1464,9 @@ func addTablesTableRow(
 		if table.IsVirtualTable() {
 			tableType = tableTypeSystemView
 			insertable = noString
+		} else if table.IsForeignTable() {
+			tableType = tableTypeForeign
+			insertable = noString
 		} else if table.IsView() {
 			tableType = tableTypeView
 			insertable = noString
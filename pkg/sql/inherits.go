@@ -0,0 +1,361 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// resolveInheritedTables resolves the parent tables listed in the INHERITS
+// clause of a CREATE TABLE statement and returns them, along with the table
+// definitions of n with the columns inherited from the parents prepended.
+//
+// As in Postgres, the visible columns of the parents are added to the new
+// table in order. Columns with the same name, whether they come from
+// different parents or from the definition of the new table, are merged into
+// a single column, provided that they have the same type. A merged column is
+// NOT NULL if any of its definitions is NOT NULL.
+func (p *planner) resolveInheritedTables(
+	ctx context.Context, n *tree.CreateTable,
+) (parents []*tabledesc.Mutable, defs tree.TableDefs, _ error) {
+	if len(n.Inherits) == 0 {
+		return nil, n.Defs, nil
+	}
+	var inherited []*tree.ColumnTableDef
+	inheritedByName := make(map[tree.Name]*tree.ColumnTableDef)
+	inheritedTypes := make(map[tree.Name]*types.T)
+	for i := range n.Inherits {
+		tn := &n.Inherits[i]
+		_, parent, err := p.ResolveMutableTableDescriptor(ctx, tn, true /* required */, tree.ResolveRequireTableDesc)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := p.CheckPrivilege(ctx, parent, privilege.CREATE); err != nil {
+			return nil, nil, err
+		}
+		for _, other := range parents {
+			if other.GetID() == parent.GetID() {
+				return nil, nil, pgerror.Newf(pgcode.DuplicateRelation,
+					"relation %q would be inherited from more than once", parent.GetName())
+			}
+		}
+		parents = append(parents, parent)
+
+		for _, col := range parent.VisibleColumns() {
+			name := tree.Name(col.GetName())
+			if def, ok := inheritedByName[name]; ok {
+				if !inheritedTypes[name].Identical(col.GetType()) {
+					return nil, nil, inheritedTypeConflictError(name, inheritedTypes[name], col.GetType())
+				}
+				p.BufferClientNotice(ctx, pgnotice.Newf(
+					"merging multiple inherited definitions of column %q", col.GetName()))
+				if !col.IsNullable() {
+					def.Nullable.Nullability = tree.NotNull
+				}
+				continue
+			}
+			def := &tree.ColumnTableDef{Name: name, Type: col.GetType()}
+			if col.IsNullable() {
+				def.Nullable.Nullability = tree.Null
+			} else {
+				def.Nullable.Nullability = tree.NotNull
+			}
+			if col.HasDefault() {
+				if def.DefaultExpr.Expr, err = parser.ParseExpr(col.GetDefaultExpr()); err != nil {
+					return nil, nil, err
+				}
+			}
+			if col.IsComputed() {
+				def.Computed.Computed = true
+				def.Computed.Virtual = col.IsVirtual()
+				if def.Computed.Expr, err = parser.ParseExpr(col.GetComputeExpr()); err != nil {
+					return nil, nil, err
+				}
+			}
+			inherited = append(inherited, def)
+			inheritedByName[name] = def
+			inheritedTypes[name] = col.GetType()
+		}
+	}
+
+	// Merge the columns defined by the new table into the inherited columns.
+	defs = make(tree.TableDefs, 0, len(inherited)+len(n.Defs))
+	for _, def := range inherited {
+		defs = append(defs, def)
+	}
+	for _, def := range n.Defs {
+		d, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			defs = append(defs, def)
+			continue
+		}
+		inheritedDef, ok := inheritedByName[d.Name]
+		if !ok {
+			defs = append(defs, def)
+			continue
+		}
+		typ, err := tree.ResolveType(ctx, d.Type, p.semaCtx.GetTypeResolver())
+		if err != nil {
+			return nil, nil, err
+		}
+		if !typ.Identical(inheritedTypes[d.Name]) {
+			return nil, nil, inheritedTypeConflictError(d.Name, inheritedTypes[d.Name], typ)
+		}
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"merging column %q with inherited definition", d.Name))
+		// The definition of the new table takes the position of the inherited
+		// column. Copy it so that the statement is not modified.
+		merged := *d
+		if inheritedDef.Nullable.Nullability == tree.NotNull {
+			merged.Nullable.Nullability = tree.NotNull
+		}
+		if merged.DefaultExpr.Expr == nil {
+			merged.DefaultExpr = inheritedDef.DefaultExpr
+		}
+		for i := range defs {
+			if defs[i] == inheritedDef {
+				defs[i] = &merged
+				break
+			}
+		}
+	}
+	return parents, defs, nil
+}
+
+func inheritedTypeConflictError(name tree.Name, a, b *types.T) error {
+	return errors.WithDetailf(
+		pgerror.Newf(pgcode.DatatypeMismatch, "column %q has a type conflict", name),
+		"%s versus %s", a.SQLString(), b.SQLString(),
+	)
+}
+
+// checkNoInheritingTables returns an error if other tables inherit from the
+// given table. It guards the schema changes that would have to be propagated
+// to the inheriting tables, which is not supported: queries on the table would
+// otherwise read NULL for the columns of the inheriting tables that no longer
+// match.
+func checkNoInheritingTables(desc catalog.TableDescriptor, op string) error {
+	if len(desc.GetInheritedBy()) == 0 {
+		return nil
+	}
+	return errors.WithHint(
+		pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot %s table %q because other tables inherit from it", op, desc.GetName()),
+		"Changes to the columns of a table are not propagated to the tables that inherit from it.",
+	)
+}
+
+// addInheritance makes child inherit from parent. The child must already
+// have all the visible columns of the parent, with the same types. The
+// parent's descriptor is written, while the caller is responsible for
+// writing the child's descriptor.
+func (p *planner) addInheritance(
+	ctx context.Context, child, parent *tabledesc.Mutable, checkColumns bool,
+) error {
+	if child.GetID() == parent.GetID() {
+		return pgerror.New(pgcode.DuplicateRelation, "circular inheritance not allowed")
+	}
+	if parent.IsTemporary() && !child.IsTemporary() {
+		return pgerror.Newf(pgcode.WrongObjectType,
+			"cannot inherit from temporary relation %q", parent.GetName())
+	}
+	for _, id := range child.Inherits {
+		if id == parent.GetID() {
+			return pgerror.Newf(pgcode.DuplicateRelation,
+				"relation %q would be inherited from more than once", parent.GetName())
+		}
+	}
+	// The parent must not be a descendant of the child.
+	isDescendant, err := p.inheritsFrom(ctx, parent, child.GetID())
+	if err != nil {
+		return err
+	}
+	if isDescendant {
+		return errors.WithDetailf(
+			pgerror.New(pgcode.DuplicateRelation, "circular inheritance not allowed"),
+			"%q is already a child of %q.", parent.GetName(), child.GetName(),
+		)
+	}
+	if checkColumns {
+		for _, parentCol := range parent.VisibleColumns() {
+			col := catalog.FindColumnByName(child, parentCol.GetName())
+			if col == nil || !col.Public() || col.IsHidden() {
+				return pgerror.Newf(pgcode.DatatypeMismatch,
+					"child table is missing column %q", parentCol.GetName())
+			}
+			if !col.GetType().Identical(parentCol.GetType()) {
+				return pgerror.Newf(pgcode.DatatypeMismatch,
+					"child table %q has different type for column %q", child.GetName(), col.GetName())
+			}
+			if !parentCol.IsNullable() && col.IsNullable() {
+				return pgerror.Newf(pgcode.DatatypeMismatch,
+					"column %q in child table must be marked NOT NULL", col.GetName())
+			}
+		}
+	}
+	child.Inherits = append(child.Inherits, parent.GetID())
+	parent.InheritedBy = append(parent.InheritedBy, child.GetID())
+	return p.writeSchemaChange(ctx, parent, descpb.InvalidMutationID,
+		fmt.Sprintf("updating table %q after adding inheriting table %q", parent.GetName(), child.GetName()),
+	)
+}
+
+// removeInheritance removes parent from the parents of child. The parent's
+// descriptor is written, while the caller is responsible for writing the
+// child's descriptor.
+func (p *planner) removeInheritance(ctx context.Context, child, parent *tabledesc.Mutable) error {
+	found := false
+	for i, id := range child.Inherits {
+		if id == parent.GetID() {
+			child.Inherits = append(child.Inherits[:i:i], child.Inherits[i+1:]...)
+			found = true
+			break
+		}
+	}
+	if !found {
+		return pgerror.Newf(pgcode.UndefinedTable,
+			"relation %q is not a parent of relation %q", parent.GetName(), child.GetName())
+	}
+	removeInheritedBy(parent, child.GetID())
+	return p.writeSchemaChange(ctx, parent, descpb.InvalidMutationID,
+		fmt.Sprintf("updating table %q after removing inheriting table %q", parent.GetName(), child.GetName()),
+	)
+}
+
+func removeInheritedBy(parent *tabledesc.Mutable, childID descpb.ID) {
+	for i, id := range parent.InheritedBy {
+		if id == childID {
+			parent.InheritedBy = append(parent.InheritedBy[:i:i], parent.InheritedBy[i+1:]...)
+			return
+		}
+	}
+}
+
+// inheritsFrom returns true if the given table inherits, directly or
+// indirectly, from the table with the given ID.
+func (p *planner) inheritsFrom(
+	ctx context.Context, table catalog.TableDescriptor, ancestorID descpb.ID,
+) (bool, error) {
+	for _, id := range table.GetInherits() {
+		if id == ancestorID {
+			return true, nil
+		}
+		parent, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Table(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		if ok, err := p.inheritsFrom(ctx, parent, ancestorID); ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// addInheritingTablesToDrop adds the tables that inherit, directly or
+// indirectly, from the tables in td to td. It returns an error if there are
+// such tables and the drop behavior is not CASCADE.
+func (p *planner) addInheritingTablesToDrop(
+	ctx context.Context, td map[descpb.ID]toDelete, behavior tree.DropBehavior,
+) error {
+	queue := make([]*tabledesc.Mutable, 0, len(td))
+	for _, toDel := range td {
+		queue = append(queue, toDel.desc)
+	}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, childID := range parent.InheritedBy {
+			if _, ok := td[childID]; ok {
+				continue
+			}
+			child, err := p.Descriptors().MutableByID(p.txn).Table(ctx, childID)
+			if err != nil {
+				return err
+			}
+			if behavior != tree.DropCascade {
+				return errors.WithHint(
+					sqlerrors.NewDependentObjectErrorf(
+						"cannot drop table %q because table %q inherits from it",
+						parent.GetName(), child.GetName(),
+					),
+					"use DROP ... CASCADE to drop the inheriting tables too.",
+				)
+			}
+			if err := p.canDropTable(ctx, child, true /* checkOwnership */); err != nil {
+				return err
+			}
+			tn, err := p.getQualifiedTableName(ctx, child)
+			if err != nil {
+				return err
+			}
+			td[childID] = toDelete{tn, child}
+			queue = append(queue, child)
+		}
+	}
+	return nil
+}
+
+// removeInheritanceReferences removes the references to the table from its
+// parents and children, which is about to be dropped.
+func (p *planner) removeInheritanceReferences(ctx context.Context, tableDesc *tabledesc.Mutable) error {
+	for _, id := range tableDesc.Inherits {
+		parent, err := p.Descriptors().MutableByID(p.txn).Table(ctx, id)
+		if err != nil {
+			return err
+		}
+		if parent.Dropped() {
+			continue
+		}
+		removeInheritedBy(parent, tableDesc.GetID())
+		if err := p.writeSchemaChange(ctx, parent, descpb.InvalidMutationID,
+			fmt.Sprintf("updating table %q after dropping inheriting table %q", parent.GetName(), tableDesc.GetName()),
+		); err != nil {
+			return err
+		}
+	}
+	tableDesc.Inherits = nil
+	for _, id := range tableDesc.InheritedBy {
+		child, err := p.Descriptors().MutableByID(p.txn).Table(ctx, id)
+		if err != nil {
+			return err
+		}
+		if child.Dropped() {
+			continue
+		}
+		for i, parentID := range child.Inherits {
+			if parentID == tableDesc.GetID() {
+				child.Inherits = append(child.Inherits[:i:i], child.Inherits[i+1:]...)
+				break
+			}
+		}
+		if err := p.writeSchemaChange(ctx, child, descpb.InvalidMutationID,
+			fmt.Sprintf("updating table %q after dropping parent table %q", child.GetName(), tableDesc.GetName()),
+		); err != nil {
+			return err
+		}
+	}
+	tableDesc.InheritedBy = nil
+	return nil
+}
@@ -353,6 +353,7 @@ func (ie *InternalExecutor) newConnExecutorWithTxn(
 		txn.ReadTimestamp().GoTime(),
 		nil, /* historicalTimestamp */
 		roachpb.UnspecifiedUserPriority,
+		txn.IsoLevel(),
 		tree.ReadWrite,
 		txn,
 		ex.transitionCtx,
@@ -829,13 +830,13 @@ func (ie *InternalExecutor) execInternal(
 	defer func() {
 		// We wrap errors with the opName, but not if they're retriable - in that
 		// case we need to leave the error intact so that it can be retried at a
-		// higher level.
+		// higher level. Errors are also left intact if there is no opName.
 		//
 		// TODO(knz): track the callers and check whether opName could be turned
 		// into a type safe for reporting.
 		if retErr != nil || r == nil {
 			// Both retErr and r can be nil in case of panic.
-			if retErr != nil && !errIsRetriable(retErr) {
+			if retErr != nil && !errIsRetriable(retErr) && opName != "" {
 				retErr = errors.Wrapf(retErr, "%s", opName)
 			}
 			stmtBuf.Close()
@@ -843,7 +844,7 @@ func (ie *InternalExecutor) execInternal(
 			sp.Finish()
 		} else {
 			r.errCallback = func(err error) error {
-				if err != nil && !errIsRetriable(err) {
+				if err != nil && !errIsRetriable(err) && opName != "" {
 					err = errors.Wrapf(err, "%s", opName)
 				}
 				return err
@@ -86,6 +86,13 @@ func TestLogic_create_index(
 	runLogicTest(t, "create_index")
 }
 
+func TestLogic_deferrable_unique_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "deferrable_unique_mixed")
+}
+
 func TestLogic_drop_database(
 	t *testing.T,
 ) {
@@ -135,9 +142,44 @@ func TestLogic_drop_view(
 	runLogicTest(t, "drop_view")
 }
 
+func TestLogic_exclusion_constraints_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "exclusion_constraints_mixed")
+}
+
 func TestLogic_gc_job_mixed(
 	t *testing.T,
 ) {
 	defer leaktest.AfterTest(t)()
 	runLogicTest(t, "gc_job_mixed")
 }
+
+func TestLogic_read_committed_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "read_committed_mixed")
+}
+
+func TestLogic_trigger_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "trigger_mixed")
+}
+
+func TestLogic_udf_aggregate_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_aggregate_mixed")
+}
+
+func TestLogic_udf_variadic_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_variadic_mixed")
+}
@@ -0,0 +1,991 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/transform"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// incrementalRefreshInterval is the interval at which the changes made to the
+// tables that an incrementally refreshed materialized view reads from are
+// applied to the view.
+var incrementalRefreshInterval = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"sql.materialized_views.incremental_refresh_interval",
+	"the interval at which changes to the tables read by incrementally "+
+		"refreshed materialized views are applied to the views",
+	5*time.Second,
+	settings.PositiveDuration,
+)
+
+// maxIncrementalRefreshKeys is the maximum number of changed rows that are
+// applied to an incrementally refreshed materialized view one by one. If more
+// rows have changed since the last refresh, the view is recomputed in full.
+const maxIncrementalRefreshKeys = 1000
+
+// incrementalRefreshBatchSize is the maximum number of view rows that are
+// looked up, deleted or inserted by a single statement.
+const incrementalRefreshBatchSize = 100
+
+// recomputeDeleteBatchSize is the maximum number of view rows that are deleted
+// by a single statement when the view is recomputed in full.
+const recomputeDeleteBatchSize = 10000
+
+// batchEnd returns the end of the batch of at most incrementalRefreshBatchSize
+// elements which starts at the given index of a slice of length n.
+func batchEnd(start, n int) int {
+	if end := start + incrementalRefreshBatchSize; end < n {
+		return end
+	}
+	return n
+}
+
+// incrementalViewQuery is the analyzed query of an incrementally refreshed
+// materialized view. Only select-project-join queries, optionally with
+// aggregation, are supported.
+type incrementalViewQuery struct {
+	sel *tree.SelectClause
+	// sources are the tables in the FROM clause, in the order in which they
+	// appear.
+	sources []incrementalViewSource
+	// groupCols contains, for each GROUP BY expression, the ordinal of the
+	// select list item that it matches.
+	groupCols []int
+	// scalarAgg is set if the query computes aggregates without a GROUP BY. The
+	// single row of such a view is recomputed whenever any source changes.
+	scalarAgg bool
+}
+
+// incrementalViewSource is a table in the FROM clause of the query of an
+// incrementally refreshed materialized view.
+type incrementalViewSource struct {
+	name  tree.TableName
+	alias tree.Name
+	only  bool
+}
+
+// columnRef returns a reference to the given column of the source that is
+// unambiguous within the view query.
+func (s *incrementalViewSource) columnRef(col string) *tree.UnresolvedName {
+	if s.alias != "" {
+		return tree.NewUnresolvedName(string(s.alias), col)
+	}
+	var parts []string
+	if s.name.ExplicitCatalog {
+		parts = append(parts, s.name.Catalog())
+	}
+	if s.name.ExplicitSchema {
+		parts = append(parts, s.name.Schema())
+	}
+	parts = append(parts, s.name.Table(), col)
+	return tree.NewUnresolvedName(parts...)
+}
+
+func unsupportedIncrementalViewError(what string) error {
+	return pgerror.Newf(pgcode.FeatureNotSupported,
+		"%s are not supported in incrementally refreshed materialized views", what)
+}
+
+// analyzeIncrementalViewQuery parses the given view query and checks that it
+// can be maintained incrementally.
+func analyzeIncrementalViewQuery(
+	ctx context.Context, viewQuery string, searchPath sessiondata.SearchPath,
+) (*incrementalViewQuery, error) {
+	stmt, err := parser.ParseOne(viewQuery)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		return nil, errors.AssertionFailedf("unexpected view query statement %T", stmt.AST)
+	}
+	switch {
+	case sel.With != nil:
+		return nil, unsupportedIncrementalViewError("WITH clauses")
+	case len(sel.OrderBy) > 0:
+		return nil, unsupportedIncrementalViewError("ORDER BY clauses")
+	case sel.Limit != nil:
+		return nil, unsupportedIncrementalViewError("LIMIT clauses")
+	case len(sel.Locking) > 0:
+		return nil, unsupportedIncrementalViewError("locking clauses")
+	}
+	sc, ok := sel.Select.(*tree.SelectClause)
+	if !ok || sc.TableSelect {
+		return nil, unsupportedIncrementalViewError("set operations and VALUES clauses")
+	}
+	switch {
+	case sc.Distinct || len(sc.DistinctOn) > 0:
+		return nil, unsupportedIncrementalViewError("DISTINCT clauses")
+	case len(sc.Window) > 0:
+		return nil, unsupportedIncrementalViewError("window functions")
+	case len(sc.From.Tables) == 0:
+		return nil, unsupportedIncrementalViewError("queries without a FROM clause")
+	}
+
+	q := &incrementalViewQuery{sel: sc}
+	var v incrementalViewExprChecker
+	for _, t := range sc.From.Tables {
+		if err := q.addSources(t, &v); err != nil {
+			return nil, err
+		}
+	}
+	for i := range sc.Exprs {
+		v.check(sc.Exprs[i].Expr)
+	}
+	if sc.Where != nil {
+		v.check(sc.Where.Expr)
+	}
+	if sc.Having != nil {
+		v.check(sc.Having.Expr)
+	}
+	for _, g := range sc.GroupBy {
+		v.check(g)
+	}
+	if v.err != nil {
+		return nil, v.err
+	}
+
+	var txCtx transform.ExprTransformContext
+	aggregate := sc.Having != nil
+	for i := range sc.Exprs {
+		aggregate = aggregate || txCtx.AggregateInExpr(ctx, sc.Exprs[i].Expr, searchPath)
+	}
+	if len(sc.GroupBy) == 0 {
+		q.scalarAgg = aggregate
+		return q, nil
+	}
+	q.groupCols = make([]int, len(sc.GroupBy))
+	for i, g := range sc.GroupBy {
+		q.groupCols[i] = q.findGroupCol(g)
+		if q.groupCols[i] < 0 {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"GROUP BY expression %s must appear in the select list of an "+
+					"incrementally refreshed materialized view", tree.AsString(g))
+		}
+	}
+	return q, nil
+}
+
+// addSources adds the tables in the given FROM clause item to the sources of
+// the query.
+func (q *incrementalViewQuery) addSources(
+	expr tree.TableExpr, v *incrementalViewExprChecker,
+) error {
+	switch t := expr.(type) {
+	case *tree.AliasedTableExpr:
+		tn, ok := t.Expr.(*tree.TableName)
+		if !ok {
+			return unsupportedIncrementalViewError("data sources other than tables")
+		}
+		if t.Ordinality {
+			return unsupportedIncrementalViewError("WITH ORDINALITY clauses")
+		}
+		if len(t.As.Cols) > 0 {
+			return unsupportedIncrementalViewError("column aliases")
+		}
+		q.sources = append(q.sources, incrementalViewSource{name: *tn, alias: t.As.Alias, only: t.Only})
+		return nil
+
+	case *tree.ParenTableExpr:
+		return q.addSources(t.Expr, v)
+
+	case *tree.JoinTableExpr:
+		if t.JoinType != "" && t.JoinType != tree.AstInner && t.JoinType != tree.AstCross {
+			return unsupportedIncrementalViewError("outer joins")
+		}
+		if on, ok := t.Cond.(*tree.OnJoinCond); ok {
+			v.check(on.Expr)
+		}
+		if err := q.addSources(t.Left, v); err != nil {
+			return err
+		}
+		return q.addSources(t.Right, v)
+
+	default:
+		return unsupportedIncrementalViewError("data sources other than tables")
+	}
+}
+
+// findGroupCol returns the ordinal of the select list item that the given
+// GROUP BY expression refers to, or -1 if there is none.
+func (q *incrementalViewQuery) findGroupCol(g tree.Expr) int {
+	str := tree.AsString(g)
+	for i := range q.sel.Exprs {
+		if tree.AsString(q.sel.Exprs[i].Expr) == str {
+			return i
+		}
+	}
+	switch t := g.(type) {
+	case *tree.UnresolvedName:
+		if t.NumParts == 1 {
+			for i := range q.sel.Exprs {
+				if string(q.sel.Exprs[i].As) == t.Parts[0] {
+					return i
+				}
+			}
+		}
+	case *tree.NumVal:
+		if ord, err := t.AsInt64(); err == nil && ord >= 1 && ord <= int64(len(q.sel.Exprs)) {
+			return int(ord - 1)
+		}
+	}
+	return -1
+}
+
+// withFilter returns the query with the given filter added to its WHERE
+// clause.
+func (q *incrementalViewQuery) withFilter(filter tree.Expr) *tree.SelectClause {
+	sc := *q.sel
+	if sc.Where == nil {
+		sc.Where = tree.NewWhere(tree.AstWhere, filter)
+	} else {
+		sc.Where = tree.NewWhere(tree.AstWhere, &tree.AndExpr{
+			Left:  &tree.ParenExpr{Expr: sc.Where.Expr},
+			Right: &tree.ParenExpr{Expr: filter},
+		})
+	}
+	return &sc
+}
+
+// incrementalViewExprChecker looks for expressions that prevent a view from
+// being maintained incrementally.
+type incrementalViewExprChecker struct {
+	err error
+}
+
+var _ tree.Visitor = &incrementalViewExprChecker{}
+
+func (v *incrementalViewExprChecker) check(expr tree.Expr) {
+	if v.err == nil {
+		tree.WalkExprConst(v, expr)
+	}
+}
+
+// VisitPre is part of the tree.Visitor interface.
+func (v *incrementalViewExprChecker) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	switch t := expr.(type) {
+	case *tree.Subquery:
+		v.err = unsupportedIncrementalViewError("subqueries")
+	case *tree.FuncExpr:
+		if t.IsWindowFunctionApplication() {
+			v.err = unsupportedIncrementalViewError("window functions")
+		}
+	}
+	return v.err == nil, expr
+}
+
+// VisitPost is part of the tree.Visitor interface.
+func (*incrementalViewExprChecker) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// resolveIncrementalViewSources checks that the given query of a materialized
+// view can be maintained incrementally, and returns the IDs of the tables in
+// its FROM clause.
+func (p *planner) resolveIncrementalViewSources(
+	ctx context.Context, viewQuery string,
+) ([]descpb.ID, error) {
+	q, err := analyzeIncrementalViewQuery(ctx, viewQuery, p.SessionData().SearchPath)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]descpb.ID, len(q.sources))
+	for i := range q.sources {
+		tn := q.sources[i].name
+		_, desc, err := resolver.ResolveExistingTableObject(ctx, p, &tn, tree.ObjectLookupFlags{
+			Required:             true,
+			DesiredObjectKind:    tree.TableObject,
+			DesiredTableDescKind: tree.ResolveRequireTableDesc,
+		})
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case desc.IsVirtualTable():
+			return nil, unsupportedIncrementalViewError("virtual tables")
+		case desc.IsForeignTable():
+			return nil, unsupportedIncrementalViewError("foreign tables")
+		case len(desc.GetInheritedBy()) > 0 && !q.sources[i].only:
+			return nil, errors.WithHint(
+				unsupportedIncrementalViewError("tables with inheriting tables"),
+				"Use ONLY to read only the rows of the parent table.",
+			)
+		}
+		ids[i] = desc.GetID()
+	}
+	return ids, nil
+}
+
+// addIncrementalViewIndex adds a secondary index to the descriptor of a new
+// incrementally refreshed materialized view, so that the view rows which are
+// replaced when changes are applied can be found without scanning the view.
+// The index is on the GROUP BY columns of views with aggregation, and on all
+// the columns of other views. Columns that cannot be indexed are left out.
+func (p *planner) addIncrementalViewIndex(
+	ctx context.Context, desc *tabledesc.Mutable, viewQuery string,
+) error {
+	q, err := analyzeIncrementalViewQuery(ctx, viewQuery, p.SessionData().SearchPath)
+	if err != nil {
+		return err
+	}
+	if q.scalarAgg {
+		// The view has a single row.
+		return nil
+	}
+	ords := q.groupCols
+	if ords == nil {
+		ords = make([]int, len(desc.Columns))
+		for i := range ords {
+			ords[i] = i
+		}
+	}
+	var idx descpb.IndexDescriptor
+	for _, ord := range ords {
+		col := &desc.Columns[ord]
+		if !colinfo.ColumnTypeIsIndexable(col.Type) {
+			continue
+		}
+		idx.KeyColumnNames = append(idx.KeyColumnNames, col.Name)
+		idx.KeyColumnDirections = append(idx.KeyColumnDirections, catenumpb.IndexColumn_ASC)
+	}
+	if len(idx.KeyColumnNames) == 0 {
+		return nil
+	}
+	if idx.Name, err = tabledesc.BuildIndexName(desc, &idx); err != nil {
+		return err
+	}
+	return desc.AddSecondaryIndex(idx)
+}
+
+// viewSourceChange is a change to one of the tables that an incrementally
+// refreshed materialized view reads from.
+type viewSourceChange struct {
+	ts  hlc.Timestamp
+	key roachpb.Key
+	// span is set instead of key for changes which affect a range of keys, such
+	// as ingested SSTables and range deletions.
+	span roachpb.Span
+}
+
+// incrementalViewMaintainer applies the changes made to the tables that an
+// incrementally refreshed materialized view reads from to the view.
+//
+// The contents of the view always correspond to the results of its query as
+// of the high-water of the maintenance job. The changes in a window of time
+// (prev, cur] are applied by looking up the primary keys of the changed rows,
+// and running the view query at prev and at cur restricted to the rows
+// derived from them:
+//
+//   - For views without aggregation, each view row is derived from exactly one
+//     combination of source rows. The rows derived from a changed source row
+//     at prev are deleted from the view, and the rows derived from it at cur
+//     are inserted.
+//   - For views with a GROUP BY, the groups which contain a changed source row
+//     at prev or at cur are recomputed.
+//
+// If the window contains too many changes, schema changes to the sources, or
+// changes which cannot be attributed to individual rows, the view is
+// recomputed in full at cur instead. The view rows and the job high-water are
+// updated in a single transaction.
+type incrementalViewMaintainer struct {
+	execCfg *ExecutorConfig
+	job     *jobs.Job
+	viewID  descpb.ID
+}
+
+// sessionData returns the session data of the internal executor used to read
+// the sources and write the view.
+func (m *incrementalViewMaintainer) sessionData() *sessiondata.SessionData {
+	sd := NewFakeSessionData(&m.execCfg.Settings.SV)
+	sd.AllowMaterializedViewMutations = true
+	return sd
+}
+
+// getView returns the descriptor of the view, or nil if it does not exist.
+func (m *incrementalViewMaintainer) getView(ctx context.Context) (view catalog.TableDescriptor, _ error) {
+	err := m.execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) (err error) {
+		view, err = txn.Descriptors().ByID(txn.KV()).Get().Table(ctx, m.viewID)
+		return err
+	})
+	if errors.Is(err, catalog.ErrDescriptorNotFound) {
+		return nil, nil
+	}
+	return view, err
+}
+
+// readAt runs f in a transaction which reads at the given timestamp.
+func (m *incrementalViewMaintainer) readAt(
+	ctx context.Context, ts hlc.Timestamp, f func(context.Context, descs.Txn) error,
+) error {
+	return m.execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		if err := txn.KV().SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		return f(ctx, txn)
+	}, isql.WithSessionData(m.sessionData()))
+}
+
+// queryAt runs the given query as of the given timestamp.
+func (m *incrementalViewMaintainer) queryAt(
+	ctx context.Context, ts hlc.Timestamp, sc *tree.SelectClause, args []interface{},
+) (rows []tree.Datums, _ error) {
+	stmt := tree.AsStringWithFlags(&tree.Select{Select: sc}, tree.FmtParsable)
+	err := m.readAt(ctx, ts, func(ctx context.Context, txn descs.Txn) (err error) {
+		rows, err = txn.QueryBufferedEx(
+			ctx, "materialized-view-maintenance-read", txn.KV(),
+			sessiondata.NoSessionDataOverride, stmt, args...,
+		)
+		return err
+	})
+	return rows, err
+}
+
+// readSources returns the view descriptor and the descriptors of its sources
+// as of the given timestamp.
+func (m *incrementalViewMaintainer) readSources(
+	ctx context.Context, ts hlc.Timestamp,
+) (view catalog.TableDescriptor, sources map[descpb.ID]catalog.TableDescriptor, _ error) {
+	err := m.readAt(ctx, ts, func(ctx context.Context, txn descs.Txn) (err error) {
+		view, err = txn.Descriptors().ByID(txn.KV()).Get().Table(ctx, m.viewID)
+		if err != nil {
+			return err
+		}
+		if !view.IsIncrementallyRefreshed() {
+			return errors.AssertionFailedf("view %d is not incrementally refreshed", m.viewID)
+		}
+		sources = make(map[descpb.ID]catalog.TableDescriptor)
+		for _, id := range view.GetIncrementalRefresh().TableIDs {
+			if sources[id], err = txn.Descriptors().ByID(txn.KV()).Get().Table(ctx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return view, sources, err
+}
+
+// apply applies the given changes, which happened in the window (prev, cur],
+// to the view, and advances the job high-water to cur.
+func (m *incrementalViewMaintainer) apply(
+	ctx context.Context, prev, cur hlc.Timestamp, changes []viewSourceChange,
+) error {
+	view, sources, err := m.readSources(ctx, cur)
+	if err != nil {
+		return err
+	}
+	q, err := analyzeIncrementalViewQuery(ctx, view.GetViewQuery(), m.sessionData().SearchPath)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return m.write(ctx, prev, cur, nil /* f */)
+	}
+	full := q.scalarAgg
+	if !full {
+		_, prevSources, err := m.readSources(ctx, prev)
+		if err != nil {
+			return err
+		}
+		for id, desc := range sources {
+			if prevSources[id].GetVersion() != desc.GetVersion() {
+				full = true
+			}
+		}
+	}
+	var keys map[descpb.ID][]tree.Datums
+	if !full {
+		keys, full = m.decodeChangedKeys(sources, changes)
+	}
+	if full {
+		return m.recompute(ctx, prev, cur, view, q)
+	}
+	if err := m.applyChangedKeys(ctx, prev, cur, view, sources, q, keys); err != nil {
+		log.Warningf(ctx, "incremental refresh of materialized view %d failed, "+
+			"recomputing it in full: %v", m.viewID, err)
+		return m.recompute(ctx, prev, cur, view, q)
+	}
+	return nil
+}
+
+// applyChangedKeys updates the view rows derived from the source rows with the
+// given primary keys.
+func (m *incrementalViewMaintainer) applyChangedKeys(
+	ctx context.Context,
+	prev, cur hlc.Timestamp,
+	view catalog.TableDescriptor,
+	sources map[descpb.ID]catalog.TableDescriptor,
+	q *incrementalViewQuery,
+	keys map[descpb.ID][]tree.Datums,
+) error {
+	// Build a filter which restricts the view query to the rows derived from
+	// the changed source rows.
+	var args []interface{}
+	var filter tree.Expr
+	for i := range q.sources {
+		src := &q.sources[i]
+		id := view.GetIncrementalRefresh().TableIDs[i]
+		if len(keys[id]) == 0 {
+			continue
+		}
+		pred := changedKeysPredicate(src, sources[id].GetPrimaryIndex(), keys[id], &args)
+		if filter == nil {
+			filter = pred
+		} else {
+			filter = &tree.OrExpr{Left: filter, Right: pred}
+		}
+	}
+	if filter == nil {
+		// Only secondary indexes or tables that are not read by the view changed.
+		return m.write(ctx, prev, cur, nil /* f */)
+	}
+
+	cols := viewColumnNames(view)
+	if q.groupCols == nil {
+		sc := q.withFilter(filter)
+		oldRows, err := m.queryAt(ctx, prev, sc, args)
+		if err != nil {
+			return err
+		}
+		newRows, err := m.queryAt(ctx, cur, sc, args)
+		if err != nil {
+			return err
+		}
+		return m.write(ctx, prev, cur, func(ctx context.Context, txn isql.Txn) error {
+			if err := m.deleteRows(ctx, txn, view, cols, oldRows); err != nil {
+				return err
+			}
+			return m.insertRows(ctx, txn, cols, newRows)
+		})
+	}
+
+	// Find the groups which contain a changed source row before or after the
+	// changes, and recompute them.
+	groupsSel := *q.withFilter(filter)
+	groupsSel.Distinct = true
+	groupsSel.GroupBy = nil
+	groupsSel.Having = nil
+	groupsSel.Exprs = make(tree.SelectExprs, len(q.groupCols))
+	for i, ord := range q.groupCols {
+		groupsSel.Exprs[i] = tree.SelectExpr{Expr: q.sel.Exprs[ord].Expr}
+	}
+	var groups []tree.Datums
+	seen := make(map[string]struct{})
+	for _, ts := range []hlc.Timestamp{prev, cur} {
+		rows, err := m.queryAt(ctx, ts, &groupsSel, args)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if k := viewTupleKey(row); !isSeen(seen, k) {
+				groups = append(groups, row)
+			}
+		}
+	}
+	groupExprs := make([]tree.Expr, len(q.groupCols))
+	groupColNames := make([]string, len(q.groupCols))
+	for i, ord := range q.groupCols {
+		groupExprs[i] = q.sel.Exprs[ord].Expr
+		groupColNames[i] = cols[ord]
+	}
+	var newRows []tree.Datums
+	for i := 0; i < len(groups); i += incrementalRefreshBatchSize {
+		batch := groups[i:batchEnd(i, len(groups))]
+		var groupArgs []interface{}
+		sc := q.withFilter(tuplesMatchPredicate(groupExprs, batch, &groupArgs))
+		rows, err := m.queryAt(ctx, cur, sc, groupArgs)
+		if err != nil {
+			return err
+		}
+		newRows = append(newRows, rows...)
+	}
+	names := make([]tree.Expr, len(groupColNames))
+	for i, c := range groupColNames {
+		names[i] = tree.NewUnresolvedName(c)
+	}
+	return m.write(ctx, prev, cur, func(ctx context.Context, txn isql.Txn) error {
+		for i := 0; i < len(groups); i += incrementalRefreshBatchSize {
+			batch := groups[i:batchEnd(i, len(groups))]
+			var delArgs []interface{}
+			pred := tuplesMatchPredicate(names, batch, &delArgs)
+			if _, err := txn.ExecEx(
+				ctx, "materialized-view-maintenance-delete", txn.KV(), sessiondata.NoSessionDataOverride,
+				fmt.Sprintf("DELETE FROM [%d AS v] WHERE %s", m.viewID, tree.AsStringWithFlags(pred, tree.FmtParsable)),
+				delArgs...,
+			); err != nil {
+				return err
+			}
+		}
+		return m.insertRows(ctx, txn, cols, newRows)
+	})
+}
+
+// decodeChangedKeys decodes the primary keys of the changed source rows. It
+// returns true if the view needs to be recomputed in full instead.
+func (m *incrementalViewMaintainer) decodeChangedKeys(
+	sources map[descpb.ID]catalog.TableDescriptor, changes []viewSourceChange,
+) (keys map[descpb.ID][]tree.Datums, full bool) {
+	codec := m.execCfg.Codec
+	keys = make(map[descpb.ID][]tree.Datums)
+	seen := make(map[string]struct{})
+	var alloc tree.DatumAlloc
+	for _, c := range changes {
+		if c.key == nil {
+			return nil, true
+		}
+		_, tableID, err := codec.DecodeTablePrefix(c.key)
+		if err != nil {
+			return nil, true
+		}
+		desc, ok := sources[descpb.ID(tableID)]
+		if !ok {
+			continue
+		}
+		indexID, rest, err := rowenc.DecodeIndexKeyPrefix(codec, desc.GetID(), c.key)
+		if err != nil {
+			return nil, true
+		}
+		idx := desc.GetPrimaryIndex()
+		if indexID != idx.GetID() {
+			continue
+		}
+		colTypes := make([]*types.T, idx.NumKeyColumns())
+		dirs := make([]catenumpb.IndexColumn_Direction, idx.NumKeyColumns())
+		for i := range colTypes {
+			col := catalog.FindColumnByID(desc, idx.GetKeyColumnID(i))
+			if col == nil {
+				return nil, true
+			}
+			colTypes[i] = col.GetType()
+			dirs[i] = idx.GetKeyColumnDirection(i)
+		}
+		vals := make([]rowenc.EncDatum, len(colTypes))
+		remaining, _, err := rowenc.DecodeKeyVals(colTypes, vals, dirs, rest)
+		if err != nil {
+			return nil, true
+		}
+		if isSeen(seen, string(c.key[:len(c.key)-len(remaining)])) {
+			continue
+		}
+		row := make(tree.Datums, len(vals))
+		for i := range vals {
+			if err := vals[i].EnsureDecoded(colTypes[i], &alloc); err != nil {
+				return nil, true
+			}
+			row[i] = vals[i].Datum
+		}
+		keys[desc.GetID()] = append(keys[desc.GetID()], row)
+		if len(seen) > maxIncrementalRefreshKeys {
+			return nil, true
+		}
+	}
+	return keys, false
+}
+
+// recompute replaces the contents of the view with the results of its query
+// as of cur. The view rows are deleted in batches, and the results of the
+// query are streamed into the view in batches, so that neither is buffered in
+// memory.
+func (m *incrementalViewMaintainer) recompute(
+	ctx context.Context, prev, cur hlc.Timestamp, view catalog.TableDescriptor, q *incrementalViewQuery,
+) error {
+	cols := viewColumnNames(view)
+	stmt := tree.AsStringWithFlags(&tree.Select{Select: q.sel}, tree.FmtParsable)
+	return m.write(ctx, prev, cur, func(ctx context.Context, txn isql.Txn) error {
+		for {
+			n, err := txn.ExecEx(
+				ctx, "materialized-view-maintenance-delete", txn.KV(), sessiondata.NoSessionDataOverride,
+				fmt.Sprintf("DELETE FROM [%d AS v] LIMIT %d", m.viewID, recomputeDeleteBatchSize),
+			)
+			if err != nil {
+				return err
+			}
+			if n < recomputeDeleteBatchSize {
+				break
+			}
+		}
+		return m.readAt(ctx, cur, func(ctx context.Context, readTxn descs.Txn) (err error) {
+			it, err := readTxn.QueryIteratorEx(
+				ctx, "materialized-view-maintenance-read", readTxn.KV(),
+				sessiondata.NoSessionDataOverride, stmt,
+			)
+			if err != nil {
+				return err
+			}
+			defer func() { err = errors.CombineErrors(err, it.Close()) }()
+			batch := make([]tree.Datums, 0, incrementalRefreshBatchSize)
+			var ok bool
+			for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+				if batch = append(batch, it.Cur()); len(batch) == incrementalRefreshBatchSize {
+					if err := m.insertRows(ctx, txn, cols, batch); err != nil {
+						return err
+					}
+					batch = batch[:0]
+				}
+			}
+			if err != nil {
+				return err
+			}
+			return m.insertRows(ctx, txn, cols, batch)
+		})
+	})
+}
+
+// write runs f, which writes to the view, and advances the job high-water from
+// prev to cur in the same transaction.
+func (m *incrementalViewMaintainer) write(
+	ctx context.Context, prev, cur hlc.Timestamp, f func(context.Context, isql.Txn) error,
+) error {
+	return m.execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		if f != nil {
+			if err := f(ctx, txn); err != nil {
+				return err
+			}
+		}
+		return m.job.WithTxn(txn).Update(ctx, func(
+			txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+		) error {
+			if hw := md.Progress.GetHighWater(); hw != nil && !hw.IsEmpty() && !hw.Equal(prev) {
+				return errors.Errorf(
+					"high-water of materialized view maintenance job %d concurrently moved from %s to %s",
+					m.job.ID(), prev, hw,
+				)
+			}
+			return jobs.UpdateHighwaterProgressed(cur, md, ju)
+		})
+	}, isql.WithSessionData(m.sessionData()))
+}
+
+// deleteRows deletes one view row for each of the given rows.
+func (m *incrementalViewMaintainer) deleteRows(
+	ctx context.Context, txn isql.Txn, view catalog.TableDescriptor, cols []string, rows []tree.Datums,
+) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	remaining := make(map[string]int)
+	var distinct []tree.Datums
+	for _, row := range rows {
+		k := viewTupleKey(row)
+		if remaining[k] == 0 {
+			distinct = append(distinct, row)
+		}
+		remaining[k]++
+	}
+	names := make([]tree.Expr, len(cols))
+	for i, c := range cols {
+		names[i] = tree.NewUnresolvedName(c)
+	}
+	rowIDCol := view.GetPrimaryIndex().GetKeyColumnName(0)
+	var rowIDs []tree.Datum
+	for i := 0; i < len(distinct); i += incrementalRefreshBatchSize {
+		batch := distinct[i:batchEnd(i, len(distinct))]
+		var args []interface{}
+		pred := tuplesMatchPredicate(names, batch, &args)
+		viewRows, err := txn.QueryBufferedEx(
+			ctx, "materialized-view-maintenance-lookup", txn.KV(), sessiondata.NoSessionDataOverride,
+			fmt.Sprintf("SELECT %s, %s FROM [%d AS v] WHERE %s",
+				tree.NameString(rowIDCol), viewColumnList(cols), m.viewID,
+				tree.AsStringWithFlags(pred, tree.FmtParsable)),
+			args...,
+		)
+		if err != nil {
+			return err
+		}
+		for _, viewRow := range viewRows {
+			if k := viewTupleKey(viewRow[1:]); remaining[k] > 0 {
+				remaining[k]--
+				rowIDs = append(rowIDs, viewRow[0])
+			}
+		}
+	}
+	for i := 0; i < len(rowIDs); i += incrementalRefreshBatchSize {
+		batch := rowIDs[i:batchEnd(i, len(rowIDs))]
+		var b strings.Builder
+		args := make([]interface{}, len(batch))
+		for j := range batch {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "$%d", j+1)
+			args[j] = batch[j]
+		}
+		if _, err := txn.ExecEx(
+			ctx, "materialized-view-maintenance-delete", txn.KV(), sessiondata.NoSessionDataOverride,
+			fmt.Sprintf("DELETE FROM [%d AS v] WHERE %s IN (%s)", m.viewID, tree.NameString(rowIDCol), b.String()),
+			args...,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertRows inserts the given rows into the view.
+func (m *incrementalViewMaintainer) insertRows(
+	ctx context.Context, txn isql.Txn, cols []string, rows []tree.Datums,
+) error {
+	for i := 0; i < len(rows); i += incrementalRefreshBatchSize {
+		batch := rows[i:batchEnd(i, len(rows))]
+		var b strings.Builder
+		args := make([]interface{}, 0, len(batch)*len(cols))
+		for j, row := range batch {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteByte('(')
+			for k, d := range row {
+				if k > 0 {
+					b.WriteString(", ")
+				}
+				args = append(args, d)
+				fmt.Fprintf(&b, "$%d", len(args))
+			}
+			b.WriteByte(')')
+		}
+		if _, err := txn.ExecEx(
+			ctx, "materialized-view-maintenance-insert", txn.KV(), sessiondata.NoSessionDataOverride,
+			fmt.Sprintf("INSERT INTO [%d AS v] (%s) VALUES %s", m.viewID, viewColumnList(cols), b.String()),
+			args...,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changedKeysPredicate returns a predicate which matches the rows of the given
+// source with one of the given primary keys.
+func changedKeysPredicate(
+	src *incrementalViewSource, idx catalog.Index, keys []tree.Datums, args *[]interface{},
+) tree.Expr {
+	placeholder := func(d tree.Datum) tree.Expr {
+		*args = append(*args, d)
+		return &tree.Placeholder{Idx: tree.PlaceholderIdx(len(*args) - 1)}
+	}
+	n := idx.NumKeyColumns()
+	list := make(tree.Exprs, len(keys))
+	for i, key := range keys {
+		if n == 1 {
+			list[i] = placeholder(key[0])
+			continue
+		}
+		tuple := &tree.Tuple{Exprs: make(tree.Exprs, n)}
+		for j := range key {
+			tuple.Exprs[j] = placeholder(key[j])
+		}
+		list[i] = tuple
+	}
+	var left tree.Expr
+	if n == 1 {
+		left = src.columnRef(idx.GetKeyColumnName(0))
+	} else {
+		tuple := &tree.Tuple{Exprs: make(tree.Exprs, n)}
+		for j := 0; j < n; j++ {
+			tuple.Exprs[j] = src.columnRef(idx.GetKeyColumnName(j))
+		}
+		left = tuple
+	}
+	return &tree.ParenExpr{Expr: &tree.ComparisonExpr{
+		Operator: treecmp.MakeComparisonOperator(treecmp.In),
+		Left:     left,
+		Right:    &tree.Tuple{Exprs: list},
+	}}
+}
+
+// tuplesMatchPredicate returns a predicate which matches the rows for which
+// the given expressions are not distinct from the values of one of the given
+// tuples.
+func tuplesMatchPredicate(exprs []tree.Expr, tuples []tree.Datums, args *[]interface{}) tree.Expr {
+	var pred tree.Expr
+	for _, tuple := range tuples {
+		var match tree.Expr
+		for i, d := range tuple {
+			*args = append(*args, d)
+			cmp := &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(treecmp.IsNotDistinctFrom),
+				Left:     &tree.ParenExpr{Expr: exprs[i]},
+				Right:    &tree.Placeholder{Idx: tree.PlaceholderIdx(len(*args) - 1)},
+			}
+			if match == nil {
+				match = cmp
+			} else {
+				match = &tree.AndExpr{Left: match, Right: cmp}
+			}
+		}
+		if pred == nil {
+			pred = &tree.ParenExpr{Expr: match}
+		} else {
+			pred = &tree.OrExpr{Left: pred, Right: &tree.ParenExpr{Expr: match}}
+		}
+	}
+	return &tree.ParenExpr{Expr: pred}
+}
+
+// viewColumnNames returns the names of the columns of the view which hold the
+// results of the view query.
+func viewColumnNames(view catalog.TableDescriptor) []string {
+	cols := view.VisibleColumns()
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = col.GetName()
+	}
+	return names
+}
+
+func viewColumnList(cols []string) string {
+	var b strings.Builder
+	for i, c := range cols {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(tree.NameString(c))
+	}
+	return b.String()
+}
+
+// viewTupleKey returns a string which identifies the given row of the view.
+func viewTupleKey(row tree.Datums) string {
+	return tree.AsStringWithFlags(&row, tree.FmtParsable)
+}
+
+// isSeen returns whether k is in the given set, and adds it if not.
+func isSeen(seen map[string]struct{}, k string) bool {
+	if _, ok := seen[k]; ok {
+		return true
+	}
+	seen[k] = struct{}{}
+	return false
+}
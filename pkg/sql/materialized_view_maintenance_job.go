@@ -0,0 +1,233 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// materializedViewMaintenanceResumer implements the job which keeps an
+// incrementally refreshed materialized view up to date. The job watches the
+// tables that the view reads from with a rangefeed and periodically applies
+// the changes that have been resolved to the view. The job high-water is the
+// timestamp as of which the contents of the view are current. The job
+// finishes once the view is dropped.
+type materializedViewMaintenanceResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = (*materializedViewMaintenanceResumer)(nil)
+
+// viewChangeBuffer accumulates the changes to the tables that an incrementally
+// refreshed materialized view reads from until they are applied to the view.
+type viewChangeBuffer struct {
+	syncutil.Mutex
+	// frontier is the timestamp up to which all changes have been received.
+	frontier hlc.Timestamp
+	changes  []viewSourceChange
+	// overflow is the timestamp of the latest change which was not buffered
+	// because the buffer was full. The view is recomputed in full until the
+	// high-water passes it.
+	overflow hlc.Timestamp
+}
+
+func (b *viewChangeBuffer) add(c viewSourceChange) {
+	b.Lock()
+	defer b.Unlock()
+	if len(b.changes) >= maxIncrementalRefreshKeys {
+		b.overflow.Forward(c.ts)
+		return
+	}
+	b.changes = append(b.changes, c)
+}
+
+// take returns the frontier and the changes in the window (hw, frontier].
+func (b *viewChangeBuffer) take(hw hlc.Timestamp) (hlc.Timestamp, []viewSourceChange) {
+	b.Lock()
+	defer b.Unlock()
+	var changes []viewSourceChange
+	for _, c := range b.changes {
+		if hw.Less(c.ts) && c.ts.LessEq(b.frontier) {
+			changes = append(changes, c)
+		}
+	}
+	if hw.Less(b.overflow) {
+		// Changes which are not buffered may be in the window.
+		changes = append(changes, viewSourceChange{ts: b.overflow})
+	}
+	return b.frontier, changes
+}
+
+// discard discards the changes up to and including hw.
+func (b *viewChangeBuffer) discard(hw hlc.Timestamp) {
+	b.Lock()
+	defer b.Unlock()
+	changes := b.changes[:0]
+	for _, c := range b.changes {
+		if hw.Less(c.ts) {
+			changes = append(changes, c)
+		}
+	}
+	b.changes = changes
+}
+
+// Resume is part of the jobs.Resumer interface.
+func (r *materializedViewMaintenanceResumer) Resume(
+	ctx context.Context, execCtx interface{},
+) error {
+	execCfg := execCtx.(JobExecContext).ExecCfg()
+	details := r.job.Details().(jobspb.MaterializedViewMaintenanceDetails)
+	m := &incrementalViewMaintainer{execCfg: execCfg, job: r.job, viewID: details.ViewID}
+
+	var hw hlc.Timestamp
+	progress := r.job.Progress()
+	if p := progress.GetHighWater(); p != nil {
+		hw = *p
+	}
+	var buf viewChangeBuffer
+	var rf *rangefeed.RangeFeed
+	defer func() {
+		if rf != nil {
+			rf.Close()
+		}
+	}()
+
+	var timer timeutil.Timer
+	defer timer.Stop()
+	for {
+		view, err := m.getView(ctx)
+		if err != nil {
+			log.Warningf(ctx, "failed to read materialized view %d: %v", details.ViewID, err)
+		} else if view == nil || view.Dropped() {
+			return nil
+		} else if !view.Adding() {
+			if rf == nil {
+				if hw.IsEmpty() {
+					// The view was populated as of its creation time.
+					hw = view.GetCreateAsOfTime()
+				}
+				if rf, err = startViewSourcesRangeFeed(ctx, execCfg, view, hw, &buf); err != nil {
+					return err
+				}
+			} else if frontier, changes := buf.take(hw); hw.Less(frontier) {
+				if err := m.apply(ctx, hw, frontier, changes); err != nil {
+					log.Warningf(ctx, "failed to refresh materialized view %d: %v", details.ViewID, err)
+				} else {
+					hw = frontier
+					buf.discard(hw)
+				}
+			}
+		}
+
+		timer.Reset(incrementalRefreshInterval.Get(&execCfg.Settings.SV))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			timer.Read = true
+		}
+	}
+}
+
+// startViewSourcesRangeFeed starts a rangefeed over the tables that the given
+// view reads from, which adds the changes after initialTS to buf.
+func startViewSourcesRangeFeed(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	view catalog.TableDescriptor,
+	initialTS hlc.Timestamp,
+	buf *viewChangeBuffer,
+) (*rangefeed.RangeFeed, error) {
+	var spans []roachpb.Span
+	seen := make(map[descpb.ID]struct{})
+	for _, id := range view.GetIncrementalRefresh().TableIDs {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			prefix := execCfg.Codec.TablePrefix(uint32(id))
+			spans = append(spans, roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()})
+		}
+	}
+	buf.frontier = initialTS
+	return execCfg.RangeFeedFactory.RangeFeed(
+		ctx,
+		fmt.Sprintf("materialized-view-maintenance-%d", view.GetID()),
+		spans,
+		initialTS,
+		func(ctx context.Context, v *roachpb.RangeFeedValue) {
+			buf.add(viewSourceChange{ts: v.Value.Timestamp, key: v.Key})
+		},
+		rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+			buf.Lock()
+			defer buf.Unlock()
+			buf.frontier.Forward(ts)
+		}),
+		rangefeed.WithOnSSTable(func(
+			ctx context.Context, sst *roachpb.RangeFeedSSTable, registeredSpan roachpb.Span,
+		) {
+			buf.add(viewSourceChange{ts: sst.WriteTS, span: sst.Span})
+		}),
+		rangefeed.WithOnDeleteRange(func(ctx context.Context, e *roachpb.RangeFeedDeleteRange) {
+			buf.add(viewSourceChange{ts: e.Timestamp, span: e.Span})
+		}),
+	)
+}
+
+// createMaterializedViewMaintenanceJob creates the job which keeps the given
+// incrementally refreshed materialized view up to date. The job runs for as
+// long as the view exists, so it is adopted by the registry once the
+// transaction commits rather than waited for by the statement.
+func (p *planner) createMaterializedViewMaintenanceJob(
+	ctx context.Context, view *tabledesc.Mutable,
+) error {
+	registry := p.ExecCfg().JobRegistry
+	record := jobs.Record{
+		Description:   fmt.Sprintf("materialized view maintenance for %s", view.GetName()),
+		Username:      username.NodeUserName(),
+		DescriptorIDs: descpb.IDs{view.GetID()},
+		Details:       jobspb.MaterializedViewMaintenanceDetails{ViewID: view.GetID()},
+		Progress:      jobspb.MaterializedViewMaintenanceProgress{},
+		NonCancelable: true,
+	}
+	_, err := registry.CreateAdoptableJobWithTxn(ctx, record, registry.MakeJobID(), p.InternalSQLTxn())
+	return err
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface.
+func (r *materializedViewMaintenanceResumer) OnFailOrCancel(
+	ctx context.Context, execCtx interface{}, _ error,
+) error {
+	return nil
+}
+
+func init() {
+	jobs.RegisterConstructor(
+		jobspb.TypeMaterializedViewMaintenance,
+		func(job *jobs.Job, settings *cluster.Settings) jobs.Resumer {
+			return &materializedViewMaintenanceResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
@@ -0,0 +1,452 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// maxNotifyChannelLength is the maximum length of a notification channel
+// name. It matches the maximum identifier length in Postgres.
+const maxNotifyChannelLength = 63
+
+// maxNotifyPayloadLength is the maximum length of a notification payload. It
+// matches the limit in Postgres.
+const maxNotifyPayloadLength = 8000
+
+// maxQueuedNotifications is the maximum number of notifications that can be
+// waiting to be delivered to a single listening session. Further
+// notifications are dropped until the session catches up, and the client is
+// sent a notice with the number of dropped notifications.
+const maxQueuedNotifications = 10000
+
+// maxOutgoingNotificationBatches is the maximum number of transactions whose
+// notifications can be waiting to be sent to the other nodes. The
+// notifications of further transactions are dropped until the queue drains.
+const maxOutgoingNotificationBatches = 1000
+
+// NotificationSender is implemented by the ClientComm of client connections
+// that can deliver asynchronous notifications, sent with NOTIFY, to the
+// client.
+type NotificationSender interface {
+	// SendNotification sends a NotificationResponse message to the client. It
+	// is called concurrently with the execution of statements on the
+	// connection.
+	SendNotification(pid int32, channel, payload string) error
+	// SendNotice sends a NoticeResponse message to the client. It is called
+	// concurrently with the execution of statements on the connection.
+	SendNotice(ctx context.Context, notice pgnotice.Notice) error
+}
+
+// notification is a message sent with NOTIFY or pg_notify().
+type notification struct {
+	channel string
+	payload string
+	// pid identifies the session that sent the notification. See
+	// pg_backend_pid().
+	pid int32
+}
+
+// notificationRegistry routes notifications to the sessions on this node that
+// listen on their channel.
+//
+// The notifications committed by a session are queued in the outbox, and sent
+// by an async task to the registry of every node through the
+// SendNotifications endpoint of the status server, so that they reach the
+// listening sessions regardless of the node they are connected to. Committing
+// a transaction does not wait for the notifications to be delivered.
+type notificationRegistry struct {
+	// stopper and statusServer are set when the server is started. The stopper
+	// runs the task that sends the notifications in the outbox, as well as the
+	// tasks that deliver notifications to the clients of listening sessions.
+	stopper      *stop.Stopper
+	statusServer serverpb.SQLStatusServer
+
+	// outbox holds the notifications of the transactions committed on this
+	// node that have not been sent yet.
+	outbox chan []notification
+
+	mu struct {
+		syncutil.Mutex
+		// listeners maps each channel to the sessions listening on it.
+		listeners map[string]map[*notificationListener]struct{}
+	}
+}
+
+func newNotificationRegistry() *notificationRegistry {
+	r := &notificationRegistry{outbox: make(chan []notification, maxOutgoingNotificationBatches)}
+	r.mu.listeners = make(map[string]map[*notificationListener]struct{})
+	return r
+}
+
+// start starts the task that sends the notifications in the outbox to all
+// nodes.
+func (r *notificationRegistry) start(
+	ctx context.Context, stopper *stop.Stopper, statusServer serverpb.SQLStatusServer,
+) {
+	r.stopper = stopper
+	r.statusServer = statusServer
+	_ = stopper.RunAsyncTask(ctx, "notification-sender", func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		for {
+			select {
+			case ns := <-r.outbox:
+				r.send(ctx, ns)
+			case <-stopper.ShouldQuiesce():
+				return
+			}
+		}
+	})
+}
+
+// enqueue queues the notifications committed by a transaction for delivery
+// to the listening sessions on all nodes. If the registry was not started,
+// the notifications are only delivered on this node.
+func (r *notificationRegistry) enqueue(ctx context.Context, ns []notification) {
+	if r.stopper == nil {
+		r.publish(ctx, ns)
+		return
+	}
+	select {
+	case r.outbox <- ns:
+	default:
+		// The transaction has already committed, so there is no way to report
+		// the error to the client.
+		log.Warningf(ctx, "dropping %d notifications: too many notifications are waiting to be sent",
+			len(ns))
+	}
+}
+
+// send delivers the given notifications to the listening sessions on all
+// nodes. If the status server is not available, the notifications are only
+// delivered on this node.
+func (r *notificationRegistry) send(ctx context.Context, ns []notification) {
+	if r.statusServer == nil {
+		r.publish(ctx, ns)
+		return
+	}
+	req := &serverpb.SendNotificationsRequest{
+		Notifications: make([]serverpb.Notification, len(ns)),
+	}
+	for i, n := range ns {
+		req.Notifications[i] = serverpb.Notification{Channel: n.channel, Payload: n.payload, PID: n.pid}
+	}
+	if _, err := r.statusServer.SendNotifications(ctx, req); err != nil {
+		log.Warningf(ctx, "failed to deliver notifications to all nodes: %v", err)
+	}
+}
+
+// listen registers the listener on the given channel.
+func (r *notificationRegistry) listen(channel string, l *notificationListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ls, ok := r.mu.listeners[channel]
+	if !ok {
+		ls = make(map[*notificationListener]struct{})
+		r.mu.listeners[channel] = ls
+	}
+	ls[l] = struct{}{}
+}
+
+// unlisten removes the listener from the given channel.
+func (r *notificationRegistry) unlisten(channel string, l *notificationListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ls := r.mu.listeners[channel]
+	delete(ls, l)
+	if len(ls) == 0 {
+		delete(r.mu.listeners, channel)
+	}
+}
+
+// publish queues the given notifications for delivery to the sessions
+// listening on their channels.
+func (r *notificationRegistry) publish(ctx context.Context, ns []notification) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, n := range ns {
+		for l := range r.mu.listeners[n.channel] {
+			l.enqueue(ctx, n)
+		}
+	}
+}
+
+// notificationListener delivers the notifications received by a session to
+// its client. Notifications are held while the session has an open
+// transaction, as in Postgres, and are written to the client by a separate
+// goroutine so that idle sessions receive them without delay.
+type notificationListener struct {
+	sender NotificationSender
+
+	// channels is the set of channels that the session listens on. It is only
+	// accessed by the session's connExecutor.
+	channels map[string]struct{}
+
+	// wake is signaled when notifications may be ready for delivery.
+	wake chan struct{}
+	// done is closed when the session is closed.
+	done chan struct{}
+
+	mu struct {
+		syncutil.Mutex
+		queue []notification
+		// dropped is the number of notifications that were dropped because the
+		// queue was full, and that the client has not been told about yet.
+		dropped int
+		// inTxn is true while the session has an open transaction.
+		inTxn bool
+	}
+}
+
+func newNotificationListener(sender NotificationSender) *notificationListener {
+	return &notificationListener{
+		sender:   sender,
+		channels: make(map[string]struct{}),
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+}
+
+// enqueue queues a notification for delivery.
+func (l *notificationListener) enqueue(ctx context.Context, n notification) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.mu.queue) >= maxQueuedNotifications {
+		if l.mu.dropped == 0 {
+			log.Warningf(ctx, "dropping notifications: too many queued notifications for session")
+		}
+		l.mu.dropped++
+		return
+	}
+	l.mu.queue = append(l.mu.queue, n)
+	l.signal()
+}
+
+// setInTxn records whether the session has an open transaction.
+func (l *notificationListener) setInTxn(inTxn bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.mu.inTxn = inTxn
+	if !inTxn && len(l.mu.queue) > 0 {
+		l.signal()
+	}
+}
+
+func (l *notificationListener) signal() {
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run delivers the queued notifications to the client until the session is
+// closed, the client connection fails or the server shuts down.
+func (l *notificationListener) run(ctx context.Context, quiesce <-chan struct{}) {
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-quiesce:
+			return
+		case <-l.wake:
+		}
+		l.mu.Lock()
+		var ns []notification
+		var dropped int
+		if !l.mu.inTxn {
+			ns, l.mu.queue = l.mu.queue, nil
+			dropped, l.mu.dropped = l.mu.dropped, 0
+		}
+		l.mu.Unlock()
+		for _, n := range ns {
+			if err := l.sender.SendNotification(n.pid, n.channel, n.payload); err != nil {
+				log.VEventf(ctx, 2, "failed to send notification: %v", err)
+				return
+			}
+		}
+		if dropped > 0 {
+			notice := pgnotice.NewWithSeverityf("WARNING",
+				"%d notifications were dropped because too many notifications were queued for the session",
+				dropped)
+			if err := l.sender.SendNotice(ctx, notice); err != nil {
+				log.VEventf(ctx, 2, "failed to send notice: %v", err)
+				return
+			}
+		}
+	}
+}
+
+// notificationTxnState holds the effects of the LISTEN, UNLISTEN and NOTIFY
+// statements of a transaction, which are applied when it commits.
+type notificationTxnState struct {
+	// pending are the notifications sent by the transaction, in order.
+	// Duplicate notifications are only sent once.
+	pending []notification
+	seen    map[notification]struct{}
+	// listenOps are the LISTEN and UNLISTEN statements of the transaction.
+	listenOps []listenOp
+}
+
+// listenOp is the effect of a LISTEN or UNLISTEN statement.
+type listenOp struct {
+	channel string
+	// unlisten is true for UNLISTEN. If unlisten is true and channel is
+	// empty, the session stops listening on all channels.
+	unlisten bool
+}
+
+// addNotification records a notification to send on commit.
+func (s *notificationTxnState) addNotification(n notification) {
+	if _, ok := s.seen[n]; ok {
+		return
+	}
+	if s.seen == nil {
+		s.seen = make(map[notification]struct{})
+	}
+	s.seen[n] = struct{}{}
+	s.pending = append(s.pending, n)
+}
+
+// notify records a notification on the given channel, which is sent when the
+// transaction commits.
+func (p *planner) notify(channel, payload string) error {
+	if p.notifications == nil {
+		return pgerror.New(pgcode.FeatureNotSupported, "NOTIFY is not supported in this context")
+	}
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > maxNotifyChannelLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	if len(payload) >= maxNotifyPayloadLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	p.notifications.addNotification(notification{
+		channel: channel,
+		payload: payload,
+		pid:     int32(p.extendedEvalCtx.QueryCancelKey.GetPGBackendPID()),
+	})
+	return nil
+}
+
+// NotifyChannel is part of the eval.Planner interface. It implements
+// pg_notify().
+func (p *planner) NotifyChannel(ctx context.Context, channel, payload string) error {
+	return p.notify(channel, payload)
+}
+
+// Listen implements the LISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-listen.html.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	if p.notifications == nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported, "LISTEN is not supported in this context")
+	}
+	p.notifications.listenOps = append(p.notifications.listenOps, listenOp{channel: string(n.ChannelName)})
+	return newZeroNode(nil /* columns */), nil
+}
+
+// Notify implements the NOTIFY statement.
+// See https://www.postgresql.org/docs/current/sql-notify.html.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	var payload string
+	if n.Payload != nil {
+		payload = *n.Payload
+	}
+	if err := p.notify(string(n.ChannelName), payload); err != nil {
+		return nil, err
+	}
+	return newZeroNode(nil /* columns */), nil
+}
+
+// commitNotifications applies the LISTEN and UNLISTEN statements of the
+// transaction that just committed, and publishes its notifications.
+func (ex *connExecutor) commitNotifications(ctx context.Context) {
+	s := &ex.extraTxnState.notifications
+	registry := ex.server.notifications
+	for _, op := range s.listenOps {
+		if op.unlisten {
+			if ex.notificationListener == nil {
+				continue
+			}
+			for channel := range ex.notificationListener.channels {
+				if op.channel == "" || op.channel == channel {
+					registry.unlisten(channel, ex.notificationListener)
+					delete(ex.notificationListener.channels, channel)
+				}
+			}
+			continue
+		}
+		if ex.notificationListener == nil {
+			sender, ok := ex.clientComm.(NotificationSender)
+			if !ok {
+				log.VEventf(ctx, 2, "client connection does not support notifications")
+				continue
+			}
+			stopper := registry.stopper
+			if stopper == nil {
+				log.VEventf(ctx, 2, "notifications are not available before the server is started")
+				continue
+			}
+			l := newNotificationListener(sender)
+			// The session is still in its transaction, so delivery is held
+			// until the transaction state is reset.
+			l.setInTxn(true)
+			if err := stopper.RunAsyncTask(ex.ctxHolder.connCtx, "notification-listener", func(ctx context.Context) {
+				l.run(ctx, stopper.ShouldQuiesce())
+			}); err != nil {
+				log.VEventf(ctx, 2, "failed to start notification listener: %v", err)
+				continue
+			}
+			ex.notificationListener = l
+		}
+		ex.notificationListener.channels[op.channel] = struct{}{}
+		registry.listen(op.channel, ex.notificationListener)
+	}
+	if len(s.pending) > 0 {
+		registry.enqueue(ctx, s.pending)
+	}
+}
+
+// PublishNotifications delivers the given notifications, committed by a
+// session on any node, to the sessions on this node that listen on their
+// channel. It is called by the status server.
+func (s *Server) PublishNotifications(ctx context.Context, ns []serverpb.Notification) {
+	notifications := make([]notification, len(ns))
+	for i := range ns {
+		notifications[i] = notification{channel: ns[i].Channel, payload: ns[i].Payload, pid: ns[i].PID}
+	}
+	s.notifications.publish(ctx, notifications)
+}
+
+// closeNotificationListener stops the delivery of notifications to the
+// session.
+func (ex *connExecutor) closeNotificationListener() {
+	l := ex.notificationListener
+	if l == nil {
+		return
+	}
+	for channel := range l.channels {
+		ex.server.notifications.unlisten(channel, l)
+	}
+	close(l.done)
+	ex.notificationListener = nil
+}
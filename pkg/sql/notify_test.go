@@ -0,0 +1,152 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListenNotify verifies that notifications are delivered to listening
+// sessions when the notifying transaction commits.
+func TestListenNotify(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	pgURL, cleanup := sqlutils.PGUrl(t, s.ServingSQLAddr(), t.Name(), url.User(username.RootUser))
+	defer cleanup()
+	listener, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	defer func() { _ = listener.Close(ctx) }()
+
+	_, err = listener.Exec(ctx, "LISTEN jobs")
+	require.NoError(t, err)
+	// A LISTEN that is rolled back has no effect.
+	_, err = listener.Exec(ctx, "BEGIN; LISTEN other; ROLLBACK")
+	require.NoError(t, err)
+
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+	sqlDB := sqlutils.MakeSQLRunner(conn)
+	var pid uint32
+	sqlDB.QueryRow(t, "SELECT pg_backend_pid()").Scan(&pid)
+
+	// Notifications of rolled back transactions and on other channels are not
+	// delivered, and duplicate notifications in a transaction are only
+	// delivered once.
+	sqlDB.Exec(t, "BEGIN; NOTIFY jobs, 'lost'; ROLLBACK")
+	sqlDB.Exec(t, "NOTIFY other, 'other'")
+	sqlDB.Exec(t, "BEGIN; NOTIFY jobs, 'a'; SELECT pg_notify('jobs', 'b'); NOTIFY jobs, 'a'; COMMIT")
+	sqlDB.Exec(t, "NOTIFY jobs")
+
+	waitCtx, cancel := context.WithTimeout(ctx, 45*time.Second)
+	defer cancel()
+	for _, payload := range []string{"a", "b", ""} {
+		n, err := listener.WaitForNotification(waitCtx)
+		require.NoError(t, err)
+		require.Equal(t, "jobs", n.Channel)
+		require.Equal(t, payload, n.Payload)
+		require.Equal(t, pid, n.PID)
+	}
+
+	// After UNLISTEN, notifications on the channel are no longer delivered.
+	_, err = listener.Exec(ctx, "UNLISTEN *; LISTEN other")
+	require.NoError(t, err)
+	sqlDB.Exec(t, "NOTIFY jobs, 'ignored'")
+	sqlDB.Exec(t, "NOTIFY other, 'c'")
+	n, err := listener.WaitForNotification(waitCtx)
+	require.NoError(t, err)
+	require.Equal(t, "other", n.Channel)
+	require.Equal(t, "c", n.Payload)
+
+	sqlDB.ExpectErr(t, "channel name cannot be empty", "SELECT pg_notify('', 'payload')")
+}
+
+// TestListenNotifyAcrossNodes verifies that notifications are delivered to
+// listening sessions connected to other nodes, and that the client is told
+// about the notifications dropped because its queue is full.
+func TestListenNotifyAcrossNodes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := testcluster.StartTestCluster(t, 2, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+
+	pgURL, cleanup := sqlutils.PGUrl(t, tc.Server(1).ServingSQLAddr(), t.Name(), url.User(username.RootUser))
+	defer cleanup()
+	config, err := pgx.ParseConfig(pgURL.String())
+	require.NoError(t, err)
+	notices := make(chan string, 1)
+	config.OnNotice = func(_ *pgconn.PgConn, n *pgconn.Notice) {
+		notices <- n.Message
+	}
+	listener, err := pgx.ConnectConfig(ctx, config)
+	require.NoError(t, err)
+	defer func() { _ = listener.Close(ctx) }()
+
+	_, err = listener.Exec(ctx, "LISTEN jobs")
+	require.NoError(t, err)
+
+	sqlDB := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+	sqlDB.Exec(t, "NOTIFY jobs, 'remote'")
+
+	waitCtx, cancel := context.WithTimeout(ctx, 45*time.Second)
+	defer cancel()
+	n, err := listener.WaitForNotification(waitCtx)
+	require.NoError(t, err)
+	require.Equal(t, "jobs", n.Channel)
+	require.Equal(t, "remote", n.Payload)
+
+	// Notifications are held while the listening session has an open
+	// transaction, and the ones that do not fit in its queue are dropped.
+	_, err = listener.Exec(ctx, "BEGIN")
+	require.NoError(t, err)
+	sqlDB.Exec(t, "SELECT pg_notify('jobs', i::STRING) FROM generate_series(1, 10005) AS g(i)")
+	_, err = listener.Exec(ctx, "COMMIT")
+	require.NoError(t, err)
+	for i := 0; i < 10000; i++ {
+		_, err := listener.WaitForNotification(waitCtx)
+		require.NoError(t, err)
+	}
+	// The notice is processed by the client when it reads from the connection.
+	testutils.SucceedsSoon(t, func() error {
+		select {
+		case msg := <-notices:
+			require.Equal(t, "5 notifications were dropped because too many notifications were queued for the session", msg)
+			return nil
+		default:
+		}
+		_, err := listener.Exec(ctx, "SELECT 1")
+		require.NoError(t, err)
+		return errors.New("notice not received")
+	})
+}
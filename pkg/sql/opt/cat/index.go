@@ -13,8 +13,10 @@ package cat
 import (
 	"github.com/cockroachdb/cockroach/pkg/geo/geoindex"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 )
 
 // IndexOrdinal identifies an index (in the context of a Table).
@@ -194,6 +196,15 @@ type Index interface {
 	// describes the configuration for this geospatial inverted index.
 	GeoConfig() geoindex.Config
 
+	// InvertedColumnKind returns the kind of the inverted column of an inverted
+	// index. It is DEFAULT for indexes that are not inverted.
+	InvertedColumnKind() catpb.InvertedIndexColumnKind
+
+	// VectorConfig returns the configuration of the IVF lists that the vectors
+	// of a vector inverted index are partitioned into. It is only meaningful if
+	// InvertedColumnKind is a vector kind.
+	VectorConfig() vector.IVFConfig
+
 	// Version returns the IndexDescriptorVersion of the index.
 	Version() descpb.IndexDescriptorVersion
 
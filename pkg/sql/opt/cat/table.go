@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -176,6 +177,22 @@ type Table interface {
 	// GetDatabaseID returns the owning database id of the table, or zero, if the
 	// owning database could not be determined.
 	GetDatabaseID() descpb.ID
+
+	// InheritedByCount returns the number of tables that inherit directly from
+	// this table.
+	InheritedByCount() int
+
+	// InheritedBy returns the ID of the ith table that inherits directly from
+	// this table, where i < InheritedByCount. Queries on this table also scan
+	// the inheriting tables, unless the table is qualified with ONLY.
+	InheritedBy(i int) StableID
+
+	// TriggerCount returns the number of triggers on the table.
+	TriggerCount() int
+
+	// Trigger returns the ith trigger on the table, where i < TriggerCount.
+	// Triggers are returned in the order in which they fire.
+	Trigger(i int) Trigger
 }
 
 // CheckConstraint contains the SQL text and the validity status for a check
@@ -314,6 +331,10 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+
+	// Deferrability returns whether checking the constraint can be postponed
+	// until the end of the transaction, and whether that is the default.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -361,6 +382,22 @@ type UniqueConstraint interface {
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// IsExclusion is true if this is an exclusion constraint rather than a
+	// unique constraint. Two rows violate an exclusion constraint if the
+	// ExclusionOperator of every column returns true when applied to their
+	// values, so exclusion constraints do not form keys.
+	IsExclusion() bool
+
+	// ExclusionOperator returns the operator used to compare values of the ith
+	// column in this constraint. It is always EQ for unique constraints.
+	ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol
+
+	// Deferrability returns whether the checking of this constraint may be
+	// deferred until the end of the transaction. A deferrable constraint can be
+	// violated within a transaction, so it does not form a key and cannot be
+	// used as an arbiter.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
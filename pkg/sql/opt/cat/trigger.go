@@ -0,0 +1,56 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cat
+
+import "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+
+// Trigger is an interface to a trigger on a table, exposing only the
+// information needed by the query optimizer to fire it.
+type Trigger interface {
+	// Name is the name of the trigger.
+	Name() tree.Name
+
+	// ActionTime returns whether the trigger fires before or after the
+	// operation.
+	ActionTime() tree.TriggerActionTime
+
+	// HasEvent returns whether the trigger fires for the given operation.
+	HasEvent(event tree.TriggerEventType) bool
+
+	// UpdateColumnCount returns the number of columns in the UPDATE OF event of
+	// the trigger. If it is zero, the trigger fires for every UPDATE.
+	UpdateColumnCount() int
+
+	// UpdateColumnOrdinal returns the table column ordinal of the ith column in
+	// the UPDATE OF event of the trigger, where i < UpdateColumnCount.
+	UpdateColumnOrdinal(i int) int
+
+	// ForEachRow returns true if the trigger fires once for every modified row,
+	// and false if it fires once per statement.
+	ForEachRow() bool
+
+	// WhenExpr returns the SQL text of the WHEN condition of the trigger, or
+	// the empty string if it has none. The condition refers to the new and old
+	// rows as new.<column> and old.<column>.
+	WhenExpr() string
+
+	// FuncID returns the ID of the user-defined function executed by the
+	// trigger. It is zero if the trigger executes a builtin trigger function.
+	FuncID() StableID
+
+	// FuncName returns the name of the builtin trigger function executed by
+	// the trigger, if FuncID is zero.
+	FuncName() string
+
+	// FuncArgs returns the arguments of the trigger function, which are
+	// available to it as TG_ARGV.
+	FuncArgs() []string
+}
@@ -13,6 +13,7 @@ package execbuilder
 import (
 	"bytes"
 	"fmt"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
@@ -123,6 +124,10 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (execPlan, error) {
 		return execPlan{}, err
 	}
 
+	if err := b.buildFKCascades(ins.WithID, ins.FKCascades); err != nil {
+		return execPlan{}, err
+	}
+
 	return ep, nil
 }
 
@@ -159,6 +164,12 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 		return execPlan{}, false, nil
 	}
 
+	// We cannot use the fast path if any triggers must be fired after the
+	// insert.
+	if len(ins.FKCascades) > 0 {
+		return execPlan{}, false, nil
+	}
+
 	md := b.mem.Metadata()
 	tab := md.Table(ins.Table)
 
@@ -172,6 +183,10 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 			return execPlan{}, false, nil
 		}
 		fk := tab.OutboundForeignKey(c.FKOrdinal)
+		if fk.Deferrability() != tree.ConstraintNotDeferrable {
+			// Deferrable FK; violations may need to be postponed until commit.
+			return execPlan{}, false, nil
+		}
 		lookupJoin, isLookupJoin := c.Check.(*memo.LookupJoinExpr)
 		if !isLookupJoin || lookupJoin.JoinType != opt.AntiJoinOp {
 			// Not a lookup anti-join.
@@ -714,7 +729,7 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 			for i, col := range c.KeyCols {
 				keyVals[i] = row[query.getNodeColumnOrdinal(col)]
 			}
-			return mkUniqueCheckErr(md, c, keyVals)
+			return maybeDeferrableUniqueCheckErr(md, c, keyVals, mkUniqueCheckErr(md, c, keyVals))
 		}
 		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
 		if err != nil {
@@ -740,7 +755,7 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 			for i, col := range c.KeyCols {
 				keyVals[i] = row[query.getNodeColumnOrdinal(col)]
 			}
-			return mkFKCheckErr(md, c, keyVals)
+			return maybeDeferrableFKCheckErr(md, c, keyVals, mkFKCheckErr(md, c, keyVals))
 		}
 		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
 		if err != nil {
@@ -757,6 +772,9 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums) error {
 	tabMeta := md.TableMeta(c.Table)
 	uc := tabMeta.Table.Unique(c.CheckOrdinal)
+	if uc.IsExclusion() {
+		return mkExclusionCheckErr(md, c, keyVals)
+	}
 	constraintName := uc.Name()
 	var msg, details bytes.Buffer
 
@@ -793,6 +811,51 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	)
 }
 
+// mkExclusionCheckErr generates a user-friendly error describing an exclusion
+// constraint violation. The keyVals are the values of the constraint columns,
+// in table column order.
+func mkExclusionCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums) error {
+	tabMeta := md.TableMeta(c.Table)
+	uc := tabMeta.Table.Unique(c.CheckOrdinal)
+	constraintName := uc.Name()
+	var msg, details bytes.Buffer
+
+	// Generate an error of the form:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (k, r)=(2, {1,2}) conflicts with an existing key.
+	msg.WriteString("conflicting key value violates exclusion constraint ")
+	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
+
+	ords := make([]int, uc.ColumnCount())
+	for i := range ords {
+		ords[i] = uc.ColumnOrdinal(tabMeta.Table, i)
+	}
+	sort.Ints(ords)
+	details.WriteString("Key (")
+	for i, ord := range ords {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		details.WriteString(string(tabMeta.Table.Column(ord).ColName()))
+	}
+	details.WriteString(")=(")
+	for i, d := range keyVals {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		details.WriteString(d.String())
+	}
+	details.WriteString(") conflicts with an existing key.")
+
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(pgcode.ExclusionViolation, "%s", msg.String()),
+			constraintName,
+		),
+		details.String(),
+	)
+}
+
 // mkFKCheckErr generates a user-friendly error describing a foreign key
 // violation. The keyVals are the values that correspond to the
 // cat.ForeignKeyConstraint columns.
@@ -886,6 +949,57 @@ func mkFKCheckErr(md *opt.Metadata, c *memo.FKChecksItem, keyVals tree.Datums) e
 	)
 }
 
+// maybeDeferrableFKCheckErr wraps the error describing a violation of a
+// deferrable foreign key in an exec.DeferrableConstraintViolation, so that the
+// check can be postponed until commit. Checks enforcing a RESTRICT action are
+// never deferred.
+func maybeDeferrableFKCheckErr(
+	md *opt.Metadata, c *memo.FKChecksItem, keyVals tree.Datums, err error,
+) error {
+	var fk cat.ForeignKeyConstraint
+	if c.FKOutbound {
+		fk = md.TableMeta(c.OriginTable).Table.OutboundForeignKey(c.FKOrdinal)
+	} else {
+		fk = md.TableMeta(c.ReferencedTable).Table.InboundForeignKey(c.FKOrdinal)
+		action := fk.UpdateReferenceAction()
+		if c.OpName == "delete" {
+			action = fk.DeleteReferenceAction()
+		}
+		if action == tree.Restrict {
+			return err
+		}
+	}
+	if fk.Deferrability() == tree.ConstraintNotDeferrable {
+		return err
+	}
+	return &exec.DeferrableConstraintViolation{
+		Err:            err,
+		TableID:        fk.OriginTableID(),
+		ConstraintName: fk.Name(),
+		Deferrability:  fk.Deferrability(),
+		KeyVals:        keyVals,
+	}
+}
+
+// maybeDeferrableUniqueCheckErr wraps the error describing a violation of a
+// deferrable unique constraint in an exec.DeferrableConstraintViolation, so
+// that the check can be postponed until commit.
+func maybeDeferrableUniqueCheckErr(
+	md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums, err error,
+) error {
+	uc := md.TableMeta(c.Table).Table.Unique(c.CheckOrdinal)
+	if uc.Deferrability() == tree.ConstraintNotDeferrable {
+		return err
+	}
+	return &exec.DeferrableConstraintViolation{
+		Err:            err,
+		TableID:        uc.TableID(),
+		ConstraintName: uc.Name(),
+		Deferrability:  uc.Deferrability(),
+		KeyVals:        keyVals,
+	}
+}
+
 func (b *Builder) buildFKCascades(withID opt.WithID, cascades memo.FKCascades) error {
 	if len(cascades) == 0 {
 		return nil
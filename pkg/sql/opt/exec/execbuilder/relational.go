@@ -287,6 +287,9 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.CreateFunctionExpr:
 		ep, err = b.buildCreateFunction(t)
 
+	case *memo.CallExpr:
+		ep, err = b.buildCall(t)
+
 	case *memo.WithExpr:
 		ep, err = b.buildWith(t)
 
@@ -1494,6 +1497,27 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 			agg = aggDistinct.Input
 		}
 
+		if udAgg, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			info, err := b.buildUserDefinedAggInfo(udAgg)
+			if err != nil {
+				return execPlan{}, err
+			}
+			argCols := make([]exec.NodeColumnOrdinal, len(udAgg.Args))
+			for j := range udAgg.Args {
+				argCols[j] = input.getNodeColumnOrdinal(udAgg.Args[j].(*memo.VariableExpr).Col)
+			}
+			aggInfos[i] = exec.AggInfo{
+				FuncName:    udAgg.Name,
+				Distinct:    distinct,
+				ResultType:  item.Agg.DataType(),
+				ArgCols:     argCols,
+				Filter:      filterOrd,
+				UserDefined: info,
+			}
+			ep.outputCols.Set(int(item.Col), len(groupingColIdx)+i)
+			continue
+		}
+
 		name, _ := memo.FindAggregateOverload(agg)
 
 		// Accumulate variable arguments in argCols and constant arguments in
@@ -1545,6 +1569,37 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 	return ep, nil
 }
 
+// buildUserDefinedAggInfo builds the transition and final expressions of an
+// aggregate created with CREATE AGGREGATE. The expressions refer to the state
+// and the arguments of the aggregate as IndexedVars, in that order.
+func (b *Builder) buildUserDefinedAggInfo(
+	agg *memo.UserDefinedAggExpr,
+) (*exec.UserDefinedAggInfo, error) {
+	var colMap opt.ColMap
+	for i, col := range agg.Params {
+		colMap.Set(int(col), i)
+	}
+	ctx := buildScalarCtx{
+		ivh:     tree.MakeIndexedVarHelper(nil /* container */, colMap.Len()),
+		ivarMap: colMap,
+	}
+	transition, err := b.buildScalar(&ctx, agg.Transition)
+	if err != nil {
+		return nil, err
+	}
+	final, err := b.buildScalar(&ctx, agg.Final)
+	if err != nil {
+		return nil, err
+	}
+	return &exec.UserDefinedAggInfo{
+		Transition: transition,
+		Final:      final,
+		StateType:  agg.StateType,
+		InitCond:   agg.InitCond,
+		Strict:     agg.Strict,
+	}, nil
+}
+
 func (b *Builder) buildDistinct(distinct memo.RelExpr) (execPlan, error) {
 	private := distinct.Private().(*memo.GroupingPrivate)
 
@@ -3050,20 +3105,52 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 	filterIdxs := make([]int, len(w.Windows))
 	exprs := make([]*tree.FuncExpr, len(w.Windows))
 	windowVals := make([]tree.WindowDef, len(w.Windows))
+	var udAggs []*exec.UserDefinedAggInfo
 
 	for i := range w.Windows {
 		item := &w.Windows[i]
 		fn := b.extractWindowFunction(item.Function)
-		name, overload := memo.FindWindowOverload(fn)
-		if !b.disableTelemetry {
-			telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+		var name string
+		var overload *tree.Overload
+		var props *tree.FunctionProperties
+		var fnRef tree.ResolvableFunctionReference
+		var argExprs []opt.ScalarExpr
+		if udAgg, ok := fn.(*memo.UserDefinedAggExpr); ok {
+			info, err := b.buildUserDefinedAggInfo(udAgg)
+			if err != nil {
+				return execPlan{}, err
+			}
+			if udAggs == nil {
+				udAggs = make([]*exec.UserDefinedAggInfo, len(w.Windows))
+			}
+			udAggs[i] = info
+			name = udAgg.Name
+			props = &tree.FunctionProperties{Class: tree.AggregateClass}
+			overload = &tree.Overload{
+				FunctionProperties: *props,
+				ReturnType:         tree.FixedReturnType(udAgg.Typ),
+			}
+			fnRef = tree.ResolvableFunctionReference{
+				FunctionReference: &tree.ResolvedFunctionDefinition{Name: name},
+			}
+			argExprs = udAgg.Args
+		} else {
+			name, overload = memo.FindWindowOverload(fn)
+			if !b.disableTelemetry {
+				telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+			}
+			props, _ = builtinsregistry.GetBuiltinProperties(name)
+			fnRef = b.wrapFunction(name)
+			argExprs = make([]opt.ScalarExpr, fn.ChildCount())
+			for j := range argExprs {
+				argExprs[j] = fn.Child(j).(opt.ScalarExpr)
+			}
 		}
-		props, _ := builtinsregistry.GetBuiltinProperties(name)
 
-		args := make([]tree.TypedExpr, fn.ChildCount())
-		argIdxs[i] = make([]exec.NodeColumnOrdinal, fn.ChildCount())
-		for j, n := 0, fn.ChildCount(); j < n; j++ {
-			col := fn.Child(j).(*memo.VariableExpr).Col
+		args := make([]tree.TypedExpr, len(argExprs))
+		argIdxs[i] = make([]exec.NodeColumnOrdinal, len(argExprs))
+		for j := range argExprs {
+			col := argExprs[j].(*memo.VariableExpr).Col
 			args[j] = b.indexedVar(&ctx, b.mem.Metadata(), col)
 			idx, _ := input.outputCols.Get(int(col))
 			argIdxs[i][j] = exec.NodeColumnOrdinal(idx)
@@ -3097,7 +3184,7 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 			Frame:      frame,
 		}
 		exprs[i] = tree.NewTypedFuncExpr(
-			b.wrapFunction(name),
+			fnRef,
 			0,
 			args,
 			builtFilter,
@@ -3143,6 +3230,8 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 		FilterIdxs: filterIdxs,
 		Partition:  partitionIdxs,
 		Ordering:   input.sqlOrdering(ord),
+
+		UserDefinedAggs: udAggs,
 	})
 	if err != nil {
 		return execPlan{}, err
@@ -651,7 +651,9 @@ func (b *Builder) buildSubquery(
 
 		// Create a tree.RoutinePlanFn that can plan the single statement
 		// representing the subquery.
-		planFn := b.buildRoutinePlanFn(params, stmts, true /* allowOuterWithRefs */)
+		planFn := b.buildRoutinePlanFn(
+			params, stmts, true /* allowOuterWithRefs */, false, /* allowPostqueries */
+		)
 		return tree.NewTypedRoutineExpr(
 			"subquery",
 			args,
@@ -769,28 +771,49 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 	}
 
 	// Create a tree.RoutinePlanFn that can plan the statements in the UDF body.
+	// The statements of a PL/pgSQL function are planned with the values of all
+	// of the variables of the function, and they can modify tables, so they
+	// can have cascades and checks.
 	// TODO(mgartner): Add support for WITH expressions inside UDF bodies.
-	planFn := b.buildRoutinePlanFn(udf.Params, udf.Body, false /* allowOuterWithRefs */)
+	params := udf.Params
+	if udf.PLpgSQL != nil {
+		params = udf.PLpgSQL.Vars
+	}
+	planFn := b.buildRoutinePlanFn(
+		params, udf.Body, false /* allowOuterWithRefs */, udf.PLpgSQL != nil, /* allowPostqueries */
+	)
 
 	// Enable stepping for volatile functions so that statements within the UDF
 	// see mutations made by the invoking statement and by previous executed
 	// statements.
 	enableStepping := udf.Volatility == volatility.Volatile
 
-	return tree.NewTypedRoutineExpr(
+	routine := tree.NewTypedRoutineExpr(
 		udf.Name,
 		args,
 		planFn,
 		len(udf.Body),
 		udf.Typ,
 		enableStepping,
-	), nil
+	)
+	if udf.PLpgSQL != nil {
+		routine.PLpgSQLBody = udf.PLpgSQL
+		routine.ParamNames = udf.PLpgSQL.ParamNames
+		routine.ParamTypes = make([]*types.T, len(udf.Params))
+		for i, param := range udf.Params {
+			routine.ParamTypes[i] = b.mem.Metadata().ColumnMeta(param).Type
+		}
+		routine.Trigger = udf.PLpgSQL.Trigger
+	}
+	return routine, nil
 }
 
 // buildRoutinePlanFn returns a tree.RoutinePlanFn that can plan the statements
-// in a routine that has one or more arguments.
+// in a routine that has one or more arguments. If allowPostqueries is true,
+// the plans can have cascades and checks, which must be run after the main
+// query of the plan.
 func (b *Builder) buildRoutinePlanFn(
-	params opt.ColList, stmts memo.RelListExpr, allowOuterWithRefs bool,
+	params opt.ColList, stmts memo.RelListExpr, allowOuterWithRefs, allowPostqueries bool,
 ) tree.RoutinePlanFn {
 	// argOrd returns the ordinal of the argument within the arguments list that
 	// can be substituted for each reference to the given function parameter
@@ -894,10 +917,10 @@ func (b *Builder) buildRoutinePlanFn(
 		if len(eb.subqueries) > 0 {
 			return nil, expectedLazyRoutineError("subquery")
 		}
-		if len(eb.cascades) > 0 {
+		if len(eb.cascades) > 0 && !allowPostqueries {
 			return nil, expectedLazyRoutineError("cascade")
 		}
-		if len(eb.checks) > 0 {
+		if len(eb.checks) > 0 && !allowPostqueries {
 			return nil, expectedLazyRoutineError("check")
 		}
 		return plan, nil
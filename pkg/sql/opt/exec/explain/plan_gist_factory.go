@@ -18,6 +18,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/geo/geoindex"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
@@ -32,11 +33,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
 func init() {
-	if numOperators != 61 {
+	if numOperators != 62 {
 		// This error occurs when an operator has been added or removed in
 		// pkg/sql/opt/exec/explain/factory.opt. If an operator is added at the
 		// end of factory.opt, simply adjust the hardcoded value above. If an
@@ -612,6 +614,26 @@ func (u *unknownTable) GetDatabaseID() descpb.ID {
 	return 0
 }
 
+// InheritedByCount is part of the cat.Table interface.
+func (u *unknownTable) InheritedByCount() int {
+	return 0
+}
+
+// InheritedBy is part of the cat.Table interface.
+func (u *unknownTable) InheritedBy(i int) cat.StableID {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (u *unknownTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (u *unknownTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 var _ cat.Table = &unknownTable{}
 
 // unknownTable implements the cat.Index interface and is used to represent
@@ -717,6 +739,14 @@ func (u *unknownIndex) GeoConfig() geoindex.Config {
 	return geoindex.Config{}
 }
 
+func (u *unknownIndex) InvertedColumnKind() catpb.InvertedIndexColumnKind {
+	return catpb.InvertedIndexColumnKind_DEFAULT
+}
+
+func (u *unknownIndex) VectorConfig() vector.IVFConfig {
+	return vector.IVFConfig{}
+}
+
 func (u *unknownIndex) Version() descpb.IndexDescriptorVersion {
 	return descpb.LatestIndexDescriptorVersion
 }
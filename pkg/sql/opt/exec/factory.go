@@ -152,6 +152,32 @@ type AggInfo struct {
 	// Filter is the index of the column, if any, which should be used as the
 	// FILTER condition for the aggregate. If there is no filter, Filter is -1.
 	Filter NodeColumnOrdinal
+
+	// UserDefined is set if the aggregate was created with CREATE AGGREGATE.
+	UserDefined *UserDefinedAggInfo
+}
+
+// UserDefinedAggInfo represents the information about an aggregate created
+// with CREATE AGGREGATE that must be passed through to the execution engine.
+type UserDefinedAggInfo struct {
+	// Transition computes the next state. It refers to the state as the
+	// IndexedVar with index 0 and to the arguments of the aggregate as the
+	// IndexedVars with indexes 1, 2, etc.
+	Transition tree.TypedExpr
+
+	// Final computes the result of the aggregate from the state, which it
+	// refers to as the IndexedVar with index 0.
+	Final tree.TypedExpr
+
+	// StateType is the type of the state.
+	StateType *types.T
+
+	// InitCond is the initial state of each group.
+	InitCond tree.Datum
+
+	// Strict is true if rows with a NULL argument are not passed to the
+	// transition function.
+	Strict bool
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -181,6 +207,10 @@ type WindowInfo struct {
 
 	// Ordering is the set of input columns to order on.
 	Ordering colinfo.ColumnOrdering
+
+	// UserDefinedAggs contains, for each of the Exprs, the information about
+	// the aggregate if it was created with CREATE AGGREGATE, or nil otherwise.
+	UserDefinedAggs []*UserDefinedAggInfo
 }
 
 // ExplainEnvData represents the data that's going to be displayed in EXPLAIN (env).
@@ -271,6 +301,35 @@ type InsertFastPathFKCheck struct {
 // relevant row.
 type MkErrFn func(tree.Datums) error
 
+// DeferrableConstraintViolation is the error generated by a MkErrFn for a
+// violation of a deferrable constraint. The execution engine uses it to decide
+// whether the violation must be reported right away, or whether the constraint
+// is to be checked again when the transaction commits.
+type DeferrableConstraintViolation struct {
+	// Err is the error that is reported if the constraint is not deferred.
+	Err error
+
+	// TableID is the ID of the table that owns the constraint.
+	TableID cat.StableID
+
+	// ConstraintName is the name of the violated constraint.
+	ConstraintName string
+
+	// Deferrability is the declared deferrability of the constraint.
+	Deferrability tree.ConstraintDeferrability
+
+	// KeyVals are the values of the constraint columns of the violating row.
+	// For a foreign key, they are ordered like the foreign key columns, and for
+	// a unique constraint, like the cat.UniqueConstraint columns.
+	KeyVals tree.Datums
+}
+
+// Error implements the error interface.
+func (e *DeferrableConstraintViolation) Error() string { return e.Err.Error() }
+
+// Unwrap returns the underlying error.
+func (e *DeferrableConstraintViolation) Unwrap() error { return e.Err }
+
 // ExplainFactory is an extension of Factory used when constructing a plan that
 // can be explained. It allows annotation of nodes with extra information.
 type ExplainFactory interface {
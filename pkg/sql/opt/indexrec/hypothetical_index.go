@@ -13,11 +13,13 @@ package indexrec
 import (
 	"github.com/cockroachdb/cockroach/pkg/geo/geoindex"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -227,6 +229,16 @@ func (hi *hypotheticalIndex) GeoConfig() geoindex.Config {
 	return geoindex.Config{}
 }
 
+// InvertedColumnKind is part of the cat.Index interface.
+func (hi *hypotheticalIndex) InvertedColumnKind() catpb.InvertedIndexColumnKind {
+	return catpb.InvertedIndexColumnKind_DEFAULT
+}
+
+// VectorConfig is part of the cat.Index interface.
+func (hi *hypotheticalIndex) VectorConfig() vector.IVFConfig {
+	return vector.IVFConfig{}
+}
+
 // Version is part of the cat.Index interface.
 func (hi *hypotheticalIndex) Version() descpb.IndexDescriptorVersion {
 	return descpb.LatestIndexDescriptorVersion
@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/errors"
 )
 
@@ -394,6 +395,10 @@ func (j *jsonOrArrayFilterPlanner) extractInvertedFilterConditionFromLeaf(
 		}
 	case *memo.OverlapsExpr:
 		invertedExpr = j.extractArrayOverlapsCondition(ctx, evalCtx, t.Left, t.Right)
+	case *memo.JsonPathExistsExpr:
+		invertedExpr = j.extractJSONPathCondition(ctx, evalCtx, t.Left, t.Right, false /* match */)
+	case *memo.JsonPathMatchExpr:
+		invertedExpr = j.extractJSONPathCondition(ctx, evalCtx, t.Left, t.Right, true /* match */)
 	}
 
 	if invertedExpr == nil {
@@ -505,6 +510,185 @@ func (j *jsonOrArrayFilterPlanner) extractJSONExistsCondition(
 	return inverted.NonInvertedColExpression{}
 }
 
+// maxLaxJSONPathKeys is the maximum number of keys in a lax mode jsonpath for
+// which an inverted expression is built. In lax mode, every step of the path
+// may implicitly unwrap an array, so the number of objects that need to be
+// looked up in the index doubles with every key.
+const maxLaxJSONPathKeys = 3
+
+// extractJSONPathCondition extracts an InvertedExpression representing an
+// inverted filter with the @? or @@ operators over the planner's inverted
+// index, based on the given left and right expression arguments. The match
+// parameter is true for @@ and false for @?. Returns an empty
+// InvertedExpression if no inverted filter could be extracted.
+//
+// Only index-friendly paths are supported: paths which compare a chain of keys
+// with a constant, such as '$.a.b ? (@ == 1)', '$.a ? (@.b == 1)' for @?, or
+// '$.a.b == 1' for @@. The resulting expression is never tight.
+func (j *jsonOrArrayFilterPlanner) extractJSONPathCondition(
+	ctx context.Context, evalCtx *eval.Context, left, right opt.ScalarExpr, match bool,
+) inverted.Expression {
+	if !isIndexColumn(j.tabID, j.index, left, j.computedColumns) || !memo.CanExtractConstDatum(right) {
+		return inverted.NonInvertedColExpression{}
+	}
+	path, ok := memo.ExtractConstDatum(right).(*tree.DJsonpath)
+	if !ok {
+		return inverted.NonInvertedColExpression{}
+	}
+	var keys []string
+	var val json.JSON
+	filterLevel := -1
+	if match {
+		keys, val, ok = jsonPathEqualityFromPredicate(path.Root, false /* current */, nil /* keys */)
+	} else {
+		keys, val, filterLevel, ok = jsonPathEqualityFromFilter(path.Root)
+	}
+	if !ok || (!path.Strict && len(keys) > maxLaxJSONPathKeys) {
+		return inverted.NonInvertedColExpression{}
+	}
+
+	var invertedExpr inverted.Expression
+	for _, obj := range buildJSONPathObjects(keys, val, !path.Strict /* lax */, filterLevel) {
+		expr := getInvertedExprForJSONOrArrayIndexForContaining(ctx, evalCtx, tree.NewDJSON(obj))
+		if invertedExpr == nil {
+			invertedExpr = expr
+		} else {
+			invertedExpr = inverted.Or(invertedExpr, expr)
+		}
+	}
+	// The path may contain arrays or objects which do not satisfy the equality
+	// at the expected level of nesting, so the original filter must always be
+	// applied.
+	invertedExpr.SetNotTight()
+	return invertedExpr
+}
+
+// isJSONPathBase returns whether n is the current item (@) if current is true,
+// or the root item ($) otherwise.
+func isJSONPathBase(n jsonpath.Node, current bool) bool {
+	if current {
+		_, ok := n.(*jsonpath.Current)
+		return ok
+	}
+	_, ok := n.(*jsonpath.Root)
+	return ok
+}
+
+// jsonPathKeys returns the keys of n if it is an accessor chain consisting only
+// of member accessors on the base item, or no keys if n is the base item
+// itself. See isJSONPathBase for the meaning of current. The keys are appended
+// to keys.
+func jsonPathKeys(n jsonpath.Node, current bool, keys []string) (_ []string, ok bool) {
+	if isJSONPathBase(n, current) {
+		return keys, true
+	}
+	a, ok := n.(*jsonpath.Accessor)
+	if !ok || !isJSONPathBase(a.Base, current) {
+		return nil, false
+	}
+	for _, op := range a.Chain {
+		k, ok := op.(*jsonpath.Key)
+		if !ok {
+			return nil, false
+		}
+		keys = append(keys, k.Name)
+	}
+	return keys, true
+}
+
+// jsonPathEqualityFromPredicate returns the keys and the constant value of a
+// jsonpath predicate of the form <keys> == <constant>. See isJSONPathBase for
+// the meaning of current. The keys are appended to keys.
+func jsonPathEqualityFromPredicate(
+	n jsonpath.Node, current bool, keys []string,
+) (_ []string, val json.JSON, ok bool) {
+	b, ok := n.(*jsonpath.Binary)
+	if !ok || b.Op != jsonpath.OpEq {
+		return nil, nil, false
+	}
+	operand, lit := b.Left, b.Right
+	if _, ok := lit.(*jsonpath.Literal); !ok {
+		operand, lit = lit, operand
+	}
+	l, ok := lit.(*jsonpath.Literal)
+	if !ok {
+		return nil, nil, false
+	}
+	if keys, ok = jsonPathKeys(operand, current, keys); !ok {
+		return nil, nil, false
+	}
+	return keys, l.Value, true
+}
+
+// jsonPathEqualityFromFilter returns the keys and the constant value of a
+// jsonpath of the form $.<keys> ? (@.<keys> == <constant>), along with the
+// number of keys before the filter.
+func jsonPathEqualityFromFilter(
+	n jsonpath.Node,
+) (keys []string, val json.JSON, filterLevel int, ok bool) {
+	a, ok := n.(*jsonpath.Accessor)
+	if !ok || len(a.Chain) == 0 || !isJSONPathBase(a.Base, false /* current */) {
+		return nil, nil, 0, false
+	}
+	filter, ok := a.Chain[len(a.Chain)-1].(*jsonpath.Filter)
+	if !ok {
+		return nil, nil, 0, false
+	}
+	for _, op := range a.Chain[:len(a.Chain)-1] {
+		k, ok := op.(*jsonpath.Key)
+		if !ok {
+			return nil, nil, 0, false
+		}
+		keys = append(keys, k.Name)
+	}
+	filterLevel = len(keys)
+	keys, val, ok = jsonPathEqualityFromPredicate(filter.Pred, true /* current */, keys)
+	return keys, val, filterLevel, ok
+}
+
+// buildJSONPathObjects constructs the JSON objects which a JSON value must
+// contain for the jsonpath equality between the given keys and val to be true.
+// The JSON value must contain at least one of the returned objects.
+//
+// In lax mode, each value along the path (including the value compared with
+// val) may be an array which is implicitly unwrapped, so objects are built with
+// each combination of wrapped values. For example, the keys {"a"} and val 1
+// result in the following objects in lax mode:
+//
+//	{"a": 1}, {"a": [1]}, [{"a": 1}], [{"a": [1]}]
+//
+// A filter unwraps its input and the predicate unwraps the current item again,
+// so the value at filterLevel (the number of keys before the filter, or -1 if
+// there is no filter) may be wrapped in up to two arrays.
+func buildJSONPathObjects(keys []string, val json.JSON, lax bool, filterLevel int) []json.JSON {
+	wrap := func(objs []json.JSON, level int) []json.JSON {
+		if !lax {
+			return objs
+		}
+		n := len(objs)
+		maxWraps := 1
+		if level == filterLevel {
+			maxWraps = 2
+		}
+		for i := 0; i < maxWraps; i++ {
+			for _, obj := range objs[len(objs)-n:] {
+				b := json.NewArrayBuilder(1)
+				b.Add(obj)
+				objs = append(objs, b.Build())
+			}
+		}
+		return objs
+	}
+	objs := wrap([]json.JSON{val}, len(keys))
+	for i := len(keys) - 1; i >= 0; i-- {
+		for k := range objs {
+			objs[k] = buildObject([]string{keys[i]}, objs[k])
+		}
+		objs = wrap(objs, i)
+	}
+	return objs
+}
+
 // extractJSONFetchValEqCondition extracts an InvertedExpression representing an
 // inverted filter over the planner's inverted index, based on equality between
 // a chain of fetch val expressions and a scalar expression. If an
@@ -750,6 +750,54 @@ func TestTryFilterJsonOrArrayIndex(t *testing.T) {
 			indexOrd: jsonOrd,
 			ok:       false,
 		},
+		{
+			// JSONPathExists with an equality filter is supported. It is never
+			// tight since the containment spans are a superset of the matching
+			// rows.
+			filters:          `j @? '$.a ? (@ == 1)'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           false,
+			remainingFilters: `j @? '$.a ? (@ == 1)'`,
+		},
+		{
+			// In strict mode a single containment object is built, so the span
+			// expression is unique.
+			filters:          `j @? 'strict $.a ? (@.b == "x")'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           true,
+			remainingFilters: `j @? 'strict $.a ? (@.b == "x")'`,
+		},
+		{
+			// JSONPathMatch with an equality predicate is supported.
+			filters:          `j @@ '$.a.b == "x"'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           false,
+			remainingFilters: `j @@ '$.a.b == "x"'`,
+		},
+		{
+			// JSONPathExists without an equality filter is not supported.
+			filters:  `j @? '$.a'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
+		{
+			// JSONPathExists with an inequality filter is not supported.
+			filters:  `j @? '$.a ? (@ > 1)'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
+		{
+			// JSONPathMatch with a non-equality predicate is not supported.
+			filters:  `j @@ '$.a[*] > 1'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
 		{
 			// Overlaps is supported for arrays.
 			// Overlaps with a single element array produces
@@ -81,6 +81,32 @@ func (t *trigramFilterPlanner) extractInvertedFilterConditionFromLeaf(
 		allMustMatch = false
 		// Similarity is commutative.
 		commutative = true
+	case *memo.FunctionExpr:
+		// The <%, %>, <<% and %>> operators are parsed into calls to the word
+		// similarity operator functions. A string must share at least one
+		// trigram with the constant to have a non-zero word similarity with it,
+		// so like %, we construct an OR out of the spans and filter the results
+		// further afterwards.
+		if !trigramWordSimilarityOps[e.Name] || len(e.Args) != 2 {
+			return inverted.NonInvertedColExpression{}, expr, nil
+		}
+		left, right = e.Args[0], e.Args[1]
+		allMustMatch = false
+		// The indexed column can be on either side of the operator, since
+		// either way it must share a trigram with the constant.
+		commutative = true
+	case *memo.LtExpr, *memo.LeExpr:
+		// A trigram distance below 1 implies a non-zero similarity, so the
+		// same reasoning as for % applies.
+		var ok bool
+		left, right, ok = extractTrigramDistanceArgs(e)
+		if !ok {
+			return inverted.NonInvertedColExpression{}, expr, nil
+		}
+		allMustMatch = false
+		// Trigram distance is commutative for the purposes of index
+		// acceleration.
+		commutative = true
 	default:
 		// Only the above types are supported.
 		return inverted.NonInvertedColExpression{}, expr, nil
@@ -119,3 +145,76 @@ func (t *trigramFilterPlanner) extractInvertedFilterConditionFromLeaf(
 	// the returned pre-filter state is nil.
 	return invertedExpr, remainingFilters, nil
 }
+
+// trigramWordSimilarityOps contains the names of the functions that implement
+// the trigram word similarity threshold operators.
+var trigramWordSimilarityOps = map[string]bool{
+	"word_similarity_op":                   true,
+	"word_similarity_commutator_op":        true,
+	"strict_word_similarity_op":            true,
+	"strict_word_similarity_commutator_op": true,
+}
+
+// trigramDistanceOps contains the names of the functions that implement the
+// trigram word similarity distance operators.
+var trigramDistanceOps = map[string]bool{
+	"similarity_dist":                           true,
+	"word_similarity_dist_op":                   true,
+	"word_similarity_dist_commutator_op":        true,
+	"strict_word_similarity_dist_op":            true,
+	"strict_word_similarity_dist_commutator_op": true,
+}
+
+// extractTrigramDistanceArgs returns the arguments of the trigram distance
+// operator in a comparison of the form:
+//
+//	left <-> right < bound
+//
+// where bound is a constant that is at most 1 (or less than 1, if the
+// comparison is inclusive). Such a comparison can only be satisfied by strings
+// that share at least one trigram. The word similarity distance operators are
+// supported as well. ok is false if the comparison does not have this form.
+func extractTrigramDistanceArgs(cmp opt.ScalarExpr) (left, right opt.ScalarExpr, ok bool) {
+	var dist, bound opt.ScalarExpr
+	var inclusive bool
+	switch t := cmp.(type) {
+	case *memo.LtExpr:
+		dist, bound = t.Left, t.Right
+	case *memo.LeExpr:
+		dist, bound, inclusive = t.Left, t.Right, true
+	default:
+		return nil, nil, false
+	}
+	if !memo.CanExtractConstDatum(bound) {
+		return nil, nil, false
+	}
+	var f float64
+	switch d := memo.ExtractConstDatum(bound).(type) {
+	case *tree.DFloat:
+		f = float64(*d)
+	case *tree.DDecimal:
+		var err error
+		if f, err = d.Float64(); err != nil {
+			return nil, nil, false
+		}
+	case *tree.DInt:
+		f = float64(*d)
+	default:
+		return nil, nil, false
+	}
+	if f > 1 || (inclusive && f == 1) {
+		return nil, nil, false
+	}
+	switch t := dist.(type) {
+	case *memo.DistanceExpr:
+		left, right = t.Left, t.Right
+	case *memo.FunctionExpr:
+		if !trigramDistanceOps[t.Name] || len(t.Args) != 2 {
+			return nil, nil, false
+		}
+		left, right = t.Args[0], t.Args[1]
+	default:
+		return nil, nil, false
+	}
+	return left, right, true
+}
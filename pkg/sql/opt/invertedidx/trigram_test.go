@@ -96,6 +96,24 @@ func TestTryFilterTrigram(t *testing.T) {
 		{filters: "s = 'lkjsdlkj'", ok: true, unique: false},
 		{filters: "s = 'lkj'", ok: true, unique: true},
 		{filters: "s = 'lkj' OR s LIKE 'blah'", ok: true, unique: false},
+
+		// Word similarity queries.
+		{filters: "'lkj' <% s", ok: true, unique: false},
+		{filters: "s %> 'lkj'", ok: true, unique: false},
+		{filters: "'lkj' <<% s", ok: true, unique: false},
+		{filters: "s %>> 'lkj'", ok: true, unique: false},
+		{filters: "'lkj' <% s OR s LIKE 'blah'", ok: true, unique: false},
+		{filters: "word_similarity(s, 'lkj') > 0.5", ok: false},
+
+		// Distance queries.
+		{filters: "s <-> 'lkj' < 0.5", ok: true, unique: false},
+		{filters: "s <-> 'lkj' <= 0.5", ok: true, unique: false},
+		{filters: "'lkj' <-> s < 1", ok: true, unique: false},
+		{filters: "s <-> 'lkj' <= 1", ok: false},
+		{filters: "s <-> 'lkj' < 2", ok: false},
+		{filters: "s <-> 'lkj' > 0.5", ok: false},
+		{filters: "'lkj' <<-> s < 0.5", ok: true, unique: false},
+		{filters: "s <->>> 'lkj' < 0.5", ok: true, unique: false},
 	}
 
 	for _, tc := range testCases {
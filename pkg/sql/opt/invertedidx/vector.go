@@ -0,0 +1,53 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package invertedidx
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
+)
+
+// VectorIndexKindForDistance returns the kind of vector inverted index that
+// can be used to find the nearest neighbors of a vector by the given distance
+// operator. ok=false is returned if the operator is not a vector distance.
+func VectorIndexKindForDistance(op opt.Operator) (_ catpb.InvertedIndexColumnKind, ok bool) {
+	switch op {
+	case opt.DistanceOp:
+		return catpb.InvertedIndexColumnKind_VECTOR_L2, true
+	case opt.CosDistanceOp:
+		return catpb.InvertedIndexColumnKind_VECTOR_COSINE, true
+	case opt.NegInnerProductOp:
+		return catpb.InvertedIndexColumnKind_VECTOR_IP, true
+	}
+	return catpb.InvertedIndexColumnKind_DEFAULT, false
+}
+
+// VectorSearchSpans returns the spans of a vector inverted index that must be
+// scanned to find the approximate nearest neighbors of the query vector, when
+// the index partitions its vectors into lists with the given configuration.
+// The spans cover the given number of lists whose centroids are the closest to
+// the query vector.
+func VectorSearchSpans(
+	query vector.T, config vector.IVFConfig, probes int,
+) (inverted.Spans, error) {
+	probed, err := config.Probes(query, probes)
+	if err != nil {
+		return nil, err
+	}
+	spans := make(inverted.Spans, len(probed))
+	for i, list := range probed {
+		spans[i] = inverted.MakeSingleValSpan(rowenc.EncodeVectorInvertedIndexListKey(list))
+	}
+	return spans, nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -1149,7 +1150,8 @@ func ExprIsNeverNull(e opt.ScalarExpr, notNullCols opt.ColSet) bool {
 		*NotRegMatchExpr, *RegIMatchExpr, *NotRegIMatchExpr, *ContainsExpr, *ContainedByExpr, *JsonExistsExpr,
 		*JsonAllExistsExpr, *JsonSomeExistsExpr, *AnyScalarExpr, *BitandExpr, *BitorExpr, *BitxorExpr,
 		*PlusExpr, *MinusExpr, *MultExpr, *DivExpr, *FloorDivExpr, *ModExpr, *PowExpr, *ConcatExpr,
-		*LShiftExpr, *RShiftExpr, *WhenExpr:
+		*LShiftExpr, *RShiftExpr, *DistanceExpr, *CosDistanceExpr, *NegInnerProductExpr,
+		*WhenExpr:
 		return ExprIsNeverNull(t.Child(0).(opt.ScalarExpr), notNullCols) &&
 			ExprIsNeverNull(t.Child(1).(opt.ScalarExpr), notNullCols)
 
@@ -1286,6 +1288,57 @@ type CascadeBuilder interface {
 	) (RelExpr, error)
 }
 
+// PLpgSQLBody is the body of a user-defined function written in PL/pgSQL.
+// Each expression and SQL statement embedded in the body is built into a
+// relational expression in the Body of the UDF, like the statements of a SQL
+// function. The control flow of the body is executed during execution, which
+// plans and runs the built statements as they are reached.
+type PLpgSQLBody struct {
+	// Block is the top-level block of the function body.
+	Block *plpgsqltree.Block
+
+	// ParamNames contains the names of the function parameters, in order. The
+	// name of an unnamed parameter is empty; it can only be referenced with a
+	// placeholder like $1.
+	ParamNames []string
+
+	// Trigger is true if the function is executed by a trigger. The parameters
+	// are then the special variables NEW, OLD, TG_NAME, etc.
+	Trigger bool
+
+	// OutParams are the ordinals of the OUT and INOUT parameters of a
+	// procedure. The procedure returns a row with their final values.
+	OutParams []int
+
+	// Vars contains a column for each variable of the body. The first columns
+	// are the parameters of the function, followed by the FOUND variable. The
+	// built statements refer to variables through these columns, which are
+	// replaced with the current values of the variables when a statement is
+	// planned.
+	Vars opt.ColList
+
+	// VarOrds maps the declarations and statements that introduce variables to
+	// the ordinal in Vars of the variable they introduce. The keys are
+	// *plpgsqltree.Declaration for declared variables, *plpgsqltree.ForInt for
+	// loop variables, and *plpgsqltree.Exception for the SQLSTATE variable of
+	// an exception handler, which is followed by its SQLERRM variable.
+	VarOrds map[interface{}]int
+
+	// Stmts maps each embedded expression and SQL statement to the index of the
+	// statement built for it in the Body of the UDF. Statements that cannot be
+	// executed, like COMMIT, are not built.
+	Stmts map[PLpgSQLStmtKey]int
+}
+
+// PLpgSQLStmtKey identifies an expression or SQL statement embedded in the
+// body of a PL/pgSQL function. Stmt is the PL/pgSQL statement or declaration
+// that contains it, and Ord is its position in Stmt, for statements that
+// contain more than one expression.
+type PLpgSQLStmtKey struct {
+	Stmt interface{}
+	Ord  int
+}
+
 // GroupingOrderType is the grouping column order type for group by and distinct
 // operations in the memo.
 type GroupingOrderType int
@@ -1568,7 +1568,7 @@ func (f *ExprFmtCtx) formatDependencies(
 		n.Child(f.Buffer.String())
 	}
 	for _, typ := range f.Memo.Metadata().AllUserDefinedTypes() {
-		typeID := catid.UserDefinedOIDToID(typ.Oid())
+		typeID := catid.UserDefinedOIDToID(typ.UserDefinedOID())
 		if typeDeps.Contains(int(typeID)) {
 			n.Child(typ.Name())
 		}
@@ -1652,6 +1652,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *FunctionPrivate:
 		fmt.Fprintf(f.Buffer, " %s", t.Name)
 
+	case *UserDefinedAggPrivate:
+		fmt.Fprintf(f.Buffer, " %s", t.Name)
+
 	case *WindowsItemPrivate:
 		fmt.Fprintf(f.Buffer, " frame=%q", &t.Frame)
 
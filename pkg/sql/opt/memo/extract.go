@@ -133,6 +133,13 @@ func ExtractAggInputColumns(e opt.ScalarExpr) opt.ColSet {
 		panic(errors.AssertionFailedf("not an Aggregate"))
 	}
 
+	if udAgg, ok := e.(*UserDefinedAggExpr); ok {
+		for _, arg := range udAgg.Args {
+			res.Add(arg.(*VariableExpr).Col)
+		}
+		return res
+	}
+
 	for i, n := 0, e.ChildCount(); i < n; i++ {
 		if variable, ok := e.Child(i).(*VariableExpr); ok {
 			res.Add(variable.Col)
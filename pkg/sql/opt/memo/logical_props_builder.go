@@ -1525,6 +1525,10 @@ func (b *logicalPropsBuilder) buildCreateFunctionProps(
 	BuildSharedProps(cf, &rel.Shared, b.evalCtx)
 }
 
+func (b *logicalPropsBuilder) buildCallProps(c *CallExpr, rel *props.Relational) {
+	BuildSharedProps(c, &rel.Shared, b.evalCtx)
+}
+
 func (b *logicalPropsBuilder) buildFiltersItemProps(item *FiltersItem, scalar *props.Scalar) {
 	BuildSharedProps(item.Condition, &scalar.Shared, b.evalCtx)
 
@@ -1861,6 +1865,18 @@ func MakeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 			continue
 		}
 
+		if unique.IsExclusion() {
+			// Exclusion constraints do not prevent rows from having equal values
+			// for the constrained columns, so they do not form keys.
+			continue
+		}
+
+		if unique.Deferrability() != tree.ConstraintNotDeferrable {
+			// Deferrable constraints can be violated until the transaction
+			// commits, so they do not form keys.
+			continue
+		}
+
 		// If any of the columns are nullable, add a lax key FD. Otherwise, add a
 		// strict key.
 		var keyCols opt.ColSet
@@ -312,6 +312,18 @@ func TestMemoIsStale(t *testing.T) {
 	evalCtx.SessionData().OptimizerUseLimitOrderingForStreamingGroupBy = false
 	notStale()
 
+	// Stale vector search probes.
+	evalCtx.SessionData().VectorSearchProbes = 20
+	stale()
+	evalCtx.SessionData().VectorSearchProbes = 0
+	notStale()
+
+	// Stale allow materialized view mutations.
+	evalCtx.SessionData().AllowMaterializedViewMutations = true
+	stale()
+	evalCtx.SessionData().AllowMaterializedViewMutations = false
+	notStale()
+
 	// Stale testing_optimizer_random_seed.
 	evalCtx.SessionData().TestingOptimizerRandomSeed = 100
 	stale()
@@ -218,7 +218,7 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 		}
 		for i := range from.userDefinedTypesSlice {
 			typ := from.userDefinedTypesSlice[i]
-			md.userDefinedTypes[typ.Oid()] = struct{}{}
+			md.userDefinedTypes[typ.UserDefinedOID()] = struct{}{}
 			md.userDefinedTypesSlice = append(md.userDefinedTypesSlice, typ)
 		}
 	}
@@ -367,8 +367,8 @@ func (md *Metadata) AddUserDefinedType(typ *types.T) {
 	if md.userDefinedTypes == nil {
 		md.userDefinedTypes = make(map[oid.Oid]struct{})
 	}
-	if _, ok := md.userDefinedTypes[typ.Oid()]; !ok {
-		md.userDefinedTypes[typ.Oid()] = struct{}{}
+	if _, ok := md.userDefinedTypes[typ.UserDefinedOID()]; !ok {
+		md.userDefinedTypes[typ.UserDefinedOID()] = struct{}{}
 		md.userDefinedTypesSlice = append(md.userDefinedTypesSlice, typ)
 	}
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
 )
@@ -411,6 +412,10 @@ func (c *CustomFuncs) InlineConstVar(f memo.FiltersExpr) memo.FiltersExpr {
 //     leak-proof.
 //  2. It has a single statement.
 //  3. Its arguments are non-volatile expressions.
+//  4. It does not return VOID. The result of the body of a VOID function is
+//     discarded, which a subquery cannot express.
+//  5. It is written in SQL. The body of a PL/pgSQL function is interpreted
+//     during execution and has no relational expression to inline.
 //
 // UDFs with mutations (INSERT, UPDATE, UPSERT, DELETE) cannot be inlined, but
 // we do not need an explicit check for this because immutable UDFs cannot
@@ -423,7 +428,8 @@ func (c *CustomFuncs) InlineConstVar(f memo.FiltersExpr) memo.FiltersExpr {
 // able to inline volatile UDFs. We must take care not to inline UDFs with
 // volatile arguments used more than once in the function body.
 func (c *CustomFuncs) IsInlinableUDF(args memo.ScalarListExpr, udfp *memo.UDFPrivate) bool {
-	if udfp.Volatility == volatility.Volatile || len(udfp.Body) > 1 {
+	if udfp.Volatility == volatility.Volatile || len(udfp.Body) > 1 ||
+		udfp.Typ.Family() == types.VoidFamily || udfp.PLpgSQL != nil {
 		return false
 	}
 	for i := range args {
@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 )
@@ -242,6 +243,25 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 		}
 	}
 
+	// AFTER ROW triggers read the old values of all visible columns from the
+	// buffered mutation input.
+	for i, n := 0, tabMeta.Table.TriggerCount(); i < n; i++ {
+		trig := tabMeta.Table.Trigger(i)
+		if trig.ActionTime() != tree.TriggerActionTimeAfter || !trig.ForEachRow() {
+			continue
+		}
+		if (op == opt.DeleteOp && trig.HasEvent(tree.TriggerEventDelete)) ||
+			(op != opt.DeleteOp && trig.HasEvent(tree.TriggerEventUpdate)) {
+			for j, m := 0, tabMeta.Table.ColumnCount(); j < m; j++ {
+				col := tabMeta.Table.Column(j)
+				if col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible {
+					cols.Add(tabMeta.MetaID.ColumnID(j))
+				}
+			}
+			break
+		}
+	}
+
 	return cols
 }
 
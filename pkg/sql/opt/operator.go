@@ -146,28 +146,33 @@ var ComparisonOpReverseMap = map[Operator]treecmp.ComparisonOperatorSymbol{
 	BBoxCoversOp:     treecmp.RegMatch,
 	BBoxIntersectsOp: treecmp.Overlaps,
 	TSMatchesOp:      treecmp.TSMatches,
+	JsonPathExistsOp: treecmp.JSONPathExists,
+	JsonPathMatchOp:  treecmp.TSMatches,
 }
 
 // BinaryOpReverseMap maps from an optimizer operator type to a semantic tree
 // binary operator type.
 var BinaryOpReverseMap = map[Operator]treebin.BinaryOperatorSymbol{
-	BitandOp:        treebin.Bitand,
-	BitorOp:         treebin.Bitor,
-	BitxorOp:        treebin.Bitxor,
-	PlusOp:          treebin.Plus,
-	MinusOp:         treebin.Minus,
-	MultOp:          treebin.Mult,
-	DivOp:           treebin.Div,
-	FloorDivOp:      treebin.FloorDiv,
-	ModOp:           treebin.Mod,
-	PowOp:           treebin.Pow,
-	ConcatOp:        treebin.Concat,
-	LShiftOp:        treebin.LShift,
-	RShiftOp:        treebin.RShift,
-	FetchValOp:      treebin.JSONFetchVal,
-	FetchTextOp:     treebin.JSONFetchText,
-	FetchValPathOp:  treebin.JSONFetchValPath,
-	FetchTextPathOp: treebin.JSONFetchTextPath,
+	BitandOp:          treebin.Bitand,
+	BitorOp:           treebin.Bitor,
+	BitxorOp:          treebin.Bitxor,
+	PlusOp:            treebin.Plus,
+	MinusOp:           treebin.Minus,
+	MultOp:            treebin.Mult,
+	DivOp:             treebin.Div,
+	FloorDivOp:        treebin.FloorDiv,
+	ModOp:             treebin.Mod,
+	PowOp:             treebin.Pow,
+	ConcatOp:          treebin.Concat,
+	LShiftOp:          treebin.LShift,
+	RShiftOp:          treebin.RShift,
+	FetchValOp:        treebin.JSONFetchVal,
+	FetchTextOp:       treebin.JSONFetchText,
+	FetchValPathOp:    treebin.JSONFetchValPath,
+	FetchTextPathOp:   treebin.JSONFetchTextPath,
+	DistanceOp:        treebin.Distance,
+	CosDistanceOp:     treebin.CosDistance,
+	NegInnerProductOp: treebin.NegInnerProduct,
 }
 
 // UnaryOpReverseMap maps from an optimizer operator type to a semantic tree
@@ -228,6 +233,10 @@ var AggregateOpReverseMap = map[Operator]string{
 	STUnionOp:             "st_union",
 	STCollectOp:           "st_collect",
 	STExtentOp:            "st_extent",
+
+	STClusterIntersectingOp: "st_clusterintersecting",
+	STClusterWithinOp:       "st_clusterwithin",
+	STPolygonizeOp:          "st_polygonize",
 }
 
 // WindowOpReverseMap maps from an optimizer operator type to the name of a
@@ -244,6 +253,9 @@ var WindowOpReverseMap = map[Operator]string{
 	FirstValueOp:  "first_value",
 	LastValueOp:   "last_value",
 	NthValueOp:    "nth_value",
+
+	STClusterDBSCANOp: "st_clusterdbscan",
+	STClusterKMeansOp: "st_clusterkmeans",
 }
 
 // NegateOpMap maps from a comparison operator type to its negated operator
@@ -279,7 +291,8 @@ func ScalarOperatorTransmitsNulls(op Operator) bool {
 	case BitandOp, BitorOp, BitxorOp, PlusOp, MinusOp, MultOp, DivOp, FloorDivOp,
 		ModOp, PowOp, EqOp, NeOp, LtOp, GtOp, LeOp, GeOp, LikeOp, NotLikeOp, ILikeOp,
 		NotILikeOp, SimilarToOp, NotSimilarToOp, RegMatchOp, NotRegMatchOp, RegIMatchOp,
-		NotRegIMatchOp, ConstOp, BBoxCoversOp, BBoxIntersectsOp:
+		NotRegIMatchOp, ConstOp, BBoxCoversOp, BBoxIntersectsOp, DistanceOp, CosDistanceOp,
+		NegInnerProductOp:
 		return true
 
 	default:
@@ -326,11 +339,12 @@ func AggregateIgnoresNulls(op Operator) bool {
 		PercentileContOp, STMakeLineOp, STCollectOp, STExtentOp, STUnionOp, StdDevPopOp,
 		VarPopOp, CovarPopOp, CovarSampOp, RegressionAvgXOp, RegressionAvgYOp,
 		RegressionInterceptOp, RegressionR2Op, RegressionSlopeOp, RegressionSXXOp,
-		RegressionSXYOp, RegressionSYYOp, RegressionCountOp:
+		RegressionSXYOp, RegressionSYYOp, RegressionCountOp, STClusterIntersectingOp,
+		STClusterWithinOp, STPolygonizeOp:
 		return true
 
 	case ArrayAggOp, ConcatAggOp, ConstAggOp, CountRowsOp, FirstAggOp, JsonAggOp,
-		JsonbAggOp, JsonObjectAggOp, JsonbObjectAggOp:
+		JsonbAggOp, JsonObjectAggOp, JsonbObjectAggOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -352,10 +366,11 @@ func AggregateIsNullOnEmpty(op Operator) bool {
 		JsonObjectAggOp, JsonbObjectAggOp, StdDevPopOp, STCollectOp, STExtentOp, STUnionOp,
 		VarPopOp, CovarPopOp, CovarSampOp, RegressionAvgXOp, RegressionAvgYOp,
 		RegressionInterceptOp, RegressionR2Op, RegressionSlopeOp, RegressionSXXOp,
-		RegressionSXYOp, RegressionSYYOp:
+		RegressionSXYOp, RegressionSYYOp, STClusterIntersectingOp, STClusterWithinOp,
+		STPolygonizeOp:
 		return true
 
-	case CountOp, CountRowsOp, RegressionCountOp:
+	case CountOp, CountRowsOp, RegressionCountOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -380,11 +395,12 @@ func AggregateIsNeverNullOnNonNullInput(op Operator) bool {
 		StringAggOp, SumOp, SumIntOp, XorAggOp, PercentileDiscOp, PercentileContOp,
 		JsonObjectAggOp, JsonbObjectAggOp, StdDevPopOp, STCollectOp, STUnionOp,
 		VarPopOp, CovarPopOp, RegressionAvgXOp, RegressionAvgYOp, RegressionSXXOp,
-		RegressionSXYOp, RegressionSYYOp, RegressionCountOp:
+		RegressionSXYOp, RegressionSYYOp, RegressionCountOp, STClusterIntersectingOp,
+		STClusterWithinOp, STPolygonizeOp:
 		return true
 
 	case VarianceOp, StdDevOp, CorrOp, CovarSampOp, RegressionInterceptOp,
-		RegressionR2Op, RegressionSlopeOp, STExtentOp, STMakeLineOp:
+		RegressionR2Op, RegressionSlopeOp, STExtentOp, STMakeLineOp, UserDefinedAggOp:
 		// These aggregations can return NULL even with non-null input values.
 		return false
 
@@ -435,7 +451,8 @@ func AggregatesCanMerge(inner, outer Operator) bool {
 		SqrDiffOp, STCollectOp, StdDevOp, StringAggOp, VarianceOp, StdDevPopOp,
 		VarPopOp, CovarPopOp, CovarSampOp, RegressionAvgXOp, RegressionAvgYOp,
 		RegressionInterceptOp, RegressionR2Op, RegressionSlopeOp, RegressionSXXOp,
-		RegressionSXYOp, RegressionSYYOp, RegressionCountOp:
+		RegressionSXYOp, RegressionSYYOp, RegressionCountOp, STClusterIntersectingOp,
+		STClusterWithinOp, STPolygonizeOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -457,7 +474,8 @@ func AggregateIgnoresDuplicates(op Operator) bool {
 		VarPopOp, JsonObjectAggOp, JsonbObjectAggOp, STCollectOp, CovarPopOp,
 		CovarSampOp, RegressionAvgXOp, RegressionAvgYOp, RegressionInterceptOp,
 		RegressionR2Op, RegressionSlopeOp, RegressionSXXOp, RegressionSXYOp,
-		RegressionSYYOp, RegressionCountOp:
+		RegressionSYYOp, RegressionCountOp, STClusterIntersectingOp, STClusterWithinOp,
+		STPolygonizeOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -153,6 +153,10 @@ type Builder struct {
 	// (without ON CONFLICT) or false otherwise. All mutated tables will have an
 	// entry in the map.
 	areAllTableMutationsSimpleInserts map[cat.StableID]bool
+
+	// buildingPLpgSQLFuncs contains the OIDs of the PL/pgSQL functions whose
+	// bodies are currently being built.
+	buildingPLpgSQLFuncs map[oid.Oid]struct{}
 }
 
 // New creates a new Builder structure initialized with the given
@@ -282,7 +286,7 @@ func (b *Builder) buildStmt(
 	if b.insideViewDef {
 		// A blocklist of statements that can't be used from inside a view.
 		switch stmt := stmt.(type) {
-		case *tree.Delete, *tree.Insert, *tree.Update, *tree.CreateTable, *tree.CreateView,
+		case *tree.Delete, *tree.Insert, *tree.Update, *tree.Merge, *tree.CreateTable, *tree.CreateView,
 			*tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
 			*tree.ControlJobs, *tree.ControlSchedules, *tree.CancelQueries, *tree.CancelSessions,
 			*tree.CreateFunction:
@@ -324,6 +328,17 @@ func (b *Builder) buildStmt(
 			return b.buildUpdate(stmt, inScope)
 		})
 
+	case *tree.Merge:
+		// MERGE does not support a RETURNING clause, so it cannot be used as a
+		// data source.
+		if !inScope.atRoot {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"MERGE can only be used as a top-level statement"))
+		}
+		return b.processWiths(stmt.With, inScope, func(inScope *scope) *scope {
+			return b.buildMerge(stmt, inScope)
+		})
+
 	case *tree.CreateTable:
 		return b.buildCreateTable(stmt, inScope)
 
@@ -333,6 +348,9 @@ func (b *Builder) buildStmt(
 	case *tree.CreateFunction:
 		return b.buildCreateFunction(stmt, inScope)
 
+	case *tree.Call:
+		return b.buildCall(stmt, inScope)
+
 	case *tree.Explain:
 		return b.buildExplain(stmt, inScope)
 
@@ -0,0 +1,72 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// buildCall builds a CALL statement. The procedure invocation is built as a
+// UDF expression and wrapped in a Call expression, which discards the result.
+// A procedure with OUT or INOUT parameters returns a row with their values,
+// so it is instead projected over a single row, and the CALL returns a column
+// for each parameter.
+func (b *Builder) buildCall(c *tree.Call, inScope *scope) (outScope *scope) {
+	typedExpr := inScope.resolveType(c.Proc, types.Any)
+	f, ok := typedExpr.(*tree.FuncExpr)
+	if !ok {
+		panic(errors.AssertionFailedf("expected FuncExpr, got %T", typedExpr))
+	}
+
+	def, err := f.Func.Resolve(b.ctx, b.semaCtx.SearchPath, b.semaCtx.FunctionResolver)
+	if err != nil {
+		panic(err)
+	}
+	if !f.ResolvedOverload().IsProcedure {
+		panic(errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s() is not a procedure", def.Name),
+			"To call a function, use SELECT.",
+		))
+	}
+
+	proc := b.buildUDF(f, def, inScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+
+	outScope = b.allocScope()
+	typ := proc.DataType()
+	if typ.Family() != types.TupleFamily {
+		outScope.expr = b.factory.ConstructCall(proc)
+		return outScope
+	}
+
+	rowScope := b.allocScope()
+	rowCol := b.synthesizeColumn(rowScope, scopeColName(""), typ, nil /* expr */, proc)
+	input := b.factory.ConstructValues(memo.ScalarListWithEmptyTuple, &memo.ValuesPrivate{
+		Cols: opt.ColList{},
+		ID:   b.factory.Metadata().NextUniqueID(),
+	})
+	rowScope.expr = b.constructProject(input, rowScope.cols)
+	for i, label := range typ.TupleLabels() {
+		field := b.factory.ConstructColumnAccess(
+			b.factory.ConstructVariable(rowCol.id), memo.TupleOrdinal(i),
+		)
+		b.synthesizeColumn(
+			outScope, scopeColName(tree.Name(label)), typ.TupleContents()[i], nil /* expr */, field,
+		)
+	}
+	outScope.expr = b.constructProject(rowScope.expr, outScope.cols)
+	return outScope
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -74,6 +75,7 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	funcBodyFound := false
 	languageFound := false
 	var funcBodyStr string
+	var language tree.FunctionLanguage
 	for _, option := range cf.Options {
 		switch opt := option.(type) {
 		case tree.FunctionBodyStr:
@@ -81,6 +83,12 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 			funcBodyStr = string(opt)
 		case tree.FunctionLanguage:
 			languageFound = true
+			language = opt
+		case tree.FunctionVolatility, tree.FunctionLeakproof, tree.FunctionNullInputBehavior:
+			if cf.IsProcedure {
+				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"invalid attribute in procedure definition: %s", tree.AsString(opt)))
+			}
 		}
 	}
 
@@ -90,7 +98,6 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	if !languageFound {
 		panic(pgerror.New(pgcode.InvalidFunctionDefinition, "no language specified"))
 	}
-
 	// Track the dependencies in the arguments, return type, and statements in
 	// the function body.
 	var deps opt.SchemaDeps
@@ -100,16 +107,48 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	// named parameters to the scope so that references to them in the body can
 	// be resolved.
 	bodyScope := b.allocScope()
+	hasPolymorphicParam := false
+	// outContents are the types of the OUT and INOUT parameters of a
+	// procedure, whose values are the result of the procedure.
+	var outContents []*types.T
 	for i := range cf.Params {
 		param := &cf.Params[i]
 		typ, err := tree.ResolveType(b.ctx, param.Type, b.semaCtx.TypeResolver)
 		if err != nil {
 			panic(err)
 		}
+		if param.Class == tree.FunctionParamOut || param.Class == tree.FunctionParamInOut {
+			if !cf.IsProcedure {
+				panic(unimplemented.New("function OUT parameters",
+					"OUT and INOUT parameters are only supported in procedures"))
+			}
+			if param.Class == tree.FunctionParamOut && param.DefaultVal != nil {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+					"only input parameters can have default values"))
+			}
+			outContents = append(outContents, typ)
+		}
+		if param.Class == tree.FunctionParamVariadic {
+			if i != len(cf.Params)-1 {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+					"VARIADIC parameter must be the last input parameter"))
+			}
+			if typ.Family() != types.ArrayFamily {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+					"VARIADIC parameter must be an array"))
+			}
+		}
 
-		// Add the parameter to the base scope of the body.
+		// Add the parameter to the base scope of the body. The types of
+		// polymorphic parameters are not known until the function is called, so
+		// they are typed as unknown while the body is validated.
+		colTyp := typ
+		if tree.IsPolymorphicType(typ) {
+			hasPolymorphicParam = true
+			colTyp = tree.InstantiatePolymorphicType(typ, types.Unknown)
+		}
 		paramColName := funcParamColName(param.Name, i)
-		col := b.synthesizeColumn(bodyScope, paramColName, typ, nil /* expr */, nil /* scalar */)
+		col := b.synthesizeColumn(bodyScope, paramColName, colTyp, nil /* expr */, nil /* scalar */)
 		col.setParamOrd(i)
 
 		// Collect the user defined type dependencies.
@@ -126,6 +165,46 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	typedesc.GetTypeDescriptorClosure(funcReturnType).ForEach(func(id descpb.ID) {
 		typeDeps.Add(int(id))
 	})
+	if tree.IsPolymorphicType(funcReturnType) && !hasPolymorphicParam {
+		panic(errors.WithDetailf(
+			pgerror.New(pgcode.InvalidFunctionDefinition, "cannot determine result data type"),
+			"A result of type %s requires at least one input of type anyelement or anyarray.",
+			polymorphicTypeName(funcReturnType),
+		))
+	}
+
+	if funcReturnType.Identical(types.Trigger) {
+		if language != tree.FunctionLangPLpgSQL {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"SQL functions cannot return type trigger"))
+		}
+		if len(cf.Params) > 0 {
+			panic(errors.WithHint(
+				pgerror.New(pgcode.InvalidFunctionDefinition,
+					"trigger functions cannot have declared arguments"),
+				"The arguments of the trigger can be accessed through TG_NARGS and TG_ARGV instead.",
+			))
+		}
+	}
+
+	// The body of a PL/pgSQL function is validated, but its statements are not
+	// built until the function is executed. The body is stored as written.
+	if language == tree.FunctionLangPLpgSQL {
+		block, err := plpgsqlparser.Parse(funcBodyStr)
+		if err != nil {
+			panic(err)
+		}
+		typeDeps.UnionWith(b.validatePLpgSQLBody(block, cf.Params, funcReturnType, cf.IsProcedure))
+		outScope = b.allocScope()
+		outScope.expr = b.factory.ConstructCreateFunction(
+			&memo.CreateFunctionPrivate{
+				Schema:   schID,
+				Syntax:   cf,
+				TypeDeps: typeDeps,
+			},
+		)
+		return outScope
+	}
 
 	// Parse the function body.
 	stmts, err := parser.Parse(funcBodyStr)
@@ -136,6 +215,13 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	// Validate each statement and collect the dependencies.
 	fmtCtx := tree.NewFmtCtx(tree.FmtSimple)
 	for i, stmt := range stmts {
+		if cf.IsProcedure {
+			switch stmt.AST.(type) {
+			case *tree.CommitTransaction, *tree.RollbackTransaction:
+				panic(unimplemented.NewWithIssue(17511,
+					"transaction control statements in procedures are not yet supported"))
+			}
+		}
 		stmtScope := b.buildStmt(stmts[i].AST, nil /* desiredTypes */, bodyScope)
 
 		// Format the statements with qualified datasource names.
@@ -147,7 +233,14 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 			// TODO(mgartner): stmtScope.cols does not describe the result
 			// columns of the statement. We should use physical.Presentation
 			// instead.
-			err := validateReturnType(funcReturnType, stmtScope.cols)
+			var err error
+			if outContents != nil {
+				// The result of a procedure with OUT or INOUT parameters is the
+				// row of their values.
+				err = validateReturnRow(types.MakeTuple(outContents), stmtScope.cols)
+			} else {
+				err = validateReturnType(funcReturnType, stmtScope.cols)
+			}
 			if err != nil {
 				panic(err)
 			}
@@ -180,6 +273,15 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	return outScope
 }
 
+// polymorphicTypeName returns the name of the polymorphic type typ as it is
+// written in SQL.
+func polymorphicTypeName(typ *types.T) string {
+	if typ.Family() == types.ArrayFamily {
+		return "anyarray"
+	}
+	return "anyelement"
+}
+
 func formatFuncBodyStmt(fmtCtx *tree.FmtCtx, ast tree.Statement, newLine bool) {
 	if newLine {
 		fmtCtx.WriteString("\n")
@@ -229,7 +331,12 @@ func validateReturnType(expected *types.T, cols []scopeColumn) error {
 			pgcode.InvalidFunctionDefinition,
 		)
 	}
+	return validateReturnRow(expected, cols)
+}
 
+// validateReturnRow validates that the columns returned by the last statement
+// of a routine match the contents of the expected tuple type.
+func validateReturnRow(expected *types.T, cols []scopeColumn) error {
 	i := 0
 	for _, typ := range expected.TupleContents() {
 		if i < len(cols) {
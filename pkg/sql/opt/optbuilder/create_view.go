@@ -14,8 +14,12 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/storageparam"
+	"github.com/cockroachdb/cockroach/pkg/sql/storageparam/viewstorageparam"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
@@ -27,6 +31,24 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 	schID := b.factory.Metadata().AddSchema(sch)
 	viewName := tree.MakeTableNameFromPrefix(resName, tree.Name(cv.Name.Object()))
 
+	var params viewstorageparam.Setter
+	if len(cv.StorageParams) > 0 {
+		if !cv.Materialized {
+			panic(pgerror.New(pgcode.FeatureNotSupported,
+				"storage parameters are only supported for materialized views"))
+		}
+		if err := storageparam.Set(
+			b.ctx, b.semaCtx, b.evalCtx, cv.StorageParams, &params,
+		); err != nil {
+			panic(err)
+		}
+	}
+
+	source := cv.AsSource
+	if cv.Recursive {
+		source = recursiveViewQuery(cv)
+	}
+
 	preFuncResolver := b.semaCtx.FunctionResolver
 	b.semaCtx.FunctionResolver = nil
 
@@ -49,7 +71,7 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 		maybePanicOnUnknownFunction("view query")
 	}()
 
-	defScope := b.buildStmtAtRoot(cv.AsSource, nil /* desiredTypes */)
+	defScope := b.buildStmtAtRoot(source, nil /* desiredTypes */)
 
 	p := defScope.makePhysicalProps().Presentation
 	if len(cv.ColumnNames) != 0 {
@@ -93,16 +115,54 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 			Replace:      cv.Replace,
 			Persistence:  cv.Persistence,
 			Materialized: cv.Materialized,
-			ViewQuery:    tree.AsStringWithFlags(cv.AsSource, tree.FmtParsable),
+			ViewQuery:    tree.AsStringWithFlags(source, tree.FmtParsable),
 			Columns:      p,
 			Deps:         b.schemaDeps,
 			TypeDeps:     b.schemaTypeDeps,
 			WithData:     cv.WithData,
+			Incremental:  params.Incremental,
 		},
 	)
 	return outScope
 }
 
+// recursiveViewQuery returns the query of a recursive view, which is stored as
+// a recursive CTE named after the view, as in Postgres:
+//
+//	CREATE RECURSIVE VIEW v (a, b) AS <query>
+//
+// is equivalent to
+//
+//	CREATE VIEW v (a, b) AS WITH RECURSIVE v (a, b) AS (<query>) SELECT a, b FROM v
+func recursiveViewQuery(cv *tree.CreateView) *tree.Select {
+	if len(cv.ColumnNames) == 0 {
+		panic(pgerror.New(pgcode.Syntax, "recursive views must have a column list"))
+	}
+	cte := &tree.CTE{
+		Name: tree.AliasClause{
+			Alias: cv.Name.ObjectName,
+			Cols:  make(tree.ColumnDefList, len(cv.ColumnNames)),
+		},
+		Stmt: cv.AsSource,
+	}
+	exprs := make(tree.SelectExprs, len(cv.ColumnNames))
+	for i, col := range cv.ColumnNames {
+		cte.Name.Cols[i] = tree.ColumnDef{Name: col}
+		exprs[i] = tree.SelectExpr{Expr: tree.NewUnresolvedName(string(col))}
+	}
+	return &tree.Select{
+		With: &tree.With{Recursive: true, CTEList: []*tree.CTE{cte}},
+		Select: &tree.SelectClause{
+			Exprs: exprs,
+			From: tree.From{
+				Tables: tree.TableExprs{
+					&tree.AliasedTableExpr{Expr: tree.NewUnqualifiedTableName(cv.Name.ObjectName)},
+				},
+			},
+		},
+	}
+}
+
 func maybePanicOnUnknownFunction(target string) {
 	// TODO(chengxiong,mgartner): this is a hack to disallow UDF usage in view and
 	// we will need to lift this hack when we plan to allow it.
@@ -46,6 +46,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // groupby information stored in scopes.
@@ -250,9 +251,15 @@ func (a aggregateInfo) isOrderingSensitive() bool {
 	if a.isOrderedSetAggregate() {
 		return true
 	}
+	if a.def.Overload != nil && a.def.Overload.UDFAggregate != nil {
+		// The state of a user-defined aggregate can depend arbitrarily on the
+		// order of its input.
+		return true
+	}
 	switch a.def.Name {
 	case "array_agg", "concat_agg", "string_agg", "json_agg", "jsonb_agg", "json_object_agg", "jsonb_object_agg",
-		"st_makeline", "st_collect", "st_memcollect":
+		"st_makeline", "st_collect", "st_memcollect", "st_clusterintersecting",
+		"st_clusterwithin", "st_polygonize":
 		return true
 	default:
 		return false
@@ -384,7 +391,7 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		aggCols[i].scalar = b.constructAggregate(&agg.def, args)
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -773,8 +780,13 @@ func (b *Builder) buildAggregateFunction(
 	return &info
 }
 
-func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.ScalarExpr {
-	switch name {
+func (b *Builder) constructWindowFn(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	if def.Overload != nil && def.Overload.UDFAggregate != nil {
+		return b.constructUserDefinedAggregate(def, args)
+	}
+	switch def.Name {
 	case "rank":
 		return b.factory.ConstructRank()
 	case "row_number":
@@ -797,13 +809,22 @@ func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.Scal
 		return b.factory.ConstructLastValue(args[0])
 	case "nth_value":
 		return b.factory.ConstructNthValue(args[0], args[1])
+	case "st_clusterdbscan":
+		return b.factory.ConstructSTClusterDBSCAN(args[0], args[1], args[2])
+	case "st_clusterkmeans":
+		return b.factory.ConstructSTClusterKMeans(args[0], args[1])
 	default:
-		return b.constructAggregate(name, args)
+		return b.constructAggregate(def, args)
 	}
 }
 
-func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.ScalarExpr {
-	switch name {
+func (b *Builder) constructAggregate(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	if def.Overload != nil && def.Overload.UDFAggregate != nil {
+		return b.constructUserDefinedAggregate(def, args)
+	}
+	switch def.Name {
 	case "array_agg":
 		return b.factory.ConstructArrayAgg(args[0])
 	case "avg":
@@ -872,6 +893,12 @@ func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.Sca
 		return b.factory.ConstructSTExtent(args[0])
 	case "st_union", "st_memunion":
 		return b.factory.ConstructSTUnion(args[0])
+	case "st_clusterintersecting":
+		return b.factory.ConstructSTClusterIntersecting(args[0])
+	case "st_clusterwithin":
+		return b.factory.ConstructSTClusterWithin(args[0], args[1])
+	case "st_polygonize":
+		return b.factory.ConstructSTPolygonize(args[0])
 	case "xor_agg":
 		return b.factory.ConstructXorAgg(args[0])
 	case "json_agg":
@@ -890,7 +917,91 @@ func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.Sca
 		return b.factory.ConstructJsonbObjectAgg(args[0], args[1])
 	}
 
-	panic(errors.AssertionFailedf("unhandled aggregate: %s", name))
+	panic(errors.AssertionFailedf("unhandled aggregate: %s", def.Name))
+}
+
+// constructUserDefinedAggregate constructs a UserDefinedAgg expression for an
+// aggregate created with CREATE AGGREGATE. The transition and final functions
+// are built as calls over synthesized columns that represent the state and the
+// arguments of the aggregate.
+func (b *Builder) constructUserDefinedAggregate(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	o := def.Overload
+	agg := o.UDFAggregate
+
+	paramScope := b.allocScope()
+	params := make(opt.ColList, len(args)+1)
+	params[0] = b.synthesizeColumn(
+		paramScope, scopeColName("state"), agg.StateType, nil /* expr */, nil, /* scalar */
+	).id
+	for i := range args {
+		typ := o.Types.GetAt(i)
+		if typ == nil {
+			typ = args[i].DataType()
+		}
+		params[i+1] = b.synthesizeColumn(
+			paramScope, funcParamColName("", i), typ, nil /* expr */, nil, /* scalar */
+		).id
+	}
+
+	transition, sfunc := b.buildAggregateSupportCall(agg.TransitionFunc, paramScope.cols, paramScope)
+	var final opt.ScalarExpr
+	if agg.FinalFunc != 0 {
+		final, _ = b.buildAggregateSupportCall(agg.FinalFunc, paramScope.cols[:1], paramScope)
+	} else {
+		final = b.factory.ConstructVariable(params[0])
+	}
+
+	initCond := tree.Datum(tree.DNull)
+	if agg.InitCond != nil {
+		var err error
+		initCond, _, err = tree.ParseAndRequireString(agg.StateType, *agg.InitCond, b.evalCtx)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return b.factory.ConstructUserDefinedAgg(args, &memo.UserDefinedAggPrivate{
+		Name:       def.Name,
+		Params:     params,
+		Transition: transition,
+		Final:      final,
+		StateType:  agg.StateType,
+		InitCond:   initCond,
+		Strict:     !sfunc.CalledOnNullInput,
+		Typ:        o.FixedReturnType(),
+	})
+}
+
+// buildAggregateSupportCall builds a call of the transition or final function
+// of a user-defined aggregate with the given columns as arguments. It returns
+// the built expression and the overload of the called function.
+func (b *Builder) buildAggregateSupportCall(
+	fnOID oid.Oid, cols []scopeColumn, paramScope *scope,
+) (opt.ScalarExpr, *tree.Overload) {
+	name, o, err := b.catalog.ResolveFunctionByOID(b.ctx, fnOID)
+	if err != nil {
+		panic(err)
+	}
+	exprs := make(tree.TypedExprs, len(cols))
+	for i := range cols {
+		exprs[i] = &cols[i]
+	}
+	fn := tree.NewTypedFuncExpr(
+		tree.ResolvableFunctionReference{FunctionReference: &tree.ResolvedFunctionDefinition{
+			Name:      name,
+			Overloads: []tree.QualifiedOverload{{Overload: o}},
+		}},
+		0, /* aggQualifier */
+		exprs,
+		nil, /* filter */
+		nil, /* windowDef */
+		o.FixedReturnType(),
+		&o.FunctionProperties,
+		o,
+	)
+	return b.buildScalar(fn, paramScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */), o
 }
 
 func isAggregate(def *tree.ResolvedFunctionDefinition) bool {
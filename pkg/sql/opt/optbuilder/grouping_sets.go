@@ -0,0 +1,616 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+)
+
+// maxGroupingSets is the maximum number of grouping sets that a GROUP BY
+// clause can expand to. It matches the limit in Postgres.
+const maxGroupingSets = 4096
+
+// maxCubeElements is the maximum number of elements in a CUBE. It matches the
+// limit in Postgres.
+const maxCubeElements = 12
+
+// maxGroupingArgs is the maximum number of arguments of the GROUPING function,
+// so that the result fits in the bits of an INT4.
+const maxGroupingArgs = 31
+
+// groupingSets holds the grouping sets that a GROUP BY clause with ROLLUP,
+// CUBE or GROUPING SETS expands to.
+//
+// A query with several grouping sets is built as a UNION ALL of one
+// aggregation per grouping set. The FROM and WHERE clauses are built once and
+// bound to a WITH expression, which each aggregation reads. Within the branch
+// for a given set, references to grouping expressions that are not in the set
+// are replaced with NULL, and calls to the GROUPING function are replaced with
+// constants. For example:
+//
+//	SELECT a, b, grouping(a, b), count(*) FROM t GROUP BY ROLLUP (a, b)
+//
+// is built as:
+//
+//	WITH input AS (SELECT * FROM t)
+//	SELECT a, b, 0, count(*) FROM input GROUP BY a, b
+//	UNION ALL
+//	SELECT a, NULL, 1, count(*) FROM input GROUP BY a
+//	UNION ALL
+//	SELECT NULL, NULL, 3, count(*) FROM input HAVING true
+//
+// Grouping expressions are matched after resolving their column references
+// against the FROM clause, so that a qualified column reference matches an
+// unqualified grouping column.
+type groupingSets struct {
+	sel *tree.SelectClause
+
+	// fromScope is the scope of the FROM clause, against which the grouping
+	// expressions are resolved.
+	fromScope *scope
+
+	// exprs are the distinct grouping expressions that appear in any set.
+	exprs tree.Exprs
+
+	// types are the resolved types of the grouping expressions that are
+	// replaced with NULL in at least one set. They are resolved lazily.
+	types []*types.T
+
+	// exprIdx maps the symbolic representation of each grouping expression,
+	// in which column references are resolved, to its index in exprs.
+	exprIdx map[string]int
+
+	// sets are the grouping sets. Each set contains indexes into exprs.
+	sets []intsets.Fast
+}
+
+// hasGroupingSets returns true if the given select clause uses ROLLUP, CUBE
+// or GROUPING SETS in its GROUP BY clause, or calls the GROUPING function.
+func hasGroupingSets(sel *tree.SelectClause) bool {
+	for _, e := range sel.GroupBy {
+		if _, ok := e.(*tree.GroupingSet); ok {
+			return true
+		}
+	}
+	var v groupingFuncFinder
+	for i := range sel.Exprs {
+		tree.WalkExprConst(&v, sel.Exprs[i].Expr)
+	}
+	if sel.Having != nil {
+		tree.WalkExprConst(&v, sel.Having.Expr)
+	}
+	return v.found
+}
+
+// groupingFuncFinder is a tree.Visitor that detects calls to the GROUPING
+// function outside of subqueries.
+type groupingFuncFinder struct {
+	found bool
+}
+
+var _ tree.Visitor = &groupingFuncFinder{}
+
+// VisitPre is part of the tree.Visitor interface.
+func (v *groupingFuncFinder) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if v.found {
+		return false, expr
+	}
+	switch t := expr.(type) {
+	case *tree.Subquery:
+		return false, expr
+	case *tree.FuncExpr:
+		if isGroupingFunc(t) {
+			v.found = true
+			return false, expr
+		}
+	}
+	return true, expr
+}
+
+// VisitPost is part of the tree.Visitor interface.
+func (*groupingFuncFinder) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// isGroupingFunc returns true if the given function expression is a call to
+// the GROUPING function.
+func isGroupingFunc(f *tree.FuncExpr) bool {
+	name, ok := f.Func.FunctionReference.(*tree.UnresolvedName)
+	return ok && name.NumParts == 1 && name.Parts[0] == "grouping"
+}
+
+// buildGroupingSets builds a select clause that uses grouping sets. See
+// groupingSets for details.
+//
+// See Builder.buildStmt for a description of the remaining input and
+// return values.
+func (b *Builder) buildGroupingSets(
+	sel *tree.SelectClause,
+	orderBy tree.OrderBy,
+	locking lockingSpec,
+	desiredTypes []*types.T,
+	inScope *scope,
+) (outScope *scope) {
+	g := groupingSets{sel: sel, exprIdx: make(map[string]int)}
+	g.fromScope = b.buildFrom(sel.From, locking, inScope)
+	g.sets = []intsets.Fast{{}}
+	for _, e := range sel.GroupBy {
+		g.sets = g.crossProduct(g.sets, g.expandItem(e, false /* nested */))
+	}
+	g.types = make([]*types.T, len(g.exprs))
+
+	if len(g.sets) == 1 {
+		// Only the GROUPING function needs to be handled, so the clause can be
+		// built directly, along with its ORDER BY.
+		branch, rewrite := b.buildGroupingSetBranch(&g, g.sets[0], nil /* extraExprs */)
+		if orderBy != nil {
+			orderBy = append(tree.OrderBy(nil), orderBy...)
+			for i := range orderBy {
+				order := *orderBy[i]
+				order.Expr = rewrite(order.Expr)
+				orderBy[i] = &order
+			}
+		}
+		return b.buildSelectClauseFromScope(branch, orderBy, locking, desiredTypes, g.fromScope)
+	}
+
+	if len(sel.DistinctOn) > 0 {
+		panic(unimplemented.NewWithIssue(46280, "DISTINCT ON with grouping sets"))
+	}
+	if len(sel.Window) > 0 || hasWindowFunc(sel.Exprs) {
+		panic(unimplemented.NewWithIssue(46280, "window functions with grouping sets"))
+	}
+	b.rejectIfLocking(locking, "GROUP BY clause")
+
+	// ORDER BY expressions that do not refer to an output column are computed
+	// by each branch as extra columns, so that they can refer to grouping
+	// expressions and call GROUPING.
+	var extraExprs tree.Exprs
+	var extraOrders []int
+	for i, order := range orderBy {
+		if order.OrderType == tree.OrderByColumn && !b.isGroupingSetOutputRef(sel, order.Expr) {
+			extraExprs = append(extraExprs, order.Expr)
+			extraOrders = append(extraOrders, i)
+		}
+	}
+	if len(extraExprs) > 0 && sel.Distinct {
+		panic(pgerror.Newf(pgcode.InvalidColumnReference,
+			"for SELECT DISTINCT, ORDER BY expressions must appear in select list"))
+	}
+
+	// Build the input of the aggregations once, and bind it to a WITH
+	// expression.
+	b.buildWhere(sel.Where, g.fromScope)
+	withID := b.factory.Memo().NextWithID()
+	b.factory.Metadata().AddWithBinding(withID, g.fromScope.expr)
+	input := &cteSource{
+		id: withID,
+		originalExpr: &tree.Select{Select: &tree.SelectClause{
+			Exprs: tree.SelectExprs{tree.StarSelectExpr()},
+			From:  sel.From,
+			Where: sel.Where,
+		}},
+		expr: g.fromScope.expr,
+	}
+
+	for _, set := range g.sets {
+		branch, _ := b.buildGroupingSetBranch(&g, set, extraExprs)
+		// The WHERE clause is part of the input, and DISTINCT applies to the
+		// result of the whole query.
+		branch.Where = nil
+		branch.Distinct = false
+		branchScope := b.buildSelectClauseFromScope(
+			branch, nil /* orderBy */, noRowLocking, desiredTypes, b.buildGroupingSetInput(&g, withID, inScope),
+		)
+		if outScope == nil {
+			outScope = branchScope
+			// Propagate types left-to-right, if we didn't already have desired
+			// types.
+			if len(desiredTypes) == 0 {
+				desiredTypes = branchScope.makeColumnTypes()
+			}
+			continue
+		}
+		outScope = b.buildSetOp(tree.UnionOp, true /* all */, inScope, outScope, branchScope)
+	}
+	if sel.Distinct {
+		outScope.expr = b.constructDistinct(outScope)
+	}
+
+	if orderBy != nil {
+		// Order the result, and remove the extra columns.
+		numCols := len(outScope.cols) - len(extraExprs)
+		orderBy = append(tree.OrderBy(nil), orderBy...)
+		for i, ord := range extraOrders {
+			order := *orderBy[ord]
+			order.Expr = &outScope.cols[numCols+i]
+			orderBy[ord] = &order
+		}
+		projectionsScope := outScope.replace()
+		projectionsScope.appendColumnsFromScope(outScope)
+		projectionsScope.cols = projectionsScope.cols[:numCols]
+		orderByScope := b.analyzeOrderBy(orderBy, outScope, projectionsScope, tree.RejectGenerators|tree.RejectAggregates|tree.RejectWindowApplications)
+		b.buildOrderBy(outScope, projectionsScope, orderByScope)
+		b.constructProjectForScope(outScope, projectionsScope)
+		outScope = projectionsScope
+	}
+
+	outScope.expr = b.buildWiths(outScope.expr, cteSources{input})
+	return outScope
+}
+
+// buildGroupingSetInput returns a scope that reads the input of the grouping
+// sets from the WITH binding with the given ID. The columns keep the names and
+// table qualifications of the columns of the FROM clause.
+func (b *Builder) buildGroupingSetInput(g *groupingSets, withID opt.WithID, inScope *scope) *scope {
+	outScope := inScope.push()
+	inCols := make(opt.ColList, len(g.fromScope.cols))
+	outCols := make(opt.ColList, len(g.fromScope.cols))
+	for i := range g.fromScope.cols {
+		c := &g.fromScope.cols[i]
+		newCol := b.synthesizeColumn(outScope, c.name, c.typ, nil /* expr */, nil /* scalar */)
+		newCol.table = c.table
+		newCol.visibility = c.visibility
+		newCol.kind = c.kind
+		newCol.mutation = c.mutation
+		inCols[i] = c.id
+		outCols[i] = newCol.id
+	}
+	outScope.expr = b.factory.ConstructWithScan(&memo.WithScanPrivate{
+		With:    withID,
+		InCols:  inCols,
+		OutCols: outCols,
+		ID:      b.factory.Metadata().NextUniqueID(),
+	})
+	return outScope
+}
+
+// isGroupingSetOutputRef returns true if the given ORDER BY expression refers
+// to an output column of the select clause, by ordinal or by name.
+func (b *Builder) isGroupingSetOutputRef(sel *tree.SelectClause, expr tree.Expr) bool {
+	switch t := tree.StripParens(expr).(type) {
+	case *tree.NumVal:
+		return true
+	case *tree.UnresolvedName:
+		if t.NumParts != 1 || t.Star {
+			return false
+		}
+		for _, e := range sel.Exprs {
+			if name, ok := b.groupingSetRenderName(e); ok && string(name) == t.Parts[0] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// groupingSetRenderName returns the name of the output column of the given
+// select expression, or false if the expression is a star expansion.
+func (b *Builder) groupingSetRenderName(e tree.SelectExpr) (tree.UnrestrictedName, bool) {
+	switch e.Expr.(type) {
+	case tree.UnqualifiedStar, *tree.AllColumnsSelector, *tree.TupleStar:
+		return "", false
+	}
+	if e.As != "" {
+		return e.As, true
+	}
+	// GROUPING is not a builtin, so its name cannot be resolved.
+	if f, ok := e.Expr.(*tree.FuncExpr); ok && isGroupingFunc(f) {
+		return "grouping", true
+	}
+	name, err := tree.GetRenderColName(b.ctx, b.semaCtx.SearchPath, e, b.semaCtx.FunctionResolver)
+	if err != nil {
+		panic(err)
+	}
+	return tree.UnrestrictedName(name), true
+}
+
+// buildGroupingSetBranch returns the select clause that computes the
+// aggregation for the given grouping set, along with the function used to
+// rewrite its expressions. The extra expressions are appended to its select
+// list.
+func (b *Builder) buildGroupingSetBranch(
+	g *groupingSets, set intsets.Fast, extraExprs tree.Exprs,
+) (*tree.SelectClause, func(tree.Expr) tree.Expr) {
+	branch := *g.sel
+	branch.GroupBy = make(tree.GroupBy, 0, set.Len())
+	set.ForEach(func(i int) {
+		branch.GroupBy = append(branch.GroupBy, g.exprs[i])
+	})
+
+	v := groupingSetReplacer{b: b, g: g, set: set}
+	rewrite := func(e tree.Expr) tree.Expr {
+		e, _ = tree.WalkExpr(&v, e)
+		return e
+	}
+
+	branch.Exprs = make(tree.SelectExprs, len(g.sel.Exprs), len(g.sel.Exprs)+len(extraExprs))
+	for i, e := range g.sel.Exprs {
+		// Keep the column name that the original expression would have.
+		if name, ok := b.groupingSetRenderName(e); ok {
+			e.As = name
+		}
+		e.Expr = rewrite(e.Expr)
+		branch.Exprs[i] = e
+	}
+	for _, e := range extraExprs {
+		branch.Exprs = append(branch.Exprs, tree.SelectExpr{Expr: rewrite(e), As: "order_by"})
+	}
+	if g.sel.Having != nil {
+		branch.Having = &tree.Where{Type: g.sel.Having.Type, Expr: rewrite(g.sel.Having.Expr)}
+	} else if set.Empty() {
+		// The empty grouping set produces a single row, even if there are no
+		// aggregate functions.
+		branch.Having = &tree.Where{Type: tree.AstHaving, Expr: tree.DBoolTrue}
+	}
+	return &branch, rewrite
+}
+
+// expandItem returns the grouping sets for an item of a GROUP BY clause. If
+// nested is true, the item is an element of GROUPING SETS.
+func (g *groupingSets) expandItem(e tree.Expr, nested bool) []intsets.Fast {
+	switch t := tree.StripParens(e).(type) {
+	case *tree.GroupingSet:
+		switch t.Type {
+		case tree.RollupGroupingSet:
+			elems := g.elements(t.Exprs)
+			sets := make([]intsets.Fast, len(elems)+1)
+			for i := range elems {
+				for j := 0; j < len(elems)-i; j++ {
+					sets[i].UnionWith(elems[j])
+				}
+			}
+			return sets
+
+		case tree.CubeGroupingSet:
+			if len(t.Exprs) > maxCubeElements {
+				panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+					"CUBE is limited to %d elements", maxCubeElements))
+			}
+			elems := g.elements(t.Exprs)
+			n := len(elems)
+			sets := make([]intsets.Fast, 0, 1<<n)
+			for mask := (1 << n) - 1; mask >= 0; mask-- {
+				var set intsets.Fast
+				for j := range elems {
+					if mask&(1<<(n-1-j)) != 0 {
+						set.UnionWith(elems[j])
+					}
+				}
+				sets = append(sets, set)
+			}
+			return sets
+
+		case tree.GroupingSetsGroupingSet:
+			var sets []intsets.Fast
+			for _, item := range t.Exprs {
+				sets = append(sets, g.expandItem(item, true /* nested */)...)
+				g.checkNumSets(len(sets))
+			}
+			return sets
+		}
+
+	case *tree.Tuple:
+		if nested || len(t.Exprs) == 0 {
+			return []intsets.Fast{g.elements(tree.Exprs{t})[0]}
+		}
+	}
+	var set intsets.Fast
+	set.Add(g.addExpr(e))
+	return []intsets.Fast{set}
+}
+
+// elements returns the set of grouping expressions for each element of a
+// ROLLUP, CUBE or GROUPING SETS. A parenthesized list of expressions is a
+// single element.
+func (g *groupingSets) elements(exprs tree.Exprs) []intsets.Fast {
+	elems := make([]intsets.Fast, len(exprs))
+	for i, e := range exprs {
+		if t, ok := tree.StripParens(e).(*tree.Tuple); ok && !t.Row {
+			for _, sub := range t.Exprs {
+				elems[i].Add(g.addExpr(sub))
+			}
+			continue
+		}
+		elems[i].Add(g.addExpr(e))
+	}
+	return elems
+}
+
+// crossProduct returns the union of each set in left with each set in right.
+func (g *groupingSets) crossProduct(left, right []intsets.Fast) []intsets.Fast {
+	g.checkNumSets(len(left) * len(right))
+	sets := make([]intsets.Fast, 0, len(left)*len(right))
+	for _, l := range left {
+		for _, r := range right {
+			sets = append(sets, l.Union(r))
+		}
+	}
+	return sets
+}
+
+func (g *groupingSets) checkNumSets(n int) {
+	if n > maxGroupingSets {
+		panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"too many grouping sets present (maximum %d)", maxGroupingSets))
+	}
+}
+
+// addExpr adds a grouping expression if it is not already known, and returns
+// its index. An integer constant refers to an expression in the select list,
+// as it does in a regular GROUP BY.
+func (g *groupingSets) addExpr(e tree.Expr) int {
+	e = tree.StripParens(e)
+	if num, ok := e.(*tree.NumVal); ok {
+		if ord, err := strconv.Atoi(num.OrigString()); err == nil {
+			if ord < 1 || ord > len(g.sel.Exprs) {
+				panic(pgerror.Newf(pgcode.InvalidColumnReference,
+					"GROUP BY position %d is not in select list", ord))
+			}
+			e = tree.StripParens(g.sel.Exprs[ord-1].Expr)
+		}
+	}
+	key := g.exprKey(e)
+	if i, ok := g.exprIdx[key]; ok {
+		return i
+	}
+	g.exprIdx[key] = len(g.exprs)
+	g.exprs = append(g.exprs, e)
+	return len(g.exprs) - 1
+}
+
+// lookupExpr returns the index of the grouping expression that matches the
+// given expression, or -1 if there is none.
+func (g *groupingSets) lookupExpr(e tree.Expr) int {
+	if i, ok := g.exprIdx[g.exprKey(tree.StripParens(e))]; ok {
+		return i
+	}
+	return -1
+}
+
+// exprKey returns the symbolic representation of the given expression, in
+// which the references to columns of the FROM clause (or of an outer scope)
+// are replaced with the resolved columns. Two expressions that refer to the
+// same columns in different ways have the same key.
+func (g *groupingSets) exprKey(e tree.Expr) string {
+	ctx := g.fromScope.builder.ctx
+	resolved, _ := tree.SimpleVisit(e, func(expr tree.Expr) (bool, tree.Expr, error) {
+		switch t := expr.(type) {
+		case *tree.Subquery:
+			return false, expr, nil
+		case *tree.UnresolvedName:
+			vn, err := t.NormalizeVarName()
+			if err != nil {
+				return false, expr, nil //nolint:returnerrcheck
+			}
+			if c, ok := vn.(*tree.ColumnItem); ok {
+				if col, err := colinfo.ResolveColumnItem(ctx, g.fromScope, c); err == nil {
+					return false, col.(*scopeColumn), nil
+				}
+			}
+			return false, expr, nil
+		}
+		return true, expr, nil
+	})
+	return symbolicExprStr(resolved)
+}
+
+// groupingSetReplacer is a tree.Visitor that rewrites the expressions of a
+// select clause for a given grouping set.
+type groupingSetReplacer struct {
+	b   *Builder
+	g   *groupingSets
+	set intsets.Fast
+}
+
+var _ tree.Visitor = &groupingSetReplacer{}
+
+// VisitPre is part of the tree.Visitor interface.
+func (v *groupingSetReplacer) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	switch t := expr.(type) {
+	case *tree.Subquery:
+		return false, expr
+
+	case *tree.FuncExpr:
+		if isGroupingFunc(t) {
+			return false, v.groupingValue(t)
+		}
+		if t.WindowDef == nil {
+			def, err := t.Func.Resolve(v.b.ctx, v.b.semaCtx.SearchPath, v.b.semaCtx.FunctionResolver)
+			if err == nil && isAggregate(def) {
+				// The arguments of an aggregate function are evaluated before
+				// grouping.
+				return false, expr
+			}
+		}
+	}
+	if i := v.g.lookupExpr(expr); i >= 0 && !v.set.Contains(i) {
+		return false, &tree.CastExpr{Expr: tree.DNull, Type: v.exprType(i), SyntaxMode: tree.CastShort}
+	}
+	return true, expr
+}
+
+// VisitPost is part of the tree.Visitor interface.
+func (*groupingSetReplacer) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// groupingValue returns the value of a call to the GROUPING function. Each
+// argument corresponds to a bit of the result, starting with the most
+// significant one, which is set if the argument is not in the grouping set.
+func (v *groupingSetReplacer) groupingValue(f *tree.FuncExpr) tree.Expr {
+	if len(f.Exprs) > maxGroupingArgs {
+		panic(pgerror.Newf(pgcode.TooManyArguments,
+			"GROUPING must have fewer than %d arguments", maxGroupingArgs+1))
+	}
+	var res int64
+	for _, arg := range f.Exprs {
+		i := v.g.lookupExpr(arg)
+		if i < 0 {
+			panic(pgerror.New(pgcode.Grouping,
+				"arguments to GROUPING must be grouping expressions of the associated query level"))
+		}
+		res <<= 1
+		if !v.set.Contains(i) {
+			res |= 1
+		}
+	}
+	return tree.NewDInt(tree.DInt(res))
+}
+
+// exprType returns the type of the i-th grouping expression.
+func (v *groupingSetReplacer) exprType(i int) *types.T {
+	if v.g.types[i] == nil {
+		v.g.types[i] = v.g.fromScope.resolveType(v.g.exprs[i], types.Any).ResolvedType()
+	}
+	return v.g.types[i]
+}
+
+// hasWindowFunc returns true if any of the given expressions contains a
+// window function application outside of a subquery.
+func hasWindowFunc(exprs tree.SelectExprs) bool {
+	var v windowFuncFinder
+	for i := range exprs {
+		tree.WalkExprConst(&v, exprs[i].Expr)
+	}
+	return v.found
+}
+
+// windowFuncFinder is a tree.Visitor that detects window function
+// applications outside of subqueries.
+type windowFuncFinder struct {
+	found bool
+}
+
+var _ tree.Visitor = &windowFuncFinder{}
+
+// VisitPre is part of the tree.Visitor interface.
+func (v *windowFuncFinder) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	switch t := expr.(type) {
+	case *tree.Subquery:
+		return false, expr
+	case *tree.FuncExpr:
+		if t.IsWindowFunctionApplication() {
+			v.found = true
+			return false, expr
+		}
+	}
+	return !v.found, expr
+}
+
+// VisitPost is part of the tree.Visitor interface.
+func (*windowFuncFinder) VisitPost(expr tree.Expr) tree.Expr { return expr }
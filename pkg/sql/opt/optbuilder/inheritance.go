@@ -0,0 +1,153 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// buildInheritingTables adds the rows of the tables that inherit, directly or
+// indirectly, from the given table to the scan of the table in tableScope.
+// The scans are combined with a UnionAll, whose output columns mirror the
+// columns of tableScope. Each inheriting table provides its columns that have
+// the same name and type as a column of the table, and NULL for the others.
+//
+// As in Postgres, reading the inheriting tables only requires the SELECT
+// privilege on the table that is queried.
+//
+// See Builder.buildStmt for a description of the remaining input and
+// return values.
+func (b *Builder) buildInheritingTables(
+	tab cat.Table, tableScope *scope, locking lockingSpec, inScope *scope,
+) (outScope *scope) {
+	var flags cat.Flags
+	if b.insideViewDef || b.insideFuncDef {
+		// Avoid taking table leases when we're creating a view or a function.
+		flags.AvoidDescriptorCaches = true
+	}
+
+	outScope = tableScope
+	// A table can inherit from several tables of the hierarchy, but its rows
+	// are only included once.
+	seen := map[cat.StableID]struct{}{tab.ID(): {}}
+	queue := []cat.Table{tab}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for i, n := 0, parent.InheritedByCount(); i < n; i++ {
+			id := parent.InheritedBy(i)
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			ds, _, err := b.catalog.ResolveDataSourceByID(b.ctx, flags, id)
+			if err != nil {
+				panic(err)
+			}
+			child, ok := ds.(cat.Table)
+			if !ok {
+				panic(errors.AssertionFailedf("inheriting data source %q is not a table", ds.Name()))
+			}
+			// Add a dependency on the inheriting table, without a privilege to
+			// recheck, so that the memo is invalidated if the table changes.
+			b.factory.Metadata().AddDependency(opt.DepByID(id), child, 0 /* priv */)
+
+			childName := tree.MakeUnqualifiedTableName(child.Name())
+			childScope := b.buildScan(
+				b.addTable(child, &childName),
+				tableOrdinals(child, columnKinds{
+					includeMutations: false,
+					includeSystem:    true,
+					includeInverted:  false,
+				}),
+				nil /* indexFlags */, locking, inScope,
+				false, /* disableNotVisibleIndex */
+			)
+			childScope = b.projectInheritedColumns(tableScope, childScope)
+			outScope = b.buildInheritanceUnion(outScope, childScope, inScope)
+			queue = append(queue, child)
+		}
+	}
+	return outScope
+}
+
+// projectInheritedColumns projects the columns of childScope that correspond
+// to the columns of tableScope, by name. Columns that the inheriting table
+// does not have, or that have a different type, are projected as NULL.
+func (b *Builder) projectInheritedColumns(tableScope, childScope *scope) *scope {
+	projScope := childScope.push()
+	projScope.cols = make([]scopeColumn, 0, len(tableScope.cols))
+	for i := range tableScope.cols {
+		col := &tableScope.cols[i]
+		var match *scopeColumn
+		for j := range childScope.cols {
+			if childScope.cols[j].name.ReferenceName() == col.name.ReferenceName() {
+				match = &childScope.cols[j]
+				break
+			}
+		}
+		if match != nil && match.typ.Identical(col.typ) {
+			projScope.appendColumn(match)
+		} else {
+			b.synthesizeColumn(projScope, col.name, col.typ, nil /* expr */, b.factory.ConstructNull(col.typ))
+		}
+	}
+	projScope.expr = b.constructProject(childScope.expr, projScope.cols)
+	return projScope
+}
+
+// buildInheritanceUnion combines the rows of leftScope and rightScope, which
+// have columns of the same types, with a UnionAll. The output columns keep
+// the names, table names and visibility of the columns of leftScope.
+func (b *Builder) buildInheritanceUnion(leftScope, rightScope, inScope *scope) (outScope *scope) {
+	outScope = inScope.push()
+	outScope.cols = make([]scopeColumn, 0, len(leftScope.cols))
+	for i := range leftScope.cols {
+		c := &leftScope.cols[i]
+		col := b.synthesizeColumn(outScope, c.name, c.typ, nil /* expr */, nil /* scalar */)
+		col.table = c.table
+		col.visibility = c.visibility
+		col.kind = c.kind
+	}
+	outScope.expr = b.factory.ConstructUnionAll(leftScope.expr, rightScope.expr, &memo.SetPrivate{
+		LeftCols:  colsToColList(leftScope.cols),
+		RightCols: colsToColList(rightScope.cols),
+		OutCols:   colsToColList(outScope.cols),
+	})
+	return outScope
+}
+
+// checkInheritedMutation raises an error if the target of an UPDATE, DELETE
+// or MERGE statement is a table that other tables inherit from, unless it is
+// qualified with ONLY. Modifying the rows of the inheriting tables is not yet
+// implemented, so the statement must exclude them explicitly.
+func checkInheritedMutation(op string, target tree.TableExpr, tab cat.Table) {
+	if tab.InheritedByCount() == 0 {
+		return
+	}
+	if ate, ok := target.(*tree.AliasedTableExpr); ok && ate.Only {
+		return
+	}
+	err := unimplemented.NewWithIssueDetailf(22456, strings.ToLower(op)+" inherited tables",
+		"%s on table %q, which other tables inherit from, is not supported", op, tab.Name())
+	if op != "MERGE" {
+		err = errors.WithHintf(err,
+			"Qualify the table with ONLY to only modify the rows of %q.", tab.Name())
+	}
+	panic(err)
+}
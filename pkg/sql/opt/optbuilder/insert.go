@@ -366,6 +366,17 @@ func (mb *mutationBuilder) needExistingRows() bool {
 		return true
 	}
 
+	// Row-level triggers need to know whether each row is inserted or updated,
+	// and UPDATE triggers need the existing values.
+	if mb.hasRowTriggers(tree.TriggerEventInsert, tree.TriggerEventUpdate) {
+		return true
+	}
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		if mb.tab.Trigger(i).HasEvent(tree.TriggerEventUpdate) {
+			return true
+		}
+	}
+
 	// If there are any implicit partitioning columns in the primary index,
 	// these columns will need to be fetched.
 	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
@@ -660,6 +671,9 @@ func (mb *mutationBuilder) addSynthesizedColsForInsert() {
 	// Add assignment casts for default column values.
 	mb.addAssignmentCasts(mb.insertColIDs)
 
+	// Fire any BEFORE INSERT triggers, which may modify the new rows.
+	mb.buildBeforeTriggers(tree.TriggerEventInsert)
+
 	// Now add all computed columns.
 	mb.addSynthesizedComputedCols(mb.insertColIDs, false /* restrict */)
 
@@ -684,6 +698,8 @@ func (mb *mutationBuilder) buildInsert(returning tree.ReturningExprs) {
 
 	mb.buildFKChecksForInsert()
 
+	mb.buildAfterTriggers(tree.TriggerEventInsert)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
@@ -907,6 +923,9 @@ func (mb *mutationBuilder) buildUpsert(returning tree.ReturningExprs) {
 
 	mb.buildFKChecksForUpsert()
 
+	mb.buildAfterTriggers(tree.TriggerEventInsert)
+	mb.buildAfterTriggers(tree.TriggerEventUpdate)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
@@ -0,0 +1,599 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// mergeDuplicateErrText is error text used when a target row is matched by
+// more than one source row that would modify it.
+const mergeDuplicateErrText = "MERGE command cannot affect row a second time"
+
+// buildMerge builds a memo group for a MERGE statement. MERGE does not have
+// its own mutation operator; instead, it is lowered onto the Insert, Update,
+// Upsert and Delete operators. For example:
+//
+//	CREATE TABLE abc (a INT PRIMARY KEY, b INT, c INT)
+//	CREATE TABLE xyz (x INT PRIMARY KEY, y INT, z INT)
+//
+//	MERGE INTO abc USING xyz ON a = x
+//	WHEN MATCHED AND z > 0 THEN UPDATE SET b = y
+//	WHEN NOT MATCHED THEN INSERT VALUES (x, y, z)
+//
+// The source is left-joined to the target table using the ON condition, and a
+// not-null "canary" column from the target's primary key distinguishes
+// matched rows from unmatched ones. Each joined row is assigned the position
+// of the first WHEN clause that applies to it, and rows with no applicable
+// clause (or a DO NOTHING clause) are filtered out. This would create an input
+// expression similar to this SQL:
+//
+//	SELECT *
+//	FROM (
+//	  SELECT
+//	    x, y, z, x AS a_ins, y AS b_ins, z AS c_ins, a, b, c,
+//	    CASE
+//	      WHEN a IS NULL THEN 2
+//	      WHEN z > 0 THEN 1
+//	      ELSE 0
+//	    END AS merge_action
+//	  FROM xyz LEFT JOIN abc ON a = x
+//	)
+//	WHERE merge_action IN (1, 2)
+//
+// The values for each target column are then computed with CASE expressions
+// over the action column, and the rows are fed into the mutation operator that
+// matches the actions of the statement:
+//
+//   - INSERT and UPDATE actions use an Upsert, with the same canary logic as
+//     INSERT ... ON CONFLICT DO UPDATE.
+//   - INSERT actions alone use an Insert.
+//   - UPDATE actions alone use an Update.
+//   - DELETE actions alone use a Delete.
+//
+// None of these operators can both delete rows and write others, so DELETE
+// actions cannot be combined with INSERT or UPDATE actions.
+//
+// A target row may be matched by several source rows, in which case it would
+// be modified more than once. As in Postgres, this raises an error at runtime,
+// which is enforced by an EnsureUpsertDistinctOn on the target's primary key.
+func (b *Builder) buildMerge(merge *tree.Merge, inScope *scope) (outScope *scope) {
+	// Find which table we're working on, check the permissions. Existing rows
+	// are always read in order to match them to source rows.
+	tab, depName, alias, refColumns := b.resolveTableForMutation(merge.Table, privilege.SELECT)
+
+	if refColumns != nil {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"cannot specify a list of column IDs with MERGE"))
+	}
+
+	// The rows of the tables that inherit from the target are not modified.
+	checkInheritedMutation("MERGE", merge.Table, tab)
+
+	hasInsert, hasUpdate, hasDelete := mergeActions(merge.Whens)
+	if hasDelete && (hasInsert || hasUpdate) {
+		panic(unimplemented.Newf("merge delete",
+			"MERGE cannot combine DELETE actions with INSERT or UPDATE actions"))
+	}
+	if hasInsert {
+		b.checkPrivilege(depName, tab, privilege.INSERT)
+	}
+	if hasUpdate {
+		b.checkPrivilege(depName, tab, privilege.UPDATE)
+	}
+	if hasDelete {
+		b.checkPrivilege(depName, tab, privilege.DELETE)
+	}
+
+	// Check if this table has already been mutated in another subquery.
+	b.checkMultipleMutations(tab, false /* simpleInsert */)
+
+	var mb mutationBuilder
+	mb.init(b, "merge", tab, alias)
+
+	// Build the input expression that joins the source to the target table
+	// and computes the values to insert and update.
+	mb.buildInputForMerge(inScope, merge)
+
+	switch {
+	case hasInsert && hasUpdate:
+		mb.buildUpsert(nil /* returning */)
+	case hasInsert:
+		mb.buildInsert(nil /* returning */)
+	case hasUpdate:
+		mb.buildUpdate(nil /* returning */)
+	case hasDelete:
+		mb.buildDelete(nil /* returning */)
+	default:
+		// Every WHEN clause is DO NOTHING, so no mutation is built.
+		return b.buildMergeRowCount(inScope, mb.outScope.expr)
+	}
+	return mb.outScope
+}
+
+// buildInputForMerge constructs the input expression for the mutation of a
+// MERGE statement. See the buildMerge comment for an example.
+//
+// All columns from the target table are added to fetchColList. Insert
+// columns are built from the source columns before the join, since the
+// target columns are not visible to WHEN NOT MATCHED clauses. Update columns
+// are built after the join, and have access to both source and target columns.
+func (mb *mutationBuilder) buildInputForMerge(inScope *scope, merge *tree.Merge) {
+	hasInsert, hasUpdate, hasDelete := mergeActions(merge.Whens)
+
+	// Fetch columns from different instance of the table metadata, so that it's
+	// possible to remap columns. Include mutation columns, but be careful to
+	// never use them for any reason other than as "fetch columns". See
+	// buildScan comment.
+	mb.fetchScope = mb.b.buildScan(
+		mb.b.addTable(mb.tab, &mb.alias),
+		tableOrdinals(mb.tab, columnKinds{
+			includeMutations: true,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		nil, /* indexFlags */
+		noRowLocking,
+		inScope,
+		false, /* disableNotVisibleIndex */
+	)
+
+	// Set list of columns that will be fetched by the input expression.
+	mb.setFetchColIDs(mb.fetchScope.cols)
+
+	mb.outScope = mb.b.buildDataSource(merge.Source, nil /* indexFlags */, noRowLocking, inScope)
+
+	// Check that the same table name is not used for the source and target.
+	mb.b.validateJoinTableNames(mb.fetchScope, mb.outScope)
+
+	// Build the insert columns, which are only visible to the mutation. They
+	// are renamed after the join if they are needed by an Insert operator.
+	var notMatchedColID opt.ColumnID
+	var insertCols []scopeColumn
+	if hasInsert {
+		notMatchedColID = mb.projectMergeAction(
+			&tree.CaseExpr{Whens: mergeActionCases(merge.Whens, false /* matched */), Else: tree.DZero},
+			"not_matched_action",
+		)
+		mb.addInsertColsForMerge(merge.Whens, notMatchedColID)
+		for i := range mb.outScope.cols {
+			if _, ok := mb.insertColIDs.Find(mb.outScope.cols[i].id); ok {
+				insertCols = append(insertCols, mb.outScope.cols[i])
+				mb.outScope.cols[i].clearName()
+			}
+		}
+	}
+
+	// Left-join the source rows to the target table using the ON condition.
+	sourceScope := mb.outScope
+	mb.outScope = sourceScope.replace()
+	mb.outScope.appendColumnsFromScope(sourceScope)
+	mb.outScope.appendColumnsFromScope(mb.fetchScope)
+	on := mb.b.resolveAndBuildScalar(
+		merge.On,
+		types.Bool,
+		exprKindOn,
+		tree.RejectGenerators|tree.RejectWindowApplications,
+		mb.outScope,
+	)
+	mb.outScope.expr = mb.b.factory.ConstructLeftJoin(
+		sourceScope.expr,
+		mb.fetchScope.expr,
+		memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(on)},
+		memo.EmptyJoinPrivate,
+	)
+
+	// Record a not-null "canary" column. After the left-join, this will be null
+	// if no target row was matched, or not null otherwise. At least one not-null
+	// column must exist, since primary key columns are not-null.
+	canaryColID := mb.fetchScope.cols[findNotNullIndexCol(mb.tab.Index(cat.PrimaryIndex))].id
+
+	// Project the position of the WHEN clause that applies to each row. Rows
+	// without a match use the position of the applicable WHEN NOT MATCHED
+	// clause computed above.
+	var notMatched tree.Expr = tree.DZero
+	if notMatchedColID != 0 {
+		notMatched = mb.outScope.getColumn(notMatchedColID)
+	}
+	whens := append([]*tree.When{{
+		Cond: &tree.IsNullExpr{Expr: mb.outScope.getColumn(canaryColID)},
+		Val:  notMatched,
+	}}, mergeActionCases(merge.Whens, true /* matched */)...)
+	actionColID := mb.projectMergeAction(&tree.CaseExpr{Whens: whens, Else: tree.DZero}, "merge_action")
+
+	// Filter out the rows that are not modified by the statement, and ensure
+	// that no target row is modified more than once. Rows without a match have
+	// null primary key values, which are treated as distinct from one another,
+	// so the check is only needed if matched rows can be modified. Ignore any
+	// ordering requested by the input, since it is meaningless for the
+	// EnsureUpsertDistinctOn operator.
+	mb.filterMergeActions(merge.Whens, actionColID)
+	mb.outScope.ordering = nil
+	if hasUpdate || hasDelete {
+		var primaryKeyCols opt.ColSet
+		primary := mb.tab.Index(cat.PrimaryIndex)
+		for i, n := 0, primary.KeyColumnCount(); i < n; i++ {
+			primaryKeyCols.Add(mb.fetchColIDs[primary.Column(i).Ordinal()])
+		}
+		mb.outScope = mb.b.buildDistinctOn(
+			primaryKeyCols, mb.outScope, true /* nullsAreDistinct */, mergeDuplicateErrText,
+		)
+	}
+
+	if hasUpdate {
+		mb.addUpdateColsForMerge(merge.Whens, actionColID)
+		mb.canaryColID = canaryColID
+	}
+
+	if hasInsert && !hasUpdate {
+		// The Insert operator does not fetch existing rows, so the fetch columns
+		// are only used to find matches. Make the insert columns visible again
+		// by name, so that they can be referenced by check constraints.
+		for i := range mb.fetchColIDs {
+			mb.fetchColIDs[i] = 0
+		}
+		for i := range insertCols {
+			col := mb.outScope.getColumn(insertCols[i].id)
+			col.name = insertCols[i].name
+			col.table = insertCols[i].table
+		}
+	}
+}
+
+// addInsertColsForMerge projects a column for each target column that is
+// assigned a value by one of the WHEN NOT MATCHED ... THEN INSERT clauses. The
+// value of the column is selected by the position of the applicable clause,
+// which is stored in the given column:
+//
+//	CASE not_matched_action
+//	  WHEN 1 THEN <value from clause 1>
+//	  WHEN 3 THEN <value from clause 3>
+//	  ELSE NULL
+//	END
+//
+// If a clause does not assign a value to a column that is assigned by another
+// clause, it uses the column's default value. Default and computed values for
+// the remaining columns are added by addSynthesizedColsForInsert.
+func (mb *mutationBuilder) addInsertColsForMerge(
+	whens tree.MergeWhens, actionColID opt.ColumnID,
+) {
+	// VALUES expressions should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require(exprKindValues.String(), tree.RejectSpecial)
+
+	// Determine the target columns of each INSERT clause, and the value it
+	// assigns to each of them.
+	values := make([]map[int]tree.Expr, len(whens))
+	var targetOrds []int
+	var targeted opt.ColSet
+	for i, when := range whens {
+		if when.Action != tree.MergeActionInsert {
+			continue
+		}
+		mb.targetColList = mb.targetColList[:0]
+		mb.targetColSet = opt.ColSet{}
+		if len(when.Columns) != 0 {
+			mb.addTargetColsByName(when.Columns)
+			mb.checkNumCols(len(mb.targetColList), len(when.Values))
+		} else if len(when.Values) != 0 {
+			mb.addTargetTableColsForInsert(len(when.Values))
+		}
+
+		// Ensure that primary key and foreign key columns are in the target
+		// column list, or that they have default values.
+		mb.checkPrimaryKeyForInsert()
+		mb.checkForeignKeysForInsert()
+
+		values[i] = make(map[int]tree.Expr, len(mb.targetColList))
+		for j, colID := range mb.targetColList {
+			ord := mb.tabID.ColumnOrdinal(colID)
+			if col := mb.tab.Column(ord); col.IsGeneratedAlwaysAsIdentity() {
+				panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnOverrideError(string(col.ColName())))
+			}
+			values[i][ord] = when.Values[j]
+			if !targeted.Contains(colID) {
+				targeted.Add(colID)
+				targetOrds = append(targetOrds, ord)
+			}
+		}
+	}
+	mb.targetColList = mb.targetColList[:0]
+	mb.targetColSet = opt.ColSet{}
+
+	inScope := mb.outScope
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	for _, ord := range targetOrds {
+		colID := mb.tabID.ColumnID(ord)
+		var cases memo.ScalarListExpr
+		for i := range whens {
+			if values[i] == nil {
+				continue
+			}
+			expr, ok := values[i][ord]
+			if _, isDefault := expr.(tree.DefaultVal); !ok || isDefault {
+				expr = mb.parseDefaultExpr(colID)
+			}
+			cases = append(cases, mb.b.factory.ConstructWhen(
+				mb.b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int),
+				mb.buildMergeValue(expr, inScope, ord),
+			))
+		}
+		orElse := mb.b.factory.ConstructNull(mb.tab.Column(ord).DatumType())
+		mb.insertColIDs[ord] = mb.projectMergeValue(projectionsScope, ord, "_ins", actionColID, cases, orElse)
+	}
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Add default columns that were not assigned by any INSERT clause, as well
+	// as computed columns. Hide the source columns while doing so, so that they
+	// cannot be confused with target columns by the default and computed
+	// expressions.
+	sourceCols := make([]scopeColumn, len(inScope.cols))
+	copy(sourceCols, inScope.cols)
+	for i := range mb.outScope.cols {
+		if inScope.getColumn(mb.outScope.cols[i].id) != nil {
+			mb.outScope.cols[i].clearName()
+		}
+	}
+	mb.addSynthesizedColsForInsert()
+	for i := range sourceCols {
+		col := mb.outScope.getColumn(sourceCols[i].id)
+		col.name = sourceCols[i].name
+		col.table = sourceCols[i].table
+	}
+}
+
+// addUpdateColsForMerge projects a column for each target column that is
+// assigned a value by one of the WHEN MATCHED ... THEN UPDATE clauses. The
+// value of the column is selected by the position of the applicable clause,
+// which is stored in the given column:
+//
+//	CASE merge_action
+//	  WHEN 1 THEN <value from clause 1>
+//	  WHEN 2 THEN <value from clause 2>
+//	  ELSE <existing value>
+//	END
+//
+// Computed columns are then added by addSynthesizedColsForUpdate.
+func (mb *mutationBuilder) addUpdateColsForMerge(
+	whens tree.MergeWhens, actionColID opt.ColumnID,
+) {
+	// SET expressions should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require("UPDATE SET", tree.RejectSpecial)
+
+	// Determine the target columns of each UPDATE clause, and the value it
+	// assigns to each of them.
+	values := make([]map[int]tree.Expr, len(whens))
+	var targetOrds []int
+	var targeted opt.ColSet
+	for i, when := range whens {
+		if when.Action != tree.MergeActionUpdate {
+			continue
+		}
+		for _, set := range when.Exprs {
+			if _, ok := set.Expr.(*tree.Subquery); ok && set.Tuple {
+				panic(unimplemented.Newf("merge update subquery",
+					"subqueries are not supported in multiple-column MERGE UPDATE SET items"))
+			}
+		}
+		mb.targetColList = mb.targetColList[:0]
+		mb.targetColSet = opt.ColSet{}
+		mb.addTargetColsForUpdate(when.Exprs)
+
+		values[i] = make(map[int]tree.Expr, len(mb.targetColList))
+		n := 0
+		for _, set := range when.Exprs {
+			exprs := tree.Exprs{set.Expr}
+			if set.Tuple {
+				exprs = set.Expr.(*tree.Tuple).Exprs
+			}
+			for _, expr := range exprs {
+				colID := mb.targetColList[n]
+				ord := mb.tabID.ColumnOrdinal(colID)
+				if _, ok := expr.(tree.DefaultVal); ok {
+					expr = mb.parseDefaultExpr(colID)
+				} else if col := mb.tab.Column(ord); col.IsGeneratedAlwaysAsIdentity() {
+					panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnUpdateError(string(col.ColName())))
+				}
+				values[i][ord] = expr
+				if !targeted.Contains(colID) {
+					targeted.Add(colID)
+					targetOrds = append(targetOrds, ord)
+				}
+				n++
+			}
+		}
+	}
+	mb.targetColList = mb.targetColList[:0]
+	mb.targetColSet = opt.ColSet{}
+
+	inScope := mb.outScope
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	for _, ord := range targetOrds {
+		var cases memo.ScalarListExpr
+		for i := range whens {
+			if expr, ok := values[i][ord]; ok {
+				cases = append(cases, mb.b.factory.ConstructWhen(
+					mb.b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int),
+					mb.buildMergeValue(expr, inScope, ord),
+				))
+			}
+		}
+		existing := mb.b.factory.ConstructVariable(mb.fetchColIDs[ord])
+		mb.updateColIDs[ord] = mb.projectMergeValue(projectionsScope, ord, "_new", actionColID, cases, existing)
+	}
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Add additional columns for computed expressions that may depend on the
+	// updated columns.
+	mb.addSynthesizedColsForUpdate()
+}
+
+// buildMergeValue builds the given value for the target column with the given
+// ordinal, adding an assignment cast if the value does not have the type of the
+// column. The cast is added to each value rather than to the projected column,
+// since the CASE expression that selects the value requires all of its
+// branches to have the same type.
+func (mb *mutationBuilder) buildMergeValue(
+	expr tree.Expr, inScope *scope, ord int,
+) opt.ScalarExpr {
+	col := mb.tab.Column(ord)
+	colType := col.DatumType()
+	texpr := inScope.resolveType(expr, colType)
+	scalar := mb.b.buildScalar(texpr, inScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	if srcType := texpr.ResolvedType(); !srcType.Identical(colType) {
+		if !cast.ValidCast(srcType, colType, cast.ContextAssignment) {
+			panic(sqlerrors.NewInvalidAssignmentCastError(srcType, colType, string(col.ColName())))
+		}
+		scalar = mb.b.factory.ConstructAssignmentCast(scalar, colType)
+	}
+	return scalar
+}
+
+// projectMergeValue adds a column to the given scope that selects between the
+// given values for the target column with the given ordinal, using the action
+// column. It returns the ID of the new column.
+func (mb *mutationBuilder) projectMergeValue(
+	projectionsScope *scope,
+	ord int,
+	suffix string,
+	actionColID opt.ColumnID,
+	cases memo.ScalarListExpr,
+	orElse opt.ScalarExpr,
+) opt.ColumnID {
+	col := mb.tab.Column(ord)
+	colName := col.ColName()
+	scalar := mb.b.factory.ConstructCase(mb.b.factory.ConstructVariable(actionColID), cases, orElse)
+	scopeCol := mb.b.synthesizeColumn(
+		projectionsScope,
+		scopeColName(colName).WithMetadataName(string(colName)+suffix),
+		col.DatumType(),
+		nil, /* expr */
+		scalar,
+	)
+	return scopeCol.id
+}
+
+// projectMergeAction projects the given CASE expression, which computes the
+// position of the WHEN clause that applies to a row, as a new anonymous column
+// with the given metadata name. It returns the ID of the new column.
+func (mb *mutationBuilder) projectMergeAction(caseExpr *tree.CaseExpr, name string) opt.ColumnID {
+	// WHEN conditions should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require("MERGE WHEN", tree.RejectSpecial)
+
+	inScope := mb.outScope
+	inScope.context = exprKindNone
+	projectionsScope := inScope.replace()
+	projectionsScope.appendColumnsFromScope(inScope)
+	texpr := inScope.resolveAndRequireType(caseExpr, types.Int)
+	scopeCol := projectionsScope.addColumn(scopeColName("").WithMetadataName(name), texpr)
+	mb.b.buildScalar(texpr, inScope, projectionsScope, scopeCol, nil /* colRefs */)
+	colID := scopeCol.id
+	mb.b.constructProjectForScope(inScope, projectionsScope)
+	mb.outScope = projectionsScope
+	return colID
+}
+
+// filterMergeActions filters the rows of the input to those to which a WHEN
+// clause applies whose action modifies the target table.
+func (mb *mutationBuilder) filterMergeActions(whens tree.MergeWhens, actionColID opt.ColumnID) {
+	var actions tree.Exprs
+	for i, when := range whens {
+		if when.Action != tree.MergeActionDoNothing {
+			actions = append(actions, tree.NewDInt(tree.DInt(i+1)))
+		}
+	}
+	var filter tree.Expr = tree.DBoolFalse
+	if len(actions) > 0 {
+		filter = &tree.ComparisonExpr{
+			Operator: treecmp.MakeComparisonOperator(treecmp.In),
+			Left:     mb.outScope.getColumn(actionColID),
+			Right:    &tree.Tuple{Exprs: actions},
+		}
+	}
+	mb.b.buildWhere(&tree.Where{Type: tree.AstWhere, Expr: filter}, mb.outScope)
+}
+
+// buildMergeRowCount builds an expression that returns the number of rows
+// returned by the given expression, which is the number of rows affected by a
+// MERGE statement whose WHEN clauses are all DO NOTHING.
+func (b *Builder) buildMergeRowCount(inScope *scope, input memo.RelExpr) (outScope *scope) {
+	outScope = inScope.push()
+	countColID := b.factory.Metadata().AddColumn("count_rows", types.Int)
+	outScope.expr = b.factory.ConstructScalarGroupBy(
+		input,
+		memo.AggregationsExpr{
+			b.factory.ConstructAggregationsItem(b.factory.ConstructCountRows(), countColID),
+		},
+		memo.EmptyGroupingPrivate,
+	)
+	count := b.factory.ConstructVariable(countColID)
+	col := b.synthesizeColumn(outScope, scopeColName("count"), types.Int, nil /* expr */, count)
+	outScope.expr = b.factory.ConstructProject(
+		outScope.expr,
+		memo.ProjectionsExpr{b.factory.ConstructProjectionsItem(count, col.id)},
+		opt.ColSet{},
+	)
+	return outScope
+}
+
+// mergeActionCases returns the branches of a CASE expression that evaluates to
+// the position (starting at one) of the first WHEN MATCHED or WHEN NOT MATCHED
+// clause whose condition holds.
+func mergeActionCases(whens tree.MergeWhens, matched bool) []*tree.When {
+	var cases []*tree.When
+	for i, when := range whens {
+		if when.Matched != matched {
+			continue
+		}
+		cond := when.Cond
+		if cond == nil {
+			cond = tree.DBoolTrue
+		}
+		cases = append(cases, &tree.When{Cond: cond, Val: tree.NewDInt(tree.DInt(i + 1))})
+	}
+	return cases
+}
+
+// mergeActions returns whether any of the given WHEN clauses performs an
+// INSERT, UPDATE or DELETE action.
+func mergeActions(whens tree.MergeWhens) (hasInsert, hasUpdate, hasDelete bool) {
+	for _, when := range whens {
+		switch when.Action {
+		case tree.MergeActionInsert:
+			hasInsert = true
+		case tree.MergeActionUpdate:
+			hasUpdate = true
+		case tree.MergeActionDelete:
+			hasDelete = true
+		}
+	}
+	return hasInsert, hasUpdate, hasDelete
+}
@@ -1097,6 +1097,14 @@ func (mb *mutationBuilder) parseDefaultExpr(colID opt.ColumnID) tree.Expr {
 	col := mb.tab.Column(ord)
 	exprStr := col.DefaultExprStr()
 
+	// If the column has no default expression, but its type is a domain with a
+	// default expression, use the domain's default.
+	if typ := col.DatumType(); exprStr == "" && typ.IsDomain() {
+		if md := typ.TypeMeta.DomainData; md != nil && md.DefaultExpr != nil {
+			exprStr = *md.DefaultExpr
+		}
+	}
+
 	// If no default expression, return NULL or a default value.
 	if exprStr == "" {
 		if col.IsMutation() && !col.IsNullable() {
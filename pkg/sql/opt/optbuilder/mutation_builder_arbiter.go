@@ -81,6 +81,16 @@ func (mb *mutationBuilder) findArbiters(onConflict *tree.OnConflict) arbiterSet
 				if _, partial := constraint.Predicate(); partial {
 					panic(partialIndexArbiterError(onConflict, mb.tab.Name()))
 				}
+				if constraint.IsExclusion() {
+					panic(pgerror.Newf(pgcode.WrongObjectType,
+						"ON CONFLICT is not supported with exclusion constraints",
+					))
+				}
+				if constraint.Deferrability() != tree.ConstraintNotDeferrable {
+					panic(pgerror.Newf(pgcode.FeatureNotSupported,
+						"ON CONFLICT does not support deferrable unique constraints as arbiters",
+					))
+				}
 				return makeSingleUniqueConstraintArbiterSet(mb, i)
 			}
 		}
@@ -165,7 +175,11 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 			}
 		}
 		for uc, ucCount := 0, mb.tab.UniqueCount(); uc < ucCount; uc++ {
-			if mb.tab.Unique(uc).WithoutIndex() {
+			// Exclusion constraints and deferrable constraints cannot be used as
+			// arbiters, so conflicts with them are reported by the uniqueness
+			// checks instead.
+			if mb.tab.Unique(uc).WithoutIndex() && !mb.tab.Unique(uc).IsExclusion() &&
+				mb.tab.Unique(uc).Deferrability() == tree.ConstraintNotDeferrable {
 				arbiters.AddUniqueConstraint(uc)
 			}
 		}
@@ -223,6 +237,14 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 			// Unique constraints with an index were handled above.
 			continue
 		}
+		if uniqueConstraint.IsExclusion() {
+			// Exclusion constraints cannot be used as arbiters.
+			continue
+		}
+		if uniqueConstraint.Deferrability() != tree.ConstraintNotDeferrable {
+			// Deferrable constraints cannot be used as arbiters.
+			continue
+		}
 
 		// Determine whether the conflict columns match the columns in the
 		// unique constraint. If not, the constraint cannot be an arbiter. We
@@ -17,9 +17,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
 )
 
 // UniquenessChecksForGenRandomUUIDClusterMode controls the cluster setting for
@@ -33,7 +35,8 @@ var UniquenessChecksForGenRandomUUIDClusterMode = settings.RegisterBoolSetting(
 ).WithPublic()
 
 // buildUniqueChecksForInsert builds uniqueness check queries for an insert.
-// These check queries are used to enforce UNIQUE WITHOUT INDEX constraints.
+// These check queries are used to enforce UNIQUE WITHOUT INDEX constraints and
+// exclusion constraints.
 func (mb *mutationBuilder) buildUniqueChecksForInsert() {
 	// We only need to build unique checks if there is at least one unique
 	// constraint without an index.
@@ -197,6 +200,11 @@ type uniqueCheckHelper struct {
 	// UniqueConstraint.
 	uniqueOrdinals intsets.Fast
 
+	// exclusionOps maps the table ordinals in uniqueOrdinals to the operators
+	// used to compare them. It is nil unless the constraint is an exclusion
+	// constraint.
+	exclusionOps map[int]treecmp.ComparisonOperatorSymbol
+
 	// primaryKeyOrdinals includes the ordinals from any primary key columns
 	// that are not included in uniqueOrdinals.
 	primaryKeyOrdinals intsets.Fast
@@ -220,12 +228,24 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 		uniqueOrdinal: uniqueOrdinal,
 	}
 
-	var uniqueOrds intsets.Fast
+	// eqOrds are the ordinals of the columns that must be equal in conflicting
+	// rows. For unique constraints, these are all the columns.
+	var uniqueOrds, eqOrds intsets.Fast
 	for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
-		uniqueOrds.Add(h.unique.ColumnOrdinal(mb.tab, i))
+		ord := h.unique.ColumnOrdinal(mb.tab, i)
+		uniqueOrds.Add(ord)
+		if op := h.unique.ExclusionOperator(i); op == treecmp.EQ {
+			eqOrds.Add(ord)
+		} else {
+			if h.exclusionOps == nil {
+				h.exclusionOps = make(map[int]treecmp.ComparisonOperatorSymbol)
+			}
+			h.exclusionOps[ord] = op
+		}
 	}
 
-	// Find the primary key columns that are not part of the unique constraint.
+	// Find the primary key columns that are not part of the unique constraint
+	// (or, for exclusion constraints, that are not compared with "=").
 	// If there aren't any, we don't need a check.
 	// TODO(mgartner): We also don't need a check if there exists a unique index
 	// with columns that are a subset of the unique constraint columns.
@@ -233,7 +253,7 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// exists a non-partial unique constraint with columns that are a subset of
 	// the partial unique constraint columns.
 	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
-	primaryOrds.DifferenceWith(uniqueOrds)
+	primaryOrds.DifferenceWith(eqOrds)
 	if primaryOrds.Empty() {
 		// The primary key columns are a subset of the unique columns; unique check
 		// not needed.
@@ -254,7 +274,7 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 
 		// If one of the columns is a UUID set to gen_random_uuid() and we don't
 		// require uniqueness checks for gen_random_uuid(), unique check not needed.
-		if mb.md.ColumnMeta(colID).Type.Family() == types.UuidFamily &&
+		if eqOrds.Contains(tabOrd) && mb.md.ColumnMeta(colID).Type.Family() == types.UuidFamily &&
 			columnIsGenRandomUUID(mb.outScope.expr, colID) {
 			requireCheck := UniquenessChecksForGenRandomUUIDClusterMode.Get(&mb.b.evalCtx.Settings.SV)
 			if !requireCheck {
@@ -290,8 +310,14 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// However, because the region column is computed and depends only on k, the
 	// presence of the unique index on (region, k) (i.e., the primary index) is
 	// sufficient to guarantee the uniqueness of k.
+	//
+	// For exclusion constraints, only the columns compared with "=" are known
+	// to be equal in conflicting rows.
+	if eqOrds.Empty() {
+		return true
+	}
 	var uniqueCols opt.ColSet
-	h.uniqueOrdinals.ForEach(func(ord int) {
+	eqOrds.ForEach(func(ord int) {
 		colID := h.scanScope.cols[ord].id
 		uniqueCols.Add(colID)
 	})
@@ -315,6 +341,8 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 	// Build the join filters:
 	//   (new_a = existing_a) AND (new_b = existing_b) AND ...
 	//
+	// Exclusion constraints use the operator of each column instead of "=".
+	//
 	// Set the capacity to h.uniqueOrdinals.Len()+1 since we'll have an equality
 	// condition for each column in the unique constraint, plus one additional
 	// condition to prevent rows from matching themselves (see below). If the
@@ -328,7 +356,8 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 	semiJoinFilters := make(memo.FiltersExpr, 0, numFilters)
 	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
 		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
-			f.ConstructEq(
+			h.constructConflictCondition(
+				i,
 				f.ConstructVariable(withScanScope.cols[i].id),
 				f.ConstructVariable(h.scanScope.cols[i].id),
 			),
@@ -393,6 +422,31 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 	})
 }
 
+// constructConflictCondition builds the condition under which the new and
+// existing values of the column with the given table ordinal conflict.
+func (h *uniqueCheckHelper) constructConflictCondition(
+	ord int, newVal, existingVal opt.ScalarExpr,
+) opt.ScalarExpr {
+	f := h.mb.b.factory
+	op, ok := h.exclusionOps[ord]
+	if !ok {
+		return f.ConstructEq(newVal, existingVal)
+	}
+	switch op {
+	case treecmp.NE:
+		return f.ConstructNe(newVal, existingVal)
+	case treecmp.Overlaps:
+		fam := h.mb.tab.Column(ord).DatumType().Family()
+		if fam == types.GeometryFamily || fam == types.Box2DFamily {
+			return f.ConstructBBoxIntersects(newVal, existingVal)
+		}
+		return f.ConstructOverlaps(newVal, existingVal)
+	}
+	panic(errors.AssertionFailedf(
+		"unsupported operator %s in exclusion constraint %q", op, h.unique.Name(),
+	))
+}
+
 // buildTableScan builds a Scan of the table. The ordinals of the columns
 // scanned are also returned.
 func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
@@ -299,7 +299,7 @@ func ensureColumnOrderable(e tree.TypedExpr) {
 	switch typ.Family() {
 	case types.JsonFamily:
 		panic(unimplementedWithIssueDetailf(35706, "", "can't order by column type jsonb"))
-	case types.TSQueryFamily, types.TSVectorFamily:
+	case types.TSQueryFamily, types.TSVectorFamily, types.JsonpathFamily, types.PGVectorFamily:
 		panic(unimplementedWithIssueDetailf(92165, "", "can't order by column type %s", typ.SQLString()))
 	}
 }
@@ -0,0 +1,575 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/lib/pq/oid"
+)
+
+// plpgsqlValidator performs the checks on the body of a PL/pgSQL function that
+// Postgres performs during CREATE FUNCTION. Expressions and embedded SQL
+// statements are not type-checked; they are built when the function is
+// invoked. See plpgsqlBuilder.
+type plpgsqlValidator struct {
+	b *Builder
+
+	// returnsVoid is true if the routine is a procedure or a function that
+	// returns VOID.
+	returnsVoid bool
+	isProcedure bool
+
+	// scopes is the stack of variable scopes. Each scope maps the names of the
+	// variables it declares to whether they are CONSTANT.
+	scopes []map[string]bool
+
+	// labels is the stack of labels of the enclosing blocks and loops. The
+	// label of an unlabeled loop is empty.
+	labels []plpgsqlLabel
+
+	// typeDeps collects the user-defined types referenced by declarations.
+	typeDeps opt.SchemaTypeDeps
+}
+
+// plpgsqlLabel is a block or loop that encloses a statement.
+type plpgsqlLabel struct {
+	name   string
+	isLoop bool
+}
+
+// validatePLpgSQLBody validates the body of a PL/pgSQL function with the given
+// parameters and returns the user-defined types referenced by the
+// declarations in the body.
+func (b *Builder) validatePLpgSQLBody(
+	block *plpgsqltree.Block, params tree.FuncParams, retType *types.T, isProcedure bool,
+) opt.SchemaTypeDeps {
+	isTrigger := retType.Identical(types.Trigger)
+	v := plpgsqlValidator{
+		b:           b,
+		returnsVoid: isProcedure || (retType.Family() == types.VoidFamily && !isTrigger),
+		isProcedure: isProcedure,
+	}
+	outer := map[string]bool{"found": false}
+	for i := range params {
+		if params[i].Name != "" {
+			outer[string(params[i].Name)] = false
+		}
+	}
+	if isTrigger {
+		for _, name := range triggerFuncParamNames {
+			outer[name] = false
+		}
+	}
+	v.scopes = append(v.scopes, outer)
+	v.validateBlock(block)
+	return v.typeDeps
+}
+
+func (v *plpgsqlValidator) validateBlock(block *plpgsqltree.Block) {
+	scope := make(map[string]bool, len(block.Decls))
+	for i := range block.Decls {
+		decl := &block.Decls[i]
+		typ, err := tree.ResolveType(v.b.ctx, decl.Typ, v.b.semaCtx.TypeResolver)
+		if err != nil {
+			panic(err)
+		}
+		typedesc.GetTypeDescriptorClosure(typ).ForEach(func(id descpb.ID) {
+			v.typeDeps.Add(int(id))
+		})
+		scope[string(decl.Var)] = decl.Constant
+	}
+	v.scopes = append(v.scopes, scope)
+	v.labels = append(v.labels, plpgsqlLabel{name: block.Label})
+	v.validateStmts(block.Body)
+	for i := range block.Exceptions {
+		exc := &block.Exceptions[i]
+		for _, cond := range exc.Conditions {
+			if cond.SQLErrName != "" && cond.SQLErrName != "others" {
+				v.validateConditionName(cond.SQLErrName)
+			}
+		}
+		v.scopes = append(v.scopes, map[string]bool{"sqlstate": false, "sqlerrm": false})
+		v.validateStmts(exc.Body)
+		v.scopes = v.scopes[:len(v.scopes)-1]
+	}
+	v.labels = v.labels[:len(v.labels)-1]
+	v.scopes = v.scopes[:len(v.scopes)-1]
+}
+
+func (v *plpgsqlValidator) validateStmts(stmts []plpgsqltree.Statement) {
+	for _, stmt := range stmts {
+		v.validateStmt(stmt)
+	}
+}
+
+func (v *plpgsqlValidator) validateStmt(stmt plpgsqltree.Statement) {
+	switch t := stmt.(type) {
+	case *plpgsqltree.Block:
+		v.validateBlock(t)
+
+	case *plpgsqltree.Assignment:
+		v.validateTarget(t.Var)
+
+	case *plpgsqltree.If:
+		v.validateStmts(t.ThenBody)
+		for i := range t.ElseIfList {
+			v.validateStmts(t.ElseIfList[i].Body)
+		}
+		v.validateStmts(t.ElseBody)
+
+	case *plpgsqltree.Loop:
+		v.validateLoop(t.Label, nil /* scope */, t.Body)
+
+	case *plpgsqltree.While:
+		v.validateLoop(t.Label, nil /* scope */, t.Body)
+
+	case *plpgsqltree.ForInt:
+		v.validateLoop(t.Label, map[string]bool{string(t.Var): false}, t.Body)
+
+	case *plpgsqltree.ForQuery:
+		v.validateSQL(t.Query)
+		for _, target := range t.Targets {
+			v.validateTarget(target)
+		}
+		v.validateLoop(t.Label, nil /* scope */, t.Body)
+
+	case *plpgsqltree.Exit:
+		if t.Label != "" {
+			v.findLabel(t.Label)
+		} else if !v.insideLoop() {
+			panic(pgerror.New(pgcode.Syntax, "EXIT cannot be used outside a loop, unless it has a label"))
+		}
+
+	case *plpgsqltree.Continue:
+		if t.Label != "" {
+			if !v.findLabel(t.Label).isLoop {
+				panic(pgerror.Newf(pgcode.Syntax, "block label %q cannot be used in CONTINUE", t.Label))
+			}
+		} else if !v.insideLoop() {
+			panic(pgerror.New(pgcode.Syntax, "CONTINUE cannot be used outside a loop"))
+		}
+
+	case *plpgsqltree.Return:
+		switch {
+		case t.Expr != nil && v.isProcedure:
+			panic(pgerror.New(pgcode.DatatypeMismatch, "RETURN cannot have a parameter in a procedure"))
+		case t.Expr != nil && v.returnsVoid:
+			panic(pgerror.New(pgcode.DatatypeMismatch,
+				"RETURN cannot have a parameter in function returning void"))
+		case t.Expr == nil && !v.returnsVoid:
+			panic(pgerror.New(pgcode.Syntax, "missing expression at or near \"RETURN\""))
+		}
+
+	case *plpgsqltree.ReturnNext:
+		panic(pgerror.New(pgcode.DatatypeMismatch, "cannot use RETURN NEXT in a non-SETOF function"))
+
+	case *plpgsqltree.ReturnQuery:
+		panic(pgerror.New(pgcode.DatatypeMismatch, "cannot use RETURN QUERY in a non-SETOF function"))
+
+	case *plpgsqltree.Raise:
+		if t.CodeName != "" {
+			v.validateConditionName(t.CodeName)
+		}
+
+	case *plpgsqltree.Perform:
+		v.validateSQL(t.Query)
+
+	case *plpgsqltree.ExecSQL:
+		v.validateSQL(t.SQL)
+		for _, target := range t.Into {
+			v.validateTarget(target)
+		}
+	}
+}
+
+func (v *plpgsqlValidator) validateLoop(
+	label string, scope map[string]bool, body []plpgsqltree.Statement,
+) {
+	if scope != nil {
+		v.scopes = append(v.scopes, scope)
+		defer func() { v.scopes = v.scopes[:len(v.scopes)-1] }()
+	}
+	v.labels = append(v.labels, plpgsqlLabel{name: label, isLoop: true})
+	v.validateStmts(body)
+	v.labels = v.labels[:len(v.labels)-1]
+}
+
+// validateTarget checks that the target of an assignment is a variable that
+// can be assigned.
+func (v *plpgsqlValidator) validateTarget(name tree.Name) {
+	for i := len(v.scopes) - 1; i >= 0; i-- {
+		if constant, ok := v.scopes[i][string(name)]; ok {
+			if constant {
+				panic(pgerror.Newf(pgcode.Syntax, "variable %q is declared CONSTANT", name))
+			}
+			return
+		}
+	}
+	panic(pgerror.Newf(pgcode.Syntax, "%q is not a known variable", name))
+}
+
+// validateSQL checks that a SQL statement embedded in the body can be
+// executed by a PL/pgSQL function.
+func (v *plpgsqlValidator) validateSQL(stmt tree.Statement) {
+	if tree.CanModifySchema(stmt) {
+		panic(unimplemented.NewWithIssue(17511,
+			"schema changes in PL/pgSQL functions are not yet supported"))
+	}
+}
+
+func (v *plpgsqlValidator) validateConditionName(name string) {
+	if _, ok := pgcode.FromConditionName(name); !ok {
+		panic(pgerror.Newf(pgcode.UndefinedObject, "unrecognized exception condition %q", name))
+	}
+}
+
+// findLabel returns the enclosing block or loop with the given label.
+func (v *plpgsqlValidator) findLabel(name string) plpgsqlLabel {
+	for i := len(v.labels) - 1; i >= 0; i-- {
+		if v.labels[i].name == name {
+			return v.labels[i]
+		}
+	}
+	panic(pgerror.Newf(pgcode.Syntax,
+		"there is no label %q attached to any block or loop enclosing this statement", name))
+}
+
+func (v *plpgsqlValidator) insideLoop() bool {
+	for i := range v.labels {
+		if v.labels[i].isLoop {
+			return true
+		}
+	}
+	return false
+}
+
+// plpgsqlBuilder builds the expressions and SQL statements embedded in the
+// body of a PL/pgSQL function into relational expressions, like the statements
+// in the body of a SQL function. The control flow of the body is executed by
+// the execution engine, which plans and runs the built statements as they are
+// reached.
+//
+// Each variable of the body is represented by a column. A reference to a
+// variable in an embedded statement is replaced with a reference to its
+// column, and the column is replaced with the current value of the variable
+// when the statement is planned. Like in the interpreter of the execution
+// engine, a name that refers to a variable is never resolved as a column of a
+// table.
+type plpgsqlBuilder struct {
+	b    *Builder
+	body *memo.PLpgSQLBody
+
+	// params are the columns of the parameters of the function, in order. They
+	// are referenced by placeholders like $1.
+	params []*scopeColumn
+
+	// scopes is the stack of variable scopes, innermost last.
+	scopes [][]*scopeColumn
+
+	// stmts are the statements built for the embedded expressions and SQL
+	// statements.
+	stmts memo.RelListExpr
+}
+
+// buildPLpgSQLBody builds the embedded expressions and SQL statements of the
+// body of the PL/pgSQL function with the given OID. The parameters of the
+// function have the given names and columns, and outParams are the ordinals of
+// the OUT and INOUT parameters of a procedure. It returns the body along with
+// the built statements, which become the Body of the UDF.
+func (b *Builder) buildPLpgSQLBody(
+	funcOid oid.Oid,
+	block *plpgsqltree.Block,
+	paramNames []string,
+	paramCols []scopeColumn,
+	outParams []int,
+	trigger bool,
+) (*memo.PLpgSQLBody, memo.RelListExpr) {
+	// The statements are built when the function is invoked, so a function that
+	// invokes itself, directly or through another function or a trigger, would
+	// be built forever.
+	if _, ok := b.buildingPLpgSQLFuncs[funcOid]; ok {
+		panic(unimplemented.Newf("plpgsql-recursion", "recursive PL/pgSQL functions are not supported"))
+	}
+	if b.buildingPLpgSQLFuncs == nil {
+		b.buildingPLpgSQLFuncs = make(map[oid.Oid]struct{})
+	}
+	b.buildingPLpgSQLFuncs[funcOid] = struct{}{}
+	defer delete(b.buildingPLpgSQLFuncs, funcOid)
+
+	pb := plpgsqlBuilder{
+		b: b,
+		body: &memo.PLpgSQLBody{
+			Block:      block,
+			ParamNames: paramNames,
+			Trigger:    trigger,
+			OutParams:  outParams,
+			VarOrds:    make(map[interface{}]int),
+			Stmts:      make(map[memo.PLpgSQLStmtKey]int),
+		},
+	}
+	for i := range paramCols {
+		pb.body.Vars = append(pb.body.Vars, paramCols[i].id)
+		pb.params = append(pb.params, &paramCols[i])
+	}
+	// The parameters shadow the FOUND variable.
+	outer := append([]*scopeColumn{pb.addVar("found", types.Bool)}, pb.params...)
+	pb.scopes = append(pb.scopes, outer)
+	pb.buildBlock(block)
+	return pb.body, pb.stmts
+}
+
+// addVar adds a column for a variable with the given name and type.
+func (pb *plpgsqlBuilder) addVar(name string, typ *types.T) *scopeColumn {
+	col := &scopeColumn{name: scopeColName(tree.Name(name)), typ: typ}
+	col.id = pb.b.factory.Metadata().AddColumn(name, typ)
+	pb.body.Vars = append(pb.body.Vars, col.id)
+	return col
+}
+
+func (pb *plpgsqlBuilder) buildBlock(block *plpgsqltree.Block) {
+	// Each declaration is in the scope of the following ones.
+	pb.scopes = append(pb.scopes, nil)
+	defer func() { pb.scopes = pb.scopes[:len(pb.scopes)-1] }()
+	for i := range block.Decls {
+		decl := &block.Decls[i]
+		typ, err := tree.ResolveType(pb.b.ctx, decl.Typ, pb.b.semaCtx.TypeResolver)
+		if err != nil {
+			panic(err)
+		}
+		if decl.Expr != nil {
+			pb.buildExpr(decl, 0 /* ord */, decl.Expr)
+		}
+		pb.body.VarOrds[decl] = len(pb.body.Vars)
+		pb.scopes[len(pb.scopes)-1] = append(pb.scopes[len(pb.scopes)-1], pb.addVar(string(decl.Var), typ))
+	}
+	pb.buildStmts(block.Body)
+	for i := range block.Exceptions {
+		exc := &block.Exceptions[i]
+		pb.body.VarOrds[exc] = len(pb.body.Vars)
+		pb.scopes = append(pb.scopes, []*scopeColumn{
+			pb.addVar("sqlstate", types.String),
+			pb.addVar("sqlerrm", types.String),
+		})
+		pb.buildStmts(exc.Body)
+		pb.scopes = pb.scopes[:len(pb.scopes)-1]
+	}
+}
+
+func (pb *plpgsqlBuilder) buildStmts(stmts []plpgsqltree.Statement) {
+	for _, stmt := range stmts {
+		pb.buildStmt(stmt)
+	}
+}
+
+func (pb *plpgsqlBuilder) buildStmt(stmt plpgsqltree.Statement) {
+	switch t := stmt.(type) {
+	case *plpgsqltree.Block:
+		pb.buildBlock(t)
+
+	case *plpgsqltree.Assignment:
+		pb.buildExpr(t, 0 /* ord */, t.Value)
+
+	case *plpgsqltree.If:
+		pb.buildExpr(t, 0 /* ord */, t.Condition)
+		pb.buildStmts(t.ThenBody)
+		for i := range t.ElseIfList {
+			pb.buildExpr(&t.ElseIfList[i], 0 /* ord */, t.ElseIfList[i].Condition)
+			pb.buildStmts(t.ElseIfList[i].Body)
+		}
+		pb.buildStmts(t.ElseBody)
+
+	case *plpgsqltree.Loop:
+		pb.buildStmts(t.Body)
+
+	case *plpgsqltree.While:
+		pb.buildExpr(t, 0 /* ord */, t.Condition)
+		pb.buildStmts(t.Body)
+
+	case *plpgsqltree.ForInt:
+		// The bounds are evaluated before the loop variable is in scope.
+		pb.buildExpr(t, 0 /* ord */, t.Lower)
+		pb.buildExpr(t, 1 /* ord */, t.Upper)
+		if t.Step != nil {
+			pb.buildExpr(t, 2 /* ord */, t.Step)
+		}
+		pb.body.VarOrds[t] = len(pb.body.Vars)
+		pb.scopes = append(pb.scopes, []*scopeColumn{pb.addVar(string(t.Var), types.Int)})
+		pb.buildStmts(t.Body)
+		pb.scopes = pb.scopes[:len(pb.scopes)-1]
+
+	case *plpgsqltree.ForQuery:
+		pb.buildSQL(t, t.Query)
+		pb.buildStmts(t.Body)
+
+	case *plpgsqltree.Exit:
+		if t.Condition != nil {
+			pb.buildExpr(t, 0 /* ord */, t.Condition)
+		}
+
+	case *plpgsqltree.Continue:
+		if t.Condition != nil {
+			pb.buildExpr(t, 0 /* ord */, t.Condition)
+		}
+
+	case *plpgsqltree.Return:
+		if t.Expr != nil {
+			pb.buildExpr(t, 0 /* ord */, t.Expr)
+		}
+
+	case *plpgsqltree.Raise:
+		for i := range t.Params {
+			pb.buildExpr(t, i, t.Params[i])
+		}
+		for i := range t.Options {
+			pb.buildExpr(&t.Options[i], 0 /* ord */, t.Options[i].Expr)
+		}
+
+	case *plpgsqltree.Perform:
+		pb.buildSQL(t, t.Query)
+
+	case *plpgsqltree.ExecSQL:
+		// Transaction control statements and statements whose results have no
+		// destination fail when they are reached, so they are not built.
+		if t.SQL.StatementType() == tree.TypeTCL ||
+			(len(t.Into) == 0 && t.SQL.StatementReturnType() == tree.Rows) {
+			return
+		}
+		pb.buildSQL(t, t.SQL)
+	}
+}
+
+// buildExpr builds an expression of the body as a query that returns its
+// value.
+func (pb *plpgsqlBuilder) buildExpr(stmt interface{}, ord int, expr tree.Expr) {
+	sel := &tree.Select{Select: &tree.SelectClause{Exprs: tree.SelectExprs{{Expr: expr}}}}
+	pb.buildSQLWithOrd(stmt, ord, sel)
+}
+
+// buildSQL builds a SQL statement of the body.
+func (pb *plpgsqlBuilder) buildSQL(stmt interface{}, sql tree.Statement) {
+	pb.buildSQLWithOrd(stmt, 0 /* ord */, sql)
+}
+
+func (pb *plpgsqlBuilder) buildSQLWithOrd(stmt interface{}, ord int, sql tree.Statement) {
+	switch sql.(type) {
+	case *tree.Prepare, *tree.Execute, *tree.CopyFrom, tree.ObserverStatement:
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported in PL/pgSQL functions", sql.StatementTag()))
+	}
+	sql = pb.resolveVars(sql)
+
+	// Each statement is built like a separate top-level statement: it has its
+	// own CTEs and may modify the tables modified by other statements.
+	b := pb.b
+	prevSubquery, prevMutations := b.subquery, b.areAllTableMutationsSimpleInserts
+	b.subquery, b.areAllTableMutationsSimpleInserts = nil, nil
+	defer func() {
+		b.subquery, b.areAllTableMutationsSimpleInserts = prevSubquery, prevMutations
+	}()
+	stmtScope := b.buildStmtAtRoot(sql, nil /* desiredTypes */)
+
+	pb.body.Stmts[memo.PLpgSQLStmtKey{Stmt: stmt, Ord: ord}] = len(pb.stmts)
+	pb.stmts = append(pb.stmts, memo.RelRequiredPropsExpr{
+		RelExpr:   stmtScope.expr,
+		PhysProps: stmtScope.makePhysicalProps(),
+	})
+}
+
+// lookup returns the column of the variable with the given name that is
+// visible in the current scope, or nil if there is none.
+func (pb *plpgsqlBuilder) lookup(name string) *scopeColumn {
+	for i := len(pb.scopes) - 1; i >= 0; i-- {
+		scope := pb.scopes[i]
+		for j := len(scope) - 1; j >= 0; j-- {
+			if string(scope[j].name.ReferenceName()) == name {
+				return scope[j]
+			}
+		}
+	}
+	return nil
+}
+
+// resolveVars replaces the references to variables and parameters in a
+// statement with references to their columns.
+func (pb *plpgsqlBuilder) resolveVars(stmt tree.Statement) tree.Statement {
+	newStmt, err := tree.SimpleStmtVisit(stmt, func(expr tree.Expr) (bool, tree.Expr, error) {
+		switch t := expr.(type) {
+		case *tree.UnresolvedName:
+			if t.NumParts == 1 && !t.Star {
+				if col := pb.lookup(t.Parts[0]); col != nil {
+					return false, col, nil
+				}
+			}
+			if t.NumParts == 2 && !t.Star {
+				// A reference to a field of a variable of a composite type, like
+				// NEW.x in a trigger function.
+				if col := pb.lookup(t.Parts[1]); col != nil {
+					if !tupleHasField(col.typ, t.Parts[0]) {
+						return false, nil, pgerror.Newf(pgcode.UndefinedColumn,
+							"record \"%s\" has no field \"%s\"", t.Parts[1], t.Parts[0])
+					}
+					return false, &tree.ColumnAccessExpr{Expr: col, ColName: tree.Name(t.Parts[0])}, nil
+				}
+			}
+		case *tree.IndirectionExpr:
+			// TG_ARGV is indexed from 0, unlike other arrays.
+			if name, ok := t.Expr.(*tree.UnresolvedName); ok && pb.body.Trigger &&
+				name.NumParts == 1 && name.Parts[0] == "tg_argv" && len(t.Indirection) == 1 &&
+				!t.Indirection[0].Slice {
+				return true, &tree.IndirectionExpr{
+					Expr: t.Expr,
+					Indirection: tree.ArraySubscripts{{
+						Begin: &tree.BinaryExpr{
+							Operator: treebin.MakeBinaryOperator(treebin.Plus),
+							Left:     &tree.ParenExpr{Expr: t.Indirection[0].Begin},
+							Right:    tree.NewDInt(1),
+						},
+					}},
+				}, nil
+			}
+		case *tree.Placeholder:
+			if int(t.Idx) >= len(pb.params) {
+				return false, nil, pgerror.Newf(pgcode.UndefinedParameter,
+					"there is no parameter %s", t)
+			}
+			return false, pb.params[t.Idx], nil
+		}
+		return true, expr, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	return newStmt
+}
+
+// tupleHasField returns true if typ is a labeled tuple type with a field with
+// the given name.
+func tupleHasField(typ *types.T, field string) bool {
+	if typ.Family() != types.TupleFamily {
+		return false
+	}
+	for _, label := range typ.TupleLabels() {
+		if label == field {
+			return true
+		}
+	}
+	return false
+}
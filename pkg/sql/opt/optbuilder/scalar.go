@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -548,6 +549,12 @@ func (b *Builder) buildFunction(
 	}
 
 	overload := f.ResolvedOverload()
+	if overload.IsProcedure {
+		panic(errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s() is a procedure", def.Name),
+			"To call a procedure, use CALL.",
+		))
+	}
 	if overload.IsUDF {
 		return b.buildUDF(f, def, inScope, outScope, outCol, colRefs)
 	}
@@ -619,6 +626,10 @@ func (b *Builder) buildUDF(
 	colRefs *opt.ColSet,
 ) (out opt.ScalarExpr) {
 	o := f.ResolvedOverload()
+	if f.ResolvedType().Identical(types.Trigger) {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"trigger functions can only be called as triggers"))
+	}
 
 	// Build the argument expressions.
 	var args memo.ScalarListExpr
@@ -632,6 +643,12 @@ func (b *Builder) buildUDF(
 				nil, /* outCol */
 				colRefs,
 			)
+			// Arguments passed to a parameter with a domain type must satisfy
+			// the domain's constraints, which are checked by the cast.
+			if typ := o.Types.GetAt(i); typ != nil && typ.IsDomain() &&
+				!args[i].DataType().Identical(typ) {
+				args[i] = b.factory.ConstructCast(args[i], typ)
+			}
 		}
 	}
 
@@ -646,29 +663,134 @@ func (b *Builder) buildUDF(
 	bodyScope := b.allocScope()
 	var params opt.ColList
 	if o.Types.Length() > 0 {
-		paramTypes, ok := o.Types.(tree.ParamTypes)
-		if !ok {
-			panic(unimplemented.NewWithIssue(88947,
-				"variadiac user-defined functions are not yet supported"))
+		var paramTypes tree.ParamTypes
+		switch t := o.Types.(type) {
+		case tree.ParamTypes:
+			paramTypes = t
+		case tree.VariadicParamTypes:
+			// Pack the arguments passed to the VARIADIC parameter into an array.
+			paramTypes = t.ParamTypes
+			n := len(paramTypes) - 1
+			elemType := args[n].DataType()
+			for _, arg := range args[n:] {
+				if arg.DataType().Family() != types.UnknownFamily {
+					elemType = arg.DataType()
+					break
+				}
+			}
+			arrayType := types.MakeArray(elemType)
+			if !tree.IsPolymorphicType(paramTypes[n].Typ) {
+				arrayType = paramTypes[n].Typ
+			}
+			elems := make(memo.ScalarListExpr, len(args)-n)
+			for i, arg := range args[n:] {
+				if arg.DataType().Family() == types.UnknownFamily {
+					arg = b.factory.ConstructNull(arrayType.ArrayContents())
+				}
+				elems[i] = arg
+			}
+			args = append(args[:n:n], b.factory.ConstructArray(elems, arrayType))
+		default:
+			panic(errors.AssertionFailedf("unexpected parameter types %T", o.Types))
 		}
+
+		// Resolve the types of polymorphic parameters from the arguments.
+		var elemType *types.T
+		argTypes := make([]*types.T, len(args))
+		for i := range args {
+			argTypes[i] = args[i].DataType()
+		}
+		for i := range paramTypes {
+			if !tree.IsPolymorphicType(paramTypes[i].Typ) {
+				continue
+			}
+			var err error
+			if elemType, err = tree.ResolvePolymorphicType(paramTypes, argTypes); err != nil {
+				panic(err)
+			}
+			if elemType == nil {
+				panic(pgerror.New(pgcode.DatatypeMismatch,
+					"could not determine polymorphic type because input has type unknown"))
+			}
+			break
+		}
+
 		params = make(opt.ColList, len(paramTypes))
 		for i := range paramTypes {
 			paramType := &paramTypes[i]
+			typ := paramType.Typ
+			if elemType != nil {
+				typ = tree.InstantiatePolymorphicType(typ, elemType)
+			}
 			argColName := funcParamColName(tree.Name(paramType.Name), i)
-			col := b.synthesizeColumn(bodyScope, argColName, paramType.Typ, nil /* expr */, nil /* scalar */)
+			col := b.synthesizeColumn(bodyScope, argColName, typ, nil /* expr */, nil /* scalar */)
 			col.setParamOrd(i)
 			params[i] = col.id
 		}
 	}
 
-	// Parse the function body.
-	stmts, err := parser.Parse(o.Body)
-	if err != nil {
-		panic(err)
+	// A procedure with OUT or INOUT parameters returns a row with their values.
+	// The arguments passed to OUT parameters are ignored.
+	typ := f.ResolvedType()
+	var outParams []int
+	if o.ParamClasses != nil {
+		var contents []*types.T
+		var labels []string
+		for i, class := range o.ParamClasses {
+			if class != tree.FunctionParamOut && class != tree.FunctionParamInOut {
+				continue
+			}
+			col := &bodyScope.cols[i]
+			if class == tree.FunctionParamOut {
+				args[i] = b.factory.ConstructNull(col.typ)
+			}
+			outParams = append(outParams, i)
+			contents = append(contents, col.typ)
+			label := string(col.name.ReferenceName())
+			if label == "" {
+				label = fmt.Sprintf("column%d", len(labels)+1)
+			}
+			labels = append(labels, label)
+		}
+		typ = types.MakeLabeledTuple(contents, labels)
+	}
+
+	// The embedded expressions and SQL statements of a PL/pgSQL function are
+	// built by plpgsqlBuilder. The statements of a SQL function are built
+	// below.
+	var stmts parser.Statements
+	var plpgsqlBody *memo.PLpgSQLBody
+	var rels memo.RelListExpr
+	if o.Language == tree.FunctionLangPLpgSQL {
+		block, err := plpgsqlparser.Parse(o.Body)
+		if err != nil {
+			panic(err)
+		}
+		var paramTypes tree.ParamTypes
+		switch t := o.Types.(type) {
+		case tree.ParamTypes:
+			paramTypes = t
+		case tree.VariadicParamTypes:
+			paramTypes = t.ParamTypes
+		}
+		paramNames := make([]string, len(paramTypes))
+		for i := range paramTypes {
+			paramNames[i] = paramTypes[i].Name
+		}
+		plpgsqlBody, rels = b.buildPLpgSQLBody(
+			o.Oid, block, paramNames, bodyScope.cols, outParams, false, /* trigger */
+		)
+	} else {
+		// Parse the function body.
+		var err error
+		stmts, err = parser.Parse(o.Body)
+		if err != nil {
+			panic(err)
+		}
+		rels = make(memo.RelListExpr, len(stmts))
 	}
 
 	// Build an expression for each statement in the function body.
-	rels := make(memo.RelListExpr, len(stmts))
 	for i := range stmts {
 		stmtScope := b.buildStmt(stmts[i].AST, nil /* desiredTypes */, bodyScope)
 		expr := stmtScope.expr
@@ -677,7 +799,11 @@ func (b *Builder) buildUDF(
 		// Add a LIMIT 1 to the last statement. This is valid because any other
 		// rows after the first can simply be ignored. The limit could be
 		// beneficial because it could allow additional optimization.
-		if i == len(stmts)-1 {
+		//
+		// The result of the last statement of a function that returns VOID is
+		// discarded, so the statement is left as-is and run to completion like
+		// the preceding ones.
+		if i == len(stmts)-1 && typ.Family() != types.VoidFamily {
 			b.buildLimit(&tree.Limit{Count: tree.NewDInt(1)}, b.allocScope(), stmtScope)
 			expr = stmtScope.expr
 			// The limit expression will maintain the desired ordering, if any,
@@ -689,15 +815,17 @@ func (b *Builder) buildUDF(
 			physProps.Ordering = props.OrderingChoice{}
 
 			// If there are multiple output columns, we must combine them into a
-			// tuple - only a single column can be returned from a UDF.
-			if cols := physProps.Presentation; len(cols) > 1 {
+			// tuple - only a single column can be returned from a UDF. The
+			// columns returned by a procedure are always combined into the row
+			// of its OUT parameters.
+			if cols := physProps.Presentation; len(cols) > 1 || outParams != nil {
 				elems := make(memo.ScalarListExpr, len(cols))
 				for i := range cols {
 					elems[i] = b.factory.ConstructVariable(cols[i].ID)
 				}
-				tup := b.factory.ConstructTuple(elems, f.ResolvedType())
+				tup := b.factory.ConstructTuple(elems, typ)
 				stmtScope = bodyScope.push()
-				col := b.synthesizeColumn(stmtScope, scopeColName(""), f.ResolvedType(), nil /* expr */, tup)
+				col := b.synthesizeColumn(stmtScope, scopeColName(""), typ, nil /* expr */, tup)
 				expr = b.constructProject(expr, []scopeColumn{*col})
 				physProps = stmtScope.makePhysicalProps()
 			}
@@ -706,17 +834,17 @@ func (b *Builder) buildUDF(
 			// its type matches the function return type.
 			returnCol := physProps.Presentation[0].ID
 			returnColMeta := b.factory.Metadata().ColumnMeta(returnCol)
-			if !returnColMeta.Type.Identical(f.ResolvedType()) {
-				if !cast.ValidCast(returnColMeta.Type, f.ResolvedType(), cast.ContextAssignment) {
+			if !returnColMeta.Type.Identical(typ) {
+				if !cast.ValidCast(returnColMeta.Type, typ, cast.ContextAssignment) {
 					panic(sqlerrors.NewInvalidAssignmentCastError(
-						returnColMeta.Type, f.ResolvedType(), returnColMeta.Alias))
+						returnColMeta.Type, typ, returnColMeta.Alias))
 				}
 				cast := b.factory.ConstructAssignmentCast(
 					b.factory.ConstructVariable(physProps.Presentation[0].ID),
-					f.ResolvedType(),
+					typ,
 				)
 				stmtScope = bodyScope.push()
-				col := b.synthesizeColumn(stmtScope, scopeColName(""), f.ResolvedType(), nil /* expr */, cast)
+				col := b.synthesizeColumn(stmtScope, scopeColName(""), typ, nil /* expr */, cast)
 				expr = b.constructProject(expr, []scopeColumn{*col})
 				physProps = stmtScope.makePhysicalProps()
 			}
@@ -734,7 +862,8 @@ func (b *Builder) buildUDF(
 			Name:       def.Name,
 			Params:     params,
 			Body:       rels,
-			Typ:        f.ResolvedType(),
+			PLpgSQL:    plpgsqlBody,
+			Typ:        typ,
 			Volatility: o.Volatility,
 		},
 	)
@@ -747,6 +876,10 @@ func (b *Builder) buildUDF(
 	if !o.CalledOnNullInput {
 		var anyArgIsNull opt.ScalarExpr
 		for i := range args {
+			// The arguments passed to OUT parameters are always NULL.
+			if o.ParamClasses != nil && o.ParamClasses[i] == tree.FunctionParamOut {
+				continue
+			}
 			// Note: We do NOT use a TupleIsNullExpr here if the argument is a
 			// tuple because a strict UDF will be called if an argument, T, is a
 			// tuple with all NULL elements, even though T IS NULL evaluates to
@@ -767,7 +900,7 @@ func (b *Builder) buildUDF(
 			memo.ScalarListExpr{
 				b.factory.ConstructWhen(
 					anyArgIsNull,
-					b.factory.ConstructNull(f.ResolvedType()),
+					b.factory.ConstructNull(typ),
 				),
 			},
 			out,
@@ -950,7 +1083,14 @@ func (b *Builder) constructComparison(
 		}
 		return b.factory.ConstructOverlaps(left, right)
 	case treecmp.TSMatches:
+		if cmp.Op.LeftType.Family() == types.JsonFamily {
+			// The @@ operator means "jsonpath predicate check" when used with jsonb
+			// and jsonpath operands.
+			return b.factory.ConstructJsonPathMatch(left, right)
+		}
 		return b.factory.ConstructTSMatches(left, right)
+	case treecmp.JSONPathExists:
+		return b.factory.ConstructJsonPathExists(left, right)
 	}
 	panic(errors.AssertionFailedf("unhandled comparison operator: %s", redact.Safe(cmp.Operator)))
 }
@@ -993,6 +1133,12 @@ func (b *Builder) constructBinary(
 		return b.factory.ConstructFetchValPath(left, right)
 	case treebin.JSONFetchTextPath:
 		return b.factory.ConstructFetchTextPath(left, right)
+	case treebin.Distance:
+		return b.factory.ConstructDistance(left, right)
+	case treebin.CosDistance:
+		return b.factory.ConstructCosDistance(left, right)
+	case treebin.NegInnerProduct:
+		return b.factory.ConstructNegInnerProduct(left, right)
 	}
 	panic(errors.AssertionFailedf("unhandled binary operator: %s", redact.Safe(bin)))
 }
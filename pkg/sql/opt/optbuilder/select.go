@@ -64,7 +64,12 @@ func (b *Builder) buildDataSource(
 			locking = locking.filter(source.As.Alias)
 		}
 
-		outScope = b.buildDataSource(source.Expr, indexFlags, locking, inScope)
+		if tn, ok := source.Expr.(*tree.TableName); ok && source.Only {
+			// ONLY excludes the tables that inherit from the table.
+			outScope = b.buildTableName(tn, indexFlags, locking, inScope, false /* inherit */)
+		} else {
+			outScope = b.buildDataSource(source.Expr, indexFlags, locking, inScope)
+		}
 
 		if source.Ordinality {
 			outScope = b.buildWithOrdinality(outScope)
@@ -79,67 +84,7 @@ func (b *Builder) buildDataSource(
 		return b.buildJoin(source, locking, inScope)
 
 	case *tree.TableName:
-		tn := source
-
-		// CTEs take precedence over other data sources.
-		if cte := inScope.resolveCTE(tn); cte != nil {
-			locking.ignoreLockingForCTE()
-			outScope = inScope.push()
-			inCols := make(opt.ColList, len(cte.cols), len(cte.cols)+len(inScope.ordering))
-			outCols := make(opt.ColList, len(cte.cols), len(cte.cols)+len(inScope.ordering))
-			outScope.cols, outScope.extraCols = nil, nil
-			for i, col := range cte.cols {
-				id := col.ID
-				c := b.factory.Metadata().ColumnMeta(id)
-				newCol := b.synthesizeColumn(outScope, scopeColName(tree.Name(col.Alias)), c.Type, nil, nil)
-				newCol.table = *tn
-				inCols[i] = id
-				outCols[i] = newCol.id
-			}
-
-			outScope.expr = b.factory.ConstructWithScan(&memo.WithScanPrivate{
-				With:    cte.id,
-				Name:    string(cte.name.Alias),
-				InCols:  inCols,
-				OutCols: outCols,
-				ID:      b.factory.Metadata().NextUniqueID(),
-				Mtr:     cte.mtr,
-			})
-
-			return outScope
-		}
-
-		ds, depName, resName := b.resolveDataSource(tn, privilege.SELECT)
-
-		locking = locking.filter(tn.ObjectName)
-		if locking.isSet() {
-			// SELECT ... FOR [KEY] UPDATE/SHARE also requires UPDATE privileges.
-			b.checkPrivilege(depName, ds, privilege.UPDATE)
-		}
-
-		switch t := ds.(type) {
-		case cat.Table:
-			tabMeta := b.addTable(t, &resName)
-			return b.buildScan(
-				tabMeta,
-				tableOrdinals(t, columnKinds{
-					includeMutations: false,
-					includeSystem:    true,
-					includeInverted:  false,
-				}),
-				indexFlags, locking, inScope,
-				false, /* disableNotVisibleIndex */
-			)
-
-		case cat.Sequence:
-			return b.buildSequenceSelect(t, &resName, inScope)
-
-		case cat.View:
-			return b.buildView(t, &resName, locking, inScope)
-
-		default:
-			panic(errors.AssertionFailedf("unknown DataSource type %T", ds))
-		}
+		return b.buildTableName(source, indexFlags, locking, inScope, true /* inherit */)
 
 	case *tree.ParenTableExpr:
 		return b.buildDataSource(source.Expr, indexFlags, locking, inScope)
@@ -382,6 +327,80 @@ func (b *Builder) renameSource(as tree.AliasClause, scope *scope) {
 	}
 }
 
+// buildTableName builds a set of memo groups that represent the data source
+// with the given name. If inherit is true and the data source is a table, the
+// rows of the tables that inherit from it are included.
+//
+// See Builder.buildStmt for a description of the remaining input and
+// return values.
+func (b *Builder) buildTableName(
+	tn *tree.TableName, indexFlags *tree.IndexFlags, locking lockingSpec, inScope *scope, inherit bool,
+) (outScope *scope) {
+	// CTEs take precedence over other data sources.
+	if cte := inScope.resolveCTE(tn); cte != nil {
+		locking.ignoreLockingForCTE()
+		outScope = inScope.push()
+		inCols := make(opt.ColList, len(cte.cols), len(cte.cols)+len(inScope.ordering))
+		outCols := make(opt.ColList, len(cte.cols), len(cte.cols)+len(inScope.ordering))
+		outScope.cols, outScope.extraCols = nil, nil
+		for i, col := range cte.cols {
+			id := col.ID
+			c := b.factory.Metadata().ColumnMeta(id)
+			newCol := b.synthesizeColumn(outScope, scopeColName(tree.Name(col.Alias)), c.Type, nil, nil)
+			newCol.table = *tn
+			inCols[i] = id
+			outCols[i] = newCol.id
+		}
+
+		outScope.expr = b.factory.ConstructWithScan(&memo.WithScanPrivate{
+			With:    cte.id,
+			Name:    string(cte.name.Alias),
+			InCols:  inCols,
+			OutCols: outCols,
+			ID:      b.factory.Metadata().NextUniqueID(),
+			Mtr:     cte.mtr,
+		})
+
+		return outScope
+	}
+
+	ds, depName, resName := b.resolveDataSource(tn, privilege.SELECT)
+
+	locking = locking.filter(tn.ObjectName)
+	if locking.isSet() {
+		// SELECT ... FOR [KEY] UPDATE/SHARE also requires UPDATE privileges.
+		b.checkPrivilege(depName, ds, privilege.UPDATE)
+	}
+
+	switch t := ds.(type) {
+	case cat.Table:
+		tabMeta := b.addTable(t, &resName)
+		outScope = b.buildScan(
+			tabMeta,
+			tableOrdinals(t, columnKinds{
+				includeMutations: false,
+				includeSystem:    true,
+				includeInverted:  false,
+			}),
+			indexFlags, locking, inScope,
+			false, /* disableNotVisibleIndex */
+		)
+		if inherit && t.InheritedByCount() > 0 {
+			outScope = b.buildInheritingTables(t, outScope, locking, inScope)
+		}
+		return outScope
+
+	case cat.Sequence:
+		return b.buildSequenceSelect(t, &resName, inScope)
+
+	case cat.View:
+		return b.buildView(t, &resName, locking, inScope)
+
+	default:
+		panic(errors.AssertionFailedf("unknown DataSource type %T", ds))
+	}
+}
+
 // buildScanFromTableRef adds support for numeric references in queries.
 // For example:
 // SELECT * FROM [53 as t]; (table reference)
@@ -1041,8 +1060,23 @@ func (b *Builder) buildSelectClause(
 	desiredTypes []*types.T,
 	inScope *scope,
 ) (outScope *scope) {
+	if hasGroupingSets(sel) {
+		return b.buildGroupingSets(sel, orderBy, locking, desiredTypes, inScope)
+	}
+
 	fromScope := b.buildFrom(sel.From, locking, inScope)
+	return b.buildSelectClauseFromScope(sel, orderBy, locking, desiredTypes, fromScope)
+}
 
+// buildSelectClauseFromScope is like buildSelectClause, but the data sources
+// of the FROM clause have already been built in fromScope.
+func (b *Builder) buildSelectClauseFromScope(
+	sel *tree.SelectClause,
+	orderBy tree.OrderBy,
+	locking lockingSpec,
+	desiredTypes []*types.T,
+	fromScope *scope,
+) (outScope *scope) {
 	b.processWindowDefs(sel, fromScope)
 	b.buildWhere(sel.Where, fromScope)
 
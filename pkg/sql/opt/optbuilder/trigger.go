@@ -0,0 +1,831 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// triggerFuncParamNames are the names of the implicit parameters of a trigger
+// function. They are the special variables that PL/pgSQL makes available to
+// the body of a trigger function.
+var triggerFuncParamNames = []string{
+	"new",
+	"old",
+	"tg_name",
+	"tg_when",
+	"tg_level",
+	"tg_op",
+	"tg_relid",
+	"tg_table_name",
+	"tg_table_schema",
+	"tg_nargs",
+	"tg_argv",
+}
+
+// triggerRowOrdinals returns the ordinals of the table columns that make up
+// the NEW and OLD rows passed to trigger functions.
+func triggerRowOrdinals(tab cat.Table) []int {
+	var ords []int
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible {
+			ords = append(ords, i)
+		}
+	}
+	return ords
+}
+
+// triggerRowType returns the type of the NEW and OLD rows passed to trigger
+// functions, which is a tuple labeled with the names of the columns.
+func triggerRowType(tab cat.Table, ords []int) *types.T {
+	typs := make([]*types.T, len(ords))
+	labels := make([]string, len(ords))
+	for i, ord := range ords {
+		col := tab.Column(ord)
+		typs[i] = col.DatumType()
+		labels[i] = string(col.ColName())
+	}
+	return types.MakeLabeledTuple(typs, labels)
+}
+
+// triggerEventName returns the value of TG_OP for the given event.
+func triggerEventName(event tree.TriggerEventType) string {
+	switch event {
+	case tree.TriggerEventInsert:
+		return "INSERT"
+	case tree.TriggerEventUpdate:
+		return "UPDATE"
+	case tree.TriggerEventDelete:
+		return "DELETE"
+	default:
+		panic(errors.AssertionFailedf("unexpected trigger event %d", event))
+	}
+}
+
+// triggers returns the triggers on the target table that fire at the given
+// time and level for the given event, in the order in which they fire. A
+// trigger with an UPDATE OF event only fires if one of its columns is a
+// target of the statement.
+func (mb *mutationBuilder) triggers(
+	actionTime tree.TriggerActionTime, forEachRow bool, event tree.TriggerEventType,
+) []cat.Trigger {
+	var res []cat.Trigger
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trig := mb.tab.Trigger(i)
+		if trig.ActionTime() != actionTime || trig.ForEachRow() != forEachRow || !trig.HasEvent(event) {
+			continue
+		}
+		if event == tree.TriggerEventUpdate && trig.UpdateColumnCount() > 0 {
+			targeted := false
+			for j, m := 0, trig.UpdateColumnCount(); j < m; j++ {
+				if mb.targetColSet.Contains(mb.tabID.ColumnID(trig.UpdateColumnOrdinal(j))) {
+					targeted = true
+					break
+				}
+			}
+			if !targeted {
+				continue
+			}
+		}
+		res = append(res, trig)
+	}
+	return res
+}
+
+// hasRowTriggers returns true if the target table has row-level triggers for
+// any of the given events.
+func (mb *mutationBuilder) hasRowTriggers(events ...tree.TriggerEventType) bool {
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trig := mb.tab.Trigger(i)
+		for _, event := range events {
+			if trig.ForEachRow() && trig.HasEvent(event) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// buildBeforeTriggers fires the BEFORE triggers of the target table for the
+// given event. It must be called once the new values of the modified rows are
+// known, but before computed columns are synthesized, so that the values
+// returned by the triggers are used to compute them.
+//
+// BEFORE STATEMENT triggers are built as uncorrelated subqueries in a filter
+// on the mutation input, so that they are executed once before the mutation.
+//
+// BEFORE ROW triggers are built as projections on the mutation input:
+//
+//	SELECT ..., (t).a AS a_new, (t).b AS b_new
+//	FROM (
+//	  SELECT *, CASE WHEN <when> THEN trig_fn((a, b), NULL, ...) ELSE (a, b) END AS t
+//	  FROM <input>
+//	)
+//	WHERE t IS DISTINCT FROM NULL
+//
+// A row for which a trigger function returns NULL is skipped. The columns of
+// the row returned by the trigger function become the new values of the row.
+func (mb *mutationBuilder) buildBeforeTriggers(event tree.TriggerEventType) {
+	if mb.tab.TriggerCount() == 0 {
+		return
+	}
+	mb.buildBeforeStatementTriggers(event)
+	for _, trig := range mb.triggers(tree.TriggerActionTimeBefore, true /* forEachRow */, event) {
+		mb.buildBeforeRowTrigger(trig, event)
+	}
+}
+
+// buildBeforeStatementTriggers fires the BEFORE STATEMENT triggers of the
+// target table for the given event. See buildBeforeTriggers.
+func (mb *mutationBuilder) buildBeforeStatementTriggers(event tree.TriggerEventType) {
+	trigs := mb.triggers(tree.TriggerActionTimeBefore, false /* forEachRow */, event)
+	if len(trigs) == 0 {
+		return
+	}
+	f := mb.b.factory
+	filters := make(memo.FiltersExpr, len(trigs))
+	for i, trig := range trigs {
+		call := mb.b.buildStatementTriggerCall(mb.tab, trig, event)
+		col := mb.md.AddColumn(string(trig.Name()), types.Bool)
+		input := f.ConstructProject(
+			f.ConstructValues(memo.ScalarListWithEmptyTuple, &memo.ValuesPrivate{
+				Cols: opt.ColList{},
+				ID:   mb.md.NextUniqueID(),
+			}),
+			memo.ProjectionsExpr{f.ConstructProjectionsItem(
+				f.ConstructIsNot(call, memo.NullSingleton), col,
+			)},
+			opt.ColSet{},
+		)
+		// The trigger function returns VOID, so the filter is always true.
+		filters[i] = f.ConstructFiltersItem(f.ConstructSubquery(input, &memo.SubqueryPrivate{}))
+	}
+	mb.outScope.expr = f.ConstructSelect(mb.outScope.expr, filters)
+}
+
+// buildBeforeRowTrigger fires a BEFORE ROW trigger of the target table for the
+// given event. See buildBeforeTriggers.
+func (mb *mutationBuilder) buildBeforeRowTrigger(trig cat.Trigger, event tree.TriggerEventType) {
+	if trig.FuncID() == 0 {
+		mb.buildBuiltinRowTrigger(trig, event)
+		return
+	}
+	f := mb.b.factory
+	ords := triggerRowOrdinals(mb.tab)
+	rowType := triggerRowType(mb.tab, ords)
+
+	// Determine the columns with the new and old values of the rows.
+	var newCols, oldCols opt.ColList
+	if event != tree.TriggerEventDelete {
+		newCols = make(opt.ColList, len(ords))
+		for i, ord := range ords {
+			newCols[i] = mb.newColID(ord, event)
+		}
+	}
+	if event != tree.TriggerEventInsert {
+		oldCols = make(opt.ColList, len(ords))
+		for i, ord := range ords {
+			oldCols[i] = mb.fetchColIDs[ord]
+		}
+	}
+	newRow := mb.b.constructTriggerRow(newCols, rowType)
+	oldRow := mb.b.constructTriggerRow(oldCols, rowType)
+
+	// The row that is used if the trigger does not fire.
+	unchanged := newRow
+	if event == tree.TriggerEventDelete {
+		unchanged = oldRow
+	}
+	result := mb.b.buildTriggerCall(mb.tab, trig, event, rowType, newRow, oldRow)
+	cond := mb.b.buildTriggerWhen(mb.tab, trig, ords, newCols, oldCols)
+	if mb.canaryColID != 0 && event == tree.TriggerEventUpdate {
+		// The BEFORE UPDATE triggers of an upsert only fire for the rows that
+		// conflict with an existing row.
+		isUpdate := f.ConstructIsNot(f.ConstructVariable(mb.canaryColID), memo.NullSingleton)
+		if cond == nil {
+			cond = isUpdate
+		} else {
+			cond = f.ConstructAnd(isUpdate, cond)
+		}
+	}
+	if cond != nil {
+		result = f.ConstructCase(
+			memo.TrueSingleton,
+			memo.ScalarListExpr{f.ConstructWhen(cond, result)},
+			unchanged,
+		)
+	}
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	name := scopeColName("").WithMetadataName(fmt.Sprintf("%s_%s", trig.Name(), "result"))
+	resultCol := mb.b.synthesizeColumn(projectionsScope, name, rowType, nil /* expr */, result)
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Skip the rows for which the trigger function returns NULL.
+	mb.outScope.expr = f.ConstructSelect(
+		mb.outScope.expr,
+		memo.FiltersExpr{f.ConstructFiltersItem(
+			f.ConstructIsNot(f.ConstructVariable(resultCol.id), memo.NullSingleton),
+		)},
+	)
+	if event == tree.TriggerEventDelete {
+		return
+	}
+
+	// Use the columns of the returned row as the new values of the row.
+	// Computed columns are synthesized afterwards, so the values that the
+	// trigger function assigns to them are ignored.
+	projectionsScope = mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	for i, ord := range ords {
+		tabCol := mb.tab.Column(ord)
+		if tabCol.IsComputed() {
+			continue
+		}
+		mb.outScope.clearNameOfColumn(newCols[i])
+		projectionsScope.clearNameOfColumn(newCols[i])
+		name := scopeColName(tabCol.ColName()).WithMetadataName(
+			fmt.Sprintf("%s_%s", tabCol.ColName(), trig.Name()),
+		)
+		access := f.ConstructColumnAccess(f.ConstructVariable(resultCol.id), memo.TupleOrdinal(i))
+		col := mb.b.synthesizeColumn(projectionsScope, name, tabCol.DatumType(), nil /* expr */, access)
+		if event == tree.TriggerEventInsert {
+			mb.insertColIDs[ord] = col.id
+		} else {
+			mb.updateColIDs[ord] = col.id
+		}
+	}
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
+
+// newColID returns the ID of the column with the new value of the table column
+// with the given ordinal for the given event.
+func (mb *mutationBuilder) newColID(ord int, event tree.TriggerEventType) opt.ColumnID {
+	if event == tree.TriggerEventInsert {
+		return mb.insertColIDs[ord]
+	}
+	if mb.updateColIDs[ord] != 0 {
+		return mb.updateColIDs[ord]
+	}
+	return mb.fetchColIDs[ord]
+}
+
+// clearNameOfColumn clears the name of the column with the given ID, so that
+// references to the name resolve to another column.
+func (s *scope) clearNameOfColumn(id opt.ColumnID) {
+	for i := range s.cols {
+		if s.cols[i].id == id {
+			s.cols[i].clearName()
+		}
+	}
+}
+
+// checkBuiltinTrigger panics if the builtin trigger function executed by the
+// given trigger cannot be fired by it for the given event. Builtin trigger
+// functions must be fired by BEFORE ROW triggers.
+func checkBuiltinTrigger(trig cat.Trigger, event tree.TriggerEventType) {
+	fnName := trig.FuncName()
+	var ok bool
+	var events string
+	switch fnName {
+	case "suppress_redundant_updates_trigger":
+		ok, events = event == tree.TriggerEventUpdate, "UPDATE"
+	case "tsvector_update_trigger", "tsvector_update_trigger_column":
+		ok = event == tree.TriggerEventInsert || event == tree.TriggerEventUpdate
+		events = "INSERT or UPDATE"
+	default:
+		panic(errors.AssertionFailedf("unexpected builtin trigger function %s", fnName))
+	}
+	if !ok || trig.ActionTime() != tree.TriggerActionTimeBefore {
+		panic(pgerror.Newf(pgcode.TriggeredActionException,
+			"%s: must be fired BEFORE %s", fnName, events))
+	}
+	if !trig.ForEachRow() {
+		panic(pgerror.Newf(pgcode.TriggeredActionException, "%s: must be fired for row", fnName))
+	}
+}
+
+// buildBuiltinRowTrigger fires a BEFORE ROW trigger that executes a builtin
+// trigger function. See checkBuiltinTrigger.
+func (mb *mutationBuilder) buildBuiltinRowTrigger(trig cat.Trigger, event tree.TriggerEventType) {
+	checkBuiltinTrigger(trig, event)
+	switch trig.FuncName() {
+	case "suppress_redundant_updates_trigger":
+		mb.buildSuppressRedundantUpdatesTrigger(trig)
+	case "tsvector_update_trigger", "tsvector_update_trigger_column":
+		mb.buildTSVectorUpdateTrigger(trig, event)
+	}
+}
+
+// buildSuppressRedundantUpdatesTrigger fires a BEFORE UPDATE ROW trigger that
+// executes suppress_redundant_updates_trigger, which skips the rows that the
+// update does not change. It is built as a filter:
+//
+//	SELECT ... FROM ... WHERE (new_a, new_b) IS DISTINCT FROM (old_a, old_b)
+func (mb *mutationBuilder) buildSuppressRedundantUpdatesTrigger(trig cat.Trigger) {
+	f := mb.b.factory
+	ords := triggerRowOrdinals(mb.tab)
+	rowType := triggerRowType(mb.tab, ords)
+	newCols := make(opt.ColList, len(ords))
+	oldCols := make(opt.ColList, len(ords))
+	for i, ord := range ords {
+		newCols[i] = mb.newColID(ord, tree.TriggerEventUpdate)
+		oldCols[i] = mb.fetchColIDs[ord]
+	}
+	filter := f.ConstructIsNot(
+		mb.b.constructTriggerRow(newCols, rowType), mb.b.constructTriggerRow(oldCols, rowType),
+	)
+	cond := mb.b.buildTriggerWhen(mb.tab, trig, ords, newCols, oldCols)
+	if mb.canaryColID != 0 {
+		// The BEFORE UPDATE triggers of an upsert only fire for the rows that
+		// conflict with an existing row.
+		isUpdate := f.ConstructIsNot(f.ConstructVariable(mb.canaryColID), memo.NullSingleton)
+		if cond == nil {
+			cond = isUpdate
+		} else {
+			cond = f.ConstructAnd(isUpdate, cond)
+		}
+	}
+	if cond != nil {
+		// Rows for which the trigger does not fire are never skipped.
+		filter = f.ConstructOr(filter, f.ConstructIsNot(cond, memo.TrueSingleton))
+	}
+	mb.outScope.expr = f.ConstructSelect(
+		mb.outScope.expr, memo.FiltersExpr{f.ConstructFiltersItem(filter)},
+	)
+}
+
+// buildTSVectorUpdateTrigger fires a BEFORE INSERT or UPDATE ROW trigger that
+// executes tsvector_update_trigger or tsvector_update_trigger_column. It is
+// built as a projection that computes the new value of the tsvector column:
+//
+//	to_tsvector(<config>, concat_ws(' ', <text columns>))
+func (mb *mutationBuilder) buildTSVectorUpdateTrigger(
+	trig cat.Trigger, event tree.TriggerEventType,
+) {
+	fnName := trig.FuncName()
+	args := trig.FuncArgs()
+	if len(args) < 3 {
+		panic(pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s: arguments must be tsvector_field, ts_config, text_field1, ...", fnName))
+	}
+
+	// Build a scope in which the table columns refer to their new values.
+	colScope := mb.b.allocScope()
+	findCol := func(name string) (int, *types.T) {
+		for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+			col := mb.tab.Column(i)
+			if col.Kind() == cat.Ordinary && string(col.ColName()) == name {
+				return i, col.DatumType()
+			}
+		}
+		panic(pgerror.Newf(pgcode.UndefinedColumn, "column \"%s\" does not exist", name))
+	}
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		col := mb.tab.Column(i)
+		if col.Kind() != cat.Ordinary {
+			continue
+		}
+		colScope.cols = append(colScope.cols, scopeColumn{
+			name: scopeColName(col.ColName()),
+			typ:  col.DatumType(),
+			id:   mb.newColID(i, event),
+		})
+	}
+
+	tsvOrd, tsvType := findCol(args[0])
+	if tsvType.Family() != types.TSVectorFamily {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch, "column \"%s\" is not of tsvector type", args[0]))
+	}
+	var config tree.Expr
+	if fnName == "tsvector_update_trigger" {
+		config = tree.NewStrVal(args[1])
+	} else {
+		if _, typ := findCol(args[1]); typ.Family() != types.StringFamily {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"column \"%s\" is not of regconfig type", args[1]))
+		}
+		config = &tree.UnresolvedName{NumParts: 1, Parts: tree.NameParts{args[1]}}
+	}
+	concatArgs := tree.Exprs{tree.NewStrVal(" ")}
+	for _, name := range args[2:] {
+		if _, typ := findCol(name); typ.Family() != types.StringFamily {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"column \"%s\" is not of a character type", name))
+		}
+		concatArgs = append(concatArgs, &tree.UnresolvedName{NumParts: 1, Parts: tree.NameParts{name}})
+	}
+	expr := &tree.FuncExpr{
+		Func: tree.WrapFunction("to_tsvector"),
+		Exprs: tree.Exprs{
+			config,
+			&tree.FuncExpr{Func: tree.WrapFunction("concat_ws"), Exprs: concatArgs},
+		},
+	}
+	texpr := colScope.resolveAndRequireType(expr, types.TSVector)
+	scalar := mb.b.buildScalar(texpr, colScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	tabCol := mb.tab.Column(tsvOrd)
+	prev := mb.newColID(tsvOrd, event)
+	mb.outScope.clearNameOfColumn(prev)
+	projectionsScope.clearNameOfColumn(prev)
+	name := scopeColName(tabCol.ColName()).WithMetadataName(
+		fmt.Sprintf("%s_%s", tabCol.ColName(), trig.Name()),
+	)
+	col := mb.b.synthesizeColumn(projectionsScope, name, tabCol.DatumType(), nil /* expr */, scalar)
+	if event == tree.TriggerEventInsert {
+		mb.insertColIDs[tsvOrd] = col.id
+	} else {
+		mb.updateColIDs[tsvOrd] = col.id
+	}
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
+
+// buildAfterTriggers fires the AFTER triggers of the target table for the
+// given event. The triggers are built as cascades, which are executed after
+// the mutation. It must be called right before the mutation operator is
+// constructed, because row-level triggers read the modified rows from the
+// buffered mutation input.
+func (mb *mutationBuilder) buildAfterTriggers(event tree.TriggerEventType) {
+	if mb.tab.TriggerCount() == 0 {
+		return
+	}
+	ords := triggerRowOrdinals(mb.tab)
+	for _, trig := range mb.triggers(tree.TriggerActionTimeAfter, true /* forEachRow */, event) {
+		if trig.FuncID() == 0 {
+			checkBuiltinTrigger(trig, event)
+		}
+		mb.ensureWithID()
+		cb := &triggerCascadeBuilder{tab: mb.tab, trig: trig, event: event}
+		var oldCols, newCols opt.ColList
+		if event != tree.TriggerEventInsert {
+			oldCols = make(opt.ColList, len(ords))
+			for i, ord := range ords {
+				oldCols[i] = mb.fetchColIDs[ord]
+			}
+		}
+		if event != tree.TriggerEventDelete {
+			newCols = make(opt.ColList, len(ords))
+			for i, ord := range ords {
+				newCols[i] = mb.newColID(ord, event)
+			}
+		}
+		if mb.canaryColID != 0 {
+			// Only the rows that were inserted by an upsert fire its AFTER
+			// INSERT triggers, and only the rows that were updated fire its AFTER
+			// UPDATE triggers. The canary column is passed as the last old value.
+			cb.canary = true
+			oldCols = append(oldCols, mb.canaryColID)
+		}
+		mb.cascades = append(mb.cascades, memo.FKCascade{
+			FKName:    string(trig.Name()),
+			Builder:   cb,
+			WithID:    mb.withID,
+			OldValues: oldCols,
+			NewValues: newCols,
+		})
+	}
+	for _, trig := range mb.triggers(tree.TriggerActionTimeAfter, false /* forEachRow */, event) {
+		mb.cascades = append(mb.cascades, memo.FKCascade{
+			FKName:  string(trig.Name()),
+			Builder: &triggerCascadeBuilder{tab: mb.tab, trig: trig, event: event},
+		})
+	}
+}
+
+// triggerCascadeBuilder is a memo.CascadeBuilder implementation that fires an
+// AFTER trigger once the mutation has been executed. For a row-level trigger,
+// the trigger function is called for each row in the mutation input:
+//
+//	SELECT trig_fn((new_a, new_b), (old_a, old_b), ...)
+//	FROM original_mutation_input
+//	WHERE <when>
+//
+// For a statement-level trigger, the function is called once. The rows
+// returned by the query are discarded.
+type triggerCascadeBuilder struct {
+	tab   cat.Table
+	trig  cat.Trigger
+	event tree.TriggerEventType
+
+	// canary is true if the last of the old values is the canary column of an
+	// upsert, which is NULL if the row was inserted.
+	canary bool
+}
+
+var _ memo.CascadeBuilder = &triggerCascadeBuilder{}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (cb *triggerCascadeBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (_ memo.RelExpr, err error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		f := b.factory
+		md := f.Metadata()
+		// The cascade does not produce any columns, so the trigger function is
+		// called in a filter, which cannot be pruned.
+		callFilter := func(call opt.ScalarExpr) memo.FiltersExpr {
+			return memo.FiltersExpr{f.ConstructFiltersItem(f.ConstructIsNot(call, memo.NullSingleton))}
+		}
+		if !cb.trig.ForEachRow() {
+			call := b.buildStatementTriggerCall(cb.tab, cb.trig, cb.event)
+			return f.ConstructSelect(
+				f.ConstructValues(memo.ScalarListWithEmptyTuple, &memo.ValuesPrivate{
+					Cols: opt.ColList{},
+					ID:   md.NextUniqueID(),
+				}),
+				callFilter(call),
+			)
+		}
+
+		// Scan the old and new values of the modified rows from the buffered
+		// mutation input.
+		inCols := make(opt.ColList, 0, len(oldValues)+len(newValues))
+		inCols = append(inCols, oldValues...)
+		inCols = append(inCols, newValues...)
+		outCols := make(opt.ColList, len(inCols))
+		for i := range outCols {
+			c := md.ColumnMeta(inCols[i])
+			outCols[i] = md.AddColumn(c.Alias, c.Type)
+		}
+		md.AddWithBinding(binding, f.ConstructFakeRel(&memo.FakeRelPrivate{
+			Props: bindingProps,
+		}))
+		var input memo.RelExpr = f.ConstructWithScan(&memo.WithScanPrivate{
+			With:    binding,
+			InCols:  inCols,
+			OutCols: outCols,
+			ID:      md.NextUniqueID(),
+		})
+		oldCols, newCols := outCols[:len(oldValues)], outCols[len(oldValues):]
+		var cond opt.ScalarExpr
+		if cb.canary {
+			canary := f.ConstructVariable(oldCols[len(oldCols)-1])
+			oldCols = oldCols[:len(oldCols)-1]
+			if cb.event == tree.TriggerEventInsert {
+				cond = f.ConstructIs(canary, memo.NullSingleton)
+				oldCols = nil
+			} else {
+				cond = f.ConstructIsNot(canary, memo.NullSingleton)
+			}
+		}
+
+		ords := triggerRowOrdinals(cb.tab)
+		rowType := triggerRowType(cb.tab, ords)
+		if when := b.buildTriggerWhen(cb.tab, cb.trig, ords, newCols, oldCols); when != nil {
+			if cond == nil {
+				cond = when
+			} else {
+				cond = f.ConstructAnd(cond, when)
+			}
+		}
+		call := b.buildTriggerCall(
+			cb.tab, cb.trig, cb.event, rowType,
+			b.constructTriggerRow(newCols, rowType), b.constructTriggerRow(oldCols, rowType),
+		)
+		if cond != nil {
+			call = f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{f.ConstructWhen(cond, call)},
+				f.ConstructNull(rowType),
+			)
+		}
+		return f.ConstructSelect(input, callFilter(call))
+	})
+}
+
+// constructTriggerRow constructs the NEW or OLD row passed to a trigger
+// function from the given columns, or NULL if there are no columns.
+func (b *Builder) constructTriggerRow(cols opt.ColList, rowType *types.T) opt.ScalarExpr {
+	if len(cols) == 0 {
+		return b.factory.ConstructNull(rowType)
+	}
+	elems := make(memo.ScalarListExpr, len(cols))
+	for i, col := range cols {
+		if col == 0 {
+			// The values of computed columns are not known before the row is
+			// modified.
+			elems[i] = b.factory.ConstructNull(rowType.TupleContents()[i])
+			continue
+		}
+		elems[i] = b.factory.ConstructVariable(col)
+	}
+	return b.factory.ConstructTuple(elems, rowType)
+}
+
+// buildTriggerWhen builds the WHEN condition of a trigger, or returns nil if
+// the trigger does not have one. The columns of the NEW and OLD rows are
+// referenced as new.<column> and old.<column>, and the rows themselves as new
+// and old. newCols and oldCols are the columns with the values of the table
+// columns with the given ordinals; they are empty if the row does not exist.
+func (b *Builder) buildTriggerWhen(
+	tab cat.Table, trig cat.Trigger, ords []int, newCols, oldCols opt.ColList,
+) opt.ScalarExpr {
+	if trig.WhenExpr() == "" {
+		return nil
+	}
+	expr, err := parser.ParseExpr(trig.WhenExpr())
+	if err != nil {
+		panic(err)
+	}
+
+	whenScope := b.allocScope()
+	rows := [...]struct {
+		name string
+		cols opt.ColList
+	}{{"new", newCols}, {"old", oldCols}}
+	for _, row := range rows {
+		if len(row.cols) == 0 {
+			continue
+		}
+		tn := tree.MakeUnqualifiedTableName(tree.Name(row.name))
+		for i, ord := range ords {
+			if row.cols[i] == 0 {
+				continue
+			}
+			col := tab.Column(ord)
+			whenScope.cols = append(whenScope.cols, scopeColumn{
+				name:  scopeColName(col.ColName()),
+				table: tn,
+				typ:   col.DatumType(),
+				id:    row.cols[i],
+			})
+		}
+	}
+
+	// Replace references to the whole rows with tuples of their columns.
+	expr, err = tree.SimpleVisit(expr, func(expr tree.Expr) (bool, tree.Expr, error) {
+		name, ok := expr.(*tree.UnresolvedName)
+		if !ok {
+			return true, expr, nil
+		}
+		var row string
+		switch {
+		case name.NumParts == 1 && !name.Star:
+			row = name.Parts[0]
+		case name.NumParts == 2 && name.Star:
+			row = name.Parts[1]
+		default:
+			return false, expr, nil
+		}
+		if row != "new" && row != "old" {
+			return false, expr, nil
+		}
+		tup := &tree.Tuple{Exprs: make(tree.Exprs, len(ords)), Labels: make([]string, len(ords))}
+		for i, ord := range ords {
+			colName := tab.Column(ord).ColName()
+			tup.Exprs[i] = &tree.UnresolvedName{NumParts: 2, Parts: tree.NameParts{string(colName), row}}
+			tup.Labels[i] = string(colName)
+		}
+		return false, tup, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	texpr := whenScope.resolveAndRequireType(expr, types.Bool)
+	return b.buildScalar(texpr, whenScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+}
+
+// buildStatementTriggerCall builds a call to the function of a statement-level
+// trigger, which is guarded by the WHEN condition of the trigger.
+func (b *Builder) buildStatementTriggerCall(
+	tab cat.Table, trig cat.Trigger, event tree.TriggerEventType,
+) opt.ScalarExpr {
+	if trig.FuncID() == 0 {
+		checkBuiltinTrigger(trig, event)
+	}
+	rowType := triggerRowType(tab, triggerRowOrdinals(tab))
+	null := b.factory.ConstructNull(rowType)
+	call := b.buildTriggerCall(tab, trig, event, types.Void, null, null)
+	if when := b.buildTriggerWhen(tab, trig, nil /* ords */, nil /* newCols */, nil /* oldCols */); when != nil {
+		call = b.factory.ConstructCase(
+			memo.TrueSingleton,
+			memo.ScalarListExpr{b.factory.ConstructWhen(when, call)},
+			b.factory.ConstructConstVal(tree.DVoidDatum, types.Void),
+		)
+	}
+	return call
+}
+
+// buildTriggerCall builds a call to the user-defined function executed by a
+// trigger. The function returns the row that replaces the modified row, or
+// VOID for a statement-level trigger; typ is the type of its result.
+func (b *Builder) buildTriggerCall(
+	tab cat.Table,
+	trig cat.Trigger,
+	event tree.TriggerEventType,
+	typ *types.T,
+	newRow, oldRow opt.ScalarExpr,
+) opt.ScalarExpr {
+	f := b.factory
+	fnName, o, err := b.catalog.ResolveFunctionByOID(
+		b.ctx, catid.FuncIDToOID(catid.DescID(trig.FuncID())),
+	)
+	if err != nil {
+		panic(err)
+	}
+	block, err := plpgsqlparser.Parse(o.Body)
+	if err != nil {
+		panic(err)
+	}
+	tn, err := b.catalog.FullyQualifiedName(b.ctx, tab)
+	if err != nil {
+		panic(err)
+	}
+
+	when, level := "BEFORE", "ROW"
+	if trig.ActionTime() == tree.TriggerActionTimeAfter {
+		when = "AFTER"
+	}
+	if !trig.ForEachRow() {
+		level = "STATEMENT"
+	}
+	argv := tree.NewDArray(types.String)
+	for _, arg := range trig.FuncArgs() {
+		if err := argv.Append(tree.NewDString(arg)); err != nil {
+			panic(err)
+		}
+	}
+	args := memo.ScalarListExpr{
+		newRow,
+		oldRow,
+		f.ConstructConstVal(tree.NewDName(string(trig.Name())), types.Name),
+		f.ConstructConstVal(tree.NewDString(when), types.String),
+		f.ConstructConstVal(tree.NewDString(level), types.String),
+		f.ConstructConstVal(tree.NewDString(triggerEventName(event)), types.String),
+		f.ConstructConstVal(tree.NewDOid(oid.Oid(tab.ID())), types.Oid),
+		f.ConstructConstVal(tree.NewDName(string(tn.ObjectName)), types.Name),
+		f.ConstructConstVal(tree.NewDName(string(tn.SchemaName)), types.Name),
+		f.ConstructConstVal(tree.NewDInt(tree.DInt(len(trig.FuncArgs()))), types.Int),
+		f.ConstructConstVal(argv, types.StringArray),
+	}
+
+	bodyScope := b.allocScope()
+	params := make(opt.ColList, len(args))
+	for i, name := range triggerFuncParamNames {
+		col := b.synthesizeColumn(
+			bodyScope, funcParamColName(tree.Name(name), i), args[i].DataType(), nil /* expr */, nil, /* scalar */
+		)
+		col.setParamOrd(i)
+		params[i] = col.id
+	}
+
+	body, stmts := b.buildPLpgSQLBody(
+		catid.FuncIDToOID(catid.DescID(trig.FuncID())), block, triggerFuncParamNames,
+		bodyScope.cols, nil /* outParams */, true, /* trigger */
+	)
+
+	// A trigger function is always treated as volatile, since it is executed
+	// for its side effects.
+	return f.ConstructUDF(
+		args,
+		&memo.UDFPrivate{
+			Name:       fnName,
+			Params:     params,
+			Body:       stmts,
+			PLpgSQL:    body,
+			Typ:        typ,
+			Volatility: volatility.Volatile,
+		},
+	)
+}
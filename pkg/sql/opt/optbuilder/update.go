@@ -82,6 +82,9 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 			"cannot specify a list of column IDs with UPDATE"))
 	}
 
+	// The rows of the tables that inherit from the target are not modified.
+	checkInheritedMutation("UPDATE", upd.Table, tab)
+
 	// Check Select permission as well, since existing values must be read.
 	b.checkPrivilege(depName, tab, privilege.SELECT)
 
@@ -313,6 +316,9 @@ func (mb *mutationBuilder) addSynthesizedColsForUpdate() {
 	// Add assignment casts for default column values.
 	mb.addAssignmentCasts(mb.updateColIDs)
 
+	// Fire any BEFORE UPDATE triggers, which may modify the new rows.
+	mb.buildBeforeTriggers(tree.TriggerEventUpdate)
+
 	// Disambiguate names so that references in the computed expression refer to
 	// the correct columns.
 	mb.disambiguateColumns()
@@ -346,6 +352,8 @@ func (mb *mutationBuilder) buildUpdate(returning tree.ReturningExprs) {
 
 	mb.buildFKChecksForUpdate()
 
+	mb.buildAfterTriggers(tree.TriggerEventUpdate)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	for _, col := range mb.extraAccessibleCols {
 		if col.id != 0 {
@@ -593,8 +593,9 @@ func (b *Builder) resolveTableForMutation(
 		alias = *outerAlias
 	}
 
-	// We can't mutate materialized views.
-	if tab.IsMaterializedView() {
+	// We can't mutate materialized views, unless this is the internal executor
+	// maintaining an incrementally refreshed view.
+	if tab.IsMaterializedView() && !b.evalCtx.SessionData().AllowMaterializedViewMutations {
 		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
 	}
 
@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 )
 
 type indexType int
@@ -362,11 +363,14 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		switch def := def.(type) {
 		case *tree.UniqueConstraintTableDef:
 			if def.WithoutIndex {
-				tab.addUniqueConstraint(def.Name, def.Columns, def.Predicate, def.WithoutIndex)
+				tab.addUniqueConstraint(def.Name, def.Columns, def.Predicate, def.WithoutIndex, def.Deferrable)
 			} else if !def.PrimaryKey {
 				tab.addIndex(&def.IndexTableDef, uniqueIndex)
 			}
 
+		case *tree.ExcludeConstraintTableDef:
+			tab.addExclusionConstraint(def)
+
 		case *tree.IndexTableDef:
 			tab.addIndex(def, nonUniqueIndex)
 
@@ -381,6 +385,7 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 						tree.IndexElemList{{Column: def.Name}},
 						nil, /* predicate */
 						def.Unique.WithoutIndex,
+						tree.ConstraintNotDeferrable,
 					)
 				} else {
 					tab.addIndex(
@@ -682,13 +687,18 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		deferrability:            d.Deferrable,
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
 }
 
 func (tt *Table) addUniqueConstraint(
-	name tree.Name, columns tree.IndexElemList, predicate tree.Expr, withoutIndex bool,
+	name tree.Name,
+	columns tree.IndexElemList,
+	predicate tree.Expr,
+	withoutIndex bool,
+	deferrability tree.ConstraintDeferrability,
 ) {
 	// We don't currently use unique constraints with an index (those are already
 	// tracked with unique indexes), so don't bother adding them.
@@ -709,6 +719,7 @@ func (tt *Table) addUniqueConstraint(
 		columnOrdinals: cols,
 		withoutIndex:   withoutIndex,
 		validated:      true,
+		deferrability:  deferrability,
 	}
 	// Add partial unique constraint predicate.
 	if predicate != nil {
@@ -727,6 +738,34 @@ func (tt *Table) addUniqueConstraint(
 	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
 }
 
+func (tt *Table) addExclusionConstraint(def *tree.ExcludeConstraintTableDef) {
+	u := UniqueConstraint{
+		name:           string(def.Name),
+		tabID:          tt.TabID,
+		columnOrdinals: make([]int, len(def.Elems)),
+		withoutIndex:   true,
+		validated:      true,
+		exclusionOps:   make([]treecmp.ComparisonOperatorSymbol, len(def.Elems)),
+	}
+	if u.name == "" {
+		var buf bytes.Buffer
+		buf.WriteString("exclude")
+		for i := range def.Elems {
+			buf.WriteRune('_')
+			buf.WriteString(string(def.Elems[i].Column))
+		}
+		u.name = buf.String()
+	}
+	for i := range def.Elems {
+		u.columnOrdinals[i] = tt.FindOrdinal(string(def.Elems[i].Column))
+		u.exclusionOps[i] = def.Elems[i].Operator.Symbol
+	}
+	if def.Predicate != nil {
+		u.predicate = tree.Serialize(def.Predicate)
+	}
+	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
+}
+
 func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 	ordinal := len(tt.Columns)
 	nullable := !def.PrimaryKey.IsPrimaryKey && def.Nullable.Nullability != tree.NotNull
@@ -849,7 +888,9 @@ func (tt *Table) addIndexWithVersion(
 ) *Index {
 	// Add a unique constraint if this is a primary or unique index.
 	if typ != nonUniqueIndex {
-		tt.addUniqueConstraint(def.Name, def.Columns, def.Predicate, false /* withoutIndex */)
+		tt.addUniqueConstraint(
+			def.Name, def.Columns, def.Predicate, false /* withoutIndex */, tree.ConstraintNotDeferrable,
+		)
 	}
 
 	idx := &Index{
@@ -920,6 +961,31 @@ func (tt *Table) addIndexWithVersion(
 						MaxCells: 3,
 					}},
 				}
+
+			case types.PGVectorFamily:
+				switch colDef.OpClass {
+				case "vector_l2_ops", "":
+					idx.invertedKind = catpb.InvertedIndexColumnKind_VECTOR_L2
+				case "vector_cosine_ops":
+					idx.invertedKind = catpb.InvertedIndexColumnKind_VECTOR_COSINE
+					idx.vectorConfig.Metric = vector.CosineMetric
+				case "vector_ip_ops":
+					idx.invertedKind = catpb.InvertedIndexColumnKind_VECTOR_IP
+					idx.vectorConfig.Metric = vector.InnerProductMetric
+				default:
+					panic(fmt.Errorf("operator class %q does not exist", colDef.OpClass))
+				}
+				idx.vectorConfig.Lists = vector.DefaultLists
+				if lists := def.StorageParams.GetVal("lists"); lists != nil {
+					n, err := strconv.Atoi(lists.String())
+					if err != nil {
+						panic(err)
+					}
+					idx.vectorConfig.Lists = n
+				}
+
+			case types.StringFamily:
+				idx.invertedKind = catpb.InvertedIndexColumnKind_TRIGRAM
 			}
 		}
 	}
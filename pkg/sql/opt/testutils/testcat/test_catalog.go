@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
@@ -33,9 +34,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/treeprinter"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -896,6 +899,26 @@ func (tt *Table) GetDatabaseID() descpb.ID {
 	return tt.DatabaseID
 }
 
+// InheritedByCount is part of the cat.Table interface.
+func (tt *Table) InheritedByCount() int {
+	return 0
+}
+
+// InheritedBy is part of the cat.Table interface.
+func (tt *Table) InheritedBy(i int) cat.StableID {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (tt *Table) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (tt *Table) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 // FindOrdinal returns the ordinal of the column with the given name.
 func (tt *Table) FindOrdinal(name string) int {
 	for i, col := range tt.Columns {
@@ -943,7 +966,7 @@ func (tt *Table) CollectTypes(ord int) (descpb.IDs, error) {
 		}
 	}
 	if col.DatumType() != nil && col.DatumType().UserDefined() {
-		visitor.OIDs[col.DatumType().Oid()] = struct{}{}
+		visitor.OIDs[col.DatumType().UserDefinedOID()] = struct{}{}
 	}
 
 	ids := make(descpb.IDs, 0, len(visitor.OIDs))
@@ -1011,6 +1034,15 @@ type Index struct {
 	// inverted index.
 	geoConfig geoindex.Config
 
+	// invertedKind is the kind of the inverted column, if this is an inverted
+	// index.
+	invertedKind catpb.InvertedIndexColumnKind
+
+	// vectorConfig is the configuration of the IVF lists, if this is a vector
+	// inverted index. The test catalog has no data, so the lists always have
+	// random centroids.
+	vectorConfig vector.IVFConfig
+
 	// version is the index descriptor version of the index.
 	version descpb.IndexDescriptorVersion
 
@@ -1127,6 +1159,16 @@ func (ti *Index) GeoConfig() geoindex.Config {
 	return ti.geoConfig
 }
 
+// InvertedColumnKind is part of the cat.Index interface.
+func (ti *Index) InvertedColumnKind() catpb.InvertedIndexColumnKind {
+	return ti.invertedKind
+}
+
+// VectorConfig is part of the cat.Index interface.
+func (ti *Index) VectorConfig() vector.IVFConfig {
+	return ti.vectorConfig
+}
+
 // Version is part of the cat.Index interface.
 func (ti *Index) Version() descpb.IndexDescriptorVersion {
 	return ti.version
@@ -1340,10 +1382,11 @@ type ForeignKeyConstraint struct {
 	originColumnOrdinals     []int
 	referencedColumnOrdinals []int
 
-	validated    bool
-	matchMethod  tree.CompositeKeyMatchMethod
-	deleteAction tree.ReferenceAction
-	updateAction tree.ReferenceAction
+	validated     bool
+	matchMethod   tree.CompositeKeyMatchMethod
+	deleteAction  tree.ReferenceAction
+	updateAction  tree.ReferenceAction
+	deferrability tree.ConstraintDeferrability
 }
 
 var _ cat.ForeignKeyConstraint = &ForeignKeyConstraint{}
@@ -1411,6 +1454,11 @@ func (fk *ForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction {
 	return fk.updateAction
 }
 
+// Deferrability is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) Deferrability() tree.ConstraintDeferrability {
+	return fk.deferrability
+}
+
 // UniqueConstraint implements cat.UniqueConstraint. See that interface
 // for more information on the fields.
 type UniqueConstraint struct {
@@ -1420,6 +1468,8 @@ type UniqueConstraint struct {
 	predicate      string
 	withoutIndex   bool
 	validated      bool
+	exclusionOps   []treecmp.ComparisonOperatorSymbol
+	deferrability  tree.ConstraintDeferrability
 }
 
 var _ cat.UniqueConstraint = &UniqueConstraint{}
@@ -1471,6 +1521,24 @@ func (u *UniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return false
 }
 
+// IsExclusion is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) IsExclusion() bool {
+	return u.exclusionOps != nil
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol {
+	if u.exclusionOps == nil {
+		return treecmp.EQ
+	}
+	return u.exclusionOps[i]
+}
+
+// Deferrability is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) Deferrability() tree.ConstraintDeferrability {
+	return u.deferrability
+}
+
 // Sequence implements the cat.Sequence interface for testing purposes.
 type Sequence struct {
 	SeqID      cat.StableID
@@ -11,13 +11,17 @@
 package xform
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedidx"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/ordering"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -251,6 +255,302 @@ func (c *CustomFuncs) SplitLimitedSelectIntoUnionScansOrSelects(
 	return c.splitScanIntoUnionScansOrSelects(limitOrdering, scan, sp, cons, limitVal, keyPrefixLength, filters)
 }
 
+// SplitLimitedTrigramDistanceScan returns a UnionAll tree that produces the
+// rows of a Project over an unconstrained Scan in ascending order of a
+// projected trigram distance between an indexed column and a constant string.
+// It is used to plan queries like:
+//
+//	SELECT * FROM t ORDER BY s <-> 'foo' LIMIT 10
+//
+// A trigram distance is below 1 if and only if the two strings share at least
+// one trigram. Those rows can be found with an inverted index scan and then
+// sorted, while all other rows have a constant distance of 1. The returned tree
+// is an ordered union of two limited branches:
+//
+//  1. the rows where s <-> 'foo' < 1, which can use a trigram index,
+//  2. the rows where s <-> 'foo' >= 1, with a constant distance of 1.
+//
+// Only the first branch needs to be sorted by the distance. The second branch
+// is an unordered limited scan which, under the ordered union, is only read
+// past its first row if the first branch has fewer rows than the limit.
+//
+// If the column is nullable, the distance of a NULL string is NULL, which sorts
+// before all other distances. Those rows are produced by a third limited
+// branch, where s IS NULL, which is placed in front of the other two.
+//
+// ok=false is returned if the limit ordering does not start with such a
+// distance, or if there is no trigram index on the column.
+func (c *CustomFuncs) SplitLimitedTrigramDistanceScan(
+	limitOrdering props.OrderingChoice,
+	sp *memo.ScanPrivate,
+	projections memo.ProjectionsExpr,
+	passthrough opt.ColSet,
+	limitExpr opt.ScalarExpr,
+) (_ memo.RelExpr, ok bool) {
+	if len(limitOrdering.Columns) == 0 || limitOrdering.Columns[0].Descending {
+		return nil, false
+	}
+
+	// Find the projected trigram distance that the ordering starts with.
+	distIdx := -1
+	var dist *memo.DistanceExpr
+	var col opt.ColumnID
+	for i := range projections {
+		if !limitOrdering.Columns[0].Group.Contains(projections[i].Col) {
+			continue
+		}
+		d, ok := projections[i].Element.(*memo.DistanceExpr)
+		if !ok {
+			continue
+		}
+		v, ok := d.Left.(*memo.VariableExpr)
+		if !ok || !memo.CanExtractConstDatum(d.Right) {
+			v, ok = d.Right.(*memo.VariableExpr)
+			if !ok || !memo.CanExtractConstDatum(d.Left) {
+				continue
+			}
+		}
+		if !sp.Cols.Contains(v.Col) || v.DataType().Family() != types.StringFamily {
+			continue
+		}
+		if d.Left.Op() == opt.NullOp || d.Right.Op() == opt.NullOp {
+			continue
+		}
+		distIdx, dist, col = i, d, v.Col
+		break
+	}
+	if distIdx == -1 || !c.hasTrigramIndex(sp, col) {
+		return nil, false
+	}
+	md := c.e.mem.Metadata()
+
+	outCols := passthrough.ToList()
+	for i := range projections {
+		outCols = append(outCols, projections[i].Col)
+	}
+
+	// makeBranch constructs a limited Project over a Select with the given
+	// filter from a duplicate of the original Scan. The filter and projections
+	// are remapped to the new Scan's columns, except for the trigram distance,
+	// which is replaced with distance if it is non-nil. The output columns of
+	// the branch are returned in the same order as outCols.
+	makeBranch := func(filter, distance opt.ScalarExpr) (memo.RelExpr, opt.ColList) {
+		newSP := c.DuplicateScanPrivate(sp)
+		var colMap opt.ColMap
+		for srcCol, ok := sp.Cols.Next(0); ok; srcCol, ok = sp.Cols.Next(srcCol + 1) {
+			colMap.Set(int(srcCol), int(newSP.Table.ColumnID(sp.Table.ColumnOrdinal(srcCol))))
+		}
+		newProjections := make(memo.ProjectionsExpr, len(projections))
+		for i := range projections {
+			elem := projections[i].Element
+			if i == distIdx && distance != nil {
+				elem = distance
+			} else {
+				elem = c.e.f.RemapCols(elem, colMap)
+			}
+			oldCol := projections[i].Col
+			newCol := md.AddColumn(md.ColumnMeta(oldCol).Alias, projections[i].Typ)
+			colMap.Set(int(oldCol), int(newCol))
+			newProjections[i] = c.e.f.ConstructProjectionsItem(elem, newCol)
+		}
+		newPassthrough := opt.TranslateColSet(passthrough, sp.Cols.ToList(), newSP.Cols.ToList())
+		branchCols := make(opt.ColList, len(outCols))
+		for i := range outCols {
+			dstCol, _ := colMap.Get(int(outCols[i]))
+			branchCols[i] = opt.ColumnID(dstCol)
+		}
+		branch := c.e.f.ConstructLimit(
+			c.e.f.ConstructProject(
+				c.e.f.ConstructSelect(
+					c.e.f.ConstructScan(newSP),
+					memo.FiltersExpr{c.e.f.ConstructFiltersItem(c.e.f.RemapCols(filter, colMap))},
+				),
+				newProjections,
+				newPassthrough,
+			),
+			limitExpr,
+			limitOrdering.RemapColumns(outCols, branchCols),
+		)
+		return branch, branchCols
+	}
+
+	one := c.e.f.ConstructConstVal(tree.NewDFloat(1), types.Float)
+	similar, similarCols := makeBranch(c.e.f.ConstructLt(dist, one), nil /* distance */)
+	rest, restCols := makeBranch(c.e.f.ConstructGe(dist, one), one)
+	if !md.Table(sp.Table).Column(sp.Table.ColumnOrdinal(col)).IsNullable() {
+		return c.e.f.ConstructUnionAll(similar, rest, &memo.SetPrivate{
+			LeftCols:  similarCols,
+			RightCols: restCols,
+			OutCols:   outCols,
+		}), true
+	}
+
+	// The union of the two branches above needs its own output columns, since
+	// outCols are produced by the union with the NULL branch.
+	nonNullCols := make(opt.ColList, len(outCols))
+	for i, outCol := range outCols {
+		colMeta := md.ColumnMeta(outCol)
+		nonNullCols[i] = md.AddColumn(colMeta.Alias, colMeta.Type)
+	}
+	nonNull := c.e.f.ConstructUnionAll(similar, rest, &memo.SetPrivate{
+		LeftCols:  similarCols,
+		RightCols: restCols,
+		OutCols:   nonNullCols,
+	})
+	nullRows, nullCols := makeBranch(
+		c.e.f.ConstructIs(c.e.f.ConstructVariable(col), memo.NullSingleton),
+		c.e.f.ConstructNull(projections[distIdx].Typ),
+	)
+	return c.e.f.ConstructUnionAll(nullRows, nonNull, &memo.SetPrivate{
+		LeftCols:  nullCols,
+		RightCols: nonNullCols,
+		OutCols:   outCols,
+	}), true
+}
+
+// hasTrigramIndex returns true if the given column of the Scan's table is
+// indexed by a non-partial trigram inverted index.
+func (c *CustomFuncs) hasTrigramIndex(sp *memo.ScanPrivate, col opt.ColumnID) bool {
+	tab := c.e.mem.Metadata().Table(sp.Table)
+	ord := sp.Table.ColumnOrdinal(col)
+	// Skip the primary index because it cannot be inverted.
+	for i := 1; i < tab.IndexCount(); i++ {
+		idx := tab.Index(i)
+		if !idx.IsInverted() {
+			continue
+		}
+		if _, isPartial := idx.Predicate(); isPartial {
+			continue
+		}
+		if idx.InvertedColumn().InvertedSourceColumnOrdinal() == ord {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateVectorIndexScan returns a Project over an IndexJoin of a Scan of a
+// vector inverted index, which produces the approximate nearest neighbors of a
+// constant vector when the limit ordering starts with a projected distance
+// between it and an indexed vector column. It is used to plan queries like:
+//
+//	SELECT * FROM t ORDER BY v <-> '[1,2,3]' LIMIT 10
+//
+// The vector index partitions the vectors into lists, and the Scan only reads
+// the lists whose centroids are the most similar to the constant vector. The
+// number of lists read is the ivfflat.probes session setting. Unlike the
+// original expression, the returned expression may miss some of the nearest
+// neighbors, and it never produces rows where the vector column is NULL.
+//
+// ok=false is returned if the limit ordering does not start with such a
+// distance, or if there is no vector index on the column whose operator class
+// matches the distance operator.
+func (c *CustomFuncs) GenerateVectorIndexScan(
+	limitOrdering props.OrderingChoice,
+	sp *memo.ScanPrivate,
+	projections memo.ProjectionsExpr,
+	passthrough opt.ColSet,
+) (_ memo.RelExpr, ok bool) {
+	if len(limitOrdering.Columns) == 0 || limitOrdering.Columns[0].Descending {
+		return nil, false
+	}
+	if sp.Flags.NoIndexJoin {
+		return nil, false
+	}
+
+	// Find the projected vector distance that the ordering starts with.
+	var col opt.ColumnID
+	var query *tree.DPGVector
+	var kind catpb.InvertedIndexColumnKind
+	for i := range projections {
+		if !limitOrdering.Columns[0].Group.Contains(projections[i].Col) {
+			continue
+		}
+		dist := projections[i].Element
+		if kind, ok = invertedidx.VectorIndexKindForDistance(dist.Op()); !ok {
+			continue
+		}
+		left, right := dist.Child(0).(opt.ScalarExpr), dist.Child(1).(opt.ScalarExpr)
+		v, ok := left.(*memo.VariableExpr)
+		if !ok || !memo.CanExtractConstDatum(right) {
+			v, ok = right.(*memo.VariableExpr)
+			if !ok || !memo.CanExtractConstDatum(left) {
+				continue
+			}
+			right = left
+		}
+		if !sp.Cols.Contains(v.Col) || v.DataType().Family() != types.PGVectorFamily {
+			continue
+		}
+		if query, ok = memo.ExtractConstDatum(right).(*tree.DPGVector); !ok {
+			continue
+		}
+		col = v.Col
+		break
+	}
+	if query == nil {
+		return nil, false
+	}
+	index := c.findVectorIndex(sp, col, kind)
+	if index == nil {
+		return nil, false
+	}
+
+	probes := int(c.e.evalCtx.SessionData().VectorSearchProbes)
+	if probes == 0 {
+		probes = vector.DefaultProbes
+	}
+	spans, err := invertedidx.VectorSearchSpans(query.T, index.VectorConfig(), probes)
+	if err != nil {
+		// The query vector has a different number of dimensions than the
+		// indexed vectors, so the distance cannot be evaluated anyway.
+		return nil, false
+	}
+
+	newSP := *sp
+	newSP.Distribution.Regions = nil
+	newSP.Index = index.Ordinal()
+	newSP.Cols = c.PrimaryKeyCols(sp.Table)
+	newSP.InvertedConstraint = spans
+
+	var sb indexScanBuilder
+	sb.Init(c, sp.Table)
+	sb.SetScan(&newSP)
+	sb.AddIndexJoin(sp.Cols)
+	return c.e.f.ConstructProject(sb.BuildNewExpr(), projections, passthrough), true
+}
+
+// findVectorIndex returns a non-partial vector inverted index of the given
+// kind on the given column of the Scan's table, or nil if there is none.
+func (c *CustomFuncs) findVectorIndex(
+	sp *memo.ScanPrivate, col opt.ColumnID, kind catpb.InvertedIndexColumnKind,
+) cat.Index {
+	tab := c.e.mem.Metadata().Table(sp.Table)
+	ord := sp.Table.ColumnOrdinal(col)
+	// Skip the primary index because it cannot be inverted.
+	for i := 1; i < tab.IndexCount(); i++ {
+		idx := tab.Index(i)
+		if !idx.IsInverted() || idx.InvertedColumnKind() != kind {
+			continue
+		}
+		if sp.Flags.ForceIndex && sp.Flags.Index != i {
+			continue
+		}
+		if _, isPartial := idx.Predicate(); isPartial {
+			continue
+		}
+		// The non-inverted prefix columns of a multi-column index would need to
+		// be constrained.
+		if idx.NonInvertedPrefixColumnCount() > 0 {
+			continue
+		}
+		if idx.InvertedColumn().InvertedSourceColumnOrdinal() == ord {
+			return idx
+		}
+	}
+	return nil
+}
+
 // MakeTopKPrivate returns a TopKPrivate operator with a constant, positive
 // integer limit and an order.
 func (c *CustomFuncs) MakeTopKPrivate(
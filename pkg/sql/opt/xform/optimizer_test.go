@@ -133,6 +133,51 @@ func TestDetachMemoRace(t *testing.T) {
 	wg.Wait()
 }
 
+// TestSplitLimitedTrigramDistanceScanCost verifies that the plan produced by
+// SplitLimitedTrigramDistanceScan is chosen because it is cheaper than sorting
+// the entire table, which is the plan when the rule is disabled.
+func TestSplitLimitedTrigramDistanceScanCost(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	catalog := testcat.New()
+	for _, ddl := range []string{
+		"CREATE TABLE trgm (k INT PRIMARY KEY, s STRING NOT NULL, INVERTED INDEX (s gin_trgm_ops))",
+		`ALTER TABLE trgm INJECT STATISTICS '[
+			{"columns": ["k"], "created_at": "2018-01-01 1:00:00.00000+00:00", "row_count": 100000, "distinct_count": 100000},
+			{"columns": ["s"], "created_at": "2018-01-01 1:00:00.00000+00:00", "row_count": 100000, "distinct_count": 90000}
+		]'`,
+	} {
+		if _, err := catalog.ExecuteDDL(ddl); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	optimize := func(disable bool) memo.RelExpr {
+		tester := opttester.New(catalog, "SELECT k FROM trgm ORDER BY s <-> 'foo' LIMIT 5")
+		if disable {
+			tester.Flags.DisableRules.Add(int(opt.SplitLimitedTrigramDistanceScan))
+		}
+		e, err := tester.Optimize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return e.(memo.RelExpr)
+	}
+	split, sorted := optimize(false /* disable */), optimize(true /* disable */)
+
+	if split.Child(0).Op() != opt.UnionAllOp {
+		t.Fatalf("expected a limited union-all, got:\n%s", split)
+	}
+	if sorted.Op() != opt.TopKOp {
+		t.Fatalf("expected a top-k with the rule disabled, got:\n%s", sorted)
+	}
+	if !split.Cost().Less(sorted.Cost()) {
+		t.Errorf("expected the cost of the split plan (%.2f) to be lower than %.2f",
+			split.Cost(), sorted.Cost())
+	}
+}
+
 // TestCoster files can be run separately like this:
 //
 //	make test PKG=./pkg/sql/opt/xform TESTS="TestCoster/sort"
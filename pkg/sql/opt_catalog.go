@@ -43,6 +43,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -490,6 +491,9 @@ func (oc *optCatalog) dataSourceForDesc(
 	case desc.IsView():
 		ds = newOptView(desc)
 
+	case desc.IsForeignTable():
+		ds = newOptForeignTable(desc)
+
 	case desc.IsSequence():
 		ds = newOptSequence(desc)
 
@@ -638,6 +642,35 @@ func (ov *optView) CollectTypes(ord int) (descpb.IDs, error) {
 	return collectTypes(col)
 }
 
+// optForeignTable is a wrapper around the catalog.TableDescriptor of a foreign
+// table that implements the cat.Object, cat.DataSource, and cat.View
+// interfaces. Foreign tables are planned like views, whose query reads the
+// rows of the file of the table.
+type optForeignTable struct {
+	optView
+	query string
+}
+
+var _ cat.View = &optForeignTable{}
+
+func newOptForeignTable(desc catalog.TableDescriptor) *optForeignTable {
+	return &optForeignTable{optView: optView{desc: desc}, query: foreignTableQuery(desc)}
+}
+
+// Equals is part of the cat.Object interface.
+func (ot *optForeignTable) Equals(other cat.Object) bool {
+	otherTable, ok := other.(*optForeignTable)
+	if !ok {
+		return false
+	}
+	return ot.desc.GetID() == otherTable.desc.GetID() && ot.desc.GetVersion() == otherTable.desc.GetVersion()
+}
+
+// Query is part of the cat.View interface.
+func (ot *optForeignTable) Query() string {
+	return ot.query
+}
+
 // optSequence is a wrapper around catalog.TableDescriptor that
 // implements the cat.Object and cat.DataSource interfaces.
 type optSequence struct {
@@ -733,6 +766,10 @@ type optTable struct {
 	// constraints for user defined types.
 	checkConstraints []cat.CheckConstraint
 
+	// triggers are the triggers defined on the table, in the order in which
+	// they fire.
+	triggers []optTrigger
+
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap catalog.TableColMap
@@ -866,12 +903,30 @@ func newOptTable(
 	ot.uniqueConstraints = make([]optUniqueConstraint, len(ot.desc.EnforcedUniqueConstraintsWithoutIndex()))
 	for i, u := range ot.desc.EnforcedUniqueConstraintsWithoutIndex() {
 		ot.uniqueConstraints[i] = optUniqueConstraint{
-			name:         u.GetName(),
-			table:        ot.ID(),
-			columns:      u.CollectKeyColumnIDs().Ordered(),
-			predicate:    u.GetPredicate(),
-			withoutIndex: true,
-			validity:     u.GetConstraintValidity(),
+			name:          u.GetName(),
+			table:         ot.ID(),
+			columns:       u.CollectKeyColumnIDs().Ordered(),
+			predicate:     u.GetPredicate(),
+			withoutIndex:  true,
+			validity:      u.GetConstraintValidity(),
+			deferrability: tree.ConstraintDeferrability(u.Deferrability()),
+		}
+		if u.IsExclusion() {
+			// The operators correspond to the columns in the order in which they
+			// were declared, so the columns cannot be sorted.
+			uc := &ot.uniqueConstraints[i]
+			uc.columns = make([]descpb.ColumnID, u.NumKeyColumns())
+			uc.exclusionOps = make([]treecmp.ComparisonOperatorSymbol, u.NumKeyColumns())
+			for j := range uc.columns {
+				uc.columns[j] = u.GetKeyColumnID(j)
+				op, ok := treecmp.LookupComparisonOperatorSymbol(u.ExclusionOperators()[j])
+				if !ok {
+					return nil, errors.AssertionFailedf(
+						"unknown operator %q in exclusion constraint %q", u.ExclusionOperators()[j], u.GetName(),
+					)
+				}
+				uc.exclusionOps[j] = op
+			}
 		}
 	}
 
@@ -976,6 +1031,7 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrability:     tree.ConstraintDeferrability(fk.Deferrability()),
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
@@ -989,6 +1045,7 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrability:     tree.ConstraintDeferrability(fk.Deferrability()),
 		})
 	}
 
@@ -1034,6 +1091,24 @@ func newOptTable(
 	}
 	ot.checkConstraints = append(ot.checkConstraints, synthesizedChecks...)
 
+	// Add triggers.
+	if triggers := desc.GetTriggers(); len(triggers) > 0 {
+		ot.triggers = make([]optTrigger, len(triggers))
+		for i := range triggers {
+			trig := &triggers[i]
+			ot.triggers[i].desc = trig
+			for j := range trig.Events {
+				for _, colID := range trig.Events[j].ColumnIDs {
+					ord, err := ot.lookupColumnOrdinal(colID)
+					if err != nil {
+						return nil, err
+					}
+					ot.triggers[i].updateCols = append(ot.triggers[i].updateCols, ord)
+				}
+			}
+		}
+	}
+
 	// Add stats last, now that other metadata is initialized.
 	if stats != nil {
 		ot.stats = make([]optTableStat, len(stats))
@@ -1339,6 +1414,26 @@ func (ot *optTable) GetDatabaseID() descpb.ID {
 	return ot.desc.GetParentID()
 }
 
+// InheritedByCount is part of the cat.Table interface.
+func (ot *optTable) InheritedByCount() int {
+	return len(ot.desc.GetInheritedBy())
+}
+
+// InheritedBy is part of the cat.Table interface.
+func (ot *optTable) InheritedBy(i int) cat.StableID {
+	return cat.StableID(ot.desc.GetInheritedBy()[i])
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	return &ot.triggers[i]
+}
+
 // lookupColumnOrdinal returns the ordinal of the column with the given ID. A
 // cache makes the lookup O(1).
 func (ot *optTable) lookupColumnOrdinal(colID descpb.ColumnID) (int, error) {
@@ -1650,6 +1745,22 @@ func (oi *optIndex) GeoConfig() geoindex.Config {
 	return oi.idx.IndexDesc().GeoConfig
 }
 
+// InvertedColumnKind is part of the cat.Index interface.
+func (oi *optIndex) InvertedColumnKind() catpb.InvertedIndexColumnKind {
+	if !oi.IsInverted() {
+		return catpb.InvertedIndexColumnKind_DEFAULT
+	}
+	return oi.idx.InvertedColumnKind()
+}
+
+// VectorConfig is part of the cat.Index interface.
+func (oi *optIndex) VectorConfig() vector.IVFConfig {
+	if !oi.IsInverted() {
+		return vector.IVFConfig{}
+	}
+	return oi.idx.VectorConfig()
+}
+
 // Version is part of the cat.Index interface.
 func (oi *optIndex) Version() descpb.IndexDescriptorVersion {
 	return oi.idx.GetVersion()
@@ -1834,6 +1945,75 @@ func (oi *optFamily) Table() cat.Table {
 	return oi.tab
 }
 
+// optTrigger implements cat.Trigger and represents a trigger on a table.
+type optTrigger struct {
+	desc *descpb.TableDescriptor_Trigger
+
+	// updateCols are the ordinals of the columns in the UPDATE OF event of the
+	// trigger.
+	updateCols []int
+}
+
+var _ cat.Trigger = &optTrigger{}
+
+// Name is part of the cat.Trigger interface.
+func (t *optTrigger) Name() tree.Name {
+	return tree.Name(t.desc.Name)
+}
+
+// ActionTime is part of the cat.Trigger interface.
+func (t *optTrigger) ActionTime() tree.TriggerActionTime {
+	if t.desc.ActionTime == descpb.TableDescriptor_Trigger_AFTER {
+		return tree.TriggerActionTimeAfter
+	}
+	return tree.TriggerActionTimeBefore
+}
+
+// HasEvent is part of the cat.Trigger interface.
+func (t *optTrigger) HasEvent(event tree.TriggerEventType) bool {
+	for i := range t.desc.Events {
+		if triggerEventType(t.desc.Events[i].Type) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateColumnCount is part of the cat.Trigger interface.
+func (t *optTrigger) UpdateColumnCount() int {
+	return len(t.updateCols)
+}
+
+// UpdateColumnOrdinal is part of the cat.Trigger interface.
+func (t *optTrigger) UpdateColumnOrdinal(i int) int {
+	return t.updateCols[i]
+}
+
+// ForEachRow is part of the cat.Trigger interface.
+func (t *optTrigger) ForEachRow() bool {
+	return t.desc.ForEachRow
+}
+
+// WhenExpr is part of the cat.Trigger interface.
+func (t *optTrigger) WhenExpr() string {
+	return t.desc.WhenExpr
+}
+
+// FuncID is part of the cat.Trigger interface.
+func (t *optTrigger) FuncID() cat.StableID {
+	return cat.StableID(t.desc.FuncID)
+}
+
+// FuncName is part of the cat.Trigger interface.
+func (t *optTrigger) FuncName() string {
+	return t.desc.FuncName
+}
+
+// FuncArgs is part of the cat.Trigger interface.
+func (t *optTrigger) FuncArgs() []string {
+	return t.desc.FuncArgs
+}
+
 // optUniqueConstraint implements cat.UniqueConstraint and represents a
 // unique constraint.
 type optUniqueConstraint struct {
@@ -1847,6 +2027,12 @@ type optUniqueConstraint struct {
 	validity     descpb.ConstraintValidity
 
 	uniquenessGuaranteedByAnotherIndex bool
+
+	// exclusionOps is non-nil for exclusion constraints, and holds the
+	// operator for each column in columns.
+	exclusionOps []treecmp.ComparisonOperatorSymbol
+
+	deferrability tree.ConstraintDeferrability
 }
 
 var _ cat.UniqueConstraint = &optUniqueConstraint{}
@@ -1902,6 +2088,24 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// IsExclusion is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) IsExclusion() bool {
+	return u.exclusionOps != nil
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol {
+	if u.exclusionOps == nil {
+		return treecmp.EQ
+	}
+	return u.exclusionOps[i]
+}
+
+// Deferrability is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrability() tree.ConstraintDeferrability {
+	return u.deferrability
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -1915,10 +2119,11 @@ type optForeignKeyConstraint struct {
 	referencedTable   cat.StableID
 	referencedColumns []descpb.ColumnID
 
-	validity     descpb.ConstraintValidity
-	match        tree.CompositeKeyMatchMethod
-	deleteAction tree.ReferenceAction
-	updateAction tree.ReferenceAction
+	validity      descpb.ConstraintValidity
+	match         tree.CompositeKeyMatchMethod
+	deleteAction  tree.ReferenceAction
+	updateAction  tree.ReferenceAction
+	deferrability tree.ConstraintDeferrability
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -1990,6 +2195,11 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return fk.updateAction
 }
 
+// Deferrability is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrability() tree.ConstraintDeferrability {
+	return fk.deferrability
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -2328,6 +2538,26 @@ func (ot *optVirtualTable) GetDatabaseID() descpb.ID {
 	return 0
 }
 
+// InheritedByCount is part of the cat.Table interface.
+func (ot *optVirtualTable) InheritedByCount() int {
+	return 0
+}
+
+// InheritedBy is part of the cat.Table interface.
+func (ot *optVirtualTable) InheritedBy(i int) cat.StableID {
+	panic(errors.AssertionFailedf("virtual tables cannot be inherited"))
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("virtual tables cannot have triggers"))
+}
+
 // CollectTypes is part of the cat.DataSource interface.
 func (ot *optVirtualTable) CollectTypes(ord int) (descpb.IDs, error) {
 	col := ot.desc.AllColumns()[ord]
@@ -2507,6 +2737,16 @@ func (oi *optVirtualIndex) GeoConfig() geoindex.Config {
 	return geoindex.Config{}
 }
 
+// InvertedColumnKind is part of the cat.Index interface.
+func (oi *optVirtualIndex) InvertedColumnKind() catpb.InvertedIndexColumnKind {
+	return catpb.InvertedIndexColumnKind_DEFAULT
+}
+
+// VectorConfig is part of the cat.Index interface.
+func (oi *optVirtualIndex) VectorConfig() vector.IVFConfig {
+	return vector.IVFConfig{}
+}
+
 // Version is part of the cat.Index interface.
 func (oi *optVirtualIndex) Version() descpb.IndexDescriptorVersion {
 	return 0
@@ -2596,7 +2836,7 @@ func collectTypes(col catalog.Column) (descpb.IDs, error) {
 		}
 	}
 	if typ := col.GetType(); typ != nil && typ.UserDefined() {
-		visitor.OIDs[typ.Oid()] = struct{}{}
+		visitor.OIDs[typ.UserDefinedOID()] = struct{}{}
 	}
 
 	ids := make(descpb.IDs, 0, len(visitor.OIDs))
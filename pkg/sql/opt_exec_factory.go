@@ -527,6 +527,7 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.Distinct,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		f.userDefined = agg.UserDefined
 
 		n.funcs = append(n.funcs, f)
 	}
@@ -1127,6 +1128,9 @@ func (ef *execFactory) ConstructWindow(root exec.Node, wi exec.WindowInfo) (exec
 			columnOrdering: wi.Ordering,
 			frame:          wi.Exprs[i].WindowDef.Frame,
 		}
+		if wi.UserDefinedAggs != nil {
+			p.funcs[i].userDefined = wi.UserDefinedAggs[i]
+		}
 		if len(wi.Ordering) == 0 {
 			frame := p.funcs[i].frame
 			if frame.Mode == treewindow.RANGE && frame.Bounds.HasOffset() {
@@ -1821,6 +1825,7 @@ func (ef *execFactory) ConstructCreateView(
 	deps opt.SchemaDeps,
 	typeDeps opt.SchemaTypeDeps,
 	withData bool,
+	incremental bool,
 ) (exec.Node, error) {
 
 	if err := checkSchemaChangeEnabled(
@@ -1848,6 +1853,7 @@ func (ef *execFactory) ConstructCreateView(
 		planDeps:     planDeps,
 		typeDeps:     typeDepSet,
 		withData:     withData,
+		incremental:  incremental,
 	}, nil
 }
 
@@ -2084,6 +2090,11 @@ func (ef *execFactory) ConstructControlSchedules(
 	}, nil
 }
 
+// ConstructCall is part of the exec.Factory interface.
+func (ef *execFactory) ConstructCall(proc tree.TypedExpr) (exec.Node, error) {
+	return &callNode{proc: proc}, nil
+}
+
 // ConstructShowCompletions is part of the exec.Factory interface.
 func (ef *execFactory) ConstructShowCompletions(command *tree.ShowCompletions) (exec.Node, error) {
 	return &completionsNode{
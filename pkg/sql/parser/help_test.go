@@ -70,6 +70,9 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER TYPE t RENAME ??`, `ALTER TYPE`},
 		{`ALTER TYPE t DROP VALUE ??`, `ALTER TYPE`},
 
+		{`ALTER DOMAIN ??`, `ALTER DOMAIN`},
+		{`ALTER DOMAIN d SET ??`, `ALTER DOMAIN`},
+
 		{`ALTER INDEX foo@bar RENAME ??`, `ALTER INDEX`},
 		{`ALTER INDEX foo@bar RENAME TO blih ??`, `ALTER INDEX`},
 		{`ALTER INDEX foo@bar SPLIT ??`, `ALTER INDEX`},
@@ -142,8 +145,17 @@ func TestContextualHelp(t *testing.T) {
 
 		{`CREATE EXTENSION ??`, `CREATE EXTENSION`},
 
+		{`CREATE LANGUAGE ??`, `CREATE LANGUAGE`},
+		{`CREATE OR REPLACE LANGUAGE ??`, `CREATE LANGUAGE`},
+
 		{`CREATE EXTERNAL CONNECTION ??`, `CREATE EXTERNAL CONNECTION`},
 
+		{`CREATE FOREIGN TABLE ??`, `CREATE FOREIGN TABLE`},
+		{`CREATE FOREIGN TABLE blah (a INT) ??`, `CREATE FOREIGN TABLE`},
+
+		{`CREATE SERVER ??`, `CREATE SERVER`},
+		{`CREATE SERVER blah FOREIGN DATA WRAPPER ??`, `CREATE SERVER`},
+
 		{`CREATE TENANT ??`, `CREATE TENANT`},
 
 		{`CREATE USER blih ??`, `CREATE ROLE`},
@@ -172,6 +184,9 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE TYPE blah AS ENUM ??`, `CREATE TYPE`},
 		{`DROP TYPE ??`, `DROP TYPE`},
 
+		{`CREATE DOMAIN ??`, `CREATE DOMAIN`},
+		{`DROP DOMAIN ??`, `DROP DOMAIN`},
+
 		{`CREATE SCHEMA IF ??`, `CREATE SCHEMA`},
 		{`CREATE SCHEMA IF NOT ??`, `CREATE SCHEMA`},
 		{`CREATE SCHEMA bli ??`, `CREATE SCHEMA`},
@@ -194,6 +209,12 @@ func TestContextualHelp(t *testing.T) {
 
 		{`DROP EXTERNAL CONNECTION blah ??`, `DROP EXTERNAL CONNECTION`},
 
+		{`DROP FOREIGN TABLE ??`, `DROP FOREIGN TABLE`},
+		{`DROP FOREIGN TABLE IF EXISTS blih, bloh ??`, `DROP FOREIGN TABLE`},
+
+		{`DROP SERVER ??`, `DROP SERVER`},
+		{`DROP SERVER IF EXISTS blih ??`, `DROP SERVER`},
+
 		{`DROP USER ??`, `DROP ROLE`},
 		{`DROP USER IF ??`, `DROP ROLE`},
 		{`DROP USER IF EXISTS bluh ??`, `DROP ROLE`},
@@ -260,6 +281,11 @@ func TestContextualHelp(t *testing.T) {
 		{`MOVE ??`, `MOVE`},
 		{`MOVE 1 ??`, `MOVE`},
 
+		{`LISTEN ??`, `LISTEN`},
+
+		{`NOTIFY ??`, `NOTIFY`},
+		{`NOTIFY foo, ??`, `NOTIFY`},
+
 		{`INSERT INTO ??`, `INSERT`},
 		{`INSERT INTO blah (??`, `<SELECTCLAUSE>`},
 		{`INSERT INTO blah VALUES (1) RETURNING ??`, `INSERT`},
@@ -267,6 +293,9 @@ func TestContextualHelp(t *testing.T) {
 		{`INSERT INTO blah VALUES (1) ??`, `VALUES`},
 		{`INSERT INTO blah TABLE foo ??`, `TABLE`},
 
+		{`MERGE ??`, `MERGE`},
+		{`MERGE INTO blah USING foo ??`, `MERGE`},
+
 		{`UPSERT INTO ??`, `UPSERT`},
 		{`UPSERT INTO blah (??`, `<SELECTCLAUSE>`},
 		{`UPSERT INTO blah VALUES (1) RETURNING ??`, `UPSERT`},
@@ -468,6 +497,9 @@ func TestContextualHelp(t *testing.T) {
 		{`SET LOCAL TIME ??`, `SET LOCAL`},
 		{`SET LOCAL TIME ZONE 'UTC' ??`, `SET LOCAL`},
 
+		{`SET CONSTRAINTS ??`, `SET CONSTRAINTS`},
+		{`SET CONSTRAINTS ALL ??`, `SET CONSTRAINTS`},
+
 		{`SET TRANSACTION ??`, `SET TRANSACTION`},
 		{`SET TRANSACTION ISOLATION LEVEL SNAPSHOT ??`, `SET TRANSACTION`},
 		{`SET TIME ??`, `SET SESSION`},
@@ -526,6 +558,21 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE FUNCTION ??`, `CREATE FUNCTION`},
 		{`ALTER FUNCTION ??`, `ALTER FUNCTION`},
 		{`DROP FUNCTION ??`, `DROP FUNCTION`},
+
+		{`CREATE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`CREATE AGGREGATE a(int) ??`, `CREATE AGGREGATE`},
+		{`ALTER AGGREGATE ??`, `ALTER AGGREGATE`},
+		{`DROP AGGREGATE ??`, `DROP AGGREGATE`},
+
+		{`CREATE TRIGGER ??`, `CREATE TRIGGER`},
+		{`CREATE TRIGGER foo BEFORE ??`, `CREATE TRIGGER`},
+		{`DROP TRIGGER ??`, `DROP TRIGGER`},
+		{`DROP TRIGGER foo ON ??`, `DROP TRIGGER`},
+
+		{`CREATE PROCEDURE ??`, `CREATE PROCEDURE`},
+		{`CREATE OR REPLACE PROCEDURE ??`, `CREATE PROCEDURE`},
+		{`DROP PROCEDURE ??`, `DROP PROCEDURE`},
+		{`CALL ??`, `CALL`},
 	}
 
 	// The following checks that the test definition above exercises all
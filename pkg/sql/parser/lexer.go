@@ -230,6 +230,8 @@ func (l *lexer) Lex(lval *sqlSymType) int {
 			switch nextToken.id {
 			case BETWEEN, IN, LIKE, ILIKE, SIMILAR:
 				lval.id = NOT_LA
+			case DEFERRABLE:
+				lval.id = NOT_DEFERRABLE
 			}
 		case GENERATED:
 			switch nextToken.id {
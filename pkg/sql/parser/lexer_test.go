@@ -26,6 +26,7 @@ func TestLexer(t *testing.T) {
 		{`NOT BETWEEN`, []int{NOT_LA, BETWEEN}},
 		{`NOT IN`, []int{NOT_LA, IN}},
 		{`NOT SIMILAR`, []int{NOT_LA, SIMILAR}},
+		{`NOT DEFERRABLE`, []int{NOT_DEFERRABLE, DEFERRABLE}},
 		{`AS OF SYSTEM TIME`, []int{AS_LA, OF, SYSTEM, TIME}},
 	}
 	for i, d := range testData {
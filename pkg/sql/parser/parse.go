@@ -70,8 +70,9 @@ type Statement struct {
 	NumAnnotations tree.AnnotationIdx
 }
 
-// IsANSIDML returns true if the AST is one of the 4 DML statements,
-// SELECT, UPDATE, INSERT, DELETE, or an EXPLAIN of one of these statements.
+// IsANSIDML returns true if the AST is one of the 5 DML statements,
+// SELECT, UPDATE, INSERT, DELETE, MERGE, or an EXPLAIN of one of these
+// statements.
 func (stmt Statement) IsANSIDML() bool {
 	return IsANSIDML(stmt.AST)
 }
@@ -80,7 +81,7 @@ func (stmt Statement) IsANSIDML() bool {
 // SELECT, UPDATE, INSERT, DELETE, or an EXPLAIN of one of these statements.
 func IsANSIDML(stmt tree.Statement) bool {
 	switch t := stmt.(type) {
-	case *tree.Select, *tree.ParenSelect, *tree.Delete, *tree.Insert, *tree.Update:
+	case *tree.Select, *tree.ParenSelect, *tree.Delete, *tree.Insert, *tree.Update, *tree.Merge:
 		return true
 	case *tree.Explain:
 		return IsANSIDML(t.Statement)
@@ -524,7 +525,7 @@ func arrayOf(
 			return nil, pgerror.Newf(pgcode.UndefinedObject, "type unknown[] does not exist")
 		}
 		if typ.Family() == types.VoidFamily {
-			return nil, pgerror.Newf(pgcode.UndefinedObject, "type void[] does not exist")
+			return nil, pgerror.Newf(pgcode.UndefinedObject, "type %s[] does not exist", typ.Name())
 		}
 		if err := types.CheckArrayElementType(typ); err != nil {
 			return nil, err
@@ -43,6 +43,7 @@ import (
     "github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
     "github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
     "github.com/cockroachdb/cockroach/pkg/sql/types"
+    "github.com/cockroachdb/cockroach/pkg/util/vector"
     "github.com/cockroachdb/errors"
     "github.com/lib/pq/oid"
 )
@@ -506,6 +507,12 @@ func (u *sqlSymUnion) updateExpr() *tree.UpdateExpr {
 func (u *sqlSymUnion) updateExprs() tree.UpdateExprs {
     return u.val.(tree.UpdateExprs)
 }
+func (u *sqlSymUnion) mergeWhen() *tree.MergeWhen {
+    return u.val.(*tree.MergeWhen)
+}
+func (u *sqlSymUnion) mergeWhens() tree.MergeWhens {
+    return u.val.(tree.MergeWhens)
+}
 func (u *sqlSymUnion) limit() *tree.Limit {
     return u.val.(*tree.Limit)
 }
@@ -617,6 +624,12 @@ func (u *sqlSymUnion) deferrableMode() tree.DeferrableMode {
 func (u *sqlSymUnion) idxElem() tree.IndexElem {
     return u.val.(tree.IndexElem)
 }
+func (u *sqlSymUnion) excludeElem() tree.ExcludeElem {
+    return u.val.(tree.ExcludeElem)
+}
+func (u *sqlSymUnion) excludeElems() tree.ExcludeElemList {
+    return u.val.(tree.ExcludeElemList)
+}
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
@@ -680,6 +693,12 @@ func (u *sqlSymUnion) intervalTypeMetadata() types.IntervalTypeMetadata {
 func (u *sqlSymUnion) kvOption() tree.KVOption {
     return u.val.(tree.KVOption)
 }
+func (u *sqlSymUnion) foreignOption() tree.ForeignOption {
+    return u.val.(tree.ForeignOption)
+}
+func (u *sqlSymUnion) foreignOptions() tree.ForeignOptions {
+    return u.val.(tree.ForeignOptions)
+}
 func (u *sqlSymUnion) kvOptions() []tree.KVOption {
     if colType, ok := u.val.([]tree.KVOption); ok {
         return colType
@@ -704,6 +723,9 @@ func (u *sqlSymUnion) transactionModes() tree.TransactionModes {
 func (u *sqlSymUnion) compositeKeyMatchMethod() tree.CompositeKeyMatchMethod {
   return u.val.(tree.CompositeKeyMatchMethod)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+  return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) referenceAction() tree.ReferenceAction {
     return u.val.(tree.ReferenceAction)
 }
@@ -810,6 +832,12 @@ func (u *sqlSymUnion) functionOptions() tree.FunctionOptions {
 func (u *sqlSymUnion) functionOption() tree.FunctionOption {
     return u.val.(tree.FunctionOption)
 }
+func (u *sqlSymUnion) aggregateOptions() tree.AggregateOptions {
+    return u.val.(tree.AggregateOptions)
+}
+func (u *sqlSymUnion) aggregateOption() tree.AggregateOption {
+    return u.val.(tree.AggregateOption)
+}
 func (u *sqlSymUnion) functionParams() tree.FuncParams {
     return u.val.(tree.FuncParams)
 }
@@ -843,6 +871,18 @@ func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
 func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
     return u.val.(tree.CTEMaterializeClause)
 }
+func (u *sqlSymUnion) triggerActionTime() tree.TriggerActionTime {
+    return u.val.(tree.TriggerActionTime)
+}
+func (u *sqlSymUnion) triggerEvent() *tree.TriggerEvent {
+    return u.val.(*tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerEvents() tree.TriggerEvents {
+    return u.val.(tree.TriggerEvents)
+}
+func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
+    return u.val.(tree.TriggerForEach)
+}
 %}
 
 // NB: the %token definitions must come before the %type definitions in this
@@ -870,21 +910,21 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %token <str> BUCKET_COUNT
 %token <str> BOOLEAN BOTH BOX2D BUNDLE BY
 
-%token <str> CACHE CALLED CANCEL CANCELQUERY CASCADE CASE CAST CBRT CHANGEFEED CHAR
+%token <str> CACHE CALL CALLED CANCEL CANCELQUERY CASCADE CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CLOSE
 %token <str> CLUSTER COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONNECTION CONNECTIONS CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
-%token <str> CONVERSION CONVERT COPY COST COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
+%token <str> CONVERSION CONVERT COPY COS_DISTANCE COST COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
 %token <str> CROSS CSV CUBE CURRENT CURRENT_CATALOG CURRENT_DATE CURRENT_SCHEMA
 %token <str> CURRENT_ROLE CURRENT_TIME CURRENT_TIMESTAMP
 %token <str> CURRENT_USER CURSOR CYCLE
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_PAUSE_ON DEC DECIMAL DEFAULT DEFAULTS DEFINER
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
-%token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
+%token <str> DISCARD DISTANCE DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> EACH ELSE ENCODING ENCRYPTED ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
@@ -905,29 +945,29 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMUTABLE IMPORT IN INCLUDE
 %token <str> INCLUDING INCREMENT INCREMENTAL INCREMENTAL_LOCATION
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
-%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
+%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERIT INHERITS INJECT INITIALLY
 %token <str> INDEX_BEFORE_PAREN INDEX_BEFORE_NAME_THEN_PAREN INDEX_AFTER_ORDER_BY_BEFORE_AT
-%token <str> INNER INOUT INPUT INSENSITIVE INSERT INT INTEGER
+%token <str> INNER INOUT INPUT INSENSITIVE INSERT INSTEAD INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED INVOKER IS ISERROR ISNULL ISOLATION
 
-%token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
+%token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS JSON_PATH_EXISTS
 
 %token <str> KEY KEYS KMS KV
 
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LISTEN LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MONTH MOVE
+%token <str> MATCH MATCHED MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
 
-%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
+%token <str> NAN NAME NAMES NATURAL NEG_INNER_PRODUCT NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NOLOGIN NOMODIFYCLUSTERSETTING
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT
-%token <str> NOTHING NOTHING_AFTER_RETURNING
+%token <str> NOTHING NOTHING_AFTER_RETURNING NOTIFY
 %token <str> NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
@@ -937,11 +977,11 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PHYSICAL PLACEMENT PLACING
 %token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIOR PRIORITY PRIVILEGES
-%token <str> PROCEDURAL PUBLIC PUBLICATION
+%token <str> PROCEDURAL PROCEDURE PUBLIC PUBLICATION
 
 %token <str> QUERIES QUERY QUOTE
 
-%token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REF REFERENCES REFRESH
+%token <str> RANGE RANGE_ADJACENT RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REF REFERENCES REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH RENAME REPEATABLE REPLACE REPLICATION
 %token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
@@ -954,7 +994,8 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL
 %token <str> SQLLOGIN
 
-%token <str> STABLE START STATE STATISTICS STATUS STDIN STREAM STRICT STRING STORAGE STORE STORED STORING SUBSTRING SUPER
+%token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STREAM STRICT STRICT_WORD_SIMILARITY_COMMUTATOR_OP
+%token <str> STRICT_WORD_SIMILARITY_DIST_COMMUTATOR_OP STRICT_WORD_SIMILARITY_DIST_OP STRICT_WORD_SIMILARITY_OP STRING STORAGE STORE STORED STORING SUBSTRING SUPER
 %token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
 
 %token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
@@ -966,10 +1007,11 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %token <str> UNBOUNDED UNCOMMITTED UNION UNIQUE UNKNOWN UNLISTEN UNLOGGED UNSPLIT
 %token <str> UPDATE UPSERT UNSET UNTIL USE USER USERS USING UUID
 
-%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VERIFY_BACKUP_TABLE_DATA VIEW VARYING VIEWACTIVITY VIEWACTIVITYREDACTED VIEWDEBUG
+%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VECTOR VERIFY_BACKUP_TABLE_DATA VIEW VARYING VIEWACTIVITY VIEWACTIVITYREDACTED VIEWDEBUG
 %token <str> VIEWCLUSTERMETADATA VIEWCLUSTERSETTING VIRTUAL VISIBLE INVISIBLE VOLATILE VOTERS
 
-%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
+%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORD_SIMILARITY_COMMUTATOR_OP WORD_SIMILARITY_DIST_COMMUTATOR_OP
+%token <str> WORD_SIMILARITY_DIST_OP WORD_SIMILARITY_OP WORK WRAPPER WRITE
 
 %token <str> YEAR
 
@@ -983,6 +1025,8 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 // - NOT_LA exists so that productions such as NOT LIKE can be given the same
 // precedence as LIKE; otherwise they'd effectively have the same precedence as
 // NOT, at least with respect to their left-hand subexpression.
+// - NOT_DEFERRABLE distinguishes NOT DEFERRABLE from NOT VALID after a
+// constraint definition.
 // - WITH_LA is needed to make the grammar LALR(1).
 // - GENERATED_ALWAYS is needed to support the Postgres syntax for computed
 // columns along with our family related extensions (CREATE FAMILY/CREATE FAMILY
@@ -995,7 +1039,7 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 // - TENANT_ALL is used to differentiate `ALTER TENANT <id>` from
 // `ALTER TENANT ALL`.
 %token NOT_LA NULLS_LA WITH_LA AS_LA GENERATED_ALWAYS GENERATED_BY_DEFAULT RESET_ALL ROLE_ALL
-%token USER_ALL ON_LA TENANT_ALL SET_TRACING
+%token USER_ALL ON_LA TENANT_ALL SET_TRACING NOT_DEFERRABLE
 
 %union {
   id    int32
@@ -1022,8 +1066,9 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <tree.Statement> alter_role_stmt
 %type <*tree.SetVar> set_or_reset_clause
 %type <tree.Statement> alter_type_stmt
+%type <tree.Statement> alter_domain_stmt
 %type <tree.Statement> alter_schema_stmt
-%type <tree.Statement> alter_unsupported_stmt
+%type <tree.Statement> alter_aggregate_stmt
 %type <tree.Statement> alter_func_stmt
 
 // ALTER RANGE
@@ -1129,6 +1174,7 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <tree.Statement> create_ddl_stmt
 %type <tree.Statement> create_database_stmt
 %type <tree.Statement> create_extension_stmt
+%type <tree.Statement> create_language_stmt
 %type <tree.Statement> create_external_connection_stmt
 %type <tree.Statement> create_index_stmt
 %type <tree.Statement> create_role_stmt
@@ -1140,7 +1186,14 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <tree.Statement> create_tenant_stmt
 %type <tree.Statement> create_view_stmt
 %type <tree.Statement> create_sequence_stmt
+%type <tree.Statement> create_aggregate_stmt
 %type <tree.Statement> create_func_stmt
+%type <tree.Statement> create_proc_stmt
+%type <tree.Statement> create_trigger_stmt
+%type <tree.Statement> create_server_stmt
+%type <tree.Statement> create_foreign_table_stmt
+%type <tree.ForeignOptions> opt_foreign_options foreign_option_list
+%type <tree.ForeignOption> foreign_option
 
 %type <tree.Statement> create_stats_stmt
 %type <*tree.CreateStatsOptions> opt_create_stats_options
@@ -1148,10 +1201,12 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <*tree.CreateStatsOptions> create_stats_option
 
 %type <tree.Statement> create_type_stmt
+%type <tree.Statement> create_domain_stmt
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
 
 %type <tree.Statement> drop_stmt
+%type <tree.Statement> call_stmt
 %type <tree.Statement> drop_ddl_stmt
 %type <tree.Statement> drop_database_stmt
 %type <tree.Statement> drop_external_connection_stmt
@@ -1160,9 +1215,15 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
 %type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_domain_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
+%type <tree.Statement> drop_aggregate_stmt
 %type <tree.Statement> drop_func_stmt
+%type <tree.Statement> drop_proc_stmt
+%type <tree.Statement> drop_trigger_stmt
+%type <tree.Statement> drop_server_stmt
+%type <tree.Statement> drop_foreign_table_stmt
 %type <tree.Statement> drop_tenant_stmt
 %type <bool>           opt_immediate
 
@@ -1177,6 +1238,8 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <tree.Statement> deallocate_stmt
 %type <tree.Statement> grant_stmt
 %type <tree.Statement> insert_stmt
+%type <tree.Statement> merge_stmt
+%type <tree.TableExpr> merge_target
 %type <tree.Statement> import_stmt
 %type <tree.Statement> pause_stmt pause_jobs_stmt pause_schedules_stmt pause_all_jobs_stmt
 %type <*tree.Select>   for_schedules_clause
@@ -1201,6 +1264,7 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt set_or_reset_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -1262,6 +1326,8 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <tree.Statement> transaction_stmt legacy_transaction_stmt legacy_begin_stmt legacy_end_stmt
 %type <tree.Statement> truncate_stmt
 %type <tree.Statement> unlisten_stmt
+%type <tree.Statement> listen_stmt
+%type <tree.Statement> notify_stmt
 %type <tree.Statement> update_stmt
 %type <tree.Statement> upsert_stmt
 %type <tree.Statement> use_stmt
@@ -1376,19 +1442,20 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <*tree.PartitionByTable> opt_partition_by_table partition_by_table
 %type <*tree.PartitionByIndex> opt_partition_by_index partition_by_index
 %type <str> partition opt_partition
-%type <str> opt_create_table_inherits
+%type <tree.TableNames> opt_create_table_inherits
 %type <tree.ListPartition> list_partition
 %type <[]tree.ListPartition> list_partitions
 %type <tree.RangePartition> range_partition
 %type <[]tree.RangePartition> range_partitions
 %type <empty> opt_all_clause
 %type <empty> opt_privileges_clause
-%type <bool> distinct_clause opt_with_data
+%type <bool> distinct_clause opt_with_data opt_view_recursive
 %type <tree.DistinctOn> distinct_on_clause
 %type <tree.NameList> opt_column_list insert_column_list opt_stats_columns query_stats_cols
 %type <tree.OrderBy> sort_clause single_sort_clause opt_sort_clause
 %type <[]*tree.Order> sortby_list
 %type <tree.IndexElemList> index_params create_as_params
+%type <tree.ExcludeElemList> exclude_elems
 %type <tree.NameList> name_list privilege_list
 %type <[]int32> opt_array_bounds
 %type <tree.From> from_clause
@@ -1402,6 +1469,9 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <tree.SelectExprs> target_list
 %type <tree.UpdateExprs> set_clause_list
 %type <*tree.UpdateExpr> set_clause multiple_set_clause
+%type <tree.MergeWhens> merge_when_list
+%type <*tree.MergeWhen> merge_when_clause merge_when_matched_action merge_when_not_matched_action
+%type <tree.Expr> opt_merge_when_cond
 %type <tree.ArraySubscripts> array_subscripts
 %type <tree.GroupBy> group_clause
 %type <tree.Exprs> group_by_list
@@ -1434,6 +1504,7 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 
 %type <bool> opt_unique opt_concurrently opt_cluster opt_without_index
 %type <bool> opt_index_access_method opt_index_visible alter_index_visible
+%type <str> opt_exclude_access_method
 
 %type <*tree.Limit> limit_clause offset_clause opt_limit_clause
 %type <tree.Expr> select_fetch_first_value
@@ -1477,7 +1548,8 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <bool> opt_ordinality opt_compact
 %type <*tree.Order> sortby
 %type <tree.IndexElem> index_elem index_elem_options create_as_param
-%type <tree.TableExpr> table_ref numeric_table_ref func_table
+%type <tree.ExcludeElem> exclude_elem
+%type <tree.TableExpr> table_ref numeric_table_ref func_table table_ref_relation_expr
 %type <tree.Exprs> rowsfrom_list
 %type <tree.Expr> rowsfrom_item
 %type <tree.TableExpr> joined_table
@@ -1506,6 +1578,7 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <*types.T> character_base
 %type <*types.T> geo_shape_type
 %type <*types.T> const_geo
+%type <*types.T> const_vector
 %type <str> extract_arg
 %type <bool> opt_varying
 
@@ -1541,6 +1614,7 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <tree.NamedColumnQualification> col_qualification create_as_col_qualification
 %type <tree.ColumnQualification> col_qualification_elem create_as_col_qualification_elem
 %type <tree.CompositeKeyMatchMethod> key_match
+%type <tree.ConstraintDeferrability> opt_deferrable constraint_deferrability
 %type <tree.ReferenceActions> reference_actions
 %type <tree.ReferenceAction> reference_action reference_on_delete reference_on_update
 
@@ -1610,6 +1684,8 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <tree.ResolvableTypeReference> func_return_type func_param_type
 %type <tree.FunctionOptions> opt_create_func_opt_list create_func_opt_list alter_func_opt_list
 %type <tree.FunctionOption> create_func_opt_item common_func_opt_item
+%type <tree.AggregateOptions> aggregate_option_list
+%type <tree.AggregateOption> aggregate_option
 %type <tree.FuncParamClass> func_param_class
 %type <*tree.UnresolvedObjectName> func_create_name
 %type <tree.Statement> routine_return_stmt routine_body_stmt
@@ -1618,6 +1694,15 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 %type <tree.FuncObj> function_with_paramtypes
 %type <tree.FuncObjs> function_with_paramtypes_list
 
+// Trigger relevant components.
+%type <tree.TriggerActionTime> trigger_action_time
+%type <tree.TriggerEvents> trigger_event_list
+%type <*tree.TriggerEvent> trigger_event
+%type <tree.TriggerForEach> opt_trigger_for_each
+%type <tree.Expr> opt_trigger_when
+%type <[]string> opt_trigger_func_args trigger_func_args
+%type <str> trigger_func_arg
+
 %type <*tree.LabelSpec> label_spec
 
 %type <*tree.ShowRangesOptions> opt_show_ranges_options show_ranges_options
@@ -1662,11 +1747,13 @@ func (u *sqlSymUnion) cteMaterializeClause() tree.CTEMaterializeClause {
 // funny behavior of UNBOUNDED on the SQL standard, though.
 %nonassoc  UNBOUNDED         // ideally should have same precedence as IDENT
 %nonassoc  IDENT NULL PARTITION RANGE ROWS GROUPS PRECEDING FOLLOWING CUBE ROLLUP
-%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT  // multi-character ops
+%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT JSON_PATH_EXISTS  // multi-character ops
 %left      '|'
 %left      '#'
 %left      '&'
-%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND SQRT CBRT
+%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND RANGE_ADJACENT SQRT CBRT
+%left      DISTANCE COS_DISTANCE NEG_INNER_PRODUCT WORD_SIMILARITY_OP WORD_SIMILARITY_COMMUTATOR_OP STRICT_WORD_SIMILARITY_OP STRICT_WORD_SIMILARITY_COMMUTATOR_OP
+%left      WORD_SIMILARITY_DIST_OP WORD_SIMILARITY_DIST_COMMUTATOR_OP STRICT_WORD_SIMILARITY_DIST_OP STRICT_WORD_SIMILARITY_DIST_COMMUTATOR_OP
 %left      OPERATOR // if changing the last token before OPERATOR, change all instances of %prec <last token>
 %left      '+' '-'
 %left      '*' '/' FLOORDIV '%'
@@ -1730,6 +1817,8 @@ stmt_without_legacy_transaction:
 | fetch_cursor_stmt          // EXTEND WITH HELP: FETCH
 | move_cursor_stmt           // EXTEND WITH HELP: MOVE
 | reindex_stmt
+| listen_stmt                // EXTEND WITH HELP: LISTEN
+| notify_stmt                // EXTEND WITH HELP: NOTIFY
 | unlisten_stmt
 | show_commit_timestamp_stmt // EXTEND WITH HELP: SHOW COMMIT TIMESTAMP
 
@@ -1740,7 +1829,6 @@ alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_role_stmt     // EXTEND WITH HELP: ALTER ROLE
 | alter_tenant_stmt   /* SKIP DOC */
-| alter_unsupported_stmt
 | ALTER error         // SHOW HELP: ALTER
 
 alter_ddl_stmt:
@@ -1753,10 +1841,12 @@ alter_ddl_stmt:
 | alter_partition_stmt          // EXTEND WITH HELP: ALTER PARTITION
 | alter_schema_stmt             // EXTEND WITH HELP: ALTER SCHEMA
 | alter_type_stmt               // EXTEND WITH HELP: ALTER TYPE
+| alter_domain_stmt             // EXTEND WITH HELP: ALTER DOMAIN
 | alter_default_privileges_stmt // EXTEND WITH HELP: ALTER DEFAULT PRIVILEGES
 | alter_changefeed_stmt         // EXTEND WITH HELP: ALTER CHANGEFEED
 | alter_backup_stmt             // EXTEND WITH HELP: ALTER BACKUP
 | alter_func_stmt               // EXTEND WITH HELP: ALTER FUNCTION
+| alter_aggregate_stmt          // EXTEND WITH HELP: ALTER AGGREGATE
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
 
 // %Help: ALTER TABLE - change the definition of a table
@@ -1778,6 +1868,7 @@ alter_ddl_stmt:
 //   ALTER TABLE ... RENAME TO <newname>
 //   ALTER TABLE ... RENAME [COLUMN] <colname> TO <newname>
 //   ALTER TABLE ... VALIDATE CONSTRAINT <constraintname>
+//   ALTER TABLE ... ALTER CONSTRAINT <constraintname> [NOT] DEFERRABLE [INITIALLY {DEFERRED | IMMEDIATE}]
 //   ALTER TABLE ... SET (storage_param = value, ...)
 //   ALTER TABLE ... SPLIT AT <selectclause> [WITH EXPIRATION <expr>]
 //   ALTER TABLE ... UNSPLIT AT <selectclause>
@@ -2701,18 +2792,27 @@ alter_table_cmd:
     }
   }
   // ALTER TABLE <name> ALTER CONSTRAINT ...
-| ALTER CONSTRAINT constraint_name error { return unimplementedWithIssueDetail(sqllex, 31632, "alter constraint") }
-  // ALTER TABLE <name> INHERITS ....
-| INHERITS error
+| ALTER CONSTRAINT constraint_name constraint_deferrability
   {
-    /* SKIP DOC */
-    return unimplementedWithIssueDetail(sqllex, 22456, "alter table inherits")
+    $$.val = &tree.AlterTableAlterConstraint{
+      Constraint: tree.Name($3),
+      Deferrable: $4.constraintDeferrability(),
+    }
   }
-  // ALTER TABLE <name> NO INHERITS ....
-| NO INHERITS error
+  // ALTER TABLE <name> INHERIT <parent>
+| INHERIT table_name
   {
-    /* SKIP DOC */
-    return unimplementedWithIssueDetail(sqllex, 22456, "alter table no inherits")
+    $$.val = &tree.AlterTableInherit{
+      Parent: $2.unresolvedObjectName().ToTableName(),
+    }
+  }
+  // ALTER TABLE <name> NO INHERIT <parent>
+| NO INHERIT table_name
+  {
+    $$.val = &tree.AlterTableInherit{
+      Parent: $3.unresolvedObjectName().ToTableName(),
+      NoInherit: true,
+    }
   }
   // ALTER TABLE <name> ALTER PRIMARY KEY USING COLUMNS ( <colnames...> )
 | ALTER PRIMARY KEY USING COLUMNS '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -2966,6 +3066,104 @@ alter_type_stmt:
   }
 | ALTER TYPE error // SHOW HELP: ALTER TYPE
 
+// %Help: ALTER DOMAIN - change the definition of a domain
+// %Category: DDL
+// %Text: ALTER DOMAIN <type_name> <command>
+//
+// Commands:
+//   ALTER DOMAIN ... { SET DEFAULT <expr> | DROP DEFAULT }
+//   ALTER DOMAIN ... { SET | DROP } NOT NULL
+//   ALTER DOMAIN ... ADD [CONSTRAINT <name>] CHECK (<expr>)
+//   ALTER DOMAIN ... DROP CONSTRAINT [IF EXISTS] <name>
+//   ALTER DOMAIN ... RENAME TO <newname>
+//   ALTER DOMAIN ... SET SCHEMA <newschemaname>
+//   ALTER DOMAIN ... OWNER TO {<newowner> | CURRENT_USER | SESSION_USER }
+//
+// %SeeAlso: CREATE DOMAIN, DROP DOMAIN
+alter_domain_stmt:
+  ALTER DOMAIN type_name SET DEFAULT a_expr
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{Default: $6.expr()},
+    }
+  }
+| ALTER DOMAIN type_name DROP DEFAULT
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{},
+    }
+  }
+| ALTER DOMAIN type_name SET NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: true},
+    }
+  }
+| ALTER DOMAIN type_name DROP NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: false},
+    }
+  }
+| ALTER DOMAIN type_name ADD CONSTRAINT constraint_name CHECK '(' a_expr ')'
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{
+        Constraint: tree.DomainConstraint{Name: tree.Name($6), Nullability: tree.SilentNull, Check: $9.expr()},
+      },
+    }
+  }
+| ALTER DOMAIN type_name ADD CHECK '(' a_expr ')'
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{
+        Constraint: tree.DomainConstraint{Nullability: tree.SilentNull, Check: $7.expr()},
+      },
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT constraint_name
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{Constraint: tree.Name($6)},
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT IF EXISTS constraint_name
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{Constraint: tree.Name($8), IfExists: true},
+    }
+  }
+| ALTER DOMAIN type_name RENAME TO name
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainRename{NewName: tree.Name($6)},
+    }
+  }
+| ALTER DOMAIN type_name SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetSchema{Schema: tree.Name($6)},
+    }
+  }
+| ALTER DOMAIN type_name OWNER TO role_spec
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainOwner{Owner: $6.roleSpec()},
+    }
+  }
+| ALTER DOMAIN error // SHOW HELP: ALTER DOMAIN
+
 opt_add_val_placement:
   BEFORE SCONST
   {
@@ -3713,16 +3911,6 @@ import_format:
     $$ = strings.ToUpper($1)
   }
 
-alter_unsupported_stmt:
-  ALTER DOMAIN error
-  {
-    return unimplemented(sqllex, "alter domain")
-  }
-| ALTER AGGREGATE error
-  {
-    return unimplementedWithIssueDetail(sqllex, 74775, "alter aggregate")
-  }
-
 // %Help: IMPORT - load data from file in a distributed manner
 // %Category: CCL
 // %Text:
@@ -4159,6 +4347,7 @@ create_stmt:
 | create_stats_stmt    // EXTEND WITH HELP: CREATE STATISTICS
 | create_changefeed_stmt
 | create_extension_stmt  // EXTEND WITH HELP: CREATE EXTENSION
+| create_language_stmt   // EXTEND WITH HELP: CREATE LANGUAGE
 | create_external_connection_stmt // EXTEND WITH HELP: CREATE EXTERNAL CONNECTION
 | create_tenant_stmt // EXTEND WITH HELP: CREATE TENANT
 | create_schedule_stmt
@@ -4253,6 +4442,17 @@ create_extension_stmt:
   }
 | CREATE EXTENSION error // SHOW HELP: CREATE EXTENSION
 
+// %Help: CREATE LANGUAGE - pseudo-statement for PostgreSQL compatibility
+// %Category: Cfg
+// %Text: CREATE [OR REPLACE] [TRUSTED] [PROCEDURAL] LANGUAGE name
+// %SeeAlso: CREATE FUNCTION
+create_language_stmt:
+  CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name
+  {
+    $$.val = &tree.CreateLanguage{Name: tree.Name($6), Replace: $2.bool()}
+  }
+| CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE error // SHOW HELP: CREATE LANGUAGE
+
 // %Help: CREATE FUNCTION - define a new function
 // %Category: DDL
 // %Text:
@@ -4286,6 +4486,103 @@ create_func_stmt:
   }
 | CREATE opt_or_replace FUNCTION error // SHOW HELP: CREATE FUNCTION
 
+// %Help: CREATE PROCEDURE - define a new procedure
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] PROCEDURE
+//    name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//  { LANGUAGE lang_name
+//    | AS 'definition'
+//  } ...
+// %SeeAlso: CALL, DROP PROCEDURE
+create_proc_stmt:
+  CREATE opt_or_replace PROCEDURE func_create_name '(' opt_func_param_with_default_list ')'
+  opt_create_func_opt_list opt_routine_body
+  {
+    name := $4.unresolvedObjectName().ToFunctionName()
+    $$.val = &tree.CreateFunction{
+      IsProcedure: true,
+      Replace: $2.bool(),
+      FuncName: name,
+      Params: $6.functionParams(),
+      ReturnType: tree.FuncReturnType{
+        Type: types.Void,
+      },
+      Options: $8.functionOptions(),
+      RoutineBody: $9.routineBody(),
+    }
+  }
+| CREATE opt_or_replace PROCEDURE error // SHOW HELP: CREATE PROCEDURE
+
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] AGGREGATE
+//    name ( [ argname ] argtype [, ...] ) (
+//      SFUNC = sfunc,
+//      STYPE = state_data_type
+//      [ , FINALFUNC = ffunc ]
+//      [ , INITCOND = initial_condition ]
+//    )
+// %SeeAlso: DROP AGGREGATE, ALTER AGGREGATE, CREATE FUNCTION
+create_aggregate_stmt:
+  CREATE opt_or_replace AGGREGATE func_create_name '(' opt_func_param_with_default_list ')' '(' aggregate_option_list ')'
+  {
+    $$.val = &tree.CreateAggregate{
+      Replace: $2.bool(),
+      FuncName: $4.unresolvedObjectName().ToFunctionName(),
+      Params: $6.functionParams(),
+      Options: $9.aggregateOptions(),
+    }
+  }
+| CREATE opt_or_replace AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+aggregate_option_list:
+  aggregate_option
+  {
+    $$.val = tree.AggregateOptions{$1.aggregateOption()}
+  }
+| aggregate_option_list ',' aggregate_option
+  {
+    $$.val = append($1.aggregateOptions(), $3.aggregateOption())
+  }
+
+aggregate_option:
+  IDENT '=' typename
+  {
+    switch $1 {
+    case "sfunc", "finalfunc":
+      fn, ok := $3.typeReference().(*tree.UnresolvedObjectName)
+      if !ok {
+        sqllex.Error(fmt.Sprintf("invalid function name for aggregate attribute %q", $1))
+        return 1
+      }
+      if $1 == "sfunc" {
+        $$.val = tree.AggregateTransitionFunc{Name: fn.ToFunctionName()}
+      } else {
+        $$.val = tree.AggregateFinalFunc{Name: fn.ToFunctionName()}
+      }
+    case "stype":
+      $$.val = tree.AggregateStateType{Type: $3.typeReference()}
+    default:
+      return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate " + $1)
+    }
+  }
+| IDENT '=' SCONST
+  {
+    if $1 != "initcond" {
+      return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate " + $1)
+    }
+    $$.val = tree.AggregateInitCond($3)
+  }
+| IDENT '=' numeric_only
+  {
+    if $1 != "initcond" {
+      return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate " + $1)
+    }
+    $$.val = tree.AggregateInitCond($3.numVal().String())
+  }
+
 opt_or_replace:
   OR REPLACE { $$.val = true }
 | /* EMPTY */ { $$.val = false }
@@ -4365,10 +4662,10 @@ func_param:
 
 func_param_class:
   IN { $$.val = tree.FunctionParamIn }
-| OUT { return unimplemented(sqllex, "create function with 'OUT' argument class") }
-| INOUT { return unimplemented(sqllex, "create function with 'INOUT' argument class") }
-| IN OUT { return unimplemented(sqllex, "create function with 'IN OUT' argument class") }
-| VARIADIC { return unimplementedWithIssueDetail(sqllex, 88947, "variadic user-defined functions") }
+| OUT { $$.val = tree.FunctionParamOut }
+| INOUT { $$.val = tree.FunctionParamInOut }
+| IN OUT { $$.val = tree.FunctionParamInOut }
+| VARIADIC { $$.val = tree.FunctionParamVariadic }
 
 func_param_type:
   typename
@@ -4542,6 +4839,74 @@ drop_func_stmt:
   }
 | DROP FUNCTION error // SHOW HELP: DROP FUNCTION
 
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text:
+// DROP AGGREGATE [ IF EXISTS ] name [ ( [ argname ] argtype [, ...] ) ] [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE AGGREGATE
+drop_aggregate_stmt:
+  DROP AGGREGATE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsAggregate: true,
+      Functions: $3.functionObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsAggregate: true,
+      IfExists: true,
+      Functions: $5.functionObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
+// %Help: DROP PROCEDURE - remove a procedure
+// %Category: DDL
+// %Text:
+// DROP PROCEDURE [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE PROCEDURE
+drop_proc_stmt:
+  DROP PROCEDURE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsProcedure: true,
+      Functions: $3.functionObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsProcedure: true,
+      IfExists: true,
+      Functions: $5.functionObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
+
+// %Help: CALL - invoke a procedure
+// %Category: Misc
+// %Text: CALL name ( [ argument ] [, ...] )
+// %SeeAlso: CREATE PROCEDURE
+call_stmt:
+  CALL func_application
+  {
+    p, ok := $2.expr().(*tree.FuncExpr)
+    if !ok || p.Type != 0 || p.OrderBy != nil {
+      sqllex.Error("invalid procedure call")
+      return 1
+    }
+    $$.val = &tree.Call{Proc: p}
+  }
+| CALL error // SHOW HELP: CALL
+
 function_with_paramtypes_list:
   function_with_paramtypes
   {
@@ -4636,45 +5001,363 @@ alter_func_owner_stmt:
       NewOwner: $6.roleSpec(),
     }
   }
-
-alter_func_dep_extension_stmt:
-  ALTER FUNCTION function_with_paramtypes opt_no DEPENDS ON EXTENSION name
+
+// %Help: ALTER AGGREGATE - change the definition of an aggregate function
+// %Category: DDL
+// %Text:
+// ALTER AGGREGATE name ( [ argname ] argtype [, ...] ) RENAME TO new_name
+// ALTER AGGREGATE name ( [ argname ] argtype [, ...] )
+//    OWNER TO { new_owner | CURRENT_USER | SESSION_USER }
+// ALTER AGGREGATE name ( [ argname ] argtype [, ...] ) SET SCHEMA new_schema
+// %SeeAlso: CREATE AGGREGATE, DROP AGGREGATE
+alter_aggregate_stmt:
+  ALTER AGGREGATE function_with_paramtypes RENAME TO name
+  {
+    $$.val = &tree.AlterFunctionRename{
+      Function: $3.functionObj(),
+      NewName: tree.Name($6),
+      IsAggregate: true,
+    }
+  }
+| ALTER AGGREGATE function_with_paramtypes SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterFunctionSetSchema{
+      Function: $3.functionObj(),
+      NewSchemaName: tree.Name($6),
+      IsAggregate: true,
+    }
+  }
+| ALTER AGGREGATE function_with_paramtypes OWNER TO role_spec
+  {
+    $$.val = &tree.AlterFunctionSetOwner{
+      Function: $3.functionObj(),
+      NewOwner: $6.roleSpec(),
+      IsAggregate: true,
+    }
+  }
+| ALTER AGGREGATE error // SHOW HELP: ALTER AGGREGATE
+
+alter_func_dep_extension_stmt:
+  ALTER FUNCTION function_with_paramtypes opt_no DEPENDS ON EXTENSION name
+  {
+    $$.val = &tree.AlterFunctionDepExtension{
+      Function: $3.functionObj(),
+      Remove: $4.bool(),
+      Extension: tree.Name($8),
+    }
+  }
+
+opt_no:
+  NO
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
+// %Help: CREATE TRIGGER - define a new trigger
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] TRIGGER name { BEFORE | AFTER | INSTEAD OF } event [ OR ... ]
+//    ON table_name
+//    [ FOR [ EACH ] { ROW | STATEMENT } ]
+//    [ WHEN ( condition ) ]
+//    EXECUTE { FUNCTION | PROCEDURE } function_name ( [ arguments ] )
+//
+// Events:
+//    INSERT
+//    UPDATE [ OF column_name [, ...] ]
+//    DELETE
+//    TRUNCATE
+// %SeeAlso: DROP TRIGGER, CREATE FUNCTION
+create_trigger_stmt:
+  CREATE opt_or_replace TRIGGER name trigger_action_time trigger_event_list ON table_name
+  opt_trigger_for_each opt_trigger_when EXECUTE function_or_procedure func_create_name '(' opt_trigger_func_args ')'
+  {
+    $$.val = &tree.CreateTrigger{
+      Replace: $2.bool(),
+      Name: tree.Name($4),
+      ActionTime: $5.triggerActionTime(),
+      Events: $6.triggerEvents(),
+      TableName: $8.unresolvedObjectName(),
+      ForEach: $9.triggerForEach(),
+      When: $10.expr(),
+      FuncName: $13.unresolvedObjectName().ToFunctionName(),
+      FuncArgs: $15.strs(),
+    }
+  }
+| CREATE opt_or_replace TRIGGER error // SHOW HELP: CREATE TRIGGER
+
+trigger_action_time:
+  BEFORE
+  {
+    $$.val = tree.TriggerActionTimeBefore
+  }
+| AFTER
+  {
+    $$.val = tree.TriggerActionTimeAfter
+  }
+| INSTEAD OF
+  {
+    $$.val = tree.TriggerActionTimeInsteadOf
+  }
+
+trigger_event_list:
+  trigger_event
+  {
+    $$.val = tree.TriggerEvents{$1.triggerEvent()}
+  }
+| trigger_event_list OR trigger_event
+  {
+    $$.val = append($1.triggerEvents(), $3.triggerEvent())
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventInsert}
+  }
+| UPDATE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventUpdate}
+  }
+| UPDATE OF name_list
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventUpdate, Columns: $3.nameList()}
+  }
+| DELETE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventDelete}
+  }
+| TRUNCATE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventTruncate}
+  }
+
+opt_trigger_for_each:
+  FOR opt_each ROW
+  {
+    $$.val = tree.TriggerForEachRow
+  }
+| FOR opt_each STATEMENT
+  {
+    $$.val = tree.TriggerForEachStatement
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.TriggerForEachStatement
+  }
+
+opt_each:
+  EACH {}
+| /* EMPTY */ {}
+
+opt_trigger_when:
+  WHEN '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+// The FUNCTION and PROCEDURE keywords are interchangeable here; PROCEDURE is
+// accepted for compatibility with older PostgreSQL versions.
+function_or_procedure:
+  FUNCTION {}
+| PROCEDURE {}
+
+opt_trigger_func_args:
+  trigger_func_args
+  {
+    $$.val = $1.strs()
+  }
+| /* EMPTY */
+  {
+    $$.val = []string(nil)
+  }
+
+trigger_func_args:
+  trigger_func_arg
+  {
+    $$.val = []string{$1}
+  }
+| trigger_func_args ',' trigger_func_arg
+  {
+    $$.val = append($1.strs(), $3)
+  }
+
+// Trigger arguments are always passed to the trigger function as strings.
+trigger_func_arg:
+  ICONST
+  {
+    $$ = $1.numVal().OrigString()
+  }
+| FCONST
+  {
+    $$ = $1.numVal().OrigString()
+  }
+| SCONST
+| unrestricted_name
+
+// %Help: DROP TRIGGER - remove a trigger
+// %Category: DDL
+// %Text: DROP TRIGGER [ IF EXISTS ] name ON table_name [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE TRIGGER
+drop_trigger_stmt:
+  DROP TRIGGER name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($3),
+      TableName: $5.unresolvedObjectName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TRIGGER IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      IfExists: true,
+      Name: tree.Name($5),
+      TableName: $7.unresolvedObjectName(),
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TRIGGER error // SHOW HELP: DROP TRIGGER
+
+// %Help: CREATE SERVER - create a new foreign server
+// %Category: DDL
+// %Text:
+// CREATE SERVER [IF NOT EXISTS] <name> FOREIGN DATA WRAPPER external_storage
+//   OPTIONS (uri '<uri>')
+//
+// A foreign server is an External Connection to the external storage
+// location at <uri>.
+// %SeeAlso: CREATE FOREIGN TABLE, DROP SERVER, CREATE EXTERNAL CONNECTION
+create_server_stmt:
+  CREATE SERVER name FOREIGN DATA WRAPPER name opt_foreign_options
+  {
+    $$.val = &tree.CreateServer{
+      Name: tree.Name($3),
+      Wrapper: tree.Name($7),
+      Options: $8.foreignOptions(),
+    }
+  }
+| CREATE SERVER IF NOT EXISTS name FOREIGN DATA WRAPPER name opt_foreign_options
+  {
+    $$.val = &tree.CreateServer{
+      Name: tree.Name($6),
+      IfNotExists: true,
+      Wrapper: tree.Name($10),
+      Options: $11.foreignOptions(),
+    }
+  }
+| CREATE SERVER error // SHOW HELP: CREATE SERVER
+
+// %Help: CREATE FOREIGN TABLE - create a table backed by a file in external storage
+// %Category: DDL
+// %Text:
+// CREATE FOREIGN TABLE [IF NOT EXISTS] <tablename> ( <colname> <type> [, ...] )
+//   SERVER <server_name> OPTIONS (filename '<path>' [, format 'csv' | 'avro' | 'parquet']
+//   [, <option> '<value>' ...])
+//
+// CSV options:
+//   delimiter '<char>', header 'true' | 'false', null '<string>'
+// %SeeAlso: CREATE SERVER, DROP FOREIGN TABLE
+create_foreign_table_stmt:
+  CREATE FOREIGN TABLE table_name '(' opt_table_elem_list ')' SERVER name opt_foreign_options
+  {
+    $$.val = &tree.CreateForeignTable{
+      Table: $4.unresolvedObjectName().ToTableName(),
+      Defs: $6.tblDefs(),
+      Server: tree.Name($9),
+      Options: $10.foreignOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' SERVER name opt_foreign_options
+  {
+    $$.val = &tree.CreateForeignTable{
+      Table: $7.unresolvedObjectName().ToTableName(),
+      IfNotExists: true,
+      Defs: $9.tblDefs(),
+      Server: tree.Name($12),
+      Options: $13.foreignOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE error // SHOW HELP: CREATE FOREIGN TABLE
+
+opt_foreign_options:
+  OPTIONS '(' foreign_option_list ')'
+  {
+    $$.val = $3.foreignOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.ForeignOptions(nil)
+  }
+
+foreign_option_list:
+  foreign_option
+  {
+    $$.val = tree.ForeignOptions{$1.foreignOption()}
+  }
+| foreign_option_list ',' foreign_option
+  {
+    $$.val = append($1.foreignOptions(), $3.foreignOption())
+  }
+
+foreign_option:
+  unrestricted_name SCONST
+  {
+    $$.val = tree.ForeignOption{Name: tree.Name($1), Value: $2}
+  }
+
+// %Help: DROP SERVER - remove a foreign server
+// %Category: DDL
+// %Text: DROP SERVER [IF EXISTS] <name> [, ...]
+// %SeeAlso: CREATE SERVER
+drop_server_stmt:
+  DROP SERVER name_list
+  {
+    $$.val = &tree.DropServer{Names: $3.nameList()}
+  }
+| DROP SERVER IF EXISTS name_list
   {
-    $$.val = &tree.AlterFunctionDepExtension{
-      Function: $3.functionObj(),
-      Remove: $4.bool(),
-      Extension: tree.Name($8),
-    }
+    $$.val = &tree.DropServer{Names: $5.nameList(), IfExists: true}
   }
+| DROP SERVER error // SHOW HELP: DROP SERVER
 
-opt_no:
-  NO
+// %Help: DROP FOREIGN TABLE - remove a foreign table
+// %Category: DDL
+// %Text: DROP FOREIGN TABLE [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE FOREIGN TABLE
+drop_foreign_table_stmt:
+  DROP FOREIGN TABLE table_name_list opt_drop_behavior
   {
-    $$.val = true
+    $$.val = &tree.DropForeignTable{Names: $4.tableNames(), DropBehavior: $5.dropBehavior()}
   }
-| /* EMPTY */
+| DROP FOREIGN TABLE IF EXISTS table_name_list opt_drop_behavior
   {
-    $$.val = false
+    $$.val = &tree.DropForeignTable{Names: $6.tableNames(), IfExists: true, DropBehavior: $7.dropBehavior()}
   }
+| DROP FOREIGN TABLE error // SHOW HELP: DROP FOREIGN TABLE
 
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
 | CREATE DEFAULT CONVERSION error { return unimplemented(sqllex, "create def conv") }
-| CREATE FOREIGN TABLE error { return unimplemented(sqllex, "create foreign table") }
 | CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
 | CREATE OPERATOR error { return unimplementedWithIssue(sqllex, 65017) }
 | CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
-| CREATE SERVER error { return unimplemented(sqllex, "create server") }
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TABLESPACE error { return unimplementedWithIssueDetail(sqllex, 54113, "create tablespace") }
 | CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
-| CREATE TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create trigger") }
 
 opt_trusted:
   TRUSTED {}
@@ -4686,23 +5369,18 @@ opt_procedural:
 
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
 | DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
-| DROP DOMAIN error { return unimplementedWithIssueDetail(sqllex, 27796, "drop") }
 | DROP EXTENSION IF EXISTS name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension if exists") }
 | DROP EXTENSION name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension") }
-| DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
 | DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
 | DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
 | DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
 | DROP RULE error { return unimplemented(sqllex, "drop rule") }
-| DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
 | DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
-| DROP TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "drop") }
 
 create_ddl_stmt:
   create_database_stmt // EXTEND WITH HELP: CREATE DATABASE
@@ -4713,9 +5391,15 @@ create_ddl_stmt:
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
 | CREATE opt_persistence_temp_table TABLE error   // SHOW HELP: CREATE TABLE
 | create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
+| create_domain_stmt   // EXTEND WITH HELP: CREATE DOMAIN
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
+| create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
+| create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
+| create_server_stmt   // EXTEND WITH HELP: CREATE SERVER
+| create_foreign_table_stmt // EXTEND WITH HELP: CREATE FOREIGN TABLE
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
@@ -5065,7 +5749,13 @@ drop_ddl_stmt:
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
+| drop_domain_stmt   // EXTEND WITH HELP: DROP DOMAIN
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_proc_stmt     // EXTEND WITH HELP: DROP PROCEDURE
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
+| drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
+| drop_server_stmt   // EXTEND WITH HELP: DROP SERVER
+| drop_foreign_table_stmt // EXTEND WITH HELP: DROP FOREIGN TABLE
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -5200,6 +5890,29 @@ drop_type_stmt:
   }
 | DROP TYPE error // SHOW HELP: DROP TYPE
 
+// %Help: DROP DOMAIN - remove a domain
+// %Category: DDL
+// %Text: DROP DOMAIN [IF EXISTS] <type_name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE DOMAIN, ALTER DOMAIN
+drop_domain_stmt:
+  DROP DOMAIN type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $3.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP DOMAIN IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP DOMAIN error // SHOW HELP: DROP DOMAIN
+
 // %Help: DROP TENANT - remove a tenant
 // %Category: Experimental
 // %Text: DROP TENANT [IF EXISTS] <tenant_spec> [IMMEDIATE]
@@ -5411,6 +6124,7 @@ explainable_stmt:
 preparable_stmt:
   alter_stmt     // help texts in sub-rule
 | backup_stmt    // EXTEND WITH HELP: BACKUP
+| call_stmt      // EXTEND WITH HELP: CALL
 | cancel_stmt    // help texts in sub-rule
 | create_stmt    // help texts in sub-rule
 | delete_stmt    // EXTEND WITH HELP: DELETE
@@ -5418,6 +6132,7 @@ preparable_stmt:
 | explain_stmt   // EXTEND WITH HELP: EXPLAIN
 | import_stmt    // EXTEND WITH HELP: IMPORT
 | insert_stmt    // EXTEND WITH HELP: INSERT
+| merge_stmt     // EXTEND WITH HELP: MERGE
 | pause_stmt     // help texts in sub-rule
 | reset_stmt     // help texts in sub-rule
 | restore_stmt   // EXTEND WITH HELP: RESTORE
@@ -6048,7 +6763,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 
 // SET SESSION / SET LOCAL / SET CLUSTER SETTING
 preparable_set_stmt:
@@ -6304,7 +7019,7 @@ set_exprs_internal:
 // %Text:
 // SET [SESSION] <var> { TO | = } <values...>
 // SET [SESSION] TIME ZONE <tz>
-// SET [SESSION] CHARACTERISTICS AS TRANSACTION ISOLATION LEVEL { SNAPSHOT | SERIALIZABLE }
+// SET [SESSION] CHARACTERISTICS AS TRANSACTION ISOLATION LEVEL { READ COMMITTED | SNAPSHOT | SERIALIZABLE }
 // SET [SESSION] TRACING { TO | = } { on | off | cluster | kv | results } [,...]
 //
 // %SeeAlso: SHOW SESSION, RESET, DISCARD, SHOW, SET CLUSTER SETTING, SET TRANSACTION, SET LOCAL
@@ -6355,13 +7070,42 @@ set_local_stmt:
   }
 | SET LOCAL error  // SHOW HELP: SET LOCAL
 
+// %Help: SET CONSTRAINTS - set when deferrable constraints are checked
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS { ALL | [<schemaname>.]<constraintname> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// DEFERRED postpones checking of the named deferrable constraints until the
+// transaction commits; IMMEDIATE checks them at the end of every statement,
+// starting with any checks that are already pending.
+//
+// %SeeAlso: SET TRANSACTION, ALTER TABLE
+set_constraints_stmt:
+  SET CONSTRAINTS ALL DEFERRED
+  {
+    $$.val = &tree.SetConstraints{Deferred: true}
+  }
+| SET CONSTRAINTS ALL IMMEDIATE
+  {
+    $$.val = &tree.SetConstraints{Deferred: false}
+  }
+| SET CONSTRAINTS db_object_name_list DEFERRED
+  {
+    $$.val = &tree.SetConstraints{Names: $3.tableNames(), Deferred: true}
+  }
+| SET CONSTRAINTS db_object_name_list IMMEDIATE
+  {
+    $$.val = &tree.SetConstraints{Names: $3.tableNames(), Deferred: false}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
 // %Help: SET TRANSACTION - configure the transaction settings
 // %Category: Txn
 // %Text:
 // SET [SESSION] TRANSACTION <txnparameters...>
 //
 // Transaction parameters:
-//    ISOLATION LEVEL { SNAPSHOT | SERIALIZABLE }
+//    ISOLATION LEVEL { READ COMMITTED | SNAPSHOT | SERIALIZABLE }
 //    PRIORITY { LOW | NORMAL | HIGH }
 //    AS OF SYSTEM TIME <expr>
 //    [NOT] DEFERRABLE
@@ -6502,11 +7246,11 @@ var_list:
 iso_level:
   READ UNCOMMITTED
   {
-    $$.val = tree.SerializableIsolation
+    $$.val = tree.ReadCommittedIsolation
   }
 | READ COMMITTED
   {
-    $$.val = tree.SerializableIsolation
+    $$.val = tree.ReadCommittedIsolation
   }
 | SNAPSHOT
   {
@@ -8608,6 +9352,7 @@ create_table_stmt:
       StorageParams: $10.storageParams(),
       OnCommit: $11.createTableOnCommitSetting(),
       Locality: $12.locality(),
+      Inherits: $8.tableNames(),
     }
   }
 | CREATE opt_persistence_temp_table TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' opt_create_table_inherits opt_partition_by_table opt_table_with opt_create_table_on_commit opt_locality
@@ -8623,6 +9368,7 @@ create_table_stmt:
       StorageParams: $13.storageParams(),
       OnCommit: $14.createTableOnCommitSetting(),
       Locality: $15.locality(),
+      Inherits: $11.tableNames(),
     }
   }
 
@@ -8652,12 +9398,11 @@ opt_table_with:
 opt_create_table_inherits:
   /* EMPTY */
   {
-    $$ = ""
+    $$.val = tree.TableNames(nil)
   }
-| INHERITS error
+| INHERITS '(' table_name_list ')'
   {
-    /* SKIP DOC */
-    return unimplementedWithIssueDetail(sqllex, 22456, "create table inherits")
+    $$.val = $3.tableNames()
   }
 
 opt_with_storage_parameter_list:
@@ -8705,6 +9450,12 @@ storage_parameter:
   {
     $$.val = tree.StorageParam{Key: tree.Name($1), Value: $3.expr()}
   }
+| storage_parameter_key
+  {
+    // A storage parameter without a value is a boolean option that is set to
+    // true, as in Postgres.
+    $$.val = tree.StorageParam{Key: tree.Name($1), Value: tree.DBoolTrue}
+  }
 
 storage_parameter_list:
   storage_parameter
@@ -9248,6 +9999,9 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrability() != tree.ConstraintNotDeferrable {
+      return setErr(sqllex, pgerror.New(pgcode.FeatureNotSupported, "CHECK constraints cannot be marked DEFERRABLE"))
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
@@ -9255,6 +10009,11 @@ constraint_elem:
 | UNIQUE opt_without_index '(' index_params ')'
     opt_storing opt_partition_by_index opt_deferrable opt_where_clause
   {
+    // Only unique constraints that are checked by queries rather than enforced
+    // by an index can be deferred.
+    if $8.constraintDeferrability() != tree.ConstraintNotDeferrable && !$2.bool() {
+      return unimplementedWithIssueDetail(sqllex, 31632, "deferrable unique constraint")
+    }
     $$.val = &tree.UniqueConstraintTableDef{
       WithoutIndex: $2.bool(),
       IndexTableDef: tree.IndexTableDef{
@@ -9263,6 +10022,7 @@ constraint_elem:
         PartitionByIndex: $7.partitionByIndex(),
         Predicate: $9.expr(),
       },
+      Deferrable: $8.constraintDeferrability(),
     }
   }
 | PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -9286,11 +10046,57 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrable: $11.constraintDeferrability(),
+    }
+  }
+| EXCLUDE opt_exclude_access_method '(' exclude_elems ')' opt_where_clause
+  {
+    $$.val = &tree.ExcludeConstraintTableDef{
+      Elems: $4.excludeElems(),
+      Predicate: $6.expr(),
+    }
+  }
+
+// Exclusion constraints are enforced by queries rather than by an index, so
+// the access method is validated but not stored.
+opt_exclude_access_method:
+  USING name
+  {
+    switch $2 {
+      case "gist", "btree":
+        $$ = $2
+      case "gin", "hash", "spgist", "brin", "ivfflat", "hnsw":
+        return setErr(sqllex, pgerror.Newf(pgcode.FeatureNotSupported,
+          "access method %q does not support exclusion constraints", $2))
+      default:
+        return setErr(sqllex, pgerror.Newf(pgcode.UndefinedObject,
+          "access method %q does not exist", $2))
     }
   }
-| EXCLUDE USING error
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
+exclude_elems:
+  exclude_elem
+  {
+    $$.val = tree.ExcludeElemList{$1.excludeElem()}
+  }
+| exclude_elems ',' exclude_elem
+  {
+    $$.val = append($1.excludeElems(), $3.excludeElem())
+  }
+
+exclude_elem:
+  name WITH all_op
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    op, ok := $3.op().(treecmp.ComparisonOperator)
+    if !ok {
+      sqllex.Error(fmt.Sprintf("operator %s is not a comparison operator", $3.op()))
+      return 1
+    }
+    $$.val = tree.ExcludeElem{Column: tree.Name($1), Operator: op}
   }
 
 
@@ -9399,12 +10205,50 @@ create_as_col_qualification_elem:
   }
 
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  /* EMPTY */
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| constraint_deferrability
+  {
+    $$.val = $1.constraintDeferrability()
+  }
+
+// As in Postgres, INITIALLY DEFERRED implies DEFERRABLE, and a constraint
+// is not deferrable unless it is declared so.
+constraint_deferrability:
+  DEFERRABLE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| NOT_DEFERRABLE DEFERRABLE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| NOT_DEFERRABLE DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| NOT_DEFERRABLE DEFERRABLE INITIALLY DEFERRED
+  {
+    return setErr(sqllex, pgerror.New(pgcode.InvalidTableDefinition, "constraint declared INITIALLY DEFERRED must be DEFERRABLE"))
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
 
 storing:
   COVERING
@@ -9781,7 +10625,8 @@ role_or_group_or_user:
 // %Category: DDL
 // %Text:
 // CREATE [TEMPORARY | TEMP] VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source>
-// CREATE [TEMPORARY | TEMP] MATERIALIZED VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source> [WITH [NO] DATA]
+// CREATE [TEMPORARY | TEMP] RECURSIVE VIEW [IF NOT EXISTS] <viewname> ( <colnames...> ) AS <source>
+// CREATE [TEMPORARY | TEMP] MATERIALIZED VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] [WITH ( <storage_parameter...> )] AS <source> [WITH [NO] DATA]
 // %SeeAlso: CREATE TABLE, SHOW CREATE, WEBDOCS/create-view.html
 create_view_stmt:
   CREATE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt
@@ -9794,6 +10639,7 @@ create_view_stmt:
       Persistence: $2.persistence(),
       IfNotExists: false,
       Replace: false,
+      Recursive: $3.bool(),
     }
   }
 // We cannot use a rule like opt_or_replace here as that would cause a conflict
@@ -9808,6 +10654,7 @@ create_view_stmt:
       Persistence: $4.persistence(),
       IfNotExists: false,
       Replace: true,
+      Recursive: $5.bool(),
     }
   }
 | CREATE opt_temp opt_view_recursive VIEW IF NOT EXISTS view_name opt_column_list AS select_stmt
@@ -9820,29 +10667,32 @@ create_view_stmt:
       Persistence: $2.persistence(),
       IfNotExists: true,
       Replace: false,
+      Recursive: $3.bool(),
     }
   }
-| CREATE MATERIALIZED VIEW view_name opt_column_list AS select_stmt opt_with_data
+| CREATE MATERIALIZED VIEW view_name opt_column_list opt_with_storage_parameter_list AS select_stmt opt_with_data
   {
     name := $4.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $5.nameList(),
-      AsSource: $7.slct(),
+      StorageParams: $6.storageParams(),
+      AsSource: $8.slct(),
       Materialized: true,
-      WithData: $8.bool(),
+      WithData: $9.bool(),
     }
   }
-| CREATE MATERIALIZED VIEW IF NOT EXISTS view_name opt_column_list AS select_stmt opt_with_data
+| CREATE MATERIALIZED VIEW IF NOT EXISTS view_name opt_column_list opt_with_storage_parameter_list AS select_stmt opt_with_data
   {
     name := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $8.nameList(),
-      AsSource: $10.slct(),
+      StorageParams: $9.storageParams(),
+      AsSource: $11.slct(),
       Materialized: true,
       IfNotExists: true,
-      WithData: $11.bool(),
+      WithData: $12.bool(),
     }
   }
 | CREATE opt_temp opt_view_recursive VIEW error // SHOW HELP: CREATE VIEW
@@ -9992,8 +10842,14 @@ valid_until_clause:
   }
 
 opt_view_recursive:
-  /* EMPTY */ { /* no error */ }
-| RECURSIVE { return unimplemented(sqllex, "create recursive view") }
+  /* EMPTY */
+  {
+    $$.val = false
+  }
+| RECURSIVE
+  {
+    $$.val = true
+  }
 
 
 // %Help: CREATE TYPE - create a type
@@ -10043,8 +10899,36 @@ create_type_stmt:
 | CREATE TYPE type_name '(' error         { return unimplementedWithIssueDetail(sqllex, 27793, "base") }
   // Shell types, gateway to define base types using the previous syntax.
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
-  // Domain types.
-| CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
+
+// %Help: CREATE DOMAIN - create a domain
+// %Category: DDL
+// %Text:
+// CREATE DOMAIN <type_name> [AS] <data_type> [DEFAULT <expr>] [<constraint> ...]
+//
+// Constraints:
+//   [CONSTRAINT <name>] NOT NULL
+//   [CONSTRAINT <name>] NULL
+//   [CONSTRAINT <name>] CHECK (<expr>)
+//
+// %SeeAlso: ALTER DOMAIN, DROP DOMAIN
+create_domain_stmt:
+  CREATE DOMAIN type_name AS typename col_qual_list
+  {
+    n, err := tree.NewCreateDomain($3.unresolvedObjectName(), $5.typeReference(), $6.colQuals())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = n
+  }
+| CREATE DOMAIN type_name typename col_qual_list
+  {
+    n, err := tree.NewCreateDomain($3.unresolvedObjectName(), $4.typeReference(), $5.colQuals())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = n
+  }
+| CREATE DOMAIN error // SHOW HELP: CREATE DOMAIN
 
 opt_enum_val_list:
   enum_val_list
@@ -10187,11 +11071,11 @@ opt_index_access_method:
   {
     /* FORCE DOC */
     switch $2 {
-      case "gin", "gist":
+      case "gin", "gist", "ivfflat":
         $$.val = true
       case "btree":
         $$.val = false
-      case "hash", "spgist", "brin":
+      case "hash", "spgist", "brin", "hnsw":
         return unimplemented(sqllex, "index using " + $2)
       default:
         sqllex.Error("unrecognized access method: " + $2)
@@ -10890,7 +11774,7 @@ transaction_stmt:
 // START TRANSACTION [ <txnparameter> [[,] ...] ]
 //
 // Transaction parameters:
-//    ISOLATION LEVEL { SNAPSHOT | SERIALIZABLE }
+//    ISOLATION LEVEL { READ COMMITTED | SNAPSHOT | SERIALIZABLE }
 //    PRIORITY { LOW | NORMAL | HIGH }
 //
 // %SeeAlso: COMMIT, ROLLBACK, WEBDOCS/begin-transaction.html
@@ -11061,7 +11945,7 @@ transaction_deferrable_mode:
   {
     $$.val = tree.Deferrable
   }
-| NOT DEFERRABLE
+| NOT_DEFERRABLE DEFERRABLE
   {
     $$.val = tree.NotDeferrable
   }
@@ -11322,6 +12206,104 @@ upsert_stmt:
   }
 | opt_with_clause UPSERT error // SHOW HELP: UPSERT
 
+// %Help: MERGE - insert, update or delete rows of a table based on a join
+// %Category: DML
+// %Text:
+// MERGE INTO <tablename> [AS <name>]
+//        USING <source> ON <expr>
+//        WHEN MATCHED [AND <expr>] THEN { UPDATE SET ... | DELETE | DO NOTHING }
+//        WHEN NOT MATCHED [AND <expr>] THEN
+//          { INSERT [( <colnames...> )] { VALUES ( <exprs...> ) | DEFAULT VALUES } | DO NOTHING }
+//        ...
+// %SeeAlso: INSERT, UPSERT, UPDATE, DELETE
+merge_stmt:
+  opt_with_clause MERGE INTO merge_target USING table_ref ON a_expr merge_when_list
+  {
+    $$.val = &tree.Merge{
+      With: $1.with(),
+      Table: $4.tblExpr(),
+      Source: $6.tblExpr(),
+      On: $8.expr(),
+      Whens: $9.mergeWhens(),
+    }
+  }
+| opt_with_clause MERGE error // SHOW HELP: MERGE
+
+// Unlike INSERT, MERGE does not have a VALUES clause following the target
+// table, so the alias can be specified without AS.
+merge_target:
+  insert_target
+| table_name table_alias_name
+  {
+    name := $1.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{Expr: &name, As: tree.AliasClause{Alias: tree.Name($2)}}
+  }
+
+merge_when_list:
+  merge_when_clause
+  {
+    $$.val = tree.MergeWhens{$1.mergeWhen()}
+  }
+| merge_when_list merge_when_clause
+  {
+    $$.val = append($1.mergeWhens(), $2.mergeWhen())
+  }
+
+merge_when_clause:
+  WHEN MATCHED opt_merge_when_cond THEN merge_when_matched_action
+  {
+    $$.val = $5.mergeWhen()
+    $$.val.(*tree.MergeWhen).Matched = true
+    $$.val.(*tree.MergeWhen).Cond = $3.expr()
+  }
+| WHEN NOT MATCHED opt_merge_when_cond THEN merge_when_not_matched_action
+  {
+    $$.val = $6.mergeWhen()
+    $$.val.(*tree.MergeWhen).Cond = $4.expr()
+  }
+
+opt_merge_when_cond:
+  AND a_expr
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+merge_when_matched_action:
+  UPDATE SET set_clause_list
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionUpdate, Exprs: $3.updateExprs()}
+  }
+| DELETE
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionDelete}
+  }
+| DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionDoNothing}
+  }
+
+merge_when_not_matched_action:
+  INSERT VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionInsert, Values: $4.exprs()}
+  }
+| INSERT '(' insert_column_list ')' VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionInsert, Columns: $3.nameList(), Values: $7.exprs()}
+  }
+| INSERT DEFAULT VALUES
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionInsert}
+  }
+| DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionDoNothing}
+  }
+
 insert_target:
   table_name
   {
@@ -12138,9 +13120,18 @@ group_by_list:
 // rather than reducing the conflicting unreserved_keyword rule.
 group_by_item:
   a_expr { $$.val = $1.expr() }
-| ROLLUP '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "rollup") }
-| CUBE '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "cube") }
-| GROUPING SETS error { return unimplementedWithIssueDetail(sqllex, 46280, "grouping sets") }
+| ROLLUP '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.RollupGroupingSet, Exprs: $3.exprs()}
+  }
+| CUBE '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.CubeGroupingSet, Exprs: $3.exprs()}
+  }
+| GROUPING SETS '(' group_by_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.GroupingSetsGroupingSet, Exprs: $4.exprs()}
+  }
 
 having_clause:
   HAVING a_expr
@@ -12344,15 +13335,13 @@ table_ref:
         As:         $4.aliasClause(),
     }
   }
-| relation_expr opt_index_flags opt_ordinality opt_alias_clause
+| table_ref_relation_expr opt_index_flags opt_ordinality opt_alias_clause
   {
-    name := $1.unresolvedObjectName().ToTableName()
-    $$.val = &tree.AliasedTableExpr{
-      Expr:       &name,
-      IndexFlags: $2.indexFlags(),
-      Ordinality: $3.bool(),
-      As:         $4.aliasClause(),
-    }
+    expr := $1.tblExpr().(*tree.AliasedTableExpr)
+    expr.IndexFlags = $2.indexFlags()
+    expr.Ordinality = $3.bool()
+    expr.As = $4.aliasClause()
+    $$.val = expr
   }
 | select_with_parens opt_ordinality opt_alias_clause
   {
@@ -12703,6 +13692,30 @@ relation_expr:
 | ONLY table_name         { $$.val = $2.unresolvedObjectName() }
 | ONLY '(' table_name ')' { $$.val = $3.unresolvedObjectName() }
 
+// table_ref_relation_expr is a relation_expr in a FROM clause, where ONLY
+// excludes the tables that inherit from the table.
+table_ref_relation_expr:
+  table_name
+  {
+    name := $1.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{Expr: &name}
+  }
+| table_name '*'
+  {
+    name := $1.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{Expr: &name}
+  }
+| ONLY table_name
+  {
+    name := $2.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{Expr: &name, Only: true}
+  }
+| ONLY '(' table_name ')'
+  {
+    name := $3.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{Expr: &name, Only: true}
+  }
+
 relation_expr_list:
   relation_expr
   {
@@ -12715,6 +13728,33 @@ relation_expr_list:
     $$.val = append($1.tableNames(), name)
   }
 
+// %Help: LISTEN - listen for notifications on a channel
+// %Category: Misc
+// %Text: LISTEN <channel>
+// %SeeAlso: NOTIFY
+listen_stmt:
+  LISTEN name
+  {
+    $$.val = &tree.Listen{ChannelName: tree.Name($2)}
+  }
+| LISTEN error // SHOW HELP: LISTEN
+
+// %Help: NOTIFY - send a notification on a channel
+// %Category: Misc
+// %Text: NOTIFY <channel> [, <payload>]
+// %SeeAlso: LISTEN
+notify_stmt:
+  NOTIFY name
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2)}
+  }
+| NOTIFY name ',' SCONST
+  {
+    payload := $4
+    $$.val = &tree.Notify{ChannelName: tree.Name($2), Payload: &payload}
+  }
+| NOTIFY error // SHOW HELP: NOTIFY
+
 // UNLISTEN
 unlisten_stmt:
    UNLISTEN type_name
@@ -12767,6 +13807,7 @@ table_name_opt_idx:
     $$.val = &tree.AliasedTableExpr{
       Expr: &name,
       IndexFlags: $3.indexFlags(),
+      Only: $1.bool(),
     }
   }
 
@@ -13006,6 +14047,21 @@ const_geo:
     $$.val = types.MakeGeography($3.geoShapeType(), geopb.SRID(val))
   }
 
+const_vector:
+  VECTOR { $$.val = types.PGVector }
+| VECTOR '(' iconst32 ')'
+  {
+    dims := $3.int32()
+    if dims < 1 {
+      sqllex.Error("dimensions for type vector must be at least 1")
+      return 1
+    } else if dims > vector.MaxDim {
+      sqllex.Error(fmt.Sprintf("dimensions for type vector cannot exceed %d", vector.MaxDim))
+      return 1
+    }
+    $$.val = types.MakePGVector(dims)
+  }
+
 // We have a separate const_typename to allow defaulting fixed-length types such
 // as CHAR() and BIT() to an unspecified length. SQL9x requires that these
 // default to a length of one, but this makes no sense for constructs like CHAR
@@ -13023,6 +14079,7 @@ const_typename:
 | character_with_length
 | const_datetime
 | const_geo
+| const_vector
 
 opt_numeric_modifiers:
   '(' iconst32 ')'
@@ -13608,6 +14665,58 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr JSON_PATH_EXISTS a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.JSONPathExists), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr RANGE_ADJACENT a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("range_adjacent"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr DISTANCE a_expr
+  {
+    $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.Distance), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr COS_DISTANCE a_expr
+  {
+    $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.CosDistance), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr NEG_INNER_PRODUCT a_expr
+  {
+    $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.NegInnerProduct), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr WORD_SIMILARITY_OP a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("word_similarity_op"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr WORD_SIMILARITY_COMMUTATOR_OP a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("word_similarity_commutator_op"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr STRICT_WORD_SIMILARITY_OP a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("strict_word_similarity_op"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr STRICT_WORD_SIMILARITY_COMMUTATOR_OP a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("strict_word_similarity_commutator_op"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr WORD_SIMILARITY_DIST_OP a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("word_similarity_dist_op"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr WORD_SIMILARITY_DIST_COMMUTATOR_OP a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("word_similarity_dist_commutator_op"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr STRICT_WORD_SIMILARITY_DIST_OP a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("strict_word_similarity_dist_op"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr STRICT_WORD_SIMILARITY_DIST_COMMUTATOR_OP a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("strict_word_similarity_dist_commutator_op"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
 | a_expr INET_CONTAINS_OR_EQUALS a_expr
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction("inet_contains_or_equals"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
@@ -13949,6 +15058,18 @@ b_expr:
   {
     $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.RShift), Left: $1.expr(), Right: $3.expr()}
   }
+| b_expr DISTANCE b_expr
+  {
+    $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.Distance), Left: $1.expr(), Right: $3.expr()}
+  }
+| b_expr COS_DISTANCE b_expr
+  {
+    $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.CosDistance), Left: $1.expr(), Right: $3.expr()}
+  }
+| b_expr NEG_INNER_PRODUCT b_expr
+  {
+    $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.NegInnerProduct), Left: $1.expr(), Right: $3.expr()}
+  }
 | b_expr LESS_EQUALS b_expr
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.LE), Left: $1.expr(), Right: $3.expr()}
@@ -14155,7 +15276,15 @@ d_expr:
   {
     $$.val = $2.expr()
   }
-| GROUPING '(' expr_list ')' { return unimplemented(sqllex, "d_expr grouping") }
+| GROUPING '(' expr_list ')'
+  {
+    $$.val = &tree.FuncExpr{
+      Func: tree.ResolvableFunctionReference{
+        FunctionReference: &tree.UnresolvedName{NumParts: 1, Parts: tree.NameParts{"grouping"}},
+      },
+      Exprs: $3.exprs(),
+    }
+  }
 
 func_application:
   func_name '(' ')'
@@ -14166,8 +15295,14 @@ func_application:
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRefFromName(), Exprs: $3.exprs(), OrderBy: $4.orderBy(), AggType: tree.GeneralAgg}
   }
-| func_name '(' VARIADIC a_expr opt_sort_clause ')' { return unimplemented(sqllex, "variadic") }
-| func_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause ')' { return unimplemented(sqllex, "variadic") }
+| func_name '(' VARIADIC a_expr opt_sort_clause ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRefFromName(), Exprs: tree.Exprs{$4.expr()}, OrderBy: $5.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
+| func_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRefFromName(), Exprs: append($3.exprs(), $6.expr()), OrderBy: $7.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
 | func_name '(' ALL expr_list opt_sort_clause ')'
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRefFromName(), Type: tree.AllFuncType, Exprs: $4.exprs(), OrderBy: $5.orderBy(), AggType: tree.GeneralAgg}
@@ -14793,6 +15928,9 @@ all_op:
 | FETCHTEXT { $$.val = treebin.MakeBinaryOperator(treebin.JSONFetchText) }
 | FETCHVAL_PATH { $$.val = treebin.MakeBinaryOperator(treebin.JSONFetchValPath) }
 | FETCHTEXT_PATH { $$.val = treebin.MakeBinaryOperator(treebin.JSONFetchTextPath) }
+| DISTANCE { $$.val = treebin.MakeBinaryOperator(treebin.Distance) }
+| COS_DISTANCE { $$.val = treebin.MakeBinaryOperator(treebin.CosDistance) }
+| NEG_INNER_PRODUCT { $$.val = treebin.MakeBinaryOperator(treebin.NegInnerProduct) }
 | JSON_SOME_EXISTS { $$.val = treecmp.MakeComparisonOperator(treecmp.JSONSomeExists) }
 | JSON_ALL_EXISTS { $$.val = treecmp.MakeComparisonOperator(treecmp.JSONAllExists) }
 | NOT_REGMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegMatch) }
@@ -14800,6 +15938,7 @@ all_op:
 | NOT_REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegIMatch) }
 | AND_AND { $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps) }
 | AT_AT { $$.val = treecmp.MakeComparisonOperator(treecmp.TSMatches) }
+| JSON_PATH_EXISTS { $$.val = treecmp.MakeComparisonOperator(treecmp.JSONPathExists) }
 | '~' { $$.val = tree.MakeUnaryOperator(tree.UnaryComplement) }
 | SQRT { $$.val = tree.MakeUnaryOperator(tree.UnarySqrt) }
 | CBRT { $$.val = tree.MakeUnaryOperator(tree.UnaryCbrt) }
@@ -15744,6 +16883,7 @@ unreserved_keyword:
 | BUNDLE
 | BY
 | CACHE
+| CALL
 | CALLED
 | CANCEL
 | CANCELQUERY
@@ -15801,6 +16941,7 @@ unreserved_keyword:
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_PASSPHRASE
@@ -15863,10 +17004,12 @@ unreserved_keyword:
 | INCREMENTAL_LOCATION
 | INDEX
 | INDEXES
+| INHERIT
 | INHERITS
 | INJECT
 | INPUT
 | INSERT
+| INSTEAD
 | INTO_DB
 | INVERTED
 | INVISIBLE
@@ -15894,6 +17037,7 @@ unreserved_keyword:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCKED
 | LOGIN
@@ -15901,6 +17045,7 @@ unreserved_keyword:
 | LOOKUP
 | LOW
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -15931,6 +17076,7 @@ unreserved_keyword:
 | NO
 | NORMAL
 | NOTHING
+| NOTIFY
 | NO_INDEX_JOIN
 | NO_ZIGZAG_JOIN
 | NO_FULL_SCAN
@@ -15988,6 +17134,8 @@ unreserved_keyword:
 | PRIOR
 | PRIORITY
 | PRIVILEGES
+| PROCEDURAL
+| PROCEDURE
 | PUBLIC
 | PUBLICATION
 | QUERIES
@@ -16074,6 +17222,7 @@ unreserved_keyword:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STDIN
@@ -16141,6 +17290,7 @@ unreserved_keyword:
 | VOTERS
 | WITHIN
 | WITHOUT
+| WRAPPER
 | WRITE
 | YEAR
 | ZONE
@@ -16151,23 +17301,35 @@ unreserved_keyword:
 // Any new keyword should be added to this list.
 bare_label_keywords:
   ATOMIC
+| CALL
 | CALLED
 | COST
 | DEFINER
 | DEPENDS
+| EACH
 | EXTERNAL
 | IMMUTABLE
+| INHERIT
 | INPUT
+| INSTEAD
 | INVOKER
 | LEAKPROOF
+| LISTEN
+| MATCHED
+| NOTIFY
 | PARALLEL
+| PROCEDURAL
+| PROCEDURE
 | RETURN
 | RETURNS
 | SECURITY
 | STABLE
+| STATEMENT
 | SUPPORT
 | TRANSFORM
+| VECTOR
 | VOLATILE
+| WRAPPER
 | SETOF
 
 // Column identifier --- keywords that can be column, table, etc names.
@@ -16235,6 +17397,7 @@ col_name_keyword:
 | VALUES
 | VARBIT
 | VARCHAR
+| VECTOR
 | VIRTUAL
 | WORK
 
@@ -641,6 +641,7 @@ var (
 	relKindView             = tree.NewDString("v")
 	relKindMaterializedView = tree.NewDString("m")
 	relKindSequence         = tree.NewDString("S")
+	relKindForeignTable     = tree.NewDString("f")
 
 	relPersistencePermanent = tree.NewDString("p")
 	relPersistenceTemporary = tree.NewDString("t")
@@ -663,6 +664,9 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 				relKind = relKindMaterializedView
 			}
 			relAm = oidZero
+		} else if table.IsForeignTable() {
+			relKind = relKindForeignTable
+			relAm = oidZero
 		} else if table.IsSequence() {
 			relKind = relKindSequence
 			relAm = oidZero
@@ -839,7 +843,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = conTypeTrigger
-	_ = conTypeExclusion
 
 	fkActionNone       = tree.NewDString("a")
 	fkActionRestrict   = tree.NewDString("r")
@@ -891,6 +894,7 @@ func populateTableConstraints(
 		consrc := tree.DNull
 		conbin := tree.DNull
 		condef := tree.DNull
+		deferrability := semenumpb.ConstraintDeferrability_NOT_DEFERRABLE
 
 		// Determine constraint kind-specific fields.
 		var err error
@@ -962,6 +966,7 @@ func populateTableConstraints(
 			if r, ok := fkMatchMap[fk.Match()]; ok {
 				confmatchtype = r
 			}
+			deferrability = fk.Deferrability()
 			if conkey, err = colIDArrayToDatum(fk.ForeignKeyDesc().OriginColumnIDs); err != nil {
 				return err
 			}
@@ -984,13 +989,34 @@ func populateTableConstraints(
 			conoid = h.UniqueWithoutIndexConstraintOid(
 				db.GetID(), sc.GetID(), table.GetID(), uwoi,
 			)
-			f.WriteString("UNIQUE WITHOUT INDEX (")
 			colNames, err := catalog.ColumnNamesForIDs(table, uwoi.UniqueWithoutIndexDesc().ColumnIDs)
 			if err != nil {
 				return err
 			}
-			f.WriteString(strings.Join(colNames, ", "))
+			if uwoi.IsExclusion() {
+				contype = conTypeExclusion
+				if conkey, err = colIDArrayToDatum(uwoi.UniqueWithoutIndexDesc().ColumnIDs); err != nil {
+					return err
+				}
+				f.WriteString("EXCLUDE (")
+				for i := range colNames {
+					if i > 0 {
+						f.WriteString(", ")
+					}
+					f.FormatName(colNames[i])
+					f.WriteString(" WITH ")
+					f.WriteString(uwoi.ExclusionOperators()[i])
+				}
+			} else {
+				f.WriteString("UNIQUE WITHOUT INDEX (")
+				f.WriteString(strings.Join(colNames, ", "))
+			}
 			f.WriteByte(')')
+			deferrability = uwoi.Deferrability()
+			if deferrability != semenumpb.ConstraintDeferrability_NOT_DEFERRABLE {
+				f.WriteByte(' ')
+				f.WriteString(tree.ConstraintDeferrability(deferrability).String())
+			}
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -1021,13 +1047,15 @@ func populateTableConstraints(
 			condef = tree.NewDString(fmt.Sprintf("CHECK ((%s))%s", displayExpr, validity))
 		}
 
+		condeferrable := tree.MakeDBool(tree.DBool(deferrability != semenumpb.ConstraintDeferrability_NOT_DEFERRABLE))
+		condeferred := tree.MakeDBool(tree.DBool(deferrability == semenumpb.ConstraintDeferrability_INITIALLY_DEFERRED))
 		if err := addRow(
 			conoid,                   // oid
 			dNameOrNull(c.GetName()), // conname
 			namespaceOid,             // connamespace
 			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
+			condeferrable,            // condeferrable
+			condeferred,              // condeferred
 			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -1491,7 +1519,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-depend.html`,
 				return nil
 			}
 
-			if table.IsTable() || table.IsView() {
+			if table.IsTable() || table.IsView() || table.IsForeignTable() {
 				if err := table.ForeachDependedOnBy(reportViewDependency); err != nil {
 					return err
 				}
@@ -1802,14 +1830,38 @@ https://www.postgresql.org/docs/9.5/catalog-pg-foreign-server.html`,
 }
 
 var pgCatalogForeignTableTable = virtualSchemaTable{
-	comment: `foreign tables (empty  - feature does not exist)
+	comment: `foreign tables
 https://www.postgresql.org/docs/9.5/catalog-pg-foreign-table.html`,
 	schema: vtable.PGCatalogForeignTable,
-	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Foreign tables are not supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables are not foreign tables */
+			func(_ catalog.DatabaseDescriptor, _ catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				if !table.IsForeignTable() {
+					return nil
+				}
+				foreignTable := table.GetForeignTable()
+				options := tree.NewDArray(types.String)
+				for _, opt := range []descpb.TableDescriptor_ForeignTable_Option{
+					{Name: foreignTableOptFilename, Value: foreignTable.Filename},
+					{Name: foreignTableOptFormat, Value: foreignTable.Format},
+				} {
+					if err := options.Append(tree.NewDString(opt.Name + "=" + opt.Value)); err != nil {
+						return err
+					}
+				}
+				for _, opt := range foreignTable.Options {
+					if err := options.Append(tree.NewDString(opt.Name + "=" + opt.Value)); err != nil {
+						return err
+					}
+				}
+				return addRow(
+					tableOid(table.GetID()),                 // ftrelid
+					h.ForeignServerOid(foreignTable.Server), // ftserver
+					options,                                 // ftoptions
+				)
+			})
 	},
-	unimplemented: true,
 }
 
 func makeZeroedOidVector(size int) (tree.Datum, error) {
@@ -2009,25 +2061,79 @@ func indexDefFromDescriptor(
 }
 
 var pgCatalogInheritsTable = virtualSchemaTable{
-	comment: `table inheritance hierarchy (empty - feature does not exist)
+	comment: `table inheritance hierarchy
 https://www.postgresql.org/docs/9.5/catalog-pg-inherits.html`,
 	schema: vtable.PGCatalogInherits,
-	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Table inheritance is not supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables cannot inherit */
+			func(_ catalog.DatabaseDescriptor, _ catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				for i, parentID := range table.GetInherits() {
+					if err := addRow(
+						tableOid(table.GetID()),      // inhrelid
+						tableOid(parentID),           // inhparent
+						tree.NewDInt(tree.DInt(i+1)), // inhseqno
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
+}
+
+// pgLanguage is a language that functions can be written in.
+type pgLanguage struct {
+	name string
+	// oid is the OID of the language in Postgres. It is zero if the language
+	// does not have a fixed OID, in which case one is generated.
+	oid       oid.Oid
+	isPL      bool
+	isTrusted bool
+}
+
+// pgLanguages are the languages that exist in every database.
+var pgLanguages = []pgLanguage{
+	{name: "internal", oid: 12},
+	{name: "c", oid: 13},
+	{name: "sql", oid: 14, isTrusted: true},
+	{name: "plpgsql", isPL: true, isTrusted: true},
+}
+
+// languageOid returns the OID of the language lang.
+func languageOid(h oidHasher, lang catpb.Function_Language) *tree.DOid {
+	if lang == catpb.Function_PLPGSQL {
+		return h.LanguageOid("plpgsql")
+	}
+	return tree.NewDOid(14)
 }
 
 var pgCatalogLanguageTable = virtualSchemaTable{
-	comment: `available languages (empty - feature does not exist)
+	comment: `available languages
 https://www.postgresql.org/docs/9.5/catalog-pg-language.html`,
 	schema: vtable.PGCatalogLanguage,
 	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Languages to write functions and stored procedures are not supported.
+		h := makeOidHasher()
+		for _, lang := range pgLanguages {
+			langOid := tree.NewDOid(lang.oid)
+			if lang.oid == 0 {
+				langOid = h.LanguageOid(lang.name)
+			}
+			if err := addRow(
+				langOid,                               // oid
+				tree.NewDName(lang.name),              // lanname
+				adminOID,                              // lanowner
+				tree.MakeDBool(tree.DBool(lang.isPL)), // lanispl
+				tree.MakeDBool(tree.DBool(lang.isTrusted)), // lanpltrusted
+				oidZero,    // lanplcallfoid
+				oidZero,    // laninline
+				oidZero,    // lanvalidator
+				tree.DNull, // lanacl
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogLocksTable = virtualSchemaTable{
@@ -2493,12 +2599,22 @@ func addPgProcUDFRow(
 	var argNames tree.Datum
 	argNamesArray := tree.NewDArray(types.String)
 	foundAnyArgNames := false
+	variadicType := oidZero
 	for _, param := range fnDesc.GetParams() {
 		if err := argTypes.Append(tree.NewDOid(param.Type.Oid())); err != nil {
 			return err
 		}
-		// We only support IN arguments at the moment.
-		if err := argModes.Append(tree.NewDString("i")); err != nil {
+		argMode := "i"
+		switch param.Class {
+		case catpb.Function_Param_OUT:
+			argMode = "o"
+		case catpb.Function_Param_IN_OUT:
+			argMode = "b"
+		case catpb.Function_Param_VARIADIC:
+			argMode = "v"
+			variadicType = tree.NewDOid(param.Type.ArrayContents().Oid())
+		}
+		if err := argModes.Append(tree.NewDString(argMode)); err != nil {
 			return err
 		}
 		if len(param.Name) > 0 {
@@ -2512,24 +2628,29 @@ func addPgProcUDFRow(
 	if foundAnyArgNames {
 		argNames = argNamesArray
 	}
+	kind := tree.NewDString("f")
+	isAggregate := fnDesc.GetAggregate() != nil
+	if fnDesc.GetIsProcedure() {
+		kind = tree.NewDString("p")
+	} else if isAggregate {
+		kind = tree.NewDString("a")
+	}
 
 	return addRow(
-		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())), // oid
-		tree.NewDName(fnDesc.GetName()),                 // proname
-		schemaOid(scDesc.GetID()),                       // pronamespace
-		h.UserOid(fnDesc.GetPrivileges().Owner()),       // proowner
-		// In postgres oid of sql language is 14, need to add a mapping if
-		// we are going to support more languages.
-		tree.NewDOid(14), // prolang
-		tree.DNull,       // procost
-		tree.DNull,       // prorows
-		oidZero,          // provariadic
-		tree.DNull,       // protransform
-		tree.DBoolFalse,  // proisagg
-		tree.DBoolFalse,  // proiswindow
-		tree.DBoolFalse,  // prosecdef
-		tree.MakeDBool(tree.DBool(fnDesc.GetLeakProof())),            // proleakproof
-		tree.MakeDBool(tree.DBool(isStrict)),                         // proisstrict
+		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())),   // oid
+		tree.NewDName(fnDesc.GetName()),                   // proname
+		schemaOid(scDesc.GetID()),                         // pronamespace
+		h.UserOid(fnDesc.GetPrivileges().Owner()),         // proowner
+		languageOid(h, fnDesc.GetLanguage()),              // prolang
+		tree.DNull,                                        // procost
+		tree.DNull,                                        // prorows
+		variadicType,                                      // provariadic
+		tree.DNull,                                        // protransform
+		tree.MakeDBool(tree.DBool(isAggregate)),           // proisagg
+		tree.DBoolFalse,                                   // proiswindow
+		tree.DBoolFalse,                                   // prosecdef
+		tree.MakeDBool(tree.DBool(fnDesc.GetLeakProof())), // proleakproof
+		tree.MakeDBool(tree.DBool(isStrict)),              // proisstrict
 		tree.MakeDBool(tree.DBool(fnDesc.GetReturnType().ReturnSet)), // proretset
 		tree.NewDString(funcVolatility(fnDesc.GetVolatility())),      // provolatile
 		tree.DNull, // proparallel
@@ -2546,7 +2667,7 @@ func addPgProcUDFRow(
 		tree.DNull,                                       // probin
 		tree.DNull,                                       // proconfig
 		tree.DNull,                                       // proacl
-		tree.NewDString("f"),                             // prokind
+		kind,                                             // prokind
 		// These columns were automatically created by pg_catalog_test's missing column generator.
 		tree.DNull, // prosupport
 	)
@@ -2680,7 +2801,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-rewrite.html`,
 			table catalog.TableDescriptor,
 			tableLookup tableLookupFn,
 		) error {
-			if !table.IsTable() && !table.IsView() {
+			if !table.IsTable() && !table.IsView() && !table.IsForeignTable() {
 				return nil
 			}
 
@@ -3037,28 +3158,82 @@ https://www.postgresql.org/docs/9.5/catalog-pg-tablespace.html`,
 }
 
 var pgCatalogTriggerTable = virtualSchemaTable{
-	comment: `triggers (empty - feature does not exist)
+	comment: `triggers
 https://www.postgresql.org/docs/9.5/catalog-pg-trigger.html`,
 	schema: vtable.PGCatalogTrigger,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Triggers are unsupported.
-		return nil
+		h := makeOidHasher()
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables do not have triggers */
+			func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				triggers := table.GetTriggers()
+				for i := range triggers {
+					trig := &triggers[i]
+					funcOid, err := triggerFuncOid(trig)
+					if err != nil {
+						return err
+					}
+					var updateCols []descpb.ColumnID
+					for j := range trig.Events {
+						if trig.Events[j].Type == descpb.TableDescriptor_Trigger_UPDATE {
+							updateCols = append(updateCols, trig.Events[j].ColumnIDs...)
+						}
+					}
+					tgattr, err := colIDArrayToVector(updateCols)
+					if err != nil {
+						return err
+					}
+					if tgattr == tree.DNull {
+						tgattr = tree.NewDIntVectorFromDArray(tree.NewDArray(types.Int2))
+					}
+					var args strings.Builder
+					for _, arg := range trig.FuncArgs {
+						args.WriteString(arg)
+						args.WriteByte(0)
+					}
+					tgqual := tree.DNull
+					if trig.WhenExpr != "" {
+						tgqual = tree.NewDString(trig.WhenExpr)
+					}
+					if err := addRow(
+						h.TriggerOid(table.GetID(), trig.ID),         // oid
+						tableOid(table.GetID()),                      // tgrelid
+						tree.NewDName(trig.Name),                     // tgname
+						funcOid,                                      // tgfoid
+						tree.NewDInt(tree.DInt(pgTriggerType(trig))), // tgtype
+						tree.NewDString("O"),                         // tgenabled
+						tree.DBoolFalse,                              // tgisinternal
+						oidZero,                                      // tgconstrrelid
+						oidZero,                                      // tgconstrindid
+						oidZero,                                      // tgconstraint
+						tree.DBoolFalse,                              // tgdeferrable
+						tree.DBoolFalse,                              // tginitdeferred
+						tree.NewDInt(tree.DInt(len(trig.FuncArgs))), // tgnargs
+						tgattr, // tgattr
+						tree.NewDBytes(tree.DBytes(args.String())), // tgargs
+						tgqual,     // tgqual
+						tree.DNull, // tgoldtable
+						tree.DNull, // tgnewtable
+						oidZero,    // tgparentid
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var (
-	typTypeBase      = tree.NewDString("b")
-	typTypeComposite = tree.NewDString("c")
-	typTypeDomain    = tree.NewDString("d")
-	typTypeEnum      = tree.NewDString("e")
-	typTypePseudo    = tree.NewDString("p")
-	typTypeRange     = tree.NewDString("r")
+	typTypeBase       = tree.NewDString("b")
+	typTypeComposite  = tree.NewDString("c")
+	typTypeDomain     = tree.NewDString("d")
+	typTypeEnum       = tree.NewDString("e")
+	typTypePseudo     = tree.NewDString("p")
+	typTypeRange      = tree.NewDString("r")
+	typTypeMultirange = tree.NewDString("m")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
-	_ = typTypeRange
 
 	// See https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html#CATALOG-TYPCATEGORY-TABLE.
 	typCategoryArray       = tree.NewDString("A")
@@ -3080,7 +3255,6 @@ var (
 	// Avoid unused warning for constants.
 	_ = typCategoryEnum
 	_ = typCategoryGeometric
-	_ = typCategoryRange
 	_ = typCategoryBitString
 
 	commaTypDelim = tree.NewDString(",")
@@ -3166,7 +3340,7 @@ func addPGTypeRow(
 			// AnyArray does not use a prefix or element type.
 		default:
 			builtinPrefix = "array_"
-			typElem = tree.NewDOid(typ.ArrayContents().Oid())
+			typElem = tree.NewDOid(typ.ArrayContents().UserDefinedOID())
 		}
 	case types.EnumFamily:
 		builtinPrefix = "enum_"
@@ -3176,6 +3350,12 @@ func addPGTypeRow(
 		builtinPrefix = "record_"
 		typType = typTypeComposite
 		typArray = tree.NewDOid(types.CalcArrayOid(typ))
+	case types.RangeFamily:
+		typType = typTypeRange
+		typArray = tree.NewDOid(types.CalcArrayOid(typ))
+	case types.MultirangeFamily:
+		typType = typTypeMultirange
+		typArray = tree.NewDOid(types.CalcArrayOid(typ))
 	case types.VoidFamily:
 		// void does not have an array type.
 	default:
@@ -3184,23 +3364,36 @@ func addPGTypeRow(
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	typDefault := tree.DNull
+	if typ.IsDomain() {
+		typType = typTypeDomain
+		typBaseType = tree.NewDOid(typ.Oid())
+		if md := typ.TypeMeta.DomainData; md != nil {
+			typNotNull = tree.MakeDBool(tree.DBool(md.NotNull))
+			if md.DefaultExpr != nil {
+				typDefault = tree.NewDString(*md.DefaultExpr)
+			}
+		}
+	}
 	typname := typ.PGName()
 	typDelim := tree.NewDString(typ.Delimiter())
 	return addRow(
-		tree.NewDOid(typ.Oid()), // oid
-		tree.NewDName(typname),  // typname
-		nspOid,                  // typnamespace
-		owner,                   // typowner
-		typLen(typ),             // typlen
-		typByVal(typ),           // typbyval (is it fixedlen or not)
-		typType,                 // typtype
-		cat,                     // typcategory
-		tree.DBoolFalse,         // typispreferred
-		tree.DBoolTrue,          // typisdefined
-		typDelim,                // typdelim
-		oidZero,                 // typrelid
-		typElem,                 // typelem
-		typArray,                // typarray
+		tree.NewDOid(typ.UserDefinedOID()), // oid
+		tree.NewDName(typname),             // typname
+		nspOid,                             // typnamespace
+		owner,                              // typowner
+		typLen(typ),                        // typlen
+		typByVal(typ),                      // typbyval (is it fixedlen or not)
+		typType,                            // typtype
+		cat,                                // typcategory
+		tree.DBoolFalse,                    // typispreferred
+		tree.DBoolTrue,                     // typisdefined
+		typDelim,                           // typdelim
+		oidZero,                            // typrelid
+		typElem,                            // typelem
+		typArray,                           // typarray
 
 		// regproc references
 		h.RegProc(builtinPrefix+"in"),   // typinput
@@ -3213,13 +3406,13 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
 		negOneVal,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
 		tree.DNull,      // typdefaultbin
-		tree.DNull,      // typdefault
+		typDefault,      // typdefault
 		tree.DNull,      // typacl
 	)
 }
@@ -3937,12 +4130,12 @@ https://www.postgresql.org/docs/14/view-pg-cursors.html`,
 				return err
 			}
 			if err := addRow(
-				tree.NewDString(string(name)), /* name */
-				tree.NewDString(c.statement),  /* statement */
-				tree.DBoolFalse,               /* is_holdable */
-				tree.DBoolFalse,               /* is_binary */
-				tree.DBoolFalse,               /* is_scrollable */
-				tz,                            /* creation_date */
+				tree.NewDString(string(name)),          /* name */
+				tree.NewDString(c.statement),           /* statement */
+				tree.MakeDBool(tree.DBool(c.withHold)), /* is_holdable */
+				tree.MakeDBool(tree.DBool(c.binary)),   /* is_binary */
+				tree.MakeDBool(tree.DBool(c.scroll)),   /* is_scrollable */
+				tz,                                     /* creation_date */
 			); err != nil {
 				return err
 			}
@@ -4426,6 +4619,10 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.GeographyFamily:   typCategoryUserDefined,
 	types.GeometryFamily:    typCategoryUserDefined,
 	types.JsonFamily:        typCategoryUserDefined,
+	types.JsonpathFamily:    typCategoryUserDefined,
+	types.PGVectorFamily:    typCategoryUserDefined,
+	types.RangeFamily:       typCategoryRange,
+	types.MultirangeFamily:  typCategoryRange,
 	types.DecimalFamily:     typCategoryNumeric,
 	types.StringFamily:      typCategoryString,
 	types.TimestampFamily:   typCategoryDateTime,
@@ -4449,6 +4646,11 @@ func typCategory(typ *types.T) tree.Datum {
 	if typ.UserDefined() && typ.Family() == types.TupleFamily {
 		return typCategoryComposite
 	}
+	// The anyrange and anymultirange wildcards are pseudo types.
+	if (typ.Family() == types.RangeFamily || typ.Family() == types.MultirangeFamily) &&
+		typ.RangeContents() == nil {
+		return typCategoryPseudo
+	}
 	return datumToTypeCategory[typ.Family()]
 }
 
@@ -4660,6 +4862,9 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	foreignServerTypeTag
+	languageTypeTag
+	triggerTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -4855,6 +5060,25 @@ func (h oidHasher) CastOid(srcID oid.Oid, tgtID oid.Oid) *tree.DOid {
 	return h.getOid()
 }
 
+func (h oidHasher) ForeignServerOid(name string) *tree.DOid {
+	h.writeTypeTag(foreignServerTypeTag)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+func (h oidHasher) TriggerOid(tableID descpb.ID, triggerID descpb.TriggerID) *tree.DOid {
+	h.writeTypeTag(triggerTypeTag)
+	h.writeTable(tableID)
+	h.writeUInt32(uint32(triggerID))
+	return h.getOid()
+}
+
+func (h oidHasher) LanguageOid(name string) *tree.DOid {
+	h.writeTypeTag(languageTypeTag)
+	h.writeStr(name)
+	return h.getOid()
+}
+
 func funcVolatility(v catpb.Function_Volatility) string {
 	switch v {
 	case catpb.Function_IMMUTABLE:
@@ -83,6 +83,10 @@ type commandResult struct {
 	// to have an entry for every column.
 	formatCodes []pgwirebase.FormatCode
 
+	// binaryFormat is set if all columns should be encoded in the binary format
+	// when formatCodes is nil.
+	binaryFormat bool
+
 	// types is a map from result column index to its type T, similar to formatCodes
 	// (except types must always be set).
 	types []*types.T
@@ -258,6 +262,12 @@ func (r *commandResult) BufferNotice(notice pgnotice.Notice) {
 func (r *commandResult) SetColumns(ctx context.Context, cols colinfo.ResultColumns) {
 	r.assertNotReleased()
 	r.conn.writerState.fi.registerCmd(r.pos)
+	if r.binaryFormat && r.formatCodes == nil {
+		r.formatCodes = make([]pgwirebase.FormatCode, len(cols))
+		for i := range r.formatCodes {
+			r.formatCodes[i] = pgwirebase.FormatBinary
+		}
+	}
 	if r.descOpt == sql.NeedRowDesc {
 		_ /* err */ = r.conn.writeRowDescription(ctx, cols, r.formatCodes, &r.conn.writerState.buf)
 	}
@@ -267,6 +277,12 @@ func (r *commandResult) SetColumns(ctx context.Context, cols colinfo.ResultColum
 	}
 }
 
+// UseBinaryFormat is part of the sql.RestrictedCommandResult interface.
+func (r *commandResult) UseBinaryFormat() {
+	r.assertNotReleased()
+	r.binaryFormat = true
+}
+
 // SetInferredTypes is part of the sql.DescribeResult interface.
 func (r *commandResult) SetInferredTypes(types []oid.Oid) {
 	r.assertNotReleased()
@@ -44,6 +44,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/netutil"
 	"github.com/cockroachdb/cockroach/pkg/util/ring"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
@@ -100,6 +101,10 @@ type conn struct {
 		// network connection.
 		buf    bytes.Buffer
 		tagBuf [64]byte
+		// netMu serializes the writes of buf to the network connection with
+		// the delivery of asynchronous notifications, which happens on a
+		// different goroutine.
+		netMu syncutil.Mutex
 	}
 
 	readBuf    pgwirebase.ReadBuffer
@@ -747,6 +752,44 @@ func (c *conn) bufferParamStatus(param, value string) error {
 	return c.msgBuilder.finishMsg(&c.writerState.buf)
 }
 
+// SendNotification is part of the sql.NotificationSender interface.
+func (c *conn) SendNotification(pid int32, channel, payload string) error {
+	if err := c.GetErr(); err != nil {
+		return err
+	}
+	// The notification is built separately from msgBuilder, which belongs to
+	// the command processor.
+	msg := newWriteBuffer(c.metrics.BytesOutCount)
+	msg.initMsg(pgwirebase.ServerMsgNotificationResponse)
+	msg.putInt32(pid)
+	msg.writeTerminatedString(channel)
+	msg.writeTerminatedString(payload)
+	c.writerState.netMu.Lock()
+	defer c.writerState.netMu.Unlock()
+	if err := msg.finishMsg(c.conn); err != nil {
+		c.setErr(err)
+		return err
+	}
+	return nil
+}
+
+// SendNotice is part of the sql.NotificationSender interface.
+func (c *conn) SendNotice(ctx context.Context, notice pgnotice.Notice) error {
+	if err := c.GetErr(); err != nil {
+		return err
+	}
+	// As for notifications, the notice is built separately from msgBuilder.
+	w := errWriter{sv: c.errWriter.sv, msgBuilder: newWriteBuffer(c.metrics.BytesOutCount)}
+	w.msgBuilder.initMsg(pgwirebase.ServerMsgNoticeResponse)
+	c.writerState.netMu.Lock()
+	defer c.writerState.netMu.Unlock()
+	if err := w.writeErrFields(ctx, notice, c.conn); err != nil {
+		c.setErr(err)
+		return err
+	}
+	return nil
+}
+
 func (c *conn) bufferNotice(ctx context.Context, noticeErr pgnotice.Notice) error {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgNoticeResponse)
 	return c.writeErrFields(ctx, noticeErr, &c.writerState.buf)
@@ -1289,7 +1332,8 @@ func cookTag(
 		tag = strconv.AppendInt(tag, int64(rowsAffected), 10)
 
 	case tree.Rows:
-		if tagStr != "SHOW" {
+		// The tags of SHOW and CALL do not include a row count.
+		if tagStr != "SHOW" && tagStr != "CALL" {
 			tag = append(tag, ' ')
 			tag = strconv.AppendUint(tag, uint64(rowsAffected), 10)
 		}
@@ -1644,7 +1688,9 @@ func (c *conn) Flush(pos sql.CmdPos) error {
 	// Make sure that the entire cmdStarts buffer is drained.
 	c.writerState.fi.cmdStarts.Discard()
 
+	c.writerState.netMu.Lock()
 	_ /* n */, err := c.writerState.buf.WriteTo(c.conn)
+	c.writerState.netMu.Unlock()
 	if err != nil {
 		c.setErr(err)
 		return err
@@ -127,6 +127,21 @@ var (
 	InvalidXMLContent                     = MakeCode("2200N")
 	InvalidXMLComment                     = MakeCode("2200S")
 	InvalidXMLProcessingInstruction       = MakeCode("2200T")
+	DuplicateJSONObjectKeyValue           = MakeCode("22030")
+	InvalidJSONText                       = MakeCode("22032")
+	InvalidSQLJSONSubscript               = MakeCode("22033")
+	MoreThanOneSQLJSONItem                = MakeCode("22034")
+	NoSQLJSONItem                         = MakeCode("22035")
+	NonNumericSQLJSONItem                 = MakeCode("22036")
+	NonUniqueKeysInAJSONObject            = MakeCode("22037")
+	SingletonSQLJSONItemRequired          = MakeCode("22038")
+	SQLJSONArrayNotFound                  = MakeCode("22039")
+	SQLJSONMemberNotFound                 = MakeCode("2203A")
+	SQLJSONNumberNotFound                 = MakeCode("2203B")
+	SQLJSONObjectNotFound                 = MakeCode("2203C")
+	TooManyJSONArrayElements              = MakeCode("2203D")
+	TooManyJSONObjectMembers              = MakeCode("2203E")
+	SQLJSONScalarRequired                 = MakeCode("2203F")
 	// Section: Class 23 - Integrity Constraint Violation
 	IntegrityConstraintViolation = MakeCode("23000")
 	RestrictViolation            = MakeCode("23001")
@@ -0,0 +1,314 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgcode
+
+// conditionNames maps the condition names listed in errcodes.txt to their
+// codes. PL/pgSQL uses these names to refer to errors, e.g. in the WHEN clause
+// of an exception handler. When a name is listed for more than one code, the
+// code of the error, rather than the warning, is used.
+var conditionNames = map[string]Code{
+	// Section: Class 00 - Successful Completion
+	"successful_completion": SuccessfulCompletion,
+	// Section: Class 01 - Warning
+	"warning":                               Warning,
+	"dynamic_result_sets_returned":          WarningDynamicResultSetsReturned,
+	"implicit_zero_bit_padding":             WarningImplicitZeroBitPadding,
+	"null_value_eliminated_in_set_function": WarningNullValueEliminatedInSetFunction,
+	"privilege_not_granted":                 WarningPrivilegeNotGranted,
+	"privilege_not_revoked":                 WarningPrivilegeNotRevoked,
+	"deprecated_feature":                    WarningDeprecatedFeature,
+	// Section: Class 02 - No Data (this is also a warning class per the SQL standard)
+	"no_data": NoData,
+	"no_additional_dynamic_result_sets_returned": NoAdditionalDynamicResultSetsReturned,
+	// Section: Class 03 - SQL Statement Not Yet Complete
+	"sql_statement_not_yet_complete": SQLStatementNotYetComplete,
+	// Section: Class 08 - Connection Exception
+	"connection_exception":                              ConnectionException,
+	"connection_does_not_exist":                         ConnectionDoesNotExist,
+	"connection_failure":                                ConnectionFailure,
+	"sqlclient_unable_to_establish_sqlconnection":       SQLclientUnableToEstablishSQLconnection,
+	"sqlserver_rejected_establishment_of_sqlconnection": SQLserverRejectedEstablishmentOfSQLconnection,
+	"transaction_resolution_unknown":                    TransactionResolutionUnknown,
+	"protocol_violation":                                ProtocolViolation,
+	// Section: Class 09 - Triggered Action Exception
+	"triggered_action_exception": TriggeredActionException,
+	// Section: Class 0A - Feature Not Supported
+	"feature_not_supported": FeatureNotSupported,
+	// Section: Class 0B - Invalid Transaction Initiation
+	"invalid_transaction_initiation": InvalidTransactionInitiation,
+	// Section: Class 0F - Locator Exception
+	"locator_exception":             LocatorException,
+	"invalid_locator_specification": InvalidLocatorSpecification,
+	// Section: Class 0L - Invalid Grantor
+	"invalid_grantor":         InvalidGrantor,
+	"invalid_grant_operation": InvalidGrantOperation,
+	// Section: Class 0P - Invalid Role Specification
+	"invalid_role_specification": InvalidRoleSpecification,
+	// Section: Class 0Z - Diagnostics Exception
+	"diagnostics_exception":                               DiagnosticsException,
+	"stacked_diagnostics_accessed_without_active_handler": StackedDiagnosticsAccessedWithoutActiveHandler,
+	// Section: Class 20 - Case Not Found
+	"case_not_found": CaseNotFound,
+	// Section: Class 21 - Cardinality Violation
+	"cardinality_violation": CardinalityViolation,
+	// Section: Class 22 - Data Exception
+	"data_exception":                                  DataException,
+	"array_subscript_error":                           ArraySubscript,
+	"character_not_in_repertoire":                     CharacterNotInRepertoire,
+	"datetime_field_overflow":                         DatetimeFieldOverflow,
+	"division_by_zero":                                DivisionByZero,
+	"error_in_assignment":                             ErrorInAssignment,
+	"escape_character_conflict":                       EscapeCharacterConflict,
+	"indicator_overflow":                              IndicatorOverflow,
+	"interval_field_overflow":                         IntervalFieldOverflow,
+	"invalid_argument_for_logarithm":                  InvalidArgumentForLogarithm,
+	"invalid_argument_for_ntile_function":             InvalidArgumentForNtileFunction,
+	"invalid_argument_for_nth_value_function":         InvalidArgumentForNthValueFunction,
+	"invalid_argument_for_power_function":             InvalidArgumentForPowerFunction,
+	"invalid_argument_for_width_bucket_function":      InvalidArgumentForWidthBucketFunction,
+	"invalid_character_value_for_cast":                InvalidCharacterValueForCast,
+	"invalid_datetime_format":                         InvalidDatetimeFormat,
+	"invalid_escape_character":                        InvalidEscapeCharacter,
+	"invalid_escape_octet":                            InvalidEscapeOctet,
+	"invalid_escape_sequence":                         InvalidEscapeSequence,
+	"nonstandard_use_of_escape_character":             NonstandardUseOfEscapeCharacter,
+	"invalid_indicator_parameter_value":               InvalidIndicatorParameterValue,
+	"invalid_parameter_value":                         InvalidParameterValue,
+	"invalid_regular_expression":                      InvalidRegularExpression,
+	"invalid_row_count_in_limit_clause":               InvalidRowCountInLimitClause,
+	"invalid_row_count_in_result_offset_clause":       InvalidRowCountInResultOffsetClause,
+	"invalid_tablesample_argument":                    MakeCode("2202H"),
+	"invalid_tablesample_repeat":                      MakeCode("2202G"),
+	"invalid_time_zone_displacement_value":            InvalidTimeZoneDisplacementValue,
+	"invalid_use_of_escape_character":                 InvalidUseOfEscapeCharacter,
+	"most_specific_type_mismatch":                     MostSpecificTypeMismatch,
+	"null_value_not_allowed":                          NullValueNotAllowed,
+	"null_value_no_indicator_parameter":               NullValueNoIndicatorParameter,
+	"numeric_value_out_of_range":                      NumericValueOutOfRange,
+	"string_data_length_mismatch":                     StringDataLengthMismatch,
+	"string_data_right_truncation":                    StringDataRightTruncation,
+	"substring_error":                                 Substring,
+	"trim_error":                                      Trim,
+	"unterminated_c_string":                           UnterminatedCString,
+	"zero_length_character_string":                    ZeroLengthCharacterString,
+	"floating_point_exception":                        FloatingPointException,
+	"invalid_text_representation":                     InvalidTextRepresentation,
+	"invalid_binary_representation":                   InvalidBinaryRepresentation,
+	"bad_copy_file_format":                            BadCopyFileFormat,
+	"untranslatable_character":                        UntranslatableCharacter,
+	"not_an_xml_document":                             NotAnXMLDocument,
+	"invalid_xml_document":                            InvalidXMLDocument,
+	"invalid_xml_content":                             InvalidXMLContent,
+	"invalid_xml_comment":                             InvalidXMLComment,
+	"invalid_xml_processing_instruction":              InvalidXMLProcessingInstruction,
+	"duplicate_json_object_key_value":                 DuplicateJSONObjectKeyValue,
+	"invalid_argument_for_sql_json_datetime_function": MakeCode("22031"),
+	"invalid_json_text":                               InvalidJSONText,
+	"invalid_sql_json_subscript":                      InvalidSQLJSONSubscript,
+	"more_than_one_sql_json_item":                     MoreThanOneSQLJSONItem,
+	"no_sql_json_item":                                NoSQLJSONItem,
+	"non_numeric_sql_json_item":                       NonNumericSQLJSONItem,
+	"non_unique_keys_in_a_json_object":                NonUniqueKeysInAJSONObject,
+	"singleton_sql_json_item_required":                SingletonSQLJSONItemRequired,
+	"sql_json_array_not_found":                        SQLJSONArrayNotFound,
+	"sql_json_member_not_found":                       SQLJSONMemberNotFound,
+	"sql_json_number_not_found":                       SQLJSONNumberNotFound,
+	"sql_json_object_not_found":                       SQLJSONObjectNotFound,
+	"too_many_json_array_elements":                    TooManyJSONArrayElements,
+	"too_many_json_object_members":                    TooManyJSONObjectMembers,
+	"sql_json_scalar_required":                        SQLJSONScalarRequired,
+	// Section: Class 23 - Integrity Constraint Violation
+	"integrity_constraint_violation": IntegrityConstraintViolation,
+	"restrict_violation":             RestrictViolation,
+	"not_null_violation":             NotNullViolation,
+	"foreign_key_violation":          ForeignKeyViolation,
+	"unique_violation":               UniqueViolation,
+	"check_violation":                CheckViolation,
+	"exclusion_violation":            ExclusionViolation,
+	// Section: Class 24 - Invalid Cursor State
+	"invalid_cursor_state": InvalidCursorState,
+	// Section: Class 25 - Invalid Transaction State
+	"invalid_transaction_state":                            InvalidTransactionState,
+	"active_sql_transaction":                               ActiveSQLTransaction,
+	"branch_transaction_already_active":                    BranchTransactionAlreadyActive,
+	"held_cursor_requires_same_isolation_level":            HeldCursorRequiresSameIsolationLevel,
+	"inappropriate_access_mode_for_branch_transaction":     InappropriateAccessModeForBranchTransaction,
+	"inappropriate_isolation_level_for_branch_transaction": InappropriateIsolationLevelForBranchTransaction,
+	"no_active_sql_transaction_for_branch_transaction":     NoActiveSQLTransactionForBranchTransaction,
+	"read_only_sql_transaction":                            ReadOnlySQLTransaction,
+	"schema_and_data_statement_mixing_not_supported":       SchemaAndDataStatementMixingNotSupported,
+	"no_active_sql_transaction":                            NoActiveSQLTransaction,
+	"in_failed_sql_transaction":                            InFailedSQLTransaction,
+	// Section: Class 26 - Invalid SQL Statement Name
+	"invalid_sql_statement_name": InvalidSQLStatementName,
+	// Section: Class 27 - Triggered Data Change Violation
+	"triggered_data_change_violation": TriggeredDataChangeViolation,
+	// Section: Class 28 - Invalid Authorization Specification
+	"invalid_authorization_specification": InvalidAuthorizationSpecification,
+	"invalid_password":                    InvalidPassword,
+	// Section: Class 2B - Dependent Privilege Descriptors Still Exist
+	"dependent_privilege_descriptors_still_exist": DependentPrivilegeDescriptorsStillExist,
+	"dependent_objects_still_exist":               DependentObjectsStillExist,
+	// Section: Class 2D - Invalid Transaction Termination
+	"invalid_transaction_termination": InvalidTransactionTermination,
+	// Section: Class 2F - SQL Routine Exception
+	"sql_routine_exception":                 MakeCode("2F000"),
+	"function_executed_no_return_statement": RoutineExceptionFunctionExecutedNoReturnStatement,
+	"modifying_sql_data_not_permitted":      RoutineExceptionModifyingSQLDataNotPermitted,
+	"prohibited_sql_statement_attempted":    RoutineExceptionProhibitedSQLStatementAttempted,
+	"reading_sql_data_not_permitted":        RoutineExceptionReadingSQLDataNotPermitted,
+	// Section: Class 34 - Invalid Cursor Name
+	"invalid_cursor_name": InvalidCursorName,
+	// Section: Class 38 - External Routine Exception
+	"external_routine_exception":   ExternalRoutineException,
+	"containing_sql_not_permitted": ExternalRoutineContainingSQLNotPermitted,
+	// Section: Class 39 - External Routine Invocation Exception
+	"external_routine_invocation_exception": ExternalRoutineInvocationException,
+	"invalid_sqlstate_returned":             ExternalRoutineInvalidSQLstateReturned,
+	"trigger_protocol_violated":             ExternalRoutineTriggerProtocolViolated,
+	"srf_protocol_violated":                 ExternalRoutineSrfProtocolViolated,
+	"event_trigger_protocol_violated":       MakeCode("39P03"),
+	// Section: Class 3B - Savepoint Exception
+	"savepoint_exception":             SavepointException,
+	"invalid_savepoint_specification": InvalidSavepointSpecification,
+	// Section: Class 3D - Invalid Catalog Name
+	"invalid_catalog_name": InvalidCatalogName,
+	// Section: Class 3F - Invalid Schema Name
+	"invalid_schema_name": InvalidSchemaName,
+	// Section: Class 40 - Transaction Rollback
+	"transaction_rollback":                       TransactionRollback,
+	"transaction_integrity_constraint_violation": TransactionIntegrityConstraintViolation,
+	"serialization_failure":                      SerializationFailure,
+	"statement_completion_unknown":               StatementCompletionUnknown,
+	"deadlock_detected":                          DeadlockDetected,
+	// Section: Class 42 - Syntax Error or Access Rule Violation
+	"syntax_error_or_access_rule_violation": SyntaxErrorOrAccessRuleViolation,
+	"syntax_error":                          Syntax,
+	"insufficient_privilege":                InsufficientPrivilege,
+	"cannot_coerce":                         CannotCoerce,
+	"grouping_error":                        Grouping,
+	"windowing_error":                       Windowing,
+	"invalid_recursion":                     InvalidRecursion,
+	"invalid_foreign_key":                   InvalidForeignKey,
+	"invalid_name":                          InvalidName,
+	"name_too_long":                         NameTooLong,
+	"reserved_name":                         ReservedName,
+	"datatype_mismatch":                     DatatypeMismatch,
+	"indeterminate_datatype":                IndeterminateDatatype,
+	"collation_mismatch":                    CollationMismatch,
+	"indeterminate_collation":               IndeterminateCollation,
+	"wrong_object_type":                     WrongObjectType,
+	"undefined_column":                      UndefinedColumn,
+	"undefined_function":                    UndefinedFunction,
+	"undefined_table":                       UndefinedTable,
+	"undefined_parameter":                   UndefinedParameter,
+	"undefined_object":                      UndefinedObject,
+	"duplicate_column":                      DuplicateColumn,
+	"duplicate_cursor":                      DuplicateCursor,
+	"duplicate_database":                    DuplicateDatabase,
+	"duplicate_function":                    DuplicateFunction,
+	"duplicate_prepared_statement":          DuplicatePreparedStatement,
+	"duplicate_schema":                      DuplicateSchema,
+	"duplicate_table":                       DuplicateRelation,
+	"duplicate_alias":                       DuplicateAlias,
+	"duplicate_object":                      DuplicateObject,
+	"ambiguous_column":                      AmbiguousColumn,
+	"ambiguous_function":                    AmbiguousFunction,
+	"ambiguous_parameter":                   AmbiguousParameter,
+	"ambiguous_alias":                       AmbiguousAlias,
+	"invalid_column_reference":              InvalidColumnReference,
+	"invalid_column_definition":             InvalidColumnDefinition,
+	"invalid_cursor_definition":             InvalidCursorDefinition,
+	"invalid_database_definition":           InvalidDatabaseDefinition,
+	"invalid_function_definition":           InvalidFunctionDefinition,
+	"invalid_prepared_statement_definition": InvalidPreparedStatementDefinition,
+	"invalid_schema_definition":             InvalidSchemaDefinition,
+	"invalid_table_definition":              InvalidTableDefinition,
+	"invalid_object_definition":             InvalidObjectDefinition,
+	// Section: Class 44 - WITH CHECK OPTION Violation
+	"with_check_option_violation": WithCheckOptionViolation,
+	// Section: Class 53 - Insufficient Resources
+	"insufficient_resources":       InsufficientResources,
+	"disk_full":                    DiskFull,
+	"out_of_memory":                OutOfMemory,
+	"too_many_connections":         TooManyConnections,
+	"configuration_limit_exceeded": ConfigurationLimitExceeded,
+	// Section: Class 54 - Program Limit Exceeded
+	"program_limit_exceeded": ProgramLimitExceeded,
+	"statement_too_complex":  StatementTooComplex,
+	"too_many_columns":       TooManyColumns,
+	"too_many_arguments":     TooManyArguments,
+	// Section: Class 55 - Object Not In Prerequisite State
+	"object_not_in_prerequisite_state": ObjectNotInPrerequisiteState,
+	"object_in_use":                    ObjectInUse,
+	"cant_change_runtime_param":        CantChangeRuntimeParam,
+	"lock_not_available":               LockNotAvailable,
+	// Section: Class 57 - Operator Intervention
+	"operator_intervention": OperatorIntervention,
+	"query_canceled":        QueryCanceled,
+	"admin_shutdown":        AdminShutdown,
+	"crash_shutdown":        CrashShutdown,
+	"cannot_connect_now":    CannotConnectNow,
+	"database_dropped":      DatabaseDropped,
+	// Section: Class 58 - System Error (errors external to PostgreSQL itself)
+	"system_error":   System,
+	"io_error":       Io,
+	"undefined_file": UndefinedFile,
+	"duplicate_file": DuplicateFile,
+	// Section: Class F0 - Configuration File Error
+	"config_file_error": ConfigFile,
+	"lock_file_exists":  LockFileExists,
+	// Section: Class HV - Foreign Data Wrapper Error (SQL/MED)
+	"fdw_error":                                  FdwError,
+	"fdw_column_name_not_found":                  FdwColumnNameNotFound,
+	"fdw_dynamic_parameter_value_needed":         FdwDynamicParameterValueNeeded,
+	"fdw_function_sequence_error":                FdwFunctionSequenceError,
+	"fdw_inconsistent_descriptor_information":    FdwInconsistentDescriptorInformation,
+	"fdw_invalid_attribute_value":                FdwInvalidAttributeValue,
+	"fdw_invalid_column_name":                    FdwInvalidColumnName,
+	"fdw_invalid_column_number":                  FdwInvalidColumnNumber,
+	"fdw_invalid_data_type":                      FdwInvalidDataType,
+	"fdw_invalid_data_type_descriptors":          FdwInvalidDataTypeDescriptors,
+	"fdw_invalid_descriptor_field_identifier":    FdwInvalidDescriptorFieldIdentifier,
+	"fdw_invalid_handle":                         FdwInvalidHandle,
+	"fdw_invalid_option_index":                   FdwInvalidOptionIndex,
+	"fdw_invalid_option_name":                    FdwInvalidOptionName,
+	"fdw_invalid_string_length_or_buffer_length": FdwInvalidStringLengthOrBufferLength,
+	"fdw_invalid_string_format":                  FdwInvalidStringFormat,
+	"fdw_invalid_use_of_null_pointer":            FdwInvalidUseOfNullPointer,
+	"fdw_too_many_handles":                       FdwTooManyHandles,
+	"fdw_out_of_memory":                          FdwOutOfMemory,
+	"fdw_no_schemas":                             FdwNoSchemas,
+	"fdw_option_name_not_found":                  FdwOptionNameNotFound,
+	"fdw_reply_handle":                           FdwReplyHandle,
+	"fdw_schema_not_found":                       FdwSchemaNotFound,
+	"fdw_table_not_found":                        FdwTableNotFound,
+	"fdw_unable_to_create_execution":             FdwUnableToCreateExecution,
+	"fdw_unable_to_create_reply":                 FdwUnableToCreateReply,
+	"fdw_unable_to_establish_connection":         FdwUnableToEstablishConnection,
+	// Section: Class P0 - PL/pgSQL Error
+	"plpgsql_error":   PLpgSQL,
+	"raise_exception": RaiseException,
+	"no_data_found":   NoDataFound,
+	"too_many_rows":   TooManyRows,
+	"assert_failure":  AssertFailure,
+	// Section: Class XX - Internal Error
+	"internal_error":  Internal,
+	"data_corrupted":  DataCorrupted,
+	"index_corrupted": IndexCorrupted,
+}
+
+// FromConditionName returns the code for the given condition name, e.g.
+// UniqueViolation for "unique_violation". The name must be lower case.
+func FromConditionName(name string) (Code, bool) {
+	c, ok := conditionNames[name]
+	return c, ok
+}
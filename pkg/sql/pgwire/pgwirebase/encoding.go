@@ -41,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 	"github.com/dustin/go-humanize"
 	"github.com/jackc/pgtype"
@@ -531,6 +532,16 @@ func DecodeDatum(
 				}
 			}
 			return out, nil
+		case oidext.T_jsonpath:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(string(b))
+		case oidext.T_pgvector:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDPGVector(string(b))
 		case oid.T_tsquery:
 			ret, err := tsearch.ParseTSQuery(string(b))
 			if err != nil {
@@ -552,6 +563,26 @@ func DecodeDatum(
 			}
 			return tree.NewDString(string(b)), nil
 		}
+		if typ.Family() == types.RangeFamily {
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDRangeFromString(evalCtx, string(b), typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
+		}
+		if typ.Family() == types.MultirangeFamily {
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDMultirangeFromString(evalCtx, string(b), typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
+		}
 	case FormatBinary:
 		switch id {
 		case oid.T_record:
@@ -824,6 +855,25 @@ func DecodeDatum(
 			}
 			ba, err := bitarray.FromEncodingParts(words, lastBitsUsed)
 			return &tree.DBitArray{BitArray: ba}, err
+		case oidext.T_pgvector:
+			v, err := vector.DecodePGBinary(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDPGVector(v), nil
+		case oidext.T_jsonpath:
+			if len(b) < 1 {
+				return nil, NewProtocolViolationErrorf("no data to decode")
+			}
+			if b[0] != 1 {
+				return nil, NewProtocolViolationErrorf("expected jsonpath version 1")
+			}
+			// Skip over the version number.
+			b = b[1:]
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(string(b))
 		case oid.T_tsquery:
 			ret, err := tsearch.DecodeTSQueryPGBinary(b)
 			if err != nil {
@@ -855,6 +905,16 @@ func DecodeDatum(
 			if typ.Family() == types.TupleFamily {
 				return decodeBinaryTuple(ctx, evalCtx, b)
 			}
+			if typ.Family() == types.RangeFamily {
+				d, _, err := decodeBinaryRange(ctx, evalCtx, typ, b)
+				if err != nil {
+					return nil, err
+				}
+				return d, nil
+			}
+			if typ.Family() == types.MultirangeFamily {
+				return decodeBinaryMultirange(ctx, evalCtx, typ, b)
+			}
 			if typ.Family() == types.OidFamily {
 				if len(b) < 4 {
 					return nil, pgerror.Newf(pgcode.ProtocolViolation, "oid requires 4 bytes for binary format")
@@ -1073,6 +1133,96 @@ func decodeBinaryArray(
 	return arr, nil
 }
 
+// Flags of the Postgres binary format of ranges.
+const (
+	rangeEmpty          byte = 0x01
+	rangeLowerInclusive byte = 0x02
+	rangeUpperInclusive byte = 0x04
+	rangeLowerInfinite  byte = 0x08
+	rangeUpperInfinite  byte = 0x10
+)
+
+// decodeBinaryRange decodes a range in the Postgres binary format, which is a
+// flags byte followed by the length-prefixed binary encoding of every finite
+// bound. It returns the bytes remaining after the range.
+func decodeBinaryRange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte,
+) (*tree.DRange, []byte, error) {
+	if len(b) < 1 {
+		return nil, nil, NewProtocolViolationErrorf("no data to decode")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&rangeEmpty != 0 {
+		return tree.NewDEmptyRange(t), b, nil
+	}
+	decodeBound := func(inclusive bool) (tree.RangeBound, error) {
+		if len(b) < 4 {
+			return tree.RangeBound{}, NewProtocolViolationErrorf("insufficient data: %d", len(b))
+		}
+		vlen := int32(binary.BigEndian.Uint32(b))
+		b = b[4:]
+		if vlen < 0 || int(vlen) > len(b) {
+			return tree.RangeBound{}, NewProtocolViolationErrorf("invalid range bound length: %d", vlen)
+		}
+		val, err := DecodeDatum(ctx, evalCtx, t.RangeContents(), FormatBinary, b[:vlen])
+		b = b[vlen:]
+		return tree.RangeBound{Val: val, Inclusive: inclusive}, err
+	}
+	var lower, upper tree.RangeBound
+	var err error
+	if flags&rangeLowerInfinite == 0 {
+		if lower, err = decodeBound(flags&rangeLowerInclusive != 0); err != nil {
+			return nil, nil, err
+		}
+	}
+	if flags&rangeUpperInfinite == 0 {
+		if upper, err = decodeBound(flags&rangeUpperInclusive != 0); err != nil {
+			return nil, nil, err
+		}
+	}
+	d, err := tree.NewDRange(evalCtx, t, lower, upper)
+	return d, b, err
+}
+
+// decodeBinaryMultirange decodes a multirange in the Postgres binary format,
+// which is the number of ranges followed by every length-prefixed range.
+func decodeBinaryMultirange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte,
+) (tree.Datum, error) {
+	if len(b) < 4 {
+		return nil, NewProtocolViolationErrorf("insufficient data: %d", len(b))
+	}
+	n := int32(binary.BigEndian.Uint32(b))
+	b = b[4:]
+	if n < 0 {
+		return nil, NewProtocolViolationErrorf("invalid number of ranges: %d", n)
+	}
+	rangeTyp := types.MultirangeRange(t)
+	ranges := make([]*tree.DRange, 0, n)
+	for i := int32(0); i < n; i++ {
+		if len(b) < 4 {
+			return nil, NewProtocolViolationErrorf("insufficient data: %d", len(b))
+		}
+		rlen := int32(binary.BigEndian.Uint32(b))
+		b = b[4:]
+		if rlen < 0 || int(rlen) > len(b) {
+			return nil, NewProtocolViolationErrorf("invalid range length: %d", rlen)
+		}
+		r, _, err := decodeBinaryRange(ctx, evalCtx, rangeTyp, b[:rlen])
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+		b = b[rlen:]
+	}
+	m, err := tree.NewDMultirange(evalCtx, t, ranges)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 const tupleHeaderSize, oidSize, elementSize = 4, 4, 4
 
 func decodeBinaryTuple(ctx context.Context, evalCtx *eval.Context, b []byte) (tree.Datum, error) {
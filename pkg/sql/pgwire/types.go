@@ -39,6 +39,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -240,6 +241,12 @@ func writeTextDatumNotNull(
 	case *tree.DJSON:
 		b.writeLengthPrefixedString(v.JSON.String())
 
+	case *tree.DJsonpath:
+		b.writeLengthPrefixedString(v.Path.String())
+
+	case *tree.DPGVector:
+		b.writeLengthPrefixedString(v.T.String())
+
 	case *tree.DTSQuery:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
@@ -252,6 +259,14 @@ func writeTextDatumNotNull(
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
 
+	case *tree.DRange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
+	case *tree.DMultirange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DArray:
 		// Arrays have custom formatting depending on their OID.
 		b.textFormatter.FormatNode(d)
@@ -733,6 +748,32 @@ func writeBinaryDatumNotNull(
 		b.putInt32(int32(len(v.EWKB())))
 		b.write(v.EWKB())
 
+	case *tree.DPGVector:
+		enc := vector.EncodePGBinary(nil, v.T)
+		b.putInt32(int32(len(enc)))
+		b.write(enc)
+
+	case *tree.DJsonpath:
+		s := v.Path.String()
+		b.putInt32(int32(len(s) + 1))
+		// Postgres version number, as of writing, `1` is the only valid value.
+		b.writeByte(1)
+		b.writeString(s)
+
+	case *tree.DRange:
+		writeBinaryRange(ctx, b, v, sessionLoc)
+
+	case *tree.DMultirange:
+		initialLen := b.Len()
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		b.putInt32(int32(len(v.Ranges)))
+		for _, r := range v.Ranges {
+			writeBinaryRange(ctx, b, r, sessionLoc)
+		}
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
 	case *tree.DTSQuery:
 		initialLen := b.Len()
 		// Reserve bytes for writing length later.
@@ -803,6 +844,50 @@ func writeBinaryDatumNotNull(
 	}
 }
 
+// Flags of the Postgres binary format of ranges.
+const (
+	rangeEmpty          byte = 0x01
+	rangeLowerInclusive byte = 0x02
+	rangeUpperInclusive byte = 0x04
+	rangeLowerInfinite  byte = 0x08
+	rangeUpperInfinite  byte = 0x10
+)
+
+// writeBinaryRange writes a range in the Postgres binary format: a flags byte
+// followed by the length-prefixed binary encoding of every finite bound.
+func writeBinaryRange(
+	ctx context.Context, b *writeBuffer, r *tree.DRange, sessionLoc *time.Location,
+) {
+	initialLen := b.Len()
+	// Reserve bytes for writing length later.
+	b.putInt32(int32(0))
+	var flags byte
+	if r.Empty {
+		flags = rangeEmpty
+	} else {
+		if r.Lower.IsInfinite() {
+			flags |= rangeLowerInfinite
+		} else if r.Lower.Inclusive {
+			flags |= rangeLowerInclusive
+		}
+		if r.Upper.IsInfinite() {
+			flags |= rangeUpperInfinite
+		} else if r.Upper.Inclusive {
+			flags |= rangeUpperInclusive
+		}
+	}
+	b.writeByte(flags)
+	elemTyp := r.ResolvedType().RangeContents()
+	if flags&(rangeEmpty|rangeLowerInfinite) == 0 {
+		b.writeBinaryDatum(ctx, r.Lower.Val, sessionLoc, elemTyp)
+	}
+	if flags&(rangeEmpty|rangeUpperInfinite) == 0 {
+		b.writeBinaryDatum(ctx, r.Upper.Val, sessionLoc, elemTyp)
+	}
+	lengthToWrite := b.Len() - (initialLen + 4)
+	b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+}
+
 // writeBinaryColumnarElement is the same as writeBinaryDatum where the datum is
 // represented in a columnar element (at position rowIdx in the vector at
 // position vecIdx in vecs).
@@ -155,11 +155,13 @@ var _ planNode = &alterTableOwnerNode{}
 var _ planNode = &alterTableSetSchemaNode{}
 var _ planNode = &alterTypeNode{}
 var _ planNode = &bufferNode{}
+var _ planNode = &callNode{}
 var _ planNode = &cancelQueriesNode{}
 var _ planNode = &cancelSessionsNode{}
 var _ planNode = &changeDescriptorBackedPrivilegesNode{}
 var _ planNode = &completionsNode{}
 var _ planNode = &createDatabaseNode{}
+var _ planNode = &createAggregateNode{}
 var _ planNode = &createFunctionNode{}
 var _ planNode = &createIndexNode{}
 var _ planNode = &createSequenceNode{}
@@ -235,11 +237,13 @@ var _ planNodeFastPath = &setZoneConfigNode{}
 var _ planNodeFastPath = &controlJobsNode{}
 var _ planNodeFastPath = &controlSchedulesNode{}
 
+var _ planNodeReadingOwnWrites = &alterDomainNode{}
 var _ planNodeReadingOwnWrites = &alterIndexNode{}
 var _ planNodeReadingOwnWrites = &alterSchemaNode{}
 var _ planNodeReadingOwnWrites = &alterSequenceNode{}
 var _ planNodeReadingOwnWrites = &alterTableNode{}
 var _ planNodeReadingOwnWrites = &alterTypeNode{}
+var _ planNodeReadingOwnWrites = &createAggregateNode{}
 var _ planNodeReadingOwnWrites = &createFunctionNode{}
 var _ planNodeReadingOwnWrites = &createIndexNode{}
 var _ planNodeReadingOwnWrites = &createSequenceNode{}
@@ -441,6 +445,8 @@ type cascadeMetadata struct {
 	// plan for the cascade. This plan is not populated upfront; it is created
 	// only when it needs to run, after the main query (and previous cascades).
 	plan planMaybePhysical
+	// subqueryPlans are the subqueries of the cascade, which are run before it.
+	subqueryPlans []subquery
 }
 
 // checkPlan is a query tree that is executed after the main one. It can only
@@ -457,6 +463,9 @@ func (p *planComponents) close(ctx context.Context) {
 	}
 	for i := range p.cascades {
 		p.cascades[i].plan.Close(ctx)
+		for j := range p.cascades[i].subqueryPlans {
+			p.cascades[i].subqueryPlans[j].plan.Close(ctx)
+		}
 	}
 	for i := range p.checkPlans {
 		p.checkPlans[i].plan.Close(ctx)
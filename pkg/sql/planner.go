@@ -210,6 +210,16 @@ type planner struct {
 
 	createdSequences createdSequences
 
+	// deferredConstraints tracks the deferrable constraints of the current
+	// transaction. It is nil when the planner is not used by a connExecutor
+	// serving a client session, in which case no constraint is deferred.
+	deferredConstraints *deferredConstraintState
+
+	// notifications tracks the LISTEN, UNLISTEN and NOTIFY statements of the
+	// current transaction. It is nil when the planner is not used by a
+	// connExecutor serving a client session.
+	notifications *notificationTxnState
+
 	// autoCommit indicates whether the plan is allowed (but not required) to
 	// commit the transaction along with other KV operations. Committing the txn
 	// might be beneficial because it may enable the 1PC optimization. Note that
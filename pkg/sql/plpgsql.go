@@ -0,0 +1,959 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// plpgsqlVar is a variable of a PL/pgSQL routine: a parameter, a variable
+// declared in a block, a loop variable, or one of the special variables FOUND,
+// SQLSTATE and SQLERRM.
+type plpgsqlVar struct {
+	// ord is the ordinal of the variable in the Vars of the body.
+	ord     int
+	name    string
+	typ     *types.T
+	val     tree.Datum
+	notNull bool
+}
+
+// plpgsqlFlow describes how control leaves a PL/pgSQL statement.
+type plpgsqlFlow int
+
+const (
+	// plpgsqlNext continues with the next statement.
+	plpgsqlNext plpgsqlFlow = iota
+	// plpgsqlExit exits the innermost loop, or the block or loop with the
+	// pending label.
+	plpgsqlExit
+	// plpgsqlContinue starts the next iteration of the innermost loop, or of
+	// the loop with the pending label.
+	plpgsqlContinue
+	// plpgsqlReturn returns from the routine.
+	plpgsqlReturn
+)
+
+// plpgsqlInterpreter executes the control flow of the body of a PL/pgSQL
+// routine. The expressions and SQL statements embedded in the body were built
+// by the optimizer along with the invoking statement. They are planned with the
+// current values of the variables and run like the statements of a SQL
+// routine when they are reached.
+type plpgsqlInterpreter struct {
+	p       *planner
+	routine *tree.RoutineExpr
+	body    *memo.PLpgSQLBody
+	ef      *execFactory
+
+	// params are the parameters of the routine, in order.
+	params []*plpgsqlVar
+	// scopes is the stack of variable scopes, innermost last.
+	scopes [][]*plpgsqlVar
+	// found is the FOUND variable.
+	found *plpgsqlVar
+
+	// handling is the stack of errors being handled by the exception handlers
+	// that are currently executing. A RAISE statement without arguments
+	// re-raises the innermost one.
+	handling []error
+
+	// label is the label targeted by a pending EXIT or CONTINUE. It is empty
+	// if the statement targets the innermost loop.
+	label string
+	// result is the value returned by RETURN.
+	result tree.Datum
+}
+
+// evalPLpgSQLRoutine interprets the body of a PL/pgSQL routine with the given
+// arguments and returns its result.
+func (p *planner) evalPLpgSQLRoutine(
+	ctx context.Context, expr *tree.RoutineExpr, args tree.Datums,
+) (tree.Datum, error) {
+	in := plpgsqlInterpreter{
+		p:       p,
+		routine: expr,
+		body:    expr.PLpgSQLBody.(*memo.PLpgSQLBody),
+		ef:      newExecFactory(ctx, p),
+	}
+	in.params = make([]*plpgsqlVar, len(args))
+	for i := range args {
+		in.params[i] = &plpgsqlVar{
+			ord: i, name: expr.ParamNames[i], typ: expr.ParamTypes[i], val: args[i],
+		}
+	}
+	in.found = &plpgsqlVar{
+		ord: len(args), name: "found", typ: types.Bool, val: tree.DBoolFalse, notNull: true,
+	}
+	in.scopes = append(in.scopes, append([]*plpgsqlVar{in.found}, in.params...))
+
+	flow, err := in.execBlock(ctx, in.body.Block)
+	if err != nil {
+		return nil, err
+	}
+	// A procedure with OUT or INOUT parameters returns their values, whether it
+	// finishes with RETURN or by reaching the end of its body.
+	if len(in.body.OutParams) > 0 {
+		row := make(tree.Datums, len(in.body.OutParams))
+		for i, ord := range in.body.OutParams {
+			row[i] = in.params[ord].val
+		}
+		return tree.NewDTuple(expr.ResolvedType(), row...), nil
+	}
+	if flow == plpgsqlReturn {
+		if in.result == nil {
+			return tree.DVoidDatum, nil
+		}
+		return in.result, nil
+	}
+	if expr.Trigger {
+		return nil, pgerror.New(pgcode.RoutineExceptionFunctionExecutedNoReturnStatement,
+			"control reached end of trigger procedure without RETURN")
+	}
+	if expr.ResolvedType().Family() == types.VoidFamily {
+		return tree.DVoidDatum, nil
+	}
+	return nil, pgerror.New(pgcode.RoutineExceptionFunctionExecutedNoReturnStatement,
+		"control reached end of function without RETURN")
+}
+
+func (in *plpgsqlInterpreter) execBlock(
+	ctx context.Context, block *plpgsqltree.Block,
+) (plpgsqlFlow, error) {
+	// Declarations are evaluated each time the block is entered.
+	var scope []*plpgsqlVar
+	in.scopes = append(in.scopes, scope)
+	defer func() { in.scopes = in.scopes[:len(in.scopes)-1] }()
+	for i := range block.Decls {
+		decl := &block.Decls[i]
+		typ, err := tree.ResolveType(ctx, decl.Typ, in.p)
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		v := &plpgsqlVar{
+			ord:     in.body.VarOrds[decl],
+			name:    string(decl.Var),
+			typ:     typ,
+			val:     tree.DNull,
+			notNull: decl.NotNull,
+		}
+		if decl.Expr != nil {
+			if v.val, err = in.evalExpr(ctx, decl, 0 /* ord */, typ); err != nil {
+				return plpgsqlNext, err
+			}
+		}
+		if err := v.checkNotNull(); err != nil {
+			return plpgsqlNext, err
+		}
+		in.scopes[len(in.scopes)-1] = append(in.scopes[len(in.scopes)-1], v)
+	}
+
+	var flow plpgsqlFlow
+	var err error
+	if len(block.Exceptions) == 0 {
+		flow, err = in.execStmts(ctx, block.Body)
+	} else {
+		flow, err = in.execBlockWithExceptions(ctx, block)
+	}
+	if err != nil {
+		return plpgsqlNext, err
+	}
+	if flow == plpgsqlExit && in.label != "" && in.label == block.Label {
+		in.label = ""
+		return plpgsqlNext, nil
+	}
+	return flow, nil
+}
+
+// execBlockWithExceptions executes the body of a block that has exception
+// handlers. The body runs under a savepoint. If it raises an error that is
+// matched by a handler, the effects of the body are rolled back and the
+// handler runs instead.
+func (in *plpgsqlInterpreter) execBlockWithExceptions(
+	ctx context.Context, block *plpgsqltree.Block,
+) (plpgsqlFlow, error) {
+	txn := in.p.Txn()
+	savepoint, err := txn.CreateSavepoint(ctx)
+	if err != nil {
+		return plpgsqlNext, err
+	}
+	flow, bodyErr := in.execStmts(ctx, block.Body)
+	if bodyErr == nil {
+		return flow, txn.ReleaseSavepoint(ctx, savepoint)
+	}
+	// Retryable errors must be handled by restarting the transaction, so they
+	// cannot be caught.
+	if errIsRetriable(bodyErr) || ctx.Err() != nil {
+		return plpgsqlNext, bodyErr
+	}
+	handler := matchPLpgSQLException(block.Exceptions, bodyErr)
+	if handler == nil {
+		return plpgsqlNext, bodyErr
+	}
+	if err := txn.RollbackToSavepoint(ctx, savepoint); err != nil {
+		return plpgsqlNext, errors.CombineErrors(bodyErr, err)
+	}
+	if err := txn.ReleaseSavepoint(ctx, savepoint); err != nil {
+		return plpgsqlNext, err
+	}
+
+	pgErr := pgerror.Flatten(bodyErr)
+	ord := in.body.VarOrds[handler]
+	in.scopes = append(in.scopes, []*plpgsqlVar{
+		{ord: ord, name: "sqlstate", typ: types.String, val: tree.NewDString(pgErr.Code)},
+		{ord: ord + 1, name: "sqlerrm", typ: types.String, val: tree.NewDString(pgErr.Message)},
+	})
+	in.handling = append(in.handling, bodyErr)
+	defer func() {
+		in.handling = in.handling[:len(in.handling)-1]
+		in.scopes = in.scopes[:len(in.scopes)-1]
+	}()
+	return in.execStmts(ctx, handler.Body)
+}
+
+// matchPLpgSQLException returns the first handler whose conditions match the
+// given error, or nil if there is none.
+func matchPLpgSQLException(
+	handlers []plpgsqltree.Exception, err error,
+) *plpgsqltree.Exception {
+	code := pgerror.GetPGCode(err)
+	for i := range handlers {
+		for _, cond := range handlers[i].Conditions {
+			if cond.SQLErrName == "others" {
+				// OTHERS does not match cancellation or failed assertions, which
+				// can only be caught by name.
+				if code != pgcode.QueryCanceled && code != pgcode.AssertFailure {
+					return &handlers[i]
+				}
+				continue
+			}
+			condCode := pgcode.MakeCode(cond.SQLErrState)
+			if cond.SQLErrName != "" {
+				condCode, _ = pgcode.FromConditionName(cond.SQLErrName)
+			}
+			if plpgsqlCodeMatches(condCode, code) {
+				return &handlers[i]
+			}
+		}
+	}
+	return nil
+}
+
+// plpgsqlCodeMatches returns true if the error code matches the code of a
+// condition. A condition whose code ends in "000" matches the entire class of
+// errors.
+func plpgsqlCodeMatches(cond, code pgcode.Code) bool {
+	c := cond.String()
+	if strings.HasSuffix(c, "000") {
+		return strings.HasPrefix(code.String(), c[:2])
+	}
+	return cond == code
+}
+
+func (in *plpgsqlInterpreter) execStmts(
+	ctx context.Context, stmts []plpgsqltree.Statement,
+) (plpgsqlFlow, error) {
+	for _, stmt := range stmts {
+		flow, err := in.execStmt(ctx, stmt)
+		if err != nil || flow != plpgsqlNext {
+			return flow, err
+		}
+	}
+	return plpgsqlNext, nil
+}
+
+func (in *plpgsqlInterpreter) execStmt(
+	ctx context.Context, stmt plpgsqltree.Statement,
+) (plpgsqlFlow, error) {
+	switch t := stmt.(type) {
+	case *plpgsqltree.Block:
+		return in.execBlock(ctx, t)
+
+	case *plpgsqltree.Assignment:
+		v := in.lookup(string(t.Var))
+		if t.Field != "" {
+			return plpgsqlNext, in.assignField(ctx, v, string(t.Field), t)
+		}
+		val, err := in.evalExpr(ctx, t, 0 /* ord */, v.typ)
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		return plpgsqlNext, v.assign(val)
+
+	case *plpgsqltree.If:
+		cond, err := in.evalBool(ctx, t)
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		if cond {
+			return in.execStmts(ctx, t.ThenBody)
+		}
+		for i := range t.ElseIfList {
+			cond, err := in.evalBool(ctx, &t.ElseIfList[i])
+			if err != nil {
+				return plpgsqlNext, err
+			}
+			if cond {
+				return in.execStmts(ctx, t.ElseIfList[i].Body)
+			}
+		}
+		return in.execStmts(ctx, t.ElseBody)
+
+	case *plpgsqltree.Loop:
+		return in.execLoop(ctx, t.Label, func() (bool, error) { return true, nil }, t.Body)
+
+	case *plpgsqltree.While:
+		return in.execLoop(ctx, t.Label, func() (bool, error) {
+			return in.evalBool(ctx, t)
+		}, t.Body)
+
+	case *plpgsqltree.ForInt:
+		return in.execForInt(ctx, t)
+
+	case *plpgsqltree.ForQuery:
+		return in.execForQuery(ctx, t)
+
+	case *plpgsqltree.Exit:
+		return in.execExitOrContinue(ctx, t, plpgsqlExit, t.Label, t.Condition != nil)
+
+	case *plpgsqltree.Continue:
+		return in.execExitOrContinue(ctx, t, plpgsqlContinue, t.Label, t.Condition != nil)
+
+	case *plpgsqltree.Return:
+		if t.Expr != nil {
+			typ := in.routine.ResolvedType()
+			if in.routine.Trigger && typ.Family() == types.VoidFamily {
+				// The value returned by a statement-level trigger is ignored.
+				if _, err := in.evalExpr(ctx, t, 0 /* ord */, nil /* typ */); err != nil {
+					return plpgsqlNext, err
+				}
+				return plpgsqlReturn, nil
+			}
+			var err error
+			if in.result, err = in.evalExpr(ctx, t, 0 /* ord */, typ); err != nil {
+				return plpgsqlNext, err
+			}
+		}
+		return plpgsqlReturn, nil
+
+	case *plpgsqltree.Raise:
+		return plpgsqlNext, in.execRaise(ctx, t)
+
+	case *plpgsqltree.Perform:
+		n, err := in.exec(ctx, t, t.Query)
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		in.found.val = tree.MakeDBool(tree.DBool(n > 0))
+		return plpgsqlNext, nil
+
+	case *plpgsqltree.ExecSQL:
+		return plpgsqlNext, in.execSQL(ctx, t)
+
+	case *plpgsqltree.Null:
+		return plpgsqlNext, nil
+
+	default:
+		return plpgsqlNext, errors.AssertionFailedf("unexpected PL/pgSQL statement %T", stmt)
+	}
+}
+
+// execLoop runs body for as long as cond returns true, or until the loop is
+// exited.
+func (in *plpgsqlInterpreter) execLoop(
+	ctx context.Context, label string, cond func() (bool, error), body []plpgsqltree.Statement,
+) (plpgsqlFlow, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return plpgsqlNext, err
+		}
+		ok, err := cond()
+		if err != nil || !ok {
+			return plpgsqlNext, err
+		}
+		flow, err := in.execStmts(ctx, body)
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		if done, flow := in.loopFlow(label, flow); done {
+			return flow, nil
+		}
+	}
+}
+
+// loopFlow determines whether a loop with the given label must stop after an
+// iteration that ended with the given flow, and the flow with which the loop
+// statement itself ends.
+func (in *plpgsqlInterpreter) loopFlow(label string, flow plpgsqlFlow) (bool, plpgsqlFlow) {
+	switch flow {
+	case plpgsqlExit, plpgsqlContinue:
+		if in.label != "" && in.label != label {
+			// The statement targets an enclosing block or loop.
+			return true, flow
+		}
+		in.label = ""
+		return flow == plpgsqlExit, plpgsqlNext
+	case plpgsqlReturn:
+		return true, flow
+	}
+	return false, plpgsqlNext
+}
+
+func (in *plpgsqlInterpreter) execForInt(
+	ctx context.Context, s *plpgsqltree.ForInt,
+) (plpgsqlFlow, error) {
+	lower, err := in.evalExpr(ctx, s, 0 /* ord */, types.Int)
+	if err != nil {
+		return plpgsqlNext, err
+	}
+	upper, err := in.evalExpr(ctx, s, 1 /* ord */, types.Int)
+	if err != nil {
+		return plpgsqlNext, err
+	}
+	if lower == tree.DNull {
+		return plpgsqlNext, pgerror.New(pgcode.NullValueNotAllowed,
+			"lower bound of FOR loop cannot be null")
+	}
+	if upper == tree.DNull {
+		return plpgsqlNext, pgerror.New(pgcode.NullValueNotAllowed,
+			"upper bound of FOR loop cannot be null")
+	}
+	step := tree.NewDInt(1)
+	if s.Step != nil {
+		d, err := in.evalExpr(ctx, s, 2 /* ord */, types.Int)
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		if d == tree.DNull {
+			return plpgsqlNext, pgerror.New(pgcode.NullValueNotAllowed,
+				"BY value of FOR loop cannot be null")
+		}
+		step = d.(*tree.DInt)
+		if *step <= 0 {
+			return plpgsqlNext, pgerror.New(pgcode.InvalidParameterValue,
+				"BY value of FOR loop must be greater than zero")
+		}
+	}
+
+	v := &plpgsqlVar{ord: in.body.VarOrds[s], name: string(s.Var), typ: types.Int}
+	in.scopes = append(in.scopes, []*plpgsqlVar{v})
+	defer func() { in.scopes = in.scopes[:len(in.scopes)-1] }()
+	i, end := int64(*lower.(*tree.DInt)), int64(*upper.(*tree.DInt))
+	found := false
+	for (!s.Reverse && i <= end) || (s.Reverse && i >= end) {
+		if err := ctx.Err(); err != nil {
+			return plpgsqlNext, err
+		}
+		found = true
+		v.val = tree.NewDInt(tree.DInt(i))
+		flow, err := in.execStmts(ctx, s.Body)
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		if done, flow := in.loopFlow(s.Label, flow); done {
+			in.found.val = tree.MakeDBool(tree.DBool(found))
+			return flow, nil
+		}
+		// Stop before the loop variable overflows, as Postgres does.
+		if s.Reverse {
+			if i < math.MinInt64+int64(*step) {
+				break
+			}
+			i -= int64(*step)
+		} else {
+			if i > math.MaxInt64-int64(*step) {
+				break
+			}
+			i += int64(*step)
+		}
+	}
+	in.found.val = tree.MakeDBool(tree.DBool(found))
+	return plpgsqlNext, nil
+}
+
+func (in *plpgsqlInterpreter) execForQuery(
+	ctx context.Context, s *plpgsqltree.ForQuery,
+) (plpgsqlFlow, error) {
+	rows, typs, err := in.query(ctx, s)
+	if err != nil {
+		return plpgsqlNext, err
+	}
+	defer rows.Close(ctx)
+	it := newRowContainerIterator(ctx, *rows, typs)
+	defer it.Close()
+	for {
+		if err := ctx.Err(); err != nil {
+			return plpgsqlNext, err
+		}
+		row, err := it.Next()
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		if row == nil {
+			break
+		}
+		if err := in.assignRow(ctx, s.Targets, row); err != nil {
+			return plpgsqlNext, err
+		}
+		flow, err := in.execStmts(ctx, s.Body)
+		if err != nil {
+			return plpgsqlNext, err
+		}
+		if done, flow := in.loopFlow(s.Label, flow); done {
+			in.found.val = tree.DBoolTrue
+			return flow, nil
+		}
+	}
+	in.found.val = tree.MakeDBool(tree.DBool(rows.Len() > 0))
+	return plpgsqlNext, nil
+}
+
+// execExitOrContinue executes an EXIT or CONTINUE statement, whose condition,
+// if it has one, is evaluated first.
+func (in *plpgsqlInterpreter) execExitOrContinue(
+	ctx context.Context, stmt plpgsqltree.Statement, flow plpgsqlFlow, label string, hasCond bool,
+) (plpgsqlFlow, error) {
+	if hasCond {
+		ok, err := in.evalBool(ctx, stmt)
+		if err != nil || !ok {
+			return plpgsqlNext, err
+		}
+	}
+	in.label = label
+	return flow, nil
+}
+
+// execSQL executes a SQL statement, storing the first row of its result in the
+// targets of its INTO clause, if any.
+func (in *plpgsqlInterpreter) execSQL(ctx context.Context, s *plpgsqltree.ExecSQL) error {
+	if s.SQL.StatementType() == tree.TypeTCL {
+		switch s.SQL.(type) {
+		case *tree.CommitTransaction, *tree.RollbackTransaction:
+			return pgerror.New(pgcode.InvalidTransactionTermination, "invalid transaction termination")
+		}
+		return pgerror.New(pgcode.FeatureNotSupported, "unsupported transaction command in PL/pgSQL")
+	}
+	if len(s.Into) == 0 {
+		if s.SQL.StatementReturnType() == tree.Rows {
+			err := pgerror.New(pgcode.Syntax, "query has no destination for result data")
+			if _, ok := s.SQL.(*tree.Select); ok {
+				err = errors.WithHint(err,
+					"If you want to discard the results of a SELECT, use PERFORM instead.")
+			}
+			return err
+		}
+		n, err := in.exec(ctx, s, s.SQL)
+		if err != nil {
+			return err
+		}
+		switch s.SQL.(type) {
+		case *tree.Insert, *tree.Update, *tree.Delete:
+			in.found.val = tree.MakeDBool(tree.DBool(n > 0))
+		}
+		return nil
+	}
+
+	// Only the first row of the result is stored.
+	var row tree.Datums
+	n := 0
+	w := NewCallbackResultWriter(func(ctx context.Context, r tree.Datums) error {
+		if n == 0 {
+			row = append(tree.Datums(nil), r...)
+		}
+		n++
+		return nil
+	})
+	if err := in.run(ctx, s, 0 /* ord */, tree.Rows, w); err != nil {
+		return err
+	}
+	if s.Strict {
+		switch n {
+		case 0:
+			return pgerror.New(pgcode.NoDataFound, "query returned no rows")
+		case 1:
+		default:
+			return pgerror.New(pgcode.TooManyRows, "query returned more than one row")
+		}
+	}
+	in.found.val = tree.MakeDBool(tree.DBool(n > 0))
+	return in.assignRow(ctx, s.Into, row)
+}
+
+// assignRow assigns the columns of a row to the given targets. A single target
+// with a tuple type receives the entire row. If row is nil, the targets are
+// set to NULL.
+func (in *plpgsqlInterpreter) assignRow(
+	ctx context.Context, targets tree.NameList, row tree.Datums,
+) error {
+	if len(targets) == 1 {
+		if v := in.lookup(string(targets[0])); v.typ.Family() == types.TupleFamily && len(row) > 0 &&
+			(len(row) > 1 || row[0].ResolvedType().Family() != types.TupleFamily) {
+			typs := make([]*types.T, len(row))
+			for i := range row {
+				typs[i] = row[i].ResolvedType()
+			}
+			row = tree.Datums{tree.NewDTuple(types.MakeTuple(typs), row...)}
+		}
+	}
+	for i, target := range targets {
+		v := in.lookup(string(target))
+		val := tree.Datum(tree.DNull)
+		if i < len(row) {
+			var err error
+			if val, err = in.coerce(ctx, row[i], v.typ); err != nil {
+				return err
+			}
+		}
+		if err := v.assign(val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execRaise reports a message or raises an error.
+func (in *plpgsqlInterpreter) execRaise(ctx context.Context, s *plpgsqltree.Raise) error {
+	if s.Level == "" && s.Message == "" && s.CodeName == "" && s.Code == "" && len(s.Options) == 0 {
+		if len(in.handling) == 0 {
+			return pgerror.New(pgcode.StackedDiagnosticsAccessedWithoutActiveHandler,
+				"RAISE without parameters cannot be used outside an exception handler")
+		}
+		return in.handling[len(in.handling)-1]
+	}
+
+	var msg, detail, hint string
+	code := pgcode.RaiseException
+	switch {
+	case s.CodeName != "":
+		code, _ = pgcode.FromConditionName(s.CodeName)
+		msg = s.CodeName
+	case s.Code != "":
+		code = pgcode.MakeCode(s.Code)
+		msg = s.Code
+	default:
+		var b strings.Builder
+		param := 0
+		for i := 0; i < len(s.Message); i++ {
+			c := s.Message[i]
+			if c != '%' {
+				b.WriteByte(c)
+				continue
+			}
+			if i+1 < len(s.Message) && s.Message[i+1] == '%' {
+				b.WriteByte('%')
+				i++
+				continue
+			}
+			d, err := in.evalExpr(ctx, s, param, nil /* typ */)
+			if err != nil {
+				return err
+			}
+			param++
+			if d == tree.DNull {
+				b.WriteString("<NULL>")
+			} else {
+				b.WriteString(tree.AsStringWithFlags(d, tree.FmtPgwireText))
+			}
+		}
+		msg = b.String()
+	}
+
+	for i := range s.Options {
+		opt := &s.Options[i]
+		d, err := in.evalExpr(ctx, opt, 0 /* ord */, types.String)
+		if err != nil {
+			return err
+		}
+		if d == tree.DNull {
+			return pgerror.Newf(pgcode.NullValueNotAllowed, "RAISE statement option cannot be null")
+		}
+		val := string(tree.MustBeDString(d))
+		switch opt.Name {
+		case "message":
+			if s.Message != "" {
+				return pgerror.New(pgcode.Syntax, "RAISE option already specified: MESSAGE")
+			}
+			msg = val
+		case "detail":
+			detail = val
+		case "hint":
+			hint = val
+		case "errcode":
+			if c, ok := pgcode.FromConditionName(val); ok {
+				code = c
+			} else if len(val) == 5 {
+				code = pgcode.MakeCode(val)
+			} else {
+				return pgerror.Newf(pgcode.UndefinedObject, "unrecognized exception condition %q", val)
+			}
+		}
+	}
+
+	switch s.Level {
+	case "", "exception":
+		err := pgerror.New(code, msg)
+		if detail != "" {
+			err = errors.WithDetail(err, detail)
+		}
+		if hint != "" {
+			err = errors.WithHint(err, hint)
+		}
+		return err
+	default:
+		severity := strings.ToUpper(s.Level)
+		if s.Level == "debug" {
+			severity = "DEBUG1"
+		}
+		notice := pgnotice.NewWithSeverityf(severity, "%s", msg)
+		if detail != "" {
+			notice = pgnotice.Notice(errors.WithDetail(notice, detail))
+		}
+		if hint != "" {
+			notice = pgnotice.Notice(errors.WithHint(notice, hint))
+		}
+		in.p.BufferClientNotice(ctx, notice)
+		return nil
+	}
+}
+
+// assignField evaluates the value of an assignment and assigns the result to a
+// field of a variable of a composite type, like NEW.x in a trigger function.
+func (in *plpgsqlInterpreter) assignField(
+	ctx context.Context, v *plpgsqlVar, field string, stmt *plpgsqltree.Assignment,
+) error {
+	idx, ok := tupleFieldOrdinal(v.typ, field)
+	if !ok {
+		return pgerror.Newf(pgcode.UndefinedColumn,
+			"record \"%s\" has no field \"%s\"", v.name, field)
+	}
+	val, err := in.evalExpr(ctx, stmt, 0 /* ord */, v.typ.TupleContents()[idx])
+	if err != nil {
+		return err
+	}
+	fields := make(tree.Datums, len(v.typ.TupleContents()))
+	if tup, ok := v.val.(*tree.DTuple); ok {
+		copy(fields, tup.D)
+	} else {
+		for i := range fields {
+			fields[i] = tree.DNull
+		}
+	}
+	fields[idx] = val
+	return v.assign(tree.NewDTuple(v.typ, fields...))
+}
+
+// tupleFieldOrdinal returns the ordinal of the field of a labeled tuple type
+// with the given name.
+func tupleFieldOrdinal(typ *types.T, field string) (int, bool) {
+	if typ.Family() != types.TupleFamily {
+		return 0, false
+	}
+	for i, label := range typ.TupleLabels() {
+		if label == field {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// lookup returns the variable with the given name that is visible in the
+// current scope, or nil if there is none.
+func (in *plpgsqlInterpreter) lookup(name string) *plpgsqlVar {
+	for i := len(in.scopes) - 1; i >= 0; i-- {
+		scope := in.scopes[i]
+		for j := len(scope) - 1; j >= 0; j-- {
+			if scope[j].name == name {
+				return scope[j]
+			}
+		}
+	}
+	return nil
+}
+
+// run plans the statement built for the expression or SQL statement with the
+// given ordinal in stmt, using the current values of the variables, and runs
+// it. The results of the statement, of the given type, are written to w.
+func (in *plpgsqlInterpreter) run(
+	ctx context.Context,
+	stmt interface{},
+	ord int,
+	stmtType tree.StatementReturnType,
+	w rowResultWriter,
+) error {
+	return in.runWithWriter(ctx, stmt, ord, stmtType, func(colinfo.ResultColumns) rowResultWriter {
+		return w
+	})
+}
+
+// runWithWriter is like run, but the results are written to the writer
+// returned by newWriter, which is passed the result columns of the statement.
+func (in *plpgsqlInterpreter) runWithWriter(
+	ctx context.Context,
+	stmt interface{},
+	ord int,
+	stmtType tree.StatementReturnType,
+	newWriter func(colinfo.ResultColumns) rowResultWriter,
+) error {
+	idx, ok := in.body.Stmts[memo.PLpgSQLStmtKey{Stmt: stmt, Ord: ord}]
+	if !ok {
+		return errors.AssertionFailedf("no statement was built for %T %d", stmt, ord)
+	}
+	// The arguments of the statement are the values of the variables, which
+	// are NULL for the variables that are not in scope.
+	args := make(tree.Datums, len(in.body.Vars))
+	for i := range args {
+		args[i] = tree.DNull
+	}
+	for _, scope := range in.scopes {
+		for _, v := range scope {
+			args[v.ord] = v.val
+		}
+	}
+	return in.p.runRoutineStmt(ctx, in.routine, in.ef, idx, args, stmtType, newWriter)
+}
+
+// evalExpr evaluates the expression with the given ordinal in stmt and coerces
+// the result to typ, if it is not nil.
+func (in *plpgsqlInterpreter) evalExpr(
+	ctx context.Context, stmt interface{}, ord int, typ *types.T,
+) (tree.Datum, error) {
+	var d tree.Datum
+	w := NewCallbackResultWriter(func(ctx context.Context, row tree.Datums) error {
+		d = row[0]
+		return nil
+	})
+	if err := in.run(ctx, stmt, ord, tree.Rows, w); err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.AssertionFailedf("expression returned no rows")
+	}
+	if typ == nil {
+		return d, nil
+	}
+	return in.coerce(ctx, d, typ)
+}
+
+func (in *plpgsqlInterpreter) evalBool(ctx context.Context, stmt interface{}) (bool, error) {
+	d, err := in.evalExpr(ctx, stmt, 0 /* ord */, types.Bool)
+	if err != nil {
+		return false, err
+	}
+	return d == tree.DBoolTrue, nil
+}
+
+// query runs the query of stmt and returns a container with the rows of its
+// result, along with their types. The caller must close the container.
+func (in *plpgsqlInterpreter) query(
+	ctx context.Context, stmt interface{},
+) (*rowContainerHelper, []*types.T, error) {
+	var rows rowContainerHelper
+	var typs []*types.T
+	err := in.runWithWriter(ctx, stmt, 0 /* ord */, tree.Rows,
+		func(cols colinfo.ResultColumns) rowResultWriter {
+			typs = getTypesFromResultColumns(cols)
+			rows.Init(ctx, typs, in.p.ExtendedEvalContext(), "plpgsql" /* opName */)
+			return NewRowResultWriter(&rows)
+		},
+	)
+	if err != nil {
+		rows.Close(ctx)
+		return nil, nil, err
+	}
+	return &rows, typs, nil
+}
+
+// exec runs the SQL statement of stmt and returns the number of rows it
+// affected or returned.
+func (in *plpgsqlInterpreter) exec(
+	ctx context.Context, stmt interface{}, sql tree.Statement,
+) (int, error) {
+	n := 0
+	w := NewCallbackResultWriter(func(ctx context.Context, row tree.Datums) error {
+		n++
+		return nil
+	})
+	stmtType := sql.StatementReturnType()
+	if err := in.run(ctx, stmt, 0 /* ord */, stmtType, w); err != nil {
+		return 0, err
+	}
+	if stmtType == tree.RowsAffected {
+		return w.rowsAffected, nil
+	}
+	return n, nil
+}
+
+// coerce converts a value to the type of a variable. Like in Postgres, values
+// that cannot be converted with an assignment cast are converted through their
+// text representation.
+func (in *plpgsqlInterpreter) coerce(
+	ctx context.Context, d tree.Datum, typ *types.T,
+) (tree.Datum, error) {
+	if d == tree.DNull || d.ResolvedType().Identical(typ) ||
+		(typ.Family() == types.TupleFamily && typ.Identical(types.AnyTuple)) {
+		return d, nil
+	}
+	if tup, ok := d.(*tree.DTuple); ok && typ.Family() == types.TupleFamily {
+		// Coerce each field of the row, like the row returned by a trigger
+		// function.
+		contents := typ.TupleContents()
+		if len(tup.D) != len(contents) {
+			return nil, pgerror.New(pgcode.DatatypeMismatch,
+				"returned row structure does not match the structure of the triggering table")
+		}
+		fields := make(tree.Datums, len(contents))
+		for i := range fields {
+			var err error
+			if fields[i], err = in.coerce(ctx, tup.D[i], contents[i]); err != nil {
+				return nil, err
+			}
+		}
+		return tree.NewDTuple(typ, fields...), nil
+	}
+	evalCtx := in.p.EvalContext()
+	if cast.ValidCast(d.ResolvedType(), typ, cast.ContextAssignment) {
+		return eval.PerformAssignmentCast(ctx, evalCtx, d, typ)
+	}
+	s := tree.NewDString(tree.AsStringWithFlags(d, tree.FmtPgwireText))
+	return eval.PerformCast(ctx, evalCtx, s, typ)
+}
+
+// assign sets the value of the variable.
+func (v *plpgsqlVar) assign(val tree.Datum) error {
+	prev := v.val
+	v.val = val
+	if err := v.checkNotNull(); err != nil {
+		v.val = prev
+		return err
+	}
+	return nil
+}
+
+func (v *plpgsqlVar) checkNotNull() error {
+	if v.notNull && v.val == tree.DNull {
+		return pgerror.Newf(pgcode.NullValueNotAllowed,
+			"null value cannot be assigned to variable %q declared NOT NULL", v.name)
+	}
+	return nil
+}
@@ -0,0 +1,872 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package parser contains a parser for the PL/pgSQL procedural language.
+//
+// The parser is a hand-written recursive descent parser over the tokens
+// produced by the SQL scanner. PL/pgSQL statements embed SQL expressions and
+// queries; those are delimited by the PL/pgSQL parser and handed to the SQL
+// parser, so the resulting AST contains regular tree.Expr and tree.Statement
+// nodes.
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	sqlparser "github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/scanner"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Parse parses the body of a PL/pgSQL function or procedure. The body must
+// consist of a single block, optionally followed by a semicolon.
+func Parse(sql string) (*plpgsqltree.Block, error) {
+	p := parser{sql: sql, toks: scanner.Inspect(sql)}
+	if last := p.toks[len(p.toks)-1]; last.ID == lexbase.ERROR || last.ID < 0 {
+		msg := last.Str
+		if last.ID < 0 {
+			msg = "unterminated token"
+		}
+		return nil, pgerror.Newf(pgcode.Syntax, "at or near %q: syntax error: %s",
+			sql[last.Start:], msg)
+	}
+	label, err := p.parseLabel()
+	if err != nil {
+		return nil, err
+	}
+	if !p.peekWord("declare") && !p.peekWord("begin") {
+		return nil, p.errorf("expected DECLARE or BEGIN")
+	}
+	block, err := p.parseBlock(label)
+	if err != nil {
+		return nil, err
+	}
+	p.accept(';')
+	if !p.atEOF() {
+		return nil, p.errorf("unexpected input after the end of the function body")
+	}
+	return block, nil
+}
+
+// raiseLevels are the severities accepted by RAISE.
+var raiseLevels = map[string]struct{}{
+	"debug":     {},
+	"log":       {},
+	"info":      {},
+	"notice":    {},
+	"warning":   {},
+	"exception": {},
+}
+
+// raiseOptions are the options accepted in the USING clause of RAISE.
+var raiseOptions = map[string]struct{}{
+	"message":    {},
+	"detail":     {},
+	"hint":       {},
+	"errcode":    {},
+	"column":     {},
+	"constraint": {},
+	"datatype":   {},
+	"table":      {},
+	"schema":     {},
+}
+
+// intoPrecedingWords are the words after which INTO is part of the SQL
+// statement itself, rather than a PL/pgSQL INTO clause.
+var intoPrecedingWords = map[string]struct{}{
+	"insert": {},
+	"upsert": {},
+	"merge":  {},
+	"import": {},
+}
+
+type parser struct {
+	sql  string
+	toks []scanner.InspectToken
+	pos  int
+}
+
+func (p *parser) peek() scanner.InspectToken {
+	return p.toks[p.pos]
+}
+
+func (p *parser) peekN(n int) scanner.InspectToken {
+	if p.pos+n >= len(p.toks) {
+		return p.toks[len(p.toks)-1]
+	}
+	return p.toks[p.pos+n]
+}
+
+func (p *parser) next() scanner.InspectToken {
+	tok := p.toks[p.pos]
+	if !p.atEOF() {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) atEOF() bool {
+	return p.toks[p.pos].ID == 0
+}
+
+// isWord returns true if tok is the given unquoted identifier or keyword.
+// Word must be lower case.
+func isWord(tok scanner.InspectToken, word string) bool {
+	return !tok.Quoted && tok.Str == word && tok.ID == lexbase.GetKeywordID(word)
+}
+
+// isIdent returns true if tok can be used as a PL/pgSQL identifier: either a
+// SQL identifier or a keyword that is not fully reserved.
+func isIdent(tok scanner.InspectToken) bool {
+	if tok.ID == lexbase.IDENT {
+		return true
+	}
+	if tok.Quoted || tok.ID != lexbase.GetKeywordID(tok.Str) {
+		return false
+	}
+	cat, ok := lexbase.KeywordsCategories[tok.Str]
+	return ok && cat != "R"
+}
+
+func (p *parser) peekWord(word string) bool {
+	return isWord(p.peek(), word)
+}
+
+func (p *parser) accept(id int32) bool {
+	if p.peek().ID == id {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) acceptWord(word string) bool {
+	if p.peekWord(word) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(id int32) error {
+	if !p.accept(id) {
+		return p.errorf("expected %q", string(rune(id)))
+	}
+	return nil
+}
+
+func (p *parser) expectWord(word string) error {
+	if !p.acceptWord(word) {
+		return p.errorf("expected %s", strings.ToUpper(word))
+	}
+	return nil
+}
+
+// peekAssign returns true if the next tokens are an assignment operator,
+// either := or =.
+func (p *parser) peekAssign() bool {
+	if p.peek().ID == '=' {
+		return true
+	}
+	return p.peek().ID == ':' && p.peekN(1).ID == '=' && p.peekN(1).Start == p.peek().End
+}
+
+func (p *parser) acceptAssign() bool {
+	if !p.peekAssign() {
+		return false
+	}
+	if p.next().ID == ':' {
+		p.next()
+	}
+	return true
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	tok := p.peek()
+	near := "EOF"
+	if !p.atEOF() {
+		near = strings.TrimSpace(p.sql[tok.Start:tok.End])
+	}
+	return pgerror.Newf(pgcode.Syntax, "at or near %q: syntax error: %s",
+		near, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) parseIdent() (tree.Name, error) {
+	tok := p.peek()
+	if !isIdent(tok) {
+		return "", p.errorf("expected identifier")
+	}
+	p.next()
+	return tree.Name(tok.Str), nil
+}
+
+// parseLabel parses an optional <<label>>.
+func (p *parser) parseLabel() (string, error) {
+	if !p.accept(lexbase.LSHIFT) {
+		return "", nil
+	}
+	label, err := p.parseIdent()
+	if err != nil {
+		return "", err
+	}
+	if !p.accept(lexbase.RSHIFT) {
+		return "", p.errorf("expected >>")
+	}
+	return string(label), nil
+}
+
+// parseEndLabel parses the optional label after the END of a block or loop,
+// which must match the label at the start of the block or loop.
+func (p *parser) parseEndLabel(label string) error {
+	if !isIdent(p.peek()) {
+		return nil
+	}
+	tok := p.peek()
+	if tok.Str != label {
+		if label == "" {
+			return p.errorf("end label %q specified for unlabeled block", tok.Str)
+		}
+		return p.errorf("end label %q differs from block's label %q", tok.Str, label)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) parseBlock(label string) (*plpgsqltree.Block, error) {
+	block := &plpgsqltree.Block{Label: label}
+	if p.acceptWord("declare") {
+		for !p.peekWord("begin") {
+			if p.atEOF() {
+				return nil, p.errorf("expected BEGIN")
+			}
+			decl, err := p.parseDeclaration()
+			if err != nil {
+				return nil, err
+			}
+			block.Decls = append(block.Decls, decl)
+		}
+	}
+	if err := p.expectWord("begin"); err != nil {
+		return nil, err
+	}
+	var err error
+	if block.Body, err = p.parseStmtList("exception", "end"); err != nil {
+		return nil, err
+	}
+	if p.acceptWord("exception") {
+		for p.peekWord("when") {
+			exc, err := p.parseException()
+			if err != nil {
+				return nil, err
+			}
+			block.Exceptions = append(block.Exceptions, exc)
+		}
+		if len(block.Exceptions) == 0 {
+			return nil, p.errorf("expected WHEN")
+		}
+	}
+	if err := p.expectWord("end"); err != nil {
+		return nil, err
+	}
+	if err := p.parseEndLabel(label); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (p *parser) parseDeclaration() (decl plpgsqltree.Declaration, err error) {
+	if decl.Var, err = p.parseIdent(); err != nil {
+		return decl, err
+	}
+	decl.Constant = p.acceptWord("constant")
+	start := p.pos
+	for !p.atEOF() && p.peek().ID != ';' && !p.peekAssign() && !p.peekWord("default") &&
+		!(p.peekWord("not") && isWord(p.peekN(1), "null")) {
+		p.next()
+	}
+	if p.pos == start {
+		return decl, p.errorf("expected type")
+	}
+	typText := p.text(start, p.pos)
+	if strings.Contains(typText, "%") {
+		return decl, pgerror.Newf(pgcode.FeatureNotSupported,
+			"%%TYPE and %%ROWTYPE are not supported in variable declarations")
+	}
+	if decl.Typ, err = sqlparser.GetTypeFromValidSQLSyntax(typText); err != nil {
+		return decl, err
+	}
+	if p.acceptWord("not") {
+		p.next()
+		decl.NotNull = true
+	}
+	if p.acceptAssign() || p.acceptWord("default") {
+		if decl.Expr, err = p.parseExprUntil(isSemicolon); err != nil {
+			return decl, err
+		}
+	} else if decl.Constant || decl.NotNull {
+		return decl, p.errorf("variable %q must have a default value", decl.Var)
+	}
+	return decl, p.expect(';')
+}
+
+func (p *parser) parseException() (exc plpgsqltree.Exception, err error) {
+	if err := p.expectWord("when"); err != nil {
+		return exc, err
+	}
+	for {
+		var cond plpgsqltree.Condition
+		if p.acceptWord("sqlstate") {
+			tok := p.peek()
+			if tok.ID != lexbase.SCONST {
+				return exc, p.errorf("expected SQLSTATE code")
+			}
+			if !isSQLState(tok.Str) {
+				return exc, p.errorf("invalid SQLSTATE code")
+			}
+			p.next()
+			cond.SQLErrState = tok.Str
+		} else {
+			name, err := p.parseIdent()
+			if err != nil {
+				return exc, err
+			}
+			cond.SQLErrName = string(name)
+		}
+		exc.Conditions = append(exc.Conditions, cond)
+		if !p.acceptWord("or") {
+			break
+		}
+	}
+	if err := p.expectWord("then"); err != nil {
+		return exc, err
+	}
+	exc.Body, err = p.parseStmtList("when", "end")
+	return exc, err
+}
+
+// parseStmtList parses statements until one of the given words is found at
+// the start of a statement.
+func (p *parser) parseStmtList(terminators ...string) ([]plpgsqltree.Statement, error) {
+	var stmts []plpgsqltree.Statement
+	for {
+		if p.atEOF() {
+			return nil, p.errorf("expected %s", strings.ToUpper(terminators[len(terminators)-1]))
+		}
+		for _, t := range terminators {
+			if p.peekWord(t) {
+				return stmts, nil
+			}
+		}
+		stmt, err := p.parseStmt()
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+	}
+}
+
+func (p *parser) parseStmt() (plpgsqltree.Statement, error) {
+	label, err := p.parseLabel()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.peekWord("declare"), p.peekWord("begin"):
+		block, err := p.parseBlock(label)
+		if err != nil {
+			return nil, err
+		}
+		return block, p.expect(';')
+	case p.peekWord("loop"):
+		p.next()
+		body, err := p.parseLoopBody(label)
+		if err != nil {
+			return nil, err
+		}
+		return &plpgsqltree.Loop{Label: label, Body: body}, nil
+	case p.peekWord("while"):
+		p.next()
+		cond, err := p.parseExprUntil(isWordFn("loop"))
+		if err != nil {
+			return nil, err
+		}
+		p.next()
+		body, err := p.parseLoopBody(label)
+		if err != nil {
+			return nil, err
+		}
+		return &plpgsqltree.While{Label: label, Condition: cond, Body: body}, nil
+	case p.peekWord("for"):
+		p.next()
+		return p.parseFor(label)
+	}
+	if label != "" {
+		return nil, p.errorf("labels are only allowed before blocks and loops")
+	}
+
+	tok := p.peek()
+	switch {
+	case isWord(tok, "if"):
+		p.next()
+		return p.parseIf()
+	case isWord(tok, "exit"), isWord(tok, "continue"):
+		p.next()
+		label, cond, err := p.parseExitOrContinue()
+		if err != nil {
+			return nil, err
+		}
+		if isWord(tok, "exit") {
+			return &plpgsqltree.Exit{Label: label, Condition: cond}, nil
+		}
+		return &plpgsqltree.Continue{Label: label, Condition: cond}, nil
+	case isWord(tok, "return"):
+		p.next()
+		return p.parseReturn()
+	case isWord(tok, "raise"):
+		p.next()
+		return p.parseRaise()
+	case isWord(tok, "perform"):
+		p.next()
+		start := p.pos
+		p.skipUntil(isSemicolon)
+		if p.pos == start {
+			return nil, p.errorf("expected query")
+		}
+		query, err := sqlparser.ParseOne("SELECT " + p.text(start, p.pos))
+		if err != nil {
+			return nil, err
+		}
+		return &plpgsqltree.Perform{Query: query.AST}, p.expect(';')
+	case isWord(tok, "null") && p.peekN(1).ID == ';':
+		p.next()
+		p.next()
+		return &plpgsqltree.Null{}, nil
+	case isIdent(tok) && p.lookaheadAssign():
+		p.next()
+		p.acceptAssign()
+		value, err := p.parseExprUntil(isSemicolon)
+		if err != nil {
+			return nil, err
+		}
+		return &plpgsqltree.Assignment{Var: tree.Name(tok.Str), Value: value}, p.expect(';')
+	case isIdent(tok) && p.peekN(1).ID == '.' && isIdent(p.peekN(2)) && p.lookaheadFieldAssign():
+		p.next()
+		p.next()
+		field := p.next()
+		p.acceptAssign()
+		value, err := p.parseExprUntil(isSemicolon)
+		if err != nil {
+			return nil, err
+		}
+		return &plpgsqltree.Assignment{
+			Var: tree.Name(tok.Str), Field: tree.Name(field.Str), Value: value,
+		}, p.expect(';')
+	}
+	return p.parseExecSQL()
+}
+
+// lookaheadAssign returns true if the token after the next one is an
+// assignment operator.
+func (p *parser) lookaheadAssign() bool {
+	p.pos++
+	defer func() { p.pos-- }()
+	return p.peekAssign()
+}
+
+// lookaheadFieldAssign returns true if the tokens after the next three, which
+// are a field reference like NEW.x, are an assignment operator.
+func (p *parser) lookaheadFieldAssign() bool {
+	p.pos += 3
+	defer func() { p.pos -= 3 }()
+	return p.peekAssign()
+}
+
+func (p *parser) parseLoopBody(label string) ([]plpgsqltree.Statement, error) {
+	body, err := p.parseStmtList("end")
+	if err != nil {
+		return nil, err
+	}
+	p.next()
+	if err := p.expectWord("loop"); err != nil {
+		return nil, err
+	}
+	if err := p.parseEndLabel(label); err != nil {
+		return nil, err
+	}
+	return body, p.expect(';')
+}
+
+func (p *parser) parseIf() (*plpgsqltree.If, error) {
+	var s plpgsqltree.If
+	var err error
+	if s.Condition, err = p.parseExprUntil(isWordFn("then")); err != nil {
+		return nil, err
+	}
+	p.next()
+	if s.ThenBody, err = p.parseStmtList("elsif", "elseif", "else", "end"); err != nil {
+		return nil, err
+	}
+	for p.acceptWord("elsif") || p.acceptWord("elseif") {
+		var elseIf plpgsqltree.ElseIf
+		if elseIf.Condition, err = p.parseExprUntil(isWordFn("then")); err != nil {
+			return nil, err
+		}
+		p.next()
+		if elseIf.Body, err = p.parseStmtList("elsif", "elseif", "else", "end"); err != nil {
+			return nil, err
+		}
+		s.ElseIfList = append(s.ElseIfList, elseIf)
+	}
+	if p.acceptWord("else") {
+		if s.ElseBody, err = p.parseStmtList("end"); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expectWord("end"); err != nil {
+		return nil, err
+	}
+	if err := p.expectWord("if"); err != nil {
+		return nil, err
+	}
+	return &s, p.expect(';')
+}
+
+func (p *parser) parseFor(label string) (plpgsqltree.Statement, error) {
+	var targets tree.NameList
+	for {
+		name, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, name)
+		if !p.accept(',') {
+			break
+		}
+	}
+	if err := p.expectWord("in"); err != nil {
+		return nil, err
+	}
+
+	// Look ahead to determine whether this is an integer or a query loop.
+	reverse := p.acceptWord("reverse")
+	start := p.pos
+	p.skipUntil(isWordFn("loop"))
+	end := p.pos
+	dotDot := -1
+	for i, depth := start, 0; i < end; i++ {
+		switch p.toks[i].ID {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case lexbase.DOT_DOT:
+			if depth == 0 && dotDot == -1 {
+				dotDot = i
+			}
+		}
+	}
+	p.pos = start
+
+	if dotDot == -1 {
+		if reverse {
+			return nil, p.errorf("REVERSE is only allowed in integer FOR loops")
+		}
+		if p.pos == end {
+			return nil, p.errorf("expected query")
+		}
+		query, err := sqlparser.ParseOne(p.text(start, end))
+		if err != nil {
+			return nil, err
+		}
+		p.pos = end + 1
+		body, err := p.parseLoopBody(label)
+		if err != nil {
+			return nil, err
+		}
+		return &plpgsqltree.ForQuery{Label: label, Targets: targets, Query: query.AST, Body: body}, nil
+	}
+
+	if len(targets) != 1 {
+		return nil, p.errorf("integer FOR loop must have only one target variable")
+	}
+	s := plpgsqltree.ForInt{Label: label, Var: targets[0], Reverse: reverse}
+	var err error
+	if s.Lower, err = p.parseExprUntil(func(tok scanner.InspectToken) bool {
+		return tok.ID == lexbase.DOT_DOT
+	}); err != nil {
+		return nil, err
+	}
+	p.next()
+	if s.Upper, err = p.parseExprUntil(func(tok scanner.InspectToken) bool {
+		return isWord(tok, "by") || isWord(tok, "loop")
+	}); err != nil {
+		return nil, err
+	}
+	if p.acceptWord("by") {
+		if s.Step, err = p.parseExprUntil(isWordFn("loop")); err != nil {
+			return nil, err
+		}
+	}
+	p.next()
+	if s.Body, err = p.parseLoopBody(label); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (p *parser) parseExitOrContinue() (label string, cond tree.Expr, err error) {
+	if isIdent(p.peek()) && !p.peekWord("when") {
+		label = p.next().Str
+	}
+	if p.acceptWord("when") {
+		if cond, err = p.parseExprUntil(isSemicolon); err != nil {
+			return "", nil, err
+		}
+	}
+	return label, cond, p.expect(';')
+}
+
+func (p *parser) parseReturn() (plpgsqltree.Statement, error) {
+	switch {
+	case p.accept(';'):
+		return &plpgsqltree.Return{}, nil
+	case p.acceptWord("next"):
+		s := &plpgsqltree.ReturnNext{}
+		if !p.accept(';') {
+			var err error
+			if s.Expr, err = p.parseExprUntil(isSemicolon); err != nil {
+				return nil, err
+			}
+			return s, p.expect(';')
+		}
+		return s, nil
+	case p.acceptWord("query"):
+		start := p.pos
+		p.skipUntil(isSemicolon)
+		if p.pos == start {
+			return nil, p.errorf("expected query")
+		}
+		query, err := sqlparser.ParseOne(p.text(start, p.pos))
+		if err != nil {
+			return nil, err
+		}
+		return &plpgsqltree.ReturnQuery{Query: query.AST}, p.expect(';')
+	}
+	expr, err := p.parseExprUntil(isSemicolon)
+	if err != nil {
+		return nil, err
+	}
+	return &plpgsqltree.Return{Expr: expr}, p.expect(';')
+}
+
+func (p *parser) parseRaise() (*plpgsqltree.Raise, error) {
+	var s plpgsqltree.Raise
+	if p.accept(';') {
+		return &s, nil
+	}
+	if tok := p.peek(); !tok.Quoted && tok.ID != lexbase.SCONST {
+		if _, ok := raiseLevels[tok.Str]; ok {
+			s.Level = tok.Str
+			p.next()
+		}
+	}
+
+	tok := p.peek()
+	switch {
+	case tok.ID == lexbase.SCONST:
+		p.next()
+		s.Message = tok.Str
+		for p.accept(',') {
+			param, err := p.parseExprUntil(func(tok scanner.InspectToken) bool {
+				return tok.ID == ',' || tok.ID == ';' || isWord(tok, "using")
+			})
+			if err != nil {
+				return nil, err
+			}
+			s.Params = append(s.Params, param)
+		}
+		if n := strings.Count(strings.ReplaceAll(s.Message, "%%", ""), "%"); n != len(s.Params) {
+			if n > len(s.Params) {
+				return nil, p.errorf("too few parameters specified for RAISE")
+			}
+			return nil, p.errorf("too many parameters specified for RAISE")
+		}
+	case isWord(tok, "sqlstate"):
+		p.next()
+		code := p.peek()
+		if code.ID != lexbase.SCONST {
+			return nil, p.errorf("expected SQLSTATE code")
+		}
+		if !isSQLState(code.Str) {
+			return nil, p.errorf("invalid SQLSTATE code")
+		}
+		p.next()
+		s.Code = code.Str
+	case isWord(tok, "using"):
+	case isIdent(tok):
+		p.next()
+		s.CodeName = tok.Str
+	default:
+		return nil, p.errorf("expected RAISE message, condition name, SQLSTATE or USING")
+	}
+
+	if p.acceptWord("using") {
+		for {
+			name, err := p.parseIdent()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := raiseOptions[string(name)]; !ok {
+				return nil, pgerror.Newf(pgcode.Syntax, "unrecognized RAISE statement option %q", name)
+			}
+			if !p.acceptAssign() {
+				return nil, p.errorf("expected = or :=")
+			}
+			expr, err := p.parseExprUntil(func(tok scanner.InspectToken) bool {
+				return tok.ID == ',' || tok.ID == ';'
+			})
+			if err != nil {
+				return nil, err
+			}
+			s.Options = append(s.Options, plpgsqltree.RaiseOption{Name: string(name), Expr: expr})
+			if !p.accept(',') {
+				break
+			}
+		}
+	}
+	return &s, p.expect(';')
+}
+
+// parseExecSQL parses a SQL statement, with an optional INTO clause that
+// stores the first row of the result into variables.
+func (p *parser) parseExecSQL() (*plpgsqltree.ExecSQL, error) {
+	start := p.pos
+	p.skipUntil(isSemicolon)
+	end := p.pos
+	if start == end {
+		return nil, p.errorf("expected statement")
+	}
+
+	var s plpgsqltree.ExecSQL
+	sql := p.text(start, end)
+	for i, depth := start, 0; i < end; i++ {
+		switch tok := p.toks[i]; {
+		case tok.ID == '(' || tok.ID == '[':
+			depth++
+		case tok.ID == ')' || tok.ID == ']':
+			depth--
+		case depth == 0 && isWord(tok, "into"):
+			if i > start {
+				if _, ok := intoPrecedingWords[p.toks[i-1].Str]; ok {
+					continue
+				}
+			}
+			j := i + 1
+			if isWord(p.toks[j], "strict") {
+				s.Strict = true
+				j++
+			}
+			for {
+				if !isIdent(p.toks[j]) {
+					p.pos = j
+					return nil, p.errorf("expected INTO target")
+				}
+				s.Into = append(s.Into, tree.Name(p.toks[j].Str))
+				j++
+				if p.toks[j].ID != ',' {
+					break
+				}
+				j++
+			}
+			sql = p.sql[p.toks[start].Start:tok.Start]
+			if j < end {
+				sql += " " + p.text(j, end)
+			}
+			i = end
+		}
+	}
+	stmt, err := sqlparser.ParseOne(sql)
+	if err != nil {
+		return nil, err
+	}
+	s.SQL = stmt.AST
+	return &s, p.expect(';')
+}
+
+// parseExprUntil parses a SQL expression that extends up to the first token,
+// outside of parentheses and CASE expressions, for which stop returns true.
+// The stop token is not consumed.
+func (p *parser) parseExprUntil(stop func(scanner.InspectToken) bool) (tree.Expr, error) {
+	start := p.pos
+	p.skipUntil(stop)
+	if p.pos == start {
+		return nil, p.errorf("expected expression")
+	}
+	if p.atEOF() {
+		return nil, p.errorf("unexpected end of function body")
+	}
+	return sqlparser.ParseExpr(p.text(start, p.pos))
+}
+
+// skipUntil advances to the first token, outside of parentheses and CASE
+// expressions, for which stop returns true, or to the end of the input.
+func (p *parser) skipUntil(stop func(scanner.InspectToken) bool) {
+	parens, cases := 0, 0
+	for ; !p.atEOF(); p.pos++ {
+		tok := p.peek()
+		if parens == 0 && cases == 0 && stop(tok) {
+			return
+		}
+		switch {
+		case tok.ID == '(' || tok.ID == '[':
+			parens++
+		case tok.ID == ')' || tok.ID == ']':
+			parens--
+		case isWord(tok, "case"):
+			cases++
+		case isWord(tok, "end") && cases > 0:
+			cases--
+		}
+	}
+}
+
+// text returns the input text spanned by the tokens in [start, end).
+func (p *parser) text(start, end int) string {
+	return p.sql[p.toks[start].Start:p.toks[end-1].End]
+}
+
+func isSemicolon(tok scanner.InspectToken) bool {
+	return tok.ID == ';'
+}
+
+func isWordFn(word string) func(scanner.InspectToken) bool {
+	return func(tok scanner.InspectToken) bool {
+		return isWord(tok, word)
+	}
+}
+
+// isSQLState returns true if code is a well-formed SQLSTATE: five digits or
+// upper-case letters.
+func isSQLState(code string) bool {
+	if len(code) != 5 {
+		return false
+	}
+	for _, c := range code {
+		if !(c >= '0' && c <= '9') && !(c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
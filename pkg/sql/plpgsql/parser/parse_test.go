@@ -0,0 +1,55 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils/datapathutils"
+	"github.com/cockroachdb/datadriven"
+)
+
+// TestParseDataDriven tests the PL/pgSQL parser. The "parse" directive prints
+// the formatted AST, and checks that it parses back to the same AST. The
+// "error" directive prints the parse error.
+func TestParseDataDriven(t *testing.T) {
+	datadriven.Walk(t, datapathutils.TestDataPath(t), func(t *testing.T, path string) {
+		datadriven.RunTest(t, path, func(t *testing.T, d *datadriven.TestData) string {
+			switch d.Cmd {
+			case "parse":
+				block, err := parser.Parse(d.Input)
+				if err != nil {
+					d.Fatalf(t, "unexpected parse error: %v", err)
+				}
+				ref := tree.AsString(block)
+				reparsed, err := parser.Parse(ref)
+				if err != nil {
+					d.Fatalf(t, "unexpected error when reparsing %s: %v", ref, err)
+				}
+				if s := tree.AsString(reparsed); s != ref {
+					d.Fatalf(t, "mismatched AST when reparsing:\nexpected: %s\nactual:   %s", ref, s)
+				}
+				return ref + "\n"
+			case "error":
+				_, err := parser.Parse(d.Input)
+				if err == nil {
+					d.Fatalf(t, "expected error, found none")
+				}
+				return pgerror.Flatten(err).Error() + "\n"
+			}
+			d.Fatalf(t, "unsupported command: %s", d.Cmd)
+			return ""
+		})
+	})
+}
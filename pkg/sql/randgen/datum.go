@@ -22,18 +22,21 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/geo"
 	"github.com/cockroachdb/cockroach/pkg/geo/geogen"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -284,11 +287,57 @@ func RandDatumWithNullChance(
 		return tree.NewDTSVector(tsearch.RandomTSVector(rng))
 	case types.TSQueryFamily:
 		return tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
+	case types.JsonpathFamily:
+		return tree.NewDJsonpath(jsonpath.RandomPath(rng))
+	case types.PGVectorFamily:
+		return tree.NewDPGVector(vector.Random(rng, int(typ.Width())))
+	case types.RangeFamily:
+		return RandRange(rng, typ)
+	case types.MultirangeFamily:
+		ranges := make([]*tree.DRange, rng.Intn(4))
+		for i := range ranges {
+			ranges[i] = RandRange(rng, types.MultirangeRange(typ))
+		}
+		m, err := tree.NewDMultirange(&eval.Context{}, typ, ranges)
+		if err != nil {
+			panic(err)
+		}
+		return m
 	default:
 		panic(errors.AssertionFailedf("invalid type %v", typ.DebugString()))
 	}
 }
 
+// RandRange generates a random DRange of the given range type.
+func RandRange(rng *rand.Rand, typ *types.T) *tree.DRange {
+	if rng.Intn(10) == 0 {
+		return tree.NewDEmptyRange(typ)
+	}
+	var bounds [2]tree.RangeBound
+	for i := range bounds {
+		// Leave some of the bounds infinite.
+		if rng.Intn(5) == 0 {
+			continue
+		}
+		bounds[i] = tree.RangeBound{
+			Val:       RandDatum(rng, typ.RangeContents(), false /* nullOk */),
+			Inclusive: rng.Intn(2) == 0,
+		}
+	}
+	evalCtx := &eval.Context{}
+	if !bounds[0].IsInfinite() && !bounds[1].IsInfinite() &&
+		bounds[0].Val.Compare(evalCtx, bounds[1].Val) > 0 {
+		bounds[0].Val, bounds[1].Val = bounds[1].Val, bounds[0].Val
+	}
+	r, err := tree.NewDRange(evalCtx, typ, bounds[0], bounds[1])
+	if err != nil {
+		// Converting a bound of a discrete range to its canonical form can
+		// overflow the subtype.
+		return tree.NewDEmptyRange(typ)
+	}
+	return r
+}
+
 // RandArray generates a random DArray where the contents have nullChance
 // of being null.
 func RandArray(rng *rand.Rand, typ *types.T, nullChance int) tree.Datum {
@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 )
 
 var (
@@ -363,6 +364,8 @@ func encodeInvertedIndexHistogramUpperBounds(colType *types.T, val tree.Datum) (
 		keys, err = rowenc.EncodeGeoInvertedIndexTableKeys(val, nil, *geoindex.DefaultGeometryIndexConfig())
 	case types.GeographyFamily:
 		keys, err = rowenc.EncodeGeoInvertedIndexTableKeys(val, nil, *geoindex.DefaultGeographyIndexConfig())
+	case types.PGVectorFamily:
+		keys, err = rowenc.EncodeVectorInvertedIndexTableKeys(val, nil, vector.IVFConfig{Lists: vector.DefaultLists})
 	default:
 		keys, err = rowenc.EncodeInvertedIndexTableKeys(val, nil, descpb.LatestIndexDescriptorVersion)
 	}
@@ -102,6 +102,8 @@ func RandTypeFromSlice(rng *rand.Rand, typs []*types.T) *types.T {
 	switch typ.Family() {
 	case types.BitFamily:
 		return types.MakeBit(int32(rng.Intn(50)))
+	case types.PGVectorFamily:
+		return types.MakePGVector(int32(1 + rng.Intn(10)))
 	case types.CollatedStringFamily:
 		return types.MakeCollatedString(types.String, *RandCollationLocale(rng))
 	case types.ArrayFamily:
@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/errors"
 )
 
 type refreshMaterializedViewNode struct {
@@ -38,6 +39,13 @@ func (p *planner) RefreshMaterializedView(
 	if !desc.MaterializedView() {
 		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a materialized view", desc.Name)
 	}
+	if desc.IsIncrementallyRefreshed() {
+		return nil, errors.WithHint(
+			pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"cannot refresh incrementally refreshed materialized view %q", desc.Name),
+			"The view is kept up to date automatically.",
+		)
+	}
 	// TODO (rohany): Not sure if this is a real restriction, but let's start with
 	//  it to be safe.
 	for i := range desc.Mutations {
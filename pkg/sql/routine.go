@@ -14,7 +14,9 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
@@ -23,20 +25,11 @@ import (
 // EvalRoutineExpr returns the result of evaluating the routine. It calls the
 // routine's PlanFn to generate a plan for each statement in the routine, then
 // runs the plans. The resulting value of the last statement in the routine is
-// returned.
+// returned. The control flow of a PL/pgSQL routine is interpreted instead, and
+// the statements are planned and run as they are reached.
 func (p *planner) EvalRoutineExpr(
 	ctx context.Context, expr *tree.RoutineExpr, input tree.Datums,
 ) (result tree.Datum, err error) {
-	retTypes := []*types.T{expr.ResolvedType()}
-
-	// The result of the routine is the result of the last statement. The result
-	// of any preceding statements is ignored. We set up a rowResultWriter that
-	// can store the results of the final statement here.
-	var rch rowContainerHelper
-	rch.Init(ctx, retTypes, p.ExtendedEvalContext(), "routine" /* opName */)
-	defer rch.Close(ctx)
-	rrw := NewRowResultWriter(&rch)
-
 	// Configure stepping for volatile routines so that mutations made by the
 	// invoking statement are visible to the routine.
 	txn := p.Txn()
@@ -54,49 +47,45 @@ func (p *planner) EvalRoutineExpr(
 		}()
 	}
 
-	// Execute each statement in the routine sequentially.
-	ef := newExecFactory(ctx, p)
-	for i := 0; i < expr.NumStmts; i++ {
-		if err := func() error {
-			opName := "udf-stmt-" + expr.Name + "-" + strconv.Itoa(i)
-			ctx, sp := tracing.ChildSpan(ctx, opName)
-			defer sp.Finish()
-
-			// Generate a plan for executing the ith statement.
-			plan, err := expr.PlanFn(ctx, ef, i, input)
-			if err != nil {
-				return err
-			}
-
-			// If this is the last statement, use the rowResultWriter created above.
-			// Otherwise, use a rowResultWriter that drops all rows added to it.
-			var w rowResultWriter
-			if i == expr.NumStmts-1 {
-				w = rrw
-			} else {
-				w = &droppingResultWriter{}
-			}
+	if expr.PLpgSQLBody != nil {
+		return p.evalPLpgSQLRoutine(ctx, expr, input)
+	}
 
-			// Place a sequence point before each statement in the routine for
-			// volatile functions.
-			if expr.EnableStepping {
-				if err := txn.Step(ctx); err != nil {
-					return err
-				}
-			}
+	retTypes := []*types.T{expr.ResolvedType()}
+	returnsVoid := expr.ResolvedType().Family() == types.VoidFamily
 
-			// Run the plan.
-			err = runPlanInsidePlan(ctx, p.RunParams(ctx), plan.(*planComponents), w)
-			if err != nil {
-				return err
-			}
+	// The result of the routine is the result of the last statement. The result
+	// of any preceding statements is ignored. We set up a rowResultWriter that
+	// can store the results of the final statement here.
+	var rch rowContainerHelper
+	rch.Init(ctx, retTypes, p.ExtendedEvalContext(), "routine" /* opName */)
+	defer rch.Close(ctx)
+	rrw := NewRowResultWriter(&rch)
 
-			return nil
-		}(); err != nil {
+	// Execute each statement in the routine sequentially.
+	ef := newExecFactory(ctx, p)
+	for i := 0; i < expr.NumStmts; i++ {
+		// If this is the last statement, use the rowResultWriter created above.
+		// Otherwise, use a rowResultWriter that drops all rows added to it.
+		// The result of a routine that returns VOID is always dropped.
+		var w rowResultWriter
+		if i == expr.NumStmts-1 && !returnsVoid {
+			w = rrw
+		} else {
+			w = &droppingResultWriter{}
+		}
+		if err := p.runRoutineStmt(
+			ctx, expr, ef, i, input, tree.Rows,
+			func(colinfo.ResultColumns) rowResultWriter { return w },
+		); err != nil {
 			return nil, err
 		}
 	}
 
+	if returnsVoid {
+		return tree.DVoidDatum, nil
+	}
+
 	// Fetch the first row from the row container and return the first
 	// datum.
 	rightRowsIterator := newRowContainerIterator(ctx, rch, retTypes)
@@ -112,6 +101,44 @@ func (p *planner) EvalRoutineExpr(
 	return res[0], nil
 }
 
+// runRoutineStmt plans the statement of the routine with the given index using
+// the given arguments, and runs the plan. The results of the statement, of the
+// given type, are written to the rowResultWriter returned by newWriter, which
+// is passed the result columns of the plan.
+func (p *planner) runRoutineStmt(
+	ctx context.Context,
+	expr *tree.RoutineExpr,
+	ef *execFactory,
+	stmtIdx int,
+	args tree.Datums,
+	stmtType tree.StatementReturnType,
+	newWriter func(colinfo.ResultColumns) rowResultWriter,
+) error {
+	opName := "udf-stmt-" + expr.Name + "-" + strconv.Itoa(stmtIdx)
+	ctx, sp := tracing.ChildSpan(ctx, opName)
+	defer sp.Finish()
+
+	// Generate a plan for executing the statement.
+	plan, err := expr.PlanFn(ctx, ef, stmtIdx, args)
+	if err != nil {
+		return err
+	}
+	pc := plan.(*planComponents)
+	w := newWriter(pc.main.planColumns())
+
+	// Place a sequence point before each statement in the routine for
+	// volatile functions.
+	if expr.EnableStepping {
+		if err := p.Txn().Step(ctx); err != nil {
+			pc.close(ctx)
+			return err
+		}
+	}
+
+	// Run the plan.
+	return runPlanInsidePlan(ctx, p.RunParams(ctx), pc, stmtType, w)
+}
+
 // droppingResultWriter drops all rows that are added to it. It only tracks
 // errors with the SetError and Err functions.
 type droppingResultWriter struct {
@@ -123,6 +150,11 @@ func (d *droppingResultWriter) AddRow(ctx context.Context, row tree.Datums) erro
 	return nil
 }
 
+// AddBatch is part of the batchResultWriter interface.
+func (d *droppingResultWriter) AddBatch(ctx context.Context, batch coldata.Batch) error {
+	return nil
+}
+
 // IncrementRowsAffected is part of the rowResultWriter interface.
 func (d *droppingResultWriter) IncrementRowsAffected(ctx context.Context, n int) {}
 
@@ -966,6 +966,77 @@ func (f *DiskBackedIndexedRowContainer) getRowWithoutCache(
 	}
 }
 
+// ScrollableRowContainer is a DiskBackedIndexedRowContainer that supports
+// reading rows while more rows are still being added, as done by scrollable
+// SQL cursors. Once it has spilled to disk, it also returns the rows that its
+// memory budget doesn't allow for caching, by reading them from disk on every
+// access.
+type ScrollableRowContainer struct {
+	*DiskBackedIndexedRowContainer
+}
+
+// NewScrollableRowContainer creates a ScrollableRowContainer. See
+// NewDiskBackedIndexedRowContainer for a description of the arguments.
+func NewScrollableRowContainer(
+	typs []*types.T,
+	evalCtx *eval.Context,
+	engine diskmap.Factory,
+	memoryMonitor *mon.BytesMonitor,
+	diskMonitor *mon.BytesMonitor,
+) *ScrollableRowContainer {
+	return &ScrollableRowContainer{
+		DiskBackedIndexedRowContainer: NewDiskBackedIndexedRowContainer(
+			colinfo.NoOrdering, typs, evalCtx, engine, memoryMonitor, diskMonitor,
+		),
+	}
+}
+
+// AddRow implements SortableRowContainer.
+func (c *ScrollableRowContainer) AddRow(ctx context.Context, row rowenc.EncDatumRow) error {
+	// The disk iterator might not see rows added after it was created, so it
+	// needs to be recreated when rows are read from the container again.
+	c.resetIterator()
+	return c.DiskBackedIndexedRowContainer.AddRow(ctx, row)
+}
+
+// GetRow implements tree.IndexedRows.
+func (c *ScrollableRowContainer) GetRow(ctx context.Context, pos int) (eval.IndexedRow, error) {
+	row, err := c.DiskBackedIndexedRowContainer.GetRow(ctx, pos)
+	if err == nil || !sqlerrors.IsOutOfMemoryError(err) || !c.UsingDisk() ||
+		c.indexedRowsCache.Len() != 0 {
+		return row, err
+	}
+	// Not even a single row fits in the memory budget of the cache, so the
+	// row is copied out of the disk iterator without being cached. The cache
+	// stays empty, and starts at the position of the iterator.
+	if c.idxRowIter > pos {
+		c.idxRowIter = 0
+		c.diskRowIter.Rewind()
+	}
+	for ; ; c.diskRowIter.Next() {
+		if ok, err := c.diskRowIter.Valid(); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, errors.Errorf("row at pos %d not found", pos)
+		}
+		if c.idxRowIter == pos {
+			break
+		}
+		c.idxRowIter++
+	}
+	c.firstCachedRowPos, c.nextPosToCache = pos, pos
+	rowWithIdx, err := c.diskRowIter.Row()
+	if err != nil {
+		return nil, err
+	}
+	for i := range rowWithIdx {
+		if err := rowWithIdx[i].EnsureDecoded(c.storedTypes[i], &c.datumAlloc); err != nil {
+			return nil, err
+		}
+	}
+	return IndexedRow{Idx: pos, Row: c.rowAlloc.CopyRow(rowWithIdx[:len(rowWithIdx)-1])}, nil
+}
+
 // IndexedRow is a row with a corresponding index.
 type IndexedRow struct {
 	Idx int
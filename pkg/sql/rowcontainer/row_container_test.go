@@ -829,6 +829,98 @@ func TestDiskBackedIndexedRowContainer(t *testing.T) {
 	})
 }
 
+// TestScrollableRowContainer verifies that rows can be read from a spilled
+// ScrollableRowContainer while rows are still being added to it, both with a
+// memory budget that allows for caching all rows and with one that doesn't
+// allow for caching any of them.
+func TestScrollableRowContainer(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	evalCtx := eval.MakeTestingEvalContext(st)
+	tempEngine, _, err := storage.NewTempEngine(ctx, base.TempStorageConfig{InMemory: true}, base.DefaultTestStoreSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tempEngine.Close()
+
+	const numRows = 10
+	const numCols = 2
+	rng, _ := randutil.NewTestRand()
+
+	for _, budget := range []int64{1, math.MaxInt64} {
+		t.Run(fmt.Sprintf("budget=%d", budget), func(t *testing.T) {
+			memoryMonitor := mon.NewMonitor(
+				"test-mem",
+				mon.MemoryResource,
+				nil,           /* curCount */
+				nil,           /* maxHist */
+				-1,            /* increment */
+				math.MaxInt64, /* noteworthy */
+				st,
+			)
+			memoryMonitor.Start(ctx, nil, mon.NewStandaloneBudget(budget))
+			defer memoryMonitor.Stop(ctx)
+			diskMonitor := mon.NewMonitor(
+				"test-disk",
+				mon.DiskResource,
+				nil,           /* curCount */
+				nil,           /* maxHist */
+				-1,            /* increment */
+				math.MaxInt64, /* noteworthy */
+				st,
+			)
+			diskMonitor.Start(ctx, nil, mon.NewStandaloneBudget(math.MaxInt64))
+			defer diskMonitor.Stop(ctx)
+
+			types := randgen.RandSortingTypes(rng, numCols)
+			rows := randgen.RandEncDatumRowsOfTypes(rng, numRows, types)
+			rc := NewScrollableRowContainer(types, &evalCtx, tempEngine, memoryMonitor, diskMonitor)
+			defer rc.Close(ctx)
+			if err := rc.SpillToDisk(ctx); err != nil {
+				t.Fatal(err)
+			}
+			checkRow := func(pos int) {
+				readRow, err := rc.GetRow(ctx, pos)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if readRow.GetIdx() != pos {
+					t.Fatalf("expected row at pos %d, found %d", pos, readRow.GetIdx())
+				}
+				for col, expectedDatum := range rows[pos] {
+					readDatum, err := readRow.GetDatum(col)
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					if cmp := readDatum.Compare(&evalCtx, expectedDatum.Datum); cmp != 0 {
+						t.Fatalf("read row is not equal to expected one")
+					}
+				}
+			}
+			for i := 0; i < numRows/2; i++ {
+				if err := rc.AddRow(ctx, rows[i]); err != nil {
+					t.Fatal(err)
+				}
+				checkRow(i)
+			}
+			for i := numRows / 2; i < numRows; i++ {
+				if err := rc.AddRow(ctx, rows[i]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for i := numRows - 1; i >= 0; i-- {
+				checkRow(i)
+			}
+			for i := 0; i < numRows; i++ {
+				checkRow(i)
+			}
+		})
+	}
+}
+
 // indexedRows are rows with the corresponding indices.
 type indexedRows struct {
 	rows []IndexedRow
@@ -40,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/trigram"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/unique"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -538,6 +539,9 @@ func EncodeInvertedIndexKeys(
 	if !indexGeoConfig.IsEmpty() {
 		return EncodeGeoInvertedIndexTableKeys(val, keyPrefix, indexGeoConfig)
 	}
+	if index.InvertedColumnKind().IsVector() {
+		return EncodeVectorInvertedIndexTableKeys(val, keyPrefix, index.VectorConfig())
+	}
 	return EncodeInvertedIndexTableKeys(val, keyPrefix, index.GetVersion())
 }
 
@@ -1014,6 +1018,32 @@ func encodeGeoKeys(
 	return keys, nil
 }
 
+// EncodeVectorInvertedIndexTableKeys produces the single inverted index key of
+// a vector, which is inKey followed by the IVF list that the vector belongs to
+// when the vectors are partitioned into lists with the given configuration. If
+// the input Datum is (SQL) NULL, no inverted index keys will be produced.
+func EncodeVectorInvertedIndexTableKeys(
+	val tree.Datum, inKey []byte, config vector.IVFConfig,
+) (key [][]byte, err error) {
+	if val == tree.DNull {
+		return nil, nil
+	}
+	v := tree.MustBeDPGVector(val)
+	list, err := config.List(v.T)
+	if err != nil {
+		return nil, err
+	}
+	outKey := make([]byte, len(inKey), len(inKey)+encoding.MaxVarintLen)
+	copy(outKey, inKey)
+	return [][]byte{encoding.EncodeUvarintAscending(outKey, uint64(list))}, nil
+}
+
+// EncodeVectorInvertedIndexListKey returns the inverted index key of the given
+// IVF list of a vector index, without any prefix.
+func EncodeVectorInvertedIndexListKey(list int) []byte {
+	return encoding.EncodeUvarintAscending(nil, uint64(list))
+}
+
 // encodeTrigramInvertedIndexTableKeys produces the trigram index table keys for
 // an input string. If pad is true, the returned table keys will include 3 extra
 // trigrams produced by padding the string with 2 spaces at the front and 1 at
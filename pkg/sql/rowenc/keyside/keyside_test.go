@@ -240,10 +240,13 @@ func hasKeyEncoding(typ *types.T) bool {
 	// Only some types are round-trip key encodable.
 	switch typ.Family() {
 	case types.JsonFamily, types.CollatedStringFamily, types.TupleFamily, types.DecimalFamily,
-		types.GeographyFamily, types.GeometryFamily, types.TSVectorFamily, types.TSQueryFamily:
+		types.GeographyFamily, types.GeometryFamily, types.TSVectorFamily, types.TSQueryFamily,
+		types.JsonpathFamily, types.PGVectorFamily:
 		return false
 	case types.ArrayFamily:
 		return hasKeyEncoding(typ.ArrayContents())
+	case types.RangeFamily, types.MultirangeFamily:
+		return hasKeyEncoding(typ.RangeContents())
 	}
 	return true
 }
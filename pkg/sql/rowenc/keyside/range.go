@@ -0,0 +1,136 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// encodeRangeKey generates an ordered key encoding of a range.
+// The encoding format for a range [a, b) is as follows:
+// [rangeMarker, finite, enc(a), inclusive, finite, enc(b), exclusive].
+// Infinite bounds are encoded with a single byte which sorts before (for the
+// lower bound) or after (for the upper bound) all finite bounds, and an empty
+// range is encoded with a single byte in place of its lower bound which sorts
+// before all other ranges. This matches the order of tree.DRange.Compare.
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	b = encoding.EncodeRangeKeyMarker(b, dir)
+	if r.Empty {
+		return encoding.EncodeRangeKeyBoundKind(b, encoding.RangeKeyEmpty, dir), nil
+	}
+	var err error
+	for _, bound := range [...]struct {
+		tree.RangeBound
+		isLower bool
+	}{{r.Lower, true}, {r.Upper, false}} {
+		if bound.IsInfinite() {
+			kind := encoding.RangeKeyUpperInfinite
+			if bound.isLower {
+				kind = encoding.RangeKeyLowerInfinite
+			}
+			b = encoding.EncodeRangeKeyBoundKind(b, kind, dir)
+			continue
+		}
+		b = encoding.EncodeRangeKeyBoundKind(b, encoding.RangeKeyFinite, dir)
+		if b, err = Encode(b, bound.Val, dir); err != nil {
+			return nil, err
+		}
+		b = encoding.EncodeRangeKeyBoundInclusive(b, bound.Inclusive, bound.isLower, dir)
+	}
+	return b, nil
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, buf []byte, dir encoding.Direction,
+) (*tree.DRange, []byte, error) {
+	var err error
+	buf, err = encoding.ValidateAndConsumeRangeKeyMarker(buf, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	var bounds [2]tree.RangeBound
+	for i, isLower := range [...]bool{true, false} {
+		var kind encoding.RangeKeyBoundKind
+		buf, kind, err = encoding.DecodeRangeKeyBoundKind(buf, dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch kind {
+		case encoding.RangeKeyEmpty:
+			if !isLower {
+				return nil, nil, errors.AssertionFailedf("invalid range encoding (empty upper bound)")
+			}
+			return tree.NewDEmptyRange(t), buf, nil
+		case encoding.RangeKeyFinite:
+			bounds[i].Val, buf, err = Decode(a, t.RangeContents(), buf, dir)
+			if err != nil {
+				return nil, nil, err
+			}
+			buf, bounds[i].Inclusive, err = encoding.DecodeRangeKeyBoundInclusive(buf, isLower, dir)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return tree.NewDRangeUnchecked(t, bounds[0], bounds[1], false /* empty */), buf, nil
+}
+
+// encodeMultirangeKey generates an ordered key encoding of a multirange.
+// The encoding format for a multirange {r1, r2} is as follows:
+// [multirangeMarker, enc(r1), enc(r2), terminator].
+// Like for arrays, the terminator sorts before all encoded ranges so that two
+// multiranges with the same prefix but different lengths sort correctly.
+func encodeMultirangeKey(
+	b []byte, m *tree.DMultirange, dir encoding.Direction,
+) ([]byte, error) {
+	var err error
+	b = encoding.EncodeMultirangeKeyMarker(b, dir)
+	for _, r := range m.Ranges {
+		if b, err = encodeRangeKey(b, r, dir); err != nil {
+			return nil, err
+		}
+	}
+	return encoding.EncodeMultirangeKeyTerminator(b, dir), nil
+}
+
+// decodeMultirangeKey decodes a multirange key generated by
+// encodeMultirangeKey.
+func decodeMultirangeKey(
+	a *tree.DatumAlloc, t *types.T, buf []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	var err error
+	buf, err = encoding.ValidateAndConsumeMultirangeKeyMarker(buf, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	rangeTyp := types.MultirangeRange(t)
+	var ranges []*tree.DRange
+	for {
+		if len(buf) == 0 {
+			return nil, nil, errors.AssertionFailedf("invalid multirange encoding (unterminated)")
+		}
+		if encoding.IsMultirangeKeyDone(buf, dir) {
+			buf = buf[1:]
+			break
+		}
+		var r *tree.DRange
+		r, buf, err = decodeRangeKey(a, rangeTyp, buf, dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	return tree.NewDMultirangeUnchecked(t, ranges), buf, nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
@@ -235,6 +236,10 @@ func DatumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 		return encoding.JSON, nil
 	case types.TupleFamily:
 		return encoding.Tuple, nil
+	case types.RangeFamily, types.MultirangeFamily:
+		return encoding.Bytes, nil
+	case types.PGVectorFamily:
+		return encoding.PGVector, nil
 	default:
 		return 0, errors.AssertionFailedf(
 			"no known encoding type for %s", redact.Safe(t.Family().Name()),
@@ -315,6 +320,22 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
 	case *tree.DTuple:
 		return encodeUntaggedTuple(t, b, encoding.NoColumnID, nil)
+	case *tree.DJsonpath:
+		return encoding.EncodeUntaggedBytesValue(b, []byte(t.Path.String())), nil
+	case *tree.DRange:
+		encoded, err := encodeRange(t, nil /* appendTo */, nil /* scratch */)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DMultirange:
+		encoded, err := encodeMultirange(t, nil /* appendTo */, nil /* scratch */)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DPGVector:
+		return encoding.EncodeUntaggedBytesValue(b, vector.Encode(nil, t.T)), nil
 	case *tree.DTSQuery:
 		encoded := tsearch.EncodeTSQueryPGBinary(nil, t.TSQuery)
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
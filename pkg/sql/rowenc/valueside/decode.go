@@ -18,8 +18,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -189,6 +191,46 @@ func DecodeUntaggedDatum(
 			return nil, b, err
 		}
 		return a.NewDJSON(tree.DJSON{JSON: j}), b, nil
+	case types.JsonpathFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		p, err := jsonpath.Parse(string(data))
+		if err != nil {
+			return nil, b, err
+		}
+		return tree.NewDJsonpath(p), b, nil
+	case types.RangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		r, _, err := decodeRange(a, t, data)
+		if err != nil {
+			return nil, b, err
+		}
+		return r, b, nil
+	case types.MultirangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		m, _, err := decodeMultirange(a, t, data)
+		if err != nil {
+			return nil, b, err
+		}
+		return m, b, nil
+	case types.PGVectorFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		v, err := vector.Decode(data)
+		if err != nil {
+			return nil, b, err
+		}
+		return tree.NewDPGVector(v), b, nil
 	case types.TSQueryFamily:
 		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
 		if err != nil {
@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -81,6 +82,10 @@ func Encode(appendTo []byte, colID ColumnIDDelta, val tree.Datum, scratch []byte
 			return nil, err
 		}
 		return encoding.EncodeJSONValue(appendTo, uint32(colID), encoded), nil
+	case *tree.DJsonpath:
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), []byte(t.Path.String())), nil
+	case *tree.DPGVector:
+		return encoding.EncodePGVectorValue(appendTo, uint32(colID), vector.Encode(scratch, t.T)), nil
 	case *tree.DTSQuery:
 		encoded, err := tsearch.EncodeTSQuery(scratch, t.TSQuery)
 		if err != nil {
@@ -93,6 +98,18 @@ func Encode(appendTo []byte, colID ColumnIDDelta, val tree.Datum, scratch []byte
 			return nil, err
 		}
 		return encoding.EncodeTSVectorValue(appendTo, uint32(colID), encoded), nil
+	case *tree.DRange:
+		r, err := encodeRange(t, nil /* appendTo */, scratch)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), r), nil
+	case *tree.DMultirange:
+		m, err := encodeMultirange(t, nil /* appendTo */, scratch)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), m), nil
 	case *tree.DArray:
 		a, err := encodeArray(t, scratch)
 		if err != nil {
@@ -18,9 +18,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -142,6 +144,34 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(data)
 			return r, nil
 		}
+	case types.JsonpathFamily:
+		if v, ok := val.(*tree.DJsonpath); ok {
+			r.SetBytes([]byte(v.Path.String()))
+			return r, nil
+		}
+	case types.RangeFamily:
+		if v, ok := val.(*tree.DRange); ok {
+			data, err := encodeRange(v, nil /* appendTo */, nil /* scratch */)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(data)
+			return r, nil
+		}
+	case types.MultirangeFamily:
+		if v, ok := val.(*tree.DMultirange); ok {
+			data, err := encodeMultirange(v, nil /* appendTo */, nil /* scratch */)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(data)
+			return r, nil
+		}
+	case types.PGVectorFamily:
+		if v, ok := val.(*tree.DPGVector); ok {
+			r.SetBytes(vector.Encode(nil, v.T))
+			return r, nil
+		}
 	case types.TSQueryFamily:
 		if v, ok := val.(*tree.DTSQuery); ok {
 			data := tsearch.EncodeTSQueryPGBinary(nil, v.TSQuery)
@@ -379,6 +409,36 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return tree.NewDJSON(jsonDatum), nil
+	case types.JsonpathFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		p, err := jsonpath.Parse(string(v))
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDJsonpath(p), nil
+	case types.RangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		datum, _, err := decodeRange(a, typ, v)
+		if err != nil {
+			return nil, err
+		}
+		return datum, nil
+	case types.MultirangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		datum, _, err := decodeMultirange(a, typ, v)
+		if err != nil {
+			return nil, err
+		}
+		return datum, nil
 	case types.TSQueryFamily:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -389,6 +449,16 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return tree.NewDTSQuery(vec), nil
+	case types.PGVectorFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		vec, err := vector.Decode(v)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDPGVector(vec), nil
 	case types.TSVectorFamily:
 		v, err := value.GetBytes()
 		if err != nil {
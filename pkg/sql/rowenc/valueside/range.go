@@ -0,0 +1,122 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// Flags describing the shape of a value encoded range. They match the flags
+// of the Postgres binary format of ranges.
+const (
+	rangeEmpty          byte = 0x01
+	rangeLowerInclusive byte = 0x02
+	rangeUpperInclusive byte = 0x04
+	rangeLowerInfinite  byte = 0x08
+	rangeUpperInfinite  byte = 0x10
+)
+
+// encodeRange produces the value encoding for a range. The encoding is a
+// flags byte followed by the value encoding of every finite bound.
+func encodeRange(r *tree.DRange, appendTo []byte, scratch []byte) ([]byte, error) {
+	var flags byte
+	switch {
+	case r.Empty:
+		flags = rangeEmpty
+	default:
+		if r.Lower.IsInfinite() {
+			flags |= rangeLowerInfinite
+		} else if r.Lower.Inclusive {
+			flags |= rangeLowerInclusive
+		}
+		if r.Upper.IsInfinite() {
+			flags |= rangeUpperInfinite
+		} else if r.Upper.Inclusive {
+			flags |= rangeUpperInclusive
+		}
+	}
+	appendTo = append(appendTo, flags)
+	var err error
+	if flags&(rangeEmpty|rangeLowerInfinite) == 0 {
+		if appendTo, err = Encode(appendTo, NoColumnID, r.Lower.Val, scratch); err != nil {
+			return nil, err
+		}
+	}
+	if flags&(rangeEmpty|rangeUpperInfinite) == 0 {
+		if appendTo, err = Encode(appendTo, NoColumnID, r.Upper.Val, scratch); err != nil {
+			return nil, err
+		}
+	}
+	return appendTo, nil
+}
+
+// decodeRange decodes a range from its value encoding. It is the counterpart
+// of encodeRange().
+func decodeRange(a *tree.DatumAlloc, t *types.T, b []byte) (*tree.DRange, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (empty)")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&rangeEmpty != 0 {
+		return tree.NewDEmptyRange(t), b, nil
+	}
+	var lower, upper tree.RangeBound
+	var err error
+	if flags&rangeLowerInfinite == 0 {
+		if lower.Val, b, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+		lower.Inclusive = flags&rangeLowerInclusive != 0
+	}
+	if flags&rangeUpperInfinite == 0 {
+		if upper.Val, b, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+		upper.Inclusive = flags&rangeUpperInclusive != 0
+	}
+	return tree.NewDRangeUnchecked(t, lower, upper, false /* empty */), b, nil
+}
+
+// encodeMultirange produces the value encoding for a multirange. The encoding
+// is the number of ranges followed by the encoding of every range.
+func encodeMultirange(m *tree.DMultirange, appendTo []byte, scratch []byte) ([]byte, error) {
+	appendTo = encoding.EncodeNonsortingUvarint(appendTo, uint64(len(m.Ranges)))
+	var err error
+	for _, r := range m.Ranges {
+		if appendTo, err = encodeRange(r, appendTo, scratch); err != nil {
+			return nil, err
+		}
+	}
+	return appendTo, nil
+}
+
+// decodeMultirange decodes a multirange from its value encoding. It is the
+// counterpart of encodeMultirange().
+func decodeMultirange(
+	a *tree.DatumAlloc, t *types.T, b []byte,
+) (*tree.DMultirange, []byte, error) {
+	b, _, n, err := encoding.DecodeNonsortingUvarint(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	rangeTyp := types.MultirangeRange(t)
+	ranges := make([]*tree.DRange, n)
+	for i := range ranges {
+		if ranges[i], b, err = decodeRange(a, rangeTyp, b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return tree.NewDMultirangeUnchecked(t, ranges), b, nil
+}
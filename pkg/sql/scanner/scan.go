@@ -292,6 +292,24 @@ func (s *Scanner) Scan(lval ScanSymType) {
 				s.pos++
 				lval.SetID(lexbase.INET_CONTAINED_BY_OR_EQUALS)
 				return
+			case '%': // <<%
+				s.pos++
+				lval.SetID(lexbase.STRICT_WORD_SIMILARITY_OP)
+				return
+			case '-': // <<-
+				if s.peekN(1) == '>' {
+					// <<->
+					s.pos += 2
+					lval.SetID(lexbase.WORD_SIMILARITY_DIST_OP)
+					return
+				}
+			case '<': // <<<
+				if s.peekN(1) == '-' && s.peekN(2) == '>' {
+					// <<<->
+					s.pos += 3
+					lval.SetID(lexbase.STRICT_WORD_SIMILARITY_DIST_OP)
+					return
+				}
 			}
 			lval.SetID(lexbase.LSHIFT)
 			return
@@ -300,13 +318,64 @@ func (s *Scanner) Scan(lval ScanSymType) {
 			lval.SetID(lexbase.NOT_EQUALS)
 			return
 		case '=': // <=
+			if s.peekN(1) == '>' {
+				// <=>
+				s.pos += 2
+				lval.SetID(lexbase.COS_DISTANCE)
+				return
+			}
 			s.pos++
 			lval.SetID(lexbase.LESS_EQUALS)
 			return
+		case '#': // <#
+			if s.peekN(1) == '>' {
+				// <#>
+				s.pos += 2
+				lval.SetID(lexbase.NEG_INNER_PRODUCT)
+				return
+			}
 		case '@': // <@
 			s.pos++
 			lval.SetID(lexbase.CONTAINED_BY)
 			return
+		case '%': // <%
+			s.pos++
+			lval.SetID(lexbase.WORD_SIMILARITY_OP)
+			return
+		case '-': // <-
+			if s.peekN(1) == '>' {
+				if s.peekN(2) == '>' {
+					if s.peekN(3) == '>' {
+						// <->>>
+						s.pos += 4
+						lval.SetID(lexbase.STRICT_WORD_SIMILARITY_DIST_COMMUTATOR_OP)
+						return
+					}
+					// <->>
+					s.pos += 3
+					lval.SetID(lexbase.WORD_SIMILARITY_DIST_COMMUTATOR_OP)
+					return
+				}
+				// <->
+				s.pos += 2
+				lval.SetID(lexbase.DISTANCE)
+				return
+			}
+		}
+		return
+
+	case '%':
+		switch s.peek() {
+		case '>': // %>
+			if s.peekN(1) == '>' {
+				// %>>
+				s.pos += 2
+				lval.SetID(lexbase.STRICT_WORD_SIMILARITY_COMMUTATOR_OP)
+				return
+			}
+			s.pos++
+			lval.SetID(lexbase.WORD_SIMILARITY_COMMUTATOR_OP)
+			return
 		}
 		return
 
@@ -391,6 +460,10 @@ func (s *Scanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.AT_AT)
 			return
+		case '?': // @?
+			s.pos++
+			lval.SetID(lexbase.JSON_PATH_EXISTS)
+			return
 		}
 		return
 
@@ -415,6 +488,12 @@ func (s *Scanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.FETCHVAL)
 			return
+		case '|': // -|-
+			if s.peekN(1) == '-' {
+				s.pos += 2
+				lval.SetID(lexbase.RANGE_ADJACENT)
+				return
+			}
 		}
 		return
 
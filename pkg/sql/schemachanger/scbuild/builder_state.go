@@ -665,7 +665,7 @@ func (b *builderState) ResolveUserDefinedTypeType(
 	case descpb.TypeDescriptor_ENUM:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
-	case descpb.TypeDescriptor_COMPOSITE:
+	case descpb.TypeDescriptor_COMPOSITE, descpb.TypeDescriptor_DOMAIN:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
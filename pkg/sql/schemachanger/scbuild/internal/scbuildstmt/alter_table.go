@@ -51,10 +51,13 @@ var supportedAlterTableStatements = map[reflect.Type]supportedAlterTableCommand{
 	reflect.TypeOf((*tree.AlterTableAddConstraint)(nil)): {fn: alterTableAddConstraint, on: true, extraChecks: func(
 		t *tree.AlterTableAddConstraint,
 	) bool {
-		// Support ALTER TABLE ... ADD PRIMARY KEY
+		// Support ALTER TABLE ... ADD PRIMARY KEY, and ADD CONSTRAINT UNIQUE
+		// WITHOUT INDEX unless the constraint is DEFERRABLE, which the element
+		// model cannot express yet.
 		if d, ok := t.ConstraintDef.(*tree.UniqueConstraintTableDef); ok && d.PrimaryKey && t.ValidationBehavior == tree.ValidationDefault {
 			return true
-		} else if ok && d.WithoutIndex && t.ValidationBehavior == tree.ValidationDefault {
+		} else if ok && d.WithoutIndex && t.ValidationBehavior == tree.ValidationDefault &&
+			d.Deferrable == tree.ConstraintNotDeferrable {
 			return true
 		}
 
@@ -63,8 +66,10 @@ var supportedAlterTableStatements = map[reflect.Type]supportedAlterTableCommand{
 			return true
 		}
 
-		// Support ALTER TABLE ... ADD CONSTRAINT FOREIGN KEY
-		if _, ok := t.ConstraintDef.(*tree.ForeignKeyConstraintTableDef); ok && t.ValidationBehavior == tree.ValidationDefault {
+		// Support ALTER TABLE ... ADD CONSTRAINT FOREIGN KEY, unless the
+		// constraint is DEFERRABLE, which the element model cannot express yet.
+		if d, ok := t.ConstraintDef.(*tree.ForeignKeyConstraintTableDef); ok && t.ValidationBehavior == tree.ValidationDefault &&
+			d.Deferrable == tree.ConstraintNotDeferrable {
 			return true
 		}
 
@@ -142,7 +142,7 @@ func alterTableAddColumn(
 
 	spec.colType.TypeT = b.ResolveTypeRef(d.Type)
 	if spec.colType.TypeT.Type.UserDefined() {
-		typeID := typedesc.UserDefinedTypeOIDToID(spec.colType.TypeT.Type.Oid())
+		typeID := typedesc.UserDefinedTypeOIDToID(spec.colType.TypeT.Type.UserDefinedOID())
 		_, _, tableNamespace := scpb.FindNamespace(b.QueryByID(tbl.TableID))
 		_, _, typeNamespace := scpb.FindNamespace(b.QueryByID(typeID))
 		if typeNamespace.DatabaseID != tableNamespace.DatabaseID {
@@ -352,6 +352,10 @@ func processColNodeType(
 			}
 			invertedKind = catpb.InvertedIndexColumnKind_TRIGRAM
 			b.IncrementSchemaChangeIndexCounter("trigram_inverted")
+		case types.PGVectorFamily:
+			// Vector indexes carry their number of IVF lists in the index
+			// descriptor, which the index elements cannot represent yet.
+			panic(scerrors.NotImplementedErrorf(n, "vector indexes are not supported"))
 
 		}
 		relationElts := b.QueryByID(indexSpec.secondary.TableID)
@@ -20,23 +20,55 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/errors"
 )
 
 // DropType implements DROP TYPE.
 func DropType(b BuildCtx, n *tree.DropType) {
-	if n.DropBehavior == tree.DropCascade {
-		panic(scerrors.NotImplementedErrorf(n, "DROP TYPE CASCADE is not yet supported"))
+	dropTypes(b, n, n.Names, n.IfExists, n.DropBehavior, false /* domains */)
+}
+
+// DropDomain implements DROP DOMAIN.
+func DropDomain(b BuildCtx, n *tree.DropDomain) {
+	dropTypes(b, n, n.Names, n.IfExists, n.DropBehavior, true /* domains */)
+}
+
+func dropTypes(
+	b BuildCtx,
+	n tree.Statement,
+	names []*tree.UnresolvedObjectName,
+	ifExists bool,
+	dropBehavior tree.DropBehavior,
+	domains bool,
+) {
+	if dropBehavior == tree.DropCascade {
+		panic(scerrors.NotImplementedErrorf(n, "%s CASCADE is not yet supported", n.StatementTag()))
 	}
 	var toCheckBackrefs []catid.DescID
 	arrayTypesToAlsoCheck := make(map[catid.DescID]catid.DescID)
-	for _, name := range n.Names {
+	for _, name := range names {
 		elts := b.ResolveUserDefinedTypeType(name, ResolveParams{
-			IsExistenceOptional: n.IfExists,
+			IsExistenceOptional: ifExists,
 			RequiredPrivilege:   privilege.DROP,
 		})
+		if elts == nil {
+			continue
+		}
+		_, _, domain := scpb.FindDomainType(elts)
+		if domains && domain == nil {
+			panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", name))
+		}
+		if !domains && domain != nil {
+			panic(errors.WithHint(
+				pgerror.Newf(pgcode.WrongObjectType, "%q is a domain", name),
+				"Use DROP DOMAIN to remove a domain."))
+		}
 		var typ scpb.Element
 		var typeID, arrayTypeID catid.DescID
-		if _, _, enum := scpb.FindEnumType(elts); enum != nil {
+		if domain != nil {
+			typeID, arrayTypeID = domain.TypeID, domain.ArrayTypeID
+			typ = domain
+		} else if _, _, enum := scpb.FindEnumType(elts); enum != nil {
 			b.IncrementEnumCounter(sqltelemetry.EnumDrop)
 			typeID, arrayTypeID = enum.TypeID, enum.ArrayTypeID
 			typ = enum
@@ -52,7 +84,7 @@ func DropType(b BuildCtx, n *tree.DropType) {
 		tn := tree.MakeTypeNameWithPrefix(prefix, name.Object())
 		b.SetUnresolvedNameAnnotation(name, &tn)
 		// Drop the type.
-		if n.DropBehavior == tree.DropCascade {
+		if dropBehavior == tree.DropCascade {
 			dropCascadeDescriptor(b, typeID)
 		} else {
 			if dropRestrictDescriptor(b, typeID) {
@@ -201,6 +201,11 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 				Name:            comp.GetElementLabel(i),
 			})
 		}
+	} else if domain := typ.AsDomainTypeDescriptor(); domain != nil {
+		w.ev(descriptorStatus(typ), &scpb.DomainType{
+			TypeID:      domain.GetID(),
+			ArrayTypeID: domain.GetArrayTypeID(),
+		})
 	} else {
 		panic(errors.AssertionFailedf("unsupported type kind %q", typ.GetKind()))
 	}
@@ -214,6 +219,20 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 }
 
 func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
+	// Table inheritance is not modeled by any element, so fall back to the
+	// legacy schema changer for any table that has parents or children.
+	if len(tbl.GetInherits()) > 0 || len(tbl.GetInheritedBy()) > 0 {
+		panic(scerrors.NotImplementedErrorf(nil, "table inheritance not supported in declarative schema changer"))
+	}
+	// Triggers are not modeled by any element, so fall back to the legacy
+	// schema changer for any table that has them.
+	if len(tbl.GetTriggers()) > 0 {
+		panic(scerrors.NotImplementedErrorf(nil, "triggers not supported in declarative schema changer"))
+	}
+	// Foreign tables are not modeled by any element either.
+	if tbl.IsForeignTable() {
+		panic(scerrors.NotImplementedErrorf(nil, "foreign tables not supported in declarative schema changer"))
+	}
 	switch {
 	case tbl.IsSequence():
 		w.ev(descriptorStatus(tbl), &scpb.Sequence{
@@ -485,6 +504,12 @@ func (w *walkCtx) walkIndex(tbl catalog.TableDescriptor, idx catalog.Index) {
 		panic(errors.NewAssertionErrorWithWrappedErrf(err, "index %q in table %q (%d)",
 			idx.GetName(), tbl.GetName(), tbl.GetID()))
 	}
+	// The number of IVF lists of a vector index and their centroids are not
+	// modeled by any element, so fall back to the legacy schema changer rather
+	// than losing them.
+	if idx.IndexDesc().VectorLists != 0 || len(idx.IndexDesc().VectorCentroids) > 0 {
+		panic(scerrors.NotImplementedErrorf(nil, "vector index lists not supported in declarative schema changer"))
+	}
 	{
 		cpy := idx.IndexDescDeepCopy()
 
@@ -593,6 +618,10 @@ func (w *walkCtx) walkUniqueWithoutIndexConstraint(
 ) {
 	// TODO(postamar): proper handling of constraint status
 
+	// The operators of exclusion constraints are not modeled by the element.
+	if c.IsExclusion() {
+		panic(scerrors.NotImplementedErrorf(nil, "exclusion constraints not supported in declarative schema changer"))
+	}
 	w.ev(scpb.Status_PUBLIC, &scpb.UniqueWithoutIndexConstraint{
 		TableID:      tbl.GetID(),
 		ConstraintID: c.GetConstraintID(),
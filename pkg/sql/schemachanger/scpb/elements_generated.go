@@ -606,6 +606,37 @@ func FindDatabaseRoleSetting(b ElementStatusIterator) (current Status, target Ta
 	return current, target, element
 }
 
+func (e DomainType) element() {}
+
+// ForEachDomainType iterates over elements of type DomainType.
+func ForEachDomainType(
+	b ElementStatusIterator, fn func(current Status, target TargetStatus, e *DomainType),
+) {
+  if b == nil {
+    return
+  }
+	b.ForEachElementStatus(func(current Status, target TargetStatus, e Element) {
+		if elt, ok := e.(*DomainType); ok {
+			fn(current, target, elt)
+		}
+	})
+}
+
+// FindDomainType finds the first element of type DomainType.
+func FindDomainType(b ElementStatusIterator) (current Status, target TargetStatus, element *DomainType) {
+  if b == nil {
+    return current, target, element
+  }
+	b.ForEachElementStatus(func(c Status, t TargetStatus, e Element) {
+		if elt, ok := e.(*DomainType); ok {
+			element = elt
+			current = c
+			target = t
+		}
+	})
+	return current, target, element
+}
+
 func (e EnumType) element() {}
 
 // ForEachEnumType iterates over elements of type EnumType.
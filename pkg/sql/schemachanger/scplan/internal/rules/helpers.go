@@ -264,7 +264,7 @@ func ForEachElementInActiveVersion(
 func IsDescriptor(e scpb.Element) bool {
 	switch e.(type) {
 	case *scpb.Database, *scpb.Schema, *scpb.Table, *scpb.View, *scpb.Sequence,
-		*scpb.AliasType, *scpb.EnumType, *scpb.CompositeType:
+		*scpb.AliasType, *scpb.EnumType, *scpb.CompositeType, *scpb.DomainType:
 		return true
 	}
 	return false
@@ -353,7 +353,7 @@ func IsWithExpression(element scpb.Element) bool {
 
 func IsTypeDescriptor(element scpb.Element) bool {
 	switch element.(type) {
-	case *scpb.EnumType, *scpb.AliasType, *scpb.CompositeType:
+	case *scpb.EnumType, *scpb.AliasType, *scpb.CompositeType, *scpb.DomainType:
 		return true
 	default:
 		return false
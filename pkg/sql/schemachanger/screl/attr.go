@@ -127,6 +127,9 @@ var elementSchemaOptions = []rel.SchemaOption{
 	rel.EntityMapping(t((*scpb.CompositeType)(nil)),
 		rel.EntityAttr(DescID, "TypeID"),
 	),
+	rel.EntityMapping(t((*scpb.DomainType)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+	),
 	rel.EntityMapping(t((*scpb.CompositeTypeAttrName)(nil)),
 		rel.EntityAttr(DescID, "CompositeTypeID"),
 		rel.EntityAttr(Name, "Name"),
@@ -405,7 +405,7 @@ func createIndexCheckOperations(
 // createConstraintCheckOperations will return all of the constraints
 // that are being checked. If constraintNames is nil, then all
 // constraints are returned.
-// Only SQL CHECK, FOREIGN KEY, and UNIQUE constraints are supported.
+// Only SQL CHECK, FOREIGN KEY, UNIQUE and exclusion constraints are supported.
 func createConstraintCheckOperations(
 	ctx context.Context,
 	p *planner,
@@ -45,6 +45,9 @@ const (
 	// UniqueConstraintViolation occurs when a row in a table is violating
 	// a unique constraint.
 	UniqueConstraintViolation = "unique_constraint_violation"
+	// ExclusionConstraintViolation occurs when a row in a table conflicts with
+	// another row under an exclusion constraint.
+	ExclusionConstraintViolation = "exclusion_constraint_violation"
 )
 
 // Error contains the details on the scrub error that was caught.
@@ -721,3 +721,54 @@ INSERT INTO db.t VALUES (1, 2), (2,3);
 	time.Sleep(1 * time.Millisecond)
 	scrubtestutils.RunScrub(t, db, `EXPERIMENTAL SCRUB TABLE db.t AS OF SYSTEM TIME '-1ms' WITH OPTIONS CONSTRAINT ALL`, exp)
 }
+
+// TestScrubExclusionConstraint tests SCRUB on a table that violates an
+// exclusion constraint.
+func TestScrubExclusionConstraint(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+
+	// Create the table and row entries. Rows 1 and 2 overlap, row 3 is in
+	// another room and row 4 is excluded by the predicate of the constraint.
+	if _, err := db.Exec(`
+CREATE DATABASE db;
+CREATE TABLE db.t (
+	id INT PRIMARY KEY,
+	room INT,
+	slots INT[],
+	active BOOL
+);
+INSERT INTO db.t VALUES
+	(1, 1, ARRAY[1, 2], true),
+	(2, 1, ARRAY[2, 3], true),
+	(3, 2, ARRAY[1, 2], true),
+	(4, 1, ARRAY[1], false);
+ALTER TABLE db.t ADD CONSTRAINT no_overlap
+	EXCLUDE (room WITH =, slots WITH &&) WHERE (active) NOT VALID;
+`); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Run SCRUB and find the rows that conflict with each other.
+	exp := []scrubtestutils.ExpectedScrubResult{
+		{
+			ErrorType:    scrub.ExclusionConstraintViolation,
+			Database:     "db",
+			Table:        "t",
+			PrimaryKey:   "(1)",
+			DetailsRegex: `{"constraint_name": "no_overlap", "row_data": {"active": "true", "id": "1", "room": "1", "slots": "ARRAY\[1,2\]"}`,
+		},
+		{
+			ErrorType:    scrub.ExclusionConstraintViolation,
+			Database:     "db",
+			Table:        "t",
+			PrimaryKey:   "(2)",
+			DetailsRegex: `{"constraint_name": "no_overlap", "row_data": {"active": "true", "id": "2", "room": "1", "slots": "ARRAY\[2,3\]"}`,
+		},
+	}
+	scrubtestutils.RunScrub(t, db, `EXPERIMENTAL SCRUB TABLE db.t WITH OPTIONS CONSTRAINT ALL`, exp)
+	time.Sleep(1 * time.Millisecond)
+	scrubtestutils.RunScrub(t, db, `EXPERIMENTAL SCRUB TABLE db.t AS OF SYSTEM TIME '-1ms' WITH OPTIONS CONSTRAINT (no_overlap)`, exp)
+}
@@ -24,7 +24,7 @@ import (
 )
 
 // sqlUniqueConstraintCheckOperation is a check which validates a
-// UNIQUE constraint on a table.
+// UNIQUE or exclusion constraint on a table.
 type sqlUniqueConstraintCheckOperation struct {
 	tableName  *tree.TableName
 	tableDesc  catalog.TableDescriptor
@@ -33,6 +33,9 @@ type sqlUniqueConstraintCheckOperation struct {
 	name       string
 	asOf       hlc.Timestamp
 	predicate  string
+	// exclusionOps are the comparison operators of an exclusion constraint,
+	// one per column in cols. It is nil for UNIQUE constraints.
+	exclusionOps []string
 
 	// columns is a list of the columns returned in the query result
 	// tree.Datums.
@@ -82,6 +85,9 @@ func newSQLUniqueWithoutIndexConstraintCheckOperation(
 		name:       constraint.GetName(),
 		predicate:  constraint.GetPredicate(),
 	}
+	if constraint.IsExclusion() {
+		op.exclusionOps = constraint.ExclusionOperators()
+	}
 	return &op
 }
 
@@ -90,29 +96,17 @@ func newSQLUniqueWithoutIndexConstraintCheckOperation(
 // then runs in the distSQL execution engine.
 func (o *sqlUniqueConstraintCheckOperation) Start(params runParams) error {
 	ctx := params.ctx
-	// Create a query of the form:
-	// SELECT a,b,c FROM db.t AS tbl1 JOIN
-	//   (SELECT b, c FROM db.t GROUP BY b, c
-	//     WHERE b IS NOT NULL AND c IS NOT NULL [AND partial index predicate]
-	//     HAVING COUNT(*) > 1) as tbl2
-	//   ON tbl1.b = tbl2.b AND tbl1.c = tbl2.c;
-	// Where a, b, and c are all the public columns in table db.t and b and c are
-	// the unique columns. We select all public columns to provide detailed
-	// information if there are constraint violations.
-
 	// Collect all the columns.
 	o.columns = o.tableDesc.PublicColumns()
 
 	// Make a list of all the unique column names.
 	keyCols := make([]string, len(o.cols))
-	matchers := make([]string, len(o.cols))
 	for i := 0; i < len(o.cols); i++ {
 		col, err := catalog.MustFindColumnByID(o.tableDesc, o.cols[i])
 		if err != nil {
 			return err
 		}
 		keyCols[i] = tree.NameString(col.GetName())
-		matchers[i] = fmt.Sprintf("tbl1.%[1]s=tbl2.%[1]s", keyCols[i])
 	}
 	// Make a list of all the public column names.
 	pCols := make([]string, len(o.columns))
@@ -124,23 +118,46 @@ func (o *sqlUniqueConstraintCheckOperation) Start(params runParams) error {
 		asOf = fmt.Sprintf("AS OF SYSTEM TIME '%s'", o.asOf.AsOfSystemTime())
 	}
 	tableName := fmt.Sprintf("%s.%s", o.tableName.Catalog(), o.tableName.Table())
-	dup, _, err := duplicateRowQuery(o.tableDesc, o.cols, o.predicate,
-		0 /* indexIDForValidation */, false /* limitResults */)
-	if err != nil {
-		return err
-	}
 
-	sel := fmt.Sprintf(`SELECT %[1]s 
+	var sel string
+	if o.exclusionOps != nil {
+		var err error
+		sel, err = o.exclusionQuery(keyCols, pCols, tableName, asOf)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Create a query of the form:
+		// SELECT a,b,c FROM db.t AS tbl1 JOIN
+		//   (SELECT b, c FROM db.t GROUP BY b, c
+		//     WHERE b IS NOT NULL AND c IS NOT NULL [AND partial index predicate]
+		//     HAVING COUNT(*) > 1) as tbl2
+		//   ON tbl1.b = tbl2.b AND tbl1.c = tbl2.c;
+		// Where a, b, and c are all the public columns in table db.t and b and c
+		// are the unique columns. We select all public columns to provide
+		// detailed information if there are constraint violations.
+		matchers := make([]string, len(keyCols))
+		for i := range keyCols {
+			matchers[i] = fmt.Sprintf("tbl1.%[1]s=tbl2.%[1]s", keyCols[i])
+		}
+		dup, _, err := duplicateRowQuery(o.tableDesc, o.cols, o.predicate,
+			0 /* indexIDForValidation */, false /* limitResults */)
+		if err != nil {
+			return err
+		}
+
+		sel = fmt.Sprintf(`SELECT %[1]s 
 FROM %[2]s AS tbl1 JOIN 
 (%[3]s) AS tbl2 
 ON %[4]s
 %[5]s `,
-		strings.Join(pCols, ","),        // 1
-		tableName,                       // 2
-		dup,                             // 3
-		strings.Join(matchers, " AND "), // 4
-		asOf,                            // 5
-	)
+			strings.Join(pCols, ","),        // 1
+			tableName,                       // 2
+			dup,                             // 3
+			strings.Join(matchers, " AND "), // 4
+			asOf,                            // 5
+		)
+	}
 
 	rows, err := params.p.InternalSQLTxn().QueryBuffered(
 		ctx, "scrub-unique", params.p.txn, sel,
@@ -156,6 +173,63 @@ ON %[4]s
 	return err
 }
 
+// exclusionQuery returns a query which finds every row that conflicts with
+// another row under the exclusion constraint. For the constraint
+// EXCLUDE (b WITH =, c WITH &&) on table db.t with public columns a, b and c
+// and primary key a, the query is:
+//
+//	SELECT tbl1.a, tbl1.b, tbl1.c FROM db.t AS tbl1
+//	WHERE b IS NOT NULL AND c IS NOT NULL [AND partial constraint predicate]
+//	AND EXISTS (
+//	  SELECT 1 FROM db.t AS tbl2
+//	  WHERE b IS NOT NULL AND c IS NOT NULL [AND partial constraint predicate]
+//	  AND tbl1.b = tbl2.b AND tbl1.c && tbl2.c AND (tbl1.a) != (tbl2.a)
+//	)
+//
+// The unqualified columns in the subquery refer to tbl2, which is the
+// innermost scope.
+func (o *sqlUniqueConstraintCheckOperation) exclusionQuery(
+	keyCols, pCols []string, tableName, asOf string,
+) (string, error) {
+	pkColNames, err := catalog.ColumnNamesForIDs(
+		o.tableDesc, o.tableDesc.GetPrimaryIndex().IndexDesc().KeyColumnIDs,
+	)
+	if err != nil {
+		return "", err
+	}
+	notNull := make([]string, 0, len(keyCols)+1)
+	conflicts := make([]string, len(keyCols))
+	for i, c := range keyCols {
+		notNull = append(notNull, fmt.Sprintf("%s IS NOT NULL", c))
+		conflicts[i] = fmt.Sprintf("tbl1.%[1]s %[2]s tbl2.%[1]s", c, o.exclusionOps[i])
+	}
+	if o.predicate != "" {
+		notNull = append(notNull, fmt.Sprintf("(%s)", o.predicate))
+	}
+	leftPK := make([]string, len(pkColNames))
+	rightPK := make([]string, len(pkColNames))
+	for i, n := range pkColNames {
+		leftPK[i] = "tbl1." + tree.NameString(n)
+		rightPK[i] = "tbl2." + tree.NameString(n)
+	}
+
+	return fmt.Sprintf(`SELECT %[1]s 
+FROM %[2]s AS tbl1 
+WHERE %[3]s AND EXISTS (
+SELECT 1 FROM %[2]s AS tbl2 
+WHERE %[3]s AND %[4]s AND (%[5]s) != (%[6]s)
+)
+%[7]s `,
+		strings.Join(pCols, ","),         // 1
+		tableName,                        // 2
+		strings.Join(notNull, " AND "),   // 3
+		strings.Join(conflicts, " AND "), // 4
+		strings.Join(leftPK, ", "),       // 5
+		strings.Join(rightPK, ", "),      // 6
+		asOf,                             // 7
+	), nil
+}
+
 // Next implements the checkOperation interface.
 func (o *sqlUniqueConstraintCheckOperation) Next(params runParams) (tree.Datums, error) {
 	row := o.run.rows[o.run.rowIndex]
@@ -185,9 +259,13 @@ func (o *sqlUniqueConstraintCheckOperation) Next(params runParams) (tree.Datums,
 		return nil, err
 	}
 
+	errorType := scrub.UniqueConstraintViolation
+	if o.exclusionOps != nil {
+		errorType = scrub.ExclusionConstraintViolation
+	}
 	return tree.Datums{
 		tree.DNull, /* job_uuid */
-		tree.NewDString(scrub.UniqueConstraintViolation),
+		tree.NewDString(errorType),
 		tree.NewDString(o.tableName.Catalog()),
 		tree.NewDString(o.tableName.Table()),
 		tree.NewDString(primaryKeyDatums.String()),
@@ -20,6 +20,7 @@ import (
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geomfn"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/geo/geos"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -545,6 +546,78 @@ var aggregates = map[string]builtinDefinition{
 	"st_memunion":   makeSTUnionBuiltin(),
 	"st_collect":    makeSTCollectBuiltin(),
 	"st_memcollect": makeSTCollectBuiltin(),
+	"st_clusterintersecting": makeBuiltin(
+		tree.FunctionProperties{
+			Class:                   tree.AggregateClass,
+			AvailableOnPublicSchema: true,
+		},
+		makeAggOverload(
+			[]*types.T{types.Geometry},
+			types.MakeArray(types.Geometry),
+			func(
+				params []*types.T, evalCtx *eval.Context, arguments tree.Datums,
+			) eval.AggregateFunc {
+				return &stClusterAgg{
+					acc: evalCtx.Planner.Mon().MakeBoundAccount(),
+				}
+			},
+			infoBuilder{
+				info: "Groups the provided geometries into clusters of geometries connected through " +
+					"intersections, and returns an array with a GeometryCollection for each cluster.",
+			}.String(),
+			volatility.Immutable,
+			true, /* calledOnNullInput */
+		),
+	),
+	"st_clusterwithin": makeBuiltin(
+		tree.FunctionProperties{
+			Class:                   tree.AggregateClass,
+			AvailableOnPublicSchema: true,
+		},
+		makeAggOverload(
+			[]*types.T{types.Geometry, types.Float},
+			types.MakeArray(types.Geometry),
+			func(
+				params []*types.T, evalCtx *eval.Context, arguments tree.Datums,
+			) eval.AggregateFunc {
+				return &stClusterAgg{
+					acc:          evalCtx.Planner.Mon().MakeBoundAccount(),
+					withDistance: true,
+				}
+			},
+			infoBuilder{
+				info: "Groups the provided geometries into clusters of geometries connected through " +
+					"pairs within the given distance of each other, and returns an array with a " +
+					"GeometryCollection for each cluster.",
+			}.String(),
+			volatility.Immutable,
+			true, /* calledOnNullInput */
+		),
+	),
+	"st_polygonize": makeBuiltin(
+		tree.FunctionProperties{
+			Class:                   tree.AggregateClass,
+			AvailableOnPublicSchema: true,
+		},
+		makeAggOverload(
+			[]*types.T{types.Geometry},
+			types.Geometry,
+			func(
+				params []*types.T, evalCtx *eval.Context, arguments tree.Datums,
+			) eval.AggregateFunc {
+				return &stPolygonizeAgg{
+					acc: evalCtx.Planner.Mon().MakeBoundAccount(),
+				}
+			},
+			infoBuilder{
+				info: "Returns a GeometryCollection of the polygons formed by the linework of the " +
+					"provided geometries.",
+				libraryUsage: usesGEOS,
+			}.String(),
+			volatility.Immutable,
+			true, /* calledOnNullInput */
+		),
+	),
 
 	AnyNotNull: makePrivate(makeBuiltin(aggProps(),
 		makeImmutableAggOverloadWithReturnType(
@@ -968,6 +1041,128 @@ func (agg *stUnionAgg) Size() int64 {
 	return sizeOfSTUnionAggregate
 }
 
+// stClusterAgg implements st_clusterintersecting and st_clusterwithin. The
+// clusters can only be determined once all geometries have been seen, so the
+// geometries are buffered until Result is called.
+type stClusterAgg struct {
+	acc   mon.BoundAccount
+	geoms []geo.Geometry
+	// withDistance is set for st_clusterwithin, whose second argument is the
+	// distance within which geometries are clustered together.
+	withDistance bool
+	distance     float64
+}
+
+// Add implements the AggregateFunc interface.
+func (agg *stClusterAgg) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	if firstArg == tree.DNull {
+		return nil
+	}
+	if agg.withDistance {
+		if otherArgs[0] == tree.DNull {
+			return nil
+		}
+		agg.distance = float64(tree.MustBeDFloat(otherArgs[0]))
+	}
+	if err := agg.acc.Grow(ctx, int64(firstArg.Size())); err != nil {
+		return err
+	}
+	agg.geoms = append(agg.geoms, tree.MustBeDGeometry(firstArg).Geometry)
+	return nil
+}
+
+// Result implements the AggregateFunc interface.
+func (agg *stClusterAgg) Result() (tree.Datum, error) {
+	if len(agg.geoms) == 0 {
+		return tree.DNull, nil
+	}
+	var clusters []geo.Geometry
+	var err error
+	if agg.withDistance {
+		clusters, err = geomfn.ClusterWithin(agg.geoms, agg.distance)
+	} else {
+		clusters, err = geomfn.ClusterIntersecting(agg.geoms)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ret := tree.NewDArray(types.Geometry)
+	for _, c := range clusters {
+		if err := ret.Append(tree.NewDGeometry(c)); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// Reset implements the AggregateFunc interface.
+func (agg *stClusterAgg) Reset(ctx context.Context) {
+	agg.geoms = agg.geoms[:0]
+	agg.acc.Empty(ctx)
+}
+
+// Close implements the AggregateFunc interface.
+func (agg *stClusterAgg) Close(ctx context.Context) {
+	agg.acc.Close(ctx)
+}
+
+// Size implements the AggregateFunc interface.
+func (agg *stClusterAgg) Size() int64 {
+	return sizeOfSTClusterAggregate
+}
+
+// stPolygonizeAgg implements st_polygonize. The linework is only polygonized
+// once all geometries have been seen, so the geometries are buffered until
+// Result is called.
+type stPolygonizeAgg struct {
+	acc   mon.BoundAccount
+	geoms []geo.Geometry
+}
+
+// Add implements the AggregateFunc interface.
+func (agg *stPolygonizeAgg) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	if firstArg == tree.DNull {
+		return nil
+	}
+	if err := agg.acc.Grow(ctx, int64(firstArg.Size())); err != nil {
+		return err
+	}
+	agg.geoms = append(agg.geoms, tree.MustBeDGeometry(firstArg).Geometry)
+	return nil
+}
+
+// Result implements the AggregateFunc interface.
+func (agg *stPolygonizeAgg) Result() (tree.Datum, error) {
+	if len(agg.geoms) == 0 {
+		return tree.DNull, nil
+	}
+	ret, err := geomfn.Polygonize(agg.geoms)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDGeometry(ret), nil
+}
+
+// Reset implements the AggregateFunc interface.
+func (agg *stPolygonizeAgg) Reset(ctx context.Context) {
+	agg.geoms = agg.geoms[:0]
+	agg.acc.Empty(ctx)
+}
+
+// Close implements the AggregateFunc interface.
+func (agg *stPolygonizeAgg) Close(ctx context.Context) {
+	agg.acc.Close(ctx)
+}
+
+// Size implements the AggregateFunc interface.
+func (agg *stPolygonizeAgg) Size() int64 {
+	return sizeOfSTPolygonizeAggregate
+}
+
 type stCollectAgg struct {
 	acc  mon.BoundAccount
 	coll geom.T
@@ -1268,6 +1463,8 @@ const sizeOfSTMakeLineAggregate = int64(unsafe.Sizeof(stMakeLineAgg{}))
 const sizeOfSTUnionAggregate = int64(unsafe.Sizeof(stUnionAgg{}))
 const sizeOfSTCollectAggregate = int64(unsafe.Sizeof(stCollectAgg{}))
 const sizeOfSTExtentAggregate = int64(unsafe.Sizeof(stExtentAgg{}))
+const sizeOfSTClusterAggregate = int64(unsafe.Sizeof(stClusterAgg{}))
+const sizeOfSTPolygonizeAggregate = int64(unsafe.Sizeof(stPolygonizeAgg{}))
 
 // singleDatumAggregateBase is a utility struct that helps aggregate builtins
 // that store a single datum internally track their memory usage related to
@@ -87,6 +87,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
@@ -272,6 +273,8 @@ var regularBuiltins = map[string]builtinDefinition{
 			"Converts all characters in `val` to their lower-case equivalents.",
 			volatility.Immutable,
 		),
+		rangeBoundOverload(types.AnyRange, true /* isLower */),
+		rangeBoundOverload(types.AnyMultirange, true /* isLower */),
 	),
 
 	"unaccent": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -303,6 +306,8 @@ var regularBuiltins = map[string]builtinDefinition{
 			"Converts all characters in `val` to their to their upper-case equivalents.",
 			volatility.Immutable,
 		),
+		rangeBoundOverload(types.AnyRange, false /* isLower */),
+		rangeBoundOverload(types.AnyMultirange, false /* isLower */),
 	),
 
 	"prettify_statement": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -3778,35 +3783,17 @@ value if you rely on the HLC for accuracy.`,
 	})),
 
 	// Full text search functions.
-	"ts_match_qv":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_match_vq":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_cmp":                   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_concat":                makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_debug":                       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_headline":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_lexize":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"websearch_to_tsquery":           makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"array_to_tsvector":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"get_current_ts_config":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"numnode":                        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"plainto_tsquery":                makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"phraseto_tsquery":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"querytree":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"setweight":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"strip":                          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"to_tsquery":                     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"to_tsvector":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"json_to_tsvector":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"jsonb_to_tsvector":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_delete":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_filter":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_rank":                        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_rank_cd":                     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_rewrite":                     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsquery_phrase":                 makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_to_array":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_update_trigger":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"tsvector_update_trigger_column": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"tsvector_cmp":      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"ts_debug":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"querytree":         makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"json_to_tsvector":  makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"jsonb_to_tsvector": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+	"ts_rewrite":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
+
+	// Trigger functions.
+	"suppress_redundant_updates_trigger": makeTriggerBuiltin(
+		"Trigger function that skips the rows that an update does not change. It " +
+			"must be executed by a BEFORE UPDATE trigger FOR EACH ROW."),
 
 	// Fuzzy String Matching
 	"soundex": makeBuiltin(
@@ -3885,13 +3872,76 @@ value if you rely on the HLC for accuracy.`,
 	// The behavior of both the JSON and JSONB data types in CockroachDB is
 	// similar to the behavior of the JSONB data type in Postgres.
 
-	"jsonb_path_exists":      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_exists_opr":  makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_match":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_match_opr":   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query_array": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query_first": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
+	"jsonb_path_exists": makeJSONPathBuiltin(
+		types.Bool,
+		eval.JSONPathExists,
+		"Returns whether the JSON path returns any item for the specified JSON value.",
+	),
+	"jsonb_path_exists_opr": makeBuiltin(
+		jsonProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "target", Typ: types.Jsonb}, {Name: "path", Typ: types.Jsonpath}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return eval.JSONPathExists(
+					tree.MustBeDJSON(args[0]).JSON, tree.MustBeDJsonpath(args[1]).Path, nil /* vars */, true, /* silent */
+				)
+			},
+			Info:       "Implementation of the @? operator.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"jsonb_path_match": makeJSONPathBuiltin(
+		types.Bool,
+		eval.JSONPathMatch,
+		"Returns the result of a JSON path predicate check for the specified JSON value. "+
+			"Only the first item of the result is taken into account. If the result is not "+
+			"Boolean, then NULL is returned.",
+	),
+	"jsonb_path_match_opr": makeBuiltin(
+		jsonProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "target", Typ: types.Jsonb}, {Name: "path", Typ: types.Jsonpath}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return eval.JSONPathMatch(
+					tree.MustBeDJSON(args[0]).JSON, tree.MustBeDJsonpath(args[1]).Path, nil /* vars */, true, /* silent */
+				)
+			},
+			Info:       "Implementation of the @@ operator for jsonb and jsonpath operands.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"jsonb_path_query_array": makeJSONPathBuiltin(
+		types.Jsonb,
+		func(target json.JSON, path jsonpath.Path, vars json.JSON, silent bool) (tree.Datum, error) {
+			res, err := evalJSONPath(target, path, vars, silent)
+			if err != nil {
+				return nil, err
+			}
+			b := json.NewArrayBuilder(len(res))
+			for _, j := range res {
+				b.Add(j)
+			}
+			return tree.NewDJSON(b.Build()), nil
+		},
+		"Returns all JSON items returned by the JSON path for the specified JSON value, as a JSON array.",
+	),
+	"jsonb_path_query_first": makeJSONPathBuiltin(
+		types.Jsonb,
+		func(target json.JSON, path jsonpath.Path, vars json.JSON, silent bool) (tree.Datum, error) {
+			res, err := evalJSONPath(target, path, vars, silent)
+			if err != nil {
+				return nil, err
+			}
+			if len(res) == 0 {
+				return tree.DNull, nil
+			}
+			return tree.NewDJSON(res[0]), nil
+		},
+		"Returns the first JSON item returned by the JSON path for the specified JSON value. "+
+			"Returns NULL if there are no results.",
+	),
 
 	"json_remove_path": makeBuiltin(jsonProps(),
 		tree.Overload{
@@ -5959,6 +6009,23 @@ value if you rely on the HLC for accuracy.`,
 			Volatility:        volatility.Stable,
 			CalledOnNullInput: true,
 		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "val", Typ: types.PGVector},
+				{Name: "version", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				// Vector indexes store each non-NULL vector under a single list.
+				if args[0] == tree.DNull {
+					return tree.DZero, nil
+				}
+				return tree.NewDInt(1), nil
+			},
+			Info:              "This function is used only by CockroachDB's developers for testing purposes.",
+			Volatility:        volatility.Stable,
+			CalledOnNullInput: true,
+		},
 	),
 
 	// Returns true iff the current user has admin role.
@@ -8561,6 +8628,73 @@ func jsonProps() tree.FunctionProperties {
 	}
 }
 
+// jsonPathFn is the signature of the functions implementing the jsonb_path_*
+// builtins.
+type jsonPathFn func(target json.JSON, path jsonpath.Path, vars json.JSON, silent bool) (tree.Datum, error)
+
+// jsonPathParamTypes returns the parameter types of the overloads of the
+// jsonb_path_* builtins. Postgres has a single signature with default values
+// for the vars and silent arguments, so one overload is defined for each
+// number of arguments instead.
+func jsonPathParamTypes() []tree.ParamTypes {
+	return []tree.ParamTypes{
+		{{Name: "target", Typ: types.Jsonb}, {Name: "path", Typ: types.Jsonpath}},
+		{{Name: "target", Typ: types.Jsonb}, {Name: "path", Typ: types.Jsonpath}, {Name: "vars", Typ: types.Jsonb}},
+		{
+			{Name: "target", Typ: types.Jsonb}, {Name: "path", Typ: types.Jsonpath},
+			{Name: "vars", Typ: types.Jsonb}, {Name: "silent", Typ: types.Bool},
+		},
+	}
+}
+
+// jsonPathArgs unpacks the arguments of the jsonb_path_* builtins.
+func jsonPathArgs(
+	args tree.Datums,
+) (target json.JSON, path jsonpath.Path, vars json.JSON, silent bool) {
+	target = tree.MustBeDJSON(args[0]).JSON
+	path = tree.MustBeDJsonpath(args[1]).Path
+	if len(args) > 2 {
+		vars = tree.MustBeDJSON(args[2]).JSON
+	}
+	if len(args) > 3 {
+		silent = bool(tree.MustBeDBool(args[3]))
+	}
+	return target, path, vars, silent
+}
+
+func makeJSONPathBuiltin(ret *types.T, fn jsonPathFn, info string) builtinDefinition {
+	paramTypes := jsonPathParamTypes()
+	overloads := make([]tree.Overload, len(paramTypes))
+	for i := range paramTypes {
+		overloads[i] = tree.Overload{
+			Types:      paramTypes[i],
+			ReturnType: tree.FixedReturnType(ret),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(jsonPathArgs(args))
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		}
+	}
+	return makeBuiltin(jsonProps(), overloads...)
+}
+
+// evalJSONPath returns the items returned by the jsonpath for the target JSON
+// value. When silent is true, errors that Postgres suppresses in silent mode
+// result in no items.
+func evalJSONPath(
+	target json.JSON, path jsonpath.Path, vars json.JSON, silent bool,
+) ([]json.JSON, error) {
+	res, err := jsonpath.Eval(path, target, vars)
+	if err != nil {
+		if silent && jsonpath.IsSilenceable(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
 var jsonBuildObjectImpl = tree.Overload{
 	Types:      tree.VariadicType{VarType: types.Any},
 	ReturnType: tree.FixedReturnType(types.Jsonb),
@@ -10439,3 +10573,21 @@ func prettyStatement(p tree.PrettyCfg, stmt string) (string, error) {
 	}
 	return formattedStmt.String(), nil
 }
+
+// makeTriggerBuiltin returns a builtin trigger function. Triggers that execute
+// it are planned by the optimizer, so it cannot be called directly.
+func makeTriggerBuiltin(info string) builtinDefinition {
+	return makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{},
+			ReturnType: tree.FixedReturnType(types.Trigger),
+			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
+				return nil, pgerror.New(pgcode.FeatureNotSupported,
+					"trigger functions can only be called as triggers")
+			},
+			Info:       info,
+			Volatility: volatility.Volatile,
+		},
+	)
+}
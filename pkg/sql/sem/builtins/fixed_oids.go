@@ -2044,6 +2044,270 @@ var builtinOidsArray = []string{
 	2068: `crdb_internal.gen_rand_ident(name_pattern: string, count: int, parameters: jsonb) -> string`,
 	2069: `crdb_internal.create_tenant(parameters: jsonb) -> int`,
 	2070: `crdb_internal.num_inverted_index_entries(val: tsvector, version: int) -> int`,
+	2071: `triggerin(input: anyelement) -> trigger`,
+	2072: `triggerout(trigger: trigger) -> bytes`,
+	2073: `triggersend(trigger: trigger) -> bytes`,
+	2074: `triggerrecv(input: anyelement) -> trigger`,
+	2075: `suppress_redundant_updates_trigger() -> trigger`,
+	2076: `pg_notify(channel: string, payload: string) -> void`,
+	2077: `crdb_internal.read_foreign_table(server: string, filename: string, format: string, columns: string[], options: string[]) -> string[]`,
+	2078: `to_tsvector(config: string, text: string) -> tsvector`,
+	2079: `to_tsvector(text: string) -> tsvector`,
+	2080: `to_tsquery(config: string, text: string) -> tsquery`,
+	2081: `to_tsquery(text: string) -> tsquery`,
+	2082: `plainto_tsquery(config: string, text: string) -> tsquery`,
+	2083: `plainto_tsquery(text: string) -> tsquery`,
+	2084: `phraseto_tsquery(config: string, text: string) -> tsquery`,
+	2085: `phraseto_tsquery(text: string) -> tsquery`,
+	2086: `websearch_to_tsquery(config: string, text: string) -> tsquery`,
+	2087: `websearch_to_tsquery(text: string) -> tsquery`,
+	2088: `get_current_ts_config() -> string`,
+	2089: `ts_lexize(dictionary: string, token: string) -> string[]`,
+	2090: `ts_rank(weights: float4[], vector: tsvector, query: tsquery, normalization: int) -> float4`,
+	2091: `ts_rank(weights: float4[], vector: tsvector, query: tsquery) -> float4`,
+	2092: `ts_rank(vector: tsvector, query: tsquery, normalization: int) -> float4`,
+	2093: `ts_rank(vector: tsvector, query: tsquery) -> float4`,
+	2094: `ts_rank_cd(weights: float4[], vector: tsvector, query: tsquery, normalization: int) -> float4`,
+	2095: `ts_rank_cd(weights: float4[], vector: tsvector, query: tsquery) -> float4`,
+	2096: `ts_rank_cd(vector: tsvector, query: tsquery, normalization: int) -> float4`,
+	2097: `ts_rank_cd(vector: tsvector, query: tsquery) -> float4`,
+	2098: `ts_headline(config: string, document: string, query: tsquery, options: string) -> string`,
+	2099: `ts_headline(config: string, document: string, query: tsquery) -> string`,
+	2100: `ts_headline(document: string, query: tsquery, options: string) -> string`,
+	2101: `ts_headline(document: string, query: tsquery) -> string`,
+	2102: `setweight(vector: tsvector, weight: "char") -> tsvector`,
+	2103: `setweight(vector: tsvector, weight: "char", lexemes: string[]) -> tsvector`,
+	2104: `strip(vector: tsvector) -> tsvector`,
+	2105: `ts_delete(vector: tsvector, lexeme: string) -> tsvector`,
+	2106: `ts_delete(vector: tsvector, lexemes: string[]) -> tsvector`,
+	2107: `ts_filter(vector: tsvector, weights: "char"[]) -> tsvector`,
+	2108: `tsvector_concat(left: tsvector, right: tsvector) -> tsvector`,
+	2109: `tsvector_to_array(vector: tsvector) -> string[]`,
+	2110: `array_to_tsvector(lexemes: string[]) -> tsvector`,
+	2111: `numnode(query: tsquery) -> int`,
+	2112: `tsquery_phrase(left: tsquery, right: tsquery) -> tsquery`,
+	2113: `tsquery_phrase(left: tsquery, right: tsquery, distance: int) -> tsquery`,
+	2114: `ts_match_vq(vector: tsvector, query: tsquery) -> bool`,
+	2115: `ts_match_qv(query: tsquery, vector: tsvector) -> bool`,
+	2116: `tsvector_update_trigger() -> trigger`,
+	2117: `tsvector_update_trigger_column() -> trigger`,
+	2118: `jsonb_path_exists(target: jsonb, path: jsonpath) -> bool`,
+	2119: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2120: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2121: `jsonb_path_exists_opr(target: jsonb, path: jsonpath) -> bool`,
+	2122: `jsonb_path_match(target: jsonb, path: jsonpath) -> bool`,
+	2123: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2124: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2125: `jsonb_path_match_opr(target: jsonb, path: jsonpath) -> bool`,
+	2126: `jsonb_path_query(target: jsonb, path: jsonpath) -> jsonb`,
+	2127: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2128: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2129: `jsonb_path_query_array(target: jsonb, path: jsonpath) -> jsonb`,
+	2130: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2131: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2132: `jsonb_path_query_first(target: jsonb, path: jsonpath) -> jsonb`,
+	2133: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2134: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2135: `jsonpathin(input: anyelement) -> jsonpath`,
+	2136: `jsonpathout(jsonpath: jsonpath) -> bytes`,
+	2137: `jsonpathrecv(input: anyelement) -> jsonpath`,
+	2138: `jsonpathsend(jsonpath: jsonpath) -> bytes`,
+	2139: `datemultirange(daterange...) -> datemultirange`,
+	2140: `datemultirangein(input: anyelement) -> datemultirange`,
+	2141: `datemultirangeout(datemultirange: datemultirange) -> bytes`,
+	2142: `datemultirangerecv(input: anyelement) -> datemultirange`,
+	2143: `datemultirangesend(datemultirange: datemultirange) -> bytes`,
+	2144: `daterange(lower: date, upper: date) -> daterange`,
+	2145: `daterange(lower: date, upper: date, bounds: string) -> daterange`,
+	2146: `daterangein(input: anyelement) -> daterange`,
+	2147: `daterangeout(daterange: daterange) -> bytes`,
+	2148: `daterangerecv(input: anyelement) -> daterange`,
+	2149: `daterangesend(daterange: daterange) -> bytes`,
+	2150: `int4multirange(int4range...) -> int4multirange`,
+	2151: `int4multirangein(input: anyelement) -> int4multirange`,
+	2152: `int4multirangeout(int4multirange: int4multirange) -> bytes`,
+	2153: `int4multirangerecv(input: anyelement) -> int4multirange`,
+	2154: `int4multirangesend(int4multirange: int4multirange) -> bytes`,
+	2155: `int4range(lower: int4, upper: int4) -> int4range`,
+	2156: `int4range(lower: int4, upper: int4, bounds: string) -> int4range`,
+	2157: `int4rangein(input: anyelement) -> int4range`,
+	2158: `int4rangeout(int4range: int4range) -> bytes`,
+	2159: `int4rangerecv(input: anyelement) -> int4range`,
+	2160: `int4rangesend(int4range: int4range) -> bytes`,
+	2161: `int8multirange(int8range...) -> int8multirange`,
+	2162: `int8multirangein(input: anyelement) -> int8multirange`,
+	2163: `int8multirangeout(int8multirange: int8multirange) -> bytes`,
+	2164: `int8multirangerecv(input: anyelement) -> int8multirange`,
+	2165: `int8multirangesend(int8multirange: int8multirange) -> bytes`,
+	2166: `int8range(lower: int, upper: int) -> int8range`,
+	2167: `int8range(lower: int, upper: int, bounds: string) -> int8range`,
+	2168: `int8rangein(input: anyelement) -> int8range`,
+	2169: `int8rangeout(int8range: int8range) -> bytes`,
+	2170: `int8rangerecv(input: anyelement) -> int8range`,
+	2171: `int8rangesend(int8range: int8range) -> bytes`,
+	2172: `isempty(val: anymultirange) -> bool`,
+	2173: `isempty(val: anyrange) -> bool`,
+	2174: `lower(val: anymultirange) -> anyelement`,
+	2175: `lower(val: anyrange) -> anyelement`,
+	2176: `lower_inc(val: anymultirange) -> bool`,
+	2177: `lower_inc(val: anyrange) -> bool`,
+	2178: `lower_inf(val: anymultirange) -> bool`,
+	2179: `lower_inf(val: anyrange) -> bool`,
+	2180: `multirange(val: anyrange) -> anyelement`,
+	2181: `nummultirange(numrange...) -> nummultirange`,
+	2182: `nummultirangein(input: anyelement) -> nummultirange`,
+	2183: `nummultirangeout(nummultirange: nummultirange) -> bytes`,
+	2184: `nummultirangerecv(input: anyelement) -> nummultirange`,
+	2185: `nummultirangesend(nummultirange: nummultirange) -> bytes`,
+	2186: `numrange(lower: decimal, upper: decimal) -> numrange`,
+	2187: `numrange(lower: decimal, upper: decimal, bounds: string) -> numrange`,
+	2188: `numrangein(input: anyelement) -> numrange`,
+	2189: `numrangeout(numrange: numrange) -> bytes`,
+	2190: `numrangerecv(input: anyelement) -> numrange`,
+	2191: `numrangesend(numrange: numrange) -> bytes`,
+	2192: `range_adjacent(left: datemultirange, right: datemultirange) -> bool`,
+	2193: `range_adjacent(left: datemultirange, right: daterange) -> bool`,
+	2194: `range_adjacent(left: daterange, right: datemultirange) -> bool`,
+	2195: `range_adjacent(left: daterange, right: daterange) -> bool`,
+	2196: `range_adjacent(left: int4multirange, right: int4multirange) -> bool`,
+	2197: `range_adjacent(left: int4multirange, right: int4range) -> bool`,
+	2198: `range_adjacent(left: int4range, right: int4multirange) -> bool`,
+	2199: `range_adjacent(left: int4range, right: int4range) -> bool`,
+	2200: `range_adjacent(left: int8multirange, right: int8multirange) -> bool`,
+	2201: `range_adjacent(left: int8multirange, right: int8range) -> bool`,
+	2202: `range_adjacent(left: int8range, right: int8multirange) -> bool`,
+	2203: `range_adjacent(left: int8range, right: int8range) -> bool`,
+	2204: `range_adjacent(left: nummultirange, right: nummultirange) -> bool`,
+	2205: `range_adjacent(left: nummultirange, right: numrange) -> bool`,
+	2206: `range_adjacent(left: numrange, right: nummultirange) -> bool`,
+	2207: `range_adjacent(left: numrange, right: numrange) -> bool`,
+	2208: `range_adjacent(left: tsmultirange, right: tsmultirange) -> bool`,
+	2209: `range_adjacent(left: tsmultirange, right: tsrange) -> bool`,
+	2210: `range_adjacent(left: tsrange, right: tsmultirange) -> bool`,
+	2211: `range_adjacent(left: tsrange, right: tsrange) -> bool`,
+	2212: `range_adjacent(left: tstzmultirange, right: tstzmultirange) -> bool`,
+	2213: `range_adjacent(left: tstzmultirange, right: tstzrange) -> bool`,
+	2214: `range_adjacent(left: tstzrange, right: tstzmultirange) -> bool`,
+	2215: `range_adjacent(left: tstzrange, right: tstzrange) -> bool`,
+	2216: `range_merge(left: daterange, right: daterange) -> daterange`,
+	2217: `range_merge(left: int4range, right: int4range) -> int4range`,
+	2218: `range_merge(left: int8range, right: int8range) -> int8range`,
+	2219: `range_merge(left: numrange, right: numrange) -> numrange`,
+	2220: `range_merge(left: tsrange, right: tsrange) -> tsrange`,
+	2221: `range_merge(left: tstzrange, right: tstzrange) -> tstzrange`,
+	2222: `range_merge(val: anymultirange) -> anyelement`,
+	2223: `tsmultirange(tsrange...) -> tsmultirange`,
+	2224: `tsmultirangein(input: anyelement) -> tsmultirange`,
+	2225: `tsmultirangeout(tsmultirange: tsmultirange) -> bytes`,
+	2226: `tsmultirangerecv(input: anyelement) -> tsmultirange`,
+	2227: `tsmultirangesend(tsmultirange: tsmultirange) -> bytes`,
+	2228: `tsrange(lower: timestamp, upper: timestamp) -> tsrange`,
+	2229: `tsrange(lower: timestamp, upper: timestamp, bounds: string) -> tsrange`,
+	2230: `tsrangein(input: anyelement) -> tsrange`,
+	2231: `tsrangeout(tsrange: tsrange) -> bytes`,
+	2232: `tsrangerecv(input: anyelement) -> tsrange`,
+	2233: `tsrangesend(tsrange: tsrange) -> bytes`,
+	2234: `tstzmultirange(tstzrange...) -> tstzmultirange`,
+	2235: `tstzmultirangein(input: anyelement) -> tstzmultirange`,
+	2236: `tstzmultirangeout(tstzmultirange: tstzmultirange) -> bytes`,
+	2237: `tstzmultirangerecv(input: anyelement) -> tstzmultirange`,
+	2238: `tstzmultirangesend(tstzmultirange: tstzmultirange) -> bytes`,
+	2239: `tstzrange(lower: timestamptz, upper: timestamptz) -> tstzrange`,
+	2240: `tstzrange(lower: timestamptz, upper: timestamptz, bounds: string) -> tstzrange`,
+	2241: `tstzrangein(input: anyelement) -> tstzrange`,
+	2242: `tstzrangeout(tstzrange: tstzrange) -> bytes`,
+	2243: `tstzrangerecv(input: anyelement) -> tstzrange`,
+	2244: `tstzrangesend(tstzrange: tstzrange) -> bytes`,
+	2245: `upper(val: anymultirange) -> anyelement`,
+	2246: `upper(val: anyrange) -> anyelement`,
+	2247: `upper_inc(val: anymultirange) -> bool`,
+	2248: `upper_inc(val: anyrange) -> bool`,
+	2249: `upper_inf(val: anymultirange) -> bool`,
+	2250: `upper_inf(val: anyrange) -> bool`,
+	2251: `word_similarity(left: string, right: string) -> float`,
+	2252: `strict_word_similarity(left: string, right: string) -> float`,
+	2253: `show_limit() -> float`,
+	2254: `set_limit(limit: float) -> float`,
+	2255: `word_similarity_op(left: string, right: string) -> bool`,
+	2256: `word_similarity_commutator_op(left: string, right: string) -> bool`,
+	2257: `strict_word_similarity_op(left: string, right: string) -> bool`,
+	2258: `strict_word_similarity_commutator_op(left: string, right: string) -> bool`,
+	2259: `similarity_dist(left: string, right: string) -> float`,
+	2260: `word_similarity_dist_op(left: string, right: string) -> float`,
+	2261: `word_similarity_dist_commutator_op(left: string, right: string) -> float`,
+	2262: `strict_word_similarity_dist_op(left: string, right: string) -> float`,
+	2263: `strict_word_similarity_dist_commutator_op(left: string, right: string) -> float`,
+	2264: `st_dump(geometry: geometry) -> tuple{int[] AS path, geometry AS geom}`,
+	2265: `st_dumppoints(geometry: geometry) -> tuple{int[] AS path, geometry AS geom}`,
+	2266: `st_dumprings(polygon: geometry) -> tuple{int[] AS path, geometry AS geom}`,
+	2267: `st_clusterintersecting(arg1: geometry) -> geometry[]`,
+	2268: `st_clusterwithin(arg1: geometry, arg2: float) -> geometry[]`,
+	2269: `st_clusterdbscan(geometry: geometry, eps: float, minpoints: int) -> int`,
+	2270: `st_clusterkmeans(geometry: geometry, number_of_clusters: int) -> int`,
+	2271: `st_delaunaytriangles(geometry: geometry) -> geometry`,
+	2272: `st_delaunaytriangles(geometry: geometry, tolerance: float) -> geometry`,
+	2273: `st_delaunaytriangles(geometry: geometry, tolerance: float, flags: int) -> geometry`,
+	2274: `st_concavehull(geometry: geometry, target_percent: float) -> geometry`,
+	2275: `st_concavehull(geometry: geometry, target_percent: float, allow_holes: bool) -> geometry`,
+	2276: `st_split(input: geometry, blade: geometry) -> geometry`,
+	2277: `st_buildarea(geometry: geometry) -> geometry`,
+	2278: `st_simplifyvw(geometry: geometry, tolerance: float) -> geometry`,
+	2279: `st_chaikinsmoothing(geometry: geometry) -> geometry`,
+	2280: `st_chaikinsmoothing(geometry: geometry, n_iterations: int) -> geometry`,
+	2281: `st_chaikinsmoothing(geometry: geometry, n_iterations: int, preserve_end_points: bool) -> geometry`,
+	2282: `st_geometricmedian(geometry: geometry) -> geometry`,
+	2283: `st_geometricmedian(geometry: geometry, tolerance: float) -> geometry`,
+	2284: `st_geometricmedian(geometry: geometry, tolerance: float, max_iter: int) -> geometry`,
+	2285: `st_geometricmedian(geometry: geometry, tolerance: float, max_iter: int, fail_if_not_converged: bool) -> geometry`,
+	2286: `st_polygonize(arg1: geometry) -> geometry`,
+	2287: `st_geomfromgml(val: string) -> geometry`,
+	2288: `st_geomfromgml(val: string, srid: int) -> geometry`,
+	2289: `st_geomfromtwkb(val: bytes) -> geometry`,
+	2290: `st_asgml(geometry: geometry) -> string`,
+	2291: `st_asgml(geometry: geometry, max_decimal_digits: int) -> string`,
+	2292: `st_asgml(geometry: geometry, max_decimal_digits: int, options: int) -> string`,
+	2293: `st_asgml(version: int, geometry: geometry) -> string`,
+	2294: `st_asgml(version: int, geometry: geometry, max_decimal_digits: int) -> string`,
+	2295: `st_asgml(version: int, geometry: geometry, max_decimal_digits: int, options: int) -> string`,
+	2296: `st_asgml(version: int, geometry: geometry, max_decimal_digits: int, options: int, nprefix: string) -> string`,
+	2297: `st_asgml(geography: geography) -> string`,
+	2298: `st_asgml(geography: geography, max_decimal_digits: int) -> string`,
+	2299: `st_asgml(geography: geography, max_decimal_digits: int, options: int) -> string`,
+	2300: `st_asgml(version: int, geography: geography) -> string`,
+	2301: `st_asgml(version: int, geography: geography, max_decimal_digits: int) -> string`,
+	2302: `st_asgml(version: int, geography: geography, max_decimal_digits: int, options: int) -> string`,
+	2303: `st_asgml(version: int, geography: geography, max_decimal_digits: int, options: int, nprefix: string) -> string`,
+	2304: `st_asgml(geometry_str: string) -> string`,
+	2305: `st_asgml(geometry_str: string, max_decimal_digits: int) -> string`,
+	2306: `st_asgml(geometry_str: string, max_decimal_digits: int, options: int) -> string`,
+	2307: `st_asgml(version: int, geometry_str: string) -> string`,
+	2308: `st_asgml(version: int, geometry_str: string, max_decimal_digits: int) -> string`,
+	2309: `st_asgml(version: int, geometry_str: string, max_decimal_digits: int, options: int) -> string`,
+	2310: `st_asgml(version: int, geometry_str: string, max_decimal_digits: int, options: int, nprefix: string) -> string`,
+	2311: `st_assvg(geometry: geometry) -> string`,
+	2312: `st_assvg(geometry: geometry, rel: int) -> string`,
+	2313: `st_assvg(geometry: geometry, rel: int, max_decimal_digits: int) -> string`,
+	2314: `st_assvg(geography: geography) -> string`,
+	2315: `st_assvg(geography: geography, rel: int) -> string`,
+	2316: `st_assvg(geography: geography, rel: int, max_decimal_digits: int) -> string`,
+	2317: `st_assvg(geometry_str: string) -> string`,
+	2318: `st_assvg(geometry_str: string, rel: int) -> string`,
+	2319: `st_assvg(geometry_str: string, rel: int, max_decimal_digits: int) -> string`,
+	2320: `st_aslatlontext(geometry: geometry) -> string`,
+	2321: `st_aslatlontext(geometry: geometry, format: string) -> string`,
+	2322: `st_gmltosql(val: string) -> geometry`,
+	2323: `st_gmltosql(val: string, srid: int) -> geometry`,
+	2324: `vectorin(input: anyelement) -> vector`,
+	2325: `vectorout(vector: vector) -> bytes`,
+	2326: `vectorrecv(input: anyelement) -> vector`,
+	2327: `vectorsend(vector: vector) -> bytes`,
+	2328: `l1_distance(v1: vector, v2: vector) -> float`,
+	2329: `l2_distance(v1: vector, v2: vector) -> float`,
+	2330: `cosine_distance(v1: vector, v2: vector) -> float`,
+	2331: `inner_product(v1: vector, v2: vector) -> float`,
+	2332: `vector_dims(vector: vector) -> int`,
+	2333: `vector_norm(vector: vector) -> float`,
+	2334: `crdb_internal.num_inverted_index_entries(val: vector, version: int) -> int`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
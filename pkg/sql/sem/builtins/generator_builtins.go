@@ -14,6 +14,7 @@ import (
 	"bytes"
 	"context"
 	gojson "encoding/json"
+	"io"
 	"math/rand"
 	"strings"
 	"time"
@@ -22,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/foreigntable"
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -36,6 +38,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/randident"
 	"github.com/cockroachdb/cockroach/pkg/util/randident/randidentcfg"
@@ -381,6 +385,7 @@ var generators = map[string]builtinDefinition{
 	"jsonb_each":                makeBuiltin(jsonGenPropsWithLabels(jsonEachGeneratorLabels), jsonEachImpl),
 	"json_each_text":            makeBuiltin(jsonGenPropsWithLabels(jsonEachGeneratorLabels), jsonEachTextImpl),
 	"jsonb_each_text":           makeBuiltin(jsonGenPropsWithLabels(jsonEachGeneratorLabels), jsonEachTextImpl),
+	"jsonb_path_query":          makeBuiltin(genProps(), jsonPathQueryImpl()...),
 	"json_populate_record": makeBuiltin(jsonPopulateProps, makeJSONPopulateImpl(makeJSONPopulateRecordGenerator,
 		"Expands the object in from_json to a row whose columns match the record type defined by base.",
 	)),
@@ -592,6 +597,30 @@ The last argument is a JSONB object containing the following optional fields:
 			volatility.Volatile,
 		),
 	),
+	"crdb_internal.read_foreign_table": makeBuiltin(
+		tree.FunctionProperties{
+			Class:             tree.GeneratorClass,
+			Category:          builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist:  true,
+			Undocumented:      true,
+			ReturnsRecordType: true,
+		},
+		makeGeneratorOverload(
+			tree.ParamTypes{
+				{Name: "server", Typ: types.String},
+				{Name: "filename", Typ: types.String},
+				{Name: "format", Typ: types.String},
+				{Name: "options", Typ: types.StringArray},
+			},
+			// NOTE: this type will never actually get used. It is replaced in the
+			// optimizer by looking at the most recent AS alias clause.
+			types.EmptyTuple,
+			makeForeignTableGenerator,
+			"Returns the rows of the file of a foreign table, decoded as the columns "+
+				"of the column definition list. The options are a flat list of names and values.",
+			volatility.Volatile,
+		),
+	),
 }
 
 var decodePlanGistGeneratorType = types.String
@@ -646,6 +675,107 @@ func makeDecodeExternalPlanGistGenerator(
 	return &gistPlanGenerator{gist: gist, evalCtx: evalCtx, external: true}, nil
 }
 
+// foreignTableGenerator produces the rows of the file of a foreign table.
+type foreignTableGenerator struct {
+	evalCtx  *eval.Context
+	server   string
+	filename string
+	opts     foreigntable.Options
+	columns  []foreigntable.Column
+	reader   foreigntable.Reader
+	row      tree.Datums
+	// acc accounts for the parts of the file held in memory by the reader.
+	acc mon.BoundAccount
+}
+
+var _ eval.ValueGenerator = &foreignTableGenerator{}
+var _ eval.AliasAwareValueGenerator = &foreignTableGenerator{}
+
+func makeForeignTableGenerator(
+	ctx context.Context, evalCtx *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	g := &foreignTableGenerator{
+		evalCtx:  evalCtx,
+		server:   string(tree.MustBeDString(args[0])),
+		filename: string(tree.MustBeDString(args[1])),
+		acc:      evalCtx.Planner.Mon().MakeBoundAccount(),
+	}
+	optArray := tree.MustBeDArray(args[3]).Array
+	if len(optArray)%2 != 0 {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"options must contain pairs of names and values")
+	}
+	opts := make([]foreigntable.Option, 0, len(optArray)/2)
+	for i := 0; i < len(optArray); i += 2 {
+		opts = append(opts, foreigntable.Option{
+			Name:  string(tree.MustBeDString(optArray[i])),
+			Value: string(tree.MustBeDString(optArray[i+1])),
+		})
+	}
+	var err error
+	g.opts, err = foreigntable.ParseOptions(string(tree.MustBeDString(args[2])), opts)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// SetAlias implements the eval.AliasAwareValueGenerator interface.
+func (g *foreignTableGenerator) SetAlias(types []*types.T, labels []string) error {
+	if len(types) != len(labels) {
+		return errors.AssertionFailedf("unexpected mismatched types/labels list in foreign table generator %v %v", types, labels)
+	}
+	g.columns = make([]foreigntable.Column, len(types))
+	for i := range types {
+		g.columns[i] = foreigntable.Column{Name: labels[i], Type: types[i]}
+	}
+	return nil
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *foreignTableGenerator) ResolvedType() *types.T {
+	return types.AnyTuple
+}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *foreignTableGenerator) Start(ctx context.Context, _ *kv.Txn) error {
+	file, err := g.evalCtx.Planner.OpenForeignTableFile(ctx, g.server, g.filename)
+	if err != nil {
+		return err
+	}
+	g.reader, err = foreigntable.NewReader(ctx, g.evalCtx, file, g.opts, g.columns, &g.acc)
+	return err
+}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *foreignTableGenerator) Next(ctx context.Context) (bool, error) {
+	row, err := g.reader.Next(ctx)
+	if err != nil {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, err
+	}
+	g.row = row
+	return true, nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *foreignTableGenerator) Values() (tree.Datums, error) {
+	return g.row, nil
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (g *foreignTableGenerator) Close(ctx context.Context) {
+	if g.reader != nil {
+		if err := g.reader.Close(ctx); err != nil {
+			log.Warningf(ctx, "closing file %q of server %q: %v", g.filename, g.server, err)
+		}
+		g.reader = nil
+	}
+	g.acc.Close(ctx)
+}
+
 func makeGeneratorOverload(
 	in tree.TypeList, ret *types.T, g eval.GeneratorOverload, info string, volatility volatility.V,
 ) tree.Overload {
@@ -1391,6 +1521,75 @@ func (g *jsonArrayGenerator) Values() (tree.Datums, error) {
 	return g.buf[:], nil
 }
 
+func jsonPathQueryImpl() []tree.Overload {
+	paramTypes := jsonPathParamTypes()
+	overloads := make([]tree.Overload, len(paramTypes))
+	for i := range paramTypes {
+		overloads[i] = makeGeneratorOverload(
+			paramTypes[i],
+			types.Jsonb,
+			makeJSONPathQueryGenerator,
+			"Returns all JSON items returned by the JSON path for the specified JSON value.",
+			volatility.Immutable,
+		)
+	}
+	return overloads
+}
+
+// jsonPathQueryGenerator supports jsonb_path_query.
+type jsonPathQueryGenerator struct {
+	target json.JSON
+	path   jsonpath.Path
+	vars   json.JSON
+	silent bool
+
+	items     []json.JSON
+	nextIndex int
+	buf       [1]tree.Datum
+}
+
+func makeJSONPathQueryGenerator(
+	_ context.Context, _ *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	target, path, vars, silent := jsonPathArgs(args)
+	return &jsonPathQueryGenerator{
+		target: target,
+		path:   path,
+		vars:   vars,
+		silent: silent,
+	}, nil
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) ResolvedType() *types.T {
+	return types.Jsonb
+}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Start(_ context.Context, _ *kv.Txn) (err error) {
+	g.items, err = evalJSONPath(g.target, g.path, g.vars, g.silent)
+	g.nextIndex = -1
+	return err
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Close(_ context.Context) {}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Next(_ context.Context) (bool, error) {
+	g.nextIndex++
+	if g.nextIndex >= len(g.items) {
+		return false, nil
+	}
+	g.buf[0] = tree.NewDJSON(g.items[g.nextIndex])
+	return true, nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Values() (tree.Datums, error) {
+	return g.buf[:], nil
+}
+
 // jsonObjectKeysImpl is a key generator of a JSON object.
 var jsonObjectKeysImpl = makeGeneratorOverload(
 	tree.ParamTypes{{Name: "input", Typ: types.Jsonb}},
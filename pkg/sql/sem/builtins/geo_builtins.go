@@ -470,6 +470,62 @@ func (s *subdividedGeometriesGen) Next(_ context.Context) (bool, error) {
 	return s.curr < len(s.geometries), nil
 }
 
+// geometryDumpReturnType is the return type of the ST_Dump family of
+// generators, mirroring the geometry_dump composite type of PostGIS.
+var geometryDumpReturnType = types.MakeLabeledTuple(
+	[]*types.T{types.IntArray, types.Geometry},
+	[]string{"path", "geom"},
+)
+
+func makeDumpedGeometriesGeneratorFactory(
+	dump func(geo.Geometry) ([]geomfn.DumpedGeometry, error),
+) eval.GeneratorOverload {
+	return func(
+		_ context.Context, _ *eval.Context, args tree.Datums,
+	) (eval.ValueGenerator, error) {
+		geometry := tree.MustBeDGeometry(args[0])
+		results, err := dump(geometry.Geometry)
+		if err != nil {
+			return nil, err
+		}
+		return &dumpedGeometriesGen{
+			geometries: results,
+			curr:       -1,
+		}, nil
+	}
+}
+
+// dumpedGeometriesGen implements the tree.ValueGenerator interface
+type dumpedGeometriesGen struct {
+	geometries []geomfn.DumpedGeometry
+	curr       int
+}
+
+func (s *dumpedGeometriesGen) ResolvedType() *types.T { return geometryDumpReturnType }
+
+func (s *dumpedGeometriesGen) Close(_ context.Context) {}
+
+func (s *dumpedGeometriesGen) Start(_ context.Context, _ *kv.Txn) error {
+	s.curr = -1
+	return nil
+}
+
+func (s *dumpedGeometriesGen) Values() (tree.Datums, error) {
+	dumped := s.geometries[s.curr]
+	path := tree.NewDArray(types.Int)
+	for _, p := range dumped.Path {
+		if err := path.Append(tree.NewDInt(tree.DInt(p))); err != nil {
+			return nil, err
+		}
+	}
+	return tree.Datums{path, tree.NewDGeometry(dumped.Geometry)}, nil
+}
+
+func (s *dumpedGeometriesGen) Next(_ context.Context) (bool, error) {
+	s.curr++
+	return s.curr < len(s.geometries), nil
+}
+
 var geoBuiltins = map[string]builtinDefinition{
 	//
 	// Meta builtins.
@@ -771,6 +827,67 @@ var geoBuiltins = map[string]builtinDefinition{
 			volatility.Immutable,
 		),
 	),
+	"st_geomfromgml": makeBuiltin(
+		defProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g, err := geo.ParseGeometryFromGML([]byte(tree.MustBeDString(args[0])), 0 /* srid */)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(g), nil
+			},
+			Info: infoBuilder{
+				info: "Returns the Geometry from a GML representation. The SRID is taken from the srsName attribute if one is present.",
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "val", Typ: types.String},
+				{Name: "srid", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g, err := geo.ParseGeometryFromGML(
+					[]byte(tree.MustBeDString(args[0])),
+					geopb.SRID(tree.MustBeDInt(args[1])),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(g), nil
+			},
+			Info: infoBuilder{
+				info: "Returns the Geometry from a GML representation with the given SRID, which takes precedence over any srsName attribute.",
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
+	"st_geomfromtwkb": makeBuiltin(
+		defProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.Bytes}},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				t, err := twkb.Unmarshal([]byte(tree.MustBeDBytes(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				g, err := geo.MakeGeometryFromGeomT(t)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(g), nil
+			},
+			Info: infoBuilder{
+				info: "Returns the Geometry from a TWKB representation.",
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
 	"st_makepoint": makeBuiltin(
 		defProps(),
 		tree.Overload{
@@ -1654,6 +1771,86 @@ var geoBuiltins = map[string]builtinDefinition{
 			volatility.Immutable,
 		),
 	),
+	"st_asgml": makeBuiltin(
+		defProps(),
+		append(
+			stAsGMLOverloads(
+				types.Geometry,
+				func(d tree.Datum) geopb.SpatialObject {
+					return tree.MustBeDGeometry(d).Geometry.SpatialObject()
+				},
+			),
+			stAsGMLOverloads(
+				types.Geography,
+				func(d tree.Datum) geopb.SpatialObject {
+					return tree.MustBeDGeography(d).Geography.SpatialObject()
+				},
+			)...,
+		)...,
+	),
+	"st_assvg": makeBuiltin(
+		defProps(),
+		append(
+			stAsSVGOverloads(
+				types.Geometry,
+				func(d tree.Datum) geopb.SpatialObject {
+					return tree.MustBeDGeometry(d).Geometry.SpatialObject()
+				},
+			),
+			stAsSVGOverloads(
+				types.Geography,
+				func(d tree.Datum) geopb.SpatialObject {
+					return tree.MustBeDGeography(d).Geography.SpatialObject()
+				},
+			)...,
+		)...,
+	),
+	"st_aslatlontext": makeBuiltin(
+		defProps(),
+		geometryOverload1(
+			func(_ context.Context, _ *eval.Context, g *tree.DGeometry) (tree.Datum, error) {
+				ret, err := geo.SpatialObjectToLatLonText(g.Geometry.SpatialObject(), "" /* format */)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDString(ret), nil
+			},
+			types.String,
+			infoBuilder{
+				info: "Returns the degrees, minutes and seconds representation of a given point, " +
+					"with the latitude followed by the longitude.",
+			},
+			volatility.Immutable,
+		),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "format", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				format := string(tree.MustBeDString(args[1]))
+				ret, err := geo.SpatialObjectToLatLonText(g.Geometry.SpatialObject(), format)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDString(ret), nil
+			},
+			Info: infoBuilder{
+				info: `Returns the degrees, minutes and seconds representation of a given point, ` +
+					`with the latitude followed by the longitude.
+
+The format may contain the following characters:
+* D, M and S are replaced by degrees, minutes and seconds. Repeating the character sets the minimum ` +
+					`width, and a decimal point followed by more characters sets the number of decimal digits ` +
+					`of the last number, e.g. 'D°MM''SS.SSS"'.
+* C is replaced by the compass direction (N, S, E or W). If omitted, negative values are prefixed with a minus sign.
+* Any other character is output as is.`,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
 	"st_geohash": makeBuiltin(
 		defProps(),
 		geometryOverload1(
@@ -6031,6 +6228,46 @@ See http://developers.google.com/maps/documentation/utilities/polylinealgorithm`
 			volatility.Immutable,
 		),
 	),
+	"st_dump": makeBuiltin(
+		genProps(),
+		makeGeneratorOverload(
+			tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+			},
+			geometryDumpReturnType,
+			makeDumpedGeometriesGeneratorFactory(geomfn.Dump),
+			"Returns a set of (path, geom) rows for the components of the given geometry. "+
+				"Multi-part geometries and collections are decomposed recursively, with path containing "+
+				"the 1-indexed position of the component at each level of nesting.",
+			volatility.Immutable,
+		),
+	),
+	"st_dumppoints": makeBuiltin(
+		genProps(),
+		makeGeneratorOverload(
+			tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+			},
+			geometryDumpReturnType,
+			makeDumpedGeometriesGeneratorFactory(geomfn.DumpPoints),
+			"Returns a set of (path, geom) rows for every vertex of the given geometry, "+
+				"where path identifies the component, ring and position of the vertex.",
+			volatility.Immutable,
+		),
+	),
+	"st_dumprings": makeBuiltin(
+		genProps(),
+		makeGeneratorOverload(
+			tree.ParamTypes{
+				{Name: "polygon", Typ: types.Geometry},
+			},
+			geometryDumpReturnType,
+			makeDumpedGeometriesGeneratorFactory(geomfn.DumpRings),
+			"Returns a set of (path, geom) rows for the rings of the given polygon, each as a polygon. "+
+				"The exterior ring has path {0} and the interior rings are numbered from 1.",
+			volatility.Immutable,
+		),
+	),
 
 	//
 	// BoundingBox
@@ -6851,6 +7088,326 @@ The parent_only boolean is always ignored.`,
 			Volatility: volatility.Immutable,
 		},
 	),
+	"st_delaunaytriangles": makeBuiltin(
+		defProps(),
+		geometryOverload1(
+			func(_ context.Context, _ *eval.Context, g *tree.DGeometry) (tree.Datum, error) {
+				ret, err := geomfn.DelaunayTriangles(g.Geometry, 0 /* tolerance */, geomfn.DelaunayTrianglesPolygons)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			types.Geometry,
+			infoBuilder{
+				info:         `Returns the Delaunay triangulation of the vertices of the given geometry as a GeometryCollection of triangular Polygons.`,
+				libraryUsage: usesGEOS,
+			},
+			volatility.Immutable,
+		),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "tolerance", Typ: types.Float},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				tolerance := float64(tree.MustBeDFloat(args[1]))
+				ret, err := geomfn.DelaunayTriangles(g.Geometry, tolerance, geomfn.DelaunayTrianglesPolygons)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info:         `Returns the Delaunay triangulation of the vertices of the given geometry as a GeometryCollection of triangular Polygons. Vertices within tolerance of each other are snapped together.`,
+				libraryUsage: usesGEOS,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "tolerance", Typ: types.Float},
+				{Name: "flags", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				tolerance := float64(tree.MustBeDFloat(args[1]))
+				flags := geomfn.DelaunayTrianglesFlag(tree.MustBeDInt(args[2]))
+				ret, err := geomfn.DelaunayTriangles(g.Geometry, tolerance, flags)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: `Returns the Delaunay triangulation of the vertices of the given geometry. ` +
+					`Vertices within tolerance of each other are snapped together. ` +
+					`If flags is 0, the triangles are returned as a GeometryCollection of Polygons. ` +
+					`If flags is 1, the edges of the triangulation are returned as a MultiLineString.`,
+				libraryUsage: usesGEOS,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
+	"st_concavehull": makeBuiltin(
+		defProps(),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "target_percent", Typ: types.Float},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				targetPercent := float64(tree.MustBeDFloat(args[1]))
+				ret, err := geomfn.ConcaveHull(g.Geometry, targetPercent, false /* allowHoles */)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: `Returns a possibly concave Polygon enclosing all the vertices of the given geometry. ` +
+					`target_percent is a value between 0 and 1 controlling the concaveness of the hull, ` +
+					`where 1 returns the convex hull and smaller values produce more concave hulls.`,
+				libraryUsage: usesGEOS,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "target_percent", Typ: types.Float},
+				{Name: "allow_holes", Typ: types.Bool},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				targetPercent := float64(tree.MustBeDFloat(args[1]))
+				allowHoles := bool(tree.MustBeDBool(args[2]))
+				ret, err := geomfn.ConcaveHull(g.Geometry, targetPercent, allowHoles)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: `Returns a possibly concave Polygon enclosing all the vertices of the given geometry. ` +
+					`target_percent is a value between 0 and 1 controlling the concaveness of the hull, ` +
+					`where 1 returns the convex hull and smaller values produce more concave hulls. ` +
+					`allow_holes must be false, as hulls with holes are not yet supported.`,
+				libraryUsage: usesGEOS,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
+	"st_split": makeBuiltin(
+		defProps(),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "input", Typ: types.Geometry},
+				{Name: "blade", Typ: types.Geometry},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				input := tree.MustBeDGeometry(args[0])
+				blade := tree.MustBeDGeometry(args[1])
+				ret, err := geomfn.Split(input.Geometry, blade.Geometry)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: `Returns a GeometryCollection of the parts resulting from splitting the input geometry by the blade geometry. ` +
+					`(Multi)LineStrings can be split by (Multi)Points, (Multi)LineStrings or the boundaries of (Multi)Polygons. ` +
+					`(Multi)Polygons can be split by (Multi)LineStrings.`,
+				libraryUsage: usesGEOS,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
+	"st_buildarea": makeBuiltin(
+		defProps(),
+		geometryOverload1(
+			func(_ context.Context, _ *eval.Context, g *tree.DGeometry) (tree.Datum, error) {
+				ret, err := geomfn.BuildArea(g.Geometry)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			types.Geometry,
+			infoBuilder{
+				info:         `Returns the areal geometry formed by the linework of the given geometry. Rings nested inside other rings become holes.`,
+				libraryUsage: usesGEOS,
+			},
+			volatility.Immutable,
+		),
+	),
+	"st_simplifyvw": makeBuiltin(
+		defProps(),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "tolerance", Typ: types.Float},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				tolerance := float64(tree.MustBeDFloat(args[1]))
+				ret, err := geomfn.SimplifyVW(g.Geometry, tolerance)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: `Simplifies the given geometry using the Visvalingam-Whyatt algorithm, removing vertices ` +
+					`forming triangles with their neighbors with an area smaller than tolerance.`,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
+	"st_chaikinsmoothing": makeBuiltin(
+		defProps(),
+		geometryOverload1(
+			func(_ context.Context, _ *eval.Context, g *tree.DGeometry) (tree.Datum, error) {
+				ret, err := geomfn.ChaikinSmoothing(g.Geometry, 1 /* numIterations */, false /* preserveEndPoints */)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			types.Geometry,
+			infoBuilder{
+				info: `Smooths the given geometry using a single iteration of Chaikin's algorithm.`,
+			},
+			volatility.Immutable,
+		),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "n_iterations", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				numIterations := int(tree.MustBeDInt(args[1]))
+				ret, err := geomfn.ChaikinSmoothing(g.Geometry, numIterations, false /* preserveEndPoints */)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: `Smooths the given geometry using n_iterations iterations of Chaikin's algorithm. ` +
+					`n_iterations must be between 1 and 5.`,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "n_iterations", Typ: types.Int},
+				{Name: "preserve_end_points", Typ: types.Bool},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				numIterations := int(tree.MustBeDInt(args[1]))
+				preserveEndPoints := bool(tree.MustBeDBool(args[2]))
+				ret, err := geomfn.ChaikinSmoothing(g.Geometry, numIterations, preserveEndPoints)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometry(ret), nil
+			},
+			Info: infoBuilder{
+				info: `Smooths the given geometry using n_iterations iterations of Chaikin's algorithm. ` +
+					`n_iterations must be between 1 and 5. The end points of LineStrings are always kept, ` +
+					`and the end points of Polygon rings are kept if preserve_end_points is true.`,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
+	"st_geometricmedian": makeBuiltin(
+		defProps(),
+		geometryOverload1(
+			func(_ context.Context, _ *eval.Context, g *tree.DGeometry) (tree.Datum, error) {
+				return geometricMedian(g.Geometry, geomfn.DefaultGeometricMedianTolerance(g.Geometry), defaultGeometricMedianMaxIterations, false /* failIfNotConverged */)
+			},
+			types.Geometry,
+			infoBuilder{
+				info: `Returns the geometric median of the given (Multi)Point, which minimizes the sum of distances to all points. ` +
+					`M coordinates are used as the weights of the points.`,
+			},
+			volatility.Immutable,
+		),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "tolerance", Typ: types.Float},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				tolerance := float64(tree.MustBeDFloat(args[1]))
+				return geometricMedian(g.Geometry, tolerance, defaultGeometricMedianMaxIterations, false /* failIfNotConverged */)
+			},
+			Info: infoBuilder{
+				info: `Returns the geometric median of the given (Multi)Point, which minimizes the sum of distances to all points. ` +
+					`M coordinates are used as the weights of the points. The computation stops once an iteration moves the median by less than tolerance.`,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "tolerance", Typ: types.Float},
+				{Name: "max_iter", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				tolerance := float64(tree.MustBeDFloat(args[1]))
+				maxIterations := int(tree.MustBeDInt(args[2]))
+				return geometricMedian(g.Geometry, tolerance, maxIterations, false /* failIfNotConverged */)
+			},
+			Info: infoBuilder{
+				info: `Returns the geometric median of the given (Multi)Point, which minimizes the sum of distances to all points. ` +
+					`M coordinates are used as the weights of the points. The computation stops once an iteration moves the median by less than tolerance, ` +
+					`or after max_iter iterations.`,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "tolerance", Typ: types.Float},
+				{Name: "max_iter", Typ: types.Int},
+				{Name: "fail_if_not_converged", Typ: types.Bool},
+			},
+			ReturnType: tree.FixedReturnType(types.Geometry),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				g := tree.MustBeDGeometry(args[0])
+				tolerance := float64(tree.MustBeDFloat(args[1]))
+				maxIterations := int(tree.MustBeDInt(args[2]))
+				failIfNotConverged := bool(tree.MustBeDBool(args[3]))
+				return geometricMedian(g.Geometry, tolerance, maxIterations, failIfNotConverged)
+			},
+			Info: infoBuilder{
+				info: `Returns the geometric median of the given (Multi)Point, which minimizes the sum of distances to all points. ` +
+					`M coordinates are used as the weights of the points. The computation stops once an iteration moves the median by less than tolerance, ` +
+					`or after max_iter iterations, in which case an error is returned if fail_if_not_converged is true.`,
+			}.String(),
+			Volatility: volatility.Immutable,
+		},
+	),
 	"st_orientedenvelope": makeBuiltin(
 		defProps(),
 		tree.Overload{
@@ -6970,38 +7527,17 @@ Note that the top vertex of the segment touching another line does not count as
 	// Unimplemented.
 	//
 
-	"st_asgml":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48877}),
-	"st_aslatlontext":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48882}),
-	"st_assvg":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48883}),
 	"st_boundingdiagonal":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48889}),
-	"st_buildarea":           makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48892}),
-	"st_chaikinsmoothing":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48894}),
 	"st_cleangeometry":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48895}),
-	"st_clusterdbscan":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48898}),
-	"st_clusterintersecting": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48899}),
-	"st_clusterkmeans":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48900}),
-	"st_clusterwithin":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48901}),
-	"st_concavehull":         makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48906}),
-	"st_delaunaytriangles":   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48915}),
-	"st_dump":                makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49785}),
-	"st_dumppoints":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49786}),
-	"st_dumprings":           makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49787}),
-	"st_geometricmedian":     makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48944}),
 	"st_interpolatepoint":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48950}),
 	"st_isvaliddetail":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48962}),
 	"st_length2dspheroid":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48967}),
 	"st_lengthspheroid":      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48968}),
-	"st_polygonize":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49011}),
 	"st_quantizecoordinates": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49012}),
 	"st_seteffectivearea":    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49030}),
-	"st_simplifyvw":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49039}),
-	"st_split":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49045}),
 	"st_tileenvelope":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49053}),
 	"st_wrapx":               makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 49068}),
 	"st_bdpolyfromtext":      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48801}),
-	"st_geomfromgml":         makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48807}),
-	"st_geomfromtwkb":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48809}),
-	"st_gmltosql":            makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 48810}),
 }
 
 // returnCompatibilityFixedStringBuiltin is an overload that takes in 0 arguments
@@ -7045,6 +7581,166 @@ func geometryOverload1(
 	}
 }
 
+// stAsGMLOverloads returns the st_asgml overloads for the given spatial type.
+// Empty inputs return NULL, following PostGIS.
+func stAsGMLOverloads(
+	typ *types.T, toSpatialObject func(tree.Datum) geopb.SpatialObject,
+) []tree.Overload {
+	typName := typ.Name()
+	label := "Geometry"
+	if typ.Family() == types.GeographyFamily {
+		label = "Geography"
+	}
+	const optionsInfo = `
+
+Options is a flag that can be bitmasked. The options are:
+* 0: Short CRS (e.g EPSG:4326) (default)
+* 1: Long CRS (e.g urn:ogc:def:crs:EPSG::4326)
+* 2: GML 3 only, omit the srsDimension attribute
+* 4: GML 3 only, use <LineString> rather than <Curve> for lines
+* 16: GML 3 only, output coordinates in lat/lon order`
+
+	var overloads []tree.Overload
+	for _, hasVersion := range []bool{false, true} {
+		// numOptionalArgs are the number of arguments after the spatial object,
+		// which are max_decimal_digits, options and nprefix in that order.
+		for numOptionalArgs := 0; numOptionalArgs <= 3; numOptionalArgs++ {
+			if numOptionalArgs == 3 && !hasVersion {
+				// PostGIS only allows a namespace prefix if the version is given.
+				continue
+			}
+			var paramTypes tree.ParamTypes
+			if hasVersion {
+				paramTypes = append(paramTypes, tree.ParamType{Name: "version", Typ: types.Int})
+			}
+			spatialIdx := len(paramTypes)
+			paramTypes = append(paramTypes, tree.ParamType{Name: typName, Typ: typ})
+			info := fmt.Sprintf("Returns the GML representation of a given %s.", label)
+			if hasVersion {
+				info += " The version may be 2 (default) or 3."
+			} else {
+				info += " GML 2 is used."
+			}
+			if numOptionalArgs >= 1 {
+				paramTypes = append(paramTypes, tree.ParamType{Name: "max_decimal_digits", Typ: types.Int})
+				info += " Coordinates have a maximum of the given number of decimal digits."
+			} else {
+				info += fmt.Sprintf(" Coordinates have a maximum of %d decimal digits.", geo.DefaultGMLDecimalDigits)
+			}
+			if numOptionalArgs >= 2 {
+				paramTypes = append(paramTypes, tree.ParamType{Name: "options", Typ: types.Int})
+				info += optionsInfo
+			}
+			if numOptionalArgs >= 3 {
+				paramTypes = append(paramTypes, tree.ParamType{Name: "nprefix", Typ: types.String})
+				info += "\n\nElements are qualified by the given namespace prefix, or not at all if the prefix is empty."
+			}
+			numOptionalArgs := numOptionalArgs
+			overloads = append(overloads, tree.Overload{
+				Types:      paramTypes,
+				ReturnType: tree.FixedReturnType(types.String),
+				Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+					version := geo.GMLVersion2
+					if spatialIdx > 0 {
+						version = geo.GMLVersion(tree.MustBeDInt(args[0]))
+					}
+					so := toSpatialObject(args[spatialIdx])
+					maxDecimalDigits := geo.DefaultGMLDecimalDigits
+					if numOptionalArgs >= 1 {
+						maxDecimalDigits = int(tree.MustBeDInt(args[spatialIdx+1]))
+					}
+					flag := geo.SpatialObjectToGMLFlag(geo.SpatialObjectToGMLFlagZero)
+					if numOptionalArgs >= 2 {
+						flag = geo.SpatialObjectToGMLFlag(tree.MustBeDInt(args[spatialIdx+2]))
+					}
+					prefix := geo.DefaultGMLNamespacePrefix
+					if numOptionalArgs >= 3 {
+						prefix = string(tree.MustBeDString(args[spatialIdx+3]))
+					}
+					if so.BoundingBox == nil {
+						return tree.DNull, nil
+					}
+					ret, err := geo.SpatialObjectToGML(so, version, maxDecimalDigits, flag, prefix)
+					if err != nil {
+						return nil, err
+					}
+					return tree.NewDString(ret), nil
+				},
+				Info:       infoBuilder{info: info}.String(),
+				Volatility: volatility.Immutable,
+			})
+		}
+	}
+	return overloads
+}
+
+// stAsSVGOverloads returns the st_assvg overloads for the given spatial type.
+func stAsSVGOverloads(
+	typ *types.T, toSpatialObject func(tree.Datum) geopb.SpatialObject,
+) []tree.Overload {
+	typName := typ.Name()
+	label := "Geometry"
+	if typ.Family() == types.GeographyFamily {
+		label = "Geography"
+	}
+	var overloads []tree.Overload
+	// numOptionalArgs are the number of arguments after the spatial object,
+	// which are rel and max_decimal_digits in that order.
+	for numOptionalArgs := 0; numOptionalArgs <= 2; numOptionalArgs++ {
+		paramTypes := tree.ParamTypes{{Name: typName, Typ: typ}}
+		info := fmt.Sprintf("Returns the SVG path data of a given %s.", label)
+		if numOptionalArgs >= 1 {
+			paramTypes = append(paramTypes, tree.ParamType{Name: "rel", Typ: types.Int})
+			info += " If rel is 1, paths are written using relative moves."
+		}
+		if numOptionalArgs >= 2 {
+			paramTypes = append(paramTypes, tree.ParamType{Name: "max_decimal_digits", Typ: types.Int})
+			info += " Coordinates have a maximum of the given number of decimal digits."
+		} else {
+			info += fmt.Sprintf(" Coordinates have a maximum of %d decimal digits.", geo.DefaultSVGDecimalDigits)
+		}
+		numOptionalArgs := numOptionalArgs
+		overloads = append(overloads, tree.Overload{
+			Types:      paramTypes,
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				so := toSpatialObject(args[0])
+				relative := false
+				if numOptionalArgs >= 1 {
+					relative = tree.MustBeDInt(args[1]) == 1
+				}
+				maxDecimalDigits := geo.DefaultSVGDecimalDigits
+				if numOptionalArgs >= 2 {
+					maxDecimalDigits = int(tree.MustBeDInt(args[2]))
+				}
+				ret, err := geo.SpatialObjectToSVG(so, relative, maxDecimalDigits)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDString(ret), nil
+			},
+			Info:       infoBuilder{info: info}.String(),
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
+// defaultGeometricMedianMaxIterations is the maximum number of iterations
+// used by st_geometricmedian if none is specified, matching PostGIS.
+const defaultGeometricMedianMaxIterations = 10000
+
+// geometricMedian wraps geomfn.GeometricMedian for st_geometricmedian.
+func geometricMedian(
+	g geo.Geometry, tolerance float64, maxIterations int, failIfNotConverged bool,
+) (tree.Datum, error) {
+	ret, err := geomfn.GeometricMedian(g, tolerance, maxIterations, failIfNotConverged)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDGeometry(ret), nil
+}
+
 // geometryOverload1UnaryPredicate hides the boilerplate for builtins
 // operating on one geometry wrapping a unary predicate.
 func geometryOverload1UnaryPredicate(
@@ -7230,6 +7926,7 @@ func init() {
 	}{
 		{"geomfromewkt", "st_geomfromewkt"},
 		{"geomfromewkb", "st_geomfromewkb"},
+		{"st_gmltosql", "st_geomfromgml"},
 		{"st_coorddim", "st_ndims"},
 		{"st_geogfromtext", "st_geographyfromtext"},
 		{"st_geomfromtext", "st_geometryfromtext"},
@@ -7276,20 +7973,14 @@ func init() {
 		"st_area",
 		"st_asewkt",
 		"st_asgeojson",
-		// TODO(#48877): uncomment
-		// "st_asgml",
+		"st_asgml",
 		"st_askml",
-		// TODO(#48883): uncomment
-		// "st_assvg",
+		"st_assvg",
 		// TODO(#48886): uncomment
 		// "st_astwkb",
 		"st_astext",
 		"st_buffer",
 		"st_centroid",
-		// TODO(#48899): uncomment
-		// "st_clusterintersecting",
-		// TODO(#48901): uncomment
-		// "st_clusterwithin",
 		"st_coveredby",
 		"st_covers",
 		"st_distance",
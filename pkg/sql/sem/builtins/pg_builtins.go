@@ -496,6 +496,32 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	// See https://www.postgresql.org/docs/current/functions-info.html.
+	"pg_notify": makeBuiltin(defProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "channel", Typ: types.String}, {Name: "payload", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Void),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[0] == tree.DNull {
+					return nil, pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+				}
+				var payload string
+				if args[1] != tree.DNull {
+					payload = string(tree.MustBeDString(args[1]))
+				}
+				if err := evalCtx.Planner.NotifyChannel(ctx, string(tree.MustBeDString(args[0])), payload); err != nil {
+					return nil, err
+				}
+				return tree.DVoidDatum, nil
+			},
+			Info: "Sends a notification event with the given payload to the sessions " +
+				"listening on the given channel when the current transaction commits. " +
+				"Notifications are only delivered to sessions connected to the same node.",
+			CalledOnNullInput: true,
+			Volatility:        volatility.Volatile,
+		},
+	),
+
 	// See https://www.postgresql.org/docs/9.3/static/catalog-pg-database.html.
 	"pg_encoding_to_char": makeBuiltin(defProps(),
 		tree.Overload{
@@ -0,0 +1,105 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
+)
+
+func init() {
+	for k, v := range pgvectorBuiltins {
+		v.props.Category = builtinconstants.CategoryVector
+		v.props.AvailableOnPublicSchema = true
+		registerBuiltin(k, v)
+	}
+}
+
+var pgvectorBuiltins = map[string]builtinDefinition{
+	"l1_distance": makeVectorDistanceBuiltin(
+		vector.L1Distance,
+		"Returns the taxicab (L1) distance between the two vectors.",
+	),
+	"l2_distance": makeVectorDistanceBuiltin(
+		vector.L2Distance,
+		"Returns the Euclidean (L2) distance between the two vectors. "+
+			"This is equivalent to the <-> operator.",
+	),
+	"cosine_distance": makeVectorDistanceBuiltin(
+		vector.CosDistance,
+		"Returns the cosine distance between the two vectors, which is one minus "+
+			"their cosine similarity. This is equivalent to the <=> operator.",
+	),
+	"inner_product": makeVectorDistanceBuiltin(
+		vector.InnerProduct,
+		"Returns the inner product of the two vectors. The <#> operator returns "+
+			"its negation.",
+	),
+	"vector_dims": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.PGVector}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDPGVector(args[0])
+				return tree.NewDInt(tree.DInt(len(v.T))), nil
+			},
+			Info:       "Returns the number of dimensions of the vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"vector_norm": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.PGVector}},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDPGVector(args[0])
+				return tree.NewDFloat(tree.DFloat(vector.Norm(v.T))), nil
+			},
+			Info:       "Returns the Euclidean norm of the vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+}
+
+// makeVectorDistanceBuiltin returns a builtin that computes the given function
+// of two vectors, which must have the same number of dimensions.
+func makeVectorDistanceBuiltin(
+	f func(v, other vector.T) (float64, error), info string,
+) builtinDefinition {
+	return makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "v1", Typ: types.PGVector},
+				{Name: "v2", Typ: types.PGVector},
+			},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v1, v2 := tree.MustBeDPGVector(args[0]), tree.MustBeDPGVector(args[1])
+				d, err := f(v1.T, v2.T)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDFloat(tree.DFloat(d)), nil
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		},
+	)
+}
@@ -0,0 +1,349 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	for k, v := range rangeBuiltins {
+		v.props.Category = builtinconstants.CategoryRange
+		v.props.AvailableOnPublicSchema = true
+		registerBuiltin(k, v)
+	}
+	for i := range types.Ranges {
+		rangeTyp, multirangeTyp := types.Ranges[i], types.Multiranges[i]
+		registerBuiltin(rangeTyp.PGName(), makeRangeConstructorBuiltin(rangeTyp))
+		registerBuiltin(multirangeTyp.PGName(), makeMultirangeConstructorBuiltin(multirangeTyp))
+	}
+}
+
+var rangeBuiltins = map[string]builtinDefinition{
+	"isempty": makeRangeAccessorBuiltin(
+		types.Bool,
+		func(r *tree.DRange) (tree.Datum, error) {
+			return tree.MakeDBool(tree.DBool(r.Empty)), nil
+		},
+		"Returns whether the %s is empty.",
+	),
+	"lower_inc": makeRangeAccessorBuiltin(
+		types.Bool,
+		func(r *tree.DRange) (tree.Datum, error) {
+			return tree.MakeDBool(tree.DBool(!r.Empty && r.Lower.Inclusive)), nil
+		},
+		"Returns whether the lower bound of the %s is inclusive.",
+	),
+	"upper_inc": makeRangeAccessorBuiltin(
+		types.Bool,
+		func(r *tree.DRange) (tree.Datum, error) {
+			return tree.MakeDBool(tree.DBool(!r.Empty && r.Upper.Inclusive)), nil
+		},
+		"Returns whether the upper bound of the %s is inclusive.",
+	),
+	"lower_inf": makeRangeAccessorBuiltin(
+		types.Bool,
+		func(r *tree.DRange) (tree.Datum, error) {
+			return tree.MakeDBool(tree.DBool(!r.Empty && r.Lower.IsInfinite())), nil
+		},
+		"Returns whether the lower bound of the %s is infinite.",
+	),
+	"upper_inf": makeRangeAccessorBuiltin(
+		types.Bool,
+		func(r *tree.DRange) (tree.Datum, error) {
+			return tree.MakeDBool(tree.DBool(!r.Empty && r.Upper.IsInfinite())), nil
+		},
+		"Returns whether the upper bound of the %s is infinite.",
+	),
+	"range_merge": makeBuiltin(
+		tree.FunctionProperties{},
+		append(
+			makeRangePairOverloads(
+				func(ctx context.Context, evalCtx *eval.Context, left, right *tree.DRange) (tree.Datum, error) {
+					return left.Merge(evalCtx, right)
+				},
+				"Returns the smallest range which includes both of the given ranges.",
+			),
+			tree.Overload{
+				Types:      tree.ParamTypes{{Name: "val", Typ: types.AnyMultirange}},
+				ReturnType: rangeOfFirstArgReturnType(types.MultirangeRange),
+				Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+					return tree.MustBeDMultirange(args[0]).Span(), nil
+				},
+				Info:       "Returns the smallest range which includes the entire multirange.",
+				Volatility: volatility.Immutable,
+			},
+		)...,
+	),
+	"range_adjacent": makeBuiltin(
+		tree.FunctionProperties{},
+		makeRangeAdjacentOverloads()...,
+	),
+	"multirange": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.AnyRange}},
+			ReturnType: rangeOfFirstArgReturnType(types.RangeMultirange),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDMultirangeFromRange(tree.MustBeDRange(args[0])), nil
+			},
+			Info:       "Returns a multirange containing just the given range.",
+			Volatility: volatility.Immutable,
+		},
+	),
+}
+
+// rangeOfFirstArgReturnType returns a ReturnTyper which applies the given
+// mapping to the range or multirange type of the first argument.
+func rangeOfFirstArgReturnType(mapType func(*types.T) *types.T) tree.ReturnTyper {
+	return func(args []tree.TypedExpr) *types.T {
+		if len(args) == 0 || args[0].ResolvedType().Family() == types.UnknownFamily {
+			return tree.UnknownReturnType
+		}
+		if t := mapType(args[0].ResolvedType()); t != nil {
+			return t
+		}
+		return tree.UnknownReturnType
+	}
+}
+
+// rangeBoundReturnType returns the subtype of the range or multirange type of
+// the first argument.
+var rangeBoundReturnType = rangeOfFirstArgReturnType(func(t *types.T) *types.T {
+	return t.RangeContents()
+})
+
+// rangeBoundOverload returns an overload of the lower or upper builtin for
+// the given range or multirange wildcard type. The bounds of a multirange are
+// the lower bound of its first range and the upper bound of its last range.
+func rangeBoundOverload(typ *types.T, isLower bool) tree.Overload {
+	name := "upper"
+	if isLower {
+		name = "lower"
+	}
+	return tree.Overload{
+		Types:      tree.ParamTypes{{Name: "val", Typ: typ}},
+		ReturnType: rangeBoundReturnType,
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			r, ok := tree.AsDRange(args[0])
+			if !ok {
+				r = tree.MustBeDMultirange(args[0]).Span()
+			}
+			b := r.Upper
+			if isLower {
+				b = r.Lower
+			}
+			if r.Empty || b.IsInfinite() {
+				return tree.DNull, nil
+			}
+			return b.Val, nil
+		},
+		Info: fmt.Sprintf("Returns the %s bound of `val`, or NULL if `val` is empty "+
+			"or the bound is infinite.", name),
+		Volatility: volatility.Immutable,
+	}
+}
+
+// makeRangeAccessorBuiltin returns a builtin with one overload for ranges and
+// one for multiranges. The function is applied to the smallest range which
+// includes the entire multirange. The info string is formatted with "range" or
+// "multirange".
+func makeRangeAccessorBuiltin(
+	ret *types.T, fn func(r *tree.DRange) (tree.Datum, error), info string,
+) builtinDefinition {
+	return makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.AnyRange}},
+			ReturnType: tree.FixedReturnType(ret),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(tree.MustBeDRange(args[0]))
+			},
+			Info:       fmt.Sprintf(info, "range"),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.AnyMultirange}},
+			ReturnType: tree.FixedReturnType(ret),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(tree.MustBeDMultirange(args[0]).Span())
+			},
+			Info:       fmt.Sprintf(info, "multirange"),
+			Volatility: volatility.Immutable,
+		},
+	)
+}
+
+// makeRangePairOverloads returns an overload taking two ranges of the same type
+// and returning a range of that type for every range type. Overloads are defined for each concrete range type
+// rather than for the AnyRange wildcard so that both arguments are required to
+// have the same type.
+func makeRangePairOverloads(
+	fn func(ctx context.Context, evalCtx *eval.Context, left, right *tree.DRange) (tree.Datum, error),
+	info string,
+) []tree.Overload {
+	overloads := make([]tree.Overload, len(types.Ranges))
+	for i, rangeTyp := range types.Ranges {
+		overloads[i] = tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: rangeTyp}, {Name: "right", Typ: rangeTyp}},
+			ReturnType: tree.FixedReturnType(rangeTyp),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(ctx, evalCtx, tree.MustBeDRange(args[0]), tree.MustBeDRange(args[1]))
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		}
+	}
+	return overloads
+}
+
+// makeRangeAdjacentOverloads returns the overloads of range_adjacent, which
+// implements the -|- operator. Any combination of a range and a multirange of
+// the same subtype is allowed.
+func makeRangeAdjacentOverloads() []tree.Overload {
+	var overloads []tree.Overload
+	for i := range types.Ranges {
+		rangeTyp, multirangeTyp := types.Ranges[i], types.Multiranges[i]
+		for _, operands := range [][2]*types.T{
+			{rangeTyp, rangeTyp},
+			{multirangeTyp, multirangeTyp},
+			{rangeTyp, multirangeTyp},
+			{multirangeTyp, rangeTyp},
+		} {
+			overloads = append(overloads, tree.Overload{
+				Types: tree.ParamTypes{
+					{Name: "left", Typ: operands[0]}, {Name: "right", Typ: operands[1]},
+				},
+				ReturnType: tree.FixedReturnType(types.Bool),
+				Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+					left, right := asDMultirange(args[0]), asDMultirange(args[1])
+					ok, err := left.Adjacent(evalCtx, right)
+					if err != nil {
+						return nil, err
+					}
+					return tree.MakeDBool(tree.DBool(ok)), nil
+				},
+				Info:       "Returns whether the arguments are adjacent. Implements the -|- operator.",
+				Volatility: volatility.Immutable,
+			})
+		}
+	}
+	return overloads
+}
+
+// asDMultirange returns the given range or multirange as a multirange.
+func asDMultirange(d tree.Datum) *tree.DMultirange {
+	if r, ok := tree.AsDRange(d); ok {
+		return tree.NewDMultirangeFromRange(r)
+	}
+	return tree.MustBeDMultirange(d)
+}
+
+// parseRangeBoundsFlags parses the bounds argument of a range constructor, for
+// example "[)", and returns whether the lower and upper bounds are inclusive.
+func parseRangeBoundsFlags(s string) (lowerInc, upperInc bool, _ error) {
+	if len(s) == 2 && (s[0] == '[' || s[0] == '(') && (s[1] == ']' || s[1] == ')') {
+		return s[0] == '[', s[1] == ']', nil
+	}
+	return false, false, errors.WithHint(
+		pgerror.New(pgcode.Syntax, "invalid range bound flags"),
+		`Valid values are "[]", "[)", "(]", and "()".`,
+	)
+}
+
+// makeRangeConstructorBuiltin returns the constructor function of the given
+// range type. A NULL bound means that the bound is infinite.
+func makeRangeConstructorBuiltin(rangeTyp *types.T) builtinDefinition {
+	elemTyp := rangeTyp.RangeContents()
+	fn := func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+		lowerInc, upperInc := true, false
+		if len(args) > 2 {
+			if args[2] == tree.DNull {
+				return nil, pgerror.New(pgcode.DataException, "range constructor flags argument must not be null")
+			}
+			var err error
+			lowerInc, upperInc, err = parseRangeBoundsFlags(string(tree.MustBeDString(args[2])))
+			if err != nil {
+				return nil, err
+			}
+		}
+		var lower, upper tree.RangeBound
+		if args[0] != tree.DNull {
+			lower = tree.RangeBound{Val: args[0], Inclusive: lowerInc}
+		}
+		if args[1] != tree.DNull {
+			upper = tree.RangeBound{Val: args[1], Inclusive: upperInc}
+		}
+		return tree.NewDRange(evalCtx, rangeTyp, lower, upper)
+	}
+	return makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryRange},
+		tree.Overload{
+			Types:             tree.ParamTypes{{Name: "lower", Typ: elemTyp}, {Name: "upper", Typ: elemTyp}},
+			ReturnType:        tree.FixedReturnType(rangeTyp),
+			Fn:                fn,
+			CalledOnNullInput: true,
+			Info: fmt.Sprintf("Constructs a %s from the given bounds. The lower bound is "+
+				"inclusive and the upper bound is exclusive. A NULL bound is infinite.", rangeTyp.PGName()),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: elemTyp}, {Name: "upper", Typ: elemTyp}, {Name: "bounds", Typ: types.String},
+			},
+			ReturnType:        tree.FixedReturnType(rangeTyp),
+			Fn:                fn,
+			CalledOnNullInput: true,
+			Info: fmt.Sprintf("Constructs a %s from the given bounds. The inclusivity of the "+
+				"bounds is given by `bounds`, which is one of \"[]\", \"[)\", \"(]\" or \"()\". "+
+				"A NULL bound is infinite.", rangeTyp.PGName()),
+			Volatility: volatility.Immutable,
+		},
+	)
+}
+
+// makeMultirangeConstructorBuiltin returns the constructor function of the
+// given multirange type.
+func makeMultirangeConstructorBuiltin(multirangeTyp *types.T) builtinDefinition {
+	rangeTyp := types.MultirangeRange(multirangeTyp)
+	return makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryRange},
+		tree.Overload{
+			Types:      tree.VariadicType{VarType: rangeTyp},
+			ReturnType: tree.FixedReturnType(multirangeTyp),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				ranges := make([]*tree.DRange, 0, len(args))
+				for _, arg := range args {
+					if arg == tree.DNull {
+						return nil, pgerror.New(pgcode.NullValueNotAllowed,
+							"multirange values cannot contain null members")
+					}
+					ranges = append(ranges, tree.MustBeDRange(arg))
+				}
+				return tree.NewDMultirange(evalCtx, multirangeTyp, ranges)
+			},
+			CalledOnNullInput: true,
+			Info: fmt.Sprintf("Constructs a %s which is the union of the given ranges.",
+				multirangeTyp.PGName()),
+			Volatility: volatility.Immutable,
+		},
+	)
+}
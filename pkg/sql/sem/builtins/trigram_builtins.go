@@ -12,6 +12,7 @@ package builtins
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -69,8 +70,188 @@ var trigramBuiltins = map[string]builtinDefinition{
 			Volatility: volatility.Immutable,
 		},
 	),
-	"word_similarity":        makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 41285, Category: builtinconstants.CategoryTrigram}),
-	"strict_word_similarity": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 41285, Category: builtinconstants.CategoryTrigram}),
-	"show_limit":             makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 41285, Category: builtinconstants.CategoryTrigram}),
-	"set_limit":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 41285, Category: builtinconstants.CategoryTrigram}),
+	"word_similarity": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryTrigram},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: types.String}, {Name: "right", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1]))
+				f := trigram.WordSimilarity(l, r)
+				return tree.NewDFloat(tree.DFloat(f)), nil
+			},
+			Info: "Returns a number that indicates the greatest similarity between the" +
+				" set of trigrams in the first string and any continuous extent of an" +
+				" ordered set of trigrams in the second string.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"strict_word_similarity": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryTrigram},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: types.String}, {Name: "right", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1]))
+				f := trigram.StrictWordSimilarity(l, r)
+				return tree.NewDFloat(tree.DFloat(f)), nil
+			},
+			Info: "Same as word_similarity, but forces extent boundaries to match word" +
+				" boundaries.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"show_limit": makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryTrigram},
+		tree.Overload{
+			Types:      tree.ParamTypes{},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ context.Context, evalCtx *eval.Context, _ tree.Datums) (tree.Datum, error) {
+				return tree.NewDFloat(tree.DFloat(evalCtx.SessionData().TrigramSimilarityThreshold)), nil
+			},
+			Info:       "Returns the current similarity threshold used by the % operator.",
+			Volatility: volatility.Stable,
+		},
+	),
+	"set_limit": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategoryTrigram,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "limit", Typ: types.Float}},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				f := float64(tree.MustBeDFloat(args[0]))
+				if err := setSessionVar(
+					ctx, evalCtx, "pg_trgm.similarity_threshold",
+					strconv.FormatFloat(f, 'g', -1, 64), false, /* isLocal */
+				); err != nil {
+					return nil, err
+				}
+				return tree.NewDFloat(tree.DFloat(evalCtx.SessionData().TrigramSimilarityThreshold)), nil
+			},
+			Info: "Sets the current similarity threshold that is used by the % operator." +
+				" Returns the same value passed in.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
+	// Trigram operator functions. The <%, %>, <<% and %>> operators and the
+	// word similarity distance operators are parsed into calls to these
+	// functions, which are named after their Postgres counterparts.
+	"word_similarity_op": makeTrigramThresholdOp(
+		trigram.WordSimilarity,
+		func(evalCtx *eval.Context) float64 { return evalCtx.SessionData().TrigramWordSimilarityThreshold },
+		false, /* commute */
+		"Returns whether the word similarity of the arguments is at least the"+
+			" current value of pg_trgm.word_similarity_threshold. Implements the"+
+			" <% operator.",
+	),
+	"word_similarity_commutator_op": makeTrigramThresholdOp(
+		trigram.WordSimilarity,
+		func(evalCtx *eval.Context) float64 { return evalCtx.SessionData().TrigramWordSimilarityThreshold },
+		true, /* commute */
+		"Commutator of word_similarity_op. Implements the %> operator.",
+	),
+	"strict_word_similarity_op": makeTrigramThresholdOp(
+		trigram.StrictWordSimilarity,
+		func(evalCtx *eval.Context) float64 {
+			return evalCtx.SessionData().TrigramStrictWordSimilarityThreshold
+		},
+		false, /* commute */
+		"Returns whether the strict word similarity of the arguments is at least"+
+			" the current value of pg_trgm.strict_word_similarity_threshold."+
+			" Implements the <<% operator.",
+	),
+	"strict_word_similarity_commutator_op": makeTrigramThresholdOp(
+		trigram.StrictWordSimilarity,
+		func(evalCtx *eval.Context) float64 {
+			return evalCtx.SessionData().TrigramStrictWordSimilarityThreshold
+		},
+		true, /* commute */
+		"Commutator of strict_word_similarity_op. Implements the %>> operator.",
+	),
+	"similarity_dist": makeTrigramDistanceOp(
+		trigram.Similarity,
+		false, /* commute */
+		"Returns one minus the similarity of the arguments. Implements the <->"+
+			" operator.",
+	),
+	"word_similarity_dist_op": makeTrigramDistanceOp(
+		trigram.WordSimilarity,
+		false, /* commute */
+		"Returns one minus the word similarity of the arguments. Implements the"+
+			" <<-> operator.",
+	),
+	"word_similarity_dist_commutator_op": makeTrigramDistanceOp(
+		trigram.WordSimilarity,
+		true, /* commute */
+		"Commutator of word_similarity_dist_op. Implements the <->> operator.",
+	),
+	"strict_word_similarity_dist_op": makeTrigramDistanceOp(
+		trigram.StrictWordSimilarity,
+		false, /* commute */
+		"Returns one minus the strict word similarity of the arguments. Implements"+
+			" the <<<-> operator.",
+	),
+	"strict_word_similarity_dist_commutator_op": makeTrigramDistanceOp(
+		trigram.StrictWordSimilarity,
+		true, /* commute */
+		"Commutator of strict_word_similarity_dist_op. Implements the <->>>"+
+			" operator.",
+	),
+}
+
+// makeTrigramThresholdOp returns a builtin that compares a trigram similarity
+// measure of its arguments against a threshold read from the session. If
+// commute is true, the arguments are swapped before computing the similarity.
+func makeTrigramThresholdOp(
+	similarity func(l, r string) float64,
+	threshold func(evalCtx *eval.Context) float64,
+	commute bool,
+	info string,
+) builtinDefinition {
+	return makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryTrigram},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: types.String}, {Name: "right", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1]))
+				if commute {
+					l, r = r, l
+				}
+				return tree.MakeDBool(tree.DBool(similarity(l, r) >= threshold(evalCtx))), nil
+			},
+			Info: info,
+			// These functions are only stable because their results depend on
+			// the value of a session setting.
+			Volatility: volatility.Stable,
+		},
+	)
+}
+
+// makeTrigramDistanceOp returns a builtin that computes one minus a trigram
+// similarity measure of its arguments. If commute is true, the arguments are
+// swapped before computing the similarity.
+func makeTrigramDistanceOp(
+	similarity func(l, r string) float64, commute bool, info string,
+) builtinDefinition {
+	return makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryTrigram},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: types.String}, {Name: "right", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Float),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1]))
+				if commute {
+					l, r = r, l
+				}
+				return tree.NewDFloat(tree.DFloat(1 - similarity(l, r))), nil
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		},
+	)
 }
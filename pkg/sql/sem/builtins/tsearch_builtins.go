@@ -0,0 +1,597 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+)
+
+func init() {
+	for k, v := range tsearchBuiltins {
+		v.props.Category = builtinconstants.CategoryFullTextSearch
+		v.props.AvailableOnPublicSchema = true
+		registerBuiltin(k, v)
+	}
+}
+
+var tsearchBuiltins = map[string]builtinDefinition{
+	"to_tsvector": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return toTSVector(string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1])))
+			},
+			Info: "Converts text to a tsvector, normalizing words according to the " +
+				"specified text search configuration.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return toTSVector(defaultTSConfig(evalCtx), string(tree.MustBeDString(args[0])))
+			},
+			Info: "Converts text to a tsvector, normalizing words according to the " +
+				"default_text_search_config session variable.",
+			Volatility: volatility.Stable,
+		},
+	),
+	"to_tsquery": makeTSQueryBuiltin(tsearch.ToTSQuery,
+		"Converts the input text, which must be formatted like a tsquery, to a tsquery, "+
+			"normalizing words according to the %s."),
+	"plainto_tsquery": makeTSQueryBuiltin(tsearch.PlainToTSQuery,
+		"Converts text to a tsquery that matches all of its words, normalizing words "+
+			"according to the %s. Punctuation in the input is ignored."),
+	"phraseto_tsquery": makeTSQueryBuiltin(tsearch.PhraseToTSQuery,
+		"Converts text to a tsquery that matches its words as a phrase, normalizing "+
+			"words according to the %s. Stop words are accounted for in the distances "+
+			"between words."),
+	"websearch_to_tsquery": makeTSQueryBuiltin(tsearch.WebSearchToTSQuery,
+		"Converts text to a tsquery using an alternative syntax similar to the one used "+
+			"by web search engines, normalizing words according to the %s. Quoted text "+
+			"is matched as a phrase, the word \"or\" is converted to |, and a dash "+
+			"is converted to !."),
+	"get_current_ts_config": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, evalCtx *eval.Context, _ tree.Datums) (tree.Datum, error) {
+				return tree.NewDString(defaultTSConfig(evalCtx)), nil
+			},
+			Info:       "Returns the default text search configuration of the session.",
+			Volatility: volatility.Stable,
+		},
+	),
+	"ts_lexize": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "dictionary", Typ: types.String}, {Name: "token", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.StringArray),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				lexemes, err := tsearch.TSLexize(string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1])))
+				if err != nil {
+					return nil, err
+				}
+				return stringsToArray(lexemes)
+			},
+			Info: "Returns the lexemes that the given text search dictionary produces for " +
+				"the token, or an empty array if the token is a stop word.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_rank": makeTSRankBuiltin(tsearch.Rank,
+		"Ranks the vector against the query based on the frequency of matching lexemes."),
+	"ts_rank_cd": makeTSRankBuiltin(tsearch.RankCD,
+		"Ranks the vector against the query using the cover density method, which "+
+			"takes into account the proximity of matching lexemes."),
+	"ts_headline": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "config", Typ: types.String},
+				{Name: "document", Typ: types.String},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "options", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tsHeadline(string(tree.MustBeDString(args[0])), args[1], args[2], args[3])
+			},
+			Info:       tsHeadlineInfo + " The options are a comma-separated list of option=value pairs.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "config", Typ: types.String},
+				{Name: "document", Typ: types.String},
+				{Name: "query", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tsHeadline(string(tree.MustBeDString(args[0])), args[1], args[2], nil /* options */)
+			},
+			Info:       tsHeadlineInfo,
+			Volatility: volatility.Immutable,
+			// Postgres takes the configuration as a regconfig, so the Postgres
+			// overload with the same parameter types is the stable
+			// ts_headline(document, query, options).
+			IgnoreVolatilityCheck: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "document", Typ: types.String},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "options", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tsHeadline(defaultTSConfig(evalCtx), args[0], args[1], args[2])
+			},
+			Info: tsHeadlineInfo + " The options are a comma-separated list of option=value pairs. " +
+				"Uses the default_text_search_config session variable.",
+			Volatility: volatility.Stable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "document", Typ: types.String},
+				{Name: "query", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tsHeadline(defaultTSConfig(evalCtx), args[0], args[1], nil /* options */)
+			},
+			Info:       tsHeadlineInfo + " Uses the default_text_search_config session variable.",
+			Volatility: volatility.Stable,
+		},
+	),
+	"setweight": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}, {Name: "weight", Typ: types.QChar}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0])
+				ret, err := tsearch.SetWeight(v.TSVector, string(tree.MustBeDString(args[1])), nil /* lexemes */)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(ret), nil
+			},
+			Info:       "Assigns the given weight to each position of the vector.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "weight", Typ: types.QChar},
+				{Name: "lexemes", Typ: types.StringArray},
+			},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0])
+				lexemes, err := tsLexemeArray(args[2])
+				if err != nil {
+					return nil, err
+				}
+				ret, err := tsearch.SetWeight(v.TSVector, string(tree.MustBeDString(args[1])), lexemes)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(ret), nil
+			},
+			Info:       "Assigns the given weight to the positions of the listed lexemes of the vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"strip": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDTSVector(tsearch.Strip(tree.MustBeDTSVector(args[0]).TSVector)), nil
+			},
+			Info:       "Removes positions and weights from the vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_delete": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}, {Name: "lexeme", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0])
+				return tree.NewDTSVector(tsearch.Delete(v.TSVector, string(tree.MustBeDString(args[1])))), nil
+			},
+			Info:       "Removes every occurrence of the given lexeme from the vector.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}, {Name: "lexemes", Typ: types.StringArray}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0])
+				lexemes, err := tsLexemeArray(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(tsearch.Delete(v.TSVector, lexemes...)), nil
+			},
+			Info:       "Removes every occurrence of the given lexemes from the vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_filter": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "weights", Typ: types.MakeArray(types.QChar)},
+			},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0])
+				arr := tree.MustBeDArray(args[1])
+				weights := make([]string, len(arr.Array))
+				for i, d := range arr.Array {
+					if d == tree.DNull {
+						return nil, pgerror.New(pgcode.NullValueNotAllowed, "weight array may not contain nulls")
+					}
+					weights[i] = string(tree.MustBeDString(d))
+				}
+				ret, err := tsearch.Filter(v.TSVector, weights)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(ret), nil
+			},
+			Info:       "Keeps only the positions of the vector that have one of the given weights.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"tsvector_concat": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: types.TSVector}, {Name: "right", Typ: types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				l, r := tree.MustBeDTSVector(args[0]), tree.MustBeDTSVector(args[1])
+				return tree.NewDTSVector(tsearch.Concat(l.TSVector, r.TSVector)), nil
+			},
+			Info: "Concatenates two vectors. The positions of the second vector are shifted " +
+				"to follow the positions of the first one.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"tsvector_to_array": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.StringArray),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return stringsToArray(tsearch.Lexemes(tree.MustBeDTSVector(args[0]).TSVector))
+			},
+			Info:       "Returns the lexemes of the vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"array_to_tsvector": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "lexemes", Typ: types.StringArray}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				lexemes, err := tsLexemeArray(args[0])
+				if err != nil {
+					return nil, err
+				}
+				ret, err := tsearch.FromLexemes(lexemes)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(ret), nil
+			},
+			Info:       "Converts an array of lexemes to a vector without positions.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"numnode": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "query", Typ: types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDInt(tree.DInt(tsearch.NumNode(tree.MustBeDTSQuery(args[0]).TSQuery))), nil
+			},
+			Info:       "Returns the number of lexemes and operators in the query.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"tsquery_phrase": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: types.TSQuery}, {Name: "right", Typ: types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tsQueryPhrase(args[0], args[1], 1 /* distance */)
+			},
+			Info:       "Returns a query that matches the left query followed immediately by the right query.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "left", Typ: types.TSQuery},
+				{Name: "right", Typ: types.TSQuery},
+				{Name: "distance", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tsQueryPhrase(args[0], args[1], int64(tree.MustBeDInt(args[2])))
+			},
+			Info: "Returns a query that matches the left query followed by the right query " +
+				"at the given distance.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_match_vq": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "vector", Typ: types.TSVector}, {Name: "query", Typ: types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tsMatch(args[0], args[1])
+			},
+			Info:       "Returns whether the vector matches the query. Equivalent to vector @@ query.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_match_qv": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "query", Typ: types.TSQuery}, {Name: "vector", Typ: types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tsMatch(args[1], args[0])
+			},
+			Info:       "Returns whether the vector matches the query. Equivalent to query @@ vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"tsvector_update_trigger": makeTriggerBuiltin(
+		"Trigger function that sets a tsvector column from text columns. The " +
+			"trigger arguments are the tsvector column, the name of the text search " +
+			"configuration and the text columns."),
+	"tsvector_update_trigger_column": makeTriggerBuiltin(
+		"Trigger function that sets a tsvector column from text columns. The " +
+			"trigger arguments are the tsvector column, a column containing the name " +
+			"of the text search configuration and the text columns."),
+}
+
+const tsHeadlineInfo = "Returns the fragments of the document that match the query, with " +
+	"the matching words highlighted."
+
+// defaultTSConfig returns the text search configuration used by the builtins
+// that aren't passed one explicitly.
+func defaultTSConfig(evalCtx *eval.Context) string {
+	if c := evalCtx.SessionData().DefaultTextSearchConfig; c != "" {
+		return c
+	}
+	return tsearch.DefaultConfig
+}
+
+func toTSVector(config string, input string) (tree.Datum, error) {
+	v, err := tsearch.ToTSVector(config, input)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDTSVector(v), nil
+}
+
+// makeTSQueryBuiltin returns a builtin that converts text to a tsquery using
+// fn, with and without an explicit text search configuration. The info string
+// is formatted with a description of the configuration used by each overload.
+func makeTSQueryBuiltin(
+	fn func(config string, input string) (tsearch.TSQuery, error), info string,
+) builtinDefinition {
+	makeQuery := func(config string, input tree.Datum) (tree.Datum, error) {
+		q, err := fn(config, string(tree.MustBeDString(input)))
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDTSQuery(q), nil
+	}
+	return makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return makeQuery(string(tree.MustBeDString(args[0])), args[1])
+			},
+			Info:       fmt.Sprintf(info, "specified text search configuration"),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return makeQuery(defaultTSConfig(evalCtx), args[0])
+			},
+			Info:       fmt.Sprintf(info, "default_text_search_config session variable"),
+			Volatility: volatility.Stable,
+		},
+	)
+}
+
+// makeTSRankBuiltin returns a builtin that ranks a vector against a query
+// using the given ranking function, with optional weights and normalization.
+func makeTSRankBuiltin(
+	rank func(weights [4]float32, v tsearch.TSVector, q tsearch.TSQuery, method int) float32,
+	info string,
+) builtinDefinition {
+	doRank := func(weights tree.Datum, v, q tree.Datum, method tree.Datum) (tree.Datum, error) {
+		w := tsearch.DefaultRankWeights
+		if weights != nil {
+			arr := tree.MustBeDArray(weights)
+			ws := make([]float32, len(arr.Array))
+			for i, d := range arr.Array {
+				if d == tree.DNull {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed, "array of weight must not contain nulls")
+				}
+				ws[i] = float32(tree.MustBeDFloat(d))
+			}
+			var err error
+			if w, err = tsearch.ValidateRankWeights(ws); err != nil {
+				return nil, err
+			}
+		}
+		m := 0
+		if method != nil {
+			m = int(tree.MustBeDInt(method))
+		}
+		r := rank(w, tree.MustBeDTSVector(v).TSVector, tree.MustBeDTSQuery(q).TSQuery, m)
+		return tree.NewDFloat(tree.DFloat(r)), nil
+	}
+	const methodInfo = " The normalization is a bit mask of the methods used to scale the " +
+		"rank by the length of the document."
+	const weightsInfo = " The weights are applied to lexemes with the D, C, B and A " +
+		"weights, in that order."
+	weightsType := types.MakeArray(types.Float4)
+	return makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "weights", Typ: weightsType},
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "normalization", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return doRank(args[0], args[1], args[2], args[3])
+			},
+			Info:       info + weightsInfo + methodInfo,
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "weights", Typ: weightsType},
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return doRank(args[0], args[1], args[2], nil /* method */)
+			},
+			Info:       info + weightsInfo,
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+				{Name: "normalization", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return doRank(nil /* weights */, args[0], args[1], args[2])
+			},
+			Info:       info + methodInfo,
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.TSVector},
+				{Name: "query", Typ: types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return doRank(nil /* weights */, args[0], args[1], nil /* method */)
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		},
+	)
+}
+
+func tsHeadline(config string, document, query, options tree.Datum) (tree.Datum, error) {
+	opts := tsearch.DefaultHeadlineOptions()
+	if options != nil {
+		var err error
+		if opts, err = tsearch.ParseHeadlineOptions(string(tree.MustBeDString(options))); err != nil {
+			return nil, err
+		}
+	}
+	h, err := tsearch.Headline(
+		config, string(tree.MustBeDString(document)), tree.MustBeDTSQuery(query).TSQuery, opts,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDString(h), nil
+}
+
+// tsLexemeArray returns the elements of a string array that holds lexemes.
+func tsLexemeArray(d tree.Datum) ([]string, error) {
+	arr := tree.MustBeDArray(d)
+	ret := make([]string, len(arr.Array))
+	for i, elem := range arr.Array {
+		if elem == tree.DNull {
+			return nil, pgerror.New(pgcode.NullValueNotAllowed, "lexeme array may not contain nulls")
+		}
+		ret[i] = string(tree.MustBeDString(elem))
+	}
+	return ret, nil
+}
+
+func tsQueryPhrase(l, r tree.Datum, distance int64) (tree.Datum, error) {
+	q, err := tsearch.Phrase(
+		tree.MustBeDTSQuery(l).TSQuery, tree.MustBeDTSQuery(r).TSQuery, int(distance),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDTSQuery(q), nil
+}
+
+func tsMatch(v, q tree.Datum) (tree.Datum, error) {
+	ret, err := tsearch.EvalTSQuery(tree.MustBeDTSQuery(q).TSQuery, tree.MustBeDTSVector(v).TSVector)
+	if err != nil {
+		return nil, err
+	}
+	return tree.MakeDBool(tree.DBool(ret)), nil
+}
+
+// stringsToArray returns a string array datum with the given elements.
+func stringsToArray(strs []string) (tree.Datum, error) {
+	ret := tree.NewDArray(types.String)
+	ret.Array = make(tree.Datums, 0, len(strs))
+	for _, s := range strs {
+		if err := ret.Append(tree.NewDString(s)); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
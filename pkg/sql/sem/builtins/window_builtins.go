@@ -13,8 +13,11 @@ package builtins
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geomfn"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
@@ -45,6 +48,15 @@ func winProps() tree.FunctionProperties {
 	}
 }
 
+// spatialWinProps is used for window functions that come from PostGIS.
+func spatialWinProps() tree.FunctionProperties {
+	return tree.FunctionProperties{
+		Class:                   tree.WindowClass,
+		Category:                builtinconstants.CategorySpatial,
+		AvailableOnPublicSchema: true,
+	}
+}
+
 // windows are a special class of builtin functions that can only be applied
 // as window functions using an OVER clause.
 // See `windowFuncHolder` in the sql package.
@@ -215,6 +227,46 @@ var windows = map[string]builtinDefinition{
 				volatility.Immutable,
 			)
 		}),
+	"st_clusterdbscan": makeBuiltin(spatialWinProps(),
+		makeWindowOverload(
+			tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "eps", Typ: types.Float},
+				{Name: "minpoints", Typ: types.Int},
+			},
+			types.Int,
+			newSpatialClusterWindow(func(
+				ctx context.Context, geoms geomfn.GeometrySource, args tree.Datums,
+			) ([]int, error) {
+				return geomfn.ClusterDBSCAN(
+					ctx, geoms, float64(tree.MustBeDFloat(args[0])), int(tree.MustBeDInt(args[1])),
+				)
+			}),
+			"Returns the 0-indexed cluster number of each geometry in the partition, "+
+				"as determined by the 2D DBSCAN algorithm. Geometries are clustered together when "+
+				"they are within `eps` of a geometry which has at least `minpoints` geometries "+
+				"(including itself) within `eps`. Geometries not in any cluster are assigned null.",
+			volatility.Immutable,
+		),
+	),
+	"st_clusterkmeans": makeBuiltin(spatialWinProps(),
+		makeWindowOverload(
+			tree.ParamTypes{
+				{Name: "geometry", Typ: types.Geometry},
+				{Name: "number_of_clusters", Typ: types.Int},
+			},
+			types.Int,
+			newSpatialClusterWindow(func(
+				ctx context.Context, geoms geomfn.GeometrySource, args tree.Datums,
+			) ([]int, error) {
+				return geomfn.ClusterKMeans(ctx, geoms, int(tree.MustBeDInt(args[0])))
+			}),
+			"Returns the 0-indexed cluster number of each geometry in the partition, "+
+				"as determined by the 2D k-means algorithm over the centers of the geometries' "+
+				"bounding boxes. Empty geometries are assigned null.",
+			volatility.Immutable,
+		),
+	),
 }
 
 func makeWindowOverload(
@@ -237,6 +289,7 @@ var _ eval.WindowFunc = &denseRankWindow{}
 var _ eval.WindowFunc = &percentRankWindow{}
 var _ eval.WindowFunc = &cumulativeDistWindow{}
 var _ eval.WindowFunc = &ntileWindow{}
+var _ eval.WindowFunc = &spatialClusterWindow{}
 var _ eval.WindowFunc = &leadLagWindow{}
 var _ eval.WindowFunc = &firstValueWindow{}
 var _ eval.WindowFunc = &lastValueWindow{}
@@ -262,6 +315,17 @@ func NewAggregateWindowFunc(
 	}
 }
 
+// NewFramableAggregateWindowFunc returns a constructor of a WindowFunc that
+// computes the aggregate created by aggConstructor over the window frame of
+// each row.
+func NewFramableAggregateWindowFunc(
+	aggConstructor func(*eval.Context, tree.Datums) eval.AggregateFunc,
+) func(*eval.Context) eval.WindowFunc {
+	return func(evalCtx *eval.Context) eval.WindowFunc {
+		return newFramableAggregateWindow(aggConstructor(evalCtx, nil /* arguments */), aggConstructor)
+	}
+}
+
 func (w *aggregateWindowFunc) Compute(
 	ctx context.Context, evalCtx *eval.Context, wfr *eval.WindowFrameRun,
 ) (tree.Datum, error) {
@@ -295,7 +359,7 @@ func (w *aggregateWindowFunc) Compute(
 	}
 
 	// Retrieve the value for the entire peer group, save it, and return it.
-	peerRes, err := w.agg.Result()
+	peerRes, err := eval.AggregateResult(ctx, w.agg)
 	if err != nil {
 		return nil, err
 	}
@@ -419,7 +483,7 @@ func (w *framableAggregateWindowFunc) Compute(
 	}
 
 	// Retrieve the value for the entire peer group, save it, and return it.
-	peerRes, err := w.agg.agg.Result()
+	peerRes, err := eval.AggregateResult(ctx, w.agg.agg)
 	if err != nil {
 		return nil, err
 	}
@@ -573,6 +637,98 @@ func (w *cumulativeDistWindow) Reset(context.Context) {
 
 func (w *cumulativeDistWindow) Close(context.Context, *eval.Context) {}
 
+// spatialClusterWindow assigns a cluster number to the geometry of every row
+// in the partition. All geometries of the partition are clustered at once, on
+// the first call to Compute; the remaining arguments are taken from the first
+// row of the partition.
+type spatialClusterWindow struct {
+	cluster func(ctx context.Context, geoms geomfn.GeometrySource, args tree.Datums) ([]int, error)
+	// clusterIDs contains the cluster number of every row in the partition, or
+	// -1 if the row is not in any cluster. It is nil until it is computed.
+	clusterIDs []int
+}
+
+func newSpatialClusterWindow(
+	cluster func(ctx context.Context, geoms geomfn.GeometrySource, args tree.Datums) ([]int, error),
+) eval.WindowOverload {
+	return func([]*types.T, *eval.Context) eval.WindowFunc {
+		return &spatialClusterWindow{cluster: cluster}
+	}
+}
+
+func (w *spatialClusterWindow) Compute(
+	ctx context.Context, _ *eval.Context, wfr *eval.WindowFrameRun,
+) (tree.Datum, error) {
+	if w.clusterIDs == nil {
+		if err := w.computeClusters(ctx, wfr); err != nil {
+			return nil, err
+		}
+	}
+	if id := w.clusterIDs[wfr.RowIdx]; id >= 0 {
+		return tree.NewDInt(tree.DInt(id)), nil
+	}
+	return tree.DNull, nil
+}
+
+func (w *spatialClusterWindow) computeClusters(
+	ctx context.Context, wfr *eval.WindowFrameRun,
+) error {
+	if wfr.PartitionSize() == 0 {
+		return nil
+	}
+	args, err := wfr.ArgsByRowIdx(ctx, 0)
+	if err != nil {
+		return err
+	}
+	params := args[1:]
+	for _, p := range params {
+		if p == tree.DNull {
+			// A NULL parameter makes every cluster number NULL.
+			w.clusterIDs = make([]int, wfr.PartitionSize())
+			for i := range w.clusterIDs {
+				w.clusterIDs[i] = -1
+			}
+			return nil
+		}
+	}
+	// The geometries are read from the rows of the partition as they are
+	// needed, rather than being copied out of the row container.
+	w.clusterIDs, err = w.cluster(ctx, windowGeometries{wfr: wfr}, params)
+	return err
+}
+
+// windowGeometries implements geomfn.GeometrySource over the geometries in the
+// first argument of the rows of a window partition.
+type windowGeometries struct {
+	wfr *eval.WindowFrameRun
+}
+
+var _ geomfn.GeometrySource = windowGeometries{}
+
+// Len implements the geomfn.GeometrySource interface.
+func (g windowGeometries) Len() int {
+	return g.wfr.PartitionSize()
+}
+
+// Geometry implements the geomfn.GeometrySource interface.
+func (g windowGeometries) Geometry(ctx context.Context, i int) (geo.Geometry, bool, error) {
+	args, err := g.wfr.ArgsByRowIdx(ctx, i)
+	if err != nil {
+		return geo.Geometry{}, false, err
+	}
+	if args[0] == tree.DNull {
+		return geo.Geometry{}, false, nil
+	}
+	return tree.MustBeDGeometry(args[0]).Geometry, true, nil
+}
+
+// Reset implements eval.WindowFunc interface.
+func (w *spatialClusterWindow) Reset(context.Context) {
+	w.clusterIDs = nil
+}
+
+func (w *spatialClusterWindow) Close(context.Context, *eval.Context) {}
+
 // ntileWindow computes an integer ranging from 1 to the argument value, dividing
 // the partition as equally as possible.
 type ntileWindow struct {
@@ -274,6 +274,24 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Casts between numeric arrays and vectors are immutable and allowed in
+	// assignment contexts, as defined by the pgvector extension.
+	if srcFamily == types.ArrayFamily && tgtFamily == types.PGVectorFamily {
+		switch src.ArrayContents().Family() {
+		case types.IntFamily, types.FloatFamily, types.DecimalFamily:
+			return Cast{
+				MaxContext: ContextAssignment,
+				Volatility: volatility.Immutable,
+			}, true
+		}
+	}
+	if srcFamily == types.PGVectorFamily && tgt.Oid() == oid.T__float4 {
+		return Cast{
+			MaxContext: ContextAssignment,
+			Volatility: volatility.Immutable,
+		}, true
+	}
+
 	// Casts from int types to bit and varbit types are allowed only if the the
 	// length of the bit or varbit is defined
 	if srcFamily == types.IntFamily &&
@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/trigram"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -140,6 +141,16 @@ func (e *evaluator) EvalCompareBox2DOp(
 	return tree.MakeDBool(tree.DBool(op.Op(left, right))), nil
 }
 
+func (e *evaluator) EvalCompareRangeOp(
+	ctx context.Context, op *tree.CompareRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	res, err := op.Op(e.ctx(), left, right)
+	if err != nil {
+		return nil, err
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
+
 func (e *evaluator) EvalCompareScalarOp(
 	ctx context.Context, op *tree.CompareScalarOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -275,6 +286,24 @@ func (e *evaluator) EvalConcatStringOp(
 
 }
 
+// EvalConcatTSQueryOp combines the two queries with the | operator, like
+// Postgres's tsquery || tsquery.
+func (e *evaluator) EvalConcatTSQueryOp(
+	ctx context.Context, _ *tree.ConcatTSQueryOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.NewDTSQuery(tsearch.Or(
+		tree.MustBeDTSQuery(left).TSQuery, tree.MustBeDTSQuery(right).TSQuery,
+	)), nil
+}
+
+func (e *evaluator) EvalConcatTSVectorOp(
+	ctx context.Context, _ *tree.ConcatTSVectorOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.NewDTSVector(tsearch.Concat(
+		tree.MustBeDTSVector(left).TSVector, tree.MustBeDTSVector(right).TSVector,
+	)), nil
+}
+
 func (e *evaluator) EvalConcatVarBitOp(
 	ctx context.Context, _ *tree.ConcatVarBitOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -321,6 +350,38 @@ func (e *evaluator) EvalContainsJsonbOp(
 	return tree.MakeDBool(tree.DBool(c)), nil
 }
 
+func (e *evaluator) EvalCosDistancePGVectorOp(
+	ctx context.Context, _ *tree.CosDistancePGVectorOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	d, err := vector.CosDistance(tree.MustBeDPGVector(left).T, tree.MustBeDPGVector(right).T)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDFloat(tree.DFloat(d)), nil
+}
+
+func (e *evaluator) EvalDistancePGVectorOp(
+	ctx context.Context, _ *tree.DistancePGVectorOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	// The vector <-> vector operator returns the Euclidean distance between
+	// the two vectors.
+	d, err := vector.L2Distance(tree.MustBeDPGVector(left).T, tree.MustBeDPGVector(right).T)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDFloat(tree.DFloat(d)), nil
+}
+
+func (e *evaluator) EvalDistanceStringOp(
+	ctx context.Context, _ *tree.DistanceStringOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	// The string <-> string operator returns the trigram distance between the
+	// two strings, which is one minus their similarity().
+	l, r := tree.MustBeDString(left), tree.MustBeDString(right)
+	f := trigram.Similarity(string(l), string(r))
+	return tree.NewDFloat(tree.DFloat(1 - f)), nil
+}
+
 func (e *evaluator) EvalDivDecimalIntOp(
 	ctx context.Context, _ *tree.DivDecimalIntOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -889,6 +950,16 @@ func (e *evaluator) EvalMinusJsonbStringOp(
 	return &tree.DJSON{JSON: j}, nil
 }
 
+func (e *evaluator) EvalMinusPGVectorOp(
+	ctx context.Context, _ *tree.MinusPGVectorOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	v, err := vector.Minus(tree.MustBeDPGVector(left).T, tree.MustBeDPGVector(right).T)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDPGVector(v), nil
+}
+
 func (e *evaluator) EvalMinusTimeIntervalOp(
 	ctx context.Context, _ *tree.MinusTimeIntervalOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1184,6 +1255,26 @@ func (e *evaluator) EvalMultIntervalIntOp(
 	}, nil
 }
 
+func (e *evaluator) EvalMultPGVectorOp(
+	ctx context.Context, _ *tree.MultPGVectorOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	v, err := vector.Mult(tree.MustBeDPGVector(left).T, tree.MustBeDPGVector(right).T)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDPGVector(v), nil
+}
+
+func (e *evaluator) EvalNegInnerProductPGVectorOp(
+	ctx context.Context, _ *tree.NegInnerProductPGVectorOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	d, err := vector.NegInnerProduct(tree.MustBeDPGVector(left).T, tree.MustBeDPGVector(right).T)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDFloat(tree.DFloat(d)), nil
+}
+
 func (e *evaluator) EvalOverlapsArrayOp(
 	ctx context.Context, _ *tree.OverlapsArrayOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1200,6 +1291,16 @@ func (e *evaluator) EvalOverlapsINetOp(
 	return tree.MakeDBool(tree.DBool(ipAddr.ContainsOrContainedBy(&other))), nil
 }
 
+func (e *evaluator) EvalPlusPGVectorOp(
+	ctx context.Context, _ *tree.PlusPGVectorOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	v, err := vector.Add(tree.MustBeDPGVector(left).T, tree.MustBeDPGVector(right).T)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDPGVector(v), nil
+}
+
 func (e *evaluator) EvalTSMatchesQueryVectorOp(
 	ctx context.Context, _ *tree.TSMatchesQueryVectorOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1218,6 +1319,22 @@ func (e *evaluator) EvalTSMatchesVectorQueryOp(
 	return tree.MakeDBool(tree.DBool(ret)), err
 }
 
+func (e *evaluator) EvalJSONPathExistsOp(
+	ctx context.Context, _ *tree.JSONPathExistsOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return JSONPathExists(
+		tree.MustBeDJSON(left).JSON, tree.MustBeDJsonpath(right).Path, nil /* vars */, true, /* silent */
+	)
+}
+
+func (e *evaluator) EvalJSONPathMatchOp(
+	ctx context.Context, _ *tree.JSONPathMatchOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return JSONPathMatch(
+		tree.MustBeDJSON(left).JSON, tree.MustBeDJsonpath(right).Path, nil /* vars */, true, /* silent */
+	)
+}
+
 func (e *evaluator) EvalPlusDateIntOp(
 	ctx context.Context, _ *tree.PlusDateIntOp, left, right tree.Datum,
 ) (tree.Datum, error) {
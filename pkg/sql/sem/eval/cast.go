@@ -33,6 +33,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -68,7 +69,14 @@ func PerformCast(
 	if err != nil {
 		return nil, err
 	}
-	return tree.AdjustValueToType(t, ret)
+	ret, err = tree.AdjustValueToType(t, ret)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckDomainConstraints(ctx, evalCtx, t, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
 }
 
 // PerformAssignmentCast performs an assignment cast from the provided Datum to
@@ -92,7 +100,14 @@ func PerformAssignmentCast(
 	if err != nil {
 		return nil, err
 	}
-	return tree.AdjustValueToType(t, d)
+	d, err = tree.AdjustValueToType(t, d)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckDomainConstraints(ctx, evalCtx, t, d); err != nil {
+		return nil, err
+	}
+	return d, nil
 }
 
 var (
@@ -444,7 +459,7 @@ func performCastWithoutPrecisionTruncation(
 				tree.FmtPgwireText,
 				tree.FmtDataConversionConfig(evalCtx.SessionData().DataConversionConfig),
 			)
-		case *tree.DArray:
+		case *tree.DArray, *tree.DRange, *tree.DMultirange:
 			s = tree.AsStringWithFlags(
 				d,
 				tree.FmtPgwireText,
@@ -477,6 +492,10 @@ func performCastWithoutPrecisionTruncation(
 			s = t.String()
 		case *tree.DJSON:
 			s = t.JSON.String()
+		case *tree.DJsonpath:
+			s = t.Path.String()
+		case *tree.DPGVector:
+			s = t.T.String()
 		case *tree.DTSQuery:
 			s = t.TSQuery.String()
 		case *tree.DTSVector:
@@ -849,6 +868,38 @@ func performCastWithoutPrecisionTruncation(
 			}
 			return tree.ParseDJSON(string(j))
 		}
+	case types.JsonpathFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			return tree.ParseDJsonpath(string(*v))
+		}
+	case types.PGVectorFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			return tree.ParseDPGVector(string(*v))
+		case *tree.DArray:
+			return performArrayToPGVectorCast(v)
+		case *tree.DPGVector:
+			return d, nil
+		}
+	case types.RangeFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, string(*v), t)
+			return res, err
+		case *tree.DRange:
+			return tree.NewDRangeUnchecked(t, v.Lower, v.Upper, v.Empty), nil
+		}
+	case types.MultirangeFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDMultirangeFromString(evalCtx, string(*v), t)
+			return res, err
+		case *tree.DRange:
+			return tree.NewDMultirange(evalCtx, t, []*tree.DRange{v})
+		case *tree.DMultirange:
+			return tree.NewDMultirangeUnchecked(t, v.Ranges), nil
+		}
 	case types.TSQueryFamily:
 		if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V23_1) {
 			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
@@ -882,6 +933,18 @@ func performCastWithoutPrecisionTruncation(
 		case *tree.DString:
 			res, _, err := tree.ParseDArrayFromString(evalCtx, string(*v), t.ArrayContents())
 			return res, err
+		case *tree.DPGVector:
+			dcast := tree.NewDArray(t.ArrayContents())
+			for _, f := range v.T {
+				ecast, err := PerformCast(ctx, evalCtx, tree.NewDFloat(tree.DFloat(f)), t.ArrayContents())
+				if err != nil {
+					return nil, err
+				}
+				if err := dcast.Append(ecast); err != nil {
+					return nil, err
+				}
+			}
+			return dcast, nil
 		case *tree.DArray:
 			dcast := tree.NewDArray(t.ArrayContents())
 			if err := dcast.MaybeSetCustomOid(t); err != nil {
@@ -953,6 +1016,37 @@ func performCastWithoutPrecisionTruncation(
 		pgcode.CannotCoerce, "invalid cast: %s -> %s", d.ResolvedType(), t)
 }
 
+// performArrayToPGVectorCast casts the input numeric array to a vector. NULL
+// elements are not allowed.
+func performArrayToPGVectorCast(arr *tree.DArray) (tree.Datum, error) {
+	vals := make([]float64, len(arr.Array))
+	for i, e := range arr.Array {
+		switch v := tree.UnwrapDOidWrapper(e).(type) {
+		case *tree.DInt:
+			vals[i] = float64(*v)
+		case *tree.DFloat:
+			vals[i] = float64(*v)
+		case *tree.DDecimal:
+			f, err := v.Float64()
+			if err != nil {
+				return nil, err
+			}
+			vals[i] = f
+		default:
+			if e == tree.DNull {
+				return nil, pgerror.New(pgcode.NullValueNotAllowed, "array must not contain nulls")
+			}
+			return nil, pgerror.Newf(pgcode.CannotCoerce,
+				"invalid cast: %s -> %s", arr.ResolvedType(), types.PGVector)
+		}
+	}
+	v, err := vector.FromFloats(vals)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDPGVector(v), nil
+}
+
 // performIntToOidCast casts the input integer to the OID type given by the
 // input types.T.
 func performIntToOidCast(
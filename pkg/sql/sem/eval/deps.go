@@ -27,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/lib/pq/oid"
 )
@@ -219,6 +220,10 @@ type Planner interface {
 	// dependency to randgen from users of this interface;
 	GenerateTestObjects(ctx context.Context, parameters string) (string, error)
 
+	// NotifyChannel sends a notification with the given payload on the given
+	// channel when the current transaction commits.
+	NotifyChannel(ctx context.Context, channel, payload string) error
+
 	// UnsafeUpsertDescriptor is used to repair descriptors in dire
 	// circumstances. See the comment on the planner implementation.
 	UnsafeUpsertDescriptor(
@@ -274,6 +279,11 @@ type Planner interface {
 	// ExternalWriteFile writes the content to an external file URI.
 	ExternalWriteFile(ctx context.Context, uri string, content []byte) error
 
+	// OpenForeignTableFile opens the file of a foreign table in the External
+	// Connection that backs the foreign server. The file must be closed by the
+	// caller.
+	OpenForeignTableFile(ctx context.Context, server, filename string) (ForeignTableFile, error)
+
 	// DecodeGist exposes gist functionality to the builtin functions.
 	DecodeGist(gist string, external bool) ([]string, error)
 
@@ -362,6 +372,17 @@ type Planner interface {
 	GetRangeDescByID(context.Context, roachpb.RangeID) (roachpb.RangeDescriptor, error)
 }
 
+// ForeignTableFile is the file of a foreign table, which is usually stored in
+// an External Connection.
+type ForeignTableFile interface {
+	// OpenAt returns a reader of the file that starts at the given offset.
+	OpenAt(ctx context.Context, offset int64) (ioctx.ReadCloserCtx, error)
+	// Size returns the length of the file in bytes.
+	Size() int64
+	// Close releases the resources held by the file.
+	Close(ctx context.Context) error
+}
+
 // InternalRows is an iterator interface that's exposed by the internal
 // executor. It provides access to the rows from a query.
 // InternalRows is a copy of the one in sql/internal.go excluding the
@@ -0,0 +1,185 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package eval
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// DomainValueName is the name by which the CHECK constraints of a domain refer
+// to the value being checked.
+const DomainValueName = "value"
+
+// domainValueContainer is an IndexedVarContainer with a single variable, which
+// holds the value that the CHECK constraints of a domain are evaluated on.
+type domainValueContainer struct {
+	typ *types.T
+	d   tree.Datum
+}
+
+var _ IndexedVarContainer = domainValueContainer{}
+
+// IndexedVarEval implements the IndexedVarContainer interface.
+func (c domainValueContainer) IndexedVarEval(
+	_ context.Context, _ int, _ tree.ExprEvaluator,
+) (tree.Datum, error) {
+	return c.d, nil
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (c domainValueContainer) IndexedVarResolvedType(int) *types.T {
+	return c.typ
+}
+
+// IndexedVarNodeFormatter implements the tree.IndexedVarContainer interface.
+func (c domainValueContainer) IndexedVarNodeFormatter(int) tree.NodeFormatter {
+	n := tree.Name(lexbase.NormalizeName(DomainValueName))
+	return &n
+}
+
+// TypeCheckDomainCheckExpr type checks the expression of a CHECK constraint on
+// a domain with the given base type. References to VALUE in the expression are
+// replaced with a variable of the base type; any other column reference is an
+// error.
+func TypeCheckDomainCheckExpr(
+	ctx context.Context, semaCtx *tree.SemaContext, expr tree.Expr, base *types.T,
+) (tree.TypedExpr, error) {
+	var err error
+	expr, _ = tree.SimpleVisit(expr, func(e tree.Expr) (recurse bool, newExpr tree.Expr, _ error) {
+		if err != nil {
+			return false, e, nil
+		}
+		switch t := e.(type) {
+		case *tree.UnresolvedName:
+			if t.NumParts == 1 && t.Parts[0] == DomainValueName {
+				return false, tree.NewOrdinalReference(0), nil
+			}
+			err = pgerror.Newf(pgcode.UndefinedColumn, "column %q does not exist", t)
+			return false, e, nil
+		case *tree.Subquery:
+			err = pgerror.New(pgcode.FeatureNotSupported,
+				"cannot use subquery in check constraint")
+			return false, e, nil
+		}
+		return true, e, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	defer semaCtx.Properties.Restore(semaCtx.Properties)
+	semaCtx.Properties.Require("DOMAIN CHECK", tree.RejectSpecial|tree.RejectSubqueries)
+	oldIVarContainer := semaCtx.IVarContainer
+	defer func() { semaCtx.IVarContainer = oldIVarContainer }()
+	semaCtx.IVarContainer = domainValueContainer{typ: base}
+	return tree.TypeCheck(ctx, expr, semaCtx, types.Bool)
+}
+
+// domainCheckCache caches the type checked expressions of the CHECK
+// constraints of domains, so that they are not parsed every time a value is
+// checked.
+var domainCheckCache struct {
+	syncutil.Mutex
+	exprs map[domainCheckKey]tree.TypedExpr
+}
+
+// maxDomainCheckCacheSize bounds the number of cached domain CHECK constraint
+// expressions. The cache is cleared when it grows beyond this size.
+const maxDomainCheckCacheSize = 1024
+
+type domainCheckKey struct {
+	expr string
+	base string
+}
+
+func getDomainCheckExpr(ctx context.Context, expr string, base *types.T) (tree.TypedExpr, error) {
+	key := domainCheckKey{expr: expr, base: base.DebugString()}
+	domainCheckCache.Lock()
+	typedExpr, ok := domainCheckCache.exprs[key]
+	domainCheckCache.Unlock()
+	if ok {
+		return typedExpr, nil
+	}
+	parsed, err := parser.ParseExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	semaCtx := tree.MakeSemaContext()
+	typedExpr, err = TypeCheckDomainCheckExpr(ctx, &semaCtx, parsed, base)
+	if err != nil {
+		return nil, err
+	}
+	domainCheckCache.Lock()
+	defer domainCheckCache.Unlock()
+	if domainCheckCache.exprs == nil || len(domainCheckCache.exprs) >= maxDomainCheckCacheSize {
+		domainCheckCache.exprs = make(map[domainCheckKey]tree.TypedExpr)
+	}
+	domainCheckCache.exprs[key] = typedExpr
+	return typedExpr, nil
+}
+
+// CheckDomainConstraints returns an error if d does not satisfy the NOT NULL
+// and CHECK constraints of the domain typ, or of the elements of d if typ is
+// an array of a domain. It is a no-op for all other types.
+func CheckDomainConstraints(
+	ctx context.Context, evalCtx *Context, typ *types.T, d tree.Datum,
+) error {
+	if typ.Family() == types.ArrayFamily && typ.ArrayContents().IsDomain() {
+		if arr, ok := tree.UnwrapDOidWrapper(d).(*tree.DArray); ok {
+			for _, e := range arr.Array {
+				if err := CheckDomainConstraints(ctx, evalCtx, typ.ArrayContents(), e); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if !typ.IsDomain() {
+		return nil
+	}
+	md := typ.TypeMeta.DomainData
+	if md == nil {
+		return errors.AssertionFailedf("domain %s is not hydrated", typ.SQLString())
+	}
+	if d == tree.DNull && md.NotNull {
+		return pgerror.Newf(pgcode.NotNullViolation,
+			"domain %s does not allow null values", typ.Name())
+	}
+	base := typ.DomainBaseType()
+	for i, expr := range md.CheckExprs {
+		typedExpr, err := getDomainCheckExpr(ctx, expr, base)
+		if err != nil {
+			return err
+		}
+		evalCtx.PushIVarContainer(domainValueContainer{typ: base, d: d})
+		res, err := Expr(ctx, evalCtx, typedExpr)
+		evalCtx.PopIVarContainer()
+		if err != nil {
+			return err
+		}
+		// Like for table CHECK constraints, a NULL result satisfies the
+		// constraint.
+		if res == tree.DBoolFalse {
+			return pgerror.Newf(pgcode.CheckViolation,
+				"value for domain %s violates check constraint %q", typ.Name(), md.CheckNames[i])
+		}
+	}
+	return nil
+}
@@ -182,8 +182,14 @@ func (e *evaluator) EvalCastExpr(ctx context.Context, expr *tree.CastExpr) (tree
 		return nil, err
 	}
 
-	// NULL cast to anything is NULL.
+	// NULL cast to anything is NULL, unless the target is a domain that does
+	// not allow it.
 	if d == tree.DNull {
+		if typ, ok := expr.Type.(*types.T); ok {
+			if err := CheckDomainConstraints(ctx, e.ctx(), typ, d); err != nil {
+				return nil, err
+			}
+		}
 		return d, nil
 	}
 	d = UnwrapDatum(ctx, e.ctx(), d)
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/errors"
 )
 
@@ -111,3 +112,38 @@ func PopulateRecordWithJSON(
 	}
 	return nil
 }
+
+// JSONPathExists returns whether the jsonpath returns any item for the target
+// JSON value. When silent is true, errors that Postgres suppresses in silent
+// mode (such as structural errors in strict mode) result in NULL.
+func JSONPathExists(
+	target json.JSON, path jsonpath.Path, vars json.JSON, silent bool,
+) (tree.Datum, error) {
+	res, err := jsonpath.EvalExists(path, target, vars)
+	if err != nil {
+		if silent && jsonpath.IsSilenceable(err) {
+			return tree.DNull, nil
+		}
+		return nil, err
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
+
+// JSONPathMatch returns the result of a jsonpath predicate check for the
+// target JSON value, or NULL if the result is unknown. When silent is true,
+// errors that Postgres suppresses in silent mode result in NULL.
+func JSONPathMatch(
+	target json.JSON, path jsonpath.Path, vars json.JSON, silent bool,
+) (tree.Datum, error) {
+	res, ok, err := jsonpath.EvalMatch(path, target, vars)
+	if err != nil {
+		if silent && jsonpath.IsSilenceable(err) {
+			return tree.DNull, nil
+		}
+		return nil, err
+	}
+	if !ok {
+		return tree.DNull, nil
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
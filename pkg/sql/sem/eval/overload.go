@@ -54,6 +54,25 @@ type AggregateFunc interface {
 	Size() int64
 }
 
+// ContextualAggregateFunc is an AggregateFunc which needs a context to compute
+// its result, for example because it evaluates a user-defined function.
+type ContextualAggregateFunc interface {
+	AggregateFunc
+
+	// ResultWithContext is like Result, but it uses the given context.
+	ResultWithContext(context.Context) (tree.Datum, error)
+}
+
+// AggregateResult returns the current value of the accumulation of fn. It
+// should be used instead of fn.Result whenever fn might be a
+// ContextualAggregateFunc.
+func AggregateResult(ctx context.Context, fn AggregateFunc) (tree.Datum, error) {
+	if cfn, ok := fn.(ContextualAggregateFunc); ok {
+		return cfn.ResultWithContext(ctx)
+	}
+	return fn.Result()
+}
+
 // FnOverload is a function generally defined as a builtin. It doesn't have
 // a concrete type with a marker method only because it's onerous to add.
 type FnOverload = func(context.Context, *Context, tree.Datums) (tree.Datum, error)
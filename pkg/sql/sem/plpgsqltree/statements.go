@@ -0,0 +1,527 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package plpgsqltree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Statement is a PL/pgSQL statement.
+type Statement interface {
+	tree.NodeFormatter
+	plpgsqlStmt()
+}
+
+func (*Block) plpgsqlStmt()       {}
+func (*Assignment) plpgsqlStmt()  {}
+func (*If) plpgsqlStmt()          {}
+func (*Loop) plpgsqlStmt()        {}
+func (*While) plpgsqlStmt()       {}
+func (*ForInt) plpgsqlStmt()      {}
+func (*ForQuery) plpgsqlStmt()    {}
+func (*Exit) plpgsqlStmt()        {}
+func (*Continue) plpgsqlStmt()    {}
+func (*Return) plpgsqlStmt()      {}
+func (*ReturnNext) plpgsqlStmt()  {}
+func (*ReturnQuery) plpgsqlStmt() {}
+func (*Raise) plpgsqlStmt()       {}
+func (*Perform) plpgsqlStmt()     {}
+func (*ExecSQL) plpgsqlStmt()     {}
+func (*Null) plpgsqlStmt()        {}
+
+// Block is a PL/pgSQL block: an optional set of variable declarations, a list
+// of statements, and an optional set of exception handlers.
+//
+//	[ <<label>> ]
+//	[ DECLARE declarations ]
+//	BEGIN
+//	  statements
+//	[ EXCEPTION handlers ]
+//	END [ label ];
+type Block struct {
+	Label      string
+	Decls      []Declaration
+	Body       []Statement
+	Exceptions []Exception
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Block) Format(ctx *tree.FmtCtx) {
+	formatLabel(ctx, s.Label)
+	if len(s.Decls) > 0 {
+		ctx.WriteString("DECLARE\n")
+		for i := range s.Decls {
+			formatIndented(ctx, &s.Decls[i])
+			ctx.WriteByte('\n')
+		}
+	}
+	ctx.WriteString("BEGIN\n")
+	formatBody(ctx, s.Body)
+	if len(s.Exceptions) > 0 {
+		ctx.WriteString("EXCEPTION\n")
+		for i := range s.Exceptions {
+			formatIndented(ctx, &s.Exceptions[i])
+			ctx.WriteByte('\n')
+		}
+	}
+	ctx.WriteString("END")
+	formatEndLabel(ctx, s.Label)
+	ctx.WriteByte(';')
+}
+
+// Declaration declares a variable in the DECLARE section of a block.
+//
+//	name [ CONSTANT ] type [ NOT NULL ] [ { DEFAULT | := | = } expression ];
+type Declaration struct {
+	Var      tree.Name
+	Constant bool
+	Typ      tree.ResolvableTypeReference
+	NotNull  bool
+	Expr     tree.Expr
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Declaration) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(&s.Var)
+	if s.Constant {
+		ctx.WriteString(" CONSTANT")
+	}
+	ctx.WriteByte(' ')
+	ctx.FormatTypeReference(s.Typ)
+	if s.NotNull {
+		ctx.WriteString(" NOT NULL")
+	}
+	if s.Expr != nil {
+		ctx.WriteString(" := ")
+		ctx.FormatNode(s.Expr)
+	}
+	ctx.WriteByte(';')
+}
+
+// Assignment assigns the result of an expression to a variable.
+type Assignment struct {
+	Var tree.Name
+	// Field is the field of a row variable that is assigned, if any, as in
+	// NEW.x := 1.
+	Field tree.Name
+	Value tree.Expr
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Assignment) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(&s.Var)
+	if s.Field != "" {
+		ctx.WriteByte('.')
+		ctx.FormatNode(&s.Field)
+	}
+	ctx.WriteString(" := ")
+	ctx.FormatNode(s.Value)
+	ctx.WriteByte(';')
+}
+
+// If is an IF statement, with optional ELSIF and ELSE branches.
+type If struct {
+	Condition  tree.Expr
+	ThenBody   []Statement
+	ElseIfList []ElseIf
+	ElseBody   []Statement
+}
+
+// ElseIf is a single ELSIF branch of an IF statement.
+type ElseIf struct {
+	Condition tree.Expr
+	Body      []Statement
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *If) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("IF ")
+	ctx.FormatNode(s.Condition)
+	ctx.WriteString(" THEN\n")
+	formatBody(ctx, s.ThenBody)
+	for i := range s.ElseIfList {
+		ctx.WriteString("ELSIF ")
+		ctx.FormatNode(s.ElseIfList[i].Condition)
+		ctx.WriteString(" THEN\n")
+		formatBody(ctx, s.ElseIfList[i].Body)
+	}
+	if len(s.ElseBody) > 0 {
+		ctx.WriteString("ELSE\n")
+		formatBody(ctx, s.ElseBody)
+	}
+	ctx.WriteString("END IF;")
+}
+
+// Loop is an unconditional LOOP, which runs until it is exited by EXIT or
+// RETURN.
+type Loop struct {
+	Label string
+	Body  []Statement
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Loop) Format(ctx *tree.FmtCtx) {
+	formatLabel(ctx, s.Label)
+	formatLoopBody(ctx, s.Label, s.Body)
+}
+
+// While is a WHILE loop, which runs as long as its condition is true.
+type While struct {
+	Label     string
+	Condition tree.Expr
+	Body      []Statement
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *While) Format(ctx *tree.FmtCtx) {
+	formatLabel(ctx, s.Label)
+	ctx.WriteString("WHILE ")
+	ctx.FormatNode(s.Condition)
+	ctx.WriteByte(' ')
+	formatLoopBody(ctx, s.Label, s.Body)
+}
+
+// ForInt is a FOR loop over a range of integers.
+//
+//	FOR name IN [ REVERSE ] lower .. upper [ BY step ] LOOP ... END LOOP;
+type ForInt struct {
+	Label   string
+	Var     tree.Name
+	Reverse bool
+	Lower   tree.Expr
+	Upper   tree.Expr
+	Step    tree.Expr
+	Body    []Statement
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *ForInt) Format(ctx *tree.FmtCtx) {
+	formatLabel(ctx, s.Label)
+	ctx.WriteString("FOR ")
+	ctx.FormatNode(&s.Var)
+	ctx.WriteString(" IN ")
+	if s.Reverse {
+		ctx.WriteString("REVERSE ")
+	}
+	ctx.FormatNode(s.Lower)
+	ctx.WriteString(" .. ")
+	ctx.FormatNode(s.Upper)
+	if s.Step != nil {
+		ctx.WriteString(" BY ")
+		ctx.FormatNode(s.Step)
+	}
+	ctx.WriteByte(' ')
+	formatLoopBody(ctx, s.Label, s.Body)
+}
+
+// ForQuery is a FOR loop over the rows returned by a query.
+//
+//	FOR target IN query LOOP ... END LOOP;
+type ForQuery struct {
+	Label   string
+	Targets tree.NameList
+	Query   tree.Statement
+	Body    []Statement
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *ForQuery) Format(ctx *tree.FmtCtx) {
+	formatLabel(ctx, s.Label)
+	ctx.WriteString("FOR ")
+	ctx.FormatNode(&s.Targets)
+	ctx.WriteString(" IN ")
+	ctx.FormatNode(s.Query)
+	ctx.WriteByte(' ')
+	formatLoopBody(ctx, s.Label, s.Body)
+}
+
+// Exit terminates the innermost loop, or the loop or block with the given
+// label. If Condition is set, the exit only happens if it is true.
+type Exit struct {
+	Label     string
+	Condition tree.Expr
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Exit) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("EXIT")
+	formatExitOrContinue(ctx, s.Label, s.Condition)
+}
+
+// Continue starts the next iteration of the innermost loop, or of the loop
+// with the given label. If Condition is set, it only takes effect if it is
+// true.
+type Continue struct {
+	Label     string
+	Condition tree.Expr
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Continue) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("CONTINUE")
+	formatExitOrContinue(ctx, s.Label, s.Condition)
+}
+
+// Return exits the function, optionally returning the value of Expr.
+type Return struct {
+	Expr tree.Expr
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Return) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN")
+	if s.Expr != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(s.Expr)
+	}
+	ctx.WriteByte(';')
+}
+
+// ReturnNext appends a row to the result set of a set-returning function.
+type ReturnNext struct {
+	Expr tree.Expr
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *ReturnNext) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN NEXT")
+	if s.Expr != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(s.Expr)
+	}
+	ctx.WriteByte(';')
+}
+
+// ReturnQuery appends the results of a query to the result set of a
+// set-returning function.
+type ReturnQuery struct {
+	Query tree.Statement
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *ReturnQuery) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN QUERY ")
+	ctx.FormatNode(s.Query)
+	ctx.WriteByte(';')
+}
+
+// Raise reports a message or raises an error.
+//
+//	RAISE [ level ] 'format' [, expression [, ...]] [ USING option = expression [, ... ] ];
+//	RAISE [ level ] condition_name [ USING option = expression [, ... ] ];
+//	RAISE [ level ] SQLSTATE 'sqlstate' [ USING option = expression [, ... ] ];
+//	RAISE [ level ] USING option = expression [, ... ];
+//	RAISE;
+//
+// A RAISE with no arguments at all re-raises the error currently being
+// handled, and is only valid inside an exception handler.
+type Raise struct {
+	// Level is the lower-case severity of the message. It is empty if no level
+	// was specified, which is equivalent to "exception".
+	Level string
+	// Message is the format string of the message. It may reference Params
+	// with %.
+	Message string
+	Params  []tree.Expr
+	// CodeName is set if the RAISE names a condition, e.g. division_by_zero.
+	CodeName string
+	// Code is set if the RAISE specifies a SQLSTATE.
+	Code    string
+	Options []RaiseOption
+}
+
+// RaiseOption is an option in the USING clause of a RAISE statement.
+type RaiseOption struct {
+	// Name is the lower-case name of the option, e.g. "message" or "hint".
+	Name string
+	Expr tree.Expr
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Raise) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RAISE")
+	if s.Level != "" {
+		ctx.WriteByte(' ')
+		ctx.WriteString(strings.ToUpper(s.Level))
+	}
+	switch {
+	case s.CodeName != "":
+		ctx.WriteByte(' ')
+		ctx.WriteString(s.CodeName)
+	case s.Code != "":
+		ctx.WriteString(" SQLSTATE ")
+		lexbase.EncodeSQLString(&ctx.Buffer, s.Code)
+	case s.Message != "" || len(s.Params) > 0:
+		ctx.WriteByte(' ')
+		lexbase.EncodeSQLString(&ctx.Buffer, s.Message)
+		for _, p := range s.Params {
+			ctx.WriteString(", ")
+			ctx.FormatNode(p)
+		}
+	}
+	for i := range s.Options {
+		if i == 0 {
+			ctx.WriteString(" USING ")
+		} else {
+			ctx.WriteString(", ")
+		}
+		ctx.WriteString(strings.ToUpper(s.Options[i].Name))
+		ctx.WriteString(" = ")
+		ctx.FormatNode(s.Options[i].Expr)
+	}
+	ctx.WriteByte(';')
+}
+
+// Perform evaluates a query and discards its result. The query is stored as
+// the equivalent SELECT statement.
+type Perform struct {
+	Query tree.Statement
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Perform) Format(ctx *tree.FmtCtx) {
+	// The query is stored as a SELECT; replace the leading keyword so that the
+	// statement round-trips.
+	start := ctx.Len()
+	ctx.FormatNode(s.Query)
+	formatted := strings.TrimPrefix(string(ctx.Bytes()[start:]), "SELECT ")
+	ctx.Truncate(start)
+	ctx.WriteString("PERFORM ")
+	ctx.WriteString(formatted)
+	ctx.WriteByte(';')
+}
+
+// ExecSQL is a SQL statement executed from PL/pgSQL. If Into is set, the
+// first row of the result is stored into the listed variables.
+type ExecSQL struct {
+	SQL tree.Statement
+	// Into is the list of target variables of an INTO clause. The clause is
+	// removed from SQL during parsing.
+	Into tree.NameList
+	// Strict is true if the INTO clause was INTO STRICT, which requires the
+	// statement to return exactly one row.
+	Strict bool
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *ExecSQL) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(s.SQL)
+	if len(s.Into) > 0 {
+		ctx.WriteString(" INTO ")
+		if s.Strict {
+			ctx.WriteString("STRICT ")
+		}
+		ctx.FormatNode(&s.Into)
+	}
+	ctx.WriteByte(';')
+}
+
+// Null is the NULL statement, which does nothing.
+type Null struct{}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Null) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("NULL;")
+}
+
+// Exception is a handler in the EXCEPTION section of a block. It runs Body if
+// the error raised by the block matches any of Conditions.
+type Exception struct {
+	Conditions []Condition
+	Body       []Statement
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Exception) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("WHEN ")
+	for i := range s.Conditions {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.FormatNode(&s.Conditions[i])
+	}
+	ctx.WriteString(" THEN")
+	for _, stmt := range s.Body {
+		ctx.WriteByte('\n')
+		formatIndented(ctx, stmt)
+	}
+}
+
+// Condition identifies the errors handled by an exception handler, either by
+// condition name (e.g. unique_violation) or by SQLSTATE code.
+type Condition struct {
+	SQLErrName  string
+	SQLErrState string
+}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *Condition) Format(ctx *tree.FmtCtx) {
+	if s.SQLErrState != "" {
+		ctx.WriteString("SQLSTATE ")
+		lexbase.EncodeSQLString(&ctx.Buffer, s.SQLErrState)
+		return
+	}
+	ctx.WriteString(s.SQLErrName)
+}
+
+func formatLabel(ctx *tree.FmtCtx, label string) {
+	if label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatName(label)
+		ctx.WriteString(">>\n")
+	}
+}
+
+func formatEndLabel(ctx *tree.FmtCtx, label string) {
+	if label != "" {
+		ctx.WriteByte(' ')
+		ctx.FormatName(label)
+	}
+}
+
+func formatLoopBody(ctx *tree.FmtCtx, label string, body []Statement) {
+	ctx.WriteString("LOOP\n")
+	formatBody(ctx, body)
+	ctx.WriteString("END LOOP")
+	formatEndLabel(ctx, label)
+	ctx.WriteByte(';')
+}
+
+func formatExitOrContinue(ctx *tree.FmtCtx, label string, cond tree.Expr) {
+	formatEndLabel(ctx, label)
+	if cond != nil {
+		ctx.WriteString(" WHEN ")
+		ctx.FormatNode(cond)
+	}
+	ctx.WriteByte(';')
+}
+
+// formatBody formats each statement on its own line, indented by one level.
+func formatBody(ctx *tree.FmtCtx, stmts []Statement) {
+	for _, stmt := range stmts {
+		formatIndented(ctx, stmt)
+		ctx.WriteByte('\n')
+	}
+}
+
+// formatIndented formats n, indenting every line of the output by one level.
+func formatIndented(ctx *tree.FmtCtx, n tree.NodeFormatter) {
+	const indent = "  "
+	start := ctx.Len()
+	ctx.FormatNode(n)
+	formatted := strings.ReplaceAll(string(ctx.Bytes()[start:]), "\n", "\n"+indent)
+	ctx.Truncate(start)
+	ctx.WriteString(indent)
+	ctx.WriteString(formatted)
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// AlterDomain represents an ALTER DOMAIN statement.
+type AlterDomain struct {
+	Domain *UnresolvedObjectName
+	Cmd    AlterDomainCmd
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomain) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER DOMAIN ")
+	ctx.FormatNode(node.Domain)
+	ctx.FormatNode(node.Cmd)
+}
+
+// AlterDomainCmd represents a domain modification operation.
+type AlterDomainCmd interface {
+	NodeFormatter
+	alterDomainCmd()
+	// TelemetryName returns the counter name to use for telemetry purposes.
+	TelemetryName() string
+}
+
+func (*AlterDomainSetDefault) alterDomainCmd()     {}
+func (*AlterDomainSetNotNull) alterDomainCmd()     {}
+func (*AlterDomainAddConstraint) alterDomainCmd()  {}
+func (*AlterDomainDropConstraint) alterDomainCmd() {}
+func (*AlterDomainRename) alterDomainCmd()         {}
+func (*AlterDomainSetSchema) alterDomainCmd()      {}
+func (*AlterDomainOwner) alterDomainCmd()          {}
+
+var _ AlterDomainCmd = &AlterDomainSetDefault{}
+var _ AlterDomainCmd = &AlterDomainSetNotNull{}
+var _ AlterDomainCmd = &AlterDomainAddConstraint{}
+var _ AlterDomainCmd = &AlterDomainDropConstraint{}
+var _ AlterDomainCmd = &AlterDomainRename{}
+var _ AlterDomainCmd = &AlterDomainSetSchema{}
+var _ AlterDomainCmd = &AlterDomainOwner{}
+
+// AlterDomainSetDefault represents an ALTER DOMAIN SET DEFAULT or DROP DEFAULT
+// command.
+type AlterDomainSetDefault struct {
+	// Default is nil for DROP DEFAULT.
+	Default Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainSetDefault) Format(ctx *FmtCtx) {
+	if node.Default == nil {
+		ctx.WriteString(" DROP DEFAULT")
+		return
+	}
+	ctx.WriteString(" SET DEFAULT ")
+	ctx.FormatNode(node.Default)
+}
+
+// TelemetryName implements the AlterDomainCmd interface.
+func (node *AlterDomainSetDefault) TelemetryName() string {
+	if node.Default == nil {
+		return "drop_default"
+	}
+	return "set_default"
+}
+
+// AlterDomainSetNotNull represents an ALTER DOMAIN SET NOT NULL or DROP NOT
+// NULL command.
+type AlterDomainSetNotNull struct {
+	NotNull bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainSetNotNull) Format(ctx *FmtCtx) {
+	if node.NotNull {
+		ctx.WriteString(" SET NOT NULL")
+	} else {
+		ctx.WriteString(" DROP NOT NULL")
+	}
+}
+
+// TelemetryName implements the AlterDomainCmd interface.
+func (node *AlterDomainSetNotNull) TelemetryName() string {
+	if node.NotNull {
+		return "set_not_null"
+	}
+	return "drop_not_null"
+}
+
+// AlterDomainAddConstraint represents an ALTER DOMAIN ADD CONSTRAINT command.
+type AlterDomainAddConstraint struct {
+	Constraint DomainConstraint
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainAddConstraint) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ADD ")
+	ctx.FormatNode(&node.Constraint)
+}
+
+// TelemetryName implements the AlterDomainCmd interface.
+func (node *AlterDomainAddConstraint) TelemetryName() string {
+	return "add_constraint"
+}
+
+// AlterDomainDropConstraint represents an ALTER DOMAIN DROP CONSTRAINT
+// command.
+type AlterDomainDropConstraint struct {
+	Constraint Name
+	IfExists   bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainDropConstraint) Format(ctx *FmtCtx) {
+	ctx.WriteString(" DROP CONSTRAINT ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Constraint)
+}
+
+// TelemetryName implements the AlterDomainCmd interface.
+func (node *AlterDomainDropConstraint) TelemetryName() string {
+	return "drop_constraint"
+}
+
+// AlterDomainRename represents an ALTER DOMAIN RENAME command.
+type AlterDomainRename struct {
+	NewName Name
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainRename) Format(ctx *FmtCtx) {
+	ctx.WriteString(" RENAME TO ")
+	ctx.FormatNode(&node.NewName)
+}
+
+// TelemetryName implements the AlterDomainCmd interface.
+func (node *AlterDomainRename) TelemetryName() string {
+	return "rename"
+}
+
+// AlterDomainSetSchema represents an ALTER DOMAIN SET SCHEMA command.
+type AlterDomainSetSchema struct {
+	Schema Name
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainSetSchema) Format(ctx *FmtCtx) {
+	ctx.WriteString(" SET SCHEMA ")
+	ctx.FormatNode(&node.Schema)
+}
+
+// TelemetryName implements the AlterDomainCmd interface.
+func (node *AlterDomainSetSchema) TelemetryName() string {
+	return "set_schema"
+}
+
+// AlterDomainOwner represents an ALTER DOMAIN OWNER TO command.
+type AlterDomainOwner struct {
+	Owner RoleSpec
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDomainOwner) Format(ctx *FmtCtx) {
+	ctx.WriteString(" OWNER TO ")
+	ctx.FormatNode(&node.Owner)
+}
+
+// TelemetryName implements the AlterDomainCmd interface.
+func (node *AlterDomainOwner) TelemetryName() string {
+	return "owner"
+}
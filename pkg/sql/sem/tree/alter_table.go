@@ -59,11 +59,13 @@ type AlterTableCmd interface {
 func (*AlterTableAddColumn) alterTableCmd()          {}
 func (*AlterTableAddConstraint) alterTableCmd()      {}
 func (*AlterTableAlterColumnType) alterTableCmd()    {}
+func (*AlterTableAlterConstraint) alterTableCmd()    {}
 func (*AlterTableAlterPrimaryKey) alterTableCmd()    {}
 func (*AlterTableDropColumn) alterTableCmd()         {}
 func (*AlterTableDropConstraint) alterTableCmd()     {}
 func (*AlterTableDropNotNull) alterTableCmd()        {}
 func (*AlterTableDropStored) alterTableCmd()         {}
+func (*AlterTableInherit) alterTableCmd()            {}
 func (*AlterTableSetNotNull) alterTableCmd()         {}
 func (*AlterTableRenameColumn) alterTableCmd()       {}
 func (*AlterTableRenameConstraint) alterTableCmd()   {}
@@ -80,10 +82,12 @@ func (*AlterTableResetStorageParams) alterTableCmd() {}
 var _ AlterTableCmd = &AlterTableAddColumn{}
 var _ AlterTableCmd = &AlterTableAddConstraint{}
 var _ AlterTableCmd = &AlterTableAlterColumnType{}
+var _ AlterTableCmd = &AlterTableAlterConstraint{}
 var _ AlterTableCmd = &AlterTableDropColumn{}
 var _ AlterTableCmd = &AlterTableDropConstraint{}
 var _ AlterTableCmd = &AlterTableDropNotNull{}
 var _ AlterTableCmd = &AlterTableDropStored{}
+var _ AlterTableCmd = &AlterTableInherit{}
 var _ AlterTableCmd = &AlterTableSetNotNull{}
 var _ AlterTableCmd = &AlterTableRenameColumn{}
 var _ AlterTableCmd = &AlterTableRenameConstraint{}
@@ -329,6 +333,50 @@ func (node *AlterTableDropConstraint) Format(ctx *FmtCtx) {
 	}
 }
 
+// AlterTableAlterConstraint represents an ALTER CONSTRAINT command, which
+// changes the deferrability of a foreign key constraint.
+type AlterTableAlterConstraint struct {
+	Constraint Name
+	Deferrable ConstraintDeferrability
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableAlterConstraint) TelemetryName() string {
+	return "alter_constraint"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableAlterConstraint) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ALTER CONSTRAINT ")
+	ctx.FormatNode(&node.Constraint)
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.Deferrable.String())
+}
+
+// AlterTableInherit represents an INHERIT or NO INHERIT command, which adds
+// or removes a parent table of the table.
+type AlterTableInherit struct {
+	Parent    TableName
+	NoInherit bool
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableInherit) TelemetryName() string {
+	if node.NoInherit {
+		return "no_inherit"
+	}
+	return "inherit"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableInherit) Format(ctx *FmtCtx) {
+	if node.NoInherit {
+		ctx.WriteString(" NO")
+	}
+	ctx.WriteString(" INHERIT ")
+	ctx.FormatNode(&node.Parent)
+}
+
 // AlterTableValidateConstraint represents a VALIDATE CONSTRAINT command.
 type AlterTableValidateConstraint struct {
 	Constraint Name
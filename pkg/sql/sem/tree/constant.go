@@ -542,8 +542,22 @@ var (
 		types.UUIDArray,
 		types.INet,
 		types.Jsonb,
+		types.Jsonpath,
+		types.PGVector,
 		types.TSQuery,
 		types.TSVector,
+		types.Int4Range,
+		types.Int8Range,
+		types.NumRange,
+		types.TSRange,
+		types.TSTZRange,
+		types.DateRange,
+		types.Int4Multirange,
+		types.Int8Multirange,
+		types.NumMultirange,
+		types.TSMultirange,
+		types.TSTZMultirange,
+		types.DateMultirange,
 		types.VarBit,
 		types.AnyEnum,
 		types.AnyEnumArray,
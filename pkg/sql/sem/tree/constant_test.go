@@ -360,6 +360,13 @@ func mustParseDTSVector(t *testing.T, s string) tree.Datum {
 	}
 	return d
 }
+func mustParseDJsonpath(t *testing.T, s string) tree.Datum {
+	d, err := tree.ParseDJsonpath(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
 func mustParseDTSQuery(t *testing.T, s string) tree.Datum {
 	d, err := tree.ParseDTSQuery(s)
 	if err != nil {
@@ -392,6 +399,7 @@ var parseFuncs = map[*types.T]func(*testing.T, string) tree.Datum{
 	types.TimestampTZ:      mustParseDTimestampTZ,
 	types.Interval:         mustParseDInterval,
 	types.Jsonb:            mustParseDJSON,
+	types.Jsonpath:         mustParseDJsonpath,
 	types.Uuid:             mustParseDUuid,
 	types.Box2D:            mustParseDBox2D,
 	types.Geography:        mustParseDGeography,
@@ -443,11 +451,11 @@ func TestStringConstantResolveAvailableTypes(t *testing.T) {
 		},
 		{
 			c:            tree.NewStrVal("true"),
-			parseOptions: typeSet(types.String, types.Bytes, types.Bool, types.Jsonb, types.TSVector, types.TSQuery),
+			parseOptions: typeSet(types.String, types.Bytes, types.Bool, types.Jsonb, types.Jsonpath, types.TSVector, types.TSQuery),
 		},
 		{
 			c:            tree.NewStrVal("2010-09-28"),
-			parseOptions: typeSet(types.String, types.Bytes, types.Date, types.Timestamp, types.TimestampTZ, types.TSVector, types.TSQuery),
+			parseOptions: typeSet(types.String, types.Bytes, types.Date, types.Timestamp, types.TimestampTZ, types.Jsonpath, types.TSVector, types.TSQuery),
 		},
 		{
 			c:            tree.NewStrVal("2010-09-28 12:00:00.1"),
@@ -504,6 +512,7 @@ func TestStringConstantResolveAvailableTypes(t *testing.T) {
 				types.Decimal,
 				types.Interval,
 				types.Jsonb,
+				types.Jsonpath,
 				types.TSVector,
 				types.TSQuery,
 			),
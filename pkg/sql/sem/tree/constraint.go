@@ -127,3 +127,30 @@ func (x CompositeKeyMatchMethod) String() string {
 		return strconv.Itoa(int(x))
 	}
 }
+
+// ConstraintDeferrability describes whether the checking of a constraint can
+// be deferred until the end of the transaction with SET CONSTRAINTS, and
+// whether it is deferred by default. It has a one-to-one mapping to
+// semenumpb.ConstraintDeferrability.
+type ConstraintDeferrability semenumpb.ConstraintDeferrability
+
+// The values for ConstraintDeferrability.
+const (
+	ConstraintNotDeferrable ConstraintDeferrability = iota
+	ConstraintInitiallyImmediate
+	ConstraintInitiallyDeferred
+)
+
+// String implements the fmt.Stringer interface.
+func (x ConstraintDeferrability) String() string {
+	switch x {
+	case ConstraintNotDeferrable:
+		return "NOT DEFERRABLE"
+	case ConstraintInitiallyImmediate:
+		return "DEFERRABLE INITIALLY IMMEDIATE"
+	case ConstraintInitiallyDeferred:
+		return "DEFERRABLE INITIALLY DEFERRED"
+	default:
+		return strconv.Itoa(int(x))
+	}
+}
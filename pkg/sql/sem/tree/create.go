@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
 	"github.com/cockroachdb/errors"
@@ -360,14 +361,113 @@ type CreateType struct {
 	// CompositeTypeList is set when this repesnets a CREATE TYPE ... AS ( )
 	// statement.
 	CompositeTypeList []CompositeTypeElem
+	// DomainBaseType, DomainDefault and DomainConstraints are set when this
+	// represents a CREATE DOMAIN statement.
+	DomainBaseType    ResolvableTypeReference
+	DomainDefault     Expr
+	DomainConstraints []DomainConstraint
 	// IfNotExists is true if IF NOT EXISTS was requested.
 	IfNotExists bool
 }
 
+// DomainConstraint represents a NOT NULL, NULL or CHECK constraint in a
+// CREATE DOMAIN statement.
+type DomainConstraint struct {
+	Name Name
+	// Nullability is NotNull or Null for a NOT NULL or NULL constraint, and
+	// SilentNull for a CHECK constraint.
+	Nullability Nullability
+	// Check is the expression of a CHECK constraint, or nil.
+	Check Expr
+}
+
+// NewCreateDomain constructs a CREATE DOMAIN statement. The constraints and
+// default of a domain share their syntax with column qualifications, from which
+// they are extracted here.
+func NewCreateDomain(
+	name *UnresolvedObjectName,
+	typRef ResolvableTypeReference,
+	qualifications []NamedColumnQualification,
+) (*CreateType, error) {
+	n := &CreateType{
+		TypeName:       name,
+		Variety:        Domain,
+		DomainBaseType: typRef,
+	}
+	nullability := SilentNull
+	for _, c := range qualifications {
+		switch t := c.Qualification.(type) {
+		case *ColumnDefault:
+			if n.DomainDefault != nil {
+				return nil, pgerror.Newf(pgcode.Syntax, "multiple default expressions")
+			}
+			n.DomainDefault = t.Expr
+		case NotNullConstraint:
+			if nullability == Null {
+				return nil, pgerror.Newf(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+			}
+			nullability = NotNull
+			n.DomainConstraints = append(n.DomainConstraints, DomainConstraint{
+				Name: c.Name, Nullability: NotNull,
+			})
+		case NullConstraint:
+			if nullability == NotNull {
+				return nil, pgerror.Newf(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+			}
+			nullability = Null
+			n.DomainConstraints = append(n.DomainConstraints, DomainConstraint{
+				Name: c.Name, Nullability: Null,
+			})
+		case *ColumnCheckConstraint:
+			n.DomainConstraints = append(n.DomainConstraints, DomainConstraint{
+				Name: c.Name, Nullability: SilentNull, Check: t.Expr,
+			})
+		default:
+			return nil, pgerror.New(pgcode.Syntax,
+				"only NOT NULL, NULL, CHECK and DEFAULT clauses are possible for domains")
+		}
+	}
+	return n, nil
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DomainConstraint) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	switch {
+	case node.Check != nil:
+		ctx.WriteString("CHECK (")
+		ctx.FormatNode(node.Check)
+		ctx.WriteByte(')')
+	case node.Nullability == NotNull:
+		ctx.WriteString("NOT NULL")
+	default:
+		ctx.WriteString("NULL")
+	}
+}
+
 var _ Statement = &CreateType{}
 
 // Format implements the NodeFormatter interface.
 func (node *CreateType) Format(ctx *FmtCtx) {
+	if node.Variety == Domain {
+		ctx.WriteString("CREATE DOMAIN ")
+		ctx.FormatNode(node.TypeName)
+		ctx.WriteString(" AS ")
+		ctx.FormatTypeReference(node.DomainBaseType)
+		if node.DomainDefault != nil {
+			ctx.WriteString(" DEFAULT ")
+			ctx.FormatNode(node.DomainDefault)
+		}
+		for i := range node.DomainConstraints {
+			ctx.WriteByte(' ')
+			ctx.FormatNode(&node.DomainConstraints[i])
+		}
+		return
+	}
 	ctx.WriteString("CREATE TYPE ")
 	if node.IfNotExists {
 		ctx.WriteString("IF NOT EXISTS ")
@@ -411,6 +511,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExcludeConstraintTableDef) tableDef()    {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -1072,6 +1173,7 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExcludeConstraintTableDef) constraintTableDef()    {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
@@ -1079,6 +1181,7 @@ type UniqueConstraintTableDef struct {
 	IndexTableDef
 	PrimaryKey   bool
 	WithoutIndex bool
+	Deferrable   ConstraintDeferrability
 	IfNotExists  bool
 }
 
@@ -1124,6 +1227,10 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionByIndex != nil {
 		ctx.FormatNode(node.PartitionByIndex)
 	}
+	if node.Deferrable != ConstraintNotDeferrable {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.Deferrable.String())
+	}
 	if node.Predicate != nil {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
@@ -1141,6 +1248,7 @@ type ForeignKeyConstraintTableDef struct {
 	ToCols      NameList
 	Actions     ReferenceActions
 	Match       CompositeKeyMatchMethod
+	Deferrable  ConstraintDeferrability
 	IfNotExists bool
 }
 
@@ -1172,6 +1280,11 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+
+	if node.Deferrable != ConstraintNotDeferrable {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.Deferrable.String())
+	}
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1218,6 +1331,71 @@ func (node *CheckConstraintTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+// ExcludeConstraintTableDef represents an exclusion constraint within a
+// CREATE TABLE statement.
+type ExcludeConstraintTableDef struct {
+	Name        Name
+	Elems       ExcludeElemList
+	Predicate   Expr
+	IfNotExists bool
+}
+
+// ExcludeElem is a single column of an exclusion constraint, along with the
+// operator used to compare values of that column.
+type ExcludeElem struct {
+	Column   Name
+	Operator treecmp.ComparisonOperator
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeElem) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.Column)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator.String())
+}
+
+// ExcludeElemList is a list of ExcludeElem.
+type ExcludeElemList []ExcludeElem
+
+// Format implements the NodeFormatter interface.
+func (l *ExcludeElemList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
+}
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// SetIfNotExists implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetIfNotExists() {
+	node.IfNotExists = true
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		if node.IfNotExists {
+			ctx.WriteString("IF NOT EXISTS ")
+		}
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("EXCLUDE (")
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
+}
+
 // FamilyTableDef represents a family definition within a CREATE TABLE
 // statement.
 type FamilyTableDef struct {
@@ -1464,6 +1642,9 @@ type CreateTable struct {
 	Defs     TableDefs
 	AsSource *Select
 	Locality *Locality
+	// Inherits contains the tables listed in the INHERITS clause, whose
+	// columns are inherited by the new table.
+	Inherits TableNames
 }
 
 // As returns true if this table represents a CREATE TABLE ... AS statement,
@@ -1519,6 +1700,11 @@ func (node *CreateTable) FormatBody(ctx *FmtCtx) {
 		ctx.WriteString(" (")
 		ctx.FormatNode(&node.Defs)
 		ctx.WriteByte(')')
+		if len(node.Inherits) > 0 {
+			ctx.WriteString(" INHERITS (")
+			ctx.FormatNode(&node.Inherits)
+			ctx.WriteByte(')')
+		}
 		if node.PartitionByTable != nil {
 			ctx.FormatNode(node.PartitionByTable)
 		}
@@ -1900,14 +2086,18 @@ func (node *CreateRole) Format(ctx *FmtCtx) {
 
 // CreateView represents a CREATE VIEW statement.
 type CreateView struct {
-	Name         TableName
-	ColumnNames  NameList
-	AsSource     *Select
-	IfNotExists  bool
-	Persistence  Persistence
-	Replace      bool
-	Materialized bool
-	WithData     bool
+	Name          TableName
+	ColumnNames   NameList
+	AsSource      *Select
+	IfNotExists   bool
+	Persistence   Persistence
+	Replace       bool
+	Materialized  bool
+	WithData      bool
+	StorageParams StorageParams
+	// Recursive is set for CREATE RECURSIVE VIEW, in which case AsSource can
+	// refer to the view by its name.
+	Recursive bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -1926,6 +2116,10 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 		ctx.WriteString("MATERIALIZED ")
 	}
 
+	if node.Recursive {
+		ctx.WriteString("RECURSIVE ")
+	}
+
 	ctx.WriteString("VIEW ")
 
 	if node.IfNotExists {
@@ -1940,6 +2134,12 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 		ctx.WriteByte(')')
 	}
 
+	if node.StorageParams != nil {
+		ctx.WriteString(" WITH (")
+		ctx.FormatNode(&node.StorageParams)
+		ctx.WriteString(")")
+	}
+
 	ctx.WriteString(" AS ")
 	ctx.FormatNode(node.AsSource)
 	if node.Materialized && node.WithData {
@@ -2120,6 +2320,23 @@ func (node *CreateExtension) Format(ctx *FmtCtx) {
 	ctx.WriteString(node.Name)
 }
 
+// CreateLanguage represents a CREATE LANGUAGE statement.
+type CreateLanguage struct {
+	Name    Name
+	Replace bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateLanguage) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("LANGUAGE ")
+	// NB: like extension names, language names are not anonymized.
+	ctx.WriteString(string(node.Name))
+}
+
 // CreateExternalConnection represents a CREATE EXTERNAL CONNECTION statement.
 type CreateExternalConnection struct {
 	ConnectionLabelSpec LabelSpec
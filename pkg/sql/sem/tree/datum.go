@@ -37,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/stringencoding"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
@@ -45,6 +46,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 	"github.com/lib/pq/oid"
@@ -3713,7 +3715,7 @@ func AsJSON(
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(formatTime(t.UTC(), "2006-01-02T15:04:05.999999999")), nil
 	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D,
-		*DTSVector, *DTSQuery:
+		*DTSVector, *DTSQuery, *DPGVector:
 		return json.FromString(AsStringWithFlags(t, FmtBareStrings, FmtDataConversionConfig(dcc))), nil
 	case *DGeometry:
 		return json.FromSpatialObject(t.Geometry.SpatialObject(), geo.DefaultGeoJSONDecimalDigits)
@@ -3824,6 +3826,137 @@ func (d *DJSON) Size() uintptr {
 	return unsafe.Sizeof(*d) + d.JSON.Size()
 }
 
+// DJsonpath is the jsonpath Datum.
+type DJsonpath struct {
+	jsonpath.Path
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DJsonpath) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	str := d.Path.String()
+	if !bareStrings {
+		str = strings.ReplaceAll(str, `'`, `''`)
+	}
+	ctx.WriteString(str)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DJsonpath) ResolvedType() *types.T {
+	return types.Jsonpath
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DJsonpath) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DJsonpath) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DJsonpath) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DJsonpath)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	l, r := d.String(), v.String()
+	if l < r {
+		return -1, nil
+	} else if l > r {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DJsonpath) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DJsonpath) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DJsonpath) IsMin(_ CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DJsonpath) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DJsonpath) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DJsonpath) Min(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DJsonpath) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.Path.String()))
+}
+
+// AsDJsonpath attempts to retrieve a DJsonpath from an Expr, returning a
+// DJsonpath and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DJsonpath wrapped by a *DOidWrapper is possible.
+func AsDJsonpath(e Expr) (*DJsonpath, bool) {
+	switch t := e.(type) {
+	case *DJsonpath:
+		return t, true
+	case *DOidWrapper:
+		return AsDJsonpath(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDJsonpath attempts to retrieve a DJsonpath from an Expr, panicking if
+// the assertion fails.
+func MustBeDJsonpath(e Expr) *DJsonpath {
+	v, ok := AsDJsonpath(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DJsonpath, found %T", e))
+	}
+	return v
+}
+
+// NewDJsonpath is a helper routine to create a DJsonpath initialized from its
+// argument.
+func NewDJsonpath(p jsonpath.Path) *DJsonpath {
+	return &DJsonpath{Path: p}
+}
+
+// ParseDJsonpath takes a string of jsonpath and returns a DJsonpath value.
+func ParseDJsonpath(s string) (Datum, error) {
+	p, err := jsonpath.Parse(s)
+	if err != nil {
+		return nil, MakeParseError(s, types.Jsonpath, err)
+	}
+	return NewDJsonpath(p), nil
+}
+
 // DTSQuery is the tsquery Datum.
 type DTSQuery struct {
 	tsearch.TSQuery
@@ -4086,6 +4219,127 @@ func ParseDTSVector(s string) (Datum, error) {
 	return NewDTSVector(v), nil
 }
 
+// DPGVector is the vector Datum.
+type DPGVector struct {
+	vector.T
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DPGVector) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	d.T.Format(&ctx.Buffer)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DPGVector) ResolvedType() *types.T {
+	return types.PGVector
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DPGVector) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DPGVector) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DPGVector) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DPGVector)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.T.Compare(v.T), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DPGVector) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DPGVector) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DPGVector) IsMin(_ CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DPGVector) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DPGVector) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DPGVector) Min(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DPGVector) Size() uintptr {
+	return unsafe.Sizeof(*d) + d.T.Size()
+}
+
+// AsDPGVector attempts to retrieve a DPGVector from an Expr, returning a
+// DPGVector and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DPGVector wrapped by a *DOidWrapper is possible.
+func AsDPGVector(e Expr) (*DPGVector, bool) {
+	switch t := e.(type) {
+	case *DPGVector:
+		return t, true
+	case *DOidWrapper:
+		return AsDPGVector(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDPGVector attempts to retrieve a DPGVector from an Expr, panicking if
+// the assertion fails.
+func MustBeDPGVector(e Expr) *DPGVector {
+	v, ok := AsDPGVector(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DPGVector, found %T", e))
+	}
+	return v
+}
+
+// NewDPGVector is a helper routine to create a DPGVector initialized from its
+// argument.
+func NewDPGVector(v vector.T) *DPGVector {
+	return &DPGVector{T: v}
+}
+
+// ParseDPGVector takes a string of a vector and returns a DPGVector value.
+func ParseDPGVector(s string) (Datum, error) {
+	v, err := vector.ParseVector(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDPGVector(v), nil
+}
+
 // DTuple is the tuple Datum.
 type DTuple struct {
 	D Datums
@@ -5764,6 +6018,10 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.TSVectorFamily:       {unsafe.Sizeof(DTSVector{}), variableSize},
 	types.IntervalFamily:       {unsafe.Sizeof(DInterval{}), fixedSize},
 	types.JsonFamily:           {unsafe.Sizeof(DJSON{}), variableSize},
+	types.JsonpathFamily:       {unsafe.Sizeof(DJsonpath{}), variableSize},
+	types.PGVectorFamily:       {unsafe.Sizeof(DPGVector{}), variableSize},
+	types.RangeFamily:          {unsafe.Sizeof(DRange{}), variableSize},
+	types.MultirangeFamily:     {unsafe.Sizeof(DMultirange{}), variableSize},
 	types.UuidFamily:           {unsafe.Sizeof(DUuid{}), fixedSize},
 	types.INetFamily:           {unsafe.Sizeof(DIPAddr{}), fixedSize},
 	types.OidFamily:            {unsafe.Sizeof(DOid{}.Oid), fixedSize},
@@ -5915,6 +6173,9 @@ func InferTypes(vals []string) []types.Family {
 // In the case of geospatial types, it will check whether the SRID and Shape in the
 // datum matches the type definition.
 //
+// In the case of vectors, it will check whether the number of dimensions of the
+// datum matches the type definition.
+//
 // This method is used by casts and parsing. It is important to note that this
 // function will error if the given value is too wide for the given type. For
 // explicit casts and parsing, inVal should be truncated before this function is
@@ -6080,6 +6341,13 @@ func AdjustValueToType(typ *types.T, inVal Datum) (outVal Datum, err error) {
 				return nil, err
 			}
 		}
+	case types.PGVectorFamily:
+		if in, ok := inVal.(*DPGVector); ok {
+			if typ.Width() > 0 && len(in.T) != int(typ.Width()) {
+				return nil, pgerror.Newf(pgcode.DataException,
+					"expected %d dimensions, not %d", typ.Width(), len(in.T))
+			}
+		}
 	}
 	return inVal, nil
 }
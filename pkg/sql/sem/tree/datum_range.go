@@ -0,0 +1,771 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// RangeBound is one of the bounds of a DRange.
+type RangeBound struct {
+	// Val is the value of the bound. It is nil if the bound is infinite.
+	Val Datum
+	// Inclusive is true if Val is contained in the range. Infinite bounds are
+	// never inclusive.
+	Inclusive bool
+}
+
+// IsInfinite returns true if the bound is unbounded.
+func (b RangeBound) IsInfinite() bool {
+	return b.Val == nil
+}
+
+// DRange is the Datum for the built-in range types. A non-empty range is
+// always stored in its normalized form: ranges of discrete subtypes use the
+// canonical "[lower, upper)" form, and infinite bounds are exclusive.
+type DRange struct {
+	typ *types.T
+	// Lower and Upper are the bounds of the range. They are unset if the range
+	// is empty.
+	Lower, Upper RangeBound
+	// Empty is true if the range contains no values.
+	Empty bool
+}
+
+var errRangeBoundsOrder = pgerror.New(pgcode.DataException,
+	"range lower bound must be less than or equal to range upper bound")
+
+// NewDRange returns a range of the given type with the given bounds. The bounds
+// are validated and, for ranges of discrete subtypes, converted to the
+// canonical "[lower, upper)" form.
+func NewDRange(ctx CompareContext, typ *types.T, lower, upper RangeBound) (*DRange, error) {
+	if lower.IsInfinite() {
+		lower.Inclusive = false
+	}
+	if upper.IsInfinite() {
+		upper.Inclusive = false
+	}
+	if !lower.IsInfinite() && !upper.IsInfinite() {
+		c, err := lower.Val.CompareError(ctx, upper.Val)
+		if err != nil {
+			return nil, err
+		}
+		if c > 0 {
+			return nil, errRangeBoundsOrder
+		}
+	}
+	if types.IsDiscreteRangeType(typ) {
+		var err error
+		if lower, err = canonicalizeRangeBound(typ, lower, true /* isLower */); err != nil {
+			return nil, err
+		}
+		if upper, err = canonicalizeRangeBound(typ, upper, false /* isLower */); err != nil {
+			return nil, err
+		}
+	}
+	if !lower.IsInfinite() && !upper.IsInfinite() {
+		c, err := lower.Val.CompareError(ctx, upper.Val)
+		if err != nil {
+			return nil, err
+		}
+		if c > 0 || (c == 0 && !(lower.Inclusive && upper.Inclusive)) {
+			return NewDEmptyRange(typ), nil
+		}
+	}
+	return &DRange{typ: typ, Lower: lower, Upper: upper}, nil
+}
+
+// NewDEmptyRange returns an empty range of the given type.
+func NewDEmptyRange(typ *types.T) *DRange {
+	return &DRange{typ: typ, Empty: true}
+}
+
+// NewDRangeUnchecked returns a range of the given type with the given bounds,
+// which must already be normalized. It is used when decoding ranges which were
+// created by NewDRange.
+func NewDRangeUnchecked(typ *types.T, lower, upper RangeBound, empty bool) *DRange {
+	if empty {
+		return NewDEmptyRange(typ)
+	}
+	return &DRange{typ: typ, Lower: lower, Upper: upper}
+}
+
+// canonicalizeRangeBound converts a bound of a range of a discrete subtype to
+// its canonical form, in which lower bounds are inclusive and upper bounds are
+// exclusive.
+func canonicalizeRangeBound(typ *types.T, b RangeBound, isLower bool) (RangeBound, error) {
+	if b.IsInfinite() || b.Inclusive == isLower {
+		return b, nil
+	}
+	switch v := b.Val.(type) {
+	case *DInt:
+		limit := int64(math.MaxInt64)
+		if typ.RangeContents().Width() == 32 {
+			limit = math.MaxInt32
+		}
+		if int64(*v) >= limit {
+			return RangeBound{}, pgerror.New(pgcode.NumericValueOutOfRange, "integer out of range")
+		}
+		return RangeBound{Val: NewDInt(*v + 1), Inclusive: isLower}, nil
+	case *DDate:
+		if !v.IsFinite() {
+			return b, nil
+		}
+		d, err := v.AddDays(1)
+		if err != nil {
+			return RangeBound{}, err
+		}
+		return RangeBound{Val: NewDDate(d), Inclusive: isLower}, nil
+	}
+	return RangeBound{}, errors.AssertionFailedf("unexpected discrete range bound %T", b.Val)
+}
+
+// compareRangeBounds compares two range bounds, each of which can be either a
+// lower or an upper bound. It mirrors range_cmp_bounds in Postgres.
+func compareRangeBounds(
+	ctx CompareContext, b1 RangeBound, isLower1 bool, b2 RangeBound, isLower2 bool,
+) (int, error) {
+	switch {
+	case b1.IsInfinite() && b2.IsInfinite():
+		if isLower1 == isLower2 {
+			return 0, nil
+		}
+		if isLower1 {
+			return -1, nil
+		}
+		return 1, nil
+	case b1.IsInfinite():
+		if isLower1 {
+			return -1, nil
+		}
+		return 1, nil
+	case b2.IsInfinite():
+		if isLower2 {
+			return 1, nil
+		}
+		return -1, nil
+	}
+	c, err := b1.Val.CompareError(ctx, b2.Val)
+	if err != nil || c != 0 {
+		return c, err
+	}
+	switch {
+	case !b1.Inclusive && !b2.Inclusive:
+		if isLower1 == isLower2 {
+			return 0, nil
+		}
+		if isLower1 {
+			return 1, nil
+		}
+		return -1, nil
+	case !b1.Inclusive:
+		if isLower1 {
+			return 1, nil
+		}
+		return -1, nil
+	case !b2.Inclusive:
+		if isLower2 {
+			return -1, nil
+		}
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// compareRanges compares two ranges. Empty ranges sort before all other
+// ranges, and other ranges are ordered by their lower bounds and then by
+// their upper bounds.
+func compareRanges(ctx CompareContext, r1, r2 *DRange) (int, error) {
+	switch {
+	case r1.Empty && r2.Empty:
+		return 0, nil
+	case r1.Empty:
+		return -1, nil
+	case r2.Empty:
+		return 1, nil
+	}
+	c, err := compareRangeBounds(ctx, r1.Lower, true /* isLower1 */, r2.Lower, true /* isLower2 */)
+	if err != nil || c != 0 {
+		return c, err
+	}
+	return compareRangeBounds(ctx, r1.Upper, false /* isLower1 */, r2.Upper, false /* isLower2 */)
+}
+
+// ContainsElem returns true if the range contains the given element.
+func (d *DRange) ContainsElem(ctx CompareContext, elem Datum) (bool, error) {
+	if d.Empty {
+		return false, nil
+	}
+	b := RangeBound{Val: elem, Inclusive: true}
+	c, err := compareRangeBounds(ctx, d.Lower, true /* isLower1 */, b, true /* isLower2 */)
+	if err != nil || c > 0 {
+		return false, err
+	}
+	c, err = compareRangeBounds(ctx, d.Upper, false /* isLower1 */, b, false /* isLower2 */)
+	if err != nil {
+		return false, err
+	}
+	return c >= 0, nil
+}
+
+// ContainsRange returns true if the range contains every value of the other
+// range.
+func (d *DRange) ContainsRange(ctx CompareContext, other *DRange) (bool, error) {
+	if other.Empty {
+		return true, nil
+	}
+	if d.Empty {
+		return false, nil
+	}
+	c, err := compareRangeBounds(ctx, d.Lower, true /* isLower1 */, other.Lower, true /* isLower2 */)
+	if err != nil || c > 0 {
+		return false, err
+	}
+	c, err = compareRangeBounds(ctx, d.Upper, false /* isLower1 */, other.Upper, false /* isLower2 */)
+	if err != nil {
+		return false, err
+	}
+	return c >= 0, nil
+}
+
+// Overlaps returns true if the ranges have any value in common.
+func (d *DRange) Overlaps(ctx CompareContext, other *DRange) (bool, error) {
+	if d.Empty || other.Empty {
+		return false, nil
+	}
+	c, err := compareRangeBounds(ctx, d.Lower, true /* isLower1 */, other.Upper, false /* isLower2 */)
+	if err != nil || c > 0 {
+		return false, err
+	}
+	c, err = compareRangeBounds(ctx, other.Lower, true /* isLower1 */, d.Upper, false /* isLower2 */)
+	if err != nil {
+		return false, err
+	}
+	return c <= 0, nil
+}
+
+// Before returns true if the range is strictly to the left of the other
+// range.
+func (d *DRange) Before(ctx CompareContext, other *DRange) (bool, error) {
+	if d.Empty || other.Empty {
+		return false, nil
+	}
+	c, err := compareRangeBounds(ctx, d.Upper, false /* isLower1 */, other.Lower, true /* isLower2 */)
+	if err != nil {
+		return false, err
+	}
+	return c < 0, nil
+}
+
+// After returns true if the range is strictly to the right of the other
+// range.
+func (d *DRange) After(ctx CompareContext, other *DRange) (bool, error) {
+	return other.Before(ctx, d)
+}
+
+// Adjacent returns true if the ranges do not overlap, but there are no values
+// between them.
+func (d *DRange) Adjacent(ctx CompareContext, other *DRange) (bool, error) {
+	if d.Empty || other.Empty {
+		return false, nil
+	}
+	adjacent := func(upper, lower RangeBound) (bool, error) {
+		if upper.IsInfinite() || lower.IsInfinite() {
+			return false, nil
+		}
+		// Ranges of discrete subtypes are canonicalized, so it is sufficient to
+		// check that the bounds have the same value and exactly one of them is
+		// inclusive.
+		c, err := upper.Val.CompareError(ctx, lower.Val)
+		if err != nil {
+			return false, err
+		}
+		return c == 0 && upper.Inclusive != lower.Inclusive, nil
+	}
+	if ok, err := adjacent(d.Upper, other.Lower); err != nil || ok {
+		return ok, err
+	}
+	return adjacent(other.Upper, d.Lower)
+}
+
+// Merge returns the smallest range which contains both ranges.
+func (d *DRange) Merge(ctx CompareContext, other *DRange) (*DRange, error) {
+	if d.Empty {
+		return other, nil
+	}
+	if other.Empty {
+		return d, nil
+	}
+	res := *d
+	c, err := compareRangeBounds(ctx, d.Lower, true /* isLower1 */, other.Lower, true /* isLower2 */)
+	if err != nil {
+		return nil, err
+	}
+	if c > 0 {
+		res.Lower = other.Lower
+	}
+	c, err = compareRangeBounds(ctx, d.Upper, false /* isLower1 */, other.Upper, false /* isLower2 */)
+	if err != nil {
+		return nil, err
+	}
+	if c < 0 {
+		res.Upper = other.Upper
+	}
+	return &res, nil
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DRange) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DRange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DRange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DRange)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return compareRanges(ctx, d, v)
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(_ CompareContext) bool {
+	return d.Empty
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(_ CompareContext) (Datum, bool) {
+	return NewDEmptyRange(d.typ), true
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if !d.Lower.IsInfinite() {
+		sz += d.Lower.Val.Size()
+	}
+	if !d.Upper.IsInfinite() {
+		sz += d.Upper.Val.Size()
+	}
+	return sz
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DRange) IsComposite() bool {
+	for _, b := range [...]RangeBound{d.Lower, d.Upper} {
+		if cdatum, ok := b.Val.(CompositeDatum); ok && cdatum.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DRange) Format(ctx *FmtCtx) {
+	if ctx.HasFlags(fmtPgwireFormat) {
+		d.pgwireFormat(ctx)
+		return
+	}
+	formatRangeText(ctx, AsStringWithFlags(d, FmtPgwireText))
+}
+
+// formatRangeText writes the text representation of a range or multirange as
+// a string literal, unless bare strings were requested.
+func formatRangeText(ctx *FmtCtx, str string) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+		str = strings.ReplaceAll(str, `'`, `''`)
+	}
+	ctx.WriteString(str)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// AsDRange attempts to retrieve a DRange from an Expr, returning a DRange and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DRange wrapped by a
+// *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	v, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return v
+}
+
+// DMultirange is the Datum for the built-in multirange types. The ranges of a
+// multirange are non-empty, sorted, and neither overlap nor are adjacent to
+// each other.
+type DMultirange struct {
+	typ    *types.T
+	Ranges []*DRange
+}
+
+// NewDMultirange returns a multirange of the given type containing the given
+// ranges. Empty ranges are discarded, and overlapping or adjacent ranges are
+// merged.
+func NewDMultirange(ctx CompareContext, typ *types.T, ranges []*DRange) (*DMultirange, error) {
+	res := make([]*DRange, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.Empty {
+			res = append(res, r)
+		}
+	}
+	var err error
+	sort.SliceStable(res, func(i, j int) bool {
+		c, cmpErr := compareRanges(ctx, res[i], res[j])
+		if cmpErr != nil {
+			err = cmpErr
+		}
+		return c < 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	merged := res[:0]
+	for _, r := range res {
+		if len(merged) > 0 {
+			last := merged[len(merged)-1]
+			overlaps, err := last.Overlaps(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			adjacent, err := last.Adjacent(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			if overlaps || adjacent {
+				if merged[len(merged)-1], err = last.Merge(ctx, r); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+	return &DMultirange{typ: typ, Ranges: merged}, nil
+}
+
+// NewDMultirangeUnchecked returns a multirange of the given type containing
+// the given ranges, which must already be normalized. It is used when decoding
+// multiranges which were created by NewDMultirange.
+func NewDMultirangeUnchecked(typ *types.T, ranges []*DRange) *DMultirange {
+	return &DMultirange{typ: typ, Ranges: ranges}
+}
+
+// NewDMultirangeFromRange returns the multirange which contains the same
+// values as the given range.
+func NewDMultirangeFromRange(r *DRange) *DMultirange {
+	res := &DMultirange{typ: types.RangeMultirange(r.typ)}
+	if !r.Empty {
+		res.Ranges = []*DRange{r}
+	}
+	return res
+}
+
+// ContainsElem returns true if the multirange contains the given element.
+func (d *DMultirange) ContainsElem(ctx CompareContext, elem Datum) (bool, error) {
+	for _, r := range d.Ranges {
+		if ok, err := r.ContainsElem(ctx, elem); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// ContainsMultirange returns true if the multirange contains every value of
+// the other multirange. Since the ranges of a multirange are neither
+// overlapping nor adjacent, each range of the other multirange must be
+// contained in a single range of this one.
+func (d *DMultirange) ContainsMultirange(ctx CompareContext, other *DMultirange) (bool, error) {
+	for _, o := range other.Ranges {
+		contained := false
+		for _, r := range d.Ranges {
+			ok, err := r.ContainsRange(ctx, o)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Overlaps returns true if the multiranges have any value in common.
+func (d *DMultirange) Overlaps(ctx CompareContext, other *DMultirange) (bool, error) {
+	for _, r := range d.Ranges {
+		for _, o := range other.Ranges {
+			if ok, err := r.Overlaps(ctx, o); err != nil || ok {
+				return ok, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// Before returns true if the multirange is strictly to the left of the other
+// multirange.
+func (d *DMultirange) Before(ctx CompareContext, other *DMultirange) (bool, error) {
+	if len(d.Ranges) == 0 || len(other.Ranges) == 0 {
+		return false, nil
+	}
+	return d.Ranges[len(d.Ranges)-1].Before(ctx, other.Ranges[0])
+}
+
+// After returns true if the multirange is strictly to the right of the other
+// multirange.
+func (d *DMultirange) After(ctx CompareContext, other *DMultirange) (bool, error) {
+	return other.Before(ctx, d)
+}
+
+// Adjacent returns true if the multiranges do not overlap, but there are no
+// values between them.
+func (d *DMultirange) Adjacent(ctx CompareContext, other *DMultirange) (bool, error) {
+	if len(d.Ranges) == 0 || len(other.Ranges) == 0 {
+		return false, nil
+	}
+	if ok, err := d.Ranges[len(d.Ranges)-1].Adjacent(ctx, other.Ranges[0]); err != nil || ok {
+		return ok, err
+	}
+	return other.Ranges[len(other.Ranges)-1].Adjacent(ctx, d.Ranges[0])
+}
+
+// Span returns the smallest range which contains every range of the
+// multirange.
+func (d *DMultirange) Span() *DRange {
+	typ := types.MultirangeRange(d.typ)
+	if len(d.Ranges) == 0 {
+		return NewDEmptyRange(typ)
+	}
+	return &DRange{typ: typ, Lower: d.Ranges[0].Lower, Upper: d.Ranges[len(d.Ranges)-1].Upper}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DMultirange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DMultirange) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DMultirange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DMultirange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DMultirange)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	n := len(d.Ranges)
+	if len(v.Ranges) < n {
+		n = len(v.Ranges)
+	}
+	for i := 0; i < n; i++ {
+		c, err := compareRanges(ctx, d.Ranges[i], v.Ranges[i])
+		if err != nil || c != 0 {
+			return c, err
+		}
+	}
+	switch {
+	case len(d.Ranges) < len(v.Ranges):
+		return -1, nil
+	case len(d.Ranges) > len(v.Ranges):
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DMultirange) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DMultirange) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DMultirange) IsMin(_ CompareContext) bool {
+	return len(d.Ranges) == 0
+}
+
+// IsMax implements the Datum interface.
+func (d *DMultirange) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DMultirange) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DMultirange) Min(_ CompareContext) (Datum, bool) {
+	return NewDMultirangeUnchecked(d.typ, nil /* ranges */), true
+}
+
+// Size implements the Datum interface.
+func (d *DMultirange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	for _, r := range d.Ranges {
+		sz += r.Size()
+	}
+	return sz
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DMultirange) IsComposite() bool {
+	for _, r := range d.Ranges {
+		if r.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DMultirange) Format(ctx *FmtCtx) {
+	if ctx.HasFlags(fmtPgwireFormat) {
+		d.pgwireFormat(ctx)
+		return
+	}
+	formatRangeText(ctx, AsStringWithFlags(d, FmtPgwireText))
+}
+
+// AsDMultirange attempts to retrieve a DMultirange from an Expr, returning a
+// DMultirange and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DMultirange wrapped by a *DOidWrapper is possible.
+func AsDMultirange(e Expr) (*DMultirange, bool) {
+	switch t := e.(type) {
+	case *DMultirange:
+		return t, true
+	case *DOidWrapper:
+		return AsDMultirange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMultirange attempts to retrieve a DMultirange from an Expr, panicking
+// if the assertion fails.
+func MustBeDMultirange(e Expr) *DMultirange {
+	v, ok := AsDMultirange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMultirange, found %T", e))
+	}
+	return v
+}
+
+// rangeBoundCompareContext is the CompareContext used to normalize ranges
+// outside of an evaluation context, such as when parsing range literals. The
+// bounds of a range always have the same type, so comparing them does not
+// depend on any session state.
+type rangeBoundCompareContext struct{}
+
+var _ CompareContext = rangeBoundCompareContext{}
+
+// UnwrapDatum is part of the CompareContext interface.
+func (rangeBoundCompareContext) UnwrapDatum(d Datum) Datum {
+	return UnwrapDOidWrapper(d)
+}
+
+// GetLocation is part of the CompareContext interface.
+func (rangeBoundCompareContext) GetLocation() *time.Location {
+	return time.UTC
+}
+
+// GetRelativeParseTime is part of the CompareContext interface.
+func (rangeBoundCompareContext) GetRelativeParseTime() time.Time {
+	return time.Now().UTC()
+}
+
+// MustGetPlaceholderValue is part of the CompareContext interface.
+func (rangeBoundCompareContext) MustGetPlaceholderValue(p *Placeholder) Datum {
+	panic(errors.AssertionFailedf("unexpected placeholder %s in range bound", p))
+}
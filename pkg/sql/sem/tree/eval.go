@@ -765,6 +765,13 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &PlusIntINetOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.PGVector,
+			RightType:  types.PGVector,
+			ReturnType: types.PGVector,
+			EvalOp:     &PlusPGVectorOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	treebin.Minus: {overloads: []*BinOp{
@@ -937,6 +944,13 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &MinusINetIntOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.PGVector,
+			RightType:  types.PGVector,
+			ReturnType: types.PGVector,
+			EvalOp:     &MinusPGVectorOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	treebin.Mult: {overloads: []*BinOp{
@@ -1020,6 +1034,13 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &MultIntervalDecimalOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.PGVector,
+			RightType:  types.PGVector,
+			ReturnType: types.PGVector,
+			EvalOp:     &MultPGVectorOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	treebin.Div: {overloads: []*BinOp{
@@ -1188,10 +1209,24 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &ConcatJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.TSVector,
+			RightType:  types.TSVector,
+			ReturnType: types.TSVector,
+			EvalOp:     &ConcatTSVectorOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.TSQuery,
+			RightType:  types.TSQuery,
+			ReturnType: types.TSQuery,
+			EvalOp:     &ConcatTSQueryOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	// TODO(pmattis): Check that the shift is valid.
-	treebin.LShift: {overloads: []*BinOp{
+	treebin.LShift: {overloads: append([]*BinOp{
 		{
 			LeftType:   types.Int,
 			RightType:  types.Int,
@@ -1213,9 +1248,13 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &LShiftINetOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeBinaryOperators(
+		(*DRange).Before,
+		(*DMultirange).Before,
+	)...),
+	},
 
-	treebin.RShift: {overloads: []*BinOp{
+	treebin.RShift: {overloads: append([]*BinOp{
 		{
 			LeftType:   types.Int,
 			RightType:  types.Int,
@@ -1237,7 +1276,11 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &RShiftINetOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeBinaryOperators(
+		(*DRange).After,
+		(*DMultirange).After,
+	)...),
+	},
 
 	treebin.Pow: {overloads: []*BinOp{
 		{
@@ -1332,6 +1375,43 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			Volatility: volatility.Immutable,
 		},
 	}},
+
+	treebin.Distance: {overloads: []*BinOp{
+		{
+			LeftType:   types.String,
+			RightType:  types.String,
+			ReturnType: types.Float,
+			EvalOp:     &DistanceStringOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.PGVector,
+			RightType:  types.PGVector,
+			ReturnType: types.Float,
+			EvalOp:     &DistancePGVectorOp{},
+			Volatility: volatility.Immutable,
+		},
+	}},
+
+	treebin.CosDistance: {overloads: []*BinOp{
+		{
+			LeftType:   types.PGVector,
+			RightType:  types.PGVector,
+			ReturnType: types.Float,
+			EvalOp:     &CosDistancePGVectorOp{},
+			Volatility: volatility.Immutable,
+		},
+	}},
+
+	treebin.NegInnerProduct: {overloads: []*BinOp{
+		{
+			LeftType:   types.PGVector,
+			RightType:  types.PGVector,
+			ReturnType: types.Float,
+			EvalOp:     &NegInnerProductPGVectorOp{},
+			Volatility: volatility.Immutable,
+		},
+	}},
 }
 
 // CmpOp is a comparison operator.
@@ -1493,7 +1573,7 @@ func makeIsFn(a, b *types.T, v volatility.V) *CmpOp {
 
 // CmpOps contains the comparison operations indexed by operation type.
 var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
-	treecmp.EQ: {overloads: []*CmpOp{
+	treecmp.EQ: {overloads: append([]*CmpOp{
 		// Single-type comparisons.
 		makeEqFn(types.AnyEnum, types.AnyEnum, volatility.Immutable),
 		makeEqFn(types.Bool, types.Bool, volatility.Leakproof),
@@ -1513,6 +1593,7 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEqFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeEqFn(types.Jsonb, types.Jsonb, volatility.Immutable),
 		makeEqFn(types.Oid, types.Oid, volatility.Leakproof),
+		makeEqFn(types.PGVector, types.PGVector, volatility.Leakproof),
 		makeEqFn(types.String, types.String, volatility.Leakproof),
 		makeEqFn(types.Time, types.Time, volatility.Leakproof),
 		makeEqFn(types.TimeTZ, types.TimeTZ, volatility.Leakproof),
@@ -1550,9 +1631,10 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeScalarCmpOps(makeEqFn)...),
+	},
 
-	treecmp.LT: {overloads: []*CmpOp{
+	treecmp.LT: {overloads: append([]*CmpOp{
 		// Single-type comparisons.
 		makeLtFn(types.AnyEnum, types.AnyEnum, volatility.Immutable),
 		makeLtFn(types.Bool, types.Bool, volatility.Leakproof),
@@ -1571,6 +1653,7 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLtFn(types.Int, types.Int, volatility.Leakproof),
 		makeLtFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeLtFn(types.Oid, types.Oid, volatility.Leakproof),
+		makeLtFn(types.PGVector, types.PGVector, volatility.Leakproof),
 		makeLtFn(types.String, types.String, volatility.Leakproof),
 		makeLtFn(types.Time, types.Time, volatility.Leakproof),
 		makeLtFn(types.TimeTZ, types.TimeTZ, volatility.Leakproof),
@@ -1606,9 +1689,10 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeScalarCmpOps(makeLtFn)...),
+	},
 
-	treecmp.LE: {overloads: []*CmpOp{
+	treecmp.LE: {overloads: append([]*CmpOp{
 		// Single-type comparisons.
 		makeLeFn(types.AnyEnum, types.AnyEnum, volatility.Immutable),
 		makeLeFn(types.Bool, types.Bool, volatility.Leakproof),
@@ -1627,6 +1711,7 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLeFn(types.Int, types.Int, volatility.Leakproof),
 		makeLeFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeLeFn(types.Oid, types.Oid, volatility.Leakproof),
+		makeLeFn(types.PGVector, types.PGVector, volatility.Leakproof),
 		makeLeFn(types.String, types.String, volatility.Leakproof),
 		makeLeFn(types.Time, types.Time, volatility.Leakproof),
 		makeLeFn(types.TimeTZ, types.TimeTZ, volatility.Leakproof),
@@ -1662,9 +1747,10 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeScalarCmpOps(makeLeFn)...),
+	},
 
-	treecmp.IsNotDistinctFrom: {overloads: []*CmpOp{
+	treecmp.IsNotDistinctFrom: {overloads: append([]*CmpOp{
 		{
 			LeftType:  types.Unknown,
 			RightType: types.Unknown,
@@ -1703,7 +1789,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeIsFn(types.Int, types.Int, volatility.Leakproof),
 		makeIsFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeIsFn(types.Jsonb, types.Jsonb, volatility.Immutable),
+		makeIsFn(types.Jsonpath, types.Jsonpath, volatility.Immutable),
 		makeIsFn(types.Oid, types.Oid, volatility.Leakproof),
+		makeIsFn(types.PGVector, types.PGVector, volatility.Leakproof),
 		makeIsFn(types.String, types.String, volatility.Leakproof),
 		makeIsFn(types.Time, types.Time, volatility.Leakproof),
 		makeIsFn(types.TimeTZ, types.TimeTZ, volatility.Leakproof),
@@ -1751,7 +1839,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeScalarCmpOps(makeIsFn)...),
+	},
 
 	treecmp.In: {overloads: []*CmpOp{
 		makeEvalTupleIn(types.AnyEnum, volatility.Leakproof),
@@ -1857,7 +1946,7 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		},
 	}},
 
-	treecmp.Contains: {overloads: []*CmpOp{
+	treecmp.Contains: {overloads: append(append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -1870,9 +1959,13 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainsJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeComparisonOperators(
+		(*DRange).ContainsRange,
+		(*DMultirange).ContainsMultirange,
+	)...), makeRangeContainsElemOperators(false /* elemOnLeft */)...),
+	},
 
-	treecmp.ContainedBy: {overloads: []*CmpOp{
+	treecmp.ContainedBy: {overloads: append(append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -1885,7 +1978,15 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainedByJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeComparisonOperators(
+		func(left *DRange, ctx CompareContext, right *DRange) (bool, error) {
+			return right.ContainsRange(ctx, left)
+		},
+		func(left *DMultirange, ctx CompareContext, right *DMultirange) (bool, error) {
+			return right.ContainsMultirange(ctx, left)
+		},
+	)...), makeRangeContainsElemOperators(true /* elemOnLeft */)...),
+	},
 	treecmp.Overlaps: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
@@ -1899,11 +2000,14 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &OverlapsINetOp{},
 			Volatility: volatility.Immutable,
 		},
-	}, makeBox2DComparisonOperators(
+	}, append(makeBox2DComparisonOperators(
 		func(lhs, rhs *geo.CartesianBoundingBox) bool {
 			return lhs.Intersects(rhs)
 		},
-	)...),
+	), makeRangeComparisonOperators(
+		(*DRange).Overlaps,
+		(*DMultirange).Overlaps,
+	)...)...),
 	},
 	treecmp.TSMatches: {overloads: []*CmpOp{
 		{
@@ -1918,6 +2022,20 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &TSMatchesVectorQueryOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathMatchOp{},
+			Volatility: volatility.Immutable,
+		},
+	}},
+	treecmp.JSONPathExists: {overloads: []*CmpOp{
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathExistsOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 })
 
@@ -1970,6 +2088,114 @@ func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bo
 	}
 }
 
+// makeRangeScalarCmpOps returns the overloads of a scalar comparison for
+// every built-in range and multirange type.
+func makeRangeScalarCmpOps(fn func(a, b *types.T, v volatility.V) *CmpOp) []*CmpOp {
+	ops := make([]*CmpOp, 0, len(types.Ranges)+len(types.Multiranges))
+	for _, t := range types.Ranges {
+		ops = append(ops, fn(t, t, volatility.Immutable))
+	}
+	for _, t := range types.Multiranges {
+		ops = append(ops, fn(t, t, volatility.Immutable))
+	}
+	return ops
+}
+
+// rangeOperandAsMultirange returns the operand of a range operator as a
+// multirange, so that the overloads mixing ranges and multiranges can share the
+// multirange implementation.
+func rangeOperandAsMultirange(d Datum) *DMultirange {
+	if r, ok := AsDRange(d); ok {
+		return NewDMultirangeFromRange(r)
+	}
+	return MustBeDMultirange(d)
+}
+
+// makeRangeComparisonOperators returns the overloads of a range operator for
+// every built-in range type and its multirange type. Both operands must have
+// the same subtype, but either of them may be a range or a multirange.
+func makeRangeComparisonOperators(
+	rangeOp func(left *DRange, ctx CompareContext, right *DRange) (bool, error),
+	multirangeOp func(left *DMultirange, ctx CompareContext, right *DMultirange) (bool, error),
+) []*CmpOp {
+	ops := make([]*CmpOp, 0, 4*len(types.Ranges))
+	for i, r := range types.Ranges {
+		m := types.Multiranges[i]
+		ops = append(ops, &CmpOp{
+			LeftType:  r,
+			RightType: r,
+			EvalOp: &CompareRangeOp{Op: func(ctx CompareContext, left, right Datum) (bool, error) {
+				return rangeOp(MustBeDRange(left), ctx, MustBeDRange(right))
+			}},
+			Volatility: volatility.Immutable,
+		})
+		for _, operands := range [][2]*types.T{{m, m}, {m, r}, {r, m}} {
+			ops = append(ops, &CmpOp{
+				LeftType:  operands[0],
+				RightType: operands[1],
+				EvalOp: &CompareRangeOp{Op: func(ctx CompareContext, left, right Datum) (bool, error) {
+					return multirangeOp(rangeOperandAsMultirange(left), ctx, rangeOperandAsMultirange(right))
+				}},
+				Volatility: volatility.Immutable,
+			})
+		}
+	}
+	return ops
+}
+
+// makeRangeContainsElemOperators returns the overloads of the operators which
+// check whether a range or multirange contains an element of its subtype. If
+// elemOnLeft is true, the element is the left operand (as with <@).
+func makeRangeContainsElemOperators(elemOnLeft bool) []*CmpOp {
+	ops := make([]*CmpOp, 0, 2*len(types.Ranges))
+	for i, r := range types.Ranges {
+		elem := r.RangeContents()
+		for _, t := range []*types.T{r, types.Multiranges[i]} {
+			op := &CmpOp{
+				LeftType:   t,
+				RightType:  elem,
+				Volatility: volatility.Immutable,
+			}
+			contains := func(ctx CompareContext, container, elem Datum) (bool, error) {
+				if r, ok := AsDRange(container); ok {
+					return r.ContainsElem(ctx, elem)
+				}
+				return MustBeDMultirange(container).ContainsElem(ctx, elem)
+			}
+			if elemOnLeft {
+				op.LeftType, op.RightType = elem, t
+				op.EvalOp = &CompareRangeOp{Op: func(ctx CompareContext, left, right Datum) (bool, error) {
+					return contains(ctx, right, left)
+				}}
+			} else {
+				op.EvalOp = &CompareRangeOp{Op: contains}
+			}
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// makeRangeBinaryOperators returns the overloads of a range operator that is
+// parsed as a binary operator, like << and >>.
+func makeRangeBinaryOperators(
+	rangeOp func(left *DRange, ctx CompareContext, right *DRange) (bool, error),
+	multirangeOp func(left *DMultirange, ctx CompareContext, right *DMultirange) (bool, error),
+) []*BinOp {
+	cmpOps := makeRangeComparisonOperators(rangeOp, multirangeOp)
+	ops := make([]*BinOp, len(cmpOps))
+	for i, o := range cmpOps {
+		ops[i] = &BinOp{
+			LeftType:   o.LeftType,
+			RightType:  o.RightType,
+			ReturnType: types.Bool,
+			EvalOp:     o.EvalOp,
+			Volatility: o.Volatility,
+		}
+	}
+	return ops
+}
+
 // This map contains the inverses for operators in the CmpOps map that have
 // inverses.
 var cmpOpsInverse map[treecmp.ComparisonOperatorSymbol]treecmp.ComparisonOperatorSymbol
@@ -32,6 +32,11 @@ type CompareBox2DOp struct {
 	Op func(left, right Datum) bool
 }
 
+// CompareRangeOp is a BinaryEvalOp.
+type CompareRangeOp struct {
+	Op func(ctx CompareContext, left, right Datum) (bool, error)
+}
+
 // InTupleOp is a BinaryEvalOp.
 type InTupleOp struct{}
 
@@ -81,6 +86,12 @@ type TSMatchesVectorQueryOp struct{}
 // TSMatchesQueryVectorOp is a BinaryEvalOp.
 type TSMatchesQueryVectorOp struct{}
 
+// JSONPathExistsOp is a BinaryEvalOp.
+type JSONPathExistsOp struct{}
+
+// JSONPathMatchOp is a BinaryEvalOp.
+type JSONPathMatchOp struct{}
+
 // AppendToMaybeNullArrayOp is a BinaryEvalOp.
 type AppendToMaybeNullArrayOp struct {
 	Typ *types.T
@@ -176,6 +187,8 @@ type (
 	PlusINetIntOp struct{}
 	// PlusIntINetOp is a BinaryEvalOp.
 	PlusIntINetOp struct{}
+	// PlusPGVectorOp is a BinaryEvalOp.
+	PlusPGVectorOp struct{}
 )
 
 type (
@@ -227,6 +240,8 @@ type (
 	MinusINetOp struct{}
 	// MinusINetIntOp is a BinaryEvalOp.
 	MinusINetIntOp struct{}
+	// MinusPGVectorOp is a BinaryEvalOp.
+	MinusPGVectorOp struct{}
 )
 type (
 	// MultDecimalIntOp is a BinaryEvalOp.
@@ -251,6 +266,8 @@ type (
 	MultIntervalFloatOp struct{}
 	// MultIntervalIntOp is a BinaryEvalOp.
 	MultIntervalIntOp struct{}
+	// MultPGVectorOp is a BinaryEvalOp.
+	MultPGVectorOp struct{}
 )
 
 type (
@@ -305,6 +322,10 @@ type (
 	ConcatJsonbOp struct{}
 	// ConcatStringOp is a BinaryEvalOp.
 	ConcatStringOp struct{}
+	// ConcatTSQueryOp is a BinaryEvalOp.
+	ConcatTSQueryOp struct{}
+	// ConcatTSVectorOp is a BinaryEvalOp.
+	ConcatTSVectorOp struct{}
 	// ConcatVarBitOp is a BinaryEvalOp.
 	ConcatVarBitOp struct{}
 )
@@ -368,6 +389,18 @@ type JSONFetchValPathOp struct{}
 // JSONFetchTextPathOp is a BinaryEvalOp.
 type JSONFetchTextPathOp struct{}
 
+// DistanceStringOp is a BinaryEvalOp.
+type DistanceStringOp struct{}
+
+// DistancePGVectorOp is a BinaryEvalOp.
+type DistancePGVectorOp struct{}
+
+// CosDistancePGVectorOp is a BinaryEvalOp.
+type CosDistancePGVectorOp struct{}
+
+// NegInnerProductPGVectorOp is a BinaryEvalOp.
+type NegInnerProductPGVectorOp struct{}
+
 // ContainsArrayOp is a BinaryEvalOp.
 type ContainsArrayOp struct{}
 
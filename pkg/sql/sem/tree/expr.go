@@ -1030,7 +1030,7 @@ var binaryOpPrio = [...]int{
 	treebin.Pow:  1,
 	treebin.Mult: 2, treebin.Div: 2, treebin.FloorDiv: 2, treebin.Mod: 2,
 	treebin.Plus: 3, treebin.Minus: 3,
-	treebin.LShift: 4, treebin.RShift: 4,
+	treebin.LShift: 4, treebin.RShift: 4, treebin.Distance: 4, treebin.CosDistance: 4, treebin.NegInnerProduct: 4,
 	treebin.Bitand: 5,
 	treebin.Bitxor: 6,
 	treebin.Bitor:  7,
@@ -1043,7 +1043,7 @@ var binaryOpFullyAssoc = [...]bool{
 	treebin.Pow:  false,
 	treebin.Mult: true, treebin.Div: false, treebin.FloorDiv: false, treebin.Mod: false,
 	treebin.Plus: true, treebin.Minus: false,
-	treebin.LShift: false, treebin.RShift: false,
+	treebin.LShift: false, treebin.RShift: false, treebin.Distance: false, treebin.CosDistance: false, treebin.NegInnerProduct: false,
 	treebin.Bitand: true,
 	treebin.Bitxor: true,
 	treebin.Bitor:  true,
@@ -1248,6 +1248,10 @@ type FuncExpr struct {
 	// OrderBy is used for aggregations which specify an order. This same field
 	// is used for any type of aggregation.
 	OrderBy OrderBy
+	// Variadic is true if the last argument is preceded by VARIADIC, in which
+	// case it is an array that is passed to the VARIADIC parameter of a
+	// user-defined function in place of a list of arguments.
+	Variadic bool
 
 	typeAnnotation
 	fnProps *FunctionProperties
@@ -1361,7 +1365,17 @@ func (node *FuncExpr) Format(ctx *FmtCtx) {
 
 	ctx.WriteByte('(')
 	ctx.WriteString(typ)
-	ctx.FormatNode(&node.Exprs)
+	if n := len(node.Exprs) - 1; node.Variadic && n >= 0 {
+		args := node.Exprs[:n]
+		ctx.FormatNode(&args)
+		if n > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.WriteString("VARIADIC ")
+		ctx.FormatNode(node.Exprs[n])
+	} else {
+		ctx.FormatNode(&node.Exprs)
+	}
 	if node.AggType == GeneralAgg && len(node.OrderBy) > 0 {
 		ctx.WriteByte(' ')
 		ctx.FormatNode(&node.OrderBy)
@@ -1709,6 +1723,8 @@ func (node *DGeometry) String() string        { return AsString(node) }
 func (node *DInt) String() string             { return AsString(node) }
 func (node *DInterval) String() string        { return AsString(node) }
 func (node *DJSON) String() string            { return AsString(node) }
+func (node *DRange) String() string           { return AsString(node) }
+func (node *DMultirange) String() string      { return AsString(node) }
 func (node *DUuid) String() string            { return AsString(node) }
 func (node *DIPAddr) String() string          { return AsString(node) }
 func (node *DString) String() string          { return AsString(node) }
@@ -1746,6 +1762,7 @@ func (node *TupleStar) String() string        { return AsString(node) }
 func (node *AnnotateTypeExpr) String() string { return AsString(node) }
 func (node *UnaryExpr) String() string        { return AsString(node) }
 func (node DefaultVal) String() string        { return AsString(node) }
+func (node *GroupingSet) String() string      { return AsString(node) }
 func (node PartitionMaxVal) String() string   { return AsString(node) }
 func (node PartitionMinVal) String() string   { return AsString(node) }
 func (node *Placeholder) String() string      { return AsString(node) }
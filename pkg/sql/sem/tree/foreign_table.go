@@ -0,0 +1,138 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// ForeignOption is an option of a foreign server or a foreign table.
+type ForeignOption struct {
+	Name  Name
+	Value string
+}
+
+// ForeignOptions is the list of options of a foreign server or a foreign
+// table, as specified with OPTIONS (name 'value', ...).
+type ForeignOptions []ForeignOption
+
+// Format implements the NodeFormatter interface.
+func (node *ForeignOptions) Format(ctx *FmtCtx) {
+	ctx.WriteString("OPTIONS (")
+	for i := range *node {
+		opt := &(*node)[i]
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		// Option names never contain PII and should be distinguished for
+		// feature tracking purposes.
+		ctx.WithFlags(ctx.flags&^FmtMarkRedactionNode, func() {
+			ctx.FormatNode(&opt.Name)
+		})
+		ctx.WriteByte(' ')
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, opt.Value, ctx.flags.EncodeFlags())
+		}
+	}
+	ctx.WriteByte(')')
+}
+
+// CreateServer represents a CREATE SERVER statement.
+type CreateServer struct {
+	Name        Name
+	IfNotExists bool
+	Wrapper     Name
+	Options     ForeignOptions
+}
+
+var _ Statement = &CreateServer{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateServer) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE SERVER ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" FOREIGN DATA WRAPPER ")
+	ctx.FormatNode(&node.Wrapper)
+	if len(node.Options) > 0 {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Options)
+	}
+}
+
+// DropServer represents a DROP SERVER statement.
+type DropServer struct {
+	Names    NameList
+	IfExists bool
+}
+
+var _ Statement = &DropServer{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropServer) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP SERVER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+}
+
+// CreateForeignTable represents a CREATE FOREIGN TABLE statement.
+type CreateForeignTable struct {
+	Table       TableName
+	IfNotExists bool
+	Defs        TableDefs
+	Server      Name
+	Options     ForeignOptions
+}
+
+var _ Statement = &CreateForeignTable{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateForeignTable) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE FOREIGN TABLE ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Table)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Defs)
+	ctx.WriteString(") SERVER ")
+	ctx.FormatNode(&node.Server)
+	if len(node.Options) > 0 {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Options)
+	}
+}
+
+// DropForeignTable represents a DROP FOREIGN TABLE statement.
+type DropForeignTable struct {
+	Names        TableNames
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropForeignTable{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropForeignTable) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP FOREIGN TABLE ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
@@ -440,7 +440,10 @@ func (ctx *FmtCtx) FormatNode(n NodeFormatter) {
 			return
 		}
 	}
-	if f.HasFlags(FmtAlwaysGroupExprs) {
+	// Grouping sets are not grouped, since a parenthesized ROLLUP or CUBE
+	// would be parsed as a function call.
+	_, isGroupingSet := n.(*GroupingSet)
+	if f.HasFlags(FmtAlwaysGroupExprs) && !isGroupingSet {
 		if _, ok := n.(Expr); ok {
 			ctx.WriteByte('(')
 		}
@@ -452,7 +455,7 @@ func (ctx *FmtCtx) FormatNode(n NodeFormatter) {
 		ctx.formatNodeOrHideConstants(n)
 	}
 
-	if f.HasFlags(FmtAlwaysGroupExprs) {
+	if f.HasFlags(FmtAlwaysGroupExprs) && !isGroupingSet {
 		if _, ok := n.(Expr); ok {
 			ctx.WriteByte(')')
 		}
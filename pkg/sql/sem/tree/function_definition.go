@@ -267,6 +267,46 @@ func (fd *ResolvedFunctionDefinition) MergeWith(
 	}, nil
 }
 
+// variadicArrayOverloads returns the definition of the function restricted to
+// the overloads that can be called with an array passed to a VARIADIC
+// parameter, as in f(VARIADIC ARRAY[1, 2]). Only user-defined functions with a
+// VARIADIC parameter can be called this way.
+func (fd *ResolvedFunctionDefinition) variadicArrayOverloads() *ResolvedFunctionDefinition {
+	ret := &ResolvedFunctionDefinition{Name: fd.Name}
+	for _, o := range fd.Overloads {
+		if vo := o.variadicArrayOverload(); vo != nil {
+			ret.Overloads = append(ret.Overloads, MakeQualifiedOverload(o.Schema, vo))
+		}
+	}
+	return ret
+}
+
+// matchUDFSignature returns true if the declared parameter types of a
+// user-defined function are the same as paramTypes. Unlike TypeList.Match, the
+// declared type of a VARIADIC parameter is its array type, and polymorphic
+// parameter types only match the same polymorphic types.
+func matchUDFSignature(params TypeList, paramTypes []*types.T) bool {
+	if v, ok := params.(VariadicParamTypes); ok {
+		params = v.ParamTypes
+	}
+	if params.Length() != len(paramTypes) {
+		return false
+	}
+	for i, typ := range paramTypes {
+		paramType := params.GetAt(i)
+		if IsPolymorphicType(paramType) || IsPolymorphicType(typ) {
+			if paramType.Oid() != typ.Oid() {
+				return false
+			}
+			continue
+		}
+		if !params.MatchAt(typ, i) {
+			return false
+		}
+	}
+	return true
+}
+
 // MatchOverload searches an overload which has exactly the same parameter
 // types. The overload from the most significant schema is returned. If
 // paramTypes==nil, an error is returned if the function name is not unique in
@@ -276,7 +316,16 @@ func (fd *ResolvedFunctionDefinition) MatchOverload(
 	paramTypes []*types.T, explicitSchema string, searchPath SearchPath,
 ) (QualifiedOverload, error) {
 	matched := func(ol QualifiedOverload, schema string) bool {
-		return schema == ol.Schema && (paramTypes == nil || ol.params().Match(paramTypes))
+		if schema != ol.Schema {
+			return false
+		}
+		if paramTypes == nil {
+			return true
+		}
+		if ol.IsUDF {
+			return matchUDFSignature(ol.params(), paramTypes)
+		}
+		return ol.params().Match(paramTypes)
 	}
 	typeNames := func() string {
 		ns := make([]string, len(paramTypes))
@@ -177,14 +177,16 @@ const (
 	ResolveRequireViewDesc
 	ResolveRequireTableOrViewDesc
 	ResolveRequireSequenceDesc
+	ResolveRequireForeignTableDesc
 )
 
 var requiredTypeNames = [...]string{
-	ResolveAnyTableKind:           "any",
-	ResolveRequireTableDesc:       "table",
-	ResolveRequireViewDesc:        "view",
-	ResolveRequireTableOrViewDesc: "table or view",
-	ResolveRequireSequenceDesc:    "sequence",
+	ResolveAnyTableKind:            "any",
+	ResolveRequireTableDesc:        "table",
+	ResolveRequireViewDesc:         "view",
+	ResolveRequireTableOrViewDesc:  "table or view",
+	ResolveRequireSequenceDesc:     "sequence",
+	ResolveRequireForeignTableDesc: "foreign table",
 }
 
 func (r RequiredTableKind) String() string {
@@ -188,9 +188,23 @@ type Overload struct {
 	UDFContainsOnlySignature bool
 	// Body is the SQL string body of a user-defined function.
 	Body string
+	// Language is the language of Body.
+	Language FunctionLanguage
 	// ReturnSet is set to true when a user-defined function is defined to return
 	// a set of values.
 	ReturnSet bool
+	// IsProcedure is set to true when this is the overload of a user-defined
+	// procedure. Procedures can only be invoked with CALL.
+	IsProcedure bool
+	// ParamClasses are the classes of the parameters of a user-defined
+	// procedure. A CALL of a procedure with OUT or INOUT parameters returns a
+	// row with their values. It is nil if all of the parameters are IN or
+	// VARIADIC parameters, and for overloads which only contain the signature.
+	ParamClasses []FuncParamClass
+	// UDFAggregate is set when this is the overload of a user-defined
+	// aggregate, i.e. one created with CREATE AGGREGATE. It is nil for
+	// overloads which only contain the signature.
+	UDFAggregate *UDFAggregate
 }
 
 // params implements the overloadImpl interface.
@@ -301,6 +315,7 @@ type TypeList interface {
 var _ TypeList = ParamTypes{}
 var _ TypeList = HomogeneousType{}
 var _ TypeList = VariadicType{}
+var _ TypeList = VariadicParamTypes{}
 
 // ParamTypes is a list of function parameter names and their types.
 type ParamTypes []ParamType
@@ -487,6 +502,192 @@ func (v VariadicType) String() string {
 	return s.String()
 }
 
+// VariadicParamTypes is the list of parameters of a user-defined function
+// whose last parameter is declared VARIADIC. The last parameter has an array
+// type, and one or more arguments of the element type of the array are passed
+// in its place.
+type VariadicParamTypes struct {
+	ParamTypes
+}
+
+// Match is part of the TypeList interface.
+func (v VariadicParamTypes) Match(types []*types.T) bool {
+	if !v.MatchLen(len(types)) {
+		return false
+	}
+	for i := range types {
+		if !v.MatchAt(types[i], i) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchAt is part of the TypeList interface.
+func (v VariadicParamTypes) MatchAt(typ *types.T, i int) bool {
+	if i < len(v.ParamTypes)-1 {
+		return v.ParamTypes.MatchAt(typ, i)
+	}
+	return typ.Family() == types.UnknownFamily || v.GetAt(i).Equivalent(typ)
+}
+
+// MatchLen is part of the TypeList interface.
+func (v VariadicParamTypes) MatchLen(l int) bool {
+	return l >= len(v.ParamTypes)
+}
+
+// GetAt is part of the TypeList interface.
+func (v VariadicParamTypes) GetAt(i int) *types.T {
+	if n := len(v.ParamTypes) - 1; i >= n {
+		return v.ParamTypes[n].Typ.ArrayContents()
+	}
+	return v.ParamTypes[i].Typ
+}
+
+// Types is part of the TypeList interface.
+func (v VariadicParamTypes) Types() []*types.T {
+	ret := v.ParamTypes.Types()
+	ret[len(ret)-1] = ret[len(ret)-1].ArrayContents()
+	return ret
+}
+
+func (v VariadicParamTypes) String() string {
+	var s strings.Builder
+	for i, param := range v.ParamTypes {
+		if i > 0 {
+			s.WriteString(", ")
+		}
+		s.WriteString(param.Name)
+		s.WriteString(": ")
+		if i == len(v.ParamTypes)-1 {
+			s.WriteString("VARIADIC ")
+		}
+		s.WriteString(param.Typ.String())
+	}
+	return s.String()
+}
+
+// MakeUDFParamTypes returns the TypeList of the parameters of a user-defined
+// function. If isVariadic is true, the last parameter is VARIADIC.
+func MakeUDFParamTypes(params ParamTypes, isVariadic bool) TypeList {
+	if isVariadic && len(params) > 0 {
+		return VariadicParamTypes{ParamTypes: params}
+	}
+	return params
+}
+
+// variadicArrayOverload returns a copy of the overload of a user-defined
+// function with a VARIADIC parameter in which that parameter takes an array,
+// as in the call f(VARIADIC ARRAY[1, 2]). It returns nil if the function does
+// not have a VARIADIC parameter.
+func (b *Overload) variadicArrayOverload() *Overload {
+	v, ok := b.Types.(VariadicParamTypes)
+	if !ok {
+		return nil
+	}
+	ret := *b
+	ret.Types = v.ParamTypes
+	returnType := b.ReturnType
+	ret.ReturnType = func(args []TypedExpr) *types.T {
+		n := len(v.ParamTypes) - 1
+		if len(args) != n+1 {
+			return UnknownReturnType
+		}
+		// The return type of the function is resolved from the type of the
+		// elements of the array, as if they were passed as separate arguments.
+		elemArgs := make([]TypedExpr, n+1)
+		copy(elemArgs, args[:n])
+		elemArgs[n] = DNull
+		if typ := args[n].ResolvedType(); typ.Family() == types.ArrayFamily {
+			elemArgs[n] = NewTypedCastExpr(DNull, typ.ArrayContents())
+		}
+		return returnType(elemArgs)
+	}
+	return &ret
+}
+
+// IsPolymorphicType returns true if typ is one of the polymorphic types
+// anyelement and anyarray, which are resolved to concrete types from the
+// arguments of each call of a user-defined function.
+func IsPolymorphicType(typ *types.T) bool {
+	switch typ.Family() {
+	case types.AnyFamily:
+		return true
+	case types.ArrayFamily:
+		return typ.ArrayContents().Family() == types.AnyFamily
+	}
+	return false
+}
+
+// ResolvePolymorphicType returns the type that the anyelement parameters of a
+// user-defined function with the given parameters take in a call with
+// arguments of the given types. The element type of the arrays passed to the
+// anyarray parameters must be the same type. It returns nil if the type cannot
+// be determined, for example because all the polymorphic arguments are NULL.
+func ResolvePolymorphicType(params TypeList, argTypes []*types.T) (*types.T, error) {
+	var elemType *types.T
+	for i, argType := range argTypes {
+		paramType := params.GetAt(i)
+		if paramType == nil || !IsPolymorphicType(paramType) {
+			continue
+		}
+		if argType.Family() == types.UnknownFamily {
+			continue
+		}
+		typ := argType
+		if paramType.Family() == types.ArrayFamily {
+			if argType.Family() != types.ArrayFamily {
+				return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+					"argument declared anyarray is not an array but type %s", argType.SQLStandardName())
+			}
+			if typ = argType.ArrayContents(); typ.Family() == types.UnknownFamily {
+				continue
+			}
+		}
+		if elemType == nil {
+			elemType = typ
+		} else if !elemType.Equivalent(typ) {
+			return nil, pgerror.New(pgcode.DatatypeMismatch,
+				`arguments declared "anyelement" are not all alike`)
+		}
+	}
+	return elemType, nil
+}
+
+// InstantiatePolymorphicType returns the concrete type that the type typ of a
+// parameter or of the result of a user-defined function takes when its
+// anyelement parameters take the type elemType.
+func InstantiatePolymorphicType(typ, elemType *types.T) *types.T {
+	switch {
+	case !IsPolymorphicType(typ):
+		return typ
+	case typ.Family() == types.ArrayFamily:
+		return types.MakeArray(elemType)
+	default:
+		return elemType
+	}
+}
+
+// PolymorphicReturnType returns a ReturnTyper for a user-defined function
+// with the given parameters and return type. If the return type is
+// polymorphic, it is resolved from the types of the arguments.
+func PolymorphicReturnType(params TypeList, retType *types.T) ReturnTyper {
+	if !IsPolymorphicType(retType) {
+		return FixedReturnType(retType)
+	}
+	return func(args []TypedExpr) *types.T {
+		argTypes := make([]*types.T, len(args))
+		for i := range args {
+			argTypes[i] = args[i].ResolvedType()
+		}
+		elemType, err := ResolvePolymorphicType(params, argTypes)
+		if err != nil || elemType == nil {
+			return UnknownReturnType
+		}
+		return InstantiatePolymorphicType(retType, elemType)
+	}
+}
+
 // UnknownReturnType is returned from ReturnTypers when the arguments provided are
 // not sufficient to determine a return type. This is necessary for cases like overload
 // resolution, where the argument types are not resolved yet so the type-level function
@@ -1313,6 +1514,9 @@ func getFuncSig(expr *FuncExpr, typedInputExprs []TypedExpr, desiredType *types.
 	for _, expr := range typedInputExprs {
 		typeNames = append(typeNames, expr.ResolvedType().String())
 	}
+	if expr.Variadic && len(typeNames) > 0 {
+		typeNames[len(typeNames)-1] = "VARIADIC " + typeNames[len(typeNames)-1]
+	}
 	var desStr string
 	if desiredType.Family() != types.AnyFamily {
 		desStr = fmt.Sprintf(" (desired <%s>)", desiredType)
@@ -0,0 +1,228 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func malformedRangeError(s string, detail string) error {
+	return errors.WithDetail(
+		pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed range literal: %q", s),
+		detail,
+	)
+}
+
+func malformedMultirangeError(s string, detail string) error {
+	return errors.WithDetail(
+		pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed multirange literal: %q", s),
+		detail,
+	)
+}
+
+// ParseDRangeFromString parses the string-form of a range of the given type,
+// for example "[1,10)" or "empty".
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseTimeContext (either for the time or the local timezone).
+func ParseDRangeFromString(
+	ctx ParseTimeContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	ret, dependsOnContext, err := doParseDRangeFromString(ctx, s, t)
+	if err != nil {
+		return nil, false, MakeParseError(s, t, err)
+	}
+	return ret, dependsOnContext, nil
+}
+
+// doParseDRangeFromString does most of the work of ParseDRangeFromString,
+// except the error it returns isn't prettified as a parsing error.
+func doParseDRangeFromString(
+	ctx ParseTimeContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	in := strings.TrimLeft(s, " \t\n\r\v\f")
+	if strings.EqualFold(strings.TrimRight(in, " \t\n\r\v\f"), "empty") {
+		return NewDEmptyRange(t), false, nil
+	}
+	if len(in) == 0 || (in[0] != '[' && in[0] != '(') {
+		return nil, false, malformedRangeError(s, "Missing left parenthesis or bracket.")
+	}
+	lowerInc := in[0] == '['
+	in = in[1:]
+
+	lowerStr, lowerInf, in := parseRangeBound(in)
+	if len(in) == 0 || in[0] != ',' {
+		return nil, false, malformedRangeError(s, "Missing comma after lower bound.")
+	}
+	in = in[1:]
+	upperStr, upperInf, in := parseRangeBound(in)
+	if len(in) == 0 {
+		return nil, false, malformedRangeError(s, "Missing right parenthesis or bracket.")
+	}
+	if in[0] == ',' {
+		return nil, false, malformedRangeError(s, "Too many commas.")
+	}
+	upperInc := in[0] == ']'
+	if strings.TrimLeft(in[1:], " \t\n\r\v\f") != "" {
+		return nil, false, malformedRangeError(s, "Junk after right parenthesis or bracket.")
+	}
+
+	var lower, upper RangeBound
+	if !lowerInf {
+		d, dep, err := ParseAndRequireString(t.RangeContents(), lowerStr, ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		lower = RangeBound{Val: d, Inclusive: lowerInc}
+		dependsOnContext = dependsOnContext || dep
+	}
+	if !upperInf {
+		d, dep, err := ParseAndRequireString(t.RangeContents(), upperStr, ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		upper = RangeBound{Val: d, Inclusive: upperInc}
+		dependsOnContext = dependsOnContext || dep
+	}
+	r, err := NewDRange(rangeBoundCompareContext{}, t, lower, upper)
+	return r, dependsOnContext, err
+}
+
+// parseRangeBound parses a bound of a range literal up to the next comma or
+// closing parenthesis or bracket which is not quoted. Double quotes are
+// removed, a pair of double quotes inside a quoted string is interpreted as a
+// single double quote, and backslashes escape the following character. An
+// empty, unquoted bound means that the bound is infinite.
+func parseRangeBound(in string) (bound string, infinite bool, rest string) {
+	if len(in) > 0 && (in[0] == ',' || in[0] == ')' || in[0] == ']') {
+		return "", true, in
+	}
+	var b strings.Builder
+	inQuote := false
+	i := 0
+	for ; i < len(in); i++ {
+		ch := in[i]
+		if !inQuote && (ch == ',' || ch == ')' || ch == ']') {
+			break
+		}
+		switch {
+		case ch == '\\':
+			i++
+			if i < len(in) {
+				b.WriteByte(in[i])
+			}
+		case ch == '"':
+			if inQuote && i+1 < len(in) && in[i+1] == '"' {
+				b.WriteByte('"')
+				i++
+			} else {
+				inQuote = !inQuote
+			}
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String(), false, in[i:]
+}
+
+// ParseDMultirangeFromString parses the string-form of a multirange of the
+// given type, for example "{[1,3), [5,7)}".
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseTimeContext (either for the time or the local timezone).
+func ParseDMultirangeFromString(
+	ctx ParseTimeContext, s string, t *types.T,
+) (_ *DMultirange, dependsOnContext bool, _ error) {
+	ret, dependsOnContext, err := doParseDMultirangeFromString(ctx, s, t)
+	if err != nil {
+		return nil, false, MakeParseError(s, t, err)
+	}
+	return ret, dependsOnContext, nil
+}
+
+// doParseDMultirangeFromString does most of the work of
+// ParseDMultirangeFromString, except the error it returns isn't prettified as
+// a parsing error.
+func doParseDMultirangeFromString(
+	ctx ParseTimeContext, s string, t *types.T,
+) (_ *DMultirange, dependsOnContext bool, _ error) {
+	in := strings.TrimLeft(s, " \t\n\r\v\f")
+	if len(in) == 0 || in[0] != '{' {
+		return nil, false, malformedMultirangeError(s, "Missing left brace.")
+	}
+	in = strings.TrimLeft(in[1:], " \t\n\r\v\f")
+	rangeTyp := types.MultirangeRange(t)
+	var ranges []*DRange
+	if len(in) > 0 && in[0] == '}' {
+		in = in[1:]
+	} else {
+		for {
+			// Find the end of the next range literal, skipping over any quoted
+			// or escaped characters.
+			i := 0
+			if len(in) >= len("empty") && strings.EqualFold(in[:len("empty")], "empty") {
+				i = len("empty")
+			} else {
+				inQuote := false
+				for ; i < len(in); i++ {
+					ch := in[i]
+					if ch == '\\' {
+						i++
+						continue
+					}
+					if ch == '"' {
+						inQuote = !inQuote
+						continue
+					}
+					if !inQuote && i > 0 && (ch == ')' || ch == ']') {
+						i++
+						break
+					}
+					if !inQuote && (ch == '{' || ch == '}') {
+						return nil, false, malformedMultirangeError(s, "Unexpected character.")
+					}
+				}
+			}
+			if i == 0 || i > len(in) {
+				return nil, false, malformedMultirangeError(s, "Unexpected end of input.")
+			}
+			r, dep, err := doParseDRangeFromString(ctx, in[:i], rangeTyp)
+			if err != nil {
+				return nil, false, err
+			}
+			dependsOnContext = dependsOnContext || dep
+			ranges = append(ranges, r)
+
+			in = strings.TrimLeft(in[i:], " \t\n\r\v\f")
+			if len(in) == 0 {
+				return nil, false, malformedMultirangeError(s, "Unexpected end of input.")
+			}
+			if in[0] == '}' {
+				in = in[1:]
+				break
+			}
+			if in[0] != ',' {
+				return nil, false, malformedMultirangeError(s, "Expected comma or end of multirange.")
+			}
+			in = strings.TrimLeft(in[1:], " \t\n\r\v\f")
+		}
+	}
+	if strings.TrimLeft(in, " \t\n\r\v\f") != "" {
+		return nil, false, malformedMultirangeError(s, "Junk after closing right brace.")
+	}
+	m, err := NewDMultirange(rangeBoundCompareContext{}, t, ranges)
+	return m, dependsOnContext, err
+}
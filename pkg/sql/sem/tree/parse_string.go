@@ -95,6 +95,14 @@ func ParseAndRequireString(
 		if err == nil {
 			d = NewDEnum(e)
 		}
+	case types.JsonpathFamily:
+		d, err = ParseDJsonpath(s)
+	case types.PGVectorFamily:
+		d, err = ParseDPGVector(s)
+	case types.RangeFamily:
+		d, dependsOnContext, err = ParseDRangeFromString(ctx, s, t)
+	case types.MultirangeFamily:
+		d, dependsOnContext, err = ParseDMultirangeFromString(ctx, s, t)
 	case types.TSQueryFamily:
 		d, err = ParseDTSQuery(s)
 	case types.TSVectorFamily:
@@ -167,7 +167,63 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 	}
 }
 
-var tupleQuoteSet, arrayQuoteSet asciiSet
+func (d *DRange) pgwireFormat(ctx *FmtCtx) {
+	if d.Empty {
+		ctx.WriteString("empty")
+		return
+	}
+	if d.Lower.Inclusive {
+		ctx.WriteByte('[')
+	} else {
+		ctx.WriteByte('(')
+	}
+	if !d.Lower.IsInfinite() {
+		s := AsStringWithFlags(d.Lower.Val, ctx.flags, FmtDataConversionConfig(ctx.dataConversionConfig))
+		pgwireFormatStringInRange(&ctx.Buffer, s)
+	}
+	ctx.WriteByte(',')
+	if !d.Upper.IsInfinite() {
+		s := AsStringWithFlags(d.Upper.Val, ctx.flags, FmtDataConversionConfig(ctx.dataConversionConfig))
+		pgwireFormatStringInRange(&ctx.Buffer, s)
+	}
+	if d.Upper.Inclusive {
+		ctx.WriteByte(']')
+	} else {
+		ctx.WriteByte(')')
+	}
+}
+
+func pgwireFormatStringInRange(buf *bytes.Buffer, in string) {
+	// Range bounds are quoted if they contain any of the characters which are
+	// special in range literals. Like in tuples, double quotes and backslashes
+	// are doubled.
+	quote := in == "" || rangeQuoteSet.in(in)
+	if quote {
+		buf.WriteByte('"')
+	}
+	for _, r := range in {
+		if r == '"' || r == '\\' {
+			buf.WriteByte(byte(r))
+		}
+		buf.WriteRune(r)
+	}
+	if quote {
+		buf.WriteByte('"')
+	}
+}
+
+func (d *DMultirange) pgwireFormat(ctx *FmtCtx) {
+	ctx.WriteByte('{')
+	for i, r := range d.Ranges {
+		if i > 0 {
+			ctx.WriteByte(',')
+		}
+		r.pgwireFormat(ctx)
+	}
+	ctx.WriteByte('}')
+}
+
+var tupleQuoteSet, arrayQuoteSet, rangeQuoteSet asciiSet
 
 func init() {
 	var ok bool
@@ -179,6 +235,10 @@ func init() {
 	if !ok {
 		panic("array asciiset")
 	}
+	rangeQuoteSet, ok = makeASCIISet(" \t\v\f\r\n()[],\"\\")
+	if !ok {
+		panic("range asciiset")
+	}
 }
 
 // PgwireFormatFloat returns a []byte representing a float according to
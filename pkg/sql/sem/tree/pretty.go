@@ -690,6 +690,12 @@ func (node *Subquery) doc(p *PrettyCfg) pretty.Doc {
 
 func (node *AliasedTableExpr) doc(p *PrettyCfg) pretty.Doc {
 	d := p.Doc(node.Expr)
+	if node.Only {
+		d = pretty.Concat(
+			p.keywordWithText("", "ONLY", " "),
+			d,
+		)
+	}
 	if node.Lateral {
 		d = pretty.Concat(
 			p.keywordWithText("", "LATERAL", " "),
@@ -725,6 +731,15 @@ func (node *FuncExpr) doc(p *PrettyCfg) pretty.Doc {
 
 	if len(node.Exprs) > 0 {
 		args := node.Exprs.doc(p)
+		if n := len(node.Exprs) - 1; node.Variadic {
+			variadic := pretty.ConcatSpace(pretty.Keyword("VARIADIC"), p.Doc(node.Exprs[n]))
+			if n == 0 {
+				args = variadic
+			} else {
+				prefix := node.Exprs[:n]
+				args = p.commaSeparated(prefix.doc(p), variadic)
+			}
+		}
 		if node.Type != 0 {
 			args = pretty.ConcatLine(
 				pretty.Text(funcTypeName[node.Type]),
@@ -1273,6 +1288,15 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	if node.As() {
 		clauses = append(clauses, p.Doc(node.AsSource))
 	}
+	if len(node.Inherits) > 0 {
+		clauses = append(
+			clauses,
+			pretty.ConcatSpace(
+				pretty.Keyword("INHERITS"),
+				p.bracket("(", p.Doc(&node.Inherits), ")"),
+			),
+		)
+	}
 	if node.PartitionByTable != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByTable))
 	}
@@ -1310,6 +1334,9 @@ func (node *CreateView) doc(p *PrettyCfg) pretty.Doc {
 	if node.Materialized {
 		title = pretty.ConcatSpace(title, pretty.Keyword("MATERIALIZED"))
 	}
+	if node.Recursive {
+		title = pretty.ConcatSpace(title, pretty.Keyword("RECURSIVE"))
+	}
 	title = pretty.ConcatSpace(title, pretty.Keyword("VIEW"))
 	if node.IfNotExists {
 		title = pretty.ConcatSpace(title, pretty.Keyword("IF NOT EXISTS"))
@@ -1324,6 +1351,15 @@ func (node *CreateView) doc(p *PrettyCfg) pretty.Doc {
 			p.bracket("(", p.Doc(&node.ColumnNames), ")"),
 		)
 	}
+	if node.StorageParams != nil {
+		d = pretty.ConcatSpace(
+			d,
+			pretty.ConcatSpace(
+				pretty.Keyword(`WITH`),
+				p.bracket(`(`, p.Doc(&node.StorageParams), `)`),
+			),
+		)
+	}
 	d = p.nestUnder(
 		pretty.ConcatSpace(d, pretty.Keyword("AS")),
 		p.Doc(node.AsSource),
@@ -1757,6 +1793,7 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
+	//    [DEFERRABLE ...]
 	//    [WHERE ...]
 	//    [NOT VISIBLE]
 	//
@@ -1766,6 +1803,7 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
+	//    [DEFERRABLE ...]
 	//    [WHERE ...]
 	//    [NOT VISIBLE]
 	//
@@ -1797,6 +1835,9 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionByIndex != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByIndex))
 	}
+	if node.Deferrable != ConstraintNotDeferrable {
+		clauses = append(clauses, pretty.Keyword(node.Deferrable.String()))
+	}
 	if node.Predicate != nil {
 		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
 	}
@@ -1817,6 +1858,7 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl (...)
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
 	// or (no constraint name):
 	//
@@ -1824,6 +1866,7 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl [(...)]
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
 	clauses := make([]pretty.Doc, 0, 4)
 	title := pretty.ConcatSpace(
@@ -1850,6 +1893,10 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 		clauses = append(clauses, actions)
 	}
 
+	if node.Deferrable != ConstraintNotDeferrable {
+		clauses = append(clauses, pretty.Keyword(node.Deferrable.String()))
+	}
+
 	return p.nestUnder(title, pretty.Group(pretty.Stack(clauses...)))
 }
 
@@ -33,6 +33,11 @@ type RoutinePlan interface{}
 // avoid import cycles.
 type RoutineExecFactory interface{}
 
+// RoutinePLpgSQLBody is the body of a routine written in PL/pgSQL. It
+// currently maps to *memo.PLpgSQLBody. We use the empty interface here rather
+// than *memo.PLpgSQLBody to avoid import cycles.
+type RoutinePLpgSQLBody interface{}
+
 // RoutineExpr represents sequential execution of multiple statements. For
 // example, it is used to represent execution of statements in the body of a
 // user-defined function. It is only created by execbuilder - it is never
@@ -61,6 +66,21 @@ type RoutineExpr struct {
 	// routine will see a snapshot of the data as of the start of the statement
 	// invoking the routine.
 	EnableStepping bool
+
+	// PLpgSQLBody is the body of the routine if it is written in PL/pgSQL. If
+	// set, the body is interpreted, and PlanFn plans the statements built for
+	// the expressions and SQL statements embedded in the body as they are
+	// reached.
+	PLpgSQLBody RoutinePLpgSQLBody
+
+	// ParamNames and ParamTypes are the names and types of the parameters of a
+	// PL/pgSQL routine. The name of an unnamed parameter is empty.
+	ParamNames []string
+	ParamTypes []*types.T
+
+	// Trigger is true if the routine is a PL/pgSQL function executed by a
+	// trigger.
+	Trigger bool
 }
 
 // NewTypedRoutineExpr returns a new RoutineExpr that is well-typed.
@@ -572,7 +572,10 @@ type AliasedTableExpr struct {
 	IndexFlags *IndexFlags
 	Ordinality bool
 	Lateral    bool
-	As         AliasClause
+	// Only is true if the table was qualified with ONLY, in which case the
+	// tables that inherit from it are not scanned.
+	Only bool
+	As   AliasClause
 }
 
 // Format implements the NodeFormatter interface.
@@ -580,6 +583,9 @@ func (node *AliasedTableExpr) Format(ctx *FmtCtx) {
 	if node.Lateral {
 		ctx.WriteString("LATERAL ")
 	}
+	if node.Only {
+		ctx.WriteString("ONLY ")
+	}
 	ctx.FormatNode(node.Expr)
 	if node.IndexFlags != nil {
 		ctx.FormatNode(node.IndexFlags)
@@ -758,6 +764,47 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingSetType is the kind of a GroupingSet.
+type GroupingSetType int
+
+// The values for GroupingSetType.
+const (
+	// RollupGroupingSet is ROLLUP (e1, ..., en).
+	RollupGroupingSet GroupingSetType = iota
+	// CubeGroupingSet is CUBE (e1, ..., en).
+	CubeGroupingSet
+	// GroupingSetsGroupingSet is GROUPING SETS (s1, ..., sn).
+	GroupingSetsGroupingSet
+)
+
+var groupingSetTypeName = [...]string{
+	RollupGroupingSet:       "ROLLUP",
+	CubeGroupingSet:         "CUBE",
+	GroupingSetsGroupingSet: "GROUPING SETS",
+}
+
+func (t GroupingSetType) String() string {
+	return groupingSetTypeName[t]
+}
+
+// GroupingSet represents a ROLLUP, CUBE or GROUPING SETS item in a GROUP BY
+// clause. It can only appear in a GroupBy, or nested within another
+// GroupingSet of type GroupingSetsGroupingSet. A Tuple without the ROW keyword
+// within a GroupingSet denotes a composite element, and the empty Tuple
+// denotes the empty grouping set.
+type GroupingSet struct {
+	Type  GroupingSetType
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.Type.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
@@ -70,6 +70,7 @@ const (
 	JSONAllExists
 	Overlaps
 	TSMatches
+	JSONPathExists
 
 	// The following operators will always be used with an associated SubOperator.
 	// If Go had algebraic data types they would be defined in a self-contained
@@ -122,6 +123,7 @@ var comparisonOpName = [...]string{
 	JSONAllExists:     "?&",
 	Overlaps:          "&&",
 	TSMatches:         "@@",
+	JSONPathExists:    "@?",
 	Any:               "ANY",
 	Some:              "SOME",
 	All:               "ALL",
@@ -134,6 +136,18 @@ func (i ComparisonOperatorSymbol) String() string {
 	return comparisonOpName[i]
 }
 
+// LookupComparisonOperatorSymbol returns the comparison operator symbol with
+// the given name (as returned by ComparisonOperatorSymbol.String), and false if
+// there is no such operator.
+func LookupComparisonOperatorSymbol(name string) (ComparisonOperatorSymbol, bool) {
+	for i := range comparisonOpName {
+		if comparisonOpName[i] == name {
+			return ComparisonOperatorSymbol(i), true
+		}
+	}
+	return 0, false
+}
+
 // HasSubOperator returns if the ComparisonOperator is used with a sub-operator.
 func (i ComparisonOperatorSymbol) HasSubOperator() bool {
 	switch i {
@@ -0,0 +1,192 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Replace    bool
+	Name       Name
+	ActionTime TriggerActionTime
+	Events     TriggerEvents
+	TableName  *UnresolvedObjectName
+	ForEach    TriggerForEach
+	When       Expr
+	FuncName   FunctionName
+	FuncArgs   []string
+}
+
+var _ Statement = &CreateTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.FormatNode(node.ActionTime)
+	ctx.WriteByte(' ')
+	ctx.FormatNode(node.Events)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	ctx.WriteByte(' ')
+	ctx.FormatNode(node.ForEach)
+	if node.When != nil {
+		ctx.WriteString(" WHEN (")
+		ctx.FormatNode(node.When)
+		ctx.WriteByte(')')
+	}
+	ctx.WriteString(" EXECUTE FUNCTION ")
+	ctx.FormatNode(&node.FuncName)
+	ctx.WriteByte('(')
+	for i, arg := range node.FuncArgs {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, arg, ctx.flags.EncodeFlags())
+		}
+	}
+	ctx.WriteByte(')')
+}
+
+// TriggerActionTime describes when a trigger fires relative to the event
+// that caused it.
+type TriggerActionTime int
+
+const (
+	// TriggerActionTimeBefore fires the trigger before the operation is
+	// attempted.
+	TriggerActionTimeBefore TriggerActionTime = iota
+	// TriggerActionTimeAfter fires the trigger after the operation has
+	// completed.
+	TriggerActionTimeAfter
+	// TriggerActionTimeInsteadOf fires the trigger in place of the operation.
+	// It is only valid for triggers on views.
+	TriggerActionTimeInsteadOf
+)
+
+// Format implements the NodeFormatter interface.
+func (node TriggerActionTime) Format(ctx *FmtCtx) {
+	switch node {
+	case TriggerActionTimeBefore:
+		ctx.WriteString("BEFORE")
+	case TriggerActionTimeAfter:
+		ctx.WriteString("AFTER")
+	case TriggerActionTimeInsteadOf:
+		ctx.WriteString("INSTEAD OF")
+	}
+}
+
+// TriggerEventType is the type of operation that fires a trigger.
+type TriggerEventType int
+
+const (
+	// TriggerEventInsert fires the trigger on INSERT.
+	TriggerEventInsert TriggerEventType = iota
+	// TriggerEventUpdate fires the trigger on UPDATE.
+	TriggerEventUpdate
+	// TriggerEventDelete fires the trigger on DELETE.
+	TriggerEventDelete
+	// TriggerEventTruncate fires the trigger on TRUNCATE.
+	TriggerEventTruncate
+)
+
+// TriggerEvent is an operation that fires a trigger. Columns is only set for
+// UPDATE OF events.
+type TriggerEvent struct {
+	EventType TriggerEventType
+	Columns   NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerEvent) Format(ctx *FmtCtx) {
+	switch node.EventType {
+	case TriggerEventInsert:
+		ctx.WriteString("INSERT")
+	case TriggerEventUpdate:
+		ctx.WriteString("UPDATE")
+		if len(node.Columns) > 0 {
+			ctx.WriteString(" OF ")
+			ctx.FormatNode(&node.Columns)
+		}
+	case TriggerEventDelete:
+		ctx.WriteString("DELETE")
+	case TriggerEventTruncate:
+		ctx.WriteString("TRUNCATE")
+	}
+}
+
+// TriggerEvents is a list of events, any of which fires a trigger.
+type TriggerEvents []*TriggerEvent
+
+// Format implements the NodeFormatter interface.
+func (node TriggerEvents) Format(ctx *FmtCtx) {
+	for i, event := range node {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.FormatNode(event)
+	}
+}
+
+// TriggerForEach describes whether a trigger fires once per modified row or
+// once per statement.
+type TriggerForEach int
+
+const (
+	// TriggerForEachStatement fires the trigger once per statement. This is
+	// the default if FOR EACH is not specified.
+	TriggerForEachStatement TriggerForEach = iota
+	// TriggerForEachRow fires the trigger once for every modified row.
+	TriggerForEachRow
+)
+
+// Format implements the NodeFormatter interface.
+func (node TriggerForEach) Format(ctx *FmtCtx) {
+	switch node {
+	case TriggerForEachStatement:
+		ctx.WriteString("FOR EACH STATEMENT")
+	case TriggerForEachRow:
+		ctx.WriteString("FOR EACH ROW")
+	}
+}
+
+// DropTrigger represents a DROP TRIGGER statement.
+type DropTrigger struct {
+	IfExists     bool
+	Name         Name
+	TableName    *UnresolvedObjectName
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TRIGGER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
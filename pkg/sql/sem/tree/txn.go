@@ -25,17 +25,25 @@ type IsolationLevel int
 const (
 	UnspecifiedIsolation IsolationLevel = iota
 	SerializableIsolation
+	ReadCommittedIsolation
 )
 
 var isolationLevelNames = [...]string{
-	UnspecifiedIsolation:  "UNSPECIFIED",
-	SerializableIsolation: "SERIALIZABLE",
+	UnspecifiedIsolation:   "UNSPECIFIED",
+	SerializableIsolation:  "SERIALIZABLE",
+	ReadCommittedIsolation: "READ COMMITTED",
 }
 
 // IsolationLevelMap is a map from string isolation level name to isolation
-// level, in the lowercase format that set isolation_level supports.
+// level, in the lowercase format that set isolation_level supports. As in
+// PostgreSQL, READ UNCOMMITTED behaves like READ COMMITTED, and the isolation
+// levels between READ COMMITTED and SERIALIZABLE are upgraded to SERIALIZABLE.
 var IsolationLevelMap = map[string]IsolationLevel{
-	"serializable": SerializableIsolation,
+	"read uncommitted": ReadCommittedIsolation,
+	"read committed":   ReadCommittedIsolation,
+	"snapshot":         SerializableIsolation,
+	"repeatable read":  SerializableIsolation,
+	"serializable":     SerializableIsolation,
 }
 
 func (i IsolationLevel) String() string {
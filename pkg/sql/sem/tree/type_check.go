@@ -541,25 +541,36 @@ func (expr *CastExpr) TypeCheck(
 		return nil, err
 	}
 	expr.Type = exprType
+	// Casts to domains must be evaluated in order to check the domain's
+	// constraints, so the child is typed with the domain's base type instead of
+	// the domain itself.
+	childType := exprType
 	canElideCast := true
+	if exprType.IsDomain() {
+		childType = exprType.DomainBaseType()
+		canElideCast = false
+	} else if exprType.Family() == types.ArrayFamily && exprType.ArrayContents().IsDomain() {
+		childType = types.MakeArray(exprType.ArrayContents().DomainBaseType())
+		canElideCast = false
+	}
 	switch {
 	case isConstant(expr.Expr):
 		c := expr.Expr.(Constant)
-		if canConstantBecome(c, exprType) {
+		if canConstantBecome(c, childType) {
 			// If a Constant is subject to a cast which it can naturally become (which
 			// is in its resolvable type set), we desire the cast's type for the
 			// Constant. In many cases, the CastExpr will then become a no-op and will
 			// be elided below. In other cases, the types may be equivalent but not
 			// Identical (e.g. string::char(2) or oid::regclass) and the CastExpr is
 			// still needed.
-			desired = exprType
+			desired = childType
 		}
 	case semaCtx.isUnresolvedPlaceholder(expr.Expr):
 		// If the placeholder has not yet been resolved, then we can make its
 		// expected type be the cast type. If it already has been resolved, but the
 		// type we gave it before is not compatible with the usage here, then
 		// type-checking will fail as desired.
-		desired = exprType
+		desired = childType
 	case isArrayExpr(expr.Expr):
 		// If we're going to cast to another array type, which is a common pattern
 		// in SQL (select array[]::int[], select array[$1]::int[]), use the cast
@@ -575,7 +586,7 @@ func (expr *CastExpr) TypeCheck(
 			if baseType, ok := types.OidToType[contents.Oid()]; ok && !baseType.Identical(contents) {
 				canElideCast = false
 			}
-			desired = exprType
+			desired = childType
 		}
 	}
 
@@ -1055,6 +1066,14 @@ func (expr *FuncExpr) TypeCheck(
 		return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
 			"%s()", def.Name)
 	}
+	if expr.Variadic {
+		def = def.variadicArrayOverloads()
+		if len(def.Overloads) == 0 {
+			return nil, pgerror.Newf(pgcode.UndefinedFunction,
+				"%s(): VARIADIC arguments can only be passed to user-defined functions with a VARIADIC parameter",
+				def.Name)
+		}
+	}
 
 	if semaCtx != nil {
 		// We'll need to remember we are in a function application to
@@ -1086,7 +1105,12 @@ func (expr *FuncExpr) TypeCheck(
 	}
 
 	var calledOnNullInputFns, notCalledOnNullInputFns intsets.Fast
+	hasUDFOverload := false
 	for _, idx := range s.overloadIdxs {
+		// The signatures of user-defined functions do not include their null
+		// input behavior, which is instead handled when the function body is
+		// built.
+		hasUDFOverload = hasUDFOverload || def.Overloads[idx].IsUDF
 		if def.Overloads[idx].CalledOnNullInput {
 			calledOnNullInputFns.Add(int(idx))
 		} else {
@@ -1135,10 +1159,10 @@ func (expr *FuncExpr) TypeCheck(
 	}
 
 	// Return NULL if at least one overload is possible, no overload accepts
-	// NULL arguments, the function isn't a generator or aggregate builtin, and
-	// NULL is given as an argument.
+	// NULL arguments, the function isn't a generator or aggregate builtin or a
+	// user-defined function, and NULL is given as an argument.
 	if len(s.overloadIdxs) > 0 && calledOnNullInputFns.Len() == 0 && funcCls != GeneratorClass &&
-		funcCls != AggregateClass {
+		funcCls != AggregateClass && !hasUDFOverload {
 		for _, expr := range s.typedExprs {
 			if expr.ResolvedType().Family() == types.UnknownFamily {
 				return DNull, nil
@@ -1170,6 +1194,9 @@ func (expr *FuncExpr) TypeCheck(
 		if err != nil {
 			return nil, err
 		}
+		if expr.Variadic {
+			overloadImpl = overloadImpl.variadicArrayOverload()
+		}
 	}
 
 	if expr.IsWindowFunctionApplication() {
@@ -1558,6 +1585,13 @@ func (expr DefaultVal) TypeCheck(
 	return nil, errInvalidDefaultUsage
 }
 
+// TypeCheck implements the Expr interface.
+func (expr *GroupingSet) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, pgerror.Newf(pgcode.Syntax, "%s can only appear in a GROUP BY clause", expr.Type)
+}
+
 // TypeCheck implements the Expr interface.
 func (expr PartitionMinVal) TypeCheck(
 	_ context.Context, _ *SemaContext, desired *types.T,
@@ -1870,12 +1904,36 @@ func (d *DJSON) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedE
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DJsonpath) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DMultirange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTSQuery) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DPGVector) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTSVector) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -14,11 +14,13 @@ import (
 	"context"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // ErrConflictingFunctionOption indicates that there are conflicting or
@@ -97,17 +99,24 @@ func (node *CreateFunction) Format(ctx *FmtCtx) {
 	if node.Replace {
 		ctx.WriteString("OR REPLACE ")
 	}
-	ctx.WriteString("FUNCTION ")
+	if node.IsProcedure {
+		ctx.WriteString("PROCEDURE ")
+	} else {
+		ctx.WriteString("FUNCTION ")
+	}
 	ctx.FormatNode(&node.FuncName)
 	ctx.WriteString("(")
 	ctx.FormatNode(node.Params)
 	ctx.WriteString(")\n\t")
-	ctx.WriteString("RETURNS ")
-	if node.ReturnType.IsSet {
-		ctx.WriteString("SETOF ")
+	// Procedures do not have a return type.
+	if !node.IsProcedure {
+		ctx.WriteString("RETURNS ")
+		if node.ReturnType.IsSet {
+			ctx.WriteString("SETOF ")
+		}
+		ctx.WriteString(node.ReturnType.Type.SQLString())
+		ctx.WriteString("\n\t")
 	}
-	ctx.WriteString(node.ReturnType.Type.SQLString())
-	ctx.WriteString("\n\t")
 	var funcBody FunctionBodyStr
 	for _, option := range node.Options {
 		switch t := option.(type) {
@@ -131,6 +140,116 @@ func (node *CreateFunction) Format(ctx *FmtCtx) {
 	}
 }
 
+// CreateAggregate represents a CREATE AGGREGATE statement.
+type CreateAggregate struct {
+	Replace  bool
+	FuncName FunctionName
+	Params   FuncParams
+	Options  AggregateOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("AGGREGATE ")
+	ctx.FormatNode(&node.FuncName)
+	ctx.WriteString("(")
+	ctx.FormatNode(node.Params)
+	ctx.WriteString(") (")
+	ctx.FormatNode(node.Options)
+	ctx.WriteString(")")
+}
+
+// AggregateOptions represent a list of aggregate options.
+type AggregateOptions []AggregateOption
+
+// Format implements the NodeFormatter interface.
+func (node AggregateOptions) Format(ctx *FmtCtx) {
+	for i, option := range node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(option)
+	}
+}
+
+// AggregateOption is an interface representing the properties of a
+// user-defined aggregate.
+type AggregateOption interface {
+	NodeFormatter
+	aggregateOption()
+}
+
+func (AggregateTransitionFunc) aggregateOption() {}
+func (AggregateStateType) aggregateOption()      {}
+func (AggregateFinalFunc) aggregateOption()      {}
+func (AggregateInitCond) aggregateOption()       {}
+
+// AggregateTransitionFunc represents the SFUNC option of an aggregate.
+type AggregateTransitionFunc struct {
+	Name FunctionName
+}
+
+// Format implements the NodeFormatter interface.
+func (node AggregateTransitionFunc) Format(ctx *FmtCtx) {
+	ctx.WriteString("SFUNC = ")
+	ctx.FormatNode(&node.Name)
+}
+
+// AggregateStateType represents the STYPE option of an aggregate.
+type AggregateStateType struct {
+	Type ResolvableTypeReference
+}
+
+// Format implements the NodeFormatter interface.
+func (node AggregateStateType) Format(ctx *FmtCtx) {
+	ctx.WriteString("STYPE = ")
+	ctx.FormatTypeReference(node.Type)
+}
+
+// AggregateFinalFunc represents the FINALFUNC option of an aggregate.
+type AggregateFinalFunc struct {
+	Name FunctionName
+}
+
+// Format implements the NodeFormatter interface.
+func (node AggregateFinalFunc) Format(ctx *FmtCtx) {
+	ctx.WriteString("FINALFUNC = ")
+	ctx.FormatNode(&node.Name)
+}
+
+// AggregateInitCond represents the INITCOND option of an aggregate.
+type AggregateInitCond string
+
+// Format implements the NodeFormatter interface.
+func (node AggregateInitCond) Format(ctx *FmtCtx) {
+	ctx.WriteString("INITCOND = ")
+	if ctx.flags.HasFlags(FmtHideConstants) {
+		ctx.WriteString("'_'")
+	} else {
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, string(node), ctx.flags.EncodeFlags())
+	}
+}
+
+// UDFAggregate describes the definition of a user-defined aggregate. It is
+// set on the Overload of every function created with CREATE AGGREGATE.
+type UDFAggregate struct {
+	// TransitionFunc is the OID of the function that computes the next state
+	// from the current state and the aggregated arguments.
+	TransitionFunc oid.Oid
+	// FinalFunc is the OID of the function that computes the result from the
+	// final state. It is zero if the state is returned as-is.
+	FinalFunc oid.Oid
+	// StateType is the type of the aggregate state.
+	StateType *types.T
+	// InitCond is the string representation of the initial state, or nil if
+	// the state is initially NULL.
+	InitCond *string
+}
+
 // RoutineBody represent a list of statements in a UDF body.
 type RoutineBody struct {
 	Stmts Statements
@@ -241,6 +360,8 @@ const (
 	_ FunctionLanguage = iota
 	// FunctionLangSQL represent SQL language.
 	FunctionLangSQL
+	// FunctionLangPLpgSQL represents the PL/pgSQL procedural language.
+	FunctionLangPLpgSQL
 )
 
 // Format implements the NodeFormatter interface.
@@ -249,6 +370,8 @@ func (node FunctionLanguage) Format(ctx *FmtCtx) {
 	switch node {
 	case FunctionLangSQL:
 		ctx.WriteString("SQL")
+	case FunctionLangPLpgSQL:
+		ctx.WriteString("plpgsql")
 	default:
 		panic(pgerror.New(pgcode.InvalidParameterValue, "Unknown function option"))
 	}
@@ -260,6 +383,8 @@ func AsFunctionLanguage(lang string) (FunctionLanguage, error) {
 	switch strings.ToLower(lang) {
 	case "sql":
 		return FunctionLangSQL, nil
+	case "plpgsql":
+		return FunctionLangPLpgSQL, nil
 	}
 	return 0, errors.Newf("language %q does not exist", lang)
 }
@@ -342,8 +467,11 @@ type FuncReturnType struct {
 	IsSet bool
 }
 
-// DropFunction represents a DROP FUNCTION statement.
+// DropFunction represents a DROP FUNCTION, DROP PROCEDURE or DROP AGGREGATE
+// statement.
 type DropFunction struct {
+	IsProcedure  bool
+	IsAggregate  bool
 	IfExists     bool
 	Functions    FuncObjs
 	DropBehavior DropBehavior
@@ -351,7 +479,13 @@ type DropFunction struct {
 
 // Format implements the NodeFormatter interface.
 func (node *DropFunction) Format(ctx *FmtCtx) {
-	ctx.WriteString("DROP FUNCTION ")
+	if node.IsProcedure {
+		ctx.WriteString("DROP PROCEDURE ")
+	} else if node.IsAggregate {
+		ctx.WriteString("DROP AGGREGATE ")
+	} else {
+		ctx.WriteString("DROP FUNCTION ")
+	}
 	if node.IfExists {
 		ctx.WriteString("IF EXISTS ")
 	}
@@ -362,6 +496,19 @@ func (node *DropFunction) Format(ctx *FmtCtx) {
 	}
 }
 
+// Call represents a CALL statement, which invokes a procedure.
+type Call struct {
+	Proc *FuncExpr
+}
+
+var _ Statement = &Call{}
+
+// Format implements the NodeFormatter interface.
+func (node *Call) Format(ctx *FmtCtx) {
+	ctx.WriteString("CALL ")
+	ctx.FormatNode(node.Proc)
+}
+
 // FuncObjs is a slice of FuncObj.
 type FuncObjs []FuncObj
 
@@ -426,43 +573,54 @@ func (node *AlterFunctionOptions) Format(ctx *FmtCtx) {
 	}
 }
 
-// AlterFunctionRename represents a ALTER FUNCTION...RENAME statement.
+func formatAlterRoutineKind(ctx *FmtCtx, isAggregate bool) {
+	if isAggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
+	} else {
+		ctx.WriteString("ALTER FUNCTION ")
+	}
+}
+
+// AlterFunctionRename represents a ALTER {FUNCTION|AGGREGATE}...RENAME statement.
 type AlterFunctionRename struct {
-	Function FuncObj
-	NewName  Name
+	Function    FuncObj
+	NewName     Name
+	IsAggregate bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionRename) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	formatAlterRoutineKind(ctx, node.IsAggregate)
 	ctx.FormatNode(node.Function)
 	ctx.WriteString(" RENAME TO ")
 	ctx.WriteString(string(node.NewName))
 }
 
-// AlterFunctionSetSchema represents a ALTER FUNCTION...SET SCHEMA statement.
+// AlterFunctionSetSchema represents a ALTER {FUNCTION|AGGREGATE}...SET SCHEMA statement.
 type AlterFunctionSetSchema struct {
 	Function      FuncObj
 	NewSchemaName Name
+	IsAggregate   bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionSetSchema) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	formatAlterRoutineKind(ctx, node.IsAggregate)
 	ctx.FormatNode(node.Function)
 	ctx.WriteString(" SET SCHEMA ")
 	ctx.WriteString(string(node.NewSchemaName))
 }
 
-// AlterFunctionSetOwner represents the ALTER FUNCTION...OWNER TO statement.
+// AlterFunctionSetOwner represents the ALTER {FUNCTION|AGGREGATE}...OWNER TO statement.
 type AlterFunctionSetOwner struct {
-	Function FuncObj
-	NewOwner RoleSpec
+	Function    FuncObj
+	NewOwner    RoleSpec
+	IsAggregate bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionSetOwner) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	formatAlterRoutineKind(ctx, node.IsAggregate)
 	ctx.FormatNode(node.Function)
 	ctx.WriteString(" OWNER TO ")
 	ctx.FormatNode(&node.NewOwner)
@@ -170,6 +170,9 @@ func ShowCreateTable(
 	if err := showConstraintClause(ctx, desc, &p.RunParams(ctx).p.semaCtx, p.RunParams(ctx).p.SessionData(), f); err != nil {
 		return "", err
 	}
+	if err := showInheritsClause(desc, dbPrefix, lCtx, f); err != nil {
+		return "", err
+	}
 
 	if err := ShowCreatePartitioning(
 		a, p.ExecCfg().Codec, desc, desc.GetPrimaryIndex(), desc.GetPrimaryIndex().GetPartitioning(), &f.Buffer, 0 /* indent */, 0, /* colOffset */
@@ -226,6 +229,9 @@ func (p *planner) ShowCreate(
 	defer sp.Finish()
 
 	tn := tree.MakeUnqualifiedTableName(tree.Name(desc.GetName()))
+	if desc.IsForeignTable() {
+		return ShowCreateForeignTable(&tn, desc), nil
+	}
 	if desc.IsView() {
 		return ShowCreateView(ctx, &p.RunParams(ctx).p.semaCtx, p.RunParams(ctx).p.SessionData(), &tn, desc)
 	}
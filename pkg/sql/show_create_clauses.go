@@ -129,7 +129,13 @@ func ShowCreateView(
 			f.WriteRune(',')
 		}
 	}
-	f.WriteString(") AS ")
+	f.WriteString(")")
+	if storageParams := desc.GetStorageParams(true /* spaceBetweenEqual */); len(storageParams) > 0 {
+		f.WriteString(" WITH (")
+		f.WriteString(strings.Join(storageParams, ", "))
+		f.WriteString(")")
+	}
+	f.WriteString(" AS ")
 
 	cfg := tree.DefaultPrettyCfg()
 	cfg.UseTabs = true
@@ -144,6 +150,42 @@ func ShowCreateView(
 	return f.CloseAndGetString(), nil
 }
 
+// ShowCreateForeignTable returns a valid SQL representation of the CREATE
+// FOREIGN TABLE statement used to create the given foreign table.
+func ShowCreateForeignTable(tn *tree.TableName, desc catalog.TableDescriptor) string {
+	foreignTable := desc.GetForeignTable()
+	f := tree.NewFmtCtx(tree.FmtSimple)
+	f.WriteString("CREATE FOREIGN TABLE ")
+	f.FormatNode(tn)
+	f.WriteString(" (")
+	cols := desc.PublicColumns()
+	for i, col := range cols {
+		f.WriteString("\n\t")
+		name := col.GetName()
+		f.FormatNameP(&name)
+		f.WriteByte(' ')
+		f.WriteString(col.GetType().SQLString())
+		if i == len(cols)-1 {
+			f.WriteRune('\n')
+		} else {
+			f.WriteRune(',')
+		}
+	}
+	f.WriteString(") SERVER ")
+	server := tree.Name(foreignTable.Server)
+	f.FormatNode(&server)
+	opts := tree.ForeignOptions{
+		{Name: foreignTableOptFilename, Value: foreignTable.Filename},
+		{Name: foreignTableOptFormat, Value: foreignTable.Format},
+	}
+	for _, opt := range foreignTable.Options {
+		opts = append(opts, tree.ForeignOption{Name: tree.Name(opt.Name), Value: opt.Value})
+	}
+	f.WriteByte(' ')
+	f.FormatNode(&opts)
+	return f.CloseAndGetString()
+}
+
 // formatViewQueryForDisplay walks the view query and replaces references to
 // user-defined types and sequences with their names. It then round-trips the
 // string representation through the parser and the pretty renderer to return
@@ -485,6 +527,10 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(tree.ForeignKeyReferenceActionType[fk.OnUpdate].String())
 	}
+	if fk.Deferrability != semenumpb.ConstraintDeferrability_NOT_DEFERRABLE {
+		buf.WriteByte(' ')
+		buf.WriteString(tree.ConstraintDeferrability(fk.Deferrability).String())
+	}
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -687,6 +733,39 @@ func ShowCreatePartitioning(
 	return nil
 }
 
+// showInheritsClause creates the INHERITS clause for a CREATE statement,
+// writing it to tree.FmtCtx f.
+func showInheritsClause(
+	desc catalog.TableDescriptor, dbPrefix string, lCtx simpleSchemaResolver, f *tree.FmtCtx,
+) error {
+	if len(desc.GetInherits()) == 0 {
+		return nil
+	}
+	f.WriteString(" INHERITS (")
+	for i, id := range desc.GetInherits() {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		var parentName tree.TableName
+		if lCtx != nil {
+			parent, err := lCtx.getTableByID(id)
+			if err != nil {
+				return err
+			}
+			parentName, err = getTableNameFromTableDescriptor(lCtx, parent, dbPrefix)
+			if err != nil {
+				return err
+			}
+		} else {
+			parentName = tree.MakeTableNameWithSchema(tree.Name(""), tree.PublicSchemaName, tree.Name(fmt.Sprintf("[%d as parent]", id)))
+			parentName.ExplicitSchema = false
+		}
+		f.FormatNode(&parentName)
+	}
+	f.WriteString(")")
+	return nil
+}
+
 // showConstraintClause creates the CONSTRAINT clauses for a CREATE statement,
 // writing them to tree.FmtCtx f
 func showConstraintClause(
@@ -724,13 +803,34 @@ func showConstraintClause(
 			formatQuoteNames(&f.Buffer, c.GetName())
 			f.WriteString(" ")
 		}
-		f.WriteString("UNIQUE WITHOUT INDEX (")
-		colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
-		if err != nil {
-			return err
+		if c.IsExclusion() {
+			f.WriteString("EXCLUDE (")
+			for i, n := 0, c.NumKeyColumns(); i < n; i++ {
+				if i > 0 {
+					f.WriteString(", ")
+				}
+				col, err := catalog.MustFindColumnByID(desc, c.GetKeyColumnID(i))
+				if err != nil {
+					return err
+				}
+				f.FormatName(col.GetName())
+				f.WriteString(" WITH ")
+				f.WriteString(c.ExclusionOperators()[i])
+			}
+			f.WriteString(")")
+		} else {
+			f.WriteString("UNIQUE WITHOUT INDEX (")
+			colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
+			if err != nil {
+				return err
+			}
+			f.WriteString(strings.Join(colNames, ", "))
+			f.WriteString(")")
+		}
+		if c.Deferrability() != semenumpb.ConstraintDeferrability_NOT_DEFERRABLE {
+			f.WriteString(" ")
+			f.WriteString(tree.ConstraintDeferrability(c.Deferrability()).String())
 		}
-		f.WriteString(strings.Join(colNames, ", "))
-		f.WriteString(")")
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(ctx, desc, c.GetPredicate(), semaCtx, sessionData, tree.FmtParsable)
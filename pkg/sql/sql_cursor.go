@@ -12,18 +12,25 @@ package sql
 
 import (
 	"context"
+	"math"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -31,20 +38,26 @@ import (
 // DeclareCursor implements the DECLARE statement.
 // See https://www.postgresql.org/docs/current/sql-declare.html for details.
 func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (planNode, error) {
-	if s.Binary {
-		return nil, unimplemented.NewWithIssue(77099, "DECLARE BINARY CURSOR")
-	}
-	if s.Scroll == tree.Scroll {
-		return nil, unimplemented.NewWithIssue(77102, "DECLARE SCROLL CURSOR")
+	if len(s.Select.Locking) > 0 {
+		if s.Scroll == tree.Scroll {
+			return nil, errors.WithDetail(pgerror.Newf(pgcode.FeatureNotSupported,
+				"DECLARE SCROLL CURSOR ... FOR UPDATE/SHARE is not supported"),
+				"Scrollable cursors must be READ ONLY.")
+		}
+		if s.Hold {
+			return nil, errors.WithDetail(pgerror.Newf(pgcode.FeatureNotSupported,
+				"DECLARE CURSOR WITH HOLD ... FOR UPDATE/SHARE is not supported"),
+				"Holdable cursors must be READ ONLY.")
+		}
 	}
 
 	return &delayedNode{
 		name: s.String(),
 		constructor: func(ctx context.Context, p *planner) (_ planNode, _ error) {
-			if p.extendedEvalCtx.TxnImplicit {
-				if s.Hold {
-					return nil, unimplemented.NewWithIssue(77101, "DECLARE CURSOR WITH HOLD can only be used in transaction blocks")
-				}
+			// Holdable cursors may be declared outside of a transaction block, in
+			// which case their results are materialized when the implicit
+			// transaction commits.
+			if p.extendedEvalCtx.TxnImplicit && !s.Hold {
 				return nil, pgerror.Newf(pgcode.NoActiveSQLTransaction, "DECLARE CURSOR can only be used in transaction blocks")
 			}
 
@@ -100,12 +113,23 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 				statement:  statement,
 				created:    timeutil.Now(),
 				withHold:   s.Hold,
+				scroll:     s.Scroll == tree.Scroll,
+				binary:     s.Binary,
+			}
+			if cursor.scroll || cursor.withHold {
+				// Scrollable cursors need to be able to revisit rows, and holdable
+				// cursors need to store their results when the transaction commits.
+				// The row buffer is accounted for by the session, since holdable
+				// cursors outlive the transaction.
+				cursor.buf = newCursorRowBuffer(
+					ctx, p.ExtendedEvalContext(), p.sqlCursors.memMonitor(), rows.Types(),
+				)
 			}
 			if err := p.sqlCursors.addCursor(s.Name, cursor); err != nil {
 				// This case shouldn't happen because cursor names are scoped to a session,
 				// and sessions can't have more than one statement running at once. But
 				// let's be diligent and clean up if it somehow does happen anyway.
-				_ = cursor.Close()
+				_ = cursor.close(ctx)
 				return nil, err
 			}
 			return newZeroNode(nil /* columns */), nil
@@ -113,7 +137,10 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 	}, nil
 }
 
-var errBackwardScan = pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "cursor can only scan forward")
+var errBackwardScan = errors.WithHint(
+	pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "cursor can only scan forward"),
+	"Declare it with SCROLL option to enable backward scan.",
+)
 
 // FetchCursor implements the FETCH and MOVE statements.
 // See https://www.postgresql.org/docs/current/sql-fetch.html for details.
@@ -126,7 +153,8 @@ func (p *planner) FetchCursor(
 			pgcode.InvalidCursorName, "cursor %q does not exist", s.Name,
 		)
 	}
-	if s.Count < 0 || s.FetchType == tree.FetchBackwardAll {
+	if !cursor.scroll && (s.Count < 0 || s.FetchType == tree.FetchBackwardAll ||
+		s.FetchType == tree.FetchLast) {
 		return nil, errBackwardScan
 	}
 	node := &fetchNode{
@@ -135,7 +163,21 @@ func (p *planner) FetchCursor(
 		cursor:    cursor,
 		isMove:    isMove,
 	}
-	if s.FetchType != tree.FetchNormal {
+	switch s.FetchType {
+	case tree.FetchNormal:
+		if s.Count == 0 {
+			// FETCH FORWARD 0 and FETCH BACKWARD 0 re-fetch the current row.
+			node.fetchType = tree.FetchRelative
+		} else if s.Count < 0 {
+			node.n = -s.Count
+			node.backward = true
+		}
+	case tree.FetchAll:
+		node.n = math.MaxInt64
+	case tree.FetchBackwardAll:
+		node.n = math.MaxInt64
+		node.backward = true
+	default:
 		node.n = 0
 		node.offset = s.Count
 	}
@@ -146,8 +188,9 @@ type fetchNode struct {
 	cursor *sqlCursor
 	// n is the number of rows requested.
 	n int64
-	// offset is the number of rows to read first, when in relative or absolute
-	// mode.
+	// backward is true if the rows are requested in the backward direction.
+	backward bool
+	// offset is the position to seek to, when in relative or absolute mode.
 	offset    int64
 	fetchType tree.FetchType
 	// isMove is true if this is a MOVE statement, which is identical to a FETCH
@@ -163,6 +206,11 @@ type fetchNode struct {
 }
 
 func (f *fetchNode) startExec(params runParams) error {
+	if f.cursor.txn == nil {
+		// The results of holdable cursors are materialized when the transaction
+		// that declared them commits, so there's nothing left to read from it.
+		return nil
+	}
 	state := f.cursor.txn.GetLeafTxnInputState(params.ctx)
 	// We need to make sure that we're reading at the same read sequence number
 	// that we had when we created the cursor, to preserve the "sensitivity"
@@ -173,52 +221,38 @@ func (f *fetchNode) startExec(params runParams) error {
 }
 
 func (f *fetchNode) Next(params runParams) (bool, error) {
-	if f.fetchType == tree.FetchAll {
-		return f.cursor.Next(params.ctx)
-	}
-
-	if !f.seeked {
-		// FIRST, LAST, ABSOLUTE, and RELATIVE require seeking before returning
-		// values. Do that first.
-		f.seeked = true
-		switch f.fetchType {
-		case tree.FetchFirst:
-			switch f.cursor.curRow {
-			case 0:
-				_, err := f.cursor.Next(params.ctx)
-				return true, err
-			case 1:
-				return true, nil
-			}
-			return false, errBackwardScan
-		case tree.FetchLast:
-			return false, errBackwardScan
-		case tree.FetchAbsolute:
-			if f.cursor.curRow > f.offset {
-				return false, errBackwardScan
-			}
-			for f.cursor.curRow < f.offset {
-				more, err := f.cursor.Next(params.ctx)
-				if !more || err != nil {
-					return more, err
-				}
-			}
-			return true, nil
-		case tree.FetchRelative:
-			for i := int64(0); i < f.offset; i++ {
-				more, err := f.cursor.Next(params.ctx)
-				if !more || err != nil {
-					return more, err
-				}
-			}
-			return true, nil
+	switch f.fetchType {
+	case tree.FetchNormal, tree.FetchAll, tree.FetchBackwardAll:
+		if f.n <= 0 {
+			return false, nil
+		}
+		f.n--
+		if f.backward {
+			return f.cursor.seek(params.ctx, f.cursor.curRow-1)
 		}
+		return f.cursor.seek(params.ctx, f.cursor.curRow+1)
 	}
-	if f.n <= 0 {
+
+	// FIRST, LAST, ABSOLUTE, and RELATIVE move the cursor to a single position,
+	// and return the row at that position, if any.
+	if f.seeked {
 		return false, nil
 	}
-	f.n--
-	return f.cursor.Next(params.ctx)
+	f.seeked = true
+	switch f.fetchType {
+	case tree.FetchFirst:
+		return f.cursor.seek(params.ctx, 1)
+	case tree.FetchLast:
+		return f.cursor.seekFromEnd(params.ctx, 1)
+	case tree.FetchAbsolute:
+		if f.offset < 0 {
+			return f.cursor.seekFromEnd(params.ctx, -f.offset)
+		}
+		return f.cursor.seek(params.ctx, f.offset)
+	case tree.FetchRelative:
+		return f.cursor.seek(params.ctx, f.cursor.curRow+f.offset)
+	}
+	return false, errors.AssertionFailedf("unexpected fetch type %s", f.fetchType)
 }
 
 func (f fetchNode) Values() tree.Datums {
@@ -229,6 +263,9 @@ func (f fetchNode) Close(ctx context.Context) {
 	// We explicitly do not pass through the Close to our Rows, because
 	// running FETCH on a CURSOR does not close it.
 
+	if f.cursor.txn == nil {
+		return
+	}
 	// Reset the transaction's read sequence number to what it was before the
 	// fetch began, so that subsequent reads in the transaction can still see
 	// writes from that transaction.
@@ -244,9 +281,9 @@ func (p *planner) CloseCursor(ctx context.Context, n *tree.CloseCursor) (planNod
 		name: n.String(),
 		constructor: func(ctx context.Context, p *planner) (planNode, error) {
 			if n.All {
-				return newZeroNode(nil /* columns */), p.sqlCursors.closeAll(false /* errorOnWithHold */)
+				return newZeroNode(nil /* columns */), p.sqlCursors.closeAll(ctx, false /* keepHoldable */)
 			}
-			return newZeroNode(nil /* columns */), p.sqlCursors.closeCursor(n.Name)
+			return newZeroNode(nil /* columns */), p.sqlCursors.closeCursor(ctx, n.Name)
 		},
 	}, nil
 }
@@ -254,35 +291,222 @@ func (p *planner) CloseCursor(ctx context.Context, n *tree.CloseCursor) (planNod
 type sqlCursor struct {
 	isql.Rows
 	// txn is the transaction object that the internal executor for this cursor
-	// is running with.
+	// is running with. It is nil for holdable cursors once the transaction has
+	// committed.
 	txn *kv.Txn
 	// readSeqNum is the sequence number of the transaction that the cursor was
 	// initialized with.
 	readSeqNum enginepb.TxnSeq
 	statement  string
 	created    time.Time
-	curRow     int64
 	withHold   bool
+	scroll     bool
+	binary     bool
+
+	// curRow is the position of the cursor. Position 0 is before the first
+	// row, and position numRows+1 is after the last row once all rows have been
+	// read.
+	curRow int64
+	// numRows is the number of rows read from Rows so far.
+	numRows int64
+	// eof is set once Rows has been exhausted.
+	eof bool
+	// buf contains all rows read from Rows so far. It is only set for scrollable
+	// and holdable cursors.
+	buf *cursorRowBuffer
+	// cur is the row at the current position of a cursor with a row buffer.
+	cur tree.Datums
 }
 
-// Next implements the Rows interface.
-func (s *sqlCursor) Next(ctx context.Context) (bool, error) {
+// Cur returns the row at the current position of the cursor.
+func (s *sqlCursor) Cur() tree.Datums {
+	if s.buf != nil {
+		return s.cur
+	}
+	return s.Rows.Cur()
+}
+
+// seek moves the cursor to the given position, returning whether the cursor
+// is positioned on a row. Seeking before the first row or after the last row
+// leaves the cursor before the first row or after the last row, respectively.
+func (s *sqlCursor) seek(ctx context.Context, pos int64) (bool, error) {
+	if pos < s.curRow && !s.scroll {
+		return false, errBackwardScan
+	}
+	if pos <= 0 {
+		s.curRow = 0
+		return false, nil
+	}
+	if s.buf == nil {
+		for s.curRow < pos {
+			if more, err := s.readRow(ctx); !more || err != nil {
+				return false, err
+			}
+			s.curRow++
+		}
+		return s.curRow <= s.numRows, nil
+	}
+
+	for s.numRows < pos {
+		if more, err := s.readRow(ctx); err != nil {
+			return false, err
+		} else if !more {
+			s.curRow = s.numRows + 1
+			return false, nil
+		}
+		if s.numRows == pos {
+			// This row was just read, so there's no need to get it from the
+			// buffer.
+			s.curRow = pos
+			s.cur = s.Rows.Cur()
+			return true, nil
+		}
+	}
+	row, err := s.buf.getRow(ctx, int(pos-1))
+	if err != nil {
+		return false, err
+	}
+	s.curRow = pos
+	s.cur = row
+	return true, nil
+}
+
+// seekFromEnd moves the cursor to the given position counted from the end of
+// the results, with 1 being the last row. All remaining rows are read.
+func (s *sqlCursor) seekFromEnd(ctx context.Context, pos int64) (bool, error) {
+	for !s.eof {
+		if _, err := s.readRow(ctx); err != nil {
+			return false, err
+		}
+	}
+	return s.seek(ctx, s.numRows+1-pos)
+}
+
+// readRow reads the next row from Rows, storing it in the row buffer if the
+// cursor has one. It returns false once all rows have been read, in which case
+// the cursor is moved after the last row.
+func (s *sqlCursor) readRow(ctx context.Context) (bool, error) {
+	if s.eof {
+		s.curRow = s.numRows + 1
+		return false, nil
+	}
 	more, err := s.Rows.Next(ctx)
-	if err == nil {
-		s.curRow++
+	if err != nil {
+		return false, err
+	}
+	if !more {
+		s.eof = true
+		s.curRow = s.numRows + 1
+		return false, nil
 	}
-	return more, err
+	s.numRows++
+	if s.buf != nil {
+		if err := s.buf.addRow(ctx, s.Rows.Cur()); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// materialize reads all remaining rows of a holdable cursor into its row
+// buffer, so that the cursor no longer depends on its transaction. It must be
+// called before the transaction commits.
+func (s *sqlCursor) materialize(ctx context.Context) error {
+	// Read the remaining rows at the cursor's read sequence number, like FETCH
+	// does.
+	origTxnSeqNum := s.txn.GetLeafTxnInputState(ctx).ReadSeqNum
+	if err := s.txn.SetReadSeqNum(s.readSeqNum); err != nil {
+		return err
+	}
+	// readRow moves the cursor after the last row once all rows are read, so
+	// restore the position afterwards.
+	curRow := s.curRow
+	for !s.eof {
+		if _, err := s.readRow(ctx); err != nil {
+			return err
+		}
+	}
+	s.curRow = curRow
+	if err := s.txn.SetReadSeqNum(origTxnSeqNum); err != nil {
+		return err
+	}
+	return s.Rows.Close()
+}
+
+// close closes the cursor's query and releases its row buffer.
+func (s *sqlCursor) close(ctx context.Context) error {
+	if s.buf != nil {
+		s.buf.close(ctx)
+		s.buf = nil
+	}
+	return s.Rows.Close()
+}
+
+// cursorRowBuffer stores the rows of a scrollable or holdable cursor in a
+// disk-backed row container that supports random access.
+type cursorRowBuffer struct {
+	memMonitor  *mon.BytesMonitor
+	diskMonitor *mon.BytesMonitor
+	rows        *rowcontainer.ScrollableRowContainer
+	numCols     int
+	scratch     rowenc.EncDatumRow
+}
+
+func newCursorRowBuffer(
+	ctx context.Context,
+	evalCtx *extendedEvalContext,
+	parent *mon.BytesMonitor,
+	cols colinfo.ResultColumns,
+) *cursorRowBuffer {
+	distSQLCfg := &evalCtx.DistSQLPlanner.distSQLSrv.ServerConfig
+	b := &cursorRowBuffer{
+		memMonitor: execinfra.NewLimitedMonitorNoFlowCtx(
+			ctx, parent, distSQLCfg, evalCtx.SessionData(), "sql-cursor-limited",
+		),
+		diskMonitor: execinfra.NewMonitor(ctx, distSQLCfg.ParentDiskMonitor, "sql-cursor-disk"),
+		numCols:     len(cols),
+		scratch:     make(rowenc.EncDatumRow, len(cols)),
+	}
+	typs := make([]*types.T, len(cols))
+	for i := range cols {
+		typs[i] = cols[i].Typ
+	}
+	b.rows = rowcontainer.NewScrollableRowContainer(
+		typs, &evalCtx.Context, distSQLCfg.TempStorage, b.memMonitor, b.diskMonitor,
+	)
+	return b
+}
+
+func (b *cursorRowBuffer) addRow(ctx context.Context, row tree.Datums) error {
+	for i := range row {
+		b.scratch[i].Datum = row[i]
+	}
+	return b.rows.AddRow(ctx, b.scratch)
+}
+
+func (b *cursorRowBuffer) getRow(ctx context.Context, idx int) (tree.Datums, error) {
+	row, err := b.rows.GetRow(ctx, idx)
+	if err != nil {
+		return nil, err
+	}
+	return row.GetDatums(0, b.numCols)
+}
+
+func (b *cursorRowBuffer) close(ctx context.Context) {
+	b.rows.Close(ctx)
+	b.memMonitor.Stop(ctx)
+	b.diskMonitor.Stop(ctx)
 }
 
 // sqlCursors contains a set of active cursors for a session.
 type sqlCursors interface {
-	// closeAll closes all cursors in the set. If any of the cursors were
-	// created WITH HOLD, and the errorOnWithHold flag is true, an error is
-	// returned.
-	closeAll(errorOnWithHold bool) error
+	// closeAll closes all cursors in the set. If keepHoldable is true, holdable
+	// cursors declared in transactions that have already committed are kept
+	// open.
+	closeAll(ctx context.Context, keepHoldable bool) error
 	// closeCursor closes the named cursor, returning an error if that cursor
 	// didn't exist in the set.
-	closeCursor(tree.Name) error
+	closeCursor(context.Context, tree.Name) error
 	// getCursor returns the named cursor, returning nil if that cursor
 	// didn't exist in the set.
 	getCursor(tree.Name) *sqlCursor
@@ -291,32 +515,67 @@ type sqlCursors interface {
 	addCursor(tree.Name, *sqlCursor) error
 	// list returns all open cursors in the set.
 	list() map[tree.Name]*sqlCursor
+	// memMonitor returns the session-bound memory monitor that the row buffers
+	// of cursors in the set are accounted against.
+	memMonitor() *mon.BytesMonitor
 }
 
 // cursorMap is a sqlCursors that's backed by an actual map.
 type cursorMap struct {
 	cursors map[tree.Name]*sqlCursor
+	mon     *mon.BytesMonitor
 }
 
-func (c *cursorMap) closeAll(errorOnWithHold bool) error {
-	for n, c := range c.cursors {
-		if c.withHold && errorOnWithHold {
-			return unimplemented.NewWithIssuef(77101, "cursor %s WITH HOLD must be closed before committing", n)
+func (c *cursorMap) closeAll(ctx context.Context, keepHoldable bool) error {
+	for n, cursor := range c.cursors {
+		if keepHoldable && cursor.txn == nil {
+			continue
 		}
-		if err := c.Close(); err != nil {
+		delete(c.cursors, n)
+		if err := cursor.close(ctx); err != nil {
 			return err
 		}
 	}
-	c.cursors = nil
 	return nil
 }
 
-func (c *cursorMap) closeCursor(s tree.Name) error {
+// materializeHoldable prepares the cursors declared in the current
+// transaction for its commit: the results of holdable cursors are
+// materialized, and all other cursors are closed.
+func (c *cursorMap) materializeHoldable(ctx context.Context) error {
+	for n, cursor := range c.cursors {
+		if cursor.txn == nil {
+			continue
+		}
+		if cursor.withHold {
+			if err := cursor.materialize(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+		delete(c.cursors, n)
+		if err := cursor.close(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// detachHoldable marks the holdable cursors declared in the current
+// transaction as no longer bound to it. It is called once the transaction has
+// committed, after materializeHoldable.
+func (c *cursorMap) detachHoldable() {
+	for _, cursor := range c.cursors {
+		cursor.txn = nil
+	}
+}
+
+func (c *cursorMap) closeCursor(ctx context.Context, s tree.Name) error {
 	cursor, ok := c.cursors[s]
 	if !ok {
 		return pgerror.Newf(pgcode.InvalidCursorName, "cursor %q does not exist", s)
 	}
-	err := cursor.Close()
+	err := cursor.close(ctx)
 	delete(c.cursors, s)
 	return err
 }
@@ -340,18 +599,22 @@ func (c *cursorMap) list() map[tree.Name]*sqlCursor {
 	return c.cursors
 }
 
+func (c *cursorMap) memMonitor() *mon.BytesMonitor {
+	return c.mon
+}
+
 // connExCursorAccessor is a sqlCursors that delegates to a connExecutor's
 // extraTxnState.
 type connExCursorAccessor struct {
 	ex *connExecutor
 }
 
-func (c connExCursorAccessor) closeAll(errorOnWithHold bool) error {
-	return c.ex.extraTxnState.sqlCursors.closeAll(errorOnWithHold)
+func (c connExCursorAccessor) closeAll(ctx context.Context, keepHoldable bool) error {
+	return c.ex.extraTxnState.sqlCursors.closeAll(ctx, keepHoldable)
 }
 
-func (c connExCursorAccessor) closeCursor(s tree.Name) error {
-	return c.ex.extraTxnState.sqlCursors.closeCursor(s)
+func (c connExCursorAccessor) closeCursor(ctx context.Context, s tree.Name) error {
+	return c.ex.extraTxnState.sqlCursors.closeCursor(ctx, s)
 }
 
 func (c connExCursorAccessor) getCursor(s tree.Name) *sqlCursor {
@@ -366,6 +629,10 @@ func (c connExCursorAccessor) list() map[tree.Name]*sqlCursor {
 	return c.ex.extraTxnState.sqlCursors.list()
 }
 
+func (c connExCursorAccessor) memMonitor() *mon.BytesMonitor {
+	return c.ex.extraTxnState.sqlCursors.memMonitor()
+}
+
 // checkNoConflictingCursors returns an error if the input schema changing
 // statement conflicts with any open SQL cursors in the current planner.
 func (p *planner) checkNoConflictingCursors(stmt tree.Statement) error {
@@ -375,9 +642,13 @@ func (p *planner) checkNoConflictingCursors(stmt tree.Statement) error {
 	// We could improve this by matching the memo metadata's list of dependent
 	// schema objects in each open cursor with the objects being changed in the
 	// schema change.
-	if len(p.sqlCursors.list()) > 0 {
-		return unimplemented.NewWithIssue(74608, "cannot run schema change "+
-			"in a transaction with open DECLARE cursors")
+	for _, c := range p.sqlCursors.list() {
+		// Holdable cursors from transactions that already committed don't read
+		// from the schema anymore.
+		if c.txn != nil {
+			return unimplemented.NewWithIssue(74608, "cannot run schema change "+
+				"in a transaction with open DECLARE cursors")
+		}
 	}
 	return nil
 }
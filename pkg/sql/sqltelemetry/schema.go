@@ -74,6 +74,11 @@ var (
 	// indexes counted in InvertedIndexCounter.
 	TrigramInvertedIndexCounter = telemetry.GetCounterOnce("sql.schema.trigram_inverted_index")
 
+	// VectorInvertedIndexCounter is to be incremented every time a
+	// vector inverted index is created. These are a subset of the
+	// indexes counted in InvertedIndexCounter.
+	VectorInvertedIndexCounter = telemetry.GetCounterOnce("sql.schema.vector_inverted_index")
+
 	// PartialIndexCounter is to be incremented every time a partial index is
 	// created. This includes both regular and inverted partial indexes.
 	PartialIndexCounter = telemetry.GetCounterOnce("sql.schema.partial_index")
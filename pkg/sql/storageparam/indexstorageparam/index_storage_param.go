@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/storageparam"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -107,6 +108,29 @@ func (po *Setter) applyGeometryIndexSetting(
 	return nil
 }
 
+func (po *Setter) applyVectorIndexSetting(
+	ctx context.Context, evalCtx *eval.Context, key string, expr tree.Datum,
+) error {
+	if len(po.IndexDesc.InvertedColumnKinds) == 0 || !po.IndexDesc.InvertedColumnKinds[0].IsVector() {
+		return pgerror.Newf(pgcode.InvalidParameterValue, "%q can only be applied to VECTOR indexes", key)
+	}
+	val, err := paramparse.DatumAsInt(ctx, evalCtx, key, expr)
+	if err != nil {
+		return errors.Wrapf(err, "error decoding %q", key)
+	}
+	if val < 1 || val > vector.MaxLists {
+		return pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"%q value must be between %d and %d inclusive",
+			key,
+			1,
+			vector.MaxLists,
+		)
+	}
+	po.IndexDesc.VectorLists = int32(val)
+	return nil
+}
+
 // Set implements the Setter interface.
 func (po *Setter) Set(
 	ctx context.Context,
@@ -126,6 +150,8 @@ func (po *Setter) Set(
 		return po.applyS2ConfigSetting(ctx, evalCtx, key, expr, 1, 32)
 	case `geometry_min_x`, `geometry_max_x`, `geometry_min_y`, `geometry_max_y`:
 		return po.applyGeometryIndexSetting(ctx, evalCtx, key, expr)
+	case `lists`:
+		return po.applyVectorIndexSetting(ctx, evalCtx, key, expr)
 	// `bucket_count` is handled in schema changer when creating hash sharded
 	// indexes.
 	case `bucket_count`:
@@ -0,0 +1,64 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package viewstorageparam implements storageparam.Setter for materialized
+// views.
+package viewstorageparam
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/storageparam"
+	"github.com/cockroachdb/errors"
+)
+
+// Setter observes storage parameters for materialized views.
+type Setter struct {
+	// Incremental is set if the view is to be maintained incrementally
+	// rather than recomputed by REFRESH MATERIALIZED VIEW.
+	Incremental bool
+}
+
+var _ storageparam.Setter = (*Setter)(nil)
+
+// Set implements the Setter interface.
+func (po *Setter) Set(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	key string,
+	datum tree.Datum,
+) error {
+	switch key {
+	case `incremental`:
+		b, err := paramparse.GetSingleBool(key, datum)
+		if err != nil {
+			return err
+		}
+		po.Incremental = bool(*b)
+		return nil
+	}
+	return pgerror.Newf(pgcode.InvalidParameterValue, "invalid storage parameter %q", key)
+}
+
+// Reset implements the Setter interface.
+func (po *Setter) Reset(ctx context.Context, evalCtx *eval.Context, key string) error {
+	return errors.AssertionFailedf("non-implemented codepath")
+}
+
+// RunPostChecks implements the Setter interface.
+func (po *Setter) RunPostChecks() error {
+	return nil
+}
@@ -0,0 +1,691 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+type createTriggerNode struct {
+	n         *tree.CreateTrigger
+	tableDesc *tabledesc.Mutable
+	trigger   descpb.TableDescriptor_Trigger
+	// fnDesc is the user-defined function executed by the trigger, or nil if
+	// the trigger executes a builtin trigger function.
+	fnDesc *funcdesc.Mutable
+}
+
+// CreateTrigger creates a trigger on a table.
+// Privileges: CREATE on the table and EXECUTE on the trigger function.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE TRIGGER",
+	); err != nil {
+		return nil, err
+	}
+	// Triggers are stored in a field of the table descriptor that nodes running
+	// older versions don't know about.
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_1) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create triggers",
+			clusterversion.ByKey(clusterversion.V23_1))
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptorEx(
+		ctx, n.TableName, true /* required */, tree.ResolveAnyTableKind,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTriggerRelation(tableDesc, n.ActionTime); err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	trig := descpb.TableDescriptor_Trigger{
+		Name:       string(n.Name),
+		ActionTime: descpb.TableDescriptor_Trigger_BEFORE,
+		ForEachRow: n.ForEach == tree.TriggerForEachRow,
+		FuncArgs:   n.FuncArgs,
+	}
+	if n.ActionTime == tree.TriggerActionTimeAfter {
+		trig.ActionTime = descpb.TableDescriptor_Trigger_AFTER
+	}
+	if trig.Events, err = makeTriggerEvents(tableDesc, n.Events); err != nil {
+		return nil, err
+	}
+	if n.When != nil {
+		if trig.WhenExpr, err = p.checkTriggerWhenExpr(ctx, tableDesc, &trig, n.When); err != nil {
+			return nil, err
+		}
+	}
+
+	fnDesc, err := p.resolveTriggerFunction(ctx, tableDesc, n, &trig)
+	if err != nil {
+		return nil, err
+	}
+	return &createTriggerNode{n: n, tableDesc: tableDesc, trigger: trig, fnDesc: fnDesc}, nil
+}
+
+// checkTriggerRelation returns an error if a trigger with the given action
+// time cannot be created on the relation.
+func checkTriggerRelation(tableDesc catalog.TableDescriptor, actionTime tree.TriggerActionTime) error {
+	switch {
+	case tableDesc.IsView():
+		return unimplemented.NewWithIssue(28296, "triggers on views are not supported")
+	case !tableDesc.IsTable() || tableDesc.IsVirtualTable():
+		return pgerror.Newf(pgcode.WrongObjectType,
+			"relation %q cannot have triggers", tableDesc.GetName())
+	case actionTime == tree.TriggerActionTimeInsteadOf:
+		return errors.WithDetail(
+			pgerror.Newf(pgcode.WrongObjectType, "%q is a table", tableDesc.GetName()),
+			"Tables cannot have INSTEAD OF triggers.",
+		)
+	}
+	return nil
+}
+
+// makeTriggerEvents converts the events of a CREATE TRIGGER statement to the
+// events stored in the trigger descriptor.
+func makeTriggerEvents(
+	tableDesc catalog.TableDescriptor, events tree.TriggerEvents,
+) ([]descpb.TableDescriptor_Trigger_Event, error) {
+	res := make([]descpb.TableDescriptor_Trigger_Event, 0, len(events))
+	var seen [tree.TriggerEventTruncate + 1]bool
+	for _, event := range events {
+		if seen[event.EventType] {
+			return nil, pgerror.New(pgcode.Syntax, "duplicate trigger events specified")
+		}
+		seen[event.EventType] = true
+		var typ descpb.TableDescriptor_Trigger_EventType
+		switch event.EventType {
+		case tree.TriggerEventInsert:
+			typ = descpb.TableDescriptor_Trigger_INSERT
+		case tree.TriggerEventUpdate:
+			typ = descpb.TableDescriptor_Trigger_UPDATE
+		case tree.TriggerEventDelete:
+			typ = descpb.TableDescriptor_Trigger_DELETE
+		case tree.TriggerEventTruncate:
+			return nil, unimplemented.NewWithIssue(28296, "TRUNCATE triggers are not supported")
+		default:
+			return nil, errors.AssertionFailedf("unexpected trigger event %d", event.EventType)
+		}
+		res = append(res, descpb.TableDescriptor_Trigger_Event{Type: typ})
+		var colIDs catalog.TableColSet
+		for _, name := range event.Columns {
+			col := catalog.FindColumnByTreeName(tableDesc, name)
+			if col == nil || !col.Public() || col.IsInaccessible() {
+				return nil, pgerror.Newf(pgcode.UndefinedColumn,
+					"column %q of relation %q does not exist", name, tableDesc.GetName())
+			}
+			if colIDs.Contains(col.GetID()) {
+				return nil, pgerror.Newf(pgcode.DuplicateColumn,
+					"column %q specified more than once", name)
+			}
+			colIDs.Add(col.GetID())
+			res[len(res)-1].ColumnIDs = append(res[len(res)-1].ColumnIDs, col.GetID())
+		}
+	}
+	return res, nil
+}
+
+// triggerEventType converts the type of a trigger event stored in a
+// descriptor to its tree equivalent.
+func triggerEventType(typ descpb.TableDescriptor_Trigger_EventType) tree.TriggerEventType {
+	switch typ {
+	case descpb.TableDescriptor_Trigger_UPDATE:
+		return tree.TriggerEventUpdate
+	case descpb.TableDescriptor_Trigger_DELETE:
+		return tree.TriggerEventDelete
+	default:
+		return tree.TriggerEventInsert
+	}
+}
+
+// triggerHasEvent returns whether the trigger fires for the given event.
+func triggerHasEvent(
+	trig *descpb.TableDescriptor_Trigger, typ descpb.TableDescriptor_Trigger_EventType,
+) bool {
+	for i := range trig.Events {
+		if trig.Events[i].Type == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTriggerWhenExpr type-checks the WHEN condition of a trigger and
+// returns its serialized form.
+func (p *planner) checkTriggerWhenExpr(
+	ctx context.Context,
+	tableDesc catalog.TableDescriptor,
+	trig *descpb.TableDescriptor_Trigger,
+	when tree.Expr,
+) (string, error) {
+	expr, refs, err := replaceTriggerWhenVars(tableDesc, when)
+	if err != nil {
+		return "", err
+	}
+	if refs.usesNew || refs.usesOld {
+		if !trig.ForEachRow {
+			return "", pgerror.New(pgcode.InvalidObjectDefinition,
+				"statement trigger's WHEN condition cannot reference column values")
+		}
+		if refs.usesOld && triggerHasEvent(trig, descpb.TableDescriptor_Trigger_INSERT) {
+			return "", pgerror.New(pgcode.InvalidObjectDefinition,
+				"INSERT trigger's WHEN condition cannot reference OLD values")
+		}
+		if refs.usesNew && triggerHasEvent(trig, descpb.TableDescriptor_Trigger_DELETE) {
+			return "", pgerror.New(pgcode.InvalidObjectDefinition,
+				"DELETE trigger's WHEN condition cannot reference NEW values")
+		}
+	}
+
+	semaCtx := p.SemaCtx()
+	defer semaCtx.Properties.Restore(semaCtx.Properties)
+	semaCtx.Properties.Require("trigger WHEN conditions", tree.RejectSpecial)
+	typedExpr, err := tree.TypeCheckAndRequire(ctx, expr, semaCtx, types.Bool, "WHEN")
+	if err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
+
+// triggerWhenRefs describes the rows referenced by the WHEN condition of a
+// trigger.
+type triggerWhenRefs struct {
+	usesNew, usesOld bool
+	// cols are the columns of the new and old rows that are referenced.
+	cols catalog.TableColSet
+}
+
+// replaceTriggerWhenVars replaces the references to the new and old rows in
+// the WHEN condition of a trigger with triggerWhenVars of the same type, so
+// that the condition can be type-checked. A column of the new row is
+// referenced as new.<column>, and the whole row as new or new.*.
+func replaceTriggerWhenVars(
+	tableDesc catalog.TableDescriptor, when tree.Expr,
+) (tree.Expr, triggerWhenRefs, error) {
+	var refs triggerWhenRefs
+	expr, err := tree.SimpleVisit(when, func(expr tree.Expr) (bool, tree.Expr, error) {
+		switch t := expr.(type) {
+		case *tree.Subquery:
+			return false, nil, pgerror.New(pgcode.FeatureNotSupported,
+				"cannot use subquery in trigger WHEN condition")
+		case *tree.UnresolvedName:
+			var row, col string
+			switch {
+			case t.NumParts == 1 && !t.Star:
+				row = t.Parts[0]
+			case t.NumParts == 2:
+				row, col = t.Parts[1], t.Parts[0]
+			}
+			if row != "new" && row != "old" {
+				if t.NumParts == 1 {
+					return false, nil, pgerror.Newf(pgcode.UndefinedColumn,
+						"column %q does not exist", t.Parts[0])
+				}
+				return false, nil, pgerror.Newf(pgcode.UndefinedTable,
+					"missing FROM-clause entry for table %q", t.Parts[1])
+			}
+			if row == "new" {
+				refs.usesNew = true
+			} else {
+				refs.usesOld = true
+			}
+			if t.Star || col == "" {
+				cols := tableDesc.VisibleColumns()
+				typs := make([]*types.T, len(cols))
+				labels := make([]string, len(cols))
+				for i, c := range cols {
+					typs[i] = c.GetType()
+					labels[i] = c.GetName()
+					refs.cols.Add(c.GetID())
+				}
+				return false, &triggerWhenVar{name: t, typ: types.MakeLabeledTuple(typs, labels)}, nil
+			}
+			c := catalog.FindColumnByName(tableDesc, col)
+			if c == nil || !c.Public() || c.IsInaccessible() {
+				return false, nil, pgerror.Newf(pgcode.UndefinedColumn,
+					"column %q does not exist", col)
+			}
+			refs.cols.Add(c.GetID())
+			return false, &triggerWhenVar{name: t, typ: c.GetType()}, nil
+		}
+		return true, expr, nil
+	})
+	return expr, refs, err
+}
+
+// triggerWhenColumns returns the columns referenced by the WHEN condition of
+// a trigger.
+func triggerWhenColumns(
+	tableDesc catalog.TableDescriptor, trig *descpb.TableDescriptor_Trigger,
+) (catalog.TableColSet, error) {
+	if trig.WhenExpr == "" {
+		return catalog.TableColSet{}, nil
+	}
+	when, err := parser.ParseExpr(trig.WhenExpr)
+	if err != nil {
+		return catalog.TableColSet{}, err
+	}
+	_, refs, err := replaceTriggerWhenVars(tableDesc, when)
+	return refs.cols, err
+}
+
+// triggerWhenVar is a reference to the new or old row, or to one of their
+// columns, in the WHEN condition of a trigger. It is only used to type-check
+// the condition, and it is formatted as the original reference.
+type triggerWhenVar struct {
+	name *tree.UnresolvedName
+	typ  *types.T
+}
+
+var _ tree.TypedExpr = &triggerWhenVar{}
+
+// String implements the Stringer interface.
+func (v *triggerWhenVar) String() string {
+	return tree.AsString(v)
+}
+
+// Format implements the NodeFormatter interface.
+func (v *triggerWhenVar) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(v.name)
+}
+
+// Walk implements the Expr interface.
+func (v *triggerWhenVar) Walk(_ tree.Visitor) tree.Expr {
+	return v
+}
+
+// TypeCheck implements the Expr interface.
+func (v *triggerWhenVar) TypeCheck(
+	_ context.Context, _ *tree.SemaContext, desired *types.T,
+) (tree.TypedExpr, error) {
+	return v, nil
+}
+
+// Eval implements the TypedExpr interface.
+func (*triggerWhenVar) Eval(ctx context.Context, _ tree.ExprEvaluator) (tree.Datum, error) {
+	return nil, errors.AssertionFailedf("triggerWhenVar cannot be evaluated")
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (v *triggerWhenVar) ResolvedType() *types.T {
+	return v.typ
+}
+
+// resolveTriggerFunction resolves the function executed by a trigger and sets
+// it in trig. It returns the descriptor of the function, or nil if it is a
+// builtin trigger function.
+func (p *planner) resolveTriggerFunction(
+	ctx context.Context,
+	tableDesc catalog.TableDescriptor,
+	n *tree.CreateTrigger,
+	trig *descpb.TableDescriptor_Trigger,
+) (*funcdesc.Mutable, error) {
+	path := p.CurrentSearchPath()
+	fnDef, err := p.ResolveFunction(ctx, n.FuncName.ToUnresolvedObjectName().ToUnresolvedName(), &path)
+	if err != nil {
+		return nil, err
+	}
+	ol, err := fnDef.MatchOverload([]*types.T{}, n.FuncName.Schema(), &path)
+	if err != nil {
+		return nil, err
+	}
+	if !ol.FixedReturnType().Identical(types.Trigger) {
+		return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must return type trigger", fnDef.Name)
+	}
+	if !ol.IsUDF {
+		trig.FuncName = fnDef.Name
+		return nil, nil
+	}
+	fnDesc, err := p.Descriptors().MutableByID(p.Txn()).Function(ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid))
+	if err != nil {
+		return nil, err
+	}
+	if fnDesc.GetParentID() != tableDesc.GetParentID() {
+		return nil, unimplemented.Newf("CREATE TRIGGER",
+			"cross-database function references not supported")
+	}
+	if err := p.CheckPrivilege(ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	trig.FuncID = fnDesc.GetID()
+	return fnDesc, nil
+}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	p := params.p
+	tableDesc := n.tableDesc
+	trig := n.trigger
+
+	idx := sort.Search(len(tableDesc.Triggers), func(i int) bool {
+		return tableDesc.Triggers[i].Name >= trig.Name
+	})
+	if idx < len(tableDesc.Triggers) && tableDesc.Triggers[idx].Name == trig.Name {
+		if !n.n.Replace {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"trigger %q for relation %q already exists", trig.Name, tableDesc.GetName())
+		}
+		// Replace the existing trigger, which keeps its ID.
+		existing := &tableDesc.Triggers[idx]
+		if err := p.removeTriggerBackReference(params.ctx, tableDesc, existing); err != nil {
+			return err
+		}
+		trig.ID = existing.ID
+		*existing = trig
+	} else {
+		if tableDesc.NextTriggerID == 0 {
+			tableDesc.NextTriggerID = 1
+		}
+		trig.ID = tableDesc.NextTriggerID
+		tableDesc.NextTriggerID++
+		tableDesc.Triggers = append(tableDesc.Triggers, descpb.TableDescriptor_Trigger{})
+		copy(tableDesc.Triggers[idx+1:], tableDesc.Triggers[idx:])
+		tableDesc.Triggers[idx] = trig
+	}
+
+	if n.fnDesc != nil {
+		if err := p.addTriggerBackReference(params.ctx, tableDesc, n.fnDesc, trig.ID); err != nil {
+			return err
+		}
+	}
+	if err := p.writeSchemaChange(
+		params.ctx, tableDesc, descpb.InvalidMutationID,
+		fmt.Sprintf("creating trigger %s on table %s(%d)", trig.Name, tableDesc.Name, tableDesc.ID),
+	); err != nil {
+		return err
+	}
+	// The WHEN condition may reference user-defined types.
+	return p.addBackRefsFromAllTypesInTable(params.ctx, tableDesc)
+}
+
+func (n *createTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createTriggerNode) Close(context.Context)        {}
+
+// addTriggerBackReference records in the descriptor of the function that it
+// is executed by a trigger of the table.
+func (p *planner) addTriggerBackReference(
+	ctx context.Context, tableDesc *tabledesc.Mutable, fnDesc *funcdesc.Mutable, trigID catid.TriggerID,
+) error {
+	ref := (*descpb.FunctionDescriptor_Reference)(nil)
+	for i := range fnDesc.DependedOnBy {
+		if fnDesc.DependedOnBy[i].ID == tableDesc.ID {
+			ref = &fnDesc.DependedOnBy[i]
+			break
+		}
+	}
+	if ref == nil {
+		fnDesc.DependedOnBy = append(fnDesc.DependedOnBy, descpb.FunctionDescriptor_Reference{ID: tableDesc.ID})
+		ref = &fnDesc.DependedOnBy[len(fnDesc.DependedOnBy)-1]
+	}
+	ref.TriggerIDs = append(ref.TriggerIDs, trigID)
+	return p.writeFuncSchemaChange(ctx, fnDesc)
+}
+
+// removeTriggerBackReference removes the reference to the trigger from the
+// descriptor of the function it executes, if any.
+func (p *planner) removeTriggerBackReference(
+	ctx context.Context, tableDesc *tabledesc.Mutable, trig *descpb.TableDescriptor_Trigger,
+) error {
+	if trig.FuncID == descpb.InvalidID {
+		return nil
+	}
+	fnDesc, err := p.Descriptors().MutableByID(p.Txn()).Function(ctx, trig.FuncID)
+	if err != nil {
+		return err
+	}
+	refs := fnDesc.DependedOnBy[:0]
+	for _, ref := range fnDesc.DependedOnBy {
+		if ref.ID == tableDesc.ID {
+			trigIDs := ref.TriggerIDs[:0]
+			for _, id := range ref.TriggerIDs {
+				if id != trig.ID {
+					trigIDs = append(trigIDs, id)
+				}
+			}
+			ref.TriggerIDs = trigIDs
+			if len(ref.TriggerIDs) == 0 {
+				continue
+			}
+		}
+		refs = append(refs, ref)
+	}
+	fnDesc.DependedOnBy = refs
+	return p.writeFuncSchemaChange(ctx, fnDesc)
+}
+
+// removeTriggerBackReferences removes the references to all the triggers of
+// a table that is being dropped from the functions they execute.
+func (p *planner) removeTriggerBackReferences(ctx context.Context, tableDesc *tabledesc.Mutable) error {
+	for i := range tableDesc.Triggers {
+		if err := p.removeTriggerBackReference(ctx, tableDesc, &tableDesc.Triggers[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropTriggersReferencingColumn removes the triggers that reference a column
+// that is being dropped, in their UPDATE OF event or their WHEN condition. If
+// the drop behavior is not CASCADE, an error is returned instead.
+func (p *planner) dropTriggersReferencingColumn(
+	ctx context.Context, tableDesc *tabledesc.Mutable, col catalog.Column, behavior tree.DropBehavior,
+) error {
+	for i := 0; i < len(tableDesc.Triggers); i++ {
+		trig := &tableDesc.Triggers[i]
+		uses := false
+		for j := range trig.Events {
+			for _, id := range trig.Events[j].ColumnIDs {
+				uses = uses || id == col.GetID()
+			}
+		}
+		if !uses {
+			cols, err := triggerWhenColumns(tableDesc, trig)
+			if err != nil {
+				return err
+			}
+			uses = cols.Contains(col.GetID())
+		}
+		if !uses {
+			continue
+		}
+		if behavior != tree.DropCascade {
+			return errors.WithHint(
+				errors.WithDetailf(
+					pgerror.Newf(pgcode.DependentObjectsStillExist,
+						"cannot drop column %s of table %s because other objects depend on it",
+						col.GetName(), tableDesc.GetName()),
+					"trigger %s on table %s depends on column %s of table %s",
+					trig.Name, tableDesc.GetName(), col.GetName(), tableDesc.GetName(),
+				),
+				"Use DROP ... CASCADE to drop the dependent objects too.",
+			)
+		}
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"drop cascades to trigger %s on table %s", trig.Name, tableDesc.GetName()))
+		if err := p.removeTriggerBackReference(ctx, tableDesc, trig); err != nil {
+			return err
+		}
+		tableDesc.Triggers = append(tableDesc.Triggers[:i], tableDesc.Triggers[i+1:]...)
+		i--
+	}
+	return nil
+}
+
+type dropTriggerNode struct {
+	n         *tree.DropTrigger
+	tableDesc *tabledesc.Mutable
+}
+
+// DropTrigger drops a trigger from a table.
+// Privileges: CREATE on the table.
+func (p *planner) DropTrigger(ctx context.Context, n *tree.DropTrigger) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP TRIGGER",
+	); err != nil {
+		return nil, err
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptorEx(
+		ctx, n.TableName, !n.IfExists, tree.ResolveAnyTableKind,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"relation %q does not exist, skipping", n.TableName.String()))
+		return newZeroNode(nil /* columns */), nil
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	return &dropTriggerNode{n: n, tableDesc: tableDesc}, nil
+}
+
+func (n *dropTriggerNode) startExec(params runParams) error {
+	p := params.p
+	tableDesc := n.tableDesc
+	for i := range tableDesc.Triggers {
+		trig := &tableDesc.Triggers[i]
+		if trig.Name != string(n.n.Name) {
+			continue
+		}
+		if err := p.removeTriggerBackReference(params.ctx, tableDesc, trig); err != nil {
+			return err
+		}
+		tableDesc.Triggers = append(tableDesc.Triggers[:i], tableDesc.Triggers[i+1:]...)
+		return p.writeSchemaChange(
+			params.ctx, tableDesc, descpb.InvalidMutationID,
+			fmt.Sprintf("dropping trigger %s on table %s(%d)", n.n.Name, tableDesc.Name, tableDesc.ID),
+		)
+	}
+	if n.n.IfExists {
+		p.BufferClientNotice(params.ctx, pgnotice.Newf(
+			"trigger %q for relation %q does not exist, skipping", n.n.Name, tableDesc.GetName()))
+		return nil
+	}
+	return pgerror.Newf(pgcode.UndefinedObject,
+		"trigger %q for table %q does not exist", n.n.Name, tableDesc.GetName())
+}
+
+func (n *dropTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropTriggerNode) Close(context.Context)        {}
+
+// checkNoDependentTriggers returns an error if the function is executed by any
+// triggers. Like Postgres, the error details name every dependent trigger.
+func (p *planner) checkNoDependentTriggers(
+	ctx context.Context, fnDesc catalog.FunctionDescriptor,
+) error {
+	var details []string
+	for _, by := range fnDesc.GetDependedOnBy() {
+		if len(by.TriggerIDs) == 0 {
+			continue
+		}
+		tbl, err := p.Descriptors().ByID(p.Txn()).Get().Table(ctx, by.ID)
+		if err != nil {
+			return err
+		}
+		for _, trigID := range by.TriggerIDs {
+			for _, trig := range tbl.GetTriggers() {
+				if trig.ID == trigID {
+					details = append(details, fmt.Sprintf("trigger %s on table %s depends on function %s()",
+						trig.Name, tbl.GetName(), fnDesc.GetName()))
+				}
+			}
+		}
+	}
+	if len(details) == 0 {
+		return nil
+	}
+	return errors.WithHint(
+		errors.WithDetail(
+			pgerror.Newf(pgcode.DependentObjectsStillExist,
+				"cannot drop function %s() because other objects depend on it", fnDesc.GetName()),
+			strings.Join(details, "\n"),
+		),
+		"Drop the triggers first.",
+	)
+}
+
+// Trigger types of pg_trigger.tgtype.
+const (
+	pgTriggerTypeRow    = 1 << 0
+	pgTriggerTypeBefore = 1 << 1
+	pgTriggerTypeInsert = 1 << 2
+	pgTriggerTypeDelete = 1 << 3
+	pgTriggerTypeUpdate = 1 << 4
+)
+
+// pgTriggerType returns the pg_trigger.tgtype of a trigger.
+func pgTriggerType(trig *descpb.TableDescriptor_Trigger) int {
+	var typ int
+	if trig.ForEachRow {
+		typ |= pgTriggerTypeRow
+	}
+	if trig.ActionTime == descpb.TableDescriptor_Trigger_BEFORE {
+		typ |= pgTriggerTypeBefore
+	}
+	for i := range trig.Events {
+		switch trig.Events[i].Type {
+		case descpb.TableDescriptor_Trigger_INSERT:
+			typ |= pgTriggerTypeInsert
+		case descpb.TableDescriptor_Trigger_UPDATE:
+			typ |= pgTriggerTypeUpdate
+		case descpb.TableDescriptor_Trigger_DELETE:
+			typ |= pgTriggerTypeDelete
+		}
+	}
+	return typ
+}
+
+// triggerFuncOid returns the OID of the function executed by a trigger.
+func triggerFuncOid(trig *descpb.TableDescriptor_Trigger) (tree.Datum, error) {
+	if trig.FuncID != descpb.InvalidID {
+		return tree.NewDOid(catid.FuncIDToOID(trig.FuncID)), nil
+	}
+	_, overloads := builtinsregistry.GetBuiltinProperties(trig.FuncName)
+	if len(overloads) == 0 {
+		return nil, errors.AssertionFailedf("unknown trigger function %s", trig.FuncName)
+	}
+	return tree.NewDOid(overloads[0].Oid), nil
+}
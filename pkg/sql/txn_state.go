@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -161,6 +162,10 @@ const (
 //
 //	not nil.
 //
+// isoLevel: The transaction's isolation level. Ignored if the txn arg is not
+//
+//	nil.
+//
 // readOnly: The read-only character of the new txn.
 // txn: If not nil, this txn will be used instead of creating a new txn. If so,
 //
@@ -177,6 +182,7 @@ func (ts *txnState) resetForNewSQLTxn(
 	sqlTimestamp time.Time,
 	historicalTimestamp *hlc.Timestamp,
 	priority roachpb.UserPriority,
+	isoLevel isolation.Level,
 	readOnly tree.ReadWriteMode,
 	txn *kv.Txn,
 	tranCtx transitionCtx,
@@ -224,6 +230,9 @@ func (ts *txnState) resetForNewSQLTxn(
 			if err := ts.setPriorityLocked(priority); err != nil {
 				panic(err)
 			}
+			if err := ts.setIsolationLevelLocked(isoLevel); err != nil {
+				panic(err)
+			}
 		} else {
 			if priority != roachpb.UnspecifiedUserPriority {
 				panic(errors.AssertionFailedf("unexpected priority when using an existing txn: %s", priority))
@@ -338,6 +347,19 @@ func (ts *txnState) setPriorityLocked(userPriority roachpb.UserPriority) error {
 	return nil
 }
 
+func (ts *txnState) setIsolationLevel(isoLevel isolation.Level) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.setIsolationLevelLocked(isoLevel)
+}
+
+func (ts *txnState) setIsolationLevelLocked(isoLevel isolation.Level) error {
+	if err := ts.mu.txn.SetIsoLevel(isoLevel); err != nil {
+		return pgerror.WithCandidateCode(err, pgcode.ActiveSQLTransaction)
+	}
+	return nil
+}
+
 func (ts *txnState) setReadOnlyMode(mode tree.ReadWriteMode) error {
 	switch mode {
 	case tree.UnspecifiedReadWriteMode:
@@ -198,6 +198,9 @@ type UserDefinedTypeMetadata struct {
 	// EnumData is non-nil iff the metadata is for an ENUM type.
 	EnumData *EnumMetadata
 
+	// DomainData is non-nil iff the metadata is for a DOMAIN type.
+	DomainData *DomainMetadata
+
 	// ImplicitRecordType is true if the metadata is for an implicit record type
 	// for a table. Note: this can be deleted if we migrate implicit record types
 	// to ordinary persisted composite types.
@@ -220,6 +223,21 @@ type EnumMetadata struct {
 	//  should occur, if at all.
 }
 
+// DomainMetadata is metadata about a DOMAIN needed to enforce its
+// constraints during evaluation.
+type DomainMetadata struct {
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// DefaultExpr is the serialized default expression of the domain, or
+	// nil if the domain has no default.
+	DefaultExpr *string
+	// CheckNames holds the names of the CHECK constraints of the domain.
+	CheckNames []string
+	// CheckExprs holds the serialized expressions of the CHECK constraints of
+	// the domain, in which the VALUE keyword refers to the value being checked.
+	CheckExprs []string
+}
+
 func (e *EnumMetadata) debugString() string {
 	return fmt.Sprintf(
 		"PhysicalReps: %v; LogicalReps: %s",
@@ -483,6 +501,17 @@ var (
 		},
 	}
 
+	// Trigger is the pseudo-type returned by trigger functions. Like void, it
+	// has no values: a trigger function returns the row being modified, or
+	// NULL, to the statement that fired it.
+	Trigger = &T{
+		InternalType: InternalType{
+			Family: VoidFamily,
+			Oid:    oid.T_trigger,
+			Locale: &emptyLocale,
+		},
+	}
+
 	// EncodedKey is a special type used internally for passing encoded key data.
 	// It behaves similarly to Bytes in most circumstances, except
 	// encoding/decoding. It is currently used to pass around inverted index keys,
@@ -514,6 +543,84 @@ var (
 		},
 	}
 
+	// Jsonpath is the type of an SQL/JSON path expression, which can be
+	// evaluated against a JSONB value.
+	Jsonpath = &T{
+		InternalType: InternalType{
+			Family: JsonpathFamily,
+			Oid:    oidext.T_jsonpath,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// PGVector is the type of a vector of floats with an unconstrained number
+	// of dimensions. Use MakePGVector to construct a vector type with a fixed
+	// number of dimensions.
+	PGVector = &T{
+		InternalType: InternalType{
+			Family: PGVectorFamily,
+			Oid:    oidext.T_pgvector,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// Int4Range is the type of a range of INT4 values.
+	Int4Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int4range, Locale: &emptyLocale}}
+
+	// Int8Range is the type of a range of INT8 values.
+	Int8Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int8range, Locale: &emptyLocale}}
+
+	// NumRange is the type of a range of DECIMAL values.
+	NumRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_numrange, Locale: &emptyLocale}}
+
+	// TSRange is the type of a range of TIMESTAMP values.
+	TSRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tsrange, Locale: &emptyLocale}}
+
+	// TSTZRange is the type of a range of TIMESTAMPTZ values.
+	TSTZRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tstzrange, Locale: &emptyLocale}}
+
+	// DateRange is the type of a range of DATE values.
+	DateRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_daterange, Locale: &emptyLocale}}
+
+	// Int4Multirange is the type of a multirange of INT4 values.
+	Int4Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_int4multirange, Locale: &emptyLocale}}
+
+	// Int8Multirange is the type of a multirange of INT8 values.
+	Int8Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_int8multirange, Locale: &emptyLocale}}
+
+	// NumMultirange is the type of a multirange of DECIMAL values.
+	NumMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_nummultirange, Locale: &emptyLocale}}
+
+	// TSMultirange is the type of a multirange of TIMESTAMP values.
+	TSMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_tsmultirange, Locale: &emptyLocale}}
+
+	// TSTZMultirange is the type of a multirange of TIMESTAMPTZ values.
+	TSTZMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_tstzmultirange, Locale: &emptyLocale}}
+
+	// DateMultirange is the type of a multirange of DATE values.
+	DateMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_datemultirange, Locale: &emptyLocale}}
+
+	// Ranges contains all of the built-in range types.
+	Ranges = []*T{Int4Range, Int8Range, NumRange, TSRange, TSTZRange, DateRange}
+
+	// Multiranges contains all of the built-in multirange types, in the same
+	// order as Ranges.
+	Multiranges = []*T{
+		Int4Multirange, Int8Multirange, NumMultirange, TSMultirange, TSTZMultirange, DateMultirange,
+	}
+
 	// Scalar contains all types that meet this criteria:
 	//
 	//   1. Scalar type (no ArrayFamily or TupleFamily types).
@@ -563,6 +670,18 @@ var (
 	AnyEnum = &T{InternalType: InternalType{
 		Family: EnumFamily, Locale: &emptyLocale, Oid: oid.T_anyenum}}
 
+	// AnyRange is a special type only used during static analysis as a wildcard
+	// type that matches any range type. Execution-time values should never have
+	// this type.
+	AnyRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Locale: &emptyLocale, Oid: oid.T_anyrange}}
+
+	// AnyMultirange is a special type only used during static analysis as a
+	// wildcard type that matches any multirange type. Execution-time values
+	// should never have this type.
+	AnyMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Locale: &emptyLocale, Oid: oidext.T_anymultirange}}
+
 	// AnyTuple is a special type used only during static analysis as a wildcard
 	// type that matches a tuple with any number of fields of any type (including
 	// tuple types). Execution-time values should never have this type.
@@ -811,7 +930,7 @@ func MakeScalar(family Family, o oid.Oid, precision, width int32, locale string)
 			panic(errors.AssertionFailedf(
 				"decimal scale %d cannot be larger than precision %d", width, precision))
 		}
-	case StringFamily, BytesFamily, CollatedStringFamily, BitFamily:
+	case StringFamily, BytesFamily, CollatedStringFamily, BitFamily, PGVectorFamily:
 		// These types can have any width.
 	case GeometryFamily:
 		geoMetadata = &GeoMetadata{}
@@ -1141,6 +1260,23 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeDomain constructs a new user-defined DOMAIN type with the given stable
+// type IDs over the given base type. The domain shares the family, OID and
+// all other attributes of the base type, so that its values are represented
+// and operated upon exactly like values of the base type. Note that it does
+// not hydrate cached fields on the type.
+func MakeDomain(typeOID, arrayTypeOID oid.Oid, base *T) *T {
+	if base.UserDefined() {
+		panic(errors.AssertionFailedf("domain base type %s cannot be user-defined", base.SQLString()))
+	}
+	typ := &T{InternalType: base.InternalType}
+	typ.InternalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		ArrayTypeOID: arrayTypeOID,
+		DomainOID:    &typeOID,
+	}
+	return typ
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1253,6 +1389,19 @@ func (t *T) Locale() string {
 	return *t.InternalType.Locale
 }
 
+// MakePGVector constructs a new instance of the VECTOR type (oid = T_pgvector)
+// having the given number of dimensions (0 = unspecified number).
+func MakePGVector(dims int32) *T {
+	if dims == 0 {
+		return PGVector
+	}
+	if dims < 0 {
+		panic(errors.AssertionFailedf("dimensions %d cannot be negative", dims))
+	}
+	return &T{InternalType: InternalType{
+		Family: PGVectorFamily, Oid: oidext.T_pgvector, Width: dims, Locale: &emptyLocale}}
+}
+
 // Width is the size or scale of the type, such as number of bits or characters.
 //
 //	INT           : # of bits (64, 32, 16)
@@ -1261,6 +1410,7 @@ func (t *T) Locale() string {
 //	STRING        : max # of characters
 //	COLLATEDSTRING: max # of characters
 //	BIT           : max # of bits
+//	VECTOR        : # of dimensions
 //
 // Width is always 0 for other types.
 func (t *T) Width() int32 {
@@ -1311,7 +1461,7 @@ func (t *T) TypeModifier() int32 {
 			// var header size.
 			return width + 4
 		}
-	case BitFamily:
+	case BitFamily, PGVectorFamily:
 		if width := t.Width(); width != 0 {
 			return width
 		}
@@ -1399,6 +1549,71 @@ func (t *T) ArrayContents() *T {
 	return t.InternalType.ArrayContents
 }
 
+// RangeContents returns the type of the bounds (the subtype) of a range or
+// multirange type. This is nil for other types, as well as for the AnyRange
+// and AnyMultirange wildcards.
+func (t *T) RangeContents() *T {
+	switch t.Family() {
+	case RangeFamily:
+		return rangeContents[t.Oid()]
+	case MultirangeFamily:
+		if r := MultirangeRange(t); r != nil {
+			return rangeContents[r.Oid()]
+		}
+	}
+	return nil
+}
+
+var rangeContents = map[oid.Oid]*T{
+	oid.T_int4range: Int4,
+	oid.T_int8range: Int,
+	oid.T_numrange:  Decimal,
+	oid.T_tsrange:   Timestamp,
+	oid.T_tstzrange: TimestampTZ,
+	oid.T_daterange: Date,
+}
+
+// MultirangeRange returns the range type of the elements of the given
+// multirange type, or nil if there is no such type.
+func MultirangeRange(t *T) *T {
+	for i := range Multiranges {
+		if Multiranges[i].Oid() == t.Oid() {
+			return Ranges[i]
+		}
+	}
+	if t.Oid() == oidext.T_anymultirange {
+		return AnyRange
+	}
+	return nil
+}
+
+// RangeMultirange returns the multirange type whose elements have the given
+// range type, or nil if there is no such type.
+func RangeMultirange(t *T) *T {
+	for i := range Ranges {
+		if Ranges[i].Oid() == t.Oid() {
+			return Multiranges[i]
+		}
+	}
+	if t.Oid() == oid.T_anyrange {
+		return AnyMultirange
+	}
+	return nil
+}
+
+// IsDiscreteRangeType returns true if the given range or multirange type has
+// a discrete subtype. Ranges of discrete subtypes are stored in the canonical
+// form "[lower, upper)".
+func IsDiscreteRangeType(t *T) bool {
+	if c := t.RangeContents(); c != nil {
+		switch c.Family() {
+		case IntFamily, DateFamily:
+			return true
+		}
+	}
+	return false
+}
+
 // TupleContents returns a slice containing the type of each tuple field. This
 // is nil for non-TupleFamily types.
 func (t *T) TupleContents() []*T {
@@ -1429,6 +1644,16 @@ func (t *T) UserDefinedArrayOID() oid.Oid {
 // be used when type is known to not be shared. If the input oid values are
 // 0 then the RemapUserDefinedTypeOIDs has no effect.
 func RemapUserDefinedTypeOIDs(t *T, newOID, newArrayOID oid.Oid) {
+	if t.IsDomain() {
+		// The OID of a domain is that of its base type, which is never remapped.
+		if newOID != 0 {
+			t.InternalType.UDTMetadata.DomainOID = &newOID
+		}
+		if newArrayOID != 0 {
+			t.InternalType.UDTMetadata.ArrayTypeOID = newArrayOID
+		}
+		return
+	}
 	if newOID != 0 {
 		t.InternalType.Oid = newOID
 	}
@@ -1439,7 +1664,33 @@ func RemapUserDefinedTypeOIDs(t *T, newOID, newArrayOID oid.Oid) {
 
 // UserDefined returns whether or not t is a user defined type.
 func (t *T) UserDefined() bool {
-	return IsOIDUserDefinedType(t.Oid())
+	return t.IsDomain() || IsOIDUserDefinedType(t.Oid())
+}
+
+// UserDefinedOID returns the OID of the descriptor that defines t. This is
+// the same as Oid for all types except domains, whose Oid is that of their
+// base type.
+func (t *T) UserDefinedOID() oid.Oid {
+	if t.IsDomain() {
+		return *t.InternalType.UDTMetadata.DomainOID
+	}
+	return t.Oid()
+}
+
+// IsDomain returns whether or not t is a user-defined DOMAIN type.
+func (t *T) IsDomain() bool {
+	return t.InternalType.UDTMetadata != nil && t.InternalType.UDTMetadata.DomainOID != nil
+}
+
+// DomainBaseType returns the base type of a DOMAIN type. It returns t itself
+// if t is not a domain.
+func (t *T) DomainBaseType() *T {
+	if !t.IsDomain() {
+		return t
+	}
+	base := &T{InternalType: t.InternalType}
+	base.InternalType.UDTMetadata = nil
+	return base
 }
 
 // IsOIDUserDefinedType returns whether or not o corresponds to a user
@@ -1466,7 +1717,11 @@ var familyNames = map[Family]string{
 	IntFamily:            "int",
 	IntervalFamily:       "interval",
 	JsonFamily:           "jsonb",
+	JsonpathFamily:       "jsonpath",
+	MultirangeFamily:     "multirange",
 	OidFamily:            "oid",
+	PGVectorFamily:       "vector",
+	RangeFamily:          "range",
 	StringFamily:         "string",
 	TimeFamily:           "time",
 	TimestampFamily:      "timestamp",
@@ -1500,6 +1755,9 @@ func (f Family) Name() string {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.Basename()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
 		return "anyelement"
@@ -1541,7 +1799,7 @@ func (t *T) Name() string {
 			panic(errors.AssertionFailedf("programming error: unknown int width: %d", t.Width()))
 		}
 
-	case OidFamily:
+	case OidFamily, RangeFamily, MultirangeFamily:
 		return t.SQLStandardName()
 
 	case StringFamily, CollatedStringFamily:
@@ -1563,6 +1821,12 @@ func (t *T) Name() string {
 	case TupleFamily:
 		return t.SQLStandardName()
 
+	case VoidFamily:
+		if t.Oid() == oid.T_trigger {
+			return "trigger"
+		}
+		return "void"
+
 	case EnumFamily:
 		if t.Oid() == oid.T_anyenum {
 			return "anyenum"
@@ -1589,6 +1853,9 @@ func (t *T) Name() string {
 //	bytes        bytea
 //	int4[]       _int4
 func (t *T) PGName() string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.Basename()
+	}
 	name, ok := oidext.TypeName(t.Oid())
 	if ok {
 		return strings.ToLower(name)
@@ -1637,6 +1904,9 @@ func (t *T) TelemetryName() string {
 // This function is full of special cases. See backend/utils/adt/format_type.c
 // in Postgres.
 func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.Basename()
+	}
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
@@ -1795,6 +2065,15 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		return "tsquery"
 	case TSVectorFamily:
 		return "tsvector"
+	case JsonpathFamily:
+		return "jsonpath"
+	case PGVectorFamily:
+		if !haveTypmod || typmod <= 0 {
+			return "vector"
+		}
+		return fmt.Sprintf("vector(%d)", typmod)
+	case RangeFamily, MultirangeFamily:
+		return t.PGName()
 	case TupleFamily:
 		if t.UserDefined() {
 			// If we have a user-defined tuple type, use its user-defined name.
@@ -1806,6 +2085,9 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 	case UuidFamily:
 		return "uuid"
 	case VoidFamily:
+		if t.Oid() == oid.T_trigger {
+			return "trigger"
+		}
 		return "void"
 	case EnumFamily:
 		return t.TypeMeta.Name.Basename()
@@ -1835,6 +2117,9 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.FQName()
+	}
 	switch t.Family() {
 	case BitFamily:
 		o := t.Oid()
@@ -1886,6 +2171,10 @@ func (t *T) SQLString() string {
 		}
 	case GeometryFamily, GeographyFamily:
 		return strings.ToUpper(t.Name() + t.InternalType.GeoMetadata.SQLString())
+	case PGVectorFamily:
+		if t.Width() > 0 {
+			return fmt.Sprintf("VECTOR(%d)", t.Width())
+		}
 	case IntervalFamily:
 		switch t.InternalType.IntervalDurationField.DurationType {
 		case IntervalDurationType_UNSET:
@@ -1999,6 +2288,16 @@ func (t *T) Equivalent(other *T) bool {
 		if t.Oid() != other.Oid() {
 			return false
 		}
+
+	case RangeFamily, MultirangeFamily:
+		// The wildcard types match any range or multirange type. Otherwise, the
+		// subtypes (which are determined by the OID) must match.
+		if t.RangeContents() == nil || other.RangeContents() == nil {
+			return true
+		}
+		if t.Oid() != other.Oid() {
+			return false
+		}
 	}
 
 	return true
@@ -2148,6 +2447,13 @@ func (t *InternalType) Identical(other *InternalType) bool {
 		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID {
 			return false
 		}
+		if t.UDTMetadata.DomainOID != nil && other.UDTMetadata.DomainOID != nil {
+			if *t.UDTMetadata.DomainOID != *other.UDTMetadata.DomainOID {
+				return false
+			}
+		} else if t.UDTMetadata.DomainOID != nil || other.UDTMetadata.DomainOID != nil {
+			return false
+		}
 	} else if t.UDTMetadata != nil {
 		return false
 	} else if other.UDTMetadata != nil {
@@ -2487,6 +2793,9 @@ func (t *T) downgradeType() error {
 // TODO(andyk): It'd be nice to have this return SqlString() method output,
 // since that is more descriptive.
 func (t *T) String() string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.Name()
+	}
 	switch t.Family() {
 	case CollatedStringFamily:
 		if t.Locale() == "" {
@@ -2581,6 +2890,8 @@ func (t *T) IsAmbiguous() bool {
 		return t.ArrayContents().IsAmbiguous()
 	case EnumFamily:
 		return t.Oid() == oid.T_anyenum
+	case RangeFamily, MultirangeFamily:
+		return t.RangeContents() == nil
 	}
 	return false
 }
@@ -2664,6 +2975,10 @@ func IsValidArrayElementType(t *T) (valid bool, issueNum int) {
 		return false, 90886
 	case TSVectorFamily:
 		return false, 90886
+	case JsonpathFamily:
+		return false, 22513
+	case RangeFamily, MultirangeFamily:
+		return false, 27791
 	default:
 		return true, 0
 	}
@@ -2876,7 +3191,6 @@ var postgresPredefinedTypeIssues = map[string]int{
 	"box":           21286,
 	"cidr":          18846,
 	"circle":        21286,
-	"jsonpath":      22513,
 	"line":          21286,
 	"lseg":          21286,
 	"macaddr":       45813,
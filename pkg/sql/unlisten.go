@@ -13,17 +13,21 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
+// Unlisten implements the UNLISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-unlisten.html.
 func (p *planner) Unlisten(ctx context.Context, n *tree.Unlisten) (planNode, error) {
-	p.BufferClientNotice(ctx,
-		pgerror.WithSeverity(
-			unimplemented.NewWithIssuef(41522, "CRDB does not support LISTEN, making UNLISTEN a no-op"),
-			"NOTICE",
-		),
-	)
+	if p.notifications == nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported, "UNLISTEN is not supported in this context")
+	}
+	op := listenOp{unlisten: true}
+	if !n.Star {
+		op.channel = n.ChannelName.Object()
+	}
+	p.notifications.listenOps = append(p.notifications.listenOps, op)
 	return newZeroNode(nil /* columns */), nil
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/build"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -47,9 +48,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -398,20 +402,47 @@ var varGen = map[string]sessionVar{
 		GlobalDefault: func(sv *settings.Values) string { return "" },
 	},
 
-	// See https://www.postgresql.org/docs/10/static/runtime-config-client.html#GUC-DEFAULT-TRANSACTION-ISOLATION
-	`default_transaction_isolation`: {
+	// See https://www.postgresql.org/docs/15/runtime-config-client.html#GUC-DEFAULT-TEXT-SEARCH-CONFIG
+	`default_text_search_config`: {
 		Set: func(_ context.Context, m sessionDataMutator, s string) error {
-			switch strings.ToUpper(s) {
-			case `READ UNCOMMITTED`, `READ COMMITTED`, `SNAPSHOT`, `REPEATABLE READ`, `SERIALIZABLE`, `DEFAULT`:
-				// Do nothing. All transactions execute with serializable isolation.
-			default:
-				return newVarValueError(`default_transaction_isolation`, s, "serializable")
+			name, err := tsearch.NormalizeConfigName(s)
+			if err != nil {
+				return err
 			}
+			m.SetDefaultTextSearchConfig(name)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return evalCtx.SessionData().DefaultTextSearchConfig, nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return tsearch.DefaultConfig
+		},
+	},
 
+	// See https://www.postgresql.org/docs/10/static/runtime-config-client.html#GUC-DEFAULT-TRANSACTION-ISOLATION
+	`default_transaction_isolation`: {
+		Set: func(ctx context.Context, m sessionDataMutator, s string) error {
+			level, ok := tree.IsolationLevelMap[strings.ToLower(s)]
+			if !ok {
+				if !strings.EqualFold(s, "default") {
+					return newVarValueError(`default_transaction_isolation`, s,
+						"read committed", "serializable")
+				}
+				level = tree.SerializableIsolation
+			}
+			if err := checkIsolationLevelActive(ctx, m.settings, level); err != nil {
+				return err
+			}
+			m.SetDefaultTransactionIsolationLevel(level)
 			return nil
 		},
 		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
-			return "serializable", nil
+			level := tree.IsolationLevel(evalCtx.SessionData().DefaultTxnIsolationLevel)
+			if level == tree.UnspecifiedIsolation {
+				level = tree.SerializableIsolation
+			}
+			return strings.ToLower(level.String()), nil
 		},
 		GlobalDefault: func(sv *settings.Values) string { return "default" },
 	},
@@ -1208,13 +1239,80 @@ var varGen = map[string]sessionVar{
 			}
 			if f < 0 || f > 1 {
 				return pgerror.Newf(pgcode.InvalidParameterValue,
-					"%f is out of range for similarity_threshold")
+					"%f is out of range for similarity_threshold", f)
 			}
 			m.SetTrigramSimilarityThreshold(f)
 			return nil
 		},
 	},
 
+	`pg_trgm.word_similarity_threshold`: {
+		GetStringVal: makeFloatGetStringValFn(`pg_trgm.word_similarity_threshold`),
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatFloatAsPostgresSetting(evalCtx.SessionData().TrigramWordSimilarityThreshold), nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return "0.6"
+		},
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return err
+			}
+			if f < 0 || f > 1 {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"%f is out of range for word_similarity_threshold", f)
+			}
+			m.SetTrigramWordSimilarityThreshold(f)
+			return nil
+		},
+	},
+
+	`pg_trgm.strict_word_similarity_threshold`: {
+		GetStringVal: makeFloatGetStringValFn(`pg_trgm.strict_word_similarity_threshold`),
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatFloatAsPostgresSetting(evalCtx.SessionData().TrigramStrictWordSimilarityThreshold), nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return "0.5"
+		},
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return err
+			}
+			if f < 0 || f > 1 {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"%f is out of range for strict_word_similarity_threshold", f)
+			}
+			m.SetTrigramStrictWordSimilarityThreshold(f)
+			return nil
+		},
+	},
+
+	`ivfflat.probes`: {
+		GetStringVal: makeIntGetStringValFn(`ivfflat.probes`),
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return strconv.FormatInt(evalCtx.SessionData().VectorSearchProbes, 10), nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return strconv.Itoa(vector.DefaultProbes)
+		},
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			i, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return wrapSetVarError(err, "ivfflat.probes", s)
+			}
+			if i < 1 || i > vector.MaxLists {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"%d is outside the valid range for parameter \"ivfflat.probes\" (1 .. %d)",
+					i, vector.MaxLists)
+			}
+			m.SetVectorSearchProbes(i)
+			return nil
+		},
+	},
+
 	// CockroachDB extension.
 	`troubleshooting_mode`: {
 		GetStringVal: makePostgresBoolGetStringValFn(`troubleshooting_mode`),
@@ -1414,15 +1512,20 @@ var varGen = map[string]sessionVar{
 	// This is not directly documented in PG's docs but does indeed behave this way.
 	// See https://github.com/postgres/postgres/blob/REL_10_STABLE/src/backend/utils/misc/guc.c#L3401-L3409
 	`transaction_isolation`: {
-		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
-			return "serializable", nil
+		Get: func(evalCtx *extendedEvalContext, txn *kv.Txn) (string, error) {
+			level := tree.SerializableIsolation
+			if txn.IsoLevel() == isolation.ReadCommitted {
+				level = tree.ReadCommittedIsolation
+			}
+			return strings.ToLower(level.String()), nil
 		},
-		RuntimeSet: func(_ context.Context, evalCtx *extendedEvalContext, local bool, s string) error {
-			_, ok := tree.IsolationLevelMap[s]
+		RuntimeSet: func(ctx context.Context, evalCtx *extendedEvalContext, local bool, s string) error {
+			level, ok := tree.IsolationLevelMap[strings.ToLower(s)]
 			if !ok {
-				return newVarValueError(`transaction_isolation`, s, "serializable")
+				return newVarValueError(`transaction_isolation`, s, "read committed", "serializable")
 			}
-			return nil
+			return evalCtx.TxnModesSetter.setTransactionModes(
+				ctx, tree.TransactionModes{Isolation: level}, hlc.Timestamp{} /* asOfTs */)
 		},
 		GlobalDefault: func(_ *settings.Values) string { return "serializable" },
 	},
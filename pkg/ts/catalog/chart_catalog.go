@@ -2518,6 +2518,29 @@ var charts = []sectionDescription{
 			},
 		},
 	},
+	{
+		Organization: [][]string{{SQLLayer, "Materialized View Maintenance"}},
+		Charts: []chartDescription{
+			{
+				Title: "Jobs Running",
+				Metrics: []string{
+					"jobs.materialized_view_maintenance.currently_running",
+					"jobs.materialized_view_maintenance.currently_idle",
+				},
+			},
+			{
+				Title: "Jobs Statistics",
+				Metrics: []string{
+					"jobs.materialized_view_maintenance.fail_or_cancel_completed",
+					"jobs.materialized_view_maintenance.fail_or_cancel_failed",
+					"jobs.materialized_view_maintenance.fail_or_cancel_retry_error",
+					"jobs.materialized_view_maintenance.resume_completed",
+					"jobs.materialized_view_maintenance.resume_failed",
+					"jobs.materialized_view_maintenance.resume_retry_error",
+				},
+			},
+		},
+	},
 	{
 		Organization: [][]string{{SQLLayer, "SQL Memory", "Internal"}},
 		Charts: []chartDescription{
@@ -104,6 +104,17 @@ const (
 	emptyArray = geoInvertedIndexMarker + 1
 	voidMarker = emptyArray + 1
 
+	// Markers and terminators for key encoding ranges and multiranges in
+	// sorted order. Like arrays, they need separate markers for both
+	// directions so that they can be decoded without knowing the direction.
+	rangeKeyMarker                = voidMarker + 1
+	rangeKeyDescendingMarker      = rangeKeyMarker + 1
+	multirangeKeyMarker           = rangeKeyDescendingMarker + 1
+	multirangeKeyDescendingMarker = multirangeKeyMarker + 1
+
+	multirangeKeyTerminator           byte = 0x00
+	multirangeKeyDescendingTerminator byte = 0xFF
+
 	arrayKeyTerminator           byte = 0x00
 	arrayKeyDescendingTerminator byte = 0xFF
 	// We use different null encodings for nulls within key arrays. Doing this
@@ -1665,20 +1676,25 @@ const (
 	// value requires more than 4 bits, and thus will be encoded in two bytes. It
 	// is not used as a type value, and thus intentionally overlaps with the
 	// subsequent type value. The 'Type' annotation is intentionally omitted here.
-	SentinelType      = 15
-	JSON         Type = 15
-	Tuple        Type = 16
-	BitArray     Type = 17
-	BitArrayDesc Type = 18 // BitArray encoded descendingly
-	TimeTZ       Type = 19
-	Geo          Type = 20
-	GeoDesc      Type = 21
-	ArrayKeyAsc  Type = 22 // Array key encoding
-	ArrayKeyDesc Type = 23 // Array key encoded descendingly
-	Box2D        Type = 24
-	Void         Type = 25
-	TSQuery      Type = 26
-	TSVector     Type = 27
+	SentinelType           = 15
+	JSON              Type = 15
+	Tuple             Type = 16
+	BitArray          Type = 17
+	BitArrayDesc      Type = 18 // BitArray encoded descendingly
+	TimeTZ            Type = 19
+	Geo               Type = 20
+	GeoDesc           Type = 21
+	ArrayKeyAsc       Type = 22 // Array key encoding
+	ArrayKeyDesc      Type = 23 // Array key encoded descendingly
+	Box2D             Type = 24
+	Void              Type = 25
+	TSQuery           Type = 26
+	TSVector          Type = 27
+	RangeKeyAsc       Type = 28 // Range key encoding
+	RangeKeyDesc      Type = 29 // Range key encoded descendingly
+	MultirangeKeyAsc  Type = 30 // Multirange key encoding
+	MultirangeKeyDesc Type = 31 // Multirange key encoded descendingly
+	PGVector          Type = 32
 )
 
 // typMap maps an encoded type byte to a decoded Type. It's got 256 slots, one
@@ -1715,6 +1731,14 @@ func slowPeekType(b []byte) Type {
 			return ArrayKeyAsc
 		case m == arrayKeyDescendingMarker:
 			return ArrayKeyDesc
+		case m == rangeKeyMarker:
+			return RangeKeyAsc
+		case m == rangeKeyDescendingMarker:
+			return RangeKeyDesc
+		case m == multirangeKeyMarker:
+			return MultirangeKeyAsc
+		case m == multirangeKeyDescendingMarker:
+			return MultirangeKeyDesc
 		case m == bytesMarker:
 			return Bytes
 		case m == bytesDescMarker:
@@ -1805,6 +1829,60 @@ func getArrayLength(buf []byte, dir Direction) (int, error) {
 	return result, nil
 }
 
+// getRangeLength returns the length of a key encoded range. The input must
+// have had the range type marker stripped from the front.
+func getRangeLength(buf []byte, dir Direction) (int, error) {
+	result := 0
+	for _, isLower := range []bool{true, false} {
+		rest, kind, err := DecodeRangeKeyBoundKind(buf, dir)
+		if err != nil {
+			return 0, err
+		}
+		result += len(buf) - len(rest)
+		buf = rest
+		switch kind {
+		case RangeKeyEmpty:
+			return result, nil
+		case RangeKeyFinite:
+			n, err := PeekLength(buf)
+			if err != nil {
+				return 0, err
+			}
+			buf = buf[n:]
+			rest, _, err := DecodeRangeKeyBoundInclusive(buf, isLower, dir)
+			if err != nil {
+				return 0, err
+			}
+			result += n + len(buf) - len(rest)
+			buf = rest
+		}
+	}
+	return result, nil
+}
+
+// getMultirangeLength returns the length of a key encoded multirange. The
+// input must have had the multirange type marker stripped from the front.
+func getMultirangeLength(buf []byte, dir Direction) (int, error) {
+	result := 0
+	for {
+		if len(buf) == 0 {
+			return 0, errors.AssertionFailedf("invalid multirange encoding (unterminated)")
+		}
+		if IsMultirangeKeyDone(buf, dir) {
+			// Increment to include the terminator byte.
+			result++
+			break
+		}
+		next, err := PeekLength(buf)
+		if err != nil {
+			return 0, err
+		}
+		buf = buf[next:]
+		result += next
+	}
+	return result, nil
+}
+
 // peekBox2DLength peeks to look at the length of a box2d encoding.
 func peekBox2DLength(b []byte) (int, error) {
 	length := 0
@@ -1865,6 +1943,20 @@ func PeekLength(b []byte) (int, error) {
 		}
 		length, err := getArrayLength(b[1:], dir)
 		return 1 + length, err
+	case rangeKeyMarker, rangeKeyDescendingMarker:
+		dir := Ascending
+		if m == rangeKeyDescendingMarker {
+			dir = Descending
+		}
+		length, err := getRangeLength(b[1:], dir)
+		return 1 + length, err
+	case multirangeKeyMarker, multirangeKeyDescendingMarker:
+		dir := Ascending
+		if m == multirangeKeyDescendingMarker {
+			dir = Descending
+		}
+		length, err := getMultirangeLength(b[1:], dir)
+		return 1 + length, err
 	case bytesMarker:
 		return getBytesLength(b, ascendingBytesEscapes)
 	case box2DMarker:
@@ -2106,6 +2198,43 @@ func prettyPrintFirstValue(dir Direction, b []byte) ([]byte, string, error) {
 		}
 		build.WriteString("]")
 		return buf, build.String(), nil
+	case RangeKeyAsc, RangeKeyDesc:
+		encDir := Ascending
+		if typ == RangeKeyDesc {
+			encDir = Descending
+		}
+		return prettyPrintRangeKey(b, encDir)
+	case MultirangeKeyAsc, MultirangeKeyDesc:
+		encDir := Ascending
+		if typ == MultirangeKeyDesc {
+			encDir = Descending
+		}
+		buf, err := ValidateAndConsumeMultirangeKeyMarker(b, encDir)
+		if err != nil {
+			return nil, "", err
+		}
+		var build strings.Builder
+		build.WriteString("{")
+		for first := true; ; first = false {
+			if len(buf) == 0 {
+				return nil, "", errors.AssertionFailedf("invalid multirange (unterminated)")
+			}
+			if IsMultirangeKeyDone(buf, encDir) {
+				buf = buf[1:]
+				break
+			}
+			var next string
+			buf, next, err = prettyPrintRangeKey(buf, encDir)
+			if err != nil {
+				return nil, "", err
+			}
+			if !first {
+				build.WriteString(",")
+			}
+			build.WriteString(next)
+		}
+		build.WriteString("}")
+		return buf, build.String(), nil
 	case NotNull:
 		b, _ = DecodeIfNotNull(b)
 		return b, "!NULL", nil
@@ -2654,6 +2783,14 @@ func EncodeTSVectorValue(appendTo []byte, colID uint32, data []byte) []byte {
 	return EncodeUntaggedBytesValue(appendTo, data)
 }
 
+// EncodePGVectorValue encodes an already-byte-encoded PGVector value with no
+// value tag but with a length prefix, appends it to the supplied buffer, and
+// returns the final buffer.
+func EncodePGVectorValue(appendTo []byte, colID uint32, data []byte) []byte {
+	appendTo = EncodeValueTag(appendTo, colID, PGVector)
+	return EncodeUntaggedBytesValue(appendTo, data)
+}
+
 // DecodeValueTag decodes a value encoded by EncodeValueTag, used as a prefix in
 // each of the other EncodeFooValue methods.
 //
@@ -3042,7 +3179,7 @@ func PeekValueLengthWithOffsetsAndType(b []byte, dataOffset int, typ Type) (leng
 		return dataOffset + n, err
 	case Float:
 		return dataOffset + floatValueEncodedLength, nil
-	case Bytes, Array, JSON, Geo, TSVector, TSQuery:
+	case Bytes, Array, JSON, Geo, TSVector, TSQuery, PGVector:
 		_, n, i, err := DecodeNonsortingUvarint(b)
 		return dataOffset + n + int(i), err
 	case Box2D:
@@ -3337,6 +3474,217 @@ func IsArrayKeyDone(buf []byte, dir Direction) bool {
 	return buf[0] == expected
 }
 
+// RangeKeyBoundKind describes a bound of a key encoded range. Since a bound
+// kind is encoded in front of the bound's value, the kinds are ordered such
+// that empty ranges sort before all other ranges, and infinite bounds sort
+// before (lower) or after (upper) all finite bounds.
+type RangeKeyBoundKind byte
+
+const (
+	// RangeKeyEmpty is encoded instead of the lower bound of an empty range.
+	// Empty ranges have no upper bound.
+	RangeKeyEmpty RangeKeyBoundKind = iota
+	// RangeKeyLowerInfinite is an infinite lower bound.
+	RangeKeyLowerInfinite
+	// RangeKeyFinite is a finite bound. It is followed by the key encoding of
+	// the bound's value and by its inclusivity.
+	RangeKeyFinite
+	// RangeKeyUpperInfinite is an infinite upper bound.
+	RangeKeyUpperInfinite
+)
+
+// EncodeRangeKeyMarker adds the range key encoding marker to buf and
+// returns the new buffer.
+func EncodeRangeKeyMarker(buf []byte, dir Direction) []byte {
+	switch dir {
+	case Ascending:
+		return append(buf, rangeKeyMarker)
+	case Descending:
+		return append(buf, rangeKeyDescendingMarker)
+	default:
+		panic("invalid direction")
+	}
+}
+
+// ValidateAndConsumeRangeKeyMarker checks that the marker at the front of buf
+// is valid for a range of the given direction, and consumes it if so. It
+// returns an error if the tag is invalid.
+func ValidateAndConsumeRangeKeyMarker(buf []byte, dir Direction) ([]byte, error) {
+	typ := PeekType(buf)
+	expected := RangeKeyAsc
+	if dir == Descending {
+		expected = RangeKeyDesc
+	}
+	if typ != expected {
+		return nil, errors.Newf("invalid type found %s", typ)
+	}
+	return buf[1:], nil
+}
+
+// EncodeRangeKeyBoundKind adds the kind of a range bound to buf and returns
+// the new buffer.
+func EncodeRangeKeyBoundKind(buf []byte, kind RangeKeyBoundKind, dir Direction) []byte {
+	switch dir {
+	case Ascending:
+		return append(buf, byte(kind))
+	case Descending:
+		return append(buf, ^byte(kind))
+	default:
+		panic("invalid direction")
+	}
+}
+
+// DecodeRangeKeyBoundKind decodes the kind of a range bound from the front of
+// buf.
+func DecodeRangeKeyBoundKind(buf []byte, dir Direction) ([]byte, RangeKeyBoundKind, error) {
+	if len(buf) == 0 {
+		return nil, 0, errors.AssertionFailedf("invalid range encoding (unterminated)")
+	}
+	b := buf[0]
+	if dir == Descending {
+		b = ^b
+	}
+	if b > byte(RangeKeyUpperInfinite) {
+		return nil, 0, errors.AssertionFailedf("invalid range bound kind %x", buf[0])
+	}
+	return buf[1:], RangeKeyBoundKind(b), nil
+}
+
+// EncodeRangeKeyBoundInclusive adds the inclusivity of a finite range bound to
+// buf and returns the new buffer. An inclusive lower bound sorts before an
+// exclusive one with the same value, and the opposite is true for upper
+// bounds.
+func EncodeRangeKeyBoundInclusive(buf []byte, inclusive, isLower bool, dir Direction) []byte {
+	var b byte
+	if inclusive != isLower {
+		b = 1
+	}
+	if dir == Descending {
+		b = ^b
+	}
+	return append(buf, b)
+}
+
+// DecodeRangeKeyBoundInclusive decodes the inclusivity of a finite range bound
+// from the front of buf.
+func DecodeRangeKeyBoundInclusive(buf []byte, isLower bool, dir Direction) ([]byte, bool, error) {
+	if len(buf) == 0 {
+		return nil, false, errors.AssertionFailedf("invalid range encoding (unterminated)")
+	}
+	b := buf[0]
+	if dir == Descending {
+		b = ^b
+	}
+	if b > 1 {
+		return nil, false, errors.AssertionFailedf("invalid range bound inclusivity %x", buf[0])
+	}
+	return buf[1:], (b == 1) != isLower, nil
+}
+
+// EncodeMultirangeKeyMarker adds the multirange key encoding marker to buf and
+// returns the new buffer.
+func EncodeMultirangeKeyMarker(buf []byte, dir Direction) []byte {
+	switch dir {
+	case Ascending:
+		return append(buf, multirangeKeyMarker)
+	case Descending:
+		return append(buf, multirangeKeyDescendingMarker)
+	default:
+		panic("invalid direction")
+	}
+}
+
+// EncodeMultirangeKeyTerminator adds the multirange key terminator to buf and
+// returns the new buffer.
+func EncodeMultirangeKeyTerminator(buf []byte, dir Direction) []byte {
+	switch dir {
+	case Ascending:
+		return append(buf, multirangeKeyTerminator)
+	case Descending:
+		return append(buf, multirangeKeyDescendingTerminator)
+	default:
+		panic("invalid direction")
+	}
+}
+
+// ValidateAndConsumeMultirangeKeyMarker checks that the marker at the front of
+// buf is valid for a multirange of the given direction, and consumes it if so.
+// It returns an error if the tag is invalid.
+func ValidateAndConsumeMultirangeKeyMarker(buf []byte, dir Direction) ([]byte, error) {
+	typ := PeekType(buf)
+	expected := MultirangeKeyAsc
+	if dir == Descending {
+		expected = MultirangeKeyDesc
+	}
+	if typ != expected {
+		return nil, errors.Newf("invalid type found %s", typ)
+	}
+	return buf[1:], nil
+}
+
+// IsMultirangeKeyDone returns if the first byte in the input is the multirange
+// terminator for the input direction.
+func IsMultirangeKeyDone(buf []byte, dir Direction) bool {
+	expected := multirangeKeyTerminator
+	if dir == Descending {
+		expected = multirangeKeyDescendingTerminator
+	}
+	return buf[0] == expected
+}
+
+// prettyPrintRangeKey returns a string representation of the key encoded
+// range at the front of b, along with the remaining byte slice.
+func prettyPrintRangeKey(b []byte, dir Direction) ([]byte, string, error) {
+	buf, err := ValidateAndConsumeRangeKeyMarker(b, dir)
+	if err != nil {
+		return nil, "", err
+	}
+	var build strings.Builder
+	for _, isLower := range []bool{true, false} {
+		var kind RangeKeyBoundKind
+		buf, kind, err = DecodeRangeKeyBoundKind(buf, dir)
+		if err != nil {
+			return nil, "", err
+		}
+		switch kind {
+		case RangeKeyEmpty:
+			return buf, "empty", nil
+		case RangeKeyLowerInfinite:
+			build.WriteString("(")
+		case RangeKeyUpperInfinite:
+			build.WriteString(")")
+		case RangeKeyFinite:
+			var val string
+			buf, val, err = prettyPrintFirstValue(dir, buf)
+			if err != nil {
+				return nil, "", err
+			}
+			var inclusive bool
+			buf, inclusive, err = DecodeRangeKeyBoundInclusive(buf, isLower, dir)
+			if err != nil {
+				return nil, "", err
+			}
+			switch {
+			case isLower && inclusive:
+				build.WriteString("[")
+			case isLower:
+				build.WriteString("(")
+			}
+			build.WriteString(val)
+			switch {
+			case !isLower && inclusive:
+				build.WriteString("]")
+			case !isLower:
+				build.WriteString(")")
+			}
+		}
+		if isLower {
+			build.WriteString(",")
+		}
+	}
+	return buf, build.String(), nil
+}
+
 // BytesNext returns the next possible byte slice, using the extra capacity
 // of the provided slice if possible, and if not, appending an \x00.
 func BytesNext(b []byte) []byte {
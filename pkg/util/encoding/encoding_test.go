@@ -933,6 +933,87 @@ func TestPrettyPrintValue(t *testing.T) {
 	}
 }
 
+func TestEncodeRangeKey(t *testing.T) {
+	type bound struct {
+		val       int64
+		inclusive bool
+		infinite  bool
+	}
+	encodeRange := func(b []byte, lower, upper bound, empty bool, dir Direction) []byte {
+		encodeVal := EncodeVarintAscending
+		if dir == Descending {
+			encodeVal = EncodeVarintDescending
+		}
+		b = EncodeRangeKeyMarker(b, dir)
+		if empty {
+			return EncodeRangeKeyBoundKind(b, RangeKeyEmpty, dir)
+		}
+		if lower.infinite {
+			b = EncodeRangeKeyBoundKind(b, RangeKeyLowerInfinite, dir)
+		} else {
+			b = EncodeRangeKeyBoundKind(b, RangeKeyFinite, dir)
+			b = encodeVal(b, lower.val)
+			b = EncodeRangeKeyBoundInclusive(b, lower.inclusive, true /* isLower */, dir)
+		}
+		if upper.infinite {
+			return EncodeRangeKeyBoundKind(b, RangeKeyUpperInfinite, dir)
+		}
+		b = EncodeRangeKeyBoundKind(b, RangeKeyFinite, dir)
+		b = encodeVal(b, upper.val)
+		return EncodeRangeKeyBoundInclusive(b, upper.inclusive, false /* isLower */, dir)
+	}
+	inf := bound{infinite: true}
+	// The ranges are listed in ascending order.
+	testCases := []struct {
+		lower, upper bound
+		empty        bool
+		exp          string
+	}{
+		{empty: true, exp: "empty"},
+		{lower: inf, upper: bound{val: 1}, exp: "(,1)"},
+		{lower: inf, upper: inf, exp: "(,)"},
+		{lower: bound{val: 1, inclusive: true}, upper: bound{val: 5}, exp: "[1,5)"},
+		{lower: bound{val: 1, inclusive: true}, upper: bound{val: 5, inclusive: true}, exp: "[1,5]"},
+		{lower: bound{val: 1, inclusive: true}, upper: inf, exp: "[1,)"},
+		{lower: bound{val: 1}, upper: bound{val: 5}, exp: "(1,5)"},
+		{lower: bound{val: 2, inclusive: true}, upper: bound{val: 3}, exp: "[2,3)"},
+	}
+	for _, dir := range []Direction{Ascending, Descending} {
+		var prev []byte
+		var multirange []byte
+		multirange = EncodeMultirangeKeyMarker(multirange, dir)
+		for i, tc := range testCases {
+			enc := encodeRange(nil, tc.lower, tc.upper, tc.empty, dir)
+			multirange = append(multirange, enc...)
+
+			typ := PeekType(enc)
+			if (dir == Ascending && typ != RangeKeyAsc) || (dir == Descending && typ != RangeKeyDesc) {
+				t.Fatalf("%d: unexpected type %s", i, typ)
+			}
+			l, err := PeekLength(append(enc, 0xAB))
+			require.NoError(t, err)
+			require.Equal(t, len(enc), l)
+
+			rest, s, err := prettyPrintFirstValue(dir, enc)
+			require.NoError(t, err)
+			require.Empty(t, rest)
+			require.Equal(t, tc.exp, s)
+
+			if prev != nil {
+				c := bytes.Compare(prev, enc)
+				if (dir == Ascending && c >= 0) || (dir == Descending && c <= 0) {
+					t.Fatalf("%d: %s is not ordered correctly in direction %d", i, tc.exp, dir)
+				}
+			}
+			prev = enc
+		}
+		multirange = EncodeMultirangeKeyTerminator(multirange, dir)
+		l, err := PeekLength(multirange)
+		require.NoError(t, err)
+		require.Equal(t, len(multirange), l)
+	}
+}
+
 func TestEncodeDecodeUnsafeStringDescending(t *testing.T) {
 	testCases := []struct {
 		value   string
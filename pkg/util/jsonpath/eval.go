@@ -0,0 +1,886 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// errSilenceable marks the errors that are suppressed when a path is
+// evaluated in silent mode: missing object keys and array elements,
+// unexpected item types, and numeric errors.
+var errSilenceable = errors.New("silenceable jsonpath error")
+
+// IsSilenceable returns whether the error is one of the errors that the
+// silent argument of the jsonb_path functions, and the @? and @@ operators,
+// suppress.
+func IsSilenceable(err error) bool {
+	return errors.Is(err, errSilenceable)
+}
+
+func newSilenceableError(code pgcode.Code, format string, args ...interface{}) error {
+	return errors.Mark(pgerror.Newf(code, format, args...), errSilenceable)
+}
+
+// decimalCtx is the context used for arithmetic. It matches the one used for
+// the DECIMAL type.
+var decimalCtx = &apd.Context{
+	Precision:   20,
+	Rounding:    apd.RoundHalfUp,
+	MaxExponent: 2000,
+	MinExponent: -2000,
+	Traps:       apd.DefaultTraps,
+}
+
+// exactCtx is the context used for arithmetic that doesn't lose precision.
+var exactCtx = decimalCtx.WithPrecision(0)
+
+// highPrecisionCtx is the context used for the modulo operator.
+var highPrecisionCtx = decimalCtx.WithPrecision(2000)
+
+// truncCtx is the context used to truncate array subscripts to integers.
+var truncCtx = func() *apd.Context {
+	ctx := *exactCtx
+	ctx.Rounding = apd.RoundDown
+	return &ctx
+}()
+
+// truth is the result of a predicate, which follows three-valued logic.
+type truth int
+
+const (
+	truthFalse truth = iota
+	truthTrue
+	truthUnknown
+)
+
+func truthOf(b bool) truth {
+	if b {
+		return truthTrue
+	}
+	return truthFalse
+}
+
+// toJSON returns the JSON representation of a predicate result, which is
+// null for unknown.
+func (t truth) toJSON() json.JSON {
+	switch t {
+	case truthTrue:
+		return json.TrueJSONValue
+	case truthFalse:
+		return json.FalseJSONValue
+	}
+	return json.NullJSONValue
+}
+
+type evaluator struct {
+	root   json.JSON
+	vars   json.JSON
+	strict bool
+	// last is the index of the last element of the innermost array being
+	// subscripted, or -1 outside of subscripts.
+	last int
+}
+
+// Eval evaluates the path against the target document and returns the
+// resulting sequence of JSON items. vars, if not nil, must be a JSON object
+// that holds the values of the variables referenced by the path.
+func Eval(p Path, target, vars json.JSON) ([]json.JSON, error) {
+	if vars != nil && vars.Type() != json.ObjectJSONType {
+		return nil, errors.WithDetail(
+			pgerror.New(pgcode.InvalidParameterValue, `"vars" argument is not an object`),
+			`Jsonpath parameters should be encoded as key-value pairs of "vars" object.`,
+		)
+	}
+	e := evaluator{root: target, vars: vars, strict: p.Strict, last: -1}
+	return e.eval(p.Root, nil /* cur */)
+}
+
+// EvalExists returns whether the path returns any item for the target document.
+// It implements jsonb_path_exists and the @? operator.
+func EvalExists(p Path, target, vars json.JSON) (bool, error) {
+	res, err := Eval(p, target, vars)
+	if err != nil {
+		return false, err
+	}
+	return len(res) > 0, nil
+}
+
+// EvalMatch returns the result of a predicate check path for the target
+// document. The bool return value is false if the result is unknown.
+// It implements jsonb_path_match and the @@ operator.
+func EvalMatch(p Path, target, vars json.JSON) (result bool, ok bool, err error) {
+	res, err := Eval(p, target, vars)
+	if err != nil {
+		return false, false, err
+	}
+	if len(res) == 1 {
+		if b, isBool := res[0].AsBool(); isBool {
+			return b, true, nil
+		}
+		if res[0].Type() == json.NullJSONType {
+			return false, false, nil
+		}
+	}
+	return false, false, newSilenceableError(pgcode.SingletonSQLJSONItemRequired,
+		"single boolean result is expected")
+}
+
+// eval evaluates the node and returns the resulting sequence of items. cur
+// is the item that @ refers to.
+func (e *evaluator) eval(n Node, cur json.JSON) ([]json.JSON, error) {
+	switch t := n.(type) {
+	case *Literal:
+		return []json.JSON{t.Value}, nil
+	case *Variable:
+		var v json.JSON
+		if e.vars != nil {
+			var err error
+			if v, err = e.vars.FetchValKey(t.Name); err != nil {
+				return nil, err
+			}
+		}
+		if v == nil {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"could not find jsonpath variable %q", t.Name)
+		}
+		return []json.JSON{v}, nil
+	case *Root:
+		return []json.JSON{e.root}, nil
+	case *Current:
+		return []json.JSON{cur}, nil
+	case *Last:
+		if e.last < 0 {
+			return nil, pgerror.New(pgcode.Syntax, "evaluating jsonpath LAST outside of array subscript")
+		}
+		return []json.JSON{json.FromInt(e.last)}, nil
+	case *Binary:
+		if t.Op.isArithmetic() {
+			return e.evalArithmetic(t, cur)
+		}
+	case *Unary:
+		if t.Op != OpNot {
+			return e.evalUnaryArithmetic(t, cur)
+		}
+	case *Accessor:
+		items, err := e.eval(t.Base, cur)
+		if err != nil {
+			return nil, err
+		}
+		for _, op := range t.Chain {
+			var next []json.JSON
+			for _, item := range items {
+				if next, err = e.evalAccessor(op, item, cur, next); err != nil {
+					return nil, err
+				}
+			}
+			items = next
+		}
+		return items, nil
+	}
+	res, err := e.evalPredicate(n, cur)
+	if err != nil {
+		return nil, err
+	}
+	return []json.JSON{res.toJSON()}, nil
+}
+
+// evalUnwrapped evaluates the node and, in lax mode, replaces the arrays in
+// the result by their elements.
+func (e *evaluator) evalUnwrapped(n Node, cur json.JSON) ([]json.JSON, error) {
+	items, err := e.eval(n, cur)
+	if err != nil || e.strict {
+		return items, err
+	}
+	var res []json.JSON
+	for _, item := range items {
+		if item.Type() == json.ArrayJSONType {
+			if res, err = appendElements(res, item); err != nil {
+				return nil, err
+			}
+		} else {
+			res = append(res, item)
+		}
+	}
+	return res, nil
+}
+
+// appendElements appends the elements of the array to items.
+func appendElements(items []json.JSON, array json.JSON) ([]json.JSON, error) {
+	for i, n := 0, array.Len(); i < n; i++ {
+		elem, err := array.FetchValIdx(i)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, elem)
+	}
+	return items, nil
+}
+
+// appendValues appends the values of the object to items.
+func appendValues(items []json.JSON, object json.JSON) ([]json.JSON, error) {
+	it, err := object.ObjectIter()
+	if err != nil {
+		return nil, err
+	}
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, nil
+}
+
+// evalAccessor applies the accessor to the item, appending the results to
+// res.
+func (e *evaluator) evalAccessor(
+	op AccessorOp, item, cur json.JSON, res []json.JSON,
+) ([]json.JSON, error) {
+	// In lax mode, most accessors are applied to the elements of arrays rather
+	// than to the arrays themselves.
+	if !e.strict && item.Type() == json.ArrayJSONType {
+		switch t := op.(type) {
+		case *Key, *AnyKey, *Filter:
+			return e.evalAccessorOnElements(op, item, cur, res)
+		case *Method:
+			if t.Name != MethodType && t.Name != MethodSize {
+				return e.evalAccessorOnElements(op, item, cur, res)
+			}
+		}
+	}
+
+	switch t := op.(type) {
+	case *Key:
+		if item.Type() != json.ObjectJSONType {
+			if e.strict {
+				return nil, newSilenceableError(pgcode.SQLJSONMemberNotFound,
+					"jsonpath member accessor can only be applied to an object")
+			}
+			return res, nil
+		}
+		v, err := item.FetchValKey(t.Name)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			if e.strict {
+				return nil, newSilenceableError(pgcode.SQLJSONMemberNotFound,
+					"JSON object does not contain key %q", t.Name)
+			}
+			return res, nil
+		}
+		return append(res, v), nil
+
+	case *AnyKey:
+		if item.Type() != json.ObjectJSONType {
+			if e.strict {
+				return nil, newSilenceableError(pgcode.SQLJSONObjectNotFound,
+					"jsonpath wildcard member accessor can only be applied to an object")
+			}
+			return res, nil
+		}
+		return appendValues(res, item)
+
+	case *AnyArray:
+		if item.Type() != json.ArrayJSONType {
+			if e.strict {
+				return nil, newSilenceableError(pgcode.SQLJSONArrayNotFound,
+					"jsonpath wildcard array accessor can only be applied to an array")
+			}
+			return append(res, item), nil
+		}
+		return appendElements(res, item)
+
+	case *Index:
+		return e.evalIndex(t, item, cur, res)
+
+	case *AnyPath:
+		first, last := t.First, t.Last
+		if first == 0 {
+			res = append(res, item)
+		}
+		return e.evalAnyPath(item, 1 /* level */, first, last, res)
+
+	case *Method:
+		return e.evalMethod(t.Name, item, res)
+
+	case *Filter:
+		ok, err := e.evalPredicate(t.Pred, item)
+		if err != nil {
+			return nil, err
+		}
+		if ok == truthTrue {
+			res = append(res, item)
+		}
+		return res, nil
+	}
+	return nil, errors.AssertionFailedf("unhandled jsonpath accessor %T", op)
+}
+
+// evalAccessorOnElements applies the accessor to each element of the array,
+// which is how arrays are unwrapped in lax mode.
+func (e *evaluator) evalAccessorOnElements(
+	op AccessorOp, array, cur json.JSON, res []json.JSON,
+) ([]json.JSON, error) {
+	elems, err := appendElements(nil, array)
+	if err != nil {
+		return nil, err
+	}
+	for _, elem := range elems {
+		if elem.Type() == json.ArrayJSONType {
+			// Only one level of arrays is unwrapped. Accessors that need an
+			// object or a number skip the nested arrays.
+			switch t := op.(type) {
+			case *Key, *AnyKey:
+				continue
+			case *Method:
+				if t.Name != MethodType && t.Name != MethodSize {
+					return nil, e.methodTypeError(t.Name)
+				}
+			}
+		}
+		if res, err = e.evalAccessor(op, elem, cur, res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (e *evaluator) evalIndex(
+	idx *Index, item, cur json.JSON, res []json.JSON,
+) ([]json.JSON, error) {
+	elems := []json.JSON{item}
+	if item.Type() == json.ArrayJSONType {
+		var err error
+		if elems, err = appendElements(nil, item); err != nil {
+			return nil, err
+		}
+	} else if e.strict {
+		return nil, newSilenceableError(pgcode.SQLJSONArrayNotFound,
+			"jsonpath array accessor can only be applied to an array")
+	}
+
+	savedLast := e.last
+	e.last = len(elems) - 1
+	defer func() { e.last = savedLast }()
+	for _, s := range idx.Subscripts {
+		from, err := e.evalSubscript(s.From, cur)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if s.To != nil {
+			if to, err = e.evalSubscript(s.To, cur); err != nil {
+				return nil, err
+			}
+		}
+		if from < 0 || from > to || to >= len(elems) {
+			if e.strict {
+				return nil, newSilenceableError(pgcode.InvalidSQLJSONSubscript,
+					"jsonpath array subscript is out of bounds")
+			}
+			if from < 0 {
+				from = 0
+			}
+			if to >= len(elems) {
+				to = len(elems) - 1
+			}
+		}
+		for i := from; i <= to; i++ {
+			res = append(res, elems[i])
+		}
+	}
+	return res, nil
+}
+
+// evalSubscript evaluates an array subscript, which must be a single number.
+// The number is truncated to an integer.
+func (e *evaluator) evalSubscript(n Node, cur json.JSON) (int, error) {
+	items, err := e.eval(n, cur)
+	if err != nil {
+		return 0, err
+	}
+	if len(items) != 1 {
+		return 0, newSilenceableError(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is not a single numeric value")
+	}
+	d, ok := items[0].AsDecimal()
+	if !ok {
+		return 0, newSilenceableError(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is not a single numeric value")
+	}
+	var trunc apd.Decimal
+	if _, err := truncCtx.RoundToIntegralValue(&trunc, d); err != nil {
+		return 0, err
+	}
+	i, err := trunc.Int64()
+	if err != nil || i > math.MaxInt32 || i < math.MinInt32 {
+		return 0, newSilenceableError(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is out of integer range")
+	}
+	return int(i), nil
+}
+
+// evalAnyPath appends the items nested within item at the given level and
+// below to res, for the levels between first and last.
+func (e *evaluator) evalAnyPath(
+	item json.JSON, level, first, last int, res []json.JSON,
+) ([]json.JSON, error) {
+	var children []json.JSON
+	var err error
+	switch item.Type() {
+	case json.ObjectJSONType:
+		children, err = appendValues(nil, item)
+	case json.ArrayJSONType:
+		children, err = appendElements(nil, item)
+	default:
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		container := child.Type() == json.ObjectJSONType || child.Type() == json.ArrayJSONType
+		switch {
+		case first == AnyLevel && last == AnyLevel:
+			// {last} selects the leaves.
+			if !container {
+				res = append(res, child)
+			}
+		case first != AnyLevel && level >= first && (last == AnyLevel || level <= last):
+			res = append(res, child)
+		}
+		if container && (last == AnyLevel || level < last) {
+			if res, err = e.evalAnyPath(child, level+1, first, last, res); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+func (e *evaluator) methodTypeError(m MethodName) error {
+	switch m {
+	case MethodDouble:
+		return newSilenceableError(pgcode.NonNumericSQLJSONItem,
+			"jsonpath item method .%s() can only be applied to a string or numeric value", m)
+	case MethodKeyValue:
+		return newSilenceableError(pgcode.SQLJSONObjectNotFound,
+			"jsonpath item method .%s() can only be applied to an object", m)
+	}
+	return newSilenceableError(pgcode.NonNumericSQLJSONItem,
+		"jsonpath item method .%s() can only be applied to a numeric value", m)
+}
+
+// jsonTypeName returns the name of the type of the item, as returned by the
+// .type() method.
+func jsonTypeName(j json.JSON) string {
+	switch j.Type() {
+	case json.NullJSONType:
+		return "null"
+	case json.TrueJSONType, json.FalseJSONType:
+		return "boolean"
+	case json.NumberJSONType:
+		return "number"
+	case json.StringJSONType:
+		return "string"
+	case json.ArrayJSONType:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func (e *evaluator) evalMethod(m MethodName, item json.JSON, res []json.JSON) ([]json.JSON, error) {
+	switch m {
+	case MethodType:
+		return append(res, json.FromString(jsonTypeName(item))), nil
+
+	case MethodSize:
+		if item.Type() != json.ArrayJSONType {
+			if e.strict {
+				return nil, newSilenceableError(pgcode.SQLJSONArrayNotFound,
+					"jsonpath item method .%s() can only be applied to an array", m)
+			}
+			return append(res, json.FromInt(1)), nil
+		}
+		return append(res, json.FromInt(item.Len())), nil
+
+	case MethodAbs, MethodFloor, MethodCeiling:
+		d, ok := item.AsDecimal()
+		if !ok {
+			return nil, e.methodTypeError(m)
+		}
+		var r apd.Decimal
+		var err error
+		switch m {
+		case MethodAbs:
+			_, err = exactCtx.Abs(&r, d)
+		case MethodFloor:
+			_, err = exactCtx.Floor(&r, d)
+		case MethodCeiling:
+			_, err = exactCtx.Ceil(&r, d)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return append(res, json.FromDecimal(r)), nil
+
+	case MethodDouble:
+		var f float64
+		switch item.Type() {
+		case json.NumberJSONType:
+			d, _ := item.AsDecimal()
+			var err error
+			if f, err = d.Float64(); err != nil || math.IsInf(f, 0) {
+				return nil, newSilenceableError(pgcode.NonNumericSQLJSONItem,
+					"numeric argument of jsonpath item method .%s() is out of range for type double precision", m)
+			}
+		case json.StringJSONType:
+			s, err := item.AsText()
+			if err != nil {
+				return nil, err
+			}
+			if f, err = strconv.ParseFloat(strings.TrimSpace(*s), 64); err != nil {
+				return nil, newSilenceableError(pgcode.NonNumericSQLJSONItem,
+					"string argument of jsonpath item method .%s() is not a valid representation of a double precision number", m)
+			}
+			if math.IsInf(f, 0) || math.IsNaN(f) {
+				return nil, newSilenceableError(pgcode.NonNumericSQLJSONItem,
+					"string argument of jsonpath item method .%s() is not a valid representation of a double precision number", m)
+			}
+		default:
+			return nil, e.methodTypeError(m)
+		}
+		// Like Postgres, round the number to the precision of a double.
+		var r apd.Decimal
+		if _, _, err := r.SetString(strconv.FormatFloat(f, 'g', 15, 64)); err != nil {
+			return nil, err
+		}
+		return append(res, json.FromDecimal(r)), nil
+
+	case MethodKeyValue:
+		if item.Type() != json.ObjectJSONType {
+			return nil, e.methodTypeError(m)
+		}
+		it, err := item.ObjectIter()
+		if err != nil {
+			return nil, err
+		}
+		for it.Next() {
+			// Postgres sets id to an identifier derived from the position of the
+			// object within its document, which we don't have. All the pairs of
+			// an object have the same id, so we use 0.
+			b := json.NewObjectBuilder(3)
+			b.Add("id", json.FromInt(0))
+			b.Add("key", json.FromString(it.Key()))
+			b.Add("value", it.Value())
+			res = append(res, b.Build())
+		}
+		return res, nil
+	}
+	return nil, errors.AssertionFailedf("unhandled jsonpath item method %s", m)
+}
+
+// evalNumericOperand evaluates an operand of an arithmetic operator, which
+// must be a single number.
+func (e *evaluator) evalNumericOperand(n Node, cur json.JSON, which string, op BinaryOp) (*apd.Decimal, error) {
+	items, err := e.evalUnwrapped(n, cur)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 1 {
+		if d, ok := items[0].AsDecimal(); ok {
+			return d, nil
+		}
+	}
+	return nil, newSilenceableError(pgcode.SingletonSQLJSONItemRequired,
+		"%s operand of jsonpath operator %s is not a single numeric value", which, op)
+}
+
+func (e *evaluator) evalArithmetic(n *Binary, cur json.JSON) ([]json.JSON, error) {
+	l, err := e.evalNumericOperand(n.Left, cur, "left", n.Op)
+	if err != nil {
+		return nil, err
+	}
+	r, err := e.evalNumericOperand(n.Right, cur, "right", n.Op)
+	if err != nil {
+		return nil, err
+	}
+	var res apd.Decimal
+	switch n.Op {
+	case OpAdd:
+		_, err = exactCtx.Add(&res, l, r)
+	case OpSub:
+		_, err = exactCtx.Sub(&res, l, r)
+	case OpMul:
+		_, err = exactCtx.Mul(&res, l, r)
+	case OpDiv, OpMod:
+		if r.IsZero() {
+			return nil, newSilenceableError(pgcode.DivisionByZero, "division by zero")
+		}
+		if n.Op == OpDiv {
+			_, err = decimalCtx.Quo(&res, l, r)
+		} else {
+			_, err = highPrecisionCtx.Rem(&res, l, r)
+		}
+	}
+	if err != nil {
+		return nil, errors.Mark(pgerror.WithCandidateCode(err, pgcode.NumericValueOutOfRange), errSilenceable)
+	}
+	return []json.JSON{json.FromDecimal(res)}, nil
+}
+
+func (e *evaluator) evalUnaryArithmetic(n *Unary, cur json.JSON) ([]json.JSON, error) {
+	items, err := e.evalUnwrapped(n.Operand, cur)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]json.JSON, len(items))
+	for i, item := range items {
+		d, ok := item.AsDecimal()
+		if !ok {
+			op := "+"
+			if n.Op == OpMinus {
+				op = "-"
+			}
+			return nil, newSilenceableError(pgcode.NonNumericSQLJSONItem,
+				"operand of unary jsonpath operator %s is not a numeric value", op)
+		}
+		if n.Op == OpMinus {
+			var neg apd.Decimal
+			neg.Neg(d)
+			res[i] = json.FromDecimal(neg)
+		} else {
+			res[i] = item
+		}
+	}
+	return res, nil
+}
+
+// evalPredicate evaluates a predicate. Silenceable errors within the
+// predicate make it unknown.
+func (e *evaluator) evalPredicate(n Node, cur json.JSON) (truth, error) {
+	res, err := e.evalPredicateInternal(n, cur)
+	if err != nil {
+		if IsSilenceable(err) {
+			return truthUnknown, nil
+		}
+		return truthUnknown, err
+	}
+	return res, nil
+}
+
+func (e *evaluator) evalPredicateInternal(n Node, cur json.JSON) (truth, error) {
+	switch t := n.(type) {
+	case *Binary:
+		switch t.Op {
+		case OpAnd, OpOr:
+			l, err := e.evalPredicate(t.Left, cur)
+			if err != nil {
+				return truthUnknown, err
+			}
+			// Short-circuit if the left side determines the result.
+			if (t.Op == OpAnd && l == truthFalse) || (t.Op == OpOr && l == truthTrue) {
+				return l, nil
+			}
+			r, err := e.evalPredicate(t.Right, cur)
+			if err != nil {
+				return truthUnknown, err
+			}
+			if l == truthUnknown {
+				if (t.Op == OpAnd && r == truthFalse) || (t.Op == OpOr && r == truthTrue) {
+					return r, nil
+				}
+				return truthUnknown, nil
+			}
+			return r, nil
+		}
+		return e.evalComparison(t, cur)
+
+	case *Unary:
+		res, err := e.evalPredicate(t.Operand, cur)
+		if err != nil {
+			return truthUnknown, err
+		}
+		switch res {
+		case truthTrue:
+			return truthFalse, nil
+		case truthFalse:
+			return truthTrue, nil
+		}
+		return truthUnknown, nil
+
+	case *IsUnknown:
+		res, err := e.evalPredicate(t.Pred, cur)
+		if err != nil {
+			return truthUnknown, err
+		}
+		return truthOf(res == truthUnknown), nil
+
+	case *Exists:
+		items, err := e.eval(t.Expr, cur)
+		if err != nil {
+			return truthUnknown, err
+		}
+		return truthOf(len(items) > 0), nil
+
+	case *LikeRegex:
+		return e.evalStringPredicate(t.Expr, cur, func(s string) bool {
+			return t.re.MatchString(s)
+		})
+
+	case *StartsWith:
+		prefix, err := e.eval(t.Prefix, cur)
+		if err != nil {
+			return truthUnknown, err
+		}
+		if len(prefix) != 1 || prefix[0].Type() != json.StringJSONType {
+			return truthUnknown, nil
+		}
+		p, err := prefix[0].AsText()
+		if err != nil {
+			return truthUnknown, err
+		}
+		return e.evalStringPredicate(t.Expr, cur, func(s string) bool {
+			return strings.HasPrefix(s, *p)
+		})
+	}
+	return truthUnknown, errors.AssertionFailedf("unhandled jsonpath predicate %T", n)
+}
+
+// evalStringPredicate evaluates a predicate on the strings returned by the
+// expression. In lax mode the predicate is true if it is true for any of the
+// strings, even if some items are not strings. In strict mode it is unknown
+// if any item is not a string.
+func (e *evaluator) evalStringPredicate(
+	n Node, cur json.JSON, pred func(string) bool,
+) (truth, error) {
+	items, err := e.evalUnwrapped(n, cur)
+	if err != nil {
+		return truthUnknown, err
+	}
+	found, unknown := false, false
+	for _, item := range items {
+		if item.Type() != json.StringJSONType {
+			if e.strict {
+				return truthUnknown, nil
+			}
+			unknown = true
+			continue
+		}
+		s, err := item.AsText()
+		if err != nil {
+			return truthUnknown, err
+		}
+		if pred(*s) {
+			if !e.strict {
+				return truthTrue, nil
+			}
+			found = true
+		}
+	}
+	if found {
+		return truthTrue, nil
+	}
+	if unknown {
+		return truthUnknown, nil
+	}
+	return truthFalse, nil
+}
+
+// evalComparison evaluates a comparison. The comparison is true if it is
+// true for any pair of items of the two operands.
+func (e *evaluator) evalComparison(n *Binary, cur json.JSON) (truth, error) {
+	left, err := e.evalUnwrapped(n.Left, cur)
+	if err != nil {
+		return truthUnknown, err
+	}
+	right, err := e.evalUnwrapped(n.Right, cur)
+	if err != nil {
+		return truthUnknown, err
+	}
+	found, unknown := false, false
+	for _, l := range left {
+		for _, r := range right {
+			res, err := compareItems(n.Op, l, r)
+			if err != nil {
+				return truthUnknown, err
+			}
+			switch res {
+			case truthUnknown:
+				if e.strict {
+					return truthUnknown, nil
+				}
+				unknown = true
+			case truthTrue:
+				if !e.strict {
+					return truthTrue, nil
+				}
+				found = true
+			}
+		}
+	}
+	if found {
+		return truthTrue, nil
+	}
+	if unknown {
+		return truthUnknown, nil
+	}
+	return truthFalse, nil
+}
+
+// compareItems compares two scalar items. Items of different types, other
+// than null, can't be compared, and neither can arrays and objects.
+func compareItems(op BinaryOp, l, r json.JSON) (truth, error) {
+	lt, rt := l.Type(), r.Type()
+	isBool := func(t json.Type) bool { return t == json.TrueJSONType || t == json.FalseJSONType }
+	if lt != rt && !(isBool(lt) && isBool(rt)) {
+		if lt == json.NullJSONType || rt == json.NullJSONType {
+			// null is only equal to null.
+			return truthOf(op == OpNe), nil
+		}
+		return truthUnknown, nil
+	}
+	var cmp int
+	switch lt {
+	case json.NullJSONType:
+		cmp = 0
+	case json.TrueJSONType, json.FalseJSONType, json.NumberJSONType, json.StringJSONType:
+		var err error
+		if cmp, err = l.Compare(r); err != nil {
+			return truthUnknown, err
+		}
+	default:
+		return truthUnknown, nil
+	}
+	switch op {
+	case OpEq:
+		return truthOf(cmp == 0), nil
+	case OpNe:
+		return truthOf(cmp != 0), nil
+	case OpLt:
+		return truthOf(cmp < 0), nil
+	case OpLe:
+		return truthOf(cmp <= 0), nil
+	case OpGt:
+		return truthOf(cmp > 0), nil
+	case OpGe:
+		return truthOf(cmp >= 0), nil
+	}
+	return truthUnknown, errors.AssertionFailedf("unhandled jsonpath comparison %s", op)
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	const doc = `{
+		"a": 1,
+		"b": [1, 2, 3, {"c": 4}],
+		"d": {"e": "abc", "f": null},
+		"g": [{"h": 1}, {"h": 5}, {"h": "x"}]
+	}`
+	target, err := json.ParseJSON(doc)
+	require.NoError(t, err)
+	vars, err := json.ParseJSON(`{"x": 2, "s": "ab"}`)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		path string
+		// expected is the sequence of items, separated by semicolons.
+		expected string
+	}{
+		{`$.a`, `1`},
+		{`$.missing`, ``},
+		{`$.b[*]`, `1; 2; 3; {"c": 4}`},
+		{`$.b[0, 2 to last]`, `1; 3; {"c": 4}`},
+		{`$.b[last - 1]`, `3`},
+		{`$.b[1.7]`, `2`},
+		{`$.b[10]`, ``},
+		{`$.b.c`, `4`},
+		{`$.a[0]`, `1`},
+		{`$.a[*]`, `1`},
+		{`$.d.*`, `"abc"; null`},
+		{`$.g.h`, `1; 5; "x"`},
+		{`$.g ? (@.h > 2).h`, `5`},
+		{`$.g[*] ? (@.h == $x || @.h == 1).h`, `1`},
+		{`$.b[*] ? (@ >= $x)`, `2; 3`},
+		{`$.** ? (@ == 4)`, `4`},
+		{`$.b.**{1}`, `1; 2; 3; {"c": 4}`},
+		{`$.b.**{2}`, `4`},
+		{`$.d.**{last}`, `"abc"; null`},
+		{`$.a + $x * 3`, `7`},
+		{`$.b[*].c - 1`, `3`},
+		{`10 / 4`, `2.5000000000000000000`},
+		{`1 / 3`, `0.33333333333333333333`},
+		{`7 % 3`, `1`},
+		{`-$.b[0 to 1]`, `-1; -2`},
+		{`$.b.size()`, `4`},
+		{`$.a.size()`, `1`},
+		{`$.b.type()`, `"array"`},
+		{`$.*.type()`, `"number"; "array"; "object"; "array"`},
+		{`$.d.e.type()`, `"string"`},
+		{`(-1.5).abs()`, `1.5`},
+		{`$.b[0 to 2].double()`, `1; 2; 3`},
+		{`"1.5e1".double()`, `15`},
+		{`(1.5).floor()`, `1`},
+		{`(1.5).ceiling()`, `2`},
+		{`$.d.keyvalue()`, `{"id": 0, "key": "e", "value": "abc"}; {"id": 0, "key": "f", "value": null}`},
+		{`$.a == 1`, `true`},
+		{`$.a == "1"`, `null`},
+		{`$.d.f == null`, `true`},
+		{`$.d.f != 1`, `true`},
+		{`$.b[*] > 2`, `true`},
+		{`$.g[*].h > 2`, `true`},
+		{`exists($.missing)`, `false`},
+		{`($.a == "1") is unknown`, `true`},
+		{`$.d.e like_regex "^A" flag "i"`, `true`},
+		{`$.d.e like_regex "^a.c$"`, `true`},
+		{`$.d.e starts with "ab"`, `true`},
+		{`$.d.e starts with $s`, `true`},
+		{`$.g[*].h starts with "x"`, `true`},
+		{`!($.a > 0)`, `false`},
+		{`$.a > 0 && $.a == "x"`, `null`},
+		{`$.a > 0 || $.a == "x"`, `true`},
+		{`$.a == "x" && $.a < 0`, `false`},
+		{`strict $.a`, `1`},
+		{`strict $.b[*] ? (@ > 1)`, `2; 3`},
+		{`strict $.g[*].h > 2`, `null`},
+	} {
+		p, err := Parse(tc.path)
+		require.NoError(t, err, tc.path)
+		res, err := Eval(p, target, vars)
+		require.NoError(t, err, tc.path)
+		strs := make([]string, len(res))
+		for i := range res {
+			strs[i] = res[i].String()
+		}
+		assert.Equal(t, tc.expected, strings.Join(strs, "; "), "path: %s", tc.path)
+	}
+
+	for _, tc := range []struct {
+		path        string
+		err         string
+		silenceable bool
+	}{
+		{`strict $.missing`, `JSON object does not contain key "missing"`, true},
+		{`strict $.a.b`, `jsonpath member accessor can only be applied to an object`, true},
+		{`strict $.a[*]`, `jsonpath wildcard array accessor can only be applied to an array`, true},
+		{`strict $.b[10]`, `jsonpath array subscript is out of bounds`, true},
+		{`strict $.a.size()`, `jsonpath item method .size() can only be applied to an array`, true},
+		{`$.b + 1`, `left operand of jsonpath operator + is not a single numeric value`, true},
+		{`1 - $.d`, `right operand of jsonpath operator - is not a single numeric value`, true},
+		{`$.a / 0`, `division by zero`, true},
+		{`-$.d`, `operand of unary jsonpath operator - is not a numeric value`, true},
+		{`$.d.abs()`, `jsonpath item method .abs() can only be applied to a numeric value`, true},
+		{`$.d.e.double()`,
+			`string argument of jsonpath item method .double() is not a valid representation of a double precision number`, true},
+		{`$.a.keyvalue()`, `jsonpath item method .keyvalue() can only be applied to an object`, true},
+		{`$.b["x"]`, `jsonpath array subscript is not a single numeric value`, true},
+		{`$.a + $y`, `could not find jsonpath variable "y"`, false},
+	} {
+		p, err := Parse(tc.path)
+		require.NoError(t, err, tc.path)
+		_, err = Eval(p, target, vars)
+		require.EqualError(t, err, tc.err, "path: %s", tc.path)
+		assert.Equal(t, tc.silenceable, IsSilenceable(err), "path: %s", tc.path)
+	}
+
+	_, err = Eval(MustParse(`$`), target, json.FromInt(1))
+	require.EqualError(t, err, `"vars" argument is not an object`)
+}
+
+func TestEvalExistsAndMatch(t *testing.T) {
+	target, err := json.ParseJSON(`{"a": [1, 2, 3]}`)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		path   string
+		exists bool
+	}{
+		{`$.a[*] ? (@ > 2)`, true},
+		{`$.a[*] ? (@ > 3)`, false},
+		{`$.b`, false},
+		// A predicate always returns a single item.
+		{`$.a[*] > 5`, true},
+	} {
+		res, err := EvalExists(MustParse(tc.path), target, nil /* vars */)
+		require.NoError(t, err)
+		assert.Equal(t, tc.exists, res, "path: %s", tc.path)
+	}
+
+	for _, tc := range []struct {
+		path   string
+		result bool
+		ok     bool
+		err    string
+	}{
+		{path: `$.a[*] > 2`, result: true, ok: true},
+		{path: `$.a[*] > 5`, result: false, ok: true},
+		{path: `$.a[*] > "x"`, result: false, ok: false},
+		{path: `$.a[0]`, err: `single boolean result is expected`},
+		{path: `$.a`, err: `single boolean result is expected`},
+	} {
+		res, ok, err := EvalMatch(MustParse(tc.path), target, nil /* vars */)
+		if tc.err != "" {
+			require.EqualError(t, err, tc.err)
+			require.True(t, IsSilenceable(err))
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tc.result, res, "path: %s", tc.path)
+		assert.Equal(t, tc.ok, ok, "path: %s", tc.path)
+	}
+}
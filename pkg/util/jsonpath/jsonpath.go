@@ -0,0 +1,490 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package jsonpath implements the SQL/JSON path language, which is used to
+// query JSON documents. See
+// https://www.postgresql.org/docs/current/functions-json.html#FUNCTIONS-SQLJSON-PATH
+// for a description of the language.
+package jsonpath
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+)
+
+// Path is a parsed SQL/JSON path expression.
+type Path struct {
+	// Strict is true if the path is evaluated in strict mode. In lax mode, the
+	// default, structural errors like accessing a missing key are ignored and
+	// arrays are automatically unwrapped.
+	Strict bool
+	// Root is the top-level expression of the path.
+	Root Node
+}
+
+// String returns the canonical representation of the path, which is the same
+// as the one that Postgres produces.
+func (p Path) String() string {
+	var sb strings.Builder
+	if p.Strict {
+		sb.WriteString("strict ")
+	}
+	formatNode(&sb, p.Root, true /* brackets */)
+	return sb.String()
+}
+
+// IsPredicate returns whether the path is a predicate check expression, like
+// `$.a > 1`, as opposed to a path that returns a sequence of JSON items.
+func (p Path) IsPredicate() bool {
+	return isPredicate(p.Root)
+}
+
+// Node is a single expression within a path.
+type Node interface {
+	// priority returns the operator priority of the node, which determines
+	// whether it needs to be wrapped in parentheses when it is formatted as
+	// the operand of another operator.
+	priority() int
+	// format writes the node to the builder. If brackets is true and the node
+	// is an operator, it is wrapped in parentheses.
+	format(sb *strings.Builder, brackets bool)
+}
+
+// Literal is a scalar constant: a string, a number, a boolean or null.
+type Literal struct {
+	Value json.JSON
+}
+
+// Variable is a reference to a named variable, like `$x`. The variables are
+// supplied by the vars argument of the jsonb_path functions.
+type Variable struct {
+	Name string
+}
+
+// Root is the `$` context item, which is the JSON document being queried.
+type Root struct{}
+
+// Current is the `@` item, which is the item being tested by a filter.
+type Current struct{}
+
+// Last is the `last` item, which is the index of the last element of the
+// array being subscripted.
+type Last struct{}
+
+// BinaryOp is an operator with two operands.
+type BinaryOp int
+
+// The binary operators, in no particular order.
+const (
+	OpAnd BinaryOp = iota
+	OpOr
+	OpEq
+	OpNe
+	OpLt
+	OpLe
+	OpGt
+	OpGe
+	OpAdd
+	OpSub
+	OpMul
+	OpDiv
+	OpMod
+)
+
+var binaryOpNames = [...]string{
+	OpAnd: "&&",
+	OpOr:  "||",
+	OpEq:  "==",
+	OpNe:  "!=",
+	OpLt:  "<",
+	OpLe:  "<=",
+	OpGt:  ">",
+	OpGe:  ">=",
+	OpAdd: "+",
+	OpSub: "-",
+	OpMul: "*",
+	OpDiv: "/",
+	OpMod: "%",
+}
+
+func (o BinaryOp) String() string {
+	return binaryOpNames[o]
+}
+
+func (o BinaryOp) isComparison() bool {
+	return o >= OpEq && o <= OpGe
+}
+
+func (o BinaryOp) isArithmetic() bool {
+	return o >= OpAdd
+}
+
+// Binary is an arithmetic, comparison or logical operator with two operands.
+type Binary struct {
+	Op          BinaryOp
+	Left, Right Node
+}
+
+// UnaryOp is an operator with a single operand.
+type UnaryOp int
+
+// The unary operators.
+const (
+	OpPlus UnaryOp = iota
+	OpMinus
+	OpNot
+)
+
+// Unary is an arithmetic or logical operator with a single operand.
+type Unary struct {
+	Op      UnaryOp
+	Operand Node
+}
+
+// Exists is the `exists (expr)` predicate, which checks whether the
+// expression returns any items.
+type Exists struct {
+	Expr Node
+}
+
+// IsUnknown is the `(pred) is unknown` predicate, which checks whether the
+// predicate evaluates to unknown.
+type IsUnknown struct {
+	Pred Node
+}
+
+// LikeRegex is the `expr like_regex "pattern" flag "flags"` predicate.
+type LikeRegex struct {
+	Expr    Node
+	Pattern string
+	Flags   string
+	re      *regexp.Regexp
+}
+
+// StartsWith is the `expr starts with prefix` predicate.
+type StartsWith struct {
+	Expr   Node
+	Prefix Node
+}
+
+// Accessor is an expression followed by a chain of accessors, like
+// `$.a[*].b`.
+type Accessor struct {
+	Base  Node
+	Chain []AccessorOp
+}
+
+// AccessorOp is a single step in an accessor chain.
+type AccessorOp interface {
+	formatOp(sb *strings.Builder)
+}
+
+// Key is the `.key` member accessor.
+type Key struct {
+	Name string
+}
+
+// AnyKey is the `.*` wildcard member accessor.
+type AnyKey struct{}
+
+// AnyArray is the `[*]` wildcard array element accessor.
+type AnyArray struct{}
+
+// Subscript is a single element of an array accessor, either an index or a
+// range of indexes. To is nil if the subscript is a single index.
+type Subscript struct {
+	From, To Node
+}
+
+// Index is the `[subscript, ...]` array element accessor.
+type Index struct {
+	Subscripts []Subscript
+}
+
+// AnyLevel is used as the upper bound of an AnyPath accessor to indicate
+// that all nesting levels are included.
+const AnyLevel = -1
+
+// AnyPath is the `.**{first to last}` recursive wildcard accessor. Level 0 is
+// the current item.
+type AnyPath struct {
+	First, Last int
+}
+
+// Method is an item method like `.type()` or `.size()`.
+type Method struct {
+	Name MethodName
+}
+
+// MethodName is the name of an item method.
+type MethodName string
+
+// The supported item methods.
+const (
+	MethodType     MethodName = "type"
+	MethodSize     MethodName = "size"
+	MethodDouble   MethodName = "double"
+	MethodCeiling  MethodName = "ceiling"
+	MethodFloor    MethodName = "floor"
+	MethodAbs      MethodName = "abs"
+	MethodKeyValue MethodName = "keyvalue"
+)
+
+// Filter is the `?(pred)` filter accessor.
+type Filter struct {
+	Pred Node
+}
+
+// Operator priorities, matching the ones used by Postgres to decide where to
+// put parentheses.
+const (
+	priorityOr = iota
+	priorityAnd
+	priorityComparison
+	priorityAdditive
+	priorityMultiplicative
+	priorityUnary
+	priorityPrimary
+)
+
+func (*Literal) priority() int    { return priorityPrimary }
+func (*Variable) priority() int   { return priorityPrimary }
+func (*Root) priority() int       { return priorityPrimary }
+func (*Current) priority() int    { return priorityPrimary }
+func (*Last) priority() int       { return priorityPrimary }
+func (*Exists) priority() int     { return priorityPrimary }
+func (*IsUnknown) priority() int  { return priorityPrimary }
+func (*LikeRegex) priority() int  { return priorityPrimary }
+func (*StartsWith) priority() int { return priorityComparison }
+func (*Accessor) priority() int   { return priorityPrimary }
+
+func (n *Binary) priority() int {
+	switch {
+	case n.Op == OpOr:
+		return priorityOr
+	case n.Op == OpAnd:
+		return priorityAnd
+	case n.Op.isComparison():
+		return priorityComparison
+	case n.Op == OpAdd || n.Op == OpSub:
+		return priorityAdditive
+	default:
+		return priorityMultiplicative
+	}
+}
+
+func (n *Unary) priority() int {
+	if n.Op == OpNot {
+		return priorityPrimary
+	}
+	return priorityUnary
+}
+
+// formatNode formats n as a top-level expression or as the operand of an
+// operator.
+func formatNode(sb *strings.Builder, n Node, brackets bool) {
+	n.format(sb, brackets)
+}
+
+// formatOperand formats n as an operand of the parent node, adding
+// parentheses if n binds less tightly than the parent.
+func formatOperand(sb *strings.Builder, n Node, parent Node) {
+	n.format(sb, n.priority() <= parent.priority())
+}
+
+func (n *Literal) format(sb *strings.Builder, _ bool) {
+	switch n.Value.Type() {
+	case json.NumberJSONType:
+		d, _ := n.Value.AsDecimal()
+		sb.WriteString(formatDecimal(d))
+	default:
+		sb.WriteString(n.Value.String())
+	}
+}
+
+// formatDecimal formats a number without an exponent, like Postgres does for
+// numeric values.
+func formatDecimal(d *apd.Decimal) string {
+	return d.Text('f')
+}
+
+func (n *Variable) format(sb *strings.Builder, _ bool) {
+	sb.WriteByte('$')
+	sb.WriteString(json.FromString(n.Name).String())
+}
+
+func (*Root) format(sb *strings.Builder, _ bool)    { sb.WriteByte('$') }
+func (*Current) format(sb *strings.Builder, _ bool) { sb.WriteByte('@') }
+func (*Last) format(sb *strings.Builder, _ bool)    { sb.WriteString("last") }
+
+func (n *Binary) format(sb *strings.Builder, brackets bool) {
+	if brackets {
+		sb.WriteByte('(')
+	}
+	formatOperand(sb, n.Left, n)
+	sb.WriteByte(' ')
+	sb.WriteString(n.Op.String())
+	sb.WriteByte(' ')
+	formatOperand(sb, n.Right, n)
+	if brackets {
+		sb.WriteByte(')')
+	}
+}
+
+func (n *Unary) format(sb *strings.Builder, brackets bool) {
+	if n.Op == OpNot {
+		sb.WriteString("!(")
+		formatNode(sb, n.Operand, false /* brackets */)
+		sb.WriteByte(')')
+		return
+	}
+	if brackets {
+		sb.WriteByte('(')
+	}
+	if n.Op == OpPlus {
+		sb.WriteByte('+')
+	} else {
+		sb.WriteByte('-')
+	}
+	formatOperand(sb, n.Operand, n)
+	if brackets {
+		sb.WriteByte(')')
+	}
+}
+
+func (n *Exists) format(sb *strings.Builder, _ bool) {
+	sb.WriteString("exists (")
+	formatNode(sb, n.Expr, false /* brackets */)
+	sb.WriteByte(')')
+}
+
+func (n *IsUnknown) format(sb *strings.Builder, _ bool) {
+	sb.WriteByte('(')
+	formatNode(sb, n.Pred, false /* brackets */)
+	sb.WriteString(") is unknown")
+}
+
+func (n *LikeRegex) format(sb *strings.Builder, brackets bool) {
+	if brackets {
+		sb.WriteByte('(')
+	}
+	formatOperand(sb, n.Expr, n)
+	sb.WriteString(" like_regex ")
+	sb.WriteString(json.FromString(n.Pattern).String())
+	if n.Flags != "" {
+		sb.WriteString(` flag "`)
+		sb.WriteString(n.Flags)
+		sb.WriteByte('"')
+	}
+	if brackets {
+		sb.WriteByte(')')
+	}
+}
+
+func (n *StartsWith) format(sb *strings.Builder, brackets bool) {
+	if brackets {
+		sb.WriteByte('(')
+	}
+	formatOperand(sb, n.Expr, n)
+	sb.WriteString(" starts with ")
+	formatOperand(sb, n.Prefix, n)
+	if brackets {
+		sb.WriteByte(')')
+	}
+}
+
+func (n *Accessor) format(sb *strings.Builder, _ bool) {
+	// The base of an accessor chain is always wrapped in parentheses if it is
+	// an operator or a number, so that the accessors apply to all of it.
+	switch n.Base.(type) {
+	case *Variable, *Root, *Current, *Last:
+		formatNode(sb, n.Base, false /* brackets */)
+	default:
+		if l, ok := n.Base.(*Literal); ok && l.Value.Type() != json.NumberJSONType {
+			formatNode(sb, n.Base, false /* brackets */)
+		} else {
+			sb.WriteByte('(')
+			formatNode(sb, n.Base, false /* brackets */)
+			sb.WriteByte(')')
+		}
+	}
+	for _, op := range n.Chain {
+		op.formatOp(sb)
+	}
+}
+
+func (k *Key) formatOp(sb *strings.Builder) {
+	sb.WriteByte('.')
+	sb.WriteString(json.FromString(k.Name).String())
+}
+
+func (*AnyKey) formatOp(sb *strings.Builder)   { sb.WriteString(".*") }
+func (*AnyArray) formatOp(sb *strings.Builder) { sb.WriteString("[*]") }
+
+func (i *Index) formatOp(sb *strings.Builder) {
+	sb.WriteByte('[')
+	for j, s := range i.Subscripts {
+		if j > 0 {
+			sb.WriteByte(',')
+		}
+		formatNode(sb, s.From, false /* brackets */)
+		if s.To != nil {
+			sb.WriteString(" to ")
+			formatNode(sb, s.To, false /* brackets */)
+		}
+	}
+	sb.WriteByte(']')
+}
+
+func (a *AnyPath) formatOp(sb *strings.Builder) {
+	sb.WriteString(".**")
+	level := func(l int) string {
+		if l == AnyLevel {
+			return "last"
+		}
+		return apd.New(int64(l), 0).String()
+	}
+	switch {
+	case a.First == a.Last:
+		sb.WriteString("{" + level(a.First) + "}")
+	case a.First == 0 && a.Last == AnyLevel:
+	default:
+		sb.WriteString("{" + level(a.First) + " to " + level(a.Last) + "}")
+	}
+}
+
+func (m *Method) formatOp(sb *strings.Builder) {
+	sb.WriteByte('.')
+	sb.WriteString(string(m.Name))
+	sb.WriteString("()")
+}
+
+func (f *Filter) formatOp(sb *strings.Builder) {
+	sb.WriteString("?(")
+	formatNode(sb, f.Pred, false /* brackets */)
+	sb.WriteByte(')')
+}
+
+// isPredicate returns whether n evaluates to a boolean truth value rather
+// than to a sequence of JSON items.
+func isPredicate(n Node) bool {
+	switch t := n.(type) {
+	case *Binary:
+		return !t.Op.isArithmetic()
+	case *Unary:
+		return t.Op == OpNot
+	case *Exists, *IsUnknown, *LikeRegex, *StartsWith:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{`$`, `$`},
+		{`strict $`, `strict $`},
+		{`lax $`, `$`},
+		{`$.a`, `$."a"`},
+		{`$."a b"`, `$."a b"`},
+		{`$.a.b[*].c`, `$."a"."b"[*]."c"`},
+		{`$.*`, `$.*`},
+		{`$[1, 2 to 4, last]`, `$[1,2 to 4,last]`},
+		{`$[last - 1]`, `$[last - 1]`},
+		{`$.**`, `$.**`},
+		{`$.**{2}`, `$.**{2}`},
+		{`$.**{1 to last}`, `$.**{1 to last}`},
+		{`$.**{last}`, `$.**{last}`},
+		{`$.a.type()`, `$."a".type()`},
+		{`$.size() + 1`, `($.size() + 1)`},
+		{`$.a/+-1`, `($."a" / -1)`},
+		{`1 * 2 + 4 % -3 != false`, `(1 * 2 + 4 % -3 != false)`},
+		{`$ ? (@ > 1)`, `$?(@ > 1)`},
+		{`$.a ? (@.b == "x" && @.c < 2 || !(@.d == null))`,
+			`$."a"?(@."b" == "x" && @."c" < 2 || !(@."d" == null))`},
+		{`$ ? ((@ + 1) * 2 > 3)`, `$?((@ + 1) * 2 > 3)`},
+		{`$ ? (exists (@.a))`, `$?(exists (@."a"))`},
+		{`$ ? ((@ == 1) is unknown)`, `$?((@ == 1) is unknown)`},
+		{`$ ? (@ like_regex "^a.*" flag "i")`, `$?(@ like_regex "^a.*" flag "i")`},
+		{`$ ? (@ starts with "ab")`, `$?(@ starts with "ab")`},
+		{`$ ? (@ starts with $x)`, `$?(@ starts with $"x")`},
+		{`$.a <> $b`, `($."a" != $"b")`},
+		{`$."A\x42\n"`, `$."AB\n"`},
+		{`1.5e2`, `150`},
+		{`-$.a`, `(-$."a")`},
+		{`true`, `true`},
+		{`null`, `null`},
+		{`"abc"`, `"abc"`},
+	} {
+		p, err := Parse(tc.input)
+		require.NoError(t, err, tc.input)
+		assert.Equal(t, tc.expected, p.String(), "input: %s", tc.input)
+		// The output must parse to the same path.
+		p2, err := Parse(p.String())
+		require.NoError(t, err, p.String())
+		assert.Equal(t, p.String(), p2.String())
+	}
+
+	for _, tc := range []struct {
+		input string
+		err   string
+	}{
+		{``, `syntax error at end of jsonpath input`},
+		{`$.`, `syntax error at end of jsonpath input`},
+		{`$ ? (@)`, `syntax error at or near ")" of jsonpath input`},
+		{`@.a`, `@ is not allowed in root expressions`},
+		{`last`, `LAST is allowed only in array subscripts`},
+		{`$ && $`, `syntax error at or near "&&" of jsonpath input`},
+		{`$.a.foo()`, `syntax error at or near "(" of jsonpath input`},
+		{`1a`, `trailing junk after numeric literal at or near "1a" of jsonpath input`},
+		{`"abc`, `unterminated quoted string in jsonpath input`},
+		{`$ ? (@ like_regex "a" flag "z")`,
+			`invalid input syntax for type jsonpath: unrecognized flag character "z" in LIKE_REGEX predicate`},
+		{`$.datetime()`, `unimplemented: jsonpath item method .datetime() is not supported`},
+	} {
+		_, err := Parse(tc.input)
+		require.EqualError(t, err, tc.err, "input: %s", tc.input)
+	}
+}
@@ -0,0 +1,924 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+)
+
+// tokenKind is the kind of a lexical token of a path.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	// tokIdent is an unquoted identifier, including keywords.
+	tokIdent
+	// tokString is a double-quoted string.
+	tokString
+	// tokNumber is a numeric literal.
+	tokNumber
+	// tokVariable is a named variable, like $x or $"x".
+	tokVariable
+	// tokPunct is an operator or punctuation, like && or [.
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	// val is the identifier, the unescaped string, the number or the
+	// punctuation.
+	val string
+	// pos is the position of the token in the input.
+	pos int
+}
+
+// punctuation lists the operators and punctuation of the language, longest
+// first.
+var punctuation = []string{
+	"**", "==", "!=", "<>", "<=", ">=", "&&", "||",
+	"$", "@", "<", ">", "!", "+", "-", "*", "/", "%",
+	"(", ")", "[", "]", "{", "}", ",", ".", "?",
+}
+
+type lexer struct {
+	input  string
+	pos    int
+	tokens []token
+}
+
+func syntaxError(input string, tok token) error {
+	if tok.kind == tokEOF {
+		return pgerror.New(pgcode.Syntax, "syntax error at end of jsonpath input")
+	}
+	near := tok.val
+	if tok.kind == tokString || tok.kind == tokVariable {
+		near = input[tok.pos:]
+	}
+	return pgerror.Newf(pgcode.Syntax, "syntax error at or near %q of jsonpath input", near)
+}
+
+func (l *lexer) lex() error {
+	for {
+		for l.pos < len(l.input) && isSpace(l.input[l.pos]) {
+			l.pos++
+		}
+		if l.pos >= len(l.input) {
+			l.tokens = append(l.tokens, token{kind: tokEOF, pos: l.pos})
+			return nil
+		}
+		start := l.pos
+		c := l.input[l.pos]
+		switch {
+		case c == '"':
+			s, err := l.lexString()
+			if err != nil {
+				return err
+			}
+			l.tokens = append(l.tokens, token{kind: tokString, val: s, pos: start})
+		case c == '$' && l.pos+1 < len(l.input) && l.input[l.pos+1] == '"':
+			l.pos++
+			s, err := l.lexString()
+			if err != nil {
+				return err
+			}
+			l.tokens = append(l.tokens, token{kind: tokVariable, val: s, pos: start})
+		case c == '$' && l.pos+1 < len(l.input) && isIdentStart(l.input[l.pos+1:]):
+			l.pos++
+			l.tokens = append(l.tokens, token{kind: tokVariable, val: l.lexIdent(), pos: start})
+		case c >= '0' && c <= '9':
+			n, err := l.lexNumber()
+			if err != nil {
+				return err
+			}
+			l.tokens = append(l.tokens, token{kind: tokNumber, val: n, pos: start})
+		case isIdentStart(l.input[l.pos:]):
+			l.tokens = append(l.tokens, token{kind: tokIdent, val: l.lexIdent(), pos: start})
+		default:
+			found := false
+			for _, p := range punctuation {
+				if strings.HasPrefix(l.input[l.pos:], p) {
+					l.pos += len(p)
+					l.tokens = append(l.tokens, token{kind: tokPunct, val: p, pos: start})
+					found = true
+					break
+				}
+			}
+			if !found {
+				_, size := utf8.DecodeRuneInString(l.input[l.pos:])
+				return syntaxError(l.input, token{kind: tokPunct, val: l.input[l.pos : l.pos+size]})
+			}
+		}
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isIdentStart(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func (l *lexer) lexIdent() string {
+	start := l.pos
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		l.pos += size
+	}
+	return l.input[start:l.pos]
+}
+
+// lexNumber lexes a numeric literal. A trailing dot is not part of the
+// number, so that accessors can be applied to integers, like in 1.type().
+func (l *lexer) lexNumber() (string, error) {
+	start := l.pos
+	digits := func() {
+		for l.pos < len(l.input) && l.input[l.pos] >= '0' && l.input[l.pos] <= '9' {
+			l.pos++
+		}
+	}
+	digits()
+	if l.pos+1 < len(l.input) && l.input[l.pos] == '.' &&
+		l.input[l.pos+1] >= '0' && l.input[l.pos+1] <= '9' {
+		l.pos++
+		digits()
+	}
+	if l.pos < len(l.input) && (l.input[l.pos] == 'e' || l.input[l.pos] == 'E') {
+		save := l.pos
+		l.pos++
+		if l.pos < len(l.input) && (l.input[l.pos] == '+' || l.input[l.pos] == '-') {
+			l.pos++
+		}
+		if l.pos < len(l.input) && l.input[l.pos] >= '0' && l.input[l.pos] <= '9' {
+			digits()
+		} else {
+			l.pos = save
+		}
+	}
+	if l.pos < len(l.input) && isIdentStart(l.input[l.pos:]) {
+		return "", pgerror.Newf(pgcode.Syntax,
+			"trailing junk after numeric literal at or near %q of jsonpath input",
+			l.input[start:l.pos+1])
+	}
+	return l.input[start:l.pos], nil
+}
+
+// lexString lexes a double-quoted string, handling the same escape sequences
+// as JSON strings, as well as \v, \xNN and \u{N...}.
+func (l *lexer) lexString() (string, error) {
+	var sb strings.Builder
+	// Skip the opening quote.
+	l.pos++
+	for {
+		if l.pos >= len(l.input) {
+			return "", pgerror.New(pgcode.Syntax, "unterminated quoted string in jsonpath input")
+		}
+		c := l.input[l.pos]
+		switch c {
+		case '"':
+			l.pos++
+			return sb.String(), nil
+		case '\\':
+			l.pos++
+			if l.pos >= len(l.input) {
+				return "", pgerror.New(pgcode.Syntax, "unterminated quoted string in jsonpath input")
+			}
+			e := l.input[l.pos]
+			l.pos++
+			switch e {
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'v':
+				sb.WriteByte('\v')
+			case 'x':
+				r, err := l.lexHex(2, 2)
+				if err != nil {
+					return "", err
+				}
+				sb.WriteRune(r)
+			case 'u':
+				var r rune
+				var err error
+				if l.pos < len(l.input) && l.input[l.pos] == '{' {
+					l.pos++
+					r, err = l.lexHex(1, 6)
+					if err == nil {
+						if l.pos >= len(l.input) || l.input[l.pos] != '}' {
+							err = pgerror.New(pgcode.Syntax, "invalid Unicode escape sequence in jsonpath input")
+						}
+						l.pos++
+					}
+				} else {
+					r, err = l.lexHex(4, 4)
+					if err == nil && utf16IsHighSurrogate(r) {
+						// A high surrogate must be followed by a low surrogate.
+						if !strings.HasPrefix(l.input[l.pos:], `\u`) {
+							return "", pgerror.New(pgcode.Syntax, "invalid Unicode surrogate pair in jsonpath input")
+						}
+						l.pos += 2
+						var low rune
+						low, err = l.lexHex(4, 4)
+						r = (r-0xd800)<<10 + (low - 0xdc00) + 0x10000
+					}
+				}
+				if err != nil {
+					return "", err
+				}
+				if r == 0 {
+					return "", pgerror.New(pgcode.UntranslatableCharacter,
+						`unsupported Unicode escape sequence: \u0000 cannot be converted to text`)
+				}
+				sb.WriteRune(r)
+			default:
+				// Any other escaped character, including " and \, stands for
+				// itself.
+				sb.WriteByte(e)
+			}
+		default:
+			sb.WriteByte(c)
+			l.pos++
+		}
+	}
+}
+
+func utf16IsHighSurrogate(r rune) bool {
+	return r >= 0xd800 && r < 0xdc00
+}
+
+// lexHex lexes between min and max hexadecimal digits and returns their
+// value.
+func (l *lexer) lexHex(min, max int) (rune, error) {
+	start := l.pos
+	for l.pos < len(l.input) && l.pos-start < max && isHexDigit(l.input[l.pos]) {
+		l.pos++
+	}
+	if l.pos-start < min {
+		return 0, pgerror.New(pgcode.Syntax, "invalid hexadecimal character sequence in jsonpath input")
+	}
+	v, err := strconv.ParseUint(l.input[start:l.pos], 16, 32)
+	if err != nil || v > unicode.MaxRune {
+		return 0, pgerror.New(pgcode.Syntax, "invalid Unicode escape sequence in jsonpath input")
+	}
+	return rune(v), nil
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// parser is a recursive descent parser for paths. The grammar follows the
+// one of Postgres, in src/backend/utils/adt/jsonpath_gram.y.
+type parser struct {
+	input  string
+	tokens []token
+	pos    int
+	// inFilter is the nesting depth of filter expressions, in which @ is
+	// allowed.
+	inFilter int
+	// inSubscript is the nesting depth of array subscripts, in which last is
+	// allowed.
+	inSubscript int
+}
+
+// Parse parses a SQL/JSON path expression.
+func Parse(input string) (Path, error) {
+	l := lexer{input: input}
+	if err := l.lex(); err != nil {
+		return Path{}, err
+	}
+	p := parser{input: input, tokens: l.tokens}
+	var path Path
+	if p.isIdent("strict") {
+		path.Strict = true
+		p.pos++
+	} else if p.isIdent("lax") {
+		p.pos++
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return Path{}, err
+	}
+	if p.peek().kind != tokEOF {
+		return Path{}, p.errorAtPeek()
+	}
+	path.Root = root
+	return path, nil
+}
+
+// MustParse parses a path and panics on error. It's used for tests.
+func MustParse(input string) Path {
+	p, err := Parse(input)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isPunct(s string) bool {
+	t := p.peek()
+	return t.kind == tokPunct && t.val == s
+}
+
+func (p *parser) isIdent(s string) bool {
+	t := p.peek()
+	return t.kind == tokIdent && t.val == s
+}
+
+func (p *parser) errorAtPeek() error {
+	return syntaxError(p.input, p.peek())
+}
+
+func (p *parser) expectPunct(s string) error {
+	if !p.isPunct(s) {
+		return p.errorAtPeek()
+	}
+	p.pos++
+	return nil
+}
+
+// requirePredicate returns a syntax error at the current token if n is not a
+// predicate.
+func (p *parser) requirePredicate(n Node, tok token) error {
+	if !isPredicate(n) {
+		return syntaxError(p.input, tok)
+	}
+	return nil
+}
+
+// requireExpr returns a syntax error at the current token if n is a
+// predicate.
+func (p *parser) requireExpr(n Node, tok token) error {
+	if isPredicate(n) {
+		return syntaxError(p.input, tok)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("||") {
+		tok := p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.requirePredicate(left, tok); err != nil {
+			return nil, err
+		}
+		if err := p.requirePredicate(right, p.peek()); err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: OpOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("&&") {
+		tok := p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.requirePredicate(left, tok); err != nil {
+			return nil, err
+		}
+		if err := p.requirePredicate(right, p.peek()); err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: OpAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Node, error) {
+	if !p.isPunct("!") {
+		return p.parseComparison()
+	}
+	p.next()
+	// The operand of ! must be a parenthesized predicate or exists.
+	tok := p.peek()
+	if !p.isPunct("(") && !p.isIdent("exists") {
+		return nil, p.errorAtPeek()
+	}
+	operand, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.requirePredicate(operand, tok); err != nil {
+		return nil, err
+	}
+	return &Unary{Op: OpNot, Operand: operand}, nil
+}
+
+var comparisonOps = map[string]BinaryOp{
+	"==": OpEq,
+	"!=": OpNe,
+	"<>": OpNe,
+	"<":  OpLt,
+	"<=": OpLe,
+	">":  OpGt,
+	">=": OpGe,
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	tok := p.peek()
+	if op, ok := comparisonOps[tok.val]; ok && tok.kind == tokPunct {
+		p.next()
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.requireExpr(left, tok); err != nil {
+			return nil, err
+		}
+		if err := p.requireExpr(right, p.peek()); err != nil {
+			return nil, err
+		}
+		return &Binary{Op: op, Left: left, Right: right}, nil
+	}
+	switch {
+	case p.isIdent("like_regex"):
+		if err := p.requireExpr(left, tok); err != nil {
+			return nil, err
+		}
+		p.next()
+		if p.peek().kind != tokString {
+			return nil, p.errorAtPeek()
+		}
+		n := &LikeRegex{Expr: left, Pattern: p.next().val}
+		if p.isIdent("flag") {
+			p.next()
+			if p.peek().kind != tokString {
+				return nil, p.errorAtPeek()
+			}
+			n.Flags = p.next().val
+		}
+		if err := n.compile(); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case p.isIdent("starts"):
+		if err := p.requireExpr(left, tok); err != nil {
+			return nil, err
+		}
+		p.next()
+		if !p.isIdent("with") {
+			return nil, p.errorAtPeek()
+		}
+		p.next()
+		var prefix Node
+		switch t := p.next(); t.kind {
+		case tokString:
+			prefix = &Literal{Value: json.FromString(t.val)}
+		case tokVariable:
+			prefix = &Variable{Name: t.val}
+		default:
+			return nil, syntaxError(p.input, t)
+		}
+		return &StartsWith{Expr: left, Prefix: prefix}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseAdditive() (Node, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("+") || p.isPunct("-") {
+		tok := p.next()
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.requireExpr(left, tok); err != nil {
+			return nil, err
+		}
+		if err := p.requireExpr(right, p.peek()); err != nil {
+			return nil, err
+		}
+		op := OpAdd
+		if tok.val == "-" {
+			op = OpSub
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseMultiplicative() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("*") || p.isPunct("/") || p.isPunct("%") {
+		tok := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.requireExpr(left, tok); err != nil {
+			return nil, err
+		}
+		if err := p.requireExpr(right, p.peek()); err != nil {
+			return nil, err
+		}
+		op := OpMul
+		switch tok.val {
+		case "/":
+			op = OpDiv
+		case "%":
+			op = OpMod
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if !p.isPunct("+") && !p.isPunct("-") {
+		return p.parseAccessor()
+	}
+	tok := p.next()
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.requireExpr(operand, p.peek()); err != nil {
+		return nil, err
+	}
+	// Like Postgres, fold the sign into numeric literals.
+	if l, ok := operand.(*Literal); ok && l.Value.Type() == json.NumberJSONType {
+		if tok.val == "+" {
+			return l, nil
+		}
+		d, _ := l.Value.AsDecimal()
+		var neg apd.Decimal
+		neg.Neg(d)
+		return &Literal{Value: json.FromDecimal(neg)}, nil
+	}
+	op := OpPlus
+	if tok.val == "-" {
+		op = OpMinus
+	}
+	return &Unary{Op: op, Operand: operand}, nil
+}
+
+func (p *parser) parseAccessor() (Node, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	var chain []AccessorOp
+	for {
+		var op AccessorOp
+		var err error
+		switch {
+		case p.isPunct("."):
+			p.next()
+			op, err = p.parseDotAccessor()
+		case p.isPunct("["):
+			p.next()
+			op, err = p.parseArrayAccessor()
+		case p.isPunct("?"):
+			p.next()
+			op, err = p.parseFilter()
+		default:
+			if len(chain) == 0 {
+				return base, nil
+			}
+			return &Accessor{Base: base, Chain: chain}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Accessors can only be applied to parenthesized predicates, which
+		// then evaluate to their truth value, but not to other predicates.
+		if len(chain) == 0 {
+			switch base.(type) {
+			case *Exists, *IsUnknown:
+				return nil, syntaxError(p.input, p.tokens[p.pos-1])
+			}
+		}
+		chain = append(chain, op)
+	}
+}
+
+// methods maps the names of the item methods to the methods.
+var methods = map[string]MethodName{
+	string(MethodType):     MethodType,
+	string(MethodSize):     MethodSize,
+	string(MethodDouble):   MethodDouble,
+	string(MethodCeiling):  MethodCeiling,
+	string(MethodFloor):    MethodFloor,
+	string(MethodAbs):      MethodAbs,
+	string(MethodKeyValue): MethodKeyValue,
+}
+
+// parseDotAccessor parses the accessor after a dot: a key, a wildcard, a
+// recursive wildcard or an item method.
+func (p *parser) parseDotAccessor() (AccessorOp, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokString:
+		return &Key{Name: tok.val}, nil
+	case tokIdent:
+		if next := p.peek(); next.kind == tokPunct && next.val == "(" {
+			if tok.val == "datetime" {
+				return nil, unimplementedDatetime()
+			}
+			m, ok := methods[tok.val]
+			if !ok {
+				return nil, syntaxError(p.input, next)
+			}
+			p.next()
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			return &Method{Name: m}, nil
+		}
+		return &Key{Name: tok.val}, nil
+	case tokVariable:
+		// Postgres accepts .$var as a key named after the variable.
+		return &Key{Name: "$" + tok.val}, nil
+	case tokPunct:
+		switch tok.val {
+		case "*":
+			return &AnyKey{}, nil
+		case "**":
+			return p.parseAnyPath()
+		}
+	}
+	return nil, syntaxError(p.input, tok)
+}
+
+func (p *parser) parseAnyPath() (AccessorOp, error) {
+	a := &AnyPath{First: 0, Last: AnyLevel}
+	if !p.isPunct("{") {
+		return a, nil
+	}
+	p.next()
+	first, err := p.parseAnyLevel()
+	if err != nil {
+		return nil, err
+	}
+	a.First, a.Last = first, first
+	if p.isIdent("to") {
+		p.next()
+		if a.Last, err = p.parseAnyLevel(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expectPunct("}"); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (p *parser) parseAnyLevel() (int, error) {
+	tok := p.next()
+	switch {
+	case tok.kind == tokIdent && tok.val == "last":
+		return AnyLevel, nil
+	case tok.kind == tokNumber:
+		n, err := strconv.ParseInt(tok.val, 10, 32)
+		if err == nil && n >= 0 {
+			return int(n), nil
+		}
+	}
+	return 0, syntaxError(p.input, tok)
+}
+
+func (p *parser) parseArrayAccessor() (AccessorOp, error) {
+	if p.isPunct("*") && p.peekAt(1).kind == tokPunct && p.peekAt(1).val == "]" {
+		p.pos += 2
+		return &AnyArray{}, nil
+	}
+	p.inSubscript++
+	defer func() { p.inSubscript-- }()
+	var idx Index
+	for {
+		var s Subscript
+		var err error
+		tok := p.peek()
+		if s.From, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+		if err := p.requireExpr(s.From, tok); err != nil {
+			return nil, err
+		}
+		if p.isIdent("to") {
+			p.next()
+			tok := p.peek()
+			if s.To, err = p.parseOr(); err != nil {
+				return nil, err
+			}
+			if err := p.requireExpr(s.To, tok); err != nil {
+				return nil, err
+			}
+		}
+		idx.Subscripts = append(idx.Subscripts, s)
+		if p.isPunct("]") {
+			p.next()
+			return &idx, nil
+		}
+		if err := p.expectPunct(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseFilter() (AccessorOp, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	p.inFilter++
+	defer func() { p.inFilter-- }()
+	pred, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.requirePredicate(pred, p.peek()); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	return &Filter{Pred: pred}, nil
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokString:
+		return &Literal{Value: json.FromString(tok.val)}, nil
+	case tokNumber:
+		var d apd.Decimal
+		if _, _, err := d.SetString(tok.val); err != nil {
+			return nil, syntaxError(p.input, tok)
+		}
+		return &Literal{Value: json.FromDecimal(d)}, nil
+	case tokVariable:
+		return &Variable{Name: tok.val}, nil
+	case tokIdent:
+		switch tok.val {
+		case "true":
+			return &Literal{Value: json.TrueJSONValue}, nil
+		case "false":
+			return &Literal{Value: json.FalseJSONValue}, nil
+		case "null":
+			return &Literal{Value: json.NullJSONValue}, nil
+		case "last":
+			if p.inSubscript == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "LAST is allowed only in array subscripts")
+			}
+			return &Last{}, nil
+		case "exists":
+			if err := p.expectPunct("("); err != nil {
+				return nil, err
+			}
+			tok := p.peek()
+			expr, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.requireExpr(expr, tok); err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			return &Exists{Expr: expr}, nil
+		}
+	case tokPunct:
+		switch tok.val {
+		case "$":
+			return &Root{}, nil
+		case "@":
+			if p.inFilter == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "@ is not allowed in root expressions")
+			}
+			return &Current{}, nil
+		case "(":
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			if p.isIdent("is") {
+				p.next()
+				if !p.isIdent("unknown") {
+					return nil, p.errorAtPeek()
+				}
+				p.next()
+				if !isPredicate(inner) {
+					return nil, syntaxError(p.input, p.tokens[p.pos-2])
+				}
+				return &IsUnknown{Pred: inner}, nil
+			}
+			return inner, nil
+		}
+	}
+	return nil, syntaxError(p.input, tok)
+}
+
+// compile validates the flags of the regular expression and compiles it.
+// The flags are the ones of XQuery: i for case-insensitive matching, s to let
+// . match newlines, m for multi-line mode, x to ignore whitespace and q to
+// quote the whole pattern.
+func (n *LikeRegex) compile() error {
+	pattern := n.Pattern
+	var goFlags strings.Builder
+	for _, f := range n.Flags {
+		switch f {
+		case 'i', 's', 'm':
+			goFlags.WriteRune(f)
+		case 'x':
+			return unimplemented.NewWithIssueDetail(22513, "like_regex x flag",
+				`XQuery "x" flag (expanded regular expressions) is not implemented`)
+		case 'q':
+			pattern = regexp.QuoteMeta(pattern)
+		default:
+			return pgerror.Newf(pgcode.Syntax,
+				"invalid input syntax for type jsonpath: unrecognized flag character %q in LIKE_REGEX predicate",
+				string(f))
+		}
+	}
+	if goFlags.Len() > 0 {
+		pattern = "(?" + goFlags.String() + ")" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return pgerror.Wrap(err, pgcode.InvalidRegularExpression, "invalid regular expression")
+	}
+	n.re = re
+	return nil
+}
+
+func unimplementedDatetime() error {
+	return unimplemented.NewWithIssueDetail(22513, "datetime",
+		"jsonpath item method .datetime() is not supported")
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+var alphabet = "abcdefghijklmnopqrstuvwxyz"
+
+func randomKey(rng *rand.Rand) string {
+	l := make([]byte, 1+rng.Intn(5))
+	for i := range l {
+		l[i] = alphabet[rng.Intn(len(alphabet))]
+	}
+	return string(l)
+}
+
+// RandomPath returns a random jsonpath for testing.
+func RandomPath(rng *rand.Rand) Path {
+	var sb strings.Builder
+	if rng.Intn(4) == 0 {
+		sb.WriteString("strict ")
+	}
+	sb.WriteString("$")
+	for i, n := 0, rng.Intn(4); i < n; i++ {
+		switch rng.Intn(4) {
+		case 0, 1:
+			sb.WriteString(".")
+			sb.WriteString(randomKey(rng))
+		case 2:
+			sb.WriteString("[")
+			sb.WriteString(strconv.Itoa(rng.Intn(10)))
+			sb.WriteString("]")
+		case 3:
+			sb.WriteString("[*]")
+		}
+	}
+	if rng.Intn(2) == 0 {
+		sb.WriteString(" ? (@.")
+		sb.WriteString(randomKey(rng))
+		sb.WriteString(" > ")
+		sb.WriteString(strconv.Itoa(rng.Intn(100)))
+		sb.WriteString(")")
+	}
+	return MustParse(sb.String())
+}
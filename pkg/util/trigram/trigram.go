@@ -32,13 +32,43 @@ func MakeTrigrams(s string, pad bool) []string {
 
 	// Approximately pre-size as if the string is all 1 big word.
 	output := make([]string, 0, len(s)+2)
+	forEachWord(s, func(word string, oneByteCharsOnly bool) {
+		output = generateTrigrams(output, word, pad, oneByteCharsOnly)
+	})
 
+	if len(output) == 0 {
+		return nil
+	}
+
+	// Sort the array and deduplicate.
+	sort.Strings(output)
+
+	// Then distinct: (wouldn't it be nice if Go had generics?)
+	lastUniqueIdx := 0
+	for i := 1; i < len(output); i++ {
+		if output[i] != output[lastUniqueIdx] {
+			// We found a unique entry, at index i. The last unique entry in the array
+			// was at lastUniqueIdx, so set the entry after that one to our new unique
+			// entry, and bump lastUniqueIdx for the next loop iteration.
+			lastUniqueIdx++
+			output[lastUniqueIdx] = output[i]
+		}
+	}
+	output = output[:lastUniqueIdx+1]
+
+	return output
+}
+
+// forEachWord calls fn on each word of the input string. Non-alphanumeric
+// characters (calculated via unicode's Letter and Number designations) are
+// treated as word boundaries. oneByteCharsOnly is true if the word contains no
+// wide characters.
+func forEachWord(s string, fn func(word string, oneByteCharsOnly bool)) {
 	start := -1
 	oneByteCharsOnly := true
-	// Loop through the input string searching for word boundaries. For each found
-	// word, generate trigrams and add to the output list. The start and end
-	// variables are used to track the beginning and end of the current word
-	// throughout the loop.
+	// Loop through the input string searching for word boundaries. The start
+	// and end variables are used to track the beginning and end of the current
+	// word throughout the loop.
 	// This loop would be more ergonomic with strings.FieldsFunc, but doing so
 	// costs twice the allocations.
 	for end, r := range s {
@@ -60,36 +90,14 @@ func MakeTrigrams(s string, pad bool) []string {
 			continue
 		}
 
-		output = generateTrigrams(output, s[start:end], pad, oneByteCharsOnly)
+		fn(s[start:end], oneByteCharsOnly)
 		oneByteCharsOnly = true
 		start = -1
 	}
 	if start >= 0 {
 		// Collect final word.
-		output = generateTrigrams(output, s[start:], pad, oneByteCharsOnly)
+		fn(s[start:], oneByteCharsOnly)
 	}
-
-	if len(output) == 0 {
-		return nil
-	}
-
-	// Sort the array and deduplicate.
-	sort.Strings(output)
-
-	// Then distinct: (wouldn't it be nice if Go had generics?)
-	lastUniqueIdx := 0
-	for i := 1; i < len(output); i++ {
-		if output[i] != output[lastUniqueIdx] {
-			// We found a unique entry, at index i. The last unique entry in the array
-			// was at lastUniqueIdx, so set the entry after that one to our new unique
-			// entry, and bump lastUniqueIdx for the next loop iteration.
-			lastUniqueIdx++
-			output[lastUniqueIdx] = output[i]
-		}
-	}
-	output = output[:lastUniqueIdx+1]
-
-	return output
 }
 
 func generateTrigrams(appendTo []string, word string, pad bool, onlyOneByteChars bool) []string {
@@ -169,3 +177,155 @@ func Similarity(l string, r string) float64 {
 	shared := float64(nShared)
 	return shared / (float64(len(lTrigrams)+len(rTrigrams)) - shared)
 }
+
+// WordSimilarity returns the greatest trigram similarity between the set of
+// trigrams in l and any continuous extent of the ordered trigrams in r. It
+// matches Postgres' word_similarity function.
+func WordSimilarity(l string, r string) float64 {
+	return wordSimilarity(l, r, false /* strict */)
+}
+
+// StrictWordSimilarity is like WordSimilarity, but it forces the extent of r
+// to match word boundaries. It matches Postgres' strict_word_similarity
+// function.
+func StrictWordSimilarity(l string, r string) float64 {
+	return wordSimilarity(l, r, true /* strict */)
+}
+
+// Flags that mark a trigram as the first or last trigram of its word.
+const (
+	boundLeft = 1 << iota
+	boundRight
+)
+
+// makeOrderedTrigrams returns the padded trigrams of the input string in the
+// order that they appear, without de-duplication. Each trigram is paired with
+// a set of flags indicating whether it lies on a word boundary.
+func makeOrderedTrigrams(s string) (trigrams []string, bounds []uint8) {
+	s = strings.ToLower(s)
+	trigrams = make([]string, 0, len(s)+2)
+	forEachWord(s, func(word string, oneByteCharsOnly bool) {
+		start := len(trigrams)
+		trigrams = generateTrigrams(trigrams, word, true /* pad */, oneByteCharsOnly)
+		for len(bounds) < len(trigrams) {
+			bounds = append(bounds, 0)
+		}
+		if start < len(trigrams) {
+			bounds[start] |= boundLeft
+			bounds[len(trigrams)-1] |= boundRight
+		}
+	})
+	return trigrams, bounds
+}
+
+func wordSimilarity(l string, r string, strict bool) float64 {
+	lTrigrams := MakeTrigrams(l, true /* pad */)
+	if len(lTrigrams) == 0 {
+		return 0
+	}
+	rTrigrams, bounds := makeOrderedTrigrams(r)
+	if len(rTrigrams) == 0 {
+		return 0
+	}
+
+	// Assign each distinct trigram an index. The trigrams of l are assigned the
+	// first indexes, so a trigram of r is found in l iff its index is less than
+	// len(lTrigrams).
+	indexes := make(map[string]int, len(lTrigrams)+len(rTrigrams))
+	for i, t := range lTrigrams {
+		indexes[t] = i
+	}
+	rIndexes := make([]int, len(rTrigrams))
+	for i, t := range rTrigrams {
+		idx, ok := indexes[t]
+		if !ok {
+			idx = len(indexes)
+			indexes[t] = idx
+		}
+		rIndexes[i] = idx
+	}
+	return iterateWordSimilarity(rIndexes, bounds, len(lTrigrams), len(indexes), strict)
+}
+
+// iterateWordSimilarity finds the extent of the ordered trigrams of r with the
+// greatest similarity to the trigrams of l. It is a port of
+// iterate_word_similarity in Postgres contrib/pg_trgm/trgm_op.c.
+func iterateWordSimilarity(
+	rIndexes []int, bounds []uint8, numLTrigrams int, numTrigrams int, strict bool,
+) float64 {
+	found := func(idx int) bool { return idx < numLTrigrams }
+	calcSimilarity := func(count, rLen int) float64 {
+		return float64(count) / float64(numLTrigrams+rLen-count)
+	}
+
+	// lastPos[idx] is the last position at which the trigram with the given
+	// index was seen within the current extent, or -1.
+	lastPos := make([]int, numTrigrams)
+	for i := range lastPos {
+		lastPos[i] = -1
+	}
+
+	var maxSim float64
+	// count is the number of trigrams of l in the current extent, and rLen is
+	// the number of distinct trigrams in the current extent.
+	count, rLen := 0, 0
+	lower := -1
+	if strict {
+		lower = 0
+	}
+	for upper, idx := range rIndexes {
+		if lower >= 0 || found(idx) {
+			if lastPos[idx] < 0 {
+				rLen++
+				if found(idx) {
+					count++
+				}
+			}
+			lastPos[idx] = upper
+		}
+
+		// Only consider extents whose upper bound is a trigram of l, or in
+		// strict mode, the end of a word.
+		if strict {
+			if bounds[upper]&boundRight == 0 {
+				continue
+			}
+		} else if !found(idx) {
+			continue
+		}
+		if lower == -1 {
+			lower = upper
+			rLen = 1
+		}
+		curSim := calcSimilarity(count, rLen)
+
+		// Try to move the lower bound forward to increase the similarity.
+		tmpCount, tmpLen, prevLower := count, rLen, lower
+		for tmpLower := lower; tmpLower <= upper; tmpLower++ {
+			if !strict || bounds[tmpLower]&boundLeft != 0 {
+				if tmpSim := calcSimilarity(tmpCount, tmpLen); tmpSim > curSim {
+					curSim, rLen, lower, count = tmpSim, tmpLen, tmpLower, tmpCount
+				}
+			}
+			tmpIdx := rIndexes[tmpLower]
+			if lastPos[tmpIdx] == tmpLower {
+				tmpLen--
+				if found(tmpIdx) {
+					tmpCount--
+				}
+			}
+		}
+		if curSim > maxSim {
+			maxSim = curSim
+		}
+
+		// Forget the trigrams that fell out of the extent.
+		for tmpLower := prevLower; tmpLower < lower; tmpLower++ {
+			tmpIdx := rIndexes[tmpLower]
+			if lastPos[tmpIdx] == tmpLower {
+				lastPos[tmpIdx] = -1
+			}
+		}
+	}
+	return maxSim
+}
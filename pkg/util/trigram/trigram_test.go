@@ -86,6 +86,33 @@ func TestSimilarity(t *testing.T) {
 	}
 }
 
+func TestWordSimilarity(t *testing.T) {
+	for _, tc := range []struct {
+		l          string
+		r          string
+		want       float64
+		wantStrict float64
+	}{
+		// Empty cases.
+		{"", "", 0, 0},
+		{"a", "", 0, 0},
+		{"", "a", 0, 0},
+
+		{"a", "a", 1, 1},
+		{"word", "word", 1, 1},
+		{"word", "two words", 0.8, 0.5714},
+		{"word", "words", 0.8, 0.5714},
+		{"two words", "word", 0.4, 0.3636},
+		{"trigram", "this is a trigram test", 1, 1},
+		{"trigram", "this is a trigramtest", 0.875, 0.5384},
+		{"gram", "trigram", 0.6, 0.3},
+		{"abc", "xyz", 0, 0},
+	} {
+		assert.InDelta(t, tc.want, WordSimilarity(tc.l, tc.r), 0.0001, "for %s <%% %s", tc.l, tc.r)
+		assert.InDelta(t, tc.wantStrict, StrictWordSimilarity(tc.l, tc.r), 0.0001, "for %s <<%% %s", tc.l, tc.r)
+	}
+}
+
 func BenchmarkSimilarity(b *testing.B) {
 	for _, t := range []struct {
 		x string
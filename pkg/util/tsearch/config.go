@@ -0,0 +1,364 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// This file implements text search configurations, which control how a
+// document or a query is turned into a list of lexemes. A document is first
+// split into tokens by the parser, and then each word token is normalized by
+// the configuration: words are lower-cased, stop words are discarded, and the
+// remaining words are reduced to their stems.
+//
+// Unlike Postgres, there is only one parser, which splits the input into words
+// made up of letters and digits. Words that contain a digit are never stemmed
+// or treated as stop words, like Postgres does for its numword tokens.
+
+// textSearchConfig is a text search configuration.
+type textSearchConfig struct {
+	// stem is the stemming algorithm of the configuration. If it is nil, words
+	// are only lower-cased.
+	stem func(string) string
+	// stopwords is the set of words that are discarded by the configuration.
+	stopwords map[string]struct{}
+}
+
+var textSearchConfigs = map[string]*textSearchConfig{
+	"simple":  {},
+	"english": {stem: stemEnglish, stopwords: englishStopwords},
+	"french":  {stem: stemFrench, stopwords: frenchStopwords},
+	"german":  {stem: stemGerman, stopwords: germanStopwords},
+	"spanish": {stem: stemSpanish, stopwords: spanishStopwords},
+}
+
+// DefaultConfig is the default text search configuration.
+const DefaultConfig = "pg_catalog.english"
+
+// NormalizeConfigName returns the schema-qualified name of the text search
+// configuration with the given name, or an error if there is no such
+// configuration.
+func NormalizeConfigName(name string) (string, error) {
+	n, _, err := lookupConfig(name)
+	if err != nil {
+		return "", err
+	}
+	return "pg_catalog." + n, nil
+}
+
+// getConfig returns the text search configuration with the given name. The
+// name may be qualified with the pg_catalog schema.
+func getConfig(name string) (*textSearchConfig, error) {
+	_, c, err := lookupConfig(name)
+	return c, err
+}
+
+func lookupConfig(name string) (string, *textSearchConfig, error) {
+	n := strings.TrimPrefix(strings.ToLower(name), "pg_catalog.")
+	if c, ok := textSearchConfigs[n]; ok {
+		return n, c, nil
+	}
+	return "", nil, pgerror.Newf(pgcode.UndefinedObject,
+		"text search configuration %q does not exist", name)
+}
+
+// lexize returns the lexeme for the given word. It returns false if the word
+// is a stop word.
+func (c *textSearchConfig) lexize(word string) (string, bool) {
+	word = strings.ToLower(word)
+	if c.stem == nil || strings.IndexFunc(word, unicode.IsDigit) >= 0 {
+		return word, true
+	}
+	if _, ok := c.stopwords[word]; ok {
+		return "", false
+	}
+	return c.stem(word), true
+}
+
+// tsToken is a token produced by tsParse.
+type tsToken struct {
+	text string
+	// isWord is true if the token is a word, and false if it is a run of
+	// characters that separate words.
+	isWord bool
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// tsParse splits the input into alternating word and separator tokens.
+// Concatenating the text of the tokens returns the input.
+func tsParse(input string) []tsToken {
+	var ret []tsToken
+	start := 0
+	inWord := false
+	for i, r := range input {
+		if w := isWordRune(r); w != inWord {
+			if i > start {
+				ret = append(ret, tsToken{text: input[start:i], isWord: inWord})
+			}
+			start, inWord = i, w
+		}
+	}
+	if len(input) > start {
+		ret = append(ret, tsToken{text: input[start:], isWord: inWord})
+	}
+	return ret
+}
+
+// ToTSVector parses the input document into a TSVector using the given text
+// search configuration. Every word in the document, including stop words,
+// occupies a position.
+func ToTSVector(config string, input string) (TSVector, error) {
+	c, err := getConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	var ret TSVector
+	pos := 0
+	for _, tok := range tsParse(input) {
+		if !tok.isWord {
+			continue
+		}
+		pos++
+		lexeme, ok := c.lexize(tok.text)
+		if !ok {
+			continue
+		}
+		term, err := newLexemeTerm(lexeme)
+		if err != nil {
+			// Like Postgres, silently skip words that are too long to be stored.
+			continue
+		}
+		p := pos
+		if p > maxTSVectorPosition {
+			p = maxTSVectorPosition
+		}
+		term.positions = []tsPosition{{position: uint16(p)}}
+		ret = append(ret, term)
+	}
+	return normalizeTSVector(ret), nil
+}
+
+// makePhrase returns a tree of followedby nodes that matches the words of the
+// input in order. Stop words are discarded, but they increase the distance
+// between the surrounding words. Each leaf is given the input positions, which
+// carry the weight restrictions of the leaves. It returns nil if the input has
+// no words other than stop words.
+func (c *textSearchConfig) makePhrase(input string, positions []tsPosition) *tsNode {
+	var ret *tsNode
+	dist := 0
+	for _, tok := range tsParse(input) {
+		if !tok.isWord {
+			continue
+		}
+		dist++
+		lexeme, ok := c.lexize(tok.text)
+		if !ok {
+			continue
+		}
+		leaf := &tsNode{term: tsTerm{lexeme: lexeme, positions: positions}}
+		if ret == nil {
+			ret = leaf
+		} else {
+			if dist > maxTSVectorFollowedBy {
+				dist = maxTSVectorFollowedBy
+			}
+			ret = &tsNode{op: followedby, followedN: uint16(dist), l: ret, r: leaf}
+		}
+		dist = 0
+	}
+	return ret
+}
+
+// normalizeTSQueryNode returns a copy of the input query tree in which every
+// lexeme has been normalized using the configuration. Lexemes that are stop
+// words are removed from the tree, along with the operators that no longer
+// have operands.
+func (c *textSearchConfig) normalizeTSQueryNode(n *tsNode) *tsNode {
+	switch n.op {
+	case invalid:
+		return c.makePhrase(n.term.lexeme, n.term.positions)
+	case not:
+		l := c.normalizeTSQueryNode(n.l)
+		if l == nil {
+			return nil
+		}
+		return &tsNode{op: not, l: l}
+	}
+	l := c.normalizeTSQueryNode(n.l)
+	r := c.normalizeTSQueryNode(n.r)
+	if l == nil {
+		return r
+	}
+	if r == nil {
+		return l
+	}
+	return &tsNode{op: n.op, followedN: n.followedN, l: l, r: r}
+}
+
+// ToTSQuery parses the input, which must be in the TSQuery input format, and
+// normalizes its lexemes using the given text search configuration.
+func ToTSQuery(config string, input string) (TSQuery, error) {
+	c, err := getConfig(config)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	terms, err := lexTSQuery(input)
+	if err != nil || len(terms) == 0 {
+		return TSQuery{}, err
+	}
+	q, err := ParseTSQuery(input)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	return TSQuery{root: c.normalizeTSQueryNode(q.root)}, nil
+}
+
+// PlainToTSQuery returns a TSQuery that matches documents containing all of
+// the words of the input, which is treated as plain text.
+func PlainToTSQuery(config string, input string) (TSQuery, error) {
+	c, err := getConfig(config)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	var root *tsNode
+	for _, tok := range tsParse(input) {
+		if !tok.isWord {
+			continue
+		}
+		if leaf := c.makePhrase(tok.text, nil /* positions */); leaf != nil {
+			root = andNodes(root, leaf)
+		}
+	}
+	return TSQuery{root: root}, nil
+}
+
+// PhraseToTSQuery returns a TSQuery that matches documents containing the
+// words of the input, which is treated as plain text, in the same order.
+func PhraseToTSQuery(config string, input string) (TSQuery, error) {
+	c, err := getConfig(config)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	return TSQuery{root: c.makePhrase(input, nil /* positions */)}, nil
+}
+
+// WebSearchToTSQuery returns a TSQuery for the input, which uses a syntax
+// similar to the one used by web search engines:
+//   - unquoted words are combined with &.
+//   - text inside double quotes is turned into a phrase, as with
+//     PhraseToTSQuery. A quote that is never closed is ignored.
+//   - the word "or" combines the words or phrases on either side with |.
+//   - a dash immediately before a word or phrase negates it with !.
+//
+// The input never causes syntax errors.
+func WebSearchToTSQuery(config string, input string) (TSQuery, error) {
+	c, err := getConfig(config)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	var root, group *tsNode
+	negate := false
+	addOperand := func(n *tsNode) {
+		if n != nil {
+			if negate {
+				n = &tsNode{op: not, l: n}
+			}
+			group = andNodes(group, n)
+		}
+		negate = false
+	}
+	endGroup := func() {
+		if group != nil {
+			if root == nil {
+				root = group
+			} else {
+				root = &tsNode{op: or, l: root, r: group}
+			}
+		}
+		group = nil
+	}
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case r == '"':
+			j := i + 1
+			for j < len(runes) && runes[j] != '"' {
+				j++
+			}
+			if j == len(runes) {
+				// Ignore a quote that is never closed.
+				i++
+				continue
+			}
+			addOperand(c.makePhrase(string(runes[i+1:j]), nil /* positions */))
+			i = j + 1
+		case r == '-' && (i == 0 || !isWordRune(runes[i-1])) &&
+			i+1 < len(runes) && (isWordRune(runes[i+1]) || runes[i+1] == '"'):
+			negate = true
+			i++
+		case isWordRune(r):
+			j := i
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+			if word := string(runes[i:j]); !negate && strings.EqualFold(word, "or") {
+				endGroup()
+			} else {
+				addOperand(c.makePhrase(word, nil /* positions */))
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+	endGroup()
+	return TSQuery{root: root}, nil
+}
+
+// andNodes returns a node that combines l and r with the & operator. If l is
+// nil, it returns r.
+func andNodes(l, r *tsNode) *tsNode {
+	if l == nil {
+		return r
+	}
+	return &tsNode{op: and, l: l, r: r}
+}
+
+// TSLexize returns the lexemes that the given text search dictionary produces
+// for the input token. The dictionaries are named after the text search
+// configurations, with a _stem suffix, except for the simple dictionary. Stop
+// words produce no lexemes.
+func TSLexize(dictionary string, token string) ([]string, error) {
+	name := strings.ToLower(dictionary)
+	var c *textSearchConfig
+	if name == "simple" {
+		c = textSearchConfigs[name]
+	} else if n := strings.TrimSuffix(name, "_stem"); n != name && n != "simple" {
+		c = textSearchConfigs[n]
+	}
+	if c == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", dictionary)
+	}
+	lexeme, ok := c.lexize(token)
+	if !ok {
+		return []string{}, nil
+	}
+	return []string{lexeme}, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStem(t *testing.T) {
+	for _, tc := range []struct {
+		stem     func(string) string
+		input    string
+		expected string
+	}{
+		{stemEnglish, "running", "run"},
+		{stemEnglish, "jumped", "jump"},
+		{stemEnglish, "foxes", "fox"},
+		{stemEnglish, "supernovae", "supernova"},
+		{stemEnglish, "stars", "star"},
+		{stemEnglish, "generously", "generous"},
+		{stemEnglish, "consigned", "consign"},
+		{stemEnglish, "knightly", "knight"},
+		{stemEnglish, "dying", "die"},
+		{stemEnglish, "skies", "sky"},
+		{stemEnglish, "news", "news"},
+		{stemEnglish, "relational", "relat"},
+		{stemEnglish, "happiness", "happi"},
+		{stemEnglish, "caresses", "caress"},
+
+		{stemGerman, "häuser", "haus"},
+		{stemGerman, "aufeinanderfolgenden", "aufeinanderfolg"},
+		{stemGerman, "kategorischen", "kategor"},
+		{stemGerman, "bücher", "buch"},
+
+		{stemSpanish, "continuación", "continu"},
+		{stemSpanish, "cantando", "cant"},
+		{stemSpanish, "bibliotecas", "bibliotec"},
+		{stemSpanish, "abandonados", "abandon"},
+		{stemSpanish, "rápidamente", "rapid"},
+
+		{stemFrench, "continuation", "continu"},
+		{stemFrench, "chevaux", "cheval"},
+		{stemFrench, "nationalité", "national"},
+		{stemFrench, "voyageuse", "voyag"},
+	} {
+		assert.Equal(t, tc.expected, tc.stem(tc.input), "input: %s", tc.input)
+	}
+}
+
+func TestToTSVector(t *testing.T) {
+	for _, tc := range []struct {
+		config   string
+		input    string
+		expected string
+	}{
+		{"english", "", ""},
+		{"english", "The quick brown foxes jumped over the lazy dogs, 3 times!",
+			`'3':10 'brown':3 'dog':9 'fox':4 'jump':5 'lazi':8 'quick':2 'time':11`},
+		{"simple", "The quick brown foxes", `'brown':3 'foxes':4 'quick':2 'the':1`},
+		{"pg_catalog.english", "stars stars STARS", `'star':1,2,3`},
+		{"English", "a fat cat", `'cat':3 'fat':2`},
+		{"german", "Die Bücher", `'buch':2`},
+	} {
+		v, err := ToTSVector(tc.config, tc.input)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, v.String(), "input: %s", tc.input)
+	}
+
+	_, err := ToTSVector("klingon", "foo")
+	require.EqualError(t, err, `text search configuration "klingon" does not exist`)
+}
+
+func TestToTSQuery(t *testing.T) {
+	for _, tc := range []struct {
+		fn       func(string, string) (TSQuery, error)
+		input    string
+		expected string
+	}{
+		{ToTSQuery, "", ""},
+		{ToTSQuery, "The & Fat & Rats", `'fat' & 'rat'`},
+		{ToTSQuery, "supernovae & !stars:AB", `'supernova' & !'star':AB`},
+		{ToTSQuery, "'fat cats ate' <-> rats", `'fat' <-> 'cat' <-> 'ate' <-> 'rat'`},
+		{ToTSQuery, "the | !a", ``},
+		{ToTSQuery, "star:*", `'star':*`},
+
+		{PlainToTSQuery, "The Fat Rats", `'fat' & 'rat'`},
+		{PlainToTSQuery, "the", ``},
+
+		{PhraseToTSQuery, "The Cat and the Rats", `'cat' <3> 'rat'`},
+		{PhraseToTSQuery, "fat cats", `'fat' <-> 'cat'`},
+
+		{WebSearchToTSQuery, `fat rat`, `'fat' & 'rat'`},
+		{WebSearchToTSQuery, `"supernovae stars" -crab`, `'supernova' <-> 'star' & !'crab'`},
+		{WebSearchToTSQuery, `"sad cat" or "fat rat"`, `'sad' <-> 'cat' | 'fat' <-> 'rat'`},
+		{WebSearchToTSQuery, `signal -"segmentation fault"`, `'signal' & !( 'segment' <-> 'fault' )`},
+		{WebSearchToTSQuery, `state-of-the-art`, `'state' & 'art'`},
+		{WebSearchToTSQuery, `""" )( dummy \\ query <->`, `'dummi' & 'queri'`},
+	} {
+		q, err := tc.fn("english", tc.input)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, q.String(), "input: %s", tc.input)
+	}
+}
+
+func TestTSLexize(t *testing.T) {
+	for _, tc := range []struct {
+		dict     string
+		input    string
+		expected []string
+	}{
+		{"english_stem", "stars", []string{"star"}},
+		{"english_stem", "a", []string{}},
+		{"simple", "Stars", []string{"stars"}},
+		{"spanish_stem", "bibliotecas", []string{"bibliotec"}},
+	} {
+		lexemes, err := TSLexize(tc.dict, tc.input)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, lexemes)
+	}
+
+	_, err := TSLexize("english", "stars")
+	require.EqualError(t, err, `text search dictionary "english" does not exist`)
+}
@@ -0,0 +1,522 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// This file implements ts_headline, which displays the fragments of a
+// document that match a query. The selection of fragments follows the
+// Postgres implementation in src/backend/tsearch/wparser_def.c, treating
+// phrase operators like & operators.
+
+// HeadlineOptions are the options accepted by Headline.
+type HeadlineOptions struct {
+	// StartSel and StopSel are the strings that delimit the query words that
+	// appear in the headline.
+	StartSel, StopSel string
+	// MaxWords and MinWords are the longest and shortest headlines to output.
+	MaxWords, MinWords int
+	// ShortWord is the length of the words that are dropped at the start and
+	// end of a headline, unless they are query words.
+	ShortWord int
+	// HighlightAll, if true, causes the whole document to be used as the
+	// headline, ignoring the preceding three options.
+	HighlightAll bool
+	// MaxFragments is the maximum number of text fragments to display. If it
+	// is zero, a single fragment is displayed.
+	MaxFragments int
+	// FragmentDelimiter is the string that separates fragments, when more than
+	// one is displayed.
+	FragmentDelimiter string
+}
+
+// DefaultHeadlineOptions returns the default options for Headline.
+func DefaultHeadlineOptions() HeadlineOptions {
+	return HeadlineOptions{
+		StartSel:          "<b>",
+		StopSel:           "</b>",
+		MaxWords:          35,
+		MinWords:          15,
+		ShortWord:         3,
+		FragmentDelimiter: " ... ",
+	}
+}
+
+// ParseHeadlineOptions parses a comma-separated list of option=value pairs,
+// and returns the resulting options. Unspecified options keep their default
+// values.
+func ParseHeadlineOptions(input string) (HeadlineOptions, error) {
+	opts := DefaultHeadlineOptions()
+	for _, pair := range strings.Split(input, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		eq := strings.IndexByte(pair, '=')
+		if eq < 0 {
+			return opts, pgerror.Newf(pgcode.Syntax, "invalid parameter list format: %q", input)
+		}
+		key := strings.TrimSpace(pair[:eq])
+		val := strings.TrimSpace(pair[eq+1:])
+		if len(val) >= 2 && (val[0] == '"' || val[0] == '\'') && val[len(val)-1] == val[0] {
+			val = val[1 : len(val)-1]
+		}
+		var err error
+		switch strings.ToLower(key) {
+		case "startsel":
+			opts.StartSel = val
+		case "stopsel":
+			opts.StopSel = val
+		case "maxwords":
+			opts.MaxWords, err = parseHeadlineInt(val)
+		case "minwords":
+			opts.MinWords, err = parseHeadlineInt(val)
+		case "shortword":
+			opts.ShortWord, err = parseHeadlineInt(val)
+		case "highlightall":
+			switch strings.ToLower(val) {
+			case "1", "on", "true", "t", "y", "yes":
+				opts.HighlightAll = true
+			default:
+				opts.HighlightAll = false
+			}
+		case "maxfragments":
+			opts.MaxFragments, err = parseHeadlineInt(val)
+		case "fragmentdelimiter":
+			opts.FragmentDelimiter = val
+		default:
+			return opts, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized headline parameter: %q", key)
+		}
+		if err != nil {
+			return opts, err
+		}
+	}
+	return opts, opts.validate()
+}
+
+func parseHeadlineInt(val string) (int, error) {
+	i, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return 0, pgerror.Newf(pgcode.InvalidTextRepresentation,
+			"invalid input syntax for type integer: %q", val)
+	}
+	return int(i), nil
+}
+
+func (o HeadlineOptions) validate() error {
+	if o.HighlightAll {
+		return nil
+	}
+	if o.MinWords >= o.MaxWords {
+		return pgerror.New(pgcode.InvalidParameterValue, "MinWords should be less than MaxWords")
+	}
+	if o.MinWords <= 0 {
+		return pgerror.New(pgcode.InvalidParameterValue, "MinWords should be positive")
+	}
+	if o.ShortWord < 0 {
+		return pgerror.New(pgcode.InvalidParameterValue, "ShortWord should be >= 0")
+	}
+	if o.MaxFragments < 0 {
+		return pgerror.New(pgcode.InvalidParameterValue, "MaxFragments should be >= 0")
+	}
+	return nil
+}
+
+// hlWord is a token of a document for which a headline is generated.
+type hlWord struct {
+	tsToken
+	// items are the query lexemes that match the word.
+	items []*tsNode
+	// in is true if the word is part of the headline.
+	in bool
+}
+
+// headline holds the state used to generate a headline.
+type headline struct {
+	words []hlWord
+	q     TSQuery
+	opts  HeadlineOptions
+}
+
+func (h *headline) isWord(i int) bool {
+	return h.words[i].isWord
+}
+
+func (h *headline) interesting(i int) bool {
+	return len(h.words[i].items) > 0
+}
+
+// badEndpoint returns whether a headline should not end at the given word,
+// because it is a separator or a short word that is not a query word.
+func (h *headline) badEndpoint(i int) bool {
+	w := &h.words[i]
+	return (!w.isWord || utf8.RuneCountInString(w.text) <= h.opts.ShortWord) && !h.interesting(i)
+}
+
+// Headline returns the fragments of the document that match the query, with
+// the query words highlighted.
+func Headline(config string, document string, q TSQuery, opts HeadlineOptions) (string, error) {
+	c, err := getConfig(config)
+	if err != nil {
+		return "", err
+	}
+	if err := opts.validate(); err != nil {
+		return "", err
+	}
+	leaves := q.leaves()
+	h := headline{q: q, opts: opts}
+	for _, tok := range tsParse(document) {
+		w := hlWord{tsToken: tok}
+		if tok.isWord {
+			if lexeme, ok := c.lexize(tok.text); ok {
+				for _, leaf := range leaves {
+					_, prefix := leafWeight(leaf)
+					if lexeme == leaf.term.lexeme || (prefix && strings.HasPrefix(lexeme, leaf.term.lexeme)) {
+						w.items = append(w.items, leaf)
+					}
+				}
+			}
+		}
+		h.words = append(h.words, w)
+	}
+	if len(h.words) == 0 {
+		return "", nil
+	}
+	switch {
+	case opts.HighlightAll:
+		h.mark(0, len(h.words)-1)
+	case opts.MaxFragments == 0:
+		h.markWords()
+	default:
+		h.markFragments()
+	}
+
+	var buf strings.Builder
+	inFragment := false
+	numFragments := 0
+	for i := range h.words {
+		w := &h.words[i]
+		if !w.in {
+			inFragment = false
+			continue
+		}
+		if !inFragment {
+			inFragment = true
+			numFragments++
+			if numFragments > 1 {
+				buf.WriteString(opts.FragmentDelimiter)
+			}
+		}
+		if h.interesting(i) {
+			buf.WriteString(opts.StartSel)
+			buf.WriteString(w.text)
+			buf.WriteString(opts.StopSel)
+		} else {
+			buf.WriteString(w.text)
+		}
+	}
+	return buf.String(), nil
+}
+
+// mark adds the words between start and end, inclusive, to the headline.
+func (h *headline) mark(start, end int) {
+	for i := start; i <= end; i++ {
+		h.words[i].in = true
+	}
+}
+
+// cover finds the shortest range of words, starting at or after *p, that
+// satisfies the query. Both ends of the range are query words. It returns
+// false if there is no such range.
+func (h *headline) cover(p, q *int) bool {
+	maxCover := h.opts.MaxWords * 10
+	if maxCover < 100 {
+		maxCover = 100
+	}
+	found := make(map[*tsNode]bool)
+	for pmin := *p; pmin < len(h.words); pmin++ {
+		if !h.interesting(pmin) {
+			continue
+		}
+		for k := range found {
+			delete(found, k)
+		}
+		for pmax := pmin; pmax < len(h.words) && pmax-pmin < maxCover; pmax++ {
+			if !h.interesting(pmax) {
+				continue
+			}
+			for _, item := range h.words[pmax].items {
+				found[item] = true
+			}
+			if evalCover(h.q.root, found) {
+				*p, *q = pmin, pmax
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// markWords selects a single fragment for the headline.
+func (h *headline) markWords() {
+	minWords, maxWords := h.opts.MinWords, h.opts.MaxWords
+	p, q := 0, 0
+	bestb, beste, bestlen := -1, -1, -1
+	bestCover := false
+	for h.cover(&p, &q) {
+		// Count the words and the query words within the cover, but stop once
+		// we reach MaxWords. posb and pose are the bounds of the candidate
+		// headline.
+		curlen, poslen := 0, 0
+		posb, pose := p, p
+		i := p
+		for ; i <= q && curlen < maxWords; i++ {
+			if h.isWord(i) {
+				curlen++
+			}
+			if h.interesting(i) {
+				poslen++
+			}
+			pose = i
+		}
+		if curlen < maxWords {
+			// There is room to lengthen the headline, so search forward until
+			// it's full or we find a good stopping point.
+			for i = i - 1; i < len(h.words) && curlen < maxWords; i++ {
+				if i > q {
+					if h.isWord(i) {
+						curlen++
+					}
+					if h.interesting(i) {
+						poslen++
+					}
+				}
+				pose = i
+				if h.badEndpoint(i) {
+					continue
+				}
+				if curlen >= minWords {
+					break
+				}
+			}
+			if curlen < minWords {
+				// We reached the end of the text and the headline is still
+				// shorter than MinWords, so try to extend it to the left.
+				for i = p - 1; i >= 0; i-- {
+					if h.isWord(i) {
+						curlen++
+					}
+					if h.interesting(i) {
+						poslen++
+					}
+					if curlen >= maxWords {
+						break
+					}
+					if h.badEndpoint(i) {
+						continue
+					}
+					if curlen >= minWords {
+						break
+					}
+				}
+				if i >= 0 {
+					posb = i
+				} else {
+					posb = 0
+				}
+			}
+		} else {
+			// The headline can't be made longer, so consider making it shorter
+			// to avoid a bad endpoint.
+			if i > q {
+				i = q
+			}
+			for ; curlen > minWords; i-- {
+				if !h.badEndpoint(i) {
+					break
+				}
+				if h.isWord(i) {
+					curlen--
+				}
+				if h.interesting(i) {
+					poslen--
+				}
+				pose = i - 1
+			}
+		}
+		// Prefer headlines that include the whole cover, then headlines with
+		// more query words, then headlines with good endpoints.
+		posCover := posb <= p && pose >= q
+		if (posCover && !bestCover) ||
+			(posCover == bestCover && poslen > bestlen) ||
+			(posCover == bestCover && poslen == bestlen &&
+				!h.badEndpoint(pose) && (beste < 0 || h.badEndpoint(beste))) {
+			bestb, beste, bestlen, bestCover = posb, pose, poslen, posCover
+		}
+		p++
+	}
+	if bestlen < 0 {
+		// Nothing matched, so use the first MinWords words.
+		bestb, beste = 0, h.firstWords(minWords)
+	}
+	h.mark(bestb, beste)
+}
+
+// firstWords returns the index of the last token of the first n words.
+func (h *headline) firstWords(n int) int {
+	end, curlen := 0, 0
+	for i := 0; i < len(h.words) && curlen < n; i++ {
+		if h.isWord(i) {
+			curlen++
+		}
+		end = i
+	}
+	return end
+}
+
+// hlFragment is a candidate fragment for a headline with multiple fragments.
+type hlFragment struct {
+	start, end       int
+	curlen, poslen   int
+	chosen, excluded bool
+}
+
+// nextFragment shrinks the range between start and end so that it contains
+// at most MaxWords words, and both of its ends are query words.
+func (h *headline) nextFragment(f *hlFragment) {
+	for i := f.start; i <= f.end; i++ {
+		f.start = i
+		if h.interesting(i) {
+			break
+		}
+	}
+	f.curlen, f.poslen = 0, 0
+	i := f.start
+	for ; i <= f.end && f.curlen < h.opts.MaxWords; i++ {
+		if h.isWord(i) {
+			f.curlen++
+		}
+		if h.interesting(i) {
+			f.poslen++
+		}
+	}
+	// If the range was cut, move its end back to a query word.
+	if f.end > i {
+		f.end = i
+		for i = f.end; i >= f.start; i-- {
+			f.end = i
+			if h.interesting(i) {
+				break
+			}
+			if h.isWord(i) {
+				f.curlen--
+			}
+		}
+	}
+}
+
+// markFragments selects up to MaxFragments fragments for the headline.
+func (h *headline) markFragments() {
+	maxWords := h.opts.MaxWords
+	var frags []hlFragment
+	p, q := 0, 0
+	for h.cover(&p, &q) {
+		// Break the cover into fragments that have at most MaxWords words and
+		// end with query words, so that they can be stretched in either
+		// direction.
+		for start := p; start <= q; {
+			f := hlFragment{start: start, end: q}
+			h.nextFragment(&f)
+			frags = append(frags, f)
+			start = f.end + 1
+		}
+		p++
+	}
+
+	numChosen := 0
+	for n := 0; n < h.opts.MaxFragments; n++ {
+		// Choose the fragment with the most query words, breaking ties in favor
+		// of the shortest one.
+		maxItems, minWords, best := 0, math.MaxInt32, -1
+		for i := range frags {
+			f := &frags[i]
+			if !f.chosen && !f.excluded &&
+				(maxItems < f.poslen || (maxItems == f.poslen && minWords > f.curlen)) {
+				maxItems, minWords, best = f.poslen, f.curlen, i
+			}
+		}
+		if best < 0 {
+			break
+		}
+		f := &frags[best]
+		f.chosen = true
+		if f.curlen < maxWords {
+			// Stretch the fragment on both sides, without running into a
+			// fragment that has already been chosen.
+			maxStretch := (maxWords - f.curlen) / 2
+			stretch := 0
+			marker := f.start
+			for i := f.start - 1; i >= 0 && stretch < maxStretch && !h.words[i].in; i-- {
+				if h.isWord(i) {
+					f.curlen++
+					stretch++
+				}
+				marker = i
+			}
+			// Cut back the start until we find a good endpoint.
+			i := marker
+			for ; i < f.start && h.badEndpoint(i); i++ {
+				if h.isWord(i) {
+					f.curlen--
+				}
+			}
+			f.start = i
+			marker = f.end
+			for i = f.end + 1; i < len(h.words) && f.curlen < maxWords && !h.words[i].in; i++ {
+				if h.isWord(i) {
+					f.curlen++
+				}
+				marker = i
+			}
+			// Cut back the end until we find a good endpoint.
+			for i = marker; i > f.end && h.badEndpoint(i); i-- {
+				if h.isWord(i) {
+					f.curlen--
+				}
+			}
+			f.end = i
+		}
+		h.mark(f.start, f.end)
+		numChosen++
+		// Exclude the fragments that overlap with the chosen one.
+		for i := range frags {
+			o := &frags[i]
+			if i != best && ((o.start >= f.start && o.start <= f.end) ||
+				(o.end >= f.start && o.end <= f.end) ||
+				(o.start < f.start && o.end > f.end)) {
+				o.excluded = true
+			}
+		}
+	}
+	if numChosen == 0 {
+		// Nothing matched, so use the first MinWords words.
+		h.mark(0, h.firstWords(h.opts.MinWords))
+	}
+}
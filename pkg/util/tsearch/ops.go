@@ -0,0 +1,250 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// This file implements the functions and operators that manipulate TSVectors
+// and TSQueries. None of them modify their inputs.
+
+// parseWeight returns the TSVector weight for the given weight name, which is
+// one of A, B, C or D, in either case.
+func parseWeight(name string) (tsWeight, error) {
+	switch name {
+	case "A", "a":
+		return weightA, nil
+	case "B", "b":
+		return weightB, nil
+	case "C", "c":
+		return weightC, nil
+	case "D", "d":
+		// Weight D is stored as 0, like in the TSVector parser.
+		return 0, nil
+	}
+	return 0, pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized weight: %q", name)
+}
+
+// copy returns a deep copy of the vector.
+func (t TSVector) copy() TSVector {
+	ret := make(TSVector, len(t))
+	for i := range t {
+		ret[i] = t[i]
+		ret[i].positions = append([]tsPosition(nil), t[i].positions...)
+	}
+	return ret
+}
+
+// SetWeight returns a copy of the vector in which every position has the
+// given weight. If lexemes is not nil, only the positions of those lexemes
+// are changed.
+func SetWeight(v TSVector, weight string, lexemes []string) (TSVector, error) {
+	w, err := parseWeight(weight)
+	if err != nil {
+		return nil, err
+	}
+	var filter map[string]struct{}
+	if lexemes != nil {
+		filter = make(map[string]struct{}, len(lexemes))
+		for _, l := range lexemes {
+			filter[l] = struct{}{}
+		}
+	}
+	ret := v.copy()
+	for i := range ret {
+		if filter != nil {
+			if _, ok := filter[ret[i].lexeme]; !ok {
+				continue
+			}
+		}
+		for j := range ret[i].positions {
+			ret[i].positions[j].weight = w
+		}
+	}
+	return ret, nil
+}
+
+// Strip returns a copy of the vector without position or weight information.
+func Strip(v TSVector) TSVector {
+	ret := make(TSVector, len(v))
+	for i := range v {
+		ret[i] = tsTerm{lexeme: v[i].lexeme}
+	}
+	return ret
+}
+
+// Length returns the number of lexemes in the vector.
+func Length(v TSVector) int {
+	return len(v)
+}
+
+// Delete returns a copy of the vector without the given lexemes.
+func Delete(v TSVector, lexemes ...string) TSVector {
+	remove := make(map[string]struct{}, len(lexemes))
+	for _, l := range lexemes {
+		remove[l] = struct{}{}
+	}
+	ret := make(TSVector, 0, len(v))
+	for _, t := range v {
+		if _, ok := remove[t.lexeme]; !ok {
+			ret = append(ret, t)
+		}
+	}
+	return ret.copy()
+}
+
+// Filter returns a copy of the vector with only the positions that have one
+// of the given weights. Lexemes that are left without positions are removed.
+func Filter(v TSVector, weights []string) (TSVector, error) {
+	var mask tsWeight
+	for _, name := range weights {
+		w, err := parseWeight(name)
+		if err != nil {
+			return nil, err
+		}
+		if w == 0 {
+			w = weightD
+		}
+		mask |= w
+	}
+	var ret TSVector
+	for _, t := range v {
+		var positions []tsPosition
+		for _, p := range t.positions {
+			if p.weight.matches(mask) {
+				positions = append(positions, p)
+			}
+		}
+		if len(positions) > 0 {
+			ret = append(ret, tsTerm{lexeme: t.lexeme, positions: positions})
+		}
+	}
+	return ret, nil
+}
+
+// Concat returns the concatenation of the two vectors. The positions of the
+// right vector are shifted by the largest position of the left vector, so
+// that they appear after the lexemes of the left vector.
+func Concat(l, r TSVector) TSVector {
+	maxPos := 0
+	for _, t := range l {
+		for _, p := range t.positions {
+			if int(p.position) > maxPos {
+				maxPos = int(p.position)
+			}
+		}
+	}
+	ret := make(TSVector, 0, len(l)+len(r))
+	ret = append(ret, l.copy()...)
+	for _, t := range r.copy() {
+		for j := range t.positions {
+			pos := int(t.positions[j].position) + maxPos
+			if pos > maxTSVectorPosition {
+				pos = maxTSVectorPosition
+			}
+			t.positions[j].position = uint16(pos)
+		}
+		ret = append(ret, t)
+	}
+	return normalizeTSVector(ret)
+}
+
+// Lexemes returns the lexemes of the vector, in order.
+func Lexemes(v TSVector) []string {
+	ret := make([]string, len(v))
+	for i := range v {
+		ret[i] = v[i].lexeme
+	}
+	return ret
+}
+
+// FromLexemes returns a vector made of the given lexemes, without positions.
+func FromLexemes(lexemes []string) (TSVector, error) {
+	ret := make(TSVector, 0, len(lexemes))
+	for _, l := range lexemes {
+		if l == "" {
+			return nil, pgerror.New(pgcode.ZeroLengthCharacterString,
+				"lexeme array may not contain empty strings")
+		}
+		t, err := newLexemeTerm(l)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, t)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].lexeme < ret[j].lexeme
+	})
+	out := ret[:0]
+	for i, t := range ret {
+		if i == 0 || t.lexeme != ret[i-1].lexeme {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// NumNode returns the number of lexemes and operators in the query.
+func NumNode(q TSQuery) int {
+	var count func(n *tsNode) int
+	count = func(n *tsNode) int {
+		if n == nil {
+			return 0
+		}
+		return 1 + count(n.l) + count(n.r)
+	}
+	return count(q.root)
+}
+
+// And returns a query that matches if both queries match. If either query is
+// empty, the other one is returned.
+func And(l, r TSQuery) TSQuery {
+	return combineQueries(l, r, and, 0)
+}
+
+// Or returns a query that matches if either query matches. If either query is
+// empty, the other one is returned.
+func Or(l, r TSQuery) TSQuery {
+	return combineQueries(l, r, or, 0)
+}
+
+// Not returns a query that matches if the input query doesn't match.
+func Not(q TSQuery) TSQuery {
+	if q.root == nil {
+		return q
+	}
+	return TSQuery{root: &tsNode{op: not, l: q.root}}
+}
+
+// Phrase returns a query that matches if the right query matches at the given
+// distance after the left query.
+func Phrase(l, r TSQuery, distance int) (TSQuery, error) {
+	if distance < 0 || distance > maxTSVectorFollowedBy {
+		return TSQuery{}, pgerror.Newf(pgcode.InvalidParameterValue,
+			"distance in phrase operator must be an integer value between zero and %d inclusive",
+			maxTSVectorFollowedBy)
+	}
+	return combineQueries(l, r, followedby, uint16(distance)), nil
+}
+
+func combineQueries(l, r TSQuery, op tsOperator, followedN uint16) TSQuery {
+	if l.root == nil {
+		return r
+	}
+	if r.root == nil {
+		return l
+	}
+	return TSQuery{root: &tsNode{op: op, followedN: followedN, l: l.root, r: r.root}}
+}
@@ -0,0 +1,488 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// This file implements the ts_rank and ts_rank_cd ranking functions. The
+// implementation follows the Postgres one in src/backend/utils/adt/tsrank.c,
+// including its use of single precision floats, so that the results match.
+
+// Normalization flags accepted by Rank and RankCD. They may be combined.
+const (
+	// RankNormLogLength divides the rank by 1 + the logarithm of the document
+	// length.
+	RankNormLogLength = 1 << iota
+	// RankNormLength divides the rank by the document length.
+	RankNormLength
+	// RankNormExtDist divides the rank by the mean harmonic distance between
+	// extents. It is only used by RankCD.
+	RankNormExtDist
+	// RankNormUniq divides the rank by the number of unique words in the
+	// document.
+	RankNormUniq
+	// RankNormLogUniq divides the rank by 1 + the logarithm of the number of
+	// unique words in the document.
+	RankNormLogUniq
+	// RankNormRDivRPlus1 divides the rank by itself + 1.
+	RankNormRDivRPlus1
+)
+
+// DefaultRankWeights are the weights given to lexemes with the D, C, B and A
+// weights, in that order.
+var DefaultRankWeights = [4]float32{0.1, 0.2, 0.4, 1.0}
+
+// noPosition is the position used for lexemes of a stripped TSVector.
+var noPosition = []tsPosition{{position: maxTSVectorPosition}}
+
+// ValidateRankWeights checks the weights passed to a ranking function, and
+// returns the weights to use. Negative weights are replaced by the default
+// weight.
+func ValidateRankWeights(weights []float32) ([4]float32, error) {
+	var ret [4]float32
+	if len(weights) < len(ret) {
+		return ret, pgerror.New(pgcode.ArraySubscript, "array of weight is too short")
+	}
+	for i := range ret {
+		switch w := weights[i]; {
+		case w > 1.0:
+			return ret, pgerror.New(pgcode.InvalidParameterValue, "weight out of range")
+		case w >= 0:
+			ret[i] = w
+		default:
+			ret[i] = DefaultRankWeights[i]
+		}
+	}
+	return ret, nil
+}
+
+// weightIndex returns the index of the given TSVector weight in an array of
+// rank weights.
+func weightIndex(w tsWeight) int {
+	switch w {
+	case weightA:
+		return 3
+	case weightB:
+		return 2
+	case weightC:
+		return 1
+	}
+	return 0
+}
+
+// Rank returns the rank of the vector against the query, which is computed
+// from the frequency of the query lexemes in the vector.
+func Rank(weights [4]float32, v TSVector, q TSQuery, method int) float32 {
+	if len(v) == 0 || q.root == nil {
+		return 0
+	}
+	var res float32
+	if q.root.op == and || q.root.op == followedby {
+		res = rankAnd(weights, v, q)
+	} else {
+		res = rankOr(weights, v, q)
+	}
+	if res < 0 {
+		res = 1e-20
+	}
+	if method&RankNormLogLength != 0 {
+		res /= float32(math.Log(float64(v.cntLength()+1)) / math.Log(2.0))
+	}
+	if method&RankNormLength != 0 {
+		if l := v.cntLength(); l > 0 {
+			res /= float32(l)
+		}
+	}
+	if method&RankNormUniq != 0 {
+		res /= float32(len(v))
+	}
+	if method&RankNormLogUniq != 0 {
+		res /= float32(math.Log(float64(len(v)+1)) / math.Log(2.0))
+	}
+	if method&RankNormRDivRPlus1 != 0 {
+		res /= res + 1
+	}
+	return res
+}
+
+// cntLength returns the length of the document represented by the vector,
+// counting lexemes without positions once.
+func (t TSVector) cntLength() int {
+	n := 0
+	for _, term := range t {
+		if len(term.positions) == 0 {
+			n++
+		} else {
+			n += len(term.positions)
+		}
+	}
+	return n
+}
+
+func wordDistance(w int) float32 {
+	if w > 100 {
+		return 1e-30
+	}
+	return float32(1.0 / (1.005 + 0.05*math.Exp(float64(float32(w))/1.5-2)))
+}
+
+// rankAnd computes the rank of a query whose root is an & or <-> operator,
+// which depends on the distances between the query lexemes in the document.
+func rankAnd(weights [4]float32, v TSVector, q TSQuery) float32 {
+	items := q.uniqueLeaves()
+	if len(items) < 2 {
+		return rankOr(weights, v, q)
+	}
+	pos := make([][]tsPosition, len(items))
+	var res float32 = -1
+	for i, item := range items {
+		for _, idx := range v.matchingTerms(item) {
+			pos[i] = v[idx].positions
+			if len(pos[i]) == 0 {
+				pos[i] = noPosition
+			}
+			for k := 0; k < i; k++ {
+				if pos[k] == nil {
+					continue
+				}
+				for _, l := range pos[i] {
+					for _, p := range pos[k] {
+						dist := int(l.position) - int(p.position)
+						if dist < 0 {
+							dist = -dist
+						}
+						if dist == 0 && !(&pos[i][0] == &noPosition[0] || &pos[k][0] == &noPosition[0]) {
+							continue
+						}
+						if dist == 0 {
+							dist = maxTSVectorPosition + 1
+						}
+						curw := float32(math.Sqrt(float64(
+							weights[weightIndex(l.weight)] * weights[weightIndex(p.weight)] * wordDistance(dist))))
+						if res < 0 {
+							res = curw
+						} else {
+							res = float32(1.0 - (1.0-float64(res))*(1.0-float64(curw)))
+						}
+					}
+				}
+			}
+		}
+	}
+	if res < 0 {
+		res = 1e-20
+	}
+	return res
+}
+
+// rankOr computes the rank of a query whose root is not an & or <->
+// operator, which depends only on the frequencies of the query lexemes.
+func rankOr(weights [4]float32, v TSVector, q TSQuery) float32 {
+	items := q.uniqueLeaves()
+	var res float32
+	for _, item := range items {
+		for _, idx := range v.matchingTerms(item) {
+			positions := v[idx].positions
+			if len(positions) == 0 {
+				positions = noPosition
+			}
+			var resj float32
+			var wjm float32 = -1
+			jm := 0
+			for j, p := range positions {
+				w := weights[weightIndex(p.weight)]
+				resj += w / float32((j+1)*(j+1))
+				if w > wjm {
+					wjm = w
+					jm = j
+				}
+			}
+			// The limit of sum(1/i^2) as i goes to infinity is pi^2/6.
+			res = float32(float64(res) +
+				float64(wjm+resj-wjm/float32((jm+1)*(jm+1)))/1.64493406685)
+		}
+	}
+	if len(items) > 0 {
+		res /= float32(len(items))
+	}
+	return res
+}
+
+// leaves returns the lexeme nodes of the query, in order.
+func (q TSQuery) leaves() []*tsNode {
+	var ret []*tsNode
+	var walk func(n *tsNode)
+	walk = func(n *tsNode) {
+		if n == nil {
+			return
+		}
+		if n.op == invalid {
+			ret = append(ret, n)
+			return
+		}
+		walk(n.l)
+		walk(n.r)
+	}
+	walk(q.root)
+	return ret
+}
+
+// uniqueLeaves returns the lexeme nodes of the query, sorted by lexeme and
+// with duplicate lexemes removed.
+func (q TSQuery) uniqueLeaves() []*tsNode {
+	leaves := q.leaves()
+	sort.SliceStable(leaves, func(i, j int) bool {
+		return leaves[i].term.lexeme < leaves[j].term.lexeme
+	})
+	ret := leaves[:0]
+	for i, n := range leaves {
+		if i == 0 || n.term.lexeme != leaves[i-1].term.lexeme {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+// leafWeight returns the weights that a query lexeme matches, and whether it
+// is a prefix match.
+func leafWeight(n *tsNode) (weight tsWeight, prefix bool) {
+	if len(n.term.positions) == 0 {
+		return 0, false
+	}
+	w := n.term.positions[0].weight
+	return w &^ weightStar, w&weightStar != 0
+}
+
+// matchingTerms returns the indexes of the terms of the vector that are
+// matched by the lexeme of the given query node, ignoring weights.
+func (t TSVector) matchingTerms(n *tsNode) []int {
+	target := n.term.lexeme
+	_, prefix := leafWeight(n)
+	i := sort.Search(len(t), func(i int) bool {
+		return t[i].lexeme >= target
+	})
+	var ret []int
+	for ; i < len(t); i++ {
+		if t[i].lexeme == target || (prefix && strings.HasPrefix(t[i].lexeme, target)) {
+			ret = append(ret, i)
+		} else {
+			break
+		}
+	}
+	return ret
+}
+
+// docEntry is an occurrence of a query lexeme within a document, used by
+// RankCD.
+type docEntry struct {
+	pos    int
+	weight tsWeight
+	// term is the index of the term in the vector.
+	term int
+	// items are the query lexemes that match the term.
+	items []*tsNode
+}
+
+// coverExt is an extent that covers the query, as found by cover.
+type coverExt struct {
+	// pos is the index of the docEntry at which to search for the next extent.
+	pos int
+	// p and q are the first and last positions of the extent.
+	p, q int
+	// begin and end are the indexes of the first and last docEntry of the
+	// extent.
+	begin, end int
+}
+
+// RankCD returns the cover density rank of the vector against the query,
+// which depends on the length of the extents of the document that contain
+// all of the query lexemes.
+func RankCD(weights [4]float32, v TSVector, q TSQuery, method int) float32 {
+	var invws [4]float64
+	for i, w := range weights {
+		invws[i] = 1.0 / float64(w)
+	}
+	doc := getDocRep(v, q)
+	if len(doc) == 0 {
+		return 0
+	}
+	var wdoc, sumDist, prevExtPos float64
+	nExtent := 0
+	var ext coverExt
+	for cover(doc, q, &ext) {
+		var invSum float64
+		for _, e := range doc[ext.begin : ext.end+1] {
+			invSum += invws[weightIndex(e.weight)]
+		}
+		cpos := float64(ext.end-ext.begin+1) / invSum
+		// If the document is big enough, then q may be equal to p due to the
+		// limit on positions. In this case, approximate the number of noise
+		// words as half of the cover's length.
+		nNoise := (ext.q - ext.p) - (ext.end - ext.begin)
+		if nNoise < 0 {
+			nNoise = (ext.end - ext.begin) / 2
+		}
+		wdoc += cpos / float64(1+nNoise)
+		curExtPos := float64(ext.q+ext.p) / 2
+		if nExtent > 0 && curExtPos > prevExtPos {
+			sumDist += 1.0 / (curExtPos - prevExtPos)
+		}
+		prevExtPos = curExtPos
+		nExtent++
+	}
+	if method&RankNormLogLength != 0 {
+		wdoc /= math.Log(float64(v.cntLength() + 1))
+	}
+	if method&RankNormLength != 0 {
+		if l := v.cntLength(); l > 0 {
+			wdoc /= float64(l)
+		}
+	}
+	if method&RankNormExtDist != 0 && nExtent > 0 && sumDist > 0 {
+		wdoc /= float64(nExtent) / sumDist
+	}
+	if method&RankNormUniq != 0 {
+		wdoc /= float64(len(v))
+	}
+	if method&RankNormLogUniq != 0 {
+		wdoc /= math.Log(float64(len(v)+1)) / math.Log(2.0)
+	}
+	if method&RankNormRDivRPlus1 != 0 {
+		wdoc /= wdoc + 1
+	}
+	return float32(wdoc)
+}
+
+// getDocRep returns the occurrences of the query lexemes in the document,
+// sorted by position.
+func getDocRep(v TSVector, q TSQuery) []docEntry {
+	var doc []docEntry
+	for _, leaf := range q.leaves() {
+		weight, _ := leafWeight(leaf)
+		for _, idx := range v.matchingTerms(leaf) {
+			positions := v[idx].positions
+			if len(positions) == 0 {
+				positions = noPosition
+			}
+			for _, p := range positions {
+				if weight == 0 || p.weight.matches(weight) {
+					doc = append(doc, docEntry{
+						pos: int(p.position), weight: p.weight, term: idx, items: []*tsNode{leaf},
+					})
+				}
+			}
+		}
+	}
+	sort.SliceStable(doc, func(i, j int) bool {
+		a, b := doc[i], doc[j]
+		if a.pos != b.pos {
+			return a.pos < b.pos
+		}
+		if wa, wb := weightIndex(a.weight), weightIndex(b.weight); wa != wb {
+			return wa < wb
+		}
+		return a.term < b.term
+	})
+	// Merge the entries for the same term and position.
+	ret := doc[:0]
+	for i, e := range doc {
+		if i > 0 && e.pos == doc[i-1].pos && e.term == doc[i-1].term {
+			last := &ret[len(ret)-1]
+			last.items = append(last.items, e.items...)
+		} else {
+			ret = append(ret, e)
+		}
+	}
+	return ret
+}
+
+// cover finds the next extent of the document that satisfies the query,
+// starting the search at ext.pos. It returns false if there are no more
+// extents. Phrase operators are treated like & operators.
+func cover(doc []docEntry, q TSQuery, ext *coverExt) bool {
+	found := make(map[*tsNode]bool)
+	satisfied := func() bool {
+		return evalCover(q.root, found)
+	}
+	for {
+		for k := range found {
+			delete(found, k)
+		}
+		ext.p, ext.q = math.MaxInt32, 0
+		lastPos := ext.pos
+		foundEnd := false
+		// Find the upper bound of the extent by moving forward from the current
+		// position.
+		for i := ext.pos; i < len(doc); i++ {
+			for _, item := range doc[i].items {
+				found[item] = true
+			}
+			if satisfied() {
+				if doc[i].pos > ext.q {
+					ext.q = doc[i].pos
+					ext.end = i
+					lastPos = i
+					foundEnd = true
+				}
+				break
+			}
+		}
+		if !foundEnd {
+			return false
+		}
+		for k := range found {
+			delete(found, k)
+		}
+		// Find the lower bound of the extent by moving backward from the upper
+		// bound.
+		i := lastPos
+		for ; i >= ext.pos; i-- {
+			for _, item := range doc[i].items {
+				found[item] = true
+			}
+			if satisfied() {
+				if doc[i].pos < ext.p {
+					ext.begin = i
+					ext.p = doc[i].pos
+				}
+				break
+			}
+		}
+		if ext.p <= ext.q {
+			// The next search starts after the beginning of this extent.
+			ext.pos = i + 1
+			return true
+		}
+		ext.pos++
+	}
+}
+
+// evalCover evaluates the query against the set of lexemes found in a
+// candidate extent.
+func evalCover(n *tsNode, found map[*tsNode]bool) bool {
+	switch n.op {
+	case invalid:
+		return found[n]
+	case not:
+		return !evalCover(n.l, found)
+	case or:
+		return evalCover(n.l, found) || evalCover(n.r, found)
+	default:
+		return evalCover(n.l, found) && evalCover(n.r, found)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRank(t *testing.T) {
+	for _, tc := range []struct {
+		vector string
+		query  string
+		method int
+		rank   float32
+		rankCD float32
+	}{
+		{`'brown':3 'dog':9 'fox':4 'jump':5 'lazi':8 'quick':2`, `fox`, 0, 0.06079271, 0.1},
+		{`'brown':3 'dog':9 'fox':4 'jump':5 'lazi':8 'quick':2`, `fox`, RankNormUniq, 0.010132118, 0.016666668},
+		{`'brown':3 'dog':9 'fox':4 'jump':5 'lazi':8 'quick':2`, `cat`, 0, 0, 0},
+		{`'a':1A 'b':2`, `a`, 0, 0.6079271, 1},
+		{`'a':1A 'b':2`, `a:B`, 0, 0.6079271, 0},
+		{`a:1 b:2`, `a & b`, 0, 0.0991032, 0.1},
+		{`a:1 b:2`, `a <-> b`, RankNormRDivRPlus1, 0.09016758, 0.09090909},
+	} {
+		v, err := ParseTSVector(tc.vector)
+		require.NoError(t, err)
+		q, err := ParseTSQuery(tc.query)
+		require.NoError(t, err)
+		assert.InDelta(t, tc.rank, Rank(DefaultRankWeights, v, q, tc.method), 1e-6,
+			"%s @@ %s", tc.vector, tc.query)
+		assert.InDelta(t, tc.rankCD, RankCD(DefaultRankWeights, v, q, tc.method), 1e-6,
+			"%s @@ %s", tc.vector, tc.query)
+	}
+}
+
+func TestHeadline(t *testing.T) {
+	const doc = `The most common type of search is to find all documents containing given ` +
+		`query terms and return them in order of their similarity to the query.`
+	for _, tc := range []struct {
+		query    string
+		options  string
+		expected string
+	}{
+		{`query & similarity`, ``,
+			`containing given <b>query</b> terms and return them in order of their <b>similarity</b> to the <b>query</b>.`},
+		{`search & term`, `MaxWords=10, MinWords=5`,
+			`<b>search</b> is to find all documents containing given query <b>terms</b>`},
+		{`search & term`, `MaxFragments=2, MaxWords=4, MinWords=2`,
+			`<b>search</b> is to find ... query <b>terms</b> and return`},
+		{`search & term`, `MaxFragments=10, MaxWords=7, MinWords=3, StartSel=<<, StopSel=>>`,
+			`common type of <<search>> is to find ... containing given query <<terms>> and return them`},
+		{`missing`, `MaxWords=4, MinWords=2`, `The most`},
+		{`query`, `StartSel=<, StopSel=>, HighlightAll=true`,
+			`The most common type of search is to find all documents containing given <query> terms ` +
+				`and return them in order of their similarity to the <query>.`},
+	} {
+		q, err := ToTSQuery("english", tc.query)
+		require.NoError(t, err)
+		opts, err := ParseHeadlineOptions(tc.options)
+		require.NoError(t, err)
+		h, err := Headline("english", doc, q, opts)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, h, "query: %s, options: %s", tc.query, tc.options)
+	}
+
+	for _, tc := range []struct {
+		options string
+		err     string
+	}{
+		{`MaxWords=5, MinWords=10`, `MinWords should be less than MaxWords`},
+		{`MinWords=0`, `MinWords should be positive`},
+		{`Foo=1`, `unrecognized headline parameter: "Foo"`},
+		{`MaxWords`, `invalid parameter list format: "MaxWords"`},
+		{`MaxWords=x`, `invalid input syntax for type integer: "x"`},
+	} {
+		_, err := ParseHeadlineOptions(tc.options)
+		require.EqualError(t, err, tc.err)
+	}
+}
+
+func TestVectorOps(t *testing.T) {
+	v, err := ParseTSVector(`'cat':1,3A 'fat':2B 'rat':4`)
+	require.NoError(t, err)
+	r, err := ParseTSVector(`'cat':2 'dog':1`)
+	require.NoError(t, err)
+
+	w, err := SetWeight(v, "c", nil /* lexemes */)
+	require.NoError(t, err)
+	assert.Equal(t, `'cat':1C,3C 'fat':2C 'rat':4C`, w.String())
+	w, err = SetWeight(v, "A", []string{"rat"})
+	require.NoError(t, err)
+	assert.Equal(t, `'cat':1,3A 'fat':2B 'rat':4A`, w.String())
+	_, err = SetWeight(v, "x", nil /* lexemes */)
+	require.EqualError(t, err, `unrecognized weight: "x"`)
+
+	assert.Equal(t, `'cat' 'fat' 'rat'`, Strip(v).String())
+	assert.Equal(t, `'fat':2B`, Delete(v, "cat", "rat", "cow").String())
+	f, err := Filter(v, []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, `'cat':3A 'fat':2B`, f.String())
+	assert.Equal(t, `'cat':1,3A,6 'dog':5 'fat':2B 'rat':4`, Concat(v, r).String())
+	assert.Equal(t, []string{"cat", "fat", "rat"}, Lexemes(v))
+
+	l, err := FromLexemes([]string{"b", "a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, `'a' 'b'`, l.String())
+
+	// The inputs must not have been modified.
+	assert.Equal(t, `'cat':1,3A 'fat':2B 'rat':4`, v.String())
+}
+
+func TestQueryOps(t *testing.T) {
+	a, err := ParseTSQuery(`fat | rat`)
+	require.NoError(t, err)
+	b, err := ParseTSQuery(`cat`)
+	require.NoError(t, err)
+
+	assert.Equal(t, `( 'fat' | 'rat' ) & 'cat'`, And(a, b).String())
+	assert.Equal(t, `'fat' | 'rat' | 'cat'`, Or(a, b).String())
+	assert.Equal(t, `!( 'fat' | 'rat' )`, Not(a).String())
+	p, err := Phrase(a, b, 10)
+	require.NoError(t, err)
+	assert.Equal(t, `( 'fat' | 'rat' ) <10> 'cat'`, p.String())
+	assert.Equal(t, 3, NumNode(a))
+	assert.Equal(t, `'cat'`, And(TSQuery{}, b).String())
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import "sort"
+
+// This file contains the machinery shared by the snowball stemmers in the
+// stem_*.go files. See https://snowballstem.org/algorithms/ for a description
+// of the algorithms and of the terminology used in their implementations
+// (R1, R2, RV and so on).
+
+// stemWord is a word that is being stemmed. All of the region markers are
+// indexes into the rune slice, and may point past the end of the word if the
+// region is empty.
+type stemWord struct {
+	r []rune
+	// r1 and r2 are the starts of the standard R1 and R2 regions.
+	r1, r2 int
+	// rv is the start of the RV region, which is only used by the Romance
+	// language stemmers.
+	rv int
+}
+
+func newStemWord(word string) *stemWord {
+	return &stemWord{r: []rune(word)}
+}
+
+func (w *stemWord) String() string {
+	return string(w.r)
+}
+
+// markR1R2 sets the R1 and R2 regions of the word. R1 is the region after the
+// first non-vowel following a vowel, and R2 is the same region computed within
+// R1.
+func (w *stemWord) markR1R2(isVowel func(rune) bool) {
+	w.r1 = w.regionAfter(0, isVowel)
+	w.r2 = w.regionAfter(w.r1, isVowel)
+}
+
+// regionAfter returns the index following the first non-vowel that follows a
+// vowel, starting the search at start.
+func (w *stemWord) regionAfter(start int, isVowel func(rune) bool) int {
+	for i := start + 1; i < len(w.r); i++ {
+		if !isVowel(w.r[i]) && isVowel(w.r[i-1]) {
+			return i + 1
+		}
+	}
+	return len(w.r)
+}
+
+// hasSuffix returns whether the word ends in the given suffix.
+func (w *stemWord) hasSuffix(suffix string) bool {
+	s := []rune(suffix)
+	if len(s) > len(w.r) {
+		return false
+	}
+	off := len(w.r) - len(s)
+	for i := range s {
+		if w.r[off+i] != s[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// longestSuffix returns the longest suffix in the given list that the word
+// ends with, or the empty string if there is none. The list must have been
+// sorted with sortSuffixes.
+func (w *stemWord) longestSuffix(suffixes []string) string {
+	for _, s := range suffixes {
+		if w.hasSuffix(s) {
+			return s
+		}
+	}
+	return ""
+}
+
+// longestSuffixInRV returns the longest suffix in the given list that the
+// word ends with and that lies entirely within RV.
+func (w *stemWord) longestSuffixInRV(suffixes []string) string {
+	for _, s := range suffixes {
+		if w.hasSuffix(s) && w.inRV(s) {
+			return s
+		}
+	}
+	return ""
+}
+
+// suffixStart returns the index at which the given suffix of the word starts.
+func (w *stemWord) suffixStart(suffix string) int {
+	return len(w.r) - len([]rune(suffix))
+}
+
+// inR1 returns whether the given suffix of the word lies within R1.
+func (w *stemWord) inR1(suffix string) bool {
+	return w.suffixStart(suffix) >= w.r1
+}
+
+// inR2 returns whether the given suffix of the word lies within R2.
+func (w *stemWord) inR2(suffix string) bool {
+	return w.suffixStart(suffix) >= w.r2
+}
+
+// inRV returns whether the given suffix of the word lies within RV.
+func (w *stemWord) inRV(suffix string) bool {
+	return w.suffixStart(suffix) >= w.rv
+}
+
+// replaceSuffix replaces the given suffix of the word with repl.
+func (w *stemWord) replaceSuffix(suffix, repl string) {
+	w.r = append(w.r[:w.suffixStart(suffix)], []rune(repl)...)
+}
+
+// removeSuffix removes the given suffix from the word.
+func (w *stemWord) removeSuffix(suffix string) {
+	w.r = w.r[:w.suffixStart(suffix)]
+}
+
+// precededBy returns whether the given suffix of the word is immediately
+// preceded by the string s.
+func (w *stemWord) precededBy(suffix string, s string) bool {
+	start := w.suffixStart(suffix)
+	p := []rune(s)
+	if start < len(p) {
+		return false
+	}
+	for i := range p {
+		if w.r[start-len(p)+i] != p[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// precedingRune returns the rune immediately preceding the given suffix of
+// the word, and its index, or -1 if there is none.
+func (w *stemWord) precedingRune(suffix string) (rune, int) {
+	i := w.suffixStart(suffix) - 1
+	if i < 0 {
+		return 0, -1
+	}
+	return w.r[i], i
+}
+
+// sortSuffixes sorts the given list of suffixes so that longer suffixes come
+// first, which is the order expected by longestSuffix.
+func sortSuffixes(suffixes ...string) []string {
+	sort.SliceStable(suffixes, func(i, j int) bool {
+		return len([]rune(suffixes[i])) > len([]rune(suffixes[j]))
+	})
+	return suffixes
+}
+
+// runeIn returns whether r is one of the runes in set.
+func runeIn(r rune, set string) bool {
+	for _, c := range set {
+		if r == c {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,310 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+// stemEnglish implements the snowball English (Porter2) stemming algorithm.
+// See https://snowballstem.org/algorithms/english/stemmer.html.
+func stemEnglish(word string) string {
+	if len([]rune(word)) <= 2 {
+		return word
+	}
+	if s, ok := englishExceptions[word]; ok {
+		return s
+	}
+	w := newStemWord(word)
+	if w.r[0] == '\'' {
+		w.r = w.r[1:]
+	}
+	for i, c := range w.r {
+		if c == 'y' && (i == 0 || isEnglishVowel(w.r[i-1])) {
+			w.r[i] = 'Y'
+		}
+	}
+	w.markR1R2(isEnglishVowel)
+	for _, prefix := range []string{"gener", "commun", "arsen"} {
+		if hasRunePrefix(w.r, prefix) {
+			w.r1 = len(prefix)
+			w.r2 = w.regionAfter(w.r1, isEnglishVowel)
+			break
+		}
+	}
+
+	englishStep0(w)
+	englishStep1a(w)
+	if englishStep1aInvariants[w.String()] {
+		return w.String()
+	}
+	englishStep1b(w)
+	englishStep1c(w)
+	englishStep2(w)
+	englishStep3(w)
+	englishStep4(w)
+	englishStep5(w)
+
+	for i, c := range w.r {
+		if c == 'Y' {
+			w.r[i] = 'y'
+		}
+	}
+	return w.String()
+}
+
+var englishExceptions = map[string]string{
+	"skis":   "ski",
+	"skies":  "sky",
+	"dying":  "die",
+	"lying":  "lie",
+	"tying":  "tie",
+	"idly":   "idl",
+	"gently": "gentl",
+	"ugly":   "ugli",
+	"early":  "earli",
+	"only":   "onli",
+	"singly": "singl",
+	"sky":    "sky",
+	"news":   "news",
+	"howe":   "howe",
+	"atlas":  "atlas",
+	"cosmos": "cosmos",
+	"bias":   "bias",
+	"andes":  "andes",
+}
+
+var englishStep1aInvariants = map[string]bool{
+	"inning":  true,
+	"outing":  true,
+	"canning": true,
+	"herring": true,
+	"earring": true,
+	"proceed": true,
+	"exceed":  true,
+	"succeed": true,
+}
+
+func isEnglishVowel(r rune) bool {
+	return runeIn(r, "aeiouy")
+}
+
+func hasRunePrefix(r []rune, prefix string) bool {
+	p := []rune(prefix)
+	if len(p) > len(r) {
+		return false
+	}
+	for i := range p {
+		if r[i] != p[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// englishEndsInShortSyllable returns whether r ends in a short syllable,
+// which is either a vowel followed by a non-vowel other than w, x or Y and
+// preceded by a non-vowel, or a vowel at the beginning of the word followed by
+// a non-vowel.
+func englishEndsInShortSyllable(r []rune) bool {
+	n := len(r)
+	if n == 2 {
+		return isEnglishVowel(r[0]) && !isEnglishVowel(r[1])
+	}
+	return n >= 3 && !isEnglishVowel(r[n-3]) && isEnglishVowel(r[n-2]) &&
+		!isEnglishVowel(r[n-1]) && !runeIn(r[n-1], "wxY")
+}
+
+// isShortEnglishWord returns whether the word is short, which means that it
+// ends in a short syllable and has an empty R1.
+func (w *stemWord) isShortEnglishWord() bool {
+	return w.r1 >= len(w.r) && englishEndsInShortSyllable(w.r)
+}
+
+func containsEnglishVowel(r []rune) bool {
+	for _, c := range r {
+		if isEnglishVowel(c) {
+			return true
+		}
+	}
+	return false
+}
+
+var englishStep0Suffixes = sortSuffixes("'", "'s", "'s'")
+
+func englishStep0(w *stemWord) {
+	if s := w.longestSuffix(englishStep0Suffixes); s != "" {
+		w.removeSuffix(s)
+	}
+}
+
+var englishStep1aSuffixes = sortSuffixes("sses", "ied", "ies", "us", "ss", "s")
+
+func englishStep1a(w *stemWord) {
+	switch s := w.longestSuffix(englishStep1aSuffixes); s {
+	case "sses":
+		w.replaceSuffix(s, "ss")
+	case "ied", "ies":
+		if w.suffixStart(s) > 1 {
+			w.replaceSuffix(s, "i")
+		} else {
+			w.replaceSuffix(s, "ie")
+		}
+	case "s":
+		// Delete the s if the preceding word part contains a vowel not
+		// immediately before the s.
+		if start := w.suffixStart(s); start >= 2 && containsEnglishVowel(w.r[:start-1]) {
+			w.removeSuffix(s)
+		}
+	}
+}
+
+var englishStep1bSuffixes = sortSuffixes("eed", "eedly", "ed", "edly", "ing", "ingly")
+
+func englishStep1b(w *stemWord) {
+	switch s := w.longestSuffix(englishStep1bSuffixes); s {
+	case "eed", "eedly":
+		if w.inR1(s) {
+			w.replaceSuffix(s, "ee")
+		}
+	case "ed", "edly", "ing", "ingly":
+		if !containsEnglishVowel(w.r[:w.suffixStart(s)]) {
+			return
+		}
+		w.removeSuffix(s)
+		switch {
+		case w.hasSuffix("at"), w.hasSuffix("bl"), w.hasSuffix("iz"):
+			w.r = append(w.r, 'e')
+		case englishEndsInDouble(w.r):
+			w.r = w.r[:len(w.r)-1]
+		case w.isShortEnglishWord():
+			w.r = append(w.r, 'e')
+		}
+	}
+}
+
+func englishEndsInDouble(r []rune) bool {
+	n := len(r)
+	return n >= 2 && r[n-1] == r[n-2] && runeIn(r[n-1], "bdfgmnprt")
+}
+
+func englishStep1c(w *stemWord) {
+	n := len(w.r)
+	if n > 2 && (w.r[n-1] == 'y' || w.r[n-1] == 'Y') && !isEnglishVowel(w.r[n-2]) {
+		w.r[n-1] = 'i'
+	}
+}
+
+var englishStep2Replacements = map[string]string{
+	"tional":  "tion",
+	"enci":    "ence",
+	"anci":    "ance",
+	"abli":    "able",
+	"entli":   "ent",
+	"izer":    "ize",
+	"ization": "ize",
+	"ational": "ate",
+	"ation":   "ate",
+	"ator":    "ate",
+	"alism":   "al",
+	"aliti":   "al",
+	"alli":    "al",
+	"fulness": "ful",
+	"ousli":   "ous",
+	"ousness": "ous",
+	"iveness": "ive",
+	"iviti":   "ive",
+	"biliti":  "ble",
+	"bli":     "ble",
+	"ogi":     "og",
+	"fulli":   "ful",
+	"lessli":  "less",
+	"li":      "",
+}
+
+var englishStep2Suffixes = sortSuffixes(mapKeys(englishStep2Replacements)...)
+
+func englishStep2(w *stemWord) {
+	s := w.longestSuffix(englishStep2Suffixes)
+	if s == "" || !w.inR1(s) {
+		return
+	}
+	switch s {
+	case "ogi":
+		if !w.precededBy(s, "l") {
+			return
+		}
+	case "li":
+		if c, i := w.precedingRune(s); i < 0 || !runeIn(c, "cdeghkmnrt") {
+			return
+		}
+	}
+	w.replaceSuffix(s, englishStep2Replacements[s])
+}
+
+var englishStep3Replacements = map[string]string{
+	"tional":  "tion",
+	"ational": "ate",
+	"alize":   "al",
+	"icate":   "ic",
+	"iciti":   "ic",
+	"ical":    "ic",
+	"ful":     "",
+	"ness":    "",
+	"ative":   "",
+}
+
+var englishStep3Suffixes = sortSuffixes(mapKeys(englishStep3Replacements)...)
+
+func englishStep3(w *stemWord) {
+	s := w.longestSuffix(englishStep3Suffixes)
+	if s == "" || !w.inR1(s) {
+		return
+	}
+	if s == "ative" && !w.inR2(s) {
+		return
+	}
+	w.replaceSuffix(s, englishStep3Replacements[s])
+}
+
+var englishStep4Suffixes = sortSuffixes(
+	"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment",
+	"ent", "ism", "ate", "iti", "ous", "ive", "ize", "ion",
+)
+
+func englishStep4(w *stemWord) {
+	s := w.longestSuffix(englishStep4Suffixes)
+	if s == "" || !w.inR2(s) {
+		return
+	}
+	if s == "ion" && !w.precededBy(s, "s") && !w.precededBy(s, "t") {
+		return
+	}
+	w.removeSuffix(s)
+}
+
+func englishStep5(w *stemWord) {
+	switch {
+	case w.hasSuffix("e"):
+		if w.inR2("e") ||
+			(w.inR1("e") && !englishEndsInShortSyllable(w.r[:len(w.r)-1])) {
+			w.removeSuffix("e")
+		}
+	case w.hasSuffix("l"):
+		if w.inR2("l") && w.precededBy("l", "l") {
+			w.removeSuffix("l")
+		}
+	}
+}
+
+func mapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,366 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+// stemFrench implements the snowball French stemming algorithm.
+// See https://snowballstem.org/algorithms/french/stemmer.html.
+func stemFrench(word string) string {
+	w := newStemWord(word)
+	// Put into upper case u or i preceded and followed by a vowel, y preceded
+	// or followed by a vowel, and u after q, so that they are treated as
+	// consonants.
+	for i, c := range w.r {
+		prevVowel := i > 0 && isFrenchVowel(w.r[i-1])
+		nextVowel := i < len(w.r)-1 && isFrenchVowel(w.r[i+1])
+		switch {
+		case c == 'u' && prevVowel && nextVowel:
+			w.r[i] = 'U'
+		case c == 'i' && prevVowel && nextVowel:
+			w.r[i] = 'I'
+		case c == 'y' && (prevVowel || nextVowel):
+			w.r[i] = 'Y'
+		case c == 'u' && i > 0 && w.r[i-1] == 'q':
+			w.r[i] = 'U'
+		}
+	}
+	w.markR1R2(isFrenchVowel)
+	w.markFrenchRV()
+
+	if frenchStep1(w) || frenchStep2a(w) || frenchStep2b(w) {
+		if n := len(w.r); n > 0 {
+			switch w.r[n-1] {
+			case 'Y':
+				w.r[n-1] = 'i'
+			case 'ç':
+				w.r[n-1] = 'c'
+			}
+		}
+	} else {
+		frenchStep4(w)
+	}
+	frenchStep5(w)
+	frenchStep6(w)
+
+	for i, c := range w.r {
+		switch c {
+		case 'I':
+			w.r[i] = 'i'
+		case 'U':
+			w.r[i] = 'u'
+		case 'Y':
+			w.r[i] = 'y'
+		}
+	}
+	return w.String()
+}
+
+func isFrenchVowel(r rune) bool {
+	return runeIn(r, "aeiouyâàëéêèïîôûù")
+}
+
+// markFrenchRV sets the RV region of the word. If the word begins with two
+// vowels, RV is the region after the third letter; if it begins with par, col
+// or tap, RV is the region after those letters; and otherwise RV is the region
+// after the first vowel not at the beginning of the word.
+func (w *stemWord) markFrenchRV() {
+	w.rv = len(w.r)
+	switch {
+	case len(w.r) >= 3 && isFrenchVowel(w.r[0]) && isFrenchVowel(w.r[1]):
+		w.rv = 3
+	case hasRunePrefix(w.r, "par"), hasRunePrefix(w.r, "col"), hasRunePrefix(w.r, "tap"):
+		w.rv = 3
+	default:
+		for i := 1; i < len(w.r); i++ {
+			if isFrenchVowel(w.r[i]) {
+				w.rv = i + 1
+				break
+			}
+		}
+	}
+}
+
+var frenchStep1Suffixes = sortSuffixes(
+	"ance", "iqUe", "isme", "able", "iste", "eux", "ances", "iqUes", "ismes",
+	"ables", "istes",
+	"atrice", "ateur", "ation", "atrices", "ateurs", "ations",
+	"logie", "logies",
+	"usion", "ution", "usions", "utions",
+	"ence", "ences",
+	"ement", "ements",
+	"ité", "ités",
+	"if", "ive", "ifs", "ives",
+	"eaux",
+	"aux",
+	"euse", "euses",
+	"issement", "issements",
+	"amment",
+	"emment",
+	"ment", "ments",
+)
+
+// frenchStep1 removes standard suffixes, and returns whether it succeeded.
+// Note that the amment, emment, ment and ments endings never count as
+// success, even though they may alter the word.
+func frenchStep1(w *stemWord) bool {
+	s := w.longestSuffix(frenchStep1Suffixes)
+	switch s {
+	case "":
+		return false
+	case "ance", "iqUe", "isme", "able", "iste", "eux", "ances", "iqUes",
+		"ismes", "ables", "istes":
+		if !w.inR2(s) {
+			return false
+		}
+		w.removeSuffix(s)
+	case "atrice", "ateur", "ation", "atrices", "ateurs", "ations":
+		if !w.inR2(s) {
+			return false
+		}
+		w.removeSuffix(s)
+		if w.hasSuffix("ic") {
+			if w.inR2("ic") {
+				w.removeSuffix("ic")
+			} else {
+				w.replaceSuffix("ic", "iqU")
+			}
+		}
+	case "logie", "logies":
+		if !w.inR2(s) {
+			return false
+		}
+		w.replaceSuffix(s, "log")
+	case "usion", "ution", "usions", "utions":
+		if !w.inR2(s) {
+			return false
+		}
+		w.replaceSuffix(s, "u")
+	case "ence", "ences":
+		if !w.inR2(s) {
+			return false
+		}
+		w.replaceSuffix(s, "ent")
+	case "ement", "ements":
+		if !w.inRV(s) {
+			return false
+		}
+		w.removeSuffix(s)
+		switch p := w.longestSuffix(frenchEmentSuffixes); p {
+		case "iv":
+			if w.inR2(p) {
+				w.removeSuffix(p)
+				if w.hasSuffix("at") && w.inR2("at") {
+					w.removeSuffix("at")
+				}
+			}
+		case "eus":
+			if w.inR2(p) {
+				w.removeSuffix(p)
+			} else if w.inR1(p) {
+				w.replaceSuffix(p, "eux")
+			}
+		case "abl", "iqU":
+			if w.inR2(p) {
+				w.removeSuffix(p)
+			}
+		case "ièr", "Ièr":
+			if w.inRV(p) {
+				w.replaceSuffix(p, "i")
+			}
+		}
+	case "ité", "ités":
+		if !w.inR2(s) {
+			return false
+		}
+		w.removeSuffix(s)
+		switch p := w.longestSuffix(frenchIteSuffixes); p {
+		case "abil":
+			if w.inR2(p) {
+				w.removeSuffix(p)
+			} else {
+				w.replaceSuffix(p, "abl")
+			}
+		case "ic":
+			if w.inR2(p) {
+				w.removeSuffix(p)
+			} else {
+				w.replaceSuffix(p, "iqU")
+			}
+		case "iv":
+			if w.inR2(p) {
+				w.removeSuffix(p)
+			}
+		}
+	case "if", "ive", "ifs", "ives":
+		if !w.inR2(s) {
+			return false
+		}
+		w.removeSuffix(s)
+		if w.hasSuffix("at") && w.inR2("at") {
+			w.removeSuffix("at")
+			if w.hasSuffix("ic") {
+				if w.inR2("ic") {
+					w.removeSuffix("ic")
+				} else {
+					w.replaceSuffix("ic", "iqU")
+				}
+			}
+		}
+	case "eaux":
+		w.replaceSuffix(s, "eau")
+	case "aux":
+		if !w.inR1(s) {
+			return false
+		}
+		w.replaceSuffix(s, "al")
+	case "euse", "euses":
+		if w.inR2(s) {
+			w.removeSuffix(s)
+		} else if w.inR1(s) {
+			w.replaceSuffix(s, "eux")
+		} else {
+			return false
+		}
+	case "issement", "issements":
+		c, i := w.precedingRune(s)
+		if !w.inR1(s) || i < 0 || isFrenchVowel(c) {
+			return false
+		}
+		w.removeSuffix(s)
+	case "amment":
+		if w.inRV(s) {
+			w.replaceSuffix(s, "ant")
+		}
+		return false
+	case "emment":
+		if w.inRV(s) {
+			w.replaceSuffix(s, "ent")
+		}
+		return false
+	case "ment", "ments":
+		if c, i := w.precedingRune(s); i >= w.rv && isFrenchVowel(c) {
+			w.removeSuffix(s)
+		}
+		return false
+	}
+	return true
+}
+
+var frenchEmentSuffixes = sortSuffixes("iv", "eus", "abl", "iqU", "ièr", "Ièr")
+
+var frenchIteSuffixes = sortSuffixes("abil", "ic", "iv")
+
+var frenchStep2aSuffixes = sortSuffixes(
+	"îmes", "ît", "îtes", "i", "ie", "ies", "ir", "ira", "irai", "iraIent",
+	"irais", "irait", "iras", "irent", "irez", "iriez", "irions", "irons",
+	"iront", "is", "issaIent", "issais", "issait", "issant", "issante",
+	"issantes", "issants", "isse", "issent", "isses", "issez", "issiez",
+	"issions", "issons", "it",
+)
+
+// frenchStep2a removes verb suffixes beginning with i, and returns whether it
+// removed anything.
+func frenchStep2a(w *stemWord) bool {
+	s := w.longestSuffixInRV(frenchStep2aSuffixes)
+	if s == "" {
+		return false
+	}
+	// The suffix must be preceded by a non-vowel that is also in RV.
+	if c, i := w.precedingRune(s); i < w.rv || isFrenchVowel(c) {
+		return false
+	}
+	w.removeSuffix(s)
+	return true
+}
+
+var frenchStep2bSuffixes = sortSuffixes(
+	"ions",
+	"é", "ée", "ées", "és", "èrent", "er", "era", "erai", "eraIent", "erais",
+	"erait", "eras", "erez", "eriez", "erions", "erons", "eront", "ez", "iez",
+	"âmes", "ât", "âtes", "a", "ai", "aIent", "ais", "ait", "ant", "ante",
+	"antes", "ants", "as", "asse", "assent", "asses", "assiez", "assions",
+)
+
+// frenchStep2b removes other verb suffixes, and returns whether it removed
+// anything.
+func frenchStep2b(w *stemWord) bool {
+	s := w.longestSuffixInRV(frenchStep2bSuffixes)
+	switch s {
+	case "":
+		return false
+	case "ions":
+		if !w.inR2(s) {
+			return false
+		}
+		w.removeSuffix(s)
+	case "âmes", "ât", "âtes", "a", "ai", "aIent", "ais", "ait", "ant", "ante",
+		"antes", "ants", "as", "asse", "assent", "asses", "assiez", "assions":
+		w.removeSuffix(s)
+		if w.hasSuffix("e") && w.inRV("e") {
+			w.removeSuffix("e")
+		}
+	default:
+		w.removeSuffix(s)
+	}
+	return true
+}
+
+var frenchStep4Suffixes = sortSuffixes("ion", "ier", "ière", "Ier", "Ière", "e", "ë")
+
+// frenchStep4 removes residual suffixes.
+func frenchStep4(w *stemWord) {
+	if w.hasSuffix("s") {
+		if c, i := w.precedingRune("s"); i >= 0 && !runeIn(c, "aiouès") {
+			w.removeSuffix("s")
+		}
+	}
+	s := w.longestSuffixInRV(frenchStep4Suffixes)
+	switch s {
+	case "ion":
+		if w.inR2(s) {
+			if c, i := w.precedingRune(s); i >= w.rv && (c == 's' || c == 't') {
+				w.removeSuffix(s)
+			}
+		}
+	case "ier", "ière", "Ier", "Ière":
+		w.replaceSuffix(s, "i")
+	case "e":
+		w.removeSuffix(s)
+	case "ë":
+		if w.precededBy(s, "gu") {
+			w.removeSuffix(s)
+		}
+	}
+}
+
+// frenchStep5 undoubles the final consonant of some endings.
+func frenchStep5(w *stemWord) {
+	for _, s := range []string{"enn", "onn", "ett", "ell", "eill"} {
+		if w.hasSuffix(s) {
+			w.r = w.r[:len(w.r)-1]
+			return
+		}
+	}
+}
+
+// frenchStep6 removes the accent from a final é or è that is followed by at
+// least one non-vowel.
+func frenchStep6(w *stemWord) {
+	i := len(w.r) - 1
+	for i >= 0 && !isFrenchVowel(w.r[i]) {
+		i--
+	}
+	if i < 0 || i == len(w.r)-1 {
+		return
+	}
+	if w.r[i] == 'é' || w.r[i] == 'è' {
+		w.r[i] = 'e'
+	}
+}
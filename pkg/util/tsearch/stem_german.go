@@ -0,0 +1,132 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import "strings"
+
+// stemGerman implements the snowball German stemming algorithm.
+// See https://snowballstem.org/algorithms/german/stemmer.html.
+func stemGerman(word string) string {
+	w := newStemWord(strings.ReplaceAll(word, "ß", "ss"))
+	for i := 1; i < len(w.r)-1; i++ {
+		if !isGermanVowel(w.r[i-1]) || !isGermanVowel(w.r[i+1]) {
+			continue
+		}
+		switch w.r[i] {
+		case 'u':
+			w.r[i] = 'U'
+		case 'y':
+			w.r[i] = 'Y'
+		}
+	}
+	w.markR1R2(isGermanVowel)
+	// The region before R1 must contain at least 3 letters.
+	if w.r1 < 3 {
+		w.r1 = 3
+	}
+
+	germanStep1(w)
+	germanStep2(w)
+	germanStep3(w)
+
+	for i, c := range w.r {
+		switch c {
+		case 'U', 'ü':
+			w.r[i] = 'u'
+		case 'Y':
+			w.r[i] = 'y'
+		case 'ä':
+			w.r[i] = 'a'
+		case 'ö':
+			w.r[i] = 'o'
+		}
+	}
+	return w.String()
+}
+
+func isGermanVowel(r rune) bool {
+	return runeIn(r, "aeiouyäöü")
+}
+
+var germanStep1Suffixes = sortSuffixes("em", "ern", "er", "e", "en", "es", "s")
+
+func germanStep1(w *stemWord) {
+	s := w.longestSuffix(germanStep1Suffixes)
+	if s == "" || !w.inR1(s) {
+		return
+	}
+	switch s {
+	case "em", "ern", "er":
+		w.removeSuffix(s)
+	case "e", "en", "es":
+		w.removeSuffix(s)
+		if w.hasSuffix("niss") {
+			w.removeSuffix("s")
+		}
+	case "s":
+		if c, i := w.precedingRune(s); i >= 0 && runeIn(c, "bdfghklmnrt") {
+			w.removeSuffix(s)
+		}
+	}
+}
+
+var germanStep2Suffixes = sortSuffixes("en", "er", "est", "st")
+
+func germanStep2(w *stemWord) {
+	s := w.longestSuffix(germanStep2Suffixes)
+	if s == "" || !w.inR1(s) {
+		return
+	}
+	if s == "st" {
+		// The st must be preceded by a valid st-ending, itself preceded by at
+		// least 3 letters.
+		if c, i := w.precedingRune(s); i < 3 || !runeIn(c, "bdfghklmnt") {
+			return
+		}
+	}
+	w.removeSuffix(s)
+}
+
+var germanStep3Suffixes = sortSuffixes("end", "ung", "ig", "ik", "isch", "lich", "heit", "keit")
+
+func germanStep3(w *stemWord) {
+	s := w.longestSuffix(germanStep3Suffixes)
+	if s == "" || !w.inR2(s) {
+		return
+	}
+	switch s {
+	case "end", "ung":
+		w.removeSuffix(s)
+		if w.hasSuffix("ig") && !w.precededBy("ig", "e") && w.inR2("ig") {
+			w.removeSuffix("ig")
+		}
+	case "ig", "ik", "isch":
+		if !w.precededBy(s, "e") {
+			w.removeSuffix(s)
+		}
+	case "lich", "heit":
+		w.removeSuffix(s)
+		for _, p := range []string{"er", "en"} {
+			if w.hasSuffix(p) && w.inR1(p) {
+				w.removeSuffix(p)
+				break
+			}
+		}
+	case "keit":
+		w.removeSuffix(s)
+		for _, p := range []string{"lich", "ig"} {
+			if w.hasSuffix(p) && w.inR2(p) {
+				w.removeSuffix(p)
+				break
+			}
+		}
+	}
+}
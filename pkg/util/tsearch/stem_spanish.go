@@ -0,0 +1,264 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+// stemSpanish implements the snowball Spanish stemming algorithm.
+// See https://snowballstem.org/algorithms/spanish/stemmer.html.
+func stemSpanish(word string) string {
+	w := newStemWord(word)
+	w.markR1R2(isSpanishVowel)
+	w.markSpanishRV()
+
+	spanishStep0(w)
+	if !spanishStep1(w) && !spanishStep2a(w) {
+		spanishStep2b(w)
+	}
+	spanishStep3(w)
+
+	for i, c := range w.r {
+		switch c {
+		case 'á':
+			w.r[i] = 'a'
+		case 'é':
+			w.r[i] = 'e'
+		case 'í':
+			w.r[i] = 'i'
+		case 'ó':
+			w.r[i] = 'o'
+		case 'ú':
+			w.r[i] = 'u'
+		}
+	}
+	return w.String()
+}
+
+func isSpanishVowel(r rune) bool {
+	return runeIn(r, "aeiouáéíóúü")
+}
+
+// markSpanishRV sets the RV region of the word. If the second letter is a
+// consonant, RV is the region after the next following vowel; if the first
+// two letters are vowels, RV is the region after the next consonant; and
+// otherwise RV is the region after the third letter.
+func (w *stemWord) markSpanishRV() {
+	w.rv = len(w.r)
+	if len(w.r) < 2 {
+		return
+	}
+	gopast := func(want bool) {
+		for i := 2; i < len(w.r); i++ {
+			if isSpanishVowel(w.r[i]) == want {
+				w.rv = i + 1
+				return
+			}
+		}
+	}
+	switch {
+	case !isSpanishVowel(w.r[1]):
+		gopast(true /* want */)
+	case isSpanishVowel(w.r[0]):
+		gopast(false /* want */)
+	default:
+		w.rv = 3
+	}
+}
+
+var spanishPronounSuffixes = sortSuffixes(
+	"me", "se", "sela", "selo", "selas", "selos", "la", "le", "lo", "las",
+	"les", "los", "nos",
+)
+
+var spanishPronounVerbForms = map[string]string{
+	"iéndo": "iendo",
+	"ándo":  "ando",
+	"ár":    "ar",
+	"ér":    "er",
+	"ír":    "ir",
+	"ando":  "ando",
+	"iendo": "iendo",
+	"ar":    "ar",
+	"er":    "er",
+	"ir":    "ir",
+	"yendo": "yendo",
+}
+
+var spanishPronounVerbSuffixes = sortSuffixes(mapKeys(spanishPronounVerbForms)...)
+
+// spanishStep0 removes attached pronouns.
+func spanishStep0(w *stemWord) {
+	p := w.longestSuffix(spanishPronounSuffixes)
+	if p == "" {
+		return
+	}
+	rest := &stemWord{r: w.r[:w.suffixStart(p)], rv: w.rv}
+	v := rest.longestSuffix(spanishPronounVerbSuffixes)
+	if v == "" || !rest.inRV(v) {
+		return
+	}
+	if v == "yendo" && !rest.precededBy(v, "u") {
+		return
+	}
+	rest.replaceSuffix(v, spanishPronounVerbForms[v])
+	w.r = rest.r
+}
+
+var spanishStep1Suffixes = sortSuffixes(
+	"anza", "anzas", "ico", "ica", "icos", "icas", "ismo", "ismos", "able",
+	"ables", "ible", "ibles", "ista", "istas", "oso", "osa", "osos", "osas",
+	"amiento", "amientos", "imiento", "imientos",
+	"adora", "ador", "ación", "adoras", "adores", "aciones", "ante", "antes",
+	"ancia", "ancias",
+	"logía", "logías",
+	"ución", "uciones",
+	"encia", "encias",
+	"amente",
+	"mente",
+	"idad", "idades",
+	"iva", "ivo", "ivas", "ivos",
+)
+
+// spanishStep1 removes standard suffixes, and returns whether it removed
+// anything.
+func spanishStep1(w *stemWord) bool {
+	s := w.longestSuffix(spanishStep1Suffixes)
+	if s == "" {
+		return false
+	}
+	switch s {
+	case "adora", "ador", "ación", "adoras", "adores", "aciones", "ante",
+		"antes", "ancia", "ancias":
+		if !w.inR2(s) {
+			return false
+		}
+		w.removeSuffix(s)
+		if w.hasSuffix("ic") && w.inR2("ic") {
+			w.removeSuffix("ic")
+		}
+	case "logía", "logías":
+		if !w.inR2(s) {
+			return false
+		}
+		w.replaceSuffix(s, "log")
+	case "ución", "uciones":
+		if !w.inR2(s) {
+			return false
+		}
+		w.replaceSuffix(s, "u")
+	case "encia", "encias":
+		if !w.inR2(s) {
+			return false
+		}
+		w.replaceSuffix(s, "ente")
+	case "amente":
+		if !w.inR1(s) {
+			return false
+		}
+		w.removeSuffix(s)
+		if p := w.longestSuffix([]string{"iv", "os", "ic", "ad"}); p != "" && w.inR2(p) {
+			w.removeSuffix(p)
+			if p == "iv" && w.hasSuffix("at") && w.inR2("at") {
+				w.removeSuffix("at")
+			}
+		}
+	case "mente":
+		if !w.inR2(s) {
+			return false
+		}
+		w.removeSuffix(s)
+		if p := w.longestSuffix([]string{"ante", "able", "ible"}); p != "" && w.inR2(p) {
+			w.removeSuffix(p)
+		}
+	case "idad", "idades":
+		if !w.inR2(s) {
+			return false
+		}
+		w.removeSuffix(s)
+		if p := w.longestSuffix([]string{"abil", "ic", "iv"}); p != "" && w.inR2(p) {
+			w.removeSuffix(p)
+		}
+	case "iva", "ivo", "ivas", "ivos":
+		if !w.inR2(s) {
+			return false
+		}
+		w.removeSuffix(s)
+		if w.hasSuffix("at") && w.inR2("at") {
+			w.removeSuffix("at")
+		}
+	default:
+		if !w.inR2(s) {
+			return false
+		}
+		w.removeSuffix(s)
+	}
+	return true
+}
+
+var spanishStep2aSuffixes = sortSuffixes(
+	"ya", "ye", "yan", "yen", "yeron", "yendo", "yo", "yó", "yas", "yes",
+	"yais", "yamos",
+)
+
+// spanishStep2a removes verb suffixes beginning with y, and returns whether it
+// removed anything.
+func spanishStep2a(w *stemWord) bool {
+	s := w.longestSuffixInRV(spanishStep2aSuffixes)
+	if s == "" || !w.precededBy(s, "u") {
+		return false
+	}
+	w.removeSuffix(s)
+	return true
+}
+
+var spanishStep2bSuffixes = sortSuffixes(
+	"en", "es", "éis", "emos",
+	"arían", "arías", "arán", "arás", "aríais", "aría", "aréis", "aríamos",
+	"aremos", "ará", "aré", "erían", "erías", "erán", "erás", "eríais",
+	"ería", "eréis", "eríamos", "eremos", "erá", "eré", "irían", "irías",
+	"irán", "irás", "iríais", "iría", "iréis", "iríamos", "iremos", "irá",
+	"iré", "aba", "ada", "ida", "ía", "ara", "iera", "ad", "ed", "id", "ase",
+	"iese", "aste", "iste", "an", "aban", "ían", "aran", "ieran", "asen",
+	"iesen", "aron", "ieron", "ado", "ido", "ando", "iendo", "ió", "ar", "er",
+	"ir", "as", "abas", "adas", "idas", "ías", "aras", "ieras", "ases",
+	"ieses", "ís", "áis", "abais", "íais", "arais", "ierais", "aseis",
+	"ieseis", "asteis", "isteis", "ados", "idos", "amos", "ábamos", "íamos",
+	"imos", "áramos", "iéramos", "iésemos", "ásemos",
+)
+
+// spanishStep2b removes other verb suffixes.
+func spanishStep2b(w *stemWord) {
+	s := w.longestSuffixInRV(spanishStep2bSuffixes)
+	if s == "" {
+		return
+	}
+	switch s {
+	case "en", "es", "éis", "emos":
+		w.removeSuffix(s)
+		if w.hasSuffix("gu") {
+			w.removeSuffix("u")
+		}
+	default:
+		w.removeSuffix(s)
+	}
+}
+
+var spanishStep3Suffixes = sortSuffixes("os", "a", "o", "á", "í", "ó", "e", "é")
+
+// spanishStep3 removes residual suffixes.
+func spanishStep3(w *stemWord) {
+	s := w.longestSuffix(spanishStep3Suffixes)
+	if s == "" || !w.inRV(s) {
+		return
+	}
+	w.removeSuffix(s)
+	if (s == "e" || s == "é") && w.hasSuffix("gu") && w.inRV("u") {
+		w.removeSuffix("u")
+	}
+}
@@ -255,7 +255,12 @@ func ParseTSVector(input string) (TSVector, error) {
 	if err != nil {
 		return ret, err
 	}
+	return normalizeTSVector(ret), nil
+}
 
+// normalizeTSVector sorts the input TSVector by lexeme, merging the position
+// lists of duplicate lexemes.
+func normalizeTSVector(ret TSVector) TSVector {
 	if len(ret) > 1 {
 		// Sort and de-duplicate the resultant TSVector.
 		sort.Slice(ret, func(i, j int) bool {
@@ -287,5 +292,5 @@ func ParseTSVector(input string) (TSVector, error) {
 		lastIdx := len(ret) - 1
 		ret[lastIdx].positions = sortAndUniqTSPositions(ret[lastIdx].positions)
 	}
-	return ret, nil
+	return ret
 }
@@ -0,0 +1,409 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package vector
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// DefaultLists is the default number of lists that an IVF vector index
+// partitions its vectors into.
+const DefaultLists = 100
+
+// MaxLists is the maximum number of lists of an IVF vector index.
+const MaxLists = 32768
+
+// DefaultProbes is the default number of lists that are searched by a nearest
+// neighbor query that uses an IVF vector index.
+const DefaultProbes = 10
+
+// MaxCentroidValues is the maximum number of values in the centroids of an
+// IVF, i.e. the number of lists times the number of dimensions. The centroids
+// are stored in the index descriptor, which must remain small.
+const MaxCentroidValues = 1 << 20
+
+// maxKMeansIterations is the maximum number of iterations of k-means when
+// training the centroids of an IVF. It is the same as in pgvector.
+const maxKMeansIterations = 500
+
+// Metric is the distance metric by which the vectors of an IVF index are
+// ordered.
+type Metric int
+
+const (
+	// L2Metric orders vectors by Euclidean distance.
+	L2Metric Metric = iota
+	// CosineMetric orders vectors by cosine distance.
+	CosineMetric
+	// InnerProductMetric orders vectors by negative inner product.
+	InnerProductMetric
+)
+
+// spherical returns whether the centroids of an IVF with the metric are unit
+// vectors. The direction of the vectors is what matters for the cosine
+// distance, and it is what pgvector clusters for the inner product too.
+func (m Metric) spherical() bool {
+	return m != L2Metric
+}
+
+// IVFConfig is the configuration of an IVF index, which partitions vectors
+// with a fixed number of dimensions into lists, so that a nearest neighbor
+// search only needs to scan the vectors in the few lists closest to the query
+// vector. This is the scheme used by pgvector's "IVFFlat" indexes.
+//
+// Each list has a centroid, and a vector belongs to the list whose centroid is
+// the closest to it. Like in pgvector, the centroids are trained with k-means
+// on a sample of the vectors when the index is built (see TrainIVF). The
+// centroids of an index that is built before there are any vectors to train on
+// are random unit vectors, derived deterministically from the number of
+// dimensions and lists.
+type IVFConfig struct {
+	Metric Metric
+	Lists  int
+	// Centroids are the trained centroids of the lists, stored one after the
+	// other. If empty, the lists have random centroids.
+	Centroids []float32
+}
+
+// List returns the list that the given vector belongs to.
+func (c IVFConfig) List(v T) (int, error) {
+	ic, err := c.centroids(len(v))
+	if err != nil {
+		return 0, err
+	}
+	best, bestScore := 0, math.Inf(-1)
+	for i := 0; i < ic.lists(); i++ {
+		if score := ic.score(v, i); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best, nil
+}
+
+// Probes returns the given number of lists whose centroids are the closest to
+// the given vector, which are the lists that should be searched for its
+// nearest neighbors. The lists are returned in ascending order.
+func (c IVFConfig) Probes(v T, probes int) ([]int, error) {
+	ic, err := c.centroids(len(v))
+	if err != nil {
+		return nil, err
+	}
+	n := ic.lists()
+	if probes > n {
+		probes = n
+	}
+	lists := make([]int, n)
+	scores := make([]float64, n)
+	for i := range lists {
+		lists[i] = i
+		scores[i] = ic.score(v, i)
+	}
+	sort.SliceStable(lists, func(i, j int) bool {
+		return scores[lists[i]] > scores[lists[j]]
+	})
+	lists = lists[:probes]
+	sort.Ints(lists)
+	return lists, nil
+}
+
+// centroids returns the centroids of the configuration for vectors with the
+// given number of dimensions.
+func (c IVFConfig) centroids(dims int) (ivfCentroids, error) {
+	if len(c.Centroids) == 0 {
+		return randomCentroids(dims, c.Lists), nil
+	}
+	ic := ivfCentroids{
+		dims:      len(c.Centroids) / c.Lists,
+		centroids: c.Centroids,
+		unit:      c.Metric.spherical(),
+	}
+	if dims != ic.dims {
+		return ic, pgerror.Newf(pgcode.DataException,
+			"expected %d dimensions, not %d", ic.dims, dims)
+	}
+	return ic, nil
+}
+
+// ivfCentroids holds the centroids of the lists of an IVF.
+type ivfCentroids struct {
+	dims int
+	// centroids are the centroids of the lists, stored one after the other.
+	centroids []float32
+	// unit is set if the centroids are unit vectors, in which case the closest
+	// centroid to a vector by every metric is the one with the largest inner
+	// product with it.
+	unit bool
+}
+
+func (ic ivfCentroids) lists() int {
+	return len(ic.centroids) / ic.dims
+}
+
+func (ic ivfCentroids) centroid(i int) T {
+	return ic.centroids[i*ic.dims : (i+1)*ic.dims]
+}
+
+// score returns how close a vector is to the centroid of the given list, with
+// the larger scores being the closer. It is the inner product of the vector
+// and the centroid if the centroids are unit vectors, and their negative
+// squared Euclidean distance otherwise.
+//
+// The list of a vector is persisted in the index, so it must be computed the
+// same way on every platform. The explicit conversions of the products prevent
+// the compiler from fusing them with the additions into FMA instructions,
+// which round differently and are only used on some architectures.
+func (ic ivfCentroids) score(v T, list int) float64 {
+	c := ic.centroid(list)
+	var score float64
+	if ic.unit {
+		for i := range v {
+			score += float64(float64(v[i]) * float64(c[i]))
+		}
+		return score
+	}
+	for i := range v {
+		diff := float64(v[i]) - float64(c[i])
+		score -= float64(diff * diff)
+	}
+	return score
+}
+
+type ivfKey struct {
+	dims, lists int
+}
+
+// randomCentroidsCache caches the random centroids of each number of
+// dimensions and lists, since they are needed for every vector that is
+// encoded into or searched in an index without trained centroids.
+var randomCentroidsCache sync.Map // map[ivfKey][]float32
+
+// randomCentroids returns the random centroids of an IVF for vectors with the
+// given number of dimensions that are partitioned into the given number of
+// lists.
+//
+// The centroids must never change for a given number of dimensions and lists,
+// since they determine the layout of persisted indexes.
+func randomCentroids(dims, lists int) ivfCentroids {
+	key := ivfKey{dims: dims, lists: lists}
+	if centroids, ok := randomCentroidsCache.Load(key); ok {
+		return ivfCentroids{dims: dims, centroids: centroids.([]float32), unit: true}
+	}
+	// The sequence generated by a seeded math/rand source is guaranteed to be
+	// stable across releases.
+	rng := rand.New(rand.NewSource(int64(dims)<<32 | int64(lists)))
+	centroids := make([]float32, dims*lists)
+	for i := 0; i < lists; i++ {
+		c := centroids[i*dims : (i+1)*dims]
+		var norm float64
+		for j := range c {
+			f := rng.NormFloat64()
+			c[j] = float32(f)
+			// See score for the explicit conversion.
+			norm += float64(f * f)
+		}
+		norm = math.Sqrt(norm)
+		for j := range c {
+			c[j] = float32(float64(c[j]) / norm)
+		}
+	}
+	actual, _ := randomCentroidsCache.LoadOrStore(key, centroids)
+	return ivfCentroids{dims: dims, centroids: actual.([]float32), unit: true}
+}
+
+// SampleSize returns the number of vectors that should be sampled to train the
+// centroids of an IVF with the given number of lists. Like in pgvector, it
+// targets 50 vectors per list, and at least 10000 vectors.
+func SampleSize(lists int) int {
+	if n := lists * 50; n > 10000 {
+		return n
+	}
+	return 10000
+}
+
+// TrainIVF returns the centroids of an IVF with the given number of lists for
+// vectors with the given number of dimensions, stored one after the other as
+// in IVFConfig. The centroids are computed with k-means over a sample of the
+// vectors to index, initialized with k-means++, which is how pgvector builds
+// IVFFlat indexes. For the cosine and inner product metrics, the vectors are
+// clustered by direction and the centroids are unit vectors.
+//
+// If there are no more samples than lists, every sample is the centroid of a
+// list and the remaining lists get random centroids.
+//
+// Each iteration of k-means takes O(samples * lists * dims) time, so the
+// context is checked between iterations.
+func TrainIVF(
+	ctx context.Context, metric Metric, dims, lists int, samples []T, rng *rand.Rand,
+) ([]float32, error) {
+	if dims*lists > MaxCentroidValues {
+		return nil, pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"an index on vectors with %d dimensions can have at most %d lists",
+			dims, MaxCentroidValues/dims)
+	}
+	points := make([]T, len(samples))
+	for i, s := range samples {
+		if len(s) != dims {
+			return nil, pgerror.Newf(pgcode.DataException,
+				"expected %d dimensions, not %d", dims, len(s))
+		}
+		points[i] = s
+		if metric.spherical() {
+			points[i] = normalized(s)
+		}
+	}
+	centroids := make([]T, lists)
+	if len(points) <= lists {
+		copy(centroids, points)
+		for i := len(points); i < lists; i++ {
+			centroids[i] = randomUnitVector(rng, dims)
+		}
+		return flatten(centroids), nil
+	}
+
+	// Initialize the centroids with k-means++: each one is a sample chosen with
+	// a probability proportional to its squared distance to the closest
+	// centroid chosen so far.
+	minDist := make([]float64, len(points))
+	for i := range minDist {
+		minDist[i] = math.Inf(1)
+	}
+	centroids[0] = points[rng.Intn(len(points))]
+	for k := 1; k < lists; k++ {
+		var sum float64
+		for i, p := range points {
+			if d := squaredDistance(p, centroids[k-1]); d < minDist[i] {
+				minDist[i] = d
+			}
+			sum += minDist[i]
+		}
+		chosen := rng.Intn(len(points))
+		if sum > 0 {
+			target := rng.Float64() * sum
+			for i, d := range minDist {
+				if target -= d; target <= 0 {
+					chosen = i
+					break
+				}
+			}
+		}
+		centroids[k] = points[chosen]
+	}
+
+	// Run Lloyd's algorithm until the lists of the samples are stable.
+	assignments := make([]int, len(points))
+	for i := range assignments {
+		assignments[i] = -1
+	}
+	sums := make([][]float64, lists)
+	for i := range sums {
+		sums[i] = make([]float64, dims)
+	}
+	counts := make([]int, lists)
+	for iter := 0; iter < maxKMeansIterations; iter++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		changed := false
+		for i, p := range points {
+			best, bestDist := 0, math.Inf(1)
+			for k, c := range centroids {
+				if d := squaredDistance(p, c); d < bestDist {
+					best, bestDist = k, d
+				}
+			}
+			if assignments[i] != best {
+				assignments[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		for k := range sums {
+			for j := range sums[k] {
+				sums[k][j] = 0
+			}
+			counts[k] = 0
+		}
+		for i, p := range points {
+			k := assignments[i]
+			counts[k]++
+			for j, f := range p {
+				sums[k][j] += float64(f)
+			}
+		}
+		for k := range centroids {
+			if counts[k] == 0 {
+				// Move the centroid of an empty list to a random sample, so that
+				// all the lists are used.
+				centroids[k] = points[rng.Intn(len(points))]
+				continue
+			}
+			c := make(T, dims)
+			for j := range c {
+				c[j] = float32(sums[k][j] / float64(counts[k]))
+			}
+			if metric.spherical() {
+				c = normalized(c)
+			}
+			centroids[k] = c
+		}
+	}
+	return flatten(centroids), nil
+}
+
+// squaredDistance returns the squared Euclidean distance between two vectors
+// with the same number of dimensions.
+func squaredDistance(v, other T) float64 {
+	var distance float64
+	for i := range v {
+		diff := float64(v[i]) - float64(other[i])
+		distance += diff * diff
+	}
+	return distance
+}
+
+// normalized returns the unit vector with the same direction as the given
+// vector, or the vector itself if its norm is zero.
+func normalized(v T) T {
+	norm := Norm(v)
+	if norm == 0 {
+		return v
+	}
+	ret := make(T, len(v))
+	for i, f := range v {
+		ret[i] = float32(float64(f) / norm)
+	}
+	return ret
+}
+
+func randomUnitVector(rng *rand.Rand, dims int) T {
+	v := make(T, dims)
+	for i := range v {
+		v[i] = float32(rng.NormFloat64())
+	}
+	return normalized(v)
+}
+
+func flatten(vectors []T) []float32 {
+	var ret []float32
+	for _, v := range vectors {
+		ret = append(ret, v...)
+	}
+	return ret
+}
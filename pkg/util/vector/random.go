@@ -0,0 +1,26 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package vector
+
+import "math/rand"
+
+// Random returns a random vector with the given number of dimensions for
+// testing. If dims is zero, a random number of dimensions is used.
+func Random(rng *rand.Rand, dims int) T {
+	if dims <= 0 {
+		dims = 1 + rng.Intn(10)
+	}
+	v := make(T, dims)
+	for i := range v {
+		v[i] = float32(rng.NormFloat64())
+	}
+	return v
+}
@@ -0,0 +1,340 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package vector implements the fixed-dimension float vectors used by the
+// VECTOR SQL type, along with the distance functions between them. The text
+// format, operators and error messages are compatible with the pgvector
+// Postgres extension.
+package vector
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// MaxDim is the maximum number of dimensions a vector can have.
+const MaxDim = 16000
+
+// T is a vector of float32 values.
+type T []float32
+
+// ParseVector parses the text representation of a vector, e.g. '[1,2,3]'.
+func ParseVector(input string) (T, error) {
+	s := strings.TrimSpace(input)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, pgerror.Newf(pgcode.InvalidTextRepresentation,
+			"could not parse %q as type vector", input)
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	if s == "" {
+		return nil, pgerror.New(pgcode.DataException, "vector must have at least 1 dimension")
+	}
+	parts := strings.Split(s, ",")
+	if len(parts) > MaxDim {
+		return nil, pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"vector cannot have more than %d dimensions", MaxDim)
+	}
+	ret := make(T, len(parts))
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+		if err != nil {
+			if errors.Is(err, strconv.ErrRange) {
+				return nil, pgerror.Newf(pgcode.NumericValueOutOfRange,
+					"%q is out of range for type vector", strings.TrimSpace(part))
+			}
+			return nil, pgerror.Newf(pgcode.InvalidTextRepresentation,
+				"could not parse %q as type vector", input)
+		}
+		ret[i] = float32(f)
+	}
+	if err := ret.validate(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// FromFloats constructs a vector from the given values, returning an error if
+// any of them cannot be represented in a vector.
+func FromFloats(vals []float64) (T, error) {
+	if len(vals) == 0 {
+		return nil, pgerror.New(pgcode.DataException, "vector must have at least 1 dimension")
+	}
+	if len(vals) > MaxDim {
+		return nil, pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"vector cannot have more than %d dimensions", MaxDim)
+	}
+	ret := make(T, len(vals))
+	for i, f := range vals {
+		ret[i] = float32(f)
+		if math.IsInf(float64(ret[i]), 0) && !math.IsInf(f, 0) {
+			return nil, pgerror.New(pgcode.NumericValueOutOfRange, "value out of range: overflow")
+		}
+	}
+	if err := ret.validate(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// validate checks that every element of the vector is a finite number.
+func (v T) validate() error {
+	for _, f := range v {
+		if math.IsNaN(float64(f)) {
+			return pgerror.New(pgcode.DataException, "NaN not allowed in vector")
+		}
+		if math.IsInf(float64(f), 0) {
+			return pgerror.New(pgcode.DataException, "infinite value not allowed in vector")
+		}
+	}
+	return nil
+}
+
+// Format writes the text representation of the vector to the given buffer.
+func (v T) Format(buf *bytes.Buffer) {
+	buf.WriteByte('[')
+	for i, f := range v {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(strconv.FormatFloat(float64(f), 'g', -1, 32))
+	}
+	buf.WriteByte(']')
+}
+
+// String implements the fmt.Stringer interface.
+func (v T) String() string {
+	var buf bytes.Buffer
+	v.Format(&buf)
+	return buf.String()
+}
+
+// Size returns the size of the vector in bytes.
+func (v T) Size() uintptr {
+	return uintptr(len(v)) * 4
+}
+
+// Compare compares two vectors element by element, with a shorter vector
+// sorting before a longer vector that it is a prefix of.
+func (v T) Compare(other T) int {
+	for i := 0; i < len(v) && i < len(other); i++ {
+		if v[i] < other[i] {
+			return -1
+		}
+		if v[i] > other[i] {
+			return 1
+		}
+	}
+	if len(v) < len(other) {
+		return -1
+	}
+	if len(v) > len(other) {
+		return 1
+	}
+	return 0
+}
+
+// CheckDims returns an error if the two vectors have different dimensions.
+func CheckDims(v, other T) error {
+	if len(v) != len(other) {
+		return pgerror.Newf(pgcode.DataException,
+			"different vector dimensions %d and %d", len(v), len(other))
+	}
+	return nil
+}
+
+// L1Distance returns the taxicab distance between two vectors.
+func L1Distance(v, other T) (float64, error) {
+	if err := CheckDims(v, other); err != nil {
+		return 0, err
+	}
+	var distance float32
+	for i := range v {
+		diff := v[i] - other[i]
+		distance += float32(math.Abs(float64(diff)))
+	}
+	return float64(distance), nil
+}
+
+// L2Distance returns the Euclidean distance between two vectors.
+func L2Distance(v, other T) (float64, error) {
+	if err := CheckDims(v, other); err != nil {
+		return 0, err
+	}
+	var distance float32
+	for i := range v {
+		diff := v[i] - other[i]
+		distance += diff * diff
+	}
+	return math.Sqrt(float64(distance)), nil
+}
+
+// CosDistance returns the cosine distance between two vectors, which is one
+// minus their cosine similarity. The distance is NaN if either vector has a
+// norm of zero.
+func CosDistance(v, other T) (float64, error) {
+	if err := CheckDims(v, other); err != nil {
+		return 0, err
+	}
+	var similarity, normV, normOther float32
+	for i := range v {
+		similarity += v[i] * other[i]
+		normV += v[i] * v[i]
+		normOther += other[i] * other[i]
+	}
+	// Use sqrt(a * b) over sqrt(a) * sqrt(b), and keep the result within
+	// [-1, 1] to account for floating point error.
+	sim := float64(similarity) / math.Sqrt(float64(normV)*float64(normOther))
+	if math.IsNaN(sim) {
+		return sim, nil
+	}
+	if sim > 1 {
+		sim = 1
+	} else if sim < -1 {
+		sim = -1
+	}
+	return 1 - sim, nil
+}
+
+// InnerProduct returns the inner product of two vectors.
+func InnerProduct(v, other T) (float64, error) {
+	if err := CheckDims(v, other); err != nil {
+		return 0, err
+	}
+	var product float32
+	for i := range v {
+		product += v[i] * other[i]
+	}
+	return float64(product), nil
+}
+
+// NegInnerProduct returns the negative inner product of two vectors, which
+// sorts vectors with the largest inner product first.
+func NegInnerProduct(v, other T) (float64, error) {
+	p, err := InnerProduct(v, other)
+	return -p, err
+}
+
+// Norm returns the Euclidean norm of the vector.
+func Norm(v T) float64 {
+	var norm float64
+	for _, f := range v {
+		norm += float64(f) * float64(f)
+	}
+	return math.Sqrt(norm)
+}
+
+// Add returns the element-wise sum of two vectors.
+func Add(v, other T) (T, error) {
+	return elementwise(v, other, func(a, b float32) float32 { return a + b })
+}
+
+// Minus returns the element-wise difference of two vectors.
+func Minus(v, other T) (T, error) {
+	return elementwise(v, other, func(a, b float32) float32 { return a - b })
+}
+
+// Mult returns the element-wise product of two vectors.
+func Mult(v, other T) (T, error) {
+	return elementwise(v, other, func(a, b float32) float32 { return a * b })
+}
+
+func elementwise(v, other T, op func(a, b float32) float32) (T, error) {
+	if err := CheckDims(v, other); err != nil {
+		return nil, err
+	}
+	ret := make(T, len(v))
+	for i := range v {
+		ret[i] = op(v[i], other[i])
+		if math.IsInf(float64(ret[i]), 0) {
+			return nil, pgerror.New(pgcode.NumericValueOutOfRange, "value out of range: overflow")
+		}
+	}
+	return ret, nil
+}
+
+// Encode encodes the vector as a byte array, suitable for storing in a
+// value-encoded column.
+func Encode(appendTo []byte, v T) []byte {
+	appendTo = encoding.EncodeUint32Ascending(appendTo, uint32(len(v)))
+	for _, f := range v {
+		appendTo = encoding.EncodeUint32Ascending(appendTo, math.Float32bits(f))
+	}
+	return appendTo
+}
+
+// Decode decodes a vector that was encoded with Encode.
+func Decode(b []byte) (ret T, err error) {
+	var n uint32
+	b, n, err = encoding.DecodeUint32Ascending(b)
+	if err != nil {
+		return nil, err
+	}
+	if int(n) != len(b)/4 || len(b)%4 != 0 {
+		return nil, errors.AssertionFailedf("vector of %d dimensions encoded in %d bytes", n, len(b))
+	}
+	ret = make(T, n)
+	for i := range ret {
+		var bits uint32
+		b, bits, err = encoding.DecodeUint32Ascending(b)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = math.Float32frombits(bits)
+	}
+	return ret, nil
+}
+
+// EncodePGBinary encodes the vector in the Postgres binary format used by
+// pgvector: a 2-byte dimension count, 2 unused bytes, and then each element as
+// a 4-byte float.
+func EncodePGBinary(appendTo []byte, v T) []byte {
+	appendTo = encoding.EncodeUint16Ascending(appendTo, uint16(len(v)))
+	appendTo = encoding.EncodeUint16Ascending(appendTo, 0)
+	for _, f := range v {
+		appendTo = encoding.EncodeUint32Ascending(appendTo, math.Float32bits(f))
+	}
+	return appendTo
+}
+
+// DecodePGBinary decodes a vector in the Postgres binary format.
+func DecodePGBinary(b []byte) (T, error) {
+	if len(b) < 4 {
+		return nil, pgerror.New(pgcode.ProtocolViolation, "insufficient data left in message")
+	}
+	_, n, _ := encoding.DecodeUint16Ascending(b)
+	b = b[4:]
+	if n == 0 {
+		return nil, pgerror.New(pgcode.DataException, "vector must have at least 1 dimension")
+	}
+	if n > MaxDim {
+		return nil, pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"vector cannot have more than %d dimensions", MaxDim)
+	}
+	if len(b) != int(n)*4 {
+		return nil, pgerror.New(pgcode.ProtocolViolation, "insufficient data left in message")
+	}
+	ret := make(T, n)
+	for i := range ret {
+		var bits uint32
+		b, bits, _ = encoding.DecodeUint32Ascending(b)
+		ret[i] = math.Float32frombits(bits)
+	}
+	if err := ret.validate(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
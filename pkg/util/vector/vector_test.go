@@ -0,0 +1,254 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package vector
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVector(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		err      string
+	}{
+		{input: "[1,2,3]", expected: "[1,2,3]"},
+		{input: " [ 1.5 , -2, 3e2 ] ", expected: "[1.5,-2,300]"},
+		{input: "[0.1]", expected: "[0.1]"},
+		{input: "[1e-5,123456789]", expected: "[1e-05,1.2345679e+08]"},
+		{input: "[]", err: "vector must have at least 1 dimension"},
+		{input: "1,2,3", err: `could not parse "1,2,3" as type vector`},
+		{input: "[1,,3]", err: `could not parse "[1,,3]" as type vector`},
+		{input: "[1,a]", err: `could not parse "[1,a]" as type vector`},
+		{input: "[1,NaN]", err: "NaN not allowed in vector"},
+		{input: "[Infinity]", err: "infinite value not allowed in vector"},
+		{input: "[1e100]", err: `"1e100" is out of range for type vector`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			v, err := ParseVector(tc.input)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, v.String())
+		})
+	}
+}
+
+func TestDistances(t *testing.T) {
+	a := T{1, 2, 3}
+	b := T{4, 5, 6}
+
+	l1, err := L1Distance(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, 9.0, l1)
+
+	l2, err := L2Distance(a, b)
+	require.NoError(t, err)
+	assert.InDelta(t, math.Sqrt(27), l2, 1e-6)
+
+	ip, err := InnerProduct(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, 32.0, ip)
+
+	nip, err := NegInnerProduct(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, -32.0, nip)
+
+	cos, err := CosDistance(a, b)
+	require.NoError(t, err)
+	assert.InDelta(t, 1-32/math.Sqrt(14*77), cos, 1e-6)
+
+	cos, err = CosDistance(a, T{0, 0, 0})
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(cos))
+
+	assert.InDelta(t, math.Sqrt(14), Norm(a), 1e-9)
+
+	sum, err := Add(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, T{5, 7, 9}, sum)
+	diff, err := Minus(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, T{-3, -3, -3}, diff)
+	prod, err := Mult(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, T{4, 10, 18}, prod)
+
+	_, err = Mult(T{math.MaxFloat32}, T{2})
+	require.EqualError(t, err, "value out of range: overflow")
+	_, err = L2Distance(a, T{1, 2})
+	require.EqualError(t, err, "different vector dimensions 3 and 2")
+}
+
+func TestCompare(t *testing.T) {
+	assert.Equal(t, 0, T{1, 2}.Compare(T{1, 2}))
+	assert.Equal(t, -1, T{1, 2}.Compare(T{1, 3}))
+	assert.Equal(t, 1, T{2}.Compare(T{1, 3}))
+	assert.Equal(t, -1, T{1}.Compare(T{1, 3}))
+	assert.Equal(t, 1, T{1, 3}.Compare(T{1}))
+}
+
+func TestEncodeDecode(t *testing.T) {
+	rng, _ := randutil.NewTestRand()
+	for i := 0; i < 100; i++ {
+		v := Random(rng, 0)
+		decoded, err := Decode(Encode(nil, v))
+		require.NoError(t, err)
+		assert.Equal(t, v, decoded)
+
+		decoded, err = DecodePGBinary(EncodePGBinary(nil, v))
+		require.NoError(t, err)
+		assert.Equal(t, v, decoded)
+
+		parsed, err := ParseVector(v.String())
+		require.NoError(t, err)
+		assert.Equal(t, v, parsed)
+	}
+}
+
+func TestIVF(t *testing.T) {
+	rng, _ := randutil.NewTestRand()
+	const dims, lists = 8, 16
+	random := randomCentroids(dims, lists)
+	require.Equal(t, lists, random.lists())
+
+	// Every centroid belongs to its own list.
+	for _, c := range []IVFConfig{
+		{Lists: lists},
+		{Metric: L2Metric, Lists: 3, Centroids: flatten([]T{
+			{1, 0, 0, 0, 0, 0, 0, 0}, {0, 1, 0, 0, 0, 0, 0, 0}, {2, 0, 0, 0, 0, 0, 0, 0},
+		})},
+	} {
+		ic, err := c.centroids(dims)
+		require.NoError(t, err)
+		for i := 0; i < ic.lists(); i++ {
+			if c.Centroids == nil {
+				assert.InDelta(t, 1, Norm(ic.centroid(i)), 1e-6)
+			}
+			list, err := c.List(ic.centroid(i))
+			require.NoError(t, err)
+			assert.Equal(t, i, list)
+		}
+	}
+
+	c := IVFConfig{Lists: lists}
+	for i := 0; i < 100; i++ {
+		v := Random(rng, dims)
+		list, err := c.List(v)
+		require.NoError(t, err)
+
+		// The closest list is always probed, and the probed lists are sorted.
+		probes, err := c.Probes(v, 3)
+		require.NoError(t, err)
+		require.Len(t, probes, 3)
+		assert.Contains(t, probes, list)
+		assert.IsIncreasing(t, probes)
+
+		probes, err = c.Probes(v, lists+1)
+		require.NoError(t, err)
+		require.Len(t, probes, lists)
+	}
+
+	c.Centroids = random.centroids
+	_, err := c.List(T{1, 2, 3})
+	require.EqualError(t, err, "expected 8 dimensions, not 3")
+}
+
+func TestTrainIVF(t *testing.T) {
+	ctx := context.Background()
+	rng, _ := randutil.NewTestRand()
+
+	// The samples are clustered around the corners of a square, which are also
+	// in different directions from the origin.
+	corners := []T{{10, 10}, {-10, 10}, {-10, -10}, {10, -10}}
+	samples := make([]T, 400)
+	for i := range samples {
+		corner := corners[i%len(corners)]
+		samples[i] = T{corner[0] + float32(rng.NormFloat64()), corner[1] + float32(rng.NormFloat64())}
+	}
+	for _, metric := range []Metric{L2Metric, CosineMetric, InnerProductMetric} {
+		centroids, err := TrainIVF(ctx, metric, 2, len(corners), samples, rng)
+		require.NoError(t, err)
+		require.Len(t, centroids, 2*len(corners))
+		c := IVFConfig{Metric: metric, Lists: len(corners), Centroids: centroids}
+
+		// All the vectors of a cluster are in the same list, and each cluster
+		// has its own list.
+		seen := make(map[int]bool)
+		for i, corner := range corners {
+			list, err := c.List(corner)
+			require.NoError(t, err)
+			assert.False(t, seen[list])
+			seen[list] = true
+			for j := i; j < len(samples); j += len(corners) {
+				l, err := c.List(samples[j])
+				require.NoError(t, err)
+				assert.Equal(t, list, l, "metric: %d, sample: %s", metric, samples[j])
+			}
+		}
+		if metric != L2Metric {
+			ic, err := c.centroids(2)
+			require.NoError(t, err)
+			for i := 0; i < ic.lists(); i++ {
+				assert.InDelta(t, 1, Norm(ic.centroid(i)), 1e-6)
+			}
+		}
+	}
+
+	// With fewer samples than lists, the samples are centroids.
+	centroids, err := TrainIVF(ctx, L2Metric, 2, 3, []T{{1, 2}}, rng)
+	require.NoError(t, err)
+	require.Len(t, centroids, 6)
+	assert.Equal(t, []float32{1, 2}, centroids[:2])
+
+	_, err = TrainIVF(ctx, L2Metric, 3, 4, []T{{1, 2}}, rng)
+	require.EqualError(t, err, "expected 3 dimensions, not 2")
+	_, err = TrainIVF(ctx, L2Metric, 2000, 1000, nil, rng)
+	require.EqualError(t, err, "an index on vectors with 2000 dimensions can have at most 524 lists")
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = TrainIVF(canceled, L2Metric, 2, 2, samples, rng)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// TestIVFLists pins the lists of a few vectors, which are persisted in vector
+// indexes and must not change across releases or architectures.
+func TestIVFLists(t *testing.T) {
+	testCases := []struct {
+		lists int
+		v     T
+		list  int
+	}{
+		{lists: 100, v: T{1, 2, 3}, list: 87},
+		{lists: 100, v: T{-1, 0, 1}, list: 95},
+		{lists: 100, v: T{0.5, -0.25, 4}, list: 40},
+		{lists: 100, v: T{100, 200, -300}, list: 29},
+		{lists: 100, v: T{0, 0, 1}, list: 40},
+		{lists: 16, v: T{1, 2, 3, 4, 5, 6, 7, 8}, list: 14},
+		{lists: 16, v: T{-1, 1, -1, 1, -1, 1, -1, 1}, list: 8},
+		{lists: 16, v: T{0.1, 0, 0, 0, 0, 0, 0, -0.2}, list: 7},
+	}
+	for _, tc := range testCases {
+		list, err := IVFConfig{Lists: tc.lists}.List(tc.v)
+		require.NoError(t, err)
+		assert.Equal(t, tc.list, list, "lists: %d, vector: %s", tc.lists, tc.v)
+	}
+}
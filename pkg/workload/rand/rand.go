@@ -428,6 +428,12 @@ func DatumToGoSQL(d tree.Datum) (interface{}, error) {
 		return geo.SpatialObjectToEWKT(d.Geography.SpatialObject(), 2)
 	case *tree.DGeometry:
 		return geo.SpatialObjectToEWKT(d.Geometry.SpatialObject(), 2)
+	case *tree.DJsonpath:
+		return d.Path.String(), nil
+	case *tree.DPGVector:
+		return d.T.String(), nil
+	case *tree.DRange, *tree.DMultirange:
+		return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
 	case *tree.DTSQuery:
 		return d.String(), nil
 	case *tree.DTSVector: